@@ -0,0 +1,92 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cubefs/cubefs/blobstore/common/memcache"
+	"github.com/cubefs/cubefs/blobstore/common/proto"
+)
+
+const defaultLocationCacheExpireS = 30
+
+// locationCache keeps the locations of the sealed blobs read lately, so that reading
+// a blob again does not ask the shardnode for its location. A nil cache is disabled.
+type locationCache struct {
+	mc         *memcache.MemCache
+	expiration int64 // nanoseconds
+}
+
+type cachedLocation struct {
+	loc      proto.Location
+	expireAt int64
+}
+
+func newLocationCache(size, expireS int) (*locationCache, error) {
+	if size <= 0 {
+		return nil, nil
+	}
+	mc, err := memcache.NewMemCache(size)
+	if err != nil {
+		return nil, err
+	}
+	return &locationCache{mc: mc, expiration: int64(time.Duration(expireS) * time.Second)}, nil
+}
+
+func locationKey(cid proto.ClusterID, blobName []byte, shardKeys [][]byte) string {
+	var b strings.Builder
+	b.WriteString(strconv.FormatUint(uint64(cid), 10))
+	b.WriteByte('/')
+	b.Write(blobName)
+	for _, key := range shardKeys {
+		b.WriteByte('/')
+		b.Write(key)
+	}
+	return b.String()
+}
+
+func (c *locationCache) Get(key string) (proto.Location, bool) {
+	if c == nil {
+		return proto.Location{}, false
+	}
+	val := c.mc.Get(key)
+	if val == nil {
+		return proto.Location{}, false
+	}
+	cached := val.(*cachedLocation)
+	if time.Now().UnixNano() >= cached.expireAt {
+		c.mc.Remove(key)
+		return proto.Location{}, false
+	}
+	return cached.loc, true
+}
+
+// Set caches the location of a sealed blob, the location of a blob still written is not kept.
+func (c *locationCache) Set(key string, loc proto.Location) {
+	if c == nil || loc.Size_ == 0 {
+		return
+	}
+	c.mc.Set(key, &cachedLocation{loc: loc, expireAt: time.Now().UnixNano() + c.expiration})
+}
+
+func (c *locationCache) Remove(key string) {
+	if c == nil {
+		return
+	}
+	c.mc.Remove(key)
+}
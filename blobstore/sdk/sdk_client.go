@@ -85,16 +85,24 @@ type Config struct {
 	MaxRetry        int                `json:"max_retry"`
 	RetryDelayMs    uint32             `json:"retry_delay_ms"`
 	PartConcurrence int                `json:"part_concurrence"`
+	// RetryBackoff retries with a delay growing by RetryDelayMs on each attempt.
+	RetryBackoff bool `json:"retry_backoff"`
+	// MaxGetResume is the times a failed get resumes from the data already read, 0 disables it.
+	MaxGetResume int `json:"max_get_resume"`
+	// LocationCacheSize is the number of blob locations cached for GetBlob, 0 disables the cache.
+	LocationCacheSize    int `json:"location_cache_size"`
+	LocationCacheExpireS int `json:"location_cache_expire_s"`
 
 	LogConf cmd.LogConfig `json:"log"`
 	Logger  io.Writer     `json:"-"`
 }
 
 type sdkHandler struct {
-	conf    Config
-	handler stream.StreamHandler
-	limiter stream.Limiter
-	closer  closer.Closer
+	conf      Config
+	handler   stream.StreamHandler
+	limiter   stream.Limiter
+	locations *locationCache
+	closer    closer.Closer
 }
 
 func New(conf *Config) (acapi.Client, error) {
@@ -102,6 +110,12 @@ func New(conf *Config) (acapi.Client, error) {
 	// add region magic checksum to the secret keys
 	security.InitWithRegionMagic(conf.StreamConfig.ClusterConfig.RegionMagic)
 
+	locations, err := newLocationCache(conf.LocationCacheSize, conf.LocationCacheExpireS)
+	if err != nil {
+		log.Errorf("new location cache failed, err: %+v", err)
+		return nil, err
+	}
+
 	cl := closer.New()
 	h, err := stream.NewStreamHandler(&conf.StreamConfig, cl.Done())
 	if err != nil {
@@ -110,10 +124,11 @@ func New(conf *Config) (acapi.Client, error) {
 	}
 
 	return &sdkHandler{
-		conf:    *conf,
-		handler: h,
-		limiter: stream.NewLimiter(conf.Limit),
-		closer:  cl,
+		conf:      *conf,
+		handler:   h,
+		limiter:   stream.NewLimiter(conf.Limit),
+		locations: locations,
+		closer:    cl,
 	}, nil
 }
 
@@ -169,7 +184,7 @@ func (s *sdkHandler) Delete(ctx context.Context, args *acapi.DeleteArgs) (failed
 	}
 	defer s.limiter.Release(name)
 
-	if err = retry.Timed(s.conf.MaxRetry, s.conf.RetryDelayMs).On(func() error {
+	if err = s.retryer(s.conf.MaxRetry).On(func() error {
 		// access response 2xx even if there has failed locations
 		deleteResp, err1 := s.doDelete(ctx, &acapi.DeleteArgs{Locations: locations})
 		if err1 != nil && rpc.DetectStatusCode(err1) != http.StatusIMUsed {
@@ -215,7 +230,7 @@ func (s *sdkHandler) Put(ctx context.Context, args *acapi.PutArgs) (lc proto.Loc
 		}
 
 		i := 0
-		err = retry.Timed(s.conf.MaxRetry, s.conf.RetryDelayMs).On(func() error {
+		err = s.retryer(s.conf.MaxRetry).On(func() error {
 			if i >= 1 {
 				args.Body, err = args.GetBody()
 				if err != nil {
@@ -256,6 +271,7 @@ func (s *sdkHandler) DeleteBlob(ctx context.Context, args *acapi.DelBlobArgs) er
 	ctx = acapi.ClientWithReqidContext(ctx)
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("accept sdk DeleteBlob request, name=%s, keys=%s, args: %v", args.BlobName, args.ShardKeys, *args)
+	s.locations.Remove(locationKey(args.ClusterID, args.BlobName, args.ShardKeys))
 	return s.handler.DeleteBlob(ctx, args)
 }
 
@@ -268,13 +284,19 @@ func (s *sdkHandler) GetBlob(ctx context.Context, args *acapi.GetBlobArgs) (io.R
 	span := trace.SpanFromContextSafe(ctx)
 	span.Debugf("accept sdk GetBlob request, name=%s, keys=%s, clusterID:%d, mode:%d, offset:%d, size:%d",
 		args.BlobName, args.ShardKeys, args.ClusterID, args.Mode, args.Offset, args.ReadSize)
-	loc, err := s.handler.GetBlob(ctx, args)
-	if err != nil {
-		return nil, err
+	key := locationKey(args.ClusterID, args.BlobName, args.ShardKeys)
+	loc, ok := s.locations.Get(key)
+	if !ok {
+		got, err := s.handler.GetBlob(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		loc = *got
+		s.locations.Set(key, loc)
 	}
 
 	arg := &acapi.GetArgs{
-		Location: *loc,
+		Location: loc,
 		Offset:   args.Offset,
 		ReadSize: args.ReadSize,
 		Writer:   args.Writer,
@@ -317,6 +339,7 @@ func (s *sdkHandler) PutBlob(ctx context.Context, args *acapi.PutBlobArgs) (cid
 		args.BlobName, args.ShardKeys, args.NeedSeal, loc, hashes)
 
 	needDel = false // put ok, don't need to delete
+	s.locations.Remove(locationKey(loc.ClusterID, args.BlobName, args.ShardKeys))
 	if args.NeedSeal {
 		sealArgs := &acapi.SealBlobArgs{
 			BlobName:  args.BlobName,
@@ -461,7 +484,7 @@ func (s *sdkHandler) doGet(ctx context.Context, args *acapi.GetArgs) (io.ReadClo
 
 	r, w := io.Pipe()
 	writer := s.limiter.Writer(ctx, w)
-	transfer, err := s.handler.Get(ctx, writer, args.Location, args.ReadSize, args.Offset)
+	transfer, err := s.getTransfer(ctx, writer, args)
 	if err != nil {
 		span.Error("stream get prepare failed", errors.Detail(err))
 		w.Close()
@@ -487,7 +510,7 @@ func (s *sdkHandler) zeroCopyGet(ctx context.Context, args *acapi.GetArgs) error
 	span := trace.SpanFromContextSafe(ctx)
 
 	writer := s.limiter.Writer(ctx, args.Writer)
-	transfer, err := s.handler.Get(ctx, writer, args.Location, args.ReadSize, args.Offset)
+	transfer, err := s.getTransfer(ctx, writer, args)
 	if err != nil {
 		span.Error("stream get prepare failed", errors.Detail(err))
 		return err
@@ -503,6 +526,56 @@ func (s *sdkHandler) zeroCopyGet(ctx context.Context, args *acapi.GetArgs) error
 	return nil
 }
 
+// countWriter counts the bytes written and keeps the error of the writer.
+type countWriter struct {
+	io.Writer
+	n   uint64
+	err error
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += uint64(n)
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// getTransfer prepares the stream get, a failed transfer resumes from the data already
+// written up to MaxGetResume times. The errors of the writer are not resumed.
+func (s *sdkHandler) getTransfer(ctx context.Context, w io.Writer, args *acapi.GetArgs) (func() error, error) {
+	if s.conf.MaxGetResume <= 0 {
+		return s.handler.Get(ctx, w, args.Location, args.ReadSize, args.Offset)
+	}
+
+	cw := &countWriter{Writer: w}
+	transfer, err := s.handler.Get(ctx, cw, args.Location, args.ReadSize, args.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		span := trace.SpanFromContextSafe(ctx)
+		resumes := 0
+		return s.retryer(s.conf.MaxGetResume + 1).RuptOn(func() (bool, error) {
+			if resumes > 0 {
+				if cw.n >= args.ReadSize {
+					return true, nil
+				}
+				span.Warnf("resume stream get at %d of %d, resumes:%d", cw.n, args.ReadSize, resumes)
+				var err error
+				if transfer, err = s.handler.Get(ctx, cw, args.Location,
+					args.ReadSize-cw.n, args.Offset+cw.n); err != nil {
+					return false, err
+				}
+			}
+			resumes++
+			err := transfer()
+			return err != nil && cw.err != nil, err
+		})
+	}, nil
+}
+
 func (s *sdkHandler) doDelete(ctx context.Context, args *acapi.DeleteArgs) (resp acapi.DeleteResp, err error) {
 	span := trace.SpanFromContextSafe(ctx)
 
@@ -905,7 +978,7 @@ func (s *sdkHandler) putParts(ctx context.Context, args *acapi.PutArgs) (proto.L
 
 			var restPartsResp *acapi.AllocResp
 			// alloc the rest parts
-			err = retry.Timed(s.conf.MaxRetry, s.conf.RetryDelayMs).RuptOn(func() (bool, error) {
+			err = s.retryer(s.conf.MaxRetry).RuptOn(func() (bool, error) {
 				resp, err1 := s.alloc(ctx, &acapi.AllocArgs{
 					Size:            remainSize,
 					BlobSize:        loc.SliceSize,
@@ -1112,7 +1185,7 @@ func (s *sdkHandler) retryAllocSlice(ctx context.Context, args *acapi.PutBlobArg
 
 	// maybe all fail, or at least 1 failed. only alloc the fail part: slice{new bid:[failIdx:]} ; and only return the same area(fail part slice)
 	var alloc shardnode.AllocSliceRet
-	rerr := retry.Timed(s.conf.MaxRetry, s.conf.RetryDelayMs).RuptOn(func() (bool, error) {
+	rerr := s.retryer(s.conf.MaxRetry).RuptOn(func() (bool, error) {
 		alloc, err = s.handler.AllocSlice(ctx, &acapi.AllocSliceArgs{
 			ClusterID: loc.ClusterID,
 			BlobName:  args.BlobName,
@@ -1198,11 +1271,19 @@ func httpError(err error) error {
 	return rpc.NewError(errcode.ErrUnexpected.Status, errcode.ErrUnexpected.Error(), err)
 }
 
+func (s *sdkHandler) retryer(attempts int) retry.Retryer {
+	if s.conf.RetryBackoff {
+		return retry.ExponentialBackoff(attempts, s.conf.RetryDelayMs)
+	}
+	return retry.Timed(attempts, s.conf.RetryDelayMs)
+}
+
 func fixConfig(cfg *Config) {
 	defaulter.LessOrEqual(&cfg.MaxSizePutOnce, defaultMaxSizePutOnce)
 	defaulter.LessOrEqual(&cfg.MaxRetry, defaultMaxRetry)
 	defaulter.LessOrEqual(&cfg.RetryDelayMs, defaultRetryDelayMs)
 	defaulter.LessOrEqual(&cfg.PartConcurrence, defaultPartConcurrence)
+	defaulter.LessOrEqual(&cfg.LocationCacheExpireS, defaultLocationCacheExpireS)
 	log.SetOutputLevel(cfg.LogConf.Level)
 	if cfg.Logger != nil {
 		log.SetOutput(cfg.Logger)
@@ -1035,3 +1035,151 @@ func TestSdkBlob_Put(t *testing.T) {
 	require.Equal(t, proto.ClusterID(1), cid)
 	require.Nil(t, hashes)
 }
+
+func TestSdkHandler_GetResume(t *testing.T) {
+	ctx := context.Background()
+	hd := newSdkHandler(t)
+	hd.conf.MaxGetResume = 2
+	hd.conf.RetryBackoff = true
+
+	data := "test resume"
+	args := &acapi.GetArgs{
+		Location: proto.Location{ClusterID: 1, CodeMode: codemode.EC3P3, Size_: uint64(len(data))},
+		ReadSize: uint64(len(data)),
+	}
+	require.NoError(t, security.LocationCrcFill(&args.Location))
+	partialGet := func(written int) func(ctx context.Context, w io.Writer, location proto.Location, readSize, offset uint64) (func() error, error) {
+		return func(ctx context.Context, w io.Writer, location proto.Location, readSize, offset uint64) (func() error, error) {
+			return func() error {
+				end := offset + readSize
+				if written > 0 {
+					end = offset + uint64(written)
+				}
+				if _, err := w.Write([]byte(data[offset:end])); err != nil {
+					return err
+				}
+				if end < offset+readSize {
+					return errMock
+				}
+				return nil
+			}, nil
+		}
+	}
+
+	// resumed from the data written
+	buff := bytes.NewBuffer(nil)
+	args.Writer = buff
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, uint64(len(data)), uint64(0)).DoAndReturn(partialGet(4))
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, uint64(len(data)-4), uint64(4)).DoAndReturn(partialGet(3))
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, uint64(len(data)-7), uint64(7)).DoAndReturn(partialGet(0))
+	_, err := hd.Get(ctx, args)
+	require.NoError(t, err)
+	require.Equal(t, data, buff.String())
+
+	// resumed too many times
+	buff.Reset()
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, gAny, gAny).DoAndReturn(partialGet(1)).Times(3)
+	_, err = hd.Get(ctx, args)
+	require.ErrorIs(t, err, errMock)
+	require.Equal(t, data[:3], buff.String())
+
+	// resumed through the pipe
+	args.Writer = nil
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, uint64(len(data)), uint64(0)).DoAndReturn(partialGet(5))
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, uint64(len(data)-5), uint64(5)).DoAndReturn(partialGet(0))
+	rc, err := hd.Get(ctx, args)
+	require.NoError(t, err)
+	ret, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, data, string(ret))
+
+	// the reader is closed, not resumed
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, uint64(len(data)), uint64(0)).DoAndReturn(partialGet(0))
+	args.Writer = &limitedWriter{max: 2}
+	_, err = hd.Get(ctx, args)
+	require.ErrorIs(t, err, io.ErrShortWrite)
+}
+
+type limitedWriter struct{ max int }
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		return w.max, io.ErrShortWrite
+	}
+	return len(p), nil
+}
+
+func TestSdkBlob_GetLocationCache(t *testing.T) {
+	ctx := context.Background()
+	hd := newSdkHandler(t)
+	locations, err := newLocationCache(16, 60)
+	require.NoError(t, err)
+	hd.locations = locations
+
+	data := "test cache"
+	loc := proto.Location{
+		ClusterID: 1,
+		CodeMode:  codemode.EC3P3,
+		Size_:     uint64(len(data)),
+		SliceSize: uint32(len(data)),
+		Slices:    []proto.Slice{{MinSliceID: 1, Vid: 1, Count: 1, ValidSize: uint64(len(data))}},
+	}
+	require.NoError(t, security.LocationCrcFill(&loc))
+	getData := func(ctx context.Context, w io.Writer, location proto.Location, readSize, offset uint64) (func() error, error) {
+		return func() error {
+			_, err := w.Write([]byte(data)[offset : offset+readSize])
+			return err
+		}, nil
+	}
+
+	buff := bytes.NewBuffer(nil)
+	args := &acapi.GetBlobArgs{
+		ClusterID: 1,
+		BlobName:  []byte("blob1"),
+		ShardKeys: [][]byte{[]byte("key")},
+		ReadSize:  uint64(len(data)),
+		Writer:    buff,
+	}
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().GetBlob(gAny, gAny).Return(&loc, nil)
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, gAny, gAny).DoAndReturn(getData).Times(2)
+	for i := 0; i < 2; i++ {
+		buff.Reset()
+		_, err = hd.GetBlob(ctx, args)
+		require.NoError(t, err)
+		require.Equal(t, data, buff.String())
+	}
+
+	// deleted, the location is asked again
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().DeleteBlob(gAny, gAny).Return(nil)
+	require.NoError(t, hd.DeleteBlob(ctx, &acapi.DelBlobArgs{ClusterID: 1, BlobName: args.BlobName, ShardKeys: args.ShardKeys}))
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().GetBlob(gAny, gAny).Return(nil, errMock)
+	_, err = hd.GetBlob(ctx, args)
+	require.ErrorIs(t, err, errMock)
+
+	// the location of a blob not sealed is not cached
+	unsealed := loc
+	unsealed.Size_ = 0
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().GetBlob(gAny, gAny).Return(&unsealed, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err = hd.GetBlob(ctx, args)
+		require.Error(t, err)
+	}
+
+	// expired
+	hd.locations, err = newLocationCache(16, 0)
+	require.NoError(t, err)
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().GetBlob(gAny, gAny).Return(&loc, nil).Times(2)
+	hd.handler.(*mocks.MockStreamHandler).EXPECT().Get(gAny, gAny, gAny, gAny, gAny).DoAndReturn(getData).Times(2)
+	for i := 0; i < 2; i++ {
+		buff.Reset()
+		_, err = hd.GetBlob(ctx, args)
+		require.NoError(t, err)
+	}
+
+	// disabled
+	nilCache, err := newLocationCache(0, 0)
+	require.NoError(t, err)
+	require.Nil(t, nilCache)
+	_, ok := nilCache.Get("key")
+	require.False(t, ok)
+}
@@ -495,6 +495,13 @@ build_snapshot() {
     popd >/dev/null
 }
 
+build_publish() {
+    pushd $SrcPath >/dev/null
+    echo -n "build cfs-publish	"
+    go build $MODFLAGS -ldflags "${LDFlags}" -o ${BuildBinPath}/cfs-publish ${SrcPath}/tool/publish/*.go  && echo "success" || echo "failed"
+    popd >/dev/null
+}
+
 build_libsdkpre() {
     case `uname` in
         Linux)
@@ -645,6 +652,9 @@ case "$cmd" in
     "snapshot")
         build_snapshot
         ;;
+    "publish")
+        build_publish
+        ;;
     "libsdkpre")
         build_libsdkpre
         ;;
@@ -38,6 +38,9 @@ func setupCommands(cfg *cmd.Config) *cobra.Command {
 	mc := master.NewMasterClient(cfg.MasterAddr, false)
 	mc.SetTimeout(cfg.Timeout)
 	mc.SetClientIDKey(cfg.ClientIDKey)
+	if cfg.AccessKey != "" {
+		mc.SetSigV4Credentials(cfg.AccessKey, cfg.SecretKey)
+	}
 	cfsRootCmd := cmd.NewRootCmd(mc)
 	completionCmd := &cobra.Command{
 		Use:   "completion",
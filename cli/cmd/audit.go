@@ -0,0 +1,177 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cmdAuditUse   = "audit [COMMAND]"
+	cmdAuditShort = "Verify the checksums of the partitions of a volume across replicas"
+)
+
+func newAuditCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdAuditUse,
+		Short: cmdAuditShort,
+	}
+	cmd.AddCommand(
+		newAuditStartCmd(client),
+		newAuditStatusCmd(client),
+		newAuditListCmd(client),
+		newAuditCancelCmd(client),
+		newAuditReportCmd(client),
+	)
+	return cmd
+}
+
+const (
+	cmdAuditStartUse    = "start [VOLUME]"
+	cmdAuditStartShort  = "Start an audit campaign of volume"
+	cmdAuditStatusUse   = "status [CAMPAIGN ID]"
+	cmdAuditStatusShort = "Show the progress of an audit campaign"
+	cmdAuditListShort   = "List audit campaigns"
+	cmdAuditCancelUse   = "cancel [CAMPAIGN ID]"
+	cmdAuditCancelShort = "Cancel a running audit campaign"
+	cmdAuditReportUse   = "report [CAMPAIGN ID]"
+	cmdAuditReportShort = "Show the signed report of a finished audit campaign"
+)
+
+func newAuditStartCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optBudget      time.Duration
+		optConcurrency int
+	)
+	cmd := &cobra.Command{
+		Use:   cmdAuditStartUse,
+		Short: cmdAuditStartShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var svv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(args[0]); err != nil {
+				return
+			}
+			var view *proto.AuditCampaignView
+			if view, err = client.AdminAPI().CreateAuditCampaign(args[0], util.CalcAuthKey(svv.Owner), optBudget, optConcurrency); err != nil {
+				return
+			}
+			stdout("Audit campaign %v of %v partitions has been started.\n", view.ID, view.Total)
+		},
+	}
+	cmd.Flags().DurationVar(&optBudget, "budget", time.Hour, "Time budget of the campaign, in minutes granularity")
+	cmd.Flags().IntVar(&optConcurrency, "concurrency", 4, "Partitions verified at the same time")
+	return cmd
+}
+
+func newAuditStatusCmd(client *master.MasterClient) *cobra.Command {
+	var optDetail bool
+	cmd := &cobra.Command{
+		Use:   cmdAuditStatusUse,
+		Short: cmdAuditStatusShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var view *proto.AuditCampaignView
+			if view, err = client.AdminAPI().GetAuditCampaign(args[0]); err != nil {
+				return
+			}
+			if !optDetail {
+				view.Partitions = nil
+			}
+			stdout("%v", formatAuditCampaigns([]*proto.AuditCampaignView{view}))
+			for _, res := range view.Partitions {
+				stdout("%v", formatAuditPartitionResult(res))
+			}
+		},
+	}
+	cmd.Flags().BoolVarP(&optDetail, "detail", "d", false, "Show the result of every partition")
+	return cmd
+}
+
+func newAuditListCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpList,
+		Short: cmdAuditListShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var views []*proto.AuditCampaignView
+			if views, err = client.AdminAPI().ListAuditCampaigns(); err != nil {
+				return
+			}
+			stdout("%v", formatAuditCampaigns(views))
+		},
+	}
+	return cmd
+}
+
+func newAuditCancelCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdAuditCancelUse,
+		Short: cmdAuditCancelShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			if err = client.AdminAPI().CancelAuditCampaign(args[0]); err != nil {
+				return
+			}
+			stdout("Audit campaign %v has been canceled.\n", args[0])
+		},
+	}
+	return cmd
+}
+
+func newAuditReportCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdAuditReportUse,
+		Short: cmdAuditReportShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var report *proto.AuditReport
+			if report, err = client.AdminAPI().GetAuditCampaignReport(args[0]); err != nil {
+				return
+			}
+			var out []byte
+			if out, err = json.MarshalIndent(report, "", "    "); err != nil {
+				return
+			}
+			stdout("%s\n", string(out))
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/master"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cmdClientEvictionUse   = "clienteviction [COMMAND]"
+	cmdClientEvictionShort = "Evict clients from the cluster"
+)
+
+func newClientEvictionCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdClientEvictionUse,
+		Short: cmdClientEvictionShort,
+	}
+	cmd.AddCommand(
+		newClientEvictionAddCmd(client),
+		newClientEvictionRemoveCmd(client),
+		newClientEvictionListCmd(client),
+	)
+	return cmd
+}
+
+const (
+	cmdClientEvictionAddShort    = "Evict a client by its ip or mount id"
+	cmdClientEvictionRemoveShort = "Lift the eviction of a client"
+	cmdClientEvictionListShort   = "List evicted clients"
+)
+
+func newClientEvictionAddCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		ip      string
+		mountID string
+		volName string
+		reason  string
+		expire  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpAdd,
+		Short: cmdClientEvictionAddShort,
+		Long: fmt.Sprintf("%v. Metanodes and datanodes reject the requests of the client, to the volume only if it is given, "+
+			"from their next heartbeat on. A mount id evicts the ip it runs on for its volume.", cmdClientEvictionAddShort),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var eviction *proto.ClientEviction
+			if eviction, err = client.AdminAPI().EvictClient(ip, mountID, volName, reason, uint64(expire.Seconds())); err != nil {
+				return
+			}
+			stdout("Client %v has been evicted.\n", eviction.Key())
+		},
+	}
+	cmd.Flags().StringVar(&ip, "ip", "", "IP of the client")
+	cmd.Flags().StringVar(&mountID, "mount-id", "", "Mount id of the client, logged by the client when it mounts")
+	cmd.Flags().StringVar(&volName, "vol", "", "Evict the client from the volume only")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the client is evicted")
+	cmd.Flags().DurationVar(&expire, "expire", 0, "How long the eviction lasts, 0 until it is removed")
+	return cmd
+}
+
+func newClientEvictionRemoveCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		ip      string
+		mountID string
+		volName string
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpRemove,
+		Short: cmdClientEvictionRemoveShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var removed []*proto.ClientEviction
+			if removed, err = client.AdminAPI().RemoveClientEviction(ip, mountID, volName); err != nil {
+				return
+			}
+			for _, e := range removed {
+				stdout("Eviction of client %v has been removed.\n", e.Key())
+			}
+		},
+	}
+	cmd.Flags().StringVar(&ip, "ip", "", "IP of the client")
+	cmd.Flags().StringVar(&mountID, "mount-id", "", "Mount id the client was evicted by")
+	cmd.Flags().StringVar(&volName, "vol", "", "Volume the client was evicted from")
+	return cmd
+}
+
+func newClientEvictionListCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpList,
+		Short: cmdClientEvictionListShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var evictions []*proto.ClientEviction
+			if evictions, err = client.AdminAPI().ListClientEvictions(); err != nil {
+				return
+			}
+			stdout("%v", formatClientEvictions(evictions))
+		},
+	}
+	return cmd
+}
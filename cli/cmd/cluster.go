@@ -22,6 +22,7 @@ import (
 
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/util"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +39,16 @@ func newClusterCmd(client *master.MasterClient) *cobra.Command {
 	clusterCmd.AddCommand(
 		newClusterInfoCmd(client),
 		newClusterStatCmd(client),
+		newClusterFeaturesCmd(client),
+		newClusterClockSkewCmd(client),
+		newClusterEventsCmd(client),
+		newClusterSummaryCmd(client),
+		newClusterZoneCostCmd(client),
+		newClusterSimulatePlacementCmd(client),
+		newClusterBalanceLeadersCmd(client),
+		newClusterDataBalanceCmd(client),
+		newClusterZoneBalanceCmd(client),
+		newClusterMaintenanceCmd(client),
 		newClusterFreezeCmd(client),
 		newClusterSetThresholdCmd(client),
 		newClusterSetParasCmd(client),
@@ -51,6 +62,8 @@ func newClusterCmd(client *master.MasterClient) *cobra.Command {
 		newClusterQueryDpOpCmd(client),
 		newClusterQueryDiskOpCmd(client),
 		newClusterChangeMasterLeaderCmd(client),
+		newClusterStandbyCmd(client),
+		newClusterPromoteStandbyCmd(client),
 	)
 	return clusterCmd
 }
@@ -58,10 +71,22 @@ func newClusterCmd(client *master.MasterClient) *cobra.Command {
 const (
 	cmdClusterInfoShort                    = "Show cluster summary information"
 	cmdClusterStatShort                    = "Show cluster status information"
+	cmdClusterFeaturesShort                = "Show which features are supported by all nodes"
+	cmdClusterClockSkewShort               = "Show the clock skew of the nodes measured by the master"
+	cmdClusterEventsShort                  = "Show or follow the flash group and node offline events of the cluster"
+	cmdClusterSummaryShort                 = "Show the health score and the top issues of the cluster"
+	cmdClusterZoneCostShort                = "Show or set the traffic costs between zones"
+	cmdClusterSimulatePlacementShort       = "Simulate the placement of the data partitions after adding or removing datanodes, or changing replica numbers"
+	cmdClusterBalanceLeadersShort          = "Show the raft leaders of the data and meta nodes and move them to even them out"
+	cmdClusterDataBalanceShort             = "Show or control the data balancer moving data partition replicas off the most used data nodes"
+	cmdClusterZoneBalanceShort             = "Show or control the data balancer moving data partition replicas off the most used zones"
+	cmdClusterMaintenanceShort             = "Show, start or stop the maintenance of the cluster, a zone or a node set"
 	cmdClusterFreezeShort                  = "Freeze cluster"
 	cmdClusterThresholdShort               = "Set memory threshold of metanodes"
 	cmdClusterSetClusterInfoShort          = "Set cluster parameters"
 	cmdClusterSetVolDeletionDelayTimeShort = "Set volDeletionDelayTime of master"
+	cmdClusterStandbyShort                 = "Show the role and the raft progress of the masters of the primary and standby groups"
+	cmdClusterPromoteStandbyShort          = "Fence the primary masters and promote the standby group"
 	nodeDeleteBatchCountKey                = "batchCount"
 	nodeMarkDeleteRateKey                  = "markDeleteRate"
 	nodeDeleteWorkerSleepMs                = "deleteWorkerSleepMs"
@@ -158,6 +183,479 @@ func newClusterStatCmd(client *master.MasterClient) *cobra.Command {
 	return cmd
 }
 
+func newClusterFeaturesCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpFeatures,
+		Short: cmdClusterFeaturesShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err    error
+				matrix *proto.ClusterFeatureMatrix
+			)
+			defer func() {
+				if err != nil {
+					errout(err)
+				}
+			}()
+			if matrix, err = client.AdminAPI().GetClusterFeatures(); err != nil {
+				err = fmt.Errorf("Get cluster features fail:\n%v\n", err)
+				return
+			}
+			stdout("[Cluster Features]\n")
+			stdout("%v", formatClusterFeatures(matrix))
+		},
+	}
+	return cmd
+}
+
+func newClusterClockSkewCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpClockSkew,
+		Short: cmdClusterClockSkewShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err  error
+				view *proto.ClockSkewView
+			)
+			defer func() {
+				if err != nil {
+					errout(err)
+				}
+			}()
+			if view, err = client.AdminAPI().GetClusterClockSkew(); err != nil {
+				err = fmt.Errorf("Get cluster clock skew fail:\n%v\n", err)
+				return
+			}
+			stdout("[Cluster Clock Skew]\n")
+			stdout("%v", formatClusterClockSkew(view))
+		},
+	}
+	return cmd
+}
+
+// clusterEventsFollowSec is how long a follow request waits for the new events.
+const clusterEventsFollowSec = 30
+
+func newClusterEventsCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		after  int64
+		types  []string
+		follow bool
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpEvents,
+		Short: cmdClusterEventsShort,
+		Long: `Show the events kept by the master leader after the event id, of the types
+if set. With follow, wait for the new events until interrupted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err  error
+				view *proto.ClusterEventsView
+			)
+			defer func() {
+				errout(err)
+			}()
+			timeoutSec := 0
+			if follow {
+				timeoutSec = clusterEventsFollowSec
+			}
+			stdout("%v", clusterEventTableHeader)
+			for {
+				if view, err = client.AdminAPI().GetClusterEvents(after, types, timeoutSec); err != nil {
+					return
+				}
+				stdout("%v", formatClusterEvents(view))
+				after = view.Last
+				if !follow {
+					return
+				}
+			}
+		},
+	}
+	cmd.Flags().Int64Var(&after, "after", 0, "Show the events after the event id")
+	cmd.Flags().StringSliceVar(&types, "types", nil, "Show the events of the types, e.g. flashGroupStatus,dataNodeOffline")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Wait for the new events")
+	return cmd
+}
+
+func newClusterSummaryCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpSummary,
+		Short: cmdClusterSummaryShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err     error
+				summary *proto.ClusterSummary
+			)
+			defer func() {
+				if err != nil {
+					errout(err)
+				}
+			}()
+			if summary, err = client.AdminAPI().GetClusterSummary(); err != nil {
+				err = fmt.Errorf("Get cluster summary fail:\n%v\n", err)
+				return
+			}
+			stdout("[Cluster Summary]\n")
+			stdout("%v", formatClusterSummary(summary))
+		},
+	}
+	return cmd
+}
+
+func newClusterSimulatePlacementCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optAddNodes    []string
+		optRemoveNodes []string
+		optReplicaNums []string
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpSimulatePlacement,
+		Short: cmdClusterSimulatePlacementShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err    error
+				result *proto.PlacementSimulation
+			)
+			defer func() {
+				if err != nil {
+					errout(err)
+				}
+			}()
+			req := &proto.PlacementSimulationRequest{RemoveNodes: optRemoveNodes}
+			if req.AddNodes, err = parseSimulatedNodes(optAddNodes); err != nil {
+				return
+			}
+			if req.ReplicaNums, err = parseSimulatedReplicaNums(optReplicaNums); err != nil {
+				return
+			}
+			if result, err = client.AdminAPI().SimulatePlacement(req); err != nil {
+				err = fmt.Errorf("Simulate placement fail:\n%v\n", err)
+				return
+			}
+			stdout("[Placement Simulation]\n")
+			stdout("%v", formatPlacementSimulation(result))
+		},
+	}
+	cmd.Flags().StringSliceVar(&optAddNodes, "addNodes", nil,
+		"datanodes to add as ZONE:COUNT[:CAPACITY_GB], the capacity defaults to the average of the zone")
+	cmd.Flags().StringSliceVar(&optRemoveNodes, "removeNodes", nil, "addresses of the datanodes to remove")
+	cmd.Flags().StringSliceVar(&optReplicaNums, "replicaNums", nil, "replica numbers to set as VOLUME:REPLICA_NUM")
+	return cmd
+}
+
+func parseSimulatedNodes(specs []string) (nodes []*proto.SimulatedNodes, err error) {
+	for _, spec := range specs {
+		fields := strings.Split(spec, ":")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("invalid nodes to add %q, expect ZONE:COUNT[:CAPACITY_GB]", spec)
+		}
+		node := &proto.SimulatedNodes{ZoneName: fields[0]}
+		if node.Count, err = strconv.Atoi(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid count of nodes to add %q: %v", spec, err)
+		}
+		if len(fields) == 3 {
+			var capacity uint64
+			if capacity, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid capacity of nodes to add %q: %v", spec, err)
+			}
+			node.Capacity = capacity * util.GB
+		}
+		nodes = append(nodes, node)
+	}
+	return
+}
+
+func parseSimulatedReplicaNums(specs []string) (replicaNums []*proto.SimulatedReplicaNum, err error) {
+	for _, spec := range specs {
+		i := strings.LastIndex(spec, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid replica number %q, expect VOLUME:REPLICA_NUM", spec)
+		}
+		replicaNum := &proto.SimulatedReplicaNum{Volume: spec[:i]}
+		if replicaNum.ReplicaNum, err = strconv.Atoi(spec[i+1:]); err != nil {
+			return nil, fmt.Errorf("invalid replica number %q: %v", spec, err)
+		}
+		replicaNums = append(replicaNums, replicaNum)
+	}
+	return
+}
+
+func newClusterZoneCostCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpZoneCost + " [FROM TO COST]",
+		Short: cmdClusterZoneCostShort,
+		Long: `Show the traffic costs between zones, or set the cost between two zones,
+0 to remove it. The clients mounted with zoneName read from the cheapest of
+the equally near replicas and flash nodes.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 3 {
+				return fmt.Errorf("accepts 0 or 3 arg(s), received %d", len(args))
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err   error
+				cost  float64
+				costs []proto.ZoneCost
+			)
+			defer func() {
+				errout(err)
+			}()
+			if len(args) == 0 {
+				if costs, err = client.AdminAPI().GetZoneCost(); err != nil {
+					return
+				}
+				stdout("%v", formatZoneCosts(costs))
+				return
+			}
+			if cost, err = strconv.ParseFloat(args[2], 64); err != nil {
+				err = fmt.Errorf("Parse Float fail: %v\n", err)
+				return
+			}
+			if costs, err = client.AdminAPI().SetZoneCost(args[0], args[1], cost); err != nil {
+				return
+			}
+			stdout("Cost between zone %v and zone %v is set to %v!\n", args[0], args[1], cost)
+			stdout("%v", formatZoneCosts(costs))
+		},
+	}
+	return cmd
+}
+
+func newClusterBalanceLeadersCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optDryRun bool
+		optLimit  uint64
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpBalanceLeaders,
+		Short: cmdClusterBalanceLeadersShort,
+		Long: `Show the raft leaders each data and meta node holds and its share of them,
+and ask the replicas on the nodes under their share to take over the leaders of
+the nodes over it. The leaders moved are not moved again for 30 minutes.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err  error
+				view *proto.LeaderBalanceView
+			)
+			defer func() {
+				errout(err)
+			}()
+			if view, err = client.AdminAPI().BalanceLeaders(optLimit, optDryRun); err != nil {
+				return
+			}
+			stdout("%v", formatLeaderBalanceView(view))
+		},
+	}
+	cmd.Flags().BoolVar(&optDryRun, "dryRun", false, "show the leader transfers without requesting them")
+	cmd.Flags().Uint64Var(&optLimit, "limit", 0, "request up to the number of leader transfers, 32 if 0")
+	return cmd
+}
+
+func newClusterDataBalanceCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optMaxConcurrency int
+		optBandwidthMBps  int
+		optThreshold      float64
+	)
+	cmd := &cobra.Command{
+		Use:       CliOpDataBalance + " [enable|disable|pause|resume]",
+		Short:     cmdClusterDataBalanceShort,
+		ValidArgs: []string{"enable", "disable", "pause", "resume"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			return cobra.OnlyValidArgs(cmd, args)
+		},
+		Long: `Show the space usage of the data nodes and their disks per zone and media
+type, with the replica moves running, finished and planned by the data balancer.
+The balancer moves the replicas of the data nodes over the mean usage of their
+zone by more than the threshold to the least used data nodes of the zone, at
+most maxConcurrency at a time and copying at most bandwidthMBps. Pausing it
+keeps the running moves going but starts no new one.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err  error
+				view *proto.DataBalanceView
+			)
+			defer func() {
+				errout(err)
+			}()
+			if len(args) == 0 {
+				if view, err = client.AdminAPI().GetDataBalanceStatus(); err != nil {
+					return
+				}
+				stdout("%v", formatDataBalanceView(view))
+				return
+			}
+			switch args[0] {
+			case "pause", "resume":
+				if err = client.AdminAPI().PauseDataBalance(args[0] == "pause"); err != nil {
+					return
+				}
+			default:
+				if view, err = client.AdminAPI().GetDataBalanceStatus(); err != nil {
+					return
+				}
+				cfg := view.Config
+				cfg.Enabled = args[0] == "enable"
+				if cmd.Flags().Changed("maxConcurrency") {
+					cfg.MaxConcurrency = optMaxConcurrency
+				}
+				if cmd.Flags().Changed("bandwidthMBps") {
+					cfg.BandwidthMBps = optBandwidthMBps
+				}
+				if cmd.Flags().Changed("threshold") {
+					cfg.Threshold = optThreshold
+				}
+				if err = client.AdminAPI().SetDataBalance(&cfg); err != nil {
+					return
+				}
+			}
+			stdout("Data balance %vd!\n", args[0])
+		},
+	}
+	cmd.Flags().IntVar(&optMaxConcurrency, "maxConcurrency", 0, "the moves running at a time, 1 to 64")
+	cmd.Flags().IntVar(&optBandwidthMBps, "bandwidthMBps", 0, "the bytes the moves copy per second in MB, 0 for unlimited")
+	cmd.Flags().Float64Var(&optThreshold, "threshold", 0, "the usage ratio a data node may be over the mean of its zone, in (0, 1)")
+	return cmd
+}
+
+func newClusterZoneBalanceCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optWindow         string
+		optMaxTBPerWindow float64
+		optTargetSpread   float64
+		optMaxZoneCost    float64
+	)
+	cmd := &cobra.Command{
+		Use:       CliOpZoneBalance + " [enable|disable]",
+		Short:     cmdClusterZoneBalanceShort,
+		ValidArgs: []string{"enable", "disable"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			return cobra.OnlyValidArgs(cmd, args)
+		},
+		Long: `Show the usage spread of the zones of each media type, with the replica moves
+of the data balancer. Once enabled, the balancer also moves the replicas of the
+most used zone, while it is over the least used zone by more than targetSpread,
+to the zones under the mean usage, the cheapest first by the zone costs and
+none over maxZoneCost. The moves start only in the window of local time, e.g.
+01:00-05:00, or 22:00-02:00 over midnight, and move at most maxTBPerWindow in
+a window. They share the concurrency, the bandwidth and the pause of the moves
+within the zones, see the dataBalance command.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err  error
+				view *proto.DataBalanceView
+			)
+			defer func() {
+				errout(err)
+			}()
+			if view, err = client.AdminAPI().GetDataBalanceStatus(); err != nil {
+				return
+			}
+			if len(args) == 0 {
+				stdout("%v", formatDataBalanceView(view))
+				return
+			}
+			cfg := view.Config.Zone
+			cfg.Enabled = args[0] == "enable"
+			if cmd.Flags().Changed("window") {
+				cfg.Window = optWindow
+			}
+			if cmd.Flags().Changed("maxTBPerWindow") {
+				cfg.MaxTBPerWindow = optMaxTBPerWindow
+			}
+			if cmd.Flags().Changed("targetSpread") {
+				cfg.TargetSpread = optTargetSpread
+			}
+			if cmd.Flags().Changed("maxZoneCost") {
+				cfg.MaxZoneCost = optMaxZoneCost
+			}
+			if err = client.AdminAPI().SetZoneBalance(&cfg); err != nil {
+				return
+			}
+			stdout("Zone balance %vd!\n", args[0])
+		},
+	}
+	cmd.Flags().StringVar(&optWindow, "window", "", "the local time the moves start in, e.g. 01:00-05:00, 00:00-00:00 for all day")
+	cmd.Flags().Float64Var(&optMaxTBPerWindow, "maxTBPerWindow", 0, "the bytes moved in a window in TB")
+	cmd.Flags().Float64Var(&optTargetSpread, "targetSpread", 0, "the usage ratio the most used zone may be over the least used one, in (0, 1)")
+	cmd.Flags().Float64Var(&optMaxZoneCost, "maxZoneCost", 0, "the highest cost between the zones of a move, 0 for no ceiling")
+	return cmd
+}
+
+func newClusterMaintenanceCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optZone     string
+		optNodeSet  uint64
+		optDuration string
+		optReason   string
+	)
+	cmd := &cobra.Command{
+		Use:       CliOpMaintenance + " [start|stop]",
+		Short:     cmdClusterMaintenanceShort,
+		ValidArgs: []string{"start", "stop"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			return cobra.OnlyValidArgs(cmd, args)
+		},
+		Long: `Show the maintenances on, or start or stop the maintenance of the whole
+cluster, of the zone with --zone or of the node set with --nodeset. While in
+maintenance, the master neither decommissions the bad disks of the nodes, nor
+repairs the metadata of their data partitions, nor moves data partition replicas
+off or onto them, and the nodes defer the replica repair and the deletion of the
+freed extents. The leader balance and the meta partition balance stop while any
+maintenance is on. A maintenance started with --duration ends by itself.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err          error
+				maintenances []proto.Maintenance
+			)
+			defer func() {
+				errout(err)
+			}()
+			if len(args) == 0 {
+				if maintenances, err = client.AdminAPI().ListMaintenances(); err != nil {
+					return
+				}
+				stdout("%v", formatMaintenances(maintenances))
+				return
+			}
+			scope := proto.MaintenanceScopeCluster
+			if cmd.Flags().Changed("nodeset") {
+				scope = proto.MaintenanceScopeNodeSet
+			} else if optZone != "" {
+				scope = proto.MaintenanceScopeZone
+			}
+			if args[0] == "stop" {
+				if err = client.AdminAPI().StopMaintenance(scope, optZone, optNodeSet); err != nil {
+					return
+				}
+				stdout("Maintenance stopped!\n")
+				return
+			}
+			if err = client.AdminAPI().StartMaintenance(scope, optZone, optNodeSet, optDuration, optReason); err != nil {
+				return
+			}
+			stdout("Maintenance started!\n")
+		},
+	}
+	cmd.Flags().StringVar(&optZone, "zone", "", "the zone in maintenance")
+	cmd.Flags().Uint64Var(&optNodeSet, "nodeset", 0, "the node set in maintenance")
+	cmd.Flags().StringVar(&optDuration, "duration", "", "the maintenance ends by itself after the duration, as 4h")
+	cmd.Flags().StringVar(&optReason, "reason", "", "the reason of the maintenance")
+	return cmd
+}
+
 func newClusterFreezeCmd(client *master.MasterClient) *cobra.Command {
 	var clientIDKey string
 	cmd := &cobra.Command{
@@ -283,12 +781,28 @@ func newClusterSetParasCmd(client *master.MasterClient) *cobra.Command {
 	dpTimeout := ""
 	mpTimeout := ""
 	dpBackupTimeout := ""
+	replicaTombstoneRetention := ""
+	clockSkewWarn := ""
+	clockSkewLimit := ""
+	hotDpReadQps := ""
+	hotDpReadReplicas := ""
+	heartbeatMaxInterval := ""
 	decommissionDpLimit := ""
 	decommissionDiskLimit := ""
 	forbidWriteOpOfProtoVersion0 := ""
 	dataMediaType := ""
 	handleTimeout := ""
 	readDataNodeTimeout := ""
+	peerFillEnable := ""
+	peerFillTimeout := ""
+	admissionEnable := ""
+	readLimitMBps := ""
+	readLimitIops := ""
+	flashGroupMinNodesPerZone := ""
+	leaderBalanceMaxTransfers := ""
+	hotMpSplitQps := ""
+	hotMpSplitMemMB := ""
+	decommissionBandwidthMBps := ""
 	cmd := &cobra.Command{
 		Use:   CliOpSetCluster,
 		Short: cmdClusterSetClusterInfoShort,
@@ -388,6 +902,58 @@ func newClusterSetParasCmd(client *master.MasterClient) *cobra.Command {
 
 				dpBackupTimeout = strconv.FormatInt(int64(backupTimeout), 10)
 			}
+			if replicaTombstoneRetention != "" {
+				var retention time.Duration
+				retention, err = time.ParseDuration(replicaTombstoneRetention)
+				if err != nil {
+					return
+				}
+				if retention < 0 {
+					err = fmt.Errorf("replica tombstone retention %v is negative", retention)
+					return
+				}
+
+				replicaTombstoneRetention = strconv.FormatInt(int64(retention.Seconds()), 10)
+			}
+			for _, skew := range []*string{&clockSkewWarn, &clockSkewLimit} {
+				if *skew == "" {
+					continue
+				}
+				var d time.Duration
+				if d, err = time.ParseDuration(*skew); err != nil {
+					return
+				}
+				if d < 0 {
+					err = fmt.Errorf("clock skew %v is negative", d)
+					return
+				}
+				*skew = strconv.FormatInt(d.Milliseconds(), 10)
+			}
+			if heartbeatMaxInterval != "" {
+				var d time.Duration
+				if d, err = time.ParseDuration(heartbeatMaxInterval); err != nil {
+					return
+				}
+				if d < 0 {
+					err = fmt.Errorf("heartbeat max interval %v is negative", d)
+					return
+				}
+				heartbeatMaxInterval = strconv.FormatInt(int64(d.Seconds()), 10)
+			}
+			for flag, val := range map[string]string{
+				CliFlagHotDpReadQps: hotDpReadQps, CliFlagHotDpReadReplicas: hotDpReadReplicas,
+				"flashGroupMinNodesPerZone": flashGroupMinNodesPerZone, "leaderBalanceMaxTransfers": leaderBalanceMaxTransfers,
+				"hotMpSplitQps": hotMpSplitQps, "hotMpSplitMemMB": hotMpSplitMemMB,
+				"decommissionBandwidthMBps": decommissionBandwidthMBps,
+			} {
+				if val == "" {
+					continue
+				}
+				if _, err = strconv.ParseUint(val, 10, 64); err != nil {
+					err = fmt.Errorf("param %v(%v) should be a non negative number", flag, val)
+					return
+				}
+			}
 
 			if forbidWriteOpOfProtoVersion0 != "" {
 				if _, err = strconv.ParseBool(forbidWriteOpOfProtoVersion0); err != nil {
@@ -422,13 +988,50 @@ func newClusterSetParasCmd(client *master.MasterClient) *cobra.Command {
 					return
 				}
 			}
+			if peerFillEnable != "" {
+				if _, err = strconv.ParseBool(peerFillEnable); err != nil {
+					err = fmt.Errorf("param flashNodePeerFillEnable(%v) should be true or false", peerFillEnable)
+					return
+				}
+			}
+			if peerFillTimeout != "" {
+				if tmp, err = strconv.ParseInt(peerFillTimeout, 10, 64); err != nil {
+					err = fmt.Errorf("param (%v) failed, should be int", peerFillTimeout)
+					return
+				}
+				if tmp <= 0 {
+					err = fmt.Errorf("peerFillTimeout (%v) should grater than 0", peerFillTimeout)
+					return
+				}
+			}
+			if admissionEnable != "" {
+				if _, err = strconv.ParseBool(admissionEnable); err != nil {
+					err = fmt.Errorf("param flashNodeAdmissionEnable(%v) should be true or false", admissionEnable)
+					return
+				}
+			}
+			for _, limit := range []struct{ name, value string }{
+				{"flashNodeReadLimitMBps", readLimitMBps},
+				{"flashNodeReadLimitIops", readLimitIops},
+			} {
+				if limit.value == "" {
+					continue
+				}
+				if tmp, err = strconv.ParseInt(limit.value, 10, 32); err != nil || tmp < 0 {
+					err = fmt.Errorf("param %v(%v) should be a number not less than 0", limit.name, limit.value)
+					return
+				}
+			}
 
 			if err = client.AdminAPI().SetClusterParas(optDelBatchCount, optMarkDeleteRate, optDelWorkerSleepMs,
 				optAutoRepairRate, optLoadFactor, opMaxDpCntLimit, opMaxMpCntLimit, clientIDKey,
 				autoDecommissionDisk, autoDecommissionDiskInterval,
 				autoDpMetaRepair, autoDpMetaRepairParallelCnt,
 				dpRepairTimeout, dpTimeout, mpTimeout, dpBackupTimeout, decommissionDpLimit, decommissionDiskLimit,
-				forbidWriteOpOfProtoVersion0, dataMediaType, handleTimeout, readDataNodeTimeout); err != nil {
+				forbidWriteOpOfProtoVersion0, dataMediaType, handleTimeout, readDataNodeTimeout, peerFillEnable, peerFillTimeout, admissionEnable,
+				replicaTombstoneRetention, clockSkewWarn, clockSkewLimit, hotDpReadQps, hotDpReadReplicas,
+				readLimitMBps, readLimitIops, heartbeatMaxInterval, flashGroupMinNodesPerZone, leaderBalanceMaxTransfers,
+				hotMpSplitQps, hotMpSplitMemMB, decommissionBandwidthMBps); err != nil {
 				return
 			}
 			stdout("Cluster parameters has been set successfully. \n")
@@ -455,6 +1058,16 @@ func newClusterSetParasCmd(client *master.MasterClient) *cobra.Command {
 	cmd.Flags().StringVar(&autoDecommissionDisk, CliFlagAutoDecommissionDisk, "", "Enable or disable auto decommission disk")
 	cmd.Flags().StringVar(&autoDecommissionDiskInterval, CliFlagAutoDecommissionDiskInterval, "", "Interval of auto decommission disk(example: 10s)")
 	cmd.Flags().StringVar(&dpBackupTimeout, CliFlagDpBackupTimeout, "", "Data partition backup directory timeout(example: 1h)")
+	cmd.Flags().StringVar(&replicaTombstoneRetention, CliFlagReplicaTombstoneRetention, "",
+		"Keep the data of the replicas dropped by decommission or migration as tombstones for this long, 0 to delete it at once(example: 72h)")
+	cmd.Flags().StringVar(&clockSkewWarn, CliFlagClockSkewWarn, "", "Raise an alarm for the nodes whose clock is off by more than this, 0 for the default 1s(example: 500ms)")
+	cmd.Flags().StringVar(&clockSkewLimit, CliFlagClockSkewLimit, "",
+		"Give no new partitions to the nodes whose clock is off by more than this, 0 to disable it(example: 5s)")
+	cmd.Flags().StringVar(&hotDpReadQps, CliFlagHotDpReadQps, "",
+		"Give read replicas to the read only data partitions read more than this many times a second, 0 to disable them")
+	cmd.Flags().StringVar(&hotDpReadReplicas, CliFlagHotDpReadReplicas, "", "Max read replicas of a hot data partition, 0 for the default 2")
+	cmd.Flags().StringVar(&heartbeatMaxInterval, CliFlagHeartbeatMaxInterval, "",
+		"Space out the heartbeats of the stable data nodes up to this interval, 0 to heartbeat all of them every 6s(example: 1m)")
 	cmd.Flags().StringVar(&decommissionDpLimit, CliFlagDecommissionDpLimit, "", "Limit for parallel  decommission dp")
 	cmd.Flags().StringVar(&decommissionDiskLimit, CliFlagDecommissionDiskLimit, "", "Limit for parallel decommission disk")
 	cmd.Flags().StringVar(&forbidWriteOpOfProtoVersion0, CliForbidWriteOpOfProtoVersion0, "",
@@ -462,6 +1075,21 @@ func newClusterSetParasCmd(client *master.MasterClient) *cobra.Command {
 	cmd.Flags().StringVar(&dataMediaType, "clusterDataMediaType", "", "set cluster media type, 1(ssd), 2(hdd)")
 	cmd.Flags().StringVar(&handleTimeout, "flashNodeHandleReadTimeout", "", "Specify flash node handle read timeout (example:1000ms)")
 	cmd.Flags().StringVar(&readDataNodeTimeout, "flashNodeReadDataNodeTimeout", "", "Specify flash node read data node timeout (example:3000ms)")
+	cmd.Flags().StringVar(&peerFillEnable, "flashNodePeerFillEnable", "", "Enable or disable flash node reading missed blocks from flash group peers first: [true | false]")
+	cmd.Flags().StringVar(&peerFillTimeout, "flashNodePeerFillTimeout", "", "Specify flash node read flash group peer timeout (example:500ms)")
+	cmd.Flags().StringVar(&admissionEnable, "flashNodeAdmissionEnable", "", "Enable or disable flash node only caching missed blocks more popular than the ones they evict: [true | false]")
+	cmd.Flags().StringVar(&readLimitMBps, "flashNodeReadLimitMBps", "", "Limit the read bandwidth served by each flash node[Unit: MB/s], 0 for unlimited")
+	cmd.Flags().StringVar(&readLimitIops, "flashNodeReadLimitIops", "", "Limit the reads served by each flash node per second, 0 for unlimited")
+	cmd.Flags().StringVar(&flashGroupMinNodesPerZone, "flashGroupMinNodesPerZone", "",
+		"Keep at least this many active flash nodes in each zone of a flash group, 0 to disable it")
+	cmd.Flags().StringVar(&leaderBalanceMaxTransfers, "leaderBalanceMaxTransfers", "",
+		"Move up to this many raft leaders every 5 minutes to even them out over the data and meta nodes, 0 to disable it")
+	cmd.Flags().StringVar(&hotMpSplitQps, "hotMpSplitQps", "",
+		"Split the meta partitions handling more than this many ops a second for 5 minutes, 0 to disable it")
+	cmd.Flags().StringVar(&hotMpSplitMemMB, "hotMpSplitMemMB", "",
+		"Split the meta partitions using more than this many MB of memory for 5 minutes, 0 to disable it")
+	cmd.Flags().StringVar(&decommissionBandwidthMBps, "decommissionBandwidthMBps", "",
+		"Start the data partition decommissions of up to this many MB a second, 0 for unlimited")
 	return cmd
 }
 
@@ -734,3 +1362,60 @@ func newClusterChangeMasterLeaderCmd(client *master.MasterClient) *cobra.Command
 	cmd.Flags().StringVar(&leaderAddr, CliFlagAddress, "", "The address of the new master leader")
 	return cmd
 }
+
+func newClusterStandbyCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpStandby + " [ADDR]...",
+		Short: cmdClusterStandbyShort,
+		Long: `Show the role, the fence and the raft progress of the masters at ADDR, or
+of the masters of the client if none. A standby master lags behind the primary
+group by the commit of the leader less its applied index.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			addrs := args
+			if len(addrs) == 0 {
+				addrs = client.Nodes()
+			}
+			stdout("%v", masterStandbyTableHeader)
+			for _, addr := range addrs {
+				status, err := client.AdminAPI().GetMasterStandbyStatus(addr)
+				stdout("%v", formatMasterStandbyStatus(addr, status, err))
+			}
+		},
+	}
+	return cmd
+}
+
+func newClusterPromoteStandbyCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		force   bool
+		waitSec int
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpPromoteStandby + " [STANDBY ADDR]",
+		Short: cmdClusterPromoteStandbyShort,
+		Long: `Promote the standby group from the standby master at ADDR. It fences the
+masters of the primary group, waits for the standby to apply what they
+committed, and makes the standby masters the voters of a group of their own.
+Without force, it fails if a master of either group is down or the standby does
+not catch up in time. With force, the primary masters down are not fenced and
+must be kept down.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err    error
+				result *proto.MasterPromoteResult
+			)
+			defer func() {
+				errout(err)
+			}()
+			if result, err = client.AdminAPI().PromoteMasterStandby(args[0], force, waitSec); err != nil {
+				return
+			}
+			stdout("Promote the standby group successfully\n")
+			stdout("%v", formatMasterPromoteResult(result))
+		},
+	}
+	cmd.Flags().BoolVar(&force, CliFlagForce, false, "Promote with the primary masters down or the entries not applied")
+	cmd.Flags().IntVar(&waitSec, "waitSec", 0, "Seconds to wait for the standby to catch up, 0 for 30")
+	return cmd
+}
@@ -48,6 +48,8 @@ type Config struct {
 	MasterAddr  []string `json:"masterAddr"`
 	Timeout     uint16   `json:"timeout"`
 	ClientIDKey string   `json:"clientIDKey"`
+	AccessKey   string   `json:"accessKey,omitempty"` // signs the requests with sigv4
+	SecretKey   string   `json:"secretKey,omitempty"`
 }
 
 func newConfigCmd() *cobra.Command {
@@ -68,6 +70,8 @@ const (
 func newConfigSetCmd() *cobra.Command {
 	var optMasterHosts string
 	var optTimeout string
+	var optAccessKey string
+	var optSecretKey string
 	cmd := &cobra.Command{
 		Use:   CliOpSet,
 		Short: cmdConfigSetShort,
@@ -93,7 +97,12 @@ func newConfigSetCmd() *cobra.Command {
 				return
 			}
 
-			if err = setConfig(optMasterHosts, timeOut); err != nil {
+			if (optAccessKey == "") != (optSecretKey == "") {
+				stdout("Please set both accessKey and secretKey.\n")
+				return
+			}
+
+			if err = setConfig(optMasterHosts, timeOut, optAccessKey, optSecretKey); err != nil {
 				return
 			}
 			stdout("Config has been set successfully!\n")
@@ -102,6 +111,8 @@ func newConfigSetCmd() *cobra.Command {
 	cmd.Flags().StringVar(&optMasterHosts, "addr", "",
 		"Specify master address {HOST}:{PORT}[,{HOST}:{PORT}]")
 	cmd.Flags().StringVar(&optTimeout, "timeout", "60", "Specify timeout for requests [Unit: s]")
+	cmd.Flags().StringVar(&optAccessKey, "accessKey", "", "Sign the requests with the access key of a root or admin user")
+	cmd.Flags().StringVar(&optSecretKey, "secretKey", "", "Specify the secret key of the access key")
 	return cmd
 }
 
@@ -126,9 +137,12 @@ func printConfigInfo(config *Config) {
 	stdout("Config info:\n")
 	stdout("  Master  Address    : %v\n", config.MasterAddr)
 	stdout("  Request Timeout [s]: %v\n", config.Timeout)
+	if config.AccessKey != "" {
+		stdout("  SigV4 Access Key   : %v\n", config.AccessKey)
+	}
 }
 
-func setConfig(masterHosts string, timeout uint16) (err error) {
+func setConfig(masterHosts string, timeout uint16, accessKey, secretKey string) (err error) {
 	var config *Config
 	if config, err = LoadConfig(); err != nil {
 		return
@@ -140,6 +154,9 @@ func setConfig(masterHosts string, timeout uint16) (err error) {
 	if timeout != 0 {
 		config.Timeout = timeout
 	}
+	if accessKey != "" {
+		config.AccessKey, config.SecretKey = accessKey, secretKey
+	}
 	var configData []byte
 	if configData, err = json.Marshal(config); err != nil {
 		return
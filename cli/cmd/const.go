@@ -19,6 +19,16 @@ const (
 	CliOpGet                          = "get"
 	CliOpList                         = "list"
 	CliOpStatus                       = "stat"
+	CliOpFeatures                     = "features"
+	CliOpClockSkew                    = "clockSkew"
+	CliOpEvents                       = "events"
+	CliOpSummary                      = "summary"
+	CliOpZoneCost                     = "zoneCost"
+	CliOpSimulatePlacement            = "simulatePlacement"
+	CliOpBalanceLeaders               = "balanceLeaders"
+	CliOpDataBalance                  = "dataBalance"
+	CliOpZoneBalance                  = "zoneBalance"
+	CliOpMaintenance                  = "maintenance"
 	CliOpCreate                       = "create"
 	CliOpDelete                       = "delete"
 	CliOpRemove                       = "remove"
@@ -29,6 +39,7 @@ const (
 	CliOpDecommission                 = "decommission"
 	CliOpRecommission                 = "recommission"
 	CliOpQueryProgress                = "query-progress"
+	CliOpLoadProgress                 = "load-progress"
 	CliOpQueryDiskStat                = "query-disk-stat"
 	CliOpQueryNodeStat                = "query-node-stat"
 	CliOpAbortDecommission            = "abort-decommission"
@@ -43,6 +54,7 @@ const (
 	CliOpReset                        = "reset"
 	CliOpReplicate                    = "add-replica"
 	CliOpDelReplica                   = "del-replica"
+	CliOpSplitAt                      = "split-at"
 	CliOpExpand                       = "expand"
 	CliOpShrink                       = "shrink"
 	CliOpGetDiscard                   = "get-discard"
@@ -60,6 +72,8 @@ const (
 	CliOpDataNodeOp                   = "datanodeop"
 	CliOpVolOp                        = "volop"
 	CliOpToLeader                     = "to-leader"
+	CliOpStandby                      = "standby"
+	CliOpPromoteStandby               = "promoteStandby"
 
 	CliOpSetDecommissionLimit    = "set-decommission-limit"
 	CliOpQueryDecommissionStatus = "query-decommission-status"
@@ -147,6 +161,12 @@ const (
 	CliFlagAutoDecommissionDisk         = "autoDecommissionDisk"
 	CliFlagAutoDecommissionDiskInterval = "autoDecommissionDiskInterval"
 	CliFlagDpBackupTimeout              = "dpBackupTimeout"
+	CliFlagReplicaTombstoneRetention    = "replicaTombstoneRetention"
+	CliFlagClockSkewWarn                = "clockSkewWarn"
+	CliFlagClockSkewLimit               = "clockSkewLimit"
+	CliFlagHotDpReadQps                 = "hotDpReadQps"
+	CliFlagHotDpReadReplicas            = "hotDpReadReplicas"
+	CliFlagHeartbeatMaxInterval         = "heartbeatMaxInterval"
 	CliFlagDecommissionDpLimit          = "decommissionDpLimit"
 	CliFlagDecommissionDiskLimit        = "decommissionDiskLimit"
 	CliFlagTrashInterval                = "trashInterval"
@@ -171,9 +191,17 @@ const (
 	CliFlagRemoteCacheMaxFileSizeGB     = "remoteCacheMaxFileSizeGB"
 	CliFlagRemoteCacheOnlyForNotSSD     = "remoteCacheOnlyForNotSSD"
 	CliFlagRemoteCacheMultiRead         = "remoteCacheMultiRead"
+	CliFlagRemoteCacheAlwaysAdmit       = "remoteCacheAlwaysAdmit"
+	CliFlagRemoteCacheAuth              = "remoteCacheAuth"
+	CliFlagRemoteCacheReadAheadMB       = "remoteCacheReadAheadMB"
+	CliFlagRemoteCacheMetaTTL           = "remoteCacheMetaTTL"
+	CliFlagRemoteCacheReadLimitMBps     = "remoteCacheReadLimitMBps"
+	CliFlagRemoteCacheReadLimitIops     = "remoteCacheReadLimitIops"
 	CliFlagFlashNodeTimeoutCount        = "flashNodeTimeoutCount"
 	CliFlagRemoteCacheSameZoneTimeout   = "remoteCacheSameZoneTimeout"
 	CliFlagRemoteCacheSameRegionTimeout = "remoteCacheSameRegionTimeout"
+	CliFlagRemoteCacheHedgeDelay        = "remoteCacheHedgeDelay"
+	CliFlagRemoteCacheFallbackTimeout   = "remoteCacheFallbackTimeout"
 
 	// CliFlagSetDataPartitionCount	= "count" use dp-count instead
 
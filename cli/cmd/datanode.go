@@ -44,6 +44,7 @@ func newDataNodeCmd(client *master.MasterClient) *cobra.Command {
 		newDataNodeQueryDecommissionedDisk(client),
 		newDataNodeQueryDecommissionSuccessDisk(client),
 		newDataNodeCancelDecommissionCmd(client),
+		newDataNodeLoadProgressCmd(client),
 		// newDataNodeDiskOpCmd(client),
 		// newDataNodeDpOpCmd(client),
 	)
@@ -58,11 +59,13 @@ const (
 	cmdDataNodeQueryDecommissionSuccessDisksShort = "query datanode decommissionSuccess disks"
 	cmdDataNodeCancelDecommissionedDisksShort     = "cancel decommission progress for datanode"
 	cmdDataNodeQueryDecommissionProgress          = "query datanode decommission progress"
+	cmdDataNodeLoadProgressShort                  = "Show how far the data nodes are in loading their partitions after started"
 	// cmdDataNodeDiskOpShort                    = "Show Disk_op information of a data node"
 	// cmdDataNodeDpOpShort                      = "Show Dp_op information of a data node"
 )
 
 func newDataNodeListCmd(client *master.MasterClient) *cobra.Command {
+	var opt master.ListOption
 	var optFilterStatus string
 	var optFilterWritable string
 	cmd := &cobra.Command{
@@ -70,7 +73,7 @@ func newDataNodeListCmd(client *master.MasterClient) *cobra.Command {
 		Short:   cmdDataNodeListShort,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dataNodes, err := client.AdminAPI().GetClusterDataNodes()
+			dataNodes, err := client.AdminAPI().GetClusterDataNodesWithOption(opt)
 			if err != nil {
 				return err
 			}
@@ -95,6 +98,7 @@ func newDataNodeListCmd(client *master.MasterClient) *cobra.Command {
 	}
 	cmd.Flags().StringVar(&optFilterWritable, "filter-writable", "", "Filter node writable status")
 	cmd.Flags().StringVar(&optFilterStatus, "filter-status", "", "Filter node status [Active, Inactive]")
+	addListOptionFlags(cmd, &opt, "active, inactive")
 	return cmd
 }
 
@@ -204,9 +208,46 @@ func newDataNodeQueryDecommissionProgress(client *master.MasterClient) *cobra.Co
 				return err
 			}
 			stdout("%v", formatDataNodeDecommissionProgress(progress))
+			// the bandwidth and the completion, left out by the masters not telling them
+			if nodeProgress, err := client.NodeAPI().QueryDecommissionProgress(args[0]); err == nil {
+				stdout("%v", formatDecommissionNodeEstimate(nodeProgress))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newDataNodeLoadProgressCmd(client *master.MasterClient) *cobra.Command {
+	var optLoading bool
+	cmd := &cobra.Command{
+		Use:   CliOpLoadProgress + " [{HOST}:{PORT}]",
+		Short: cmdDataNodeLoadProgressShort,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var addr string
+			if len(args) > 0 {
+				addr = args[0]
+			}
+			progresses, err := client.NodeAPI().GetDataNodeLoadProgress(addr, optLoading)
+			if err != nil {
+				return err
+			}
+			stdoutln("[Data node load progress]")
+			stdoutln(formatDataNodeLoadProgressTableHeader())
+			for i := range progresses {
+				stdoutln(formatDataNodeLoadProgress(&progresses[i]))
+			}
 			return nil
 		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return validDataNodes(client, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
 	}
+	cmd.Flags().BoolVar(&optLoading, "loading", false, "Only show the data nodes still loading")
 	return cmd
 }
 
@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/master"
@@ -42,6 +43,7 @@ func newFlashGroupCmd(client *master.MasterClient) *cobra.Command {
 	cmd.AddCommand(
 		newCmdFlashGroupTurn(client),
 		newCmdFlashGroupCreate(client),
+		newCmdFlashGroupBatchCreate(client),
 		newCmdFlashGroupSet(client),
 		newCmdFlashGroupRemove(client),
 		newCmdFlashGroupNodeAdd(client),
@@ -51,6 +53,15 @@ func newFlashGroupCmd(client *master.MasterClient) *cobra.Command {
 		newCmdFlashGroupClient(client),
 		newCmdFlashGroupSearch(client),
 		newCmdFlashGroupGraph(client),
+		newCmdFlashGroupAutoScale(client),
+		newCmdFlashGroupScaleEvents(client),
+		newCmdFlashGroupAudit(client),
+		newCmdFlashGroupAutoHeal(client),
+		newCmdFlashGroupSetVolumes(client),
+		newCmdFlashGroupRebalanceSlots(client),
+		newCmdFlashGroupMoveSlots(client),
+		newCmdFlashGroupCacheKeys(client),
+		newCmdFlashGroupPurgeCache(client),
 	)
 	return cmd
 }
@@ -78,8 +89,10 @@ func newCmdFlashGroupTurn(client *master.MasterClient) *cobra.Command {
 func newCmdFlashGroupCreate(client *master.MasterClient) *cobra.Command {
 	var optSlots string
 	var optWeight int
+	var optCacheCapacity uint64
 	var optGradualFlag bool
 	var optStep uint32
+	var optDryRun bool
 	cmd := &cobra.Command{
 		Use:   CliOpCreate,
 		Short: "create a new flash group",
@@ -106,7 +119,15 @@ func newCmdFlashGroupCreate(client *master.MasterClient) *cobra.Command {
 				}
 			}
 
-			fgView, err := client.AdminAPI().CreateFlashGroup(optSlots, optWeight, optGradualFlag, optStep)
+			if optDryRun {
+				var preview *proto.FlashGroupSlotsPreview
+				if preview, err = client.AdminAPI().PreviewCreateFlashGroup(optSlots, optWeight, optCacheCapacity); err != nil {
+					return
+				}
+				stdout("%v", formatFlashGroupSlotsPreview(preview))
+				return
+			}
+			fgView, err := client.AdminAPI().CreateFlashGroup(optSlots, optWeight, optCacheCapacity, optGradualFlag, optStep)
 			if err != nil {
 				return
 			}
@@ -116,8 +137,46 @@ func newCmdFlashGroupCreate(client *master.MasterClient) *cobra.Command {
 	}
 	cmd.Flags().StringVar(&optSlots, "slots", "", "set group in which slots, --slots=slot1,slot2,...")
 	cmd.Flags().IntVar(&optWeight, "weight", proto.FlashGroupDefaultWeight, "set group weight(default 1, must 1<=weight<=30), if it was specified slots count equal to 32*weight")
+	cmd.Flags().Uint64Var(&optCacheCapacity, "cacheCapacity", 0, "set the cache capacity(GB) of the group, its slots count is its share of the capacity of the active groups instead of 32*weight")
 	cmd.Flags().BoolVar(&optGradualFlag, "gradualFlag", false, "set whether the group's slots are created gradually or not(default false)")
 	cmd.Flags().Uint32Var(&optStep, "step", 1, "set the step size(default 1) for slot gradual creation")
+	cmd.Flags().BoolVar(&optDryRun, "dryRun", false, "show the slots of the groups once it is created without creating it")
+	return cmd
+}
+
+func newCmdFlashGroupBatchCreate(client *master.MasterClient) *cobra.Command {
+	var optZonePolicy string
+	var optWeight int
+	cmd := &cobra.Command{
+		Use:   "batchCreate [Count] [NodesPerGroup]",
+		Short: "create flash groups of idle flash nodes with their slots in one call",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			count, err := strconv.Atoi(args[0])
+			if err != nil {
+				return
+			}
+			nodesPerGroup, err := strconv.Atoi(args[1])
+			if err != nil {
+				return
+			}
+			if optWeight <= 0 || optWeight > proto.FlashGroupMaxWeight {
+				err = fmt.Errorf("param weight(%v) must greater than 0 and not greater than %v", optWeight, proto.FlashGroupMaxWeight)
+				return
+			}
+			views, err := client.AdminAPI().BatchCreateFlashGroups(count, nodesPerGroup, optZonePolicy, optWeight)
+			if err != nil {
+				return
+			}
+			for i := range views {
+				stdoutln(formatFlashGroupView(&views[i]))
+			}
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optZonePolicy, "zonePolicy", proto.FlashGroupZoneSpread,
+		fmt.Sprintf("%v: the nodes of a group in as many zones as possible, %v: in one zone", proto.FlashGroupZoneSpread, proto.FlashGroupZonePack))
+	cmd.Flags().IntVar(&optWeight, "weight", proto.FlashGroupDefaultWeight, "set groups weight(default 1, must 1<=weight<=30), slots count of each group equal to 32*weight")
 	return cmd
 }
 
@@ -145,10 +204,331 @@ func newCmdFlashGroupSet(client *master.MasterClient) *cobra.Command {
 	}
 }
 
+func newCmdFlashGroupAutoScale(client *master.MasterClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "autoScale" + _flashgroupID + " [Min] [Max]",
+		Short: "scale flash group between min and max flash nodes by its load, max 0 to turn off",
+		Args:  cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flashGroupID, err := parseFlashGroupID(args[0])
+			if err != nil {
+				return
+			}
+			min, err := strconv.Atoi(args[1])
+			if err != nil {
+				return
+			}
+			max, err := strconv.Atoi(args[2])
+			if err != nil {
+				return
+			}
+			fgView, err := client.AdminAPI().SetFlashGroupAutoScale(flashGroupID, min, max)
+			if err != nil {
+				return
+			}
+			stdoutln(formatFlashGroupView(&fgView))
+			return
+		},
+	}
+}
+
+func newCmdFlashGroupAutoHeal(client *master.MasterClient) *cobra.Command {
+	var (
+		optCooldown     int64
+		optExcludeHosts []string
+	)
+	cmd := &cobra.Command{
+		Use:   "autoHeal" + _flashgroupID + " [MinHealthy]",
+		Short: "replace inactive flash nodes of flash group by idle ones of the same zone while less healthy than min, 0 to turn off",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flashGroupID, err := parseFlashGroupID(args[0])
+			if err != nil {
+				return
+			}
+			minHealthy, err := strconv.Atoi(args[1])
+			if err != nil {
+				return
+			}
+			fgView, err := client.AdminAPI().SetFlashGroupAutoHeal(flashGroupID, minHealthy, optCooldown, optExcludeHosts)
+			if err != nil {
+				return
+			}
+			stdoutln(formatFlashGroupView(&fgView))
+			return
+		},
+	}
+	cmd.Flags().Int64Var(&optCooldown, "cooldown", 0, "seconds a flash node is inactive before replaced, and between two replacements, 0 for 600")
+	cmd.Flags().StringSliceVar(&optExcludeHosts, "excludeHosts", nil, "flash nodes never taken as replacement, comma separated")
+	return cmd
+}
+
+func newCmdFlashGroupSetVolumes(client *master.MasterClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "setVolumes" + _flashgroupID + " [Volumes]",
+		Short: "dedicate flash group to the volumes, comma separated, which read from their groups only, none to share it",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			flashGroupID, err := parseFlashGroupID(args[0])
+			if err != nil {
+				return
+			}
+			var volumes []string
+			if len(args) > 1 && args[1] != "" {
+				volumes = strings.Split(args[1], ",")
+			}
+			fgView, err := client.AdminAPI().SetFlashGroupVolumes(flashGroupID, volumes)
+			if err != nil {
+				return
+			}
+			stdoutln(formatFlashGroupView(&fgView))
+			return
+		},
+	}
+}
+
+func newCmdFlashGroupRebalanceSlots(client *master.MasterClient) *cobra.Command {
+	var (
+		optStep   uint32
+		optDryRun bool
+	)
+	cmd := &cobra.Command{
+		Use:   "rebalanceSlots",
+		Short: "set the slots count of active flash groups proportional to the cache capacity of their flash nodes",
+		Args:  cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if optDryRun {
+				var preview *proto.FlashGroupSlotsPreview
+				if preview, err = client.AdminAPI().PreviewRebalanceFlashGroupSlots(); err != nil {
+					return
+				}
+				stdout("%v", formatFlashGroupSlotsRebalance(preview.Rebalance))
+				stdout("%v", formatFlashGroupSlotsPreview(preview))
+				return
+			}
+			shares, err := client.AdminAPI().RebalanceFlashGroupSlots(optStep, false)
+			if err != nil {
+				return
+			}
+			stdout("%v", formatFlashGroupSlotsRebalance(shares))
+			return
+		},
+	}
+	cmd.Flags().Uint32Var(&optStep, "step", 0, "slots added or removed per minute for each group, 0 for all at once")
+	cmd.Flags().BoolVar(&optDryRun, "dryRun", false, "show the target slots count and the slots of the groups once rebalanced only")
+	return cmd
+}
+
+func newCmdFlashGroupMoveSlots(client *master.MasterClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "moveSlots [SrcFlashGroupID] [DstFlashGroupID] [Slots]",
+		Short: "move the slots, comma separated, of the source flash group to the active destination one",
+		Args:  cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			srcID, err := parseFlashGroupID(args[0])
+			if err != nil {
+				return
+			}
+			dstID, err := parseFlashGroupID(args[1])
+			if err != nil {
+				return
+			}
+			var slots []uint32
+			for _, s := range strings.Split(args[2], ",") {
+				var slot uint64
+				if slot, err = strconv.ParseUint(strings.TrimSpace(s), 10, 32); err != nil {
+					return
+				}
+				slots = append(slots, uint32(slot))
+			}
+			views, err := client.AdminAPI().MoveFlashGroupSlots(srcID, dstID, slots)
+			if err != nil {
+				return
+			}
+			for i := range views {
+				stdoutln(formatFlashGroupView(&views[i]))
+			}
+			return
+		},
+	}
+}
+
+func newCmdFlashGroupScaleEvents(client *master.MasterClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "scaleEvents [FlashGroupID]",
+		Short: "list the flash nodes added or removed by auto scaling, newest first",
+		Args:  cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var flashGroupID uint64
+			if len(args) > 0 {
+				if flashGroupID, err = parseFlashGroupID(args[0]); err != nil {
+					return
+				}
+			}
+			events, err := client.AdminAPI().FlashGroupScaleEvents(flashGroupID)
+			if err != nil {
+				return
+			}
+			tbl := table{formatFlashGroupScaleEventTitle}
+			for _, e := range events {
+				tbl = tbl.append(arow(formatTime(e.Time), e.FlashGroupID, e.Action, e.FlashNode, e.ZoneName, e.NodeCount,
+					fmt.Sprintf("%.2f", e.HitRate), fmt.Sprintf("%.2f", e.UsageRatio), e.Reason))
+			}
+			stdoutln(alignTable(tbl...))
+			return
+		},
+	}
+}
+
+func newCmdFlashGroupAudit(client *master.MasterClient) *cobra.Command {
+	var (
+		optOp    string
+		optStart string
+		optEnd   string
+	)
+	cmd := &cobra.Command{
+		Use:   "audit [FlashGroupID]",
+		Short: "list the admin operations on the flash groups, oldest first",
+		Args:  cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var flashGroupID uint64
+			if len(args) > 0 {
+				if flashGroupID, err = parseFlashGroupID(args[0]); err != nil {
+					return
+				}
+			}
+			var start, end int64
+			parseTime := func(value string) (unix int64, err error) {
+				if value == "" {
+					return
+				}
+				t, err := time.ParseInLocation(proto.TimeFormat, value, time.Local)
+				return t.Unix(), err
+			}
+			if start, err = parseTime(optStart); err != nil {
+				return
+			}
+			if end, err = parseTime(optEnd); err != nil {
+				return
+			}
+			records, err := client.AdminAPI().FlashGroupAudit(flashGroupID, optOp, start, end)
+			if err != nil {
+				return
+			}
+			tbl := table{formatFlashGroupAuditTitle}
+			for _, record := range records {
+				tbl = tbl.append(arow(formatTime(record.Time), record.FlashGroupID, record.Op, record.Operator, record.Args, record.Result))
+			}
+			stdoutln(alignTable(tbl...))
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optOp, "op", "", fmt.Sprintf("list the operations of op only, one of %v",
+		[]string{proto.FlashGroupOpCreate, proto.FlashGroupOpBatchCreate, proto.FlashGroupOpRemove, proto.FlashGroupOpSet,
+			proto.FlashGroupOpNodeAdd, proto.FlashGroupOpNodeRemove, proto.FlashGroupOpPurgeCache, proto.FlashGroupOpMoveSlots}))
+	cmd.Flags().StringVar(&optStart, "start", "", "list the operations since, \"2006-01-02 15:04:05\" in local time")
+	cmd.Flags().StringVar(&optEnd, "end", "", "list the operations until, \"2006-01-02 15:04:05\" in local time")
+	return cmd
+}
+
+func newCmdFlashGroupCacheKeys(client *master.MasterClient) *cobra.Command {
+	var (
+		optVolume string
+		optPrefix string
+		optLimit  int
+	)
+	cmd := &cobra.Command{
+		Use:   "cacheKeys [FlashGroupID]",
+		Short: "list the most read blocks cached by the flash groups, or by the flash group",
+		Args:  cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var flashGroupID uint64
+			if len(args) > 0 {
+				if flashGroupID, err = parseFlashGroupID(args[0]); err != nil {
+					return
+				}
+			}
+			keys, err := client.AdminAPI().FlashGroupCacheKeys(flashGroupID, optVolume, optPrefix, optLimit)
+			if err != nil {
+				return
+			}
+			tbl := table{formatFlashGroupCacheKeyTitle}
+			for _, key := range keys.Keys {
+				tbl = tbl.append(arow(key.FlashGroupID, key.FlashNode, key.Volume, key.Inode, key.Offset,
+					formatSize(uint64(key.Size)), key.Hits, time.Duration(key.AgeSec)*time.Second,
+					time.Duration(key.ExpireSec)*time.Second, key.Hot))
+			}
+			stdoutln(alignTable(tbl...))
+			if keys.Total > len(keys.Keys) {
+				stdoutln(fmt.Sprintf("listed %v of %v blocks", len(keys.Keys), keys.Total))
+			}
+			for addr, msg := range keys.Failed {
+				stdoutln(fmt.Sprintf("flashnode %v not listed: %v", addr, msg))
+			}
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optVolume, "volume", "", "list the blocks of the volume only")
+	cmd.Flags().StringVar(&optPrefix, "prefix", "", "list the blocks of the volume whose key starts with the prefix, e.g. \"<inode>#\"")
+	cmd.Flags().IntVar(&optLimit, "limit", 100, "list up to the number of blocks, no limit if 0")
+	return cmd
+}
+
+func newCmdFlashGroupPurgeCache(client *master.MasterClient) *cobra.Command {
+	var (
+		optVolume string
+		optPrefix string
+		optYes    bool
+	)
+	cmd := &cobra.Command{
+		Use:   "purgeCache [FlashGroupID]",
+		Short: "evict the blocks of the volume from the flash groups, or from the flash group",
+		Args:  cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var flashGroupID uint64
+			if len(args) > 0 {
+				if flashGroupID, err = parseFlashGroupID(args[0]); err != nil {
+					return
+				}
+			}
+			if optVolume == "" {
+				return fmt.Errorf("volume can not be empty")
+			}
+			if !optYes {
+				fmt.Printf("purge the blocks of volume[%v] prefix[%v] from flash group[%v]\n", optVolume, optPrefix, flashGroupID)
+				stdout("\nConfirm (yes/no)[no]: ")
+				var userConfirm string
+				_, _ = fmt.Scanln(&userConfirm)
+				if userConfirm != "yes" {
+					err = fmt.Errorf("Abort by user.\n")
+					return
+				}
+			}
+			purge, err := client.AdminAPI().PurgeFlashGroupCache(flashGroupID, optVolume, optPrefix)
+			if err != nil {
+				return
+			}
+			tbl := table{formatFlashGroupCachePurgeTitle}
+			for _, node := range purge.Nodes {
+				tbl = tbl.append(arow(node.FlashGroupID, node.FlashNode, node.Purged, len(node.Failed), node.Err))
+			}
+			stdoutln(alignTable(tbl...))
+			stdoutln(fmt.Sprintf("purged %v blocks", purge.Purged))
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optVolume, "volume", "", "purge the blocks of the volume")
+	cmd.Flags().StringVar(&optPrefix, "prefix", "", "purge the blocks of the volume whose key starts with the prefix, e.g. \"<inode>#\", all if empty")
+	cmd.Flags().BoolVarP(&optYes, "yes", "y", false, "Answer yes for all questions")
+	return cmd
+}
+
 func newCmdFlashGroupRemove(client *master.MasterClient) *cobra.Command {
 	var optYes bool
 	var optGradualFlag bool
 	var optStep uint32
+	var optDrainTTL uint32
+	var optDryRun bool
 	cmd := &cobra.Command{
 		Use:   CliOpRemove + _flashgroupID,
 		Short: "remove flash group by id",
@@ -158,6 +538,14 @@ func newCmdFlashGroupRemove(client *master.MasterClient) *cobra.Command {
 			if err != nil {
 				return
 			}
+			if optDryRun {
+				var preview *proto.FlashGroupSlotsPreview
+				if preview, err = client.AdminAPI().PreviewRemoveFlashGroup(flashGroupID); err != nil {
+					return
+				}
+				stdout("%v", formatFlashGroupSlotsPreview(preview))
+				return
+			}
 			// ask user for confirm
 			if !optYes {
 				fmt.Printf("remove flash group by id[%d]\n", flashGroupID)
@@ -177,7 +565,16 @@ func newCmdFlashGroupRemove(client *master.MasterClient) *cobra.Command {
 				}
 			}
 
-			result, err := client.AdminAPI().RemoveFlashGroup(flashGroupID, optGradualFlag, optStep)
+			var result string
+			if optDrainTTL > 0 {
+				if optGradualFlag {
+					err = fmt.Errorf("param drainTTL and gradualFlag cannot be set together")
+					return
+				}
+				result, err = client.AdminAPI().DrainFlashGroup(flashGroupID, optDrainTTL)
+			} else {
+				result, err = client.AdminAPI().RemoveFlashGroup(flashGroupID, optGradualFlag, optStep)
+			}
 			if err != nil {
 				return
 			}
@@ -188,6 +585,8 @@ func newCmdFlashGroupRemove(client *master.MasterClient) *cobra.Command {
 	cmd.Flags().BoolVarP(&optYes, "yes", "y", false, "Answer yes for all questions")
 	cmd.Flags().BoolVar(&optGradualFlag, "gradualFlag", false, "set whether the group's slots are deleted gradually or not(default false)")
 	cmd.Flags().Uint32Var(&optStep, "step", 1, "set the step size(default 1) for slot gradual deletion")
+	cmd.Flags().Uint32Var(&optDrainTTL, "drainTTL", 0, "drain the group for the seconds before removing it, the clients read it but cache nothing in it")
+	cmd.Flags().BoolVar(&optDryRun, "dryRun", false, "show the slots of the groups once it is removed without removing it")
 	return cmd
 }
 
@@ -306,14 +705,31 @@ func newCmdFlashGroupGet(client *master.MasterClient) *cobra.Command {
 }
 
 func newCmdFlashGroupList(client *master.MasterClient) *cobra.Command {
-	return &cobra.Command{
+	var optDraining bool
+	var opt master.ListOption
+	cmd := &cobra.Command{
 		Use:   CliOpList + " [IsActive]",
-		Short: "list active or inactive flash groups",
+		Short: "list active, inactive or draining flash groups",
 		Args:  cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			var fgView proto.FlashGroupsAdminView
 			var isActive bool
-			if len(args) > 0 {
+			if opt != (master.ListOption{}) {
+				if optDraining {
+					opt.Status = "draining"
+				} else if len(args) > 0 {
+					if isActive, err = strconv.ParseBool(args[0]); err != nil {
+						return
+					}
+					opt.Status = "inactive"
+					if isActive {
+						opt.Status = "active"
+					}
+				}
+				fgView, err = client.AdminAPI().ListFlashGroupsWithOption(opt)
+			} else if optDraining {
+				fgView, err = client.AdminAPI().ListDrainingFlashGroups()
+			} else if len(args) > 0 {
 				if isActive, err = strconv.ParseBool(args[0]); err != nil {
 					return
 				}
@@ -360,15 +776,22 @@ func newCmdFlashGroupList(client *master.MasterClient) *cobra.Command {
 			return
 		},
 	}
+	cmd.Flags().BoolVar(&optDraining, "draining", false, "list the draining flash groups only")
+	addListOptionFlags(cmd, &opt, "")
+	return cmd
 }
 
 func newCmdFlashGroupClient(client *master.MasterClient) *cobra.Command {
 	return &cobra.Command{
-		Use:   "client",
-		Short: "show flash group response passed from master to client",
+		Use:   "client [volume]",
+		Short: "show flash group response passed from master to client of the volume",
 		Args:  cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			fgv, err := client.AdminAPI().ClientFlashGroups()
+			var volume string
+			if len(args) > 0 {
+				volume = args[0]
+			}
+			fgv, err := client.AdminAPI().ClientFlashGroups(volume)
 			if err != nil {
 				return
 			}
@@ -379,6 +802,15 @@ func newCmdFlashGroupClient(client *master.MasterClient) *cobra.Command {
 	}
 }
 
+func isFlashGroupBoundTo(fg proto.FlashGroupAdminView, volume string) bool {
+	for _, name := range fg.Volumes {
+		if name == volume {
+			return true
+		}
+	}
+	return false
+}
+
 func newCmdFlashGroupSearch(client *master.MasterClient) *cobra.Command {
 	return &cobra.Command{
 		Use:   "search [volume] [inode] [offset]",
@@ -404,12 +836,22 @@ func newCmdFlashGroupSearch(client *master.MasterClient) *cobra.Command {
 			if err != nil {
 				return
 			}
+			// a volume bound to flash groups reads from them only, the others from the unbound ones
+			var bound bool
+			for _, fg := range fgView.FlashGroups {
+				if isFlashGroupBoundTo(fg, volume) {
+					bound = true
+				}
+			}
 			set := make(map[uint32]struct{})
 			slots := make([]slotInfo, 0)
 			for _, fg := range fgView.FlashGroups {
 				if fg.Status != proto.FlashGroupStatus_Active {
 					continue
 				}
+				if bound && !isFlashGroupBoundTo(fg, volume) || !bound && len(fg.Volumes) > 0 {
+					continue
+				}
 				for _, slot := range fg.Slots {
 					if _, in := set[slot]; in {
 						continue
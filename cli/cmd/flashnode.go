@@ -35,16 +35,20 @@ func newFlashNodeCmd(client *master.MasterClient) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newCmdFlashNodeSet(client),
+		newCmdFlashNodeReadOnly(client),
 		newCmdFlashNodeRemove(client),
 		newCmdFlashNodeGet(client),
 		newCmdFlashNodeList(client),
+		newCmdFlashNodeStats(client),
 		newCmdFlashNodeRemoveAllInactive(client),
+		newCmdFlashNodeWarmup(client),
 
 		newCmdFlashNodeHTTPStat(client),
 		newCmdFlashNodeHTTPStatAll(client),
 		newCmdFlashNodeHTTPEvict(client),
 		newCmdFlashNodeHTTPInactiveDisk(client),
 		newCmdFlashNodeHTTPSlotStat(client),
+		newCmdFlashNodeHTTPKeys(client),
 	)
 	return cmd
 }
@@ -69,6 +73,26 @@ func newCmdFlashNodeSet(client *master.MasterClient) *cobra.Command {
 	}
 }
 
+func newCmdFlashNodeReadOnly(client *master.MasterClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "readOnly" + _flashnodeAddr + " [IsReadOnly]",
+		Short: "set flash node serving the blocks it caches but caching nothing more, to drain its cache before maintenance",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			addr := args[0]
+			readOnly, err := strconv.ParseBool(args[1])
+			if err != nil {
+				return
+			}
+			if err = client.NodeAPI().SetFlashNodeReadOnly(addr, readOnly); err != nil {
+				return
+			}
+			stdoutlnf("set flashnode:%s readOnly:%v success", addr, readOnly)
+			return
+		},
+	}
+}
+
 func newCmdFlashNodeRemove(client *master.MasterClient) *cobra.Command {
 	var optYes bool
 	cmd := &cobra.Command{
@@ -185,6 +209,132 @@ func newCmdFlashNodeList(client *master.MasterClient) *cobra.Command {
 	}
 }
 
+func newCmdFlashNodeStats(client *master.MasterClient) *cobra.Command {
+	var (
+		optFlashGroupID uint64
+		optZoneName     string
+	)
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "show the cache hit rate, evictions, occupied bytes and objects of the flash nodes",
+		Args:  cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			view, err := client.NodeAPI().GetFlashNodeStats(optFlashGroupID, optZoneName)
+			if err != nil {
+				return
+			}
+			stdoutln(formatFlashNodeStats(view))
+			return
+		},
+	}
+	cmd.Flags().Uint64Var(&optFlashGroupID, "flashGroupID", 0, "show the flash nodes of the flash group")
+	cmd.Flags().StringVar(&optZoneName, CliFlagFlashZoneName, "", "show the flash nodes of the zone")
+	return cmd
+}
+
+func newCmdFlashNodeWarmup(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "warmup [COMMAND]",
+		Short: "warm up the files of a volume in the flash groups",
+	}
+	cmd.AddCommand(
+		newCmdFlashNodeWarmupCreate(client),
+		newCmdFlashNodeWarmupList(client),
+	)
+	for _, op := range []string{"stop", "pause", "resume", "delete"} {
+		cmd.AddCommand(newCmdFlashNodeWarmupOp(client, op))
+	}
+	return cmd
+}
+
+func newCmdFlashNodeWarmupCreate(client *master.MasterClient) *cobra.Command {
+	var (
+		optID             string
+		optPath           string
+		optInodes         string
+		optOffset         uint64
+		optSize           uint64
+		optTotalSizeLimit int64
+	)
+	cmd := &cobra.Command{
+		Use:   CliOpCreate + " [VOLUME]",
+		Short: "create a task to warm up the files under a path or the inodes of the volume",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			task := &proto.FlashManualTask{
+				Id:      optID,
+				Action:  proto.FlashManualWarmupAction,
+				VolName: args[0],
+				ManualTaskConfig: proto.ManualTaskConfig{
+					Prefix:             optPath,
+					TotalFileSizeLimit: optTotalSizeLimit,
+					Offset:             optOffset,
+					Size:               optSize,
+				},
+			}
+			if optInodes != "" {
+				for _, s := range strings.Split(optInodes, ",") {
+					var ino uint64
+					if ino, err = strconv.ParseUint(strings.TrimSpace(s), 10, 64); err != nil {
+						return fmt.Errorf("invalid inode %v", s)
+					}
+					task.ManualTaskConfig.Inodes = append(task.ManualTaskConfig.Inodes, ino)
+				}
+			}
+			if err = client.NodeAPI().CreateFlashManualTask(task); err != nil {
+				return
+			}
+			stdoutlnf("create warmup task %v of volume %v success", task.Id, task.VolName)
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optID, "id", "", "id of the task, generated if empty")
+	cmd.Flags().StringVar(&optPath, "path", "", "warm up the files under the path")
+	cmd.Flags().StringVar(&optInodes, "inodes", "", "warm up the inodes separated by comma instead of the files under the path")
+	cmd.Flags().Uint64Var(&optOffset, "offset", 0, "warm up the bytes of each file from the offset")
+	cmd.Flags().Uint64Var(&optSize, "size", 0, "warm up the bytes of each file up to the size, up to the end of the file if 0")
+	cmd.Flags().Int64Var(&optTotalSizeLimit, "totalSizeLimit", 0, "stop the task after warming up the total bytes, no limit if 0")
+	return cmd
+}
+
+func newCmdFlashNodeWarmupList(client *master.MasterClient) *cobra.Command {
+	var optID string
+	cmd := &cobra.Command{
+		Use:   CliOpList + " [VOLUME]",
+		Short: "list the warmup tasks of the volume or of all the volumes",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			var volName string
+			if len(args) == 1 {
+				volName = args[0]
+			}
+			tasks, err := client.NodeAPI().ListFlashManualTasks(volName, optID)
+			if err != nil {
+				return
+			}
+			stdoutln(formatFlashManualTasks(tasks))
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optID, "id", "", "show the task of the id only")
+	return cmd
+}
+
+func newCmdFlashNodeWarmupOp(client *master.MasterClient, op string) *cobra.Command {
+	return &cobra.Command{
+		Use:   op + " [TaskID]",
+		Short: op + " the warmup task",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			if err = client.NodeAPI().OpFlashManualTask(args[0], op); err != nil {
+				return
+			}
+			stdoutlnf("%v warmup task %v success", op, args[0])
+			return
+		},
+	}
+}
+
 func newCmdFlashNodeHTTPStat(client *master.MasterClient) *cobra.Command {
 	return &cobra.Command{
 		Use:   "httpStat" + _flashnodeAddr,
@@ -252,6 +402,38 @@ func newCmdFlashNodeHTTPSlotStat(client *master.MasterClient) *cobra.Command {
 	}
 }
 
+func newCmdFlashNodeHTTPKeys(client *master.MasterClient) *cobra.Command {
+	var (
+		optVolume string
+		optPrefix string
+		optHot    bool
+		optLimit  int
+	)
+	cmd := &cobra.Command{
+		Use:   "keys" + _flashnodeAddr,
+		Short: "list the blocks cached in flashnode",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			// check flashnode whether exist
+			_, err = client.NodeAPI().GetFlashNode(args[0])
+			if err != nil {
+				return
+			}
+			keys, err := httpclient.New().Addr(addr2Prof(args[0])).FlashNode().Keys(optVolume, optPrefix, optHot, optLimit)
+			if err != nil {
+				return
+			}
+			stdout("%v", formatFlashNodeCacheKeys(&keys))
+			return
+		},
+	}
+	cmd.Flags().StringVar(&optVolume, "volume", "", "list the blocks of the volume only")
+	cmd.Flags().StringVar(&optPrefix, "prefix", "", "list the blocks of the volume whose key starts with the prefix, e.g. \"<inode>#\"")
+	cmd.Flags().BoolVar(&optHot, "hot", false, "list the most read blocks first")
+	cmd.Flags().IntVar(&optLimit, "limit", 1000, "list up to the number of blocks, no limit if 0")
+	return cmd
+}
+
 func newCmdFlashNodeHTTPEvict(client *master.MasterClient) *cobra.Command {
 	return &cobra.Command{
 		Use:   "httpEvict" + _flashnodeAddr + " [volume]",
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFmtFlashNode(t *testing.T) {
@@ -35,6 +36,9 @@ func TestFmtFlashNode(t *testing.T) {
 		IsEnable:     false,
 	}
 	stdoutln(formatFlashNodeView(&fnView))
+	recoveredView := fnView
+	recoveredView.CacheRecovery = &proto.FlashNodeCacheRecovery{Time: time.Now().Unix(), CostMs: 12, Blocks: 3, Bytes: 3 << 20, Indexed: 2, Dropped: 1}
+	stdoutln(formatFlashNodeView(&recoveredView))
 
 	zoneNodes := make(map[string][]*proto.FlashNodeViewInfo)
 	for idxZ, zone := range []string{"z1", "z2", "z3"} {
@@ -91,3 +95,20 @@ func TestFmtFlashNode(t *testing.T) {
 	}
 	stdoutln(alignTable(tbl...))
 }
+
+func TestFmtFlashManualTasks(t *testing.T) {
+	now := time.Now()
+	tasks := []*proto.FlashManualTask{
+		{
+			Id: "b", VolName: "vol", Status: int(proto.Flash_Task_Running), StartTime: &now,
+			ManualTaskConfig:     proto.ManualTaskConfig{Inodes: []uint64{10, 11}, Offset: 100, Size: 1000},
+			ManualTaskStatistics: &proto.ManualTaskStatistics{FlashNode: "a.b.c.d:80", TotalFileScannedNum: 2, TotalCacheSize: 1000},
+		},
+		{Id: "a", VolName: "vol", ManualTaskConfig: proto.ManualTaskConfig{Prefix: "/dir/"}},
+	}
+	out := formatFlashManualTasks(tasks)
+	stdoutln(out)
+	require.Contains(t, out, "2 inodes [100, 1100)")
+	require.Contains(t, out, "/dir")
+	require.Contains(t, out, "Running")
+}
@@ -92,12 +92,49 @@ func formatClusterView(cv *proto.ClusterView, cn *proto.ClusterNodeInfo, cp *pro
 	sb.WriteString(fmt.Sprintf("  DecommissionDpLimit                      : %v\n", cv.DecommissionLimit))
 	sb.WriteString(fmt.Sprintf("  DecommissionDiskLimit                    : %v\n", cv.DecommissionDiskLimit))
 	sb.WriteString(fmt.Sprintf("  DpBackupTimeout                          : %v\n", cv.DpBackupTimeout))
+	sb.WriteString(fmt.Sprintf("  ReplicaTombstoneRetention                : %v\n", cv.ReplicaTombstoneRetention))
+	sb.WriteString(fmt.Sprintf("  ClockSkewWarn                            : %v\n", cv.ClockSkewWarn))
+	sb.WriteString(fmt.Sprintf("  ClockSkewLimit                           : %v\n", cv.ClockSkewLimit))
+	sb.WriteString(fmt.Sprintf("  HotDpReadQps                             : %v\n", cv.HotDpReadQps))
+	sb.WriteString(fmt.Sprintf("  HotDpReadReplicas                        : %v\n", cv.HotDpReadReplicas))
+	sb.WriteString(fmt.Sprintf("  HeartbeatMaxInterval                     : %v\n", cv.HeartbeatMaxInterval))
 	sb.WriteString(fmt.Sprintf("  ForbidWriteOpOfProtoVersion0             : %v\n", cv.ForbidWriteOpOfProtoVer0))
 	sb.WriteString(fmt.Sprintf("  LegacyDataMediaType                      : %v\n", cv.LegacyDataMediaType))
 	sb.WriteString(fmt.Sprintf("  RaftPartitionCanUsingDifferentPortEnabled: %v\n", cv.RaftPartitionCanUsingDifferentPortEnabled))
 
 	sb.WriteString(fmt.Sprintf("  FlashNodeHandleReadTimeout       : %v ms\n", cv.FlashNodeHandleReadTimeout))
 	sb.WriteString(fmt.Sprintf("  FlashNodeReadDataNodeTimeout     : %v ms\n", cv.FlashNodeReadDataNodeTimeout))
+	sb.WriteString(fmt.Sprintf("  FlashNodePeerFillEnable          : %v\n", cv.FlashNodePeerFillEnable))
+	sb.WriteString(fmt.Sprintf("  FlashNodePeerFillTimeout         : %v ms\n", cv.FlashNodePeerFillTimeout))
+	sb.WriteString(fmt.Sprintf("  FlashNodeAdmissionEnable         : %v\n", cv.FlashNodeAdmissionEnable))
+	sb.WriteString(fmt.Sprintf("  FlashNodeReadLimitMBps           : %v MB/s\n", cv.FlashNodeReadLimitMBps))
+	sb.WriteString(fmt.Sprintf("  FlashNodeReadLimitIops           : %v\n", cv.FlashNodeReadLimitIops))
+	sb.WriteString(fmt.Sprintf("  FlashGroupMinNodesPerZone        : %v\n", cv.FlashGroupMinNodesPerZone))
+	sb.WriteString(fmt.Sprintf("  LeaderBalanceMaxTransfers        : %v\n", cv.LeaderBalanceMaxTransfers))
+	sb.WriteString(fmt.Sprintf("  HotMpSplitQps                    : %v\n", cv.HotMpSplitQps))
+	sb.WriteString(fmt.Sprintf("  HotMpSplitMemMB                  : %v\n", cv.HotMpSplitMemMB))
+	sb.WriteString(fmt.Sprintf("  DecommissionBandwidthMBps        : %v\n", cv.DecommissionBandwidthMBps))
+	targets := make([]string, 0, len(cv.Maintenances))
+	for i := range cv.Maintenances {
+		targets = append(targets, cv.Maintenances[i].Target())
+	}
+	sb.WriteString(fmt.Sprintf("  Maintenance                      : %v\n", strings.Join(targets, ", ")))
+	return sb.String()
+}
+
+var maintenanceTablePattern = "%-12v    %-20v    %-20v    %v\n"
+
+func formatMaintenances(maintenances []proto.Maintenance) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(maintenanceTablePattern, "TARGET", "START", "END", "REASON"))
+	for _, m := range maintenances {
+		end := "never"
+		if m.EndTime > 0 {
+			end = time.Unix(m.EndTime, 0).Format(proto.TimeFormat)
+		}
+		sb.WriteString(fmt.Sprintf(maintenanceTablePattern, m.Target(),
+			time.Unix(m.StartTime, 0).Format(proto.TimeFormat), end, m.Reason))
+	}
 	return sb.String()
 }
 
@@ -130,6 +167,437 @@ func formatClusterStat(cs *proto.ClusterStatInfo) string {
 	return sb.String()
 }
 
+var (
+	featureTablePattern = "    %-20v    %-8v    %-10v    %-12v    %v\n"
+	featureTableHeader  = fmt.Sprintf(featureTablePattern, "FEATURE", "ENABLED", "ROLE", "SUPPORTED", "UNSUPPORTED NODES")
+)
+
+func formatClusterFeatures(matrix *proto.ClusterFeatureMatrix) string {
+	sb := strings.Builder{}
+	sb.WriteString(featureTableHeader)
+	for _, feature := range matrix.Features {
+		name, enabled := feature.Name, formatEnabledDisabled(feature.Enabled)
+		for _, role := range feature.Roles {
+			sb.WriteString(fmt.Sprintf(featureTablePattern, name, enabled, role.Role,
+				fmt.Sprintf("%v/%v", role.Supported, role.Total), strings.Join(role.Unsupported, ",")))
+			name, enabled = "", ""
+		}
+	}
+	return sb.String()
+}
+
+var (
+	clockSkewTablePattern = "    %-24v    %-10v    %-10v    %-10v    %-19v    %v\n"
+	clockSkewTableHeader  = fmt.Sprintf(clockSkewTablePattern, "ADDRESS", "ROLE", "SKEW(ms)", "RTT(ms)", "MEASURED AT", "STATUS")
+)
+
+func formatClusterClockSkew(view *proto.ClockSkewView) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("  Warn       : %vms\n", view.WarnMs))
+	limit := "disabled"
+	if view.LimitMs > 0 {
+		limit = fmt.Sprintf("%vms", view.LimitMs)
+	}
+	sb.WriteString(fmt.Sprintf("  Limit      : %v\n", limit))
+	sb.WriteString("\n")
+	sb.WriteString(clockSkewTableHeader)
+	for _, node := range view.Nodes {
+		status := "ok"
+		if node.Gated {
+			status = "gated"
+		} else if node.Exceeded {
+			status = "exceeded"
+		}
+		sb.WriteString(fmt.Sprintf(clockSkewTablePattern, node.Addr, node.Role, node.SkewMs, node.DelayMs,
+			formatTime(node.MeasuredAt), status))
+	}
+	return sb.String()
+}
+
+var (
+	clusterEventTablePattern = "    %-19v    %-20v    %-22v    %-12v    %-24v    %v\n"
+	clusterEventTableHeader  = fmt.Sprintf(clusterEventTablePattern, "TIME", "ID", "TYPE", "FLASHGROUP", "ADDR", "STATUS")
+)
+
+func formatClusterEvents(view *proto.ClusterEventsView) string {
+	sb := strings.Builder{}
+	if view.Lost {
+		sb.WriteString("    (some events were dropped by the master before they were read)\n")
+	}
+	for _, event := range view.Events {
+		flashGroup := ""
+		if event.FlashGroupID != 0 {
+			flashGroup = strconv.FormatUint(event.FlashGroupID, 10)
+		}
+		sb.WriteString(fmt.Sprintf(clusterEventTablePattern, formatTime(event.Time), event.ID, event.Type,
+			flashGroup, event.Addr, event.Status))
+	}
+	return sb.String()
+}
+
+var (
+	masterStandbyTablePattern = "    %-22v    %-8v    %-6v    %-22v    %-6v    %-10v    %v\n"
+	masterStandbyTableHeader  = fmt.Sprintf(masterStandbyTablePattern, "ADDR", "ROLE", "FENCED", "LEADER", "TERM", "COMMIT", "APPLIED")
+)
+
+func formatMasterStandbyStatus(addr string, status *proto.MasterStandbyStatus, err error) string {
+	if err != nil {
+		return fmt.Sprintf(masterStandbyTablePattern, addr, "N/A", "N/A", "N/A", "N/A", "N/A", err)
+	}
+	return fmt.Sprintf(masterStandbyTablePattern, addr, status.Role, formatYesNo(status.Fenced), status.Leader,
+		status.Term, status.Commit, status.Applied)
+}
+
+func formatMasterPromoteResult(result *proto.MasterPromoteResult) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("  Promoted      : %v\n", strings.Join(result.Promoted, ",")))
+	sb.WriteString(fmt.Sprintf("  NotPromoted   : %v\n", strings.Join(result.NotPromoted, ",")))
+	sb.WriteString(fmt.Sprintf("  Fenced        : %v\n", strings.Join(result.Fenced, ",")))
+	sb.WriteString(fmt.Sprintf("  Unfenced      : %v\n", strings.Join(result.Unfenced, ",")))
+	sb.WriteString(fmt.Sprintf("  PrimaryCommit : %v\n", result.PrimaryCommit))
+	sb.WriteString(fmt.Sprintf("  Applied       : %v\n", result.Applied))
+	sb.WriteString(fmt.Sprintf("  LostEntries   : %v\n", result.LostEntries))
+	return sb.String()
+}
+
+var (
+	summaryTablePattern = "    %-12v    %-6v    %-9v    %-8v    %v\n"
+	summaryTableHeader  = fmt.Sprintf(summaryTablePattern, "SUBSYSTEM", "SCORE", "STATUS", "ACTIVE", "DETAIL")
+	summaryIssuePattern = "    %-9v    %-12v    %v\n"
+)
+
+func formatClusterSummary(summary *proto.ClusterSummary) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("  Cluster    : %v\n", summary.Cluster))
+	sb.WriteString(fmt.Sprintf("  Leader     : %v\n", summary.LeaderAddr))
+	sb.WriteString(fmt.Sprintf("  Score      : %v\n", summary.Score))
+	sb.WriteString(fmt.Sprintf("  Status     : %v\n", summary.Status))
+	sb.WriteString("\n")
+	sb.WriteString(summaryTableHeader)
+	for _, s := range summary.Subsystems {
+		score, active := strconv.Itoa(s.Score), fmt.Sprintf("%v/%v", s.Active, s.Total)
+		if s.Status == proto.SummaryStatusUnknown {
+			score, active = "-", "-"
+		}
+		sb.WriteString(fmt.Sprintf(summaryTablePattern, s.Name, score, s.Status, active, s.Detail))
+	}
+	if len(summary.TopIssues) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("\nTop Issues:\n")
+	for _, issue := range summary.TopIssues {
+		sb.WriteString(fmt.Sprintf(summaryIssuePattern, issue.Severity, issue.Subsystem, issue.Message))
+		if len(issue.Nodes) > 0 {
+			sb.WriteString(fmt.Sprintf(summaryIssuePattern, "", "", "nodes: "+strings.Join(issue.Nodes, ",")))
+		}
+		sb.WriteString(fmt.Sprintf(summaryIssuePattern, "", "", "action: "+issue.Action))
+	}
+	return sb.String()
+}
+
+var placementSimulationTablePattern = "    %-16v    %-11v    %-21v    %-21v    %-17v    %v\n"
+
+func formatPlacementSimulation(result *proto.PlacementSimulation) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("  Migrated   : %v replicas, %v\n", result.MigratedReplicas, formatSize(result.MigratedBytes)))
+	sb.WriteString(fmt.Sprintf("  Added      : %v replicas, %v\n", result.AddedReplicas, formatSize(result.AddedBytes)))
+	sb.WriteString(fmt.Sprintf("  Dropped    : %v replicas, %v\n", result.DroppedReplicas, formatSize(result.ReleasedBytes)))
+	if len(result.UnplaceablePartitions) > 0 {
+		ids := make([]string, 0, len(result.UnplaceablePartitions))
+		for _, id := range result.UnplaceablePartitions {
+			ids = append(ids, strconv.FormatUint(id, 10))
+		}
+		sb.WriteString(fmt.Sprintf("  Unplaceable: %v partitions, %v\n", len(ids), strings.Join(ids, ",")))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(placementSimulationTablePattern, "ZONE", "NODES", "USED", "HEADROOM", "MAX NODE USED", "IN"))
+	for _, zone := range result.Zones {
+		before, after := zone.Before, zone.After
+		sb.WriteString(fmt.Sprintf(placementSimulationTablePattern, zone.ZoneName,
+			fmt.Sprintf("%v -> %v", before.Nodes, after.Nodes),
+			fmt.Sprintf("%.1f%% -> %.1f%%", before.UsedRatio*100, after.UsedRatio*100),
+			fmt.Sprintf("%v -> %v", formatSize(before.Headroom), formatSize(after.Headroom)),
+			fmt.Sprintf("%.1f%% -> %.1f%%", before.MaxNodeUsedRatio*100, after.MaxNodeUsedRatio*100),
+			fmt.Sprintf("%v replicas, %v", zone.InReplicas, formatSize(zone.InBytes))))
+	}
+	return sb.String()
+}
+
+var (
+	zoneCostTablePattern = "    %-20v    %-20v    %v\n"
+	zoneCostTableHeader  = fmt.Sprintf(zoneCostTablePattern, "FROM", "TO", "COST")
+)
+
+func formatZoneCosts(costs []proto.ZoneCost) string {
+	sb := strings.Builder{}
+	sb.WriteString(zoneCostTableHeader)
+	for _, zc := range costs {
+		sb.WriteString(fmt.Sprintf(zoneCostTablePattern, zc.From, zc.To, zc.Cost))
+	}
+	return sb.String()
+}
+
+var (
+	leaderBalanceNodePattern     = "    %-24v    %-8v    %-8v    %-8v\n"
+	leaderBalanceTransferPattern = "    %-6v    %-12v    %-24v    %-24v    %v\n"
+)
+
+func formatLeaderBalanceView(view *proto.LeaderBalanceView) string {
+	sb := strings.Builder{}
+	for _, nodes := range []struct {
+		title string
+		nodes []*proto.LeaderBalanceNode
+	}{{"[Data nodes]", view.DataNodes}, {"[Meta nodes]", view.MetaNodes}} {
+		sb.WriteString(nodes.title + "\n")
+		sb.WriteString(fmt.Sprintf(leaderBalanceNodePattern, "ADDRESS", "LEADERS", "SHARE", "BALANCED"))
+		for _, node := range nodes.nodes {
+			sb.WriteString(fmt.Sprintf(leaderBalanceNodePattern, node.Addr, node.Leaders, fmt.Sprintf("%.1f", node.Share), node.Balanced))
+		}
+	}
+	if view.DryRun {
+		sb.WriteString("[Leader transfers planned]\n")
+	} else {
+		sb.WriteString("[Leader transfers requested]\n")
+	}
+	sb.WriteString(fmt.Sprintf(leaderBalanceTransferPattern, "TYPE", "PARTITION", "FROM", "TO", "ERROR"))
+	for _, t := range view.Transfers {
+		sb.WriteString(fmt.Sprintf(leaderBalanceTransferPattern, t.Type, t.PartitionID, t.From, t.To, t.Err))
+	}
+	return sb.String()
+}
+
+var (
+	dataBalanceNodePattern = "    %-24v    %-10v    %-10v    %-8v    %-8v    %-8v\n"
+	dataBalanceMovePattern = "    %-12v    %-20v    %-24v    %-24v    %-16v    %-10v    %-8v    %-19v    %v\n"
+)
+
+func formatDataBalanceView(view *proto.DataBalanceView) string {
+	sb := strings.Builder{}
+	cfg := view.Config
+	sb.WriteString(fmt.Sprintf("  Enabled        : %v\n", cfg.Enabled))
+	sb.WriteString(fmt.Sprintf("  Paused         : %v\n", cfg.Paused))
+	sb.WriteString(fmt.Sprintf("  MaxConcurrency : %v\n", cfg.MaxConcurrency))
+	if cfg.BandwidthMBps > 0 {
+		sb.WriteString(fmt.Sprintf("  Bandwidth      : %v MB/s\n", cfg.BandwidthMBps))
+	} else {
+		sb.WriteString("  Bandwidth      : unlimited\n")
+	}
+	sb.WriteString(fmt.Sprintf("  Threshold      : %.2f\n", cfg.Threshold))
+	if view.LastRound > 0 {
+		sb.WriteString(fmt.Sprintf("  LastRound      : %v\n", formatTime(view.LastRound)))
+	}
+	sb.WriteString(fmt.Sprintf("  ZoneBalance    : %v\n", cfg.Zone.Enabled))
+	window := cfg.Zone.Window
+	if window == "" {
+		window = "all day"
+	}
+	if view.WindowOpen {
+		sb.WriteString(fmt.Sprintf("  Window         : %v, open, moved %v of %.2f TB\n", window,
+			formatSize(view.WindowMoved), cfg.Zone.MaxTBPerWindow))
+	} else {
+		sb.WriteString(fmt.Sprintf("  Window         : %v, closed, %.2f TB per window\n", window, cfg.Zone.MaxTBPerWindow))
+	}
+	sb.WriteString(fmt.Sprintf("  TargetSpread   : %.2f\n", cfg.Zone.TargetSpread))
+	if cfg.Zone.MaxZoneCost > 0 {
+		sb.WriteString(fmt.Sprintf("  MaxZoneCost    : %v\n", cfg.Zone.MaxZoneCost))
+	} else {
+		sb.WriteString("  MaxZoneCost    : unlimited\n")
+	}
+	for _, spread := range view.ZoneSpreads {
+		sb.WriteString(fmt.Sprintf("[Zones, %v] spread %.2f, most used %v, least used %v\n",
+			spread.MediaType, spread.Spread, spread.MostUsed, spread.LeastUsed))
+	}
+	for _, zone := range view.Zones {
+		sb.WriteString(fmt.Sprintf("[Zone %v, %v] mean %.2f, skew %.2f, disk skew %.2f\n",
+			zone.Zone, zone.MediaType, zone.Mean, zone.Skew, zone.DiskSkew))
+		sb.WriteString(fmt.Sprintf(dataBalanceNodePattern, "ADDRESS", "TOTAL", "USED", "USAGE", "BALANCED", "DISKSKEW"))
+		for _, node := range zone.Nodes {
+			sb.WriteString(fmt.Sprintf(dataBalanceNodePattern, node.Addr, formatSize(node.Total), formatSize(node.Used),
+				fmt.Sprintf("%.2f", node.Usage), fmt.Sprintf("%.2f", node.Balanced), fmt.Sprintf("%.2f", node.DiskSkew)))
+		}
+	}
+	for _, moves := range []struct {
+		title string
+		moves []*proto.DataBalanceMove
+	}{{"[Moves running]", view.Running}, {"[Moves planned]", view.Planned}, {"[Moves finished]", view.Finished}} {
+		sb.WriteString(moves.title + "\n")
+		sb.WriteString(fmt.Sprintf(dataBalanceMovePattern, "PARTITION", "VOLUME", "FROM", "TO", "ZONES", "SIZE", "STATUS",
+			"START", "ERROR"))
+		for _, m := range moves.moves {
+			start, zones := "", ""
+			if m.StartTime > 0 {
+				start = formatTime(m.StartTime)
+			}
+			if m.ToZone != "" {
+				zones = m.FromZone + "->" + m.ToZone
+			}
+			sb.WriteString(fmt.Sprintf(dataBalanceMovePattern, m.PartitionID, m.VolName, m.From, m.To, zones,
+				formatSize(m.Size), m.Status, start, m.Err))
+		}
+	}
+	return sb.String()
+}
+
+var (
+	volSLOTablePattern = "%-30v    %-6v    %-12v    %-10v    %-10v    %-12v    %-12v    %-8v\n"
+	volSLOTableHeader  = fmt.Sprintf(volSLOTablePattern, "VOLUME", "OP", "TARGET", "TOTAL", "ATTAINMENT",
+		"AVAILABILITY", "BUDGET LEFT", "VIOLATED")
+)
+
+func formatVolSLOStatus(statuses []*proto.VolumeSLOStatus) string {
+	sb := strings.Builder{}
+	sb.WriteString(volSLOTableHeader)
+	for _, st := range statuses {
+		ops := make([]string, 0, len(st.Ops))
+		for op := range st.Ops {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+		for _, op := range ops {
+			opSt := st.Ops[op]
+			sb.WriteString(fmt.Sprintf(volSLOTablePattern, st.Volume, op,
+				fmt.Sprintf("%v%%<=%vms", opSt.Target.Objective*100, opSt.Target.LatencyMs), opSt.Total,
+				fmt.Sprintf("%.4f", opSt.Attainment), fmt.Sprintf("%.4f", opSt.Availability),
+				fmt.Sprintf("%.2f", opSt.ErrorBudgetRemaining), opSt.Violated))
+		}
+	}
+	return sb.String()
+}
+
+var (
+	volTimelineTablePattern = "%-20v    %-18v    %v\n"
+	volTimelineTableHeader  = fmt.Sprintf(volTimelineTablePattern, "TIME", "KIND", "DETAIL")
+)
+
+func formatVolTimeline(events []*proto.VolTimelineEvent) string {
+	sb := strings.Builder{}
+	sb.WriteString(volTimelineTableHeader)
+	for _, e := range events {
+		sb.WriteString(fmt.Sprintf(volTimelineTablePattern, formatTime(e.Time), e.Kind, e.Detail))
+	}
+	return sb.String()
+}
+
+var (
+	volFenceTablePattern = "%-32v    %-10v    %v\n"
+	volFenceTableHeader  = fmt.Sprintf(volFenceTablePattern, "PATH", "TOKEN", "UPDATE TIME")
+)
+
+func formatVolFences(fences []*proto.VolFence) string {
+	sb := strings.Builder{}
+	sb.WriteString(volFenceTableHeader)
+	for _, f := range fences {
+		sb.WriteString(fmt.Sprintf(volFenceTablePattern, f.Path, f.Token, formatTime(f.UpdateTime)))
+	}
+	return sb.String()
+}
+
+var (
+	auditCampaignTablePattern = "%-40v    %-20v    %-10v    %-20v    %-20v    %-12v    %-8v    %-8v    %-8v\n"
+	auditCampaignTableHeader  = fmt.Sprintf(auditCampaignTablePattern, "ID", "VOLUME", "STATUS", "CREATE TIME",
+		"DEADLINE", "DONE", "PASSED", "FAILED", "SKIPPED")
+)
+
+func formatAuditCampaigns(views []*proto.AuditCampaignView) string {
+	sb := strings.Builder{}
+	sb.WriteString(auditCampaignTableHeader)
+	for _, view := range views {
+		sb.WriteString(fmt.Sprintf(auditCampaignTablePattern, view.ID, view.Volume, view.Status,
+			formatTime(view.CreateTime), formatTime(view.Deadline), fmt.Sprintf("%v/%v", view.Done, view.Total),
+			view.Passed, view.Failed, view.Skipped))
+	}
+	return sb.String()
+}
+
+var (
+	mountProfileTablePattern = "%-24v    %-20v    %v\n"
+	mountProfileTableHeader  = fmt.Sprintf(mountProfileTablePattern, "NAME", "UPDATE TIME", "OPTIONS")
+)
+
+func formatMountProfiles(profiles []*proto.MountProfile) string {
+	sb := strings.Builder{}
+	sb.WriteString(mountProfileTableHeader)
+	for _, profile := range profiles {
+		sb.WriteString(fmt.Sprintf(mountProfileTablePattern, profile.Name, formatTime(profile.UpdateTime),
+			proto.FormatMountProfileOptions(profile.Options)))
+	}
+	return sb.String()
+}
+
+var (
+	clientEvictionTablePattern = "%-40v    %-36v    %-20v    %-20v    %v\n"
+	clientEvictionTableHeader  = fmt.Sprintf(clientEvictionTablePattern, "CLIENT", "MOUNT ID", "CREATE TIME", "EXPIRE TIME", "REASON")
+)
+
+func formatClientEvictions(evictions []*proto.ClientEviction) string {
+	sb := strings.Builder{}
+	sb.WriteString(clientEvictionTableHeader)
+	for _, e := range evictions {
+		expire := "never"
+		if e.ExpireTime > 0 {
+			expire = formatTime(e.ExpireTime)
+		}
+		sb.WriteString(fmt.Sprintf(clientEvictionTablePattern, e.Key(), e.MountID, formatTime(e.CreateTime), expire, e.Reason))
+	}
+	return sb.String()
+}
+
+func formatAuditPartitionResult(res *proto.AuditPartitionResult) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("  %v partition %v: %v, checked %v", res.Type, res.PartitionID, res.Result, res.Checked))
+	if res.Reason != "" {
+		sb.WriteString(fmt.Sprintf(", %v", res.Reason))
+	}
+	sb.WriteString("\n")
+	for _, mismatch := range res.Mismatches {
+		sb.WriteString(fmt.Sprintf("    %v\n", mismatch))
+	}
+	return sb.String()
+}
+
+var (
+	volReclaimTablePattern = "%-30v    %-12v    %-14v    %-14v    %-14v    %-14v    %-19v\n"
+	volReclaimTableHeader  = fmt.Sprintf(volReclaimTablePattern, "VOLUME", "FREE INODES", "FREE SIZE",
+		"DELAYED SIZE", "EXTENT SIZE", "PENDING SIZE", "BOOSTED UNTIL")
+	mpReclaimTablePattern = "%-12v    %-12v    %-14v    %-14v    %-14v    %-14v    %-14v\n"
+	mpReclaimTableHeader  = fmt.Sprintf(mpReclaimTablePattern, "PARTITION ID", "FREE INODES", "FREE SIZE",
+		"DELAYED INODES", "DELAYED SIZE", "EXTENTS", "EXTENT SIZE")
+)
+
+func formatVolReclaimRow(view *proto.VolReclaimView) string {
+	boosted := "-"
+	if view.Boost != nil {
+		boosted = formatTime(view.Boost.Until)
+	}
+	return fmt.Sprintf(volReclaimTablePattern, view.Name, view.FreeInodes, formatSize(view.FreeBytes),
+		formatSize(view.DelayedBytes), formatSize(view.DelExtentBytes), formatSize(view.PendingBytes), boosted)
+}
+
+func formatVolReclaimViews(views []*proto.VolReclaimView) string {
+	sb := strings.Builder{}
+	sb.WriteString(volReclaimTableHeader)
+	for _, view := range views {
+		sb.WriteString(formatVolReclaimRow(view))
+	}
+	return sb.String()
+}
+
+func formatVolReclaimView(view *proto.VolReclaimView) string {
+	sb := strings.Builder{}
+	sb.WriteString(volReclaimTableHeader)
+	sb.WriteString(formatVolReclaimRow(view))
+	if view.Boost != nil {
+		sb.WriteString(fmt.Sprintf("  Boost batch count : %v\n", view.Boost.BatchCount))
+	}
+	sb.WriteString("\nMeta partitions:\n")
+	sb.WriteString(mpReclaimTableHeader)
+	for _, mp := range view.Partitions {
+		sb.WriteString(fmt.Sprintf(mpReclaimTablePattern, mp.PartitionID, mp.FreeInodes, formatSize(mp.FreeBytes),
+			mp.DelayedInodes, formatSize(mp.DelayedBytes), mp.DelExtents, formatSize(mp.DelExtentBytes)))
+	}
+	return sb.String()
+}
+
 func formatDataNodeOp(opv *proto.OpLogView, logNum int, dataNodeName string, filterOp string) string {
 	maxLines := 1000
 	if logNum > 0 && logNum < maxLines {
@@ -250,6 +718,12 @@ func formatSimpleVolView(svv *proto.SimpleVolView) string {
 	sb.WriteString(fmt.Sprintf("  Meta Follower read              : %v\n", formatEnabledDisabled(svv.MetaFollowerRead)))
 	sb.WriteString(fmt.Sprintf("  Direct Read                     : %v\n", formatEnabledDisabled(svv.DirectRead)))
 	sb.WriteString(fmt.Sprintf("  Ignore TinyRecover              : %v\n", formatEnabledDisabled(svv.IgnoreTinyRecover)))
+	sb.WriteString(fmt.Sprintf("  Sync mirror write               : %v\n", formatEnabledDisabled(svv.SyncMirrorWrite)))
+	sb.WriteString(fmt.Sprintf("  Require TLS                     : %v\n", formatEnabledDisabled(svv.RequireTLS)))
+	sb.WriteString(fmt.Sprintf("  Inline data threshold           : %v\n", formatInlineDataThreshold(svv.InlineDataThreshold)))
+	sb.WriteString(fmt.Sprintf("  Small file threshold            : %v\n", formatSmallFileThreshold(svv.SmallFileThreshold)))
+	sb.WriteString(fmt.Sprintf("  Consistency mode                : %v\n", formatConsistencyMode(svv.ConsistencyMode)))
+	sb.WriteString(fmt.Sprintf("  Meta witness num                : %v\n", svv.MpWitnessNum))
 	sb.WriteString(fmt.Sprintf("  Maximally Read                  : %v\n", formatEnabledDisabled(svv.MaximallyRead)))
 	sb.WriteString(fmt.Sprintf("  Inode count                     : %v\n", svv.InodeCount))
 	sb.WriteString(fmt.Sprintf("  Max metaPartition ID            : %v\n", svv.MaxMetaPartitionID))
@@ -270,6 +744,19 @@ func formatSimpleVolView(svv *proto.SimpleVolView) string {
 	sb.WriteString(fmt.Sprintf("  Tx conflict retry interval(ms)  : %v\n", svv.TxConflictRetryInterval))
 	sb.WriteString(fmt.Sprintf("  Tx limit interval(s)            : %v\n", svv.TxOpLimit))
 	sb.WriteString(fmt.Sprintf("  Forbidden                       : %v\n", svv.Forbidden))
+	sb.WriteString(fmt.Sprintf("  Interop                         : keyMapping(%v) metaSync(%v) renameVisibility(%v)\n",
+		svv.Interop.KeyMapping, svv.Interop.MetaSync, svv.Interop.RenameVisibility))
+	sb.WriteString(fmt.Sprintf("  PlacementExclusion              : %v\n", svv.PlacementExclusion))
+	sb.WriteString(fmt.Sprintf("  PlacementPolicy                 : %v\n", svv.PlacementPolicy))
+	if svv.Published {
+		sb.WriteString(fmt.Sprintf("  Published                       : %v\n", time.Unix(svv.PublishTime, 0).Format(proto.TimeFormat)))
+	}
+	if len(svv.ForkSources) > 0 {
+		sb.WriteString(fmt.Sprintf("  ForkedFrom                      : %v\n", strings.Join(svv.ForkSources, ",")))
+	}
+	if len(svv.ForkTargets) > 0 {
+		sb.WriteString(fmt.Sprintf("  ForkedBy                        : %v\n", strings.Join(svv.ForkTargets, ",")))
+	}
 	sb.WriteString(fmt.Sprintf("  DisableAuditLog                 : %v\n", svv.DisableAuditLog))
 	sb.WriteString(fmt.Sprintf("  TrashInterval                   : %v\n", time.Duration(svv.TrashInterval)*time.Minute))
 	sb.WriteString(fmt.Sprintf("  DpRepairBlockSize               : %v\n", strutil.FormatSize(svv.DpRepairBlockSize)))
@@ -307,9 +794,17 @@ func formatSimpleVolView(svv *proto.SimpleVolView) string {
 	sb.WriteString(fmt.Sprintf("  remoteCacheMaxFileSizeGB        : %v G\n", svv.RemoteCacheMaxFileSizeGB))
 	sb.WriteString(fmt.Sprintf("  remoteCacheOnlyForNotSSD        : %v\n", svv.RemoteCacheOnlyForNotSSD))
 	sb.WriteString(fmt.Sprintf("  remoteCacheMultiRead            : %v\n", svv.RemoteCacheMultiRead))
+	sb.WriteString(fmt.Sprintf("  remoteCacheAlwaysAdmit          : %v\n", svv.RemoteCacheAlwaysAdmit))
+	sb.WriteString(fmt.Sprintf("  remoteCacheAuth                 : %v\n", svv.RemoteCacheAuth))
+	sb.WriteString(fmt.Sprintf("  remoteCacheReadAheadMB          : %v MB\n", svv.RemoteCacheReadAheadMB))
+	sb.WriteString(fmt.Sprintf("  remoteCacheMetaTTL              : %v s\n", svv.RemoteCacheMetaTTL))
+	sb.WriteString(fmt.Sprintf("  remoteCacheReadLimitMBps        : %v MB/s\n", svv.RemoteCacheReadLimitMBps))
+	sb.WriteString(fmt.Sprintf("  remoteCacheReadLimitIops        : %v\n", svv.RemoteCacheReadLimitIops))
 	sb.WriteString(fmt.Sprintf("  flashNodeTimeoutCount           : %v\n", svv.FlashNodeTimeoutCount))
 	sb.WriteString(fmt.Sprintf("  remoteCacheSameZoneTimeout      : %v\n", svv.RemoteCacheSameZoneTimeout))
 	sb.WriteString(fmt.Sprintf("  remoteCacheSameRegionTimeout    : %v\n", svv.RemoteCacheSameRegionTimeout))
+	sb.WriteString(fmt.Sprintf("  remoteCacheHedgeDelay           : %v ms\n", svv.RemoteCacheHedgeDelay))
+	sb.WriteString(fmt.Sprintf("  remoteCacheFallbackTimeout      : %v ms\n", svv.RemoteCacheFallbackTimeout))
 
 	// qos of volume
 	sb.WriteString(fmt.Sprintf("  QosEnable                       : %v\n", svv.QosInfo.QosEnable))
@@ -662,6 +1157,7 @@ func formatDataPartitionInfo(partition *proto.DataPartitionInfo) string {
 	sb.WriteString(fmt.Sprintf("Forbidden     : %v\n", partition.Forbidden))
 	sb.WriteString(fmt.Sprintf("MediaType     : %v\n", proto.MediaTypeString(partition.MediaType)))
 	sb.WriteString(fmt.Sprintf("ForbidWriteOpOfProtoVer0 : %v\n", partition.ForbidWriteOpOfProtoVer0))
+	sb.WriteString(fmt.Sprintf("ReadQps       : %v\n", partition.ReadQps))
 	sb.WriteString("\n")
 	sb.WriteString("Replicas : \n")
 	sb.WriteString(fmt.Sprintf("%v\n", formatDataReplicaTableHeader()))
@@ -676,6 +1172,16 @@ func formatDataPartitionInfo(partition *proto.DataPartitionInfo) string {
 		}
 	}
 
+	if len(partition.ReadReplicas) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString("ReadReplicas : \n")
+		sb.WriteString(fmt.Sprintf(readReplicaTableRowPattern, "ADDR", "READY", "READQPS", "CREATE TIME", "REPORT TIME"))
+		for _, rr := range partition.ReadReplicas {
+			sb.WriteString(fmt.Sprintf(readReplicaTableRowPattern, rr.Addr, rr.Ready, rr.ReadQps,
+				formatTime(rr.CreateTime), formatTime(rr.ReportTime)))
+		}
+	}
+
 	sb.WriteString("\n")
 	sb.WriteString("FileInCoreMap : \n")
 	sb.WriteString(fmt.Sprintf("%v\n", formatDataFileInCoreTableHeader()))
@@ -731,6 +1237,8 @@ func formatMetaPartitionInfo(partition *proto.MetaPartitionInfo) string {
 	sb.WriteString(fmt.Sprintf("Forbidden     : %v\n", partition.Forbidden))
 	sb.WriteString(fmt.Sprintf("Freeze        : %v\n", formatMetaPartitionFreeze(partition.Freeze)))
 	sb.WriteString(fmt.Sprintf("ForbidWriteOpOfProtoVer0 : %v\n", partition.ForbidWriteOpOfProtoVer0))
+	sb.WriteString(fmt.Sprintf("Qps           : %v\n", partition.Qps))
+	sb.WriteString(fmt.Sprintf("MemUsed       : %v\n", formatSize(partition.MemUsed)))
 	sb.WriteString("\n")
 	sb.WriteString("Replicas : \n")
 	sb.WriteString(fmt.Sprintf("%v\n", formatMetaReplicaTableHeader()))
@@ -891,6 +1399,27 @@ func formatEnabledDisabled(b bool) string {
 	return "Disabled"
 }
 
+func formatInlineDataThreshold(threshold int64) string {
+	if threshold == 0 {
+		return "Disabled"
+	}
+	return formatSize(uint64(threshold))
+}
+
+func formatSmallFileThreshold(threshold int64) string {
+	if threshold == 0 {
+		return "Disabled"
+	}
+	return formatSize(uint64(threshold))
+}
+
+func formatConsistencyMode(mode string) string {
+	if mode == "" {
+		return proto.ConsistencyModeRelaxed
+	}
+	return mode
+}
+
 func formatNodeStatus(status bool) string {
 	if status {
 		return "Active"
@@ -898,6 +1427,13 @@ func formatNodeStatus(status bool) string {
 	return "Inactive"
 }
 
+func formatNodeHealth(score int, poor bool) string {
+	if poor {
+		return fmt.Sprintf("%v (poor)", score)
+	}
+	return strconv.Itoa(score)
+}
+
 func formatNodeOfflineStatus(status bool) string {
 	if status {
 		return "True"
@@ -945,6 +1481,8 @@ func formatTimeToString(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")
 }
 
+var readReplicaTableRowPattern = "%-24v    %-6v    %-8v    %-19v    %v\n"
+
 var dataReplicaTableRowPattern = "%-65v    %-12v    %-12v    %-12v    %-12v    %-12v    %-12v    %-12v    %-12v    %-18v    %-10v"
 
 func formatDataReplicaTableHeader() string {
@@ -1081,6 +1619,7 @@ func formatDataNodeDetail(dn *proto.DataNodeInfo, rowTable bool) string {
 	sb.WriteString(fmt.Sprintf("  Zone                      : %v\n", dn.ZoneName))
 	sb.WriteString(fmt.Sprintf("  Rdonly                    : %v\n", dn.RdOnly))
 	sb.WriteString(fmt.Sprintf("  Status                    : %v\n", formatNodeStatus(dn.IsActive)))
+	sb.WriteString(fmt.Sprintf("  Health score              : %v\n", formatNodeHealth(dn.HealthScore, dn.PoorHealth)))
 	sb.WriteString(fmt.Sprintf("  MediaType                 : %v\n", proto.MediaTypeString(dn.MediaType)))
 	sb.WriteString(fmt.Sprintf("  ToBeOffline               : %v\n", formatNodeOfflineStatus(dn.ToBeOffline)))
 	sb.WriteString(fmt.Sprintf("  Report time               : %v\n", formatTimeToString(dn.ReportTime)))
@@ -1095,6 +1634,8 @@ func formatDataNodeDetail(dn *proto.DataNodeInfo, rowTable bool) string {
 	sb.WriteString(fmt.Sprintf("  Can alloc partition       : %v\n", dn.CanAllocPartition))
 	sb.WriteString(fmt.Sprintf("  Max partition count       : %v\n", dn.MaxDpCntLimit))
 	sb.WriteString(fmt.Sprintf("  CpuUtil                   : %.1f%%\n", dn.CpuUtil))
+	sb.WriteString(fmt.Sprintf("  Resource throttle         : %v\n", dn.ResourceThrottle))
+	sb.WriteString(fmt.Sprintf("  Load progress             : %v\n", dn.LoadProgress))
 	sb.WriteString("  IoUtils             :\n")
 	for device, used := range dn.IoUtils {
 		sb.WriteString(fmt.Sprintf("                        %v:%.1f%%\n", device, used))
@@ -1171,6 +1712,7 @@ func formatMetaNodeDetail(mn *proto.MetaNodeInfo, rowTable bool) string {
 	sb.WriteString(fmt.Sprintf("  Total               : %v\n", formatSize(mn.Total)))
 	sb.WriteString(fmt.Sprintf("  Zone                : %v\n", mn.ZoneName))
 	sb.WriteString(fmt.Sprintf("  Status              : %v\n", formatNodeStatus(mn.IsActive)))
+	sb.WriteString(fmt.Sprintf("  Health score        : %v\n", formatNodeHealth(mn.HealthScore, mn.PoorHealth)))
 	sb.WriteString(fmt.Sprintf("  Rdonly              : %v\n", mn.RdOnly))
 	sb.WriteString(fmt.Sprintf("  Report time         : %v\n", formatTimeToString(mn.ReportTime)))
 	sb.WriteString(fmt.Sprintf("  Partition count     : %v\n", mn.MetaPartitionCount))
@@ -1178,6 +1720,7 @@ func formatMetaNodeDetail(mn *proto.MetaNodeInfo, rowTable bool) string {
 	sb.WriteString(fmt.Sprintf("  Can alloc partition : %v\n", mn.CanAllowPartition))
 	sb.WriteString(fmt.Sprintf("  Max partition count : %v\n", mn.MaxMpCntLimit))
 	sb.WriteString(fmt.Sprintf("  CpuUtil             : %.1f%%\n", mn.CpuUtil))
+	sb.WriteString(fmt.Sprintf("  Resource throttle   : %v\n", mn.ResourceThrottle))
 	return sb.String()
 }
 
@@ -1227,6 +1770,7 @@ func formatZoneView(zv *proto.ZoneView) string {
 	sb.WriteString(fmt.Sprintf("Zone Name:        %v\n", zv.Name))
 	sb.WriteString(fmt.Sprintf("Status:           %v\n", zv.Status))
 	sb.WriteString(fmt.Sprintf("DataMediaType:    %v\n", zv.DataMediaType))
+	sb.WriteString(fmt.Sprintf("Isolation:        %v\n", zv.Isolation))
 	sb.WriteString("Nodeset Selector:\n")
 	sb.WriteString(fmt.Sprintf("       Data:%v\n", zv.DataNodesetSelector))
 	sb.WriteString(fmt.Sprintf("       Meta:%v\n", zv.MetaNodesetSelector))
@@ -1327,6 +1871,24 @@ func formatDiskDataPartitionTableRow(view *proto.DataPartitionReport) string {
 		formatSize(view.Total), formatSize(view.Used), view.IsLeader, view.ExtentCount, view.NeedCompare, view.DecommissionRepairProgress)
 }
 
+var dataNodeLoadProgressTableRowPattern = "%-24v    %-20v    %-11v    %-9v    %-9v    %-10v"
+
+func formatDataNodeLoadProgressTableHeader() string {
+	return fmt.Sprintf(dataNodeLoadProgressTableRowPattern, "ADDRESS", "START TIME", "PARTITIONS", "LOADED", "VERIFYING", "LOAD TIME")
+}
+
+func formatDataNodeLoadProgress(p *proto.DataNodeLoadProgress) string {
+	startTime, loadTime := "", "loading"
+	if p.StartTime != 0 {
+		startTime = time.Unix(p.StartTime, 0).Format(proto.TimeFormat)
+	}
+	if p.Done() {
+		loadTime = (time.Duration(p.FinishTime-p.StartTime) * time.Second).String()
+	}
+	return fmt.Sprintf(dataNodeLoadProgressTableRowPattern, p.Addr, startTime, p.Partitions, p.LoadedPartitions,
+		p.VerifyingPartitions, loadTime)
+}
+
 func formatDataNodeDecommissionProgress(progress *proto.DataDecommissionProgress) string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("Status     :         %v\n", progress.StatusMessage))
@@ -1348,6 +1910,42 @@ func formatDataNodeDecommissionProgress(progress *proto.DataDecommissionProgress
 	return sb.String()
 }
 
+func formatDecommissionNodeProgress(progress *proto.DecommissionNodeProgress) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Status     :         %v\n", progress.StatusMessage))
+	sb.WriteString(fmt.Sprintf("Total      :         %v\n", progress.TotalPartitions))
+	sb.WriteString(fmt.Sprintf("Migrated   :         %v\n", progress.MigratedPartitions))
+	sb.WriteString(fmt.Sprintf("Remaining  :         %v\n", progress.RemainingPartitions))
+	sb.WriteString(fmt.Sprintf("Running    :         %v\n", progress.RunningPartitions))
+	sb.WriteString(fmt.Sprintf("Failed     :         %v\n", progress.FailedPartitions))
+	sb.WriteString(formatDecommissionNodeEstimate(progress))
+	return sb.String()
+}
+
+func formatDecommissionNodeEstimate(progress *proto.DecommissionNodeProgress) string {
+	sb := strings.Builder{}
+	if progress.StartTime > 0 {
+		sb.WriteString(fmt.Sprintf("StartTime  :         %v\n", time.Unix(progress.StartTime, 0).Format(proto.TimeFormat)))
+	}
+	if progress.NodeType == proto.DataNode {
+		sb.WriteString(fmt.Sprintf("DataMoved  :         %v\n", strutil.FormatSize(progress.MigratedBytes)))
+		sb.WriteString(fmt.Sprintf("DataLeft   :         %v\n", strutil.FormatSize(progress.RemainingBytes)))
+		sb.WriteString(fmt.Sprintf("Bandwidth  :         %vMB/s\n", progress.BandwidthMBps))
+		throttle := "unlimited"
+		if progress.ThrottleMBps > 0 {
+			throttle = fmt.Sprintf("%vMB/s", progress.ThrottleMBps)
+		}
+		sb.WriteString(fmt.Sprintf("Throttle   :         %v\n", throttle))
+	}
+	eta := "unknown"
+	if progress.EstimatedSeconds > 0 {
+		eta = fmt.Sprintf("%v (in %v)", time.Unix(progress.EstimatedCompleteTime, 0).Format(proto.TimeFormat),
+			time.Duration(progress.EstimatedSeconds)*time.Second)
+	}
+	sb.WriteString(fmt.Sprintf("ETA        :         %v\n", eta))
+	return sb.String()
+}
+
 func formatDecommissionProgress(progress *proto.DecommissionProgress) string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("Status     :         %v\n", progress.StatusMessage))
@@ -1482,6 +2080,10 @@ var (
 	formatFlashNodeSimpleViewTableTitle = arow("Zone", "ID", "Address", "Active", "Enable", "FlashGroupID", "ReportTime")
 	formatFlashNodeViewTableTitle       = append(formatFlashNodeSimpleViewTableTitle[:], "DataPath", "HitRate", "Evicts", "Limit", "MaxAlloc", "HasAlloc", "Num", "Status")
 	formatFlashGroupViewTile            = arow("ID", "Weight", "Slots", "Status", "SlotStatus", "PendingSlots", "Step", "FlashNodeCount")
+	formatFlashGroupScaleEventTitle     = arow("Time", "FlashGroupID", "Action", "FlashNode", "Zone", "NodeCount", "HitRate", "Usage", "Reason")
+	formatFlashGroupAuditTitle          = arow("Time", "FlashGroupID", "Op", "Operator", "Args", "Result")
+	formatFlashGroupCacheKeyTitle       = arow("FlashGroupID", "FlashNode", "Volume", "Inode", "Offset", "Size", "Hits", "Age", "Expire", "Hot")
+	formatFlashGroupCachePurgeTitle     = arow("FlashGroupID", "FlashNode", "Purged", "FailedKeys", "Error")
 	QosHeader                           = fmt.Sprintf(qosPattern, "NAME", "TOTAL-MB", "USED-MB")
 )
 
@@ -1499,10 +2101,91 @@ func formatFlashNodeView(fn *proto.FlashNodeViewInfo) string {
 		arow("  FlashGroupID", fn.FlashGroupID),
 		arow("  ReportTime", formatTimeToString(fn.ReportTime)),
 		arow("  IsActive", fn.IsActive),
+		arow("  HealthScore", formatNodeHealth(fn.HealthScore, fn.PoorHealth)),
 		arow("  IsEnable", fn.IsEnable),
+		arow("  ReadOnly", fn.ReadOnly),
+		arow("  CacheCapacity", formatSize(uint64(fn.CacheCapacity))),
+		arow("  CacheStat", formatFlashNodeCacheStat(fn.CacheStat)),
+		arow("  CacheRecovery", formatFlashNodeCacheRecovery(fn.CacheRecovery)),
 	)
 }
 
+func formatFlashNodeCacheRecovery(recovery *proto.FlashNodeCacheRecovery) string {
+	if recovery == nil {
+		return "not reported"
+	}
+	return fmt.Sprintf("%v blocks (%v, %v from the index), %v dropped, at %v cost %vms",
+		recovery.Blocks, formatSize(uint64(recovery.Bytes)), recovery.Indexed, recovery.Dropped,
+		formatTimeToString(time.Unix(recovery.Time, 0)), recovery.CostMs)
+}
+
+func formatFlashNodeCacheStat(stat *proto.FlashNodeCacheStat) string {
+	if stat == nil {
+		return "not reported"
+	}
+	return fmt.Sprintf("hitRate %.2f%% (hits %v, misses %v in the last minute), evicts %v, occupied %v, objects %v",
+		stat.HitRate*100, stat.Hits, stat.Misses, stat.Evicts, formatSize(uint64(stat.OccupiedBytes)), stat.ObjectCount)
+}
+
+var formatFlashNodeStatsTableTitle = arow("Zone", "Address", "FlashGroupID", "Active", "Enable", "Capacity", "Occupied", "Objects", "HitRate", "Hits", "Misses", "Evicts")
+
+func formatFlashNodeStats(view *proto.FlashNodeStatsView) string {
+	tbl := table{formatFlashNodeStatsTableTitle}
+	for _, fn := range view.FlashNodes {
+		if fn.CacheStat == nil {
+			tbl = append(tbl, arow(fn.ZoneName, fn.Addr, fn.FlashGroupID, fn.IsActive, fn.IsEnable,
+				formatSize(uint64(fn.CacheCapacity)), "-", "-", "-", "-", "-", "-"))
+			continue
+		}
+		stat := fn.CacheStat
+		tbl = append(tbl, arow(fn.ZoneName, fn.Addr, fn.FlashGroupID, fn.IsActive, fn.IsEnable,
+			formatSize(uint64(fn.CacheCapacity)), formatSize(uint64(stat.OccupiedBytes)), stat.ObjectCount,
+			fmt.Sprintf("%.2f%%", stat.HitRate*100), stat.Hits, stat.Misses, stat.Evicts))
+	}
+	return alignTable(tbl...) + "\n[Total of the active flash nodes]\n  " + formatFlashNodeCacheStat(&view.Total)
+}
+
+var formatFlashManualTasksTableTitle = arow("ID", "Volume", "Status", "Target", "FlashNode", "Files", "Cached", "Errors", "CachedSize", "StartTime")
+
+func formatFlashManualTasks(tasks []*proto.FlashManualTask) string {
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].StartTime == nil || tasks[j].StartTime == nil {
+			return tasks[i].Id < tasks[j].Id
+		}
+		return tasks[i].StartTime.Before(*tasks[j].StartTime)
+	})
+	tbl := table{formatFlashManualTasksTableTitle}
+	for _, t := range tasks {
+		config := t.ManualTaskConfig
+		target := "/" + t.GetPathPrefix()
+		if len(config.Inodes) > 0 {
+			target = fmt.Sprintf("%v inodes", len(config.Inodes))
+		}
+		if config.Offset != 0 || config.Size != 0 {
+			end := "EOF"
+			if config.Size != 0 {
+				end = strconv.FormatUint(config.Offset+config.Size, 10)
+			}
+			target += fmt.Sprintf(" [%v, %v)", config.Offset, end)
+		}
+		flashNode, files, cached, errs, size := "-", "-", "-", "-", "-"
+		if stat := t.ManualTaskStatistics; stat != nil {
+			flashNode = stat.FlashNode
+			files = strconv.FormatInt(stat.TotalFileScannedNum, 10)
+			cached = strconv.FormatInt(stat.TotalFileCachedNum, 10)
+			errs = strconv.FormatInt(stat.ErrorCacheNum, 10)
+			size = formatSize(uint64(stat.TotalCacheSize))
+		}
+		startTime := "-"
+		if t.StartTime != nil {
+			startTime = formatTimeToString(*t.StartTime)
+		}
+		tbl = append(tbl, arow(t.Id, t.VolName, proto.ManualTaskStatus(t.Status), target, flashNode,
+			files, cached, errs, size, startTime))
+	}
+	return alignTable(tbl...)
+}
+
 func formatFlashGroupView(fg *proto.FlashGroupAdminView) string {
 	return "[FlashGroup]\n" +
 		fmt.Sprintf("  ID:%v\n", fg.ID) +
@@ -1512,7 +2195,99 @@ func formatFlashGroupView(fg *proto.FlashGroupAdminView) string {
 		fmt.Sprintf("  SlotStatus:%v\n", fg.SlotStatus) +
 		fmt.Sprintf("  PedningSlots:%v\n", fg.PendingSlots) +
 		fmt.Sprintf("  Step:%v\n", fg.Step) +
-		fmt.Sprintf("  FlashNodeCount:%v\n", fg.FlashNodeCount)
+		fmt.Sprintf("  FlashNodeCount:%v\n", fg.FlashNodeCount) +
+		fmt.Sprintf("  CacheCapacity:%v\n", formatSize(uint64(fg.CacheCapacity))) +
+		fmt.Sprintf("  CacheStat:%v\n", formatFlashNodeCacheStat(&fg.CacheStat)) +
+		fmt.Sprintf("  AutoScale:%v\n", formatFlashGroupAutoScale(fg)) +
+		fmt.Sprintf("  AutoHeal:%v\n", formatFlashGroupAutoHeal(fg)) +
+		fmt.Sprintf("  DrainDeadline:%v\n", formatFlashGroupDrainDeadline(fg)) +
+		fmt.Sprintf("  Volumes:%v\n", formatFlashGroupVolumes(fg))
+}
+
+func formatFlashGroupVolumes(fg *proto.FlashGroupAdminView) string {
+	if len(fg.Volumes) == 0 {
+		return "shared"
+	}
+	return strings.Join(fg.Volumes, ",")
+}
+
+func formatFlashGroupDrainDeadline(fg *proto.FlashGroupAdminView) string {
+	if fg.Status != proto.FlashGroupStatus_Draining {
+		return "-"
+	}
+	return formatTime(fg.DrainDeadline)
+}
+
+var flashGroupRebalanceTablePattern = "    %-12v    %-16v    %-8v    %v\n"
+
+func formatFlashGroupSlotsRebalance(shares []*proto.FlashGroupSlotsRebalance) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(flashGroupRebalanceTablePattern, "ID", "CACHE CAPACITY", "SLOTS", "TARGET"))
+	for _, share := range shares {
+		target := strconv.Itoa(share.TargetSlots)
+		if share.TargetSlots == share.Slots {
+			target = "-"
+		}
+		sb.WriteString(fmt.Sprintf(flashGroupRebalanceTablePattern, share.FlashGroupID,
+			formatSize(uint64(share.CacheCapacity)), share.Slots, target))
+	}
+	return sb.String()
+}
+
+var flashGroupSlotsPreviewTablePattern = "    %-12v    %v\n"
+
+func formatFlashGroupSlotsPreview(preview *proto.FlashGroupSlotsPreview) string {
+	ids := make([]uint64, 0, len(preview.GroupSlots))
+	for id := range preview.GroupSlots {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(flashGroupSlotsPreviewTablePattern, "ID", "SLOTS"))
+	for _, id := range ids {
+		name := strconv.FormatUint(id, 10)
+		if id == 0 {
+			name = "new"
+		}
+		sb.WriteString(fmt.Sprintf(flashGroupSlotsPreviewTablePattern, name, preview.GroupSlots[id]))
+	}
+	sb.WriteString(fmt.Sprintf("  Added slots       : %v\n", formatSlots(preview.AddedSlots)))
+	sb.WriteString(fmt.Sprintf("  Removed slots     : %v\n", formatSlots(preview.RemovedSlots)))
+	sb.WriteString(fmt.Sprintf("  Remapped keyspace : %.2f%%\n", preview.RemappedPercent))
+	return sb.String()
+}
+
+func formatSlots(slots []uint32) string {
+	if len(slots) == 0 {
+		return "-"
+	}
+	strs := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		strs = append(strs, strconv.FormatUint(uint64(slot), 10))
+	}
+	return strings.Join(strs, ",")
+}
+
+func formatFlashGroupAutoHeal(fg *proto.FlashGroupAdminView) string {
+	if fg.AutoHealMinHealthy == 0 {
+		return "off"
+	}
+	cooldown := "default cooldown"
+	if fg.AutoHealCooldown > 0 {
+		cooldown = fmt.Sprintf("cooldown %vs", fg.AutoHealCooldown)
+	}
+	s := fmt.Sprintf("min %v healthy flashnodes, %v", fg.AutoHealMinHealthy, cooldown)
+	if len(fg.AutoHealExcludeHosts) > 0 {
+		s += fmt.Sprintf(", exclude %v", strings.Join(fg.AutoHealExcludeHosts, ","))
+	}
+	return s
+}
+
+func formatFlashGroupAutoScale(fg *proto.FlashGroupAdminView) string {
+	if fg.AutoScaleMax == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("%v-%v flashnodes", fg.AutoScaleMin, fg.AutoScaleMax)
 }
 
 var (
@@ -1533,6 +2308,23 @@ func formatFlashNodeSlotStat(stat *proto.FlashNodeSlotStat) string {
 	return sb.String()
 }
 
+var flashnodeCacheKeysTablePattern = "%-20v    %-12v    %-12v    %-10v    %-10v    %-10v    %-8v    %-6v    %-5v    %v\n"
+
+func formatFlashNodeCacheKeys(keys *proto.FlashNodeCacheKeys) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(flashnodeCacheKeysTablePattern,
+		"Volume", "Inode", "Offset", "Size", "Age", "Expire", "Hits", "Ready", "Hot", "DataPath"))
+	for _, key := range keys.Keys {
+		sb.WriteString(fmt.Sprintf(flashnodeCacheKeysTablePattern, key.Volume, key.Inode, key.Offset,
+			formatSize(uint64(key.Size)), time.Duration(key.AgeSec)*time.Second,
+			time.Duration(key.ExpireSec)*time.Second, key.Hits, key.Ready, key.Hot, key.DataPath))
+	}
+	if keys.Total > len(keys.Keys) {
+		sb.WriteString(fmt.Sprintf("listed %v of %v blocks\n", len(keys.Keys), keys.Total))
+	}
+	return sb.String()
+}
+
 func formatMetaPartitionFreeze(freeze int8) string {
 	switch freeze {
 	case proto.FreezeMetaPartitionInit:
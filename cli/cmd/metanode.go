@@ -40,6 +40,7 @@ func newMetaNodeCmd(client *master.MasterClient) *cobra.Command {
 		newMetaNodeDecommissionCmd(client),
 		newMetaNodeMigrateCmd(client),
 		newMetaNodeOfflineCmd(client),
+		newMetaNodeQueryDecommissionProgress(client),
 	)
 	return cmd
 }
@@ -50,9 +51,11 @@ const (
 	cmdMetaNodeDecommissionInfoShort = "Decommission partitions in a meta node to other nodes"
 	cmdMetaNodeMigrateInfoShort      = "Migrate partitions from a meta node to the other node"
 	cmdMetaNodeOfflineInfoShort      = "Offline meta node in background"
+	cmdMetaNodeQueryProgressShort    = "Show the partitions migrated and left, the bandwidth and the estimated completion of the meta node decommission"
 )
 
 func newMetaNodeListCmd(client *master.MasterClient) *cobra.Command {
+	var opt master.ListOption
 	var optFilterStatus string
 	var optFilterWritable string
 	cmd := &cobra.Command{
@@ -65,7 +68,7 @@ func newMetaNodeListCmd(client *master.MasterClient) *cobra.Command {
 				errout(err)
 			}()
 			var metaNodes []proto.NodeView
-			if metaNodes, err = client.AdminAPI().GetClusterMetaNodes(); err != nil {
+			if metaNodes, err = client.AdminAPI().GetClusterMetaNodesWithOption(opt); err != nil {
 				return
 			}
 			sort.SliceStable(metaNodes, func(i, j int) bool {
@@ -88,6 +91,7 @@ func newMetaNodeListCmd(client *master.MasterClient) *cobra.Command {
 	}
 	cmd.Flags().StringVar(&optFilterWritable, "filter-writable", "", "Filter node writable status")
 	cmd.Flags().StringVar(&optFilterStatus, "filter-status", "", "Filter status [Active, Inactive")
+	addListOptionFlags(cmd, &opt, "active, inactive")
 	return cmd
 }
 
@@ -157,6 +161,34 @@ func newMetaNodeDecommissionCmd(client *master.MasterClient) *cobra.Command {
 	return cmd
 }
 
+func newMetaNodeQueryDecommissionProgress(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpQueryProgress + " [{HOST}:{PORT}]",
+		Short: cmdMetaNodeQueryProgressShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err      error
+				progress *proto.DecommissionNodeProgress
+			)
+			defer func() {
+				errout(err)
+			}()
+			if progress, err = client.NodeAPI().QueryDecommissionProgress(args[0]); err != nil {
+				return
+			}
+			stdout("%v", formatDecommissionNodeProgress(progress))
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return validMetaNodes(client, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	return cmd
+}
+
 func newMetaNodeMigrateCmd(client *master.MasterClient) *cobra.Command {
 	var (
 		optCount    int
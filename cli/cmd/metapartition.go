@@ -39,6 +39,7 @@ func newMetaPartitionCmd(client *master.MasterClient) *cobra.Command {
 		newMetaPartitionDecommissionCmd(client),
 		newMetaPartitionReplicateCmd(client),
 		newMetaPartitionDeleteReplicaCmd(client),
+		newMetaPartitionSplitAtCmd(client),
 	)
 	return cmd
 }
@@ -49,6 +50,7 @@ const (
 	cmdMetaPartitionDecommissionShort  = "Decommission a replication of the meta partition to a new address"
 	cmdMetaPartitionReplicateShort     = "Add a replication of the meta partition on a new address"
 	cmdMetaPartitionDeleteReplicaShort = "Delete a replication of the meta partition on a fixed address"
+	cmdMetaPartitionSplitAtShort       = "Split a meta partition, the inodes over the given inode id go to a new meta partition"
 )
 
 func newMetaPartitionGetCmd(client *master.MasterClient) *cobra.Command {
@@ -360,3 +362,42 @@ func newMetaPartitionDeleteReplicaCmd(client *master.MasterClient) *cobra.Comman
 	cmd.Flags().StringVar(&clientIDKey, CliFlagClientIDKey, client.ClientIDKey(), CliUsageClientIDKey)
 	return cmd
 }
+
+func newMetaPartitionSplitAtCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpSplitAt + " [VOLUME] [META PARTITION ID] [INODE ID]",
+		Short: cmdMetaPartitionSplitAtShort,
+		Long: `Split a meta partition of the volume at the inode id, which must be over the max
+inode id of the partition. The inodes created over it go to a new meta partition, the
+rest of the inode range of the partition if it is not the last one.`,
+		Args: cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				err         error
+				partitionID uint64
+				at          uint64
+				msg         string
+			)
+			defer func() {
+				errout(err)
+			}()
+			if partitionID, err = strconv.ParseUint(args[1], 10, 64); err != nil {
+				return
+			}
+			if at, err = strconv.ParseUint(args[2], 10, 64); err != nil {
+				return
+			}
+			if msg, err = client.AdminAPI().SplitMetaPartitionAt(args[0], partitionID, at); err != nil {
+				return
+			}
+			stdout("%v\n", msg)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return validVols(client, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	return cmd
+}
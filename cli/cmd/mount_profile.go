@@ -0,0 +1,135 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/master"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cmdMountProfileUse   = "mountprofile [COMMAND]"
+	cmdMountProfileShort = "Manage the client mount profiles kept by master"
+)
+
+func newMountProfileCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdMountProfileUse,
+		Short: cmdMountProfileShort,
+	}
+	cmd.AddCommand(
+		newMountProfileSetCmd(client),
+		newMountProfileInfoCmd(client),
+		newMountProfileListCmd(client),
+		newMountProfileDeleteCmd(client),
+	)
+	return cmd
+}
+
+const (
+	cmdMountProfileSetUse      = "set [NAME]"
+	cmdMountProfileSetShort    = "Create a mount profile or replace all the options of it"
+	cmdMountProfileInfoUse     = "info [NAME]"
+	cmdMountProfileInfoShort   = "Show a mount profile"
+	cmdMountProfileListShort   = "List mount profiles"
+	cmdMountProfileDeleteUse   = "delete [NAME]"
+	cmdMountProfileDeleteShort = "Delete a mount profile"
+)
+
+func newMountProfileSetCmd(client *master.MasterClient) *cobra.Command {
+	var options map[string]string
+	cmd := &cobra.Command{
+		Use:   cmdMountProfileSetUse,
+		Short: cmdMountProfileSetShort,
+		Long: fmt.Sprintf("%v. Clients mounting with mountProfile=NAME take the options not set in their own config "+
+			"from the profile when they mount.\nOptions: %v", cmdMountProfileSetShort,
+			strings.Join(proto.MountProfileOptionKeys(), ", ")),
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			if err = client.AdminAPI().SetMountProfile(args[0], options); err != nil {
+				return
+			}
+			stdout("Mount profile %v has been set successfully, it takes effect on the next mount of clients.\n", args[0])
+		},
+	}
+	cmd.Flags().StringToStringVarP(&options, "option", "o", nil, "Mount option of the profile, key=value, can be repeated")
+	return cmd
+}
+
+func newMountProfileInfoCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdMountProfileInfoUse,
+		Short: cmdMountProfileInfoShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var profile *proto.MountProfile
+			if profile, err = client.AdminAPI().GetMountProfile(args[0]); err != nil {
+				return
+			}
+			stdout("%v", formatMountProfiles([]*proto.MountProfile{profile}))
+		},
+	}
+	return cmd
+}
+
+func newMountProfileListCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   CliOpList,
+		Short: cmdMountProfileListShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var profiles []*proto.MountProfile
+			if profiles, err = client.AdminAPI().ListMountProfiles(); err != nil {
+				return
+			}
+			stdout("%v", formatMountProfiles(profiles))
+		},
+	}
+	return cmd
+}
+
+func newMountProfileDeleteCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdMountProfileDeleteUse,
+		Short: cmdMountProfileDeleteShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			if err = client.AdminAPI().DeleteMountProfile(args[0]); err != nil {
+				return
+			}
+			stdout("Mount profile %v has been deleted.\n", args[0])
+		},
+	}
+	return cmd
+}
@@ -83,6 +83,9 @@ func NewRootCmd(client *master.MasterClient) *CubeFSCmd {
 		newFlashNodeCmd(client),
 		newFlashGroupCmd(client),
 		newBalanceCmd(client),
+		newAuditCmd(client),
+		newMountProfileCmd(client),
+		newClientEvictionCmd(client),
 	)
 	return cmd
 }
@@ -111,3 +114,14 @@ func errout(err error) {
 	log.LogFlush()
 	os.Exit(1)
 }
+
+// addListOptionFlags adds the flags of the page and the zone of a list command,
+// and of the status if the statuses listed are given.
+func addListOptionFlags(cmd *cobra.Command, opt *master.ListOption, statuses string) {
+	cmd.Flags().IntVar(&opt.Offset, "offset", 0, "Skip the first items of the list")
+	cmd.Flags().IntVar(&opt.Limit, "limit", 0, "List no more than the items, 0 for all")
+	cmd.Flags().StringVar(&opt.Zone, "zone", "", "Filter the items in the zone")
+	if statuses != "" {
+		cmd.Flags().StringVar(&opt.Status, "status", "", fmt.Sprintf("Filter the items of the status [%v]", statuses))
+	}
+}
@@ -129,7 +129,10 @@ func newVersionDelCmd(client *master.MasterClient) *cobra.Command {
 }
 
 func newVersionStrategyCmd(client *master.MasterClient) *cobra.Command {
-	var optKeyword string
+	var (
+		optKeyword      string
+		optImmutableSec string
+	)
 	cmd := &cobra.Command{
 		Use:     CliFlagVersionSetStrategy,
 		Short:   cmdVersionSetStrategyShort,
@@ -144,11 +147,13 @@ func newVersionStrategyCmd(client *master.MasterClient) *cobra.Command {
 			defer func() {
 				errout(err)
 			}()
-			if err = client.AdminAPI().SetStrategy(args[0], args[1], args[2], args[3], args[4]); err != nil {
+			if err = client.AdminAPI().SetStrategy(args[0], args[1], args[2], args[3], args[4], optImmutableSec); err != nil {
 				return
 			}
 		},
 	}
 	cmd.Flags().StringVar(&optKeyword, "keyword", "", "Specify keyword of volume name to filter")
+	cmd.Flags().StringVar(&optImmutableSec, "immutableSec", "",
+		"Specify the seconds the volume is immutable after each snapshot, 0 to disable")
 	return cmd
 }
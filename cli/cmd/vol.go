@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -58,6 +59,19 @@ func newVolCmd(client *master.MasterClient) *cobra.Command {
 		newVolAddDPCmd(client),
 		newVolAddMPCmd(client),
 		newVolSetForbiddenCmd(client),
+		newVolSetInteropCmd(client),
+		newVolSetPlacementExclusionCmd(client),
+		newVolSetPlacementPolicyCmd(client),
+		newVolSetSLOCmd(client),
+		newVolSLOCmd(client),
+		newVolReclaimCmd(client),
+		newVolBoostReclaimCmd(client),
+		newVolForkCmd(client),
+		newVolUnforkCmd(client),
+		newVolMoveCmd(client),
+		newVolTimelineCmd(client),
+		newVolAcquireFenceCmd(client),
+		newVolFenceCmd(client),
 		newVolSetAuditLogCmd(client),
 		newVolSetTrashIntervalCmd(client),
 		newVolSetDpRepairBlockSize(client),
@@ -75,6 +89,7 @@ const (
 
 func newVolListCmd(client *master.MasterClient) *cobra.Command {
 	var optKeyword string
+	var opt master.ListOption
 	cmd := &cobra.Command{
 		Use:     CliOpList,
 		Short:   cmdVolListShort,
@@ -85,7 +100,7 @@ func newVolListCmd(client *master.MasterClient) *cobra.Command {
 			defer func() {
 				errout(err)
 			}()
-			if vols, err = client.AdminAPI().ListVols(optKeyword); err != nil {
+			if vols, err = client.AdminAPI().ListVolsWithOption(optKeyword, opt); err != nil {
 				return
 			}
 			stdout("%v\n", volumeInfoTableHeader)
@@ -95,6 +110,8 @@ func newVolListCmd(client *master.MasterClient) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&optKeyword, "keyword", "", "Specify keyword of volume name to filter")
+	addListOptionFlags(cmd, &opt, "normal, markDelete")
+	cmd.Flags().StringVar(&opt.Owner, "owner", "", "Filter the volumes of the owner")
 	return cmd
 }
 
@@ -354,6 +371,12 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 	var optMaximallyRead string
 	var optDirectRead string
 	var optIgnoreTinyRecover string
+	var optSyncMirrorWrite string
+	var optRequireTLS string
+	var optInlineDataThreshold int64
+	var optSmallFileThreshold int64
+	var optConsistencyMode string
+	var optMpWitnessNum int
 	var optEbsBlkSize int
 	var optDpReadOnlyWhenVolFull string
 	var clientIDKey string
@@ -365,9 +388,17 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 	var optRemoteCacheMaxFileSizeGB int64
 	var optRemoteCacheOnlyForNotSSD string
 	var optRemoteCacheFollowerRead string
+	var optRemoteCacheAlwaysAdmit string
+	var optRemoteCacheAuth string
+	var optRemoteCacheReadAheadMB int64
+	var optRemoteCacheMetaTTL int64
+	var optRemoteCacheReadLimitMBps int64
+	var optRemoteCacheReadLimitIops int64
 	var optFlashNodeTimeoutCount int64
 	var optRemoteCacheSameZoneTimeout int64
 	var optRemoteCacheSameRegionTimeout int64
+	var optRemoteCacheHedgeDelay int64
+	var optRemoteCacheFallbackTimeout int64
 
 	var optYes bool
 	var optTxMask string
@@ -495,6 +526,56 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 				vv.IgnoreTinyRecover = enable
 			}
 
+			if optSyncMirrorWrite != "" {
+				isChange = true
+				var enable bool
+				if enable, err = strconv.ParseBool(optSyncMirrorWrite); err != nil {
+					return
+				}
+				confirmString.WriteString(fmt.Sprintf("  Sync mirror write : %v -> %v\n", formatEnabledDisabled(vv.SyncMirrorWrite), formatEnabledDisabled(enable)))
+				vv.SyncMirrorWrite = enable
+			}
+
+			if optRequireTLS != "" {
+				isChange = true
+				var enable bool
+				if enable, err = strconv.ParseBool(optRequireTLS); err != nil {
+					return
+				}
+				confirmString.WriteString(fmt.Sprintf("  Require TLS : %v -> %v\n", formatEnabledDisabled(vv.RequireTLS), formatEnabledDisabled(enable)))
+				vv.RequireTLS = enable
+			}
+
+			if optInlineDataThreshold >= 0 && optInlineDataThreshold != vv.InlineDataThreshold {
+				isChange = true
+				confirmString.WriteString(fmt.Sprintf("  Inline data threshold : %v -> %v\n", vv.InlineDataThreshold, optInlineDataThreshold))
+				vv.InlineDataThreshold = optInlineDataThreshold
+			}
+
+			if optSmallFileThreshold >= 0 && optSmallFileThreshold != vv.SmallFileThreshold {
+				isChange = true
+				confirmString.WriteString(fmt.Sprintf("  Small file threshold : %v -> %v\n", vv.SmallFileThreshold, optSmallFileThreshold))
+				vv.SmallFileThreshold = optSmallFileThreshold
+			}
+
+			if optConsistencyMode != "" {
+				if !proto.IsValidConsistencyMode(optConsistencyMode) {
+					err = fmt.Errorf("consistencyMode must be %v or %v", proto.ConsistencyModeRelaxed, proto.ConsistencyModeStrict)
+					return
+				}
+				if optConsistencyMode != formatConsistencyMode(vv.ConsistencyMode) {
+					isChange = true
+					confirmString.WriteString(fmt.Sprintf("  Consistency mode : %v -> %v\n", formatConsistencyMode(vv.ConsistencyMode), optConsistencyMode))
+					vv.ConsistencyMode = optConsistencyMode
+				}
+			}
+
+			if optMpWitnessNum >= 0 && optMpWitnessNum != int(vv.MpWitnessNum) {
+				isChange = true
+				confirmString.WriteString(fmt.Sprintf("  Meta witness num : %v -> %v\n", vv.MpWitnessNum, optMpWitnessNum))
+				vv.MpWitnessNum = uint8(optMpWitnessNum)
+			}
+
 			if optCrossZone != "" {
 				isChange = true
 				var enable bool
@@ -828,6 +909,24 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 				return
 			}
 
+			if cmd.Flags().Changed(CliFlagRemoteCacheReadAheadMB) &&
+				(optRemoteCacheReadAheadMB < 0 || optRemoteCacheReadAheadMB > proto.MaxRemoteCacheReadAheadMB) {
+				err = fmt.Errorf("param remoteCacheReadAheadMB(%v) must be 0 to %v", optRemoteCacheReadAheadMB, proto.MaxRemoteCacheReadAheadMB)
+				return
+			}
+			if cmd.Flags().Changed(CliFlagRemoteCacheMetaTTL) &&
+				(optRemoteCacheMetaTTL < 0 || optRemoteCacheMetaTTL > proto.MaxRemoteCacheMetaTTL) {
+				err = fmt.Errorf("param remoteCacheMetaTTL(%v) must be 0 to %v", optRemoteCacheMetaTTL, proto.MaxRemoteCacheMetaTTL)
+				return
+			}
+			if cmd.Flags().Changed(CliFlagRemoteCacheReadLimitMBps) && optRemoteCacheReadLimitMBps < 0 {
+				err = fmt.Errorf("param remoteCacheReadLimitMBps(%v) must not be less than 0", optRemoteCacheReadLimitMBps)
+				return
+			}
+			if cmd.Flags().Changed(CliFlagRemoteCacheReadLimitIops) && optRemoteCacheReadLimitIops < 0 {
+				err = fmt.Errorf("param remoteCacheReadLimitIops(%v) must not be less than 0", optRemoteCacheReadLimitIops)
+				return
+			}
 			if cmd.Flags().Changed(CliFlagFlashNodeTimeoutCount) && optFlashNodeTimeoutCount <= 0 {
 				err = fmt.Errorf("param flashNodeTimeoutCount(%v) must greater than 0", optFlashNodeTimeoutCount)
 				return
@@ -840,6 +939,14 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 				err = fmt.Errorf("param remoteCacheSameRegionTimeout(%v) must greater than 0", optRemoteCacheSameRegionTimeout)
 				return
 			}
+			if cmd.Flags().Changed(CliFlagRemoteCacheHedgeDelay) && optRemoteCacheHedgeDelay <= 0 {
+				err = fmt.Errorf("param remoteCacheHedgeDelay(%v) must greater than 0", optRemoteCacheHedgeDelay)
+				return
+			}
+			if cmd.Flags().Changed(CliFlagRemoteCacheFallbackTimeout) && optRemoteCacheFallbackTimeout <= 0 {
+				err = fmt.Errorf("param remoteCacheFallbackTimeout(%v) must greater than 0", optRemoteCacheFallbackTimeout)
+				return
+			}
 			for _, rcOpt := range []struct {
 				val, opt interface{}
 				name     string
@@ -852,9 +959,17 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 				{&vv.RemoteCacheMaxFileSizeGB, optRemoteCacheMaxFileSizeGB, CliFlagRemoteCacheMaxFileSizeGB},
 				{&vv.RemoteCacheOnlyForNotSSD, optRemoteCacheOnlyForNotSSD, CliFlagRemoteCacheOnlyForNotSSD},
 				{&vv.RemoteCacheMultiRead, optRemoteCacheFollowerRead, CliFlagRemoteCacheMultiRead},
+				{&vv.RemoteCacheAlwaysAdmit, optRemoteCacheAlwaysAdmit, CliFlagRemoteCacheAlwaysAdmit},
+				{&vv.RemoteCacheAuth, optRemoteCacheAuth, CliFlagRemoteCacheAuth},
+				{&vv.RemoteCacheReadAheadMB, optRemoteCacheReadAheadMB, CliFlagRemoteCacheReadAheadMB},
+				{&vv.RemoteCacheMetaTTL, optRemoteCacheMetaTTL, CliFlagRemoteCacheMetaTTL},
+				{&vv.RemoteCacheReadLimitMBps, optRemoteCacheReadLimitMBps, CliFlagRemoteCacheReadLimitMBps},
+				{&vv.RemoteCacheReadLimitIops, optRemoteCacheReadLimitIops, CliFlagRemoteCacheReadLimitIops},
 				{&vv.FlashNodeTimeoutCount, optFlashNodeTimeoutCount, CliFlagFlashNodeTimeoutCount},
 				{&vv.RemoteCacheSameZoneTimeout, optRemoteCacheSameZoneTimeout, CliFlagRemoteCacheSameZoneTimeout},
 				{&vv.RemoteCacheSameRegionTimeout, optRemoteCacheSameRegionTimeout, CliFlagRemoteCacheSameRegionTimeout},
+				{&vv.RemoteCacheHedgeDelay, optRemoteCacheHedgeDelay, CliFlagRemoteCacheHedgeDelay},
+				{&vv.RemoteCacheFallbackTimeout, optRemoteCacheFallbackTimeout, CliFlagRemoteCacheFallbackTimeout},
 			} {
 				if err = checkChangedFlag(rcOpt.val, rcOpt.opt, rcOpt.name); err != nil {
 					return
@@ -901,6 +1016,12 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 	cmd.Flags().StringVar(&optMetaFollowerRead, CliFlagMetaFollowerRead, "", "Enable read form mp follower (true|false, default false)")
 	cmd.Flags().StringVar(&optDirectRead, "directRead", "", "Enable read direct from disk (true|false, default false)")
 	cmd.Flags().StringVar(&optIgnoreTinyRecover, "ignoreTinyRecover", "", "ignore tiny extent recover (true|false, default false)")
+	cmd.Flags().Int64Var(&optInlineDataThreshold, "inlineDataThreshold", -1, "Files not larger are stored in their inode[Unit: byte](0 to disable, at most 4KB)")
+	cmd.Flags().Int64Var(&optSmallFileThreshold, "smallFileThreshold", -1, "Files not larger are packed into the container extents[Unit: byte](0 to disable, at most 1MB)")
+	cmd.Flags().StringVar(&optConsistencyMode, proto.VolConsistencyMode, "", "Visibility of the dentries changed by the other clients (relaxed: cached for a while|strict: seen at once, default relaxed)")
+	cmd.Flags().IntVar(&optMpWitnessNum, proto.VolMetaWitnessNum, -1, "Replicas of the new meta partitions voting without holding data(at most 1 of 3 replicas)")
+	cmd.Flags().StringVar(&optSyncMirrorWrite, "syncMirrorWrite", "", "Complete writes only after a replica in another zone acked, the volume must be cross zone (true|false, default false)")
+	cmd.Flags().StringVar(&optRequireTLS, "requireTLS", "", "Reject the clients not talking to the data and meta nodes over TLS (true|false, default false)")
 	cmd.Flags().StringVar(&optMaximallyRead, CliFlagMaximallyRead, "", "Enable read more hosts (true|false, default false)")
 	cmd.Flags().IntVar(&optEbsBlkSize, CliFlagEbsBlkSize, 0, "Specify ebsBlk Size[Unit: byte]")
 	cmd.Flags().StringVar(&optDpReadOnlyWhenVolFull, CliDpReadOnlyWhenVolFull, "", "Enable volume becomes read only when it is full")
@@ -935,9 +1056,17 @@ func newVolUpdateCmd(client *master.MasterClient) *cobra.Command {
 	cmd.Flags().Int64Var(&optRemoteCacheMaxFileSizeGB, CliFlagRemoteCacheMaxFileSizeGB, 0, "Remote cache max file size[Unit: GB](must > 0)")
 	cmd.Flags().StringVar(&optRemoteCacheOnlyForNotSSD, CliFlagRemoteCacheOnlyForNotSSD, "", "Remote cache only for not ssd(true|false), default false")
 	cmd.Flags().StringVar(&optRemoteCacheFollowerRead, CliFlagRemoteCacheMultiRead, "", "Remote cache follower read(true|false), default true")
+	cmd.Flags().StringVar(&optRemoteCacheAlwaysAdmit, CliFlagRemoteCacheAlwaysAdmit, "", "Remote cache always admit, let flashnode cache the blocks of the volume bypassing its admission filter(true|false)")
+	cmd.Flags().StringVar(&optRemoteCacheAuth, CliFlagRemoteCacheAuth, "", "Remote cache auth, let flashnode serve the cache of the volume to the clients with a token issued by the master only(true|false)")
+	cmd.Flags().Int64Var(&optRemoteCacheReadAheadMB, CliFlagRemoteCacheReadAheadMB, 0, "Remote cache read ahead[Unit: MB], let flashnode cache the blocks ahead of sequential reads(0 disables, at most 256)")
+	cmd.Flags().Int64Var(&optRemoteCacheMetaTTL, CliFlagRemoteCacheMetaTTL, 0, "Remote cache meta ttl[Unit: s], let flashnode cache the lookups and listings of the directories(0 disables, at most 3600)")
+	cmd.Flags().Int64Var(&optRemoteCacheReadLimitMBps, CliFlagRemoteCacheReadLimitMBps, 0, "Remote cache read limit[Unit: MB/s], the read bandwidth of the volume served by each flashnode(0 for unlimited)")
+	cmd.Flags().Int64Var(&optRemoteCacheReadLimitIops, CliFlagRemoteCacheReadLimitIops, 0, "Remote cache read limit of the volume served by each flashnode per second(0 for unlimited)")
 	cmd.Flags().Int64Var(&optFlashNodeTimeoutCount, CliFlagFlashNodeTimeoutCount, 0, "FlashNode timeout count, flashNode will be removed by client if it's timeout count exceeds this value(default 5)")
 	cmd.Flags().Int64Var(&optRemoteCacheSameZoneTimeout, CliFlagRemoteCacheSameZoneTimeout, 0, "Remote cache same zone timeout microsecond(must > 0),default 400")
 	cmd.Flags().Int64Var(&optRemoteCacheSameRegionTimeout, CliFlagRemoteCacheSameRegionTimeout, 0, "Remote cache same region timeout millisecond(must > 0),default 2")
+	cmd.Flags().Int64Var(&optRemoteCacheHedgeDelay, CliFlagRemoteCacheHedgeDelay, 0, "Remote cache hedge delay millisecond, the clients with the hedge read policy read a second flashnode after it(must > 0),default 20")
+	cmd.Flags().Int64Var(&optRemoteCacheFallbackTimeout, CliFlagRemoteCacheFallbackTimeout, 0, "Remote cache fallback timeout millisecond, the clients with the fallback read policy read the datanodes after it(must > 0),default 30")
 
 	return cmd
 }
@@ -1377,6 +1506,548 @@ func newVolSetForbiddenCmd(client *master.MasterClient) *cobra.Command {
 	return cmd
 }
 
+var (
+	cmdVolSetInteropUse   = "set-interop [VOLUME]"
+	cmdVolSetInteropShort = "Set how the S3 and POSIX views of volume interact"
+)
+
+func newVolSetInteropCmd(client *master.MasterClient) *cobra.Command {
+	var policy proto.InteropPolicy
+	cmd := &cobra.Command{
+		Use:   cmdVolSetInteropUse,
+		Short: cmdVolSetInteropShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var svv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(name); err != nil {
+				return
+			}
+			if err = client.AdminAPI().SetVolumeInterop(name, util.CalcAuthKey(svv.Owner), &policy); err != nil {
+				return
+			}
+			stdout("Volume interop policy has been set successfully, please wait few minutes for the settings to take effect.\n")
+		},
+	}
+	cmd.Flags().StringVar(&policy.KeyMapping, "key-mapping", "",
+		fmt.Sprintf("Object key to path mapping [%v|%v]", proto.ObjKeyMappingCompat, proto.ObjKeyMappingStrict))
+	cmd.Flags().StringVar(&policy.MetaSync, "meta-sync", "",
+		fmt.Sprintf("Visibility of POSIX metadata changes to S3 [%v|%v]", proto.InteropSyncCached, proto.InteropSyncImmediate))
+	cmd.Flags().StringVar(&policy.RenameVisibility, "rename-visibility", "",
+		fmt.Sprintf("Visibility of POSIX renames to S3 [%v|%v]", proto.InteropSyncCached, proto.InteropSyncImmediate))
+	return cmd
+}
+
+var (
+	cmdVolSetPlacementExclusionUse   = "set-placement-exclusion [VOLUME]"
+	cmdVolSetPlacementExclusionShort = "Set the hosts, zones and node sets the replicas of volume must never be placed on"
+)
+
+func newVolSetPlacementExclusionCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		exclusion proto.PlacementExclusion
+		nodeSets  []uint
+	)
+	cmd := &cobra.Command{
+		Use:   cmdVolSetPlacementExclusionUse,
+		Short: cmdVolSetPlacementExclusionShort,
+		Long:  "Replace the placement exclusion of volume, the lists not given are cleared. Existing replicas are not moved.",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var svv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(name); err != nil {
+				return
+			}
+			for _, id := range nodeSets {
+				exclusion.NodeSets = append(exclusion.NodeSets, uint64(id))
+			}
+			if err = client.AdminAPI().SetVolumePlacementExclusion(name, util.CalcAuthKey(svv.Owner), &exclusion); err != nil {
+				return
+			}
+			stdout("Volume placement exclusion has been set successfully.\n")
+		},
+	}
+	cmd.Flags().StringSliceVar(&exclusion.Hosts, "hosts", nil, "Excluded node addresses, an ip without port excludes the whole machine")
+	cmd.Flags().StringSliceVar(&exclusion.Zones, "zones", nil, "Excluded zones")
+	cmd.Flags().UintSliceVar(&nodeSets, "nodesets", nil, "Excluded node set ids")
+	return cmd
+}
+
+var (
+	cmdVolSetPlacementPolicyUse   = "set-placement-policy [VOLUME]"
+	cmdVolSetPlacementPolicyShort = "Set the zones the replicas of volume are pinned to and spread across"
+)
+
+func newVolSetPlacementPolicyCmd(client *master.MasterClient) *cobra.Command {
+	var policy proto.PlacementPolicy
+	cmd := &cobra.Command{
+		Use:   cmdVolSetPlacementPolicyUse,
+		Short: cmdVolSetPlacementPolicyShort,
+		Long:  "Replace the placement policy of volume, the settings not given are cleared. Existing replicas are not moved.",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			policy.Normalize()
+			if err = policy.Validate(); err != nil {
+				return
+			}
+			var svv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(name); err != nil {
+				return
+			}
+			if err = client.AdminAPI().SetVolumePlacementPolicy(name, util.CalcAuthKey(svv.Owner), &policy); err != nil {
+				return
+			}
+			stdout("Volume placement policy has been set successfully.\n")
+		},
+	}
+	cmd.Flags().StringSliceVar(&policy.RequiredZones, "required-zones", nil, "The zones the replicas are only placed in")
+	cmd.Flags().IntVar(&policy.SpreadZones, "spread-zones", 0, fmt.Sprintf("The least number of zones the replicas of a partition spread across, at most %v", proto.MaxPlacementSpreadZones))
+	return cmd
+}
+
+var (
+	cmdVolSetSLOUse   = "set-slo [VOLUME] [read|write|meta]"
+	cmdVolSetSLOShort = "Set the latency SLO of read, write or meta ops of volume"
+)
+
+func newVolSetSLOCmd(client *master.MasterClient) *cobra.Command {
+	var target proto.SLOTarget
+	cmd := &cobra.Command{
+		Use:   cmdVolSetSLOUse,
+		Short: cmdVolSetSLOShort,
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			name, op := args[0], args[1]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var svv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(name); err != nil {
+				return
+			}
+			if target.Objective == 0 {
+				if err = client.AdminAPI().SetVolumeSLO(name, util.CalcAuthKey(svv.Owner), op, nil); err != nil {
+					return
+				}
+				stdout("Volume SLO of op %v has been removed successfully.\n", op)
+				return
+			}
+			if err = client.AdminAPI().SetVolumeSLO(name, util.CalcAuthKey(svv.Owner), op, &target); err != nil {
+				return
+			}
+			stdout("Volume SLO of op %v has been set successfully.\n", op)
+		},
+	}
+	cmd.Flags().Int64Var(&target.LatencyMs, "latency-ms", 0, "Latency threshold of a good op in milliseconds")
+	cmd.Flags().Float64Var(&target.Objective, "objective", 0, "Ratio of ops that should be good, in (0, 1), 0 removes the SLO")
+	return cmd
+}
+
+var (
+	cmdVolSLOUse   = "slo [VOLUME]"
+	cmdVolSLOShort = "Show the SLO attainment of volume, or of all volumes with SLOs"
+)
+
+func newVolSLOCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdVolSLOUse,
+		Short: cmdVolSLOShort,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var statuses []*proto.VolumeSLOStatus
+			if len(args) == 1 {
+				var st *proto.VolumeSLOStatus
+				if st, err = client.AdminAPI().GetVolumeSLO(args[0]); err != nil {
+					return
+				}
+				statuses = append(statuses, st)
+			} else if statuses, err = client.AdminAPI().ListVolumeSLO(); err != nil {
+				return
+			}
+			stdout("%v", formatVolSLOStatus(statuses))
+		},
+	}
+	return cmd
+}
+
+var (
+	cmdVolReclaimUse   = "reclaim [VOLUME]"
+	cmdVolReclaimShort = "Show the space of the deleted files of volume not returned yet, or of all volumes with such space"
+)
+
+func newVolReclaimCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdVolReclaimUse,
+		Short: cmdVolReclaimShort,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			if len(args) == 1 {
+				var view *proto.VolReclaimView
+				if view, err = client.AdminAPI().GetVolumeReclaim(args[0]); err != nil {
+					return
+				}
+				stdout("%v", formatVolReclaimView(view))
+				return
+			}
+			var views []*proto.VolReclaimView
+			if views, err = client.AdminAPI().ListVolumeReclaim(); err != nil {
+				return
+			}
+			stdout("%v", formatVolReclaimViews(views))
+		},
+	}
+	return cmd
+}
+
+var (
+	cmdVolBoostReclaimUse   = "boost-reclaim [VOLUME]"
+	cmdVolBoostReclaimShort = "Delete the deleted files of volume faster for a while"
+)
+
+func newVolBoostReclaimCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optBatchCount  uint64
+		optDurationSec uint64
+	)
+	cmd := &cobra.Command{
+		Use:   cmdVolBoostReclaimUse,
+		Short: cmdVolBoostReclaimShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			if err = client.AdminAPI().BoostVolumeReclaim(name, optBatchCount, optDurationSec); err != nil {
+				return
+			}
+			if optDurationSec == 0 {
+				stdout("Volume reclaim boost has been removed successfully.\n")
+				return
+			}
+			stdout("Volume reclaim has been boosted successfully.\n")
+		},
+	}
+	cmd.Flags().Uint64Var(&optBatchCount, "batch-count", 1024, "Inodes deleted at once by each meta partition, at most 8192")
+	cmd.Flags().Uint64Var(&optDurationSec, "duration-sec", 3600, "Seconds to boost for, at most 86400, 0 removes the boost")
+	return cmd
+}
+
+var (
+	cmdVolForkUse   = "fork [SOURCE VOLUME] [SOURCE PATH] [TARGET VOLUME] [TARGET PATH]"
+	cmdVolForkShort = "Fork a directory of volume into a directory of another volume without copying the data"
+)
+
+func newVolForkCmd(client *master.MasterClient) *cobra.Command {
+	var optSettle time.Duration
+	cmd := &cobra.Command{
+		Use:   cmdVolForkUse,
+		Short: cmdVolForkShort,
+		Args:  cobra.ExactArgs(4),
+		Run: func(cmd *cobra.Command, args []string) {
+			source, sourcePath, target, targetPath := args[0], args[1], args[2], args[3]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var svv, tvv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(source); err != nil {
+				return
+			}
+			if tvv, err = client.AdminAPI().GetVolumeSimpleInfo(target); err != nil {
+				return
+			}
+			forked := false
+			for _, name := range tvv.ForkSources {
+				forked = forked || name == source
+			}
+			if !forked {
+				if err = client.AdminAPI().AddVolumeFork(target, source, util.CalcAuthKey(svv.Owner)); err != nil {
+					return
+				}
+				// the clients and the meta nodes of the source pin it on their next refreshes
+				stdout("Volume %v has been forked from %v, waiting %v for the source to be pinned.\n", target, source, optSettle)
+				time.Sleep(optSettle)
+			}
+
+			var srcMw, dstMw *meta.MetaWrapper
+			if srcMw, err = meta.NewMetaWrapper(&meta.MetaConfig{Volume: source, Masters: client.Nodes()}); err != nil {
+				return
+			}
+			defer srcMw.Close()
+			if dstMw, err = meta.NewMetaWrapper(&meta.MetaConfig{Volume: target, Masters: client.Nodes()}); err != nil {
+				return
+			}
+			defer dstMw.Close()
+			var root uint64
+			if root, err = dstMw.LookupPath(targetPath); err != nil {
+				err = fmt.Errorf("lookup %v of %v: %v", targetPath, target, err)
+				return
+			}
+			importer := dstMw.NewForkImporter(root, targetPath)
+			count := 0
+			if err = srcMw.ExportTree(sourcePath, func(record *proto.ForkRecord) error {
+				count++
+				return importer.Import(record)
+			}); err != nil {
+				return
+			}
+			stdout("%v entries of %v:%v have been forked into %v:%v.\n", count, source, sourcePath, target, targetPath)
+		},
+	}
+	cmd.Flags().DurationVar(&optSettle, "settle", 90*time.Second, "Time to wait for the source to be pinned after registering the fork")
+	return cmd
+}
+
+var (
+	cmdVolMoveUse   = "mv [VOLUME] [SOURCE DIR] [TARGET DIR] [NAME]..."
+	cmdVolMoveShort = "Move the entries of a directory into another one in batches, all of them if no name is given"
+)
+
+func newVolMoveCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdVolMoveUse,
+		Short: cmdVolMoveShort,
+		Args:  cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			volName, srcDir, dstDir := args[0], args[1], args[2]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var mw *meta.MetaWrapper
+			if mw, err = meta.NewMetaWrapper(&meta.MetaConfig{Volume: volName, Masters: client.Nodes()}); err != nil {
+				return
+			}
+			defer mw.Close()
+			var results []proto.MoveDentryResult
+			if results, err = moveDirEntries(mw, srcDir, dstDir, args[3:]); err != nil {
+				return
+			}
+			moved := 0
+			for _, r := range results {
+				if r.Status == proto.OpOk {
+					moved++
+				} else {
+					stdout("%v: %v\n", path.Join(srcDir, r.SrcName), proto.GetStatusStr(r.Status))
+				}
+			}
+			stdout("%v of %v entries have been moved from %v to %v.\n", moved, len(results), srcDir, dstDir)
+		},
+	}
+	return cmd
+}
+
+// dirEntryMover is the part of the meta wrapper moving the entries of a directory.
+type dirEntryMover interface {
+	LookupPath(subdir string) (uint64, error)
+	ReadDir_ll(parentID uint64) ([]proto.Dentry, error)
+	BatchMove_ll(srcParentID, dstParentID uint64, srcPath, dstPath string, items []proto.MoveDentryItem) ([]proto.MoveDentryResult, error)
+}
+
+// moveDirEntries moves the entries names of srcDir into dstDir under the same
+// names, all the entries of srcDir if names is empty.
+func moveDirEntries(mw dirEntryMover, srcDir, dstDir string, names []string) ([]proto.MoveDentryResult, error) {
+	srcDir, dstDir = path.Clean(srcDir), path.Clean(dstDir)
+	if srcDir == dstDir {
+		return nil, fmt.Errorf("source and target are the same directory %v", srcDir)
+	}
+	srcIno, err := mw.LookupPath(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %v: %v", srcDir, err)
+	}
+	dstIno, err := mw.LookupPath(dstDir)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %v: %v", dstDir, err)
+	}
+	if len(names) == 0 {
+		var children []proto.Dentry
+		if children, err = mw.ReadDir_ll(srcIno); err != nil {
+			return nil, fmt.Errorf("read %v: %v", srcDir, err)
+		}
+		for _, child := range children {
+			names = append(names, child.Name)
+		}
+	}
+	items := make([]proto.MoveDentryItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, proto.MoveDentryItem{SrcName: name, DstName: name})
+	}
+	return mw.BatchMove_ll(srcIno, dstIno, srcDir, dstDir, items)
+}
+
+var (
+	cmdVolUnforkUse   = "unfork [TARGET VOLUME] [SOURCE VOLUME]"
+	cmdVolUnforkShort = "Unpin the source of a fork once the files of the fork do not refer to its data any more"
+)
+
+func newVolUnforkCmd(client *master.MasterClient) *cobra.Command {
+	var optYes bool
+	cmd := &cobra.Command{
+		Use:   cmdVolUnforkUse,
+		Short: cmdVolUnforkShort,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			target, source := args[0], args[1]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			if !optYes {
+				stdout("The files of volume %v forked from %v lose their data once %v deletes it.\n", target, source, source)
+				stdout("Unfork volume %v from %v? (yes/no)[no]:", target, source)
+				var userConfirm string
+				_, _ = fmt.Scanln(&userConfirm)
+				if userConfirm != "yes" {
+					err = fmt.Errorf("Abort by user.\n")
+					return
+				}
+			}
+			var tvv *proto.SimpleVolView
+			if tvv, err = client.AdminAPI().GetVolumeSimpleInfo(target); err != nil {
+				return
+			}
+			if err = client.AdminAPI().RemoveVolumeFork(target, source, util.CalcAuthKey(tvv.Owner)); err != nil {
+				return
+			}
+			stdout("Volume %v has been unforked from %v successfully.\n", target, source)
+		},
+	}
+	cmd.Flags().BoolVarP(&optYes, "yes", "y", false, "Answer yes for all questions")
+	return cmd
+}
+
+var (
+	cmdVolTimelineUse   = "timeline [VOLUME]"
+	cmdVolTimelineShort = "Show the events of volume in the order they happened"
+)
+
+func newVolTimelineCmd(client *master.MasterClient) *cobra.Command {
+	var (
+		optKind  string
+		optStart string
+		optEnd   string
+	)
+	cmd := &cobra.Command{
+		Use:   cmdVolTimelineUse,
+		Short: cmdVolTimelineShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var start, end int64
+			parseTime := func(value string) (unix int64, err error) {
+				if value == "" {
+					return
+				}
+				t, err := time.ParseInLocation(proto.TimeFormat, value, time.Local)
+				return t.Unix(), err
+			}
+			if start, err = parseTime(optStart); err != nil {
+				return
+			}
+			if end, err = parseTime(optEnd); err != nil {
+				return
+			}
+			var events []*proto.VolTimelineEvent
+			if events, err = client.AdminAPI().GetVolumeTimeline(args[0], optKind, start, end); err != nil {
+				return
+			}
+			stdout("%v", formatVolTimeline(events))
+		},
+	}
+	cmd.Flags().StringVar(&optKind, "kind", "", fmt.Sprintf("Show the events of kind only, one of %v",
+		[]string{proto.VolEventCreate, proto.VolEventDelete, proto.VolEventCapacity, proto.VolEventAddDataPartition,
+			proto.VolEventAddMetaPartition, proto.VolEventDecommission, proto.VolEventLifecycle, proto.VolEventReadOnly,
+			proto.VolEventForbidden, proto.VolEventFence, proto.VolEventFork}))
+	cmd.Flags().StringVar(&optStart, "start", "", "Show the events since, \"2006-01-02 15:04:05\" in local time")
+	cmd.Flags().StringVar(&optEnd, "end", "", "Show the events until, \"2006-01-02 15:04:05\" in local time")
+	return cmd
+}
+
+var (
+	cmdVolAcquireFenceUse   = "acquire-fence [VOLUME]"
+	cmdVolAcquireFenceShort = "Acquire a new fencing token of volume, the writes with an older one are rejected"
+)
+
+func newVolAcquireFenceCmd(client *master.MasterClient) *cobra.Command {
+	var optPath string
+	cmd := &cobra.Command{
+		Use:   cmdVolAcquireFenceUse,
+		Short: cmdVolAcquireFenceShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var svv *proto.SimpleVolView
+			if svv, err = client.AdminAPI().GetVolumeSimpleInfo(name); err != nil {
+				return
+			}
+			var fence *proto.VolFence
+			if fence, err = client.AdminAPI().AcquireVolumeFence(name, util.CalcAuthKey(svv.Owner), optPath); err != nil {
+				return
+			}
+			stdout("%v", formatVolFences([]*proto.VolFence{fence}))
+		},
+	}
+	cmd.Flags().StringVar(&optPath, "path", "", "Fence the writers of the subtree at path only, the whole volume by default")
+	return cmd
+}
+
+var (
+	cmdVolFenceUse   = "fence [VOLUME]"
+	cmdVolFenceShort = "Show the fencing tokens of volume"
+)
+
+func newVolFenceCmd(client *master.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdVolFenceUse,
+		Short: cmdVolFenceShort,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			var fences []*proto.VolFence
+			if fences, err = client.AdminAPI().ListVolumeFences(args[0]); err != nil {
+				return
+			}
+			stdout("%v", formatVolFences(fences))
+		},
+	}
+	return cmd
+}
+
 var (
 	cmdVolSetAuditLogUse   = "set-auditlog [VOLUME] [STATUS]"
 	cmdVolSetAuditLogShort = "Enable/Disable backend audit log for volume"
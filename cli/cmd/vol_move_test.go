@@ -0,0 +1,82 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDirEntryMover struct {
+	inodes   map[string]uint64
+	children map[uint64][]proto.Dentry
+	moved    []string
+}
+
+func (f *fakeDirEntryMover) LookupPath(subdir string) (uint64, error) {
+	if ino, ok := f.inodes[subdir]; ok {
+		return ino, nil
+	}
+	return 0, syscall.ENOENT
+}
+
+func (f *fakeDirEntryMover) ReadDir_ll(parentID uint64) ([]proto.Dentry, error) {
+	return f.children[parentID], nil
+}
+
+func (f *fakeDirEntryMover) BatchMove_ll(srcParentID, dstParentID uint64, srcPath, dstPath string,
+	items []proto.MoveDentryItem,
+) ([]proto.MoveDentryResult, error) {
+	results := make([]proto.MoveDentryResult, 0, len(items))
+	for _, item := range items {
+		status := uint8(proto.OpNotExistErr)
+		for _, child := range f.children[srcParentID] {
+			if child.Name == item.SrcName {
+				status = proto.OpOk
+				f.moved = append(f.moved, srcPath+"/"+item.SrcName+">"+dstPath+"/"+item.DstName)
+			}
+		}
+		results = append(results, proto.MoveDentryResult{SrcName: item.SrcName, Status: status})
+	}
+	return results, nil
+}
+
+func TestMoveDirEntries(t *testing.T) {
+	f := &fakeDirEntryMover{
+		inodes:   map[string]uint64{"/a": 2, "/b": 3},
+		children: map[uint64][]proto.Dentry{2: {{Name: "x"}, {Name: "y"}}},
+	}
+	results, err := moveDirEntries(f, "/a/", "/b", nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, []string{"/a/x>/b/x", "/a/y>/b/y"}, f.moved, "all the entries with the paths cleaned")
+
+	f.moved = nil
+	results, err = moveDirEntries(f, "/a", "/b", []string{"y", "z"})
+	require.NoError(t, err)
+	require.Equal(t, []proto.MoveDentryResult{
+		{SrcName: "y", Status: proto.OpOk},
+		{SrcName: "z", Status: proto.OpNotExistErr},
+	}, results)
+	require.Equal(t, []string{"/a/y>/b/y"}, f.moved)
+
+	_, err = moveDirEntries(f, "/a", "/c", nil)
+	require.Error(t, err)
+	_, err = moveDirEntries(f, "/a", "/a/", nil)
+	require.Error(t, err)
+}
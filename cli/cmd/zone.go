@@ -37,14 +37,16 @@ func newZoneCmd(client *sdk.MasterClient) *cobra.Command {
 		newZoneListCmd(client),
 		newZoneInfoCmd(client),
 		newZoneUpdateCmd(client),
+		newZoneSetIsolationCmd(client),
 	)
 	return cmd
 }
 
 const (
-	cmdZoneListShort   = "List cluster zones"
-	cmdZoneInfoShort   = "Show zone information"
-	cmdZoneUpdateShort = "Update zone settings"
+	cmdZoneListShort    = "List cluster zones"
+	cmdZoneInfoShort    = "Show zone information"
+	cmdZoneUpdateShort  = "Update zone settings"
+	cmdZoneIsolateShort = "Make a zone read-only or fenced during an outage, or none once it recovered"
 )
 
 func newZoneListCmd(client *sdk.MasterClient) *cobra.Command {
@@ -61,10 +63,10 @@ func newZoneListCmd(client *sdk.MasterClient) *cobra.Command {
 			if zones, err = client.AdminAPI().ListZones(); err != nil {
 				return
 			}
-			zoneTablePattern := "%-8v    %-10v\n"
-			stdout(zoneTablePattern, "ZONE", "STATUS")
+			zoneTablePattern := "%-8v    %-10v    %-10v\n"
+			stdout(zoneTablePattern, "ZONE", "STATUS", "ISOLATION")
 			for _, zone := range zones {
-				stdout(zoneTablePattern, zone.Name, zone.Status)
+				stdout(zoneTablePattern, zone.Name, zone.Status, zone.Isolation)
 			}
 		},
 	}
@@ -141,3 +143,34 @@ func newZoneUpdateCmd(client *sdk.MasterClient) *cobra.Command {
 	cmd.Flags().StringVar(&metaNodeSelector, CliFlagMetaNodeSelector, "", "Set the node select policy(metanode) for specify zone")
 	return cmd
 }
+
+func newZoneSetIsolationCmd(client *sdk.MasterClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "setIsolation [NAME] [none|readOnly|fenced]",
+		Short: cmdZoneIsolateShort,
+		Long: `A readOnly zone gets no new partitions and its replicas reject the writes,
+a fenced zone also gives up the raft leaders of its partitions.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			defer func() {
+				errout(err)
+			}()
+			zoneName, isolation := args[0], args[1]
+			if _, err = proto.ParseZoneIsolation(isolation); err != nil {
+				return
+			}
+			if err = client.AdminAPI().SetZoneIsolation(zoneName, isolation); err != nil {
+				return
+			}
+			stdout("Zone %v isolation has been set to %v successfully!\n", zoneName, isolation)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return validZones(client, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	return cmd
+}
@@ -30,7 +30,6 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-	"syscall"
 	"time"
 
 	"github.com/cubefs/cubefs/util/errors"
@@ -745,11 +744,6 @@ func checkoutTempFileOuttime(file string) bool {
 	if err != nil {
 		return false
 	}
-	stat_t := finfo.Sys().(*syscall.Stat_t)
 	now := time.Now()
-	return now.Sub(timespecToTime(stat_t.Ctim)).Seconds() > 60*60 // 1 hour
-}
-
-func timespecToTime(ts syscall.Timespec) time.Time {
-	return time.Unix(int64(ts.Sec), int64(ts.Nsec))
+	return now.Sub(changeTime(finfo)).Seconds() > 60*60 // 1 hour
 }
@@ -22,7 +22,6 @@ import (
 	"path/filepath"
 	"runtime"
 	"strconv"
-	"syscall"
 
 	"github.com/cubefs/cubefs/blobstore/util/bytespool"
 	"github.com/cubefs/cubefs/cmd/common"
@@ -121,7 +120,7 @@ func doShutdown(server common.Server) {
 		os.Remove(UnixSocketPath)
 	}
 
-	syscall.Flock(int(unixSocketLockFile.Fd()), syscall.LOCK_UN)
+	unlockFile(unixSocketLockFile)
 	unixSocketLockFile.Close()
 	os.Remove(UnixSocketLock)
 	s.stopServer()
@@ -137,7 +136,7 @@ func (s *bcacheStore) startServer() (err error) {
 		return errors.New(fmt.Sprintf("Error: creating lock file %s", UnixSocketLock))
 	}
 
-	err = syscall.Flock(int(unixSocketLockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	err = tryLockFile(unixSocketLockFile)
 	if err != nil {
 		return errors.New(fmt.Sprintf("Error: acquire flock of %s failed, maybe server exists", UnixSocketLock))
 	}
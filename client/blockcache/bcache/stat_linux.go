@@ -17,9 +17,23 @@ package bcache
 import (
 	"os"
 	"syscall"
+	"time"
 )
 
 func AccessTime(info os.FileInfo) int64 {
 	linuxFileAttr := info.Sys().(*syscall.Stat_t)
 	return linuxFileAttr.Atim.Sec
 }
+
+func changeTime(info os.FileInfo) time.Time {
+	ts := info.Sys().(*syscall.Stat_t).Ctim
+	return time.Unix(int64(ts.Sec), int64(ts.Nsec))
+}
+
+func tryLockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
+
+func unlockFile(f *os.File) {
+	syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
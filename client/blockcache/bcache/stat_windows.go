@@ -14,12 +14,29 @@
 
 package bcache
 
-//import (
-//	"os"
-//	"syscall"
-//)
-//
-//func AccessTime(info os.FileInfo) int64 {
-//	winFileAttr := info.Sys().(*syscall.Win32FileAttributeData)
-//	return winFileAttr.LastAccessTime.Nanoseconds() / 1e9
-//}
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func AccessTime(info os.FileInfo) int64 {
+	winFileAttr := info.Sys().(*syscall.Win32FileAttributeData)
+	return winFileAttr.LastAccessTime.Nanoseconds() / 1e9
+}
+
+// changeTime returns the last write time, as windows keeps no change time.
+func changeTime(info os.FileInfo) time.Time {
+	return info.ModTime()
+}
+
+func tryLockFile(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0, &windows.Overlapped{})
+}
+
+func unlockFile(f *os.File) {
+	windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
@@ -15,6 +15,7 @@
 package fs
 
 import (
+	"errors"
 	"syscall"
 	"time"
 
@@ -73,6 +74,10 @@ func ParseError(err error) fuse.Errno {
 	case fuse.Errno:
 		return v
 	default:
+		var ce *proto.CodeError
+		if errors.As(err, &ce) {
+			return fuse.Errno(ce.Errno())
+		}
 		return fuse.EIO
 	}
 }
@@ -0,0 +1,196 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	DefaultDefragMinExtents = 64
+	defragBufSize           = 16 * util.MB
+)
+
+// DefragResult describes the extent layout of a file before and after defrag.
+type DefragResult struct {
+	Inode         uint64 `json:"ino"`
+	Size          uint64 `json:"size"`
+	ExtentsBefore int    `json:"extentsBefore"`
+	ExtentsAfter  int    `json:"extentsAfter"`
+	Skipped       bool   `json:"skipped"`
+	Cost          string `json:"cost"`
+}
+
+// ExtentCount returns the number of extents and the size of a file.
+func (s *Super) ExtentCount(ino uint64) (count int, size uint64, err error) {
+	_, size, eks, err := s.mw.GetExtents(ino, false, false, false)
+	if err != nil {
+		return
+	}
+	return len(eks), size, nil
+}
+
+// DefragFile rewrites a file with at least minExtents extents into large
+// extents. The data is copied into a scratch inode on the same meta partition
+// and the extents are swapped only if the file was not modified meanwhile, so
+// the file stays readable during the whole process.
+func (s *Super) DefragFile(ino uint64, minExtents int) (result *DefragResult, err error) {
+	start := time.Now()
+	info, err := s.InodeGet(ino)
+	if err != nil {
+		return
+	}
+	if !proto.IsRegular(info.Mode) || !proto.IsStorageClassReplica(info.StorageClass) {
+		return nil, syscall.EINVAL
+	}
+	// make sure the local dirty data is visible before taking the generation
+	if err = s.ec.Flush(ino); err != nil {
+		return
+	}
+	gen, size, eks, err := s.mw.GetExtents(ino, false, false, false)
+	if err != nil {
+		return
+	}
+	result = &DefragResult{Inode: ino, Size: size, ExtentsBefore: len(eks), ExtentsAfter: len(eks)}
+	if len(eks) < minExtents || len(eks) <= 1 {
+		result.Skipped = true
+		return
+	}
+
+	tmp, err := s.mw.DefragInodeCreate_ll(ino, "")
+	if err != nil {
+		return
+	}
+	defer func() {
+		s.ec.EvictStream(tmp.Inode)
+		if _, e := s.mw.InodeUnlink_ll(tmp.Inode, ""); e != nil {
+			log.LogWarnf("DefragFile: unlink tmp ino(%v) err(%v)", tmp.Inode, e)
+			return
+		}
+		if e := s.mw.Evict(tmp.Inode, ""); e != nil {
+			log.LogWarnf("DefragFile: evict tmp ino(%v) err(%v)", tmp.Inode, e)
+		}
+	}()
+
+	if err = s.copyForDefrag(ino, info.StorageClass, tmp, size); err != nil {
+		return
+	}
+	if err = s.mw.SwapExtents_ll(ino, tmp.Inode, gen); err != nil {
+		log.LogWarnf("DefragFile: ino(%v) gen(%v) swap extents err(%v)", ino, gen, err)
+		return
+	}
+	s.ic.Delete(ino)
+	if err = s.ec.RefreshExtentsCache(ino); err != nil {
+		log.LogWarnf("DefragFile: ino(%v) refresh extents cache err(%v)", ino, err)
+		err = nil
+	}
+	if result.ExtentsAfter, _, err = s.ExtentCount(ino); err != nil {
+		return
+	}
+	result.Cost = time.Since(start).String()
+	log.LogInfof("DefragFile: ino(%v) size(%v) extents %v -> %v cost(%v)",
+		ino, size, result.ExtentsBefore, result.ExtentsAfter, result.Cost)
+	return
+}
+
+func (s *Super) copyForDefrag(ino uint64, storageClass uint32, tmp *proto.InodeInfo, size uint64) (err error) {
+	if err = s.ec.OpenStream(ino, false, false, ""); err != nil {
+		return
+	}
+	defer s.ec.CloseStream(ino)
+	if err = s.ec.OpenStream(tmp.Inode, true, false, ""); err != nil {
+		return
+	}
+	defer s.ec.CloseStream(tmp.Inode)
+
+	buf := make([]byte, defragBufSize)
+	for off := uint64(0); off < size; {
+		n := int(util.Min(int(size-off), defragBufSize))
+		read, e := s.ec.Read(ino, buf, int(off), n, storageClass, false)
+		if e != nil && read < n {
+			return fmt.Errorf("read ino(%v) off(%v) size(%v): %v", ino, off, n, e)
+		}
+		if _, err = s.ec.Write(tmp.Inode, int(off), buf[:n], 0, nil, tmp.StorageClass, false); err != nil {
+			return fmt.Errorf("write tmp ino(%v) off(%v) size(%v): %v", tmp.Inode, off, n, err)
+		}
+		off += uint64(n)
+	}
+	return s.ec.Flush(tmp.Inode)
+}
+
+func parseDefragIno(w http.ResponseWriter, r *http.Request) (ino uint64, ok bool) {
+	if err := r.ParseForm(); err != nil {
+		replyFail(w, r, err.Error())
+		return
+	}
+	ino, err := strconv.ParseUint(r.FormValue("ino"), 10, 64)
+	if err != nil {
+		replyFail(w, r, fmt.Sprintf("invalid ino: %v", err))
+		return
+	}
+	return ino, true
+}
+
+// GetFragmentation reports the extent count of one file.
+func (s *Super) GetFragmentation(w http.ResponseWriter, r *http.Request) {
+	ino, ok := parseDefragIno(w, r)
+	if !ok {
+		return
+	}
+	count, size, err := s.ExtentCount(ino)
+	if err != nil {
+		replyFail(w, r, err.Error())
+		return
+	}
+	report := &proto.FragmentedInode{Inode: ino, Size: size, ExtentCount: count}
+	if count > 0 {
+		report.AvgExtent = size / uint64(count)
+	}
+	data, _ := json.Marshal(report)
+	replySucc(w, r, string(data))
+}
+
+// Defrag rewrites one file into large extents, see DefragFile.
+func (s *Super) Defrag(w http.ResponseWriter, r *http.Request) {
+	ino, ok := parseDefragIno(w, r)
+	if !ok {
+		return
+	}
+	minExtents := DefaultDefragMinExtents
+	if v := r.FormValue("minExtents"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			replyFail(w, r, fmt.Sprintf("invalid minExtents: %v", v))
+			return
+		}
+		minExtents = n
+	}
+	result, err := s.DefragFile(ino, minExtents)
+	if err != nil {
+		replyFail(w, r, err.Error())
+		return
+	}
+	data, _ := json.Marshal(result)
+	replySucc(w, r, string(data))
+}
@@ -184,6 +184,7 @@ func (d *Dir) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.Cr
 	defer func() {
 		stat.EndStat("Create", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		auditlog.LogClientOp("Create", fullPath, "nil", err, time.Since(start).Microseconds(), newInode, 0)
 		d.super.runningMonitor.SubClientOp(runningStat, err)
 	}()
@@ -213,9 +214,10 @@ func (d *Dir) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.Cr
 	if d.super.keepCache {
 		resp.Flags |= fuse.OpenKeepCache
 	}
-	resp.EntryValid = LookupValidDuration
+	resp.EntryValid = d.super.lookupValid()
 
 	d.super.ic.Delete(d.info.Inode)
+	d.evictMetaCache(req.Name)
 
 	elapsed := time.Since(start)
 	log.LogDebugf("TRACE Create: parent(%v) req(%v) resp(%v) ino(%v) (%v)ns", d.info.Inode, req, resp, info.Inode, elapsed.Nanoseconds())
@@ -252,6 +254,7 @@ func (d *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error
 	defer func() {
 		stat.EndStat("Mkdir", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		auditlog.LogClientOp("Mkdir", fullPath, "nil", err, time.Since(start).Microseconds(), newInode, 0)
 		d.super.runningMonitor.SubClientOp(runningStat, err)
 	}()
@@ -271,6 +274,7 @@ func (d *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error
 	d.super.fslock.Unlock()
 
 	d.super.ic.Delete(d.info.Inode)
+	d.evictMetaCache(req.Name)
 
 	elapsed := time.Since(start)
 	log.LogDebugf("TRACE Mkdir: parent(%v) req(%v) ino(%v) (%v)ns", d.info.Inode, req, info.Inode, elapsed.Nanoseconds())
@@ -293,6 +297,7 @@ func (d *Dir) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
 	defer func() {
 		stat.EndStat("Remove", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		auditlog.LogClientOp("Remove", fullPath, "nil", err, time.Since(start).Microseconds(), deletedInode, 0)
 		log.LogDebugf("Remove: parent(%v) entry(%v) fullPath(%v) consume %v err %v",
 			d.info.Inode, req.Name, fullPath, time.Since(start).Seconds(), err)
@@ -309,6 +314,7 @@ func (d *Dir) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
 		deletedInode = info.Inode
 	}
 	d.super.ic.Delete(d.info.Inode)
+	d.evictMetaCache(req.Name)
 
 	if info != nil && info.Nlink == 0 && !proto.IsDir(info.Mode) {
 		d.super.orphan.Put(info.Inode)
@@ -373,7 +379,7 @@ func (d *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.Lo
 	} else {
 		cino, ok := d.dcache.Get(req.Name)
 		if !ok {
-			cino, _, err = d.super.mw.Lookup_ll(d.info.Inode, req.Name)
+			cino, err = d.lookupIno(req.Name)
 			if err != nil {
 				if err != syscall.ENOENT {
 					log.LogErrorf("Lookup: parent(%v) name(%v) err(%v)", d.info.Inode, req.Name, err)
@@ -438,7 +444,7 @@ func (d *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.Lo
 	}
 	d.super.fslock.Unlock()
 
-	resp.EntryValid = LookupValidDuration
+	resp.EntryValid = d.super.lookupValid()
 
 	log.LogDebugf("TRACE Lookup exit: parent(%v) req(%v) cost (%d)", d.info.Inode, req, time.Since(*bgTime).Microseconds())
 	return child, nil
@@ -460,6 +466,7 @@ func (d *Dir) ReadDir(ctx context.Context, req *fuse.ReadRequest, resp *fuse.Rea
 	defer func() {
 		stat.EndStat("ReadDirLimit", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		d.super.runningMonitor.SubClientOp(runningStat, err)
 	}()
 	var dirCtx DirContext = d.dctx.GetCopy(req.Handle)
@@ -519,7 +526,7 @@ func (d *Dir) ReadDir(ctx context.Context, req *fuse.ReadRequest, resp *fuse.Rea
 
 	log.LogDebugf("Readdir ino(%v) path(%v) d.super.bcacheDir(%v)", d.info.Inode, d.getCwd(), d.super.bcacheDir)
 	var dcache *DentryCache
-	if !d.super.disableDcache {
+	if d.super.dentryCacheEnabled() {
 		dcache = NewDentryCache()
 	}
 
@@ -579,29 +586,12 @@ func (d *Dir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
 	defer func() {
 		stat.EndStat("ReadDirAll", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 	}()
 
-	// transform ReadDirAll to ReadDirLimit_ll
-	noMore := false
-	from := ""
-	var children []proto.Dentry
-	for !noMore {
-		batches, err := d.super.mw.ReadDirLimit_ll(d.info.Inode, from, DefaultReaddirLimit)
-		if err != nil {
-			log.LogErrorf("Readdir: ino(%v) err(%v) from(%v)", d.info.Inode, err, from)
-			return make([]fuse.Dirent, 0), ParseError(err)
-		}
-		batchNr := uint64(len(batches))
-		if batchNr == 0 || (from != "" && batchNr == 1) {
-			break
-		} else if batchNr < DefaultReaddirLimit {
-			noMore = true
-		}
-		if from != "" {
-			batches = batches[1:]
-		}
-		children = append(children, batches...)
-		from = batches[len(batches)-1].Name
+	children, err := d.readDirChildren()
+	if err != nil {
+		return make([]fuse.Dirent, 0), ParseError(err)
 	}
 
 	inodes := make([]uint64, 0, len(children))
@@ -609,7 +599,7 @@ func (d *Dir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
 
 	log.LogDebugf("Readdir ino(%v) path(%v) d.super.bcacheDir(%v)", d.info.Inode, d.getCwd(), d.super.bcacheDir)
 	var dcache *DentryCache
-	if !d.super.disableDcache {
+	if d.super.dentryCacheEnabled() {
 		dcache = NewDentryCache()
 	}
 
@@ -684,6 +674,7 @@ func (d *Dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDir fs.Nod
 	defer func() {
 		stat.EndStat("Rename", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		d.super.fslock.Lock()
 		node, ok := d.super.nodeCache[srcInode]
 		if ok && srcInode != 0 {
@@ -718,6 +709,8 @@ func (d *Dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDir fs.Nod
 	// }
 	d.super.ic.Delete(d.info.Inode)
 	d.super.ic.Delete(dstDir.info.Inode)
+	d.evictMetaCache(req.OldName)
+	dstDir.evictMetaCache(req.NewName)
 
 	elapsed := time.Since(start)
 	log.LogDebugf("TRACE Rename: SrcParent(%v) OldName(%v) DstParent(%v) NewName(%v) (%v)ns",
@@ -773,6 +766,7 @@ func (d *Dir) Mknod(ctx context.Context, req *fuse.MknodRequest) (fs.Node, error
 	defer func() {
 		stat.EndStat("Mknod", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		d.super.runningMonitor.SubClientOp(runningStat, err)
 	}()
 	fullPath := path.Join(d.getCwd(), req.Name)
@@ -788,6 +782,7 @@ func (d *Dir) Mknod(ctx context.Context, req *fuse.MknodRequest) (fs.Node, error
 	d.super.fslock.Lock()
 	d.super.nodeCache[info.Inode] = child
 	d.super.fslock.Unlock()
+	d.evictMetaCache(req.Name)
 
 	elapsed := time.Since(start)
 	log.LogDebugf("TRACE Mknod: parent(%v) req(%v) ino(%v) (%v)ns", d.info.Inode, req, info.Inode, elapsed.Nanoseconds())
@@ -806,6 +801,7 @@ func (d *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node, e
 	defer func() {
 		stat.EndStat("Symlink", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		d.super.runningMonitor.SubClientOp(runningStat, err)
 	}()
 	fullPath := path.Join(d.getCwd(), req.NewName)
@@ -821,6 +817,7 @@ func (d *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node, e
 	d.super.fslock.Lock()
 	d.super.nodeCache[info.Inode] = child
 	d.super.fslock.Unlock()
+	d.evictMetaCache(req.NewName)
 
 	elapsed := time.Since(start)
 	log.LogDebugf("TRACE Symlink: parent(%v) req(%v) ino(%v) (%v)ns", parentIno, req, info.Inode, elapsed.Nanoseconds())
@@ -851,6 +848,7 @@ func (d *Dir) Link(ctx context.Context, req *fuse.LinkRequest, old fs.Node) (fs.
 	defer func() {
 		stat.EndStat("Link", err, bgTime, 1)
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: d.super.volname})
+		d.super.observeSLO(proto.SLOOpMeta, start, err)
 		d.super.runningMonitor.SubClientOp(runningStat, err)
 	}()
 	fullPath := path.Join(d.getCwd(), req.NewName)
@@ -869,6 +867,7 @@ func (d *Dir) Link(ctx context.Context, req *fuse.LinkRequest, old fs.Node) (fs.
 		d.super.nodeCache[info.Inode] = newFile
 	}
 	d.super.fslock.Unlock()
+	d.evictMetaCache(req.NewName)
 
 	elapsed := time.Since(start)
 	log.LogDebugf("TRACE Link: parent(%v) name(%v) ino(%v) (%v)ns", d.info.Inode, req.NewName, info.Inode, elapsed.Nanoseconds())
@@ -926,6 +925,11 @@ func (d *Dir) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fus
 
 		value = []byte(summaryStr)
 
+	} else if name == proto.StorageClassKey {
+		if value, err = d.super.getStorageClass(ino); err != nil {
+			log.LogErrorf("GetXattr: ino(%v) name(%v) err(%v)", ino, name, err)
+			return err
+		}
 	} else {
 		info, err = d.super.mw.XAttrGet_ll(ino, name)
 		if err != nil {
@@ -997,6 +1001,23 @@ func (d *Dir) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
 		log.LogErrorf("Set 'DirStat' is not supported.")
 		return fuse.ENOSYS
 	}
+	switch name {
+	case proto.DirShardLayoutKey, proto.DirShardSealedKey:
+		log.LogErrorf("Setxattr: ino(%v) %v can not be set directly", ino, req.Name)
+		return fuse.EPERM
+	case proto.DirShardCountKey:
+		var count int
+		if count, err = strconv.Atoi(string(value)); err != nil {
+			return fuse.Errno(syscall.EINVAL)
+		}
+		if err = d.super.mw.EnableDirShard_ll(ino, count); err != nil {
+			log.LogErrorf("Setxattr: ino(%v) shard dir into %v err(%v)", ino, count, err)
+			return ParseError(err)
+		}
+		return nil
+	case proto.StorageClassKey:
+		return d.super.setStorageClass(ino, value)
+	}
 	// TODO： implement flag to improve compatible (Mofei Zhang)
 	if err = d.super.mw.XAttrSet_ll(ino, []byte(name), []byte(value)); err != nil {
 		log.LogErrorf("Setxattr: ino(%v) name(%v) err(%v)", ino, name, err)
@@ -1026,6 +1047,9 @@ func (d *Dir) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) err
 		log.LogErrorf("Remove 'DirStat' is not supported.")
 		return fuse.ENOSYS
 	}
+	if name == proto.StorageClassKey {
+		return d.super.setStorageClass(ino, []byte(proto.StorageClassString(proto.StorageClass_Unspecified)))
+	}
 	if err = d.super.mw.XAttrDel_ll(ino, name); err != nil {
 		log.LogErrorf("Removexattr: ino(%v) name(%v) err(%v)", ino, name, err)
 		return ParseError(err)
@@ -0,0 +1,151 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fs
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// lookupIno looks the name up in the flash groups if the volume caches its
+// metadata there, and in the metanodes otherwise or on a miss. The inodes
+// found in the metanodes are put for the other clients, the names not found
+// are not cached.
+func (d *Dir) lookupIno(name string) (ino uint64, err error) {
+	if !d.super.ec.IsRemoteMetaCacheEnabled() {
+		ino, _, err = d.super.mw.Lookup_ll(d.info.Inode, name)
+		return
+	}
+	version, usable := d.metaCacheVersion()
+	if usable {
+		if data, ok := d.super.ec.RemoteCache.GetMeta(d.info.Inode, name); ok {
+			if data, ok = d.checkMetaCacheVersion(data, version); ok && len(data) == 8 {
+				exporter.NewCounter("lookupRemoteMetaHit").AddWithLabels(1, map[string]string{exporter.Vol: d.super.volname})
+				return binary.BigEndian.Uint64(data), nil
+			}
+		}
+	}
+	if ino, _, err = d.super.mw.Lookup_ll(d.info.Inode, name); err != nil || !usable {
+		return
+	}
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, ino)
+	d.super.ec.RemoteCache.PutMeta(d.info.Inode, name, d.withMetaCacheVersion(data, version))
+	return
+}
+
+// readDirChildren lists all the dentries of the directory, from the flash
+// groups if the volume caches its metadata there and the listing is cached.
+func (d *Dir) readDirChildren() (children []proto.Dentry, err error) {
+	var version uint64
+	enabled := d.super.ec.IsRemoteMetaCacheEnabled()
+	if enabled {
+		version, enabled = d.metaCacheVersion()
+	}
+	if enabled {
+		if data, ok := d.super.ec.RemoteCache.GetMeta(d.info.Inode, ""); ok {
+			if data, ok = d.checkMetaCacheVersion(data, version); ok {
+				if err = json.Unmarshal(data, &children); err == nil {
+					exporter.NewCounter("readdirRemoteMetaHit").AddWithLabels(1, map[string]string{exporter.Vol: d.super.volname})
+					return
+				}
+				log.LogWarnf("readDirChildren: ino(%v) decode cached listing err(%v)", d.info.Inode, err)
+				children, err = nil, nil
+			}
+		}
+	}
+
+	// transform ReadDirAll to ReadDirLimit_ll
+	noMore := false
+	from := ""
+	for !noMore {
+		batches, err := d.super.mw.ReadDirLimit_ll(d.info.Inode, from, DefaultReaddirLimit)
+		if err != nil {
+			log.LogErrorf("Readdir: ino(%v) err(%v) from(%v)", d.info.Inode, err, from)
+			return nil, err
+		}
+		batchNr := uint64(len(batches))
+		if batchNr == 0 || (from != "" && batchNr == 1) {
+			break
+		} else if batchNr < DefaultReaddirLimit {
+			noMore = true
+		}
+		if from != "" {
+			batches = batches[1:]
+		}
+		children = append(children, batches...)
+		from = batches[len(batches)-1].Name
+	}
+
+	if enabled {
+		if data, merr := json.Marshal(children); merr == nil && len(data) <= proto.MaxRemoteCacheMetaSize {
+			d.super.ec.RemoteCache.PutMeta(d.info.Inode, "", d.withMetaCacheVersion(data, version))
+		}
+	}
+	return
+}
+
+// evictMetaCache evicts the names changed in the directory and its listing
+// from the flash groups.
+func (d *Dir) evictMetaCache(names ...string) {
+	if d.super.ec.IsRemoteMetaCacheEnabled() {
+		d.super.ec.RemoteCache.EvictMeta(d.info.Inode, names...)
+	}
+}
+
+// metaCacheVersion returns the version of the directory its metadata are cached
+// with. In the strict consistency mode, they are cached with the version of the
+// directory on its meta node and only used while it is the same, not at all if
+// the version is not known. In the relaxed mode, they are used until they expire.
+func (d *Dir) metaCacheVersion() (version uint64, usable bool) {
+	if !d.super.ec.IsStrictConsistency() {
+		return 0, true
+	}
+	version, err := d.super.mw.GetDirVersion_ll(d.info.Inode)
+	if err != nil {
+		log.LogDebugf("metaCacheVersion: ino(%v) err(%v)", d.info.Inode, err)
+		return 0, false
+	}
+	return version, true
+}
+
+// withMetaCacheVersion prefixes the metadata cached with the version of the
+// directory in the strict consistency mode.
+func (d *Dir) withMetaCacheVersion(data []byte, version uint64) []byte {
+	if !d.super.ec.IsStrictConsistency() {
+		return data
+	}
+	versioned := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint64(versioned, version)
+	copy(versioned[8:], data)
+	return versioned
+}
+
+// checkMetaCacheVersion strips the version of the metadata cached, and tells
+// whether they are of the version of the directory in the strict consistency mode.
+func (d *Dir) checkMetaCacheVersion(data []byte, version uint64) ([]byte, bool) {
+	if !d.super.ec.IsStrictConsistency() {
+		return data, true
+	}
+	if len(data) < 8 || binary.BigEndian.Uint64(data) != version {
+		exporter.NewCounter("metaCacheStale").AddWithLabels(1, map[string]string{exporter.Vol: d.super.volname})
+		return nil, false
+	}
+	return data[8:], true
+}
@@ -413,6 +413,7 @@ func (f *File) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadR
 	metric := exporter.NewTPCnt("fileread")
 	defer func() {
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: f.super.volname})
+		f.super.observeSLO(proto.SLOOpRead, start, err)
 	}()
 
 	var size int
@@ -519,6 +520,7 @@ func (f *File) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.Wri
 	metric := exporter.NewTPCnt("filewrite")
 	defer func() {
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: f.super.volname})
+		f.super.observeSLO(proto.SLOOpWrite, start, err)
 	}()
 
 	checkFunc := func() error {
@@ -606,6 +608,7 @@ func (f *File) Flush(ctx context.Context, req *fuse.FlushRequest) (err error) {
 	metric := exporter.NewTPCnt("filesync")
 	defer func() {
 		metric.SetWithLabels(err, map[string]string{exporter.Vol: f.super.volname})
+		f.super.observeSLO(proto.SLOOpWrite, start, err)
 	}()
 	if proto.IsHot(f.super.volType) || proto.IsStorageClassReplica(f.info.StorageClass) {
 		err = f.super.ec.Flush(f.info.Inode)
@@ -782,12 +785,20 @@ func (f *File) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fu
 	name := req.Name
 	size := req.Size
 	pos := req.Position
-	info, err := f.super.mw.XAttrGet_ll(ino, name)
-	if err != nil {
-		log.LogErrorf("GetXattr: ino(%v) name(%v) err(%v)", ino, name, err)
-		return ParseError(err)
+	var value []byte
+	if name == proto.StorageClassKey {
+		if value, err = f.super.getStorageClass(ino); err != nil {
+			log.LogErrorf("GetXattr: ino(%v) name(%v) err(%v)", ino, name, err)
+			return err
+		}
+	} else {
+		var info *proto.XAttrInfo
+		if info, err = f.super.mw.XAttrGet_ll(ino, name); err != nil {
+			log.LogErrorf("GetXattr: ino(%v) name(%v) err(%v)", ino, name, err)
+			return ParseError(err)
+		}
+		value = info.Get(name)
 	}
-	value := info.Get(name)
 	if pos > 0 {
 		value = value[pos:]
 	}
@@ -844,6 +855,9 @@ func (f *File) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
 	ino := f.info.Inode
 	name := req.Name
 	value := req.Xattr
+	if name == proto.StorageClassKey {
+		return f.super.setStorageClass(ino, value)
+	}
 	// TODO： implement flag to improve compatible (Mofei Zhang)
 	if err = f.super.mw.XAttrSet_ll(ino, []byte(name), []byte(value)); err != nil {
 		log.LogErrorf("Setxattr: ino(%v) name(%v) err(%v)", ino, name, err)
@@ -164,3 +164,35 @@ func inodeExpired(info *proto.InodeInfo) bool {
 func inodeSetExpiration(info *proto.InodeInfo, t time.Duration) {
 	info.SetExpiration(time.Now().Add(t).UnixNano())
 }
+
+// setStorageClass serves the xattr proto.StorageClassKey: it sets the storage class
+// of an empty file, or of the inodes created in a directory, Unspecified to unset it.
+func (s *Super) setStorageClass(ino uint64, value []byte) error {
+	storageClass, err := proto.ParseStorageClass(string(value))
+	if err != nil {
+		log.LogErrorf("setStorageClass: ino(%v) err(%v)", ino, err)
+		return fuse.Errno(syscall.EINVAL)
+	}
+	if storageClass != proto.StorageClass_Unspecified && !proto.IsVolSupportStorageClass(s.allowedStorageClass, storageClass) {
+		log.LogErrorf("setStorageClass: ino(%v) storageClass(%v) not allowed by vol(%v), allowed(%v)",
+			ino, proto.StorageClassString(storageClass), s.volname, s.allowedStorageClass)
+		return fuse.Errno(syscall.EINVAL)
+	}
+	if err = s.mw.SetStorageClass_ll(ino, storageClass); err != nil {
+		return ParseError(err)
+	}
+	s.ic.Delete(ino)
+	_, err = s.InodeGet(ino)
+	return err
+}
+
+func (s *Super) getStorageClass(ino uint64) (value []byte, err error) {
+	info, err := s.InodeGet(ino)
+	if err != nil {
+		return
+	}
+	if proto.IsDir(info.Mode) {
+		return []byte(proto.StorageClassString(info.DirStorageClass)), nil
+	}
+	return []byte(proto.StorageClassString(info.StorageClass)), nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaStatfs(t *testing.T) {
+	quota := &proto.QuotaInfo{MaxBytes: 100, MaxFiles: math.MaxUint64}
+	quota.UsedInfo.UsedBytes = 30
+	total, free, files, ffree := quotaStatfs(quota, 1000, 900, 5, 50)
+	require.EqualValues(t, []uint64{100, 70, 5, 50}, []uint64{total, free, files, ffree})
+
+	// the volume has less free space than the quota left
+	total, free, _, _ = quotaStatfs(quota, 1000, 20, 5, 50)
+	require.EqualValues(t, 100, total)
+	require.EqualValues(t, 20, free)
+
+	quota = &proto.QuotaInfo{MaxBytes: math.MaxUint64, MaxFiles: 10}
+	quota.UsedInfo.UsedFiles = 12
+	total, free, files, ffree = quotaStatfs(quota, 1000, 900, 5, 50)
+	require.EqualValues(t, []uint64{1000, 900, 10, 0}, []uint64{total, free, files, ffree})
+}
+
+func TestCheckStatfsMode(t *testing.T) {
+	mode, err := checkStatfsMode("")
+	require.NoError(t, err)
+	require.Equal(t, proto.StatfsModeVolume, mode, "df reports the volume unless asked")
+	for _, m := range []string{proto.StatfsModeQuota, proto.StatfsModeVolume} {
+		mode, err = checkStatfsMode(m)
+		require.NoError(t, err)
+		require.Equal(t, m, mode)
+	}
+	_, err = checkStatfsMode("qouta")
+	require.Error(t, err)
+	_, err = NewSuper(&proto.MountOptions{StatfsMode: "Volume"})
+	require.Error(t, err, "rejected before the volume is mounted")
+}
@@ -18,6 +18,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
 	"path"
@@ -35,11 +36,13 @@ import (
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/data/blobstore"
 	"github.com/cubefs/cubefs/sdk/data/stream"
+	masterSDK "github.com/cubefs/cubefs/sdk/master"
 	"github.com/cubefs/cubefs/sdk/meta"
 	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/auditlog"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/slo"
 	"github.com/cubefs/cubefs/util/stat"
 	"github.com/cubefs/cubefs/util/ump"
 )
@@ -67,6 +70,7 @@ type Super struct {
 	fsyncOnClose  bool
 	enableXattr   bool
 	rootIno       uint64
+	statfsMode    string
 
 	state     fs.FSStatType
 	sockaddr  string
@@ -74,6 +78,7 @@ type Super struct {
 
 	// data lake
 	volType             int
+	allowedStorageClass []uint32
 	ebsEndpoint         string
 	EbsBlockSize        int
 	enableBcache        bool
@@ -90,6 +95,9 @@ type Super struct {
 
 	taskPool []common.TaskPool
 	closeC   chan struct{}
+
+	// latency samples reported to the master for volume SLOs
+	sloRecorder *slo.Recorder
 }
 
 // Functions that Super needs to implement
@@ -105,6 +113,9 @@ const (
 
 // NewSuper returns a new Super.
 func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
+	if opt.StatfsMode, err = checkStatfsMode(opt.StatfsMode); err != nil {
+		return nil, err
+	}
 	s = new(Super)
 	masters := strings.Split(opt.Master, meta.HostsSeparator)
 	metaConfig := &meta.MetaConfig{
@@ -119,6 +130,12 @@ func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
 		SubDir:                     opt.SubDir,
 		TrashRebuildGoroutineLimit: int(opt.TrashRebuildGoroutineLimit),
 		TrashTraverseLimit:         int(opt.TrashDeleteExpiredDirGoroutineLimit),
+		PacketCompressThreshold:    int(opt.PacketCompressThreshold),
+	}
+	if opt.EnableTLS {
+		if metaConfig.TLSConfig, err = util.NewClientTLSConfig(opt.TLSCAFile); err != nil {
+			return nil, errors.Trace(err, "NewClientTLSConfig failed!")
+		}
 	}
 	s.mw, err = meta.NewMetaWrapper(metaConfig)
 	if err != nil {
@@ -161,6 +178,7 @@ func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
 	s.disableDcache = opt.DisableDcache
 	s.fsyncOnClose = opt.FsyncOnClose
 	s.enableXattr = opt.EnableXattr
+	s.statfsMode = opt.StatfsMode
 	s.bcacheCheckInterval = opt.BcacheCheckIntervalS
 	s.bcacheFilterFiles = opt.BcacheFilterFiles
 	s.bcacheBatchCnt = opt.BcacheBatchCnt
@@ -199,6 +217,7 @@ func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
 	}
 
 	s.volType = opt.VolType
+	s.allowedStorageClass = opt.VolAllowedStorageClass
 	s.ebsEndpoint = opt.EbsEndpoint
 	s.EbsBlockSize = opt.EbsBlockSize
 	s.enableBcache = opt.EnableBcache
@@ -215,6 +234,7 @@ func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
 		Masters:           masters,
 		FollowerRead:      opt.FollowerRead,
 		NearRead:          opt.NearRead,
+		ZoneName:          opt.ZoneName,
 		MaximallyRead:     opt.MaximallyRead,
 		ReadRate:          opt.ReadRate,
 		WriteRate:         opt.WriteRate,
@@ -234,6 +254,10 @@ func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
 
 		DisableMetaCache:            DisableMetaCache,
 		StreamRetryTimeout:          opt.StreamRetryTimeout,
+		WriteCoalesceSize:           int(opt.WriteCoalesceSize),
+		WriteCoalesceWindowMs:       int(opt.WriteCoalesceWindowMs),
+		SessionResumeTimeout:        int(opt.SessionResumeTimeout),
+		RemoteCacheReadPolicy:       opt.RemoteCacheReadPolicy,
 		OnRenewalForbiddenMigration: s.mw.RenewalForbiddenMigration,
 		VolStorageClass:             opt.VolStorageClass,
 		VolAllowedStorageClass:      opt.VolAllowedStorageClass,
@@ -315,9 +339,31 @@ func NewSuper(opt *proto.MountOptions) (s *Super, err error) {
 	}
 	go s.loopSyncMeta()
 
+	s.sloRecorder = slo.NewRecorder(fmt.Sprintf("client(%v)", s.mountPoint))
+	s.sloRecorder.Start(slo.DefaultReportInterval, masterSDK.NewMasterClientFromString(opt.Master, false).AdminAPI().ReportSLO)
+
 	return s, nil
 }
 
+func (s *Super) observeSLO(op string, start time.Time, err error) {
+	s.sloRecorder.Observe(s.volname, op, time.Since(start), err != nil)
+}
+
+// dentryCacheEnabled tells whether the dentries read are cached to answer the
+// lookups, never in the strict consistency mode of the volume.
+func (s *Super) dentryCacheEnabled() bool {
+	return !s.disableDcache && !s.ec.IsStrictConsistency()
+}
+
+// lookupValid is how long the kernel caches the lookups, not at all in the
+// strict consistency mode of the volume.
+func (s *Super) lookupValid() time.Duration {
+	if s.ec.IsStrictConsistency() {
+		return 0
+	}
+	return LookupValidDuration
+}
+
 func (s *Super) scheduleFlush() {
 	t := time.NewTicker(2 * time.Second)
 	defer t.Stop()
@@ -377,21 +423,65 @@ func (s *Super) Node(ino, pino uint64, mode uint32) (fs.Node, error) {
 	return node, nil
 }
 
+// checkStatfsMode returns the statfsMode mounted with, the volume if unset.
+func checkStatfsMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return proto.StatfsModeVolume, nil
+	case proto.StatfsModeQuota, proto.StatfsModeVolume:
+		return mode, nil
+	}
+	return "", errors.New(fmt.Sprintf("invalid statfsMode(%v), must be %v or %v", mode, proto.StatfsModeQuota, proto.StatfsModeVolume))
+}
+
 // Statfs handles the Statfs request and returns a set of statistics.
 func (s *Super) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.StatfsResponse) error {
 	const defaultMaxMetaPartitionInodeID uint64 = 1<<63 - 1
 	total, used, inodeCount := s.mw.Statfs()
+	free, files, ffree := total-used, inodeCount, defaultMaxMetaPartitionInodeID-inodeCount
+	if s.statfsMode == proto.StatfsModeQuota {
+		if quota := s.mw.MountQuota(); quota != nil {
+			total, free, files, ffree = quotaStatfs(quota, total, free, files, ffree)
+		}
+	}
 	resp.Blocks = total / uint64(DefaultBlksize)
-	resp.Bfree = (total - used) / uint64(DefaultBlksize)
+	resp.Bfree = free / uint64(DefaultBlksize)
 	resp.Bavail = resp.Bfree
 	resp.Bsize = DefaultBlksize
 	resp.Namelen = DefaultMaxNameLen
 	resp.Frsize = DefaultBlksize
-	resp.Files = inodeCount
-	resp.Ffree = defaultMaxMetaPartitionInodeID - inodeCount
+	resp.Files = files
+	resp.Ffree = ffree
 	return nil
 }
 
+// quotaStatfs narrows the space and inodes of the volume to the limits of the
+// quota. A dimension the quota does not limit keeps the volume values.
+func quotaStatfs(quota *proto.QuotaInfo, total, free, files, ffree uint64) (uint64, uint64, uint64, uint64) {
+	if quota.MaxBytes != math.MaxUint64 {
+		if quota.MaxBytes < total {
+			total = quota.MaxBytes
+		}
+		if left := quotaLeft(quota.MaxBytes, quota.UsedInfo.UsedBytes); left < free {
+			free = left
+		}
+	}
+	if quota.MaxFiles != math.MaxUint64 {
+		files, ffree = quota.MaxFiles, quotaLeft(quota.MaxFiles, quota.UsedInfo.UsedFiles)
+	}
+	return total, free, files, ffree
+}
+
+func quotaLeft(max uint64, used int64) uint64 {
+	if used <= 0 {
+		return max
+	}
+	if uint64(used) >= max {
+		return 0
+	}
+	return max - uint64(used)
+}
+
 // ClusterName returns the cluster name.
 func (s *Super) ClusterName() string {
 	return s.cluster
@@ -428,6 +518,24 @@ func (s *Super) SetRate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SetFenceToken sets the fencing token acquired from master for the writes of the
+// mount, a token of 0 sends none.
+func (s *Super) SetFenceToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		replyFail(w, r, err.Error())
+		return
+	}
+	token, err := strconv.ParseUint(r.FormValue("token"), 10, 64)
+	if err != nil {
+		replyFail(w, r, fmt.Sprintf("invalid token: %v\n", err))
+		return
+	}
+	s.mw.SetFenceToken(token)
+	s.ec.SetFenceToken(token)
+	log.LogWarnf("SetFenceToken: vol(%v) writes with fence token %v", s.volname, token)
+	w.Write([]byte(fmt.Sprintf("Set fence token to %v successfully\n", token)))
+}
+
 func (s *Super) umpKey(act string) string {
 	return fmt.Sprintf("%v_fuseclient_%v", s.cluster, act)
 }
@@ -776,6 +884,9 @@ func getDelInodes(src []uint64, act []*proto.InodeInfo) []uint64 {
 
 func (s *Super) Close() {
 	close(s.closeC)
+	if s.sloRecorder != nil {
+		s.sloRecorder.Stop()
+	}
 	s.mw.Close()
 }
 
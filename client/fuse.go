@@ -22,6 +22,7 @@ package main
 
 import (
 	"bufio"
+	goerrors "errors"
 	"flag"
 	"fmt"
 	"io"
@@ -58,9 +59,11 @@ import (
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/logship"
 	"github.com/cubefs/cubefs/util/stat"
 	sysutil "github.com/cubefs/cubefs/util/sys"
 	"github.com/cubefs/cubefs/util/ump"
+	"github.com/google/uuid"
 	"github.com/jacobsa/daemonize"
 	_ "go.uber.org/automaxprocs"
 )
@@ -95,6 +98,9 @@ const (
 	ControlCommandFreeOSMemory = "/debug/freeosmemory"
 	ControlCommandSuspend      = "/suspend"
 	ControlCommandResume       = "/resume"
+	ControlCommandExtentCount  = "/file/extents"
+	ControlCommandDefrag       = "/file/defrag"
+	ControlCommandSetFence     = "/fence/set"
 	Role                       = "Client"
 
 	DefaultIP            = "127.0.0.1"
@@ -377,7 +383,7 @@ func main() {
 	for retry := 0; retry < MasterRetrys; retry++ {
 		err = loadConfFromMaster(opt)
 		// if vol not exists or vol name not match regexp, not retry
-		if err != nil && err.Error() != proto.ErrVolNotExists.Error() && err.Error() != proto.ErrVolNameRegExpNotMatch.Error() {
+		if err != nil && !goerrors.Is(err, proto.ErrVolNotExists) && err.Error() != proto.ErrVolNameRegExpNotMatch.Error() {
 			time.Sleep(5 * time.Second * time.Duration(retry+1))
 		} else {
 			break
@@ -398,6 +404,19 @@ func main() {
 	if opt.ReqChanCnt > 0 {
 		stream.SetReqChansize(int(opt.ReqChanCnt))
 	}
+	if opt.PacketCompressThreshold > 0 {
+		stream.SetPacketCompressThreshold(int(opt.PacketCompressThreshold))
+	}
+	if opt.EnableTLS {
+		tlsConfig, err := util.NewClientTLSConfig(opt.TLSCAFile)
+		if err != nil {
+			err = errors.NewErrorf("load tls CA failed: %v\n", err)
+			fmt.Println(err)
+			daemonize.SignalOutcome(err)
+			os.Exit(1)
+		}
+		stream.SetPacketTLSConfig(tlsConfig)
+	}
 
 	level := parseLogLevel(opt.Loglvl)
 	_, err = log.InitLog(opt.Logpath, opt.Volname, level, nil, log.DefaultLogLeftSpaceLimitRatio)
@@ -439,6 +458,18 @@ func main() {
 		}
 	}
 
+	shipper, err := logship.New(cfg, path.Join(opt.Logpath, LoggerPrefix), LoggerPrefix)
+	if err != nil {
+		err = errors.NewErrorf("Init log shipper fail: %v\n", err)
+		fmt.Println(err)
+		daemonize.SignalOutcome(err)
+		os.Exit(1)
+	}
+	if shipper != nil {
+		shipper.Start()
+		defer shipper.Stop()
+	}
+
 	proto.InitBufferPoolEx(opt.BuffersTotalLimit, int(opt.BufferChanSize))
 	log.LogInfof("InitBufferPoolEx: total limit %d, chan size %d", opt.BuffersTotalLimit, opt.BufferChanSize)
 	if proto.IsCold(opt.VolType) || proto.IsStorageClassBlobStore(opt.VolStorageClass) {
@@ -746,6 +777,8 @@ func mount(opt *proto.MountOptions) (fsConn *fuse.Conn, super *cfs.Super, err er
 	}
 
 	master.BcacheOnlyForNotSSD = opt.EnableBcache && opt.BcacheOnlyForNotSSD
+	master.ClientMountID = uuid.New().String()
+	syslog.Printf("mount id %v", master.ClientMountID)
 	super, err = cfs.NewSuper(opt)
 	if err != nil {
 		log.LogError(errors.Stack(err))
@@ -759,6 +792,9 @@ func mount(opt *proto.MountOptions) (fsConn *fuse.Conn, super *cfs.Super, err er
 	http.HandleFunc(log.GetLogPath, log.GetLog)
 	http.HandleFunc(ControlCommandSuspend, super.SetSuspend)
 	http.HandleFunc(ControlCommandResume, super.SetResume)
+	http.HandleFunc(ControlCommandExtentCount, super.GetFragmentation)
+	http.HandleFunc(ControlCommandDefrag, super.Defrag)
+	http.HandleFunc(ControlCommandSetFence, super.SetFenceToken)
 	// auditlog
 	http.HandleFunc(auditlog.EnableAuditLogReqPath, super.EnableAuditLog)
 	http.HandleFunc(auditlog.DisableAuditLogReqPath, auditlog.DisableAuditLog)
@@ -804,7 +840,7 @@ func mount(opt *proto.MountOptions) (fsConn *fuse.Conn, super *cfs.Super, err er
 			volumeInfo, err = mc.AdminAPI().GetVolumeSimpleInfo(opt.Volname)
 			if err != nil {
 				log.LogErrorf("UpdateVolConf: get vol info from master failed, err %s", err.Error())
-				if err.Error() == proto.ErrVolNotExists.Error() {
+				if goerrors.Is(err, proto.ErrVolNotExists) {
 					log.LogErrorf("volume %v not exist, stop client\n", opt.Volname)
 					log.LogFlush()
 					daemonize.SignalOutcome(err)
@@ -917,6 +953,9 @@ func parseMountOption(cfg *config.Config) (*proto.MountOptions, error) {
 	opt := new(proto.MountOptions)
 
 	proto.ParseMountOptions(GlobalMountOptions, cfg)
+	if opt.MountProfile, err = applyMountProfile(cfg); err != nil {
+		return nil, err
+	}
 
 	rawmnt := GlobalMountOptions[proto.MountPoint].GetString()
 	opt.MountPoint, err = filepath.Abs(rawmnt)
@@ -1005,6 +1044,15 @@ func parseMountOption(cfg *config.Config) (*proto.MountOptions, error) {
 	opt.DisableMountSubtype = GlobalMountOptions[proto.DisableMountSubtype].GetBool()
 	opt.StreamRetryTimeout = int(GlobalMountOptions[proto.StreamRetryTimeOut].GetInt64())
 	opt.ForceRemoteCache = GlobalMountOptions[proto.ForceRemoteCache].GetBool()
+	opt.PacketCompressThreshold = GlobalMountOptions[proto.PacketCompressThreshold].GetInt64()
+	opt.EnableTLS = GlobalMountOptions[proto.EnableTLS].GetBool()
+	opt.TLSCAFile = GlobalMountOptions[proto.TLSCAFile].GetString()
+	opt.WriteCoalesceSize = GlobalMountOptions[proto.WriteCoalesceSize].GetInt64()
+	opt.WriteCoalesceWindowMs = GlobalMountOptions[proto.WriteCoalesceWindowMs].GetInt64()
+	opt.SessionResumeTimeout = GlobalMountOptions[proto.SessionResumeTimeout].GetInt64()
+	opt.RemoteCacheReadPolicy = GlobalMountOptions[proto.RemoteCacheReadPolicy].GetString()
+	opt.ZoneName = GlobalMountOptions[proto.ZoneName].GetString()
+	opt.StatfsMode = GlobalMountOptions[proto.StatfsMode].GetString()
 	opt.AheadReadEnable = GlobalMountOptions[proto.AheadReadEnable].GetBool()
 	if opt.AheadReadEnable {
 		var (
@@ -1032,6 +1080,25 @@ func parseMountOption(cfg *config.Config) (*proto.MountOptions, error) {
 		return nil, errors.New(fmt.Sprintf("invalid fields, BuffersTotalLimit(%v) must larger or equal than 0", opt.BuffersTotalLimit))
 	}
 
+	if opt.WriteCoalesceSize < 0 || opt.WriteCoalesceSize > util.ExtentSize {
+		return nil, errors.New(fmt.Sprintf("invalid fields, WriteCoalesceSize(%v) must be in [0, %v]", opt.WriteCoalesceSize, util.ExtentSize))
+	}
+
+	if opt.SessionResumeTimeout < 0 {
+		return nil, errors.New(fmt.Sprintf("invalid fields, SessionResumeTimeout(%v) must larger or equal than 0", opt.SessionResumeTimeout))
+	}
+
+	switch opt.RemoteCacheReadPolicy {
+	case "", proto.RemoteCacheReadHedge, proto.RemoteCacheReadFallback:
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid fields, RemoteCacheReadPolicy(%v) must be %v or %v",
+			opt.RemoteCacheReadPolicy, proto.RemoteCacheReadHedge, proto.RemoteCacheReadFallback))
+	}
+
+	if opt.StatfsMode != proto.StatfsModeQuota && opt.StatfsMode != proto.StatfsModeVolume {
+		return nil, errors.New(fmt.Sprintf("invalid fields, StatfsMode(%v) must be %v or %v", opt.StatfsMode, proto.StatfsModeQuota, proto.StatfsModeVolume))
+	}
+
 	if opt.FileSystemName == "" {
 		opt.FileSystemName = "cubefs-" + opt.Volname
 	}
@@ -1047,6 +1114,23 @@ func parseMountOption(cfg *config.Config) (*proto.MountOptions, error) {
 	return opt, nil
 }
 
+// applyMountProfile takes the options set neither on the command line nor in cfg
+// from the mount profile on master, if the mount refers to one.
+func applyMountProfile(cfg *config.Config) (name string, err error) {
+	if name = GlobalMountOptions[proto.MountProfileName].GetString(); name == "" {
+		return
+	}
+	mc := master.NewMasterClientFromString(GlobalMountOptions[proto.Master].GetString(), false)
+	profile, err := mc.AdminAPI().GetMountProfile(name)
+	if err != nil {
+		return "", errors.Trace(err, "get mount profile(%v) failed", name)
+	}
+	applied := proto.ApplyMountProfile(GlobalMountOptions, cfg, profile)
+	syslog.Printf("mount profile(%v) updated at %v applied options %v\n",
+		name, time.Unix(profile.UpdateTime, 0).Format(proto.TimeFormat), applied)
+	return
+}
+
 func checkPermission(opt *proto.MountOptions) (err error) {
 	mc := master.NewMasterClientFromString(opt.Master, false)
 	localIP, _ := ump.GetLocalIpAddr()
@@ -1130,6 +1214,8 @@ func loadConfFromMaster(opt *proto.MountOptions) (err error) {
 	opt.TxConflictRetryInterval = volumeInfo.TxConflictRetryInterval
 	opt.VolStorageClass = volumeInfo.VolStorageClass
 	opt.VolAllowedStorageClass = volumeInfo.AllowedStorageClass
+	// the data and meta nodes reject the plain packets of a volume requiring TLS
+	opt.EnableTLS = opt.EnableTLS || volumeInfo.RequireTLS
 
 	var clusterInfo *proto.ClusterInfo
 	clusterInfo, err = mc.AdminAPI().GetClusterInfo()
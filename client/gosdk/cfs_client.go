@@ -358,6 +358,36 @@ func (c *Client) GetAttr(path string) (stat *StatInfo, err error) {
 	return stat, nil
 }
 
+// GetLocality returns the hosts holding the range [offset, offset+size) of the
+// file, to the end of it if size is 0.
+func (c *Client) GetLocality(path string, offset, size uint64) (*proto.FileLocality, error) {
+	absPath := c.absPath(path)
+	info, err := c.lookupPath(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if !proto.IsRegular(info.Mode) {
+		return nil, syscall.EINVAL
+	}
+	_, fileSize, eks, err := c.mw.GetExtents(info.Inode, false, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 || offset+size > fileSize {
+		size = 0
+		if offset < fileSize {
+			size = fileSize - offset
+		}
+	}
+	return &proto.FileLocality{
+		Volume: c.cfg.VolName,
+		Path:   absPath,
+		Inode:  info.Inode,
+		Size:   fileSize,
+		Blocks: c.ec.Locality(info.Inode, eks, offset, size),
+	}, nil
+}
+
 func (c *Client) SetAttr(path string, stat *StatInfo, valid uint32) error {
 	info, err := c.lookupPath(c.absPath(path))
 	if err != nil {
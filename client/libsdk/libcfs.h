@@ -182,6 +182,7 @@ extern int cfs_IsDir(mode_t mode);
 extern int cfs_IsRegular(mode_t mode);
 extern int cfs_list_vols(int64_t id, GoSlice cfs_vol_info, int count);
 extern char* cfs_get_xattr(int64_t id, char* path, char* key);
+extern char* cfs_get_locality(int64_t id, char* path, uint64_t offset, uint64_t size);
 extern int cfs_get_accessFiles(int64_t id, char* path, int maxDepth, int goroutine_num, GoSlice cfs_access_file_info, int count);
 
 #ifdef __cplusplus
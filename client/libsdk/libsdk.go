@@ -98,6 +98,7 @@ import "C"
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	syslog "log"
@@ -315,6 +316,49 @@ func cfs_get_xattr(id C.int64_t, path *C.char, key *C.char) *C.char {
 	return C.CString(string(value))
 }
 
+//export cfs_get_locality
+func cfs_get_locality(id C.int64_t, path *C.char, offset C.uint64_t, size C.uint64_t) *C.char {
+	dstPath := C.GoString(path)
+	c, exist := getClient(int64(id))
+	if !exist {
+		log.LogErrorf("cfs_get_locality path(%v) failed, client not exist", dstPath)
+		return C.CString("")
+	}
+
+	absPath := c.absPath(dstPath)
+	info, err := c.lookupPath(absPath)
+	if err != nil {
+		log.LogErrorf("cfs_get_locality path(%v) failed, not found path", dstPath)
+		return C.CString("")
+	}
+	_, fileSize, eks, err := c.mw.GetExtents(info.Inode, false, false, false)
+	if err != nil {
+		log.LogErrorf("cfs_get_locality path(%v) ino(%v) failed, err(%v)", dstPath, info.Inode, err)
+		return C.CString("")
+	}
+	start, length := uint64(offset), uint64(size)
+	if length == 0 || start+length > fileSize {
+		length = 0
+		if start < fileSize {
+			length = fileSize - start
+		}
+	}
+	locality := &proto.FileLocality{
+		Volume: c.volName,
+		Path:   absPath,
+		Inode:  info.Inode,
+		Size:   fileSize,
+		Blocks: c.ec.Locality(info.Inode, eks, start, length),
+	}
+	data, err := json.Marshal(locality)
+	if err != nil {
+		log.LogErrorf("cfs_get_locality path(%v) ino(%v) marshal failed, err(%v)", dstPath, info.Inode, err)
+		return C.CString("")
+	}
+	log.LogDebugf("cfs_get_locality path(%v) ino(%v) blocks(%v) success", dstPath, info.Inode, len(locality.Blocks))
+	return C.CString(string(data))
+}
+
 //export cfs_list_vols
 func cfs_list_vols(id C.int64_t, volsInfo []C.struct_cfs_vol_info, count C.int) (n C.int) {
 	c, exist := getClient(int64(id))
@@ -0,0 +1,56 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// cfs-winfsp mounts a volume as a windows drive through WinFsp.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cubefs/cubefs/client/winfsp"
+	"github.com/cubefs/cubefs/proto"
+)
+
+const Role = "WinClient"
+
+var (
+	configFile    = flag.String("c", "", "windows client config file")
+	configVersion = flag.Bool("v", false, "show version")
+)
+
+func main() {
+	flag.Parse()
+	if *configVersion {
+		fmt.Print(proto.DumpVersion(Role))
+		os.Exit(0)
+	}
+	cfg, err := winfsp.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load config: %v\n", err)
+		os.Exit(1)
+	}
+	fs, err := winfsp.NewFileSystem(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	defer fs.Close()
+	fmt.Printf("mounting volume %v at %v\n", cfg.VolName, cfg.MountPoint)
+	if err = winfsp.Mount(fs); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package winfsp
+
+import (
+	"fmt"
+	"os"
+	gopath "path"
+	"strings"
+
+	"github.com/cubefs/cubefs/util/config"
+)
+
+// The keys of the mount config, named after the ones of the fuse client.
+const (
+	configMountPoint      = "mountPoint"
+	configVolName         = "volName"
+	configMasterAddr      = "masterAddr"
+	configSubDir          = "subdir"
+	configAccessKey       = "accessKey"
+	configSecretKey       = "secretKey"
+	configCredentialsFile = "credentialsFile"
+	configRdonly          = "rdonly"
+	configFollowerRead    = "followerRead"
+	configLogDir          = "logDir"
+	configLogLevel        = "logLevel"
+)
+
+// The environment variables the credentials are taken from when the config
+// and the credentials file leave them out.
+const (
+	EnvAccessKey = "CFS_ACCESS_KEY"
+	EnvSecretKey = "CFS_SECRET_KEY"
+)
+
+// AnyDrive asks WinFsp for the first unused drive letter.
+const AnyDrive = "*"
+
+type Config struct {
+	MountPoint   string // a drive letter as X:, or AnyDrive
+	VolName      string
+	Masters      []string
+	SubDir       string
+	AccessKey    string
+	SecretKey    string
+	Rdonly       bool
+	FollowerRead bool
+	LogDir       string
+	LogLevel     string
+}
+
+// LoadConfig loads the mount config of the file, in the json format of the
+// config of the fuse client. The credentials are taken from the config, or
+// else from the credentials file it names, or else from the environment.
+func LoadConfig(name string) (cfg *Config, err error) {
+	c, err := config.LoadConfigFile(name)
+	if err != nil {
+		return
+	}
+	cfg = &Config{
+		MountPoint:   c.GetString(configMountPoint),
+		VolName:      c.GetString(configVolName),
+		SubDir:       c.GetString(configSubDir),
+		AccessKey:    c.GetString(configAccessKey),
+		SecretKey:    c.GetString(configSecretKey),
+		Rdonly:       c.GetBool(configRdonly),
+		FollowerRead: c.GetBool(configFollowerRead),
+		LogDir:       c.GetString(configLogDir),
+		LogLevel:     c.GetString(configLogLevel),
+	}
+	for _, addr := range strings.Split(c.GetString(configMasterAddr), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			cfg.Masters = append(cfg.Masters, addr)
+		}
+	}
+	if cfg.AccessKey == "" && cfg.SecretKey == "" {
+		if file := c.GetString(configCredentialsFile); file != "" {
+			var cred *config.Config
+			if cred, err = config.LoadConfigFile(file); err != nil {
+				return nil, fmt.Errorf("load credentials file %v: %v", file, err)
+			}
+			cfg.AccessKey, cfg.SecretKey = cred.GetString(configAccessKey), cred.GetString(configSecretKey)
+		} else {
+			cfg.AccessKey, cfg.SecretKey = os.Getenv(EnvAccessKey), os.Getenv(EnvSecretKey)
+		}
+	}
+	if err = cfg.Check(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Check checks the config and normalizes the mount point and the sub dir.
+func (cfg *Config) Check() (err error) {
+	if cfg.MountPoint, err = ParseDrive(cfg.MountPoint); err != nil {
+		return
+	}
+	if cfg.VolName == "" {
+		return fmt.Errorf("%v is required", configVolName)
+	}
+	if len(cfg.Masters) == 0 {
+		return fmt.Errorf("%v is required", configMasterAddr)
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return fmt.Errorf("%v and %v are required, in the config, the %v or the %v and %v environment",
+			configAccessKey, configSecretKey, configCredentialsFile, EnvAccessKey, EnvSecretKey)
+	}
+	cfg.SubDir = gopath.Clean("/" + cfg.SubDir)
+	return
+}
+
+// ParseDrive parses a drive letter as X, X: or X:\ into X:, or AnyDrive.
+func ParseDrive(s string) (string, error) {
+	if s == AnyDrive {
+		return s, nil
+	}
+	drive := strings.TrimSuffix(strings.TrimSuffix(s, `\`), ":")
+	if len(drive) != 1 || !(drive[0] >= 'A' && drive[0] <= 'Z' || drive[0] >= 'a' && drive[0] <= 'z') {
+		return "", fmt.Errorf("mount point(%v) is not a drive letter as X: or %v", s, AnyDrive)
+	}
+	return strings.ToUpper(drive) + ":", nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package winfsp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDrive(t *testing.T) {
+	for _, s := range []string{"x", "X:", `x:\`} {
+		drive, err := ParseDrive(s)
+		require.NoError(t, err)
+		require.Equal(t, "X:", drive)
+	}
+	drive, err := ParseDrive(AnyDrive)
+	require.NoError(t, err)
+	require.Equal(t, AnyDrive, drive)
+	for _, s := range []string{"", "XY:", "1:", `C:\mnt`} {
+		_, err = ParseDrive(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		name = filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+		return name
+	}
+
+	cfg, err := LoadConfig(write("inline.json", `{"mountPoint":"y","volName":"vol","masterAddr":"m1:17010, m2:17010",
+		"subdir":"a/b","accessKey":"ak","secretKey":"sk","rdonly":"true"}`))
+	require.NoError(t, err)
+	require.Equal(t, "Y:", cfg.MountPoint)
+	require.Equal(t, []string{"m1:17010", "m2:17010"}, cfg.Masters)
+	require.Equal(t, "/a/b", cfg.SubDir)
+	require.Equal(t, "ak", cfg.AccessKey)
+	require.True(t, cfg.Rdonly)
+
+	cred := write("cred.json", `{"accessKey":"fileAk","secretKey":"fileSk"}`)
+	cfg, err = LoadConfig(write("file.json", `{"mountPoint":"*","volName":"vol","masterAddr":"m1:17010",
+		"credentialsFile":"`+filepath.ToSlash(cred)+`"}`))
+	require.NoError(t, err)
+	require.Equal(t, "fileAk", cfg.AccessKey)
+	require.Equal(t, "/", cfg.SubDir)
+
+	noCred := write("env.json", `{"mountPoint":"z:","volName":"vol","masterAddr":"m1:17010"}`)
+	t.Setenv(EnvAccessKey, "")
+	t.Setenv(EnvSecretKey, "")
+	_, err = LoadConfig(noCred)
+	require.Error(t, err)
+	t.Setenv(EnvAccessKey, "envAk")
+	t.Setenv(EnvSecretKey, "envSk")
+	cfg, err = LoadConfig(noCred)
+	require.NoError(t, err)
+	require.Equal(t, "envSk", cfg.SecretKey)
+
+	_, err = LoadConfig(write("nodrive.json", `{"mountPoint":"/mnt/cfs","volName":"vol","masterAddr":"m1:17010"}`))
+	require.Error(t, err)
+}
+
+func TestSplitPath(t *testing.T) {
+	for _, c := range []struct{ path, dir, name string }{
+		{"/a", "/", "a"},
+		{"/a/b/", "/a", "b"},
+		{"a/b", "/a", "b"},
+		{"/", "/", ""},
+	} {
+		dir, name := splitPath(c.path)
+		require.Equal(t, c.dir, dir, c.path)
+		require.Equal(t, c.name, name, c.path)
+	}
+	fs := &FileSystem{cfg: &Config{SubDir: "/sub"}}
+	require.Equal(t, "/sub/a", fs.fullPath("a"))
+	require.Equal(t, "/sub", fs.fullPath("/"))
+}
@@ -0,0 +1,567 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package winfsp
+
+import (
+	"io"
+	"os"
+	gopath "path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/data/stream"
+	masterSDK "github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/sdk/meta"
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The file type bits of the posix mode the fuse layer of WinFsp takes.
+const (
+	modeDir     = 0o040000
+	modeRegular = 0o100000
+	modeSymlink = 0o120000
+	modePerm    = 0o777
+)
+
+const (
+	blockSize = 4096
+	nameMax   = 255
+)
+
+type Attr struct {
+	Ino    uint64
+	Size   uint64
+	Blocks uint64 // of 512 bytes
+	Mode   uint32 // posix
+	Nlink  uint32
+	Uid    uint32
+	Gid    uint32
+	Atime  time.Time
+	Mtime  time.Time
+	Ctime  time.Time
+	Btime  time.Time
+}
+
+type DirEntry struct {
+	Name string
+	Attr *Attr // nil when the inode is not found
+}
+
+type Statfs struct {
+	Bsize  uint32
+	Blocks uint64
+	Bfree  uint64
+	Files  uint64
+}
+
+// handle is an open file.
+type handle struct {
+	ino          uint64
+	pino         uint64
+	path         string
+	write        bool
+	storageClass uint32
+}
+
+// FileSystem serves the path based operations of the fuse layer of WinFsp on
+// a volume through the meta and the data wrappers. The paths are of slashes
+// and relative to the mounted sub dir, and the errors are syscall.Errno.
+type FileSystem struct {
+	cfg *Config
+	mw  *meta.MetaWrapper
+	ec  *stream.ExtentClient
+
+	sync.Mutex
+	handles    map[uint64]*handle
+	nextHandle uint64
+}
+
+// NewFileSystem checks the credentials on the volume and connects to it. Only
+// the volumes of replicas are served, as the blob store is not reachable from
+// windows.
+func NewFileSystem(cfg *Config) (fs *FileSystem, err error) {
+	if cfg.LogDir != "" && log.LogDir == "" {
+		if _, err = log.InitLog(cfg.LogDir, "winfsp", parseLogLevel(cfg.LogLevel), nil, log.DefaultLogLeftSpaceLimitRatio); err != nil {
+			return
+		}
+	}
+	proto.InitBufferPool(int64(32768))
+	mc := masterSDK.NewMasterClient(cfg.Masters, false)
+	volView, err := mc.AdminAPI().GetVolumeSimpleInfo(cfg.VolName)
+	if err != nil {
+		return nil, errors.NewErrorf("get volume %v: %v", cfg.VolName, err)
+	}
+	if proto.IsCold(volView.VolType) {
+		return nil, errors.NewErrorf("volume %v is of the blob store, which is not supported on windows", cfg.VolName)
+	}
+	if err = checkPermission(mc, cfg); err != nil {
+		return nil, errors.NewErrorf("check permission: %v", err)
+	}
+
+	fs = &FileSystem{cfg: cfg, handles: make(map[uint64]*handle)}
+	if fs.mw, err = meta.NewMetaWrapper(&meta.MetaConfig{
+		Volume:  cfg.VolName,
+		Masters: cfg.Masters,
+		SubDir:  cfg.SubDir,
+	}); err != nil {
+		return nil, errors.NewErrorf("new meta wrapper: %v", err)
+	}
+	if _, err = fs.mw.GetRootIno(cfg.SubDir); err != nil {
+		fs.mw.Close()
+		return nil, err
+	}
+	if fs.ec, err = stream.NewExtentClient(&stream.ExtentConfig{
+		Volume:                      cfg.VolName,
+		Masters:                     cfg.Masters,
+		FollowerRead:                cfg.FollowerRead,
+		OnAppendExtentKey:           fs.mw.AppendExtentKey,
+		OnGetExtents:                fs.mw.GetExtents,
+		OnTruncate:                  fs.mw.Truncate,
+		DisableMetaCache:            true,
+		VolStorageClass:             volView.VolStorageClass,
+		VolAllowedStorageClass:      volView.AllowedStorageClass,
+		OnRenewalForbiddenMigration: fs.mw.RenewalForbiddenMigration,
+		OnForbiddenMigration:        fs.mw.ForbiddenMigration,
+		MetaWrapper:                 fs.mw,
+	}); err != nil {
+		fs.mw.Close()
+		return nil, errors.NewErrorf("new extent client: %v", err)
+	}
+	return
+}
+
+// checkPermission checks the credentials, and mounts read only if the user
+// may only read the volume.
+func checkPermission(mc *masterSDK.MasterClient, cfg *Config) (err error) {
+	userInfo, err := mc.UserAPI().GetAKInfo(cfg.AccessKey)
+	if err != nil {
+		return
+	}
+	if userInfo.SecretKey != cfg.SecretKey {
+		return proto.ErrNoPermission
+	}
+	policy := userInfo.Policy
+	if policy.IsOwn(cfg.VolName) {
+		return
+	}
+	if !policy.IsAuthorized(cfg.VolName, cfg.SubDir, proto.POSIXReadAction) {
+		return proto.ErrNoPermission
+	}
+	if !policy.IsAuthorized(cfg.VolName, cfg.SubDir, proto.POSIXWriteAction) {
+		cfg.Rdonly = true
+	}
+	return
+}
+
+func parseLogLevel(level string) log.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return log.DebugLevel
+	case "info":
+		return log.InfoLevel
+	case "warn":
+		return log.WarnLevel
+	case "fatal":
+		return log.FatalLevel
+	}
+	return log.ErrorLevel
+}
+
+func (fs *FileSystem) Close() {
+	fs.Lock()
+	for fh, h := range fs.handles {
+		fs.closeStream(h)
+		delete(fs.handles, fh)
+	}
+	fs.Unlock()
+	fs.ec.Close()
+	fs.mw.Close()
+	log.LogFlush()
+}
+
+// fullPath returns the path in the volume.
+func (fs *FileSystem) fullPath(path string) string {
+	return gopath.Join(fs.cfg.SubDir, gopath.Clean("/"+path))
+}
+
+// splitPath splits the path into the cleaned path of the parent and the name.
+func splitPath(path string) (dir, name string) {
+	dir, name = gopath.Split(gopath.Clean("/" + path))
+	return gopath.Clean(dir), name
+}
+
+func (fs *FileSystem) lookup(path string) (*proto.InodeInfo, error) {
+	ino, err := fs.mw.LookupPath(fs.fullPath(path))
+	if err != nil {
+		return nil, err
+	}
+	return fs.mw.InodeGet_ll(ino)
+}
+
+func (fs *FileSystem) lookupParent(path string) (pino uint64, name string, err error) {
+	dir, name := splitPath(path)
+	if name == "" {
+		return 0, "", syscall.EPERM
+	}
+	if len(name) > nameMax {
+		return 0, "", syscall.ENAMETOOLONG
+	}
+	pino, err = fs.mw.LookupPath(fs.fullPath(dir))
+	return
+}
+
+func (fs *FileSystem) checkWritable() error {
+	if fs.cfg.Rdonly {
+		return syscall.EROFS
+	}
+	return nil
+}
+
+func modeOf(info *proto.InodeInfo) (mode uint32) {
+	switch {
+	case proto.IsDir(info.Mode):
+		mode = modeDir
+	case proto.IsSymlink(info.Mode):
+		mode = modeSymlink
+	default:
+		mode = modeRegular
+	}
+	return mode | info.Mode&modePerm
+}
+
+func (fs *FileSystem) attrOf(info *proto.InodeInfo) *Attr {
+	size := info.Size
+	if proto.IsRegular(info.Mode) {
+		// take the size of the data not flushed yet
+		if s, gen, valid := fs.ec.FileSize(info.Inode); valid && gen >= info.Generation {
+			size = uint64(s)
+		}
+	}
+	return &Attr{
+		Ino:    info.Inode,
+		Size:   size,
+		Blocks: (size + 511) >> 9,
+		Mode:   modeOf(info),
+		Nlink:  info.Nlink,
+		Uid:    info.Uid,
+		Gid:    info.Gid,
+		Atime:  info.AccessTime,
+		Mtime:  info.ModifyTime,
+		Ctime:  info.ModifyTime,
+		Btime:  info.CreateTime,
+	}
+}
+
+func (fs *FileSystem) Getattr(path string) (*Attr, error) {
+	info, err := fs.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.attrOf(info), nil
+}
+
+func (fs *FileSystem) Readlink(path string) (string, error) {
+	info, err := fs.lookup(path)
+	if err != nil {
+		return "", err
+	}
+	if !proto.IsSymlink(info.Mode) {
+		return "", syscall.EINVAL
+	}
+	return string(info.Target), nil
+}
+
+// Readdir lists the dir with the attrs of the entries, to spare the explorer a
+// getattr on each of them.
+func (fs *FileSystem) Readdir(path string) ([]DirEntry, error) {
+	ino, err := fs.mw.LookupPath(fs.fullPath(path))
+	if err != nil {
+		return nil, err
+	}
+	dentries, err := fs.mw.ReadDir_ll(ino)
+	if err != nil {
+		return nil, err
+	}
+	inodes := make([]uint64, 0, len(dentries))
+	for _, d := range dentries {
+		inodes = append(inodes, d.Inode)
+	}
+	infos := make(map[uint64]*proto.InodeInfo, len(dentries))
+	for _, info := range fs.mw.BatchInodeGet(inodes) {
+		infos[info.Inode] = info
+	}
+	entries := make([]DirEntry, 0, len(dentries))
+	for _, d := range dentries {
+		entry := DirEntry{Name: d.Name}
+		if info, ok := infos[d.Inode]; ok {
+			entry.Attr = fs.attrOf(info)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (fs *FileSystem) Mkdir(path string, mode uint32) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	pino, name, err := fs.lookupParent(path)
+	if err != nil {
+		return
+	}
+	_, err = fs.mw.Create_ll(pino, name, mode&modePerm|uint32(os.ModeDir), 0, 0, nil, fs.fullPath(path), false)
+	return
+}
+
+func (fs *FileSystem) Rmdir(path string) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	pino, name, err := fs.lookupParent(path)
+	if err != nil {
+		return
+	}
+	_, err = fs.mw.Delete_ll(pino, name, true, fs.fullPath(path))
+	return
+}
+
+func (fs *FileSystem) Unlink(path string) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	pino, name, err := fs.lookupParent(path)
+	if err != nil {
+		return
+	}
+	_, mode, err := fs.mw.Lookup_ll(pino, name)
+	if err != nil {
+		return
+	}
+	if proto.IsDir(mode) {
+		return syscall.EISDIR
+	}
+	info, err := fs.mw.Delete_ll(pino, name, false, fs.fullPath(path))
+	if err != nil {
+		return
+	}
+	if info != nil {
+		_ = fs.mw.Evict(info.Inode, fs.fullPath(path))
+	}
+	return
+}
+
+// Rename renames the file, replacing the one of the new path as windows asks.
+func (fs *FileSystem) Rename(oldPath, newPath string) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	srcPino, srcName, err := fs.lookupParent(oldPath)
+	if err != nil {
+		return
+	}
+	dstPino, dstName, err := fs.lookupParent(newPath)
+	if err != nil {
+		return
+	}
+	return fs.mw.Rename_ll(srcPino, srcName, dstPino, dstName, fs.fullPath(oldPath), fs.fullPath(newPath), true)
+}
+
+func (fs *FileSystem) Chmod(path string, mode uint32) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	info, err := fs.lookup(path)
+	if err != nil {
+		return
+	}
+	return fs.mw.Setattr(info.Inode, proto.AttrMode, info.Mode&^modePerm|mode&modePerm, 0, 0, 0, 0)
+}
+
+func (fs *FileSystem) Utimens(path string, atime, mtime time.Time) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	info, err := fs.lookup(path)
+	if err != nil {
+		return
+	}
+	return fs.mw.Setattr(info.Inode, proto.AttrAccessTime|proto.AttrModifyTime, 0, 0, 0, atime.Unix(), mtime.Unix())
+}
+
+func (fs *FileSystem) Statfs() *Statfs {
+	total, used, inodeCount := fs.mw.Statfs()
+	free := uint64(0)
+	if total > used {
+		free = total - used
+	}
+	return &Statfs{Bsize: blockSize, Blocks: total / blockSize, Bfree: free / blockSize, Files: inodeCount}
+}
+
+func (fs *FileSystem) openHandle(info *proto.InodeInfo, pino uint64, path string, write bool) (fh uint64, err error) {
+	if err = fs.ec.OpenStream(info.Inode, write, false, fs.fullPath(path)); err != nil {
+		return
+	}
+	h := &handle{ino: info.Inode, pino: pino, path: fs.fullPath(path), write: write, storageClass: info.StorageClass}
+	fs.Lock()
+	fs.nextHandle++
+	fh = fs.nextHandle
+	fs.handles[fh] = h
+	fs.Unlock()
+	return
+}
+
+func (fs *FileSystem) getHandle(fh uint64) (*handle, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	h, ok := fs.handles[fh]
+	if !ok {
+		return nil, syscall.EBADF
+	}
+	return h, nil
+}
+
+func (fs *FileSystem) closeStream(h *handle) {
+	if h.write {
+		if err := fs.ec.Flush(h.ino); err != nil {
+			log.LogErrorf("winfsp: flush ino(%v) path(%v) err(%v)", h.ino, h.path, err)
+		}
+	}
+	_ = fs.ec.CloseStream(h.ino)
+	_ = fs.ec.EvictStream(h.ino)
+}
+
+// Create creates and opens a file for writing.
+func (fs *FileSystem) Create(path string, mode uint32) (fh uint64, err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	pino, name, err := fs.lookupParent(path)
+	if err != nil {
+		return
+	}
+	info, err := fs.mw.Create_ll(pino, name, mode&modePerm, 0, 0, nil, fs.fullPath(path), false)
+	if err != nil {
+		return
+	}
+	return fs.openHandle(info, pino, path, true)
+}
+
+func (fs *FileSystem) Open(path string, write bool) (fh uint64, err error) {
+	if write {
+		if err = fs.checkWritable(); err != nil {
+			return
+		}
+	}
+	pino, _, err := fs.lookupParent(path)
+	if err != nil {
+		return
+	}
+	info, err := fs.lookup(path)
+	if err != nil {
+		return
+	}
+	if !proto.IsRegular(info.Mode) {
+		return 0, syscall.EISDIR
+	}
+	return fs.openHandle(info, pino, path, write)
+}
+
+func (fs *FileSystem) Read(fh uint64, buf []byte, off int64) (n int, err error) {
+	h, err := fs.getHandle(fh)
+	if err != nil {
+		return
+	}
+	n, err = fs.ec.Read(h.ino, buf, int(off), len(buf), h.storageClass, false)
+	if err == io.EOF {
+		err = nil
+	}
+	return
+}
+
+func (fs *FileSystem) Write(fh uint64, data []byte, off int64) (n int, err error) {
+	h, err := fs.getHandle(fh)
+	if err != nil {
+		return
+	}
+	if !h.write {
+		return 0, syscall.EBADF
+	}
+	fs.ec.GetStreamer(h.ino).SetParentInode(h.pino)
+	checkFunc := func() error {
+		if fs.mw.EnableQuota && fs.mw.IsQuotaLimitedById(h.ino, true, false) {
+			return syscall.ENOSPC
+		}
+		return nil
+	}
+	return fs.ec.Write(h.ino, int(off), data, 0, checkFunc, h.storageClass, false)
+}
+
+func (fs *FileSystem) Flush(fh uint64) error {
+	h, err := fs.getHandle(fh)
+	if err != nil {
+		return err
+	}
+	if !h.write {
+		return nil
+	}
+	return fs.ec.Flush(h.ino)
+}
+
+func (fs *FileSystem) Release(fh uint64) error {
+	fs.Lock()
+	h, ok := fs.handles[fh]
+	delete(fs.handles, fh)
+	fs.Unlock()
+	if !ok {
+		return syscall.EBADF
+	}
+	fs.closeStream(h)
+	return nil
+}
+
+// Truncate truncates the file of the handle, or of the path if fh is 0.
+func (fs *FileSystem) Truncate(path string, fh uint64, size int64) (err error) {
+	if err = fs.checkWritable(); err != nil {
+		return
+	}
+	if fh != 0 {
+		var h *handle
+		if h, err = fs.getHandle(fh); err != nil {
+			return
+		}
+		return fs.ec.Truncate(fs.mw, h.pino, h.ino, int(size), h.path)
+	}
+	pino, _, err := fs.lookupParent(path)
+	if err != nil {
+		return
+	}
+	info, err := fs.lookup(path)
+	if err != nil {
+		return
+	}
+	if !proto.IsRegular(info.Mode) {
+		return syscall.EISDIR
+	}
+	if err = fs.ec.OpenStream(info.Inode, true, false, fs.fullPath(path)); err != nil {
+		return
+	}
+	err = fs.ec.Truncate(fs.mw, pino, info.Inode, int(size), fs.fullPath(path))
+	fs.closeStream(&handle{ino: info.Inode, path: fs.fullPath(path)})
+	return
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+#include <fcntl.h>
+#include <string.h>
+
+#include "host_windows.h"
+#include "_cgo_export.h"
+
+static void cfs_stat(const cfs_attr *a, struct fuse_stat *st)
+{
+	memset(st, 0, sizeof(*st));
+	st->st_ino = a->ino;
+	st->st_mode = a->mode;
+	st->st_nlink = a->nlink;
+	st->st_uid = a->uid;
+	st->st_gid = a->gid;
+	st->st_size = a->size;
+	st->st_blksize = 4096;
+	st->st_blocks = a->blocks;
+	st->st_atim.tv_sec = a->atime;
+	st->st_atim.tv_nsec = a->atimensec;
+	st->st_mtim.tv_sec = a->mtime;
+	st->st_mtim.tv_nsec = a->mtimensec;
+	st->st_ctim.tv_sec = a->ctime;
+	st->st_ctim.tv_nsec = a->ctimensec;
+	st->st_birthtim.tv_sec = a->btime;
+	st->st_birthtim.tv_nsec = a->btimensec;
+}
+
+int cfs_fill_dir(fuse_fill_dir_t filler, void *buf, char *name, cfs_attr *attr)
+{
+	struct fuse_stat st;
+
+	if (attr == NULL)
+		return filler(buf, name, NULL, 0);
+	cfs_stat(attr, &st);
+	return filler(buf, name, &st, 0);
+}
+
+static int cfs_getattr(const char *path, struct fuse_stat *st)
+{
+	cfs_attr a;
+	int err = cfsGetattr((char *)path, &a);
+
+	if (err == 0)
+		cfs_stat(&a, st);
+	return err;
+}
+
+static int cfs_fgetattr(const char *path, struct fuse_stat *st, struct fuse_file_info *fi)
+{
+	return cfs_getattr(path, st);
+}
+
+static int cfs_readlink(const char *path, char *buf, size_t size)
+{
+	return cfsReadlink((char *)path, buf, size);
+}
+
+static int cfs_mkdir(const char *path, fuse_mode_t mode)
+{
+	return cfsMkdir((char *)path, mode);
+}
+
+static int cfs_unlink(const char *path)
+{
+	return cfsUnlink((char *)path);
+}
+
+static int cfs_rmdir(const char *path)
+{
+	return cfsRmdir((char *)path);
+}
+
+static int cfs_rename(const char *oldpath, const char *newpath)
+{
+	return cfsRename((char *)oldpath, (char *)newpath);
+}
+
+static int cfs_chmod(const char *path, fuse_mode_t mode)
+{
+	return cfsChmod((char *)path, mode);
+}
+
+static int cfs_truncate(const char *path, fuse_off_t size)
+{
+	return cfsTruncate((char *)path, 0, size);
+}
+
+static int cfs_ftruncate(const char *path, fuse_off_t size, struct fuse_file_info *fi)
+{
+	return cfsTruncate((char *)path, fi->fh, size);
+}
+
+static int cfs_utimens(const char *path, const struct fuse_timespec tv[2])
+{
+	return cfsUtimens((char *)path, tv[0].tv_sec, tv[0].tv_nsec, tv[1].tv_sec, tv[1].tv_nsec);
+}
+
+static int cfs_open(const char *path, struct fuse_file_info *fi)
+{
+	uint64_t fh;
+	int err = cfsOpen((char *)path, (fi->flags & (O_WRONLY | O_RDWR)) != 0, &fh);
+
+	if (err == 0)
+		fi->fh = fh;
+	return err;
+}
+
+static int cfs_create(const char *path, fuse_mode_t mode, struct fuse_file_info *fi)
+{
+	uint64_t fh;
+	int err = cfsCreate((char *)path, mode, &fh);
+
+	if (err == 0)
+		fi->fh = fh;
+	return err;
+}
+
+static int cfs_read(const char *path, char *buf, size_t size, fuse_off_t off, struct fuse_file_info *fi)
+{
+	return cfsRead(fi->fh, buf, size, off);
+}
+
+static int cfs_write(const char *path, const char *buf, size_t size, fuse_off_t off, struct fuse_file_info *fi)
+{
+	return cfsWrite(fi->fh, (char *)buf, size, off);
+}
+
+static int cfs_flush(const char *path, struct fuse_file_info *fi)
+{
+	return cfsFlush(fi->fh);
+}
+
+static int cfs_fsync(const char *path, int datasync, struct fuse_file_info *fi)
+{
+	return cfsFlush(fi->fh);
+}
+
+static int cfs_release(const char *path, struct fuse_file_info *fi)
+{
+	return cfsRelease(fi->fh);
+}
+
+static int cfs_readdir(const char *path, void *buf, fuse_fill_dir_t filler, fuse_off_t off, struct fuse_file_info *fi)
+{
+	return cfsReaddir((char *)path, filler, buf);
+}
+
+static int cfs_statfs(const char *path, struct fuse_statvfs *stbuf)
+{
+	cfs_fsstat s;
+	int err = cfsStatfs(&s);
+
+	if (err != 0)
+		return err;
+	memset(stbuf, 0, sizeof(*stbuf));
+	stbuf->f_bsize = s.bsize;
+	stbuf->f_frsize = s.bsize;
+	stbuf->f_blocks = s.blocks;
+	stbuf->f_bfree = s.bfree;
+	stbuf->f_bavail = s.bfree;
+	stbuf->f_files = s.files;
+	stbuf->f_namemax = s.namemax;
+	return 0;
+}
+
+// cfs_fuse is the file system mounted, set once the drive is up.
+static struct fuse *volatile cfs_fuse;
+
+static void *cfs_init(struct fuse_conn_info *conn)
+{
+	struct fuse_context *ctx = fuse_get_context();
+
+	cfs_fuse = ctx->fuse;
+	return ctx->private_data;
+}
+
+static struct fuse_operations cfs_ops = {
+	.init      = cfs_init,
+	.getattr   = cfs_getattr,
+	.fgetattr  = cfs_fgetattr,
+	.readlink  = cfs_readlink,
+	.mkdir     = cfs_mkdir,
+	.unlink    = cfs_unlink,
+	.rmdir     = cfs_rmdir,
+	.rename    = cfs_rename,
+	.chmod     = cfs_chmod,
+	.truncate  = cfs_truncate,
+	.ftruncate = cfs_ftruncate,
+	.utimens   = cfs_utimens,
+	.open      = cfs_open,
+	.create    = cfs_create,
+	.read      = cfs_read,
+	.write     = cfs_write,
+	.flush     = cfs_flush,
+	.fsync     = cfs_fsync,
+	.release   = cfs_release,
+	.readdir   = cfs_readdir,
+	.statfs    = cfs_statfs,
+};
+
+int cfs_mount(int argc, char *argv[])
+{
+	int ret = fuse_main_real(argc, argv, &cfs_ops, sizeof(cfs_ops), NULL);
+
+	cfs_fuse = NULL;
+	return ret;
+}
+
+void cfs_unmount(void)
+{
+	struct fuse *f = cfs_fuse;
+
+	if (f != NULL)
+		fuse_exit(f);
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package winfsp
+
+// The fuse layer of WinFsp is linked with winfsp-x64.dll. Build with the
+// include dir of it in CGO_CFLAGS and the lib dir in CGO_LDFLAGS, as
+//   -I"C:/Program Files (x86)/WinFsp/inc/fuse" and
+//   -L"C:/Program Files (x86)/WinFsp/lib".
+
+/*
+#cgo CFLAGS: -DFUSE_USE_VERSION=28
+#cgo LDFLAGS: -lwinfsp-x64
+
+#include <errno.h>
+#include <stdlib.h>
+#include "host_windows.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// hostFS is the file system the callbacks from c are served by, the
+// FileSystem of a volume, or a fake one in the tests.
+type hostFS interface {
+	Getattr(path string) (*Attr, error)
+	Readlink(path string) (string, error)
+	Readdir(path string) ([]DirEntry, error)
+	Mkdir(path string, mode uint32) error
+	Rmdir(path string) error
+	Unlink(path string) error
+	Rename(oldPath, newPath string) error
+	Chmod(path string, mode uint32) error
+	Utimens(path string, atime, mtime time.Time) error
+	Statfs() *Statfs
+	Create(path string, mode uint32) (uint64, error)
+	Open(path string, write bool) (uint64, error)
+	Read(fh uint64, buf []byte, off int64) (int, error)
+	Write(fh uint64, data []byte, off int64) (int, error)
+	Flush(fh uint64) error
+	Release(fh uint64) error
+	Truncate(path string, fh uint64, size int64) error
+}
+
+// mounted is the file system served, as the callbacks from c carry no context.
+var mounted hostFS
+
+// Mount serves the file system at the drive of the config until it is
+// unmounted, or the process is interrupted.
+func Mount(fs *FileSystem) error {
+	return mount(fs, fs.cfg.MountPoint, fs.cfg.VolName)
+}
+
+func mount(fs hostFS, mountPoint, volName string) error {
+	mounted = fs
+	args := []string{"cfs-winfsp", "-f", mountPoint, "-o",
+		fmt.Sprintf("uid=-1,gid=-1,volname=%v,FileSystemName=CubeFS", volName)}
+	argv := make([]*C.char, len(args))
+	for i, arg := range args {
+		argv[i] = C.CString(arg)
+		defer C.free(unsafe.Pointer(argv[i]))
+	}
+	if ret := C.cfs_mount(C.int(len(argv)), &argv[0]); ret != 0 {
+		return fmt.Errorf("mount %v at %v: %v", volName, mountPoint, ret)
+	}
+	return nil
+}
+
+// Unmount stops serving the file system mounted, so that Mount returns. It
+// does nothing if the drive is not up yet.
+func Unmount() {
+	C.cfs_unmount()
+}
+
+// errno returns the negated errno of the c runtime, which does not share the
+// values of syscall.Errno on windows.
+func errno(err error) C.int {
+	if err == nil {
+		return 0
+	}
+	var e syscall.Errno
+	if !errors.As(err, &e) {
+		return -C.EIO
+	}
+	switch e {
+	case syscall.EPERM:
+		return -C.EPERM
+	case syscall.ENOENT:
+		return -C.ENOENT
+	case syscall.EEXIST:
+		return -C.EEXIST
+	case syscall.ENOTDIR:
+		return -C.ENOTDIR
+	case syscall.EISDIR:
+		return -C.EISDIR
+	case syscall.ENOTEMPTY:
+		return -C.ENOTEMPTY
+	case syscall.EACCES:
+		return -C.EACCES
+	case syscall.EINVAL:
+		return -C.EINVAL
+	case syscall.EBADF, syscall.EBADFD:
+		return -C.EBADF
+	case syscall.ENOSPC, syscall.EDQUOT:
+		return -C.ENOSPC
+	case syscall.EROFS:
+		return -C.EROFS
+	case syscall.ENAMETOOLONG:
+		return -C.ENAMETOOLONG
+	case syscall.EAGAIN:
+		return -C.EAGAIN
+	case syscall.ENOMEM:
+		return -C.ENOMEM
+	}
+	return -C.EIO
+}
+
+func fillAttr(a *Attr, c *C.cfs_attr) {
+	c.ino = C.uint64_t(a.Ino)
+	c.size = C.uint64_t(a.Size)
+	c.blocks = C.uint64_t(a.Blocks)
+	c.mode = C.uint32_t(a.Mode)
+	c.nlink = C.uint32_t(a.Nlink)
+	c.uid = C.uint32_t(a.Uid)
+	c.gid = C.uint32_t(a.Gid)
+	c.atime, c.atimensec = C.int64_t(a.Atime.Unix()), C.int64_t(a.Atime.Nanosecond())
+	c.mtime, c.mtimensec = C.int64_t(a.Mtime.Unix()), C.int64_t(a.Mtime.Nanosecond())
+	c.ctime, c.ctimensec = C.int64_t(a.Ctime.Unix()), C.int64_t(a.Ctime.Nanosecond())
+	c.btime, c.btimensec = C.int64_t(a.Btime.Unix()), C.int64_t(a.Btime.Nanosecond())
+}
+
+//export cfsGetattr
+func cfsGetattr(path *C.char, attr *C.cfs_attr) C.int {
+	a, err := mounted.Getattr(C.GoString(path))
+	if err != nil {
+		return errno(err)
+	}
+	fillAttr(a, attr)
+	return 0
+}
+
+//export cfsReadlink
+func cfsReadlink(path *C.char, buf *C.char, size C.size_t) C.int {
+	target, err := mounted.Readlink(C.GoString(path))
+	if err != nil {
+		return errno(err)
+	}
+	if size == 0 {
+		return -C.EINVAL
+	}
+	b := unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(size))
+	b[copy(b[:len(b)-1], target)] = 0
+	return 0
+}
+
+//export cfsMkdir
+func cfsMkdir(path *C.char, mode C.uint32_t) C.int {
+	return errno(mounted.Mkdir(C.GoString(path), uint32(mode)))
+}
+
+//export cfsUnlink
+func cfsUnlink(path *C.char) C.int {
+	return errno(mounted.Unlink(C.GoString(path)))
+}
+
+//export cfsRmdir
+func cfsRmdir(path *C.char) C.int {
+	return errno(mounted.Rmdir(C.GoString(path)))
+}
+
+//export cfsRename
+func cfsRename(oldPath, newPath *C.char) C.int {
+	return errno(mounted.Rename(C.GoString(oldPath), C.GoString(newPath)))
+}
+
+//export cfsChmod
+func cfsChmod(path *C.char, mode C.uint32_t) C.int {
+	return errno(mounted.Chmod(C.GoString(path), uint32(mode)))
+}
+
+//export cfsTruncate
+func cfsTruncate(path *C.char, fh C.uint64_t, size C.int64_t) C.int {
+	return errno(mounted.Truncate(C.GoString(path), uint64(fh), int64(size)))
+}
+
+//export cfsUtimens
+func cfsUtimens(path *C.char, asec, ansec, msec, mnsec C.int64_t) C.int {
+	return errno(mounted.Utimens(C.GoString(path), time.Unix(int64(asec), int64(ansec)), time.Unix(int64(msec), int64(mnsec))))
+}
+
+//export cfsOpen
+func cfsOpen(path *C.char, write C.int, fh *C.uint64_t) C.int {
+	h, err := mounted.Open(C.GoString(path), write != 0)
+	if err != nil {
+		return errno(err)
+	}
+	*fh = C.uint64_t(h)
+	return 0
+}
+
+//export cfsCreate
+func cfsCreate(path *C.char, mode C.uint32_t, fh *C.uint64_t) C.int {
+	h, err := mounted.Create(C.GoString(path), uint32(mode))
+	if err != nil {
+		return errno(err)
+	}
+	*fh = C.uint64_t(h)
+	return 0
+}
+
+//export cfsRead
+func cfsRead(fh C.uint64_t, buf *C.char, size C.size_t, off C.int64_t) C.int {
+	n, err := mounted.Read(uint64(fh), unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(size)), int64(off))
+	if err != nil {
+		return errno(err)
+	}
+	return C.int(n)
+}
+
+//export cfsWrite
+func cfsWrite(fh C.uint64_t, buf *C.char, size C.size_t, off C.int64_t) C.int {
+	n, err := mounted.Write(uint64(fh), unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(size)), int64(off))
+	if err != nil {
+		return errno(err)
+	}
+	return C.int(n)
+}
+
+//export cfsFlush
+func cfsFlush(fh C.uint64_t) C.int {
+	return errno(mounted.Flush(uint64(fh)))
+}
+
+//export cfsRelease
+func cfsRelease(fh C.uint64_t) C.int {
+	return errno(mounted.Release(uint64(fh)))
+}
+
+//export cfsReaddir
+func cfsReaddir(path *C.char, filler C.fuse_fill_dir_t, buf unsafe.Pointer) C.int {
+	entries, err := mounted.Readdir(C.GoString(path))
+	if err != nil {
+		return errno(err)
+	}
+	entries = append([]DirEntry{{Name: "."}, {Name: ".."}}, entries...)
+	for _, entry := range entries {
+		name := C.CString(entry.Name)
+		var attr *C.cfs_attr
+		if entry.Attr != nil {
+			attr = &C.cfs_attr{}
+			fillAttr(entry.Attr, attr)
+		}
+		full := C.cfs_fill_dir(filler, buf, name, attr)
+		C.free(unsafe.Pointer(name))
+		if full != 0 {
+			break
+		}
+	}
+	return 0
+}
+
+//export cfsStatfs
+func cfsStatfs(st *C.cfs_fsstat) C.int {
+	s := mounted.Statfs()
+	st.bsize = C.uint32_t(s.Bsize)
+	st.namemax = nameMax
+	st.blocks = C.uint64_t(s.Blocks)
+	st.bfree = C.uint64_t(s.Bfree)
+	st.files = C.uint64_t(s.Files)
+	return 0
+}
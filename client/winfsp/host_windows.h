@@ -0,0 +1,60 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+#ifndef CFS_WINFSP_HOST_H
+#define CFS_WINFSP_HOST_H
+
+#include <stdint.h>
+#include <fuse.h>
+
+// cfs_attr carries the attrs from go, so that go does not depend on the layout
+// of struct fuse_stat.
+typedef struct cfs_attr {
+	uint64_t ino;
+	uint64_t size;
+	uint64_t blocks;
+	uint32_t mode;
+	uint32_t nlink;
+	uint32_t uid;
+	uint32_t gid;
+	int64_t  atime;
+	int64_t  atimensec;
+	int64_t  mtime;
+	int64_t  mtimensec;
+	int64_t  ctime;
+	int64_t  ctimensec;
+	int64_t  btime;
+	int64_t  btimensec;
+} cfs_attr;
+
+typedef struct cfs_fsstat {
+	uint32_t bsize;
+	uint32_t namemax;
+	uint64_t blocks;
+	uint64_t bfree;
+	uint64_t files;
+} cfs_fsstat;
+
+// cfs_fill_dir adds an entry to the listing of a readdir, without the attr if
+// it is NULL.
+int cfs_fill_dir(fuse_fill_dir_t filler, void *buf, char *name, cfs_attr *attr);
+
+// cfs_mount serves the file system at the mount point of the args until it is
+// unmounted.
+int cfs_mount(int argc, char *argv[]);
+
+// cfs_unmount makes the cfs_mount serving return.
+void cfs_unmount(void);
+
+#endif
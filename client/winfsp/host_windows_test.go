@@ -0,0 +1,364 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package winfsp
+
+import (
+	"os"
+	gopath "path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memNode struct {
+	attr Attr
+	data []byte
+}
+
+// memFS is a volume in memory, to mount a drive without a cluster.
+type memFS struct {
+	sync.Mutex
+	nodes   map[string]*memNode
+	handles map[uint64]string
+	nextIno uint64
+	nextFh  uint64
+}
+
+func newMemFS() *memFS {
+	fs := &memFS{nodes: make(map[string]*memNode), handles: make(map[uint64]string), nextIno: 1}
+	fs.nodes["/"] = fs.newNode(modeDir | 0o755)
+	return fs
+}
+
+func (fs *memFS) newNode(mode uint32) *memNode {
+	now := time.Now()
+	fs.nextIno++
+	n := &memNode{attr: Attr{Ino: fs.nextIno, Mode: mode, Nlink: 1, Atime: now, Mtime: now, Ctime: now, Btime: now}}
+	if mode&modeDir != 0 {
+		n.attr.Nlink = 2
+	}
+	return n
+}
+
+func (fs *memFS) get(path string) (*memNode, error) {
+	n, ok := fs.nodes[path]
+	if !ok {
+		return nil, syscall.ENOENT
+	}
+	return n, nil
+}
+
+func (fs *memFS) add(path string, mode uint32) (*memNode, error) {
+	if _, ok := fs.nodes[path]; ok {
+		return nil, syscall.EEXIST
+	}
+	parent, err := fs.get(gopath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	if parent.attr.Mode&modeDir == 0 {
+		return nil, syscall.ENOTDIR
+	}
+	n := fs.newNode(mode)
+	fs.nodes[path] = n
+	return n, nil
+}
+
+func (fs *memFS) children(path string) (names []string) {
+	for p := range fs.nodes {
+		if p != "/" && gopath.Dir(p) == path {
+			names = append(names, gopath.Base(p))
+		}
+	}
+	return
+}
+
+func (fs *memFS) Getattr(path string) (*Attr, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.get(path)
+	if err != nil {
+		return nil, err
+	}
+	a := n.attr
+	a.Size = uint64(len(n.data))
+	a.Blocks = (a.Size + 511) >> 9
+	return &a, nil
+}
+
+func (fs *memFS) Readlink(path string) (string, error) {
+	return "", syscall.EINVAL
+}
+
+func (fs *memFS) Readdir(path string) (entries []DirEntry, err error) {
+	fs.Lock()
+	names := fs.children(path)
+	fs.Unlock()
+	for _, name := range names {
+		attr, _ := fs.Getattr(gopath.Join(path, name))
+		entries = append(entries, DirEntry{Name: name, Attr: attr})
+	}
+	return
+}
+
+func (fs *memFS) Mkdir(path string, mode uint32) error {
+	fs.Lock()
+	defer fs.Unlock()
+	_, err := fs.add(path, modeDir|mode&modePerm)
+	return err
+}
+
+func (fs *memFS) Rmdir(path string) error {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.get(path)
+	if err != nil {
+		return err
+	}
+	if n.attr.Mode&modeDir == 0 {
+		return syscall.ENOTDIR
+	}
+	if len(fs.children(path)) > 0 {
+		return syscall.ENOTEMPTY
+	}
+	delete(fs.nodes, path)
+	return nil
+}
+
+func (fs *memFS) Unlink(path string) error {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.get(path)
+	if err != nil {
+		return err
+	}
+	if n.attr.Mode&modeDir != 0 {
+		return syscall.EISDIR
+	}
+	delete(fs.nodes, path)
+	return nil
+}
+
+func (fs *memFS) Rename(oldPath, newPath string) error {
+	fs.Lock()
+	defer fs.Unlock()
+	if _, err := fs.get(oldPath); err != nil {
+		return err
+	}
+	if _, err := fs.get(gopath.Dir(newPath)); err != nil {
+		return err
+	}
+	if _, ok := fs.nodes[newPath]; ok && len(fs.children(newPath)) > 0 {
+		return syscall.ENOTEMPTY
+	}
+	moved := make(map[string]*memNode)
+	for p, n := range fs.nodes {
+		if p == oldPath || strings.HasPrefix(p, oldPath+"/") {
+			moved[newPath+strings.TrimPrefix(p, oldPath)] = n
+			delete(fs.nodes, p)
+		}
+	}
+	for p, n := range moved {
+		fs.nodes[p] = n
+	}
+	return nil
+}
+
+func (fs *memFS) Chmod(path string, mode uint32) error {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.get(path)
+	if err != nil {
+		return err
+	}
+	n.attr.Mode = n.attr.Mode&^modePerm | mode&modePerm
+	return nil
+}
+
+func (fs *memFS) Utimens(path string, atime, mtime time.Time) error {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.get(path)
+	if err != nil {
+		return err
+	}
+	n.attr.Atime, n.attr.Mtime = atime, mtime
+	return nil
+}
+
+func (fs *memFS) Statfs() *Statfs {
+	fs.Lock()
+	defer fs.Unlock()
+	return &Statfs{Bsize: blockSize, Blocks: 1 << 20, Bfree: 1 << 19, Files: uint64(len(fs.nodes))}
+}
+
+func (fs *memFS) openHandle(path string) uint64 {
+	fs.nextFh++
+	fs.handles[fs.nextFh] = path
+	return fs.nextFh
+}
+
+func (fs *memFS) handleNode(fh uint64) (*memNode, error) {
+	path, ok := fs.handles[fh]
+	if !ok {
+		return nil, syscall.EBADF
+	}
+	return fs.get(path)
+}
+
+func (fs *memFS) Create(path string, mode uint32) (uint64, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	if _, err := fs.add(path, modeRegular|mode&modePerm); err != nil {
+		return 0, err
+	}
+	return fs.openHandle(path), nil
+}
+
+func (fs *memFS) Open(path string, write bool) (uint64, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	if _, err := fs.get(path); err != nil {
+		return 0, err
+	}
+	return fs.openHandle(path), nil
+}
+
+func (fs *memFS) Read(fh uint64, buf []byte, off int64) (int, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.handleNode(fh)
+	if err != nil {
+		return 0, err
+	}
+	if off >= int64(len(n.data)) {
+		return 0, nil
+	}
+	return copy(buf, n.data[off:]), nil
+}
+
+func (fs *memFS) Write(fh uint64, data []byte, off int64) (int, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	n, err := fs.handleNode(fh)
+	if err != nil {
+		return 0, err
+	}
+	if end := off + int64(len(data)); end > int64(len(n.data)) {
+		n.data = append(n.data, make([]byte, end-int64(len(n.data)))...)
+	}
+	copy(n.data[off:], data)
+	n.attr.Mtime = time.Now()
+	return len(data), nil
+}
+
+func (fs *memFS) Flush(fh uint64) error {
+	return nil
+}
+
+func (fs *memFS) Release(fh uint64) error {
+	fs.Lock()
+	defer fs.Unlock()
+	delete(fs.handles, fh)
+	return nil
+}
+
+func (fs *memFS) Truncate(path string, fh uint64, size int64) error {
+	fs.Lock()
+	defer fs.Unlock()
+	var (
+		n   *memNode
+		err error
+	)
+	if fh != 0 {
+		n, err = fs.handleNode(fh)
+	} else {
+		n, err = fs.get(path)
+	}
+	if err != nil {
+		return err
+	}
+	if size < int64(len(n.data)) {
+		n.data = n.data[:size]
+	} else {
+		n.data = append(n.data, make([]byte, size-int64(len(n.data)))...)
+	}
+	return nil
+}
+
+func freeDrive(t *testing.T) string {
+	for c := 'Z'; c >= 'D'; c-- {
+		drive := string(c) + ":"
+		if _, err := os.Stat(drive + `\`); err != nil {
+			return drive
+		}
+	}
+	t.Skip("no drive letter is free")
+	return ""
+}
+
+func TestMountDrive(t *testing.T) {
+	drive := freeDrive(t)
+	done := make(chan error, 1)
+	go func() { done <- mount(newMemFS(), drive, "test") }()
+	t.Cleanup(func() {
+		Unmount()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			t.Error("unmount timed out")
+		}
+	})
+	root := drive + `\`
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(root)
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond, "drive %v is not up", drive)
+
+	dir := filepath.Join(root, "dir")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	file := filepath.Join(dir, "f.txt")
+	data := []byte("hello cubefs")
+	require.NoError(t, os.WriteFile(file, data, 0o644))
+	got, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "f.txt", entries[0].Name())
+
+	renamed := filepath.Join(dir, "g.txt")
+	require.NoError(t, os.Rename(file, renamed))
+	_, err = os.Stat(file)
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, os.Truncate(renamed, 5))
+	info, err := os.Stat(renamed)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, info.Size())
+
+	require.Error(t, os.Remove(dir))
+	require.NoError(t, os.Remove(renamed))
+	require.NoError(t, os.Remove(dir))
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+}
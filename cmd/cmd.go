@@ -42,10 +42,13 @@ import (
 	"github.com/cubefs/cubefs/objectnode"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/bundle"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/faultinject"
 	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/logship"
 	sysutil "github.com/cubefs/cubefs/util/sys"
 	"github.com/cubefs/cubefs/util/ump"
 	"github.com/jacobsa/daemonize"
@@ -284,6 +287,18 @@ func main() {
 	}
 	defer auditlog.StopAudit()
 
+	shipper, err := logship.New(cfg, path.Join(logDir, module), module)
+	if err != nil {
+		err = errors.NewErrorf("Fatal: failed to init log shipper - %v", err)
+		fmt.Println(err)
+		daemonize.SignalOutcome(err)
+		os.Exit(1)
+	}
+	if shipper != nil {
+		shipper.Start()
+		defer shipper.Stop()
+	}
+
 	if *redirectSTD {
 		// Init output file
 		outputFilePath := path.Join(logDir, module, LoggerOutput)
@@ -314,6 +329,10 @@ func main() {
 	}
 
 	proto.InitBufferPool(buffersTotalLimit)
+	bundle.Init(role, Version, cfg)
+	if role == RoleData || role == RoleMeta || role == RoleFlash {
+		faultinject.SetEnabled(cfg.GetBool(faultinject.ConfigKeyEnable))
+	}
 	syslog.Printf("Hello, CubeFS Storage\n%s\n", Version)
 	err = modifyOpenFiles()
 	if err != nil {
@@ -345,6 +364,10 @@ func main() {
 			mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 			mux.Handle("/debug/", http.HandlerFunc(pprof.Index))
 			mux.Handle("/debug/releaseMemory", http.HandlerFunc(releaseMemory))
+			mux.Handle(bundle.Path, http.HandlerFunc(bundle.Handler))
+			mux.Handle(faultinject.Path, http.HandlerFunc(faultinject.ListHandler))
+			mux.Handle(faultinject.SetPath, http.HandlerFunc(faultinject.SetHandler))
+			mux.Handle(faultinject.ClearPath, http.HandlerFunc(faultinject.ClearHandler))
 			mainHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 				if strings.HasPrefix(req.URL.Path, "/debug/") {
 					mux.ServeHTTP(w, req)
@@ -81,6 +81,8 @@ const (
 	ActionDeleteLostDisk              = "ActionDeleteLostDisk"
 	ActionReloadDisk                  = "ActionReloadDisk"
 	ActionSetRepairingStatus          = "ActionSetRepairingStatus"
+	ActionNegotiateFeatures           = "ActionNegotiateFeatures"
+	ActionGetContainerIndex           = "ActionGetContainerIndex"
 )
 
 // Apply the raft log operation. Currently we only have the random write operation.
@@ -111,6 +113,12 @@ const (
 	BufferWrite = false
 )
 
+// The leader of a sync mirror volume waits for a peer in another zone to catch up.
+const (
+	syncMirrorAckTimeout       = 5 * time.Second
+	syncMirrorMaxCheckInterval = 16 * time.Millisecond
+)
+
 const (
 	EmptyResponse                                = 'E'
 	TinyExtentRepairReadResponseArgLen           = 17
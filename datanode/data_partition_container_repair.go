@@ -0,0 +1,300 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package datanode
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"net"
+	"strings"
+
+	"github.com/cubefs/cubefs/datanode/repl"
+	"github.com/cubefs/cubefs/datanode/storage"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The records of the containers are not repaired by the size of the extents
+// like the tiny extents, each replica compares the digests of its containers
+// with the other replicas, and pulls the records and the tombstones it misses
+// for the containers the digests differ on.
+
+// repairContainers repairs the containers of the partition from the other replicas.
+func (dp *DataPartition) repairContainers() {
+	if dp.partitionStatus == proto.Unavailable || dp.isReadReplica() {
+		return
+	}
+	hosts := make([]string, 0)
+	for _, host := range dp.getReplicaCopy() {
+		if host != dp.dataNode.localServerAddr {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) == 0 {
+		return
+	}
+	if err := dp.repairContainersFrom(hosts); err != nil {
+		log.LogWarnf("[repairContainers] dp(%v) repair containers from hosts(%v) err(%v)", dp.partitionID, hosts, err)
+	}
+}
+
+// repairContainersFrom pulls the records and the tombstones of the containers
+// from the hosts. Two replicas only hold different records of the same id when
+// the leader changed while a record was written, the record held by the most
+// replicas is kept, that is the one acknowledged to the client.
+func (dp *DataPartition) repairContainersFrom(hosts []string) (err error) {
+	containers := dp.ExtentStore().Containers()
+	summary := containers.Summary()
+	local := make(map[uint64]*storage.ContainerDigest)
+	for _, digest := range summary.Digests {
+		local[digest.ExtentID] = digest
+	}
+	removed := make(map[uint64]struct{})
+	for _, extentID := range summary.Removed {
+		removed[extentID] = struct{}{}
+	}
+
+	differ := make(map[string][]uint64)
+	for _, host := range hosts {
+		var summary *storage.ContainerSummary
+		if summary, err = dp.getRemoteContainerSummary(host); err != nil {
+			return
+		}
+		for _, extentID := range summary.Removed {
+			if _, ok := removed[extentID]; ok {
+				continue
+			}
+			if err = containers.Remove(extentID); err != nil {
+				return errors.Trace(err, "remove container(%v)", extentID)
+			}
+			removed[extentID] = struct{}{}
+		}
+		for _, digest := range summary.Digests {
+			if l, ok := local[digest.ExtentID]; ok && *l == *digest {
+				continue
+			}
+			if _, ok := removed[digest.ExtentID]; ok {
+				continue
+			}
+			differ[host] = append(differ[host], digest.ExtentID)
+		}
+	}
+	if len(differ) == 0 {
+		return
+	}
+
+	indexes := make(map[uint64]map[string]*storage.ContainerIndex)
+	for host, extentIDs := range differ {
+		var remote []*storage.ContainerIndex
+		if remote, err = dp.getRemoteContainerIndexes(host, extentIDs); err != nil {
+			return
+		}
+		for _, index := range remote {
+			if indexes[index.ExtentID] == nil {
+				indexes[index.ExtentID] = make(map[string]*storage.ContainerIndex)
+			}
+			indexes[index.ExtentID][host] = index
+		}
+	}
+	for extentID, remote := range indexes {
+		if _, ok := removed[extentID]; ok {
+			continue
+		}
+		if err = dp.repairContainer(extentID, remote); err != nil {
+			return errors.Trace(err, "repair container(%v)", extentID)
+		}
+	}
+	return
+}
+
+func (dp *DataPartition) repairContainer(extentID uint64, remote map[string]*storage.ContainerIndex) (err error) {
+	store := dp.ExtentStore()
+	records := make(map[uint64]storage.ContainerRecordInfo)
+	deleted := make(map[uint64]struct{})
+	if index, e := store.Containers().Index(extentID); e == nil {
+		for _, rec := range index.Records {
+			records[rec.RecordID] = rec
+		}
+		for _, recordID := range index.Tombstones {
+			deleted[recordID] = struct{}{}
+		}
+	}
+
+	for _, index := range remote {
+		for _, recordID := range index.Tombstones {
+			if _, ok := deleted[recordID]; ok {
+				continue
+			}
+			dp.disk.diskLimit(OpDelete, 0, func() {
+				err = store.MarkDelete(extentID, int64(proto.ContainerOffset(recordID, 0)), 0)
+			})
+			if err != nil {
+				return
+			}
+			deleted[recordID] = struct{}{}
+		}
+	}
+
+	// the replicas holding each version of a record
+	versions := make(map[uint64]map[uint32][]string)
+	for host, index := range remote {
+		for _, rec := range index.Records {
+			if _, ok := deleted[rec.RecordID]; ok {
+				continue
+			}
+			if versions[rec.RecordID] == nil {
+				versions[rec.RecordID] = make(map[uint32][]string)
+			}
+			versions[rec.RecordID][rec.Crc] = append(versions[rec.RecordID][rec.Crc], host)
+		}
+	}
+	for recordID, byCrc := range versions {
+		localRec, replace := records[recordID]
+		var (
+			best  uint32
+			votes int
+		)
+		for crc, holders := range byCrc {
+			if len(holders) > votes {
+				best, votes = crc, len(holders)
+			}
+		}
+		if replace && (localRec.Crc == best || votes <= len(byCrc[localRec.Crc])+1) {
+			continue
+		}
+		var size uint32
+		for _, rec := range remote[byCrc[best][0]].Records {
+			if rec.RecordID == recordID {
+				size = rec.Size
+				break
+			}
+		}
+		var data []byte
+		if data, err = dp.readRemoteContainerRecord(byCrc[best][0], extentID, recordID, size, best); err != nil {
+			return
+		}
+		dp.disk.diskLimit(OpAsyncWrite, size, func() {
+			_, err = store.Write(&storage.WriteParam{
+				ExtentID:  extentID,
+				Offset:    int64(proto.ContainerOffset(recordID, 0)),
+				Size:      int64(size),
+				Data:      data,
+				Crc:       best,
+				WriteType: storage.AppendWriteType,
+				IsRepair:  !replace,
+			})
+		})
+		if err != nil && strings.Contains(err.Error(), storage.ExtentHasBeenDeletedError.Error()) {
+			// deleted meanwhile
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		log.LogInfof("[repairContainer] dp(%v) container(%v) record(%v) size(%v) repaired from host(%v), replace(%v)",
+			dp.partitionID, extentID, recordID, size, byCrc[best][0], replace)
+	}
+	return
+}
+
+func (dp *DataPartition) getRemoteContainerSummary(target string) (summary *storage.ContainerSummary, err error) {
+	data, err := dp.getRemoteContainerIndex(target, nil)
+	if err != nil {
+		return
+	}
+	summary = new(storage.ContainerSummary)
+	if err = json.Unmarshal(data, summary); err != nil {
+		err = errors.Trace(err, "getRemoteContainerSummary DataPartition(%v) unmarshal from host(%v)", dp.partitionID, target)
+	}
+	return
+}
+
+func (dp *DataPartition) getRemoteContainerIndexes(target string, extentIDs []uint64) (indexes []*storage.ContainerIndex, err error) {
+	data, err := dp.getRemoteContainerIndex(target, extentIDs)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(data, &indexes); err != nil {
+		err = errors.Trace(err, "getRemoteContainerIndexes DataPartition(%v) unmarshal from host(%v)", dp.partitionID, target)
+	}
+	return
+}
+
+func (dp *DataPartition) getRemoteContainerIndex(target string, extentIDs []uint64) (data []byte, err error) {
+	p, err := repl.NewPacketToGetContainerIndex(dp.partitionID, extentIDs)
+	if err != nil {
+		return
+	}
+	var conn *net.TCPConn
+	conn, err = gConnPool.GetConnect(target)
+	if err != nil {
+		err = errors.Trace(err, "getRemoteContainerIndex DataPartition(%v) get host(%v) connect", dp.partitionID, target)
+		return
+	}
+	defer func() {
+		gConnPool.PutConnect(conn, err != nil)
+	}()
+	if err = p.WriteToConn(conn); err != nil {
+		err = errors.Trace(err, "getRemoteContainerIndex DataPartition(%v) write to host(%v)", dp.partitionID, target)
+		return
+	}
+	reply := new(repl.Packet)
+	if err = reply.ReadFromConnWithVer(conn, proto.GetAllWatermarksDeadLineTime); err != nil {
+		err = errors.Trace(err, "getRemoteContainerIndex DataPartition(%v) read from host(%v)", dp.partitionID, target)
+		return
+	}
+	if reply.ResultCode != proto.OpOk {
+		err = fmt.Errorf("getRemoteContainerIndex DataPartition(%v) host(%v) reply(%v)",
+			dp.partitionID, target, string(reply.Data[:reply.Size]))
+		return
+	}
+	return reply.Data[:reply.Size], nil
+}
+
+// readRemoteContainerRecord reads a whole record of a container from the host.
+func (dp *DataPartition) readRemoteContainerRecord(target string, extentID, recordID uint64, size, crc uint32) (data []byte, err error) {
+	conn, err := dp.getRepairConn(target)
+	if err != nil {
+		return nil, errors.Trace(err, "readRemoteContainerRecord get conn from host(%v)", target)
+	}
+	defer func() {
+		dp.putRepairConn(conn, dp.enableSmux() || err != nil)
+	}()
+	request := repl.NewExtentRepairReadPacket(dp.partitionID, extentID, int(proto.ContainerOffset(recordID, 0)), int(size))
+	if err = request.WriteToConn(conn); err != nil {
+		return nil, errors.Trace(err, "readRemoteContainerRecord send to host(%v)", target)
+	}
+	data = make([]byte, 0, size)
+	for uint32(len(data)) < size {
+		reply := repl.NewPacket()
+		if err = reply.ReadFromConnWithVer(conn, proto.ReadDeadlineTime); err != nil {
+			return nil, errors.Trace(err, "readRemoteContainerRecord receive from host(%v)", target)
+		}
+		if reply.ResultCode != proto.OpOk {
+			return nil, fmt.Errorf("readRemoteContainerRecord host(%v) reply(%v)", target, string(reply.Data[:reply.Size]))
+		}
+		if reply.ReqID != request.GetReqID() || reply.ExtentID != extentID || reply.Size == 0 {
+			return nil, fmt.Errorf("readRemoteContainerRecord host(%v) invalid reply(%v)", target, reply.GetUniqueLogId())
+		}
+		data = append(data, reply.Data[:reply.Size]...)
+	}
+	if crc32.ChecksumIEEE(data) != crc {
+		return nil, fmt.Errorf("readRemoteContainerRecord host(%v) container(%v) record(%v) crc mismatch", target, extentID, recordID)
+	}
+	return
+}
@@ -103,6 +103,15 @@ func (dp *DataPartition) repair(extentType uint8) {
 	start := time.Now()
 	log.LogInfof("action[repair] partition(%v) start extentType %v.",
 		dp.partitionID, extentType)
+	if !dp.extentStore.Verified() {
+		log.LogInfof("action[repair] partition(%v) skipped, the extents loaded from the checkpoint are being verified.",
+			dp.partitionID)
+		return
+	}
+	if dp.dataNode != nil && dp.dataNode.maintenance.Load() {
+		log.LogInfof("action[repair] partition(%v) skipped, the data node is in maintenance.", dp.partitionID)
+		return
+	}
 
 	var tinyExtents []uint64 // unavailable extents
 	if proto.IsTinyExtentType(extentType) {
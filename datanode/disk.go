@@ -46,13 +46,14 @@ var (
 	// RegexpDataPartitionDir validates the directory name of a data partition.
 	RegexpDataPartitionDir, _               = regexp.Compile(`^datapartition_(\d)+_(\d)+$`)
 	RegexpExpiredDataPartitionDir, _        = regexp.Compile(`^expired_datapartition_(\d)+_(\d)+$`)
-	RegexpBackupDataPartitionDirToDelete, _ = regexp.Compile(`backup_datapartition_(\d+)_(\d+)-(\d+)`)
+	RegexpBackupDataPartitionDirToDelete, _ = regexp.Compile(`(backup|tombstone)_datapartition_(\d+)_(\d+)-(\d+)`)
 )
 
 const (
 	ExpiredPartitionPrefix    = "expired_"
 	ExpiredPartitionExistTime = time.Hour * time.Duration(24*7)
 	BackupPartitionPrefix     = "backup_"
+	TombstonePartitionPrefix  = "tombstone_"
 )
 
 const DefaultCurrentLoadDpLimit = 4
@@ -89,6 +90,7 @@ type Disk struct {
 	DiskRdonlySpace uint64
 
 	RejectWrite                               bool
+	writes                                    writeReservation // bytes written since the last usage computation
 	partitionMap                              map[uint64]*DataPartition
 	syncTinyDeleteRecordFromLeaderOnEveryDisk chan bool
 	space                                     *SpaceManager
@@ -432,6 +434,87 @@ func (d *Disk) CanWrite() bool {
 	return false
 }
 
+// writeReservation counts the bytes of the writes in flight and the bytes written
+// since the usage was last measured. The measurement holds the lock, so a write
+// that finishes meanwhile is counted either in the usage or in written, never in
+// both nor in neither.
+type writeReservation struct {
+	sync.Mutex
+	inflight int64
+	written  int64
+}
+
+// reserve adds size to the writes in flight if fits accepts the bytes pending then.
+func (r *writeReservation) reserve(size int64, fits func(pending int64) bool) bool {
+	r.Lock()
+	defer r.Unlock()
+	if !fits(r.inflight + r.written + size) {
+		return false
+	}
+	r.inflight += size
+	return true
+}
+
+// commit moves size from the writes in flight to the bytes written.
+func (r *writeReservation) commit(size int64) {
+	r.Lock()
+	r.inflight -= size
+	r.written += size
+	r.Unlock()
+}
+
+// release gives back size reserved for a write that failed.
+func (r *writeReservation) release(size int64) {
+	r.Lock()
+	r.inflight -= size
+	r.Unlock()
+}
+
+// measure runs the usage measurement, then forgets the bytes written before it
+// unless it failed.
+func (r *writeReservation) measure(f func() error) error {
+	r.Lock()
+	defer r.Unlock()
+	if err := f(); err != nil {
+		return err
+	}
+	r.written = 0
+	return nil
+}
+
+func (r *writeReservation) pending() int64 {
+	r.Lock()
+	defer r.Unlock()
+	return r.inflight + r.written
+}
+
+// reserveWrite accounts size bytes about to be written on the disk, unless they
+// would eat into ReservedSpace. The usage is computed every few seconds, so the
+// bytes written since then are counted as well.
+func (d *Disk) reserveWrite(size int64) bool {
+	var pending int64
+	ok := d.writes.reserve(size, func(p int64) bool {
+		pending = p
+		return d.Total+d.DiskRdonlySpace >= d.Used+d.ReservedSpace+uint64(p)
+	})
+	if !ok {
+		log.LogWarnf("[reserveWrite] disk(%v) reject write of %v, total(%v) disk rdonly space(%v) used(%v) written since(%v) reserved space(%v)",
+			d.Path, size, strutil.FormatSize(d.Total), strutil.FormatSize(d.DiskRdonlySpace), strutil.FormatSize(d.Used),
+			strutil.FormatSize(uint64(pending)), strutil.FormatSize(d.ReservedSpace))
+	}
+	return ok
+}
+
+// commitWrite accounts the bytes reserved for a write that landed on the disk.
+func (d *Disk) commitWrite(size int64) {
+	d.writes.commit(size)
+}
+
+// releaseWrite gives back the bytes reserved for a write that failed.
+func (d *Disk) releaseWrite(size int64) {
+	d.writes.release(size)
+}
+
 // Compute the disk usage
 func (d *Disk) computeUsage() (err error) {
 	if d.isLost {
@@ -441,7 +524,10 @@ func (d *Disk) computeUsage() (err error) {
 	d.RLock()
 	defer d.RUnlock()
 	fs := syscall.Statfs_t{}
-	err = syscall.Statfs(d.Path, &fs)
+	// the writes finished before statfs are in the usage now
+	err = d.writes.measure(func() error {
+		return syscall.Statfs(d.Path, &fs)
+	})
 	if err != nil {
 		log.LogErrorf("computeUsage. err %v", err)
 		return
@@ -829,6 +915,7 @@ func (d *Disk) RestorePartition(visitor PartitionVisitor) (err error) {
 					err error
 				)
 				defer func() {
+					atomic.AddInt64(&d.space.loadedPartitions, 1)
 					if err == nil {
 						log.LogInfof("[RestorePartition] disk(%v) load dp(%v) using time(%v) slow(%v)", d.Path, dp.partitionID, time.Since(begin), time.Since(begin) > 1*time.Second)
 					}
@@ -912,6 +999,7 @@ func (d *Disk) RestorePartition(visitor PartitionVisitor) (err error) {
 			continue
 		}
 		dpNum++
+		atomic.AddInt64(&d.space.loadPartitions, 1)
 		loadCh <- dpLoadInfo{
 			Id:       partitionID,
 			FileName: filename,
@@ -1085,7 +1173,7 @@ func unmarshalBackupPartitionDirNameAndTimestamp(name string) (partitionID uint6
 		return
 	}
 	timestampStr := arr[1]
-	t, err := time.Parse("20060102150405", timestampStr)
+	t, err := time.ParseInLocation("20060102150405", timestampStr, time.Local)
 	if err != nil {
 		err = fmt.Errorf("error backupDataPartition timestamp(%v)", timestampStr)
 		return
@@ -1111,6 +1199,22 @@ func (d *Disk) isBackupPartitionDirToDelete(filename string) (isBackupPartitionD
 	return
 }
 
+func trimBackupPartitionPrefix(filename string) string {
+	if strings.HasPrefix(filename, TombstonePartitionPrefix) {
+		return strings.TrimPrefix(filename, TombstonePartitionPrefix)
+	}
+	return strings.TrimPrefix(filename, BackupPartitionPrefix)
+}
+
+// backupRetention is how long the backup directory is kept. The tombstones of
+// the dropped replicas follow the retention of the cluster if it is set.
+func (d *Disk) backupRetention(filename string) time.Duration {
+	if strings.HasPrefix(filename, TombstonePartitionPrefix) && d.dataNode.replicaTombstoneRetention > 0 {
+		return d.dataNode.replicaTombstoneRetention
+	}
+	return d.dataNode.dpBackupTimeout
+}
+
 func (d *Disk) startScheduleToDeleteBackupReplicaDirectories() {
 	go func() {
 		ticker := time.NewTicker(time.Minute * 5)
@@ -1139,12 +1243,12 @@ func (d *Disk) startScheduleToDeleteBackupReplicaDirectories() {
 					continue
 				}
 
-				if _, _, err = unmarshalBackupPartitionDirNameAndTimestamp(filename); err != nil {
+				if _, ts, err = unmarshalBackupPartitionDirNameAndTimestamp(filename); err != nil {
 					log.LogErrorf("action[startScheduleToDeleteBackupReplicaDirectories] unmarshal partitionName(%v) from disk(%v) err(%v) ",
 						filename, d.Path, err.Error())
 					continue
 				}
-				if time.Since(time.Unix(ts, 0)) > d.dataNode.dpBackupTimeout {
+				if time.Since(time.Unix(ts, 0)) > d.backupRetention(filename) {
 					err = os.RemoveAll(path.Join(d.Path, filename))
 					if err != nil {
 						log.LogWarnf("action[startScheduleToDeleteBackupReplicaDirectories] failed to remove %v err(%v) ",
@@ -0,0 +1,66 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package datanode
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+// partitionReportDelta keeps the partition reports last sent to the master,
+// the next heartbeat carries the reports changed since them only if the
+// master applied them, and all of them otherwise.
+type partitionReportDelta struct {
+	sync.Mutex
+	seq  uint64
+	last map[uint64]*proto.DataPartitionReport
+}
+
+// reduce replaces the reports of the response by the changes since the
+// reports of ackSeq, the last ones the master applied, if they are the last
+// ones sent.
+func (d *partitionReportDelta) reduce(ackSeq uint64, response *proto.DataNodeHeartbeatResponse) {
+	d.Lock()
+	defer d.Unlock()
+	if d.seq == 0 {
+		// unlikely to be the seq of the reports sent before a restart
+		d.seq = uint64(time.Now().UnixNano())
+	}
+	current := make(map[uint64]*proto.DataPartitionReport, len(response.PartitionReports))
+	for _, report := range response.PartitionReports {
+		current[report.PartitionID] = report
+	}
+	if ackSeq != 0 && ackSeq == d.seq && d.last != nil {
+		changed := make([]*proto.DataPartitionReport, 0)
+		for _, report := range response.PartitionReports {
+			if last, ok := d.last[report.PartitionID]; !ok || !reflect.DeepEqual(last, report) {
+				changed = append(changed, report)
+			}
+		}
+		for id := range d.last {
+			if _, ok := current[id]; !ok {
+				response.RemovedPartitions = append(response.RemovedPartitions, id)
+			}
+		}
+		response.PartitionReports = changed
+		response.DeltaBaseSeq = d.seq
+	}
+	d.seq++
+	d.last = current
+	response.ReportSeq = d.seq
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package datanode
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func heartbeatReports(used ...uint64) *proto.DataNodeHeartbeatResponse {
+	response := &proto.DataNodeHeartbeatResponse{}
+	for i, u := range used {
+		response.PartitionReports = append(response.PartitionReports,
+			&proto.DataPartitionReport{PartitionID: uint64(i + 1), Used: u})
+	}
+	return response
+}
+
+func TestPartitionReportDelta(t *testing.T) {
+	d := &partitionReportDelta{}
+
+	// the master asks for all of them
+	full := heartbeatReports(10, 20, 30)
+	d.reduce(0, full)
+	require.Len(t, full.PartitionReports, 3)
+	require.Zero(t, full.DeltaBaseSeq)
+	require.NotZero(t, full.ReportSeq)
+
+	// partition 2 written, partition 3 deleted
+	delta := heartbeatReports(10, 25)
+	d.reduce(full.ReportSeq, delta)
+	require.Equal(t, full.ReportSeq, delta.DeltaBaseSeq)
+	require.Len(t, delta.PartitionReports, 1)
+	require.Equal(t, uint64(2), delta.PartitionReports[0].PartitionID)
+	require.Equal(t, []uint64{3}, delta.RemovedPartitions)
+
+	// the delta was lost, the master acks the full reports again
+	lost := heartbeatReports(10, 25)
+	d.reduce(full.ReportSeq, lost)
+	require.Len(t, lost.PartitionReports, 2)
+	require.Zero(t, lost.DeltaBaseSeq)
+
+	idle := heartbeatReports(10, 25)
+	d.reduce(lost.ReportSeq, idle)
+	require.Empty(t, idle.PartitionReports)
+	require.Empty(t, idle.RemovedPartitions)
+	require.Equal(t, lost.ReportSeq, idle.DeltaBaseSeq)
+}
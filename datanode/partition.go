@@ -81,6 +81,8 @@ type DataPartitionMetadata struct {
 	ApplyID                 uint64
 	DiskErrCnt              uint64
 	IsRepairing             bool
+	ReadReplica             bool
+	ReadReplicaApplyID      uint64
 }
 
 func (md *DataPartitionMetadata) Validate() (err error) {
@@ -115,6 +117,7 @@ type DataPartition struct {
 	isRaftLeader    bool
 	path            string
 	used            int
+	writes          writeReservation // bytes written since used was computed
 	leaderSize      int
 	extentStore     *storage.ExtentStore
 	raftPartition   raftstore.Partition
@@ -157,6 +160,10 @@ type DataPartition struct {
 	readOnlyReasons     uint32
 	isMissingTinyExtent bool
 	isRepairing         bool
+
+	readOps            uint64 // stream reads served since start
+	readReplicaApplyID uint64 // max apply id of the hosts when the read replica was synced
+	readReplicaReady   int32
 }
 
 type PersistApplyIdRequest struct {
@@ -204,7 +211,11 @@ func CreateDataPartition(dpCfg *dataPartitionCfg, disk *Disk, request *proto.Cre
 	disk.space.partitions[dp.partitionID] = dp
 	disk.space.partitionMutex.Unlock()
 	dp.ForceLoadHeader()
-	if request.CreateType == proto.NormalCreateDataPartition {
+	if dpCfg.ReadReplica {
+		// a read replica stays out of the raft group, it's filled by the sync from the hosts
+		dp.partitionStatus = proto.ReadOnly
+		go dp.readReplicaSchedule()
+	} else if request.CreateType == proto.NormalCreateDataPartition {
 		err = dp.StartRaft(false)
 	} else {
 		// init leaderSize to partitionSize
@@ -317,6 +328,7 @@ func LoadDataPartition(partitionDir string, disk *Disk) (dp *DataPartition, err
 		NodeID:           disk.space.GetNodeID(),
 		ClusterID:        disk.space.GetClusterID(),
 		IsEnableSnapshot: disk.space.dataNode.clusterEnableSnapshot,
+		ReadReplica:      meta.ReadReplica,
 	}
 	if dp, err = newDataPartition(dpCfg, disk, false); err != nil {
 		return
@@ -324,9 +336,21 @@ func LoadDataPartition(partitionDir string, disk *Disk) (dp *DataPartition, err
 	dp.stopRecover = meta.StopRecover
 	dp.metaAppliedID = meta.ApplyID
 	dp.isRepairing = meta.IsRepairing
+	dp.readReplicaApplyID = meta.ReadReplicaApplyID
 	dp.computeUsage()
 	dp.ForceSetDataPartitionToLoading()
 	disk.space.AttachPartition(dp)
+	if meta.ReadReplica {
+		// not ready until the next sync checked the hosts didn't apply any write since
+		log.LogInfof("[LoadDataPartition] dp(%v) is a read replica, synced at apply id(%v)",
+			dp.partitionID, dp.readReplicaApplyID)
+		dp.partitionStatus = proto.ReadOnly
+		go dp.StartRaftLoggingSchedule()
+		go dp.readReplicaSchedule()
+		disk.AddSize(uint64(dp.Size()))
+		dp.ForceLoadHeader()
+		return
+	}
 	if err = dp.LoadAppliedID(); err != nil {
 		log.LogErrorf("action[LoadDataPartition] load apply id failed %v", err)
 		dp.checkIsDiskError(err, ReadFlag)
@@ -818,6 +842,40 @@ func (dp *DataPartition) Available() int {
 	return dp.partitionSize - dp.used
 }
 
+// reserveWrite accounts size bytes about to be appended to the partition. The write
+// is rejected if the partition would grow beyond its size, or the disk would have
+// less than its reserved space left, so that a runaway partition cannot fill up the
+// disk under the others before its usage is computed again.
+func (dp *DataPartition) reserveWrite(size int64) error {
+	var pending int64
+	ok := dp.writes.reserve(size, func(p int64) bool {
+		pending = p
+		return int64(dp.used)+p <= int64(dp.partitionSize)
+	})
+	if !ok {
+		log.LogWarnf("[reserveWrite] dp(%v) reject write of %v, used(%v) written since(%v) size(%v)",
+			dp.partitionID, size, strutil.FormatSize(uint64(dp.used)), strutil.FormatSize(uint64(pending)), strutil.FormatSize(uint64(dp.partitionSize)))
+		return storage.NoSpaceError
+	}
+	if !dp.disk.reserveWrite(size) {
+		dp.writes.release(size)
+		return storage.NoSpaceError
+	}
+	return nil
+}
+
+// commitWrite accounts the bytes reserved for a write that landed in the extent store.
+func (dp *DataPartition) commitWrite(size int64) {
+	dp.writes.commit(size)
+	dp.disk.commitWrite(size)
+}
+
+// releaseWrite gives back the bytes reserved for a write that failed.
+func (dp *DataPartition) releaseWrite(size int64) {
+	dp.writes.release(size)
+	dp.disk.releaseWrite(size)
+}
+
 func (dp *DataPartition) ReadOnlyReasons() uint32 {
 	return dp.readOnlyReasons
 }
@@ -826,14 +884,16 @@ func (dp *DataPartition) ForceLoadHeader() {
 	dp.loadExtentHeaderStatus = FinishLoadDataPartitionExtentHeader
 }
 
-func (dp *DataPartition) RemoveAll(force bool) (err error) {
+// RemoveAll removes the partition directory, or renames it with the backup
+// prefix if not empty, to be deleted by the backup cleaner.
+func (dp *DataPartition) RemoveAll(backupPrefix string) (err error) {
 	dp.persistMetaMutex.Lock()
 	defer dp.persistMetaMutex.Unlock()
-	if force {
+	if backupPrefix != "" {
 		originalPath := dp.Path()
 		parent := path.Dir(originalPath)
 		fileName := path.Base(originalPath)
-		newFilename := BackupPartitionPrefix + fileName
+		newFilename := backupPrefix + fileName
 		newPath := fmt.Sprintf("%v-%v", path.Join(parent, newFilename), time.Now().Format("20060102150405"))
 		//_, err = os.Stat(newPath)
 		//if err == nil {
@@ -897,6 +957,8 @@ func (dp *DataPartition) PersistMetadata() (err error) {
 		ApplyID:                 dp.appliedID,
 		DiskErrCnt:              atomic.LoadUint64(&dp.diskErrCnt),
 		IsRepairing:             dp.isRepairing,
+		ReadReplica:             dp.config.ReadReplica,
+		ReadReplicaApplyID:      atomic.LoadUint64(&dp.readReplicaApplyID),
 	}
 
 	if metaData, err = json.Marshal(md); err != nil {
@@ -936,6 +998,7 @@ func (dp *DataPartition) statusUpdateScheduler() {
 
 			if index%2 == 0 {
 				dp.LaunchRepair(proto.TinyExtentType)
+				dp.repairContainers()
 			} else {
 				dp.LaunchRepair(proto.NormalExtentType)
 			}
@@ -978,7 +1041,11 @@ func (dp *DataPartition) statusUpdate() {
 		status = proto.ReadOnly
 		dp.readOnlyReasons |= proto.DiskReadOnly
 	}
-	if dp.isNormalType() && dp.raftStatus == RaftStatusStopped {
+	if dp.isReadReplica() {
+		// no raft on a read replica, it only serves the reads
+		status = proto.ReadOnly
+		dp.readOnlyReasons |= proto.DpReadReplica
+	} else if dp.isNormalType() && dp.raftStatus == RaftStatusStopped {
 		// dp is still recovering
 		if dp.DataPartitionCreateType == proto.DecommissionedCreateDataPartition {
 			status = proto.Recovering
@@ -1003,7 +1070,10 @@ func (dp *DataPartition) computeUsage() {
 		log.LogDebugf("[computeUsage] dp(%v) skip size update", dp.partitionID)
 		return
 	}
-	dp.used = int(dp.ExtentStore().GetStoreUsedSize())
+	dp.writes.measure(func() error {
+		dp.used = int(dp.ExtentStore().GetStoreUsedSize())
+		return nil
+	})
 	if log.EnableDebug() {
 		log.LogDebugf("[computeUsage] dp(%v) update size(%v)", dp.partitionID, strutil.FormatSize(uint64(dp.used)))
 	}
@@ -1048,7 +1118,7 @@ func (dp *DataPartition) String() (m string) {
 
 // LaunchRepair launches the repair of extents.
 func (dp *DataPartition) LaunchRepair(extentType uint8) {
-	if dp.partitionStatus == proto.Unavailable {
+	if dp.partitionStatus == proto.Unavailable || dp.isReadReplica() {
 		return
 	}
 	if err := dp.updateReplicas(false); err != nil {
@@ -23,6 +23,7 @@ import (
 	"net"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/cubefs/cubefs/datanode/repl"
 	"github.com/cubefs/cubefs/datanode/storage"
@@ -328,6 +329,44 @@ func (dp *DataPartition) RandomWriteSubmit(pkg *repl.Packet) (err error) {
 	return
 }
 
+// remoteZonePeers returns the ids of the peers not in the local zone.
+func (dp *DataPartition) remoteZonePeers(zones map[string]string, localZone string) map[uint64]struct{} {
+	remote := make(map[uint64]struct{})
+	for _, peer := range dp.config.Peers {
+		if zone, ok := zones[peer.Addr]; ok && zone != localZone && peer.ID != dp.config.NodeID {
+			remote[peer.ID] = struct{}{}
+		}
+	}
+	return remote
+}
+
+// waitSyncMirrorAck waits until one of the remote peers has the raft logs the
+// leader committed, as a majority may be made up of the local zone only.
+func (dp *DataPartition) waitSyncMirrorAck(remote map[uint64]struct{}) (err error) {
+	var commit uint64
+	deadline := time.Now().Add(syncMirrorAckTimeout)
+	for interval := time.Millisecond; ; {
+		st := dp.raftPartition.Status()
+		if commit == 0 {
+			commit = st.Commit
+		}
+		for id, replica := range st.Replicas {
+			if _, ok := remote[id]; ok && replica.Match >= commit {
+				return
+			}
+		}
+		if time.Now().After(deadline) {
+			log.LogWarnf("[waitSyncMirrorAck] dp(%v) commit(%v) not acked by another zone in %v",
+				dp.partitionID, commit, syncMirrorAckTimeout)
+			return ErrSyncMirrorAckTimeout
+		}
+		time.Sleep(interval)
+		if interval < syncMirrorMaxCheckInterval {
+			interval *= 2
+		}
+	}
+}
+
 func (dp *DataPartition) Submit(val []byte) (retCode uint8, err error) {
 	var resp interface{}
 	resp, err = dp.Put(nil, val)
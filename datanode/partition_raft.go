@@ -56,6 +56,7 @@ type dataPartitionCfg struct {
 	DpRepairBlockSize        uint64
 	IsEnableSnapshot         bool
 	ForbidWriteOpOfProtoVer0 bool
+	ReadReplica              bool // a read only copy out of the raft group, synced from the Hosts
 }
 
 func (dp *DataPartition) raftPort() (heartbeat, replica int, err error) {
@@ -18,9 +18,13 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
+	"time"
 
+	"github.com/cubefs/cubefs/datanode/repl"
 	"github.com/cubefs/cubefs/datanode/storage"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/util"
@@ -108,3 +112,160 @@ func TestCheckAvailable(t *testing.T) {
 		})
 	})
 }
+
+func TestSyncMirrorPeers(t *testing.T) {
+	s := &DataNode{zoneName: "z1"}
+	zones := map[string]string{"a1": "z1", "a2": "z1", "a3": "z2"}
+	s.setSyncMirror([]string{"mirror"}, 5, nil)
+	require.Zero(t, s.syncMirrorZonesVersion(), "the zones not sent are not held")
+	s.setSyncMirror([]string{"mirror"}, 5, zones)
+	require.EqualValues(t, 5, s.syncMirrorZonesVersion())
+
+	_, ok := s.syncMirrorZones("plain")
+	require.False(t, ok)
+	got, ok := s.syncMirrorZones("mirror")
+	require.True(t, ok)
+	require.Equal(t, zones, got)
+
+	// the zones are sent once per version
+	s.setSyncMirror([]string{"mirror"}, 5, nil)
+	got, _ = s.syncMirrorZones("mirror")
+	require.Equal(t, zones, got)
+	s.setSyncMirror([]string{"mirror"}, 6, nil)
+	require.EqualValues(t, 5, s.syncMirrorZonesVersion(), "the master is told to send the new ones")
+	s.setSyncMirror(nil, 0, nil)
+	require.Zero(t, s.syncMirrorZonesVersion())
+	s.setSyncMirror([]string{"mirror"}, 5, zones)
+
+	dp := &DataPartition{volumeID: "mirror", config: &dataPartitionCfg{
+		NodeID: 1,
+		Peers:  []proto.Peer{{ID: 1, Addr: "a1"}, {ID: 2, Addr: "a2"}, {ID: 3, Addr: "a3"}},
+	}}
+	require.Equal(t, map[uint64]struct{}{3: {}}, dp.remoteZonePeers(zones, s.zoneName))
+	dp.config.Peers = dp.config.Peers[:2]
+	require.Empty(t, dp.remoteZonePeers(zones, s.zoneName))
+
+	// a leader packet without followers can't be mirrored
+	p := repl.NewPacket()
+	require.ErrorIs(t, s.checkSyncMirrorFollowers(p, dp), ErrSyncMirrorNoRemoteZone)
+	dp.volumeID = "plain"
+	require.NoError(t, s.checkSyncMirrorFollowers(p, dp))
+}
+
+func TestTLSRequired(t *testing.T) {
+	dp := &DataPartition{volumeID: "secure", replicas: []string{"10.0.0.1:17310", "10.0.0.2:17310"}}
+	s := &DataNode{space: &SpaceManager{partitions: map[uint64]*DataPartition{1: dp}}}
+	p := repl.NewPacket()
+	p.PartitionID = 1
+	p.Opcode = proto.OpWrite
+
+	client := "10.0.0.9:50000"
+	require.False(t, s.isTLSRequired(p, client))
+	s.setTLSRequiredVols([]string{"secure"})
+	require.True(t, s.isTLSRequired(p, client))
+	// the replicas replicating and repairing are not clients
+	require.False(t, s.isTLSRequired(p, "10.0.0.2:41000"))
+	p.Opcode = proto.OpCreateDataPartition
+	require.False(t, s.isTLSRequired(p, client))
+	p.Opcode = proto.OpWrite
+	dp.volumeID = "plain"
+	require.False(t, s.isTLSRequired(p, client))
+	p.PartitionID = 2
+	require.False(t, s.isTLSRequired(p, client))
+}
+
+func TestVolImmutable(t *testing.T) {
+	dp := &DataPartition{volumeID: "locked"}
+	s := &DataNode{space: &SpaceManager{partitions: map[uint64]*DataPartition{1: dp}}}
+	p := repl.NewPacket()
+	p.PartitionID = 1
+	p.Opcode = proto.OpRandomWrite
+
+	now := time.Now()
+	require.False(t, s.isVolImmutable(p, now))
+	s.setImmutableVols(map[string]int64{"locked": now.Unix() + 60})
+	require.True(t, s.isVolImmutable(p, now))
+	p.Opcode = proto.OpSyncRandomWriteVer
+	require.True(t, s.isVolImmutable(p, now))
+	// the appends are new extents, the metanodes reject adding them to the files
+	p.Opcode = proto.OpWrite
+	require.False(t, s.isVolImmutable(p, now))
+	p.Opcode = proto.OpRandomWrite
+	require.False(t, s.isVolImmutable(p, now.Add(time.Minute)), "the window is over")
+	dp.volumeID = "plain"
+	require.False(t, s.isVolImmutable(p, now))
+	p.PartitionID = 2
+	require.False(t, s.isVolImmutable(p, now))
+}
+
+func TestReserveWrite(t *testing.T) {
+	disk := &Disk{Path: "/data0", Total: 100 * util.GB, DiskRdonlySpace: 10 * util.GB, ReservedSpace: 10 * util.GB, Used: 90 * util.GB}
+	dp1 := &DataPartition{partitionID: 1, partitionSize: 4 * util.GB, used: 3 * util.GB, disk: disk}
+	dp2 := &DataPartition{partitionID: 2, partitionSize: 100 * util.GB, disk: disk}
+
+	// the partition is capped at its size before its usage is computed again
+	require.NoError(t, dp1.reserveWrite(util.GB/2))
+	require.NoError(t, dp1.reserveWrite(util.GB/2))
+	require.ErrorIs(t, dp1.reserveWrite(1), storage.NoSpaceError)
+
+	// and the disk keeps its reserved space for the other partitions
+	require.NoError(t, dp2.reserveWrite(8*util.GB))
+	require.ErrorIs(t, dp2.reserveWrite(2*util.GB), storage.NoSpaceError)
+	require.Equal(t, int64(9*util.GB), disk.writes.pending())
+	require.Equal(t, int64(8*util.GB), dp2.writes.pending())
+	dp1.commitWrite(util.GB)
+	dp2.commitWrite(8 * util.GB)
+	disk.Used += 9 * util.GB
+	require.NoError(t, disk.writes.measure(func() error { return nil }))
+	require.ErrorIs(t, dp2.reserveWrite(2*util.GB), storage.NoSpaceError)
+	disk.Used -= 2 * util.GB
+	require.NoError(t, dp2.reserveWrite(2*util.GB))
+}
+
+func TestWriteReservationConcurrent(t *testing.T) {
+	var (
+		r       writeReservation
+		used    int64 // the bytes on the disk
+		usage   int64 // the bytes on the disk when last measured
+		wg      sync.WaitGroup
+		stopped = make(chan struct{})
+	)
+	fits := func(int64) bool { return true }
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				size := int64(i*1000 + j + 1)
+				require.True(t, r.reserve(size, fits))
+				if j%3 == 0 {
+					r.release(size)
+					continue
+				}
+				atomic.AddInt64(&used, size)
+				r.commit(size)
+			}
+		}(i)
+	}
+	go func() {
+		for {
+			select {
+			case <-stopped:
+				return
+			default:
+			}
+			r.measure(func() error {
+				usage = atomic.LoadInt64(&used)
+				return nil
+			})
+		}
+	}()
+	wg.Wait()
+	close(stopped)
+
+	// whenever it is measured, the usage and the bytes written since add up
+	r.Lock()
+	defer r.Unlock()
+	require.Zero(t, r.inflight)
+	require.Equal(t, atomic.LoadInt64(&used), usage+r.written)
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package datanode
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/datanode/repl"
+	"github.com/cubefs/cubefs/datanode/storage"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A read replica is a copy of a read only hot partition kept out of its raft group. It copies the extents
+// of the hosts and serves the stream reads only while none of the hosts applied a write since the copy:
+// the random writes overwrite the extents in place and can't be caught up by a copy of the size growth.
+// A stale read replica refuses the reads and reports itself not ready, the master removes it.
+
+const (
+	readReplicaCheckInterval = 10 * time.Second
+	readReplicaSyncInterval  = time.Minute
+)
+
+func (dp *DataPartition) isReadReplica() bool {
+	return dp.config.ReadReplica
+}
+
+func (dp *DataPartition) isReadReplicaReady() bool {
+	return atomic.LoadInt32(&dp.readReplicaReady) == 1
+}
+
+func (dp *DataPartition) readReplicaSchedule() {
+	checkTimer := time.NewTimer(readReplicaCheckInterval)
+	syncTimer := time.NewTimer(0)
+	defer func() {
+		checkTimer.Stop()
+		syncTimer.Stop()
+	}()
+	for {
+		select {
+		case <-dp.stopC:
+			return
+		case <-checkTimer.C:
+			if atomic.LoadUint64(&dp.readReplicaApplyID) != 0 {
+				dp.checkReadReplicaStale()
+			}
+			checkTimer.Reset(readReplicaCheckInterval)
+		case <-syncTimer.C:
+			if err := dp.syncReadReplica(); err != nil {
+				log.LogWarnf("[readReplicaSchedule] dp(%v) sync read replica from hosts(%v) failed: %v",
+					dp.partitionID, dp.config.Hosts, err)
+			}
+			syncTimer.Reset(readReplicaSyncInterval)
+		}
+	}
+}
+
+// getHostsMaxAppliedID returns the max apply id of the hosts, all of them have to answer.
+func (dp *DataPartition) getHostsMaxAppliedID() (maxAppliedID uint64, err error) {
+	for _, host := range dp.config.Hosts {
+		var appliedID uint64
+		if appliedID, err = dp.getRemoteAppliedID(host, NewPacketToGetAppliedID(dp.partitionID)); err != nil {
+			return
+		}
+		if appliedID > maxAppliedID {
+			maxAppliedID = appliedID
+		}
+	}
+	return
+}
+
+// checkReadReplicaStale stops the reads once a host applied a write after the sync.
+func (dp *DataPartition) checkReadReplicaStale() (stale bool) {
+	if !dp.isReadReplicaReady() {
+		return
+	}
+	appliedID, err := dp.getHostsMaxAppliedID()
+	if err != nil {
+		return
+	}
+	if appliedID > atomic.LoadUint64(&dp.readReplicaApplyID) {
+		atomic.StoreInt32(&dp.readReplicaReady, 0)
+		log.LogWarnf("[checkReadReplicaStale] dp(%v) read replica is stale, hosts applied id(%v) synced id(%v)",
+			dp.partitionID, appliedID, atomic.LoadUint64(&dp.readReplicaApplyID))
+		return true
+	}
+	return
+}
+
+func (dp *DataPartition) syncReadReplica() (err error) {
+	if len(dp.config.Hosts) == 0 {
+		return fmt.Errorf("no host to sync from")
+	}
+	syncedApplyID := atomic.LoadUint64(&dp.readReplicaApplyID)
+	appliedID, err := dp.getHostsMaxAppliedID()
+	if err != nil {
+		return
+	}
+	if syncedApplyID == 0 {
+		// the first sync, every write applied later makes the replica stale
+		atomic.StoreUint64(&dp.readReplicaApplyID, appliedID)
+		if err = dp.PersistMetadata(); err != nil {
+			atomic.StoreUint64(&dp.readReplicaApplyID, 0)
+			return
+		}
+		syncedApplyID = appliedID
+	} else if appliedID > syncedApplyID {
+		atomic.StoreInt32(&dp.readReplicaReady, 0)
+		return fmt.Errorf("stale, hosts applied id(%v) synced id(%v)", appliedID, syncedApplyID)
+	}
+
+	var lastErr error
+	for _, host := range dp.config.Hosts {
+		if lastErr = dp.syncReadReplicaFrom(host); lastErr == nil {
+			break
+		}
+		log.LogWarnf("[syncReadReplica] dp(%v) sync from host(%v) failed: %v", dp.partitionID, host, lastErr)
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	// a write applied during the copy may be missed
+	if appliedID, err = dp.getHostsMaxAppliedID(); err != nil {
+		return
+	}
+	if appliedID > syncedApplyID {
+		atomic.StoreInt32(&dp.readReplicaReady, 0)
+		return fmt.Errorf("stale, hosts applied id(%v) synced id(%v)", appliedID, syncedApplyID)
+	}
+	if atomic.CompareAndSwapInt32(&dp.readReplicaReady, 0, 1) {
+		log.LogInfof("[syncReadReplica] dp(%v) read replica is ready, synced at apply id(%v)", dp.partitionID, syncedApplyID)
+	}
+	return
+}
+
+// syncReadReplicaFrom creates the extents missing locally and copies the data appended on the host.
+func (dp *DataPartition) syncReadReplicaFrom(host string) (err error) {
+	tinyExtents := make([]uint64, 0, storage.TinyExtentCount)
+	for id := storage.TinyExtentStartID; id < storage.TinyExtentStartID+storage.TinyExtentCount; id++ {
+		tinyExtents = append(tinyExtents, uint64(id))
+	}
+	store := dp.ExtentStore()
+	for _, extentType := range []uint8{proto.NormalExtentType, proto.TinyExtentType} {
+		var remoteExtents []*storage.ExtentInfo
+		if remoteExtents, err = dp.getRemoteExtentInfo(extentType, tinyExtents, host); err != nil {
+			return
+		}
+		for _, remote := range remoteExtents {
+			if remote.IsDeleted || store.IsDeletedNormalExtent(remote.FileID) {
+				continue
+			}
+			if !store.HasExtent(remote.FileID) {
+				if storage.IsTinyExtent(remote.FileID) {
+					continue
+				}
+				dp.disk.diskLimit(OpAsyncWrite, 0, func() {
+					err = store.Create(remote.FileID)
+				})
+				if err != nil {
+					return errors.Trace(err, "create extent(%v)", remote.FileID)
+				}
+			}
+			var local *storage.ExtentInfo
+			if local, err = store.Watermark(remote.FileID); err != nil {
+				return errors.Trace(err, "watermark extent(%v)", remote.FileID)
+			}
+			if local.TotalSize() >= remote.TotalSize() {
+				continue
+			}
+			info := &RepairExtentInfo{ExtentInfo: *remote, Source: host}
+			if err = dp.streamRepairExtent(info, repl.NewTinyExtentRepairReadPacket, repl.NewExtentRepairReadPacket,
+				repl.NewNormalExtentWithHoleRepairReadPacket, repl.NewPacketEx); err != nil {
+				return errors.Trace(err, "copy extent(%v)", remote.FileID)
+			}
+		}
+	}
+	if err = dp.repairContainersFrom([]string{host}); err != nil {
+		return errors.Trace(err, "copy containers")
+	}
+	dp.computeUsage()
+	return
+}
@@ -15,6 +15,7 @@
 package repl
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -249,6 +250,24 @@ func NewPacketToGetAllWatermarks(partitionID uint64, extentType uint8) (p *Packe
 	return
 }
 
+// NewPacketToGetContainerIndex asks the summary of the containers of a
+// partition, or the indexes of the given containers.
+func NewPacketToGetContainerIndex(partitionID uint64, extentIDs []uint64) (p *Packet, err error) {
+	p = new(Packet)
+	p.Opcode = proto.OpGetContainerIndex
+	p.PartitionID = partitionID
+	p.Magic = proto.ProtoMagic
+	p.ReqID = proto.GenerateRequestID()
+	p.ExtentType = proto.TinyExtentType
+	if len(extentIDs) > 0 {
+		if p.Data, err = json.Marshal(extentIDs); err != nil {
+			return nil, err
+		}
+		p.Size = uint32(len(p.Data))
+	}
+	return
+}
+
 func NewPacketToReadTinyDeleteRecord(partitionID uint64, offset int64) (p *Packet) {
 	p = new(Packet)
 	p.Opcode = proto.OpReadTinyDeleteRecord
@@ -411,6 +430,10 @@ func (p *Packet) GetArg() []byte {
 	return p.Arg
 }
 
+func (p *Packet) GetFollowersAddrs() []string {
+	return p.followersAddrs
+}
+
 func (p *Packet) GetCRC() uint32 {
 	return p.CRC
 }
@@ -484,6 +507,10 @@ func (p *Packet) identificationErrorResultCode(errLog string, errMsg string) {
 		p.ResultCode = proto.OpWriteOpOfProtoVerForbidden
 	} else if strings.Contains(errMsg, storage.VolForbidWriteOpOfProtoVer.Error()) {
 		p.ResultCode = proto.OpWriteOpOfProtoVerForbidden
+	} else if strings.Contains(errMsg, proto.ErrClientEvicted.Error()) {
+		p.ResultCode = proto.OpForbidErr
+	} else if strings.Contains(errMsg, proto.ErrStaleFenceToken.Error()) {
+		p.ResultCode = proto.OpForbidErr
 	} else {
 		if p.Opcode == proto.OpReadTinyDeleteRecord ||
 			(p.Opcode == proto.OpStreamFollowerRead && strings.Contains(errMsg, "timeout")) {
@@ -529,7 +556,9 @@ func (p *Packet) IsMasterCommand() bool {
 		proto.OpQueryBadDiskRecoverProgress,
 		proto.OpDeleteBackupDirectories,
 		proto.OpDeleteLostDisk,
-		proto.OpReloadDisk:
+		proto.OpReloadDisk,
+		// not sent by master, but served without a partition as well
+		proto.OpNegotiateFeatures:
 		return true
 	default:
 		return false
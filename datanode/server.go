@@ -16,8 +16,10 @@ package datanode
 
 import (
 	"bytes"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	syslog "log"
 	"net"
 	"net/http"
@@ -34,11 +36,14 @@ import (
 
 	"github.com/cubefs/cubefs/cmd/common"
 	"github.com/cubefs/cubefs/datanode/repl"
+	"github.com/cubefs/cubefs/datanode/storage"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/raftstore"
 	masterSDK "github.com/cubefs/cubefs/sdk/master"
 	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/atomicutil"
+	"github.com/cubefs/cubefs/util/bundle"
+	"github.com/cubefs/cubefs/util/cgroup"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/loadutil"
@@ -53,6 +58,8 @@ var (
 	ErrNoSpaceToCreatePartition    = errors.New("No disk space to create a data partition")
 	ErrNewSpaceManagerFailed       = errors.New("Creater new space manager failed")
 	ErrGetMasterDatanodeInfoFailed = errors.New("Failed to get datanode info from master")
+	ErrSyncMirrorNoRemoteZone      = errors.New("sync mirror write has no replica in another zone")
+	ErrSyncMirrorAckTimeout        = errors.New("sync mirror write is not acked by another zone in time")
 
 	LocalIP   string
 	gConnPool = util.NewConnectPool()
@@ -136,6 +143,8 @@ const (
 	// load/stop dp limit
 	ConfigDiskCurrentLoadDpLimit = "diskCurrentLoadDpLimit"
 	ConfigDiskCurrentStopDpLimit = "diskCurrentStopDpLimit"
+	// extent files examined at the same time while loading the partitions
+	ConfigExtentLoadConcurrency = "extentLoadConcurrency"
 	// disk read extent limit
 	ConfigEnableDiskReadExtentLimit = "enableDiskReadRepairExtentLimit" // bool
 
@@ -152,6 +161,10 @@ const (
 
 	// storage device media type, for hybrid cloud, in string: SDD or HDD
 	ConfigMediaType = "mediaType"
+
+	// shed load when the usage reaches this ratio of the cgroup limit, 0 disables it
+	ConfigCgroupMemThrottleRatio = "cgroupMemThrottleRatio" // float
+	ConfigCgroupCpuThrottleRatio = "cgroupCpuThrottleRatio" // float
 )
 
 const cpuSampleDuration = 1 * time.Second
@@ -239,6 +252,8 @@ type DataNode struct {
 	diskUnavailablePartitionErrorCount uint64 // disk status becomes unavailable when disk error partition count reaches this value
 	started                            int32
 	dpBackupTimeout                    time.Duration
+	replicaTombstoneRetention          time.Duration   // 0 keeps the tombstones as long as the backups
+	maintenance                        atomicutil.Bool // set by master, the replica repair is deferred
 	cacheCap                           int
 	mediaType                          uint32              // type of storage hardware medi
 	nodeForbidWriteOpOfProtoVer0       bool                // whether forbid by node granularity,
@@ -246,6 +261,15 @@ type DataNode struct {
 	DirectReadVols                     map[string]struct{}
 	IgnoreTinyRecoverVols              map[string]struct{}
 	ExtentCacheTtlByMin                int
+	throttleConf                       cgroup.ThrottleConfig
+	throttler                          *cgroup.Throttler
+	clientFence                        atomic.Value // *proto.ClientFence, the clients evicted by master
+	volFences                          atomic.Value // *proto.FenceTokens, the fencing tokens of the volumes
+	syncMirror                         atomic.Value // *syncMirrorInfo, the volumes whose writes are acked by another zone
+	tlsConfig                          *tls.Config  // the TLS clients are served if set
+	tlsRequiredVols                    atomic.Value // map[string]struct{}, the volumes whose clients are served over TLS only
+	immutableVols                      atomic.Value // map[string]int64, the volumes immutable until the unix second
+	reportDelta                        partitionReportDelta
 }
 
 type verOp2Phase struct {
@@ -352,6 +376,10 @@ func doStart(server common.Server, cfg *config.Config) (err error) {
 	// start cpu sampler
 	s.startCpuSample()
 
+	// throttle itself when running close to the cgroup limits
+	s.throttler = cgroup.NewThrottler(s.throttleConf, s.space.ShrinkExtentCache)
+	s.throttler.Start(s.cpuSamplerDone)
+
 	s.startGcTimer()
 
 	s.setStart()
@@ -421,6 +449,12 @@ func (s *DataNode) parseConfig(cfg *config.Config) (err error) {
 	s.cacheCap = cfg.GetInt(ConfigKeyCacheCap)
 	log.LogWarnf("parseConfig: cache cap size %d", s.cacheCap)
 
+	if certFile := cfg.GetString(proto.TLSCertFileKey); certFile != "" {
+		if s.tlsConfig, err = util.NewServerTLSConfig(certFile, cfg.GetString(proto.TLSKeyFileKey)); err != nil {
+			return fmt.Errorf("parseConfig: load tls certificate: %v", err)
+		}
+	}
+
 	updateInterval := cfg.GetInt(configNameResolveInterval)
 	if updateInterval <= 0 || updateInterval > 60 {
 		log.LogWarnf("name resolving interval[1-60] is set to default: %v", DefaultNameResolveInterval)
@@ -511,6 +545,15 @@ func (s *DataNode) parseConfig(cfg *config.Config) (err error) {
 
 	s.ExtentCacheTtlByMin = cfg.GetIntWithDefault(ConfigExtentCacheTtlByMin, DefaultExtentCacheTtlByMin)
 
+	s.throttleConf = cgroup.ThrottleConfig{MemoryRatio: cgroup.DefaultMemoryThrottleRatio, CPURatio: cgroup.DefaultCPUThrottleRatio}
+	if cfg.HasKey(ConfigCgroupMemThrottleRatio) {
+		s.throttleConf.MemoryRatio = cfg.GetFloat(ConfigCgroupMemThrottleRatio)
+	}
+	if cfg.HasKey(ConfigCgroupCpuThrottleRatio) {
+		s.throttleConf.CPURatio = cfg.GetFloat(ConfigCgroupCpuThrottleRatio)
+	}
+	log.LogDebugf("action[parseConfig] load throttleConf(%+v).", s.throttleConf)
+
 	log.LogDebugf("action[parseConfig] load masterAddrs(%v).", MasterClient.Nodes())
 	log.LogDebugf("action[parseConfig] load port(%v).", s.port)
 	log.LogDebugf("action[parseConfig] load zoneName(%v).", s.zoneName)
@@ -565,6 +608,7 @@ func (s *DataNode) newSpaceManager(cfg *config.Config) (err error) {
 	stopLimit := cfg.GetInt(ConfigDiskCurrentStopDpLimit)
 	s.space.SetCurrentLoadDpLimit(loadLimit)
 	s.space.SetCurrentStopDpLimit(stopLimit)
+	storage.SetExtentLoadConcurrency(cfg.GetInt(ConfigExtentLoadConcurrency))
 
 	return
 }
@@ -970,6 +1014,10 @@ func (s *DataNode) registerHandler() {
 	http.HandleFunc("/setGOGC", s.setGOGC)
 	http.HandleFunc("/getGOGC", s.getGOGC)
 	http.HandleFunc("/triggerRaftLogRotate", s.triggerRaftLogRotate)
+
+	bundle.RegisterState("disks", s.disksReport)
+	bundle.RegisterState("partitions", s.partitionsReport)
+	bundle.RegisterState("stat", s.statReport)
 }
 
 func (s *DataNode) startTCPService() (err error) {
@@ -1019,7 +1067,20 @@ func (s *DataNode) serveConn(conn net.Conn) {
 	c, _ := conn.(*net.TCPConn)
 	c.SetKeepAlive(true)
 	c.SetNoDelay(true)
-	packetProcessor := repl.NewReplProtocol(conn, s.Prepare, s.OperatePacket, s.Post)
+	sc, secure, err := util.AcceptTLSConn(conn, s.tlsConfig)
+	if err == nil && !secure {
+		sc, err = util.AcceptCompressConn(sc)
+	}
+	if err != nil {
+		if err != io.EOF {
+			log.LogWarnf("action[serveConn] remote(%v) accept conn err: %v", conn.RemoteAddr(), err)
+		}
+		conn.Close()
+		space.Stats().RemoveConnection()
+		return
+	}
+	conn = sc
+	packetProcessor := repl.NewReplProtocol(conn, s.prepareFrom(conn), s.OperatePacket, s.Post)
 	packetProcessor.ServerConn()
 	space.Stats().RemoveConnection()
 }
@@ -1101,7 +1162,7 @@ func (s *DataNode) serveSmuxConn(conn net.Conn) {
 }
 
 func (s *DataNode) serveSmuxStream(stream *smux.Stream) {
-	packetProcessor := repl.NewReplProtocol(stream, s.Prepare, s.OperatePacket, s.Post)
+	packetProcessor := repl.NewReplProtocol(stream, s.prepareFrom(stream), s.OperatePacket, s.Post)
 	if s.enableSmuxConnPool {
 		packetProcessor.SetSmux(s.getRepairConnFunc, s.putRepairConnFunc)
 	}
@@ -45,6 +45,10 @@ var parseArgs = common.ParseArguments
 var AutoRepairStatus = true
 
 func (s *DataNode) getDiskAPI(w http.ResponseWriter, r *http.Request) {
+	s.buildSuccessResp(w, s.disksReport())
+}
+
+func (s *DataNode) disksReport() interface{} {
 	disks := make([]interface{}, 0)
 	for _, diskItem := range s.space.GetDisks() {
 		disk := &struct {
@@ -83,17 +87,20 @@ func (s *DataNode) getDiskAPI(w http.ResponseWriter, r *http.Request) {
 		Disks: disks,
 		Zone:  s.zoneName,
 	}
-	s.buildSuccessResp(w, diskReport)
+	return diskReport
 }
 
 func (s *DataNode) getStatAPI(w http.ResponseWriter, r *http.Request) {
+	s.buildSuccessResp(w, s.statReport())
+}
+
+func (s *DataNode) statReport() interface{} {
 	response := &proto.DataNodeHeartbeatResponse{}
 	forbiddenVols := make(map[string]struct{})
 	volDpRepairBlockSizes := make(map[string]uint64)
 
 	s.buildHeartBeatResponse(response, forbiddenVols, volDpRepairBlockSizes, "")
-
-	s.buildSuccessResp(w, response)
+	return response
 }
 
 func (s *DataNode) setAutoRepairStatus(w http.ResponseWriter, r *http.Request) {
@@ -117,6 +124,10 @@ func (s *DataNode) getRaftStatus(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *DataNode) getPartitionsAPI(w http.ResponseWriter, r *http.Request) {
+	s.buildSuccessResp(w, s.partitionsReport())
+}
+
+func (s *DataNode) partitionsReport() interface{} {
 	partitions := make([]interface{}, 0)
 	var lock sync.Mutex
 	s.space.RangePartitions(func(dp *DataPartition, testID string) bool {
@@ -149,7 +160,7 @@ func (s *DataNode) getPartitionsAPI(w http.ResponseWriter, r *http.Request) {
 		Partitions:     partitions,
 		PartitionCount: len(partitions),
 	}
-	s.buildSuccessResp(w, result)
+	return result
 }
 
 func (s *DataNode) getPartitionAPI(w http.ResponseWriter, r *http.Request) {
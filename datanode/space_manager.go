@@ -63,6 +63,9 @@ type SpaceManager struct {
 	diskUtils          map[string]*atomicutil.Float64
 	samplerDone        chan struct{}
 	allDisksLoaded     bool
+	loadPartitions     int64 // found on the disks to load
+	loadedPartitions   int64
+	loadFinishTime     int64 // unix time all the partitions are loaded and verified
 	dataNodeIDs        map[string]uint64
 	dataNodeIDsMutex   sync.RWMutex
 }
@@ -667,6 +670,7 @@ func (manager *SpaceManager) CreatePartition(request *proto.CreateDataPartitionR
 		Forbidden:                false,
 		IsEnableSnapshot:         manager.dataNode.clusterEnableSnapshot,
 		ForbidWriteOpOfProtoVer0: false,
+		ReadReplica:              request.ReadReplica,
 	}
 	log.LogInfof("action[CreatePartition] dp %v dpCfg.Peers %v request.Members %v",
 		dpCfg.PartitionID, dpCfg.Peers, request.Members)
@@ -700,14 +704,23 @@ func (manager *SpaceManager) CreatePartition(request *proto.CreateDataPartitionR
 }
 
 // DeletePartition deletes a partition based on the partition id.
-func (manager *SpaceManager) DeletePartition(dpID uint64, force bool) (err error) {
+// DeletePartition deletes the partition. The data of a raft force deletion is
+// kept as a backup, and the data of a replica dropped by decommission or
+// migration as a tombstone, both to be resurrected or deleted later.
+func (manager *SpaceManager) DeletePartition(dpID uint64, force, tombstone bool) (err error) {
+	backupPrefix := ""
+	if force {
+		backupPrefix = BackupPartitionPrefix
+	} else if tombstone {
+		backupPrefix = TombstonePartitionPrefix
+	}
 	manager.partitionMutex.Lock()
 
 	dp := manager.partitions[dpID]
 	if dp == nil {
 		manager.partitionMutex.Unlock()
 		// maybe dp not loaded when triggered disk error, need to remove disk root dir
-		err = manager.deleteDataPartitionNotLoaded(dpID, force)
+		err = manager.deleteDataPartitionNotLoaded(dpID, backupPrefix)
 		return err
 	}
 
@@ -715,7 +728,7 @@ func (manager *SpaceManager) DeletePartition(dpID uint64, force bool) (err error
 	manager.partitionMutex.Unlock()
 	dp.Stop()
 	dp.Disk().DetachDataPartition(dp)
-	if err := dp.RemoveAll(force); err != nil {
+	if err := dp.RemoveAll(backupPrefix); err != nil {
 		return err
 	}
 	return nil
@@ -740,9 +753,11 @@ func (s *DataNode) buildHeartBeatResponse(response *proto.DataNodeHeartbeatRespo
 	response.LostDisks = make([]string, 0)
 	response.StartTime = s.startTime
 	stat.Unlock()
+	response.LoadProgress = s.space.LoadProgress()
 
 	response.ZoneName = s.zoneName
 	response.ReceivedForbidWriteOpOfProtoVer0 = s.nodeForbidWriteOpOfProtoVer0
+	response.Features = proto.DataNodeFeatures
 	response.PartitionReports = make([]*proto.DataPartitionReport, 0)
 	space := s.space
 	begin := time.Now()
@@ -769,6 +784,9 @@ func (s *DataNode) buildHeartBeatResponse(response *proto.DataNodeHeartbeatRespo
 			ReadOnlyReasons:            partition.ReadOnlyReasons(),
 			IsMissingTinyExtent:        partition.isMissingTinyExtent,
 			IsRepairing:                partition.isRepairing,
+			ReadOps:                    atomic.LoadUint64(&partition.readOps),
+			ReadReplica:                partition.isReadReplica(),
+			ReadReplicaReady:           partition.isReadReplicaReady(),
 		}
 		log.LogDebugf("action[Heartbeats] dpid(%v), status(%v) total(%v) used(%v) leader(%v) isLeader(%v) "+
 			"TriggerDiskError(%v) reqId(%v) testID(%v) cost(%v).",
@@ -877,7 +895,29 @@ func (s *DataNode) buildHeartBeatResponse(response *proto.DataNodeHeartbeatRespo
 	}
 }
 
-func (manager *SpaceManager) deleteDataPartitionNotLoaded(id uint64, force bool) error {
+// LoadProgress returns how far the data node is in loading its partitions.
+func (manager *SpaceManager) LoadProgress() (p proto.DataNodeLoadProgress) {
+	p.StartTime = manager.dataNode.startTime
+	p.Partitions = int(atomic.LoadInt64(&manager.loadPartitions))
+	p.LoadedPartitions = int(atomic.LoadInt64(&manager.loadedPartitions))
+	for _, dp := range manager.getPartitions() {
+		if store := dp.ExtentStore(); store != nil && !store.Verified() {
+			p.VerifyingPartitions++
+		}
+	}
+	if p.FinishTime = atomic.LoadInt64(&manager.loadFinishTime); p.FinishTime != 0 {
+		return
+	}
+	if manager.dataNode.checkAllDiskLoaded() && p.VerifyingPartitions == 0 {
+		p.FinishTime = time.Now().Unix()
+		atomic.StoreInt64(&manager.loadFinishTime, p.FinishTime)
+		log.LogInfof("[LoadProgress] loaded partitions(%v) using time(%v)", p.Partitions,
+			time.Duration(p.FinishTime-p.StartTime)*time.Second)
+	}
+	return
+}
+
+func (manager *SpaceManager) deleteDataPartitionNotLoaded(id uint64, backupPrefix string) error {
 	if !manager.dataNode.checkAllDiskLoaded() {
 		return errors.NewErrorf("Disks on data node %v are not loaded completed", manager.dataNode.localServerAddr)
 	}
@@ -908,8 +948,8 @@ func (manager *SpaceManager) deleteDataPartitionNotLoaded(id uint64, force bool)
 				} else {
 					if partitionID == id {
 						rootPath := path.Join(d.Path, filename)
-						if force {
-							newPath := fmt.Sprintf("%v-%v", path.Join(d.Path, BackupPartitionPrefix+filename), time.Now().Format("20060102150405"))
+						if backupPrefix != "" {
+							newPath := fmt.Sprintf("%v-%v", path.Join(d.Path, backupPrefix+filename), time.Now().Format("20060102150405"))
 							//_, err := os.Stat(newPath)
 							//if err == nil {
 							//	newPathWithTimestamp := fmt.Sprintf("%v-%v", newPath, time.Now().Format("20060102150405"))
@@ -998,6 +1038,16 @@ func (manager *SpaceManager) getPartitions() []*DataPartition {
 	return partitions
 }
 
+// ShrinkExtentCache closes all the cached extents that are not accessed right now,
+// it is called when the datanode runs short of memory.
+func (manager *SpaceManager) ShrinkExtentCache() {
+	for _, partition := range manager.getPartitions() {
+		if err := partition.extentStore.DoExtentCacheTtl(0); err != nil {
+			log.LogWarnf("[ShrinkExtentCache] dp(%v) err: %v", partition.partitionID, err)
+		}
+	}
+}
+
 func (manager *SpaceManager) StartEvictExtentCache() {
 	interval := manager.dataNode.ExtentCacheTtlByMin / 2
 	if interval <= 0 {
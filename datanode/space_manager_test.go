@@ -19,6 +19,7 @@ import (
 	"math/rand"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,3 +112,25 @@ func TestSelectDiskForSmallDp(t *testing.T) {
 		t.Logf("disk(%v) left space(%v) GB", disk.Path, disk.Available/util.GB)
 	}
 }
+
+func TestBackupPartitionRetention(t *testing.T) {
+	d := &Disk{dataNode: &DataNode{dpBackupTimeout: proto.DefaultDataPartitionBackupTimeOut}}
+	now := time.Now()
+	backup := fmt.Sprintf("%vdatapartition_10_128-%v", BackupPartitionPrefix, now.Format("20060102150405"))
+	tombstone := fmt.Sprintf("%vdatapartition_11_128-%v", TombstonePartitionPrefix, now.Format("20060102150405"))
+
+	for id, name := range map[uint64]string{10: backup, 11: tombstone} {
+		require.True(t, d.isBackupPartitionDirToDelete(name))
+		partitionID, ts, err := unmarshalBackupPartitionDirNameAndTimestamp(name)
+		require.NoError(t, err)
+		require.Equal(t, id, partitionID)
+		require.Equal(t, now.Unix(), ts)
+	}
+	require.Equal(t, "datapartition_10_128", strings.Split(trimBackupPartitionPrefix(backup), "-")[0])
+	require.Equal(t, "datapartition_11_128", strings.Split(trimBackupPartitionPrefix(tombstone), "-")[0])
+
+	require.Equal(t, proto.DefaultDataPartitionBackupTimeOut, d.backupRetention(tombstone))
+	d.dataNode.replicaTombstoneRetention = time.Hour
+	require.Equal(t, time.Hour, d.backupRetention(tombstone))
+	require.Equal(t, proto.DefaultDataPartitionBackupTimeOut, d.backupRetention(backup))
+}
@@ -0,0 +1,959 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A container extent packs the small files of a volume as records, so that
+// billions of them take neither as many extent files nor as many extent infos.
+// A record is appended with a header of its id, size and crc, a deletion
+// appends a tombstone of the record. The index of a container is kept in
+// memory and persisted beside it from time to time, on load it is rebuilt from
+// the persisted index and the entries appended after it, a torn tail is cut.
+//
+// The leader of the partition allocates the record ids, the extent offset of a
+// key in a container is proto.ContainerOffset of its record, so the keys stay
+// valid when the containers with mostly deleted records are compacted. The
+// containers with every record deleted are removed, their ids are never used
+// again.
+
+const (
+	ContainerFilePrefix      = "container_"
+	ContainerIndexSuffix     = ".idx"
+	ContainerTempSuffix      = ".tmp"
+	ContainerRemovedFileName = "CONTAINER_REMOVED"
+
+	ContainerGarbageRatio = 0.5 // the share of garbage a container is compacted at
+
+	containerEntryMagic     uint16 = 0xc0f5
+	containerIndexMagic     uint32 = 0x43494458
+	containerEntryHeaderLen        = 24
+	containerIndexHeaderLen        = 36
+	containerIndexEntryLen         = 24
+	containerIndexSealed    uint32 = 1
+	containerIndexInterval         = 4 * util.MB // of the entries appended after the persisted index
+	containerIdleTime              = 10 * time.Minute
+)
+
+var (
+	// ContainerSealSize is the size no record is allocated in a container from.
+	ContainerSealSize int64 = 128 * util.MB
+	// ContainerRemoveDelay is the time since the last entry appended to a
+	// container before it is removed.
+	ContainerRemoveDelay = time.Hour
+)
+
+const (
+	containerEntryRecord uint8 = iota + 1
+	containerEntryTombstone
+)
+
+var RegexpContainerFile = regexp.MustCompile(`^container_(\d+)$`)
+
+// IsContainerExtent checks if the given extent is a container extent.
+func IsContainerExtent(extentID uint64) bool {
+	return proto.IsContainerExtent(extentID)
+}
+
+// ContainerRecordInfo is a record of a container index.
+type ContainerRecordInfo struct {
+	RecordID uint64
+	Size     uint32
+	Crc      uint32
+}
+
+// ContainerIndex is the index of a container sent to the other replicas.
+type ContainerIndex struct {
+	ExtentID   uint64
+	Records    []ContainerRecordInfo
+	Tombstones []uint64
+}
+
+// ContainerDigest sums the index of a container up, the replicas only
+// exchange the indexes of the containers their digests differ on.
+type ContainerDigest struct {
+	ExtentID    uint64
+	Records     int
+	Tombstones  int
+	MaxRecordID uint64
+	Sum         uint64
+}
+
+// ContainerSummary is what a replica knows of the containers of a partition.
+type ContainerSummary struct {
+	Digests []*ContainerDigest
+	Removed []uint64
+}
+
+type containerRecord struct {
+	offset int64 // of the data in the container file
+	size   uint32
+	crc    uint32
+}
+
+type container struct {
+	sync.RWMutex
+	id          uint64
+	path        string
+	size        int64 // of the container file, the entries are appended at
+	indexed     int64 // of the container file covered by the persisted index
+	records     map[uint64]containerRecord
+	deleted     map[uint64]struct{}
+	maxRecordID uint64 // of the records and the tombstones
+	allocated   uint64 // the last record id allocated by the leader
+	garbage     int64  // bytes of the records replaced or deleted
+	sum         uint64
+	sealed      bool  // no record is allocated in it any more
+	modified    int64 // when the last entry was appended
+
+	fdLock  sync.Mutex
+	file    *os.File
+	lastUse int64
+}
+
+// ContainerStore holds the container extents of an extent store.
+type ContainerStore struct {
+	sync.RWMutex
+	dataPath    string
+	partitionID uint64
+	containers  map[uint64]*container
+	removed     map[uint64]struct{}
+	removedFp   *os.File
+	active      uint64 // the container the records are allocated in
+}
+
+func containerEntrySum(recordID uint64, kind uint8) uint64 {
+	h := recordID*0x9e3779b97f4a7c15 + uint64(kind)
+	h ^= h >> 31
+	h *= 0xbf58476d1ce4e5b9
+	return h ^ h>>29
+}
+
+func marshalContainerEntryHeader(buf []byte, kind uint8, recordID uint64, size, crc uint32) {
+	binary.BigEndian.PutUint16(buf[0:2], containerEntryMagic)
+	buf[2] = kind
+	buf[3] = 0
+	binary.BigEndian.PutUint32(buf[4:8], size)
+	binary.BigEndian.PutUint64(buf[8:16], recordID)
+	binary.BigEndian.PutUint32(buf[16:20], crc)
+	binary.BigEndian.PutUint32(buf[20:24], crc32.ChecksumIEEE(buf[0:20]))
+}
+
+func unmarshalContainerEntryHeader(buf []byte) (kind uint8, recordID uint64, size, crc uint32, ok bool) {
+	if binary.BigEndian.Uint16(buf[0:2]) != containerEntryMagic ||
+		binary.BigEndian.Uint32(buf[20:24]) != crc32.ChecksumIEEE(buf[0:20]) {
+		return
+	}
+	kind = buf[2]
+	if kind != containerEntryRecord && kind != containerEntryTombstone {
+		return
+	}
+	return kind, binary.BigEndian.Uint64(buf[8:16]), binary.BigEndian.Uint32(buf[4:8]),
+		binary.BigEndian.Uint32(buf[16:20]), true
+}
+
+// NewContainerStore loads the containers in dataPath.
+func NewContainerStore(dataPath string, partitionID uint64) (s *ContainerStore, err error) {
+	s = &ContainerStore{
+		dataPath:    dataPath,
+		partitionID: partitionID,
+		containers:  make(map[uint64]*container),
+		removed:     make(map[uint64]struct{}),
+	}
+	if s.removedFp, err = os.OpenFile(path.Join(dataPath, ContainerRemovedFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o666); err != nil {
+		return
+	}
+	data, err := io.ReadAll(s.removedFp)
+	if err != nil {
+		s.removedFp.Close()
+		return
+	}
+	for off := 0; off+8 <= len(data); off += 8 {
+		s.removed[binary.BigEndian.Uint64(data[off:off+8])] = struct{}{}
+	}
+
+	entries, err := os.ReadDir(dataPath)
+	if err != nil {
+		s.removedFp.Close()
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ContainerFilePrefix) && strings.HasSuffix(name, ContainerTempSuffix) {
+			os.Remove(path.Join(dataPath, name))
+			continue
+		}
+		match := RegexpContainerFile.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		id, _ := strconv.ParseUint(match[1], 10, 64)
+		if _, ok := s.removed[id]; ok {
+			// removed but not deleted yet
+			os.Remove(path.Join(dataPath, name))
+			os.Remove(path.Join(dataPath, name+ContainerIndexSuffix))
+			continue
+		}
+		c := &container{id: id, path: path.Join(dataPath, name)}
+		if err = c.load(); err != nil {
+			s.Close()
+			return nil, fmt.Errorf("load container(%v) of dp(%v): %v", id, partitionID, err)
+		}
+		s.containers[id] = c
+		if id > s.active {
+			s.active = id
+		}
+	}
+	return
+}
+
+func (c *container) getFile() (f *os.File, err error) {
+	atomic.StoreInt64(&c.lastUse, time.Now().Unix())
+	c.fdLock.Lock()
+	defer c.fdLock.Unlock()
+	if c.file == nil {
+		c.file, err = os.OpenFile(c.path, os.O_CREATE|os.O_RDWR, 0o666)
+	}
+	return c.file, err
+}
+
+func (c *container) closeFile() {
+	c.fdLock.Lock()
+	defer c.fdLock.Unlock()
+	if c.file != nil {
+		c.file.Close()
+		c.file = nil
+	}
+}
+
+func (c *container) reset() {
+	c.records = make(map[uint64]containerRecord)
+	c.deleted = make(map[uint64]struct{})
+	c.size, c.indexed, c.maxRecordID, c.garbage, c.sum = 0, 0, 0, 0, 0
+	c.sealed = false
+}
+
+// apply adds an entry at offset of the container file to the index.
+func (c *container) apply(kind uint8, recordID uint64, offset int64, size, crc uint32) {
+	old, exist := c.records[recordID]
+	if exist {
+		c.garbage += int64(old.size) + containerEntryHeaderLen
+	}
+	if kind == containerEntryRecord {
+		if !exist {
+			c.sum ^= containerEntrySum(recordID, containerEntryRecord)
+		}
+		c.records[recordID] = containerRecord{offset: offset + containerEntryHeaderLen, size: size, crc: crc}
+	} else if _, ok := c.deleted[recordID]; !ok {
+		if exist {
+			c.sum ^= containerEntrySum(recordID, containerEntryRecord)
+			delete(c.records, recordID)
+		}
+		c.sum ^= containerEntrySum(recordID, containerEntryTombstone)
+		c.deleted[recordID] = struct{}{}
+	}
+	if recordID > c.maxRecordID {
+		c.maxRecordID = recordID
+	}
+}
+
+func (c *container) load() (err error) {
+	c.reset()
+	if err = c.loadIndex(); err != nil {
+		log.LogWarnf("[container.load] container(%v) ignore the index: %v", c.path, err)
+		c.reset()
+	}
+	f, err := c.getFile()
+	if err != nil {
+		return
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	if info.Size() < c.indexed {
+		log.LogWarnf("[container.load] container(%v) size(%v) below the index(%v)", c.path, info.Size(), c.indexed)
+		c.reset()
+	}
+	c.size = c.indexed
+	header := make([]byte, containerEntryHeaderLen)
+	data := make([]byte, 0)
+	for c.size+containerEntryHeaderLen <= info.Size() {
+		if _, err = f.ReadAt(header, c.size); err != nil {
+			return
+		}
+		kind, recordID, size, crc, ok := unmarshalContainerEntryHeader(header)
+		if !ok || c.size+containerEntryHeaderLen+int64(size) > info.Size() {
+			break
+		}
+		if kind == containerEntryRecord {
+			if cap(data) < int(size) {
+				data = make([]byte, size)
+			}
+			if _, err = f.ReadAt(data[:size], c.size+containerEntryHeaderLen); err != nil {
+				return
+			}
+			if crc32.ChecksumIEEE(data[:size]) != crc {
+				break
+			}
+		}
+		c.apply(kind, recordID, c.size, size, crc)
+		c.size += containerEntryHeaderLen + int64(size)
+	}
+	if c.size != info.Size() {
+		log.LogWarnf("[container.load] container(%v) cut the torn tail from (%v) to (%v)", c.path, info.Size(), c.size)
+		if err = f.Truncate(c.size); err != nil {
+			return
+		}
+	}
+	c.allocated = c.maxRecordID
+	c.sealed = c.sealed || c.size >= ContainerSealSize
+	c.modified = info.ModTime().Unix()
+	return
+}
+
+// loadIndex loads the persisted index, which is the magic, the flags, the size
+// of the container file covered, the max record id, the garbage, the count of
+// the entries, the entries and the crc of them all.
+func (c *container) loadIndex() (err error) {
+	data, err := os.ReadFile(c.path + ContainerIndexSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if len(data) < containerIndexHeaderLen+4 {
+		return fmt.Errorf("index too short(%v)", len(data))
+	}
+	body, sum := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return fmt.Errorf("index crc mismatch")
+	}
+	if magic := binary.BigEndian.Uint32(body[0:4]); magic != containerIndexMagic {
+		return fmt.Errorf("index magic(%x) mismatch", magic)
+	}
+	count := int(binary.BigEndian.Uint32(body[32:36]))
+	if len(body) != containerIndexHeaderLen+count*containerIndexEntryLen {
+		return fmt.Errorf("index of (%v) entries has size(%v)", count, len(body))
+	}
+	for i := 0; i < count; i++ {
+		entry := body[containerIndexHeaderLen+i*containerIndexEntryLen:]
+		recordID := binary.BigEndian.Uint64(entry[0:8])
+		offset := int64(binary.BigEndian.Uint64(entry[8:16]))
+		if offset < 0 {
+			c.apply(containerEntryTombstone, recordID, 0, 0, 0)
+			continue
+		}
+		c.apply(containerEntryRecord, recordID, offset-containerEntryHeaderLen,
+			binary.BigEndian.Uint32(entry[16:20]), binary.BigEndian.Uint32(entry[20:24]))
+	}
+	c.sealed = binary.BigEndian.Uint32(body[4:8])&containerIndexSealed != 0
+	c.indexed = int64(binary.BigEndian.Uint64(body[8:16]))
+	if maxRecordID := binary.BigEndian.Uint64(body[16:24]); maxRecordID > c.maxRecordID {
+		c.maxRecordID = maxRecordID
+	}
+	c.garbage = int64(binary.BigEndian.Uint64(body[24:32]))
+	return
+}
+
+// persistIndex writes the index of the container, the caller holds the lock.
+func (c *container) persistIndex() (err error) {
+	buff := bytes.NewBuffer(make([]byte, 0, containerIndexHeaderLen+(len(c.records)+len(c.deleted))*containerIndexEntryLen+4))
+	flags := uint32(0)
+	if c.sealed {
+		flags |= containerIndexSealed
+	}
+	binary.Write(buff, binary.BigEndian, containerIndexMagic)
+	binary.Write(buff, binary.BigEndian, flags)
+	binary.Write(buff, binary.BigEndian, uint64(c.size))
+	binary.Write(buff, binary.BigEndian, c.maxRecordID)
+	binary.Write(buff, binary.BigEndian, uint64(c.garbage))
+	binary.Write(buff, binary.BigEndian, uint32(len(c.records)+len(c.deleted)))
+	entry := make([]byte, containerIndexEntryLen)
+	for recordID, rec := range c.records {
+		binary.BigEndian.PutUint64(entry[0:8], recordID)
+		binary.BigEndian.PutUint64(entry[8:16], uint64(rec.offset))
+		binary.BigEndian.PutUint32(entry[16:20], rec.size)
+		binary.BigEndian.PutUint32(entry[20:24], rec.crc)
+		buff.Write(entry)
+	}
+	for recordID := range c.deleted {
+		binary.BigEndian.PutUint64(entry[0:8], recordID)
+		binary.BigEndian.PutUint64(entry[8:16], uint64(1)<<63)
+		binary.BigEndian.PutUint64(entry[16:24], 0)
+		buff.Write(entry)
+	}
+	binary.Write(buff, binary.BigEndian, crc32.ChecksumIEEE(buff.Bytes()))
+
+	f, err := c.getFile()
+	if err != nil {
+		return
+	}
+	// the entries covered have to be on the disk before the index
+	if err = f.Sync(); err != nil {
+		return
+	}
+	tempPath := c.path + ContainerIndexSuffix + ContainerTempSuffix
+	if err = os.WriteFile(tempPath, buff.Bytes(), 0o666); err != nil {
+		return
+	}
+	if err = os.Rename(tempPath, c.path+ContainerIndexSuffix); err != nil {
+		return
+	}
+	c.indexed = c.size
+	return
+}
+
+// appendEntry appends an entry to the container, the caller holds the lock.
+func (c *container) appendEntry(kind uint8, recordID uint64, data []byte, isSync bool) (err error) {
+	f, err := c.getFile()
+	if err != nil {
+		return
+	}
+	crc := uint32(0)
+	if kind == containerEntryRecord {
+		crc = crc32.ChecksumIEEE(data)
+	}
+	buf := make([]byte, containerEntryHeaderLen+len(data))
+	marshalContainerEntryHeader(buf, kind, recordID, uint32(len(data)), crc)
+	copy(buf[containerEntryHeaderLen:], data)
+	if _, err = f.WriteAt(buf, c.size); err != nil {
+		return
+	}
+	if isSync {
+		if err = f.Sync(); err != nil {
+			return
+		}
+	}
+	c.apply(kind, recordID, c.size, uint32(len(data)), crc)
+	c.size += int64(len(buf))
+	c.sealed = c.sealed || c.size >= ContainerSealSize
+	c.modified = time.Now().Unix()
+	return
+}
+
+func (c *container) isSealed() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.sealed
+}
+
+func (c *container) allocRecordID() uint64 {
+	c.Lock()
+	defer c.Unlock()
+	if c.allocated < c.maxRecordID {
+		c.allocated = c.maxRecordID
+	}
+	c.allocated++
+	return c.allocated
+}
+
+// removable tells whether every record allocated in the sealed container is
+// deleted. The records allocated before it was sealed may still be on their
+// way, so it is removed a while after the last entry appended.
+func (c *container) removable() bool {
+	return c.sealed && len(c.records) == 0 && uint64(len(c.deleted)) == c.maxRecordID && c.maxRecordID > 0 &&
+		time.Since(time.Unix(c.modified, 0)) >= ContainerRemoveDelay
+}
+
+func (s *ContainerStore) getContainer(extentID uint64) *container {
+	s.RLock()
+	defer s.RUnlock()
+	return s.containers[extentID]
+}
+
+// getOrCreateContainer returns the container, it is created on the first
+// record of it, unless it is removed.
+func (s *ContainerStore) getOrCreateContainer(extentID uint64) (c *container, err error) {
+	if c = s.getContainer(extentID); c != nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.createContainer(extentID)
+}
+
+func (s *ContainerStore) createContainer(extentID uint64) (c *container, err error) {
+	if c = s.containers[extentID]; c != nil {
+		return
+	}
+	if _, ok := s.removed[extentID]; ok {
+		return nil, ExtentHasBeenDeletedError
+	}
+	c = &container{id: extentID, path: path.Join(s.dataPath, ContainerFilePrefix+strconv.FormatUint(extentID, 10))}
+	c.reset()
+	c.modified = time.Now().Unix()
+	if _, err = c.getFile(); err != nil {
+		return nil, err
+	}
+	s.containers[extentID] = c
+	return
+}
+
+func (s *ContainerStore) nextContainerID() uint64 {
+	next := uint64(proto.ContainerExtentStartID)
+	for id := range s.containers {
+		if id >= next {
+			next = id + 1
+		}
+	}
+	for id := range s.removed {
+		if id >= next {
+			next = id + 1
+		}
+	}
+	return next
+}
+
+// Alloc allocates a record in the active container, on the leader.
+func (s *ContainerStore) Alloc() (extentID uint64, offset int64, err error) {
+	s.Lock()
+	c := s.containers[s.active]
+	if c == nil || c.isSealed() {
+		id := s.nextContainerID()
+		if !proto.IsContainerExtent(id) {
+			s.Unlock()
+			return 0, 0, NoAvailableExtentError
+		}
+		if c, err = s.createContainer(id); err != nil {
+			s.Unlock()
+			return
+		}
+		s.active = id
+		log.LogInfof("[ContainerStore.Alloc] dp(%v) new active container(%v)", s.partitionID, id)
+	}
+	s.Unlock()
+	return c.id, int64(proto.ContainerOffset(c.allocRecordID(), 0)), nil
+}
+
+// Write writes the record at offset of the container, it replaces the record
+// of the same id. A repair write keeps the record already there.
+func (s *ContainerStore) Write(extentID uint64, offset int64, data []byte, isSync, isRepair bool) (err error) {
+	recordID, delta := proto.ParseContainerOffset(uint64(offset))
+	if recordID == 0 || delta != 0 || len(data) == 0 || uint64(len(data)) >= proto.ContainerRecordSpan {
+		return newParameterError("container(%v) offset=%d size=%d", extentID, offset, len(data))
+	}
+	c, err := s.getOrCreateContainer(extentID)
+	if err != nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.deleted[recordID]; ok {
+		if isRepair {
+			return nil
+		}
+		return ExtentHasBeenDeletedError
+	}
+	if _, ok := c.records[recordID]; ok && isRepair {
+		return nil
+	}
+	return c.appendEntry(containerEntryRecord, recordID, data, isSync)
+}
+
+func (c *container) overwriteRange(recordID, delta uint64, size int64) (rec containerRecord, err error) {
+	rec, ok := c.records[recordID]
+	if !ok || delta+uint64(size) > uint64(rec.size) {
+		return rec, errors.Trace(ExtentNotFoundError, "container(%v) record(%v) delta(%v) size(%v)", c.id, recordID, delta, size)
+	}
+	return
+}
+
+// CheckOverwrite checks that an overwrite is within a record.
+func (s *ContainerStore) CheckOverwrite(extentID uint64, offset, size int64) (err error) {
+	c := s.getContainer(extentID)
+	if c == nil {
+		return ExtentNotFoundError
+	}
+	recordID, delta := proto.ParseContainerOffset(uint64(offset))
+	c.RLock()
+	defer c.RUnlock()
+	_, err = c.overwriteRange(recordID, delta, size)
+	return
+}
+
+// Overwrite writes data over a part of a record, it fails with
+// ExtentNotFoundError if the record doesn't hold the part.
+func (s *ContainerStore) Overwrite(extentID uint64, offset int64, data []byte, isSync bool) (err error) {
+	c := s.getContainer(extentID)
+	if c == nil {
+		return ExtentNotFoundError
+	}
+	recordID, delta := proto.ParseContainerOffset(uint64(offset))
+	c.Lock()
+	defer c.Unlock()
+	rec, err := c.overwriteRange(recordID, delta, int64(len(data)))
+	if err != nil {
+		return
+	}
+	f, err := c.getFile()
+	if err != nil {
+		return
+	}
+	record := make([]byte, rec.size)
+	if _, err = f.ReadAt(record, rec.offset); err != nil {
+		return
+	}
+	copy(record[delta:], data)
+	if _, err = f.WriteAt(data, rec.offset+int64(delta)); err != nil {
+		return
+	}
+	rec.crc = crc32.ChecksumIEEE(record)
+	header := make([]byte, containerEntryHeaderLen)
+	marshalContainerEntryHeader(header, containerEntryRecord, recordID, rec.size, rec.crc)
+	if _, err = f.WriteAt(header, rec.offset-containerEntryHeaderLen); err != nil {
+		return
+	}
+	if isSync {
+		if err = f.Sync(); err != nil {
+			return
+		}
+	}
+	c.records[recordID] = rec
+	c.modified = time.Now().Unix()
+	return
+}
+
+// Read reads size bytes at offset of a container, the bytes beyond the end of
+// the record read as zero.
+func (s *ContainerStore) Read(extentID uint64, offset, size int64, nbuf []byte) (crc uint32, err error) {
+	c := s.getContainer(extentID)
+	if c == nil {
+		if s.isRemoved(extentID) {
+			return 0, errors.Trace(ExtentHasBeenDeletedError, "container(%v) is removed", extentID)
+		}
+		return 0, errors.Trace(ExtentNotFoundError, "container(%v)", extentID)
+	}
+	recordID, delta := proto.ParseContainerOffset(uint64(offset))
+	if delta+uint64(size) > proto.ContainerRecordSpan || int64(len(nbuf)) < size {
+		return 0, newParameterError("container(%v) offset=%d size=%d", extentID, offset, size)
+	}
+	c.RLock()
+	defer c.RUnlock()
+	rec, ok := c.records[recordID]
+	if !ok {
+		if _, deleted := c.deleted[recordID]; deleted {
+			return 0, errors.Trace(ExtentHasBeenDeletedError, "container(%v) record(%v)", extentID, recordID)
+		}
+		return 0, errors.Trace(ExtentNotFoundError, "container(%v) record(%v)", extentID, recordID)
+	}
+	n := int64(0)
+	if delta < uint64(rec.size) {
+		if n = int64(rec.size) - int64(delta); n > size {
+			n = size
+		}
+		var f *os.File
+		if f, err = c.getFile(); err != nil {
+			return
+		}
+		if _, err = f.ReadAt(nbuf[:n], rec.offset+int64(delta)); err != nil {
+			return
+		}
+	}
+	for i := n; i < size; i++ {
+		nbuf[i] = 0
+	}
+	crc = crc32.ChecksumIEEE(nbuf[:size])
+	return
+}
+
+// Delete appends the tombstone of the record at offset. A key of a container
+// is only released whole, so the deletions of a part of a record are ignored.
+func (s *ContainerStore) Delete(extentID uint64, offset int64) (err error) {
+	recordID, delta := proto.ParseContainerOffset(uint64(offset))
+	if recordID == 0 || delta != 0 {
+		log.LogInfof("[ContainerStore.Delete] dp(%v) container(%v) ignore the deletion at (%v)", s.partitionID, extentID, offset)
+		return nil
+	}
+	c, err := s.getOrCreateContainer(extentID)
+	if err == ExtentHasBeenDeletedError {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.deleted[recordID]; ok {
+		return nil
+	}
+	return c.appendEntry(containerEntryTombstone, recordID, nil, false)
+}
+
+func (s *ContainerStore) isRemoved(extentID uint64) bool {
+	s.RLock()
+	defer s.RUnlock()
+	_, ok := s.removed[extentID]
+	return ok
+}
+
+// Remove removes a container of which every record is deleted, on this replica
+// or another one.
+func (s *ContainerStore) Remove(extentID uint64) (err error) {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.removed[extentID]; ok {
+		return nil
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, extentID)
+	if _, err = s.removedFp.Write(buf); err != nil {
+		return
+	}
+	if err = s.removedFp.Sync(); err != nil {
+		return
+	}
+	s.removed[extentID] = struct{}{}
+	if s.active == extentID {
+		s.active = 0
+	}
+	c := s.containers[extentID]
+	if c == nil {
+		return nil
+	}
+	delete(s.containers, extentID)
+	c.Lock()
+	defer c.Unlock()
+	c.closeFile()
+	c.reset()
+	if err = os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return
+	}
+	if err = os.Remove(c.path + ContainerIndexSuffix); err != nil && !os.IsNotExist(err) {
+		return
+	}
+	log.LogInfof("[ContainerStore.Remove] dp(%v) container(%v) removed", s.partitionID, extentID)
+	return nil
+}
+
+func (s *ContainerStore) list() (containers []*container, active uint64) {
+	s.RLock()
+	defer s.RUnlock()
+	containers = make([]*container, 0, len(s.containers))
+	for _, c := range s.containers {
+		containers = append(containers, c)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].id < containers[j].id })
+	return containers, s.active
+}
+
+// compact rewrites the container with the records and the tombstones only.
+func (c *container) compact() (err error) {
+	c.Lock()
+	defer c.Unlock()
+	f, err := c.getFile()
+	if err != nil {
+		return
+	}
+	recordIDs := make([]uint64, 0, len(c.records))
+	for recordID := range c.records {
+		recordIDs = append(recordIDs, recordID)
+	}
+	sort.Slice(recordIDs, func(i, j int) bool { return recordIDs[i] < recordIDs[j] })
+
+	tempPath := c.path + ContainerTempSuffix
+	tmp, err := os.OpenFile(tempPath, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o666)
+	if err != nil {
+		return
+	}
+	defer func() {
+		tmp.Close()
+		if err != nil {
+			os.Remove(tempPath)
+		}
+	}()
+	w := bufio.NewWriterSize(tmp, 1*util.MB)
+	records := make(map[uint64]containerRecord, len(c.records))
+	header := make([]byte, containerEntryHeaderLen)
+	data := make([]byte, 0)
+	size := int64(0)
+	for _, recordID := range recordIDs {
+		rec := c.records[recordID]
+		if cap(data) < int(rec.size) {
+			data = make([]byte, rec.size)
+		}
+		if _, err = f.ReadAt(data[:rec.size], rec.offset); err != nil {
+			return
+		}
+		marshalContainerEntryHeader(header, containerEntryRecord, recordID, rec.size, rec.crc)
+		w.Write(header)
+		if _, err = w.Write(data[:rec.size]); err != nil {
+			return
+		}
+		records[recordID] = containerRecord{offset: size + containerEntryHeaderLen, size: rec.size, crc: rec.crc}
+		size += containerEntryHeaderLen + int64(rec.size)
+	}
+	for recordID := range c.deleted {
+		marshalContainerEntryHeader(header, containerEntryTombstone, recordID, 0, 0)
+		if _, err = w.Write(header); err != nil {
+			return
+		}
+		size += containerEntryHeaderLen
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	if err = tmp.Sync(); err != nil {
+		return
+	}
+	if err = os.Rename(tempPath, c.path); err != nil {
+		return
+	}
+	c.closeFile()
+	before := c.size
+	c.records, c.size, c.garbage, c.indexed = records, size, 0, 0
+	log.LogInfof("[container.compact] container(%v) compacted from (%v) to (%v)", c.path, before, size)
+	return c.persistIndex()
+}
+
+// Compact compacts the containers with mostly garbage and removes the ones
+// every record of is deleted, but the active one.
+func (s *ContainerStore) Compact() {
+	containers, active := s.list()
+	for _, c := range containers {
+		if c.id == active {
+			continue
+		}
+		c.RLock()
+		removable := c.removable()
+		due := c.size > 0 && float64(c.garbage) >= float64(c.size)*ContainerGarbageRatio
+		c.RUnlock()
+		var err error
+		if removable {
+			err = s.Remove(c.id)
+		} else if due {
+			err = c.compact()
+		}
+		if err != nil {
+			log.LogErrorf("[ContainerStore.Compact] dp(%v) container(%v) err(%v)", s.partitionID, c.id, err)
+		}
+	}
+}
+
+// BackendTask compacts the containers, persists the indexes with many entries
+// appended after them and closes the containers not used for a while.
+func (s *ContainerStore) BackendTask() {
+	s.Compact()
+	containers, active := s.list()
+	for _, c := range containers {
+		c.Lock()
+		if c.size-c.indexed >= containerIndexInterval {
+			if err := c.persistIndex(); err != nil {
+				log.LogErrorf("[ContainerStore.BackendTask] dp(%v) container(%v) persist index err(%v)", s.partitionID, c.id, err)
+			}
+		}
+		if c.id != active && time.Since(time.Unix(atomic.LoadInt64(&c.lastUse), 0)) > containerIdleTime {
+			c.closeFile()
+		}
+		c.Unlock()
+	}
+}
+
+// UsedSize returns the size of the container files.
+func (s *ContainerStore) UsedSize() (used int64) {
+	containers, _ := s.list()
+	for _, c := range containers {
+		c.RLock()
+		used += c.size
+		c.RUnlock()
+	}
+	return
+}
+
+// Summary returns the digests of the containers and the ids of the removed ones.
+func (s *ContainerStore) Summary() (summary *ContainerSummary) {
+	summary = &ContainerSummary{}
+	containers, _ := s.list()
+	for _, c := range containers {
+		c.RLock()
+		summary.Digests = append(summary.Digests, &ContainerDigest{
+			ExtentID:    c.id,
+			Records:     len(c.records),
+			Tombstones:  len(c.deleted),
+			MaxRecordID: c.maxRecordID,
+			Sum:         c.sum,
+		})
+		c.RUnlock()
+	}
+	s.RLock()
+	for id := range s.removed {
+		summary.Removed = append(summary.Removed, id)
+	}
+	s.RUnlock()
+	sort.Slice(summary.Removed, func(i, j int) bool { return summary.Removed[i] < summary.Removed[j] })
+	return
+}
+
+// Index returns the index of a container.
+func (s *ContainerStore) Index(extentID uint64) (index *ContainerIndex, err error) {
+	c := s.getContainer(extentID)
+	if c == nil {
+		return nil, ExtentNotFoundError
+	}
+	index = &ContainerIndex{ExtentID: extentID}
+	c.RLock()
+	defer c.RUnlock()
+	index.Records = make([]ContainerRecordInfo, 0, len(c.records))
+	for recordID, rec := range c.records {
+		index.Records = append(index.Records, ContainerRecordInfo{RecordID: recordID, Size: rec.size, Crc: rec.crc})
+	}
+	index.Tombstones = make([]uint64, 0, len(c.deleted))
+	for recordID := range c.deleted {
+		index.Tombstones = append(index.Tombstones, recordID)
+	}
+	sort.Slice(index.Records, func(i, j int) bool { return index.Records[i].RecordID < index.Records[j].RecordID })
+	sort.Slice(index.Tombstones, func(i, j int) bool { return index.Tombstones[i] < index.Tombstones[j] })
+	return
+}
+
+// Close persists the indexes and closes the containers.
+func (s *ContainerStore) Close() {
+	containers, _ := s.list()
+	for _, c := range containers {
+		c.Lock()
+		if c.size != c.indexed {
+			if err := c.persistIndex(); err != nil {
+				log.LogErrorf("[ContainerStore.Close] dp(%v) container(%v) persist index err(%v)", s.partitionID, c.id, err)
+			}
+		}
+		c.closeFile()
+		c.Unlock()
+	}
+	if s.removedFp != nil {
+		s.removedFp.Close()
+	}
+}
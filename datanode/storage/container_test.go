@@ -0,0 +1,278 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage_test
+
+import (
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/datanode/storage"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestContainerStore(t *testing.T) (*storage.ExtentStore, string) {
+	dir := t.TempDir()
+	s, err := storage.NewExtentStore(dir, 1, 1*util.GB, proto.PartitionTypeNormal, 1024, true)
+	require.NoError(t, err)
+	return s, dir
+}
+
+func writeRecord(t *testing.T, s *storage.ExtentStore, extentID uint64, offset int64, data []byte) {
+	_, err := s.Write(&storage.WriteParam{
+		ExtentID:  extentID,
+		Offset:    offset,
+		Size:      int64(len(data)),
+		Data:      data,
+		Crc:       crc32.ChecksumIEEE(data),
+		WriteType: storage.AppendWriteType,
+	})
+	require.NoError(t, err)
+}
+
+func readRecord(s *storage.ExtentStore, extentID uint64, offset, size int64) ([]byte, error) {
+	buf := make([]byte, size)
+	crc, err := s.Read(extentID, offset, size, buf, false, false)
+	if err == nil && crc != crc32.ChecksumIEEE(buf) {
+		err = fmt.Errorf("crc mismatch")
+	}
+	return buf, err
+}
+
+func TestContainerWriteReadDelete(t *testing.T) {
+	s, _ := newTestContainerStore(t)
+	defer s.Close()
+	containers := s.Containers()
+
+	extentID, offset, err := containers.Alloc()
+	require.NoError(t, err)
+	require.True(t, storage.IsContainerExtent(extentID))
+	require.False(t, storage.IsTinyExtent(extentID))
+	writeRecord(t, s, extentID, offset, []byte("hello container"))
+
+	nextID, nextOffset, err := containers.Alloc()
+	require.NoError(t, err)
+	require.Equal(t, extentID, nextID)
+	require.Equal(t, offset+proto.ContainerRecordSpan, nextOffset)
+	writeRecord(t, s, nextID, nextOffset, []byte("second"))
+
+	data, err := readRecord(s, extentID, offset+6, 9)
+	require.NoError(t, err)
+	require.Equal(t, "container", string(data))
+	// beyond the end of the record reads as zero
+	data, err = readRecord(s, extentID, offset+10, 8)
+	require.NoError(t, err)
+	require.Equal(t, append([]byte("ainer"), 0, 0, 0), data)
+
+	// overwrite in place, the header crc follows
+	require.NoError(t, containers.CheckOverwrite(extentID, offset+6, 4))
+	require.ErrorContains(t, containers.CheckOverwrite(extentID, offset+12, 4), storage.ExtentNotFoundError.Error())
+	_, err = s.Write(&storage.WriteParam{
+		ExtentID: extentID, Offset: offset + 6, Size: 4, Data: []byte("CONT"), WriteType: storage.RandomWriteType,
+	})
+	require.NoError(t, err)
+	data, err = readRecord(s, extentID, offset, 15)
+	require.NoError(t, err)
+	require.Equal(t, "hello CONTainer", string(data))
+
+	// a deletion of a part of the record is ignored
+	require.NoError(t, s.MarkDelete(extentID, offset+6, 9))
+	_, err = readRecord(s, extentID, offset, 5)
+	require.NoError(t, err)
+	require.NoError(t, s.MarkDelete(extentID, offset, 15))
+	_, err = readRecord(s, extentID, offset, 5)
+	require.ErrorContains(t, err, storage.ExtentHasBeenDeletedError.Error())
+	// the record is gone for good
+	_, err = s.Write(&storage.WriteParam{
+		ExtentID: extentID, Offset: offset, Size: 1, Data: []byte("x"), WriteType: storage.AppendWriteType,
+	})
+	require.ErrorContains(t, err, storage.ExtentHasBeenDeletedError.Error())
+
+	_, err = readRecord(s, extentID, proto.ContainerRecordSpan*100, 5)
+	require.ErrorContains(t, err, storage.ExtentNotFoundError.Error())
+	require.Greater(t, s.GetStoreUsedSize(), int64(0))
+	// a container is no extent of the store
+	require.False(t, s.HasExtent(extentID))
+}
+
+func TestContainerReload(t *testing.T) {
+	s, dir := newTestContainerStore(t)
+	extentID, _, err := s.Containers().Alloc()
+	require.NoError(t, err)
+	for i := uint64(1); i <= 10; i++ {
+		writeRecord(t, s, extentID, int64(proto.ContainerOffset(i, 0)), bytes.Repeat([]byte{byte(i)}, int(i*100)))
+	}
+	require.NoError(t, s.MarkDelete(extentID, int64(proto.ContainerOffset(3, 0)), 300))
+	s.Close()
+
+	// append after the persisted index and tear the tail
+	s, err = storage.NewExtentStore(dir, 1, 1*util.GB, proto.PartitionTypeNormal, 1024, false)
+	require.NoError(t, err)
+	writeRecord(t, s, extentID, int64(proto.ContainerOffset(11, 0)), []byte("appended"))
+	writeRecord(t, s, extentID, int64(proto.ContainerOffset(12, 0)), []byte("torn"))
+	s.Close()
+	name := filepath.Join(dir, fmt.Sprintf("%v%v", storage.ContainerFilePrefix, extentID))
+	info, err := os.Stat(name)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(name, info.Size()-2))
+	require.NoError(t, os.Remove(name+storage.ContainerIndexSuffix))
+
+	s, err = storage.NewExtentStore(dir, 1, 1*util.GB, proto.PartitionTypeNormal, 1024, false)
+	require.NoError(t, err)
+	defer s.Close()
+	for i := uint64(1); i <= 10; i++ {
+		data, err := readRecord(s, extentID, int64(proto.ContainerOffset(i, 0)), int64(i*100))
+		if i == 3 {
+			require.ErrorContains(t, err, storage.ExtentHasBeenDeletedError.Error())
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, bytes.Repeat([]byte{byte(i)}, int(i*100)), data)
+	}
+	data, err := readRecord(s, extentID, int64(proto.ContainerOffset(11, 0)), 8)
+	require.NoError(t, err)
+	require.Equal(t, "appended", string(data))
+	_, err = readRecord(s, extentID, int64(proto.ContainerOffset(12, 0)), 4)
+	require.ErrorContains(t, err, storage.ExtentNotFoundError.Error())
+
+	// the allocation goes on after the records loaded
+	_, offset, err := s.Containers().Alloc()
+	require.NoError(t, err)
+	recordID, _ := proto.ParseContainerOffset(uint64(offset))
+	require.Equal(t, uint64(12), recordID)
+}
+
+func TestContainerCompactAndRemove(t *testing.T) {
+	sealSize, removeDelay := storage.ContainerSealSize, storage.ContainerRemoveDelay
+	defer func() {
+		storage.ContainerSealSize, storage.ContainerRemoveDelay = sealSize, removeDelay
+	}()
+	storage.ContainerSealSize, storage.ContainerRemoveDelay = 64*util.KB, 0
+
+	s, dir := newTestContainerStore(t)
+	defer s.Close()
+	containers := s.Containers()
+	record := bytes.Repeat([]byte("r"), 4*util.KB)
+	offsets := make(map[uint64][]int64)
+	for {
+		extentID, offset, err := containers.Alloc()
+		require.NoError(t, err)
+		if len(offsets) == 2 && offsets[extentID] == nil {
+			break
+		}
+		writeRecord(t, s, extentID, offset, record)
+		offsets[extentID] = append(offsets[extentID], offset)
+	}
+	require.Len(t, offsets, 2)
+	var first, second uint64
+	for extentID := range offsets {
+		if first == 0 || extentID < first {
+			first = extentID
+		}
+		if extentID > second {
+			second = extentID
+		}
+	}
+
+	// most of the records of the second container are deleted
+	for i, offset := range offsets[second] {
+		if i%4 != 0 {
+			require.NoError(t, s.MarkDelete(second, offset, 0))
+		}
+	}
+	name := filepath.Join(dir, fmt.Sprintf("%v%v", storage.ContainerFilePrefix, second))
+	before, err := os.Stat(name)
+	require.NoError(t, err)
+	s.BackendTask()
+	after, err := os.Stat(name)
+	require.NoError(t, err)
+	require.Less(t, after.Size(), before.Size()/2)
+	for i, offset := range offsets[second] {
+		_, err = readRecord(s, second, offset, int64(len(record)))
+		if i%4 != 0 {
+			require.ErrorContains(t, err, storage.ExtentHasBeenDeletedError.Error())
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	// every record of the first container is deleted
+	for _, offset := range offsets[first] {
+		require.NoError(t, s.MarkDelete(first, offset, 0))
+	}
+	time.Sleep(time.Second)
+	s.BackendTask()
+	_, err = os.Stat(filepath.Join(dir, fmt.Sprintf("%v%v", storage.ContainerFilePrefix, first)))
+	require.True(t, os.IsNotExist(err))
+	summary := containers.Summary()
+	require.Equal(t, []uint64{first}, summary.Removed)
+	_, err = readRecord(s, first, offsets[first][0], 1)
+	require.ErrorContains(t, err, storage.ExtentHasBeenDeletedError.Error())
+	// a removed container takes no record of a late or repair write
+	_, err = s.Write(&storage.WriteParam{
+		ExtentID: first, Offset: offsets[first][0], Size: 1, Data: []byte("x"), WriteType: storage.AppendWriteType,
+	})
+	require.ErrorContains(t, err, storage.ExtentHasBeenDeletedError.Error())
+	require.NoError(t, s.MarkDelete(first, offsets[first][0], 0))
+}
+
+func TestContainerIndexForRepair(t *testing.T) {
+	s, _ := newTestContainerStore(t)
+	defer s.Close()
+	containers := s.Containers()
+	extentID, offset, err := containers.Alloc()
+	require.NoError(t, err)
+	writeRecord(t, s, extentID, offset, []byte("live"))
+	_, deletedOffset, err := containers.Alloc()
+	require.NoError(t, err)
+	// the tombstone of a record never written here keeps it from being repaired
+	require.NoError(t, s.MarkDelete(extentID, deletedOffset, 0))
+
+	index, err := containers.Index(extentID)
+	require.NoError(t, err)
+	require.Equal(t, []storage.ContainerRecordInfo{{RecordID: 1, Size: 4, Crc: crc32.ChecksumIEEE([]byte("live"))}}, index.Records)
+	require.Equal(t, []uint64{2}, index.Tombstones)
+	summary := containers.Summary()
+	require.Len(t, summary.Digests, 1)
+	require.Equal(t, 1, summary.Digests[0].Records)
+	require.Equal(t, 1, summary.Digests[0].Tombstones)
+	require.Equal(t, uint64(2), summary.Digests[0].MaxRecordID)
+
+	// a repair write keeps the record there and skips the deleted one
+	_, err = s.Write(&storage.WriteParam{
+		ExtentID: extentID, Offset: offset, Size: 5, Data: []byte("stale"), WriteType: storage.AppendWriteType, IsRepair: true,
+	})
+	require.NoError(t, err)
+	_, err = s.Write(&storage.WriteParam{
+		ExtentID: extentID, Offset: deletedOffset, Size: 5, Data: []byte("stale"), WriteType: storage.AppendWriteType, IsRepair: true,
+	})
+	require.NoError(t, err)
+	data, err := readRecord(s, extentID, offset, 4)
+	require.NoError(t, err)
+	require.Equal(t, "live", string(data))
+	again, err := containers.Index(extentID)
+	require.NoError(t, err)
+	require.Equal(t, index, again)
+
+	require.NoError(t, containers.Remove(extentID))
+	require.Contains(t, containers.Summary().Removed, extentID)
+	require.Empty(t, containers.Summary().Digests)
+}
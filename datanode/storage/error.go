@@ -40,6 +40,7 @@ var (
 	BrokenExtentError                = errors.New("extent has been broken")
 	BrokenDiskError                  = errors.New("disk has broken")
 	ForbidWriteError                 = errors.New("single replica decommission forbid write")
+	ExtentStoreVerifyingError        = errors.New("extent store is verifying the extents loaded from the checkpoint")
 	VerNotConsistentError            = errors.New("ver not consistent")
 	SnapshotNeedNewExtentError       = errors.New("snapshot need new extent error")
 	NoDiskReadRepairExtentTokenError = errors.New("no disk read repair extent token")
@@ -0,0 +1,258 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// Without the readdir hint of a clean shutdown, the extent infos are loaded from the
+// checkpoint taken periodically, with the size and the crc of the extents, and the
+// extent files not in it are examined in parallel. The infos from the checkpoint are
+// verified against the extent files in background, the store serves the reads and
+// writes meanwhile, but not the watermarks the repairs are computed from.
+
+const (
+	ExtentInfoCheckpoint     = "EXTENT_INFO_CHECKPOINT"
+	ExtentInfoCheckpointTemp = "EXTENT_INFO_CHECKPOINT.tmp"
+
+	DefaultExtentLoadConcurrency = 32
+
+	extentCheckpointMagic uint32 = 0x45434b50
+	// the files modified this close before the checkpoint may not be in it as they are
+	extentCheckpointMargin = time.Second
+)
+
+var (
+	// ExtentCheckpointInterval is the interval between the checkpoints of a store.
+	ExtentCheckpointInterval = 10 * time.Minute
+
+	extentLoadLimit = make(chan struct{}, DefaultExtentLoadConcurrency)
+)
+
+// SetExtentLoadConcurrency sets how many extent files all the stores examine at the
+// same time while loading, it should be called before any store is loaded.
+func SetExtentLoadConcurrency(n int) {
+	if n > 0 {
+		extentLoadLimit = make(chan struct{}, n)
+	}
+}
+
+// Verified returns false while the extent infos loaded from the checkpoint are being
+// verified against the extent files.
+func (s *ExtentStore) Verified() bool {
+	return atomic.LoadInt32(&s.verifying) == 0
+}
+
+// statExtents loads the infos of the extents from the disk in parallel.
+func (s *ExtentStore) statExtents(ids []uint64) (infos []*ExtentInfo, err error) {
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		limit   = extentLoadLimit
+	)
+	infos = make([]*ExtentInfo, len(ids))
+	for i, id := range ids {
+		limit <- struct{}{}
+		wg.Add(1)
+		go func(i int, id uint64) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			ei, e := s.GetExtentInfoFromDisk(id)
+			if e != nil {
+				errOnce.Do(func() { err = fmt.Errorf("load extent(%v): %v", id, e) })
+				return
+			}
+			infos[i] = ei
+		}(i, id)
+	}
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// loadExtentInfos loads the infos of the extents into the store, the ones in the
+// checkpoint from it, then verifies them in background.
+func (s *ExtentStore) loadExtentInfos(ids []uint64) (err error) {
+	at, checkpoint, err := s.readExtentCheckpoint()
+	if err != nil {
+		log.LogWarnf("[loadExtentInfos] store(%v) ignore checkpoint, err(%v)", s.dataPath, err)
+		err = nil
+	}
+	var toStat, toVerify []uint64
+	for _, id := range ids {
+		if ei, ok := checkpoint[id]; ok && !IsTinyExtent(id) {
+			s.extentInfoMap[id] = ei
+			toVerify = append(toVerify, id)
+			continue
+		}
+		toStat = append(toStat, id)
+	}
+	infos, err := s.statExtents(toStat)
+	if err != nil {
+		return
+	}
+	for _, ei := range infos {
+		s.extentInfoMap[ei.FileID] = ei
+	}
+	log.LogInfof("[loadExtentInfos] store(%v) extents(%v) from checkpoint(%v) taken at %v",
+		s.dataPath, len(ids), len(toVerify), at)
+	if len(toVerify) > 0 {
+		atomic.StoreInt32(&s.verifying, 1)
+		go s.verifyExtentInfos(toVerify, at)
+	}
+	return
+}
+
+// verifyExtentInfos corrects the infos of the extents loaded from the checkpoint
+// taken at at with the files modified since, whose crc is computed again.
+func (s *ExtentStore) verifyExtentInfos(ids []uint64, at time.Time) {
+	var (
+		wg      sync.WaitGroup
+		changed int64
+		limit   = extentLoadLimit
+		since   = at.Add(-extentCheckpointMargin).Unix()
+		begin   = time.Now()
+	)
+	for _, id := range ids {
+		if s.IsClosed() {
+			break
+		}
+		limit <- struct{}{}
+		wg.Add(1)
+		go func(id uint64) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			fresh, err := s.GetExtentInfoFromDisk(id)
+			if err != nil || fresh.ModifyTime < since {
+				// the deleted are removed by the deletion
+				return
+			}
+			atomic.AddInt64(&changed, 1)
+			s.eiMutex.Lock()
+			defer s.eiMutex.Unlock()
+			// a write since the stat has updated the info already
+			if ei, ok := s.extentInfoMap[id]; ok && !ei.IsDeleted && ei.Size <= fresh.Size {
+				ei.Size = fresh.Size
+				ei.SnapshotDataOff = fresh.SnapshotDataOff
+				ei.ModifyTime = fresh.ModifyTime
+				atomic.StoreUint32(&ei.Crc, 0)
+			}
+		}(id)
+	}
+	wg.Wait()
+	atomic.StoreInt32(&s.verifying, 0)
+	log.LogInfof("[verifyExtentInfos] store(%v) verified extents(%v) changed(%v) using time(%v)",
+		s.dataPath, len(ids), changed, time.Since(begin))
+}
+
+// checkpointExtentInfos writes the checkpoint of the extent infos if it is due.
+func (s *ExtentStore) checkpointExtentInfos() {
+	if s.IsClosed() || !s.Verified() || time.Since(s.checkpointAt) < ExtentCheckpointInterval {
+		return
+	}
+	if err := s.writeExtentCheckpoint(); err != nil {
+		log.LogErrorf("[checkpointExtentInfos] store(%v) err(%v)", s.dataPath, err)
+	}
+}
+
+// writeExtentCheckpoint writes the magic, the time taken, the infos of the normal
+// extents each followed by its crc, and the crc of them all.
+func (s *ExtentStore) writeExtentCheckpoint() (err error) {
+	at := time.Now()
+	buff := bytes.NewBuffer(nil)
+	binary.Write(buff, binary.BigEndian, extentCheckpointMagic)
+	binary.Write(buff, binary.BigEndian, at.UnixNano())
+	err = s.RangeExtentInfo(func(id uint64, ei *ExtentInfo) (ok bool, err error) {
+		if IsTinyExtent(id) || ei.IsDeleted {
+			return true, nil
+		}
+		if err = ei.MarshalBinaryWithBuffer(buff); err != nil {
+			return
+		}
+		err = binary.Write(buff, binary.BigEndian, atomic.LoadUint32(&ei.Crc))
+		return err == nil, err
+	})
+	if err != nil {
+		return
+	}
+	binary.Write(buff, binary.BigEndian, crc32.ChecksumIEEE(buff.Bytes()))
+
+	tempPath := path.Join(s.dataPath, ExtentInfoCheckpointTemp)
+	if err = os.WriteFile(tempPath, buff.Bytes(), 0o666); err != nil {
+		return
+	}
+	if err = os.Rename(tempPath, path.Join(s.dataPath, ExtentInfoCheckpoint)); err != nil {
+		return
+	}
+	s.checkpointAt = at
+	log.LogDebugf("[writeExtentCheckpoint] store(%v) size(%v) using time(%v)", s.dataPath, buff.Len(), time.Since(at))
+	return
+}
+
+// readExtentCheckpoint returns nothing if there is no checkpoint.
+func (s *ExtentStore) readExtentCheckpoint() (at time.Time, extMap map[uint64]*ExtentInfo, err error) {
+	data, err := os.ReadFile(path.Join(s.dataPath, ExtentInfoCheckpoint))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	const headerLen, crcLen = 12, 4
+	if len(data) < headerLen+crcLen {
+		err = fmt.Errorf("checkpoint too short(%v)", len(data))
+		return
+	}
+	body, sum := data[:len(data)-crcLen], binary.BigEndian.Uint32(data[len(data)-crcLen:])
+	if crc32.ChecksumIEEE(body) != sum {
+		err = fmt.Errorf("checkpoint crc mismatch")
+		return
+	}
+	if magic := binary.BigEndian.Uint32(body); magic != extentCheckpointMagic {
+		err = fmt.Errorf("checkpoint magic(%x) mismatch", magic)
+		return
+	}
+	at = time.Unix(0, int64(binary.BigEndian.Uint64(body[4:headerLen])))
+	extMap = make(map[uint64]*ExtentInfo)
+	buff := bytes.NewBuffer(body[headerLen:])
+	for buff.Len() != 0 {
+		ei := &ExtentInfo{}
+		if err = ei.UnmarshalBinaryWithBuffer(buff); err != nil {
+			return at, nil, err
+		}
+		if err = binary.Read(buff, binary.BigEndian, &ei.Crc); err != nil {
+			return at, nil, err
+		}
+		extMap[ei.FileID] = ei
+	}
+	return
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage_test
+
+import (
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/datanode/storage"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func appendExtent(t *testing.T, s *storage.ExtentStore, id uint64, offset int64) {
+	data := []byte(dataStr)
+	_, err := s.Write(&storage.WriteParam{
+		ExtentID:  id,
+		Offset:    offset,
+		Size:      int64(len(data)),
+		Data:      data,
+		Crc:       crc32.ChecksumIEEE(data),
+		WriteType: storage.AppendWriteType,
+		IsSync:    true,
+	})
+	require.NoError(t, err)
+}
+
+func TestExtentStoreCheckpoint(t *testing.T) {
+	path, clean, err := getTestPathExtentStore()
+	require.NoError(t, err)
+	defer clean()
+	interval := storage.ExtentCheckpointInterval
+	defer func() { storage.ExtentCheckpointInterval = interval }()
+	size := uint64(len(dataStr))
+
+	s, err := storage.NewExtentStore(path, 0, 1*util.GB, proto.PartitionTypeNormal, 0, true)
+	require.NoError(t, err)
+	defer s.Close()
+	var ids [3]uint64
+	for i := range ids {
+		ids[i], err = s.NextExtentID()
+		require.NoError(t, err)
+	}
+	unchanged, appended, created := ids[0], ids[1], ids[2]
+	for _, id := range []uint64{unchanged, appended} {
+		require.NoError(t, s.Create(id))
+		appendExtent(t, s, id, 0)
+	}
+	// the info of the extent unchanged since the checkpoint is taken from it, not from the file
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(path, fmt.Sprint(unchanged)), old, old))
+	ei, err := s.Watermark(unchanged)
+	require.NoError(t, err)
+	modifyTime := ei.ModifyTime
+	require.NotEqual(t, old.Unix(), modifyTime)
+
+	storage.ExtentCheckpointInterval = 0
+	s.BackendTask()
+	require.FileExists(t, filepath.Join(path, storage.ExtentInfoCheckpoint))
+	appendExtent(t, s, appended, int64(size))
+	require.NoError(t, s.Create(created))
+	appendExtent(t, s, created, 0)
+
+	// loaded as after a crash, without the hint of Close
+	crashed, err := storage.NewExtentStore(path, 0, 1*util.GB, proto.PartitionTypeNormal, 0, false)
+	require.NoError(t, err)
+	defer crashed.Close()
+	require.Eventually(t, crashed.Verified, 5*time.Second, 10*time.Millisecond)
+	_, _, err = crashed.GetAllWatermarks(nil)
+	require.NoError(t, err)
+	for id, want := range map[uint64]uint64{unchanged: size, appended: 2 * size, created: size} {
+		ei, err = crashed.Watermark(id)
+		require.NoError(t, err)
+		require.Equal(t, want, ei.Size, "extent %v", id)
+	}
+	ei, _ = crashed.Watermark(unchanged)
+	require.Equal(t, modifyTime, ei.ModifyTime)
+
+	// a broken checkpoint is ignored
+	require.NoError(t, os.WriteFile(filepath.Join(path, storage.ExtentInfoCheckpoint), []byte("broken checkpoint"), 0o666))
+	reloaded, err := storage.NewExtentStore(path, 0, 1*util.GB, proto.PartitionTypeNormal, 0, false)
+	require.NoError(t, err)
+	defer reloaded.Close()
+	require.True(t, reloaded.Verified())
+	ei, err = reloaded.Watermark(unchanged)
+	require.NoError(t, err)
+	require.Equal(t, old.Unix(), ei.ModifyTime)
+	ei, err = reloaded.Watermark(appended)
+	require.NoError(t, err)
+	require.Equal(t, 2*size, ei.Size)
+}
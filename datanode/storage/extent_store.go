@@ -49,8 +49,8 @@ const (
 	TinyExtDeletedFileName   = "TINYEXTENT_DELETE"
 	NormalExtDeletedFileName = "NORMALEXTENT_DELETE"
 	MaxExtentCount           = 20000
-	TinyExtentCount          = 64
-	TinyExtentStartID        = 1
+	TinyExtentCount          = proto.TinyExtentCount
+	TinyExtentStartID        = proto.TinyExtentStartID
 	MinExtentID              = 1024
 	DeleteTinyRecordSize     = 24
 	UpdateCrcInterval        = 600
@@ -156,6 +156,9 @@ type ExtentStore struct {
 	IgnoreTinyRecover                 bool
 	IsEnableSnapshot                  bool
 	extIDLock                         sync.Mutex
+	verifying                         int32     // the extent infos loaded from the checkpoint are being verified
+	checkpointAt                      time.Time // the last checkpoint of the extent infos
+	containers                        *ContainerStore
 }
 
 func MkdirAll(name string) (err error) {
@@ -238,6 +241,9 @@ func NewExtentStore(dataDir string, partitionID uint64, storeSize, dpType, cap i
 		log.LogInfof("[NewExtentStore] load dp(%v) write zero buffer", partitionID)
 	}
 
+	if s.containers, err = NewContainerStore(dataDir, partitionID); err != nil {
+		return
+	}
 	s.extentInfoMap = make(map[uint64]*ExtentInfo)
 	s.extentLockMap = make(map[uint64]proto.GcFlag)
 	s.cache = NewExtentCache(cap)
@@ -616,21 +622,18 @@ func (s *ExtentStore) initBaseFileID() error {
 		}
 		log.LogInfof("[initBaseFileID] store(%v) init base file to read dir using time(%v)", s.dataPath, time.Since(begin))
 
-		var ei *ExtentInfo
+		ids := make([]uint64, 0, len(files))
 		for _, f := range files {
-			extentID, isExtent := s.ExtentID(f)
-			if !isExtent {
-				continue
+			if extentID, isExtent := s.ExtentID(f); isExtent {
+				ids = append(ids, extentID)
 			}
-
-			extNum++
-			ei, err = s.GetExtentInfoFromDisk(extentID)
-			if err != nil {
-				log.LogErrorf("[initBaseFileID] store(%v) failed to load extent(%v), err(%v)", s.dataPath, extentID, err)
-				return err
-			}
-			s.extentInfoMap[extentID] = ei
-
+		}
+		extNum = len(ids)
+		if err = s.loadExtentInfos(ids); err != nil {
+			log.LogErrorf("[initBaseFileID] store(%v) failed to load extents, err(%v)", s.dataPath, err)
+			return err
+		}
+		for _, extentID := range ids {
 			if !IsTinyExtent(extentID) && extentID > baseFileID {
 				baseFileID = extentID
 			}
@@ -670,6 +673,9 @@ func (s *ExtentStore) Write(param *WriteParam) (status uint8, err error) {
 		log.LogErrorf("[Write] store(%v) failed to write param(%v), err(%v)", s.dataPath, param, err)
 		return
 	}
+	if IsContainerExtent(param.ExtentID) {
+		return proto.OpOk, s.writeContainer(param)
+	}
 
 	var (
 		e  *Extent
@@ -758,7 +764,30 @@ func (s *ExtentStore) checkOffsetAndSize(param *WriteParam) error {
 
 // IsTinyExtent checks if the given extent is tiny extent.
 func IsTinyExtent(extentID uint64) bool {
-	return extentID >= TinyExtentStartID && extentID < TinyExtentStartID+TinyExtentCount
+	return proto.IsTinyExtent(extentID)
+}
+
+func (s *ExtentStore) writeContainer(param *WriteParam) (err error) {
+	data := param.Data[:param.Size]
+	switch param.WriteType {
+	case AppendWriteType:
+		stat.RecordStat(s.partitionID, "WriteContainer", s.dataPath)
+		err = s.containers.Write(param.ExtentID, param.Offset, data, param.IsSync, param.IsRepair)
+	case RandomWriteType:
+		stat.RecordStat(s.partitionID, "OverwriteContainer", s.dataPath)
+		err = s.containers.Overwrite(param.ExtentID, param.Offset, data, param.IsSync)
+	default:
+		err = newParameterError("container(%v) write type(%v)", param.ExtentID, param.WriteType)
+	}
+	if err != nil {
+		log.LogInfof("action[writeContainer] dp %v param(%v) err %v", s.partitionID, param, err)
+	}
+	return
+}
+
+// Containers returns the container extents of the store.
+func (s *ExtentStore) Containers() *ContainerStore {
+	return s.containers
 }
 
 // Read reads the extent based on the given id.
@@ -774,6 +803,11 @@ func (s *ExtentStore) Read(extentID uint64, offset, size int64, nbuf []byte, isR
 		}
 	}()
 
+	if IsContainerExtent(extentID) {
+		stat.RecordStat(s.partitionID, "ReadContainer", s.dataPath)
+		return s.containers.Read(extentID, offset, size, nbuf)
+	}
+
 	ei, _ := s.GetExtentInfo(extentID)
 	if ei == nil {
 		return 0, errors.Trace(ExtentHasBeenDeletedError, "[Read] dp %v extent[%d] is already been deleted", s.partitionID, extentID)
@@ -885,6 +919,10 @@ func (s *ExtentStore) MarkDelete(extentID uint64, offset, size int64) (err error
 	if IsTinyExtent(extentID) {
 		return s.punchDelete(extentID, offset, size)
 	}
+	if IsContainerExtent(extentID) {
+		stat.RecordStat(s.partitionID, "MarkDeleteContainer", s.dataPath)
+		return s.containers.Delete(extentID, offset)
+	}
 
 	ei, _ = s.GetExtentInfo(extentID)
 	if err != nil {
@@ -1005,6 +1043,9 @@ func (s *ExtentStore) Close() {
 	s.stopMutex.Lock()
 	defer s.stopMutex.Unlock()
 	s.setClosed(true)
+	if s.containers != nil {
+		s.containers.Close()
+	}
 	if err := s.writeReadDirHint(); err != nil {
 		log.LogErrorf("[Close] store(%v) failed to write extent hint, err(%v)", s.dataPath, err)
 	}
@@ -1120,14 +1161,23 @@ func (s *ExtentStore) GetStoreUsedSize() (used int64) {
 			normalTotal += uint64(size)
 		}
 	}
+	var containerTotal int64
+	if s.containers != nil {
+		containerTotal = s.containers.UsedSize()
+	}
+	used += containerTotal
 	if log.EnableInfo() {
-		log.LogInfof("[GetStoreUsedSize] store(%v) total size(%v) raw(%v) tiny total(%v) raw(%v) normal total(%v) raw(%v)", s.dataPath, strutil.FormatSize(uint64(used)), used, strutil.FormatSize(tinyTotal), tinyTotal, strutil.FormatSize(normalTotal), normalTotal)
+		log.LogInfof("[GetStoreUsedSize] store(%v) total size(%v) raw(%v) tiny total(%v) raw(%v) normal total(%v) raw(%v) container total(%v) raw(%v)", s.dataPath, strutil.FormatSize(uint64(used)), used, strutil.FormatSize(tinyTotal), tinyTotal, strutil.FormatSize(normalTotal), normalTotal, strutil.FormatSize(uint64(containerTotal)), containerTotal)
 	}
 	return
 }
 
 // GetAllWatermarks returns all the watermarks.
 func (s *ExtentStore) GetAllWatermarks(filter ExtentFilter) (extents []*ExtentInfo, tinyDeleteFileSize int64, err error) {
+	if !s.Verified() {
+		err = ExtentStoreVerifyingError
+		return
+	}
 	extents = make([]*ExtentInfo, 0, len(s.extentInfoMap))
 	extentInfoSlice := make([]*ExtentInfo, 0, len(s.extentInfoMap))
 	s.eiMutex.RLock()
@@ -1553,8 +1603,19 @@ func (arr ExtentInfoArr) Less(i, j int) bool { return arr[i].FileID < arr[j].Fil
 func (arr ExtentInfoArr) Swap(i, j int)      { arr[i], arr[j] = arr[j], arr[i] }
 
 func (s *ExtentStore) BackendTask() {
+	s.checkpointExtentInfos()
 	s.autoComputeExtentCrc()
 	s.cleanExpiredNormalExtentDeleteCache()
+	s.compactContainers()
+}
+
+func (s *ExtentStore) compactContainers() {
+	s.stopMutex.RLock()
+	defer s.stopMutex.RUnlock()
+	if s.IsClosed() || s.containers == nil {
+		return
+	}
+	s.containers.BackendTask()
 }
 
 func (s *ExtentStore) cleanExpiredNormalExtentDeleteCache() {
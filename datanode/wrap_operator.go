@@ -28,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cubefs/cubefs/datanode/repl"
@@ -39,6 +40,7 @@ import (
 	"github.com/cubefs/cubefs/util/auditlog"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/faultinject"
 	"github.com/cubefs/cubefs/util/log"
 	"github.com/cubefs/cubefs/util/strutil"
 )
@@ -85,6 +87,39 @@ func isColdVolExtentDelErr(p *repl.Packet) bool {
 	return false
 }
 
+// isLowPriorityOp reports whether the op is a background one that can be retried
+// later, these ops are rejected while the datanode throttles itself.
+func isLowPriorityOp(opcode uint8) bool {
+	switch opcode {
+	case proto.OpBatchDeleteExtent, proto.OpGcBatchDeleteExtent,
+		proto.OpExtentRepairRead, proto.OpTinyExtentRepairRead, proto.OpSnapshotExtentRepairRead,
+		proto.OpGetAllWatermarks, proto.OpGetContainerIndex:
+		return true
+	}
+	return false
+}
+
+// injectFault applies the faults injected into the ops of the partitions, the
+// master commands are not affected. It returns true if the op is not handled.
+func (s *DataNode) injectFault(p *repl.Packet) bool {
+	if !faultinject.Active() {
+		return false
+	}
+	partition, ok := p.Object.(*DataPartition)
+	if !ok {
+		return false
+	}
+	switch faultinject.Inject(partition.partitionID, partition.path) {
+	case faultinject.Drop:
+		p.NeedReply = false
+		return true
+	case faultinject.EIO:
+		p.PackErrorBody("OperatePacket", faultinject.ErrEIO.Error())
+		return true
+	}
+	return false
+}
+
 func (s *DataNode) OperatePacket(p *repl.Packet, c net.Conn) (err error) {
 	var (
 		tpLabels map[string]string
@@ -148,6 +183,14 @@ func (s *DataNode) OperatePacket(p *repl.Packet, c net.Conn) (err error) {
 		}
 	}()
 
+	if isLowPriorityOp(p.Opcode) && s.throttler.ShouldReject() {
+		p.PackErrorBody("OperatePacket", storage.LimitedIoError.Error())
+		return
+	}
+	if s.injectFault(p) {
+		return
+	}
+
 	switch p.Opcode {
 	case proto.OpCreateExtent:
 		s.handlePacketToCreateExtent(p)
@@ -232,6 +275,10 @@ func (s *DataNode) OperatePacket(p *repl.Packet, c net.Conn) (err error) {
 		s.handlePacketToDeleteLostDisk(p)
 	case proto.OpReloadDisk:
 		s.handlePacketToReloadDisk(p)
+	case proto.OpNegotiateFeatures:
+		s.handlePacketToNegotiateFeatures(p)
+	case proto.OpGetContainerIndex:
+		s.handlePacketToGetContainerIndex(p)
 	default:
 		p.PackErrorBody(repl.ErrorUnknownOp.Error(), repl.ErrorUnknownOp.Error()+strconv.Itoa(int(p.Opcode)))
 	}
@@ -559,6 +606,7 @@ func (s *DataNode) handleHeartbeatPacket(p *repl.Packet) {
 		request := &proto.HeartBeatRequest{}
 		response := &proto.DataNodeHeartbeatResponse{}
 		begin := time.Now()
+		response.Clock.RecvTime = begin.UnixNano()
 		if task.OpCode == proto.OpDataNodeHeartbeat {
 			marshaled, _ := json.Marshal(task.Request)
 			_ = json.Unmarshal(marshaled, request)
@@ -582,6 +630,14 @@ func (s *DataNode) handleHeartbeatPacket(p *repl.Packet) {
 			}
 			s.dpBackupTimeout = dpBackupTimeout
 			log.LogDebugf("handleHeartbeatPacket receive req(%v) dpBackupTimeout(%v)", task.RequestID, dpBackupTimeout)
+			if retention, err := time.ParseDuration(request.ReplicaTombstoneRetention); err == nil {
+				s.replicaTombstoneRetention = retention
+			}
+			if s.maintenance.Load() != request.Maintenance {
+				log.LogWarnf("[handleHeartbeatPacket] change maintenance, old(%v) new(%v)",
+					s.maintenance.Load(), request.Maintenance)
+				s.maintenance.Store(request.Maintenance)
+			}
 
 			if s.nodeForbidWriteOpOfProtoVer0 != request.NotifyForbidWriteOpOfProtoVer0 {
 				log.LogWarnf("[handleHeartbeatPacket] change nodeForbidWriteOpOfProtoVer0, old(%v) new(%v)",
@@ -625,8 +681,15 @@ func (s *DataNode) handleHeartbeatPacket(p *repl.Packet) {
 				}
 			}
 			s.IgnoreTinyRecoverVols = ignoreTinyRecoverVols
+			s.setSyncMirror(request.SyncMirrorWriteVols, request.DataNodeZonesVersion, request.DataNodeZones)
+			s.setTLSRequiredVols(request.TLSRequiredVols)
+			s.setImmutableVols(request.ImmutableVols)
+			s.setClientFence(request.EvictedClients)
+			s.setVolFences(request.VolFences)
 
 			s.buildHeartBeatResponse(response, forbiddenVols, request.VolDpRepairBlockSize, task.RequestID)
+			s.reportDelta.reduce(request.ReportSeq, response)
+			response.DataNodeZonesVersion = s.syncMirrorZonesVersion()
 			log.LogDebugf("handleHeartbeatPacket buildHeartBeatResponse req(%v) cost %v",
 				task.RequestID, time.Since(begin))
 			s.diskQosEnableFromMaster = request.EnableDiskQos
@@ -653,6 +716,7 @@ func (s *DataNode) handleHeartbeatPacket(p *repl.Packet) {
 			// set cpu util and io used in here
 			response.CpuUtil = s.cpuUtil.Load()
 			response.IoUtils = s.space.GetDiskUtils()
+			response.ResourceThrottle = s.throttler.State()
 
 			if needUpdate {
 				log.LogWarnf("action[handleHeartbeatPacket] master change disk qos limit to [flowWrite %v, flowRead %v, iopsWrite %v, iopsRead %v]",
@@ -664,6 +728,7 @@ func (s *DataNode) handleHeartbeatPacket(p *repl.Packet) {
 			err = fmt.Errorf("illegal opcode")
 			response.Result = err.Error()
 		}
+		response.Clock.ReplyTime = time.Now().UnixNano()
 		task.Response = response
 		log.LogDebugf("handleHeartbeatPacket send response req(%v) cost %v, cost from sendTime %v",
 			task.RequestID, time.Since(begin), time.Since(time.Unix(task.SendTime, 0)))
@@ -698,7 +763,7 @@ func (s *DataNode) handlePacketToDeleteDataPartition(p *repl.Packet) {
 		if err != nil {
 			return
 		} else {
-			err = s.space.DeletePartition(request.PartitionId, request.Force)
+			err = s.space.DeletePartition(request.PartitionId, request.Force, request.Tombstone)
 		}
 	} else {
 		err = fmt.Errorf("illegal opcode ")
@@ -850,8 +915,14 @@ func (s *DataNode) handleBatchMarkDeletePacket(p *repl.Packet, c net.Conn) {
 		}
 
 		log.LogInfof(fmt.Sprintf("[handleBatchMarkDeletePacket] recive DeleteExtent (%v) from (%v)", ext, c.RemoteAddr().String()))
+		if storage.IsContainerExtent(ext.ExtentId) && ext.IsSnapshotDeletion {
+			// a record is only released with the last key of it
+			log.LogInfof("[handleBatchMarkDeletePacket] vol(%v) dp(%v) skip the snapshot deletion of container(%v)",
+				partition.config.VolName, partition.partitionID, ext.ExtentId)
+			continue
+		}
 		partition.disk.diskLimit(OpDelete, 0, func() {
-			if storage.IsTinyExtent(ext.ExtentId) || ext.IsSnapshotDeletion {
+			if storage.IsTinyExtent(ext.ExtentId) || storage.IsContainerExtent(ext.ExtentId) || ext.IsSnapshotDeletion {
 				log.LogInfof("[handleBatchMarkDeletePacket] vol(%v) dp(%v) mark delete extent(%v), tinyExtent or snapDeletion",
 					partition.config.VolName, partition.partitionID, ext.ExtentId)
 				err = store.MarkDelete(ext.ExtentId, int64(ext.ExtentOffset), int64(ext.Size))
@@ -950,6 +1021,18 @@ func (s *DataNode) handleWritePacket(p *repl.Packet) {
 		err = storage.BrokenDiskError
 		return
 	}
+	if err = partition.reserveWrite(int64(p.Size)); err != nil {
+		return
+	}
+	// the bytes not written would count against the space until the usage
+	// is computed again
+	defer func() {
+		if err != nil {
+			partition.releaseWrite(int64(p.Size))
+			return
+		}
+		partition.commitWrite(int64(p.Size))
+	}()
 	store := partition.ExtentStore()
 	if proto.IsTinyExtentType(p.ExtentType) {
 		if !shallDegrade {
@@ -1109,6 +1192,23 @@ func (s *DataNode) handleRandomWritePacket(p *repl.Packet) {
 		err = raft.ErrNotLeader
 		return
 	}
+	var mirrorPeers map[uint64]struct{}
+	if zones, ok := s.syncMirrorZones(partition.volumeID); ok {
+		if mirrorPeers = partition.remoteZonePeers(zones, s.zoneName); len(mirrorPeers) == 0 {
+			err = ErrSyncMirrorNoRemoteZone
+			return
+		}
+	}
+	// a failed write to a container would stop the apply of the raft log
+	if storage.IsContainerExtent(p.ExtentID) {
+		if p.Opcode != proto.OpRandomWrite && p.Opcode != proto.OpSyncRandomWrite {
+			err = fmt.Errorf("container(%v) takes no %v", p.ExtentID, p.GetOpMsg())
+			return
+		}
+		if err = partition.ExtentStore().Containers().CheckOverwrite(p.ExtentID, p.ExtentOffset, int64(p.Size)); err != nil {
+			return
+		}
+	}
 	shallDegrade := p.ShallDegrade()
 	if !shallDegrade {
 		metricPartitionIOLabels = GetIoMetricLabels(partition, "randwrite")
@@ -1133,6 +1233,9 @@ func (s *DataNode) handleRandomWritePacket(p *repl.Packet) {
 		err = storage.TryAgainError
 		return
 	}
+	if err == nil && len(mirrorPeers) > 0 {
+		err = partition.waitSyncMirrorAck(mirrorPeers)
+	}
 	log.LogDebugf("action[handleRandomWritePacket] opcod %v seq %v dpid %v dpseq %v after raft submit err %v resultCode %v",
 		p.Opcode, p.VerSeq, p.PartitionID, partition.verSeq, err, p.ResultCode)
 }
@@ -1213,6 +1316,13 @@ func (s *DataNode) extentRepairReadPacket(p *repl.Packet, connect net.Conn, isRe
 		err = storage.ForbiddenDataPartitionError
 		return
 	}
+	if partition.isReadReplica() && !partition.isReadReplicaReady() {
+		err = fmt.Errorf("read replica of dp(%v) not synced with the hosts", p.PartitionID)
+		return
+	}
+	if !isRepairRead {
+		atomic.AddUint64(&partition.readOps, 1)
+	}
 	log.LogDebugf("extentRepairReadPacket ready to repair dp(%v) disk(%v) extent(%v) offset (%v) needSize (%v)",
 		p.PartitionID, partition.disk.Path, p.ExtentID, p.ExtentOffset, p.Size)
 
@@ -1393,6 +1503,50 @@ func (s *DataNode) handlePacketToGetAppliedID(p *repl.Packet) {
 	p.AddMesgLog(fmt.Sprintf("_AppliedID(%v)", appliedID))
 }
 
+func (s *DataNode) handlePacketToNegotiateFeatures(p *repl.Packet) {
+	reply, err := json.Marshal(&proto.FeatureNegotiation{Features: proto.DataNodeFeatures, Version: proto.Version})
+	if err != nil {
+		p.PackErrorBody(ActionNegotiateFeatures, err.Error())
+		return
+	}
+	p.PacketOkWithBody(reply)
+}
+
+// handlePacketToGetContainerIndex answers the summary of the containers of the
+// partition, or the indexes of the containers asked.
+func (s *DataNode) handlePacketToGetContainerIndex(p *repl.Packet) {
+	var (
+		err   error
+		reply []byte
+	)
+	defer func() {
+		if err != nil {
+			p.PackErrorBody(ActionGetContainerIndex, err.Error())
+		}
+	}()
+	containers := p.Object.(*DataPartition).ExtentStore().Containers()
+	if p.Size == 0 {
+		reply, err = json.Marshal(containers.Summary())
+	} else {
+		var extentIDs []uint64
+		if err = json.Unmarshal(p.Data[:p.Size], &extentIDs); err != nil {
+			return
+		}
+		indexes := make([]*storage.ContainerIndex, 0, len(extentIDs))
+		for _, extentID := range extentIDs {
+			// the containers removed meanwhile are in the next summary
+			if index, e := containers.Index(extentID); e == nil {
+				indexes = append(indexes, index)
+			}
+		}
+		reply, err = json.Marshal(indexes)
+	}
+	if err != nil {
+		return
+	}
+	p.PacketOkWithBody(reply)
+}
+
 func (s *DataNode) handlePacketToGetPartitionSize(p *repl.Packet) {
 	partition := p.Object.(*DataPartition)
 	logicSize := partition.extentStore.StoreSizeExtentID(p.ExtentID)
@@ -2047,7 +2201,7 @@ func (s *DataNode) handlePacketToRecoverBackupDataReplica(p *repl.Packet) {
 	}
 	log.LogDebugf("action[handlePacketToRecoverBackupDataReplica] ready to recover %v", rootDir)
 	// rename root dir back to normal
-	newPath := strings.Replace(rootDir, BackupPartitionPrefix, "", 1)
+	newPath := trimBackupPartitionPrefix(rootDir)
 	parts := strings.Split(newPath, "-")
 	newPath = parts[0]
 	err = os.Rename(path.Join(disk.Path, rootDir), path.Join(disk.Path, newPath))
@@ -16,6 +16,7 @@ package datanode
 
 import (
 	"encoding/json"
+	"hash/crc32"
 	"net"
 	"os"
 	"path"
@@ -124,6 +125,39 @@ func TestSkipAppendWrite(t *testing.T) {
 	require.EqualValues(t, proto.OpArgMismatchErr, p.ResultCode)
 }
 
+func TestWritePacketReleaseReservation(t *testing.T) {
+	dn := newDataNodeForOperatorTest(t)
+	dp := newDpForOperatorTest(t, dn)
+	extentId := uint64(1000)
+	p := newPacketForOperatorTest(t, dp, extentId)
+	p.Opcode = proto.OpCreateExtent
+	dn.handlePacketToCreateExtent(p)
+	require.EqualValues(t, proto.OpOk, p.ResultCode)
+
+	data := []byte("HelloWorld")
+	write := func(offset int64) *repl.Packet {
+		p := newPacketForOperatorTest(t, dp, extentId)
+		p.Opcode = proto.OpWrite
+		p.Data = data
+		p.ExtentOffset = offset
+		p.Size = uint32(len(p.Data))
+		dn.handleWritePacket(p)
+		return p
+	}
+
+	// the write fails after the bytes are reserved, they are given back
+	p = write(10)
+	require.EqualValues(t, proto.OpArgMismatchErr, p.ResultCode)
+	require.Zero(t, dp.writes.pending())
+	require.Zero(t, dp.disk.writes.pending())
+
+	p = write(0)
+	require.EqualValues(t, proto.OpOk, p.ResultCode)
+	require.EqualValues(t, len(data), dp.writes.written)
+	require.EqualValues(t, len(data), dp.disk.writes.written)
+	require.Zero(t, dp.writes.inflight)
+}
+
 func newPacketForTest(task *proto.AdminTask) *repl.Packet {
 	data, _ := json.Marshal(task)
 	return &repl.Packet{
@@ -389,3 +423,57 @@ func TestReloadDisk(t *testing.T) {
 		require.Contains(t, string(p.Data), "not lost")
 	})
 }
+
+func TestContainerWritePacket(t *testing.T) {
+	dn := newDataNodeForOperatorTest(t)
+	dp := newDpForOperatorTest(t, dn)
+	containers := dp.ExtentStore().Containers()
+
+	data := []byte("small file")
+	extentID, offset, err := containers.Alloc()
+	require.NoError(t, err)
+	p := newPacketForOperatorTest(t, dp, extentID)
+	p.Opcode = proto.OpWrite
+	p.ExtentType = proto.TinyExtentType | proto.ContainerExtentFlag
+	p.ExtentOffset = offset
+	p.Data = data
+	p.Size = uint32(len(data))
+	p.CRC = crc32.ChecksumIEEE(data)
+	dn.handleWritePacket(p)
+	require.EqualValues(t, proto.OpOk, p.ResultCode)
+
+	buf := make([]byte, len(data))
+	_, err = dp.ExtentStore().Read(extentID, offset, int64(len(data)), buf, false, false)
+	require.NoError(t, err)
+	require.Equal(t, data, buf)
+
+	// an overwrite beyond the record is rejected before it is submitted
+	p = newPacketForOperatorTest(t, dp, extentID)
+	p.Opcode = proto.OpRandomWrite
+	p.ExtentOffset = offset + int64(len(data)) - 1
+	p.Data = []byte("ab")
+	p.Size = 2
+	dn.handleRandomWritePacket(p)
+	require.NotEqualValues(t, proto.OpOk, p.ResultCode)
+
+	p = newPacketForOperatorTest(t, dp, 0)
+	p.Opcode = proto.OpGetContainerIndex
+	dn.handlePacketToGetContainerIndex(p)
+	require.EqualValues(t, proto.OpOk, p.ResultCode)
+	summary := new(storage.ContainerSummary)
+	require.NoError(t, json.Unmarshal(p.Data[:p.Size], summary))
+	require.Len(t, summary.Digests, 1)
+	require.Equal(t, extentID, summary.Digests[0].ExtentID)
+
+	p = newPacketForOperatorTest(t, dp, 0)
+	p.Opcode = proto.OpGetContainerIndex
+	p.Data, err = json.Marshal([]uint64{extentID, extentID + 1})
+	require.NoError(t, err)
+	p.Size = uint32(len(p.Data))
+	dn.handlePacketToGetContainerIndex(p)
+	require.EqualValues(t, proto.OpOk, p.ResultCode)
+	var indexes []*storage.ContainerIndex
+	require.NoError(t, json.Unmarshal(p.Data[:p.Size], &indexes))
+	require.Len(t, indexes, 1)
+	require.Equal(t, []storage.ContainerRecordInfo{{RecordID: 1, Size: uint32(len(data)), Crc: crc32.ChecksumIEEE(data)}}, indexes[0].Records)
+}
@@ -18,14 +18,237 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/crc32"
+	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/cubefs/cubefs/datanode/repl"
 	"github.com/cubefs/cubefs/datanode/storage"
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/log"
 )
 
+// prepareFrom returns the prepare func of the packets read from c. The packets of a
+// client evicted by master, writing with a stale fence token, sent in plain for a
+// volume requiring TLS, or overwriting the extents of an immutable volume, are
+// rejected before they are forwarded to the followers.
+func (s *DataNode) prepareFrom(c net.Conn) func(p *repl.Packet) error {
+	var remoteAddr string
+	if c.RemoteAddr() != nil {
+		remoteAddr = c.RemoteAddr().String()
+	}
+	secure := util.IsTLSConn(c)
+	return func(p *repl.Packet) error {
+		if s.isClientEvicted(p, remoteAddr) {
+			p.SetPacketHasPrepare()
+			p.PackErrorBody(repl.ActionPreparePkt, proto.ErrClientEvicted.Error())
+			log.LogWarnf("prepare: reject %v from %v, client evicted", p.GetOpMsg(), remoteAddr)
+			return proto.ErrClientEvicted
+		}
+		if s.isFenceStale(p) {
+			p.SetPacketHasPrepare()
+			p.PackErrorBody(repl.ActionPreparePkt, proto.ErrStaleFenceToken.Error())
+			log.LogWarnf("prepare: reject %v from %v, stale fence token %v", p.GetOpMsg(), remoteAddr, p.FenceToken)
+			return proto.ErrStaleFenceToken
+		}
+		if !secure && s.isTLSRequired(p, remoteAddr) {
+			p.SetPacketHasPrepare()
+			p.PackErrorBody(repl.ActionPreparePkt, proto.ErrTLSRequired.Error())
+			log.LogWarnf("prepare: reject %v from %v, volume requires TLS", p.GetOpMsg(), remoteAddr)
+			return proto.ErrTLSRequired
+		}
+		if s.isVolImmutable(p, time.Now()) {
+			p.SetPacketHasPrepare()
+			p.PackErrorBody(repl.ActionPreparePkt, proto.ErrVolImmutable.Error())
+			log.LogWarnf("prepare: reject %v from %v, volume is immutable", p.GetOpMsg(), remoteAddr)
+			return proto.ErrVolImmutable
+		}
+		return s.Prepare(p)
+	}
+}
+
+func (s *DataNode) setTLSRequiredVols(vols []string) {
+	required := make(map[string]struct{}, len(vols))
+	for _, vol := range vols {
+		required[vol] = struct{}{}
+	}
+	if old, _ := s.tlsRequiredVols.Load().(map[string]struct{}); len(old) != len(required) {
+		log.LogWarnf("[setTLSRequiredVols] vols requiring TLS change to %v, tls configured %v", vols, s.tlsConfig != nil)
+	}
+	s.tlsRequiredVols.Store(required)
+}
+
+// isTLSRequired reports whether the volume of the packet serves its clients over TLS
+// only. The replicas of the partition replicating or repairing are not clients.
+func (s *DataNode) isTLSRequired(p *repl.Packet, remoteAddr string) bool {
+	vols, _ := s.tlsRequiredVols.Load().(map[string]struct{})
+	if len(vols) == 0 || p.IsMasterCommand() {
+		return false
+	}
+	dp := s.space.Partition(p.PartitionID)
+	if dp == nil {
+		return false
+	}
+	if _, ok := vols[dp.volumeID]; !ok {
+		return false
+	}
+	host, _, _ := net.SplitHostPort(remoteAddr)
+	for _, replica := range dp.getReplicaCopy() {
+		if replicaHost, _, _ := net.SplitHostPort(replica); replicaHost == host {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *DataNode) setImmutableVols(vols map[string]int64) {
+	if old, _ := s.immutableVols.Load().(map[string]int64); len(old) != len(vols) {
+		log.LogWarnf("[setImmutableVols] immutable vols change to %v", vols)
+	}
+	s.immutableVols.Store(vols)
+}
+
+// isVolImmutable reports whether the packet overwrites the extents of a volume
+// in its immutability window. The metanodes reject the new extents of the files
+// then, the random writes in place never reach them.
+func (s *DataNode) isVolImmutable(p *repl.Packet, now time.Time) bool {
+	vols, _ := s.immutableVols.Load().(map[string]int64)
+	if len(vols) == 0 || p.IsMasterCommand() {
+		return false
+	}
+	switch p.Opcode {
+	case proto.OpRandomWrite, proto.OpSyncRandomWrite, proto.OpRandomWriteVer, proto.OpSyncRandomWriteVer,
+		proto.OpRandomWriteAppend, proto.OpSyncRandomWriteAppend:
+	default:
+		return false
+	}
+	dp := s.space.Partition(p.PartitionID)
+	if dp == nil {
+		return false
+	}
+	until, ok := vols[dp.volumeID]
+	return ok && now.Unix() < until
+}
+
+func (s *DataNode) setClientFence(evictions []*proto.ClientEviction) {
+	fence := proto.NewClientFence(evictions, time.Now().Unix())
+	if old, _ := s.clientFence.Load().(*proto.ClientFence); old.Len() != fence.Len() {
+		log.LogWarnf("[setClientFence] evicted clients change from %v to %v", old.Len(), fence.Len())
+	}
+	s.clientFence.Store(fence)
+}
+
+func (s *DataNode) isClientEvicted(p *repl.Packet, remoteAddr string) bool {
+	fence, _ := s.clientFence.Load().(*proto.ClientFence)
+	if !fence.HasIP(remoteAddr) || p.IsMasterCommand() {
+		return false
+	}
+	var vol string
+	if dp := s.space.Partition(p.PartitionID); dp != nil {
+		vol = dp.volumeID
+	}
+	return fence.Evicted(remoteAddr, vol)
+}
+
+func (s *DataNode) setVolFences(fences []*proto.VolFence) {
+	tokens := proto.NewFenceTokens(fences)
+	if old, _ := s.volFences.Load().(*proto.FenceTokens); old.Len() != tokens.Len() {
+		log.LogWarnf("[setVolFences] fenced volumes change from %v to %v", old.Len(), tokens.Len())
+	}
+	s.volFences.Store(tokens)
+}
+
+// isFenceStale reports whether the packet writes with a token older than the
+// fence of its volume, only the writes of the clients carry a token.
+func (s *DataNode) isFenceStale(p *repl.Packet) bool {
+	if p.ExtentType&proto.FenceTokenFlag == 0 || p.IsMasterCommand() {
+		return false
+	}
+	tokens, _ := s.volFences.Load().(*proto.FenceTokens)
+	if tokens.Len() == 0 {
+		return false
+	}
+	dp := s.space.Partition(p.PartitionID)
+	if dp == nil {
+		return false
+	}
+	return tokens.Stale(dp.volumeID, p.FenceToken)
+}
+
+type syncMirrorInfo struct {
+	vols    map[string]struct{}
+	zones   map[string]string // data node addr -> zone
+	version uint64            // of zones, reported to the master
+}
+
+// setSyncMirror sets the sync mirror volumes of a heartbeat. The master sends
+// the zones only when the version the node reported is not the latest, the
+// ones held are kept otherwise.
+func (s *DataNode) setSyncMirror(vols []string, version uint64, zones map[string]string) {
+	info := &syncMirrorInfo{vols: make(map[string]struct{}, len(vols)), zones: zones, version: version}
+	for _, vol := range vols {
+		info.vols[vol] = struct{}{}
+	}
+	var oldCnt int
+	old, _ := s.syncMirror.Load().(*syncMirrorInfo)
+	if old != nil {
+		oldCnt = len(old.vols)
+	}
+	if zones == nil && len(vols) > 0 {
+		info.zones, info.version = nil, 0
+		if old != nil {
+			info.zones, info.version = old.zones, old.version
+		}
+	}
+	if oldCnt != len(info.vols) {
+		log.LogWarnf("[setSyncMirror] sync mirror write vols change to %v", vols)
+	}
+	if info.version != version {
+		log.LogWarnf("[setSyncMirror] zones of version %v not sent, keep the ones of version %v", version, info.version)
+	}
+	s.syncMirror.Store(info)
+}
+
+// syncMirrorZonesVersion returns the version of the zones held.
+func (s *DataNode) syncMirrorZonesVersion() uint64 {
+	if info, _ := s.syncMirror.Load().(*syncMirrorInfo); info != nil {
+		return info.version
+	}
+	return 0
+}
+
+// syncMirrorZones returns the zones of the data nodes if the writes of vol
+// should be acked by a replica in another zone.
+func (s *DataNode) syncMirrorZones(vol string) (zones map[string]string, ok bool) {
+	info, _ := s.syncMirror.Load().(*syncMirrorInfo)
+	if info == nil {
+		return
+	}
+	if _, ok = info.vols[vol]; ok {
+		zones = info.zones
+	}
+	return
+}
+
+// checkSyncMirrorFollowers makes sure one of the followers is in another zone.
+// The leader replies after all the followers acked, so the write is mirrored
+// to that zone once it completes.
+func (s *DataNode) checkSyncMirrorFollowers(p *repl.Packet, dp *DataPartition) error {
+	zones, ok := s.syncMirrorZones(dp.volumeID)
+	if !ok {
+		return nil
+	}
+	for _, addr := range p.GetFollowersAddrs() {
+		if zone, ok := zones[addr]; ok && zone != s.zoneName {
+			return nil
+		}
+	}
+	log.LogWarnf("[checkSyncMirrorFollowers] dp(%v) vol(%v) followers %v are all in zone(%v)",
+		dp.partitionID, dp.volumeID, p.GetFollowersAddrs(), s.zoneName)
+	return ErrSyncMirrorNoRemoteZone
+}
+
 func (s *DataNode) Prepare(p *repl.Packet) (err error) {
 	defer func() {
 		p.SetPacketHasPrepare()
@@ -98,6 +321,11 @@ func (s *DataNode) checkPartition(p *repl.Packet) (err error) {
 		return
 	}
 	p.Object = dp
+	if dp.isReadReplica() && (p.IsNormalWriteOperation() || p.IsCreateExtentOperation() || p.IsRandomWrite() ||
+		p.IsSnapshotModWriteAppendOperation() || p.IsMarkDeleteExtentOperation()) {
+		err = fmt.Errorf("dp(%v) is a read replica, no write on it", p.PartitionID)
+		return
+	}
 	if p.IsNormalWriteOperation() || p.IsCreateExtentOperation() {
 		if dp.Available() <= 0 {
 			log.LogErrorf("[checkPartition] dp(%v) disk no space available(%v) can write(%v)", dp.partitionID, dp.Available(), dp.disk.CanWrite())
@@ -105,6 +333,11 @@ func (s *DataNode) checkPartition(p *repl.Packet) (err error) {
 			return
 		}
 	}
+	if p.IsLeaderPacket() && (p.IsNormalWriteOperation() || p.IsCreateExtentOperation()) {
+		if err = s.checkSyncMirrorFollowers(p, dp); err != nil {
+			return
+		}
+	}
 	if p.IsNormalWriteOperation() || p.IsRandomWrite() {
 		dp.disk.allocCheckLimit(proto.FlowWriteType, uint32(p.Size))
 		dp.disk.allocCheckLimit(proto.IopsWriteType, 1)
@@ -129,7 +362,13 @@ func (s *DataNode) checkPacketAndPrepare(p *repl.Packet) error {
 			return err
 		}
 	}
-	if p.IsLeaderPacket() && proto.IsTinyExtentType(p.ExtentType) && p.IsNormalWriteOperation() {
+	if p.IsLeaderPacket() && proto.IsTinyExtentType(p.ExtentType) && p.IsNormalWriteOperation() &&
+		p.ExtentType&proto.ContainerExtentFlag != 0 {
+		p.ExtentID, p.ExtentOffset, err = store.Containers().Alloc()
+		if err != nil {
+			return fmt.Errorf("checkPacketAndPrepare partition %v alloc container record error %v", p.PartitionID, err.Error())
+		}
+	} else if p.IsLeaderPacket() && proto.IsTinyExtentType(p.ExtentType) && p.IsNormalWriteOperation() {
 		extentID, err = store.GetAvailableTinyExtent()
 		if err != nil {
 			return fmt.Errorf("checkPacketAndPrepare partition %v GetAvailableTinyExtent error %v", p.PartitionID, err.Error())
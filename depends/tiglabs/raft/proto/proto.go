@@ -54,6 +54,8 @@ const (
 
 	PeerNormal  PeerType = 0
 	PeerArbiter PeerType = 1
+	// PeerLearner receives the log but neither votes nor counts in the quorum.
+	PeerLearner PeerType = 2
 )
 
 // The Snapshot interface is supplied by the application to access the snapshot data of application.
@@ -195,6 +197,8 @@ func (t PeerType) String() string {
 		return "PeerNormal"
 	case 1:
 		return "PeerArbiter"
+	case 2:
+		return "PeerLearner"
 	}
 	return "unknown"
 }
@@ -41,6 +41,12 @@ type apply struct {
 	readIndexes []*Future
 }
 
+// handle user's forced reset of the peers
+type resetPeersRequest struct {
+	future *Future
+	peers  []proto.Peer
+}
+
 // handle user's get log entries request
 type entryRequest struct {
 	future     *Future
@@ -117,6 +123,7 @@ type raft struct {
 	readIndexC        chan *Future
 	statusc           chan chan *Status
 	entryRequestC     chan *entryRequest
+	resetPeersC       chan *resetPeersRequest
 	readyc            chan struct{}
 	tickc             chan struct{}
 	electc            chan struct{}
@@ -157,6 +164,7 @@ func newRaft(config *Config, raftConfig *RaftConfig) (*raft, error) {
 		readIndexC:    make(chan *Future, 256),
 		statusc:       make(chan chan *Status, 1),
 		entryRequestC: make(chan *entryRequest, 16),
+		resetPeersC:   make(chan *resetPeersRequest, 1),
 		tickc:         make(chan struct{}, 64),
 		readyc:        make(chan struct{}, 1),
 		electc:        make(chan struct{}, 1),
@@ -435,6 +443,9 @@ func (s *raft) run() {
 
 		case req := <-s.entryRequestC:
 			s.getEntriesInLoop(req)
+
+		case req := <-s.resetPeersC:
+			s.resetPeersInLoop(req)
 		}
 	}
 }
@@ -818,6 +829,38 @@ func (s *raft) getPeers() (peers []uint64) {
 	return s.peerState.get()
 }
 
+// resetPeers replaces the peers of this member without a conf change, to form
+// a new group of the members left when the others are lost for good.
+func (s *raft) resetPeers(peers []proto.Peer, future *Future) {
+	if s.restoringSnapshot.Get() {
+		future.respond(nil, ErrSnapping)
+		return
+	}
+
+	select {
+	case <-s.stopc:
+		future.respond(nil, ErrStopped)
+	case s.resetPeersC <- &resetPeersRequest{future: future, peers: peers}:
+	}
+}
+
+func (s *raft) resetPeersInLoop(req *resetPeersRequest) {
+	kept := make(map[uint64]struct{}, len(req.peers))
+	for _, p := range req.peers {
+		kept[p.ID] = struct{}{}
+	}
+	for id := range s.raftFsm.replicas {
+		if _, ok := kept[id]; !ok {
+			s.removeSnapping(id)
+		}
+	}
+	s.raftFsm.resetPeers(req.peers)
+	s.peerState.replace(req.peers)
+	s.maybeChange(true)
+	logger.Warn("raft[%v] reset peers to %v", s.raftFsm.id, req.peers)
+	req.future.respond(nil, nil)
+}
+
 func (s *raft) readIndex(future *Future) {
 	if !s.isLeader() {
 		future.respond(nil, ErrNotLeader)
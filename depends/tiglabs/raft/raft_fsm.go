@@ -488,8 +488,43 @@ func (r *raftFsm) updatePeer(peer proto.Peer) {
 	}
 }
 
+// isVoter returns whether the replica votes and counts in the quorum.
+func (r *raftFsm) isVoter(id uint64) bool {
+	pr, ok := r.replicas[id]
+	return ok && pr.peer.Type != proto.PeerLearner
+}
+
+func (r *raftFsm) voters() (n int) {
+	for _, pr := range r.replicas {
+		if pr.peer.Type != proto.PeerLearner {
+			n++
+		}
+	}
+	return
+}
+
 func (r *raftFsm) quorum() int {
-	return len(r.replicas)/2 + 1
+	return r.voters()/2 + 1
+}
+
+// resetPeers replaces the replicas by peers without a conf change, the replicas
+// kept take the type of the peer. It is applied to every member of the new
+// group, and the leader steps down.
+func (r *raftFsm) resetPeers(peers []proto.Peer) {
+	replicas := make(map[uint64]*replica, len(peers))
+	for _, p := range peers {
+		if pr, ok := r.replicas[p.ID]; ok {
+			pr.peer = p
+			replicas[p.ID] = pr
+			continue
+		}
+		replicas[p.ID] = newReplica(p, 0)
+	}
+	r.replicas = replicas
+	r.pendingConf = false
+	if r.state != stateFollower {
+		r.becomeFollower(r.term, NoLeader)
+	}
 }
 
 func (r *raftFsm) send(m *proto.Message) {
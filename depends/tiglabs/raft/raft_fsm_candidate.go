@@ -116,7 +116,7 @@ func (r *raftFsm) campaign(force bool, t CampaignType) {
 	}
 
 	for id := range r.replicas {
-		if id == r.config.NodeID {
+		if id == r.config.NodeID || !r.isVoter(id) {
 			continue
 		}
 		li, lt := r.raftLog.lastIndexAndTerm()
@@ -147,8 +147,8 @@ func (r *raftFsm) poll(id uint64, v bool) (granted int) {
 	if _, ok := r.votes[id]; !ok {
 		r.votes[id] = v
 	}
-	for _, vv := range r.votes {
-		if vv {
+	for vid, vv := range r.votes {
+		if vv && r.isVoter(vid) {
 			granted++
 		}
 	}
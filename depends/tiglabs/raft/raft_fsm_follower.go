@@ -207,5 +207,7 @@ func (r *raftFsm) handleAppendEntries(m *proto.Message) {
 func (r *raftFsm) promotable() bool {
 	// todo check snapshot
 	pr, ok := r.replicas[r.config.NodeID]
-	return ok && pr.state != replicaStateSnapshot
+	// an arbiter votes but holds no data, and a learner does not vote, they never campaign
+	return ok && pr.state != replicaStateSnapshot && pr.peer.Type != proto.PeerArbiter &&
+		pr.peer.Type != proto.PeerLearner
 }
@@ -308,7 +308,9 @@ func stepLeader(r *raftFsm, m *proto.Message) {
 		if logger.IsEnableDebug() {
 			logger.Debug("raft[%d] recv check quorum resp from %d, index=%d", r.id, m.From, m.Index)
 		}
-		r.readOnly.recvAck(m.Index, m.From, r.quorum())
+		if r.isVoter(m.From) {
+			r.readOnly.recvAck(m.Index, m.From, r.quorum())
+		}
 		proto.ReturnMessage(m)
 		return
 	}
@@ -364,7 +366,9 @@ func stepPreCandidate(r *raftFsm, m *proto.Message) {
 		if logger.IsEnableDebug() {
 			logger.Debug("raft[%d] recv check quorum resp from %d, index=%d", r.id, m.From, m.Index)
 		}
-		r.readOnly.recvAck(m.Index, m.From, r.quorum())
+		if r.isVoter(m.From) {
+			r.readOnly.recvAck(m.Index, m.From, r.quorum())
+		}
 		proto.ReturnMessage(m)
 		return
 
@@ -439,6 +443,9 @@ func (r *raftFsm) tickElectionAck() {
 func (r *raftFsm) checkLeaderLease() bool {
 	var act int
 	for id, peer := range r.replicas {
+		if peer.peer.Type == proto.PeerLearner {
+			continue
+		}
 		if id == r.config.NodeID || peer.state == replicaStateSnapshot {
 			act++
 			continue
@@ -458,7 +465,12 @@ func (r *raftFsm) checkLeaderLease() bool {
 func (r *raftFsm) maybeCommit() bool {
 	mis := make(util.Uint64Slice, 0, len(r.replicas))
 	for _, rp := range r.replicas {
-		mis = append(mis, rp.match)
+		if rp.peer.Type != proto.PeerLearner {
+			mis = append(mis, rp.match)
+		}
+	}
+	if len(mis) == 0 {
+		return false
 	}
 	sort.Sort(sort.Reverse(mis))
 	mci := mis[r.quorum()-1]
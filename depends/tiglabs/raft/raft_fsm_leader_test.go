@@ -818,6 +818,120 @@ func TestPromotable(t *testing.T) {
 	}
 }
 
+func TestArbiterNotPromotable(t *testing.T) {
+	peers := []proto.Peer{
+		{ID: 1, PeerID: 1},
+		{ID: 2, PeerID: 2},
+		{ID: 3, PeerID: 3, Type: proto.PeerArbiter},
+	}
+	newFsm := func(id uint64) *raftFsm {
+		cfg := newTestRaftConfig(id, withStorage(stor.DefaultMemoryStorage()))
+		cfg.Peers = peers
+		return newTestRaftFsm(10, 1, cfg)
+	}
+	a, b, c := newFsm(1), newFsm(2), newFsm(3)
+	if !a.promotable() || c.promotable() {
+		t.Fatalf("promotable = %v %v, want true false", a.promotable(), c.promotable())
+	}
+
+	nt := newNetwork(a, b, c)
+	// newNetwork overwrites the replicas, mark the arbiter again
+	for _, r := range []*raftFsm{a, b, c} {
+		r.replicas[3].peer.Type = proto.PeerArbiter
+	}
+	nt.send(proto.Message{From: 3, To: 3, Type: proto.LocalMsgHup})
+	if c.state != stateFollower {
+		t.Fatalf("arbiter state = %s, want %v", c.state, stateFollower)
+	}
+
+	// the arbiter votes, so a data replica is elected without the other one
+	nt.isolate(2)
+	nt.send(proto.Message{From: 1, To: 1, Type: proto.LocalMsgHup})
+	if a.state != stateLeader {
+		t.Fatalf("state = %s, want %v", a.state, stateLeader)
+	}
+}
+
+func TestLearner(t *testing.T) {
+	peers := []proto.Peer{
+		{ID: 1, PeerID: 1},
+		{ID: 2, PeerID: 2},
+		{ID: 3, PeerID: 3, Type: proto.PeerLearner},
+	}
+	newFsm := func(id uint64) *raftFsm {
+		cfg := newTestRaftConfig(id, withStorage(stor.DefaultMemoryStorage()))
+		cfg.Peers = peers
+		return newTestRaftFsm(10, 1, cfg)
+	}
+	a, b, c := newFsm(1), newFsm(2), newFsm(3)
+	if !a.promotable() || c.promotable() {
+		t.Fatalf("promotable = %v %v, want true false", a.promotable(), c.promotable())
+	}
+
+	nt := newNetwork(a, b, c)
+	// newNetwork overwrites the replicas, mark the learner again
+	for _, r := range []*raftFsm{a, b, c} {
+		r.replicas[3].peer.Type = proto.PeerLearner
+	}
+	if q := a.quorum(); q != 2 {
+		t.Fatalf("quorum = %d, want 2", q)
+	}
+	nt.send(proto.Message{From: 3, To: 3, Type: proto.LocalMsgHup})
+	if c.state != stateFollower {
+		t.Fatalf("learner state = %s, want %v", c.state, stateFollower)
+	}
+
+	// the learner does not vote, a data replica is not elected without the other one
+	nt.isolate(2)
+	nt.send(proto.Message{From: 1, To: 1, Type: proto.LocalMsgHup})
+	if a.state == stateLeader {
+		t.Fatalf("state = %s, want not %v", a.state, stateLeader)
+	}
+	nt.recover()
+	nt.send(proto.Message{From: 1, To: 1, Type: proto.LocalMsgHup})
+	if a.state != stateLeader {
+		t.Fatalf("state = %s, want %v", a.state, stateLeader)
+	}
+
+	// the learner does not count in the quorum of the commit
+	propose := func(id uint64) {
+		r := nt.peers[id].(*raftFsm)
+		e := &proto.Entry{Index: r.raftLog.lastIndex() + 1, Term: r.term}
+		nt.send(proto.Message{From: id, To: id, Type: proto.LocalMsgProp, Entries: []*proto.Entry{e}})
+	}
+	nt.isolate(3)
+	propose(1)
+	if a.raftLog.committed != a.raftLog.lastIndex() {
+		t.Fatalf("committed = %d, want %d", a.raftLog.committed, a.raftLog.lastIndex())
+	}
+	nt.recover()
+	nt.isolate(2)
+	committed := a.raftLog.committed
+	propose(1)
+	if a.raftLog.committed != committed {
+		t.Fatalf("committed = %d, want %d", a.raftLog.committed, committed)
+	}
+	if c.raftLog.lastIndex() != a.raftLog.lastIndex() {
+		t.Fatalf("learner last index = %d, want %d", c.raftLog.lastIndex(), a.raftLog.lastIndex())
+	}
+
+	// promote the learner into a new group without the lost replica
+	promoted := []proto.Peer{{ID: 1, PeerID: 1}, {ID: 3, PeerID: 3}}
+	a.resetPeers(promoted)
+	c.resetPeers(promoted)
+	if a.state != stateFollower || !c.promotable() || len(c.replicas) != 2 {
+		t.Fatalf("state = %s, promotable = %v, replicas = %d", a.state, c.promotable(), len(c.replicas))
+	}
+	nt.send(proto.Message{From: 3, To: 3, Type: proto.LocalMsgHup})
+	if c.state != stateLeader {
+		t.Fatalf("promoted state = %s, want %v", c.state, stateLeader)
+	}
+	propose(3)
+	if c.raftLog.committed != c.raftLog.lastIndex() || c.raftLog.committed <= committed {
+		t.Fatalf("committed = %d, want %d", c.raftLog.committed, c.raftLog.lastIndex())
+	}
+}
+
 func TestCampaignWhileLeader(t *testing.T) {
 	testCampaignWhileLeader(t, false)
 }
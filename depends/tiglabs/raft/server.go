@@ -218,6 +218,23 @@ func (rs *RaftServer) ChangeMember(id uint64, changeType proto.ConfChangeType, p
 	return
 }
 
+// ResetPeers replaces the peers of the raft on this server without a conf change.
+// It is applied to every member of the new group, and must not be used while the
+// members out of the new group may still form a quorum.
+func (rs *RaftServer) ResetPeers(id uint64, peers []proto.Peer) (future *Future) {
+	rs.mu.RLock()
+	raft, ok := rs.rafts[id]
+	rs.mu.RUnlock()
+
+	future = newFuture()
+	if !ok {
+		future.respond(nil, ErrRaftNotExists)
+		return
+	}
+	raft.resetPeers(peers, future)
+	return
+}
+
 func (rs *RaftServer) IsRestoring(id uint64) bool {
 	rs.mu.RLock()
 	defer rs.mu.RUnlock()
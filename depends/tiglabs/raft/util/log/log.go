@@ -26,7 +26,6 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 )
 
@@ -351,19 +350,19 @@ func (l *Log) SetPrefix(s, level string) string {
 }
 
 func (l *Log) SetRotate(logDir string) error {
-	fs := syscall.Statfs_t{}
-	if err := syscall.Statfs(logDir, &fs); err != nil {
+	total, avail, err := diskSpace(logDir)
+	if err != nil {
 		return fmt.Errorf("[InitLog] stats disk space: %s", err.Error())
 	}
 	var minRatio float64
-	if float64(fs.Bavail*uint64(fs.Bsize)) < float64(fs.Blocks*uint64(fs.Bsize))*DefaultHeadRatio {
-		minRatio = float64(fs.Bavail*uint64(fs.Bsize)) * DefaultHeadRatio / 1024 / 1024
+	if float64(avail) < float64(total)*DefaultHeadRatio {
+		minRatio = float64(avail) * DefaultHeadRatio / 1024 / 1024
 	} else {
-		minRatio = float64(fs.Blocks*uint64(fs.Bsize)) * DefaultHeadRatio / 1024 / 1024
+		minRatio = float64(total) * DefaultHeadRatio / 1024 / 1024
 	}
 	l.headRoomMB = int64(math.Min(minRatio, DefaultHeadRoom))
 
-	minRollingSize := int64(fs.Bavail*uint64(fs.Bsize)/4) / 1024 / 1024 // because 4 log levels
+	minRollingSize := int64(avail/4) / 1024 / 1024 // because 4 log levels
 	if minRollingSize < DefaultMinRollingSize {
 		minRollingSize = DefaultMinRollingSize
 	}
@@ -496,13 +495,13 @@ func (l *Log) checkCleanLog(logDir, module string) {
 
 	for {
 		// check disk space
-		fs := syscall.Statfs_t{}
-		if err := syscall.Statfs(logDir, &fs); err != nil {
+		_, avail, err := diskSpace(logDir)
+		if err != nil {
 			fmt.Printf("[Util.Logger]Check disk space of dir[%s] err: [%s]\r\n", logDir, err)
 			time.Sleep(time.Second * 600)
 			continue
 		}
-		diskSpaceLeft := int64(fs.Bavail * uint64(fs.Bsize))
+		diskSpaceLeft := int64(avail)
 		diskSpaceLeft -= l.headRoomMB * 1024 * 1024
 
 		fInfos, err := ioutil.ReadDir(logDir)
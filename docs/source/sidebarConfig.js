@@ -150,6 +150,8 @@ module.exports = [
                     'dev-guide/admin-api/master/management.md',
                     'dev-guide/admin-api/master/user.md',
                     'dev-guide/admin-api/master/failureDomain.md',
+                    'dev-guide/admin-api/master/openapi.md',
+                    'dev-guide/admin-api/master/error-codes.md',
                     'dev-guide/admin-api/metanode/partition.md',
                     'dev-guide/admin-api/metanode/inode.md',
                     'dev-guide/admin-api/metanode/dentry.md',
@@ -0,0 +1,100 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// peerCacheTokenTTL is the life of the tokens the flash node signs for the
+// requests it sends to the peers on behalf of a client.
+const peerCacheTokenTTL = time.Minute
+
+// cacheAuth is pushed by the master heartbeat: the volumes whose cache is served
+// to the holders of a flash cache token only, the key verifying the tokens, and
+// the volumes whose cache is served over TLS only.
+type cacheAuth struct {
+	key      []byte
+	authVols map[string]struct{}
+	tlsVols  map[string]struct{}
+}
+
+func volSet(vols []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(vols))
+	for _, vol := range vols {
+		set[vol] = struct{}{}
+	}
+	return set
+}
+
+// SetCacheAuth updates the volumes whose cache requests are authenticated.
+func (f *FlashNode) SetCacheAuth(key []byte, authVols, tlsVols []string) {
+	auth := &cacheAuth{key: key, authVols: volSet(authVols), tlsVols: volSet(tlsVols)}
+	if old := f.getCacheAuth(); len(old.authVols) != len(auth.authVols) || len(old.tlsVols) != len(auth.tlsVols) {
+		log.LogWarnf("FlashNode set vols requiring token to %v, requiring TLS to %v, tls configured %v",
+			authVols, tlsVols, f.tlsConfig != nil)
+	}
+	f.cacheAuth.Store(auth)
+}
+
+func (f *FlashNode) getCacheAuth() *cacheAuth {
+	if auth, ok := f.cacheAuth.Load().(*cacheAuth); ok {
+		return auth
+	}
+	return &cacheAuth{}
+}
+
+// checkCacheAuth checks a cache request of the volume, carrying the encoded
+// token and received over TLS if secure.
+func (f *FlashNode) checkCacheAuth(volume string, token []byte, secure bool) error {
+	auth := f.getCacheAuth()
+	if _, ok := auth.tlsVols[volume]; ok && !secure {
+		return proto.ErrTLSRequired
+	}
+	if _, ok := auth.authVols[volume]; !ok {
+		return nil
+	}
+	t, err := proto.DecodeFlashCacheToken(token)
+	if err != nil {
+		return err
+	}
+	return t.Verify(auth.key, volume, time.Now().Unix())
+}
+
+// peerCacheToken returns the token of the requests of the volume to the peers,
+// nil if the volume requires none.
+func (f *FlashNode) peerCacheToken(volume string) []byte {
+	auth := f.getCacheAuth()
+	if _, ok := auth.authVols[volume]; !ok || len(auth.key) == 0 {
+		return nil
+	}
+	return proto.NewFlashCacheToken(auth.key, volume, time.Now().Add(peerCacheTokenTTL).Unix()).Encode()
+}
+
+// packetToken returns the token carried in the arg of a cache request.
+func packetToken(p *proto.Packet) []byte {
+	if int(p.ArgLen) > len(p.Arg) {
+		return nil
+	}
+	return p.Arg[:p.ArgLen]
+}
+
+func setPacketToken(p *proto.Packet, token []byte) {
+	p.Arg = token
+	p.ArgLen = uint32(len(token))
+}
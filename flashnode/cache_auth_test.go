@@ -0,0 +1,60 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheAuth(t *testing.T) {
+	f := new(FlashNode)
+	require.NoError(t, f.checkCacheAuth("vol", nil, false))
+	require.Nil(t, f.peerCacheToken("vol"))
+
+	key := []byte("key")
+	f.SetCacheAuth(key, []string{"vol"}, []string{"tls"})
+	require.NoError(t, f.checkCacheAuth("other", nil, false))
+	require.Equal(t, proto.ErrTLSRequired, f.checkCacheAuth("tls", nil, false))
+	require.NoError(t, f.checkCacheAuth("tls", nil, true))
+
+	err := f.checkCacheAuth("vol", nil, true)
+	require.True(t, errors.Is(err, proto.ErrFlashCacheTokenInvalid))
+	expire := time.Now().Add(time.Hour).Unix()
+	require.NoError(t, f.checkCacheAuth("vol", proto.NewFlashCacheToken(key, "vol", expire).Encode(), false))
+	err = f.checkCacheAuth("vol", proto.NewFlashCacheToken(key, "other", expire).Encode(), false)
+	require.True(t, errors.Is(err, proto.ErrFlashCacheTokenInvalid))
+	err = f.checkCacheAuth("vol", proto.NewFlashCacheToken([]byte("old"), "vol", expire).Encode(), false)
+	require.True(t, errors.Is(err, proto.ErrFlashCacheTokenInvalid))
+
+	// the peers verify the tokens signed by the node
+	token := f.peerCacheToken("vol")
+	require.NotNil(t, token)
+	require.NoError(t, f.checkCacheAuth("vol", token, false))
+	require.Nil(t, f.peerCacheToken("other"))
+
+	p := proto.NewPacket()
+	require.Empty(t, packetToken(p))
+	setPacketToken(p, token)
+	require.Equal(t, token, packetToken(p))
+
+	f.SetCacheAuth(nil, nil, nil)
+	require.NoError(t, f.checkCacheAuth("vol", nil, false))
+	require.NoError(t, f.checkCacheAuth("tls", nil, false))
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+const (
+	sketchDepth       = 4
+	sketchMaxCount    = 15 // counters saturate like 4-bit ones
+	sketchMinWidth    = 1024
+	sketchSampleRatio = 10 // counters are halved after sampleRatio * width records
+)
+
+var sketchSeeds = [sketchDepth]uint64{0x9e3779b97f4a7c15, 0xbf58476d1ce4e5b9, 0x94d049bb133111eb, 0xd6e8feb86659fd93}
+
+// admissionFilter is a TinyLFU admission policy. It estimates how often the
+// blocks were accessed recently with a count-min sketch, in front of which a
+// doorkeeper bitmap keeps the blocks seen only once out of the sketch. A new
+// block only replaces the lru victim if it was accessed more often, so that a
+// one-shot sequential scan does not evict the hot blocks.
+type admissionFilter struct {
+	sync.Mutex
+	width      uint64 // power of 2
+	counters   [sketchDepth][]uint8
+	doorkeeper []uint64
+	additions  uint64
+	sampleSize uint64
+}
+
+func newAdmissionFilter(capacity int) *admissionFilter {
+	width := uint64(sketchMinWidth)
+	for width < uint64(capacity) {
+		width <<= 1
+	}
+	a := &admissionFilter{
+		width:      width,
+		doorkeeper: make([]uint64, width/64),
+		sampleSize: width * sketchSampleRatio,
+	}
+	for i := range a.counters {
+		a.counters[i] = make([]uint8, width)
+	}
+	return a
+}
+
+func (a *admissionFilter) indexes(key string) (idx [sketchDepth]uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	for i := range idx {
+		x := (sum ^ sketchSeeds[i]) * sketchSeeds[(i+1)%sketchDepth]
+		idx[i] = (x ^ x>>31) & (a.width - 1)
+	}
+	return
+}
+
+func (a *admissionFilter) inDoorkeeper(idx [sketchDepth]uint64) bool {
+	for _, i := range idx[:2] {
+		if a.doorkeeper[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// record counts an access to the block.
+func (a *admissionFilter) record(key string) {
+	idx := a.indexes(key)
+	a.Lock()
+	defer a.Unlock()
+	if !a.inDoorkeeper(idx) {
+		for _, i := range idx[:2] {
+			a.doorkeeper[i/64] |= 1 << (i % 64)
+		}
+	} else {
+		min := uint8(sketchMaxCount)
+		for d, i := range idx {
+			if a.counters[d][i] < min {
+				min = a.counters[d][i]
+			}
+		}
+		// conservative update, only the smallest counters are increased
+		if min < sketchMaxCount {
+			for d, i := range idx {
+				if a.counters[d][i] == min {
+					a.counters[d][i]++
+				}
+			}
+		}
+	}
+	a.additions++
+	if a.additions >= a.sampleSize {
+		a.reset()
+	}
+}
+
+// reset ages the filter, so that the blocks hot long ago can be replaced.
+func (a *admissionFilter) reset() {
+	for d := range a.counters {
+		for i := range a.counters[d] {
+			a.counters[d][i] >>= 1
+		}
+	}
+	for i := range a.doorkeeper {
+		a.doorkeeper[i] = 0
+	}
+	a.additions /= 2
+}
+
+func (a *admissionFilter) estimate(key string) int {
+	idx := a.indexes(key)
+	a.Lock()
+	defer a.Unlock()
+	return a.estimateLocked(idx)
+}
+
+func (a *admissionFilter) estimateLocked(idx [sketchDepth]uint64) int {
+	min := uint8(sketchMaxCount)
+	for d, i := range idx {
+		if a.counters[d][i] < min {
+			min = a.counters[d][i]
+		}
+	}
+	n := int(min)
+	if a.inDoorkeeper(idx) {
+		n++
+	}
+	return n
+}
+
+// admit tells whether the candidate block should replace the victim block.
+func (a *admissionFilter) admit(candidate, victim string) bool {
+	cIdx, vIdx := a.indexes(candidate), a.indexes(victim)
+	a.Lock()
+	defer a.Unlock()
+	return a.estimateLocked(cIdx) > a.estimateLocked(vIdx)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionFilter(t *testing.T) {
+	a := newAdmissionFilter(16)
+	require.Equal(t, uint64(sketchMinWidth), a.width)
+	require.Equal(t, 0, a.estimate("hot"))
+
+	a.record("hot")
+	require.Equal(t, 1, a.estimate("hot"))
+	for i := 0; i < 10; i++ {
+		a.record("hot")
+	}
+	require.Equal(t, 11, a.estimate("hot"))
+	for i := 0; i < 10; i++ {
+		a.record("hot")
+	}
+	require.Equal(t, sketchMaxCount+1, a.estimate("hot"))
+
+	// a scan touches every block once, none of them replaces the hot block
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("scan_%d", i)
+		a.record(key)
+		require.False(t, a.admit(key, "hot"))
+	}
+	require.True(t, a.admit("hot", "scan_0"))
+
+	// aging halves the counters and forgets the doorkeeper
+	a.reset()
+	require.Equal(t, sketchMaxCount/2, a.estimate("hot"))
+	require.Equal(t, 0, a.estimate("scan_0"))
+}
+
+func TestEngineAdmission(t *testing.T) {
+	var ce *CacheEngine
+	var err error
+	lruCap := 2
+	if _, err = os.Stat(testTmpFS); err != nil {
+		require.Equal(t, true, os.IsNotExist(err.(*os.PathError)))
+		err = os.MkdirAll(testTmpFS, 0o755)
+		require.NoError(t, err)
+	}
+	disk := &Disk{Path: testTmpFS, TotalSpace: 200 * util.MB, Capacity: lruCap, Status: proto.ReadWrite}
+	disks := []*Disk{disk}
+	if !enabledTmpfs() {
+		ce, err = NewCacheEngine("", 0, DefaultCacheMaxUsedRatio, disks, lruCap, lruCap, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	} else {
+		ce, err = NewCacheEngine(testTmpFS, util.GB, DefaultCacheMaxUsedRatio, disks, lruCap, lruCap, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	}
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ce.Stop()) }()
+	ce.SetAdmission(true, nil)
+
+	inode, fixedOffset, version := uint64(1), uint64(1024), uint32(112358796)
+	read := func(vol string) error {
+		_, err := ce.GetCacheBlockForRead(vol, inode, fixedOffset, version, 0)
+		return err
+	}
+	create := func(vol string) error {
+		_, err := ce.createCacheBlock(vol, inode, fixedOffset, version, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+		return err
+	}
+	hot, cold := t.Name()+"_hot", t.Name()+"_cold"
+	for _, vol := range []string{hot, cold} {
+		require.Error(t, read(vol))
+		require.NoError(t, create(vol))
+	}
+	require.NoError(t, read(cold))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, read(hot))
+	}
+
+	// the cache is full, a block read once does not replace the cold victim
+	scan := t.Name() + "_scan"
+	require.Error(t, read(scan))
+	require.ErrorIs(t, create(scan), proto.ErrFlashNodeNotAdmitted)
+	require.NoError(t, read(hot))
+	require.NoError(t, read(cold))
+
+	ce.SetAdmission(true, []string{scan})
+	require.NoError(t, create(scan))
+	require.NoError(t, read(scan))
+
+	st := ce.GetAdmissionStat()
+	require.True(t, st.Enable)
+	require.Equal(t, uint64(1), st.Rejected)
+	require.Equal(t, uint64(1), st.AlwaysAdmitted)
+	require.Equal(t, []string{scan}, st.AlwaysAdmitVols)
+
+	ce.SetAdmission(false, nil)
+	require.False(t, ce.GetAdmissionStat().Enable)
+}
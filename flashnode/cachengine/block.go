@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash/crc32"
+	"io"
 	"os"
 	"path"
 	"strings"
@@ -36,6 +37,10 @@ import (
 const (
 	_cacheBlockOpenOpt = os.O_CREATE | os.O_RDWR
 	HeaderSize         = 40
+
+	// set in the first reserved field of the header along with the crc of the data,
+	// the blocks written before have none
+	checksumFlag = uint64(1) << 32
 )
 
 type CacheBlock struct {
@@ -63,6 +68,13 @@ type CacheBlock struct {
 	closeCh   chan struct{}
 	clientIP  string
 	disk      *Disk
+
+	// reads served by the disk since the block was demoted from the hot tier
+	diskReads int32
+	// reads served by the block since it was cached
+	hits uint64
+	// the crc of the data with checksumFlag, kept in the header once the block is ready
+	checksum uint64
 }
 
 // NewCacheBlock create and returns a new extent instance.
@@ -103,6 +115,8 @@ func (cb *CacheBlock) Close() (err error) {
 
 func (cb *CacheBlock) Delete(reason string) (err error) {
 	_ = cb.Close()
+	cb.cacheEngine.hotTier.remove(cb.blockKey)
+	cb.cacheEngine.blockVersions.remove(cb.volume, cb.inode, cb.fixedOffset, cb.version)
 	if cb.Exist() {
 		err = os.Remove(cb.filePath)
 		auditlog.LogFlashNodeOp("BlockDelete", fmt.Sprintf("delete block %v, by :%v",
@@ -181,7 +195,18 @@ func (cb *CacheBlock) Read(ctx context.Context, data []byte, offset, size int64,
 	if err = cb.ready(ctx, waitForBlock); err != nil {
 		return
 	}
+	if offset >= cb.getAllocSize() || offset > cb.getUsedSize() || cb.getUsedSize() == 0 {
+		return 0, fmt.Errorf("invalid read, offset:%d, allocSize:%d, usedSize:%d", offset, cb.getAllocSize(), cb.getUsedSize())
+	}
+	realSize := cb.getUsedSize() - offset
+	if realSize >= size {
+		realSize = size
+	}
 	bgTime := stat.BeginStat()
+	if cb.cacheEngine.hotTier.read(cb.blockKey, data, offset, realSize) {
+		stat.EndStat("HitCacheRead:ReadFromMemory", nil, bgTime, 1)
+		return crc32.ChecksumIEEE(data), nil
+	}
 	defer func() {
 		if err != nil {
 			if IsDiskErr(err.Error()) {
@@ -192,13 +217,6 @@ func (cb *CacheBlock) Read(ctx context.Context, data []byte, offset, size int64,
 		stat.EndStat("HitCacheRead:ReadFromDisk", err, bgTime, 1)
 	}()
 
-	if offset >= cb.getAllocSize() || offset > cb.getUsedSize() || cb.getUsedSize() == 0 {
-		return 0, fmt.Errorf("invalid read, offset:%d, allocSize:%d, usedSize:%d", offset, cb.getAllocSize(), cb.getUsedSize())
-	}
-	realSize := cb.getUsedSize() - offset
-	if realSize >= size {
-		realSize = size
-	}
 	log.LogDebugf("action[Read] read cache block:%v, offset:%d, allocSize:%d, usedSize:%d", cb.blockKey, offset, cb.allocSize, cb.usedSize)
 
 	if file, err = cb.GetOrOpenFileHandler(); err != nil {
@@ -210,6 +228,7 @@ func (cb *CacheBlock) Read(ctx context.Context, data []byte, offset, size int64,
 		return
 	}
 	crc = crc32.ChecksumIEEE(data)
+	cb.cacheEngine.maybePromote(cb)
 	return
 }
 
@@ -217,9 +236,16 @@ func (cb *CacheBlock) writeCacheBlockFileHeader(file *os.File) (err error) {
 	if _, err = file.Seek(0, 0); err != nil {
 		return
 	}
-	// add two reserverd
+	// add two reserverd, the first one keeps the crc of the data
 	var reserved uint64 = 0
-	if err = binary.Write(file, binary.BigEndian, reserved); err != nil {
+	if cb.getUsedSize() != 0 {
+		var crc uint32
+		if crc, err = cb.dataChecksum(file); err != nil {
+			return
+		}
+		cb.checksum = checksumFlag | uint64(crc)
+	}
+	if err = binary.Write(file, binary.BigEndian, cb.checksum); err != nil {
 		return
 	}
 	if err = binary.Write(file, binary.BigEndian, reserved); err != nil {
@@ -248,7 +274,16 @@ func (cb *CacheBlock) writeCacheBlockFileHeader(file *os.File) (err error) {
 	return
 }
 
-func (cb *CacheBlock) checkCacheBlockFileHeader(file *os.File) (allocSize, usedSize int64, expiredTime time.Time, err error) {
+// dataChecksum computes the crc of the data written to the block file.
+func (cb *CacheBlock) dataChecksum(file *os.File) (crc uint32, err error) {
+	h := crc32.NewIEEE()
+	if _, err = io.Copy(h, io.NewSectionReader(file, HeaderSize, cb.getUsedSize())); err != nil {
+		return
+	}
+	return h.Sum32(), nil
+}
+
+func (cb *CacheBlock) checkCacheBlockFileHeader(file *os.File) (allocSize, usedSize int64, checksum uint64, expiredTime time.Time, err error) {
 	var stat os.FileInfo
 	var seconds int64
 	var reserved1, reserved2 uint64
@@ -259,6 +294,7 @@ func (cb *CacheBlock) checkCacheBlockFileHeader(file *os.File) (allocSize, usedS
 	if err = binary.Read(file, binary.BigEndian, &reserved1); err != nil {
 		return
 	}
+	checksum = reserved1
 	if err = binary.Read(file, binary.BigEndian, &reserved2); err != nil {
 		return
 	}
@@ -346,7 +382,7 @@ func (cb *CacheBlock) initFilePath(isLoad bool) (err error) {
 	} else {
 		var allocSize, usedSize int64
 		var expiredTime time.Time
-		if allocSize, usedSize, expiredTime, err = cb.checkCacheBlockFileHeader(file); err != nil {
+		if allocSize, usedSize, cb.checksum, expiredTime, err = cb.checkCacheBlockFileHeader(file); err != nil {
 			file.Close()
 			return fmt.Errorf("initFilePath check file header failed: %s", err.Error())
 		}
@@ -468,7 +504,7 @@ func (cb *CacheBlock) prepareSource(ctx context.Context, sourceCh <-chan *proto.
 			if log.EnableDebug() {
 				log.LogDebugf("%s start", logPrefix())
 			}
-			if _, err = cb.sourceReader(source, writeCacheAfterRead, readDataNodeTimeout, cb.volume, cb.inode, cb.clientIP); err != nil {
+			if err = cb.readSource(source, writeCacheAfterRead, readDataNodeTimeout); err != nil {
 				log.LogErrorf("%s err:%v", logPrefix(), err)
 				return
 			}
@@ -613,7 +649,7 @@ func (cb *CacheBlock) InitForCacheRead(sources []*proto.DataSource, readDataNode
 		if log.EnableDebug() {
 			log.LogDebugf("%s start", logPrefix())
 		}
-		if _, err = cb.sourceReader(s, writeCacheAfterRead, readDataNodeTimeout, cb.volume, cb.inode, cb.clientIP); err != nil {
+		if err = cb.readSource(s, writeCacheAfterRead, readDataNodeTimeout); err != nil {
 			log.LogErrorf("%s err:%v", logPrefix(), err)
 			break
 		}
@@ -643,6 +679,32 @@ func (cb *CacheBlock) InitForCacheRead(sources []*proto.DataSource, readDataNode
 	cb.notifyReady()
 }
 
+// readSource reads the data of the source into the block. The flash group peers are tried
+// first if the engine has a peer reader, then the datanode.
+func (cb *CacheBlock) readSource(source *proto.DataSource, afterReadFunc ReadExtentAfter, readDataNodeTimeout int) (err error) {
+	if cb.cacheEngine.readPeerFunc != nil {
+		data, peerErr := cb.cacheEngine.readPeerFunc(source, cb.volume, cb.inode, cb.fixedOffset, cb.version)
+		if peerErr == nil {
+			return afterReadFunc(data, int64(len(data)))
+		}
+		if log.EnableDebug() {
+			log.LogDebugf("action[readSource] block(%s) source:%s read from peers err:%v", cb.blockKey, source.String(), peerErr)
+		}
+	}
+	_, err = cb.sourceReader(source, afterReadFunc, readDataNodeTimeout, cb.volume, cb.inode, cb.clientIP)
+	return
+}
+
+// Covers reports whether the block is ready and holds the data of [offset, offset+size).
+func (cb *CacheBlock) Covers(offset, size int64) bool {
+	select {
+	case <-cb.readyCh:
+	default:
+		return false
+	}
+	return offset >= 0 && size > 0 && offset+size <= cb.getUsedSize()
+}
+
 func (cb *CacheBlock) info() string {
 	return fmt.Sprintf("path(%v)_from(%v)_size(%v)", cb.filePath, cb.clientIP, cb.allocSize)
 }
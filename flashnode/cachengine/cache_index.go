@@ -0,0 +1,199 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	CacheIndexFileName            = "cache.index"
+	DefaultCacheIndexSaveInterval = 5 * time.Minute
+)
+
+// The cache index of a disk keeps the ready blocks cached on it, saved every
+// DefaultCacheIndexSaveInterval and on stop. On start, a block in the index
+// whose file was not written since the index was saved is loaded from it, with
+// its hits, without reading its header. The others are loaded from their
+// headers as without the index, and those invalid or expired are deleted.
+
+type cacheIndexEntry struct {
+	Volume    string `json:"vol"`
+	Inode     uint64 `json:"ino"`
+	Offset    uint64 `json:"off"`
+	Version   uint32 `json:"ver"`
+	AllocSize int64  `json:"alloc"`
+	UsedSize  int64  `json:"used"`
+	Checksum  uint64 `json:"crc"`
+	ExpiredAt int64  `json:"expired"`
+	Hits      uint64 `json:"hits"`
+}
+
+type cacheIndex struct {
+	SavedAt int64              `json:"savedAt"` // unix nanoseconds
+	Blocks  []*cacheIndexEntry `json:"blocks"`
+}
+
+// the counts of the blocks loaded on start
+type cacheRecoveryCount struct {
+	blocks  int64
+	bytes   int64
+	indexed int64
+	dropped int64
+}
+
+func (cb *CacheBlock) isReady() bool {
+	return cb.ready(context.Background(), false) == nil
+}
+
+// loadFromIndex sets the block ready as it was when the index was saved.
+func (cb *CacheBlock) loadFromIndex(entry *cacheIndexEntry) {
+	cb.updateAllocSize(entry.AllocSize)
+	cb.maybeUpdateUsedSize(entry.UsedSize)
+	cb.checksum = entry.Checksum
+	cb.ttl = entry.ExpiredAt - time.Now().Unix()
+	atomic.StoreUint64(&cb.hits, entry.Hits)
+	cb.notifyReady()
+}
+
+// validIndexEntry returns the entry of the block file if the file was not
+// written since the index was saved and the block is not expired.
+func validIndexEntry(index *cacheIndex, entries map[string]*cacheIndexEntry, file *cacheLoadFile) *cacheIndexEntry {
+	if index == nil {
+		return nil
+	}
+	entry, ok := entries[path.Join(file.volume, file.fileName)]
+	if !ok || entry.ExpiredAt <= time.Now().Unix() {
+		return nil
+	}
+	info, err := os.Stat(path.Join(file.fullPath, file.fileName))
+	if err != nil || info.Size() != HeaderSize+entry.UsedSize || info.ModTime().UnixNano() > index.SavedAt {
+		return nil
+	}
+	return entry
+}
+
+// loadCacheIndex returns the index saved in the data path, nil if there is none
+// or it is not readable.
+func loadCacheIndex(dataPath string) (index *cacheIndex, entries map[string]*cacheIndexEntry) {
+	data, err := os.ReadFile(path.Join(dataPath, CacheIndexFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.LogWarnf("action[loadCacheIndex] dataPath(%v) read index failed, err:%v", dataPath, err)
+		}
+		return nil, nil
+	}
+	index = new(cacheIndex)
+	if err = json.Unmarshal(data, index); err != nil {
+		log.LogWarnf("action[loadCacheIndex] dataPath(%v) unmarshal index failed, err:%v", dataPath, err)
+		return nil, nil
+	}
+	entries = make(map[string]*cacheIndexEntry, len(index.Blocks))
+	for _, entry := range index.Blocks {
+		entries[GenCacheBlockKey(entry.Volume, entry.Inode, entry.Offset, entry.Version)] = entry
+	}
+	log.LogInfof("action[loadCacheIndex] dataPath(%v) %v blocks in the index saved at %v",
+		dataPath, len(index.Blocks), time.Unix(0, index.SavedAt).Format(proto.TimeFormat))
+	return
+}
+
+// saveCacheIndex saves the ready blocks of the disk, it replaces the index
+// saved before only once written.
+func (c *CacheEngine) saveCacheIndex(cacheItem *lruCacheItem) (count int, err error) {
+	index := &cacheIndex{SavedAt: time.Now().UnixNano()}
+	cacheItem.lruCache.Range(func(_, v interface{}, _, expiredAt time.Time) bool {
+		cb := v.(*CacheBlock)
+		if usedSize := cb.getUsedSize(); usedSize > 0 && cb.isReady() {
+			index.Blocks = append(index.Blocks, &cacheIndexEntry{
+				Volume:    cb.volume,
+				Inode:     cb.inode,
+				Offset:    cb.fixedOffset,
+				Version:   cb.version,
+				AllocSize: cb.getAllocSize(),
+				UsedSize:  usedSize,
+				Checksum:  cb.checksum,
+				ExpiredAt: expiredAt.Unix(),
+				Hits:      atomic.LoadUint64(&cb.hits),
+			})
+		}
+		return true
+	})
+	data, err := json.Marshal(index)
+	if err != nil {
+		return
+	}
+	indexPath := path.Join(cacheItem.config.Path, CacheIndexFileName)
+	tmpPath := indexPath + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return
+	}
+	if err = os.Rename(tmpPath, indexPath); err != nil {
+		return
+	}
+	return len(index.Blocks), nil
+}
+
+// SaveCacheIndex saves the index of the available disks.
+func (c *CacheEngine) SaveCacheIndex() {
+	if c.enableTmpfs {
+		return
+	}
+	c.lruCacheMap.Range(func(_, value interface{}) bool {
+		cacheItem := value.(*lruCacheItem)
+		if atomic.LoadInt32(&cacheItem.disk.Status) == proto.Unavailable {
+			return true
+		}
+		begin := time.Now()
+		count, err := c.saveCacheIndex(cacheItem)
+		if err != nil {
+			log.LogErrorf("action[SaveCacheIndex] dataPath(%v) err:%v", cacheItem.config.Path, err)
+			return true
+		}
+		log.LogInfof("action[SaveCacheIndex] dataPath(%v) saved %v blocks cost(%v)", cacheItem.config.Path, count, time.Since(begin))
+		return true
+	})
+}
+
+func (c *CacheEngine) startCacheIndexSaver(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.closeCh:
+				return
+			case <-ticker.C:
+				c.SaveCacheIndex()
+			}
+		}
+	}()
+}
+
+// GetCacheRecovery returns the cache recovered from the disks on start, nil
+// with the cache in memory or before the recovery ends.
+func (c *CacheEngine) GetCacheRecovery() *proto.FlashNodeCacheRecovery {
+	if v := c.recovery.Load(); v != nil {
+		return v.(*proto.FlashNodeCacheRecovery)
+	}
+	return nil
+}
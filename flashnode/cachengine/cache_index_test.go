@@ -0,0 +1,116 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"context"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineCacheIndex(t *testing.T) {
+	if enabledTmpfs() {
+		t.Skip("the cache in memory is not kept across restarts")
+	}
+	diskPath := t.TempDir()
+	newEngine := func() *CacheEngine {
+		disk := &Disk{Path: diskPath, TotalSpace: 200 * util.MB, Capacity: 1024, Status: proto.ReadWrite}
+		ce, err := NewCacheEngine("", 0, DefaultCacheMaxUsedRatio, []*Disk{disk}, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, false, "")
+		require.NoError(t, err)
+		require.NoError(t, ce.Start())
+		return ce
+	}
+
+	ce := newEngine()
+	recovery := ce.GetCacheRecovery()
+	require.NotNil(t, recovery)
+	require.Zero(t, recovery.Blocks)
+
+	blocks := make([]*CacheBlock, 0, 5)
+	for inode := uint64(1); inode <= 5; inode++ {
+		cb, err := ce.createCacheBlock(t.Name(), inode, 0, 1, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+		require.NoError(t, err)
+		require.NoError(t, cb.WriteAt(randTestData(4096), 0, 4096))
+		file, err := cb.GetOrOpenFileHandler()
+		require.NoError(t, err)
+		require.NoError(t, cb.writeCacheBlockFileHeader(file))
+		cb.notifyReady()
+		atomic.StoreUint64(&cb.hits, inode)
+		blocks = append(blocks, cb)
+	}
+	filePaths := make([]string, 0, len(blocks))
+	for _, cb := range blocks {
+		filePaths = append(filePaths, cb.filePath)
+	}
+	// not ready, left out of the index but loaded from its header
+	filling, err := ce.createCacheBlock(t.Name(), 6, 0, 1, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+	require.NoError(t, err)
+	require.NoError(t, filling.WriteAt(randTestData(4096), 0, 4096))
+	file, err := filling.GetOrOpenFileHandler()
+	require.NoError(t, err)
+	require.NoError(t, filling.writeCacheBlockFileHeader(file))
+	require.NoError(t, ce.Stop())
+
+	index, entries := loadCacheIndex(path.Join(diskPath, DefaultCacheDirName))
+	require.NotNil(t, index)
+	require.Len(t, entries, 5)
+	require.Equal(t, uint64(3), entries[blocks[2].blockKey].Hits)
+
+	// written since saved, loaded from its header
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(filePaths[1], later, later))
+	// removed
+	require.NoError(t, os.Remove(filePaths[2]))
+	// truncated, dropped
+	require.NoError(t, os.Truncate(filePaths[3], 10))
+
+	ce = newEngine()
+	defer func() { require.NoError(t, ce.Stop()) }()
+	recovery = ce.GetCacheRecovery()
+	require.NotNil(t, recovery)
+	require.Equal(t, int64(4), recovery.Blocks)
+	require.Equal(t, int64(4*4096), recovery.Bytes)
+	require.Equal(t, int64(2), recovery.Indexed)
+	require.Equal(t, int64(1), recovery.Dropped)
+
+	for i, inode := range []uint64{1, 2, 5, 6} {
+		cb, err := ce.PeekCacheBlock(GenCacheBlockKey(t.Name(), inode, 0, 1))
+		require.NoError(t, err, "inode %v", inode)
+		require.Equal(t, int64(4096), cb.getUsedSize())
+		require.True(t, cb.isReady())
+		if i == 0 || i == 2 {
+			require.Equal(t, inode, atomic.LoadUint64(&cb.hits))
+			require.Equal(t, blocks[inode-1].checksum, cb.checksum)
+		} else {
+			require.Zero(t, atomic.LoadUint64(&cb.hits))
+		}
+		data := make([]byte, 4096)
+		_, err = cb.Read(context.Background(), data, 0, 4096, false)
+		require.NoError(t, err)
+	}
+	for _, inode := range []uint64{3, 4} {
+		_, err = ce.PeekCacheBlock(GenCacheBlockKey(t.Name(), inode, 0, 1))
+		require.Error(t, err)
+	}
+	_, err = os.Stat(filePaths[3])
+	require.True(t, os.IsNotExist(err))
+}
@@ -22,6 +22,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -118,6 +119,7 @@ type CacheEngine struct {
 	lruCacheMap           sync.Map
 	lruFhCache            LruCache
 	readSourceFunc        ReadExtentData
+	readPeerFunc          ReadPeerData
 
 	closeOnce sync.Once
 	closeCh   chan struct{}
@@ -126,11 +128,43 @@ type CacheEngine struct {
 	localAddr   string
 
 	readDataNodeTimeout int
+
+	// pushed by master heartbeat, keep the blocks accessed once from evicting the hot ones
+	admission       *admissionFilter
+	admissionEnable int32
+	alwaysAdmitVols atomic.Value // map[string]struct{}
+	admitted        uint64
+	rejected        uint64
+	alwaysAdmitted  uint64
+
+	// the blocks of the disks read the most, nil if disabled or with tmpfs
+	hotTier *hotTier
+	// the metadata put by the clients, nil if disabled
+	metaCache *metaCache
+	// the versions cached of each block, evicted together on an overwrite
+	blockVersions blockVersions
+
+	evictPolicy atomic.Value // *evictPolicyConfig
+
+	// the blocks verified by the scrubber and the corrupt ones evicted
+	scrubbed       uint64
+	scrubCorrupted uint64
+
+	// the cache loaded from the disks on start, reported in the heartbeats
+	recoveryCount cacheRecoveryCount
+	recovery      atomic.Value // *proto.FlashNodeCacheRecovery
+}
+
+type evictPolicyConfig struct {
+	policy      string
+	volPolicies map[string]string
 }
 
 type (
 	ReadExtentAfter func([]byte, int64) error
 	ReadExtentData  func(source *proto.DataSource, afterReadFunc ReadExtentAfter, timeout int, volume string, ino uint64, clientIP string) (n int, err error)
+	// ReadPeerData reads the whole data of the source from the cache block held by another flash node.
+	ReadPeerData func(source *proto.DataSource, volume string, ino, fixedOffset uint64, version uint32) (data []byte, err error)
 )
 
 func NewCacheEngine(memDataDir string, totalMemSize int64, maxUseRatio float64, disks []*Disk,
@@ -173,6 +207,7 @@ func NewCacheEngine(memDataDir string, totalMemSize int64, maxUseRatio float64,
 			})
 		s.lruCacheMap.Store(fullPath, &lruCacheItem{lruCache: cache, config: memCacheConfig, disk: disks[0]})
 		s.totalCacheNum = 1
+		s.admission = newAdmissionFilter(memCacheConfig.Capacity)
 		s.lruFhCache = NewCache(LRUFileHandleCacheType, fhCapacity, -1, expireTime,
 			func(v interface{}, reason string) error {
 				file := v.(*os.File)
@@ -238,6 +273,11 @@ func NewCacheEngine(memDataDir string, totalMemSize int64, maxUseRatio float64,
 			}
 		}
 	}
+	totalCapacity := 0
+	for _, d := range disks {
+		totalCapacity += d.Capacity
+	}
+	s.admission = newAdmissionFilter(totalCapacity)
 	s.lruFhCache = NewCache(LRUFileHandleCacheType, fhCapacity, -1, expireTime,
 		func(v interface{}, reason string) error {
 			file := v.(*os.File)
@@ -277,6 +317,7 @@ func unmarshalCacheBlockName(name string) (inode uint64, offset uint64, version
 
 func (c *CacheEngine) LoadCacheBlock() (err error) {
 	var wg sync.WaitGroup
+	begin := time.Now()
 	loadDiskErrors := make([]error, 0)
 	c.lruCacheMap.Range(func(key, value interface{}) bool {
 		dataPath := key.(string)
@@ -291,6 +332,14 @@ func (c *CacheEngine) LoadCacheBlock() (err error) {
 		return true
 	})
 	wg.Wait()
+	c.recovery.Store(&proto.FlashNodeCacheRecovery{
+		Time:    time.Now().Unix(),
+		CostMs:  time.Since(begin).Milliseconds(),
+		Blocks:  atomic.LoadInt64(&c.recoveryCount.blocks),
+		Bytes:   atomic.LoadInt64(&c.recoveryCount.bytes),
+		Indexed: atomic.LoadInt64(&c.recoveryCount.indexed),
+		Dropped: atomic.LoadInt64(&c.recoveryCount.dropped),
+	})
 	if len(loadDiskErrors) != 0 {
 		sb := strings.Builder{}
 		for index, loadDiskErr := range loadDiskErrors {
@@ -314,13 +363,14 @@ func (c *CacheEngine) LoadDisk(diskPath string) (err error) {
 		syslog.Print(msg)
 		log.LogInfo(msg)
 	}()
+	index, indexEntries := loadCacheIndex(diskPath)
 	filePathChan := make(chan cacheLoadFile, 1024)
 	for i := 0; i < c.cacheLoadWorkerNum; i++ {
 		fileLoadWg.Add(1)
 		go func() {
 			defer fileLoadWg.Done()
 			for fileInfo := range filePathChan {
-				c.handlerFile(&fileInfo, &cbNum, &errorCbNum)
+				c.handlerFile(&fileInfo, validIndexEntry(index, indexEntries, &fileInfo), &cbNum, &errorCbNum)
 			}
 		}()
 	}
@@ -363,6 +413,9 @@ func (c *CacheEngine) LoadDisk(diskPath string) (err error) {
 		return
 	}
 	for _, volEntry := range entries {
+		if !volEntry.IsDir() {
+			continue
+		}
 		cacheLoadTaskCh <- cacheLoadTask{volume: volEntry.Name(), dataPath: diskPath}
 	}
 	close(cacheLoadTaskCh)
@@ -372,7 +425,7 @@ func (c *CacheEngine) LoadDisk(diskPath string) (err error) {
 	return
 }
 
-func (c *CacheEngine) handlerFile(file *cacheLoadFile, cbNum *atomicutil.Int64, errorCbNum *atomicutil.Int64) {
+func (c *CacheEngine) handlerFile(file *cacheLoadFile, indexed *cacheIndexEntry, cbNum *atomicutil.Int64, errorCbNum *atomicutil.Int64) {
 	inode, offset, version, err := unmarshalCacheBlockName(file.fileName)
 	if err != nil {
 		log.LogErrorf("action[LoadDisk] unmarshal cacheBlockName(%v) from dataPath(%v) volume(%v) err(%v) ",
@@ -382,14 +435,21 @@ func (c *CacheEngine) handlerFile(file *cacheLoadFile, cbNum *atomicutil.Int64,
 	log.LogDebugf("acton[LoadDisk] dataPath(%v) cacheBlockName(%v) volume(%v) inode(%v) offset(%v) version(%v).",
 		file.fullPath, file.fileName, file.volume, inode, offset, version)
 
-	if _, err := c.createCacheBlockFromExist(file.dataPath, file.volume, inode, offset, version, 0, ""); err != nil {
+	block, err := c.createCacheBlockFromExist(file.dataPath, file.volume, inode, offset, version, 0, "", indexed)
+	if err != nil {
 		c.deleteCacheBlock(GenCacheBlockKey(file.volume, inode, offset, version))
 		log.LogInfof("action[LoadDisk] createCacheBlock(%v) from dataPath(%v) volume(%v) err(%v) ",
 			file.fileName, file.fullPath, file.volume, err.Error())
 		errorCbNum.Add(1)
+		atomic.AddInt64(&c.recoveryCount.dropped, 1)
 		return
 	}
 	cbNum.Add(1)
+	atomic.AddInt64(&c.recoveryCount.blocks, 1)
+	atomic.AddInt64(&c.recoveryCount.bytes, block.getUsedSize())
+	if indexed != nil {
+		atomic.AddInt64(&c.recoveryCount.indexed, 1)
+	}
 }
 
 func (c *CacheEngine) Start() (err error) {
@@ -398,6 +458,7 @@ func (c *CacheEngine) Start() (err error) {
 			log.LogErrorf("CacheEngine started failed, err[%v]", err)
 			return
 		}
+		c.startCacheIndexSaver(DefaultCacheIndexSaveInterval)
 	}
 	log.LogInfof("CacheEngine started.")
 	return
@@ -405,6 +466,7 @@ func (c *CacheEngine) Start() (err error) {
 
 func (c *CacheEngine) Stop() (err error) {
 	c.closeOnce.Do(func() { close(c.closeCh) })
+	c.SaveCacheIndex()
 	var wg sync.WaitGroup
 	c.lruCacheMap.Range(func(key, value interface{}) bool {
 		wg.Add(1)
@@ -420,6 +482,7 @@ func (c *CacheEngine) Stop() (err error) {
 		return true
 	})
 	wg.Wait()
+	c.hotTier.clear()
 	if err != nil {
 		return err
 	}
@@ -508,6 +571,7 @@ func (c *CacheEngine) deleteCacheBlock(key string) {
 
 func (c *CacheEngine) GetCacheBlockForRead(volume string, inode, offset uint64, version uint32, size uint64) (block *CacheBlock, err error) {
 	key := GenCacheBlockKey(volume, inode, offset, version)
+	c.recordAccess(key)
 	v, ok := c.keyToDiskMap.Load(key)
 	if ok {
 		cacheItem := v.(*lruCacheItem)
@@ -515,6 +579,7 @@ func (c *CacheEngine) GetCacheBlockForRead(volume string, inode, offset uint64,
 			blockValue, getErr := cacheItem.lruCache.Get(key)
 			if getErr == nil {
 				block = blockValue.(*CacheBlock)
+				atomic.AddUint64(&block.hits, 1)
 				return
 			}
 			return nil, errors.NewErrorf("cache item(%v) get cache block failed:%v", cacheItem.config.Path, getErr)
@@ -574,7 +639,9 @@ func (c *CacheEngine) selectAvailableLruCache() (cacheItem *lruCacheItem, err er
 	return nil, errors.NewErrorf("no available disk can select")
 }
 
-func (c *CacheEngine) createCacheBlockFromExist(dataPath string, volume string, inode, fixedOffset uint64, version uint32, allocSize uint64, clientIP string) (block *CacheBlock, err error) {
+func (c *CacheEngine) createCacheBlockFromExist(dataPath string, volume string, inode, fixedOffset uint64, version uint32, allocSize uint64, clientIP string,
+	indexed *cacheIndexEntry,
+) (block *CacheBlock, err error) {
 	key := GenCacheBlockKey(volume, inode, fixedOffset, version)
 	v, ok := c.keyToDiskMap.Load(key)
 	if ok {
@@ -604,7 +671,9 @@ func (c *CacheEngine) createCacheBlockFromExist(dataPath string, volume string,
 		}
 	}()
 
-	if err = block.initFilePath(true); err != nil {
+	if indexed != nil {
+		block.loadFromIndex(indexed)
+	} else if err = block.initFilePath(true); err != nil {
 		return
 	}
 
@@ -612,6 +681,7 @@ func (c *CacheEngine) createCacheBlockFromExist(dataPath string, volume string,
 		return
 	}
 	c.keyToDiskMap.Store(key, cacheItem)
+	c.blockVersions.add(volume, inode, fixedOffset, version)
 
 	return
 }
@@ -665,8 +735,17 @@ func (c *CacheEngine) createCacheBlock(volume string, inode, fixedOffset uint64,
 		}
 	}
 
+	if isPrepare {
+		c.recordAccess(key)
+	}
 	var cacheItem *lruCacheItem
 	if cacheItem, err = c.selectAvailableLruCache(); err == nil {
+		if !c.admit(cacheItem, volume, key, int64(allocSize)) {
+			if !isPrepare {
+				cacheItem.lruCache.AddMisses()
+			}
+			return nil, proto.ErrFlashNodeNotAdmitted
+		}
 		block = NewCacheBlock(cacheItem.config.Path, volume, inode, fixedOffset, version, allocSize,
 			c.readSourceFunc, clientIP, cacheItem.disk)
 		if ttl <= 0 {
@@ -694,6 +773,7 @@ func (c *CacheEngine) createCacheBlock(volume string, inode, fixedOffset uint64,
 			cacheItem.lruCache.AddMisses()
 		}
 		c.keyToDiskMap.Store(key, cacheItem)
+		c.blockVersions.add(volume, inode, fixedOffset, version)
 	}
 
 	return
@@ -744,7 +824,9 @@ func (c *CacheEngine) StartCachePrepareWorkers(flw *util.IoLimiter, prepareWorke
 							log.LogDebugf("action[startCachePrepareWorkers] start cache key(%v)", bk)
 						}
 						if _, err = c.CreateBlock(r, task.clientIP, true); err != nil {
-							log.LogWarnf("action[startCachePrepareWorkers] ReqID(%d) create block failed, err:%v", task.reqID, err)
+							if err != proto.ErrFlashNodeNotAdmitted {
+								log.LogWarnf("action[startCachePrepareWorkers] ReqID(%d) create block failed, err:%v", task.reqID, err)
+							}
 							return
 						}
 						var block *CacheBlock
@@ -778,6 +860,9 @@ func (c *CacheEngine) CreateBlock(req *proto.CacheRequest, clientIP string, isPr
 		return nil, fmt.Errorf("no source data")
 	}
 	if block, err = c.createCacheBlock(req.Volume, req.Inode, req.FixedFileOffset, req.Version, req.TTL, computeAllocSize(req.Sources), clientIP, isPrepare); err != nil {
+		if err == proto.ErrFlashNodeNotAdmitted {
+			return nil, err
+		}
 		log.LogWarnf("action[CreateBlock] createCacheBlock(%v) failed err %v ",
 			GenCacheBlockKey(req.Volume, req.Inode, req.FixedFileOffset, req.Version), err)
 		c.deleteCacheBlock(GenCacheBlockKey(req.Volume, req.Inode, req.FixedFileOffset, req.Version))
@@ -842,6 +927,68 @@ func (c *CacheEngine) StatusAll() []*proto.CacheStatus {
 	return statSet
 }
 
+// ListCacheKeys lists up to limit of the cached blocks of the volume, or of all
+// the volumes if it is empty, ordered by volume, inode and offset, or the most
+// read first if hot. The prefix of the keys in the volume, e.g. "<inode>#",
+// only works with the volume.
+func (c *CacheEngine) ListCacheKeys(volume, prefix string, hot bool, limit int) *proto.FlashNodeCacheKeys {
+	now := time.Now()
+	keyPrefix := cacheKeyPrefix(volume, prefix)
+	keys := make([]*proto.FlashNodeCacheKey, 0)
+	c.lruCacheMap.Range(func(_, value interface{}) bool {
+		cacheItem := value.(*lruCacheItem)
+		cacheItem.lruCache.Range(func(_, v interface{}, createAt, expiredAt time.Time) bool {
+			cb := v.(*CacheBlock)
+			if volume != "" && (cb.volume != volume || !strings.HasPrefix(cb.blockKey, keyPrefix)) {
+				return true
+			}
+			key := &proto.FlashNodeCacheKey{
+				Key:       cb.blockKey,
+				Volume:    cb.volume,
+				Inode:     cb.inode,
+				Offset:    cb.fixedOffset,
+				Version:   cb.version,
+				Size:      cb.getUsedSize(),
+				AllocSize: cb.getAllocSize(),
+				DataPath:  cacheItem.config.Path,
+				AgeSec:    int64(now.Sub(createAt).Seconds()),
+				ExpireSec: int64(expiredAt.Sub(now).Seconds()),
+				Hits:      atomic.LoadUint64(&cb.hits),
+			}
+			select {
+			case <-cb.readyCh:
+				key.Ready = true
+			default:
+			}
+			keys = append(keys, key)
+			return true
+		})
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if hot && a.Hits != b.Hits {
+			return a.Hits > b.Hits
+		}
+		if a.Volume != b.Volume {
+			return a.Volume < b.Volume
+		}
+		if a.Inode != b.Inode {
+			return a.Inode < b.Inode
+		}
+		return a.Offset < b.Offset
+	})
+	result := &proto.FlashNodeCacheKeys{Total: len(keys), Limit: limit}
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	for _, key := range keys {
+		key.Hot = c.hotTier.contains(key.Key)
+	}
+	result.Keys = keys
+	return result
+}
+
 func (c *CacheEngine) EvictCacheByVolume(evictVol string) (failedKeys []interface{}) {
 	failedKeys = make([]interface{}, 0)
 	c.lruCacheMap.Range(func(key, value interface{}) bool {
@@ -864,6 +1011,36 @@ func (c *CacheEngine) EvictCacheByVolume(evictVol string) (failedKeys []interfac
 	return
 }
 
+func cacheKeyPrefix(volume, prefix string) string {
+	return volume + "/" + prefix
+}
+
+// PurgeCache evicts the cached blocks of the volume whose key in the volume
+// starts with the prefix, all of the volume if it is empty.
+func (c *CacheEngine) PurgeCache(volume, prefix string) *proto.FlashNodeCachePurge {
+	keyPrefix := cacheKeyPrefix(volume, prefix)
+	result := &proto.FlashNodeCachePurge{Failed: make([]string, 0)}
+	c.lruCacheMap.Range(func(_, value interface{}) bool {
+		cacheItem := value.(*lruCacheItem)
+		for _, k := range cacheItem.lruCache.Status().Keys {
+			key := k.(string)
+			if !strings.HasPrefix(key, keyPrefix) {
+				continue
+			}
+			if !cacheItem.lruCache.Evict(key) {
+				result.Failed = append(result.Failed, key)
+				continue
+			}
+			c.keyToDiskMap.Delete(key)
+			result.Purged++
+		}
+		return true
+	})
+	log.LogWarnf("action[PurgeCache] volume(%v) prefix(%v) purged(%v) failed(%v)",
+		volume, prefix, result.Purged, len(result.Failed))
+	return result
+}
+
 func (c *CacheEngine) EvictCacheAll() {
 	var wg sync.WaitGroup
 	c.lruCacheMap.Range(func(key, value interface{}) bool {
@@ -893,17 +1070,24 @@ func (c *CacheEngine) GetHeartBeatCacheStat() []*proto.CacheStatus {
 	statSet := make([]*proto.CacheStatus, 0)
 	c.lruCacheMap.Range(func(key, value interface{}) bool {
 		cacheItem := value.(*lruCacheItem)
+		rateStat := cacheItem.lruCache.GetRateStat()
 		stat := &proto.CacheStatus{
 			DataPath: cacheItem.config.Path,
 			Medium:   cacheItem.config.Medium,
 			Total:    cacheItem.config.Total,
 			MaxAlloc: cacheItem.config.MaxAlloc,
 			HasAlloc: cacheItem.lruCache.GetAllocated(),
-			HitRate:  math.Trunc(cacheItem.lruCache.GetRateStat().HitRate*1e4+0.5) * 1e-4,
-			Evicts:   int(cacheItem.lruCache.GetRateStat().Evicts),
+			HitRate:  math.Trunc(rateStat.HitRate*1e4+0.5) * 1e-4,
+			Hits:     int64(rateStat.Hits),
+			Misses:   int64(rateStat.Misses),
+			Evicts:   int(rateStat.Evicts),
 			Num:      cacheItem.lruCache.Len(),
 			Status:   int(atomic.LoadInt32(&cacheItem.disk.Status)),
 		}
+		// the hits of a minute start from 1 to keep the hit rate defined
+		if stat.Hits > 0 {
+			stat.Hits--
+		}
 		statSet = append(statSet, stat)
 		return true
 	})
@@ -930,6 +1114,31 @@ func (c *CacheEngine) GetEvictCount() map[string]int {
 	return result
 }
 
+// GetInsertCount returns the blocks cached by each disk in the last minute.
+func (c *CacheEngine) GetInsertCount() map[string]int {
+	result := make(map[string]int)
+	c.lruCacheMap.Range(func(key, value interface{}) bool {
+		cacheItem := value.(*lruCacheItem)
+		result[cacheItem.config.Path] = int(cacheItem.lruCache.GetRateStat().Inserts)
+		return true
+	})
+	return result
+}
+
+// GetDiskUsage returns the ratio of the space allocated by the blocks of each
+// disk to the space configured for caching.
+func (c *CacheEngine) GetDiskUsage() map[string]float64 {
+	result := make(map[string]float64)
+	c.lruCacheMap.Range(func(key, value interface{}) bool {
+		cacheItem := value.(*lruCacheItem)
+		if cacheItem.config.Total > 0 {
+			result[cacheItem.config.Path] = float64(cacheItem.lruCache.GetAllocated()) / float64(cacheItem.config.Total)
+		}
+		return true
+	})
+	return result
+}
+
 func (c *CacheEngine) GetCacheBytes() map[string]int64 {
 	result := make(map[string]int64)
 	c.lruCacheMap.Range(func(key, value interface{}) bool {
@@ -1028,6 +1237,12 @@ func (c *CacheEngine) triggerCacheError(key string, dataPath string) {
 	}
 }
 
+// SetReadPeerFunc sets the reader tried before the datanode when a cache block is missed,
+// it must be set before the engine starts.
+func (c *CacheEngine) SetReadPeerFunc(readFunc ReadPeerData) {
+	c.readPeerFunc = readFunc
+}
+
 func (c *CacheEngine) SetReadDataNodeTimeout(timeout int) {
 	if c.readDataNodeTimeout != timeout && timeout > 0 {
 		log.LogInfof("CacheEngine set readDataNodeTimeout from %d(ms) to %d(ms)", c.readDataNodeTimeout, timeout)
@@ -1038,3 +1253,82 @@ func (c *CacheEngine) SetReadDataNodeTimeout(timeout int) {
 func (c *CacheEngine) GetReadDataNodeTimeout() int {
 	return c.readDataNodeTimeout
 }
+
+// SetAdmission updates the admission filter settings pushed by the master heartbeat,
+// the blocks of alwaysAdmitVols bypass the filter.
+func (c *CacheEngine) SetAdmission(enable bool, alwaysAdmitVols []string) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	if old := atomic.SwapInt32(&c.admissionEnable, v); old != v {
+		log.LogInfof("CacheEngine set admissionEnable from %v to %v", old == 1, enable)
+	}
+	vols := make(map[string]struct{}, len(alwaysAdmitVols))
+	for _, vol := range alwaysAdmitVols {
+		vols[vol] = struct{}{}
+	}
+	c.alwaysAdmitVols.Store(vols)
+}
+
+func (c *CacheEngine) admissionEnabled() bool {
+	return c.admission != nil && atomic.LoadInt32(&c.admissionEnable) == 1
+}
+
+func (c *CacheEngine) isAlwaysAdmitVol(volume string) bool {
+	vols, _ := c.alwaysAdmitVols.Load().(map[string]struct{})
+	_, ok := vols[volume]
+	return ok
+}
+
+func (c *CacheEngine) recordAccess(key string) {
+	if c.admissionEnabled() || (c.admission != nil && c.anyTinyLFU()) {
+		c.admission.record(key)
+	}
+}
+
+// admit tells whether the block may be cached in the lru cache. The filter is only
+// consulted when caching the block evicts another one.
+func (c *CacheEngine) admit(cacheItem *lruCacheItem, volume, key string, allocSize int64) bool {
+	if !c.admissionEnabled() && (c.admission == nil || c.evictPolicyOf(volume) != EvictPolicyTinyLFU) {
+		return true
+	}
+	if cacheItem.lruCache.Len() < cacheItem.config.Capacity &&
+		cacheItem.lruCache.GetAllocated()+allocSize <= cacheItem.config.MaxAlloc {
+		return true
+	}
+	victim, ok := cacheItem.lruCache.Victim()
+	if !ok {
+		return true
+	}
+	if c.isAlwaysAdmitVol(volume) {
+		atomic.AddUint64(&c.alwaysAdmitted, 1)
+		return true
+	}
+	if c.admission.admit(key, victim.(string)) {
+		atomic.AddUint64(&c.admitted, 1)
+		return true
+	}
+	atomic.AddUint64(&c.rejected, 1)
+	if log.EnableDebug() {
+		log.LogDebugf("action[admit] block(%v) is less popular than victim(%v)", key, victim)
+	}
+	return false
+}
+
+// GetAdmissionStat returns the decisions made by the admission filter since the engine started.
+func (c *CacheEngine) GetAdmissionStat() *proto.FlashNodeAdmissionStat {
+	vols, _ := c.alwaysAdmitVols.Load().(map[string]struct{})
+	st := &proto.FlashNodeAdmissionStat{
+		Enable:          c.admissionEnabled(),
+		Admitted:        atomic.LoadUint64(&c.admitted),
+		Rejected:        atomic.LoadUint64(&c.rejected),
+		AlwaysAdmitted:  atomic.LoadUint64(&c.alwaysAdmitted),
+		AlwaysAdmitVols: make([]string, 0, len(vols)),
+	}
+	for vol := range vols {
+		st.AlwaysAdmitVols = append(st.AlwaysAdmitVols, vol)
+	}
+	sort.Strings(st.AlwaysAdmitVols)
+	return st
+}
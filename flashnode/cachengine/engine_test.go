@@ -248,3 +248,96 @@ func TestEngineLru(t *testing.T) {
 		t.Logf("%+v", status)
 	}
 }
+
+func TestEngineEvictCacheBlocks(t *testing.T) {
+	var ce *CacheEngine
+	var err error
+	if _, err = os.Stat(testTmpFS); err != nil {
+		require.Equal(t, true, os.IsNotExist(err.(*os.PathError)))
+		err = os.MkdirAll(testTmpFS, 0o755)
+		require.NoError(t, err)
+	}
+
+	disk := &Disk{Path: testTmpFS, TotalSpace: 200 * util.MB, Capacity: 1024, Status: proto.ReadWrite}
+	disks := []*Disk{disk}
+	if !enabledTmpfs() {
+		ce, err = NewCacheEngine("", 0, DefaultCacheMaxUsedRatio, disks, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	} else {
+		ce, err = NewCacheEngine(testTmpFS, 200*util.MB, DefaultCacheMaxUsedRatio, disks, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	}
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ce.Stop()) }()
+
+	inode := uint64(1)
+	// the same block cached at two generations of the inode, and the next block
+	blocks := []struct {
+		offset  uint64
+		version uint32
+	}{{0, 1}, {0, 2}, {proto.CACHE_BLOCK_SIZE, 1}}
+	for _, b := range blocks {
+		_, err = ce.createCacheBlock(t.Name(), inode, b.offset, b.version, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 0, ce.EvictCacheBlocks(t.Name(), inode+1, []uint64{0}))
+	require.Equal(t, 2, ce.EvictCacheBlocks(t.Name(), inode, []uint64{0}))
+	for _, b := range blocks[:2] {
+		_, err = ce.GetCacheBlockForRead(t.Name(), inode, b.offset, b.version, 0)
+		require.Error(t, err)
+	}
+	_, err = ce.GetCacheBlockForRead(t.Name(), inode, blocks[2].offset, blocks[2].version, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, ce.EvictCacheBlocks(t.Name(), inode, []uint64{0}))
+
+	// the versions of the blocks deleted otherwise are dropped too
+	cb, err := ce.GetCacheBlockForRead(t.Name(), inode, blocks[2].offset, blocks[2].version, 0)
+	require.NoError(t, err)
+	require.NoError(t, cb.Delete("test"))
+	require.Empty(t, ce.blockVersions.versions)
+}
+
+func TestEnginePurgeCache(t *testing.T) {
+	var ce *CacheEngine
+	var err error
+	if _, err = os.Stat(testTmpFS); err != nil {
+		require.Equal(t, true, os.IsNotExist(err.(*os.PathError)))
+		err = os.MkdirAll(testTmpFS, 0o755)
+		require.NoError(t, err)
+	}
+
+	disk := &Disk{Path: testTmpFS, TotalSpace: 200 * util.MB, Capacity: 1024, Status: proto.ReadWrite}
+	disks := []*Disk{disk}
+	if !enabledTmpfs() {
+		ce, err = NewCacheEngine("", 0, DefaultCacheMaxUsedRatio, disks, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	} else {
+		ce, err = NewCacheEngine(testTmpFS, 200*util.MB, DefaultCacheMaxUsedRatio, disks, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	}
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ce.Stop()) }()
+
+	// inode 1 has two blocks, inode 11 has one which "1" would match too
+	for _, b := range []struct{ inode, offset uint64 }{{1, 0}, {1, proto.CACHE_BLOCK_SIZE}, {11, 0}} {
+		_, err = ce.createCacheBlock(t.Name(), b.inode, b.offset, 0, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+		require.NoError(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err = ce.GetCacheBlockForRead(t.Name(), 11, 0, 0, 0)
+		require.NoError(t, err)
+	}
+
+	keys := ce.ListCacheKeys(t.Name(), "", true, 0)
+	require.Equal(t, 3, keys.Total)
+	require.Equal(t, uint64(11), keys.Keys[0].Inode)
+	require.Equal(t, uint64(2), keys.Keys[0].Hits)
+	require.Equal(t, 2, ce.ListCacheKeys(t.Name(), "1#", false, 0).Total)
+	require.Equal(t, 3, ce.ListCacheKeys(t.Name(), "1", false, 0).Total)
+
+	purged := ce.PurgeCache(t.Name(), "1#")
+	require.Equal(t, 2, purged.Purged)
+	require.Empty(t, purged.Failed)
+	keys = ce.ListCacheKeys(t.Name(), "", false, 0)
+	require.Equal(t, 1, keys.Total)
+	require.Equal(t, uint64(11), keys.Keys[0].Inode)
+	require.Equal(t, 0, ce.PurgeCache("no-such-volume", "").Purged)
+	require.Equal(t, 1, ce.PurgeCache(t.Name(), "").Purged)
+}
@@ -0,0 +1,388 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"container/list"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	EvictPolicyLRU  = "LRU"
+	EvictPolicyLFU  = "LFU"
+	EvictPolicyFIFO = "FIFO"
+	// TinyLFU evicts like LRU, but the blocks only replace the victim if the
+	// admission filter estimates they were accessed more often.
+	EvictPolicyTinyLFU = "TinyLFU"
+
+	DefaultEvictPolicy = EvictPolicyLRU
+)
+
+// EvictPolicy orders the entries of a cache to be evicted. It is called with the
+// lock of the cache held, Victim with the read lock only.
+type EvictPolicy interface {
+	Add(key interface{})
+	Access(key interface{})
+	Remove(key interface{})
+	// Victim returns the key to be evicted next.
+	Victim() (interface{}, bool)
+	Len() int
+}
+
+var (
+	evictPoliciesLock sync.RWMutex
+	evictPolicies     = map[string]func() EvictPolicy{
+		EvictPolicyLRU:     func() EvictPolicy { return newLRUPolicy(true) },
+		EvictPolicyFIFO:    func() EvictPolicy { return newLRUPolicy(false) },
+		EvictPolicyLFU:     func() EvictPolicy { return newLFUPolicy() },
+		EvictPolicyTinyLFU: func() EvictPolicy { return newLRUPolicy(true) },
+	}
+)
+
+// RegisterEvictPolicy makes a new eviction policy selectable by name.
+func RegisterEvictPolicy(name string, create func() EvictPolicy) {
+	evictPoliciesLock.Lock()
+	defer evictPoliciesLock.Unlock()
+	evictPolicies[name] = create
+}
+
+// EvictPolicyName returns the registered name of the policy, matched case
+// insensitively, the default one for an empty name.
+func EvictPolicyName(name string) (string, error) {
+	if name == "" {
+		return DefaultEvictPolicy, nil
+	}
+	evictPoliciesLock.RLock()
+	defer evictPoliciesLock.RUnlock()
+	for registered := range evictPolicies {
+		if strings.EqualFold(registered, name) {
+			return registered, nil
+		}
+	}
+	return "", fmt.Errorf("unknown evict policy(%v), should be one of %v", name, evictPolicyNames())
+}
+
+func evictPolicyNames() []string {
+	names := make([]string, 0, len(evictPolicies))
+	for name := range evictPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewEvictPolicy creates the policy registered as name.
+func NewEvictPolicy(name string) (EvictPolicy, error) {
+	name, err := EvictPolicyName(name)
+	if err != nil {
+		return nil, err
+	}
+	evictPoliciesLock.RLock()
+	create := evictPolicies[name]
+	evictPoliciesLock.RUnlock()
+	return create(), nil
+}
+
+// lruPolicy evicts the least recently used key, or the first added one when
+// the accesses do not reorder the keys.
+type lruPolicy struct {
+	reorder bool
+	order   *list.List
+	keys    map[interface{}]*list.Element
+}
+
+func newLRUPolicy(reorder bool) *lruPolicy {
+	return &lruPolicy{reorder: reorder, order: list.New(), keys: make(map[interface{}]*list.Element)}
+}
+
+func (p *lruPolicy) Add(key interface{}) {
+	if e, ok := p.keys[key]; ok {
+		p.order.MoveToFront(e)
+		return
+	}
+	p.keys[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy) Access(key interface{}) {
+	if e, ok := p.keys[key]; ok && p.reorder {
+		p.order.MoveToFront(e)
+	}
+}
+
+func (p *lruPolicy) Remove(key interface{}) {
+	if e, ok := p.keys[key]; ok {
+		p.order.Remove(e)
+		delete(p.keys, key)
+	}
+}
+
+func (p *lruPolicy) Victim() (interface{}, bool) {
+	if e := p.order.Back(); e != nil {
+		return e.Value, true
+	}
+	return nil, false
+}
+
+func (p *lruPolicy) Len() int {
+	return len(p.keys)
+}
+
+type lfuBucket struct {
+	freq uint64
+	keys *list.List
+}
+
+type lfuNode struct {
+	bucket *list.Element
+	elem   *list.Element
+}
+
+// lfuPolicy evicts the least frequently used key in constant time, keeping
+// the keys in buckets of the same frequency ordered by it. The least recently
+// used key of the lowest bucket goes first.
+type lfuPolicy struct {
+	buckets *list.List
+	keys    map[interface{}]*lfuNode
+}
+
+func newLFUPolicy() *lfuPolicy {
+	return &lfuPolicy{buckets: list.New(), keys: make(map[interface{}]*lfuNode)}
+}
+
+func (p *lfuPolicy) moveTo(key interface{}, node *lfuNode, freq uint64, after *list.Element) {
+	var target *list.Element
+	if after == nil {
+		target = p.buckets.Front()
+	} else {
+		target = after.Next()
+	}
+	if target == nil || target.Value.(*lfuBucket).freq != freq {
+		bucket := &lfuBucket{freq: freq, keys: list.New()}
+		if after == nil {
+			target = p.buckets.PushFront(bucket)
+		} else {
+			target = p.buckets.InsertAfter(bucket, after)
+		}
+	}
+	node.bucket = target
+	node.elem = target.Value.(*lfuBucket).keys.PushFront(key)
+}
+
+func (p *lfuPolicy) unlink(node *lfuNode) {
+	bucket := node.bucket.Value.(*lfuBucket)
+	bucket.keys.Remove(node.elem)
+	if bucket.keys.Len() == 0 {
+		p.buckets.Remove(node.bucket)
+	}
+}
+
+func (p *lfuPolicy) Add(key interface{}) {
+	if _, ok := p.keys[key]; ok {
+		p.Access(key)
+		return
+	}
+	node := &lfuNode{}
+	p.moveTo(key, node, 1, nil)
+	p.keys[key] = node
+}
+
+func (p *lfuPolicy) Access(key interface{}) {
+	node, ok := p.keys[key]
+	if !ok {
+		return
+	}
+	freq := node.bucket.Value.(*lfuBucket).freq + 1
+	after := node.bucket
+	if node.bucket.Value.(*lfuBucket).keys.Len() == 1 {
+		after = node.bucket.Prev()
+	}
+	p.unlink(node)
+	p.moveTo(key, node, freq, after)
+}
+
+func (p *lfuPolicy) Remove(key interface{}) {
+	if node, ok := p.keys[key]; ok {
+		p.unlink(node)
+		delete(p.keys, key)
+	}
+}
+
+func (p *lfuPolicy) Victim() (interface{}, bool) {
+	if b := p.buckets.Front(); b != nil {
+		return b.Value.(*lfuBucket).keys.Back().Value, true
+	}
+	return nil, false
+}
+
+func (p *lfuPolicy) Len() int {
+	return len(p.keys)
+}
+
+// volumeEvictPolicy orders the cache blocks of some volumes with their own
+// policies. The victim is the candidate of the policies least recently used,
+// so that the policies of the volumes compete fairly for the space.
+type volumeEvictPolicy struct {
+	defaultPolicy EvictPolicy
+	volPolicies   map[string]EvictPolicy
+	clock         uint64
+	accessed      map[interface{}]uint64
+}
+
+func newVolumeEvictPolicy(defaultPolicy string, volPolicies map[string]string) (EvictPolicy, error) {
+	def, err := NewEvictPolicy(defaultPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if len(volPolicies) == 0 {
+		return def, nil
+	}
+	p := &volumeEvictPolicy{
+		defaultPolicy: def,
+		volPolicies:   make(map[string]EvictPolicy, len(volPolicies)),
+		accessed:      make(map[interface{}]uint64),
+	}
+	for vol, name := range volPolicies {
+		if p.volPolicies[vol], err = NewEvictPolicy(name); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// policyOf routes the cache block keys, the volume is their first element.
+func (p *volumeEvictPolicy) policyOf(key interface{}) EvictPolicy {
+	if k, ok := key.(string); ok {
+		if policy, ok := p.volPolicies[path.Dir(k)]; ok {
+			return policy
+		}
+	}
+	return p.defaultPolicy
+}
+
+func (p *volumeEvictPolicy) touch(key interface{}) {
+	p.clock++
+	p.accessed[key] = p.clock
+}
+
+func (p *volumeEvictPolicy) Add(key interface{}) {
+	p.touch(key)
+	p.policyOf(key).Add(key)
+}
+
+func (p *volumeEvictPolicy) Access(key interface{}) {
+	if _, ok := p.accessed[key]; ok {
+		p.touch(key)
+	}
+	p.policyOf(key).Access(key)
+}
+
+func (p *volumeEvictPolicy) Remove(key interface{}) {
+	delete(p.accessed, key)
+	p.policyOf(key).Remove(key)
+}
+
+func (p *volumeEvictPolicy) Victim() (victim interface{}, found bool) {
+	var oldest uint64
+	consider := func(policy EvictPolicy) {
+		if key, ok := policy.Victim(); ok {
+			if at := p.accessed[key]; !found || at < oldest {
+				victim, oldest, found = key, at, true
+			}
+		}
+	}
+	consider(p.defaultPolicy)
+	for _, policy := range p.volPolicies {
+		consider(policy)
+	}
+	return
+}
+
+func (p *volumeEvictPolicy) Len() int {
+	return len(p.accessed)
+}
+
+// SetEvictPolicy selects the eviction policy of the cache blocks, the one of
+// volPolicies for the blocks of the volumes in it. The blocks of the TinyLFU
+// volumes go through the admission filter even if it is not enabled.
+func (c *CacheEngine) SetEvictPolicy(policy string, volPolicies map[string]string) (err error) {
+	cfg := &evictPolicyConfig{volPolicies: make(map[string]string, len(volPolicies))}
+	if cfg.policy, err = EvictPolicyName(policy); err != nil {
+		return
+	}
+	for vol, name := range volPolicies {
+		if cfg.volPolicies[vol], err = EvictPolicyName(name); err != nil {
+			return
+		}
+	}
+	policies := make(map[*lruCacheItem]EvictPolicy)
+	c.lruCacheMap.Range(func(_, value interface{}) bool {
+		var p EvictPolicy
+		if p, err = newVolumeEvictPolicy(cfg.policy, cfg.volPolicies); err != nil {
+			return false
+		}
+		policies[value.(*lruCacheItem)] = p
+		return true
+	})
+	if err != nil {
+		return
+	}
+	for cacheItem, p := range policies {
+		cacheItem.lruCache.SetEvictPolicy(p)
+	}
+	c.evictPolicy.Store(cfg)
+	log.LogInfof("CacheEngine set evictPolicy(%v) volEvictPolicy(%v)", cfg.policy, cfg.volPolicies)
+	return
+}
+
+func (c *CacheEngine) getEvictPolicy() *evictPolicyConfig {
+	if cfg, ok := c.evictPolicy.Load().(*evictPolicyConfig); ok {
+		return cfg
+	}
+	return &evictPolicyConfig{policy: DefaultEvictPolicy}
+}
+
+// GetEvictPolicy returns the eviction policy of the cache blocks and the ones
+// of the volumes evicted differently.
+func (c *CacheEngine) GetEvictPolicy() (policy string, volPolicies map[string]string) {
+	cfg := c.getEvictPolicy()
+	return cfg.policy, cfg.volPolicies
+}
+
+func (c *CacheEngine) evictPolicyOf(volume string) string {
+	cfg := c.getEvictPolicy()
+	if policy, ok := cfg.volPolicies[volume]; ok {
+		return policy
+	}
+	return cfg.policy
+}
+
+func (c *CacheEngine) anyTinyLFU() bool {
+	cfg := c.getEvictPolicy()
+	if cfg.policy == EvictPolicyTinyLFU {
+		return true
+	}
+	for _, policy := range cfg.volPolicies {
+		if policy == EvictPolicyTinyLFU {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func victimOf(t *testing.T, p EvictPolicy) interface{} {
+	key, ok := p.Victim()
+	require.True(t, ok)
+	return key
+}
+
+func TestEvictPolicyOrder(t *testing.T) {
+	for name, want := range map[string]string{
+		EvictPolicyLRU:     "c",
+		EvictPolicyTinyLFU: "c",
+		EvictPolicyFIFO:    "a",
+		EvictPolicyLFU:     "b",
+	} {
+		p, err := NewEvictPolicy(name)
+		require.NoError(t, err)
+		_, ok := p.Victim()
+		require.False(t, ok)
+		p.Add("a")
+		p.Add("b")
+		p.Add("c")
+		p.Access("c")
+		p.Access("c")
+		p.Access("b")
+		p.Access("a")
+		require.Equal(t, want, victimOf(t, p), name)
+		require.Equal(t, 3, p.Len())
+		p.Remove(want)
+		require.Equal(t, 2, p.Len(), name)
+	}
+
+	// the least frequently used one, the least recently used of them first
+	lfu := newLFUPolicy()
+	for _, key := range []string{"a", "b", "c"} {
+		lfu.Add(key)
+	}
+	lfu.Access("a")
+	lfu.Access("c")
+	require.Equal(t, "b", victimOf(t, lfu))
+	lfu.Remove("b")
+	require.Equal(t, "a", victimOf(t, lfu))
+	lfu.Access("a")
+	require.Equal(t, "c", victimOf(t, lfu))
+
+	name, err := EvictPolicyName("tinylfu")
+	require.NoError(t, err)
+	require.Equal(t, EvictPolicyTinyLFU, name)
+	name, err = EvictPolicyName("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultEvictPolicy, name)
+	_, err = EvictPolicyName("ARC")
+	require.Error(t, err)
+
+	RegisterEvictPolicy("ARC", func() EvictPolicy { return newLRUPolicy(true) })
+	defer func() {
+		evictPoliciesLock.Lock()
+		delete(evictPolicies, "ARC")
+		evictPoliciesLock.Unlock()
+	}()
+	_, err = NewEvictPolicy("arc")
+	require.NoError(t, err)
+}
+
+func TestVolumeEvictPolicy(t *testing.T) {
+	p, err := newVolumeEvictPolicy(EvictPolicyLRU, map[string]string{"lfu": EvictPolicyLFU})
+	require.NoError(t, err)
+	hot, cold := GenCacheBlockKey("lfu", 1, 0, 0), GenCacheBlockKey("lfu", 2, 0, 0)
+	lru := GenCacheBlockKey("lru", 1, 0, 0)
+	p.Add(hot)
+	p.Add(cold)
+	p.Access(hot)
+	p.Add(lru)
+	// the lfu victim is older than the lru one
+	require.Equal(t, cold, victimOf(t, p))
+	p.Access(cold)
+	require.Equal(t, hot, victimOf(t, p))
+	p.Access(hot)
+	require.Equal(t, lru, victimOf(t, p))
+	p.Remove(lru)
+	require.Equal(t, 2, p.Len())
+
+	_, err = newVolumeEvictPolicy(EvictPolicyLRU, map[string]string{"vol": "none"})
+	require.Error(t, err)
+}
+
+func TestCacheEvictPolicy(t *testing.T) {
+	closeFn := func(v interface{}) error { return nil }
+	deleteFn := func(v interface{}, reason string) error { return nil }
+	c := NewCacheWithPolicy(LRUFileHandleCacheType, 2, -1, time.Hour, newLFUPolicy(), deleteFn, closeFn)
+	defer c.Close()
+	_, err := c.Set("a", 1, 0)
+	require.NoError(t, err)
+	_, err = c.Set("b", 2, 0)
+	require.NoError(t, err)
+	_, err = c.Get("a")
+	require.NoError(t, err)
+	_, err = c.Get("a")
+	require.NoError(t, err)
+	_, err = c.Get("b")
+	require.NoError(t, err)
+	// lru would evict a, lfu evicts b
+	n, err := c.Set("c", 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	_, ok := c.Peek("a")
+	require.True(t, ok)
+	_, ok = c.Peek("b")
+	require.False(t, ok)
+
+	// the new policy starts from the order of the old one
+	c.SetEvictPolicy(newLRUPolicy(false))
+	victim, ok := c.Victim()
+	require.True(t, ok)
+	require.Equal(t, "c", victim)
+	require.Equal(t, 2, c.Len())
+}
+
+func TestEngineTinyLFUPolicy(t *testing.T) {
+	var ce *CacheEngine
+	var err error
+	lruCap := 2
+	require.NoError(t, os.MkdirAll(testTmpFS, 0o755))
+	disk := &Disk{Path: testTmpFS, TotalSpace: 200 * util.MB, Capacity: lruCap, Status: proto.ReadWrite}
+	disks := []*Disk{disk}
+	if !enabledTmpfs() {
+		ce, err = NewCacheEngine("", 0, DefaultCacheMaxUsedRatio, disks, lruCap, lruCap, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	} else {
+		ce, err = NewCacheEngine(testTmpFS, util.GB, DefaultCacheMaxUsedRatio, disks, lruCap, lruCap, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	}
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ce.Stop()) }()
+
+	require.Error(t, ce.SetEvictPolicy("none", nil))
+	scan := t.Name() + "_scan"
+	require.NoError(t, ce.SetEvictPolicy(EvictPolicyLRU, map[string]string{scan: "tinylfu"}))
+	policy, volPolicies := ce.GetEvictPolicy()
+	require.Equal(t, EvictPolicyLRU, policy)
+	require.Equal(t, map[string]string{scan: EvictPolicyTinyLFU}, volPolicies)
+	require.False(t, ce.GetAdmissionStat().Enable)
+
+	inode, fixedOffset, version := uint64(1), uint64(1024), uint32(112358796)
+	read := func(vol string) error {
+		_, err := ce.GetCacheBlockForRead(vol, inode, fixedOffset, version, 0)
+		return err
+	}
+	create := func(vol string) error {
+		_, err := ce.createCacheBlock(vol, inode, fixedOffset, version, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+		return err
+	}
+	hot, cold := t.Name()+"_hot", t.Name()+"_cold"
+	for _, vol := range []string{hot, cold} {
+		require.Error(t, read(vol))
+		require.NoError(t, create(vol))
+	}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, read(hot))
+		require.NoError(t, read(cold))
+	}
+
+	// the blocks of the TinyLFU volume go through the filter, not the others
+	require.Error(t, read(scan))
+	require.ErrorIs(t, create(scan), proto.ErrFlashNodeNotAdmitted)
+	other := t.Name() + "_other"
+	require.NoError(t, create(other))
+	require.Equal(t, uint64(1), ce.GetAdmissionStat().Rejected)
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const DefaultHotTierPromoteHits = 4
+
+type hotBlock struct {
+	key   string
+	data  []byte
+	block *CacheBlock
+}
+
+// hotTier keeps the data of the hottest cache blocks of the disks in memory.
+// A block is promoted once it was read promoteHits times from the disk, and
+// demoted back to the disk only when the tier is full and it is the least
+// recently read one. The data are immutable once the block is ready, so the
+// disk copy stays valid and demoting a block just drops its memory.
+type hotTier struct {
+	sync.Mutex
+	maxBytes    int64
+	promoteHits int32
+	usedBytes   int64
+	lru         *list.List
+	blocks      map[string]*list.Element
+
+	hits     uint64
+	promoted uint64
+	demoted  uint64
+}
+
+func newHotTier(maxBytes int64, promoteHits int) *hotTier {
+	if promoteHits <= 0 {
+		promoteHits = DefaultHotTierPromoteHits
+	}
+	return &hotTier{
+		maxBytes:    maxBytes,
+		promoteHits: int32(promoteHits),
+		lru:         list.New(),
+		blocks:      make(map[string]*list.Element),
+	}
+}
+
+// read copies the data of the block at offset from memory, it tells false if
+// the block is not promoted or does not hold the whole range.
+func (h *hotTier) read(key string, data []byte, offset, size int64) bool {
+	if h == nil {
+		return false
+	}
+	h.Lock()
+	defer h.Unlock()
+	e, ok := h.blocks[key]
+	if !ok {
+		return false
+	}
+	hb := e.Value.(*hotBlock)
+	if offset+size > int64(len(hb.data)) {
+		return false
+	}
+	copy(data[:size], hb.data[offset:offset+size])
+	h.lru.MoveToFront(e)
+	h.hits++
+	return true
+}
+
+// shouldPromote counts a read of the block from the disk, and tells whether
+// it is hot enough to be promoted, only once until it is demoted.
+func (h *hotTier) shouldPromote(cb *CacheBlock) bool {
+	if h == nil || cb.getUsedSize() > h.maxBytes {
+		return false
+	}
+	return atomic.AddInt32(&cb.diskReads, 1) == h.promoteHits
+}
+
+func (h *hotTier) promote(cb *CacheBlock, data []byte) {
+	h.Lock()
+	defer h.Unlock()
+	// checked under the lock, the deleted blocks are closed before removed
+	select {
+	case <-cb.closeCh:
+		return
+	default:
+	}
+	if _, ok := h.blocks[cb.blockKey]; ok {
+		return
+	}
+	for h.usedBytes+int64(len(data)) > h.maxBytes {
+		if !h.demoteOldest() {
+			break
+		}
+	}
+	h.blocks[cb.blockKey] = h.lru.PushFront(&hotBlock{key: cb.blockKey, data: data, block: cb})
+	h.usedBytes += int64(len(data))
+	h.promoted++
+}
+
+func (h *hotTier) demoteOldest() bool {
+	e := h.lru.Back()
+	if e == nil {
+		return false
+	}
+	hb := h.removeElement(e)
+	atomic.StoreInt32(&hb.block.diskReads, 0)
+	h.demoted++
+	if log.EnableDebug() {
+		log.LogDebugf("action[demoteOldest] block(%v) demoted to the disk", hb.key)
+	}
+	return true
+}
+
+func (h *hotTier) removeElement(e *list.Element) *hotBlock {
+	hb := h.lru.Remove(e).(*hotBlock)
+	delete(h.blocks, hb.key)
+	h.usedBytes -= int64(len(hb.data))
+	return hb
+}
+
+func (h *hotTier) contains(key string) bool {
+	if h == nil {
+		return false
+	}
+	h.Lock()
+	defer h.Unlock()
+	_, ok := h.blocks[key]
+	return ok
+}
+
+// remove drops the block deleted from the disk.
+func (h *hotTier) remove(key string) {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	if e, ok := h.blocks[key]; ok {
+		h.removeElement(e)
+	}
+}
+
+func (h *hotTier) clear() {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	h.lru.Init()
+	h.blocks = make(map[string]*list.Element)
+	h.usedBytes = 0
+}
+
+func (h *hotTier) stat() *proto.FlashNodeHotTierStat {
+	if h == nil {
+		return &proto.FlashNodeHotTierStat{}
+	}
+	h.Lock()
+	defer h.Unlock()
+	return &proto.FlashNodeHotTierStat{
+		Enable:      true,
+		MaxBytes:    h.maxBytes,
+		UsedBytes:   h.usedBytes,
+		Blocks:      len(h.blocks),
+		PromoteHits: int(h.promoteHits),
+		Hits:        h.hits,
+		Promoted:    h.promoted,
+		Demoted:     h.demoted,
+	}
+}
+
+// SetHotTier keeps up to maxBytes of the blocks read promoteHits times from the
+// disks in memory. It is a no-op with tmpfs, where the blocks are in memory
+// already, and must be called before the engine starts serving reads.
+func (c *CacheEngine) SetHotTier(maxBytes int64, promoteHits int) {
+	if c.enableTmpfs || maxBytes <= 0 {
+		return
+	}
+	c.hotTier = newHotTier(maxBytes, promoteHits)
+	log.LogInfof("CacheEngine enable hot tier, maxBytes(%v) promoteHits(%v)", maxBytes, c.hotTier.promoteHits)
+}
+
+// GetHotTierStat returns the usage of the memory tier in front of the disks.
+func (c *CacheEngine) GetHotTierStat() *proto.FlashNodeHotTierStat {
+	return c.hotTier.stat()
+}
+
+// maybePromote reads the whole block from the disk into the hot tier once it
+// was read often enough, in the background not to delay the read hitting it.
+func (c *CacheEngine) maybePromote(cb *CacheBlock) {
+	if !c.hotTier.shouldPromote(cb) {
+		return
+	}
+	go func() {
+		size := cb.getUsedSize()
+		data := make([]byte, size)
+		file, err := cb.GetOrOpenFileHandler()
+		if err == nil {
+			_, err = file.ReadAt(data, HeaderSize)
+		}
+		if err != nil {
+			atomic.StoreInt32(&cb.diskReads, 0)
+			log.LogWarnf("action[maybePromote] read block(%v) failed: %v", cb.blockKey, err)
+			return
+		}
+		c.hotTier.promote(cb, data)
+	}()
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func newHotTierTestBlock(t *testing.T, engine *CacheEngine, inode uint64, data []byte) *CacheBlock {
+	disk := &Disk{Path: testTmpFS, Status: proto.ReadWrite}
+	cb := NewCacheBlock(testTmpFS, t.Name(), inode, 0, 1, proto.CACHE_BLOCK_SIZE, nil, "", disk)
+	cb.cacheEngine = engine
+	require.NoError(t, cb.initFilePath(false))
+	require.NoError(t, cb.WriteAt(data, 0, int64(len(data))))
+	cb.notifyReady()
+	return cb
+}
+
+func TestHotTierPromoteDemote(t *testing.T) {
+	umount, err := initTestTmpfs()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, umount()) }()
+
+	engine := &CacheEngine{hotTier: newHotTier(1024, 2)}
+	engine.lruFhCache = NewCache(LRUFileHandleCacheType, 10, -1, time.Hour,
+		func(v interface{}, reason string) error { return v.(*os.File).Close() },
+		func(v interface{}) error { return v.(*os.File).Close() })
+
+	readBlock := func(cb *CacheBlock, want []byte) {
+		got := make([]byte, 256)
+		_, err := cb.Read(context.Background(), got, 256, 256, false)
+		require.NoError(t, err)
+		require.Equal(t, want[256:512], got)
+	}
+
+	first := randTestData(1024)
+	cb1 := newHotTierTestBlock(t, engine, 1, first)
+	defer cb1.Delete("test")
+
+	// promoted in the background on the second read from the disk
+	readBlock(cb1, first)
+	require.Equal(t, 0, engine.GetHotTierStat().Blocks)
+	readBlock(cb1, first)
+	require.Eventually(t, func() bool { return engine.GetHotTierStat().Promoted == 1 }, time.Second, 10*time.Millisecond)
+	readBlock(cb1, first)
+	st := engine.GetHotTierStat()
+	require.Equal(t, uint64(1), st.Hits)
+	require.Equal(t, int64(1024), st.UsedBytes)
+
+	// the tier is full, the least recently read block is demoted for the new one
+	second := randTestData(1024)
+	cb2 := newHotTierTestBlock(t, engine, 2, second)
+	readBlock(cb2, second)
+	readBlock(cb2, second)
+	require.Eventually(t, func() bool { return engine.GetHotTierStat().Promoted == 2 }, time.Second, 10*time.Millisecond)
+	st = engine.GetHotTierStat()
+	require.Equal(t, uint64(1), st.Demoted)
+	require.Equal(t, 1, st.Blocks)
+	readBlock(cb1, first)
+	require.Equal(t, uint64(1), engine.GetHotTierStat().Hits)
+
+	// deleting the block drops it from memory
+	require.NoError(t, cb2.Delete("test"))
+	st = engine.GetHotTierStat()
+	require.Equal(t, 0, st.Blocks)
+	require.Equal(t, int64(0), st.UsedBytes)
+
+	disabled := &CacheEngine{}
+	require.False(t, disabled.GetHotTierStat().Enable)
+}
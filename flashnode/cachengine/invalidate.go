@@ -0,0 +1,95 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// blockVersions indexes the versions cached of each block of a file. The version of a block
+// depends on the inode generation known by the reader, so the clients with an inode cached
+// before an overwrite still read the versions cached before it. An overwrite evicts them all.
+type blockVersions struct {
+	sync.Mutex
+	versions map[string][]uint32
+}
+
+func genBlockVersionsKey(volume string, inode, offset uint64) string {
+	u := strconv.FormatUint
+	return path.Join(volume, u(inode, 10)+"#"+u(offset, 10))
+}
+
+func (bv *blockVersions) add(volume string, inode, offset uint64, version uint32) {
+	key := genBlockVersionsKey(volume, inode, offset)
+	bv.Lock()
+	defer bv.Unlock()
+	if bv.versions == nil {
+		bv.versions = make(map[string][]uint32)
+	}
+	for _, v := range bv.versions[key] {
+		if v == version {
+			return
+		}
+	}
+	bv.versions[key] = append(bv.versions[key], version)
+}
+
+func (bv *blockVersions) remove(volume string, inode, offset uint64, version uint32) {
+	key := genBlockVersionsKey(volume, inode, offset)
+	bv.Lock()
+	defer bv.Unlock()
+	versions := bv.versions[key]
+	for i, v := range versions {
+		if v == version {
+			versions = append(versions[:i], versions[i+1:]...)
+			break
+		}
+	}
+	if len(versions) == 0 {
+		delete(bv.versions, key)
+		return
+	}
+	bv.versions[key] = versions
+}
+
+func (bv *blockVersions) take(volume string, inode, offset uint64) (versions []uint32) {
+	key := genBlockVersionsKey(volume, inode, offset)
+	bv.Lock()
+	defer bv.Unlock()
+	versions = bv.versions[key]
+	delete(bv.versions, key)
+	return
+}
+
+// EvictCacheBlocks evicts every version cached of the blocks at the fixed offsets of the file.
+func (c *CacheEngine) EvictCacheBlocks(volume string, inode uint64, offsets []uint64) (evicted int) {
+	for _, offset := range offsets {
+		for _, version := range c.blockVersions.take(volume, inode, offset) {
+			key := GenCacheBlockKey(volume, inode, offset, version)
+			if _, ok := c.keyToDiskMap.Load(key); ok {
+				c.deleteCacheBlock(key)
+				evicted++
+			}
+		}
+	}
+	if log.EnableDebug() {
+		log.LogDebugf("action[EvictCacheBlocks] volume(%v) inode(%v) offsets(%v) evicted(%v)", volume, inode, offsets, evicted)
+	}
+	return
+}
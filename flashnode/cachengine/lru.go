@@ -15,7 +15,6 @@
 package cachengine
 
 import (
-	"container/list"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -29,6 +28,7 @@ import (
 type LruCache interface {
 	Get(key interface{}) (interface{}, error)
 	Peek(key interface{}) (interface{}, bool)
+	Victim() (interface{}, bool)
 	Set(key interface{}, value interface{}, expiration time.Duration) (int, error)
 	Evict(key interface{}) bool
 	EvictAll(cacheEvictWorkerNum int)
@@ -42,6 +42,8 @@ type LruCache interface {
 	AddMisses()
 	CheckDiskSpace(dataPath string, key interface{}, size int64) (n int, err error)
 	FreePreAllocatedSize(key interface{})
+	SetEvictPolicy(policy EvictPolicy)
+	Range(fn func(key, value interface{}, createAt, expiredAt time.Time) bool)
 }
 
 type Status struct {
@@ -52,8 +54,8 @@ type Status struct {
 }
 
 type RateStat struct {
-	Hits, Misses, Evicts int32
-	HitRate              float64
+	Hits, Misses, Evicts, Inserts int32
+	HitRate                       float64
 }
 
 // fCache implements a non-thread safe fixed size cache.
@@ -65,15 +67,16 @@ type fCache struct {
 	preAllocated       int64
 	preAllocatedKeyMap map[interface{}]int64
 
-	hits   int32
-	misses int32
-	evicts int32 // evict by set
-	recent *RateStat
+	hits    int32
+	misses  int32
+	evicts  int32 // evict by set
+	inserts int32 // new entries by set
+	recent  *RateStat
 
-	ttl   time.Duration
-	lock  sync.RWMutex
-	lru   *list.List
-	items map[interface{}]*list.Element
+	ttl    time.Duration
+	lock   sync.RWMutex
+	policy EvictPolicy
+	items  map[interface{}]*entry
 
 	onDelete OnDeleteF
 	onClose  OnCloseF
@@ -99,6 +102,14 @@ type (
 // NewCache constructs a new LruCache of the given size that is not safe for
 // concurrent use. If it will be panic, if size is not a positive integer.
 func NewCache(cacheType int, capacity int, maxSize int64, ttl time.Duration, onDelete OnDeleteF, onClose OnCloseF) LruCache {
+	return NewCacheWithPolicy(cacheType, capacity, maxSize, ttl, newLRUPolicy(true), onDelete, onClose)
+}
+
+// NewCacheWithPolicy constructs a new LruCache evicting the entries in the order
+// of the policy.
+func NewCacheWithPolicy(cacheType int, capacity int, maxSize int64, ttl time.Duration, policy EvictPolicy,
+	onDelete OnDeleteF, onClose OnCloseF,
+) LruCache {
 	if capacity <= 0 {
 		panic("must provide a positive capacity")
 	}
@@ -108,13 +119,13 @@ func NewCache(cacheType int, capacity int, maxSize int64, ttl time.Duration, onD
 		maxSize:            maxSize,
 		preAllocatedKeyMap: make(map[interface{}]int64),
 		ttl:                ttl,
-		lru:                list.New(),
+		policy:             policy,
 		hits:               1,
 		recent:             &RateStat{},
 		onDelete:           onDelete,
 		onClose:            onClose,
 		closeCh:            make(chan struct{}),
-		items:              make(map[interface{}]*list.Element),
+		items:              make(map[interface{}]*entry),
 	}
 	go func() {
 		tick := time.NewTicker(time.Second * 60)
@@ -139,10 +150,12 @@ func (c *fCache) replaceRecent() {
 	hits := atomic.SwapInt32(&c.hits, 1)
 	misses := atomic.SwapInt32(&c.misses, 0)
 	evicts := atomic.SwapInt32(&c.evicts, 0)
+	inserts := atomic.SwapInt32(&c.inserts, 0)
 	rs := RateStat{
 		Hits:    hits,
 		Misses:  misses,
 		Evicts:  evicts,
+		Inserts: inserts,
 		HitRate: float64(hits) / float64(hits+misses),
 	}
 	c.recent = &rs
@@ -151,14 +164,14 @@ func (c *fCache) replaceRecent() {
 func (c *fCache) Status() *Status {
 	c.lock.RLock()
 	keys := make([]interface{}, 0, len(c.items))
-	for _, i := range c.items {
-		v := i.Value.(*entry)
+	for _, v := range c.items {
 		keys = append(keys, v.key)
 	}
+	length := len(c.items)
 	c.lock.RUnlock()
 	return &Status{
 		Allocated: atomic.LoadInt64(&c.allocated),
-		Length:    c.lru.Len(),
+		Length:    length,
 		HitRate:   *c.recent,
 		Keys:      keys,
 	}
@@ -167,15 +180,15 @@ func (c *fCache) Status() *Status {
 func (c *fCache) StatusAll() *Status {
 	c.lock.RLock()
 	keys := make([]interface{}, 0, len(c.items))
-	for _, i := range c.items {
-		v := i.Value.(*entry)
+	for _, v := range c.items {
 		keyInfo := v.key.(string) + "  " + v.expiredAt.Format("2006-01-02 15:04:05")
 		keys = append(keys, keyInfo)
 	}
+	length := len(c.items)
 	c.lock.RUnlock()
 	return &Status{
 		Allocated: atomic.LoadInt64(&c.allocated),
-		Length:    c.lru.Len(),
+		Length:    length,
 		HitRate:   *c.recent,
 		Keys:      keys,
 	}
@@ -238,19 +251,19 @@ func (c *fCache) CheckDiskSpace(dataPath string, key interface{}, size int64) (n
 
 	toEvicts := make(map[interface{}]interface{})
 	for diskSpaceLeft <= 0 {
-		ent := c.lru.Back()
-		if ent == nil {
+		key, ok := c.policy.Victim()
+		if !ok {
 			break
 		}
-		toEvicts[ent.Value.(*entry).key] = c.deleteElement(ent)
+		value := c.deleteElement(c.items[key])
+		toEvicts[key] = value
 		atomic.AddInt32(&c.evicts, 1)
 		n++
-		diskSpaceLeft += ent.Value.(*entry).value.(*CacheBlock).getAllocSize()
-
+		diskSpaceLeft += value.(*CacheBlock).getAllocSize()
 	}
 	for k, e := range toEvicts {
 		_ = c.onDelete(e, fmt.Sprintf("lru disk space is full(%d / %d) diskSpaceLeft(%d)", atomic.LoadInt64(&c.allocated), c.maxSize, diskSpaceLeft))
-		log.LogInfof("delete(%s) cos disk space full, len(%d) size(%d / %d) diskSpaceLeft(%d)", k, c.lru.Len(), atomic.LoadInt64(&c.allocated), c.maxSize, diskSpaceLeft)
+		log.LogInfof("delete(%s) cos disk space full, len(%d) size(%d / %d) diskSpaceLeft(%d)", k, len(c.items), atomic.LoadInt64(&c.allocated), c.maxSize, diskSpaceLeft)
 	}
 	if diskSpaceLeft <= 0 {
 		return n, fmt.Errorf("diskSpaceLeft(%v) is not larger than 0, lru has no more entry can be deleted", diskSpaceLeft)
@@ -266,44 +279,48 @@ func (c *fCache) Set(key, value interface{}, expiration time.Duration) (n int, e
 	expiration = GenerateRandTime(expiration)
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if ent, ok := c.items[key]; ok {
-		c.lru.MoveToFront(ent)
-		v := ent.Value.(*entry)
+	if v, ok := c.items[key]; ok {
+		c.policy.Access(key)
 		v.value = value
 		v.createAt = time.Now()
 		v.expiredAt = time.Now().Add(expiration)
 		return 0, nil
 	}
 
+	var cbSize int64
 	if c.cacheType == LRUCacheBlockCacheType {
 		newCb := value.(*CacheBlock)
-		cbSize := newCb.getAllocSize()
-		atomic.AddInt64(&c.allocated, cbSize)
+		cbSize = newCb.getAllocSize()
 	}
 
-	c.items[key] = c.lru.PushFront(&entry{
+	// evict before adding, the policies may rank the new entry the lowest
+	toEvicts := make(map[interface{}]interface{})
+	for len(c.items) >= c.capacity || (c.cacheType == LRUCacheBlockCacheType && atomic.LoadInt64(&c.allocated)+cbSize > c.maxSize) {
+		victim, ok := c.policy.Victim()
+		if !ok {
+			break
+		}
+		if c.cacheType == LRUCacheBlockCacheType {
+			c.DeleteKeyFromPreAllocatedKeyMap(victim)
+		}
+		toEvicts[victim] = c.deleteElement(c.items[victim])
+		atomic.AddInt32(&c.evicts, 1)
+		n++
+	}
+	atomic.AddInt64(&c.allocated, cbSize)
+	c.items[key] = &entry{
 		key:       key,
 		value:     value,
 		createAt:  time.Now(),
 		expiredAt: time.Now().Add(expiration),
-	})
-	toEvicts := make(map[interface{}]interface{})
-	for c.lru.Len() > c.capacity || (c.cacheType == LRUCacheBlockCacheType && atomic.LoadInt64(&c.allocated) > c.maxSize) {
-		ent := c.lru.Back()
-		if ent != nil {
-			if c.cacheType == LRUCacheBlockCacheType {
-				c.DeleteKeyFromPreAllocatedKeyMap(ent.Value.(*entry).key)
-			}
-			toEvicts[ent.Value.(*entry).key] = c.deleteElement(ent)
-			atomic.AddInt32(&c.evicts, 1)
-			n++
-		}
 	}
+	c.policy.Add(key)
+	atomic.AddInt32(&c.inserts, 1)
 
 	for k, e := range toEvicts {
 		_ = c.onDelete(e, fmt.Sprintf("lru is full(%d / %d)", atomic.LoadInt64(&c.allocated), c.maxSize))
 		if c.cacheType == LRUCacheBlockCacheType {
-			log.LogInfof("delete(%s) cos lru full, len(%d) size(%d / %d)", k, c.lru.Len(), atomic.LoadInt64(&c.allocated), c.maxSize)
+			log.LogInfof("delete(%s) cos lru full, len(%d) size(%d / %d)", k, len(c.items), atomic.LoadInt64(&c.allocated), c.maxSize)
 		}
 	}
 	return n, nil
@@ -312,11 +329,10 @@ func (c *fCache) Set(key, value interface{}, expiration time.Duration) (n int, e
 func (c *fCache) Get(key interface{}) (interface{}, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if ent, ok := c.items[key]; ok {
-		v := ent.Value.(*entry)
+	if v, ok := c.items[key]; ok {
 		if v.expiredAt.After(time.Now()) {
 			atomic.AddInt32(&c.hits, 1)
-			c.lru.MoveToFront(ent)
+			c.policy.Access(key)
 			return v.value, nil
 		}
 		atomic.AddInt32(&c.misses, 1)
@@ -325,7 +341,7 @@ func (c *fCache) Get(key interface{}) (interface{}, error) {
 				key, v.createAt.Format("2006-01-02 15:04:05"), v.expiredAt.Format("2006-01-02 15:04:05"))
 			c.DeleteKeyFromPreAllocatedKeyMap(key)
 		}
-		e := c.deleteElement(ent)
+		e := c.deleteElement(v)
 		_ = c.onDelete(e, fmt.Sprintf("created: %v get expired: %v", v.createAt.Format("2006-01-02 15:04:05"),
 			v.expiredAt.Format("2006-01-02 15:04:05")))
 		return nil, fmt.Errorf("expired key[%v]", key)
@@ -339,13 +355,48 @@ func (c *fCache) Get(key interface{}) (interface{}, error) {
 func (c *fCache) Peek(key interface{}) (interface{}, bool) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	if ent, ok := c.items[key]; ok {
-		v := ent.Value.(*entry)
+	if v, ok := c.items[key]; ok {
 		return v.value, true
 	}
 	return nil, false
 }
 
+// Victim returns the key to be evicted next by the eviction policy.
+func (c *fCache) Victim() (interface{}, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.policy.Victim()
+}
+
+// SetEvictPolicy replaces the eviction policy, the keys are handed over from
+// the next victim to the last one so that the new policy starts from the same
+// order.
+func (c *fCache) SetEvictPolicy(policy EvictPolicy) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for {
+		key, ok := c.policy.Victim()
+		if !ok {
+			break
+		}
+		c.policy.Remove(key)
+		policy.Add(key)
+	}
+	c.policy = policy
+}
+
+// Range calls fn for the entries until it returns false, with the read lock of
+// the cache held.
+func (c *fCache) Range(fn func(key, value interface{}, createAt, expiredAt time.Time) bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for _, v := range c.items {
+		if !fn(v.key, v.value, v.createAt, v.expiredAt) {
+			return
+		}
+	}
+}
+
 // EvictAll is used to completely clear the cache.
 func (c *fCache) EvictAll(cacheEvictWorkerNum int) {
 	c.lock.Lock()
@@ -361,9 +412,9 @@ func (c *fCache) EvictAll(cacheEvictWorkerNum int) {
 			}
 		}()
 	}
-	for _, ent := range c.items {
+	for key, ent := range c.items {
 		if c.cacheType == LRUCacheBlockCacheType {
-			c.DeleteKeyFromPreAllocatedKeyMap(ent.Value.(*entry).key)
+			c.DeleteKeyFromPreAllocatedKeyMap(key)
 		}
 		toEvicts <- c.deleteElement(ent)
 	}
@@ -386,21 +437,21 @@ func (c *fCache) Evict(key interface{}) bool {
 	return true
 }
 
-func (c *fCache) deleteElement(ent *list.Element) interface{} {
-	v := ent.Value.(*entry)
+func (c *fCache) deleteElement(ent *entry) interface{} {
 	c.removeElement(ent)
-	return v.value
+	return ent.value
 }
 
 func (c *fCache) Len() int {
-	return c.lru.Len()
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return len(c.items)
 }
 
-// removeElement is used to remove a given list element from the cache
-func (c *fCache) removeElement(e *list.Element) {
-	c.lru.Remove(e)
-	kv := e.Value.(*entry)
+// removeElement is used to remove a given entry from the cache
+func (c *fCache) removeElement(kv *entry) {
 	delete(c.items, kv.key)
+	c.policy.Remove(kv.key)
 	if c.cacheType == LRUCacheBlockCacheType {
 		cb := kv.value.(*CacheBlock)
 		atomic.AddInt64(&c.allocated, -cb.getAllocSize())
@@ -431,8 +482,7 @@ func (c *fCache) Close() error {
 			}
 		}()
 	}
-	for _, item := range c.items {
-		kv := item.Value.(*entry)
+	for _, kv := range c.items {
 		chanItems <- kv.value
 	}
 	close(chanItems)
@@ -454,8 +504,7 @@ func (c *fCache) GetAllocated() int64 {
 func (c *fCache) GetExpiredTime(key interface{}) (time.Time, bool) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	if ent, ok := c.items[key]; ok {
-		v := ent.Value.(*entry)
+	if v, ok := c.items[key]; ok {
 		return v.expiredAt, true
 	}
 	return time.Time{}, false
@@ -89,6 +89,33 @@ func TestLRUCapacity(t *testing.T) {
 	require.Error(t, c.Close())
 }
 
+func TestLRURateStat(t *testing.T) {
+	// without the ticker of NewCache replacing the recent stat
+	c := &fCache{
+		cacheType:          LRUFileHandleCacheType,
+		capacity:           2,
+		preAllocatedKeyMap: make(map[interface{}]int64),
+		ttl:                time.Hour,
+		policy:             newLRUPolicy(true),
+		hits:               1,
+		recent:             &RateStat{},
+		onDelete:           nilDeleteFunc,
+		onClose:            nilCloseFunc,
+		items:              make(map[interface{}]*entry),
+	}
+	c.Set(1, &CacheBlock{blockKey: "block1"}, 0)
+	c.Set(2, &CacheBlock{blockKey: "block2"}, 0)
+	c.Set(2, &CacheBlock{blockKey: "block2"}, 0)
+	c.Set(3, &CacheBlock{blockKey: "block3"}, 0)
+	require.Zero(t, c.GetRateStat().Inserts)
+	c.replaceRecent()
+	rs := c.GetRateStat()
+	require.Equal(t, int32(3), rs.Inserts)
+	require.Equal(t, int32(1), rs.Evicts)
+	c.replaceRecent()
+	require.Zero(t, c.GetRateStat().Inserts)
+}
+
 func TestLRUExpired(t *testing.T) {
 	c := NewCache(LRUFileHandleCacheType, 2, util.MB*100, time.Hour, nilDeleteFunc, nilCloseFunc)
 	defer c.Close()
@@ -0,0 +1,173 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+type metaEntry struct {
+	key      string
+	data     []byte
+	expireAt time.Time
+}
+
+// metaCache keeps the metadata put by the clients in memory, the lookups and
+// the listings of the directories encoded by them, until their ttl expires or
+// the least recently read ones are evicted to fit in maxBytes. The flash node
+// does not know whether the metadata changed, the clients evict what they
+// change and the others see it within the ttl of the volume.
+type metaCache struct {
+	sync.Mutex
+	maxBytes  int64
+	usedBytes int64
+	lru       *list.List
+	entries   map[string]*list.Element
+
+	hits    uint64
+	misses  uint64
+	puts    uint64
+	evicted uint64
+}
+
+func newMetaCache(maxBytes int64) *metaCache {
+	return &metaCache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (m *metaCache) get(key string, now time.Time) (data []byte, ok bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.Lock()
+	defer m.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		m.misses++
+		return nil, false
+	}
+	me := e.Value.(*metaEntry)
+	if !now.Before(me.expireAt) {
+		m.removeElement(e)
+		m.misses++
+		return nil, false
+	}
+	m.lru.MoveToFront(e)
+	m.hits++
+	return me.data, true
+}
+
+// put replaces the entry of the key, the data are not copied.
+func (m *metaCache) put(key string, data []byte, ttl time.Duration, now time.Time) bool {
+	if m == nil || int64(len(data)) > m.maxBytes {
+		return false
+	}
+	m.Lock()
+	defer m.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.removeElement(e)
+	}
+	for m.usedBytes+int64(len(data)) > m.maxBytes {
+		e := m.lru.Back()
+		if e == nil {
+			break
+		}
+		m.removeElement(e)
+		m.evicted++
+	}
+	m.entries[key] = m.lru.PushFront(&metaEntry{key: key, data: data, expireAt: now.Add(ttl)})
+	m.usedBytes += int64(len(data))
+	m.puts++
+	return true
+}
+
+func (m *metaCache) evict(key string) {
+	if m == nil {
+		return
+	}
+	m.Lock()
+	defer m.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.removeElement(e)
+	}
+}
+
+func (m *metaCache) removeElement(e *list.Element) {
+	me := m.lru.Remove(e).(*metaEntry)
+	delete(m.entries, me.key)
+	m.usedBytes -= int64(len(me.data))
+}
+
+func (m *metaCache) stat() *proto.FlashNodeMetaCacheStat {
+	if m == nil {
+		return &proto.FlashNodeMetaCacheStat{}
+	}
+	m.Lock()
+	defer m.Unlock()
+	return &proto.FlashNodeMetaCacheStat{
+		Enable:    true,
+		MaxBytes:  m.maxBytes,
+		UsedBytes: m.usedBytes,
+		Entries:   len(m.entries),
+		Hits:      m.hits,
+		Misses:    m.misses,
+		Puts:      m.puts,
+		Evicted:   m.evicted,
+	}
+}
+
+// SetMetaCache keeps up to maxBytes of the metadata put by the clients in
+// memory, it is disabled with maxBytes not positive.
+func (c *CacheEngine) SetMetaCache(maxBytes int64) {
+	if maxBytes <= 0 {
+		return
+	}
+	c.metaCache = newMetaCache(maxBytes)
+	log.LogInfof("CacheEngine enable meta cache, maxBytes(%v)", maxBytes)
+}
+
+// GetMetaCacheStat returns the usage of the memory keeping the metadata.
+func (c *CacheEngine) GetMetaCacheStat() *proto.FlashNodeMetaCacheStat {
+	return c.metaCache.stat()
+}
+
+// GetMeta returns the metadata of the key put by a client, false if it is not
+// cached, expired or the meta cache is disabled.
+func (c *CacheEngine) GetMeta(key string) ([]byte, bool) {
+	return c.metaCache.get(key, time.Now())
+}
+
+// PutMeta caches the metadata of the key for the ttl, at most MaxRemoteCacheMetaTTL.
+func (c *CacheEngine) PutMeta(key string, data []byte, ttl time.Duration) bool {
+	if max := time.Duration(proto.MaxRemoteCacheMetaTTL) * time.Second; ttl > max {
+		ttl = max
+	}
+	if ttl <= 0 {
+		return false
+	}
+	return c.metaCache.put(key, data, ttl, time.Now())
+}
+
+func (c *CacheEngine) EvictMeta(key string) {
+	c.metaCache.evict(key)
+}
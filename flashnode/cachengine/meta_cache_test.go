@@ -0,0 +1,74 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaCache(t *testing.T) {
+	now := time.Now()
+	m := newMetaCache(10)
+	require.True(t, m.put("a", []byte("1234"), time.Second, now))
+	require.True(t, m.put("b", []byte("5678"), time.Minute, now))
+	require.False(t, m.put("big", make([]byte, 11), time.Minute, now))
+
+	data, ok := m.get("a", now)
+	require.True(t, ok)
+	require.Equal(t, []byte("1234"), data)
+	_, ok = m.get("a", now.Add(time.Second))
+	require.False(t, ok, "expired")
+
+	// b is the least recently read one and is evicted to fit c
+	require.True(t, m.put("a", []byte("1234"), time.Minute, now))
+	_, ok = m.get("a", now)
+	require.True(t, ok)
+	require.True(t, m.put("c", []byte("9012"), time.Minute, now))
+	_, ok = m.get("b", now)
+	require.False(t, ok)
+
+	m.evict("a")
+	_, ok = m.get("a", now)
+	require.False(t, ok)
+
+	st := m.stat()
+	require.True(t, st.Enable)
+	require.Equal(t, int64(4), st.UsedBytes)
+	require.Equal(t, 1, st.Entries)
+	require.Equal(t, uint64(2), st.Hits)
+	require.Equal(t, uint64(3), st.Misses)
+	require.Equal(t, uint64(1), st.Evicted)
+}
+
+func TestEngineMetaCache(t *testing.T) {
+	c := &CacheEngine{}
+	key := proto.FlashMetaCacheKey("vol", 1, "name")
+	require.False(t, c.PutMeta(key, []byte("data"), time.Minute), "disabled")
+	require.False(t, c.GetMetaCacheStat().Enable)
+
+	c.SetMetaCache(1024)
+	require.False(t, c.PutMeta(key, []byte("data"), 0))
+	require.True(t, c.PutMeta(key, []byte("data"), time.Hour*24))
+	data, ok := c.GetMeta(key)
+	require.True(t, ok)
+	require.Equal(t, []byte("data"), data)
+	c.EvictMeta(key)
+	_, ok = c.GetMeta(key)
+	require.False(t, ok)
+}
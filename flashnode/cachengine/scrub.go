@@ -0,0 +1,138 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultScrubInterval    = 24 * time.Hour
+	DefaultScrubBytesPerSec = 64 * util.MB
+)
+
+// The scrubber verifies the data of the ready cache blocks against the crc kept
+// in their headers round by round, and evicts the corrupt ones so that the next
+// read fetches the data from the datanode again instead of serving the rotted
+// ones. The blocks written before the crc was kept are skipped.
+
+// StartScrubber scrubs the cache blocks every interval, reading up to bytesPerSec
+// of them from the disks not to compete with the reads. It is a no-op with a
+// non-positive interval.
+func (c *CacheEngine) StartScrubber(interval time.Duration, bytesPerSec int64) {
+	if interval <= 0 {
+		log.LogInfof("CacheEngine scrubber disabled")
+		return
+	}
+	if bytesPerSec <= 0 {
+		bytesPerSec = DefaultScrubBytesPerSec
+	}
+	limiter := rate.NewLimiter(rate.Limit(bytesPerSec), util.Max(int(bytesPerSec), proto.CACHE_BLOCK_SIZE))
+	log.LogInfof("CacheEngine start scrubber, interval(%v) bytesPerSec(%v)", interval, bytesPerSec)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.closeCh:
+				return
+			case <-ticker.C:
+				begin := time.Now()
+				scanned, corrupted := c.scrub(limiter)
+				log.LogInfof("action[scrub] scanned(%v) corrupted(%v) blocks cost(%v)", scanned, corrupted, time.Since(begin))
+			}
+		}
+	}()
+}
+
+// scrub verifies the blocks cached when it starts, it returns early once the
+// engine is stopped.
+func (c *CacheEngine) scrub(limiter *rate.Limiter) (scanned, corrupted int) {
+	blocks := make([]*CacheBlock, 0)
+	c.lruCacheMap.Range(func(_, value interface{}) bool {
+		cacheItem := value.(*lruCacheItem)
+		cacheItem.lruCache.Range(func(_, v interface{}, _, _ time.Time) bool {
+			blocks = append(blocks, v.(*CacheBlock))
+			return true
+		})
+		return true
+	})
+	for _, cb := range blocks {
+		select {
+		case <-c.closeCh:
+			return
+		default:
+		}
+		if limiter != nil {
+			_ = limiter.WaitN(context.Background(), int(cb.getUsedSize()))
+		}
+		corrupt, err := cb.verifyChecksum()
+		if err != nil {
+			log.LogWarnf("action[scrub] verify block(%v) failed: %v", cb.blockKey, err)
+			continue
+		}
+		scanned++
+		if !corrupt {
+			continue
+		}
+		corrupted++
+		atomic.AddUint64(&c.scrubCorrupted, 1)
+		// the block may have been evicted and cached again since
+		if cached, err := c.PeekCacheBlock(cb.blockKey); err == nil && cached == cb {
+			c.deleteCacheBlock(cb.blockKey)
+		}
+		log.LogErrorf("action[scrub] block(%v) crc mismatch, evicted", cb.blockKey)
+		auditlog.LogFlashNodeOp("BlockScrub", fmt.Sprintf("evict corrupt block %v", cb.info()), nil)
+	}
+	atomic.AddUint64(&c.scrubbed, uint64(scanned))
+	return
+}
+
+// verifyChecksum tells if the data of the block do not match the crc in its
+// header. The blocks not ready yet or with no crc are taken as intact.
+func (cb *CacheBlock) verifyChecksum() (corrupt bool, err error) {
+	select {
+	case <-cb.readyCh:
+	default:
+		return false, nil
+	}
+	if cb.checksum&checksumFlag == 0 {
+		return false, nil
+	}
+	file, err := cb.GetOrOpenFileHandler()
+	if err != nil {
+		return
+	}
+	crc, err := cb.dataChecksum(file)
+	if err != nil {
+		return
+	}
+	return crc != uint32(cb.checksum), nil
+}
+
+// GetScrubStat returns the blocks verified and the corrupt ones found by the
+// scrubber since the engine started.
+func (c *CacheEngine) GetScrubStat() (scrubbed, corrupted uint64) {
+	return atomic.LoadUint64(&c.scrubbed), atomic.LoadUint64(&c.scrubCorrupted)
+}
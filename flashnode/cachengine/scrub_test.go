@@ -0,0 +1,86 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cachengine
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineScrub(t *testing.T) {
+	require.NoError(t, os.MkdirAll(testTmpFS, 0o755))
+	disk := &Disk{Path: testTmpFS, TotalSpace: 200 * util.MB, Capacity: 1024, Status: proto.ReadWrite}
+	var ce *CacheEngine
+	var err error
+	if !enabledTmpfs() {
+		ce, err = NewCacheEngine("", 0, DefaultCacheMaxUsedRatio, []*Disk{disk}, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	} else {
+		ce, err = NewCacheEngine(testTmpFS, 200*util.MB, DefaultCacheMaxUsedRatio, []*Disk{disk}, 1024, 1024, 0, 10, 10, nil, DefaultExpireTime, nil, enabledTmpfs(), "")
+	}
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ce.Stop()) }()
+
+	newBlock := func(inode uint64, ready bool) *CacheBlock {
+		cb, err := ce.createCacheBlock(t.Name(), inode, 0, 1, DefaultExpireTime, proto.CACHE_BLOCK_SIZE, "", false)
+		require.NoError(t, err)
+		require.NoError(t, cb.WriteAt(randTestData(4096), 0, 4096))
+		if ready {
+			file, err := cb.GetOrOpenFileHandler()
+			require.NoError(t, err)
+			require.NoError(t, cb.writeCacheBlockFileHeader(file))
+			require.NotZero(t, cb.checksum&checksumFlag)
+			cb.notifyReady()
+		}
+		return cb
+	}
+	intact := newBlock(1, true)
+	corrupt := newBlock(2, true)
+	filling := newBlock(3, false)
+
+	file, err := corrupt.GetOrOpenFileHandler()
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte{0xde, 0xad}, HeaderSize+100)
+	require.NoError(t, err)
+
+	scanned, corrupted := ce.scrub(nil)
+	require.Equal(t, 3, scanned)
+	require.Equal(t, 1, corrupted)
+	scrubbed, total := ce.GetScrubStat()
+	require.Equal(t, uint64(3), scrubbed)
+	require.Equal(t, uint64(1), total)
+
+	// only the corrupt block is evicted along with its file
+	_, err = ce.PeekCacheBlock(corrupt.blockKey)
+	require.Error(t, err)
+	require.False(t, corrupt.Exist())
+	for _, cb := range []*CacheBlock{intact, filling} {
+		cached, err := ce.PeekCacheBlock(cb.blockKey)
+		require.NoError(t, err)
+		require.Equal(t, cb, cached)
+	}
+
+	// the crc is kept in the header of the block loaded again
+	file, err = intact.GetOrOpenFileHandler()
+	require.NoError(t, err)
+	_, err = file.Seek(0, 0)
+	require.NoError(t, err)
+	_, _, checksum, _, err := intact.checkCacheBlockFileHeader(file)
+	require.NoError(t, err)
+	require.Equal(t, intact.checksum, checksum)
+}
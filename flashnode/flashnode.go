@@ -15,6 +15,7 @@
 package flashnode
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"time"
 
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
 
 	"github.com/cubefs/cubefs/cmd/common"
 	"github.com/cubefs/cubefs/flashnode/cachengine"
@@ -74,6 +76,8 @@ const (
 	_defaultManualScanLimitBurst           = 1000
 	_slotStatValidPeriod                   = 10 * time.Minute // min
 	_defaultPrepareRoutineNum              = 20
+	_defaultKeysLimit                      = 1000
+	_defaultOfflineGraceSec                = 65 // the clients refresh the flash groups every minute
 )
 
 // Configuration keys
@@ -107,6 +111,16 @@ const (
 	cfgPrepareLimitPerSecond        = "prepareLimitPerSecond"
 	cfgWaitForBlockCache            = "waitForBlockCache"
 	cfgPrepareLoadRoutineNum        = "prepareLoadRoutineNum"
+	cfgHotTierSize                  = "hotTierSize"        // int64, bytes
+	cfgHotTierPromoteHits           = "hotTierPromoteHits" // int
+	cfgEvictPolicy                  = "evictPolicy"        // string
+	cfgVolEvictPolicy               = "volEvictPolicy"     // string slice, VOLUME:POLICY
+	cfgOfflineGraceSec              = "offlineGraceSec"    // int, negative to exit at once
+	cfgScrubIntervalSec             = "scrubIntervalSec"   // int, negative to disable
+	cfgScrubBytesPerSec             = "scrubBytesPerSec"   // int64
+	cfgMetaCacheSize                = "metaCacheSize"      // int64, bytes
+	cfgGrpcPort                     = "grpcPort"           // string, empty to disable
+	cfgTLSCAFile                    = "tlsCAFile"          // string, the CA to verify the peers with, plain to the peers if empty
 	paramIocc                       = "iocc"
 	paramFlow                       = "flow"
 	paramFactor                     = "factor"
@@ -117,6 +131,7 @@ type FlashNode struct {
 	// from configuration
 	logDir                      string
 	listen                      string
+	grpcPort                    string
 	zoneName                    string
 	memTotal                    uint64
 	lruCapacity                 int
@@ -141,13 +156,34 @@ type FlashNode struct {
 	tcpListener net.Listener
 	cacheEngine *cachengine.CacheEngine
 
+	// serves the cache over gRPC if grpcPort is set
+	grpcListener net.Listener
+	grpcServer   *grpc.Server
+
+	// the clients are served over TLS if set, the gRPC ones only over TLS
+	tlsConfig     *tls.Config
+	peerTLSConfig *tls.Config
+
 	metrics      *FlashNodeMetrics
 	readRps      int
 	readLimiter  *rate.Limiter
 	lowerHitRate float64
 	enableTmpfs  bool
 
+	// the memory kept for the blocks read the most in front of the disks
+	hotTierSize        int64
+	hotTierPromoteHits int
+	// the memory kept for the metadata put by the clients
+	metaCacheSize int64
+
+	// the eviction policy of the cache blocks, and the ones of the volumes evicted differently
+	evictPolicy    string
+	volEvictPolicy map[string]string
+
 	handleReadTimeout     int
+	offlineGraceSec       int
+	scrubIntervalSec      int
+	scrubBytesPerSec      int64
 	diskWriteIocc         int
 	diskWriteFlow         int
 	diskWriteIoFactorFlow int
@@ -169,8 +205,25 @@ type FlashNode struct {
 	waitForCacheBlock            bool
 	prepareLoadRoutineNum        int
 
-	slotMap   sync.Map // [uint32]*SlotStat
-	readCount uint64
+	slotMap      sync.Map // [uint32]*SlotStat
+	readCount    uint64
+	volReadStats sync.Map // [string]*volReadStat
+
+	// pushed by master heartbeat, read the missed blocks from the flash group peers first
+	peerFillLock    sync.RWMutex
+	peerFillEnable  bool
+	peerFillTimeout int
+	peers           []string
+	peerFillBytes   uint64
+
+	// pushed by master heartbeat, the flash group is removed soon and caches nothing more
+	draining int32
+
+	// pushed by master heartbeat, the reads served per volume and by the whole node
+	readLimits *readLimits
+
+	// pushed by master heartbeat, *cacheAuth
+	cacheAuth atomic.Value
 }
 
 // Start starts up the flash node with the specified configuration.
@@ -210,6 +263,7 @@ func doShutdown(s common.Server) {
 	if !ok {
 		return
 	}
+	f.leave()
 	f.shutdown()
 }
 
@@ -224,6 +278,7 @@ func (f *FlashNode) start(cfg *config.Config) (err error) {
 	f.initLimiter()
 	initExtentConnPool()
 	f.connPool = util.NewConnectPoolWithTimeout(_connPoolIdleTimeout, 1)
+	f.connPool.SetTLSConfig(f.peerTLSConfig)
 	if err = f.startCacheEngine(); err != nil {
 		return
 	}
@@ -231,6 +286,9 @@ func (f *FlashNode) start(cfg *config.Config) (err error) {
 	if err = f.startTcpServer(); err != nil {
 		return
 	}
+	if err = f.startGrpcServer(); err != nil {
+		return
+	}
 
 	_, err = stat.NewStatistic(f.logDir, Stat, int64(stat.DefaultStatLogSize),
 		stat.DefaultTimeOutUs, true)
@@ -248,6 +306,7 @@ func (f *FlashNode) shutdown() {
 	})
 	// shutdown node and release the resource
 	f.stopServer()
+	f.stopGrpcServer()
 	f.stopCacheEngine()
 }
 
@@ -260,6 +319,17 @@ func (f *FlashNode) parseConfig(cfg *config.Config) (err error) {
 	if f.listen == "" {
 		return errors.New("bad listen config")
 	}
+	f.grpcPort = strings.TrimSpace(cfg.GetString(cfgGrpcPort))
+	if certFile := cfg.GetString(proto.TLSCertFileKey); certFile != "" {
+		if f.tlsConfig, err = util.NewServerTLSConfig(certFile, cfg.GetString(proto.TLSKeyFileKey)); err != nil {
+			return fmt.Errorf("parseConfig: load tls certificate: %v", err)
+		}
+	}
+	if caFile := cfg.GetString(cfgTLSCAFile); caFile != "" {
+		if f.peerTLSConfig, err = util.NewClientTLSConfig(caFile); err != nil {
+			return fmt.Errorf("parseConfig: load tls CA: %v", err)
+		}
+	}
 	if f.zoneName = cfg.GetString(cfgZoneName); f.zoneName == "" {
 		return errors.New("bad zoneName config")
 	}
@@ -410,7 +480,15 @@ func (f *FlashNode) parseConfig(cfg *config.Config) (err error) {
 	f.cacheEvictWorkerNum = cacheEvictWorkerNum
 	f.lowerHitRate = cfg.GetFloat(cfgLowerHitRate)
 	f.waitForCacheBlock = cfg.GetBoolWithDefault(cfgWaitForBlockCache, false)
+	if !f.enableTmpfs {
+		f.hotTierSize = cfg.GetInt64(cfgHotTierSize)
+		f.hotTierPromoteHits = cfg.GetInt(cfgHotTierPromoteHits)
+		if f.hotTierPromoteHits <= 0 {
+			f.hotTierPromoteHits = cachengine.DefaultHotTierPromoteHits
+		}
+	}
 	log.LogInfof("[parseConfig] load listen[%s].", f.listen)
+	log.LogInfof("[parseConfig] load grpcPort[%s].", f.grpcPort)
 	log.LogInfof("[parseConfig] load zoneName[%s].", f.zoneName)
 	log.LogInfof("[parseConfig] load totalMem[%d].", f.memTotal)
 	log.LogInfof("[parseConfig] load lruCapacity[%d].", f.lruCapacity)
@@ -422,6 +500,32 @@ func (f *FlashNode) parseConfig(cfg *config.Config) (err error) {
 	log.LogInfof("[parseConfig] load  lowerHitRate[%.2f].", f.lowerHitRate)
 	log.LogInfof("[parseConfig] load  enableTmpfs[%v].", f.enableTmpfs)
 	log.LogInfof("[parseConfig] load  memDataPath[%v].", f.memDataPath)
+	log.LogInfof("[parseConfig] load  hotTierSize[%v] hotTierPromoteHits[%v].", f.hotTierSize, f.hotTierPromoteHits)
+	f.metaCacheSize = cfg.GetInt64(cfgMetaCacheSize)
+	log.LogInfof("[parseConfig] load  metaCacheSize[%v].", f.metaCacheSize)
+	if f.evictPolicy, err = cachengine.EvictPolicyName(cfg.GetString(cfgEvictPolicy)); err != nil {
+		return
+	}
+	f.volEvictPolicy = make(map[string]string)
+	for _, p := range cfg.GetSlice(cfgVolEvictPolicy) {
+		arr := strings.Split(p.(string), ":")
+		if len(arr) != 2 {
+			return errors.New("invalid volume evict policy configuration. Example: VOLUME:POLICY")
+		}
+		if f.volEvictPolicy[arr[0]], err = cachengine.EvictPolicyName(arr[1]); err != nil {
+			return
+		}
+	}
+	log.LogInfof("[parseConfig] load  evictPolicy[%v] volEvictPolicy[%v].", f.evictPolicy, f.volEvictPolicy)
+	if f.offlineGraceSec = cfg.GetInt(cfgOfflineGraceSec); f.offlineGraceSec == 0 {
+		f.offlineGraceSec = _defaultOfflineGraceSec
+	}
+	log.LogInfof("[parseConfig] load offlineGraceSec[%v].", f.offlineGraceSec)
+	if f.scrubIntervalSec = cfg.GetInt(cfgScrubIntervalSec); f.scrubIntervalSec == 0 {
+		f.scrubIntervalSec = int(cachengine.DefaultScrubInterval / time.Second)
+	}
+	f.scrubBytesPerSec = cfg.GetInt64(cfgScrubBytesPerSec)
+	log.LogInfof("[parseConfig] load scrubIntervalSec[%v] scrubBytesPerSec[%v].", f.scrubIntervalSec, f.scrubBytesPerSec)
 	for _, d := range f.disks {
 		log.LogInfof("[parseConfig] load diskDataPath[%v] totalSize[%d] capacity[%d]", d.Path, d.TotalSpace, d.Capacity)
 	}
@@ -495,12 +599,24 @@ func (f *FlashNode) startCacheEngine() (err error) {
 		return
 	}
 	f.SetTimeout(proto.DefaultRemoteCacheHandleReadTimeout, proto.DefaultRemoteCacheExtentReadTimeout)
+	f.cacheEngine.SetReadPeerFunc(f.readFromPeers)
+	f.cacheEngine.SetHotTier(f.hotTierSize, f.hotTierPromoteHits)
+	f.cacheEngine.SetMetaCache(f.metaCacheSize)
+	if err = f.cacheEngine.SetEvictPolicy(f.evictPolicy, f.volEvictPolicy); err != nil {
+		log.LogErrorf("startCacheEngine set evict policy failed:%v", err)
+		return
+	}
 	f.cacheEngine.StartCachePrepareWorkers(f.limitWrite, f.prepareLoadRoutineNum)
-	return f.cacheEngine.Start()
+	if err = f.cacheEngine.Start(); err != nil {
+		return
+	}
+	f.cacheEngine.StartScrubber(time.Duration(f.scrubIntervalSec)*time.Second, f.scrubBytesPerSec)
+	return
 }
 
 func (f *FlashNode) initLimiter() {
 	f.readLimiter = rate.NewLimiter(rate.Limit(f.readRps), 2*f.readRps)
+	f.readLimits = newReadLimits()
 }
 
 func (f *FlashNode) register() error {
@@ -541,6 +657,21 @@ func (f *FlashNode) register() error {
 	}
 }
 
+// leave tells master the flash node is shutting down, which removes it from its
+// flash group, then keeps serving the reads of the clients for offlineGraceSec
+// so that they get the flash groups without it before the node exits.
+func (f *FlashNode) leave() {
+	if f.nodeID == 0 || f.offlineGraceSec < 0 {
+		return
+	}
+	if err := f.mc.NodeAPI().OfflineFlashNode(f.localAddr); err != nil {
+		log.LogErrorf("action[leave] flashnode(%v) cannot offline from master err(%v), exit at once", f.localAddr, err)
+		return
+	}
+	log.LogWarnf("action[leave] flashnode(%v) left its flash group, serve reads for %vs before exit", f.localAddr, f.offlineGraceSec)
+	time.Sleep(time.Duration(f.offlineGraceSec) * time.Second)
+}
+
 func (f *FlashNode) respondToMaster(task *proto.AdminTask) {
 	go func() {
 		// handle panic
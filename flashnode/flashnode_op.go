@@ -22,6 +22,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cubefs/cubefs/flashnode/cachengine"
@@ -31,12 +32,14 @@ import (
 	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/faultinject"
 	"github.com/cubefs/cubefs/util/log"
 	"github.com/cubefs/cubefs/util/stat"
 )
 
 func (f *FlashNode) preHandle(conn net.Conn, p *proto.Packet) error {
-	if (p.Opcode == proto.OpFlashNodeCacheRead || p.Opcode == proto.OpFlashNodeCachePrepare) && !f.readLimiter.Allow() {
+	if (p.Opcode == proto.OpFlashNodeCacheRead || p.Opcode == proto.OpFlashNodeCachePeerRead ||
+		p.Opcode == proto.OpFlashNodeCachePrepare) && !f.readLimiter.Allow() {
 		metric := exporter.NewTPCnt("NodeReqLimit")
 		metric.Set(nil)
 		err := errors.NewErrorf("%s", "flashnode read request was been limited")
@@ -46,6 +49,30 @@ func (f *FlashNode) preHandle(conn net.Conn, p *proto.Packet) error {
 	return nil
 }
 
+// injectFault applies the faults injected into the cache ops, which are not
+// of a partition or a disk. It returns true if the op is not handled.
+func (f *FlashNode) injectFault(conn net.Conn, p *proto.Packet) bool {
+	if !faultinject.Active() {
+		return false
+	}
+	switch p.Opcode {
+	case proto.OpFlashNodeCachePrepare, proto.OpFlashNodeCacheRead, proto.OpFlashNodeCachePeerRead:
+	default:
+		return false
+	}
+	switch faultinject.Inject(0, "") {
+	case faultinject.Drop:
+		return true
+	case faultinject.EIO:
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(faultinject.ErrEIO.Error()))
+		if err := p.WriteToConn(conn); err != nil {
+			log.LogErrorf("action[injectFault] write to conn %v", err)
+		}
+		return true
+	}
+	return false
+}
+
 func (f *FlashNode) handlePacket(conn net.Conn, p *proto.Packet) (err error) {
 	switch p.Opcode {
 	case proto.OpFlashSDKHeartbeat:
@@ -56,6 +83,10 @@ func (f *FlashNode) handlePacket(conn net.Conn, p *proto.Packet) (err error) {
 		err = f.opCachePrepare(conn, p)
 	case proto.OpFlashNodeCacheRead:
 		err = f.opCacheRead(conn, p)
+	case proto.OpFlashNodeCachePeerRead:
+		err = f.opCachePeerRead(conn, p)
+	case proto.OpFlashNodeCacheEvict:
+		err = f.opCacheEvict(conn, p)
 	case proto.OpFlashNodeSetReadIOLimits:
 		err = f.opSetReadIOLimits(conn, p)
 	case proto.OpFlashNodeSetWriteIOLimits:
@@ -64,6 +95,8 @@ func (f *FlashNode) handlePacket(conn net.Conn, p *proto.Packet) (err error) {
 		err = f.opFlashNodeScan(conn, p)
 	case proto.OpFlashNodeTaskCommand:
 		err = f.opFlashNodeTaskCommand(conn, p)
+	case proto.OpFlashNodeMetaGet, proto.OpFlashNodeMetaPut, proto.OpFlashNodeMetaEvict:
+		err = f.opMetaCache(conn, p)
 	default:
 		err = fmt.Errorf("unknown Opcode:%d", p.Opcode)
 	}
@@ -81,6 +114,20 @@ func (f *FlashNode) SetTimeout(handleReadTimeout int, readDataNodeTimeout int) {
 	f.cacheEngine.SetReadDataNodeTimeout(readDataNodeTimeout)
 }
 
+func (f *FlashNode) SetDraining(draining bool) {
+	var v int32
+	if draining {
+		v = 1
+	}
+	if old := atomic.SwapInt32(&f.draining, v); old != v {
+		log.LogInfof("FlashNode set draining from %v to %v", old == 1, draining)
+	}
+}
+
+func (f *FlashNode) isDraining() bool {
+	return atomic.LoadInt32(&f.draining) == 1
+}
+
 func (f *FlashNode) opClientHeartbeat(conn net.Conn, p *proto.Packet) (err error) {
 	p.PacketOkReply()
 	if err = p.WriteToConn(conn); err != nil {
@@ -97,6 +144,7 @@ func (f *FlashNode) opFlashNodeHeartbeat(conn net.Conn, p *proto.Packet) (err er
 	go responseAckOKToMaster(conn, p)
 	req := &proto.HeartBeatRequest{}
 	resp := &proto.FlashNodeHeartbeatResponse{}
+	resp.Clock.RecvTime = time.Now().UnixNano()
 	adminTask := &proto.AdminTask{
 		Request: req,
 	}
@@ -104,6 +152,11 @@ func (f *FlashNode) opFlashNodeHeartbeat(conn net.Conn, p *proto.Packet) (err er
 	decode.UseNumber()
 	if err = decode.Decode(adminTask); err == nil {
 		f.SetTimeout(req.FlashNodeHandleReadTimeout, req.FlashNodeReadDataNodeTimeout)
+		f.SetPeerFill(req.FlashNodePeerFillEnable, req.FlashNodePeerFillTimeout, req.FlashNodePeers)
+		f.cacheEngine.SetAdmission(req.FlashNodeAdmissionEnable, req.FlashNodeAlwaysAdmitVols)
+		f.SetDraining(req.FlashNodeDraining)
+		f.SetReadLimits(req.FlashNodeReadLimit, req.FlashNodeVolReadLimits)
+		f.SetCacheAuth(req.FlashNodeCacheKey, req.FlashNodeAuthVols, req.TLSRequiredVols)
 	} else {
 		log.LogWarnf("decode HeartBeatRequest error: %s", err.Error())
 		resp.Status = proto.TaskFailed
@@ -112,6 +165,7 @@ func (f *FlashNode) opFlashNodeHeartbeat(conn net.Conn, p *proto.Packet) (err er
 	}
 
 	resp.Stat = make([]*proto.FlashNodeDiskCacheStat, 0)
+	resp.CacheStat = new(proto.FlashNodeCacheStat)
 	for _, cacheStat := range f.cacheEngine.GetHeartBeatCacheStat() {
 		cacheStat := &proto.FlashNodeDiskCacheStat{
 			DataPath:  cacheStat.DataPath,
@@ -121,13 +175,18 @@ func (f *FlashNode) opFlashNodeHeartbeat(conn net.Conn, p *proto.Packet) (err er
 			HasAlloc:  cacheStat.HasAlloc,
 			FreeSpace: cacheStat.MaxAlloc - cacheStat.HasAlloc,
 			HitRate:   cacheStat.HitRate,
+			Hits:      cacheStat.Hits,
+			Misses:    cacheStat.Misses,
 			Evicts:    cacheStat.Evicts,
 			ReadRps:   f.readRps,
 			KeyNum:    cacheStat.Num,
 			Status:    cacheStat.Status,
 		}
 		resp.Stat = append(resp.Stat, cacheStat)
+		resp.CacheCapacity += cacheStat.MaxAlloc
+		resp.CacheStat.AddDisk(cacheStat)
 	}
+	resp.CacheRecovery = f.cacheEngine.GetCacheRecovery()
 	resp.LimiterStatus = &proto.FlashNodeLimiterStatusInfo{
 		WriteStatus: proto.FlashNodeLimiterStatus{Status: f.limitWrite.Status(true), DiskNum: len(f.disks), ReadTimeout: f.handleReadTimeout},
 		ReadStatus:  proto.FlashNodeLimiterStatus{Status: f.limitRead.Status(true), DiskNum: len(f.disks), ReadTimeout: f.handleReadTimeout},
@@ -143,6 +202,7 @@ func (f *FlashNode) opFlashNodeHeartbeat(conn net.Conn, p *proto.Packet) (err er
 	})
 	resp.Status = proto.TaskSucceeds
 end:
+	resp.Clock.ReplyTime = time.Now().UnixNano()
 	adminTask.Response = resp
 	f.respondToMaster(adminTask)
 	if log.EnableInfo() {
@@ -185,67 +245,251 @@ func (f *FlashNode) opCacheRead(conn net.Conn, p *proto.Packet) (err error) {
 	}
 
 	volume = req.CacheRequest.Volume
-	cr := req.CacheRequest
+	if err = f.checkCacheAuth(volume, packetToken(p), util.IsTLSConn(conn)); err != nil {
+		return
+	}
+	f.updateSlotStat(req.CacheRequest.Slot)
+	if err = f.limitVolRead(volume, req.Size_); err != nil {
+		return
+	}
 
-	f.updateSlotStat(cr.Slot)
+	var (
+		block *cachengine.CacheBlock
+		hit   bool
+	)
+	tp := exporter.NewTP(MetricFlashNodeCacheReadLatency)
+	defer func() {
+		f.updateCacheReadStat(tp, volume, hit, req.Size_, err)
+	}()
+	if block, hit, err = f.getBlockForRead(ctx, req, conn.RemoteAddr().String()); err != nil {
+		return
+	}
 
-	block, err := f.cacheEngine.GetCacheBlockForRead(volume, cr.Inode, cr.FixedFileOffset, cr.Version, req.Size_)
-	if err != nil {
-		hitRateMap := f.cacheEngine.GetHitRate()
-		for dataPath, hitRate := range hitRateMap {
-			if hitRate < f.lowerHitRate {
-				log.LogDebugf("opCacheRead: flashnode %v dataPath(%v) is lower hitrate %v", f.localAddr, dataPath, hitRate)
-				errMetric := exporter.NewCounter("lowerHitRate")
-				errMetric.AddWithLabels(1, map[string]string{exporter.FlashNode: f.localAddr, exporter.Disk: dataPath, exporter.Err: "LowerHitRate"})
-			}
-		}
-		bgTime2 := stat.BeginStat()
-		missTaskDone := make(chan struct{})
-		// try to cache more miss data, but reply to client more quickly
-		reqSize := 0
-		for _, source := range req.CacheRequest.Sources {
-			reqSize += int(source.Size_)
+	bgTime2 := stat.BeginStat()
+	// reply to client as quick as possible if hit cache
+	err2 := f.limitRead.RunNoWait(int(req.Size_), false, func() {
+		err = f.doStreamReadRequest(ctx, conn, req, p, block)
+	})
+	if err2 != nil {
+		err = err2
+		stat.EndStat("HitCacheRead", err, bgTime2, 1)
+		return
+	}
+	stat.EndStat("HitCacheRead", err, bgTime2, 1)
+	return
+}
+
+// getBlockForRead returns the cache block of the read request. On a miss it caches the
+// data of the request, and waits for it only if waitForCacheBlock is set.
+func (f *FlashNode) getBlockForRead(ctx context.Context, req *proto.CacheReadRequest, remoteAddr string) (
+	block *cachengine.CacheBlock, hit bool, err error,
+) {
+	cr := req.CacheRequest
+	volume := cr.Volume
+	if block, err = f.cacheEngine.GetCacheBlockForRead(volume, cr.Inode, cr.FixedFileOffset, cr.Version, req.Size_); err == nil {
+		return block, true, nil
+	}
+	if f.isDraining() {
+		// the client reads the datanode instead
+		return nil, false, proto.ErrFlashGroupDraining
+	}
+	hitRateMap := f.cacheEngine.GetHitRate()
+	for dataPath, hitRate := range hitRateMap {
+		if hitRate < f.lowerHitRate {
+			log.LogDebugf("opCacheRead: flashnode %v dataPath(%v) is lower hitrate %v", f.localAddr, dataPath, hitRate)
+			errMetric := exporter.NewCounter("lowerHitRate")
+			errMetric.AddWithLabels(1, map[string]string{exporter.FlashNode: f.localAddr, exporter.Disk: dataPath, exporter.Err: "LowerHitRate"})
 		}
-		if err = f.limitWrite.TryRunAsync(ctx, reqSize, f.waitForCacheBlock, func() {
-			if block2, err2 := f.cacheEngine.CreateBlock(cr, conn.RemoteAddr().String(), false); err2 != nil {
+	}
+	bgTime2 := stat.BeginStat()
+	missTaskDone := make(chan struct{})
+	var createErr error
+	// try to cache more miss data, but reply to client more quickly
+	reqSize := 0
+	for _, source := range cr.Sources {
+		reqSize += int(source.Size_)
+	}
+	if err = f.limitWrite.TryRunAsync(ctx, reqSize, f.waitForCacheBlock, func() {
+		if block2, err2 := f.cacheEngine.CreateBlock(cr, remoteAddr, false); err2 != nil {
+			if err2 != proto.ErrFlashNodeNotAdmitted {
 				log.LogWarnf("opCacheRead: CreateBlock failed, req(%v) err(%v)", req, err2)
-				close(missTaskDone)
-				return
-			} else {
-				block2.InitOnceForCacheRead(f.cacheEngine, cr.Sources, missTaskDone)
 			}
-		}); err != nil {
-			stat.EndStat("MissCacheReadLimit", err, bgTime2, 1)
+			createErr = err2
+			close(missTaskDone)
 			return
+		} else {
+			block2.InitOnceForCacheRead(f.cacheEngine, cr.Sources, missTaskDone)
 		}
-		if !f.waitForCacheBlock {
-			stat.EndStat("MissCacheRead:Data is caching", nil, bgTime2, 1)
-			return fmt.Errorf("require data is caching")
+	}); err != nil {
+		stat.EndStat("MissCacheReadLimit", err, bgTime2, 1)
+		return
+	}
+	if !f.waitForCacheBlock {
+		stat.EndStat("MissCacheRead:Data is caching", nil, bgTime2, 1)
+		return nil, false, fmt.Errorf("require data is caching")
+	}
+	select {
+	case <-ctx.Done():
+		stat.EndStat("MissCacheReadCancel", ctx.Err(), bgTime2, 1)
+		return nil, false, ctx.Err()
+	case <-missTaskDone:
+		if createErr == proto.ErrFlashNodeNotAdmitted {
+			// the client reads the datanode instead
+			stat.EndStat("MissCacheRead:NotAdmitted", nil, bgTime2, 1)
+			return nil, false, createErr
 		}
-		select {
-		case <-ctx.Done():
-			stat.EndStat("MissCacheReadCancel", ctx.Err(), bgTime2, 1)
-			return ctx.Err()
-		case <-missTaskDone:
-			block, err = f.cacheEngine.GetCacheBlockForRead(volume, cr.Inode, cr.FixedFileOffset, cr.Version, req.Size_)
+		block, err = f.cacheEngine.GetCacheBlockForRead(volume, cr.Inode, cr.FixedFileOffset, cr.Version, req.Size_)
+	}
+	stat.EndStat("MissCacheRead", err, bgTime2, 1)
+	return
+}
+
+func (f *FlashNode) updateCacheReadStat(tp *exporter.TimePoint, volume string, hit bool, size uint64, err error) {
+	f.updateVolReadStat(volume, hit, size, err)
+	if err == nil {
+		result := "hit"
+		if !hit {
+			result = "miss"
 		}
-		stat.EndStat("MissCacheRead", err, bgTime2, 1)
+		tp.SetWithLabels(map[string]string{"cluster": f.clusterID, exporter.FlashNode: f.localAddr, exporter.Vol: volume, "result": result})
+	}
+}
+
+// opCachePeerRead serves the blocks to the other members of the flash group. Unlike opCacheRead
+// it never caches on miss, the requester reads from the datanode instead.
+func (f *FlashNode) opCachePeerRead(conn net.Conn, p *proto.Packet) (err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("FlashNode:opCachePeerRead", err, bgTime, 1)
+	}()
+
+	defer func() {
 		if err != nil {
-			return err
+			if log.EnableDebug() {
+				log.LogDebugf("action[opCachePeerRead] logMsg:%s",
+					p.LogMessage(p.GetOpMsg(), conn.RemoteAddr().String(), p.StartT, err))
+			}
+			p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+			if e := p.WriteToConn(conn); e != nil {
+				log.LogErrorf("action[opCachePeerRead] write to conn %v", e)
+			}
 		}
-	}
+	}()
 
-	bgTime2 := stat.BeginStat()
-	// reply to client as quick as possible if hit cache
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Duration(f.handleReadTimeout)*time.Millisecond)
+	defer ctxCancel()
+
+	req := new(proto.CacheReadRequest)
+	if err = p.UnmarshalDataPb(req); err != nil {
+		return
+	}
+	if req.CacheRequest == nil {
+		err = fmt.Errorf("no cache read request")
+		return
+	}
+	cr := req.CacheRequest
+	if err = f.checkCacheAuth(cr.Volume, packetToken(p), util.IsTLSConn(conn)); err != nil {
+		return
+	}
+	if err = f.limitVolRead(cr.Volume, req.Size_); err != nil {
+		return
+	}
+	// peek so that the reads of peers do not count in the hit rate and lru of the block
+	block, err := f.cacheEngine.PeekCacheBlock(cachengine.GenCacheBlockKey(cr.Volume, cr.Inode, cr.FixedFileOffset, cr.Version))
+	if err != nil {
+		return
+	}
+	if !block.Covers(int64(req.Offset), int64(req.Size_)) {
+		err = fmt.Errorf("cache block(%v) does not hold offset(%v) size(%v)", block.String(), req.Offset, req.Size_)
+		return
+	}
 	err2 := f.limitRead.RunNoWait(int(req.Size_), false, func() {
 		err = f.doStreamReadRequest(ctx, conn, req, p, block)
 	})
 	if err2 != nil {
 		err = err2
-		stat.EndStat("HitCacheRead", err, bgTime2, 1)
+	}
+	return
+}
+
+// opCacheEvict evicts the blocks of a file the client overwrote, so that the next reads
+// cache them again from the datanode. It is never limited, a dropped eviction serves stale data.
+func (f *FlashNode) opCacheEvict(conn net.Conn, p *proto.Packet) (err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("FlashNode:opCacheEvict", err, bgTime, 1)
+		if err != nil {
+			log.LogWarnf("action[opCacheEvict] logMsg:%s",
+				p.LogMessage(p.GetOpMsg(), conn.RemoteAddr().String(), p.StartT, err))
+			p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		} else {
+			p.PacketOkReply()
+		}
+		if e := p.WriteToConn(conn); e != nil {
+			log.LogErrorf("action[opCacheEvict] write to conn %v", e)
+		}
+	}()
+
+	req := new(proto.CacheEvictRequest)
+	if err = p.UnmarshalData(req); err != nil {
 		return
 	}
-	stat.EndStat("HitCacheRead", err, bgTime2, 1)
+	evicted := f.cacheEngine.EvictCacheBlocks(req.Volume, req.Inode, req.Offsets)
+	if log.EnableDebug() {
+		log.LogDebugf("action[opCacheEvict] volume(%v) inode(%v) offsets(%v) evicted(%v) from(%v)",
+			req.Volume, req.Inode, req.Offsets, evicted, conn.RemoteAddr())
+	}
+	return
+}
+
+// opMetaCache gets, puts or evicts the metadata of a directory cached for the
+// clients. The request is in the arg of the packet, the metadata put or got
+// in the data. A get missed is replied with OpNotExistErr.
+func (f *FlashNode) opMetaCache(conn net.Conn, p *proto.Packet) (err error) {
+	bgTime := stat.BeginStat()
+	req := new(proto.FlashMetaCacheRequest)
+	defer func() {
+		stat.EndStat("FlashNode:"+p.GetOpMsg(), err, bgTime, 1)
+		if err != nil {
+			log.LogWarnf("action[opMetaCache] volume(%v) parent(%v) logMsg:%s", req.Volume, req.Parent,
+				p.LogMessage(p.GetOpMsg(), conn.RemoteAddr().String(), p.StartT, err))
+			p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		}
+		if e := p.WriteToConn(conn); e != nil {
+			log.LogErrorf("action[opMetaCache] write to conn %v", e)
+		}
+	}()
+
+	if err = json.Unmarshal(p.Arg[:p.ArgLen], req); err != nil {
+		return
+	}
+	if err = f.checkCacheAuth(req.Volume, req.Token, util.IsTLSConn(conn)); err != nil {
+		return
+	}
+	if p.Opcode != proto.OpFlashNodeMetaEvict && len(req.Names) != 1 {
+		return fmt.Errorf("%v names(%v) not one", p.GetOpMsg(), len(req.Names))
+	}
+	switch p.Opcode {
+	case proto.OpFlashNodeMetaGet:
+		data, ok := f.cacheEngine.GetMeta(proto.FlashMetaCacheKey(req.Volume, req.Parent, req.Names[0]))
+		if !ok {
+			p.PacketErrorWithBody(proto.OpNotExistErr, nil)
+			return
+		}
+		p.PacketOkWithByte(data)
+	case proto.OpFlashNodeMetaPut:
+		if len(p.Data) > proto.MaxRemoteCacheMetaSize {
+			return fmt.Errorf("meta size(%v) over %v", len(p.Data), proto.MaxRemoteCacheMetaSize)
+		}
+		f.cacheEngine.PutMeta(proto.FlashMetaCacheKey(req.Volume, req.Parent, req.Names[0]),
+			append([]byte(nil), p.Data...), time.Duration(req.TTLSec)*time.Second)
+		p.PacketOkReply()
+	default:
+		for _, name := range req.Names {
+			f.cacheEngine.EvictMeta(proto.FlashMetaCacheKey(req.Volume, req.Parent, name))
+		}
+		p.PacketOkReply()
+	}
 	return
 }
 
@@ -483,8 +727,10 @@ func (f *FlashNode) opCachePrepare(conn net.Conn, p *proto.Packet) (err error) {
 	bgTime := stat.BeginStat()
 	defer func() {
 		if err != nil {
-			log.LogErrorf("%s volume:[%s] %s", action, volume,
-				p.LogMessage(p.GetOpMsg(), conn.RemoteAddr().String(), p.StartT, err))
+			if err != proto.ErrFlashGroupDraining {
+				log.LogErrorf("%s volume:[%s] %s", action, volume,
+					p.LogMessage(p.GetOpMsg(), conn.RemoteAddr().String(), p.StartT, err))
+			}
 			p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
 			if e := p.WriteToConn(conn); e != nil {
 				log.LogErrorf("%s write to conn %v", action, e)
@@ -502,8 +748,15 @@ func (f *FlashNode) opCachePrepare(conn net.Conn, p *proto.Packet) (err error) {
 		return
 	}
 
-	f.updateSlotStat(req.CacheRequest.Slot)
 	volume = req.CacheRequest.Volume
+	if err = f.checkCacheAuth(volume, packetToken(p), util.IsTLSConn(conn)); err != nil {
+		return
+	}
+	f.updateSlotStat(req.CacheRequest.Slot)
+	if f.isDraining() {
+		err = proto.ErrFlashGroupDraining
+		return
+	}
 
 	if err = f.cacheEngine.PrepareCache(p.ReqID, req.CacheRequest, conn.RemoteAddr().String()); err != nil {
 		log.LogErrorf("%s prepare %v", action, err)
@@ -556,6 +809,7 @@ func (f *FlashNode) sendPrepareRequest(addr string, req *proto.CachePrepareReque
 
 	followerPacket := proto.NewPacketReqID()
 	followerPacket.Opcode = proto.OpFlashNodeCachePrepare
+	setPacketToken(followerPacket, f.peerCacheToken(req.CacheRequest.Volume))
 	if err = followerPacket.MarshalDataPb(req); err != nil {
 		log.LogWarnf("%s failed to MarshalDataPb (%+v) err(%v)", action, followerPacket, err)
 		return err
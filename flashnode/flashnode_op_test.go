@@ -47,7 +47,13 @@ func testTCP(t *testing.T) {
 	t.Run("Heartbeat", testTCPHeartbeat)
 	t.Run("CachePrepare", testTCPCachePrepare)
 	t.Run("CacheRead", testTCPCacheRead)
+	t.Run("CachePeerRead", testTCPCachePeerRead)
+	t.Run("CacheDraining", testTCPCacheDraining)
+	t.Run("CacheReadLimit", testTCPCacheReadLimit)
+	t.Run("PeerFill", testPeerFill)
 	t.Run("ManualScan", testTCPManualScan)
+	t.Run("CacheEvict", testTCPCacheEvict)
+	t.Run("MetaCache", testTCPMetaCache)
 }
 
 func testTCPHeartbeat(t *testing.T) {
@@ -169,6 +175,139 @@ func testTCPCacheRead(t *testing.T) {
 	require.Equal(t, uint32(blockSize), r.Size)
 }
 
+func testTCPCachePeerRead(t *testing.T) {
+	conn := newTCPConn(t)
+	defer conn.Close()
+	p := proto.NewPacketReqID()
+	r := proto.NewPacket()
+	p.Opcode = proto.OpFlashNodeCachePeerRead
+
+	req := new(proto.CacheReadRequest)
+	req.CacheRequest = &proto.CacheRequest{
+		Volume:          _volume,
+		Inode:           _inode + 1, // not cached
+		FixedFileOffset: _offset,
+		Version:         _version,
+	}
+	req.Size_ = blockSize
+	p.MarshalDataPb(req)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpErr, r.ResultCode)
+
+	req.CacheRequest.Inode = _inode
+	req.Size_ = blockSize * 2 // beyond the cached data
+	p.MarshalDataPb(req)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpErr, r.ResultCode)
+
+	req.Size_ = blockSize
+	p.MarshalDataPb(req)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpOk, r.ResultCode)
+	require.Equal(t, uint32(blockSize), r.Size)
+}
+
+func testTCPCacheDraining(t *testing.T) {
+	conn := newTCPConn(t)
+	defer conn.Close()
+	p := proto.NewPacketReqID()
+	r := proto.NewPacket()
+	flashServer.SetDraining(true)
+	defer flashServer.SetDraining(false)
+
+	source := &proto.DataSource{
+		PartitionID: 1,
+		ExtentID:    1,
+		Size_:       blockSize,
+		Hosts:       []string{extentListener.Addr().String()},
+	}
+	read := new(proto.CacheReadRequest)
+	read.CacheRequest = &proto.CacheRequest{
+		Volume:          _volume,
+		Inode:           _inode,
+		FixedFileOffset: _offset,
+		Version:         _version,
+		TTL:             _ttl,
+		Sources:         []*proto.DataSource{source},
+	}
+	read.Size_ = blockSize
+	p.Opcode = proto.OpFlashNodeCacheRead
+	p.MarshalDataPb(read)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpOk, r.ResultCode) // still serves the cached block
+
+	read.CacheRequest.Inode = _inode + 2 // not cached
+	p.MarshalDataPb(read)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpErr, r.ResultCode)
+	require.Equal(t, proto.ErrFlashGroupDraining.Error(), string(r.Data[:r.Size]))
+
+	prepare := &proto.CachePrepareRequest{CacheRequest: read.CacheRequest}
+	p.Opcode = proto.OpFlashNodeCachePrepare
+	p.MarshalDataPb(prepare)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpErr, r.ResultCode)
+	require.Equal(t, proto.ErrFlashGroupDraining.Error(), string(r.Data[:r.Size]))
+}
+
+func testTCPCacheReadLimit(t *testing.T) {
+	conn := newTCPConn(t)
+	defer conn.Close()
+	p := proto.NewPacketReqID()
+	r := proto.NewPacket()
+	flashServer.SetReadLimits(proto.FlashReadLimit{}, map[string]proto.FlashReadLimit{_volume: {Iops: 1}})
+	defer flashServer.SetReadLimits(proto.FlashReadLimit{}, nil)
+
+	read := new(proto.CacheReadRequest)
+	read.CacheRequest = &proto.CacheRequest{
+		Volume:          _volume,
+		Inode:           _inode,
+		FixedFileOffset: _offset,
+		Version:         _version,
+		TTL:             _ttl,
+	}
+	read.Size_ = blockSize
+	p.Opcode = proto.OpFlashNodeCacheRead
+	p.MarshalDataPb(read)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpOk, r.ResultCode)
+
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpErr, r.ResultCode)
+	require.Equal(t, proto.ErrFlashNodeReadLimited.Error(), string(r.Data[:r.Size]))
+	require.True(t, proto.IsFlashNodeLimitError(proto.ErrFlashNodeReadLimited))
+
+	p.Opcode = proto.OpFlashNodeCachePeerRead
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpErr, r.ResultCode)
+	require.Equal(t, proto.ErrFlashNodeReadLimited.Error(), string(r.Data[:r.Size]))
+}
+
+func testPeerFill(t *testing.T) {
+	source := &proto.DataSource{FileOffset: 0, Size_: blockSize}
+	defer flashServer.SetPeerFill(false, 0, nil)
+
+	_, err := flashServer.readFromPeers(source, _volume, _inode, _offset, _version)
+	require.ErrorIs(t, err, errPeerFillDisabled)
+
+	flashServer.SetPeerFill(true, 1000, []string{flashServer.localAddr})
+	data, err := flashServer.readFromPeers(source, _volume, _inode, _offset, _version)
+	require.NoError(t, err)
+	require.Equal(t, blockSize, len(data))
+
+	_, err = flashServer.readFromPeers(source, _volume, _inode+1, _offset, _version)
+	require.Error(t, err)
+}
+
 func testTCPManualScan(t *testing.T) {
 	conn := newTCPConn(t)
 	p := proto.NewPacketReqID()
@@ -215,3 +354,86 @@ func testTCPManualScan(t *testing.T) {
 	require.Equal(t, proto.OpOk, r.ResultCode)
 	_ = conn.Close()
 }
+
+func testTCPCacheEvict(t *testing.T) {
+	conn := newTCPConn(t)
+	defer conn.Close()
+	p := proto.NewPacketReqID()
+	r := proto.NewPacket()
+
+	inode := _inode + 3
+	prepare := new(proto.CachePrepareRequest)
+	prepare.CacheRequest = &proto.CacheRequest{
+		Volume:          _volume,
+		Inode:           inode,
+		FixedFileOffset: _offset,
+		Version:         _version,
+		TTL:             _ttl,
+		Sources: []*proto.DataSource{{
+			PartitionID: 1,
+			ExtentID:    1,
+			Size_:       blockSize,
+			Hosts:       []string{extentListener.Addr().String()},
+		}},
+	}
+	p.Opcode = proto.OpFlashNodeCachePrepare
+	p.MarshalDataPb(prepare)
+	require.NoError(t, p.WriteToConn(conn))
+	require.NoError(t, r.ReadFromConn(conn, 3))
+	require.Equal(t, proto.OpOk, r.ResultCode)
+	time.Sleep(time.Second)
+
+	read := &proto.CacheReadRequest{CacheRequest: prepare.CacheRequest, Size_: blockSize}
+	peerRead := func() uint8 {
+		p.Opcode = proto.OpFlashNodeCachePeerRead
+		p.MarshalDataPb(read)
+		require.NoError(t, p.WriteToConn(conn))
+		require.NoError(t, r.ReadFromConn(conn, 3))
+		return r.ResultCode
+	}
+	require.Equal(t, proto.OpOk, peerRead())
+
+	evict := func(offsets ...uint64) {
+		p.Opcode = proto.OpFlashNodeCacheEvict
+		require.NoError(t, p.MarshalData(&proto.CacheEvictRequest{Volume: _volume, Inode: inode, Offsets: offsets}))
+		require.NoError(t, p.WriteToConn(conn))
+		require.NoError(t, r.ReadFromConn(conn, 3))
+		require.Equal(t, proto.OpOk, r.ResultCode)
+	}
+	evict(_offset + proto.CACHE_BLOCK_SIZE) // another block
+	require.Equal(t, proto.OpOk, peerRead())
+	evict(_offset)
+	require.Equal(t, proto.OpErr, peerRead())
+}
+
+func testTCPMetaCache(t *testing.T) {
+	conn := newTCPConn(t)
+	defer conn.Close()
+	p := proto.NewPacketReqID()
+	r := proto.NewPacket()
+
+	send := func(op uint8, req *proto.FlashMetaCacheRequest, data []byte) uint8 {
+		p.Opcode = op
+		arg, err := json.Marshal(req)
+		require.NoError(t, err)
+		p.Arg, p.ArgLen = arg, uint32(len(arg))
+		p.Data, p.Size = data, uint32(len(data))
+		require.NoError(t, p.WriteToConn(conn))
+		require.NoError(t, r.ReadFromConn(conn, 3))
+		return r.ResultCode
+	}
+	lookup := &proto.FlashMetaCacheRequest{Volume: _volume, Parent: 1, Names: []string{"name"}, TTLSec: _ttl}
+	listing := &proto.FlashMetaCacheRequest{Volume: _volume, Parent: 1, Names: []string{""}, TTLSec: _ttl}
+	require.Equal(t, proto.OpNotExistErr, send(proto.OpFlashNodeMetaGet, lookup, nil))
+	require.Equal(t, proto.OpOk, send(proto.OpFlashNodeMetaPut, lookup, []byte("ino")))
+	require.Equal(t, proto.OpOk, send(proto.OpFlashNodeMetaPut, listing, []byte("dentries")))
+	require.Equal(t, proto.OpOk, send(proto.OpFlashNodeMetaGet, lookup, nil))
+	require.Equal(t, []byte("ino"), r.Data[:r.Size])
+	require.Equal(t, proto.OpErr, send(proto.OpFlashNodeMetaGet, &proto.FlashMetaCacheRequest{Volume: _volume, Parent: 1}, nil))
+
+	evict := &proto.FlashMetaCacheRequest{Volume: _volume, Parent: 1, Names: []string{"name", ""}}
+	require.Equal(t, proto.OpOk, send(proto.OpFlashNodeMetaEvict, evict, nil))
+	require.Equal(t, proto.OpNotExistErr, send(proto.OpFlashNodeMetaGet, lookup, nil))
+	require.Equal(t, proto.OpNotExistErr, send(proto.OpFlashNodeMetaGet, listing, nil))
+	require.True(t, flashServer.cacheEngine.GetMetaCacheStat().Enable)
+}
@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cubefs/cubefs/flashnode/cachengine"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/master"
 	"github.com/cubefs/cubefs/util/config"
@@ -40,6 +41,7 @@ var (
 	flashPort      int
 	flashServer    *FlashNode
 	flashHTTP      int
+	flashGrpc      int
 	httpServer     *http.Server
 	extentListener net.Listener
 	closeCh        = make(chan struct{})
@@ -47,6 +49,7 @@ var (
 	_nodeID     uint64
 	_apiGetIP   uint32
 	_apiAddNode uint32
+	_apiOffline uint32
 )
 
 func init() {
@@ -57,6 +60,7 @@ func init() {
 
 	flashPort = getFreePort()
 	flashHTTP = getFreePort()
+	flashGrpc = getFreePort()
 
 	initExtentListener()
 }
@@ -92,6 +96,10 @@ func handleMaster(w http.ResponseWriter, r *http.Request) {
 			b, _ := json.Marshal(proto.HTTPReply{Data: atomic.AddUint64(&_nodeID, 10)})
 			w.Write(b)
 		}
+	case proto.FlashNodeOffline:
+		atomic.AddUint32(&_apiOffline, 1)
+		b, _ := json.Marshal(proto.HTTPReply{})
+		w.Write(b)
 	default:
 		w.WriteHeader(http.StatusBadRequest)
 	}
@@ -105,8 +113,11 @@ func TestFlashNode(t *testing.T) {
 	t.Run("New", testNew)
 	t.Run("Config", testConfig)
 	t.Run("TCP", testTCP)
+	t.Run("GRPC", testGRPC)
 	t.Run("HTTP", testHTTP)
 	t.Run("ManualScan", testManualScanner)
+	t.Run("ManualScanInodes", testManualScannerInodes)
+	t.Run("Leave", testLeave)
 	t.Run("Shotdown", testShutdown)
 }
 
@@ -133,6 +144,8 @@ func testConfig(t *testing.T) {
 		{`"cachePercent":0.2,`, `"cacheTotal":1024,`},
 		{fmt.Sprintf("\"diskDataPath\":[\"%s\"],", "/cfs/tmpfs:0"), `"diskDataPath":[],`},
 		{`"disableTmpfs": true,`},
+		{`"metaCacheSize":1048576,`},
+		{fmt.Sprintf("\"grpcPort\":\"%d\",", flashGrpc)},
 		{fmt.Sprintf("\"masterAddr\":[\"%s\"],", masterAddr), `"masterAddr":[],`},
 	} {
 		for _, line := range lines[1:] {
@@ -157,6 +170,24 @@ func testConfig(t *testing.T) {
 	time.Sleep(time.Second)
 }
 
+func testLeave(t *testing.T) {
+	require.Equal(t, _defaultOfflineGraceSec, flashServer.offlineGraceSec)
+	require.Equal(t, int(cachengine.DefaultScrubInterval/time.Second), flashServer.scrubIntervalSec)
+	f := newFlashnode()
+	f.leave() // not registered
+	require.Zero(t, atomic.LoadUint32(&_apiOffline))
+
+	f.nodeID, f.localAddr, f.offlineGraceSec = 1, "127.0.0.1:17510", 1
+	start := time.Now()
+	f.leave()
+	require.Equal(t, uint32(1), atomic.LoadUint32(&_apiOffline))
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	f.offlineGraceSec = -1
+	f.leave()
+	require.Equal(t, uint32(1), atomic.LoadUint32(&_apiOffline))
+}
+
 func testShutdown(t *testing.T) {
 	masterServer.Close()
 	flashServer.shutdown()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -97,6 +98,17 @@ func NewManualScanner(adminTask *proto.AdminTask, f *FlashNode, metaWrapper *met
 
 func (s *ManualScanner) Start() (err error) {
 	response := s.adminTask.Response.(*proto.FlashNodeManualTaskResponse)
+	if len(s.manualTask.ManualTaskConfig.Inodes) > 0 {
+		go s.handleFileChan()
+		response.StartTime = &s.createTime
+		// fed in the dir pool, so that the scanning is not done until all fed
+		if _, err = s.dirRPool.Submit(s.feedInodes); err != nil {
+			log.LogWarnf("dirRPool.Submit err(%v), id(%v)", err, s.ID)
+			return
+		}
+		go s.checkScanning()
+		return
+	}
 	parentId, prefixDirs, err := s.FindPrefixInode()
 	log.LogInfof("startScan with parentId(%v) dirs(%v) dirlen(%v)", parentId, prefixDirs, len(prefixDirs))
 	if err != nil {
@@ -136,6 +148,19 @@ func (s *ManualScanner) Start() (err error) {
 	return
 }
 
+func (s *ManualScanner) feedInodes() {
+	for _, ino := range s.manualTask.ManualTaskConfig.Inodes {
+		select {
+		case <-s.stopC:
+			log.LogInfof("receive stop, stop feedInodes %v", s.ID)
+			return
+		default:
+		}
+		s.applyPauseIfEnabled("feedInodes", strconv.FormatUint(ino, 10))
+		s.fileChan <- &proto.ScanItem{Inode: ino, Name: strconv.FormatUint(ino, 10)}
+	}
+}
+
 func (s *ManualScanner) checkScanning() {
 	minuteProgressCount := 60 / s.flashNode.scanCheckInterval
 	repeatMinute := 0
@@ -227,6 +252,7 @@ func (s *ManualScanner) handleFileChan() {
 	}()
 
 	prefix := s.manualTask.GetPathPrefix()
+	byInode := len(s.manualTask.ManualTaskConfig.Inodes) > 0
 
 	for {
 		select {
@@ -241,7 +267,7 @@ func (s *ManualScanner) handleFileChan() {
 
 			dentry := val.(*proto.ScanItem)
 			s.applyPauseIfEnabled("handleFileChan", dentry.Name)
-			if !strings.HasPrefix(dentry.Path, prefix) {
+			if !byInode && !strings.HasPrefix(dentry.Path, prefix) {
 				continue
 			}
 
@@ -278,6 +304,10 @@ func (s *ManualScanner) handleFile(dentry *proto.ScanItem) {
 		log.LogErrorf("handleFile InodeGet_ll err: %v, dentry: %+v", err, dentry)
 		return
 	}
+	if !proto.IsRegular(info.Mode) {
+		log.LogInfof("handleFile: skip inode(%v) of mode(%v), not a regular file", dentry.Inode, info.Mode)
+		return
+	}
 	op := s.manualTask.Action
 	dentry.StorageClass = info.StorageClass
 	dentry.Size = info.Size
@@ -326,6 +356,10 @@ func (s *ManualScanner) warmUp(i *proto.ScanItem) error {
 		return err
 	}
 	for _, extent := range extents {
+		var ok bool
+		if extent, ok = s.manualTask.ManualTaskConfig.ClipExtent(extent); !ok {
+			continue
+		}
 		s.prepareLimiter.Wait(context.Background())
 		prepareReq := stream.NewPrepareRemoteCacheRequest(i.Inode, extent, true, i.WriteGen)
 		s.RemoteCache.PrepareCh <- prepareReq
@@ -13,10 +13,10 @@ import (
 	"golang.org/x/time/rate"
 )
 
-func testManualScanner(t *testing.T) {
+func newTestManualScanner(config proto.ManualTaskConfig) *ManualScanner {
 	rc := &stream.RemoteCache{}
 	rc.PrepareCh = make(chan *stream.PrepareRemoteCacheRequest, 1024)
-	scanner := &ManualScanner{
+	return &ManualScanner{
 		ID:        "test_manual_scan_id",
 		Volume:    "test_vol",
 		flashNode: flashServer,
@@ -45,8 +45,14 @@ func testManualScanner(t *testing.T) {
 			VolName: "test_vol",
 			Action:  proto.FlashManualWarmupAction,
 			Status:  int(proto.Flash_Task_Running),
+
+			ManualTaskConfig: config,
 		},
 	}
+}
+
+func testManualScanner(t *testing.T) {
+	scanner := newTestManualScanner(proto.ManualTaskConfig{})
 	err := scanner.Start()
 	require.NoError(t, err)
 	time.Sleep(time.Second * 5)
@@ -58,3 +64,17 @@ func testManualScanner(t *testing.T) {
 	require.Equal(t, int64(0), scanner.currentStat.ErrorCacheNum)
 	require.Equal(t, int64(0), scanner.currentStat.ErrorReadDirNum)
 }
+
+func testManualScannerInodes(t *testing.T) {
+	// the bytes [500, 2500) of the inodes, inode 3 is empty
+	scanner := newTestManualScanner(proto.ManualTaskConfig{Inodes: []uint64{1, 2, 3}, Offset: 500, Size: 2000})
+	err := scanner.Start()
+	require.NoError(t, err)
+	time.Sleep(time.Second * 5)
+	require.Equal(t, int64(3), scanner.currentStat.TotalFileScannedNum)
+	require.Equal(t, int64(2), scanner.currentStat.TotalFileCachedNum)
+	require.Equal(t, int64(0), scanner.currentStat.TotalDirScannedNum)
+	require.Equal(t, int64(4), scanner.currentStat.TotalExtentKeyNum)
+	require.Equal(t, int64(2000), scanner.currentStat.TotalCacheSize)
+	require.Equal(t, int64(0), scanner.currentStat.ErrorCacheNum)
+}
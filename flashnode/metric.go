@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/flashnode/cachengine"
+	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
 	"github.com/cubefs/cubefs/util/stat"
@@ -13,6 +14,7 @@ import (
 
 const (
 	StatPeriod                       = time.Minute * time.Duration(1)
+	lruStatPeriod                    = time.Minute // the lru counts its hits, evicts and inserts per minute
 	MetricFlashNodeReadBytes         = "flashNodeReadBytes"
 	MetricFlashNodeReadCount         = "flashNodeReadCount"
 	MetricFlashNodeWriteBytes        = "flashNodeWriteBytes"
@@ -22,6 +24,16 @@ const (
 	MetricFlashNodeCacheBytes        = "flashNodeCacheBytes"
 	MetricFlashNodeHandleReadLatency = "flashNodeHandleReadLatency"
 	MetricFlashNodeSourceDataLatency = "flashNodeSourceDataLatency"
+	MetricFlashNodePeerFillBytes     = "flashNodePeerFillBytes"
+	MetricFlashNodeAdmissionCount    = "flashNodeAdmissionCount"
+	MetricFlashNodeScrubCorruptCount = "flashNodeScrubCorruptCount"
+	MetricFlashNodeEvictRate         = "flashNodeEvictRate"
+	MetricFlashNodeInsertRate        = "flashNodeInsertRate"
+	MetricFlashNodeDiskUsage         = "flashNodeDiskUsage"
+	MetricFlashNodeCacheReadLatency  = "flashNodeCacheReadLatency"
+	MetricFlashNodeVolReadBytes      = "flashNodeVolReadBytes"
+	MetricFlashNodeVolReadCount      = "flashNodeVolReadCount"
+	MetricFlashNodeVolHitRate        = "flashNodeVolHitRate"
 )
 
 type FlashNodeMetrics struct {
@@ -36,6 +48,18 @@ type FlashNodeMetrics struct {
 	MetricCacheBytes        *exporter.Gauge
 	MetricHandleReadLatency *exporter.Gauge
 	MetricSourceDataLatency *exporter.Gauge
+	MetricPeerFillBytes     *exporter.Gauge
+	MetricAdmissionCount    *exporter.Gauge
+	MetricScrubCorruptCount *exporter.Counter
+	MetricEvictRate         *exporter.Gauge
+	MetricInsertRate        *exporter.Gauge
+	MetricDiskUsage         *exporter.Gauge
+	MetricVolReadBytes      *exporter.Gauge
+	MetricVolReadCount      *exporter.Gauge
+	MetricVolHitRate        *exporter.Gauge
+
+	lastAdmission    *proto.FlashNodeAdmissionStat
+	lastScrubCorrupt uint64
 }
 
 func (f *FlashNode) registerMetrics(disks []*cachengine.Disk) {
@@ -53,6 +77,16 @@ func (f *FlashNode) registerMetrics(disks []*cachengine.Disk) {
 	f.metrics.MetricCacheBytes = exporter.NewGauge(MetricFlashNodeCacheBytes)
 	f.metrics.MetricHandleReadLatency = exporter.NewGauge(MetricFlashNodeHandleReadLatency)
 	f.metrics.MetricSourceDataLatency = exporter.NewGauge(MetricFlashNodeSourceDataLatency)
+	f.metrics.MetricPeerFillBytes = exporter.NewGauge(MetricFlashNodePeerFillBytes)
+	f.metrics.MetricAdmissionCount = exporter.NewGauge(MetricFlashNodeAdmissionCount)
+	f.metrics.MetricScrubCorruptCount = exporter.NewCounter(MetricFlashNodeScrubCorruptCount)
+	f.metrics.MetricEvictRate = exporter.NewGauge(MetricFlashNodeEvictRate)
+	f.metrics.MetricInsertRate = exporter.NewGauge(MetricFlashNodeInsertRate)
+	f.metrics.MetricDiskUsage = exporter.NewGauge(MetricFlashNodeDiskUsage)
+	f.metrics.MetricVolReadBytes = exporter.NewGauge(MetricFlashNodeVolReadBytes)
+	f.metrics.MetricVolReadCount = exporter.NewGauge(MetricFlashNodeVolReadCount)
+	f.metrics.MetricVolHitRate = exporter.NewGauge(MetricFlashNodeVolHitRate)
+	f.metrics.lastAdmission = new(proto.FlashNodeAdmissionStat)
 	for _, d := range disks {
 		cachengine.StatMap[path.Join(d.Path, cachengine.DefaultCacheDirName)] = new(cachengine.MetricStat)
 	}
@@ -90,6 +124,13 @@ func (fm *FlashNodeMetrics) doStat() {
 	fm.setHitRateMetric()
 	fm.setCacheBytesMetric()
 	fm.setLatencyMetric()
+	fm.setPeerFillBytesMetric()
+	fm.setAdmissionCountMetric()
+	fm.setScrubCorruptCountMetric()
+	fm.setEvictRateMetric()
+	fm.setInsertRateMetric()
+	fm.setDiskUsageMetric()
+	fm.setVolReadMetric()
 }
 
 func (fm *FlashNodeMetrics) setReadBytesMetric() {
@@ -149,6 +190,106 @@ func (fm *FlashNodeMetrics) setLatencyMetric() {
 	fm.MetricSourceDataLatency.SetWithLabels(float64(sourceDataLatency), map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr})
 }
 
+func (fm *FlashNodeMetrics) setPeerFillBytesMetric() {
+	peerFillBytes := atomic.SwapUint64(&fm.flashNode.peerFillBytes, 0)
+	fm.MetricPeerFillBytes.SetWithLabels(float64(peerFillBytes), map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr})
+}
+
+// setAdmissionCountMetric reports the decisions of the admission filter in the last period.
+func (fm *FlashNodeMetrics) setAdmissionCountMetric() {
+	cur := fm.flashNode.cacheEngine.GetAdmissionStat()
+	last := fm.lastAdmission
+	fm.lastAdmission = cur
+	for result, count := range map[string]uint64{
+		"admitted":       cur.Admitted - last.Admitted,
+		"rejected":       cur.Rejected - last.Rejected,
+		"alwaysAdmitted": cur.AlwaysAdmitted - last.AlwaysAdmitted,
+	} {
+		fm.MetricAdmissionCount.SetWithLabels(float64(count), map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr, "result": result})
+	}
+}
+
+// setScrubCorruptCountMetric counts the corrupt blocks the scrubber found in the last period.
+func (fm *FlashNodeMetrics) setScrubCorruptCountMetric() {
+	_, corrupted := fm.flashNode.cacheEngine.GetScrubStat()
+	last := fm.lastScrubCorrupt
+	fm.lastScrubCorrupt = corrupted
+	fm.MetricScrubCorruptCount.AddWithLabels(int64(corrupted-last), map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr})
+}
+
+// setEvictRateMetric reports the blocks evicted per second to cache the new
+// ones, out of the counts of the lru in the last minute.
+func (fm *FlashNodeMetrics) setEvictRateMetric() {
+	for dataPath, evictCount := range fm.flashNode.cacheEngine.GetEvictCount() {
+		fm.MetricEvictRate.SetWithLabels(float64(evictCount)/lruStatPeriod.Seconds(), map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr, exporter.Disk: dataPath})
+	}
+}
+
+// setInsertRateMetric reports the blocks cached per second.
+func (fm *FlashNodeMetrics) setInsertRateMetric() {
+	for dataPath, insertCount := range fm.flashNode.cacheEngine.GetInsertCount() {
+		fm.MetricInsertRate.SetWithLabels(float64(insertCount)/lruStatPeriod.Seconds(), map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr, exporter.Disk: dataPath})
+	}
+}
+
+func (fm *FlashNodeMetrics) setDiskUsageMetric() {
+	for dataPath, usage := range fm.flashNode.cacheEngine.GetDiskUsage() {
+		fm.MetricDiskUsage.SetWithLabels(usage, map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr, exporter.Disk: dataPath})
+	}
+}
+
+// setVolReadMetric reports the cache reads of each volume in the last period,
+// the volumes not read since the last period are reported once more as zero
+// and then forgotten.
+func (fm *FlashNodeMetrics) setVolReadMetric() {
+	fm.flashNode.volReadStats.Range(func(key, value interface{}) bool {
+		volume, st := key.(string), value.(*volReadStat)
+		hits := atomic.SwapUint64(&st.hits, 0)
+		misses := atomic.SwapUint64(&st.misses, 0)
+		readCount := atomic.SwapUint64(&st.readCount, 0)
+		readBytes := atomic.SwapUint64(&st.readBytes, 0)
+		if hits+misses == 0 && readCount == 0 {
+			fm.flashNode.volReadStats.Delete(volume)
+		}
+		var hitRate float64
+		if hits+misses > 0 {
+			hitRate = float64(hits) / float64(hits+misses)
+		}
+		labels := map[string]string{"cluster": fm.flashNode.clusterID, exporter.FlashNode: fm.flashNode.localAddr, exporter.Vol: volume}
+		fm.MetricVolReadBytes.SetWithLabels(float64(readBytes), labels)
+		fm.MetricVolReadCount.SetWithLabels(float64(readCount), labels)
+		fm.MetricVolHitRate.SetWithLabels(hitRate, labels)
+		return true
+	})
+}
+
+// volReadStat counts the cache reads of a volume.
+type volReadStat struct {
+	hits      uint64
+	misses    uint64
+	readCount uint64
+	readBytes uint64
+}
+
+// updateVolReadStat counts a cache read of the volume, hit if the block was
+// cached when it was read.
+func (f *FlashNode) updateVolReadStat(volume string, hit bool, size uint64, err error) {
+	if volume == "" {
+		return
+	}
+	value, _ := f.volReadStats.LoadOrStore(volume, new(volReadStat))
+	st := value.(*volReadStat)
+	if hit {
+		atomic.AddUint64(&st.hits, 1)
+	} else {
+		atomic.AddUint64(&st.misses, 1)
+	}
+	if err == nil {
+		atomic.AddUint64(&st.readCount, 1)
+		atomic.AddUint64(&st.readBytes, size)
+	}
+}
+
 func (fm *FlashNodeMetrics) updateReadBytesMetric(size uint64, d string) {
 	if stat, ok := cachengine.StatMap[d]; ok {
 		atomic.AddUint64(&stat.ReadBytes, size)
@@ -0,0 +1,47 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolReadMetric(t *testing.T) {
+	f := newFlashnode()
+	f.registerMetrics(nil)
+
+	f.updateVolReadStat("", true, 100, nil)
+	f.updateVolReadStat("vol", true, 100, nil)
+	f.updateVolReadStat("vol", false, 100, nil)
+	f.updateVolReadStat("vol", false, 100, errors.New("not admitted"))
+	value, ok := f.volReadStats.Load("vol")
+	require.True(t, ok)
+	st := value.(*volReadStat)
+	require.Equal(t, volReadStat{hits: 1, misses: 2, readCount: 2, readBytes: 200}, *st)
+	_, ok = f.volReadStats.Load("")
+	require.False(t, ok)
+
+	f.metrics.setVolReadMetric()
+	require.Equal(t, volReadStat{}, *st)
+	_, ok = f.volReadStats.Load("vol")
+	require.True(t, ok, "read in the last period")
+
+	f.metrics.setVolReadMetric()
+	_, ok = f.volReadStats.Load("vol")
+	require.False(t, ok, "idle for a period")
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"hash/crc32"
+	"sync/atomic"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/stat"
+)
+
+var errPeerFillDisabled = errors.New("peer fill is disabled")
+
+// SetPeerFill updates the peer fill settings pushed by the master heartbeat.
+func (f *FlashNode) SetPeerFill(enable bool, timeout int, peers []string) {
+	f.peerFillLock.Lock()
+	defer f.peerFillLock.Unlock()
+	if f.peerFillEnable != enable {
+		log.LogInfof("FlashNode set peerFillEnable from %v to %v", f.peerFillEnable, enable)
+		f.peerFillEnable = enable
+	}
+	if timeout > 0 {
+		f.peerFillTimeout = timeout
+	}
+	f.peers = peers
+}
+
+func (f *FlashNode) getPeerFill() (enable bool, timeout int, peers []string) {
+	f.peerFillLock.RLock()
+	defer f.peerFillLock.RUnlock()
+	return f.peerFillEnable, f.peerFillTimeout, f.peers
+}
+
+// readFromPeers reads the data of the source from the other members of the flash group,
+// which may still hold the block if the slot of it has been moved recently.
+func (f *FlashNode) readFromPeers(source *proto.DataSource, volume string, ino, fixedOffset uint64, version uint32) (data []byte, err error) {
+	enable, timeout, peers := f.getPeerFill()
+	if !enable || len(peers) == 0 {
+		return nil, errPeerFillDisabled
+	}
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("MissCacheRead:ReadFromPeer", err, bgTime, 1)
+	}()
+
+	req := &proto.CacheReadRequest{
+		CacheRequest: &proto.CacheRequest{
+			Volume:          volume,
+			Inode:           ino,
+			FixedFileOffset: fixedOffset,
+			Version:         version,
+		},
+		Offset: source.FileOffset & (proto.CACHE_BLOCK_SIZE - 1),
+		Size_:  source.Size_,
+	}
+	data = make([]byte, source.Size_)
+	for _, addr := range peers {
+		if err = f.readFromPeer(addr, req, data, timeout); err == nil {
+			atomic.AddUint64(&f.peerFillBytes, source.Size_)
+			return data, nil
+		}
+		if log.EnableDebug() {
+			log.LogDebugf("action[readFromPeers] peer(%v) volume(%v) inode(%v) offset(%v) size(%v) err:%v",
+				addr, volume, ino, source.FileOffset, source.Size_, err)
+		}
+	}
+	return nil, err
+}
+
+func (f *FlashNode) readFromPeer(addr string, req *proto.CacheReadRequest, data []byte, timeout int) (err error) {
+	conn, err := f.connPool.GetConnect(addr)
+	if err != nil {
+		return
+	}
+	defer func() {
+		f.connPool.PutConnect(conn, err != nil)
+	}()
+
+	p := proto.NewPacketReqID()
+	p.Opcode = proto.OpFlashNodeCachePeerRead
+	setPacketToken(p, f.peerCacheToken(req.CacheRequest.Volume))
+	if err = p.MarshalDataPb(req); err != nil {
+		return
+	}
+	if err = p.WriteToConn(conn); err != nil {
+		return
+	}
+	offset := int64(req.Offset)
+	for readSize := 0; readSize < len(data); {
+		reply := proto.NewPacket()
+		reply.Data = data[readSize:]
+		if err = ReadReplyFromConn(reply, conn, timeout); err != nil {
+			return
+		}
+		if reply.ResultCode != proto.OpOk {
+			return errors.NewErrorf("ResultCode(%v) NOTOK", reply.GetResultMsg())
+		}
+		if reply.ReqID != p.ReqID || reply.ExtentOffset != offset || reply.Size == 0 {
+			return errors.NewErrorf("inconsistent req and reply, req(%v) reply(%v)", p, reply)
+		}
+		if expectCrc := crc32.ChecksumIEEE(reply.Data[:reply.Size]); reply.CRC != expectCrc {
+			return errors.NewErrorf("inconsistent CRC, expectCRC(%v) replyCRC(%v)", expectCrc, reply.CRC)
+		}
+		readSize += int(reply.Size)
+		offset += int64(reply.Size)
+	}
+	return
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+	"golang.org/x/time/rate"
+)
+
+// readLimiter is the token buckets of the bandwidth and the iops of a read limit,
+// nil bucket is unlimited.
+type readLimiter struct {
+	limit proto.FlashReadLimit
+	bytes *rate.Limiter
+	iops  *rate.Limiter
+}
+
+func newReadLimiter(limit proto.FlashReadLimit) (l *readLimiter) {
+	l = &readLimiter{limit: limit}
+	if limit.MBps > 0 {
+		bps := int(limit.MBps) * util.MB
+		// a single read is at most a cache block
+		burst := bps
+		if burst < proto.CACHE_BLOCK_SIZE {
+			burst = proto.CACHE_BLOCK_SIZE
+		}
+		l.bytes = rate.NewLimiter(rate.Limit(bps), burst)
+	}
+	if limit.Iops > 0 {
+		l.iops = rate.NewLimiter(rate.Limit(limit.Iops), int(limit.Iops))
+	}
+	return
+}
+
+// reserve takes the tokens of a read of size, the reservations are returned to
+// be canceled if a later limiter refuses the read.
+func (l *readLimiter) reserve(now time.Time, size int) (rs []*rate.Reservation, ok bool) {
+	for _, b := range []struct {
+		limiter *rate.Limiter
+		n       int
+	}{{l.bytes, size}, {l.iops, 1}} {
+		if b.limiter == nil {
+			continue
+		}
+		r := b.limiter.ReserveN(now, b.n)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			cancelReservations(now, rs)
+			return nil, false
+		}
+		rs = append(rs, r)
+	}
+	return rs, true
+}
+
+func cancelReservations(now time.Time, rs []*rate.Reservation) {
+	for _, r := range rs {
+		r.CancelAt(now)
+	}
+}
+
+// readLimits limits the reads served by the flash node, as a whole and per volume,
+// so that a hot volume can not take all the bandwidth of the node. They are pushed
+// by the master heartbeat.
+type readLimits struct {
+	sync.RWMutex
+	node *readLimiter
+	vols map[string]*readLimiter
+}
+
+func newReadLimits() *readLimits {
+	return &readLimits{vols: make(map[string]*readLimiter)}
+}
+
+// set keeps the buckets of the limits unchanged, only the changed ones are refilled.
+func (l *readLimits) set(node proto.FlashReadLimit, vols map[string]proto.FlashReadLimit) {
+	l.Lock()
+	defer l.Unlock()
+	if node.Unlimited() {
+		l.node = nil
+	} else if l.node == nil || l.node.limit != node {
+		log.LogInfof("FlashNode set read limit to %+v", node)
+		l.node = newReadLimiter(node)
+	}
+	for volume, limiter := range l.vols {
+		if limit, ok := vols[volume]; !ok || limit.Unlimited() {
+			log.LogInfof("FlashNode remove read limit of volume(%v)", volume)
+			delete(l.vols, volume)
+		} else if limiter.limit != limit {
+			log.LogInfof("FlashNode set read limit of volume(%v) to %+v", volume, limit)
+			l.vols[volume] = newReadLimiter(limit)
+		}
+	}
+	for volume, limit := range vols {
+		if _, ok := l.vols[volume]; !ok && !limit.Unlimited() {
+			log.LogInfof("FlashNode set read limit of volume(%v) to %+v", volume, limit)
+			l.vols[volume] = newReadLimiter(limit)
+		}
+	}
+}
+
+func (l *readLimits) get() (node proto.FlashReadLimit, vols map[string]proto.FlashReadLimit) {
+	l.RLock()
+	defer l.RUnlock()
+	if l.node != nil {
+		node = l.node.limit
+	}
+	vols = make(map[string]proto.FlashReadLimit, len(l.vols))
+	for volume, limiter := range l.vols {
+		vols[volume] = limiter.limit
+	}
+	return
+}
+
+// allow takes the tokens of a read of size from the limits of the volume and of
+// the node, nothing is taken if any of them refuses.
+func (l *readLimits) allow(volume string, size int) bool {
+	l.RLock()
+	volLimiter, nodeLimiter := l.vols[volume], l.node
+	l.RUnlock()
+	if volLimiter == nil && nodeLimiter == nil {
+		return true
+	}
+	now := time.Now()
+	var rs []*rate.Reservation
+	if volLimiter != nil {
+		var ok bool
+		if rs, ok = volLimiter.reserve(now, size); !ok {
+			return false
+		}
+	}
+	if nodeLimiter != nil {
+		if _, ok := nodeLimiter.reserve(now, size); !ok {
+			cancelReservations(now, rs)
+			return false
+		}
+	}
+	return true
+}
+
+// SetReadLimits updates the read limits pushed by the master heartbeat.
+func (f *FlashNode) SetReadLimits(node proto.FlashReadLimit, vols map[string]proto.FlashReadLimit) {
+	f.readLimits.set(node, vols)
+}
+
+// limitVolRead refuses the read if the volume or the node has read too much, the
+// client reads the datanode instead.
+func (f *FlashNode) limitVolRead(volume string, size uint64) error {
+	if f.readLimits.allow(volume, int(size)) {
+		return nil
+	}
+	metric := exporter.NewCounter("VolReadLimit")
+	metric.AddWithLabels(1, map[string]string{exporter.FlashNode: f.localAddr, exporter.Vol: volume})
+	return proto.ErrFlashNodeReadLimited
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLimits(t *testing.T) {
+	l := newReadLimits()
+	require.True(t, l.allow("vol", proto.CACHE_BLOCK_SIZE))
+
+	l.set(proto.FlashReadLimit{}, map[string]proto.FlashReadLimit{"vol": {MBps: 2}, "free": {}})
+	node, vols := l.get()
+	require.True(t, node.Unlimited())
+	require.Equal(t, map[string]proto.FlashReadLimit{"vol": {MBps: 2}}, vols)
+	// the burst is a second of bandwidth
+	require.True(t, l.allow("vol", proto.CACHE_BLOCK_SIZE))
+	require.True(t, l.allow("vol", proto.CACHE_BLOCK_SIZE))
+	require.False(t, l.allow("vol", proto.CACHE_BLOCK_SIZE))
+	require.True(t, l.allow("other", proto.CACHE_BLOCK_SIZE))
+
+	// an unchanged limit keeps its bucket
+	l.set(proto.FlashReadLimit{Iops: 1}, map[string]proto.FlashReadLimit{"vol": {MBps: 2}, "other": {MBps: 1}})
+	require.False(t, l.allow("vol", util.KB))
+	// the refused read of the node takes nothing from the volume
+	require.True(t, l.allow("free", util.KB))
+	require.False(t, l.allow("other", util.KB))
+	l.set(proto.FlashReadLimit{}, map[string]proto.FlashReadLimit{"other": {MBps: 1}})
+	require.True(t, l.allow("other", proto.CACHE_BLOCK_SIZE))
+	require.False(t, l.allow("other", util.KB))
+
+	l.set(proto.FlashReadLimit{}, nil)
+	node, vols = l.get()
+	require.True(t, node.Unlimited())
+	require.Empty(t, vols)
+	require.True(t, l.allow("other", proto.CACHE_BLOCK_SIZE))
+}
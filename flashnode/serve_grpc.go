@@ -0,0 +1,235 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/cubefs/cubefs/flashnode/cachengine"
+	"github.com/cubefs/cubefs/flashnode/flashpb"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/stat"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// The gRPC service serves CachePrepare and CacheRead as the packet protocol does,
+// for the SDKs of the other languages. The errors after which the client reads the
+// datanode instead are returned as Unavailable, and the limited ones as ResourceExhausted.
+// The token of a volume requiring the cache auth is carried in the metadata
+// proto.FlashCacheTokenMetadataKey, and the service is served over TLS if the flash
+// node is configured with a certificate.
+
+// flashCacheServer implements flashpb.FlashCacheServer on the flash node.
+type flashCacheServer struct {
+	f *FlashNode
+}
+
+func (f *FlashNode) startGrpcServer() (err error) {
+	if f.grpcPort == "" {
+		return
+	}
+	if f.grpcListener, err = net.Listen("tcp", ":"+f.grpcPort); err != nil {
+		return
+	}
+	var opts []grpc.ServerOption
+	if f.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(f.tlsConfig)))
+	}
+	f.grpcServer = grpc.NewServer(opts...)
+	flashpb.RegisterFlashCacheServer(f.grpcServer, &flashCacheServer{f: f})
+	go func() {
+		if err := f.grpcServer.Serve(f.grpcListener); err != nil {
+			log.LogWarnf("flashnode grpc server stopped: %v", err)
+		}
+	}()
+	log.LogInfof("started grpc server on port %v", f.grpcPort)
+	return
+}
+
+func (f *FlashNode) stopGrpcServer() {
+	if f.grpcServer != nil {
+		f.grpcServer.Stop()
+	}
+}
+
+func grpcRemoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err {
+	case context.DeadlineExceeded, context.Canceled:
+		return status.FromContextError(err).Err()
+	case util.LimitedRunError, util.LimitedFlowError, util.LimitedIoError, proto.ErrFlashNodeReadLimited:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, proto.ErrFlashCacheTokenInvalid) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err == proto.ErrTLSRequired {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if proto.IsFlashNodeLimitError(err) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func grpcCacheToken(ctx context.Context) []byte {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	if values := md.Get(proto.FlashCacheTokenMetadataKey); len(values) > 0 {
+		return []byte(values[0])
+	}
+	return nil
+}
+
+func (s *flashCacheServer) allow(action, remoteAddr string) error {
+	if s.f.readLimiter.Allow() {
+		return nil
+	}
+	metric := exporter.NewTPCnt("NodeReqLimit")
+	metric.Set(nil)
+	log.LogWarnf("action[%s] flashnode read request was been limited, remote address:%s", action, remoteAddr)
+	return status.Error(codes.ResourceExhausted, "flashnode read request was been limited")
+}
+
+func (s *flashCacheServer) CachePrepare(ctx context.Context, req *proto.CachePrepareRequest) (reply *flashpb.CachePrepareReply, err error) {
+	const action = "grpcCachePrepare"
+	f := s.f
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("FlashNode:"+action, err, bgTime, 1)
+	}()
+	remoteAddr := grpcRemoteAddr(ctx)
+	if err = s.allow(action, remoteAddr); err != nil {
+		return
+	}
+	if req.CacheRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "no cache prepare request")
+	}
+	if err = f.checkCacheAuth(req.CacheRequest.Volume, grpcCacheToken(ctx), f.tlsConfig != nil); err != nil {
+		return nil, grpcError(err)
+	}
+	f.updateSlotStat(req.CacheRequest.Slot)
+	if f.isDraining() {
+		return nil, grpcError(proto.ErrFlashGroupDraining)
+	}
+	if err = f.cacheEngine.PrepareCache(proto.GenerateRequestID(), req.CacheRequest, remoteAddr); err != nil {
+		log.LogErrorf("action[%s] volume:[%s] prepare %v", action, req.CacheRequest.Volume, err)
+		return nil, grpcError(err)
+	}
+	if len(req.FlashNodes) > 0 {
+		go f.dispatchRequestToFollowers(req)
+	}
+	return &flashpb.CachePrepareReply{}, nil
+}
+
+func (s *flashCacheServer) CacheRead(req *proto.CacheReadRequest, stream flashpb.FlashCache_CacheReadServer) (err error) {
+	const action = "grpcCacheRead"
+	var (
+		f     = s.f
+		block *cachengine.CacheBlock
+		hit   bool
+	)
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("FlashNode:"+action, err, bgTime, 1)
+	}()
+	remoteAddr := grpcRemoteAddr(stream.Context())
+	if err = s.allow(action, remoteAddr); err != nil {
+		return
+	}
+	if req.CacheRequest == nil {
+		return status.Error(codes.InvalidArgument, "no cache read request")
+	}
+	volume := req.CacheRequest.Volume
+	defer func() {
+		if err != nil && !proto.IsFlashNodeLimitError(err) {
+			log.LogWarnf("action[%s] volume:[%s] remote:%s err:%v", action, volume, remoteAddr, err)
+		}
+		err = grpcError(err)
+	}()
+
+	ctx, ctxCancel := context.WithTimeout(stream.Context(), time.Duration(f.handleReadTimeout)*time.Millisecond)
+	defer ctxCancel()
+
+	if err = f.checkCacheAuth(volume, grpcCacheToken(ctx), f.tlsConfig != nil); err != nil {
+		return
+	}
+	f.updateSlotStat(req.CacheRequest.Slot)
+	if err = f.limitVolRead(volume, req.Size_); err != nil {
+		return
+	}
+	tp := exporter.NewTP(MetricFlashNodeCacheReadLatency)
+	defer func() {
+		f.updateCacheReadStat(tp, volume, hit, req.Size_, err)
+	}()
+	if block, hit, err = f.getBlockForRead(ctx, req, remoteAddr); err != nil {
+		return
+	}
+	if err2 := f.limitRead.RunNoWait(int(req.Size_), false, func() {
+		err = f.streamCacheRead(ctx, stream, req, block)
+	}); err2 != nil {
+		err = err2
+	}
+	return
+}
+
+// streamCacheRead sends the data of the request read from the block in chunks of util.ReadBlockSize.
+func (f *FlashNode) streamCacheRead(ctx context.Context, stream flashpb.FlashCache_CacheReadServer,
+	req *proto.CacheReadRequest, block *cachengine.CacheBlock,
+) (err error) {
+	buf, bufErr := proto.Buffers.Get(util.ReadBlockSize)
+	if bufErr != nil {
+		buf = make([]byte, util.ReadBlockSize)
+	} else {
+		defer proto.Buffers.Put(buf[:util.ReadBlockSize])
+	}
+	offset, end := int64(req.Offset), int64(req.Offset+req.Size_)
+	for offset < end {
+		size := util.Min(int(end-offset), util.ReadBlockSize)
+		reply := &flashpb.CacheReadReply{Offset: uint64(offset), Data: buf[:size]}
+		if reply.CRC, err = block.Read(ctx, reply.Data, offset, int64(size), f.waitForCacheBlock); err != nil {
+			return
+		}
+		if err = stream.Send(reply); err != nil {
+			return
+		}
+		offset += int64(size)
+	}
+	f.metrics.updateReadCountMetric(block.GetRootPath())
+	f.metrics.updateReadBytesMetric(req.Size_, block.GetRootPath())
+	return
+}
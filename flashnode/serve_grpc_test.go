@@ -0,0 +1,139 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package flashnode
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/flashnode/flashpb"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func grpcCacheRead(client flashpb.FlashCacheClient, req *proto.CacheReadRequest) (data []byte, err error) {
+	stream, err := client.CacheRead(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if reply.Offset != req.Offset+uint64(len(data)) || reply.CRC != crc32.ChecksumIEEE(reply.Data) {
+			return nil, fmt.Errorf("bad reply offset %v crc %v", reply.Offset, reply.CRC)
+		}
+		data = append(data, reply.Data...)
+	}
+}
+
+func testGRPC(t *testing.T) {
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", flashGrpc), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := flashpb.NewFlashCacheClient(conn)
+	ctx := context.Background()
+
+	_, err = client.CachePrepare(ctx, &proto.CachePrepareRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.CacheRead(ctx, &proto.CacheReadRequest{})
+	require.NoError(t, err) // the errors of a stream are received
+	_, err = grpcCacheRead(client, &proto.CacheReadRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	cr := &proto.CacheRequest{
+		Volume:          _volume,
+		Inode:           _inode,
+		FixedFileOffset: _offset,
+		Version:         _version,
+		TTL:             _ttl,
+		Sources: []*proto.DataSource{{
+			PartitionID: 1,
+			ExtentID:    1,
+			Size_:       blockSize,
+			Hosts:       []string{extentListener.Addr().String()},
+		}},
+	}
+	read := &proto.CacheReadRequest{CacheRequest: &proto.CacheRequest{
+		Volume:          _volume,
+		Inode:           _inode + 10, // not cached
+		FixedFileOffset: _offset,
+		Version:         _version,
+	}, Size_: blockSize}
+	_, err = grpcCacheRead(client, read)
+	require.Equal(t, codes.Unavailable, status.Code(err)) // read the datanode instead
+
+	read.CacheRequest.Inode = _inode
+	_, err = client.CachePrepare(ctx, &proto.CachePrepareRequest{CacheRequest: cr})
+	require.NoError(t, err)
+	var data []byte
+	require.Eventually(t, func() bool {
+		data, err = grpcCacheRead(client, read)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Len(t, data, blockSize)
+
+	read.Offset, read.Size_ = blockSize/2, blockSize/4
+	data, err = grpcCacheRead(client, read)
+	require.NoError(t, err)
+	require.Len(t, data, blockSize/4)
+
+	flashServer.SetDraining(true)
+	read.CacheRequest.Inode = _inode + 11
+	_, err = grpcCacheRead(client, read)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Contains(t, err.Error(), proto.ErrFlashGroupDraining.Error())
+	_, err = client.CachePrepare(ctx, &proto.CachePrepareRequest{CacheRequest: cr})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	flashServer.SetDraining(false)
+
+	key := []byte("key")
+	flashServer.SetCacheAuth(key, []string{_volume}, nil)
+	read.CacheRequest.Inode = _inode
+	_, err = grpcCacheRead(client, read)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.CachePrepare(ctx, &proto.CachePrepareRequest{CacheRequest: cr})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	token := proto.NewFlashCacheToken(key, _volume, time.Now().Add(time.Hour).Unix()).Encode()
+	tokenCtx := metadata.AppendToOutgoingContext(ctx, proto.FlashCacheTokenMetadataKey, string(token))
+	stream, err := client.CacheRead(tokenCtx, read)
+	require.NoError(t, err)
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, read.Offset, reply.Offset)
+	flashServer.SetCacheAuth(nil, nil, nil)
+
+	flashServer.readLimiter.SetBurst(0)
+	flashServer.readLimiter.SetLimit(0)
+	time.Sleep(time.Second)
+	_, err = grpcCacheRead(client, read)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	flashServer.readLimiter.SetBurst(200)
+	flashServer.readLimiter.SetLimit(20)
+	time.Sleep(time.Second)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/bundle"
 	"github.com/cubefs/cubefs/util/log"
 	"github.com/google/uuid"
 )
@@ -39,15 +40,34 @@ func (f *FlashNode) registerAPIHandler() {
 	http.HandleFunc("/scannerControl", f.handleScannerCommand)
 	http.HandleFunc("/setWaitForCacheBlock", f.handleSetWaitForCacheBlock)
 	http.HandleFunc("/slotStat", f.handleSlotStat)
+	http.HandleFunc("/keys", f.handleKeys)
+	http.HandleFunc("/purge", f.handlePurge)
 	http.HandleFunc("/submitTask", f.handleSubmitTask)
+
+	bundle.RegisterState("stat", func() interface{} { return f.stat() })
+	bundle.RegisterState("slotStat", func() interface{} { return f.slotStat() })
+	bundle.RegisterState("diskQos", func() interface{} { return f.diskQos() })
 }
 
-func (f *FlashNode) handleStat(w http.ResponseWriter, r *http.Request) {
-	replyOK(w, r, proto.FlashNodeStat{
+func (f *FlashNode) stat() proto.FlashNodeStat {
+	evictPolicy, volEvictPolicy := f.cacheEngine.GetEvictPolicy()
+	readLimit, volReadLimit := f.readLimits.get()
+	return proto.FlashNodeStat{
 		NodeLimit:         uint64(f.readLimiter.Limit()),
 		CacheStatus:       f.cacheEngine.Status(),
 		WaitForCacheBlock: f.waitForCacheBlock,
-	})
+		Admission:         f.cacheEngine.GetAdmissionStat(),
+		HotTier:           f.cacheEngine.GetHotTierStat(),
+		MetaCache:         f.cacheEngine.GetMetaCacheStat(),
+		EvictPolicy:       evictPolicy,
+		VolEvictPolicy:    volEvictPolicy,
+		ReadLimit:         readLimit,
+		VolReadLimit:      volReadLimit,
+	}
+}
+
+func (f *FlashNode) handleStat(w http.ResponseWriter, r *http.Request) {
+	replyOK(w, r, f.stat())
 }
 
 func (f *FlashNode) handleSubmitTask(w http.ResponseWriter, r *http.Request) {
@@ -248,11 +268,14 @@ func (f *FlashNode) handleSetReadDiskQos(w http.ResponseWriter, r *http.Request)
 	replyOK(w, r, nil)
 }
 
-func (f *FlashNode) handleGetDiskQos(w http.ResponseWriter, r *http.Request) {
+func (f *FlashNode) diskQos() proto.FlashNodeLimiterStatusInfo {
 	writeStatus := proto.FlashNodeLimiterStatus{Status: f.limitWrite.Status(true), DiskNum: len(f.disks), ReadTimeout: f.handleReadTimeout}
 	readStatus := proto.FlashNodeLimiterStatus{Status: f.limitRead.Status(true), DiskNum: len(f.disks), ReadTimeout: f.handleReadTimeout}
-	info := proto.FlashNodeLimiterStatusInfo{WriteStatus: writeStatus, ReadStatus: readStatus}
-	replyOK(w, r, info)
+	return proto.FlashNodeLimiterStatusInfo{WriteStatus: writeStatus, ReadStatus: readStatus}
+}
+
+func (f *FlashNode) handleGetDiskQos(w http.ResponseWriter, r *http.Request) {
+	replyOK(w, r, f.diskQos())
 }
 
 func (f *FlashNode) handleScannerCommand(w http.ResponseWriter, r *http.Request) {
@@ -308,10 +331,47 @@ func (f *FlashNode) handleSetWaitForCacheBlock(w http.ResponseWriter, r *http.Re
 	replyOK(w, r, nil)
 }
 
-func (f *FlashNode) handleSlotStat(w http.ResponseWriter, r *http.Request) {
-	replyOK(w, r, proto.FlashNodeSlotStat{
+func (f *FlashNode) slotStat() proto.FlashNodeSlotStat {
+	return proto.FlashNodeSlotStat{
 		NodeId:   f.nodeID,
 		Addr:     f.localAddr,
 		SlotStat: f.GetFlashNodeSlotStat(),
-	})
+	}
+}
+
+func (f *FlashNode) handleKeys(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	limit := _defaultKeysLimit
+	if limitStr := r.FormValue("limit"); limitStr != "" {
+		val, err := strconv.Atoi(limitStr)
+		if err != nil || val < 0 {
+			replyErr(w, r, proto.ErrCodeParamError, fmt.Sprintf("invalid limit(%v)", limitStr), nil)
+			return
+		}
+		limit = val
+	}
+	var hot bool
+	if hotStr := r.FormValue("hot"); hotStr != "" {
+		val, err := strconv.ParseBool(hotStr)
+		if err != nil {
+			replyErr(w, r, proto.ErrCodeParamError, fmt.Sprintf("invalid hot(%v)", hotStr), nil)
+			return
+		}
+		hot = val
+	}
+	replyOK(w, r, f.cacheEngine.ListCacheKeys(r.FormValue("volume"), r.FormValue("prefix"), hot, limit))
+}
+
+func (f *FlashNode) handlePurge(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	volume := r.FormValue("volume")
+	if volume == "" {
+		replyErr(w, r, proto.ErrCodeParamError, "volume name can not be empty", nil)
+		return
+	}
+	replyOK(w, r, f.cacheEngine.PurgeCache(volume, r.FormValue("prefix")))
+}
+
+func (f *FlashNode) handleSlotStat(w http.ResponseWriter, r *http.Request) {
+	replyOK(w, r, f.slotStat())
 }
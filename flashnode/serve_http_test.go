@@ -15,6 +15,7 @@
 package flashnode
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/cubefs/cubefs/flashnode/cachengine"
@@ -26,6 +27,8 @@ var httpCli = httpclient.New()
 
 func testHTTP(t *testing.T) {
 	t.Run("Stat", testHTTPStat)
+	t.Run("Keys", testHTTPKeys)
+	t.Run("Purge", testHTTPPurge)
 	t.Run("EvictVol", testHTTPEvictVol)
 	t.Run("EvictAll", testHTTPEvictAll)
 }
@@ -39,6 +42,43 @@ func testHTTPStat(t *testing.T) {
 	}
 }
 
+func testHTTPKeys(t *testing.T) {
+	keys, err := httpCli.Addr(httpServer.Addr).FlashNode().Keys(_volume, "", true, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, keys.Total)
+	require.Len(t, keys.Keys, 1)
+	key := keys.Keys[0]
+	require.Equal(t, cachengine.GenCacheBlockKey(_volume, _inode, _offset, _version), key.Key)
+	require.Equal(t, _volume, key.Volume)
+	require.Equal(t, _inode, key.Inode)
+	require.Equal(t, _offset, key.Offset)
+
+	keys, err = httpCli.Addr(httpServer.Addr).FlashNode().Keys(_volume, fmt.Sprintf("%d#", _inode), false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, keys.Total)
+	keys, err = httpCli.Addr(httpServer.Addr).FlashNode().Keys(_volume, fmt.Sprintf("%d#", _inode+1), false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, keys.Total)
+	keys, err = httpCli.Addr(httpServer.Addr).FlashNode().Keys("no-such-volume", "", false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, keys.Total)
+	_, err = httpCli.Addr(httpServer.Addr).FlashNode().Keys(_volume, "", false, -1)
+	require.Error(t, err)
+}
+
+func testHTTPPurge(t *testing.T) {
+	_, err := httpCli.Addr(httpServer.Addr).FlashNode().Purge("", "")
+	require.Error(t, err)
+	// the other inodes only, the block is left for the evict tests
+	purged, err := httpCli.Addr(httpServer.Addr).FlashNode().Purge(_volume, fmt.Sprintf("%d#", _inode+1))
+	require.NoError(t, err)
+	require.Equal(t, 0, purged.Purged)
+	require.Empty(t, purged.Failed)
+	keys, err := httpCli.Addr(httpServer.Addr).FlashNode().Keys(_volume, "", false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, keys.Total)
+}
+
 func testHTTPEvictVol(t *testing.T) {
 	require.Error(t, httpCli.Addr(httpServer.Addr).FlashNode().EvictVol(""))
 	st, err := httpCli.Addr(httpServer.Addr).FlashNode().Stat()
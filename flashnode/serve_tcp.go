@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
 )
@@ -80,8 +81,17 @@ func (f *FlashNode) serveConn(conn net.Conn) {
 	c.SetNoDelay(true)
 
 	remoteAddr := conn.RemoteAddr().String()
+	// the clients of the volumes requiring TLS start a TLS handshake on the same port
+	c.SetReadDeadline(time.Now().Add(time.Second * _tcpServerTimeoutSec))
+	conn, _, err := util.AcceptTLSConn(conn, f.tlsConfig)
+	if err != nil {
+		if err != io.EOF {
+			log.LogWarnf("action[serveConn] remote(%v) accept conn err: %v", remoteAddr, err)
+		}
+		return
+	}
 	for {
-		c.SetReadDeadline(time.Now().Add(time.Second * _tcpServerTimeoutSec))
+		conn.SetReadDeadline(time.Now().Add(time.Second * _tcpServerTimeoutSec))
 		select {
 		case <-f.stopCh:
 			return
@@ -89,7 +99,7 @@ func (f *FlashNode) serveConn(conn net.Conn) {
 		}
 
 		p := proto.NewPacketReqID()
-		if err := p.ReadFromConn(c, proto.NoReadDeadlineTime); err != nil {
+		if err := p.ReadFromConn(conn, proto.NoReadDeadlineTime); err != nil {
 			if err != io.EOF {
 				log.LogWarn("flashnode read from remote", err.Error())
 			}
@@ -101,6 +111,9 @@ func (f *FlashNode) serveConn(conn net.Conn) {
 			p.WriteToConn(conn)
 			continue
 		}
+		if f.injectFault(conn, p) {
+			continue
+		}
 		f.handlePacket(conn, p)
 	}
 }
@@ -0,0 +1,664 @@
+package io.cubefs.ofs;
+
+import com.amazonaws.AmazonClientException;
+import com.amazonaws.ClientConfiguration;
+import com.amazonaws.auth.AWSStaticCredentialsProvider;
+import com.amazonaws.auth.BasicAWSCredentials;
+import com.amazonaws.client.builder.AwsClientBuilder;
+import com.amazonaws.services.s3.AmazonS3;
+import com.amazonaws.services.s3.AmazonS3ClientBuilder;
+import com.amazonaws.services.s3.model.AmazonS3Exception;
+import com.amazonaws.services.s3.model.DeleteObjectsRequest;
+import com.amazonaws.services.s3.model.ListObjectsV2Request;
+import com.amazonaws.services.s3.model.ListObjectsV2Result;
+import com.amazonaws.services.s3.model.ObjectMetadata;
+import com.amazonaws.services.s3.model.S3ObjectSummary;
+import com.amazonaws.services.s3.transfer.TransferManager;
+import com.amazonaws.services.s3.transfer.TransferManagerBuilder;
+
+import org.apache.hadoop.conf.Configuration;
+import org.apache.hadoop.fs.FSDataInputStream;
+import org.apache.hadoop.fs.FSDataOutputStream;
+import org.apache.hadoop.fs.FileAlreadyExistsException;
+import org.apache.hadoop.fs.FileStatus;
+import org.apache.hadoop.fs.FileSystem;
+import org.apache.hadoop.fs.LocatedFileStatus;
+import org.apache.hadoop.fs.Path;
+import org.apache.hadoop.fs.PathIsNotEmptyDirectoryException;
+import org.apache.hadoop.fs.RemoteIterator;
+import org.apache.hadoop.fs.permission.FsPermission;
+import org.apache.hadoop.util.Progressable;
+
+import java.io.ByteArrayInputStream;
+import java.io.FileNotFoundException;
+import java.io.IOException;
+import java.io.InterruptedIOException;
+import java.net.URI;
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.Comparator;
+import java.util.Iterator;
+import java.util.List;
+import java.util.NoSuchElementException;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.LinkedBlockingQueue;
+import java.util.concurrent.ThreadPoolExecutor;
+import java.util.concurrent.TimeUnit;
+import java.util.concurrent.atomic.AtomicInteger;
+
+/**
+ * ObjectFileSystem addresses the buckets of the CubeFS object nodes as
+ * ofs://bucket/path over the S3 API, so that the big data jobs can read and
+ * write a bucket without mounting its volume.
+ *
+ * A bucket of the object node is a volume, where the directories are real, so
+ * the status of a path is told by heading the key, or the key with a slash for
+ * a directory, without listing. The files are written by multipart uploads of
+ * the parts buffered, and listed recursively with the flat listing of the keys.
+ */
+public class ObjectFileSystem extends FileSystem {
+    public static final String SCHEME = "ofs";
+
+    public static final String ENDPOINT = "ofs.endpoint";
+    public static final String ACCESS_KEY = "ofs.access.key";
+    public static final String SECRET_KEY = "ofs.secret.key";
+    public static final String REGION = "ofs.region";
+    public static final String MAX_CONNECTIONS = "ofs.max.connections";
+    public static final String MULTIPART_SIZE = "ofs.multipart.size";
+    public static final String MULTIPART_THREADS = "ofs.multipart.threads";
+    public static final String MULTIPART_BUFFERS = "ofs.multipart.buffers";
+    public static final String LIST_MAX_KEYS = "ofs.list.max.keys";
+    public static final String BLOCK_SIZE = "ofs.block.size";
+    public static final String READAHEAD_SIZE = "ofs.readahead.size";
+
+    public static final String DEFAULT_REGION = "cfs_default";
+    public static final int DEFAULT_MAX_CONNECTIONS = 64;
+    public static final long DEFAULT_MULTIPART_SIZE = 16L << 20;
+    // the object node takes parts of at least 1MB but the last
+    public static final long MIN_MULTIPART_SIZE = 1L << 20;
+    public static final int DEFAULT_MULTIPART_THREADS = 8;
+    public static final int DEFAULT_MULTIPART_BUFFERS = 4;
+    public static final int DEFAULT_LIST_MAX_KEYS = 1000;
+    public static final long DEFAULT_BLOCK_SIZE = 128L << 20;
+    public static final long DEFAULT_READAHEAD_SIZE = 1L << 20;
+
+    private static final String DELIMITER = "/";
+    private static final int MAX_DELETE_KEYS = 1000;
+
+    private URI uri;
+    private String bucket;
+    private Path workingDir;
+    private AmazonS3 s3;
+    private TransferManager transfers;
+    private ExecutorService uploadPool;
+    private long partSize;
+    private int partBuffers;
+    private int listMaxKeys;
+    private long blockSize;
+    private long readaheadSize;
+
+    @Override
+    public String getScheme() {
+        return SCHEME;
+    }
+
+    @Override
+    public void initialize(URI name, Configuration conf) throws IOException {
+        super.initialize(name, conf);
+        setConf(conf);
+        bucket = name.getHost();
+        if (bucket == null || bucket.isEmpty()) {
+            throw new IllegalArgumentException("no bucket in " + name);
+        }
+        String endpoint = conf.getTrimmed(ENDPOINT);
+        if (endpoint == null || endpoint.isEmpty()) {
+            throw new IllegalArgumentException(ENDPOINT + " is not set");
+        }
+        if (!endpoint.contains("://")) {
+            endpoint = "http://" + endpoint;
+        }
+        uri = URI.create(SCHEME + "://" + bucket);
+        workingDir = new Path("/user", System.getProperty("user.name")).makeQualified(uri, null);
+
+        partSize = Math.max(conf.getLongBytes(MULTIPART_SIZE, DEFAULT_MULTIPART_SIZE), MIN_MULTIPART_SIZE);
+        partBuffers = Math.max(conf.getInt(MULTIPART_BUFFERS, DEFAULT_MULTIPART_BUFFERS), 1);
+        listMaxKeys = Math.min(Math.max(conf.getInt(LIST_MAX_KEYS, DEFAULT_LIST_MAX_KEYS), 1), DEFAULT_LIST_MAX_KEYS);
+        blockSize = conf.getLongBytes(BLOCK_SIZE, DEFAULT_BLOCK_SIZE);
+        readaheadSize = conf.getLongBytes(READAHEAD_SIZE, DEFAULT_READAHEAD_SIZE);
+
+        s3 = createClient(endpoint, conf);
+        // the copies of the renames, in parts for the objects over 5GB
+        transfers = TransferManagerBuilder.standard().withS3Client(s3).build();
+
+        int threads = Math.max(conf.getInt(MULTIPART_THREADS, DEFAULT_MULTIPART_THREADS), 1);
+        final AtomicInteger seq = new AtomicInteger();
+        ThreadPoolExecutor pool = new ThreadPoolExecutor(threads, threads, 60L, TimeUnit.SECONDS,
+                new LinkedBlockingQueue<Runnable>(), r -> {
+                    Thread t = new Thread(r, "ofs-upload-" + bucket + "-" + seq.incrementAndGet());
+                    t.setDaemon(true);
+                    return t;
+                });
+        pool.allowCoreThreadTimeOut(true);
+        uploadPool = pool;
+    }
+
+    // createClient builds the S3 client of the object nodes at the endpoint,
+    // replaced by the tests with one in memory.
+    AmazonS3 createClient(String endpoint, Configuration conf) throws IOException {
+        char[] secretKey = conf.getPassword(SECRET_KEY);
+        ClientConfiguration clientConf = new ClientConfiguration()
+                .withMaxConnections(conf.getInt(MAX_CONNECTIONS, DEFAULT_MAX_CONNECTIONS));
+        return AmazonS3ClientBuilder.standard()
+                .withEndpointConfiguration(new AwsClientBuilder.EndpointConfiguration(endpoint,
+                        conf.getTrimmed(REGION, DEFAULT_REGION)))
+                .withCredentials(new AWSStaticCredentialsProvider(new BasicAWSCredentials(
+                        conf.getTrimmed(ACCESS_KEY, ""), secretKey == null ? "" : new String(secretKey))))
+                .withPathStyleAccessEnabled(true)
+                .withClientConfiguration(clientConf)
+                .build();
+    }
+
+    @Override
+    public URI getUri() {
+        return uri;
+    }
+
+    @Override
+    public Path getWorkingDirectory() {
+        return workingDir;
+    }
+
+    @Override
+    public void setWorkingDirectory(Path dir) {
+        workingDir = makeQualified(dir);
+    }
+
+    @Override
+    public long getDefaultBlockSize() {
+        return blockSize;
+    }
+
+    @Override
+    public void close() throws IOException {
+        try {
+            super.close();
+        } finally {
+            if (transfers != null) {
+                transfers.shutdownNow(false);
+            }
+            if (uploadPool != null) {
+                uploadPool.shutdown();
+            }
+            if (s3 != null) {
+                s3.shutdown();
+            }
+        }
+    }
+
+    // pathToKey returns the key of the path, "" for the root.
+    String pathToKey(Path path) {
+        if (!path.isAbsolute()) {
+            path = new Path(workingDir, path);
+        }
+        String key = path.toUri().getPath();
+        if (key.startsWith(DELIMITER)) {
+            key = key.substring(1);
+        }
+        if (key.endsWith(DELIMITER)) {
+            key = key.substring(0, key.length() - 1);
+        }
+        return key;
+    }
+
+    private Path keyToPath(String key) {
+        if (key.endsWith(DELIMITER)) {
+            key = key.substring(0, key.length() - 1);
+        }
+        return new Path(DELIMITER + key).makeQualified(uri, workingDir);
+    }
+
+    private static String dirKey(String key) {
+        return key.isEmpty() ? key : key + DELIMITER;
+    }
+
+    private FileStatus fileStatus(String key, long length, long mtime) {
+        return new FileStatus(length, false, 1, blockSize, mtime, keyToPath(key));
+    }
+
+    private FileStatus dirStatus(String key, long mtime) {
+        return new FileStatus(0, true, 1, blockSize, mtime, keyToPath(key));
+    }
+
+    private static boolean notFound(AmazonS3Exception e) {
+        return e.getStatusCode() == 404;
+    }
+
+    // headObject returns the metadata of the key, null if there is none.
+    private ObjectMetadata headObject(String key) throws IOException {
+        statistics.incrementReadOps(1);
+        try {
+            return s3.getObjectMetadata(bucket, key);
+        } catch (AmazonS3Exception e) {
+            if (notFound(e)) {
+                return null;
+            }
+            throw new IOException("head " + key + ": " + e.getMessage(), e);
+        } catch (AmazonClientException e) {
+            throw new IOException("head " + key + ": " + e.getMessage(), e);
+        }
+    }
+
+    @Override
+    public FileStatus getFileStatus(Path f) throws IOException {
+        String key = pathToKey(f);
+        if (key.isEmpty()) {
+            return dirStatus(key, 0);
+        }
+        ObjectMetadata meta = headObject(key);
+        if (meta != null) {
+            return fileStatus(key, meta.getContentLength(), mtime(meta));
+        }
+        // a directory of the object node is only found with the slash
+        meta = headObject(dirKey(key));
+        if (meta != null) {
+            return dirStatus(key, mtime(meta));
+        }
+        throw new FileNotFoundException("no such file or directory: " + f);
+    }
+
+    private static long mtime(ObjectMetadata meta) {
+        return meta.getLastModified() == null ? 0 : meta.getLastModified().getTime();
+    }
+
+    private FileStatus getFileStatusOrNull(Path f) throws IOException {
+        try {
+            return getFileStatus(f);
+        } catch (FileNotFoundException e) {
+            return null;
+        }
+    }
+
+    @Override
+    public FSDataInputStream open(Path f, int bufferSize) throws IOException {
+        FileStatus status = getFileStatus(f);
+        if (status.isDirectory()) {
+            throw new FileNotFoundException("can't open " + f + ": it is a directory");
+        }
+        return new FSDataInputStream(new ObjectInputStream(s3, bucket, pathToKey(f), status.getLen(),
+                readaheadSize, statistics));
+    }
+
+    @Override
+    public FSDataOutputStream create(Path f, FsPermission permission, boolean overwrite, int bufferSize,
+                                     short replication, long blockSize, Progressable progress) throws IOException {
+        String key = pathToKey(f);
+        if (key.isEmpty()) {
+            throw new FileAlreadyExistsException("can't create the root");
+        }
+        FileStatus status = getFileStatusOrNull(f);
+        if (status != null) {
+            if (status.isDirectory()) {
+                throw new FileAlreadyExistsException(f + " is a directory");
+            }
+            if (!overwrite) {
+                throw new FileAlreadyExistsException(f + " already exists");
+            }
+        }
+        statistics.incrementWriteOps(1);
+        return new FSDataOutputStream(new ObjectOutputStream(s3, bucket, key, partSize, partBuffers, uploadPool),
+                statistics);
+    }
+
+    @Override
+    public FSDataOutputStream append(Path f, int bufferSize, Progressable progress) throws IOException {
+        throw new UnsupportedOperationException("append is not supported by " + SCHEME);
+    }
+
+    @Override
+    public boolean mkdirs(Path f, FsPermission permission) throws IOException {
+        String key = pathToKey(f);
+        if (key.isEmpty()) {
+            return true;
+        }
+        FileStatus status = getFileStatusOrNull(f);
+        if (status != null) {
+            if (status.isDirectory()) {
+                return true;
+            }
+            throw new FileAlreadyExistsException(f + " is a file");
+        }
+        for (Path parent = f.getParent(); parent != null && !parent.isRoot(); parent = parent.getParent()) {
+            status = getFileStatusOrNull(parent);
+            if (status == null) {
+                continue;
+            }
+            if (!status.isDirectory()) {
+                throw new FileAlreadyExistsException(parent + " is a file");
+            }
+            break;
+        }
+        // the object node makes the parent directories as well
+        ObjectMetadata meta = new ObjectMetadata();
+        meta.setContentLength(0);
+        statistics.incrementWriteOps(1);
+        try {
+            s3.putObject(bucket, dirKey(key), new ByteArrayInputStream(new byte[0]), meta);
+        } catch (AmazonClientException e) {
+            throw new IOException("mkdir " + f + ": " + e.getMessage(), e);
+        }
+        return true;
+    }
+
+    @Override
+    public FileStatus[] listStatus(Path f) throws IOException {
+        FileStatus status = getFileStatus(f);
+        if (!status.isDirectory()) {
+            return new FileStatus[]{status};
+        }
+        List<FileStatus> statuses = new ArrayList<>();
+        ObjectIterator it = new ObjectIterator(dirKey(pathToKey(f)), false, true);
+        while (it.hasNext()) {
+            statuses.add(it.next());
+        }
+        return statuses.toArray(new FileStatus[0]);
+    }
+
+    @Override
+    public RemoteIterator<LocatedFileStatus> listLocatedStatus(Path f) throws IOException {
+        FileStatus status = getFileStatus(f);
+        if (!status.isDirectory()) {
+            return locatedIterator(Collections.singletonList(status).iterator());
+        }
+        return locatedIterator(new ObjectIterator(dirKey(pathToKey(f)), false, true));
+    }
+
+    /**
+     * listFiles lists the files under the directory page by page, the files of
+     * all the sub directories with one flat listing of the keys if recursive.
+     */
+    @Override
+    public RemoteIterator<LocatedFileStatus> listFiles(Path f, boolean recursive) throws IOException {
+        FileStatus status = getFileStatus(f);
+        if (!status.isDirectory()) {
+            return locatedIterator(Collections.singletonList(status).iterator());
+        }
+        return locatedIterator(new ObjectIterator(dirKey(pathToKey(f)), recursive, false));
+    }
+
+    private interface StatusIterator {
+        boolean hasNext() throws IOException;
+
+        FileStatus next() throws IOException;
+    }
+
+    private RemoteIterator<LocatedFileStatus> locatedIterator(final Iterator<FileStatus> it) {
+        return locatedIterator(new StatusIterator() {
+            @Override
+            public boolean hasNext() {
+                return it.hasNext();
+            }
+
+            @Override
+            public FileStatus next() {
+                return it.next();
+            }
+        });
+    }
+
+    private RemoteIterator<LocatedFileStatus> locatedIterator(final StatusIterator it) {
+        return new RemoteIterator<LocatedFileStatus>() {
+            @Override
+            public boolean hasNext() throws IOException {
+                return it.hasNext();
+            }
+
+            @Override
+            public LocatedFileStatus next() throws IOException {
+                FileStatus status = it.next();
+                return new LocatedFileStatus(status,
+                        status.isFile() ? getFileBlockLocations(status, 0, status.getLen()) : null);
+            }
+        };
+    }
+
+    /**
+     * ObjectIterator lists the keys under the prefix a page at a time, the
+     * entries of the directory with the delimiter, or the files under it
+     * without. The directory keys are taken as directories only if withDirs.
+     */
+    private class ObjectIterator implements StatusIterator {
+        private final String prefix;
+        private final boolean recursive;
+        private final boolean withDirs;
+        private String token;
+        private boolean truncated = true;
+        private final List<FileStatus> page = new ArrayList<>();
+        private int index;
+
+        ObjectIterator(String prefix, boolean recursive, boolean withDirs) {
+            this.prefix = prefix;
+            this.recursive = recursive;
+            this.withDirs = withDirs;
+        }
+
+        @Override
+        public boolean hasNext() throws IOException {
+            while (index >= page.size() && truncated) {
+                fetch();
+            }
+            return index < page.size();
+        }
+
+        @Override
+        public FileStatus next() throws IOException {
+            if (!hasNext()) {
+                throw new NoSuchElementException();
+            }
+            return page.get(index++);
+        }
+
+        private void fetch() throws IOException {
+            ListObjectsV2Request req = new ListObjectsV2Request()
+                    .withBucketName(bucket)
+                    .withPrefix(prefix)
+                    .withMaxKeys(listMaxKeys)
+                    .withContinuationToken(token);
+            if (!recursive) {
+                req.setDelimiter(DELIMITER);
+            }
+            ListObjectsV2Result result = list(req);
+            page.clear();
+            index = 0;
+            for (S3ObjectSummary summary : result.getObjectSummaries()) {
+                String key = summary.getKey();
+                if (key.equals(prefix)) {
+                    continue;
+                }
+                long mtime = summary.getLastModified() == null ? 0 : summary.getLastModified().getTime();
+                if (key.endsWith(DELIMITER)) {
+                    if (withDirs) {
+                        page.add(dirStatus(key, mtime));
+                    }
+                    continue;
+                }
+                page.add(fileStatus(key, summary.getSize(), mtime));
+            }
+            if (withDirs) {
+                for (String dir : result.getCommonPrefixes()) {
+                    page.add(dirStatus(dir, 0));
+                }
+            }
+            truncated = result.isTruncated();
+            token = result.getNextContinuationToken();
+        }
+    }
+
+    private ListObjectsV2Result list(ListObjectsV2Request req) throws IOException {
+        statistics.incrementReadOps(1);
+        try {
+            return s3.listObjectsV2(req);
+        } catch (AmazonClientException e) {
+            throw new IOException("list " + req.getPrefix() + ": " + e.getMessage(), e);
+        }
+    }
+
+    private boolean isEmptyDir(String key) throws IOException {
+        String prefix = dirKey(key);
+        ListObjectsV2Result result = list(new ListObjectsV2Request()
+                .withBucketName(bucket)
+                .withPrefix(prefix)
+                .withDelimiter(DELIMITER)
+                .withMaxKeys(2));
+        for (S3ObjectSummary summary : result.getObjectSummaries()) {
+            if (!summary.getKey().equals(prefix)) {
+                return false;
+            }
+        }
+        return result.getCommonPrefixes().isEmpty();
+    }
+
+    // allKeys returns the keys under the directory, the deepest directories
+    // last but before their parents, so that they are empty when deleted.
+    private List<String> allKeys(String key) throws IOException {
+        String prefix = dirKey(key);
+        List<String> files = new ArrayList<>();
+        List<String> dirs = new ArrayList<>();
+        String token = null;
+        do {
+            ListObjectsV2Result result = list(new ListObjectsV2Request()
+                    .withBucketName(bucket)
+                    .withPrefix(prefix)
+                    .withMaxKeys(listMaxKeys)
+                    .withContinuationToken(token));
+            for (S3ObjectSummary summary : result.getObjectSummaries()) {
+                if (summary.getKey().endsWith(DELIMITER)) {
+                    dirs.add(summary.getKey());
+                } else {
+                    files.add(summary.getKey());
+                }
+            }
+            token = result.isTruncated() ? result.getNextContinuationToken() : null;
+        } while (token != null);
+        if (!dirs.contains(prefix)) {
+            dirs.add(prefix);
+        }
+        dirs.sort(Comparator.<String>comparingInt(String::length).reversed());
+        files.addAll(dirs);
+        return files;
+    }
+
+    private void deleteKeys(List<String> keys) throws IOException {
+        for (int i = 0; i < keys.size(); i += MAX_DELETE_KEYS) {
+            List<DeleteObjectsRequest.KeyVersion> batch = new ArrayList<>();
+            for (String key : keys.subList(i, Math.min(i + MAX_DELETE_KEYS, keys.size()))) {
+                batch.add(new DeleteObjectsRequest.KeyVersion(key));
+            }
+            statistics.incrementWriteOps(1);
+            try {
+                s3.deleteObjects(new DeleteObjectsRequest(bucket).withKeys(batch).withQuiet(true));
+            } catch (AmazonClientException e) {
+                throw new IOException("delete " + keys.get(i) + ": " + e.getMessage(), e);
+            }
+        }
+    }
+
+    @Override
+    public boolean delete(Path f, boolean recursive) throws IOException {
+        String key = pathToKey(f);
+        FileStatus status = getFileStatusOrNull(f);
+        if (status == null) {
+            return false;
+        }
+        if (!status.isDirectory()) {
+            deleteKeys(Collections.singletonList(key));
+            return true;
+        }
+        if (!isEmptyDir(key)) {
+            if (!recursive) {
+                throw new PathIsNotEmptyDirectoryException(f.toString());
+            }
+        } else if (key.isEmpty()) {
+            return true;
+        }
+        List<String> keys = allKeys(key);
+        if (key.isEmpty()) {
+            // keep the root
+            keys.remove(keys.size() - 1);
+        }
+        deleteKeys(keys);
+        return true;
+    }
+
+    private void copy(String src, String dst) throws IOException {
+        statistics.incrementWriteOps(1);
+        try {
+            transfers.copy(bucket, src, bucket, dst).waitForCompletion();
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+            throw new InterruptedIOException("copy " + src + " to " + dst + " interrupted");
+        } catch (AmazonClientException e) {
+            throw new IOException("copy " + src + " to " + dst + ": " + e.getMessage(), e);
+        }
+    }
+
+    /**
+     * rename copies the objects to the destination and deletes the source,
+     * so that it is not atomic for a directory.
+     */
+    @Override
+    public boolean rename(Path src, Path dst) throws IOException {
+        String srcKey = pathToKey(src);
+        if (srcKey.isEmpty()) {
+            return false;
+        }
+        FileStatus srcStatus = getFileStatusOrNull(src);
+        if (srcStatus == null) {
+            return false;
+        }
+        String dstKey = pathToKey(dst);
+        FileStatus dstStatus = getFileStatusOrNull(dst);
+        if (dstStatus != null) {
+            if (!dstStatus.isDirectory()) {
+                return false;
+            }
+            dstKey = dirKey(dstKey) + src.getName();
+            if (getFileStatusOrNull(keyToPath(dstKey)) != null) {
+                return false;
+            }
+        } else {
+            Path parent = dst.getParent();
+            FileStatus parentStatus = parent == null ? null : getFileStatusOrNull(parent);
+            if (parent != null && !parent.isRoot() && (parentStatus == null || !parentStatus.isDirectory())) {
+                return false;
+            }
+        }
+        if (dstKey.equals(srcKey)) {
+            return srcStatus.isFile();
+        }
+        if (!srcStatus.isDirectory()) {
+            copy(srcKey, dstKey);
+            deleteKeys(Collections.singletonList(srcKey));
+            return true;
+        }
+        if (dstKey.startsWith(dirKey(srcKey))) {
+            // into itself
+            return false;
+        }
+        List<String> keys = allKeys(srcKey);
+        String srcPrefix = dirKey(srcKey);
+        String dstPrefix = dirKey(dstKey);
+        mkdirs(keyToPath(dstKey), FsPermission.getDirDefault());
+        for (String key : keys) {
+            String target = dstPrefix + key.substring(srcPrefix.length());
+            if (key.endsWith(DELIMITER)) {
+                if (!key.equals(srcPrefix)) {
+                    mkdirs(keyToPath(target), FsPermission.getDirDefault());
+                }
+                continue;
+            }
+            copy(key, target);
+        }
+        deleteKeys(keys);
+        return true;
+    }
+}
@@ -0,0 +1,170 @@
+package io.cubefs.ofs;
+
+import com.amazonaws.AmazonClientException;
+import com.amazonaws.services.s3.AmazonS3;
+import com.amazonaws.services.s3.model.GetObjectRequest;
+import com.amazonaws.services.s3.model.S3ObjectInputStream;
+
+import org.apache.hadoop.fs.FSInputStream;
+import org.apache.hadoop.fs.FileSystem;
+
+import java.io.EOFException;
+import java.io.IOException;
+
+/**
+ * ObjectInputStream reads an object with the ranged gets from the position
+ * to the end. A seek forward within the readahead size skips the bytes of the
+ * get open, and any other seek opens a new get at the next read.
+ */
+public class ObjectInputStream extends FSInputStream {
+    private final AmazonS3 s3;
+    private final String bucket;
+    private final String key;
+    private final long length;
+    private final long readaheadSize;
+    private final FileSystem.Statistics statistics;
+
+    private S3ObjectInputStream in;
+    private long streamPos; // the position of in
+    private long pos;
+    private boolean closed;
+
+    public ObjectInputStream(AmazonS3 s3, String bucket, String key, long length, long readaheadSize,
+                             FileSystem.Statistics statistics) {
+        this.s3 = s3;
+        this.bucket = bucket;
+        this.key = key;
+        this.length = length;
+        this.readaheadSize = readaheadSize;
+        this.statistics = statistics;
+    }
+
+    private void checkOpen() throws IOException {
+        if (closed) {
+            throw new IOException("stream of " + key + " is closed");
+        }
+    }
+
+    private void closeStream() {
+        if (in == null) {
+            return;
+        }
+        // drop the connection rather than read the rest of a large range
+        if (streamPos < length) {
+            in.abort();
+        } else {
+            try {
+                in.close();
+            } catch (IOException ignored) {
+                // the stream is drained
+            }
+        }
+        in = null;
+    }
+
+    private void reopen() throws IOException {
+        if (in != null) {
+            long skip = pos - streamPos;
+            if (skip == 0) {
+                return;
+            }
+            if (skip > 0 && skip <= readaheadSize) {
+                while (streamPos < pos) {
+                    long n = in.skip(pos - streamPos);
+                    if (n <= 0) {
+                        break;
+                    }
+                    streamPos += n;
+                }
+                if (streamPos == pos) {
+                    return;
+                }
+            }
+            closeStream();
+        }
+        if (statistics != null) {
+            statistics.incrementReadOps(1);
+        }
+        try {
+            in = s3.getObject(new GetObjectRequest(bucket, key).withRange(pos, length - 1)).getObjectContent();
+        } catch (AmazonClientException e) {
+            throw new IOException("get " + key + " at " + pos + ": " + e.getMessage(), e);
+        }
+        streamPos = pos;
+    }
+
+    @Override
+    public synchronized void seek(long targetPos) throws IOException {
+        checkOpen();
+        if (targetPos < 0) {
+            throw new EOFException("can't seek to " + targetPos);
+        }
+        if (targetPos > length) {
+            throw new EOFException("can't seek to " + targetPos + " past the end " + length);
+        }
+        pos = targetPos;
+    }
+
+    @Override
+    public synchronized long getPos() {
+        return pos;
+    }
+
+    @Override
+    public boolean seekToNewSource(long targetPos) {
+        return false;
+    }
+
+    @Override
+    public synchronized int available() throws IOException {
+        checkOpen();
+        return (int) Math.min(Integer.MAX_VALUE, length - pos);
+    }
+
+    @Override
+    public synchronized int read() throws IOException {
+        byte[] b = new byte[1];
+        int n = read(b, 0, 1);
+        return n < 0 ? -1 : b[0] & 0xff;
+    }
+
+    @Override
+    public synchronized int read(byte[] buf, int off, int len) throws IOException {
+        checkOpen();
+        if (len == 0) {
+            return 0;
+        }
+        if (pos >= length) {
+            return -1;
+        }
+        reopen();
+        int n;
+        try {
+            n = in.read(buf, off, len);
+        } catch (IOException e) {
+            // read again once from a new get
+            closeStream();
+            reopen();
+            n = in.read(buf, off, len);
+        }
+        if (n < 0) {
+            throw new EOFException("unexpected end of " + key + " at " + pos + " of " + length);
+        }
+        pos += n;
+        streamPos += n;
+        if (statistics != null) {
+            statistics.incrementBytesRead(n);
+        }
+        return n;
+    }
+
+    @Override
+    public synchronized void close() throws IOException {
+        if (closed) {
+            return;
+        }
+        closed = true;
+        closeStream();
+        super.close();
+    }
+}
@@ -0,0 +1,196 @@
+package io.cubefs.ofs;
+
+import com.amazonaws.AmazonClientException;
+import com.amazonaws.services.s3.AmazonS3;
+import com.amazonaws.services.s3.model.AbortMultipartUploadRequest;
+import com.amazonaws.services.s3.model.CompleteMultipartUploadRequest;
+import com.amazonaws.services.s3.model.InitiateMultipartUploadRequest;
+import com.amazonaws.services.s3.model.ObjectMetadata;
+import com.amazonaws.services.s3.model.PartETag;
+import com.amazonaws.services.s3.model.PutObjectRequest;
+import com.amazonaws.services.s3.model.UploadPartRequest;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InterruptedIOException;
+import java.io.OutputStream;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.concurrent.ExecutionException;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Future;
+import java.util.concurrent.Semaphore;
+
+/**
+ * ObjectOutputStream buffers the bytes written by the part size. An object
+ * smaller than a part is put at close, a larger one is uploaded by parts on
+ * the upload pool while the next part is written, with no more than the
+ * given buffers of parts in memory.
+ */
+public class ObjectOutputStream extends OutputStream {
+    private final AmazonS3 s3;
+    private final String bucket;
+    private final String key;
+    private final int partSize;
+    private final ExecutorService uploadPool;
+    private final Semaphore buffers;
+    private final List<Future<PartETag>> parts = new ArrayList<>();
+
+    private byte[] buf;
+    private int count;
+    private String uploadId;
+    private boolean closed;
+
+    public ObjectOutputStream(AmazonS3 s3, String bucket, String key, long partSize, int partBuffers,
+                              ExecutorService uploadPool) {
+        this.s3 = s3;
+        this.bucket = bucket;
+        this.key = key;
+        this.partSize = (int) Math.min(partSize, Integer.MAX_VALUE - 8);
+        this.uploadPool = uploadPool;
+        this.buffers = new Semaphore(partBuffers);
+    }
+
+    private void checkOpen() throws IOException {
+        if (closed) {
+            throw new IOException("stream of " + key + " is closed");
+        }
+    }
+
+    @Override
+    public synchronized void write(int b) throws IOException {
+        write(new byte[]{(byte) b}, 0, 1);
+    }
+
+    @Override
+    public synchronized void write(byte[] b, int off, int len) throws IOException {
+        checkOpen();
+        while (len > 0) {
+            if (buf == null) {
+                acquireBuffer();
+                buf = new byte[partSize];
+            }
+            int n = Math.min(len, partSize - count);
+            System.arraycopy(b, off, buf, count, n);
+            count += n;
+            off += n;
+            len -= n;
+            if (count == partSize) {
+                uploadPart();
+            }
+        }
+    }
+
+    private void acquireBuffer() throws IOException {
+        try {
+            buffers.acquire();
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+            throw new InterruptedIOException("write " + key + " is interrupted");
+        }
+    }
+
+    private void uploadPart() throws IOException {
+        if (uploadId == null) {
+            try {
+                uploadId = s3.initiateMultipartUpload(new InitiateMultipartUploadRequest(bucket, key)).getUploadId();
+            } catch (AmazonClientException e) {
+                releaseBuffer();
+                throw new IOException("initiate the upload of " + key + ": " + e.getMessage(), e);
+            }
+        }
+        final UploadPartRequest req = new UploadPartRequest()
+                .withBucketName(bucket)
+                .withKey(key)
+                .withUploadId(uploadId)
+                .withPartNumber(parts.size() + 1)
+                .withInputStream(new ByteArrayInputStream(buf, 0, count))
+                .withPartSize(count);
+        buf = null;
+        count = 0;
+        parts.add(uploadPool.submit(() -> {
+            try {
+                return s3.uploadPart(req).getPartETag();
+            } finally {
+                buffers.release();
+            }
+        }));
+    }
+
+    private void releaseBuffer() {
+        buf = null;
+        count = 0;
+        buffers.release();
+    }
+
+    private List<PartETag> waitParts() throws IOException {
+        List<PartETag> etags = new ArrayList<>(parts.size());
+        for (Future<PartETag> part : parts) {
+            try {
+                etags.add(part.get());
+            } catch (InterruptedException e) {
+                Thread.currentThread().interrupt();
+                throw new InterruptedIOException("upload of " + key + " is interrupted");
+            } catch (ExecutionException e) {
+                throw new IOException("upload a part of " + key + ": " + e.getCause().getMessage(), e.getCause());
+            }
+        }
+        return etags;
+    }
+
+    private void abort() {
+        for (Future<PartETag> part : parts) {
+            part.cancel(true);
+        }
+        try {
+            s3.abortMultipartUpload(new AbortMultipartUploadRequest(bucket, key, uploadId));
+        } catch (AmazonClientException ignored) {
+            // the object node cleans up the parts left
+        }
+    }
+
+    /**
+     * The bytes are only visible after close, flush keeps them buffered.
+     */
+    @Override
+    public void flush() {
+    }
+
+    @Override
+    public synchronized void close() throws IOException {
+        if (closed) {
+            return;
+        }
+        closed = true;
+        if (uploadId == null) {
+            ObjectMetadata meta = new ObjectMetadata();
+            meta.setContentLength(count);
+            byte[] data = buf == null ? new byte[0] : buf;
+            try {
+                s3.putObject(new PutObjectRequest(bucket, key, new ByteArrayInputStream(data, 0, count), meta));
+            } catch (AmazonClientException e) {
+                throw new IOException("put " + key + ": " + e.getMessage(), e);
+            } finally {
+                if (buf != null) {
+                    releaseBuffer();
+                }
+            }
+            return;
+        }
+        try {
+            if (count > 0) {
+                uploadPart();
+            } else if (buf != null) {
+                releaseBuffer();
+            }
+            List<PartETag> etags = waitParts();
+            s3.completeMultipartUpload(new CompleteMultipartUploadRequest(bucket, key, uploadId, etags));
+        } catch (IOException e) {
+            abort();
+            throw e;
+        } catch (AmazonClientException e) {
+            abort();
+            throw new IOException("complete the upload of " + key + ": " + e.getMessage(), e);
+        }
+    }
+}
@@ -0,0 +1,306 @@
+package io.cubefs.ofs;
+
+import com.amazonaws.services.s3.AbstractAmazonS3;
+import com.amazonaws.services.s3.model.AbortMultipartUploadRequest;
+import com.amazonaws.services.s3.model.AmazonS3Exception;
+import com.amazonaws.services.s3.model.CompleteMultipartUploadRequest;
+import com.amazonaws.services.s3.model.CompleteMultipartUploadResult;
+import com.amazonaws.services.s3.model.CopyObjectRequest;
+import com.amazonaws.services.s3.model.CopyObjectResult;
+import com.amazonaws.services.s3.model.DeleteObjectsRequest;
+import com.amazonaws.services.s3.model.DeleteObjectsResult;
+import com.amazonaws.services.s3.model.GetObjectMetadataRequest;
+import com.amazonaws.services.s3.model.GetObjectRequest;
+import com.amazonaws.services.s3.model.InitiateMultipartUploadRequest;
+import com.amazonaws.services.s3.model.InitiateMultipartUploadResult;
+import com.amazonaws.services.s3.model.ListObjectsV2Request;
+import com.amazonaws.services.s3.model.ListObjectsV2Result;
+import com.amazonaws.services.s3.model.ObjectMetadata;
+import com.amazonaws.services.s3.model.PartETag;
+import com.amazonaws.services.s3.model.PutObjectRequest;
+import com.amazonaws.services.s3.model.PutObjectResult;
+import com.amazonaws.services.s3.model.S3Object;
+import com.amazonaws.services.s3.model.S3ObjectInputStream;
+import com.amazonaws.services.s3.model.S3ObjectSummary;
+import com.amazonaws.services.s3.model.UploadPartRequest;
+import com.amazonaws.services.s3.model.UploadPartResult;
+
+import org.apache.http.client.methods.HttpGet;
+
+import java.io.ByteArrayInputStream;
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Date;
+import java.util.List;
+import java.util.Map;
+import java.util.TreeMap;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.atomic.AtomicInteger;
+
+/**
+ * FakeObjectNode keeps the objects of a bucket in memory and answers the S3
+ * calls of the file system like an object node, which makes the parent
+ * directories of a key and lists with the delimiter by common prefixes.
+ */
+class FakeObjectNode extends AbstractAmazonS3 {
+    private static final String DELIMITER = "/";
+
+    private final TreeMap<String, byte[]> objects = new TreeMap<>();
+    private final Map<String, Map<Integer, byte[]>> uploads = new ConcurrentHashMap<>();
+    private final AtomicInteger uploadSeq = new AtomicInteger();
+
+    final AtomicInteger puts = new AtomicInteger();
+    final AtomicInteger uploadedParts = new AtomicInteger();
+    final AtomicInteger completedUploads = new AtomicInteger();
+    final AtomicInteger abortedUploads = new AtomicInteger();
+    final AtomicInteger lists = new AtomicInteger();
+    volatile boolean failUploadPart;
+
+    synchronized byte[] object(String key) {
+        return objects.get(key);
+    }
+
+    synchronized boolean exists(String key) {
+        return objects.containsKey(key);
+    }
+
+    int openUploads() {
+        return uploads.size();
+    }
+
+    private static AmazonS3Exception notFound(String key) {
+        AmazonS3Exception e = new AmazonS3Exception("no such key " + key);
+        e.setStatusCode(404);
+        e.setErrorCode("NoSuchKey");
+        return e;
+    }
+
+    private static byte[] readAll(InputStream in) {
+        ByteArrayOutputStream out = new ByteArrayOutputStream();
+        byte[] buf = new byte[8192];
+        try {
+            for (int n = in.read(buf); n >= 0; n = in.read(buf)) {
+                out.write(buf, 0, n);
+            }
+        } catch (IOException e) {
+            throw new IllegalStateException(e);
+        }
+        return out.toByteArray();
+    }
+
+    private synchronized void store(String key, byte[] data) {
+        for (int i = key.indexOf(DELIMITER); i >= 0 && i < key.length() - 1; i = key.indexOf(DELIMITER, i + 1)) {
+            objects.putIfAbsent(key.substring(0, i + 1), new byte[0]);
+        }
+        objects.put(key, data);
+    }
+
+    @Override
+    public synchronized ObjectMetadata getObjectMetadata(String bucketName, String key) {
+        byte[] data = objects.get(key);
+        if (data == null) {
+            throw notFound(key);
+        }
+        ObjectMetadata meta = new ObjectMetadata();
+        meta.setContentLength(data.length);
+        meta.setLastModified(new Date());
+        return meta;
+    }
+
+    @Override
+    public ObjectMetadata getObjectMetadata(GetObjectMetadataRequest req) {
+        return getObjectMetadata(req.getBucketName(), req.getKey());
+    }
+
+    @Override
+    public PutObjectResult putObject(String bucketName, String key, InputStream input, ObjectMetadata metadata) {
+        puts.incrementAndGet();
+        store(key, readAll(input));
+        return new PutObjectResult();
+    }
+
+    @Override
+    public PutObjectResult putObject(PutObjectRequest req) {
+        return putObject(req.getBucketName(), req.getKey(), req.getInputStream(), req.getMetadata());
+    }
+
+    @Override
+    public synchronized S3Object getObject(GetObjectRequest req) {
+        byte[] data = objects.get(req.getKey());
+        if (data == null) {
+            throw notFound(req.getKey());
+        }
+        long[] range = req.getRange();
+        int start = range == null ? 0 : (int) range[0];
+        int end = range == null ? data.length : (int) Math.min(range[1] + 1, data.length);
+        S3Object object = new S3Object();
+        object.setBucketName(req.getBucketName());
+        object.setKey(req.getKey());
+        object.setObjectContent(new S3ObjectInputStream(
+                new ByteArrayInputStream(Arrays.copyOfRange(data, start, end)), new HttpGet()));
+        return object;
+    }
+
+    /**
+     * listObjectsV2 pages the keys under the prefix, the continuation token
+     * being the last key or common prefix returned, marked by K or P.
+     */
+    @Override
+    public synchronized ListObjectsV2Result listObjectsV2(ListObjectsV2Request req) {
+        lists.incrementAndGet();
+        String prefix = req.getPrefix() == null ? "" : req.getPrefix();
+        String delimiter = req.getDelimiter();
+        int maxKeys = req.getMaxKeys() == null ? 1000 : req.getMaxKeys();
+        String token = req.getContinuationToken();
+        String after = token == null ? null : token.substring(1);
+        boolean afterPrefix = token != null && token.startsWith("P");
+
+        ListObjectsV2Result result = new ListObjectsV2Result();
+        result.setBucketName(req.getBucketName());
+        result.setPrefix(prefix);
+        result.setDelimiter(delimiter);
+        List<String> commonPrefixes = new ArrayList<>();
+        String last = null;
+        int count = 0;
+        for (Map.Entry<String, byte[]> entry : objects.tailMap(prefix, true).entrySet()) {
+            String key = entry.getKey();
+            if (!key.startsWith(prefix)) {
+                break;
+            }
+            if (after != null && (key.compareTo(after) <= 0 || (afterPrefix && key.startsWith(after)))) {
+                continue;
+            }
+            String common = null;
+            if (delimiter != null) {
+                int i = key.indexOf(delimiter, prefix.length());
+                if (i >= 0) {
+                    common = key.substring(0, i + delimiter.length());
+                }
+            }
+            if (common != null && !commonPrefixes.isEmpty()
+                    && commonPrefixes.get(commonPrefixes.size() - 1).equals(common)) {
+                continue;
+            }
+            if (count == maxKeys) {
+                result.setTruncated(true);
+                result.setNextContinuationToken(last);
+                break;
+            }
+            if (common != null) {
+                commonPrefixes.add(common);
+                last = "P" + common;
+            } else {
+                S3ObjectSummary summary = new S3ObjectSummary();
+                summary.setBucketName(req.getBucketName());
+                summary.setKey(key);
+                summary.setSize(entry.getValue().length);
+                summary.setLastModified(new Date());
+                result.getObjectSummaries().add(summary);
+                last = "K" + key;
+            }
+            count++;
+        }
+        result.setCommonPrefixes(commonPrefixes);
+        result.setKeyCount(count);
+        return result;
+    }
+
+    @Override
+    public synchronized DeleteObjectsResult deleteObjects(DeleteObjectsRequest req) {
+        List<DeleteObjectsResult.DeletedObject> deleted = new ArrayList<>();
+        for (DeleteObjectsRequest.KeyVersion kv : req.getKeys()) {
+            objects.remove(kv.getKey());
+            DeleteObjectsResult.DeletedObject d = new DeleteObjectsResult.DeletedObject();
+            d.setKey(kv.getKey());
+            deleted.add(d);
+        }
+        return new DeleteObjectsResult(deleted);
+    }
+
+    @Override
+    public InitiateMultipartUploadResult initiateMultipartUpload(InitiateMultipartUploadRequest req) {
+        String uploadId = "upload-" + uploadSeq.incrementAndGet();
+        uploads.put(uploadId, new ConcurrentHashMap<>());
+        InitiateMultipartUploadResult result = new InitiateMultipartUploadResult();
+        result.setBucketName(req.getBucketName());
+        result.setKey(req.getKey());
+        result.setUploadId(uploadId);
+        return result;
+    }
+
+    @Override
+    public UploadPartResult uploadPart(UploadPartRequest req) {
+        if (failUploadPart) {
+            throw new AmazonS3Exception("part " + req.getPartNumber() + " failed");
+        }
+        Map<Integer, byte[]> parts = uploads.get(req.getUploadId());
+        if (parts == null) {
+            throw notFound(req.getUploadId());
+        }
+        byte[] data = readAll(req.getInputStream());
+        if (data.length != req.getPartSize()) {
+            throw new AmazonS3Exception("part " + req.getPartNumber() + " is " + data.length
+                    + " bytes, not " + req.getPartSize());
+        }
+        parts.put(req.getPartNumber(), data);
+        uploadedParts.incrementAndGet();
+        UploadPartResult result = new UploadPartResult();
+        result.setPartNumber(req.getPartNumber());
+        result.setETag("etag-" + req.getPartNumber());
+        return result;
+    }
+
+    @Override
+    public CompleteMultipartUploadResult completeMultipartUpload(CompleteMultipartUploadRequest req) {
+        Map<Integer, byte[]> parts = uploads.remove(req.getUploadId());
+        if (parts == null) {
+            throw notFound(req.getUploadId());
+        }
+        ByteArrayOutputStream out = new ByteArrayOutputStream();
+        int number = 0;
+        for (PartETag etag : req.getPartETags()) {
+            if (etag.getPartNumber() != ++number) {
+                throw new AmazonS3Exception("part " + etag.getPartNumber() + " out of order");
+            }
+            byte[] data = parts.get(etag.getPartNumber());
+            if (data == null) {
+                throw new AmazonS3Exception("part " + etag.getPartNumber() + " not uploaded");
+            }
+            out.write(data, 0, data.length);
+        }
+        store(req.getKey(), out.toByteArray());
+        completedUploads.incrementAndGet();
+        CompleteMultipartUploadResult result = new CompleteMultipartUploadResult();
+        result.setBucketName(req.getBucketName());
+        result.setKey(req.getKey());
+        return result;
+    }
+
+    @Override
+    public void abortMultipartUpload(AbortMultipartUploadRequest req) {
+        uploads.remove(req.getUploadId());
+        abortedUploads.incrementAndGet();
+    }
+
+    @Override
+    public CopyObjectResult copyObject(CopyObjectRequest req) {
+        byte[] data;
+        synchronized (this) {
+            data = objects.get(req.getSourceKey());
+        }
+        if (data == null) {
+            throw notFound(req.getSourceKey());
+        }
+        store(req.getDestinationKey(), data);
+        CopyObjectResult result = new CopyObjectResult();
+        result.setLastModifiedDate(new Date());
+        return result;
+    }
+
+    @Override
+    public void shutdown() {
+    }
+}
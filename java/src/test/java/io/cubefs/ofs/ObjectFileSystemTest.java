@@ -0,0 +1,275 @@
+package io.cubefs.ofs;
+
+import com.amazonaws.services.s3.AmazonS3;
+
+import org.apache.hadoop.conf.Configuration;
+import org.apache.hadoop.fs.FSDataInputStream;
+import org.apache.hadoop.fs.FSDataOutputStream;
+import org.apache.hadoop.fs.FileAlreadyExistsException;
+import org.apache.hadoop.fs.FileStatus;
+import org.apache.hadoop.fs.LocatedFileStatus;
+import org.apache.hadoop.fs.Path;
+import org.apache.hadoop.fs.PathIsNotEmptyDirectoryException;
+import org.apache.hadoop.fs.RemoteIterator;
+import org.junit.After;
+import org.junit.Before;
+import org.junit.Test;
+
+import java.io.FileNotFoundException;
+import java.io.IOException;
+import java.net.URI;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.List;
+import java.util.Random;
+
+import static org.junit.Assert.assertArrayEquals;
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.assertFalse;
+import static org.junit.Assert.assertNull;
+import static org.junit.Assert.assertTrue;
+import static org.junit.Assert.fail;
+
+public class ObjectFileSystemTest {
+    private static final int PART_SIZE = 1 << 20;
+
+    private FakeObjectNode node;
+    private ObjectFileSystem fs;
+
+    @Before
+    public void setUp() throws IOException {
+        node = new FakeObjectNode();
+        Configuration conf = new Configuration(false);
+        conf.set(ObjectFileSystem.ENDPOINT, "127.0.0.1:17410");
+        conf.setLong(ObjectFileSystem.MULTIPART_SIZE, PART_SIZE);
+        conf.setInt(ObjectFileSystem.MULTIPART_BUFFERS, 2);
+        conf.setInt(ObjectFileSystem.LIST_MAX_KEYS, 2);
+        fs = new ObjectFileSystem() {
+            @Override
+            AmazonS3 createClient(String endpoint, Configuration c) {
+                return node;
+            }
+        };
+        fs.initialize(URI.create("ofs://bucket"), conf);
+    }
+
+    @After
+    public void tearDown() throws IOException {
+        fs.close();
+    }
+
+    private static byte[] randomBytes(int size) {
+        byte[] data = new byte[size];
+        new Random(size).nextBytes(data);
+        return data;
+    }
+
+    private void write(Path path, byte[] data) throws IOException {
+        try (FSDataOutputStream out = fs.create(path, false)) {
+            out.write(data);
+        }
+    }
+
+    private byte[] read(Path path) throws IOException {
+        FileStatus status = fs.getFileStatus(path);
+        byte[] data = new byte[(int) status.getLen()];
+        try (FSDataInputStream in = fs.open(path)) {
+            in.readFully(0, data);
+        }
+        return data;
+    }
+
+    @Test
+    public void testPathToKey() {
+        assertEquals("", fs.pathToKey(new Path("ofs://bucket/")));
+        assertEquals("a/b", fs.pathToKey(new Path("ofs://bucket/a/b")));
+        assertEquals("a/b", fs.pathToKey(new Path("/a/b/")));
+        assertEquals("user/" + System.getProperty("user.name") + "/c", fs.pathToKey(new Path("c")));
+    }
+
+    @Test
+    public void testSmallFileIsPut() throws IOException {
+        Path path = new Path("/dir/small");
+        byte[] data = randomBytes(4096);
+        write(path, data);
+        assertEquals(1, node.puts.get());
+        assertEquals(0, node.uploadedParts.get());
+        assertArrayEquals(data, node.object("dir/small"));
+
+        FileStatus status = fs.getFileStatus(path);
+        assertTrue(status.isFile());
+        assertEquals(data.length, status.getLen());
+        assertTrue(fs.getFileStatus(new Path("/dir")).isDirectory());
+        assertArrayEquals(data, read(path));
+
+        try {
+            fs.create(path, false);
+            fail("created over an existing file");
+        } catch (FileAlreadyExistsException expected) {
+            // not overwritten
+        }
+        write(new Path("/empty"), new byte[0]);
+        assertEquals(0, fs.getFileStatus(new Path("/empty")).getLen());
+    }
+
+    @Test
+    public void testLargeFileIsUploadedByParts() throws IOException {
+        Path path = new Path("/large");
+        byte[] data = randomBytes(2 * PART_SIZE + PART_SIZE / 2);
+        try (FSDataOutputStream out = fs.create(path, false)) {
+            // unaligned writes across the parts
+            for (int off = 0; off < data.length; off += 100000) {
+                out.write(data, off, Math.min(100000, data.length - off));
+            }
+            assertFalse(node.exists("large"));
+        }
+        assertEquals(0, node.puts.get());
+        assertEquals(3, node.uploadedParts.get());
+        assertEquals(1, node.completedUploads.get());
+        assertEquals(0, node.openUploads());
+        assertArrayEquals(data, node.object("large"));
+        assertArrayEquals(data, read(path));
+
+        // a file of exactly one part
+        byte[] part = randomBytes(PART_SIZE);
+        write(new Path("/part"), part);
+        assertArrayEquals(part, node.object("part"));
+    }
+
+    @Test
+    public void testFailedPartAbortsTheUpload() throws IOException {
+        node.failUploadPart = true;
+        FSDataOutputStream out = fs.create(new Path("/failed"), false);
+        out.write(randomBytes(PART_SIZE + 1));
+        try {
+            out.close();
+            fail("closed with a failed part");
+        } catch (IOException expected) {
+            // the upload is aborted
+        }
+        assertEquals(1, node.abortedUploads.get());
+        assertEquals(0, node.openUploads());
+        assertFalse(node.exists("failed"));
+    }
+
+    @Test
+    public void testSeekAndRead() throws IOException {
+        Path path = new Path("/seek");
+        byte[] data = randomBytes(64 << 10);
+        write(path, data);
+        try (FSDataInputStream in = fs.open(path)) {
+            byte[] buf = new byte[100];
+            in.seek(1000);
+            in.readFully(buf);
+            assertArrayEquals(Arrays.copyOfRange(data, 1000, 1100), buf);
+            // backwards, then forward within the readahead
+            in.seek(10);
+            in.readFully(buf);
+            assertArrayEquals(Arrays.copyOfRange(data, 10, 110), buf);
+            in.seek(5000);
+            assertEquals(data[5000] & 0xff, in.read());
+            in.seek(data.length);
+            assertEquals(-1, in.read());
+        }
+    }
+
+    @Test
+    public void testListStatusPages() throws IOException {
+        assertTrue(fs.mkdirs(new Path("/list/sub1")));
+        assertTrue(fs.mkdirs(new Path("/list/sub2")));
+        for (int i = 0; i < 5; i++) {
+            write(new Path("/list/file" + i), randomBytes(10 + i));
+            write(new Path("/list/sub1/deep" + i), randomBytes(1));
+        }
+        node.lists.set(0);
+        FileStatus[] statuses = fs.listStatus(new Path("/list"));
+        List<String> names = new ArrayList<>();
+        for (FileStatus status : statuses) {
+            names.add(status.getPath().getName() + (status.isDirectory() ? "/" : ""));
+        }
+        Collections.sort(names);
+        assertEquals(Arrays.asList("file0", "file1", "file2", "file3", "file4", "sub1/", "sub2/"), names);
+        // the key of the directory itself and its 7 entries, at 2 a page
+        assertEquals(4, node.lists.get());
+
+        assertEquals(1, fs.listStatus(new Path("/list/file3")).length);
+        try {
+            fs.listStatus(new Path("/nothing"));
+            fail("listed a missing directory");
+        } catch (FileNotFoundException expected) {
+            // not there
+        }
+    }
+
+    @Test
+    public void testListFilesRecursive() throws IOException {
+        List<String> expected = new ArrayList<>();
+        for (int i = 0; i < 3; i++) {
+            for (int j = 0; j < 3; j++) {
+                String key = "tree/d" + i + "/f" + j;
+                write(new Path("/" + key), randomBytes(j + 1));
+                expected.add(key);
+            }
+        }
+        fs.mkdirs(new Path("/tree/emptyDir"));
+        List<String> keys = new ArrayList<>();
+        RemoteIterator<LocatedFileStatus> it = fs.listFiles(new Path("/tree"), true);
+        while (it.hasNext()) {
+            LocatedFileStatus status = it.next();
+            assertTrue(status.isFile());
+            keys.add(fs.pathToKey(status.getPath()));
+        }
+        Collections.sort(keys);
+        assertEquals(expected, keys);
+
+        keys.clear();
+        it = fs.listFiles(new Path("/tree/d1"), false);
+        while (it.hasNext()) {
+            keys.add(fs.pathToKey(it.next().getPath()));
+        }
+        assertEquals(Arrays.asList("tree/d1/f0", "tree/d1/f1", "tree/d1/f2"), keys);
+    }
+
+    @Test
+    public void testDelete() throws IOException {
+        write(new Path("/del/a/file"), randomBytes(1));
+        write(new Path("/del/b"), randomBytes(1));
+        assertFalse(fs.delete(new Path("/del/none"), false));
+        try {
+            fs.delete(new Path("/del"), false);
+            fail("deleted a directory not empty");
+        } catch (PathIsNotEmptyDirectoryException expected) {
+            // kept
+        }
+        assertTrue(fs.delete(new Path("/del/b"), false));
+        assertFalse(node.exists("del/b"));
+        assertTrue(fs.delete(new Path("/del"), true));
+        assertFalse(node.exists("del/a/file"));
+        assertFalse(node.exists("del/a/"));
+        assertFalse(node.exists("del/"));
+        assertNull(node.object("del/"));
+    }
+
+    @Test
+    public void testRename() throws IOException {
+        byte[] data = randomBytes(100);
+        write(new Path("/src/file"), data);
+        write(new Path("/src/sub/file"), data);
+        fs.mkdirs(new Path("/dst"));
+
+        assertTrue(fs.rename(new Path("/src/file"), new Path("/moved")));
+        assertFalse(node.exists("src/file"));
+        assertArrayEquals(data, node.object("moved"));
+
+        // into an existing directory
+        assertTrue(fs.rename(new Path("/src"), new Path("/dst")));
+        assertFalse(node.exists("src/"));
+        assertArrayEquals(data, node.object("dst/src/sub/file"));
+        assertTrue(fs.getFileStatus(new Path("/dst/src/sub")).isDirectory());
+
+        // into itself, or from nothing
+        assertFalse(fs.rename(new Path("/dst"), new Path("/dst/src/sub/x")));
+        assertFalse(fs.rename(new Path("/none"), new Path("/x")));
+    }
+}
@@ -51,6 +51,7 @@ const (
 	defaultDelayDelMinute            = 1440           // default retention min(1 day) of old eks after migration
 	MaxSizePutOnce                   = int64(1) << 23 // 8MB
 	DirTrashSkip                     = ".Trash"
+	maxLcRecentErrors                = 20
 
 	defaultAllocRetryInterval       = 100
 	defaultWriteRetryInterval       = 100
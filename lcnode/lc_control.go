@@ -0,0 +1,162 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lcnode
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/log"
+	"golang.org/x/time/rate"
+)
+
+// lcErrorList keeps the most recent scan errors of a task, oldest first.
+type lcErrorList struct {
+	sync.Mutex
+	errs []string
+}
+
+func (el *lcErrorList) add(msg string) {
+	el.Lock()
+	defer el.Unlock()
+	if len(el.errs) >= maxLcRecentErrors {
+		el.errs = append(el.errs[:0], el.errs[1:]...)
+	}
+	el.errs = append(el.errs, fmt.Sprintf("%v %v", time.Now().Format(time.RFC3339), msg))
+}
+
+func (el *lcErrorList) list() []string {
+	el.Lock()
+	defer el.Unlock()
+	if len(el.errs) == 0 {
+		return nil
+	}
+	return append([]string(nil), el.errs...)
+}
+
+func (s *LcScanner) recordError(path string, err error) {
+	s.recentErrors.add(fmt.Sprintf("path(%v) err(%v)", path, err))
+}
+
+func (s *LcScanner) pause() {
+	atomic.StoreInt32(&s.paused, 1)
+}
+
+func (s *LcScanner) resume() {
+	atomic.StoreInt32(&s.paused, 0)
+}
+
+func (s *LcScanner) isPaused() bool {
+	return atomic.LoadInt32(&s.paused) == 1
+}
+
+// waitResume blocks while the scanner is paused, it returns false if the scanner is stopped meanwhile.
+func (s *LcScanner) waitResume() bool {
+	for s.isPaused() {
+		select {
+		case <-s.stopC:
+			return false
+		case <-time.After(time.Second):
+		}
+	}
+	return true
+}
+
+func (s *LcScanner) scanLimit() float64 {
+	limit := s.limiter.Limit()
+	if limit == rate.Inf {
+		return 0
+	}
+	return float64(limit)
+}
+
+func (s *LcScanner) progress() proto.LcNodeRuleTaskProgress {
+	return proto.LcNodeRuleTaskProgress{
+		Paused:       s.isPaused(),
+		ScanLimit:    s.scanLimit(),
+		DirQueueLen:  s.dirChan.Len(),
+		FileQueueLen: len(s.fileChan),
+		RecentErrors: s.recentErrors.list(),
+	}
+}
+
+func (l *LcNode) getLcScanner(w http.ResponseWriter, r *http.Request) (scanner *LcScanner, ok bool) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("ParseForm failed: %v", err), http.StatusBadRequest)
+		return
+	}
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "invalid task id", http.StatusBadRequest)
+		return
+	}
+	l.scannerMutex.RLock()
+	scanner, ok = l.lcScanners[id]
+	l.scannerMutex.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("task id(%v) not exist", id), http.StatusNotFound)
+	}
+	return
+}
+
+func (l *LcNode) httpServicePauseScanner(w http.ResponseWriter, r *http.Request) {
+	scanner, ok := l.getLcScanner(w, r)
+	if !ok {
+		return
+	}
+	scanner.pause()
+	log.LogInfof("httpServicePauseScanner: %v paused", scanner.ID)
+	auditlog.LogMasterOp("LcScanPause", fmt.Sprintf("ID(%v), from(%v)", scanner.ID, r.RemoteAddr), nil)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (l *LcNode) httpServiceResumeScanner(w http.ResponseWriter, r *http.Request) {
+	scanner, ok := l.getLcScanner(w, r)
+	if !ok {
+		return
+	}
+	scanner.resume()
+	log.LogInfof("httpServiceResumeScanner: %v resumed", scanner.ID)
+	auditlog.LogMasterOp("LcScanResume", fmt.Sprintf("ID(%v), from(%v)", scanner.ID, r.RemoteAddr), nil)
+	w.WriteHeader(http.StatusOK)
+}
+
+// httpServiceSetScanLimit changes the files per second of a running task, 0 means unlimited.
+func (l *LcNode) httpServiceSetScanLimit(w http.ResponseWriter, r *http.Request) {
+	scanner, ok := l.getLcScanner(w, r)
+	if !ok {
+		return
+	}
+	limit, err := strconv.ParseFloat(r.FormValue("limit"), 64)
+	if err != nil || limit < 0 || math.IsNaN(limit) || math.IsInf(limit, 0) {
+		http.Error(w, fmt.Sprintf("invalid limit(%v)", r.FormValue("limit")), http.StatusBadRequest)
+		return
+	}
+	if limit == 0 {
+		scanner.limiter.SetLimit(rate.Inf)
+	} else {
+		scanner.limiter.SetLimit(rate.Limit(limit))
+	}
+	log.LogInfof("httpServiceSetScanLimit: %v limit(%v)", scanner.ID, limit)
+	auditlog.LogMasterOp("LcScanSetLimit", fmt.Sprintf("ID(%v), limit(%v), from(%v)", scanner.ID, limit, r.RemoteAddr), nil)
+	w.WriteHeader(http.StatusOK)
+}
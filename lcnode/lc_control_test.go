@@ -0,0 +1,84 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lcnode
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/util/unboundedchan"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestLcErrorList(t *testing.T) {
+	var el lcErrorList
+	require.Nil(t, el.list())
+	for i := 0; i < maxLcRecentErrors+5; i++ {
+		el.add(fmt.Sprintf("err%d", i))
+	}
+	errs := el.list()
+	require.Len(t, errs, maxLcRecentErrors)
+	require.Contains(t, errs[0], "err5")
+	require.Contains(t, errs[maxLcRecentErrors-1], fmt.Sprintf("err%d", maxLcRecentErrors+4))
+}
+
+func TestLcScannerControl(t *testing.T) {
+	scanner := &LcScanner{
+		ID:       "vol:rule",
+		dirChan:  unboundedchan.NewUnboundedChan(10),
+		fileChan: make(chan interface{}, 10),
+		limiter:  rate.NewLimiter(rate.Inf, defaultLcScanLimitBurst),
+		stopC:    make(chan bool),
+	}
+	l := &LcNode{lcScanners: map[string]*LcScanner{scanner.ID: scanner}}
+
+	do := func(h http.HandlerFunc, url string) int {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w.Code
+	}
+	require.Equal(t, http.StatusBadRequest, do(l.httpServicePauseScanner, "/pauseScanner"))
+	require.Equal(t, http.StatusNotFound, do(l.httpServicePauseScanner, "/pauseScanner?id=none"))
+
+	require.Equal(t, http.StatusOK, do(l.httpServicePauseScanner, "/pauseScanner?id=vol:rule"))
+	require.True(t, scanner.progress().Paused)
+	resumed := make(chan bool)
+	go func() {
+		resumed <- scanner.waitResume()
+	}()
+	select {
+	case <-resumed:
+		t.Fatal("scanner should wait while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.Equal(t, http.StatusOK, do(l.httpServiceResumeScanner, "/resumeScanner?id=vol:rule"))
+	require.True(t, <-resumed)
+	require.False(t, scanner.progress().Paused)
+
+	require.Equal(t, float64(0), scanner.progress().ScanLimit)
+	require.Equal(t, http.StatusBadRequest, do(l.httpServiceSetScanLimit, "/setScanLimit?id=vol:rule&limit=-1"))
+	require.Equal(t, http.StatusOK, do(l.httpServiceSetScanLimit, "/setScanLimit?id=vol:rule&limit=200"))
+	require.Equal(t, float64(200), scanner.progress().ScanLimit)
+	require.Equal(t, http.StatusOK, do(l.httpServiceSetScanLimit, "/setScanLimit?id=vol:rule&limit=0"))
+	require.Equal(t, float64(0), scanner.progress().ScanLimit)
+
+	scanner.pause()
+	close(scanner.stopC)
+	require.False(t, scanner.waitResume())
+}
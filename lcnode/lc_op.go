@@ -44,6 +44,7 @@ func (l *LcNode) opMasterHeartbeat(conn net.Conn, p *proto.Packet, remoteAddr st
 
 	go func() {
 		start := time.Now()
+		resp.Clock.RecvTime = start.UnixNano()
 		decode := json.NewDecoder(bytes.NewBuffer(data))
 		decode.UseNumber()
 		if err = decode.Decode(adminTask); err != nil {
@@ -76,6 +77,7 @@ func (l *LcNode) opMasterHeartbeat(conn net.Conn, p *proto.Packet, remoteAddr st
 					ErrorMToBlobstoreNum:     atomic.LoadInt64(&scanner.currentStat.ErrorMToBlobstoreNum),
 					ErrorReadDirNum:          atomic.LoadInt64(&scanner.currentStat.ErrorReadDirNum),
 				},
+				LcNodeRuleTaskProgress: scanner.progress(),
 			}
 			resp.LcScanningTasks[scanner.ID] = result
 		}
@@ -101,6 +103,7 @@ func (l *LcNode) opMasterHeartbeat(conn net.Conn, p *proto.Packet, remoteAddr st
 		resp.Status = proto.TaskSucceeds
 
 	end:
+		resp.Clock.ReplyTime = time.Now().UnixNano()
 		adminTask.Response = resp
 		l.respondToMaster(adminTask)
 		msg := fmt.Sprintf("from(%v), adminTask(%+v), resp(%+v), %v", remoteAddr, adminTask, resp, time.Since(start).String())
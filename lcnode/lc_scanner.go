@@ -54,6 +54,8 @@ type LcScanner struct {
 	fileRPool     *routinepool.RoutinePool
 	currentStat   *proto.LcNodeRuleTaskStatistics
 	limiter       *rate.Limiter
+	paused        int32
+	recentErrors  lcErrorList
 	now           time.Time
 	receiveStop   bool
 	receiveStopC  chan bool
@@ -391,6 +393,9 @@ func (s *LcScanner) handleFile(dentry *proto.ScanDentry) {
 	log.LogInfof("handleFile: %v, fileChan: %v", dentry, len(s.fileChan))
 	atomic.AddInt64(&s.currentStat.TotalFileScannedNum, 1)
 
+	if !s.waitResume() {
+		return
+	}
 	s.limiter.Wait(context.Background())
 	start := time.Now()
 
@@ -429,6 +434,7 @@ func (s *LcScanner) handleFile(dentry *proto.ScanDentry) {
 		_, err = s.mw.DeleteWithCond_ll(dentry.ParentId, dentry.Inode, dentry.Name, os.FileMode(dentry.Type).IsDir(), dentry.Path)
 		if err != nil {
 			atomic.AddInt64(&s.currentStat.ErrorDeleteNum, 1)
+			s.recordError(dentry.Path, err)
 			log.LogWarnf("delete DeleteWithCond_ll err: %v, dentry: %+v", err, dentry)
 			return
 		}
@@ -454,6 +460,7 @@ func (s *LcScanner) handleFile(dentry *proto.ScanDentry) {
 				return
 			}
 			atomic.AddInt64(&s.currentStat.ErrorMToHddNum, 1)
+			s.recordError(dentry.Path, err)
 			log.LogErrorf("migrate err: %v, dentry: %+v", err, dentry)
 			return
 		}
@@ -465,6 +472,7 @@ func (s *LcScanner) handleFile(dentry *proto.ScanDentry) {
 				return
 			}
 			atomic.AddInt64(&s.currentStat.ErrorMToHddNum, 1)
+			s.recordError(dentry.Path, err)
 			err = fmt.Errorf("UpdateExtentKeyAfterMigration err(%v)", err)
 			log.LogErrorf("%v, dentry: %+v", err, dentry)
 			return
@@ -490,6 +498,7 @@ func (s *LcScanner) handleFile(dentry *proto.ScanDentry) {
 				return
 			}
 			atomic.AddInt64(&s.currentStat.ErrorMToBlobstoreNum, 1)
+			s.recordError(dentry.Path, err)
 			log.LogErrorf("migrate blobstore err: %v, dentry: %+v", err, dentry)
 			return
 		}
@@ -501,6 +510,7 @@ func (s *LcScanner) handleFile(dentry *proto.ScanDentry) {
 				return
 			}
 			atomic.AddInt64(&s.currentStat.ErrorMToBlobstoreNum, 1)
+			s.recordError(dentry.Path, err)
 			err = fmt.Errorf("UpdateExtentKeyAfterMigration err(%v)", err)
 			log.LogErrorf("%v, dentry: %+v", err, dentry)
 			return
@@ -615,10 +625,14 @@ func (s *LcScanner) handleDirLimitDepthFirst(dentry *proto.ScanDentry) {
 			return
 		default:
 		}
+		if !s.waitResume() {
+			return
+		}
 
 		children, err := s.mw.ReadDirLimit_ll(dentry.Inode, marker, uint64(defaultReadDirLimit))
 		if err != nil && err != syscall.ENOENT {
 			atomic.AddInt64(&s.currentStat.ErrorReadDirNum, 1)
+			s.recordError(dentry.Path, err)
 			log.LogErrorf("handleDirLimitDepthFirst ReadDirLimit_ll err(%v), dentry(%v), marker(%v)", err, dentry, marker)
 			return
 		}
@@ -693,10 +707,14 @@ func (s *LcScanner) handleDirLimitBreadthFirst(dentry *proto.ScanDentry) {
 			return
 		default:
 		}
+		if !s.waitResume() {
+			return
+		}
 
 		children, err := s.mw.ReadDirLimit_ll(dentry.Inode, marker, uint64(defaultReadDirLimit))
 		if err != nil && err != syscall.ENOENT {
 			atomic.AddInt64(&s.currentStat.ErrorReadDirNum, 1)
+			s.recordError(dentry.Path, err)
 			log.LogErrorf("handleDirLimitBreadthFirst ReadDirLimit_ll err(%v), dentry(%v), marker(%v)", err, dentry, marker)
 			return
 		}
@@ -780,6 +798,7 @@ func (s *LcScanner) checkScanning() {
 			response.ErrorMToHddNum = s.currentStat.ErrorMToHddNum
 			response.ErrorMToBlobstoreNum = s.currentStat.ErrorMToBlobstoreNum
 			response.ErrorReadDirNum = s.currentStat.ErrorReadDirNum
+			response.RecentErrors = s.recentErrors.list()
 			log.LogInfof("receive receiveStopC response(%+v)", response)
 
 			s.lcnode.scannerMutex.Lock()
@@ -815,6 +834,8 @@ func (s *LcScanner) checkScanning() {
 				response.ErrorMToHddNum = s.currentStat.ErrorMToHddNum
 				response.ErrorMToBlobstoreNum = s.currentStat.ErrorMToBlobstoreNum
 				response.ErrorReadDirNum = s.currentStat.ErrorReadDirNum
+				response.RecentErrors = s.recentErrors.list()
+				response.RecentErrors = s.recentErrors.list()
 				log.LogInfof("checkScanning completed response(%+v)", response)
 
 				s.lcnode.scannerMutex.Lock()
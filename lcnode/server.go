@@ -370,6 +370,15 @@ func (l *LcNode) httpServiceStart() {
 	router.NewRoute().Methods(http.MethodGet).
 		Path("/stopScanner").
 		HandlerFunc(l.httpServiceStopScanner)
+	router.NewRoute().Methods(http.MethodGet).
+		Path("/pauseScanner").
+		HandlerFunc(l.httpServicePauseScanner)
+	router.NewRoute().Methods(http.MethodGet).
+		Path("/resumeScanner").
+		HandlerFunc(l.httpServiceResumeScanner)
+	router.NewRoute().Methods(http.MethodGet).
+		Path("/setScanLimit").
+		HandlerFunc(l.httpServiceSetScanLimit)
 	router.NewRoute().Methods(http.MethodGet).
 		Path("/getFile").
 		HandlerFunc(l.httpServiceGetFile)
@@ -0,0 +1,325 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// rbacOpenAPIs are called by the nodes and the clients to join and serve the
+// cluster, they need no role.
+var rbacOpenAPIs = map[string]struct{}{
+	"/metrics":                              {},
+	proto.AdminGetIP:                        {},
+	proto.AdminGetCluster:                   {},
+	proto.AdminGetVol:                       {},
+	proto.AdminGetVolVer:                    {},
+	proto.AdminGetAllVersionInfo:            {},
+	proto.AdminGetDataPartition:             {},
+	proto.AdminGetClusterDataNodes:          {},
+	proto.AdminGetMonitorPushAddr:           {},
+	proto.AdminGetUpgradeCompatibleSettings: {},
+	proto.AdminListVols:                     {},
+	proto.AdminMountProfileGet:              {},
+	proto.AdminSLOReport:                    {},
+	proto.QuotaList:                         {},
+	proto.QosUpload:                         {},
+	proto.AddDataNode:                       {},
+	proto.AddMetaNode:                       {},
+	proto.AddLcNode:                         {},
+	proto.FlashNodeAdd:                      {},
+	proto.GetDataNode:                       {},
+	proto.GetMetaNode:                       {},
+	proto.GetDataNodeTaskResponse:           {},
+	proto.GetMetaNodeTaskResponse:           {},
+	proto.GetLcNodeTaskResponse:             {},
+	proto.GetFlashNodeTaskResponse:          {},
+	proto.S3NodeHeartbeat:                   {},
+	proto.ClientVol:                         {},
+	proto.ClientVolStat:                     {},
+	proto.ClientMetaPartitions:              {},
+	proto.ClientMetaPartition:               {},
+	proto.ClientDataPartitions:              {},
+	proto.ClientDiskDataPartitions:          {},
+	proto.ClientZoneCost:                    {},
+	proto.ClientForkPartitions:              {},
+	proto.ClientFlashGroups:                 {},
+	proto.ClientFlashCacheToken:             {},
+}
+
+// rbacAPIRoles are the roles required by the admin apis below the cluster
+// admin, the apis not listed need a cluster admin.
+var rbacAPIRoles = map[string]proto.AdminRole{
+	// the queries
+	proto.AdminGetClusterMetaNodes:                         proto.AdminRoleViewer,
+	proto.AdminGetClusterUuid:                              proto.AdminRoleViewer,
+	proto.AdminGetClusterValue:                             proto.AdminRoleViewer,
+	proto.AdminGetConfig:                                   proto.AdminRoleViewer,
+	proto.AdminGetApiQpsLimit:                              proto.AdminRoleViewer,
+	proto.AdminGetMasterApiList:                            proto.AdminRoleViewer,
+	proto.AdminGetOpLog:                                    proto.AdminRoleViewer,
+	proto.AdminGetNodeInfo:                                 proto.AdminRoleViewer,
+	proto.AdminGetIsDomainOn:                               proto.AdminRoleViewer,
+	proto.AdminGetNodeSetGrpInfo:                           proto.AdminRoleViewer,
+	proto.AdminGetAllNodeSetGrpInfo:                        proto.AdminRoleViewer,
+	proto.AdminGetInvalidNodes:                             proto.AdminRoleViewer,
+	proto.AdminGetDiscardDp:                                proto.AdminRoleViewer,
+	proto.AdminGetFileStats:                                proto.AdminRoleViewer,
+	proto.AdminGetVersionInfo:                              proto.AdminRoleViewer,
+	proto.AdminGetZoneCost:                                 proto.AdminRoleViewer,
+	proto.AdminClusterStat:                                 proto.AdminRoleViewer,
+	proto.AdminClusterEvents:                               proto.AdminRoleViewer,
+	proto.AdminClusterFeatures:                             proto.AdminRoleViewer,
+	proto.AdminClusterClockSkew:                            proto.AdminRoleViewer,
+	proto.AdminSummary:                                     proto.AdminRoleViewer,
+	proto.AdminMasterStandbyStatus:                         proto.AdminRoleViewer,
+	proto.AdminMaintenanceList:                             proto.AdminRoleViewer,
+	proto.AdminDataBalanceStatus:                           proto.AdminRoleViewer,
+	proto.AdminPlacementSimulate:                           proto.AdminRoleViewer,
+	proto.AdminCheckReplicaMeta:                            proto.AdminRoleViewer,
+	proto.AdminDiagnoseDataPartition:                       proto.AdminRoleViewer,
+	proto.AdminDiagnoseMetaPartition:                       proto.AdminRoleViewer,
+	proto.AdminMetaPartitionEmptyStatus:                    proto.AdminRoleViewer,
+	proto.AdminMetaPartitionGetCleanTask:                   proto.AdminRoleViewer,
+	proto.AdminQueryAutoDecommissionDisk:                   proto.AdminRoleViewer,
+	proto.AdminQueryDataNodeDecommissionInfoStat:           proto.AdminRoleViewer,
+	proto.AdminQueryDataPartitionDecommissionStatus:        proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionFailedDisk:                 proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionFirstHostDiskParallelLimit: proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionFirstHostParallelInfo:      proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionFirstHostParallelLimit:     proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionLimit:                      proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionProgress:                   proto.AdminRoleViewer,
+	proto.AdminQueryDecommissionToken:                      proto.AdminRoleViewer,
+	proto.AdminQueryDiskBrokenThreshold:                    proto.AdminRoleViewer,
+	proto.AdminQueryDiskDecommissionInfoStat:               proto.AdminRoleViewer,
+	proto.QueryAllDecommissionDisk:                         proto.AdminRoleViewer,
+	proto.QueryBackupDirectories:                           proto.AdminRoleViewer,
+	proto.QueryBadDiskRecoverProgress:                      proto.AdminRoleViewer,
+	proto.QueryBadDisks:                                    proto.AdminRoleViewer,
+	proto.QueryDataNodeDecoFailedDps:                       proto.AdminRoleViewer,
+	proto.QueryDataNodeDecoProgress:                        proto.AdminRoleViewer,
+	proto.QueryDecommissionDiskDecoFailedDps:               proto.AdminRoleViewer,
+	proto.QueryDecommissionSuccessDisk:                     proto.AdminRoleViewer,
+	proto.QueryDisableDisk:                                 proto.AdminRoleViewer,
+	proto.QueryDiskDecoProgress:                            proto.AdminRoleViewer,
+	proto.QueryDiskDetail:                                  proto.AdminRoleViewer,
+	proto.QueryDisks:                                       proto.AdminRoleViewer,
+	proto.GetDataNodeLoadProgress:                          proto.AdminRoleViewer,
+	proto.GetMetaNodeBalanceTask:                           proto.AdminRoleViewer,
+	proto.GetAllClients:                                    proto.AdminRoleViewer,
+	proto.GetAllNodeSets:                                   proto.AdminRoleViewer,
+	proto.GetAllZones:                                      proto.AdminRoleViewer,
+	proto.GetNodeSet:                                       proto.AdminRoleViewer,
+	proto.GetTopologyView:                                  proto.AdminRoleViewer,
+	proto.RaftStatus:                                       proto.AdminRoleViewer,
+	proto.FlashNodeGet:                                     proto.AdminRoleViewer,
+	proto.FlashNodeList:                                    proto.AdminRoleViewer,
+	proto.AdminFlashNodeStats:                              proto.AdminRoleViewer,
+	proto.AdminFlashGroupGet:                               proto.AdminRoleViewer,
+	proto.AdminFlashGroupList:                              proto.AdminRoleViewer,
+	proto.AdminFlashGroupAudit:                             proto.AdminRoleViewer,
+	proto.AdminFlashGroupCacheKeys:                         proto.AdminRoleViewer,
+	proto.AdminFlashGroupScaleEvent:                        proto.AdminRoleViewer,
+	proto.QosGetStatus:                                     proto.AdminRoleViewer,
+	proto.QosGetClientsLimitInfo:                           proto.AdminRoleViewer,
+	proto.QosGetZoneLimitInfo:                              proto.AdminRoleViewer,
+	proto.S3NodeList:                                       proto.AdminRoleViewer,
+	proto.S3QoSGet:                                         proto.AdminRoleViewer,
+	proto.GetBucketLifecycle:                               proto.AdminRoleViewer,
+	proto.AdminVolSLO:                                      proto.AdminRoleViewer,
+	proto.AdminVolReclaim:                                  proto.AdminRoleViewer,
+	proto.AdminVolTimeline:                                 proto.AdminRoleViewer,
+	proto.AdminVolFenceList:                                proto.AdminRoleViewer,
+	proto.AdminClientEvictList:                             proto.AdminRoleViewer,
+	proto.AdminMountProfileList:                            proto.AdminRoleViewer,
+	proto.AdminAuditCampaignGet:                            proto.AdminRoleViewer,
+	proto.AdminAuditCampaignList:                           proto.AdminRoleViewer,
+	proto.AdminAuditCampaignReport:                         proto.AdminRoleViewer,
+	proto.QuotaGet:                                         proto.AdminRoleViewer,
+	proto.QuotaListAll:                                     proto.AdminRoleViewer,
+	proto.UsersOfVol:                                       proto.AdminRoleViewer,
+
+	// the volumes and their users, quotas and buckets
+	proto.AdminCreateVol:                 proto.AdminRoleVolumeAdmin,
+	proto.AdminDeleteVol:                 proto.AdminRoleVolumeAdmin,
+	proto.AdminUpdateVol:                 proto.AdminRoleVolumeAdmin,
+	proto.AdminVolExpand:                 proto.AdminRoleVolumeAdmin,
+	proto.AdminVolShrink:                 proto.AdminRoleVolumeAdmin,
+	proto.AdminVolForbidden:              proto.AdminRoleVolumeAdmin,
+	proto.AdminVolEnableAuditLog:         proto.AdminRoleVolumeAdmin,
+	proto.AdminVolAddAllowedStorageClass: proto.AdminRoleVolumeAdmin,
+	proto.AdminVolSetDpRepairBlockSize:   proto.AdminRoleVolumeAdmin,
+	proto.AdminVolSetInterop:             proto.AdminRoleVolumeAdmin,
+	proto.AdminVolSetPlacementExclusion:  proto.AdminRoleVolumeAdmin,
+	proto.AdminVolSetPlacementPolicy:     proto.AdminRoleVolumeAdmin,
+	proto.AdminVolSetSLO:                 proto.AdminRoleVolumeAdmin,
+	proto.AdminVolPublish:                proto.AdminRoleVolumeAdmin,
+	proto.AdminVolReclaimBoost:           proto.AdminRoleVolumeAdmin,
+	proto.AdminVolForkAdd:                proto.AdminRoleVolumeAdmin,
+	proto.AdminVolForkRemove:             proto.AdminRoleVolumeAdmin,
+	proto.AdminVolFenceAcquire:           proto.AdminRoleVolumeAdmin,
+	proto.AdminCreateDataPartition:       proto.AdminRoleVolumeAdmin,
+	proto.AdminCreateMetaPartition:       proto.AdminRoleVolumeAdmin,
+	proto.AdminCreateVersion:             proto.AdminRoleVolumeAdmin,
+	proto.AdminDelVersion:                proto.AdminRoleVolumeAdmin,
+	proto.AdminSetVerStrategy:            proto.AdminRoleVolumeAdmin,
+	proto.AdminACL:                       proto.AdminRoleVolumeAdmin,
+	proto.AdminUid:                       proto.AdminRoleVolumeAdmin,
+	proto.AdminClientEvict:               proto.AdminRoleVolumeAdmin,
+	proto.AdminClientEvictRemove:         proto.AdminRoleVolumeAdmin,
+	proto.AdminMountProfileSet:           proto.AdminRoleVolumeAdmin,
+	proto.AdminMountProfileDelete:        proto.AdminRoleVolumeAdmin,
+	proto.QuotaCreate:                    proto.AdminRoleVolumeAdmin,
+	proto.QuotaUpdate:                    proto.AdminRoleVolumeAdmin,
+	proto.QuotaDelete:                    proto.AdminRoleVolumeAdmin,
+	proto.SetBucketLifecycle:             proto.AdminRoleVolumeAdmin,
+	proto.DeleteBucketLifecycle:          proto.AdminRoleVolumeAdmin,
+	proto.S3QoSSet:                       proto.AdminRoleVolumeAdmin,
+	proto.S3QoSDelete:                    proto.AdminRoleVolumeAdmin,
+	proto.UserUpdatePolicy:               proto.AdminRoleVolumeAdmin,
+	proto.UserRemovePolicy:               proto.AdminRoleVolumeAdmin,
+	proto.UserDeleteVolPolicy:            proto.AdminRoleVolumeAdmin,
+	proto.UserTransferVol:                proto.AdminRoleVolumeAdmin,
+}
+
+// requiredAdminRole returns the role an api requires, none for the open ones.
+// The users and their keys, /user/akInfo included, are left to the cluster
+// admins, a caller reading a secret key could sign as its user.
+func requiredAdminRole(r *http.Request) proto.AdminRole {
+	if _, ok := rbacOpenAPIs[r.URL.Path]; ok {
+		return proto.AdminRoleNone
+	}
+	// the clients check their ips on mount
+	if r.URL.Path == proto.AdminACL {
+		switch r.FormValue(OperateKey) {
+		case strconv.Itoa(util.AclCheckIP):
+			return proto.AdminRoleNone
+		case strconv.Itoa(util.AclListIP):
+			return proto.AdminRoleViewer
+		}
+	}
+	if role, ok := rbacAPIRoles[r.URL.Path]; ok {
+		return role
+	}
+	return proto.AdminRoleClusterAdmin
+}
+
+// parseTrustedHosts parses the ips and the cidrs split by commas.
+func parseTrustedHosts(hosts string) (nets []*net.IPNet, err error) {
+	for _, host := range strings.Split(hosts, commaSplit) {
+		if host = strings.TrimSpace(host); host == "" {
+			continue
+		}
+		if !strings.Contains(host, "/") {
+			if ip := net.ParseIP(host); ip == nil {
+				return nil, fmt.Errorf("invalid trusted host %v", host)
+			} else if ip.To4() != nil {
+				host += "/32"
+			} else {
+				host += "/128"
+			}
+		}
+		var ipNet *net.IPNet
+		if _, ipNet, err = net.ParseCIDR(host); err != nil {
+			return nil, fmt.Errorf("invalid trusted host %v: %v", host, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return
+}
+
+func (m *Server) isMasterPeer(host string) bool {
+	for _, peer := range m.config.peers {
+		if peer.Address == host {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Server) isTrustedHost(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range m.rbacTrustedHosts {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// adminRoleOf returns the role of the caller and who it is for the logs. The
+// signed requests have the roles of their users, the unsigned ones from the
+// masters and the trusted hosts are of the cluster admins. The followers proxy
+// the requests to the leader, so the leader takes the last forwarded hop of a
+// request from a master as where it comes from.
+func (m *Server) adminRoleOf(r *http.Request) (role proto.AdminRole, who string) {
+	if userID := sigV4User(r); userID != "" {
+		return sigV4Role(r), fmt.Sprintf("user[%v]", userID)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if m.isMasterPeer(host) {
+		forwarded := r.Header.Get("X-Forwarded-For")
+		if forwarded == "" {
+			return proto.AdminRoleClusterAdmin, fmt.Sprintf("master[%v]", host)
+		}
+		hops := strings.Split(forwarded, ",")
+		host = strings.TrimSpace(hops[len(hops)-1])
+	}
+	if m.isTrustedHost(host) {
+		return proto.AdminRoleClusterAdmin, fmt.Sprintf("trusted host[%v]", host)
+	}
+	return proto.AdminRoleNone, fmt.Sprintf("unsigned request from[%v]", host)
+}
+
+// registerRBACMiddleware checks the role of the caller against the role the
+// api requires, after the signatures are checked.
+func (m *Server) registerRBACMiddleware(router *mux.Router) {
+	rbacInterceptor := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				required := requiredAdminRole(r)
+				if required == proto.AdminRoleNone {
+					next.ServeHTTP(w, r)
+					return
+				}
+				role, who := m.adminRoleOf(r)
+				if !role.Covers(required) {
+					err := fmt.Errorf("%v of role %v can't call %v which requires %v", who, role, r.URL.Path, required)
+					log.LogWarnf("action[rbacInterceptor] remote[%v] err[%v]", r.RemoteAddr, err)
+					sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeNoPermission, Msg: err.Error()})
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+	}
+	router.Use(rbacInterceptor)
+}
@@ -48,6 +48,7 @@ type AdminTaskManager struct {
 	sync.RWMutex
 	exitCh   chan struct{}
 	connPool *util.ConnectPool
+	health   *nodeHealth // of the node the tasks are sent to, nil if not tracked
 }
 
 func newAdminTaskManager(targetAddr, clusterID string) (sender *AdminTaskManager) {
@@ -151,15 +152,18 @@ func (sender *AdminTaskManager) sendTasks(tasks []*proto.AdminTask) {
 			WarnBySpecialKey(fmt.Sprintf("%v_%v_sendTask", sender.clusterID, ModuleName), msg)
 			sender.putConn(conn, true)
 			sender.updateTaskInfo(task, false)
+			sender.health.taskDone(time.Now(), true)
 			break
 		}
 		if err = sender.sendAdminTask(task, conn); err != nil {
 			log.LogError(fmt.Sprintf("send task %v to %v err %v,errStack,%v", task.ID, sender.targetAddr, err, errors.Stack(err)))
 			sender.putConn(conn, true)
 			sender.updateTaskInfo(task, true)
+			sender.health.taskDone(time.Now(), true)
 			continue
 		}
 		sender.putConn(conn, false)
+		sender.health.taskDone(time.Now(), false)
 	}
 }
 
@@ -176,6 +180,7 @@ func (sender *AdminTaskManager) buildPacket(task *proto.AdminTask) (packet *prot
 	packet.Opcode = task.OpCode
 	packet.ReqID = proto.GenerateRequestID()
 	packet.PartitionID = task.PartitionID
+	task.SendTimeNano = time.Now().UnixNano()
 	body, err := json.Marshal(task)
 	if err != nil {
 		return nil, err
@@ -210,6 +215,7 @@ func (sender *AdminTaskManager) syncSendAdminTask(task *proto.AdminTask) (packet
 	log.LogInfof("action[syncSendAdminTask],task[%s], op %s, reqId %d", task.ToString(), packet.GetOpMsg(), packet.GetReqID())
 	conn, err := sender.getConn()
 	if err != nil {
+		sender.health.taskDone(time.Now(), true)
 		return nil, errors.Trace(err, "action[syncSendAdminTask get conn failed,task:%v]", task.ID)
 	}
 	defer func() {
@@ -218,6 +224,7 @@ func (sender *AdminTaskManager) syncSendAdminTask(task *proto.AdminTask) (packet
 		} else {
 			sender.putConn(conn, true)
 		}
+		sender.health.taskDone(time.Now(), err != nil)
 	}()
 
 	for i := 0; i < MaxRetryNum; i++ {
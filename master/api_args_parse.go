@@ -276,6 +276,15 @@ func parseVolVerStrategy(r *http.Request) (strategy proto.VolumeVerStrategy, isF
 		strategy.ForceUpdate, _ = strconv.ParseBool(value)
 	}
 
+	// -1 keeps the immutable window of the volume
+	strategy.ImmutableSec = -1
+	if value = r.FormValue(immutableSecKey); value != "" {
+		if strategy.ImmutableSec, err = strconv.Atoi(value); err != nil || strategy.ImmutableSec < 0 {
+			err = fmt.Errorf("invalid %v(%v)", immutableSecKey, value)
+			return
+		}
+	}
+
 	log.LogDebugf("parseVolVerStrategy. strategy %v", strategy)
 	return
 }
@@ -303,6 +312,162 @@ func parseGetVolParameter(r *http.Request) (p *getVolParameter, err error) {
 	return
 }
 
+func parseRequestToVolOwnerOp(r *http.Request) (name, authKey string, err error) {
+	if err = r.ParseForm(); err != nil {
+		return
+	}
+	if name, err = extractName(r); err != nil {
+		return
+	}
+	authKey, err = extractAuthKey(r)
+	return
+}
+
+// parseRequestToSetVolInterop starts from the current policy of the volume, so
+// the settings not given in the request are kept.
+func parseRequestToSetVolInterop(r *http.Request, cur proto.InteropPolicy) (policy proto.InteropPolicy, err error) {
+	policy = cur
+	policy.KeyMapping = extractStrWithDefault(r, interopKeyMappingKey, cur.KeyMapping)
+	policy.MetaSync = extractStrWithDefault(r, interopMetaSyncKey, cur.MetaSync)
+	policy.RenameVisibility = extractStrWithDefault(r, interopRenameVisibilityKey, cur.RenameVisibility)
+	err = policy.Validate()
+	return
+}
+
+// parseRequestToSetVolPlacementExclusion replaces the whole exclusion, the lists not given are cleared.
+func parseRequestToSetVolPlacementExclusion(r *http.Request) (exclusion proto.PlacementExclusion, err error) {
+	if value := r.FormValue(placementHostsKey); value != "" {
+		exclusion.Hosts = strings.Split(value, ",")
+	}
+	if value := r.FormValue(placementZonesKey); value != "" {
+		exclusion.Zones = strings.Split(value, ",")
+	}
+	if value := r.FormValue(placementNodeSetsKey); value != "" {
+		for _, idStr := range strings.Split(value, ",") {
+			var id uint64
+			if id, err = strconv.ParseUint(strings.TrimSpace(idStr), 10, 64); err != nil {
+				err = unmatchedKey(placementNodeSetsKey)
+				return
+			}
+			exclusion.NodeSets = append(exclusion.NodeSets, id)
+		}
+	}
+	exclusion.Normalize()
+	err = exclusion.Validate()
+	return
+}
+
+// parseRequestToSetVolPlacementPolicy replaces the whole policy like the exclusion.
+func parseRequestToSetVolPlacementPolicy(r *http.Request) (policy proto.PlacementPolicy, err error) {
+	if value := r.FormValue(placementRequiredKey); value != "" {
+		policy.RequiredZones = strings.Split(value, ",")
+	}
+	if policy.SpreadZones, err = extractUintWithDefault(r, placementSpreadKey, 0); err != nil {
+		return
+	}
+	policy.Normalize()
+	err = policy.Validate()
+	return
+}
+
+func parseRequestToSetMountProfile(r *http.Request) (name string, options map[string]string, err error) {
+	if name = r.FormValue(nameKey); name == "" {
+		err = keyNotFound(nameKey)
+		return
+	}
+	options, err = proto.ParseMountProfileOptions(r.FormValue(mountProfileOptionsKey))
+	return
+}
+
+// parseRequestToEvictClient takes the client by its ip or by the mount id it reports,
+// expire is the seconds the eviction lasts, 0 keeps it until removed.
+func parseRequestToEvictClient(r *http.Request) (e *proto.ClientEviction, expire uint64, err error) {
+	e = &proto.ClientEviction{
+		IP:      r.FormValue(IPKey),
+		Volume:  r.FormValue(nameKey),
+		MountID: r.FormValue(proto.MountIDKey),
+		Reason:  r.FormValue(evictReasonKey),
+	}
+	if e.IP == "" && e.MountID == "" {
+		err = keyNotFound(IPKey + " or " + proto.MountIDKey)
+		return
+	}
+	expire, err = extractUint64(r, evictExpireKey)
+	return
+}
+
+// parseRequestToSetVolSLO returns a nil target when objective is 0, which removes the SLO of op.
+func parseRequestToSetVolSLO(r *http.Request) (op string, target *proto.SLOTarget, err error) {
+	if op = r.FormValue(sloOpKey); op == "" {
+		err = keyNotFound(sloOpKey)
+		return
+	}
+	if !proto.IsValidSLOOp(op) {
+		err = fmt.Errorf("invalid op(%v), should be one of %v, %v, %v", op, proto.SLOOpRead, proto.SLOOpWrite, proto.SLOOpMeta)
+		return
+	}
+	value := r.FormValue(sloObjectiveKey)
+	if value == "" {
+		err = keyNotFound(sloObjectiveKey)
+		return
+	}
+	var objective float64
+	if objective, err = strconv.ParseFloat(value, 64); err != nil {
+		return
+	}
+	if objective == 0 {
+		return
+	}
+	var latencyMs int64
+	if latencyMs, err = extractInt64WithDefault(r, sloLatencyMsKey, 0); err != nil {
+		return
+	}
+	target = &proto.SLOTarget{LatencyMs: latencyMs, Objective: objective}
+	err = target.Validate()
+	return
+}
+
+func parseRequestToCreateAuditCampaign(r *http.Request) (budget time.Duration, concurrency int, err error) {
+	var mins, cnt int64
+	if mins, err = extractInt64WithDefault(r, auditBudgetKey, defaultAuditBudgetMins); err != nil {
+		return
+	}
+	if mins <= 0 {
+		err = fmt.Errorf("%v should be a positive number of minutes", auditBudgetKey)
+		return
+	}
+	if cnt, err = extractInt64WithDefault(r, auditConcurrencyKey, defaultAuditConcurrency); err != nil {
+		return
+	}
+	if cnt <= 0 || cnt > maxAuditConcurrency {
+		err = fmt.Errorf("%v should be in [1, %v]", auditConcurrencyKey, maxAuditConcurrency)
+		return
+	}
+	return time.Duration(mins) * time.Minute, int(cnt), nil
+}
+
+func parsePlacementSimulationRequest(r *http.Request) (req *proto.PlacementSimulationRequest, err error) {
+	var body []byte
+	if body, err = io.ReadAll(r.Body); err != nil {
+		return
+	}
+	req = &proto.PlacementSimulationRequest{}
+	if err = json.Unmarshal(body, req); err != nil {
+		return
+	}
+	err = req.Validate()
+	return
+}
+
+func parseSLOReports(r *http.Request) (reports []*proto.SLOReport, err error) {
+	var body []byte
+	if body, err = io.ReadAll(r.Body); err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &reports)
+	return
+}
+
 func parseRequestToDeleteVol(r *http.Request) (name, authKey string, status, force bool, err error) {
 	if err = r.ParseForm(); err != nil {
 		return
@@ -341,6 +506,20 @@ func extractUintWithDefault(r *http.Request, key string, def int) (val int, err
 	return val, nil
 }
 
+// extractMetaWitnessNum parses the witnesses of the new meta partitions of a
+// volume with replicaNum replicas.
+func extractMetaWitnessNum(r *http.Request, def, replicaNum uint8) (num uint8, err error) {
+	var val int
+	if val, err = extractUintWithDefault(r, proto.VolMetaWitnessNum, int(def)); err != nil {
+		return
+	}
+	if max := proto.MaxMetaWitnessNum(replicaNum); val > max {
+		err = fmt.Errorf("%v must be between 0 and %v with %v replicas, now %v", proto.VolMetaWitnessNum, max, replicaNum, val)
+		return
+	}
+	return uint8(val), nil
+}
+
 func extractUint32WithDefault(r *http.Request, key string, def uint32) (val uint32, err error) {
 	var str string
 	if str = r.FormValue(key); str == "" {
@@ -412,6 +591,12 @@ type updateVolReq struct {
 	metaFollowerRead         bool
 	directRead               bool
 	ignoreTinyRecover        bool
+	syncMirrorWrite          bool
+	requireTLS               bool
+	inlineDataThreshold      int64
+	smallFileThreshold       int64
+	consistencyMode          string
+	mpWitnessNum             uint8
 	maximallyRead            bool
 	leaderRetryTimeout       int64
 	authenticate             bool
@@ -540,6 +725,41 @@ func parseVolUpdateReq(r *http.Request, vol *Vol, req *updateVolReq) (err error)
 		return
 	}
 
+	if req.syncMirrorWrite, err = extractBoolWithDefault(r, proto.VolSyncMirrorWrite, vol.SyncMirrorWrite); err != nil {
+		return
+	}
+
+	if req.requireTLS, err = extractBoolWithDefault(r, proto.VolRequireTLS, vol.RequireTLS); err != nil {
+		return
+	}
+
+	if req.inlineDataThreshold, err = extractInt64WithDefault(r, proto.VolInlineDataThreshold, vol.InlineDataThreshold); err != nil {
+		return
+	}
+	if req.inlineDataThreshold < 0 || req.inlineDataThreshold > proto.MaxInlineDataThreshold {
+		err = fmt.Errorf("%v must be between 0 and %v, now %v", proto.VolInlineDataThreshold, proto.MaxInlineDataThreshold, req.inlineDataThreshold)
+		return
+	}
+
+	if req.smallFileThreshold, err = extractInt64WithDefault(r, proto.VolSmallFileThreshold, vol.SmallFileThreshold); err != nil {
+		return
+	}
+	if req.smallFileThreshold < 0 || req.smallFileThreshold > proto.MaxSmallFileThreshold {
+		err = fmt.Errorf("%v must be between 0 and %v, now %v", proto.VolSmallFileThreshold, proto.MaxSmallFileThreshold, req.smallFileThreshold)
+		return
+	}
+
+	req.consistencyMode = extractStrWithDefault(r, proto.VolConsistencyMode, vol.ConsistencyMode)
+	if req.consistencyMode != "" && !proto.IsValidConsistencyMode(req.consistencyMode) {
+		err = fmt.Errorf("%v must be %v or %v, now %v", proto.VolConsistencyMode,
+			proto.ConsistencyModeRelaxed, proto.ConsistencyModeStrict, req.consistencyMode)
+		return
+	}
+
+	if req.mpWitnessNum, err = extractMetaWitnessNum(r, vol.MpWitnessNum, vol.mpReplicaNum); err != nil {
+		return
+	}
+
 	if req.dpReadOnlyWhenVolFull, err = extractBoolWithDefault(r, dpReadOnlyWhenVolFull, vol.DpReadOnlyWhenVolFull); err != nil {
 		return
 	}
@@ -753,6 +973,7 @@ type createVolReq struct {
 	trashInterval           int64
 	accessTimeValidInterval int64
 	enablePersistAccessTime bool
+	mpWitnessNum            uint8
 	// cold vol args
 	coldArgs coldVolArgs
 
@@ -986,6 +1207,10 @@ func parseRequestToCreateVol(r *http.Request, req *createVolReq) (err error) {
 		return
 	}
 
+	if req.mpWitnessNum, err = extractMetaWitnessNum(r, 0, defaultReplicaNum); err != nil {
+		return
+	}
+
 	if req.allowedStorageClass, err = parseAllowedStorageClass(r); err != nil {
 		return
 	}
@@ -1528,6 +1753,30 @@ func parseAndExtractSetNodeInfoParams(r *http.Request) (params map[string]interf
 		}
 		params[nodeDpBackupKey] = val
 	}
+	if value = r.FormValue(nodeReplicaTombstoneRetentionKey); value != "" {
+		noParams = false
+		val := uint64(0)
+		val, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			err = unmatchedKey(nodeReplicaTombstoneRetentionKey)
+			return
+		}
+		params[nodeReplicaTombstoneRetentionKey] = val
+	}
+	for _, key := range []string{nodeClockSkewWarnKey, nodeClockSkewLimitKey, hotDpReadQpsKey, hotDpReadReplicasKey,
+		heartbeatMaxIntervalKey, flashGroupMinNodesPerZoneKey, leaderBalanceMaxTransfersKey, hotMpSplitQpsKey, hotMpSplitMemMBKey,
+		decommissionBandwidthMBpsKey} {
+		if value = r.FormValue(key); value != "" {
+			noParams = false
+			val := uint64(0)
+			val, err = strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				err = unmatchedKey(key)
+				return
+			}
+			params[key] = val
+		}
+	}
 	if value = r.FormValue(nodeDpMaxRepairErrCntKey); value != "" {
 		noParams = false
 		val := uint64(0)
@@ -1597,6 +1846,61 @@ func parseAndExtractSetNodeInfoParams(r *http.Request) (params map[string]interf
 		params[flashNodeReadDataNodeTimeout] = val
 	}
 
+	if value = r.FormValue(flashNodePeerFillEnable); value != "" {
+		noParams = false
+		val := false
+		val, err = strconv.ParseBool(value)
+		if err != nil {
+			err = unmatchedKey(flashNodePeerFillEnable)
+			return
+		}
+		params[flashNodePeerFillEnable] = val
+	}
+
+	if value = r.FormValue(flashNodePeerFillTimeout); value != "" {
+		noParams = false
+		val := int64(0)
+		val, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || val <= 0 {
+			err = unmatchedKey(flashNodePeerFillTimeout)
+			return
+		}
+		params[flashNodePeerFillTimeout] = val
+	}
+
+	if value = r.FormValue(flashNodeAdmissionEnable); value != "" {
+		noParams = false
+		val := false
+		val, err = strconv.ParseBool(value)
+		if err != nil {
+			err = unmatchedKey(flashNodeAdmissionEnable)
+			return
+		}
+		params[flashNodeAdmissionEnable] = val
+	}
+
+	if value = r.FormValue(flashNodeReadLimitMBps); value != "" {
+		noParams = false
+		val := int64(0)
+		val, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || val < 0 {
+			err = unmatchedKey(flashNodeReadLimitMBps)
+			return
+		}
+		params[flashNodeReadLimitMBps] = val
+	}
+
+	if value = r.FormValue(flashNodeReadLimitIops); value != "" {
+		noParams = false
+		val := int64(0)
+		val, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || val < 0 {
+			err = unmatchedKey(flashNodeReadLimitIops)
+			return
+		}
+		params[flashNodeReadLimitIops] = val
+	}
+
 	if value = r.FormValue(autoDecommissionDiskKey); value != "" {
 		noParams = false
 		val := false
@@ -2005,12 +2309,7 @@ func newErrHTTPReply(err error) *proto.HTTPReply {
 		return newSuccessHTTPReply("")
 	}
 
-	code, ok := proto.Err2CodeMap[err]
-	if ok {
-		return &proto.HTTPReply{Code: code, Msg: err.Error()}
-	}
-
-	return &proto.HTTPReply{Code: proto.ErrCodeInternalError, Msg: err.Error()}
+	return &proto.HTTPReply{Code: proto.ErrorCode(err), Msg: err.Error()}
 }
 
 func sendOkReply(w http.ResponseWriter, r *http.Request, httpReply *proto.HTTPReply) (err error) {
@@ -2063,7 +2362,8 @@ func send(w http.ResponseWriter, r *http.Request, reply []byte) {
 }
 
 func sendErrReply(w http.ResponseWriter, r *http.Request, httpReply *proto.HTTPReply) {
-	log.LogInfof("URL[%v],remoteAddr[%v],response", r.URL, r.RemoteAddr)
+	log.LogInfof("URL[%v],remoteAddr[%v],requestID[%v],response code[%v] msg[%v]", r.URL, r.RemoteAddr, getRequestID(r),
+		httpReply.Code, httpReply.Msg)
 	reply, err := json.Marshal(httpReply)
 	if err != nil {
 		log.LogErrorf("fail to marshal http reply. URL[%v],remoteAddr[%v] err:[%v]", r.URL, r.RemoteAddr, err)
@@ -2152,6 +2452,11 @@ func parseSetConfigParam(r *http.Request) (config map[string]string, err error)
 		cfgAutoMpMigrate,
 		flashNodeHandleReadTimeout,
 		flashNodeReadDataNodeTimeout,
+		flashNodePeerFillEnable,
+		flashNodePeerFillTimeout,
+		flashNodeAdmissionEnable,
+		flashNodeReadLimitMBps,
+		flashNodeReadLimitIops,
 	}
 	for _, val := range keyList {
 		key := val
@@ -106,6 +106,7 @@ type ZoneView struct {
 	MetaNodesetSelector string
 	NodeSet             map[uint64]*NodeSetView
 	DataMediaType       string
+	Isolation           string
 }
 
 func newZoneView(name string) *ZoneView {
@@ -316,6 +317,11 @@ func (m *Server) forbidVolume(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolHasDeleted, Msg: err.Error()})
 		return
 	}
+	if vol.Published && !status {
+		err = fmt.Errorf("vol[%v] is published and can not be unfrozen", name)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
 	oldForbiden := vol.Forbidden
 	vol.Forbidden = status
 	defer func() {
@@ -333,9 +339,567 @@ func (m *Server) forbidVolume(w http.ResponseWriter, r *http.Request) {
 		// set meta partition status to read only
 		vol.setMpForbid()
 	}
+	if oldForbiden != status {
+		m.cluster.recordVolEvent(name, proto.VolEventForbidden, "forbidden[%v]", status)
+	}
 	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set volume forbidden to (%v) success", status)))
 }
 
+// publishVolume finalizes a dataset volume. The data has been compacted into
+// the content-addressed layout by cfs-publish before, from now on the volume is
+// frozen the same way as a forbidden one and can not be unfrozen any more.
+func (m *Server) publishVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		authKey string
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolPublish))
+	defer func() {
+		doStatAndMetric(proto.AdminVolPublish, metric, err, nil)
+		AuditLog(r, proto.AdminVolPublish, fmt.Sprintf("publish volume(%s)", name), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if vol.Status == proto.VolStatusMarkDelete {
+		err = errors.New("vol has been mark delete, publish operation is not allowed")
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolHasDeleted, Msg: err.Error()})
+		return
+	}
+	if vol.Published {
+		sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("vol[%v] has been published at %v",
+			name, time.Unix(vol.PublishTime, 0).Format(proto.TimeFormat))))
+		return
+	}
+
+	oldForbidden := vol.Forbidden
+	vol.Published = true
+	vol.PublishTime = time.Now().Unix()
+	vol.Forbidden = true
+	if err = m.cluster.syncUpdateVol(vol); err != nil {
+		vol.Published, vol.PublishTime, vol.Forbidden = false, 0, oldForbidden
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	vol.setDpForbid()
+	vol.setMpForbid()
+	log.LogInfof("action[publishVolume] vol[%v] published", name)
+	m.cluster.recordVolEvent(name, proto.VolEventForbidden, "forbidden[true] as published")
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("publish vol[%v] successfully", name)))
+}
+
+func (m *Server) setVolInterop(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		authKey string
+		policy  proto.InteropPolicy
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolSetInterop))
+	defer func() {
+		doStatAndMetric(proto.AdminVolSetInterop, metric, err, nil)
+		AuditLog(r, proto.AdminVolSetInterop, fmt.Sprintf("set volume(%s) interop to (%+v)", name, policy), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if policy, err = parseRequestToSetVolInterop(r, vol.interop); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	oldPolicy := vol.interop
+	vol.interop = policy
+	if err = m.cluster.syncUpdateVol(vol); err != nil {
+		vol.interop = oldPolicy
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set vol[%v] interop to %+v successfully", name, policy)))
+}
+
+func (m *Server) setVolPlacementExclusion(w http.ResponseWriter, r *http.Request) {
+	var (
+		name      string
+		authKey   string
+		exclusion proto.PlacementExclusion
+		err       error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolSetPlacementExclusion))
+	defer func() {
+		doStatAndMetric(proto.AdminVolSetPlacementExclusion, metric, err, nil)
+		AuditLog(r, proto.AdminVolSetPlacementExclusion, fmt.Sprintf("set volume(%s) placement exclusion to (%v)", name, exclusion), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if exclusion, err = parseRequestToSetVolPlacementExclusion(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	policy := vol.getPlacementPolicy()
+	if err = policy.CheckExclusion(&exclusion); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	old := vol.setPlacementExclusion(exclusion)
+	if err = m.cluster.syncUpdateVol(vol); err != nil {
+		vol.setPlacementExclusion(old)
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set vol[%v] placement exclusion to %v successfully", name, exclusion)))
+}
+
+func (m *Server) setVolPlacementPolicy(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		authKey string
+		policy  proto.PlacementPolicy
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolSetPlacementPolicy))
+	defer func() {
+		doStatAndMetric(proto.AdminVolSetPlacementPolicy, metric, err, nil)
+		AuditLog(r, proto.AdminVolSetPlacementPolicy, fmt.Sprintf("set volume(%s) placement policy to (%v)", name, policy), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if policy, err = parseRequestToSetVolPlacementPolicy(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if err = m.cluster.checkPlacementPolicy(vol, &policy); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	old := vol.setPlacementPolicy(policy)
+	if err = m.cluster.syncUpdateVol(vol); err != nil {
+		vol.setPlacementPolicy(old)
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set vol[%v] placement policy to %v successfully", name, policy)))
+}
+
+func (m *Server) setVolSLO(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		authKey string
+		op      string
+		target  *proto.SLOTarget
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolSetSLO))
+	defer func() {
+		doStatAndMetric(proto.AdminVolSetSLO, metric, err, nil)
+		AuditLog(r, proto.AdminVolSetSLO, fmt.Sprintf("set volume(%s) slo of op(%v) to (%+v)", name, op, target), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if op, target, err = parseRequestToSetVolSLO(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	old := vol.setSLO(op, target)
+	if err = m.cluster.syncUpdateVol(vol); err != nil {
+		vol.restoreSLO(old)
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if target == nil {
+		sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("remove vol[%v] slo of op[%v] successfully", name, op)))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set vol[%v] slo of op[%v] to %+v successfully", name, op, *target)))
+}
+
+// getVolSLO returns the SLO attainment of the volume, or of all volumes with SLOs if name is not given.
+func (m *Server) getVolSLO(w http.ResponseWriter, r *http.Request) {
+	var err error
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolSLO))
+	defer func() {
+		doStatAndMetric(proto.AdminVolSLO, metric, err, nil)
+	}()
+
+	now := time.Now()
+	if name := r.FormValue(nameKey); name != "" {
+		var vol *Vol
+		if vol, err = m.cluster.getVol(name); err != nil {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+			return
+		}
+		sendOkReply(w, r, newSuccessHTTPReply(m.cluster.sloTracker.status(now, vol.Name, vol.getSLO())))
+		return
+	}
+	statuses := make([]*proto.VolumeSLOStatus, 0)
+	for _, vol := range m.cluster.allVols() {
+		if slo := vol.getSLO(); len(slo) > 0 {
+			statuses = append(statuses, m.cluster.sloTracker.status(now, vol.Name, slo))
+		}
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(statuses))
+}
+
+// reportSLO takes the latency samples of clients and servers, samples of unknown volumes are dropped.
+func (m *Server) reportSLO(w http.ResponseWriter, r *http.Request) {
+	var (
+		reports []*proto.SLOReport
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminSLOReport))
+	defer func() {
+		doStatAndMetric(proto.AdminSLOReport, metric, err, nil)
+	}()
+	if reports, err = parseSLOReports(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	now := time.Now()
+	accepted := 0
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		if _, e := m.cluster.getVol(report.Volume); e != nil {
+			log.LogDebugf("action[reportSLO] drop report of vol[%v] from %v: %v", report.Volume, report.Source, e)
+			continue
+		}
+		m.cluster.sloTracker.add(now, report)
+		accepted++
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("accepted %v of %v reports", accepted, len(reports))))
+}
+
+func (m *Server) setMountProfile(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		options map[string]string
+		profile *proto.MountProfile
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMountProfileSet))
+	defer func() {
+		doStatAndMetric(proto.AdminMountProfileSet, metric, err, nil)
+		AuditLog(r, proto.AdminMountProfileSet, fmt.Sprintf("set mount profile(%s) options(%s)", name, proto.FormatMountProfileOptions(options)), err)
+	}()
+	if name, options, err = parseRequestToSetMountProfile(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if profile, err = m.cluster.setMountProfile(name, options); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(profile))
+}
+
+// getMountProfile is called by the clients mounting with the profile.
+func (m *Server) getMountProfile(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		profile *proto.MountProfile
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMountProfileGet))
+	defer func() {
+		doStatAndMetric(proto.AdminMountProfileGet, metric, err, nil)
+	}()
+	if name = r.FormValue(nameKey); name == "" {
+		err = keyNotFound(nameKey)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if profile, err = m.cluster.getMountProfile(name); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(profile))
+}
+
+func (m *Server) listMountProfiles(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMountProfileList))
+	defer func() {
+		doStatAndMetric(proto.AdminMountProfileList, metric, nil, nil)
+	}()
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.mountProfiles.list()))
+}
+
+func (m *Server) deleteMountProfile(w http.ResponseWriter, r *http.Request) {
+	var (
+		name string
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMountProfileDelete))
+	defer func() {
+		doStatAndMetric(proto.AdminMountProfileDelete, metric, err, nil)
+		AuditLog(r, proto.AdminMountProfileDelete, fmt.Sprintf("delete mount profile(%s)", name), err)
+	}()
+	if name = r.FormValue(nameKey); name == "" {
+		err = keyNotFound(nameKey)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if err = m.cluster.deleteMountProfile(name); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("delete mount profile[%v] successfully", name)))
+}
+
+func (m *Server) evictClient(w http.ResponseWriter, r *http.Request) {
+	var (
+		e      *proto.ClientEviction
+		expire uint64
+		err    error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminClientEvict))
+	defer func() {
+		doStatAndMetric(proto.AdminClientEvict, metric, err, nil)
+		if e != nil {
+			AuditLog(r, proto.AdminClientEvict, fmt.Sprintf("evict client ip(%s) vol(%s) mount(%s) reason(%s) expire(%v)",
+				e.IP, e.Volume, e.MountID, e.Reason, expire), err)
+		}
+	}()
+	if e, expire, err = parseRequestToEvictClient(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if e.IP == "" {
+		mount, ok := m.cliMgr.GetMount(e.MountID)
+		if !ok {
+			err = fmt.Errorf("mount[%v] has not been reported to master", e.MountID)
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+			return
+		}
+		e.IP = mount.ip
+		if e.Volume == "" {
+			e.Volume = mount.vol
+		}
+	}
+	if expire > 0 {
+		e.ExpireTime = time.Now().Unix() + int64(expire)
+	}
+	if err = m.cluster.evictClient(e); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(e))
+}
+
+func (m *Server) removeClientEviction(w http.ResponseWriter, r *http.Request) {
+	var (
+		ip, vol, mountID string
+		removed          []*proto.ClientEviction
+		err              error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminClientEvictRemove))
+	defer func() {
+		doStatAndMetric(proto.AdminClientEvictRemove, metric, err, nil)
+		AuditLog(r, proto.AdminClientEvictRemove, fmt.Sprintf("remove client eviction ip(%s) vol(%s) mount(%s)", ip, vol, mountID), err)
+	}()
+	ip, vol, mountID = r.FormValue(IPKey), r.FormValue(nameKey), r.FormValue(proto.MountIDKey)
+	if ip == "" && mountID == "" {
+		err = keyNotFound(IPKey + " or " + proto.MountIDKey)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if removed, err = m.cluster.removeClientEviction(ip, vol, mountID); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(removed))
+}
+
+func (m *Server) listClientEvictions(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminClientEvictList))
+	defer func() {
+		doStatAndMetric(proto.AdminClientEvictList, metric, nil, nil)
+	}()
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.clientEvictions.list()))
+}
+
+func (m *Server) createAuditCampaign(w http.ResponseWriter, r *http.Request) {
+	var (
+		name        string
+		authKey     string
+		budget      time.Duration
+		concurrency int
+		ac          *auditCampaign
+		err         error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminAuditCampaignCreate))
+	defer func() {
+		doStatAndMetric(proto.AdminAuditCampaignCreate, metric, err, nil)
+		AuditLog(r, proto.AdminAuditCampaignCreate, fmt.Sprintf("create audit campaign of volume(%s) budget(%v)", name, budget), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if budget, concurrency, err = parseRequestToCreateAuditCampaign(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if ac, err = m.cluster.auditMgr.start(vol, budget, concurrency); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(ac.getView(false)))
+}
+
+func (m *Server) getAuditCampaign(w http.ResponseWriter, r *http.Request) {
+	var (
+		id  string
+		ac  *auditCampaign
+		err error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminAuditCampaignGet))
+	defer func() {
+		doStatAndMetric(proto.AdminAuditCampaignGet, metric, err, nil)
+	}()
+	if id = r.FormValue(idKey); id == "" {
+		err = keyNotFound(idKey)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if ac, err = m.cluster.auditMgr.get(id); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(ac.getView(true)))
+}
+
+func (m *Server) listAuditCampaigns(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminAuditCampaignList))
+	defer func() {
+		doStatAndMetric(proto.AdminAuditCampaignList, metric, nil, nil)
+	}()
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.auditMgr.list()))
+}
+
+func (m *Server) cancelAuditCampaign(w http.ResponseWriter, r *http.Request) {
+	var (
+		id  string
+		ac  *auditCampaign
+		err error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminAuditCampaignCancel))
+	defer func() {
+		doStatAndMetric(proto.AdminAuditCampaignCancel, metric, err, nil)
+		AuditLog(r, proto.AdminAuditCampaignCancel, fmt.Sprintf("cancel audit campaign(%s)", id), err)
+	}()
+	if id = r.FormValue(idKey); id == "" {
+		err = keyNotFound(idKey)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if ac, err = m.cluster.auditMgr.get(id); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	ac.cancel()
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("cancel audit campaign[%v] successfully, partitions being verified will finish first", id)))
+}
+
+// getAuditCampaignReport returns the signed report of a finished campaign.
+func (m *Server) getAuditCampaignReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		id  string
+		ac  *auditCampaign
+		err error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminAuditCampaignReport))
+	defer func() {
+		doStatAndMetric(proto.AdminAuditCampaignReport, metric, err, nil)
+	}()
+	if id = r.FormValue(idKey); id == "" {
+		err = keyNotFound(idKey)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if ac, err = m.cluster.auditMgr.get(id); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	ac.RLock()
+	report := ac.report
+	ac.RUnlock()
+	if report == nil {
+		err = fmt.Errorf("audit campaign[%v] is still running", id)
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(report))
+}
+
 func (m *Server) setEnableAuditLogForVolume(w http.ResponseWriter, r *http.Request) {
 	var (
 		status bool
@@ -422,6 +986,7 @@ func (m *Server) getTopology(w http.ResponseWriter, r *http.Request) {
 		cv.Status = zone.getStatusToString()
 		cv.DataNodesetSelector = zone.GetDataNodesetSelector()
 		cv.MetaNodesetSelector = zone.GetMetaNodesetSelector()
+		cv.Isolation = proto.ZoneIsolationString(zone.getIsolation())
 		cv.DataMediaType = zone.GetDataMediaTypeString()
 		tv.Zones = append(tv.Zones, cv)
 		nsc := zone.getAllNodeSet()
@@ -513,6 +1078,7 @@ func (m *Server) listZone(w http.ResponseWriter, r *http.Request) {
 		cv.Status = zone.getStatusToString()
 		cv.DataNodesetSelector = zone.GetDataNodesetSelector()
 		cv.MetaNodesetSelector = zone.GetMetaNodesetSelector()
+		cv.Isolation = proto.ZoneIsolationString(zone.getIsolation())
 		zoneViews = append(zoneViews, cv)
 	}
 	sendOkReply(w, r, newSuccessHTTPReply(zoneViews))
@@ -689,6 +1255,15 @@ func (m *Server) clusterStat(w http.ResponseWriter, r *http.Request) {
 	sendOkReply(w, r, newSuccessHTTPReply(cs))
 }
 
+func (m *Server) clusterFeatures(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminClusterFeatures))
+	defer func() {
+		doStatAndMetric(proto.AdminClusterFeatures, metric, nil, nil)
+	}()
+
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.featureMatrix()))
+}
+
 func (m *Server) UidOperate(w http.ResponseWriter, r *http.Request) {
 	var (
 		uid     uint32
@@ -943,6 +1518,18 @@ func (m *Server) getCluster(w http.ResponseWriter, r *http.Request) {
 		DataNodeGOGC:                           m.cluster.cfg.dataNodeGOGC,
 		DpRepairTimeout:                        m.cluster.GetDecommissionDataPartitionRecoverTimeOut().String(),
 		DpBackupTimeout:                        m.cluster.GetDecommissionDataPartitionBackupTimeOut().String(),
+		ReplicaTombstoneRetention:              m.cluster.getReplicaTombstoneRetention().String(),
+		ClockSkewWarn:                          m.cluster.getClockSkewWarn().String(),
+		ClockSkewLimit:                         m.cluster.getClockSkewLimit().String(),
+		HotDpReadQps:                           m.cluster.getHotDpReadQps(),
+		HotDpReadReplicas:                      m.cluster.getHotDpReadReplicas(),
+		HeartbeatMaxInterval:                   m.cluster.getHeartbeatMaxInterval().String(),
+		FlashGroupMinNodesPerZone:              m.cluster.getFlashGroupMinNodesPerZone(),
+		LeaderBalanceMaxTransfers:              m.cluster.getLeaderBalanceMaxTransfers(),
+		HotMpSplitQps:                          m.cluster.getHotMpSplitQps(),
+		HotMpSplitMemMB:                        m.cluster.getHotMpSplitMemMB(),
+		DecommissionBandwidthMBps:              m.cluster.getDecommissionBandwidthMBps(),
+		Maintenances:                           m.cluster.activeMaintenances(time.Now().Unix()),
 		MarkDiskBrokenThreshold:                m.cluster.getMarkDiskBrokenThreshold(),
 		EnableAutoDpMetaRepair:                 m.cluster.getEnableAutoDpMetaRepair(),
 		AutoDpMetaRepairParallelCnt:            m.cluster.GetAutoDpMetaRepairParallelCnt(),
@@ -967,6 +1554,11 @@ func (m *Server) getCluster(w http.ResponseWriter, r *http.Request) {
 		FlashNodes:                   make([]proto.NodeView, 0),
 		FlashNodeHandleReadTimeout:   m.cluster.cfg.flashNodeHandleReadTimeout,
 		FlashNodeReadDataNodeTimeout: m.cluster.cfg.flashNodeReadDataNodeTimeout,
+		FlashNodePeerFillEnable:      m.cluster.cfg.flashNodePeerFillEnable,
+		FlashNodePeerFillTimeout:     m.cluster.cfg.flashNodePeerFillTimeout,
+		FlashNodeAdmissionEnable:     m.cluster.cfg.flashNodeAdmissionEnable,
+		FlashNodeReadLimitMBps:       m.cluster.cfg.flashNodeReadLimitMBps,
+		FlashNodeReadLimitIops:       m.cluster.cfg.flashNodeReadLimitIops,
 	}
 
 	vols := m.cluster.allVolNames()
@@ -1053,6 +1645,37 @@ func (m *Server) getCluster(w http.ResponseWriter, r *http.Request) {
 	sendOkReply(w, r, newSuccessHTTPReply(cv))
 }
 
+// getSummary returns the health scored overview of the cluster.
+func (m *Server) getSummary(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminSummary))
+	defer func() {
+		doStatAndMetric(proto.AdminSummary, metric, nil, nil)
+	}()
+
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.summary()))
+}
+
+func (m *Server) simulatePlacement(w http.ResponseWriter, r *http.Request) {
+	var (
+		req    *proto.PlacementSimulationRequest
+		result *proto.PlacementSimulation
+		err    error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminPlacementSimulate))
+	defer func() {
+		doStatAndMetric(proto.AdminPlacementSimulate, metric, err, nil)
+	}()
+	if req, err = parsePlacementSimulationRequest(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if result, err = m.cluster.simulatePlacement(req); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(result))
+}
+
 func (m *Server) getApiList(w http.ResponseWriter, r *http.Request) {
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminGetMasterApiList))
 	defer func() {
@@ -1183,6 +1806,7 @@ func (m *Server) getIPAddr(w http.ResponseWriter, r *http.Request) {
 		ClusterUuidEnable:                  m.cluster.clusterUuidEnable,
 		ClusterEnableSnapshot:              m.cluster.cfg.EnableSnapshot,
 		RaftPartitionCanUsingDifferentPort: m.cluster.RaftPartitionCanUsingDifferentPortEnabled(),
+		Features:                           m.cluster.featureMatrix().Enabled,
 	}
 
 	sendOkReply(w, r, newSuccessHTTPReply(cInfo))
@@ -1815,6 +2439,12 @@ func (m *Server) addDataReplica(w http.ResponseWriter, r *http.Request) {
 	if err = m.cluster.checkMultipleReplicasOnSameMachine(newHosts); err != nil {
 		return
 	}
+	if vol, e := m.cluster.getVol(dp.VolName); e == nil {
+		if err = m.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{addr}); err != nil {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+			return
+		}
+	}
 
 	retry := 0
 	for {
@@ -1945,8 +2575,14 @@ func (m *Server) addMetaReplica(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
 	}
+	if vol, e := m.cluster.getVol(mp.volName); e == nil {
+		if err = m.cluster.checkPlacementExclusion(vol, TypeMetaPartition, []string{addr}); err != nil {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+			return
+		}
+	}
 
-	if err = m.cluster.addMetaReplica(mp, addr); err != nil {
+	if err = m.cluster.addMetaReplica(mp, addr, false); err != nil {
 		sendErrReply(w, r, newErrHTTPReply(err))
 		return
 	}
@@ -2154,7 +2790,7 @@ func (m *Server) decommissionDataPartition(w http.ResponseWriter, r *http.Reques
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: rstMsg})
 		return
 	}
-	err = m.cluster.markDecommissionDataPartition(dp, node, dstNodeSet, raftForce, uint32(decommissionType), weight)
+	err = m.cluster.markDecommissionDataPartition(dp, node, "", dstNodeSet, raftForce, uint32(decommissionType), weight)
 	if err != nil {
 		sendErrReply(w, r, newErrHTTPReply(err))
 		return
@@ -2570,9 +3206,17 @@ func (m *Server) updateVol(w http.ResponseWriter, r *http.Request) {
 		newArg("remoteCacheMaxFileSizeGB", &newArgs.remoteCacheMaxFileSizeGB).OmitEmpty(),
 		newArg("remoteCacheOnlyForNotSSD", &newArgs.remoteCacheOnlyForNotSSD).OmitEmpty(),
 		newArg("remoteCacheMultiRead", &newArgs.remoteCacheMultiRead).OmitEmpty(),
+		newArg("remoteCacheAlwaysAdmit", &newArgs.remoteCacheAlwaysAdmit).OmitEmpty(),
+		newArg("remoteCacheAuth", &newArgs.remoteCacheAuth).OmitEmpty(),
+		newArg("remoteCacheReadAheadMB", &newArgs.remoteCacheReadAheadMB).OmitEmpty(),
+		newArg("remoteCacheMetaTTL", &newArgs.remoteCacheMetaTTL).OmitEmpty(),
+		newArg("remoteCacheReadLimitMBps", &newArgs.remoteCacheReadLimitMBps).OmitEmpty(),
+		newArg("remoteCacheReadLimitIops", &newArgs.remoteCacheReadLimitIops).OmitEmpty(),
 		newArg("flashNodeTimeoutCount", &newArgs.flashNodeTimeoutCount).OmitEmpty(),
 		newArg("remoteCacheSameZoneTimeout", &newArgs.remoteCacheSameZoneTimeout).OmitEmpty(),
 		newArg("remoteCacheSameRegionTimeout", &newArgs.remoteCacheSameRegionTimeout).OmitEmpty(),
+		newArg("remoteCacheHedgeDelay", &newArgs.remoteCacheHedgeDelay).OmitEmpty(),
+		newArg("remoteCacheFallbackTimeout", &newArgs.remoteCacheFallbackTimeout).OmitEmpty(),
 	); err != nil {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
@@ -2591,6 +3235,32 @@ func (m *Server) updateVol(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if newArgs.remoteCacheReadAheadMB < 0 || newArgs.remoteCacheReadAheadMB > proto.MaxRemoteCacheReadAheadMB {
+		err = fmt.Errorf("remoteCacheReadAheadMB(%v) should be 0 to %v", newArgs.remoteCacheReadAheadMB, proto.MaxRemoteCacheReadAheadMB)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+
+	if newArgs.remoteCacheHedgeDelay < 0 || newArgs.remoteCacheFallbackTimeout < 0 {
+		err = fmt.Errorf("remoteCacheHedgeDelay(%v) and remoteCacheFallbackTimeout(%v) should not be negative",
+			newArgs.remoteCacheHedgeDelay, newArgs.remoteCacheFallbackTimeout)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+
+	if newArgs.remoteCacheMetaTTL < 0 || newArgs.remoteCacheMetaTTL > proto.MaxRemoteCacheMetaTTL {
+		err = fmt.Errorf("remoteCacheMetaTTL(%v) should be 0 to %v", newArgs.remoteCacheMetaTTL, proto.MaxRemoteCacheMetaTTL)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+
+	if newArgs.remoteCacheReadLimitMBps < 0 || newArgs.remoteCacheReadLimitIops < 0 {
+		err = fmt.Errorf("remoteCacheReadLimitMBps(%v) and remoteCacheReadLimitIops(%v) should not be less than 0",
+			newArgs.remoteCacheReadLimitMBps, newArgs.remoteCacheReadLimitIops)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+
 	if len(newArgs.remoteCachePath) != 0 {
 		newArgs.remoteCachePath = deduplicateAndRemoveContained(newArgs.remoteCachePath)
 	}
@@ -2603,6 +3273,12 @@ func (m *Server) updateVol(w http.ResponseWriter, r *http.Request) {
 	newArgs.metaFollowerRead = req.metaFollowerRead
 	newArgs.directRead = req.directRead
 	newArgs.ignoreTinyRecover = req.ignoreTinyRecover
+	newArgs.syncMirrorWrite = req.syncMirrorWrite
+	newArgs.requireTLS = req.requireTLS
+	newArgs.inlineDataThreshold = req.inlineDataThreshold
+	newArgs.smallFileThreshold = req.smallFileThreshold
+	newArgs.consistencyMode = req.consistencyMode
+	newArgs.mpWitnessNum = req.mpWitnessNum
 	newArgs.maximallyRead = req.maximallyRead
 	newArgs.authenticate = req.authenticate
 	newArgs.dpSelectorName = req.dpSelectorName
@@ -2628,6 +3304,12 @@ func (m *Server) updateVol(w http.ResponseWriter, r *http.Request) {
 	newArgs.volStorageClass = req.volStorageClass
 	newArgs.forbidWriteOpOfProtoVer0 = req.forbidWriteOpOfProtoVer0
 
+	if newArgs.syncMirrorWrite && !newArgs.crossZone {
+		err = fmt.Errorf("vol[%v] is not cross zone, can't enable %v", req.name, proto.VolSyncMirrorWrite)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+
 	log.LogWarnf("[updateVolOut] name [%s], z1 [%s], z2[%s] replicaNum[%v], FR[%v], metaFR[%v], MMR[%v]",
 		req.name, req.zoneName, vol.zoneName, req.replicaNum, req.followerRead, req.metaFollowerRead, req.maximallyRead)
 	if err = m.cluster.updateVol(req.name, req.authKey, newArgs); err != nil {
@@ -3097,6 +3779,8 @@ func (m *Server) getVolSimpleInfo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	volView := newSimpleView(vol)
+	volView.ForkSources = vol.getForkSources()
+	volView.ForkTargets = m.cluster.getVolForkTargets(vol.Name)
 
 	sendOkReply(w, r, newSuccessHTTPReply(volView))
 }
@@ -3121,24 +3805,30 @@ func newSimpleView(vol *Vol) (view *proto.SimpleVolView) {
 	}
 
 	view = &proto.SimpleVolView{
-		ID:                 vol.ID,
-		Name:               vol.Name,
-		Owner:              vol.Owner,
-		ZoneName:           vol.zoneName,
-		DpReplicaNum:       vol.dpReplicaNum,
-		MpReplicaNum:       vol.mpReplicaNum,
-		InodeCount:         volInodeCount,
-		DentryCount:        volDentryCount,
-		MaxMetaPartitionID: maxMetaPartitionID,
-		MaxDataPartitionID: maxDataPartitionID,
-		Status:             vol.Status,
-		Capacity:           vol.Capacity,
-		FollowerRead:       vol.FollowerRead,
-		MetaFollowerRead:   vol.MetaFollowerRead,
-		DirectRead:         vol.DirectRead,
-		IgnoreTinyRecover:  vol.IgnoreTinyRecover,
-		MaximallyRead:      vol.MaximallyRead,
-		LeaderRetryTimeOut: vol.LeaderRetryTimeout,
+		ID:                  vol.ID,
+		Name:                vol.Name,
+		Owner:               vol.Owner,
+		ZoneName:            vol.zoneName,
+		DpReplicaNum:        vol.dpReplicaNum,
+		MpReplicaNum:        vol.mpReplicaNum,
+		InodeCount:          volInodeCount,
+		DentryCount:         volDentryCount,
+		MaxMetaPartitionID:  maxMetaPartitionID,
+		MaxDataPartitionID:  maxDataPartitionID,
+		Status:              vol.Status,
+		Capacity:            vol.Capacity,
+		FollowerRead:        vol.FollowerRead,
+		MetaFollowerRead:    vol.MetaFollowerRead,
+		DirectRead:          vol.DirectRead,
+		IgnoreTinyRecover:   vol.IgnoreTinyRecover,
+		SyncMirrorWrite:     vol.SyncMirrorWrite,
+		RequireTLS:          vol.RequireTLS,
+		InlineDataThreshold: vol.InlineDataThreshold,
+		SmallFileThreshold:  vol.SmallFileThreshold,
+		ConsistencyMode:     vol.ConsistencyMode,
+		MpWitnessNum:        vol.MpWitnessNum,
+		MaximallyRead:       vol.MaximallyRead,
+		LeaderRetryTimeOut:  vol.LeaderRetryTimeout,
 
 		EnablePosixAcl:          vol.enablePosixAcl,
 		EnableQuota:             vol.enableQuota,
@@ -3172,6 +3862,11 @@ func newSimpleView(vol *Vol) (view *proto.SimpleVolView) {
 		DisableAuditLog:         vol.DisableAuditLog,
 		LatestVer:               vol.VersionMgr.getLatestVer(),
 		Forbidden:               vol.Forbidden,
+		Published:               vol.Published,
+		PublishTime:             vol.PublishTime,
+		Interop:                 vol.interop,
+		PlacementExclusion:      vol.getPlacementExclusion(),
+		PlacementPolicy:         vol.getPlacementPolicy(),
 		DeleteExecTime:          vol.DeleteExecTime,
 		DpRepairBlockSize:       vol.dpRepairBlockSize,
 		EnableAutoDpMetaRepair:  vol.EnableAutoMetaRepair.Load(),
@@ -3190,9 +3885,17 @@ func newSimpleView(vol *Vol) (view *proto.SimpleVolView) {
 		RemoteCacheMaxFileSizeGB:     vol.remoteCacheMaxFileSizeGB,
 		RemoteCacheOnlyForNotSSD:     vol.remoteCacheOnlyForNotSSD,
 		RemoteCacheMultiRead:         vol.remoteCacheMultiRead,
+		RemoteCacheAlwaysAdmit:       vol.remoteCacheAlwaysAdmit,
+		RemoteCacheAuth:              vol.remoteCacheAuth,
+		RemoteCacheReadAheadMB:       vol.remoteCacheReadAheadMB,
+		RemoteCacheMetaTTL:           vol.remoteCacheMetaTTL,
+		RemoteCacheReadLimitMBps:     vol.remoteCacheReadLimitMBps,
+		RemoteCacheReadLimitIops:     vol.remoteCacheReadLimitIops,
 		FlashNodeTimeoutCount:        vol.flashNodeTimeoutCount,
 		RemoteCacheSameZoneTimeout:   vol.remoteCacheSameZoneTimeout,
 		RemoteCacheSameRegionTimeout: vol.remoteCacheSameRegionTimeout,
+		RemoteCacheHedgeDelay:        vol.remoteCacheHedgeDelay,
+		RemoteCacheFallbackTimeout:   vol.remoteCacheFallbackTimeout,
 	}
 	view.AllowedStorageClass = make([]uint32, len(vol.allowedStorageClass))
 	copy(view.AllowedStorageClass, vol.allowedStorageClass)
@@ -3348,6 +4051,8 @@ func (m *Server) getDataNode(w http.ResponseWriter, r *http.Request) {
 		MaxDpCntLimit:                         dataNode.GetPartitionLimitCnt(),
 		CpuUtil:                               dataNode.CpuUtil.Load(),
 		IoUtils:                               dataNode.GetIoUtils(),
+		ResourceThrottle:                      dataNode.GetResourceThrottle(),
+		LoadProgress:                          dataNode.GetLoadProgress(),
 		DecommissionedDisk:                    dataNode.getDecommissionedDisks(),
 		DecommissionSuccessDisk:               dataNode.getDecommissionSuccessDisks(),
 		BackupDataPartitions:                  dataNode.getBackupDataPartitionIDs(),
@@ -3355,9 +4060,51 @@ func (m *Server) getDataNode(w http.ResponseWriter, r *http.Request) {
 		MediaType:                             dataNode.MediaType,
 		DiskOpLogs:                            dataNode.DiskOpLogs,
 		DpOpLogs:                              dataNode.DpOpLogs,
+		HealthScore:                           dataNode.health.score(),
+		PoorHealth:                            dataNode.health.isPoor(),
 	}
 
-	sendOkReply(w, r, newSuccessHTTPReply(dataNodeInfo))
+	sendOkReply(w, r, newSuccessHTTPReply(dataNodeInfo))
+}
+
+// getDataNodeLoadProgress reports how far the data nodes are in loading their partitions
+// since they started, of the node of addr if given, else of all the nodes, only the ones
+// still loading with loading=true.
+func (m *Server) getDataNodeLoadProgress(w http.ResponseWriter, r *http.Request) {
+	var err error
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.GetDataNodeLoadProgress))
+	defer func() {
+		doStatAndMetric(proto.GetDataNodeLoadProgress, metric, err, nil)
+	}()
+
+	if err = r.ParseForm(); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	loading, err := pareseBoolWithDefault(r, "loading", false)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	progresses := make([]proto.DataNodeLoadProgress, 0)
+	if nodeAddr := r.FormValue(addrKey); nodeAddr != "" {
+		var dataNode *DataNode
+		if dataNode, err = m.cluster.dataNode(nodeAddr); err != nil {
+			sendErrReply(w, r, newErrHTTPReply(proto.ErrDataNodeNotExists))
+			return
+		}
+		progresses = append(progresses, dataNode.GetLoadProgress())
+	} else {
+		m.cluster.dataNodes.Range(func(_, value interface{}) bool {
+			p := value.(*DataNode).GetLoadProgress()
+			if !loading || !p.Done() {
+				progresses = append(progresses, p)
+			}
+			return true
+		})
+		sort.Slice(progresses, func(i, j int) bool { return progresses[i].Addr < progresses[j].Addr })
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(progresses))
 }
 
 func (m *Server) setDpCntLimit(w http.ResponseWriter, r *http.Request) {
@@ -3689,6 +4436,44 @@ func (m *Server) setNodeInfoHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if val, ok := params[flashNodePeerFillEnable]; ok {
+		if v, ok := val.(bool); ok {
+			if err = m.setConfig(flashNodePeerFillEnable, strconv.FormatBool(v)); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[flashNodePeerFillTimeout]; ok {
+		if v, ok := val.(int64); ok {
+			if err = m.setConfig(flashNodePeerFillTimeout, strconv.FormatInt(v, 10)); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[flashNodeAdmissionEnable]; ok {
+		if v, ok := val.(bool); ok {
+			if err = m.setConfig(flashNodeAdmissionEnable, strconv.FormatBool(v)); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	for _, key := range []string{flashNodeReadLimitMBps, flashNodeReadLimitIops} {
+		if val, ok := params[key]; ok {
+			if v, ok := val.(int64); ok {
+				if err = m.setConfig(key, strconv.FormatInt(v, 10)); err != nil {
+					sendErrReply(w, r, newErrHTTPReply(err))
+					return
+				}
+			}
+		}
+	}
+
 	if val, ok := params[nodeAutoRepairRateKey]; ok {
 		if v, ok := val.(uint64); ok {
 			if err = m.cluster.setDataNodeAutoRepairLimitRate(v); err != nil {
@@ -3716,6 +4501,105 @@ func (m *Server) setNodeInfoHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if val, ok := params[nodeReplicaTombstoneRetentionKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setReplicaTombstoneRetention(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[nodeClockSkewWarnKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setClockSkewWarn(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[nodeClockSkewLimitKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setClockSkewLimit(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[hotDpReadQpsKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setHotDpReadQps(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[hotDpReadReplicasKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setHotDpReadReplicas(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[heartbeatMaxIntervalKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setHeartbeatMaxInterval(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[flashGroupMinNodesPerZoneKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setFlashGroupMinNodesPerZone(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[leaderBalanceMaxTransfersKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setLeaderBalanceMaxTransfers(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[hotMpSplitQpsKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setHotMpSplitQps(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[hotMpSplitMemMBKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setHotMpSplitMemMB(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
+	if val, ok := params[decommissionBandwidthMBpsKey]; ok {
+		if v, ok := val.(uint64); ok {
+			if err = m.cluster.setDecommissionBandwidthMBps(v); err != nil {
+				sendErrReply(w, r, newErrHTTPReply(err))
+				return
+			}
+		}
+	}
+
 	if val, ok := params[nodeDpMaxRepairErrCntKey]; ok {
 		if v, ok := val.(uint64); ok {
 			if err = m.cluster.setDataPartitionMaxRepairErrCnt(v); err != nil {
@@ -4205,6 +5089,8 @@ func (m *Server) buildNodeSetGrpInfo(nsg *nodeSetGroup) *proto.SimpleNodeSetGrpI
 				DataPartitionCount: node.DataPartitionCount,
 				NodeSetID:          node.NodeSetID,
 				MaxDpCntLimit:      node.GetPartitionLimitCnt(),
+				HealthScore:        node.health.score(),
+				PoorHealth:         node.health.isPoor(),
 			}
 			nsStat.DataNodes = append(nsStat.DataNodes, dataNodeInfo)
 			return true
@@ -4235,6 +5121,8 @@ func (m *Server) buildNodeSetGrpInfo(nsg *nodeSetGroup) *proto.SimpleNodeSetGrpI
 				MetaPartitionCount: node.MetaPartitionCount,
 				NodeSetID:          node.NodeSetID,
 				MaxMpCntLimit:      node.GetPartitionLimitCnt(),
+				HealthScore:        node.health.score(),
+				PoorHealth:         node.health.isPoor(),
 			}
 
 			nsStat.MetaNodes = append(nsStat.MetaNodes, metaNodeInfo)
@@ -5276,6 +6164,9 @@ func (m *Server) getMetaNode(w http.ResponseWriter, r *http.Request) {
 		CanAllowPartition:         metaNode.IsWriteAble() && metaNode.PartitionCntLimited(),
 		MaxMpCntLimit:             metaNode.GetPartitionLimitCnt(),
 		CpuUtil:                   metaNode.CpuUtil.Load(),
+		ResourceThrottle:          metaNode.GetResourceThrottle(),
+		HealthScore:               metaNode.health.score(),
+		PoorHealth:                metaNode.health.isPoor(),
 	}
 	sendOkReply(w, r, newSuccessHTTPReply(metaNodeInfo))
 }
@@ -5401,6 +6292,110 @@ func parseUintParam(r *http.Request, key string) (num int, err error) {
 	return
 }
 
+// The status filters of the list APIs.
+const (
+	listStatusNormal      = "normal"
+	listStatusMarkDelete  = "markDelete"
+	listStatusReadWrite   = "readWrite"
+	listStatusReadOnly    = "readOnly"
+	listStatusUnavailable = "unavailable"
+	listStatusActive      = "active"
+	listStatusInactive    = "inactive"
+	listStatusDraining    = "draining"
+)
+
+// listPage is the page of a list API by the offset and the limit of the items,
+// which are listed in a stable order so that the pages cover them all.
+type listPage struct {
+	offset int
+	limit  int // 0 for all the items from the offset
+}
+
+func parseListPage(r *http.Request) (page listPage, err error) {
+	if err = r.ParseForm(); err != nil {
+		return
+	}
+	if page.offset, err = extractUintWithDefault(r, listOffsetKey, 0); err != nil {
+		return
+	}
+	page.limit, err = extractUintWithDefault(r, Limit, 0)
+	return
+}
+
+func (p listPage) paged() bool {
+	return p.offset > 0 || p.limit > 0
+}
+
+// bounds returns the range of the page in n items.
+func (p listPage) bounds(n int) (begin, end int) {
+	begin, end = p.offset, n
+	if begin > n {
+		begin = n
+	}
+	if p.limit > 0 && begin+p.limit < end {
+		end = begin + p.limit
+	}
+	return
+}
+
+// listFilter filters the items of a list API, an empty field matches all.
+type listFilter struct {
+	zone   string
+	owner  string // of the volumes
+	status string
+}
+
+// parseListFilter parses the filters, the status being one of the statuses
+// of the items listed.
+func parseListFilter(r *http.Request, statuses ...string) (filter listFilter, err error) {
+	if err = r.ParseForm(); err != nil {
+		return
+	}
+	filter.zone = r.FormValue(zoneNameKey)
+	filter.status = r.FormValue(listStatusKey)
+	if filter.status == "" {
+		return
+	}
+	for _, status := range statuses {
+		if filter.status == status {
+			return
+		}
+	}
+	err = fmt.Errorf("%v %v is not one of %v", listStatusKey, filter.status, statuses)
+	return
+}
+
+func (f listFilter) empty() bool {
+	return f.zone == "" && f.owner == "" && f.status == ""
+}
+
+func (f listFilter) matchStatus(status string) bool {
+	return f.status == "" || f.status == status
+}
+
+// isListRequest returns whether a page or filtered items are requested.
+func isListRequest(r *http.Request) bool {
+	for _, key := range []string{listOffsetKey, Limit, zoneNameKey, listStatusKey} {
+		if r.FormValue(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchZone returns whether one of the zones of an item is the zone filtered.
+func (f listFilter) matchZone(zones ...string) bool {
+	if f.zone == "" {
+		return true
+	}
+	for _, zone := range zones {
+		if zone == f.zone {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Server) loadMetaPartition(w http.ResponseWriter, r *http.Request) {
 	var (
 		msg         string
@@ -5798,6 +6793,8 @@ func (m *Server) getDataPartitions(w http.ResponseWriter, r *http.Request) {
 		body     []byte
 		name     string
 		compress bool
+		page     listPage
+		filter   listFilter
 		vol      *Vol
 		err      error
 	)
@@ -5810,6 +6807,14 @@ func (m *Server) getDataPartitions(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
 	}
+	if page, err = parseListPage(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if filter, err = parseListFilter(r, listStatusReadWrite, listStatusReadOnly, listStatusUnavailable); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
 	log.LogInfof("action[getDataPartitions] current is leader[%v], compress[%v]",
 		m.cluster.partition.IsRaftLeader(), compress)
 	if !m.cluster.partition.IsRaftLeader() {
@@ -5829,6 +6834,17 @@ func (m *Server) getDataPartitions(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
 		return
 	}
+	// a page or the filtered partitions are not cached
+	if page.paged() || !filter.empty() {
+		view := proto.NewDataPartitionsView()
+		view.DataPartitions = vol.dataPartitions.getDataPartitionsPage(filter, page)
+		view.VolReadOnly = vol.IsReadOnlyForVolFull() || vol.Forbidden
+		if vol.DpReadOnlyWhenVolFull {
+			view.StatByClass = vol.StatByStorageClass
+		}
+		sendOkReply(w, r, newSuccessHTTPReply(view))
+		return
+	}
 	if compress {
 		body, err = vol.getDataPartitionViewCompress()
 	} else {
@@ -5972,7 +6988,11 @@ func (m *Server) getVolStatInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m.cliMgr.PutItem(remoteIp, hostName, name, clientVer, role, enableBcache, enableRCache)
+	mountID := r.FormValue(proto.MountIDKey)
+	m.cliMgr.PutItem(remoteIp, hostName, name, clientVer, role, enableBcache, enableRCache, mountID)
+	if mountID != "" {
+		m.cliMgr.PutMount(mountID, remoteIp, name)
+	}
 
 	if proto.IsCold(vol.VolType) && ver != proto.LFClient {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: "ec-vol is supported by LF client only"})
@@ -6038,6 +7058,7 @@ func getMetaPartitionView(mp *MetaPartition) (mpView *proto.MetaPartitionView) {
 	mp.RLock()
 	defer mp.RUnlock()
 	mpView.Members = append(mpView.Members, mp.Hosts...)
+	mpView.Witnesses = mp.getWitnesses()
 	mr, err := mp.getMetaReplicaLeader()
 	if err != nil {
 		return
@@ -6137,16 +7158,21 @@ func (m *Server) getMetaPartition(w http.ResponseWriter, r *http.Request) {
 			StatByMigrateStorageClass: mp.StatByMigrateStorageClass,
 			ForbidWriteOpOfProtoVer0:  mp.ForbidWriteOpOfProtoVer0,
 		}
+		mpInfo.Qps, mpInfo.MemUsed = mp.hotStat()
 		return mpInfo
 	}
 
 	sendOkReply(w, r, newSuccessHTTPReply(toInfo(mp)))
 }
 
+// listVols lists the volumes by the name, with their names matching the
+// keywords, of the owner, the zone and the status filtered.
 func (m *Server) listVols(w http.ResponseWriter, r *http.Request) {
 	var (
 		err      error
 		keywords string
+		page     listPage
+		filter   listFilter
 		vol      *Vol
 		volsInfo []*proto.VolInfo
 	)
@@ -6159,18 +7185,38 @@ func (m *Server) listVols(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
 	}
-	volsInfo = make([]*proto.VolInfo, 0)
-	for _, name := range m.cluster.allVolNames() {
-		if strings.Contains(name, keywords) {
-			if vol, err = m.cluster.getVol(name); err != nil {
-				sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
-				return
-			}
-			stat := volStat(vol, false)
-			volInfo := proto.NewVolInfo(vol.Name, vol.Owner, vol.createTime, vol.status(), stat.TotalSize,
-				stat.UsedSize, stat.DpReadOnlyWhenVolFull)
-			volsInfo = append(volsInfo, volInfo)
+	if page, err = parseListPage(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if filter, err = parseListFilter(r, listStatusNormal, listStatusMarkDelete); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	filter.owner = r.FormValue(volOwnerKey)
+	names := m.cluster.allVolNames()
+	sort.Strings(names)
+	vols := make([]*Vol, 0)
+	for _, name := range names {
+		if !strings.Contains(name, keywords) {
+			continue
+		}
+		if vol, err = m.cluster.getVol(name); err != nil {
+			sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
+			return
 		}
+		if !filter.empty() && !vol.matchListFilter(filter) {
+			continue
+		}
+		vols = append(vols, vol)
+	}
+	begin, end := page.bounds(len(vols))
+	volsInfo = make([]*proto.VolInfo, 0, end-begin)
+	for _, vol = range vols[begin:end] {
+		stat := volStat(vol, false)
+		volInfo := proto.NewVolInfo(vol.Name, vol.Owner, vol.createTime, vol.status(), stat.TotalSize,
+			stat.UsedSize, stat.DpReadOnlyWhenVolFull)
+		volsInfo = append(volsInfo, volInfo)
 	}
 	sendOkReply(w, r, newSuccessHTTPReply(volsInfo))
 }
@@ -7175,6 +8221,10 @@ func (m *Server) setConfig(key string, value string) (err error) {
 		autoMigrate              bool
 		fnHandleReadTimeout      int
 		fnReadDataNodeTimeout    int
+		fnPeerFillEnable         bool
+		fnPeerFillTimeout        int
+		fnAdmissionEnable        bool
+		fnReadLimit              int
 		oldIntValue              int
 	)
 
@@ -7224,6 +8274,49 @@ func (m *Server) setConfig(key string, value string) (err error) {
 		oldIntValue = m.config.flashNodeReadDataNodeTimeout
 		m.config.flashNodeReadDataNodeTimeout = fnReadDataNodeTimeout
 
+	case flashNodePeerFillEnable:
+		fnPeerFillEnable, err = strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		oldBoolValue = m.config.flashNodePeerFillEnable
+		m.config.flashNodePeerFillEnable = fnPeerFillEnable
+
+	case flashNodePeerFillTimeout:
+		fnPeerFillTimeout, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if fnPeerFillTimeout <= 0 {
+			return fmt.Errorf("%v should be greater than 0", flashNodePeerFillTimeout)
+		}
+		oldIntValue = m.config.flashNodePeerFillTimeout
+		m.config.flashNodePeerFillTimeout = fnPeerFillTimeout
+
+	case flashNodeAdmissionEnable:
+		fnAdmissionEnable, err = strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		oldBoolValue = m.config.flashNodeAdmissionEnable
+		m.config.flashNodeAdmissionEnable = fnAdmissionEnable
+
+	case flashNodeReadLimitMBps, flashNodeReadLimitIops:
+		fnReadLimit, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if fnReadLimit < 0 {
+			return fmt.Errorf("%v should not be less than 0", key)
+		}
+		if key == flashNodeReadLimitMBps {
+			oldIntValue = m.config.flashNodeReadLimitMBps
+			m.config.flashNodeReadLimitMBps = fnReadLimit
+		} else {
+			oldIntValue = m.config.flashNodeReadLimitIops
+			m.config.flashNodeReadLimitIops = fnReadLimit
+		}
+
 	default:
 		err = keyNotFound("config")
 		return err
@@ -7243,6 +8336,16 @@ func (m *Server) setConfig(key string, value string) (err error) {
 			m.config.flashNodeHandleReadTimeout = oldIntValue
 		case flashNodeReadDataNodeTimeout:
 			m.config.flashNodeReadDataNodeTimeout = oldIntValue
+		case flashNodePeerFillEnable:
+			m.config.flashNodePeerFillEnable = oldBoolValue
+		case flashNodePeerFillTimeout:
+			m.config.flashNodePeerFillTimeout = oldIntValue
+		case flashNodeAdmissionEnable:
+			m.config.flashNodeAdmissionEnable = oldBoolValue
+		case flashNodeReadLimitMBps:
+			m.config.flashNodeReadLimitMBps = oldIntValue
+		case flashNodeReadLimitIops:
+			m.config.flashNodeReadLimitIops = oldIntValue
 		}
 		log.LogErrorf("setConfig syncPutCluster fail err %v", err)
 		return err
@@ -7270,6 +8373,16 @@ func (m *Server) getConfig(key string) (value string, err error) {
 		value = strconv.Itoa(m.config.flashNodeHandleReadTimeout)
 	case flashNodeReadDataNodeTimeout:
 		value = strconv.Itoa(m.config.flashNodeReadDataNodeTimeout)
+	case flashNodePeerFillEnable:
+		value = strconv.FormatBool(m.config.flashNodePeerFillEnable)
+	case flashNodePeerFillTimeout:
+		value = strconv.Itoa(m.config.flashNodePeerFillTimeout)
+	case flashNodeAdmissionEnable:
+		value = strconv.FormatBool(m.config.flashNodeAdmissionEnable)
+	case flashNodeReadLimitMBps:
+		value = strconv.Itoa(m.config.flashNodeReadLimitMBps)
+	case flashNodeReadLimitIops:
+		value = strconv.Itoa(m.config.flashNodeReadLimitIops)
 	default:
 		err = keyNotFound("config")
 	}
@@ -7999,6 +9112,37 @@ func (m *Server) adminLcNode(w http.ResponseWriter, r *http.Request) {
 		} else {
 			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: "not leader"})
 		}
+	case "pause", "resume", "limit":
+		if m.cluster.partition != nil && m.cluster.partition.IsRaftLeader() {
+			op := r.FormValue("op")
+			vol := r.FormValue("vol")
+			rid := r.FormValue("ruleid")
+			limit := r.FormValue("limit")
+			success, msg := m.cluster.lcMgr.controlLcScan(vol, rid, op, limit)
+			AuditLog(r, "AdminLcNode", fmt.Sprintf("op(%v), vol(%v), ruleid(%v), limit(%v), msg: %v", op, vol, rid, limit, msg), nil)
+			if !success {
+				sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: msg})
+			} else {
+				sendOkReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeSuccess, Msg: msg})
+			}
+		} else {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: "not leader"})
+		}
+	case "rerun":
+		if m.cluster.partition != nil && m.cluster.partition.IsRaftLeader() {
+			vol := r.FormValue("vol")
+			rid := r.FormValue("ruleid")
+			prefix := r.FormValue("prefix")
+			success, msg := m.cluster.lcMgr.rerunLcScan(vol, rid, prefix)
+			AuditLog(r, "AdminLcNode", fmt.Sprintf("op(rerun), vol(%v), ruleid(%v), prefix(%v), msg: %v", vol, rid, prefix, msg), nil)
+			if !success {
+				sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: msg})
+			} else {
+				sendOkReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeSuccess, Msg: msg})
+			}
+		} else {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: "not leader"})
+		}
 	default:
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: "invalid op"})
 	}
@@ -8114,6 +9258,7 @@ func (m *Server) S3QosGet(w http.ResponseWriter, r *http.Request) {
 		return true
 	})
 
+	s3QosResponse.LiveNodes = uint64(len(m.cluster.s3Gateways.liveNodes(time.Now())))
 	log.LogDebugf("[S3QosGet] s3qosInfoMap %+v", s3QosResponse)
 	sendOkReply(w, r, newSuccessHTTPReply(s3QosResponse))
 }
@@ -8504,20 +9649,46 @@ func (m *Server) resetDataPartitionRestoreStatus(w http.ResponseWriter, r *http.
 }
 
 func (m *Server) getAllDataNodes(w http.ResponseWriter, r *http.Request) {
+	var (
+		page   listPage
+		filter listFilter
+		err    error
+	)
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminGetClusterDataNodes))
 	defer func() {
-		doStatAndMetric(proto.AdminGetClusterDataNodes, metric, nil, nil)
+		doStatAndMetric(proto.AdminGetClusterDataNodes, metric, err, nil)
 	}()
-	dataNodes := m.cluster.allDataNodes()
+	if page, err = parseListPage(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if filter, err = parseListFilter(r, listStatusActive, listStatusInactive); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	dataNodes := m.cluster.listDataNodes(filter, page)
 	sendOkReply(w, r, newSuccessHTTPReply(dataNodes))
 }
 
 func (m *Server) getAllMetaNodes(w http.ResponseWriter, r *http.Request) {
+	var (
+		page   listPage
+		filter listFilter
+		err    error
+	)
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminGetClusterMetaNodes))
 	defer func() {
-		doStatAndMetric(proto.AdminGetClusterMetaNodes, metric, nil, nil)
+		doStatAndMetric(proto.AdminGetClusterMetaNodes, metric, err, nil)
 	}()
-	metaNodes := m.cluster.allMetaNodes()
+	if page, err = parseListPage(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if filter, err = parseListFilter(r, listStatusActive, listStatusInactive); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	metaNodes := m.cluster.listMetaNodes(filter, page)
 	sendOkReply(w, r, newSuccessHTTPReply(metaNodes))
 }
 
@@ -8561,6 +9732,7 @@ func (m *Server) recoverBackupDataReplica(w http.ResponseWriter, r *http.Request
 	if dp.ReplicaNum == uint8(len(dp.Replicas)) {
 		err = fmt.Errorf("action[recoverBackupDataReplica] [%d] already have %v replicas", dp.PartitionID, dp.ReplicaNum)
 		sendErrReply(w, r, newErrHTTPReply(err))
+		return
 	}
 
 	retry := 0
@@ -9177,7 +10349,7 @@ func deduplicateAndRemoveContained(pathStr string) string {
 }
 
 func AuditLog(r *http.Request, op, msg string, err error) {
-	head := fmt.Sprintf("%s %s", r.RemoteAddr, op)
+	head := fmt.Sprintf("%s %s requestID[%s]", r.RemoteAddr, op, getRequestID(r))
 	auditlog.LogMasterOp(head, msg, err)
 }
 
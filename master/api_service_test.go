@@ -23,6 +23,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/pprof"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -388,6 +389,28 @@ func TestGetClusterMetaNodes(t *testing.T) {
 	process(reqURL, t)
 }
 
+func TestRequestID(t *testing.T) {
+	get := func(reqID string) string {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v%v", hostAddr, proto.AdminGetIP), nil)
+		require.NoError(t, err)
+		if reqID != "" {
+			req.Header.Set(proto.HeaderRequestID, reqID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Len(t, resp.Header.Values(proto.HeaderRequestID), 1)
+		return resp.Header.Get(proto.HeaderRequestID)
+	}
+	require.Equal(t, "trace-1", get("trace-1"))
+	generated := get("")
+	require.True(t, proto.ValidRequestID(generated))
+	require.NotEqual(t, generated, get(""))
+	invalid := get("bad id")
+	require.NotEqual(t, "bad id", invalid)
+	require.True(t, proto.ValidRequestID(invalid))
+}
+
 func TestGetIpAndClusterName(t *testing.T) {
 	reqURL := fmt.Sprintf("%v%v", hostAddr, proto.AdminGetIP)
 	process(reqURL, t)
@@ -395,6 +418,619 @@ func TestGetIpAndClusterName(t *testing.T) {
 
 type httpReply = proto.HTTPReplyRaw
 
+func TestPublishVolume(t *testing.T) {
+	name := "publishVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+
+	reqUrl := fmt.Sprintf("%v%v?name=%v", hostAddr, proto.AdminVolPublish, name)
+	reply := processNoCheck(fmt.Sprintf("%v&authKey=%v", reqUrl, buildAuthKey("other")), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	require.False(t, vol.Published)
+
+	process(fmt.Sprintf("%v&authKey=%v", reqUrl, buildAuthKey(testOwner)), t)
+	require.True(t, vol.Published)
+	require.True(t, vol.Forbidden)
+	require.NotZero(t, vol.PublishTime)
+	for _, dp := range vol.cloneDataPartitionMap() {
+		require.NotEqual(t, proto.ReadWrite, dp.Status)
+	}
+
+	// publish is idempotent, but a published volume can not be unfrozen
+	process(fmt.Sprintf("%v&authKey=%v", reqUrl, buildAuthKey(testOwner)), t)
+	unforbidUrl := fmt.Sprintf("%v%v?name=%v&%v=false", hostAddr, proto.AdminVolForbidden, name, forbiddenKey)
+	reply = processNoCheck(unforbidUrl, t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	require.True(t, vol.Forbidden)
+
+	view := getSimpleVol(name, true, t)
+	require.True(t, view.Published)
+}
+
+func TestSetVolInterop(t *testing.T) {
+	name := "interopVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+	view := getSimpleVol(name, true, t)
+	require.Equal(t, proto.DefaultInteropPolicy(), view.Interop)
+
+	reqUrl := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminVolSetInterop, name, buildAuthKey(testOwner))
+	process(fmt.Sprintf("%v&%v=%v", reqUrl, interopKeyMappingKey, proto.ObjKeyMappingStrict), t)
+	process(fmt.Sprintf("%v&%v=%v", reqUrl, interopRenameVisibilityKey, proto.InteropSyncImmediate), t)
+	reply := processNoCheck(fmt.Sprintf("%v&%v=eventual", reqUrl, interopMetaSyncKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+
+	view = getSimpleVol(name, true, t)
+	require.Equal(t, proto.ObjKeyMappingStrict, view.Interop.KeyMapping)
+	require.Equal(t, proto.InteropSyncCached, view.Interop.MetaSync)
+	require.Equal(t, proto.InteropSyncImmediate, view.Interop.RenameVisibility)
+}
+
+func TestSetVolPlacementExclusion(t *testing.T) {
+	name := "placementVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+	view := getSimpleVol(name, true, t)
+	require.True(t, view.PlacementExclusion.IsEmpty())
+
+	reqUrl := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminVolSetPlacementExclusion, name, buildAuthKey(testOwner))
+	reply := processNoCheck(fmt.Sprintf("%v&%v=host-a", reqUrl, placementHostsKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v&%v=1,x", reqUrl, placementNodeSetsKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(fmt.Sprintf("%v&%v=%v&%v=%v", reqUrl, placementHostsKey, mds1Addr, placementZonesKey, testZone3), t)
+
+	view = getSimpleVol(name, true, t)
+	require.Equal(t, []string{mds1Addr}, view.PlacementExclusion.Hosts)
+	require.Equal(t, []string{testZone3}, view.PlacementExclusion.Zones)
+
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+	require.Contains(t, server.cluster.placementExcludedHosts(vol, TypeDataPartition), mds1Addr)
+	require.Error(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds2Addr, mds1Addr}))
+	require.NoError(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds2Addr, mds3Addr}))
+
+	process(reqUrl, t)
+	view = getSimpleVol(name, true, t)
+	require.True(t, view.PlacementExclusion.IsEmpty())
+}
+
+func TestSetVolPlacementPolicy(t *testing.T) {
+	name := "placementPolicyVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+	view := getSimpleVol(name, true, t)
+	require.True(t, view.PlacementPolicy.IsEmpty())
+
+	reqUrl := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminVolSetPlacementPolicy, name, buildAuthKey(testOwner))
+	reply := processNoCheck(fmt.Sprintf("%v&%v=zone-none", reqUrl, placementRequiredKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v&%v=%v&%v=2", reqUrl, placementRequiredKey, testZone2, placementSpreadKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v&%v=x", reqUrl, placementSpreadKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(fmt.Sprintf("%v&%v=%v&%v=1", reqUrl, placementRequiredKey, testZone2, placementSpreadKey), t)
+
+	view = getSimpleVol(name, true, t)
+	require.Equal(t, []string{testZone2}, view.PlacementPolicy.RequiredZones)
+	require.Equal(t, 1, view.PlacementPolicy.SpreadZones)
+
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+	require.Contains(t, server.cluster.placementExcludedHosts(vol, TypeDataPartition), mds1Addr)
+	require.NotContains(t, server.cluster.placementExcludedHosts(vol, TypeDataPartition), mds3Addr)
+	require.Contains(t, server.cluster.placementExcludedHosts(vol, TypeMetaPartition), mms1Addr)
+	require.Error(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds1Addr, mds3Addr}))
+	require.NoError(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds3Addr, mds4Addr}))
+	zoneName, zoneNum := server.cluster.placementZones(vol, defaultMediaType)
+	require.Equal(t, testZone2, zoneName)
+	require.Equal(t, 1, zoneNum)
+	dp, err := server.cluster.createDataPartition(name, defaultMediaType)
+	require.NoError(t, err)
+	for _, host := range dp.Hosts {
+		dataNode, err := server.cluster.dataNode(host)
+		require.NoError(t, err)
+		require.Equal(t, testZone2, dataNode.ZoneName)
+	}
+
+	// a required zone can not be excluded, neither the other way round
+	exclusionUrl := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminVolSetPlacementExclusion, name, buildAuthKey(testOwner))
+	reply = processNoCheck(fmt.Sprintf("%v&%v=%v", exclusionUrl, placementZonesKey, testZone2), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(fmt.Sprintf("%v&%v=%v", exclusionUrl, placementZonesKey, testZone1), t)
+	reply = processNoCheck(fmt.Sprintf("%v&%v=%v,%v", reqUrl, placementRequiredKey, testZone1, testZone2), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(exclusionUrl, t)
+
+	process(fmt.Sprintf("%v&%v=%v,%v&%v=2", reqUrl, placementRequiredKey, testZone1, testZone2, placementSpreadKey), t)
+	require.Error(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds3Addr, mds4Addr}))
+	require.NoError(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds1Addr, mds3Addr}))
+	// a single replica being added or moved is not checked for the spread
+	require.NoError(t, server.cluster.checkPlacementExclusion(vol, TypeDataPartition, []string{mds3Addr}))
+
+	process(reqUrl, t)
+	view = getSimpleVol(name, true, t)
+	require.True(t, view.PlacementPolicy.IsEmpty())
+}
+
+func TestMountProfile(t *testing.T) {
+	name := "profile1"
+	reqURL := fmt.Sprintf("%v%v?name=%v", hostAddr, proto.AdminMountProfileSet, name)
+	reply := processNoCheck(fmt.Sprintf("%v&%v=volName=vol1", reqURL, mountProfileOptionsKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v&%v=readRate", reqURL, mountProfileOptionsKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(fmt.Sprintf("%v&%v=readRate=100,followerRead=true", reqURL, mountProfileOptionsKey), t)
+
+	profile, err := server.cluster.getMountProfile(name)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"readRate": "100", "followerRead": "true"}, profile.Options)
+	process(fmt.Sprintf("%v%v?name=%v", hostAddr, proto.AdminMountProfileGet, name), t)
+	process(fmt.Sprintf("%v%v", hostAddr, proto.AdminMountProfileList), t)
+
+	require.NoError(t, server.cluster.loadMountProfiles())
+	require.Len(t, server.cluster.mountProfiles.list(), 1)
+
+	process(fmt.Sprintf("%v&%v=writeRate=50", reqURL, mountProfileOptionsKey), t)
+	profile, err = server.cluster.getMountProfile(name)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"writeRate": "50"}, profile.Options)
+
+	process(fmt.Sprintf("%v%v?name=%v", hostAddr, proto.AdminMountProfileDelete, name), t)
+	_, err = server.cluster.getMountProfile(name)
+	require.Error(t, err)
+	require.NoError(t, server.cluster.loadMountProfiles())
+	require.Len(t, server.cluster.mountProfiles.list(), 0)
+}
+
+func TestClientEviction(t *testing.T) {
+	evictURL := fmt.Sprintf("%v%v", hostAddr, proto.AdminClientEvict)
+	reply := processNoCheck(evictURL, t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	// the nodes of the cluster are never evicted
+	reply = processNoCheck(fmt.Sprintf("%v?ip=127.0.0.1", evictURL), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v?ip=192.168.10.1&name=noSuchVol", evictURL), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(fmt.Sprintf("%v?ip=192.168.10.1&reason=runaway", evictURL), t)
+	process(fmt.Sprintf("%v?ip=192.168.10.2&expire=1", evictURL), t)
+
+	// a mount is evicted from its volume by the id it reports
+	process(fmt.Sprintf("%v%v?name=%v&version=%v&%v=mount0", hostAddr, proto.ClientVolStat, commonVolName, proto.LFClient, proto.MountIDKey), t)
+	_, ok := server.cliMgr.GetMount("mount0")
+	require.True(t, ok)
+	reply = processNoCheck(fmt.Sprintf("%v?%v=mount1", evictURL, proto.MountIDKey), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	server.cliMgr.PutMount("mount1", "192.168.10.3", commonVolName)
+	process(fmt.Sprintf("%v?%v=mount1", evictURL, proto.MountIDKey), t)
+
+	evictions := server.cluster.clientEvictions.list()
+	require.Len(t, evictions, 3)
+	require.Equal(t, "192.168.10.3/"+commonVolName, evictions[2].Key())
+	require.Equal(t, "mount1", evictions[2].MountID)
+	fence := proto.NewClientFence(server.cluster.clientEvictions.active(time.Now().Unix()+2), time.Now().Unix()+2)
+	require.True(t, fence.Evicted("192.168.10.1:17510", "anyVol"))
+	require.False(t, fence.Evicted("192.168.10.2:17510", "anyVol"))
+	require.True(t, fence.Evicted("192.168.10.3:17510", commonVolName))
+	require.False(t, fence.Evicted("192.168.10.3:17510", "anyVol"))
+	process(fmt.Sprintf("%v%v", hostAddr, proto.AdminClientEvictList), t)
+
+	require.NoError(t, server.cluster.loadClientEvictions())
+	require.Len(t, server.cluster.clientEvictions.list(), 3)
+
+	removeURL := fmt.Sprintf("%v%v", hostAddr, proto.AdminClientEvictRemove)
+	process(fmt.Sprintf("%v?%v=mount1", removeURL, proto.MountIDKey), t)
+	process(fmt.Sprintf("%v?ip=192.168.10.1", removeURL), t)
+	reply = processNoCheck(fmt.Sprintf("%v?ip=192.168.10.1", removeURL), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	time.Sleep(time.Second)
+	server.cluster.cleanExpiredClientEvictions()
+	require.Empty(t, server.cluster.clientEvictions.list())
+	require.NoError(t, server.cluster.loadClientEvictions())
+	require.Empty(t, server.cluster.clientEvictions.list())
+}
+
+func TestVolSLO(t *testing.T) {
+	name := "sloVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+
+	reqUrl := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminVolSetSLO, name, buildAuthKey(testOwner))
+	process(fmt.Sprintf("%v&op=%v&latencyMs=10&objective=0.9", reqUrl, proto.SLOOpRead), t)
+	process(fmt.Sprintf("%v&op=%v&latencyMs=100&objective=0.99", reqUrl, proto.SLOOpMeta), t)
+	reply := processNoCheck(fmt.Sprintf("%v&op=%v&latencyMs=10&objective=1.5", reqUrl, proto.SLOOpWrite), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v&op=list&latencyMs=10&objective=0.9", reqUrl), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	process(fmt.Sprintf("%v&op=%v&objective=0", reqUrl, proto.SLOOpMeta), t)
+
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+	require.Len(t, vol.getSLO(), 1)
+	require.Equal(t, proto.SLOTarget{LatencyMs: 10, Objective: 0.9}, *vol.getSLO()[proto.SLOOpRead])
+
+	h := proto.NewLatencyHistogram()
+	for i := 0; i < 8; i++ {
+		h.Observe(time.Millisecond, false)
+	}
+	h.Observe(time.Second, false)
+	h.Observe(time.Millisecond, true)
+	reports := []*proto.SLOReport{
+		{Volume: name, Source: "test", Ops: map[string]*proto.LatencyHistogram{proto.SLOOpRead: h}},
+		{Volume: "noSuchVol", Source: "test", Ops: map[string]*proto.LatencyHistogram{proto.SLOOpRead: h}},
+	}
+	data, err := json.Marshal(reports)
+	require.NoError(t, err)
+	resp, err := http.Post(fmt.Sprintf("%v%v", hostAddr, proto.AdminSLOReport), "application/json", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	reply = process(fmt.Sprintf("%v%v?name=%v", hostAddr, proto.AdminVolSLO, name), t)
+	data, err = json.Marshal(reply.Data)
+	require.NoError(t, err)
+	status := &proto.VolumeSLOStatus{}
+	require.NoError(t, json.Unmarshal(data, status))
+	require.Len(t, status.Ops, 1)
+	readStatus := status.Ops[proto.SLOOpRead]
+	require.EqualValues(t, 10, readStatus.Total)
+	require.EqualValues(t, 8, readStatus.Good)
+	require.EqualValues(t, 1, readStatus.Errors)
+	require.True(t, readStatus.Violated)
+	require.InDelta(t, -1, readStatus.ErrorBudgetRemaining, 1e-9)
+}
+
+func TestVolFence(t *testing.T) {
+	name := "fenceVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+
+	authKey := buildAuthKey(testOwner)
+	for i, path := range []string{"", "/db/", "/"} {
+		fence, err := mc.AdminAPI().AcquireVolumeFence(name, authKey, path)
+		require.NoError(t, err)
+		require.Equal(t, name, fence.Volume)
+		require.EqualValues(t, i+1, fence.Token)
+	}
+	_, err := mc.AdminAPI().AcquireVolumeFence(name, "wrongKey", "")
+	require.Error(t, err)
+	_, err = mc.AdminAPI().AcquireVolumeFence(name, authKey, "db")
+	require.Error(t, err)
+	_, err = mc.AdminAPI().AcquireVolumeFence("noSuchVol", authKey, "")
+	require.Error(t, err)
+
+	fences, err := mc.AdminAPI().ListVolumeFences(name)
+	require.NoError(t, err)
+	require.Len(t, fences, 2)
+	require.Equal(t, "/", fences[0].Path)
+	require.EqualValues(t, 3, fences[0].Token)
+	require.Equal(t, "/db", fences[1].Path)
+	require.EqualValues(t, 2, fences[1].Token)
+
+	// the fences are persisted with the volume and sent by the heartbeats
+	err, views := server.cluster.loadVolsViews()
+	require.NoError(t, err)
+	for _, vv := range views {
+		if vv.Name == name {
+			require.Equal(t, fences, newVol(*vv).getFences())
+		}
+	}
+	tokens := proto.NewFenceTokens(server.cluster.allVolFences())
+	require.True(t, tokens.Stale(name, 1))
+	require.False(t, tokens.Stale(name, 3))
+}
+
+func TestVolTimeline(t *testing.T) {
+	name := "timelineVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+	capacity := vol.Capacity
+	process(fmt.Sprintf("%v%v?name=%v&authKey=%v&capacity=%v", hostAddr, proto.AdminVolExpand, name, buildAuthKey(testOwner), capacity+100), t)
+	process(fmt.Sprintf("%v%v?name=%v&%v=true", hostAddr, proto.AdminVolForbidden, name, forbiddenKey), t)
+	process(fmt.Sprintf("%v%v?name=%v&%v=false", hostAddr, proto.AdminVolForbidden, name, forbiddenKey), t)
+	process(fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner)), t)
+
+	events, err := mc.AdminAPI().GetVolumeTimeline(name, "", 0, 0)
+	require.NoError(t, err)
+	kinds := make(map[string]int)
+	for i, e := range events {
+		require.Equal(t, name, e.Volume)
+		if i > 0 {
+			require.Greater(t, e.ID, events[i-1].ID)
+		}
+		kinds[e.Kind]++
+	}
+	require.Equal(t, proto.VolEventCreate, events[0].Kind)
+	require.Equal(t, proto.VolEventDelete, events[len(events)-1].Kind)
+	require.Equal(t, len(vol.MetaPartitions), kinds[proto.VolEventAddMetaPartition])
+	require.Equal(t, 2, kinds[proto.VolEventForbidden])
+	require.Equal(t, 1, kinds[proto.VolEventCapacity])
+
+	capacityEvents, err := mc.AdminAPI().GetVolumeTimeline(name, proto.VolEventCapacity, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, capacityEvents, 1)
+	require.Equal(t, fmt.Sprintf("capacity %vGB -> %vGB", capacity, capacity+100), capacityEvents[0].Detail)
+	none, err := mc.AdminAPI().GetVolumeTimeline(name, "", events[len(events)-1].Time+1, 0)
+	require.NoError(t, err)
+	require.Empty(t, none)
+	_, err = mc.AdminAPI().GetVolumeTimeline("noSuchVol", "", 0, 0)
+	require.Error(t, err)
+
+	// the timeline is persisted by raft, and ages out
+	require.NoError(t, server.cluster.loadVolTimeline())
+	loaded, err := mc.AdminAPI().GetVolumeTimeline(name, "", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, events, loaded)
+	require.Empty(t, server.cluster.volTimeline.expired(time.Now()))
+	require.Len(t, server.cluster.volTimeline.expired(time.Now().Add(volTimelineKeepTime+time.Hour)), server.cluster.volTimeline.count())
+}
+
+func TestVolTimelineKeep(t *testing.T) {
+	s := newVolTimelineStore()
+	for i := 0; i < volTimelineKeepPerVol+2; i++ {
+		s.put(s.newEvent("vol", proto.VolEventAddDataPartition, fmt.Sprintf("dp[%v]", i)))
+	}
+	s.put(s.newEvent("other", proto.VolEventCreate, ""))
+	expired := s.expired(time.Now())
+	require.Len(t, expired, 2)
+	require.Equal(t, "dp[0]", expired[0].Detail)
+	require.Equal(t, "dp[1]", expired[1].Detail)
+	for _, e := range expired {
+		s.delete(e)
+	}
+	require.Empty(t, s.expired(time.Now()))
+	require.Equal(t, "dp[2]", s.list("vol", "", 0, 0)[0].Detail)
+}
+
+func TestS3Gateways(t *testing.T) {
+	server.cluster.s3Gateways.reset()
+	defer server.cluster.s3Gateways.reset()
+	reply := processNoCheck(fmt.Sprintf("%v%v?addr=noPort", hostAddr, proto.S3NodeHeartbeat), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v%v?name=noSuchVol", hostAddr, proto.S3BucketChanged), t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+
+	heartbeat := func(addr string) *proto.S3NodeHeartbeatResponse {
+		reply := process(fmt.Sprintf("%v%v?addr=%v", hostAddr, proto.S3NodeHeartbeat, addr), t)
+		data, err := json.Marshal(reply.Data)
+		require.NoError(t, err)
+		resp := &proto.S3NodeHeartbeatResponse{}
+		require.NoError(t, json.Unmarshal(data, resp))
+		return resp
+	}
+	heartbeat("192.168.0.2:17410")
+	resp := heartbeat("192.168.0.1:17410")
+	require.Equal(t, []string{"192.168.0.1:17410", "192.168.0.2:17410"}, resp.Nodes)
+	require.Empty(t, resp.BucketVersions)
+
+	process(fmt.Sprintf("%v%v?name=%v", hostAddr, proto.S3BucketChanged, commonVolName), t)
+	resp = heartbeat("192.168.0.1:17410")
+	require.Equal(t, map[string]uint64{commonVolName: 1}, resp.BucketVersions)
+
+	reply = process(fmt.Sprintf("%v%v?bucket=%v", hostAddr, proto.S3NodeList, commonVolName), t)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	view := &proto.S3NodesView{}
+	require.NoError(t, json.Unmarshal(data, view))
+	require.Equal(t, resp.Nodes, view.Nodes)
+	require.Equal(t, proto.S3BucketOwner(resp.Nodes, commonVolName), view.Owner)
+
+	server.cluster.s3Gateways.nodes["192.168.0.2:17410"] = time.Now().Add(-2 * s3NodeHeartbeatTimeout)
+	require.Equal(t, []string{"192.168.0.1:17410"}, server.cluster.s3Gateways.liveNodes(time.Now()))
+	epoch := resp.Epoch
+	server.cluster.s3Gateways.reset()
+	resp = heartbeat("192.168.0.1:17410")
+	require.NotEqual(t, epoch, resp.Epoch)
+	require.Empty(t, resp.BucketVersions)
+}
+
+func TestAuditCampaign(t *testing.T) {
+	name := "auditVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+
+	reqUrl := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminAuditCampaignCreate, name, buildAuthKey(testOwner))
+	reply := processNoCheck(reqUrl+"&concurrency=0", t)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, reply.Code)
+	reply = process(reqUrl+"&budget=10&concurrency=8", t)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	view := &proto.AuditCampaignView{}
+	require.NoError(t, json.Unmarshal(data, view))
+	require.Equal(t, name, view.Volume)
+	require.Equal(t, len(vol.dataPartitions.clonePartitions())+len(vol.cloneMetaPartitionMap()), view.Total)
+
+	for i := 0; i < 150; i++ {
+		reply = process(fmt.Sprintf("%v%v?id=%v", hostAddr, proto.AdminAuditCampaignGet, view.ID), t)
+		data, err = json.Marshal(reply.Data)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, view))
+		if view.Status != proto.AuditCampaignRunning {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	require.Equal(t, proto.AuditCampaignCompleted, view.Status)
+	require.Equal(t, view.Total, view.Done)
+	require.Len(t, view.Partitions, view.Total)
+	require.Zero(t, view.Failed)
+
+	reply = process(fmt.Sprintf("%v%v?id=%v", hostAddr, proto.AdminAuditCampaignReport, view.ID), t)
+	data, err = json.Marshal(reply.Data)
+	require.NoError(t, err)
+	report := &proto.AuditReport{}
+	require.NoError(t, json.Unmarshal(data, report))
+	require.Equal(t, view.ID, report.CampaignID)
+	require.Equal(t, view.Total, report.DataPartitions+report.MetaPartitions)
+	require.True(t, report.Verify(nil))
+	report.Passed++
+	require.False(t, report.Verify(nil))
+}
+
+func TestClusterFeatures(t *testing.T) {
+	reply := process(fmt.Sprintf("%v%v", hostAddr, proto.AdminClusterFeatures), t)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	matrix := &proto.ClusterFeatureMatrix{}
+	require.NoError(t, json.Unmarshal(data, matrix))
+	require.Len(t, matrix.Features, len(proto.KnownFeatures))
+
+	metaNodes := 0
+	server.cluster.metaNodes.Range(func(_, _ interface{}) bool {
+		metaNodes++
+		return true
+	})
+	for _, feature := range matrix.Features {
+		for _, role := range feature.Roles {
+			if role.Role == proto.FeatureRoleMetaNode {
+				require.Equal(t, metaNodes, role.Total)
+			}
+		}
+		require.Equal(t, matrix.Enabled.Has(feature.Bit), feature.Enabled)
+	}
+}
+
+func TestClusterSummary(t *testing.T) {
+	reply := process(fmt.Sprintf("%v%v", hostAddr, proto.AdminSummary), t)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	summary := &proto.ClusterSummary{}
+	require.NoError(t, json.Unmarshal(data, summary))
+	require.Equal(t, proto.ClusterSummaryVersion, summary.Version)
+	require.Equal(t, server.cluster.Name, summary.Cluster)
+
+	names := make([]string, 0)
+	for _, s := range summary.Subsystems {
+		names = append(names, s.Name)
+		if s.Status == proto.SummaryStatusUnknown {
+			require.Equal(t, -1, s.Score)
+			continue
+		}
+		require.LessOrEqual(t, summary.Score, s.Score)
+	}
+	require.Equal(t, []string{
+		proto.SummaryMasters, proto.SummaryMetaNodes, proto.SummaryDataNodes,
+		proto.SummaryFlashNodes, proto.SummaryObjectNodes, proto.SummaryBlobStore,
+	}, names)
+	require.Equal(t, proto.SummaryStatusUnknown, summary.Subsystems[4].Status)
+	require.Greater(t, summary.Subsystems[2].Total, 0)
+}
+
+func TestPlacementSimulation(t *testing.T) {
+	simulate := func(req *proto.PlacementSimulationRequest) *proto.HTTPReply {
+		data, err := json.Marshal(req)
+		require.NoError(t, err)
+		resp, err := http.Post(fmt.Sprintf("%v%v", hostAddr, proto.AdminPlacementSimulate), "application/json", bytes.NewBuffer(data))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		reply := &proto.HTTPReply{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(reply))
+		return reply
+	}
+	require.EqualValues(t, proto.ErrCodeParamError, simulate(&proto.PlacementSimulationRequest{}).Code)
+	require.NotEqualValues(t, proto.ErrCodeSuccess, simulate(&proto.PlacementSimulationRequest{RemoveNodes: []string{"127.0.0.1:1"}}).Code)
+
+	replicaNum := commonVol.dpReplicaNum
+	dropped := 0
+	for _, dp := range commonVol.dataPartitions.clonePartitions() {
+		if len(dp.Hosts) > 1 {
+			dropped += len(dp.Hosts) - 1
+		}
+	}
+	reply := simulate(&proto.PlacementSimulationRequest{
+		AddNodes:    []*proto.SimulatedNodes{{ZoneName: testZone1, Count: 2}},
+		ReplicaNums: []*proto.SimulatedReplicaNum{{Volume: commonVolName, ReplicaNum: 1}},
+	})
+	require.EqualValues(t, proto.ErrCodeSuccess, reply.Code, reply.Msg)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	result := &proto.PlacementSimulation{}
+	require.NoError(t, json.Unmarshal(data, result))
+	require.Equal(t, dropped, result.DroppedReplicas)
+	require.Zero(t, result.MigratedReplicas)
+	for _, zone := range result.Zones {
+		if zone.ZoneName == testZone1 {
+			require.Equal(t, zone.Before.Nodes+2, zone.After.Nodes)
+		}
+	}
+	require.Equal(t, replicaNum, commonVol.dpReplicaNum)
+}
+
+func TestPlacementSimulator(t *testing.T) {
+	s := &placementSimulator{
+		nodes: map[string]*simNode{
+			"a": {addr: "a", zoneName: testZone1, total: 100, used: 50, writable: true, removed: true},
+			"b": {addr: "b", zoneName: testZone1, total: 100, used: 20, writable: true},
+			"c": {addr: "c", zoneName: testZone1, total: 100, used: 10, writable: true},
+			"d": {addr: "d", zoneName: testZone2, total: 100, writable: true},
+		},
+		zones:  make(map[string]*proto.ZonePlacementSimulation),
+		result: &proto.PlacementSimulation{},
+	}
+	s.placePartition(1, []string{"a", "b"}, 2, 30, []string{testZone1})
+	require.EqualValues(t, 40, s.nodes["c"].used)
+	require.Equal(t, 1, s.result.MigratedReplicas)
+	require.EqualValues(t, 30, s.result.MigratedBytes)
+
+	// no node of zone1 has room for a third replica
+	s.placePartition(2, []string{"b", "c"}, 3, 60, []string{testZone1})
+	require.Equal(t, []uint64{2}, s.result.UnplaceablePartitions)
+	s.placePartition(3, []string{"b", "c"}, 3, 60, nil)
+	require.EqualValues(t, 60, s.nodes["d"].used)
+	require.Equal(t, 1, s.result.AddedReplicas)
+	require.Equal(t, 1, s.zones[testZone2].InReplicas)
+
+	s.placePartition(4, []string{"b", "c", "d"}, 2, 5, nil)
+	require.Equal(t, 1, s.result.DroppedReplicas)
+	require.EqualValues(t, 55, s.nodes["d"].used)
+}
+
+func TestZoneCost(t *testing.T) {
+	processWithFatalV2(proto.AdminSetZoneCost, false, map[string]interface{}{"from": testZone1, "to": testZone1, "cost": 1}, t)
+	processWithFatalV2(proto.AdminSetZoneCost, false, map[string]interface{}{"from": testZone1, "to": testZone2, "cost": -1}, t)
+	processWithFatalV2(proto.AdminSetZoneCost, true, map[string]interface{}{"from": testZone2, "to": testZone1, "cost": 2}, t)
+	require.Equal(t, []proto.ZoneCost{{From: testZone1, To: testZone2, Cost: 2}}, server.cluster.getZoneCosts())
+
+	reply := processWithFatalV2(proto.ClientZoneCost, true, map[string]interface{}{"zoneName": testZone1}, t)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	view := &proto.ZoneCostView{}
+	require.NoError(t, json.Unmarshal(data, view))
+	require.Equal(t, map[string]float64{testZone2: 2}, view.Costs)
+	require.Equal(t, testZone2, view.HostZones[mds3Addr])
+	require.Equal(t, 0.0, view.HostCost(mds1Addr))
+	require.Equal(t, 2.0, view.HostCost(mds3Addr))
+
+	processWithFatalV2(proto.AdminSetZoneCost, true, map[string]interface{}{"from": testZone1, "to": testZone2, "cost": 0}, t)
+	require.Empty(t, server.cluster.getZoneCosts())
+}
+
 func processWithFatalV2(url string, success bool, req map[string]interface{}, t *testing.T) (reply *httpReply) {
 	reqURL := buildUrl(hostAddr, url, req)
 
@@ -707,6 +1343,14 @@ func TestUpdateVol(t *testing.T) {
 	checkParam("remoteCacheAutoPrepare", proto.AdminUpdateVol, req, "not-bool", false, t)
 	checkParam("remoteCacheTTL", proto.AdminUpdateVol, req, "not-number", int64(77), t)
 	checkParam("remoteCacheReadTimeout", proto.AdminUpdateVol, req, "not-number", int64(7), t)
+	checkParam("remoteCacheAlwaysAdmit", proto.AdminUpdateVol, req, "not-bool", true, t)
+	checkParam("remoteCacheAuth", proto.AdminUpdateVol, req, "not-bool", true, t)
+	checkParam("remoteCacheReadAheadMB", proto.AdminUpdateVol, req, "257", int64(8), t)
+	checkParam("remoteCacheReadLimitMBps", proto.AdminUpdateVol, req, "-1", int64(100), t)
+	checkParam("remoteCacheReadLimitIops", proto.AdminUpdateVol, req, "not-number", int64(1000), t)
+	checkParam("remoteCacheMetaTTL", proto.AdminUpdateVol, req, "3601", int64(30), t)
+	checkParam("remoteCacheHedgeDelay", proto.AdminUpdateVol, req, "-1", int64(15), t)
+	checkParam("remoteCacheFallbackTimeout", proto.AdminUpdateVol, req, "not-number", int64(40), t)
 	setParam("remoteCachePath", proto.AdminUpdateVol, req, "cache-path,a-path", t)
 
 	view = getSimpleVol(volName, true, t)
@@ -723,6 +1367,14 @@ func TestUpdateVol(t *testing.T) {
 	require.False(t, view.RemoteCacheAutoPrepare)
 	require.Equal(t, int64(77), view.RemoteCacheTTL)
 	require.Equal(t, int64(7), view.RemoteCacheReadTimeout)
+	require.True(t, view.RemoteCacheAlwaysAdmit)
+	require.True(t, view.RemoteCacheAuth)
+	require.Equal(t, int64(8), view.RemoteCacheReadAheadMB)
+	require.Equal(t, int64(100), view.RemoteCacheReadLimitMBps)
+	require.Equal(t, int64(1000), view.RemoteCacheReadLimitIops)
+	require.Equal(t, int64(30), view.RemoteCacheMetaTTL)
+	require.Equal(t, int64(15), view.RemoteCacheHedgeDelay)
+	require.Equal(t, int64(40), view.RemoteCacheFallbackTimeout)
 
 	for id, name := range []string{"z1", "z2", "z3"} {
 		zone := newZone(name, defaultMediaType)
@@ -747,6 +1399,67 @@ func TestUpdateVol(t *testing.T) {
 	assert.True(t, view.ZoneName == "z1,z2,z3")
 	assert.True(t, view.CrossZone == true)
 
+	req[proto.VolSyncMirrorWrite] = true
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.True(t, view.SyncMirrorWrite)
+	zonesVersion, zones := server.cluster.syncMirrorDataNodeZones()
+	require.NotEmpty(t, zones)
+	version, _ := server.cluster.syncMirrorDataNodeZones()
+	require.Equal(t, zonesVersion, version, "the zones are the same ones")
+
+	// sync mirror write needs the volume to stay cross zone
+	req[zoneNameKey] = "z1"
+	req[crossZoneKey] = false
+	processWithFatalV2(proto.AdminUpdateVol, false, req, t)
+	req[proto.VolSyncMirrorWrite] = false
+
+	req[proto.VolRequireTLS] = true
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.True(t, view.RequireTLS)
+	req[proto.VolRequireTLS] = false
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.False(t, view.RequireTLS)
+
+	req[proto.VolInlineDataThreshold] = 2 * util.KB
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.Equal(t, int64(2*util.KB), view.InlineDataThreshold)
+	req[proto.VolInlineDataThreshold] = proto.MaxInlineDataThreshold + 1
+	processWithFatalV2(proto.AdminUpdateVol, false, req, t)
+	req[proto.VolInlineDataThreshold] = 0
+
+	req[proto.VolSmallFileThreshold] = 128 * util.KB
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.Equal(t, int64(128*util.KB), view.SmallFileThreshold)
+	req[proto.VolSmallFileThreshold] = proto.MaxSmallFileThreshold + 1
+	processWithFatalV2(proto.AdminUpdateVol, false, req, t)
+	req[proto.VolSmallFileThreshold] = 0
+
+	req[proto.VolConsistencyMode] = proto.ConsistencyModeStrict
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.Equal(t, proto.ConsistencyModeStrict, view.ConsistencyMode)
+	req[proto.VolConsistencyMode] = "eventual"
+	processWithFatalV2(proto.AdminUpdateVol, false, req, t)
+	req[proto.VolConsistencyMode] = proto.ConsistencyModeRelaxed
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.Equal(t, proto.ConsistencyModeRelaxed, view.ConsistencyMode)
+	delete(req, proto.VolConsistencyMode)
+
+	req[proto.VolMetaWitnessNum] = 1
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+	view = getSimpleVol(volName, true, t)
+	assert.Equal(t, uint8(1), view.MpWitnessNum)
+	req[proto.VolMetaWitnessNum] = 2
+	processWithFatalV2(proto.AdminUpdateVol, false, req, t)
+	req[proto.VolMetaWitnessNum] = 0
+	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
+
 	req[zoneNameKey] = "z1"
 	req[crossZoneKey] = false
 	processWithFatalV2(proto.AdminUpdateVol, true, req, t)
@@ -1089,6 +1802,64 @@ func TestListVols(t *testing.T) {
 	process(reqURL, t)
 }
 
+func TestListPage(t *testing.T) {
+	for _, c := range []struct {
+		page       listPage
+		n          int
+		begin, end int
+	}{
+		{listPage{}, 5, 0, 5},
+		{listPage{offset: 2}, 5, 2, 5},
+		{listPage{limit: 2}, 5, 0, 2},
+		{listPage{offset: 4, limit: 2}, 5, 4, 5},
+		{listPage{offset: 6, limit: 2}, 5, 5, 5},
+	} {
+		begin, end := c.page.bounds(c.n)
+		require.Equal(t, c.begin, begin, "%+v", c.page)
+		require.Equal(t, c.end, end, "%+v", c.page)
+	}
+}
+
+func TestListWithOption(t *testing.T) {
+	adminAPI := mc.AdminAPI()
+	all, err := adminAPI.GetClusterDataNodesWithOption(master.ListOption{})
+	require.NoError(t, err)
+	require.True(t, len(all) > 2)
+	nodes, err := adminAPI.GetClusterDataNodesWithOption(master.ListOption{Offset: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, all[1:3], nodes)
+	nodes, err = adminAPI.GetClusterDataNodesWithOption(master.ListOption{Zone: testZone1})
+	require.NoError(t, err)
+	require.NotEmpty(t, nodes)
+	for _, node := range nodes {
+		dataNode, err := server.cluster.dataNode(node.Addr)
+		require.NoError(t, err)
+		require.Equal(t, testZone1, dataNode.ZoneName)
+	}
+	_, err = adminAPI.GetClusterMetaNodesWithOption(master.ListOption{Status: "unknown"})
+	require.Error(t, err)
+
+	vols, err := adminAPI.ListVolsWithOption(commonVolName, master.ListOption{Owner: commonVol.Owner, Status: "normal"})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	vols, err = adminAPI.ListVolsWithOption(commonVolName, master.ListOption{Status: "markDelete"})
+	require.NoError(t, err)
+	require.Empty(t, vols)
+	vols, err = adminAPI.ListVolsWithOption("", master.ListOption{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+
+	view, err := mc.ClientAPI().GetDataPartitionsWithOption(commonVolName, master.ListOption{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, view.DataPartitions, 1)
+	minID := view.DataPartitions[0].PartitionID
+	view, err = mc.ClientAPI().GetDataPartitionsWithOption(commonVolName, master.ListOption{Offset: 1})
+	require.NoError(t, err)
+	for _, dp := range view.DataPartitions {
+		require.Greater(t, dp.PartitionID, minID)
+	}
+}
+
 func TestUpdateNodesetNodeSelector(t *testing.T) {
 	zone, err := server.cluster.t.getZone(testZone2)
 	if err != nil {
@@ -1759,6 +2530,23 @@ func TestSetMarkDiskBrokenThreshold(t *testing.T) {
 	require.EqualValues(t, oldVal, server.cluster.getMarkDiskBrokenThreshold())
 }
 
+func TestSetReplicaTombstoneRetention(t *testing.T) {
+	reqUrl := fmt.Sprintf("%v%v", hostAddr, proto.AdminSetNodeInfo)
+	setUrl := fmt.Sprintf("%v?%v=%v&dirSizeLimit=0", reqUrl, nodeReplicaTombstoneRetentionKey, 72*3600)
+	unsetUrl := fmt.Sprintf("%v?%v=%v&dirSizeLimit=0", reqUrl, nodeReplicaTombstoneRetentionKey, 0)
+	process(setUrl, t)
+	require.Equal(t, 72*time.Hour, server.cluster.getReplicaTombstoneRetention())
+	reply := process(fmt.Sprintf("%v%v", hostAddr, proto.AdminGetCluster), t)
+	data, err := json.Marshal(reply.Data)
+	require.NoError(t, err)
+	cv := &proto.ClusterView{}
+	require.NoError(t, json.Unmarshal(data, cv))
+	require.Equal(t, (72 * time.Hour).String(), cv.ReplicaTombstoneRetention)
+
+	process(unsetUrl, t)
+	require.Zero(t, server.cluster.getReplicaTombstoneRetention())
+}
+
 func TestSetEnableAutoDecommissionDisk(t *testing.T) {
 	reqUrl := fmt.Sprintf("%v%v", hostAddr, proto.AdminSetNodeInfo)
 	oldVal := server.cluster.EnableAutoDecommissionDisk.Load()
@@ -2006,3 +2794,21 @@ func TestSetFileStats(t *testing.T) {
 	require.EqualValues(t, enable, server.cluster.fileStatsEnable)
 	require.EqualValues(t, thresholds, server.cluster.fileStatsThresholds)
 }
+
+func TestAdminLcNodeControl(t *testing.T) {
+	reply := processNoCheck(fmt.Sprintf("%v%v?op=pause", hostAddr, proto.AdminLcNode), t)
+	require.NotEqual(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v%v?op=limit&vol=%v&limit=abc", hostAddr, proto.AdminLcNode, commonVolName), t)
+	require.NotEqual(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v%v?op=resume&vol=%v", hostAddr, proto.AdminLcNode, commonVolName), t)
+	require.NotEqual(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v%v?op=rerun&vol=%v", hostAddr, proto.AdminLcNode, commonVolName), t)
+	require.NotEqual(t, proto.ErrCodeSuccess, reply.Code)
+	reply = processNoCheck(fmt.Sprintf("%v%v?op=rerun&vol=%v&ruleid=none", hostAddr, proto.AdminLcNode, commonVolName), t)
+	require.NotEqual(t, proto.ErrCodeSuccess, reply.Code)
+
+	q := url.Values{}
+	q.Set("id", "vol:rule")
+	require.Equal(t, "http://127.0.0.1:17511/pauseScanner?id=vol%3Arule", getLcScannerUrl("127.0.0.1:17510", "/pauseScanner", q))
+	require.Equal(t, "", getLcScannerUrl("127.0.0.1", "/pauseScanner", q))
+}
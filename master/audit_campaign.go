@@ -0,0 +1,441 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	defaultAuditConcurrency = 4
+	maxAuditConcurrency     = 64
+	auditMetaRetryTimes     = 5
+	// finished campaigns kept for querying their reports
+	maxFinishedAuditCampaigns = 100
+)
+
+// auditCampaign verifies the checksums of every partition of a volume across replicas:
+// the extent crcs of data partitions and the inode/dentry tree crcs of meta partitions.
+type auditCampaign struct {
+	sync.RWMutex
+	view    proto.AuditCampaignView
+	results map[string]*proto.AuditPartitionResult
+	report  *proto.AuditReport
+	cancelC chan struct{}
+	once    sync.Once
+}
+
+func auditResultKey(typ string, id uint64) string {
+	return typ + "_" + strconv.FormatUint(id, 10)
+}
+
+func (ac *auditCampaign) setResult(res *proto.AuditPartitionResult) {
+	res.FinishTime = time.Now().Unix()
+	ac.Lock()
+	defer ac.Unlock()
+	ac.results[auditResultKey(res.Type, res.PartitionID)] = res
+	ac.view.Done++
+	switch res.Result {
+	case proto.AuditResultPassed:
+		ac.view.Passed++
+	case proto.AuditResultFailed:
+		ac.view.Failed++
+	default:
+		ac.view.Skipped++
+	}
+}
+
+func (ac *auditCampaign) cancel() {
+	ac.once.Do(func() {
+		close(ac.cancelC)
+	})
+}
+
+func (ac *auditCampaign) isRunning() bool {
+	ac.RLock()
+	defer ac.RUnlock()
+	return ac.view.Status == proto.AuditCampaignRunning
+}
+
+func (ac *auditCampaign) getView(detail bool) *proto.AuditCampaignView {
+	ac.RLock()
+	defer ac.RUnlock()
+	view := ac.view
+	if detail {
+		view.Partitions = ac.sortedResults()
+	}
+	return &view
+}
+
+func (ac *auditCampaign) sortedResults() (results []*proto.AuditPartitionResult) {
+	results = make([]*proto.AuditPartitionResult, 0, len(ac.results))
+	for _, res := range ac.results {
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Type != results[j].Type {
+			return results[i].Type < results[j].Type
+		}
+		return results[i].PartitionID < results[j].PartitionID
+	})
+	return
+}
+
+type auditManager struct {
+	sync.RWMutex
+	c         *Cluster
+	campaigns map[string]*auditCampaign
+}
+
+func newAuditManager(c *Cluster) *auditManager {
+	return &auditManager{c: c, campaigns: make(map[string]*auditCampaign)}
+}
+
+func (am *auditManager) get(id string) (ac *auditCampaign, err error) {
+	am.RLock()
+	defer am.RUnlock()
+	ac, ok := am.campaigns[id]
+	if !ok {
+		err = fmt.Errorf("audit campaign[%v] not found", id)
+	}
+	return
+}
+
+// cancelAll stops the running campaigns, which only run on the leader.
+func (am *auditManager) cancelAll() {
+	am.RLock()
+	defer am.RUnlock()
+	for _, ac := range am.campaigns {
+		ac.cancel()
+	}
+}
+
+func (am *auditManager) list() (views []*proto.AuditCampaignView) {
+	am.RLock()
+	defer am.RUnlock()
+	views = make([]*proto.AuditCampaignView, 0, len(am.campaigns))
+	for _, ac := range am.campaigns {
+		views = append(views, ac.getView(false))
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].CreateTime < views[j].CreateTime })
+	return
+}
+
+// start schedules the verification of every partition of vol within budget, a volume
+// has at most one running campaign.
+func (am *auditManager) start(vol *Vol, budget time.Duration, concurrency int) (ac *auditCampaign, err error) {
+	dps := vol.dataPartitions.clonePartitions()
+	mps := vol.cloneMetaPartitionMap()
+	now := time.Now()
+
+	am.Lock()
+	defer am.Unlock()
+	for _, running := range am.campaigns {
+		if running.view.Volume == vol.Name && running.isRunning() {
+			return nil, fmt.Errorf("audit campaign[%v] of vol[%v] is still running", running.view.ID, vol.Name)
+		}
+	}
+	ac = &auditCampaign{
+		view: proto.AuditCampaignView{
+			ID:          fmt.Sprintf("%v_%v", vol.Name, now.UnixNano()),
+			Volume:      vol.Name,
+			Status:      proto.AuditCampaignRunning,
+			CreateTime:  now.Unix(),
+			Deadline:    now.Add(budget).Unix(),
+			Concurrency: concurrency,
+			Total:       len(dps) + len(mps),
+		},
+		results: make(map[string]*proto.AuditPartitionResult, len(dps)+len(mps)),
+		cancelC: make(chan struct{}),
+	}
+	jobs := make([]func() *proto.AuditPartitionResult, 0, len(dps)+len(mps))
+	for _, dp := range dps {
+		dp := dp
+		ac.results[auditResultKey(proto.AuditPartitionData, dp.PartitionID)] = &proto.AuditPartitionResult{
+			PartitionID: dp.PartitionID, Type: proto.AuditPartitionData, Result: proto.AuditResultPending,
+		}
+		jobs = append(jobs, func() *proto.AuditPartitionResult { return am.auditDataPartition(dp) })
+	}
+	for _, mp := range mps {
+		mp := mp
+		ac.results[auditResultKey(proto.AuditPartitionMeta, mp.PartitionID)] = &proto.AuditPartitionResult{
+			PartitionID: mp.PartitionID, Type: proto.AuditPartitionMeta, Result: proto.AuditResultPending,
+		}
+		jobs = append(jobs, func() *proto.AuditPartitionResult { return am.auditMetaPartition(mp) })
+	}
+	am.campaigns[ac.view.ID] = ac
+	am.evictFinished()
+	go am.run(ac, jobs, now.Add(budget))
+	log.LogInfof("action[auditCampaign] start campaign[%v] vol[%v] partitions[%v] budget[%v]",
+		ac.view.ID, vol.Name, ac.view.Total, budget)
+	return
+}
+
+func (am *auditManager) evictFinished() {
+	finished := make([]*auditCampaign, 0)
+	for _, ac := range am.campaigns {
+		if !ac.isRunning() {
+			finished = append(finished, ac)
+		}
+	}
+	if len(finished) <= maxFinishedAuditCampaigns {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].view.CreateTime < finished[j].view.CreateTime })
+	for _, ac := range finished[:len(finished)-maxFinishedAuditCampaigns] {
+		delete(am.campaigns, ac.view.ID)
+	}
+}
+
+func (am *auditManager) run(ac *auditCampaign, jobs []func() *proto.AuditPartitionResult, deadline time.Time) {
+	jobC := make(chan func() *proto.AuditPartitionResult)
+	var wg sync.WaitGroup
+	for i := 0; i < ac.view.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobC {
+				ac.setResult(job())
+			}
+		}()
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	status := proto.AuditCampaignCompleted
+dispatch:
+	for _, job := range jobs {
+		select {
+		case jobC <- job:
+		case <-timer.C:
+			status = proto.AuditCampaignExpired
+			break dispatch
+		case <-ac.cancelC:
+			status = proto.AuditCampaignCanceled
+			break dispatch
+		}
+	}
+	close(jobC)
+	wg.Wait()
+	am.finish(ac, status)
+}
+
+func (am *auditManager) finish(ac *auditCampaign, status string) {
+	ac.Lock()
+	defer ac.Unlock()
+	for _, res := range ac.results {
+		if res.Result != proto.AuditResultPending {
+			continue
+		}
+		res.Result = proto.AuditResultSkipped
+		res.Reason = "time budget exhausted"
+		if status == proto.AuditCampaignCanceled {
+			res.Reason = "campaign canceled"
+		}
+		ac.view.Done++
+		ac.view.Skipped++
+	}
+	ac.view.Status = status
+	ac.view.FinishTime = time.Now().Unix()
+	report := &proto.AuditReport{
+		CampaignID: ac.view.ID,
+		Cluster:    am.c.Name,
+		Volume:     ac.view.Volume,
+		Status:     status,
+		StartTime:  ac.view.CreateTime,
+		FinishTime: ac.view.FinishTime,
+		Passed:     ac.view.Passed,
+		Failed:     ac.view.Failed,
+		Skipped:    ac.view.Skipped,
+		Failures:   make([]*proto.AuditPartitionResult, 0),
+		Unverified: make([]*proto.AuditPartitionResult, 0),
+	}
+	for _, res := range ac.sortedResults() {
+		if res.Type == proto.AuditPartitionData {
+			report.DataPartitions++
+		} else {
+			report.MetaPartitions++
+		}
+		switch res.Result {
+		case proto.AuditResultFailed:
+			report.Failures = append(report.Failures, res)
+		case proto.AuditResultPassed:
+		default:
+			report.Unverified = append(report.Unverified, res)
+		}
+	}
+	if err := report.Sign([]byte(am.c.cfg.auditReportSignKey)); err != nil {
+		log.LogErrorf("action[auditCampaign] sign report of campaign[%v] failed: %v", ac.view.ID, err)
+	}
+	ac.report = report
+	msg := fmt.Sprintf("action[auditCampaign] campaign[%v] vol[%v] %v, passed[%v] failed[%v] unverified[%v] signature[%v]",
+		ac.view.ID, ac.view.Volume, status, report.Passed, report.Failed, len(report.Unverified), report.Signature)
+	if report.Failed > 0 {
+		Warn(am.c.Name, msg)
+	} else {
+		log.LogInfo(msg)
+	}
+}
+
+func (am *auditManager) auditDataPartition(dp *DataPartition) (res *proto.AuditPartitionResult) {
+	res = &proto.AuditPartitionResult{PartitionID: dp.PartitionID, Type: proto.AuditPartitionData, Result: proto.AuditResultSkipped}
+	if !proto.IsNormalDp(dp.PartitionType) {
+		res.Reason = "not a normal data partition"
+		return
+	}
+	if !dp.needsToCompareCRC() {
+		res.Reason = "data partition is recovering"
+		return
+	}
+	am.c.addDataNodeTasks(dp.createLoadTasks())
+	loaded := false
+	for i := 0; i < timeToWaitForResponse; i++ {
+		if dp.checkLoadResponse(am.c.getDataPartitionTimeoutSec()) {
+			loaded = true
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if !loaded {
+		res.Reason = "replicas did not respond to load"
+		return
+	}
+
+	dp.RLock()
+	defer dp.RUnlock()
+	liveReplicas := dp.liveReplicas(defaultDataPartitionTimeOutSec)
+	if len(liveReplicas) < int(dp.ReplicaNum) {
+		res.Reason = fmt.Sprintf("only %v of %v replicas are live", len(liveReplicas), dp.ReplicaNum)
+		return
+	}
+	for _, fc := range dp.FileInCoreMap {
+		infoFunc := func() string {
+			return fmt.Sprintf("audit partition[%v] extent %v", dp.PartitionID, fc.Name)
+		}
+		fms, needRepair := fc.needCrcRepair(liveReplicas, infoFunc)
+		if len(fms) == 0 {
+			continue
+		}
+		res.Checked++
+		if len(fms) < len(liveReplicas) && time.Now().Unix()-fc.LastModify > intervalToCheckMissingReplica {
+			res.Mismatches = append(res.Mismatches, fmt.Sprintf("extent %v missing on %v of %v replicas",
+				fc.Name, len(liveReplicas)-len(fms), len(liveReplicas)))
+			continue
+		}
+		if needRepair {
+			crcs := make([]string, 0, len(fms))
+			for _, fm := range fms {
+				crcs = append(crcs, fmt.Sprintf("%v:crc(%v)size(%v)", fm.getLocationAddr(), fm.getFileCrc(), fm.Size))
+			}
+			res.Mismatches = append(res.Mismatches, fmt.Sprintf("extent %v crc differs %v", fc.Name, strings.Join(crcs, ",")))
+		}
+	}
+	sort.Strings(res.Mismatches)
+	res.Reason = ""
+	res.Result = proto.AuditResultPassed
+	if len(res.Mismatches) > 0 {
+		res.Result = proto.AuditResultFailed
+	}
+	return
+}
+
+// auditMetaPartition compares the tree crcs of replicas taken at the same apply id,
+// it retries a few times as the apply id of replicas keeps moving under writes.
+func (am *auditManager) auditMetaPartition(mp *MetaPartition) (res *proto.AuditPartitionResult) {
+	res = &proto.AuditPartitionResult{PartitionID: mp.PartitionID, Type: proto.AuditPartitionMeta, Result: proto.AuditResultSkipped}
+	mp.RLock()
+	recovering := mp.IsRecover
+	// the witnesses hold no data to compare
+	hosts := mp.getDataHosts()
+	mp.RUnlock()
+	if recovering {
+		res.Reason = "meta partition is recovering"
+		return
+	}
+
+	for i := 0; i < auditMetaRetryTimes; i++ {
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
+		responses := make([]*proto.MetaPartitionLoadResponse, 0, len(hosts))
+		for _, host := range hosts {
+			resp, err := am.loadMetaPartitionChecksum(mp, host)
+			if err != nil {
+				res.Reason = err.Error()
+				break
+			}
+			responses = append(responses, resp)
+		}
+		if len(responses) < len(hosts) {
+			continue
+		}
+		base := responses[0]
+		converged := true
+		for _, resp := range responses {
+			if !resp.DoCompare || resp.ApplyID != base.ApplyID {
+				converged = false
+				break
+			}
+		}
+		if !converged {
+			res.Reason = "apply id of replicas did not converge"
+			continue
+		}
+		res.Reason = ""
+		res.Checked = base.InodeCount + base.DentryCount
+		for _, resp := range responses[1:] {
+			if resp.InodeCrc != base.InodeCrc || resp.DentryCrc != base.DentryCrc ||
+				resp.InodeCount != base.InodeCount || resp.DentryCount != base.DentryCount {
+				res.Mismatches = append(res.Mismatches, fmt.Sprintf(
+					"applyID(%v) %v:inode(%v,crc %v) dentry(%v,crc %v) differs from %v:inode(%v,crc %v) dentry(%v,crc %v)",
+					base.ApplyID, resp.Addr, resp.InodeCount, resp.InodeCrc, resp.DentryCount, resp.DentryCrc,
+					base.Addr, base.InodeCount, base.InodeCrc, base.DentryCount, base.DentryCrc))
+			}
+		}
+		res.Result = proto.AuditResultPassed
+		if len(res.Mismatches) > 0 {
+			res.Result = proto.AuditResultFailed
+		}
+		return
+	}
+	return
+}
+
+func (am *auditManager) loadMetaPartitionChecksum(mp *MetaPartition, host string) (resp *proto.MetaPartitionLoadResponse, err error) {
+	mr, err := mp.getMetaReplica(host)
+	if err != nil {
+		return
+	}
+	task := proto.NewAdminTask(proto.OpLoadMetaPartition, host, &proto.MetaPartitionLoadRequest{PartitionID: mp.PartitionID, Checksum: true})
+	resetMetaPartitionTaskID(task, mp.PartitionID)
+	packet, err := mr.metaNode.Sender.syncSendAdminTask(task)
+	if err != nil {
+		return
+	}
+	resp = &proto.MetaPartitionLoadResponse{}
+	if err = json.Unmarshal(packet.Data, resp); err != nil {
+		return
+	}
+	resp.Addr = host
+	return
+}
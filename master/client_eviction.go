@@ -0,0 +1,200 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const intervalToCleanClientEvictions = time.Minute
+
+// clientEvictionStore keeps the client evictions in memory, they are persisted by
+// raft and loaded again by the new leader. The active ones are sent to metanodes
+// and datanodes in every heartbeat.
+type clientEvictionStore struct {
+	sync.RWMutex
+	evictions map[string]*proto.ClientEviction
+}
+
+func newClientEvictionStore() *clientEvictionStore {
+	return &clientEvictionStore{evictions: make(map[string]*proto.ClientEviction)}
+}
+
+func (s *clientEvictionStore) put(e *proto.ClientEviction) {
+	s.Lock()
+	defer s.Unlock()
+	s.evictions[e.Key()] = e
+}
+
+func (s *clientEvictionStore) delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.evictions, key)
+}
+
+// find returns the evictions of the mount, or the one of the IP and volume.
+func (s *clientEvictionStore) find(ip, vol, mountID string) (evictions []*proto.ClientEviction) {
+	s.RLock()
+	defer s.RUnlock()
+	if mountID == "" {
+		if e, ok := s.evictions[(&proto.ClientEviction{IP: ip, Volume: vol}).Key()]; ok {
+			evictions = append(evictions, e)
+		}
+		return
+	}
+	for _, e := range s.evictions {
+		if e.MountID == mountID {
+			evictions = append(evictions, e)
+		}
+	}
+	return
+}
+
+func (s *clientEvictionStore) list() (evictions []*proto.ClientEviction) {
+	s.RLock()
+	defer s.RUnlock()
+	evictions = make([]*proto.ClientEviction, 0, len(s.evictions))
+	for _, e := range s.evictions {
+		evictions = append(evictions, e)
+	}
+	sort.Slice(evictions, func(i, j int) bool { return evictions[i].Key() < evictions[j].Key() })
+	return
+}
+
+// active returns the evictions not expired at now.
+func (s *clientEvictionStore) active(now int64) (evictions []*proto.ClientEviction) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, e := range s.evictions {
+		if !e.Expired(now) {
+			evictions = append(evictions, e)
+		}
+	}
+	return
+}
+
+func (s *clientEvictionStore) reset(evictions []*proto.ClientEviction) {
+	s.Lock()
+	defer s.Unlock()
+	s.evictions = make(map[string]*proto.ClientEviction, len(evictions))
+	for _, e := range evictions {
+		s.evictions[e.Key()] = e
+	}
+}
+
+// isClusterNodeIP tells whether ip belongs to a master, metanode or datanode of the
+// cluster, such IPs are never evicted since the nodes talk to each other.
+func (c *Cluster) isClusterNodeIP(ip string) (found bool) {
+	hostOf := func(addr string) string {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return host
+		}
+		return addr
+	}
+	for _, peer := range c.cfg.peers {
+		if hostOf(peer.Address) == ip {
+			return true
+		}
+	}
+	for _, nodes := range []*sync.Map{&c.dataNodes, &c.metaNodes} {
+		nodes.Range(func(addr, _ interface{}) bool {
+			found = hostOf(addr.(string)) == ip
+			return !found
+		})
+		if found {
+			return
+		}
+	}
+	return
+}
+
+// evictClient fences the client out, metanodes and datanodes reject its requests
+// from their next heartbeat on. Its dir lock leases and transactions are released
+// once they expire, as the client can no longer renew or commit them.
+func (c *Cluster) evictClient(e *proto.ClientEviction) (err error) {
+	if net.ParseIP(e.IP) == nil {
+		return fmt.Errorf("invalid client ip[%v]", e.IP)
+	}
+	if c.isClusterNodeIP(e.IP) {
+		return fmt.Errorf("ip[%v] belongs to a node of the cluster", e.IP)
+	}
+	if e.Volume != "" {
+		if _, err = c.getVol(e.Volume); err != nil {
+			return
+		}
+	}
+	e.CreateTime = time.Now().Unix()
+	if err = c.syncPutClientEviction(e); err != nil {
+		log.LogErrorf("action[evictClient] persist eviction[%v] err:%v", e.Key(), err)
+		return
+	}
+	c.clientEvictions.put(e)
+	log.LogWarnf("action[evictClient] evict client[%v] mount[%v] reason[%v] expire[%v]", e.Key(), e.MountID, e.Reason, e.ExpireTime)
+	return
+}
+
+// removeClientEviction lifts the evictions of the mount, or the one of the IP and
+// volume, and returns them.
+func (c *Cluster) removeClientEviction(ip, vol, mountID string) (removed []*proto.ClientEviction, err error) {
+	evictions := c.clientEvictions.find(ip, vol, mountID)
+	if len(evictions) == 0 {
+		return nil, fmt.Errorf("client eviction of ip[%v] vol[%v] mount[%v] not found", ip, vol, mountID)
+	}
+	for _, e := range evictions {
+		if err = c.syncDeleteClientEviction(e); err != nil {
+			log.LogErrorf("action[removeClientEviction] delete eviction[%v] err:%v", e.Key(), err)
+			return
+		}
+		c.clientEvictions.delete(e.Key())
+		removed = append(removed, e)
+		log.LogWarnf("action[removeClientEviction] eviction[%v] mount[%v] removed", e.Key(), e.MountID)
+	}
+	return
+}
+
+func (c *Cluster) cleanExpiredClientEvictions() {
+	now := time.Now().Unix()
+	for _, e := range c.clientEvictions.list() {
+		if !e.Expired(now) {
+			continue
+		}
+		if err := c.syncDeleteClientEviction(e); err != nil {
+			log.LogWarnf("action[cleanExpiredClientEvictions] delete eviction[%v] err:%v", e.Key(), err)
+			continue
+		}
+		c.clientEvictions.delete(e.Key())
+		log.LogInfof("action[cleanExpiredClientEvictions] eviction[%v] expired", e.Key())
+	}
+}
+
+func (c *Cluster) scheduleToCleanClientEvictions() {
+	c.runTask(&cTask{
+		tickTime: intervalToCleanClientEvictions,
+		name:     "scheduleToCleanClientEvictions",
+		function: func() (fin bool) {
+			if c.partition != nil && c.partition.IsRaftLeader() {
+				c.cleanExpiredClientEvictions()
+			}
+			return
+		},
+	})
+}
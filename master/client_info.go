@@ -21,16 +21,26 @@ const (
 type ClientMgr struct {
 	sync.RWMutex
 	clients map[string]int64
+	mounts  map[string]*clientMount
+}
+
+// clientMount is where a mount reporting its mount id runs, so that it can be
+// evicted by the id.
+type clientMount struct {
+	ip         string
+	vol        string
+	reportTime int64
 }
 
 func newClientMgr() *ClientMgr {
 	mgr := &ClientMgr{}
 	mgr.clients = make(map[string]int64)
+	mgr.mounts = make(map[string]*clientMount)
 	go mgr.evict()
 	return mgr
 }
 
-func (cm *ClientMgr) PutItem(ip, host, vol, version, role, enableBcache, enableRCache string) {
+func (cm *ClientMgr) PutItem(ip, host, vol, version, role, enableBcache, enableRCache, mountID string) {
 	cm.Lock()
 	defer cm.Unlock()
 
@@ -55,6 +65,9 @@ func (cm *ClientMgr) PutItem(ip, host, vol, version, role, enableBcache, enableR
 	}
 
 	key := fmt.Sprintf("_%s_%s_%s_%s_%s_enableBcache-%s_enableRCache-%s", vol, version, role, ip, host, enableBcache, enableRCache)
+	if mountID != "" {
+		key += "_mountId-" + mountID
+	}
 
 	if len(cm.clients) > maxClientCnt {
 		log.LogWarnf("PutItem: too many record in cluster, ignore, key %s", key)
@@ -64,6 +77,25 @@ func (cm *ClientMgr) PutItem(ip, host, vol, version, role, enableBcache, enableR
 	cm.clients[key] = timeutil.GetCurrentTimeUnix()
 }
 
+func (cm *ClientMgr) PutMount(mountID, ip, vol string) {
+	cm.Lock()
+	defer cm.Unlock()
+
+	if _, ok := cm.mounts[mountID]; !ok && len(cm.mounts) > maxClientCnt {
+		log.LogWarnf("PutMount: too many mounts in cluster, ignore, mount %s", mountID)
+		return
+	}
+	cm.mounts[mountID] = &clientMount{ip: ip, vol: vol, reportTime: timeutil.GetCurrentTimeUnix()}
+}
+
+func (cm *ClientMgr) GetMount(mountID string) (mount *clientMount, ok bool) {
+	cm.RLock()
+	defer cm.RUnlock()
+
+	mount, ok = cm.mounts[mountID]
+	return
+}
+
 func (cm *ClientMgr) GetClients(name string) map[string]int64 {
 	cm.RLock()
 	defer cm.RUnlock()
@@ -101,5 +133,12 @@ func (cm *ClientMgr) evict() {
 				cm.deleteByKey(k)
 			}
 		}
+		cm.Lock()
+		for id, mount := range cm.mounts {
+			if now > mount.reportTime+clientExpireInterval {
+				delete(cm.mounts, id)
+			}
+		}
+		cm.Unlock()
 	}
 }
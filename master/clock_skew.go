@@ -0,0 +1,232 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	defaultClockSkewWarn = time.Second
+	// the heartbeats the skew is measured on, the one of the shortest round
+	// trip is the least affected by the network and the queues
+	clockSkewSamples = 8
+)
+
+type clockSample struct {
+	offset time.Duration
+	delay  time.Duration
+}
+
+// clockSkew measures how far the clock of a node is from the one of the master
+// on the heartbeats, like NTP does: the master stamps the heartbeat when it
+// sends it and when it gets the response, the node when it receives it and
+// when it replies.
+type clockSkew struct {
+	sync.Mutex
+	samples    []clockSample
+	next       int
+	skew       time.Duration
+	delay      time.Duration
+	measuredAt time.Time
+	exceeded   bool
+	gated      bool
+}
+
+// measure records the heartbeat sent at sendTime and answered at now, and
+// tells whether the node crossed the warning threshold either way.
+func (s *clockSkew) measure(sendTime int64, clock proto.NodeClock, now time.Time, warn, limit time.Duration) (changed bool) {
+	if sendTime <= 0 || clock.RecvTime <= 0 || clock.ReplyTime < clock.RecvTime {
+		return false
+	}
+	t1, t2, t3, t4 := sendTime, clock.RecvTime, clock.ReplyTime, now.UnixNano()
+	sample := clockSample{
+		offset: time.Duration(((t2 - t1) + (t3 - t4)) / 2),
+		delay:  time.Duration((t4 - t1) - (t3 - t2)),
+	}
+	if sample.delay < 0 {
+		sample.delay = 0
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if len(s.samples) < clockSkewSamples {
+		s.samples = append(s.samples, sample)
+	} else {
+		s.samples[s.next] = sample
+		s.next = (s.next + 1) % clockSkewSamples
+	}
+	best := s.samples[0]
+	for _, sample := range s.samples[1:] {
+		if sample.delay < best.delay {
+			best = sample
+		}
+	}
+	s.skew, s.delay, s.measuredAt = best.offset, best.delay, now
+
+	skew := s.skew
+	if skew < 0 {
+		skew = -skew
+	}
+	exceeded := skew > warn
+	changed = exceeded != s.exceeded
+	s.exceeded = exceeded
+	s.gated = limit > 0 && skew > limit
+	return
+}
+
+// isGated tells whether the node is too skewed to be given new partitions.
+func (s *clockSkew) isGated() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.gated
+}
+
+func (s *clockSkew) view(addr, role string) *proto.NodeClockSkew {
+	s.Lock()
+	defer s.Unlock()
+	if s.measuredAt.IsZero() {
+		return nil
+	}
+	return &proto.NodeClockSkew{
+		Addr:       addr,
+		Role:       role,
+		SkewMs:     s.skew.Milliseconds(),
+		DelayMs:    s.delay.Milliseconds(),
+		MeasuredAt: s.measuredAt.Unix(),
+		Exceeded:   s.exceeded,
+		Gated:      s.gated,
+	}
+}
+
+// getClockSkewWarn is the clock skew of a node raising an alarm.
+func (c *Cluster) getClockSkewWarn() time.Duration {
+	if val := atomic.LoadUint64(&c.cfg.ClockSkewWarn); val > 0 {
+		return time.Duration(val) * time.Millisecond
+	}
+	return defaultClockSkewWarn
+}
+
+// getClockSkewLimit is the clock skew of a node above which it is given no
+// new partitions, 0 if the nodes are not gated on their clock.
+func (c *Cluster) getClockSkewLimit() time.Duration {
+	return time.Duration(atomic.LoadUint64(&c.cfg.ClockSkewLimit)) * time.Millisecond
+}
+
+func (c *Cluster) setClockSkewWarn(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.ClockSkewWarn)
+	atomic.StoreUint64(&c.cfg.ClockSkewWarn, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setClockSkewWarn] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.ClockSkewWarn, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+func (c *Cluster) setClockSkewLimit(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.ClockSkewLimit)
+	atomic.StoreUint64(&c.cfg.ClockSkewLimit, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setClockSkewLimit] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.ClockSkewLimit, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+// measureClockSkew measures the clock skew of a node on the response to the
+// heartbeat task, and raises an alarm when it gets over the threshold.
+func (c *Cluster) measureClockSkew(role, addr string, s *clockSkew, task *proto.AdminTask, clock proto.NodeClock) {
+	warn := c.getClockSkewWarn()
+	if !s.measure(task.SendTimeNano, clock, time.Now(), warn, c.getClockSkewLimit()) {
+		return
+	}
+	skew := s.view(addr, role)
+	if skew.Exceeded {
+		Warn(c.Name, fmt.Sprintf("action[measureClockSkew] clusterID[%v] %v[%v] clock skew %vms exceeds %v, round trip %vms",
+			c.Name, role, addr, skew.SkewMs, warn, skew.DelayMs))
+		return
+	}
+	log.LogWarnf("action[measureClockSkew] clusterID[%v] %v[%v] clock skew %vms is back within %v",
+		c.Name, role, addr, skew.SkewMs, warn)
+}
+
+func (c *Cluster) clockSkewView() *proto.ClockSkewView {
+	view := &proto.ClockSkewView{
+		WarnMs:  uint64(c.getClockSkewWarn().Milliseconds()),
+		LimitMs: uint64(c.getClockSkewLimit().Milliseconds()),
+		Nodes:   make([]*proto.NodeClockSkew, 0),
+	}
+	add := func(skew *proto.NodeClockSkew) {
+		if skew != nil {
+			view.Nodes = append(view.Nodes, skew)
+		}
+	}
+	c.dataNodes.Range(func(_, node interface{}) bool {
+		dataNode := node.(*DataNode)
+		add(dataNode.clock.view(dataNode.Addr, proto.FeatureRoleDataNode))
+		return true
+	})
+	c.metaNodes.Range(func(_, node interface{}) bool {
+		metaNode := node.(*MetaNode)
+		add(metaNode.clock.view(metaNode.Addr, proto.FeatureRoleMetaNode))
+		return true
+	})
+	c.flashNodeTopo.flashNodeMap.Range(func(_, node interface{}) bool {
+		flashNode := node.(*FlashNode)
+		add(flashNode.clock.view(flashNode.Addr, "flashnode"))
+		return true
+	})
+	c.lcNodes.Range(func(_, node interface{}) bool {
+		lcNode := node.(*LcNode)
+		add(lcNode.clock.view(lcNode.Addr, "lcnode"))
+		return true
+	})
+	sort.Slice(view.Nodes, func(i, j int) bool {
+		a, b := view.Nodes[i].SkewMs, view.Nodes[j].SkewMs
+		if a < 0 {
+			a = -a
+		}
+		if b < 0 {
+			b = -b
+		}
+		if a != b {
+			return a > b
+		}
+		return view.Nodes[i].Addr < view.Nodes[j].Addr
+	})
+	return view
+}
+
+func (m *Server) getClockSkew(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminClusterClockSkew))
+	defer func() {
+		doStatAndMetric(proto.AdminClusterClockSkew, metric, nil, nil)
+	}()
+
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.clockSkewView()))
+}
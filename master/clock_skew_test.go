@@ -0,0 +1,89 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// heartbeat of a node whose clock is ahead by skew, taking the given time on
+// the way to the node and back
+func skewedHeartbeat(now time.Time, skew, there, back time.Duration) (sendTime int64, clock proto.NodeClock, recv time.Time) {
+	sendTime = now.UnixNano()
+	clock.RecvTime = now.Add(there + skew).UnixNano()
+	clock.ReplyTime = now.Add(there + skew + time.Millisecond).UnixNano()
+	recv = now.Add(there + time.Millisecond + back)
+	return
+}
+
+func TestClockSkewMeasure(t *testing.T) {
+	s := &clockSkew{}
+	now := time.Now()
+	require.False(t, s.measure(0, proto.NodeClock{}, now, time.Second, 0))
+	require.Nil(t, s.view("a", "datanode"))
+
+	sendTime, clock, recv := skewedHeartbeat(now, 3*time.Second, 10*time.Millisecond, 10*time.Millisecond)
+	require.True(t, s.measure(sendTime, clock, recv, time.Second, 0))
+	view := s.view("a", "datanode")
+	require.Equal(t, int64(3000), view.SkewMs)
+	require.Equal(t, int64(20), view.DelayMs)
+	require.True(t, view.Exceeded)
+	require.False(t, view.Gated)
+
+	// a heartbeat delayed one way is not trusted over the one of the shortest round trip
+	sendTime, clock, recv = skewedHeartbeat(now, 3*time.Second, 2*time.Second, 10*time.Millisecond)
+	require.False(t, s.measure(sendTime, clock, recv, time.Second, 5*time.Second))
+	require.Equal(t, int64(3000), s.view("a", "datanode").SkewMs)
+
+	// gated over the limit
+	require.False(t, s.measure(sendTime, clock, recv, time.Second, 2*time.Second))
+	require.True(t, s.isGated())
+
+	// the clock fixed, the older samples are overtaken
+	for i := 0; i < clockSkewSamples; i++ {
+		sendTime, clock, recv = skewedHeartbeat(now, -100*time.Millisecond, time.Millisecond, time.Millisecond)
+		s.measure(sendTime, clock, recv, time.Second, 2*time.Second)
+	}
+	view = s.view("a", "datanode")
+	require.Equal(t, int64(-100), view.SkewMs)
+	require.False(t, view.Exceeded)
+	require.False(t, view.Gated)
+}
+
+func TestClockSkewView(t *testing.T) {
+	// the mock datanodes answer the heartbeats with their clock
+	require.Eventually(t, func() bool {
+		view, err := mc.AdminAPI().GetClusterClockSkew()
+		require.NoError(t, err)
+		for _, node := range view.Nodes {
+			if node.Role == proto.FeatureRoleDataNode {
+				return true
+			}
+		}
+		return false
+	}, 20*time.Second, 500*time.Millisecond)
+
+	view, err := mc.AdminAPI().GetClusterClockSkew()
+	require.NoError(t, err)
+	require.Equal(t, uint64(defaultClockSkewWarn.Milliseconds()), view.WarnMs)
+	require.Equal(t, uint64(0), view.LimitMs)
+	for _, node := range view.Nodes {
+		require.False(t, node.Exceeded, node.Addr)
+	}
+}
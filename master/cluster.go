@@ -161,6 +161,10 @@ type Cluster struct {
 	checkDataReplicasEnable bool
 	fileStatsEnable         bool
 	fileStatsThresholds     []uint64
+	zoneCostLock            sync.Mutex   // serializes the updates of zoneCosts
+	zoneCosts               atomic.Value // []proto.ZoneCost
+	maintenanceLock         sync.Mutex   // serializes the updates of maintenances
+	maintenances            atomic.Value // []proto.Maintenance
 	clusterUuidEnable       bool
 	authenticate            bool
 	legacyDataMediaType     uint32
@@ -173,11 +177,26 @@ type Cluster struct {
 	followerReadManager *followerReadManager
 	lcMgr               *lifecycleManager
 	snapshotMgr         *snapshotDelManager
+	sloTracker          *sloTracker
+	auditMgr            *auditManager
+	mountProfiles       *mountProfileStore
+	clientEvictions     *clientEvictionStore
+	mirrorZones         mirrorZones
+	volTimeline         *volTimelineStore
+	flashGroupAudit     *flashGroupAuditStore
+	events              *clusterEventBus
+	s3Gateways          *s3GatewayRegistry
 
 	ac           *authSDK.AuthClient
 	masterClient *masterSDK.MasterClient
 
-	flashNodeTopo *flashNodeTopology
+	flashNodeTopo  *flashNodeTopology
+	leaderBalancer *leaderBalancer
+	dataBalancer   *dataBalancer
+	flashCacheKey  flashCacheKey
+
+	decommissionThrottle decommissionThrottle
+	decommissionProgress *decommissionProgresses
 
 	cleanTask   map[string]*CleanTask
 	Cleaning    bool
@@ -474,6 +493,17 @@ func newCluster(name string, leaderInfo *LeaderInfo, fsm *MetadataFsm, partition
 	c.lcMgr = newLifecycleManager()
 	c.lcMgr.cluster = c
 	c.snapshotMgr = newSnapshotManager()
+	c.sloTracker = newSLOTracker()
+	c.auditMgr = newAuditManager(c)
+	c.mountProfiles = newMountProfileStore()
+	c.clientEvictions = newClientEvictionStore()
+	c.volTimeline = newVolTimelineStore()
+	c.flashGroupAudit = newFlashGroupAuditStore()
+	c.events = newClusterEventBus()
+	c.s3Gateways = newS3GatewayRegistry()
+	c.leaderBalancer = newLeaderBalancer()
+	c.dataBalancer = newDataBalancer()
+	c.decommissionProgress = newDecommissionProgresses()
 	c.snapshotMgr.cluster = c
 	c.S3ApiQosQuota = new(sync.Map)
 	c.MarkDiskBrokenThreshold.Store(defaultMarkDiskBrokenThreshold)
@@ -513,8 +543,19 @@ func (c *Cluster) scheduleTask() {
 	c.scheduleToUpdateFlashGroupRespCache()
 	c.scheduleStartBalanceTask()
 	c.scheduleToUpdateFlashGroupSlots()
+	c.scheduleToAutoScaleFlashGroups()
+	c.scheduleToAutoHealFlashGroups()
+	c.scheduleToRemoveDrainedFlashGroups()
 	c.scheduleToCheckDataPartitionRepairingStatus()
 	c.scheduleToCheckDataPartitionDecommissionDiskRetryMap()
+	c.scheduleToCleanClientEvictions()
+	c.scheduleToCleanVolTimeline()
+	c.scheduleToCleanFlashGroupAudit()
+	c.scheduleToCheckHotDataPartitions()
+	c.scheduleToCheckHotMetaPartitions()
+	c.scheduleToMoveLeadersOutOfFencedZones()
+	c.scheduleToBalanceLeaders()
+	c.scheduleToBalanceData()
 }
 
 func (c *Cluster) masterAddr() (addr string) {
@@ -900,16 +941,32 @@ func (c *Cluster) scheduleToCheckHeartbeat() {
 func (c *Cluster) checkDataNodeHeartbeat() {
 	tasks := make([]*proto.AdminTask, 0)
 	id := uuid.New()
+	evictedClients := c.clientEvictions.active(time.Now().Unix())
+	volFences := c.allVolFences()
+	zonesVersion, dataNodeZones := c.syncMirrorDataNodeZones()
+	now := time.Now()
+	maxInterval := c.dataNodeHeartbeatMaxInterval()
+	maintenances := c.activeMaintenances(now.Unix())
 	log.LogDebugf("checkDataNodeHeartbeat start %v", id.String())
 	c.dataNodes.Range(func(addr, dataNode interface{}) bool {
 		node := dataNode.(*DataNode)
-		node.checkLiveness()
+		if node.checkLiveness() {
+			c.publishEvent(proto.ClusterEventDataNodeOffline, 0, node.Addr, "")
+		}
 		log.LogDebugf("checkDataNodeHeartbeat checkLiveness for data node %v  %v", node.Addr, id.String())
+		if !node.heartbeatDue(now, maxInterval) {
+			return true
+		}
 		task := node.createHeartbeatTask(c.masterAddr(), c.diskQosEnable, c.GetDecommissionDataPartitionBackupTimeOut().String(),
 			c.cfg.forbidWriteOpOfProtoVer0, c.RaftPartitionCanUsingDifferentPortEnabled(), c.cfg.dataNodeGOGC)
 		log.LogDebugf("checkDataNodeHeartbeat createHeartbeatTask for data node %v task %v %v", node.Addr,
 			task.RequestID, id.String())
 		hbReq := task.Request.(*proto.HeartBeatRequest)
+		hbReq.EvictedClients = evictedClients
+		hbReq.VolFences = volFences
+		hbReq.ReplicaTombstoneRetention = c.getReplicaTombstoneRetention().String()
+		hbReq.ReportSeq = node.reportSeqToAck(now)
+		hbReq.Maintenance = inMaintenance(maintenances, node.ZoneName, node.NodeSetID)
 		c.volMutex.RLock()
 		defer c.volMutex.RUnlock()
 		for _, vol := range c.vols {
@@ -928,6 +985,27 @@ func (c *Cluster) checkDataNodeHeartbeat() {
 				hbReq.IgnoreTinyRecoverVols = append(hbReq.IgnoreTinyRecoverVols, vol.Name)
 			}
 
+			if vol.SyncMirrorWrite {
+				hbReq.SyncMirrorWriteVols = append(hbReq.SyncMirrorWriteVols, vol.Name)
+				hbReq.DataNodeZonesVersion = zonesVersion
+				if atomic.LoadUint64(&node.mirrorZonesVersion) != zonesVersion {
+					hbReq.DataNodeZones = dataNodeZones
+				}
+			}
+
+			if vol.RequireTLS {
+				hbReq.TLSRequiredVols = append(hbReq.TLSRequiredVols, vol.Name)
+			}
+
+			if vol.VersionMgr != nil {
+				if until := vol.VersionMgr.immutableUntil(now); until > 0 {
+					if hbReq.ImmutableVols == nil {
+						hbReq.ImmutableVols = make(map[string]int64)
+					}
+					hbReq.ImmutableVols[vol.Name] = until
+				}
+			}
+
 			if vol.ForbidWriteOpOfProtoVer0.Load() {
 				hbReq.VolsForbidWriteOpOfProtoVer0 = append(hbReq.VolsForbidWriteOpOfProtoVer0, vol.Name)
 			}
@@ -940,14 +1018,83 @@ func (c *Cluster) checkDataNodeHeartbeat() {
 	log.LogDebugf("checkDataNodeHeartbeat end %v", id.String())
 }
 
+// mirrorZones versions the zones of the data nodes sent to the leaders of the
+// sync mirror volumes, a node is sent them again only once they change.
+type mirrorZones struct {
+	sync.Mutex
+	version uint64
+	zones   map[string]string
+}
+
+// update replaces the zones if they changed and returns the latest ones. The
+// version is the time of the change, not to be taken for the version of an
+// earlier leader.
+func (z *mirrorZones) update(zones map[string]string, now time.Time) (uint64, map[string]string) {
+	z.Lock()
+	defer z.Unlock()
+	if z.zones != nil && len(z.zones) == len(zones) {
+		same := true
+		for addr, zone := range zones {
+			if old, ok := z.zones[addr]; !ok || old != zone {
+				same = false
+				break
+			}
+		}
+		if same {
+			return z.version, z.zones
+		}
+	}
+	version := uint64(now.UnixNano())
+	if version <= z.version {
+		version = z.version + 1
+	}
+	z.version, z.zones = version, zones
+	return z.version, z.zones
+}
+
+// syncMirrorDataNodeZones returns the zones of the data nodes and their
+// version, by which the leaders of the sync mirror volumes find the replicas
+// in other zones.
+func (c *Cluster) syncMirrorDataNodeZones() (version uint64, zones map[string]string) {
+	c.volMutex.RLock()
+	for _, vol := range c.vols {
+		if vol.SyncMirrorWrite {
+			zones = make(map[string]string)
+			break
+		}
+	}
+	c.volMutex.RUnlock()
+	if zones == nil {
+		return
+	}
+	c.dataNodes.Range(func(addr, dataNode interface{}) bool {
+		zones[addr.(string)] = dataNode.(*DataNode).ZoneName
+		return true
+	})
+	return c.mirrorZones.update(zones, time.Now())
+}
+
 func (c *Cluster) checkMetaNodeHeartbeat() {
 	tasks := make([]*proto.AdminTask, 0)
+	evictedClients := c.clientEvictions.active(time.Now().Unix())
+	volFences := c.allVolFences()
+	maintenances := c.activeMaintenances(time.Now().Unix())
 
 	c.metaNodes.Range(func(addr, metaNode interface{}) bool {
 		node := metaNode.(*MetaNode)
-		node.checkHeartbeat()
+		if node.checkHeartbeat() {
+			c.publishEvent(proto.ClusterEventMetaNodeOffline, 0, node.Addr, "")
+		}
 		task := node.createHeartbeatTask(c.masterAddr(), c.fileStatsEnable, c.fileStatsThresholds, c.cfg.forbidWriteOpOfProtoVer0, c.cfg.metaNodeGOGC, c.RaftPartitionCanUsingDifferentPortEnabled())
 		hbReq := task.Request.(*proto.HeartBeatRequest)
+		hbReq.EvictedClients = evictedClients
+		hbReq.VolFences = volFences
+		hbReq.ReplicaTombstoneRetention = c.getReplicaTombstoneRetention().String()
+		hbReq.Maintenance = inMaintenance(maintenances, node.ZoneName, node.NodeSetID)
+		hbReq.ImmutableVols = make(map[string]int64)
+		hbReq.ReclaimBoostVols = make(map[string]*proto.ReclaimBoost)
+		now := time.Now()
+		forkSources := make(map[string]struct{})
 
 		c.volMutex.RLock()
 		defer c.volMutex.RUnlock()
@@ -965,6 +1112,20 @@ func (c *Cluster) checkMetaNodeHeartbeat() {
 			if vol.ForbidWriteOpOfProtoVer0.Load() {
 				hbReq.VolsForbidWriteOpOfProtoVer0 = append(hbReq.VolsForbidWriteOpOfProtoVer0, vol.Name)
 			}
+			if vol.RequireTLS {
+				hbReq.TLSRequiredVols = append(hbReq.TLSRequiredVols, vol.Name)
+			}
+			if vol.VersionMgr != nil {
+				if until := vol.VersionMgr.immutableUntil(now); until > 0 {
+					hbReq.ImmutableVols[vol.Name] = until
+				}
+			}
+			if boost := vol.getReclaimBoost(); boost.Active(now) {
+				hbReq.ReclaimBoostVols[vol.Name] = boost
+			}
+			for _, source := range vol.getForkSources() {
+				forkSources[source] = struct{}{}
+			}
 
 			spaceInfo := vol.uidSpaceManager.getSpaceOp()
 			hbReq.UidLimitInfo = append(hbReq.UidLimitInfo, spaceInfo...)
@@ -1764,6 +1925,8 @@ func (c *Cluster) deleteVol(name string) {
 	c.volMutex.Lock()
 	defer c.volMutex.Unlock()
 	delete(c.vols, name)
+	c.sloTracker.remove(name)
+	c.s3Gateways.removeBucket(name)
 }
 
 func (c *Cluster) markDeleteVol(name, authKey string, force bool, isNotCancel bool) (err error) {
@@ -1788,9 +1951,14 @@ func (c *Cluster) markDeleteVol(name, authKey string, force bool, isNotCancel bo
 			vol.Status = proto.VolStatusMarkDelete
 			return proto.ErrPersistenceByRaft
 		}
+		c.recordVolEvent(vol.Name, proto.VolEventDelete, "deletion canceled")
 		return
 	}
 
+	if targets := c.getVolForkTargets(name); len(targets) > 0 {
+		return fmt.Errorf("vol %s is forked by %v, remove the forks first", name, targets)
+	}
+
 	if !c.cfg.volForceDeletion {
 		volDentryCount := uint64(0)
 		mpsCopy := vol.cloneMetaPartitionMap()
@@ -1826,6 +1994,7 @@ func (c *Cluster) markDeleteVol(name, authKey string, force bool, isNotCancel bo
 		vol.Status = proto.VolStatusNormal
 		return proto.ErrPersistenceByRaft
 	}
+	c.recordVolEvent(vol.Name, proto.VolEventDelete, "marked deleted, force[%v]", force)
 	return
 }
 
@@ -1920,7 +2089,6 @@ func (c *Cluster) createDataPartition(volName string, mediaType uint32) (dp *Dat
 	c.volMutex.RUnlock()
 
 	dpReplicaNum := vol.dpReplicaNum
-	zoneName := vol.zoneName
 
 	if vol, err = c.getVol(volName); err != nil {
 		return
@@ -1936,15 +2104,18 @@ func (c *Cluster) createDataPartition(volName string, mediaType uint32) (dp *Dat
 			goto errHandler
 		}
 	} else {
-		zoneNum := c.decideZoneNum(vol, mediaType) // zoneNum scope [1,3]
-		if targetHosts, targetPeers, err = c.getHostFromNormalZone(TypeDataPartition, nil, nil, nil,
-			int(dpReplicaNum), zoneNum, zoneName, mediaType); err != nil {
+		zoneName, zoneNum := c.placementZones(vol, mediaType)
+		if targetHosts, targetPeers, err = c.getHostFromNormalZone(TypeDataPartition, vol.getPlacementExclusion().Zones, nil,
+			c.placementExcludedHosts(vol, TypeDataPartition), int(dpReplicaNum), zoneNum, zoneName, mediaType); err != nil {
 			goto errHandler
 		}
 	}
 	if err = c.checkMultipleReplicasOnSameMachine(targetHosts); err != nil {
 		goto errHandler
 	}
+	if err = c.checkPlacementExclusion(vol, TypeDataPartition, targetHosts); err != nil {
+		goto errHandler
+	}
 
 	if partitionID, err = c.idAlloc.allocateDataPartitionID(); err != nil {
 		goto errHandler
@@ -2013,6 +2184,8 @@ func (c *Cluster) createDataPartition(volName string, mediaType uint32) (dp *Dat
 
 	vol.dataPartitions.put(dp)
 	log.LogInfof("action[createDataPartition] success,volName[%v],partitionId[%v], count[%d]", volName, partitionID, len(vol.dataPartitions.partitions))
+	c.recordVolEvent(volName, proto.VolEventAddDataPartition, "dp[%v] mediaType[%v] hosts%v",
+		partitionID, proto.MediaTypeString(mediaType), targetHosts)
 	return
 
 errHandler:
@@ -2849,6 +3022,7 @@ func (c *Cluster) migrateDataPartition(srcAddr, targetAddr string, dp *DataParti
 		ns              *nodeSet
 		excludeNodeSets []uint64
 		zones           []string
+		excludeHosts    []string
 	)
 	log.LogDebugf("[migrateDataPartition] src %v target %v raftForce %v", srcAddr, targetAddr, raftForce)
 	dp.RLock()
@@ -2909,13 +3083,23 @@ func (c *Cluster) migrateDataPartition(srcAddr, targetAddr string, dp *DataParti
 		goto errHandler
 	}
 
+	excludeHosts = dp.Hosts
+	if vol, e := c.getVol(dp.VolName); e == nil {
+		excludeHosts = c.withPlacementExclusion(vol, TypeDataPartition, excludeHosts)
+		if targetAddr != "" {
+			if err = c.checkPlacementExclusion(vol, TypeDataPartition, []string{targetAddr}); err != nil {
+				goto errHandler
+			}
+		}
+	}
+
 	if targetAddr != "" {
 		targetHosts = []string{targetAddr}
 		if err = c.checkDataNodesMediaTypeForMigrate(dataNode, targetAddr); err != nil {
 			log.LogErrorf("[migrateDataPartition] check mediaType err: %v", err.Error())
 			goto errHandler
 		}
-	} else if targetHosts, _, err = ns.getAvailDataNodeHosts(dp.Hosts, 1); err != nil {
+	} else if targetHosts, _, err = ns.getAvailDataNodeHosts(excludeHosts, 1); err != nil {
 		if _, ok := c.vols[dp.VolName]; !ok {
 			log.LogWarnf("clusterID[%v] partitionID:%v  on node:%v offline failed,PersistenceHosts:[%v]",
 				c.Name, dp.PartitionID, srcAddr, dp.Hosts)
@@ -2928,10 +3112,10 @@ func (c *Cluster) migrateDataPartition(srcAddr, targetAddr string, dp *DataParti
 		}
 		// select data nodes from the other node set in same zone
 		excludeNodeSets = append(excludeNodeSets, ns.ID)
-		if targetHosts, _, err = zone.getAvailNodeHosts(TypeDataPartition, excludeNodeSets, dp.Hosts, 1); err != nil {
+		if targetHosts, _, err = zone.getAvailNodeHosts(TypeDataPartition, excludeNodeSets, excludeHosts, 1); err != nil {
 			// select data nodes from the other zone
 			zones = dp.getLiveZones(srcAddr)
-			if targetHosts, _, err = c.getHostFromNormalZone(TypeDataPartition, zones, excludeNodeSets, dp.Hosts, 1, 1, "", dp.MediaType); err != nil {
+			if targetHosts, _, err = c.getHostFromNormalZone(TypeDataPartition, zones, excludeNodeSets, excludeHosts, 1, 1, "", dp.MediaType); err != nil {
 				goto errHandler
 			}
 		}
@@ -2978,6 +3162,7 @@ func (c *Cluster) migrateDataPartition(srcAddr, targetAddr string, dp *DataParti
 
 	log.LogWarnf("[migrateDataPartition] clusterID[%v] partitionID:%v  on node:%v offline success,newHost[%v],PersistenceHosts:[%v]",
 		c.Name, dp.PartitionID, srcAddr, newAddr, dp.Hosts)
+	c.recordVolEvent(dp.VolName, proto.VolEventDecommission, "dp[%v] replica %v -> %v", dp.PartitionID, srcAddr, newAddr)
 	dp.SetSpecialReplicaDecommissionStep(SpecialDecommissionInitial)
 	return
 
@@ -3476,6 +3661,7 @@ func (c *Cluster) deleteDataReplica(dp *DataPartition, dataNode *DataNode, raftF
 		return
 	}
 	task := dp.createTaskToDeleteDataPartition(dataNode.Addr, raftForceDel)
+	task.Request.(*proto.DeleteDataPartitionRequest).Tombstone = c.getReplicaTombstoneRetention() > 0
 	dp.Unlock()
 
 	_, err = dataNode.TaskManager.syncSendAdminTask(task)
@@ -3677,6 +3863,10 @@ func (c *Cluster) migrateMetaNode(srcAddr, targetAddr string, limit int) (err er
 	metaNode.ToBeOffline = true
 	metaNode.MaxMemAvailWeight = 1
 	errChannel := make(chan error, limit)
+	c.decommissionProgress.startMeta(srcAddr, limit, time.Now())
+	defer func() {
+		c.decommissionProgress.finishMeta(srcAddr, err)
+	}()
 
 	defer func() {
 		metaNode.ToBeOffline = false
@@ -3687,7 +3877,9 @@ func (c *Cluster) migrateMetaNode(srcAddr, targetAddr string, limit int) (err er
 		wg.Add(1)
 		go func(mp *MetaPartition) {
 			defer wg.Done()
-			if err1 := c.migrateMetaPartition(srcAddr, targetAddr, mp); err1 != nil {
+			err1 := c.migrateMetaPartition(srcAddr, targetAddr, mp)
+			c.decommissionProgress.metaMigrated(srcAddr, err1, time.Now())
+			if err1 != nil {
 				errChannel <- err1
 			}
 		}(toBeOfflineMps[idx])
@@ -3779,6 +3971,9 @@ func (c *Cluster) updateVol(name, authKey string, newArgs *VolVarargs) (err erro
 		err = proto.ErrPersistenceByRaft
 		goto errHandler
 	}
+	if oldArgs.capacity != newArgs.capacity {
+		c.recordVolEvent(name, proto.VolEventCapacity, "capacity %vGB -> %vGB", oldArgs.capacity, newArgs.capacity)
+	}
 
 	return
 
@@ -3994,6 +4189,8 @@ func (c *Cluster) createVol(req *createVolReq) (vol *Vol, err error) {
 	if vol, err = c.doCreateVol(req); err != nil {
 		goto errHandler
 	}
+	c.recordVolEvent(vol.Name, proto.VolEventCreate, "owner[%v] capacity %vGB, dp replicas[%v] mp replicas[%v]",
+		vol.Owner, vol.Capacity, vol.dpReplicaNum, vol.mpReplicaNum)
 
 	vol.aclMgr.init(c, vol)
 	vol.initUidSpaceManager(c)
@@ -4010,6 +4207,7 @@ func (c *Cluster) createVol(req *createVolReq) (vol *Vol, err error) {
 		}
 
 		c.deleteVol(req.name)
+		c.recordVolEvent(vol.Name, proto.VolEventDelete, "removed since its meta partitions failed to create")
 
 		err = fmt.Errorf("action[createVol] initMetaPartitions failed, vol[%v] err[%v]", vol.Name, err)
 		goto errHandler
@@ -4099,6 +4297,7 @@ func (c *Cluster) doCreateVol(req *createVolReq) (vol *Vol, err error) {
 		TrashInterval:           req.trashInterval,
 		AccessTimeInterval:      req.accessTimeValidInterval,
 		EnablePersistAccessTime: req.enablePersistAccessTime,
+		MpWitnessNum:            req.mpWitnessNum,
 
 		VolStorageClass:     req.volStorageClass,
 		AllowedStorageClass: req.allowedStorageClass,
@@ -4202,33 +4401,68 @@ func (c *Cluster) lcNodeCount() (len int) {
 }
 
 func (c *Cluster) allDataNodes() (dataNodes []proto.NodeView) {
-	dataNodes = make([]proto.NodeView, 0)
+	return c.listDataNodes(listFilter{}, listPage{})
+}
+
+// listDataNodes returns the page of the views of the data nodes in the zone
+// and of the status filtered, by the node id.
+func (c *Cluster) listDataNodes(filter listFilter, page listPage) (dataNodes []proto.NodeView) {
+	nodes := make([]*DataNode, 0)
 	c.dataNodes.Range(func(addr, node interface{}) bool {
 		dataNode := node.(*DataNode)
+		if filter.matchZone(dataNode.ZoneName) && filter.matchStatus(nodeListStatus(dataNode.isActive)) {
+			nodes = append(nodes, dataNode)
+		}
+		return true
+	})
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	begin, end := page.bounds(len(nodes))
+	dataNodes = make([]proto.NodeView, 0, end-begin)
+	for _, dataNode := range nodes[begin:end] {
 		dataNodes = append(dataNodes, proto.NodeView{
 			Addr: dataNode.Addr, DomainAddr: dataNode.DomainAddr,
 			Status: dataNode.isActive, ID: dataNode.ID, IsWritable: dataNode.IsWriteAble(), MediaType: dataNode.MediaType,
 			ForbidWriteOpOfProtoVer0: dataNode.ReceivedForbidWriteOpOfProtoVer0,
 		})
-		return true
-	})
+	}
 	return
 }
 
 func (c *Cluster) allMetaNodes() (metaNodes []proto.NodeView) {
-	metaNodes = make([]proto.NodeView, 0)
+	return c.listMetaNodes(listFilter{}, listPage{})
+}
+
+// listMetaNodes returns the page of the views of the meta nodes in the zone
+// and of the status filtered, by the node id.
+func (c *Cluster) listMetaNodes(filter listFilter, page listPage) (metaNodes []proto.NodeView) {
+	nodes := make([]*MetaNode, 0)
 	c.metaNodes.Range(func(addr, node interface{}) bool {
 		metaNode := node.(*MetaNode)
+		if filter.matchZone(metaNode.ZoneName) && filter.matchStatus(nodeListStatus(metaNode.IsActive)) {
+			nodes = append(nodes, metaNode)
+		}
+		return true
+	})
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	begin, end := page.bounds(len(nodes))
+	metaNodes = make([]proto.NodeView, 0, end-begin)
+	for _, metaNode := range nodes[begin:end] {
 		metaNodes = append(metaNodes, proto.NodeView{
 			ID: metaNode.ID, Addr: metaNode.Addr, DomainAddr: metaNode.DomainAddr,
 			Status: metaNode.IsActive, IsWritable: metaNode.IsWriteAble(), MediaType: proto.MediaType_Unspecified,
 			ForbidWriteOpOfProtoVer0: metaNode.ReceivedForbidWriteOpOfProtoVer0,
 		})
-		return true
-	})
+	}
 	return
 }
 
+func nodeListStatus(active bool) string {
+	if active {
+		return listStatusActive
+	}
+	return listStatusInactive
+}
+
 func (c *Cluster) allFlashNodes() (flashNodes []proto.NodeView) {
 	flashNodes = make([]proto.NodeView, 0)
 	c.flashNodeTopo.flashNodeMap.Range(func(addr, node interface{}) bool {
@@ -4539,6 +4773,18 @@ func (c *Cluster) setDataPartitionBackupTimeOut(val uint64) (err error) {
 	return
 }
 
+func (c *Cluster) setReplicaTombstoneRetention(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.ReplicaTombstoneRetention)
+	atomic.StoreUint64(&c.cfg.ReplicaTombstoneRetention, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setReplicaTombstoneRetention] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.ReplicaTombstoneRetention, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
 func (c *Cluster) setDataNodeAutoRepairLimitRate(val uint64) (err error) {
 	oldVal := atomic.LoadUint64(&c.cfg.DataNodeAutoRepairLimitRate)
 	atomic.StoreUint64(&c.cfg.DataNodeAutoRepairLimitRate, val)
@@ -4792,6 +5038,7 @@ func (c *Cluster) checkDecommissionDataNode() {
 	c.dataNodes.Range(func(addr, node interface{}) bool {
 		dataNode := node.(*DataNode)
 		dataNode.updateDecommissionStatus(c, false, true)
+		c.sampleDataNodeDecommission(dataNode)
 		if dataNode.GetDecommissionStatus() == markDecommission {
 			c.TryDecommissionDataNode(dataNode)
 		} else if dataNode.GetDecommissionStatus() == DecommissionSuccess {
@@ -4820,6 +5067,7 @@ func (c *Cluster) checkDecommissionDataNode() {
 				log.LogWarnf("action[checkDecommissionDataNode] %v", msg)
 				dataNode.delDecommissionDiskFromCache(c)
 				c.delDataNodeFromCache(dataNode)
+				c.decommissionProgress.forget(dataNode.Addr)
 				auditlog.LogMasterOp("DataNodeDecommission", msg, nil)
 			}
 		}
@@ -5310,7 +5558,7 @@ func (c *Cluster) handleDataNodeBadDisk(dataNode *DataNode) {
 					log.LogInfof("[handleDataNodeBadDisk] data node(%v) not found in dp(%v) maybe decommissioned?", dataNode.Addr, dpId)
 					continue
 				}
-				err = c.markDecommissionDataPartition(dp, dataNode, 0, false, AutoDecommission, highPriorityDecommissionWeight)
+				err = c.markDecommissionDataPartition(dp, dataNode, "", 0, false, AutoDecommission, highPriorityDecommissionWeight)
 				if err != nil {
 					log.LogErrorf("[handleDataNodeBadDisk] failed to decommssion dp(%v) on data node(%v) disk(%v), err(%v)", dataNode.Addr, disk.DiskPath, dp.PartitionID, err)
 					continue
@@ -5326,6 +5574,10 @@ func (c *Cluster) checkBadDisk() {
 		if !ok {
 			return true
 		}
+		if c.dataNodeInMaintenance(dataNode) {
+			log.LogDebugf("action[checkBadDisk] skip data node[%v] in maintenance", dataNode.Addr)
+			return true
+		}
 		c.handleDataNodeBadDisk(dataNode)
 		return true
 	})
@@ -6046,6 +6298,13 @@ func (c *Cluster) GetDecommissionDataPartitionBackupTimeOut() time.Duration {
 	return time.Duration(c.cfg.DpBackupTimeOut)
 }
 
+// getReplicaTombstoneRetention is how long the nodes keep the data of the
+// replicas dropped by decommission or migration, so that a replica removed
+// by mistake can be resurrected.
+func (c *Cluster) getReplicaTombstoneRetention() time.Duration {
+	return time.Duration(atomic.LoadUint64(&c.cfg.ReplicaTombstoneRetention)) * time.Second
+}
+
 func (c *Cluster) GetDecommissionDiskLimit() (limit uint32) {
 	limit = atomic.LoadUint32(&c.DecommissionDiskLimit)
 	return
@@ -6247,7 +6506,11 @@ func (c *Cluster) rangeAllParitions(f func(d *DataPartition) bool) {
 	}
 }
 
-func (c *Cluster) markDecommissionDataPartition(dp *DataPartition, src *DataNode, dstNodeSetID uint64, raftForce bool, migrateType uint32, weight int) (err error) {
+// markDecommissionDataPartition decommissions the replica of the data partition on src to dstAddr, or to a
+// data node chosen if empty.
+func (c *Cluster) markDecommissionDataPartition(dp *DataPartition, src *DataNode, dstAddr string, dstNodeSetID uint64, raftForce bool,
+	migrateType uint32, weight int,
+) (err error) {
 	addr := src.Addr
 	replica, err := dp.getReplica(addr)
 	if err != nil {
@@ -6265,7 +6528,7 @@ func (c *Cluster) markDecommissionDataPartition(dp *DataPartition, src *DataNode
 		return
 	}
 
-	if err = dp.MarkDecommissionStatus(addr, "", replica.DiskPath, dstNodeSetID, raftForce, uint64(time.Now().Unix()), migrateType, weight, c, ns); err != nil {
+	if err = dp.MarkDecommissionStatus(addr, dstAddr, replica.DiskPath, dstNodeSetID, raftForce, uint64(time.Now().Unix()), migrateType, weight, c, ns); err != nil {
 		if !strings.Contains(err.Error(), proto.ErrDecommissionDiskErrDPFirst.Error()) {
 			dp.markRollbackFailed(false)
 			dp.DecommissionErrorMessage = err.Error()
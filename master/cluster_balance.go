@@ -1324,6 +1324,9 @@ func (c *Cluster) scheduleStartBalanceTask() {
 				if c.partition == nil || !c.partition.IsRaftLeader() || c.PlanRun {
 					continue
 				}
+				if len(c.activeMaintenances(time.Now().Unix())) > 0 {
+					continue
+				}
 
 				err := c.RestartMetaPartitionBalanceTask()
 				if err != nil && err != proto.ErrNoMpMigratePlan {
@@ -1680,14 +1683,14 @@ func selectOneLeaderAddr(mrPlan *proto.MrBalanceInfo, mpPlan *proto.MetaBalanceP
 				break
 			}
 		}
-		if isSelected && replica.Addr != leader {
+		if isSelected && replica.Addr != leader && !mp.isWitness(replica.Addr) {
 			return replica.Addr
 		}
 	}
 
 	// Select one address which is not the current source address.
 	for _, replica := range mp.Replicas {
-		if mrPlan.Source != replica.Addr && replica.Addr != leader {
+		if mrPlan.Source != replica.Addr && replica.Addr != leader && !mp.isWitness(replica.Addr) {
 			return replica.Addr
 		}
 	}
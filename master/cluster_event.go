@@ -0,0 +1,199 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	clusterEventKeep             = 4096
+	clusterEventMaxWait          = 60 * time.Second
+	clusterEventStreamKeepAlive  = 15 * time.Second
+	clusterEventStreamMaxTime    = 4 * time.Minute // within the write timeout of the http server
+	clusterEventStreamRetryMilli = 3000
+)
+
+// clusterEventBus keeps the latest events published by this leader in memory,
+// oldest first, and wakes up the subscribers waiting for them. The events are
+// not persisted, those of the old leader are lost with a leader change.
+type clusterEventBus struct {
+	sync.RWMutex
+	events    []*proto.ClusterEvent
+	lastID    int64
+	droppedID int64         // id of the latest event dropped
+	notify    chan struct{} // closed and replaced by every publish
+}
+
+func newClusterEventBus() *clusterEventBus {
+	return &clusterEventBus{events: make([]*proto.ClusterEvent, 0), notify: make(chan struct{})}
+}
+
+// publish stamps the event with an id greater than those of the events before.
+func (b *clusterEventBus) publish(event *proto.ClusterEvent) {
+	now := time.Now()
+	b.Lock()
+	id := now.UnixNano()
+	if id <= b.lastID {
+		id = b.lastID + 1
+	}
+	b.lastID = id
+	event.ID, event.Time = id, now.Unix()
+	b.events = append(b.events, event)
+	if n := len(b.events) - clusterEventKeep; n > 0 {
+		b.droppedID = b.events[n-1].ID
+		b.events = b.events[n:]
+	}
+	close(b.notify)
+	b.notify = make(chan struct{})
+	b.Unlock()
+	log.LogInfof("action[publishClusterEvent] id[%v] type[%v] flashGroup[%v] addr[%v] status[%v]",
+		event.ID, event.Type, event.FlashGroupID, event.Addr, event.Status)
+}
+
+// since returns the events after the id of the types, or of all types if empty,
+// and a channel closed by the next publish. Last of the view is the latest id
+// looked at, so the events skipped by the types are not looked at again.
+func (b *clusterEventBus) since(after int64, types map[string]bool) (view *proto.ClusterEventsView, notify <-chan struct{}) {
+	b.RLock()
+	defer b.RUnlock()
+	view = &proto.ClusterEventsView{Events: make([]*proto.ClusterEvent, 0), Last: after, Lost: after < b.droppedID}
+	for _, event := range b.events {
+		if event.ID <= after {
+			continue
+		}
+		view.Last = event.ID
+		if len(types) == 0 || types[event.Type] {
+			view.Events = append(view.Events, event)
+		}
+	}
+	return view, b.notify
+}
+
+// wait is since, waiting up to timeout for an event if there is none, or until
+// done is closed.
+func (b *clusterEventBus) wait(after int64, types map[string]bool, timeout time.Duration, done <-chan struct{}) (view *proto.ClusterEventsView) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		var notify <-chan struct{}
+		if view, notify = b.since(after, types); len(view.Events) > 0 || view.Lost {
+			return
+		}
+		after = view.Last
+		select {
+		case <-notify:
+		case <-timer.C:
+			return
+		case <-done:
+			return
+		}
+	}
+}
+
+func (c *Cluster) publishEvent(eventType string, flashGroupID uint64, addr, status string) {
+	c.events.publish(&proto.ClusterEvent{Type: eventType, FlashGroupID: flashGroupID, Addr: addr, Status: status})
+}
+
+func parseClusterEventTypes(value string) (types map[string]bool) {
+	types = make(map[string]bool)
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = true
+		}
+	}
+	return
+}
+
+// getClusterEvents returns the events after the id asked, waiting up to
+// timeoutSec for one. With "Accept: text/event-stream" the events are streamed
+// as server-sent events instead, from the Last-Event-ID header if it is set.
+func (m *Server) getClusterEvents(w http.ResponseWriter, r *http.Request) {
+	var (
+		after      common.Int
+		types      common.String
+		timeoutSec common.Int
+		err        error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminClusterEvents))
+	defer func() {
+		doStatAndMetric(proto.AdminClusterEvents, metric, err, nil)
+	}()
+	if err = parseArgs(r, after.Key("after").OmitEmpty(), types.Key("types").OmitEmpty(),
+		timeoutSec.Key("timeoutSec").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	timeout := time.Duration(timeoutSec.V) * time.Second
+	if timeout < 0 || timeout > clusterEventMaxWait {
+		timeout = clusterEventMaxWait
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		if lastID := r.Header.Get("Last-Event-ID"); lastID != "" {
+			if after.V, err = strconv.ParseInt(lastID, 10, 64); err != nil {
+				sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+				return
+			}
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			m.streamClusterEvents(w, flusher, r, after.V, parseClusterEventTypes(types.V))
+			return
+		}
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.events.wait(after.V, parseClusterEventTypes(types.V), timeout, r.Context().Done())))
+}
+
+// streamClusterEvents writes the events as server-sent events until the client
+// goes away or this master is not the leader any more. The stream is ended in
+// a few minutes, the client connects again with the id of the last event.
+func (m *Server) streamClusterEvents(w http.ResponseWriter, flusher http.Flusher, r *http.Request, after int64, types map[string]bool) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", clusterEventStreamRetryMilli)
+	flusher.Flush()
+
+	deadline := time.Now().Add(clusterEventStreamMaxTime)
+	for time.Now().Before(deadline) && m.partition.IsRaftLeader() {
+		view := m.cluster.events.wait(after, types, clusterEventStreamKeepAlive, r.Context().Done())
+		if r.Context().Err() != nil {
+			return
+		}
+		if view.Lost {
+			fmt.Fprintf(w, "event: lost\ndata: events after %d were dropped\n\n", after)
+		}
+		for _, event := range view.Events {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+		if len(view.Events) == 0 && !view.Lost {
+			fmt.Fprint(w, ": keepalive\n\n")
+		}
+		after = view.Last
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/config"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	clusterEventKafkaTimeout = 5 * time.Second
+	clusterEventKafkaRetry   = 3 * time.Second
+
+	defaultClusterEventKafkaTopic = "cubefs_master_events"
+)
+
+// clusterEventKafkaSink sends the cluster events published by this master to
+// a kafka topic, in order. An event failed to send is retried until it is
+// dropped by the event bus.
+type clusterEventKafkaSink struct {
+	producer sarama.SyncProducer
+	topic    string
+	stopC    chan struct{}
+}
+
+func newClusterEventKafkaSink(cfg *config.Config) (s *clusterEventKafkaSink, err error) {
+	brokers := cfg.GetString(cfgEventKafkaBrokers)
+	if brokers == "" {
+		return nil, nil
+	}
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Version = sarama.V2_1_0_0
+	if version := cfg.GetString(cfgEventKafkaVersion); version != "" {
+		if saramaCfg.Version, err = sarama.ParseKafkaVersion(version); err != nil {
+			return
+		}
+	}
+	saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.Timeout = clusterEventKafkaTimeout
+	producer, err := sarama.NewSyncProducer(strings.Split(brokers, ","), saramaCfg)
+	if err != nil {
+		return
+	}
+	topic := cfg.GetString(cfgEventKafkaTopic)
+	if topic == "" {
+		topic = defaultClusterEventKafkaTopic
+	}
+	return &clusterEventKafkaSink{producer: producer, topic: topic, stopC: make(chan struct{})}, nil
+}
+
+// start sends the events published from now on.
+func (s *clusterEventKafkaSink) start(events *clusterEventBus) {
+	after := time.Now().UnixNano()
+	go func() {
+		for {
+			select {
+			case <-s.stopC:
+				return
+			default:
+			}
+			view := events.wait(after, nil, clusterEventMaxWait, s.stopC)
+			if view.Lost {
+				log.LogWarnf("action[clusterEventKafkaSink] events after %v were dropped before sent", after)
+			}
+			for _, event := range view.Events {
+				if err := s.send(event); err != nil {
+					log.LogErrorf("action[clusterEventKafkaSink] send event[%v] type[%v] failed: %v", event.ID, event.Type, err)
+					break
+				}
+				after = event.ID
+			}
+			if len(view.Events) > 0 && after != view.Events[len(view.Events)-1].ID {
+				select {
+				case <-s.stopC:
+					return
+				case <-time.After(clusterEventKafkaRetry):
+				}
+				continue
+			}
+			after = view.Last
+		}
+	}()
+}
+
+// send keys the event by its flash group or node, which keeps their events in
+// order in a partition.
+func (s *clusterEventKafkaSink) send(event *proto.ClusterEvent) (err error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	key := event.Addr
+	if event.FlashGroupID != 0 {
+		key = strconv.FormatUint(event.FlashGroupID, 10)
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: s.topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(data),
+	})
+	return
+}
+
+func (s *clusterEventKafkaSink) stop() {
+	close(s.stopC)
+	if err := s.producer.Close(); err != nil {
+		log.LogWarnf("action[clusterEventKafkaSink] close producer: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterEventBus(t *testing.T) {
+	b := newClusterEventBus()
+	b.publish(&proto.ClusterEvent{Type: proto.ClusterEventFlashGroupCreate, FlashGroupID: 1})
+	b.publish(&proto.ClusterEvent{Type: proto.ClusterEventDataNodeOffline, Addr: "127.0.0.1:17310"})
+
+	view, _ := b.since(0, nil)
+	require.Len(t, view.Events, 2)
+	require.Less(t, view.Events[0].ID, view.Events[1].ID)
+	require.Equal(t, view.Events[1].ID, view.Last)
+	require.False(t, view.Lost)
+
+	// the events skipped by the types are not looked at again
+	view, _ = b.since(0, parseClusterEventTypes(proto.ClusterEventFlashGroupCreate))
+	require.Len(t, view.Events, 1)
+	require.Equal(t, proto.ClusterEventFlashGroupCreate, view.Events[0].Type)
+	first := view.Events[0].ID
+	view, _ = b.since(first, parseClusterEventTypes(proto.ClusterEventFlashGroupCreate))
+	require.Empty(t, view.Events)
+	last := view.Last
+
+	// wait wakes up on the next event, or times out
+	start := time.Now()
+	view = b.wait(last, nil, 100*time.Millisecond, nil)
+	require.Empty(t, view.Events)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		b.publish(&proto.ClusterEvent{Type: proto.ClusterEventMetaNodeOffline, Addr: "127.0.0.1:17210"})
+	}()
+	view = b.wait(last, nil, 10*time.Second, nil)
+	require.Len(t, view.Events, 1)
+	require.Equal(t, proto.ClusterEventMetaNodeOffline, view.Events[0].Type)
+
+	for i := 0; i < clusterEventKeep; i++ {
+		b.publish(&proto.ClusterEvent{Type: proto.ClusterEventFlashNodeOffline})
+	}
+	view, _ = b.since(first, nil)
+	require.True(t, view.Lost)
+	require.Len(t, view.Events, clusterEventKeep)
+	view, _ = b.since(view.Events[0].ID, nil)
+	require.False(t, view.Lost)
+}
+
+func TestClusterEventKafkaSink(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	sent := make(chan *proto.ClusterEvent, 1)
+	producer.ExpectSendMessageAndFail(errors.New("broker down"))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		key, _ := msg.Key.Encode()
+		value, _ := msg.Value.Encode()
+		event := &proto.ClusterEvent{}
+		if err := json.Unmarshal(value, event); err != nil {
+			return err
+		}
+		require.Equal(t, "7", string(key))
+		require.Equal(t, "events", msg.Topic)
+		sent <- event
+		return nil
+	})
+
+	b := newClusterEventBus()
+	s := &clusterEventKafkaSink{producer: producer, topic: "events", stopC: make(chan struct{})}
+	s.start(b)
+	defer s.stop()
+	b.publish(&proto.ClusterEvent{Type: proto.ClusterEventFlashGroupStatus, FlashGroupID: 7, Status: "Inactive"})
+
+	// the event failed to send is sent again
+	select {
+	case event := <-sent:
+		require.Equal(t, proto.ClusterEventFlashGroupStatus, event.Type)
+		require.Equal(t, "Inactive", event.Status)
+	case <-time.After(2 * clusterEventKafkaRetry):
+		t.Fatal("event not sent")
+	}
+}
+
+func TestClusterEventsAPI(t *testing.T) {
+	view, err := mc.AdminAPI().GetClusterEvents(0, nil, 0)
+	require.NoError(t, err)
+	after := view.Last
+
+	server.cluster.publishEvent(proto.ClusterEventFlashGroupStatus, 100, "", proto.FlashGroupStatus_Inactive.String())
+	server.cluster.publishEvent(proto.ClusterEventFlashNodeOffline, 100, "127.0.0.1:10001", "")
+	view, err = mc.AdminAPI().GetClusterEvents(after, []string{proto.ClusterEventFlashNodeOffline}, 1)
+	require.NoError(t, err)
+	require.Len(t, view.Events, 1)
+	require.Equal(t, "127.0.0.1:10001", view.Events[0].Addr)
+	require.Equal(t, uint64(100), view.Events[0].FlashGroupID)
+
+	fgView, err := mc.AdminAPI().CreateFlashGroup("", proto.FlashGroupDefaultWeight, 0, false, 0)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().RemoveFlashGroup(fgView.ID, false, 0)
+	require.NoError(t, err)
+	view, err = mc.AdminAPI().GetClusterEvents(view.Last,
+		[]string{proto.ClusterEventFlashGroupCreate, proto.ClusterEventFlashGroupRemove}, 1)
+	require.NoError(t, err)
+	require.Len(t, view.Events, 2)
+	require.Equal(t, proto.ClusterEventFlashGroupCreate, view.Events[0].Type)
+	require.Equal(t, proto.ClusterEventFlashGroupRemove, view.Events[1].Type)
+	require.Equal(t, fgView.ID, view.Events[1].FlashGroupID)
+
+	// the server-sent events go on from the Last-Event-ID
+	req, err := http.NewRequest(http.MethodGet, hostAddr+proto.AdminClusterEvents, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Last-Event-ID", "0")
+	req.URL.RawQuery = "types=" + proto.ClusterEventFlashGroupStatus
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+			lines = append(lines, line)
+			if strings.Contains(line, `"FlashGroupID":100`) {
+				break
+			}
+		}
+	}
+	require.NotEmpty(t, lines)
+	event := &proto.ClusterEvent{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[len(lines)-1], "data: ")), event))
+	require.Equal(t, proto.ClusterEventFlashGroupStatus, event.Type)
+	require.Equal(t, proto.FlashGroupStatus_Inactive.String(), event.Status)
+}
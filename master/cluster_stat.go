@@ -201,3 +201,28 @@ func (c *Cluster) updateVolStatInfo() {
 		c.volStatInfo.Store(vol.Name, newVolStatInfo(vol.Name, total, used, inodeCount))
 	}
 }
+
+// featureMatrix collects the features reported in the heartbeats of every
+// registered node. A node which has not reported since master started counts
+// as supporting nothing, so features are only enabled once all nodes did.
+func (c *Cluster) featureMatrix() *proto.ClusterFeatureMatrix {
+	nodes := map[string]map[string]proto.FeatureBits{
+		proto.FeatureRoleMetaNode: make(map[string]proto.FeatureBits),
+		proto.FeatureRoleDataNode: make(map[string]proto.FeatureBits),
+	}
+	c.metaNodes.Range(func(addr, node interface{}) bool {
+		metaNode := node.(*MetaNode)
+		metaNode.RLock()
+		nodes[proto.FeatureRoleMetaNode][metaNode.Addr] = metaNode.Features
+		metaNode.RUnlock()
+		return true
+	})
+	c.dataNodes.Range(func(addr, node interface{}) bool {
+		dataNode := node.(*DataNode)
+		dataNode.RLock()
+		nodes[proto.FeatureRoleDataNode][dataNode.Addr] = dataNode.Features
+		dataNode.RUnlock()
+		return true
+	})
+	return proto.BuildFeatureMatrix(nodes)
+}
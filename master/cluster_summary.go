@@ -0,0 +1,212 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+const summaryUsageWarnRatio = 0.80
+
+// summary builds the health scored overview of the cluster from what master
+// already keeps in memory, so that it is cheap enough to be polled.
+func (c *Cluster) summary() *proto.ClusterSummary {
+	return proto.BuildClusterSummary(c.Name, c.leaderInfo.addr, time.Now().Unix(), []*proto.SubsystemSummary{
+		c.mastersSummary(),
+		c.metaNodesSummary(),
+		c.dataNodesSummary(),
+		c.flashNodesSummary(),
+		c.objectNodesSummary(),
+		c.blobStoreSummary(),
+	})
+}
+
+func (c *Cluster) mastersSummary() *proto.SubsystemSummary {
+	addrs := make(map[uint64]string, len(c.cfg.peerAddrs))
+	for _, peer := range c.cfg.peerAddrs {
+		split := strings.Split(peer, colonSplit)
+		if len(split) != 3 {
+			continue
+		}
+		id, _ := strconv.ParseUint(split[0], 10, 64)
+		addrs[id] = split[1] + ":" + split[2]
+	}
+	s := proto.NewSubsystemSummary(proto.SummaryMasters, len(addrs), len(addrs))
+	if c.leaderInfo.addr == "" {
+		s.AddIssue(proto.SummarySeverityCritical, proto.SummaryIssueNoLeader, "no master leader is elected",
+			"check the network between the masters and the raft logs of the masters")
+	}
+	// the replicas are only known by the leader, which serves the request
+	if c.partition == nil {
+		return s
+	}
+	status := c.partition.Status()
+	if status == nil || len(status.Replicas) == 0 {
+		return s
+	}
+	inactive := make([]string, 0)
+	for id, replica := range status.Replicas {
+		if id != status.NodeID && !replica.Active {
+			inactive = append(inactive, addrs[id])
+		}
+	}
+	if len(inactive) == 0 {
+		return s
+	}
+	s.Active -= len(inactive)
+	if s.Active <= s.Total/2 {
+		s.AddIssue(proto.SummarySeverityCritical, proto.SummaryIssueQuorumAtRisk,
+			fmt.Sprintf("%v of %v masters are inactive, the raft group lost its quorum", len(inactive), s.Total),
+			"restart the inactive masters at once", inactive...)
+		return s
+	}
+	s.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueNodesInactive,
+		fmt.Sprintf("%v of %v masters are inactive", len(inactive), s.Total),
+		"restart the inactive masters before another one fails", inactive...)
+	return s
+}
+
+// addNodesIssues adds the issues shared by metanodes and datanodes.
+func addNodesIssues(s *proto.SubsystemSummary, inactive []string, bad []badPartitionView, usedRatio string, action string) {
+	if s.Total == 0 {
+		s.AddIssue(proto.SummarySeverityCritical, proto.SummaryIssueNoNodes,
+			fmt.Sprintf("no %v registered", s.Name), fmt.Sprintf("start %v and check they can reach the masters", s.Name))
+		return
+	}
+	if len(inactive) > 0 {
+		severity := proto.SummarySeverityWarning
+		// with 3 replicas, a third of the nodes down is likely to stop partitions
+		if len(inactive)*3 >= s.Total {
+			severity = proto.SummarySeverityCritical
+		}
+		s.AddIssue(severity, proto.SummaryIssueNodesInactive,
+			fmt.Sprintf("%v of %v %v are inactive", len(inactive), s.Total, s.Name),
+			"restart the inactive nodes, or decommission them if they can not be recovered", inactive...)
+	}
+	badIDs := make(map[uint64]struct{})
+	for _, view := range bad {
+		for _, id := range view.PartitionIDs {
+			badIDs[id] = struct{}{}
+		}
+	}
+	if len(badIDs) > 0 {
+		s.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueBadPartitions,
+			fmt.Sprintf("%v partitions are being recovered", len(badIDs)),
+			fmt.Sprintf("follow the recovery with %v and check the decommission progress", action))
+	}
+	ratio, err := strconv.ParseFloat(usedRatio, 64)
+	if err != nil {
+		return
+	}
+	if ratio >= spaceAvailableRate {
+		s.AddIssue(proto.SummarySeverityCritical, proto.SummaryIssueHighUsage,
+			fmt.Sprintf("%.1f%% of the %v space is used", ratio*100, s.Name), fmt.Sprintf("add %v at once", s.Name))
+	} else if ratio >= summaryUsageWarnRatio {
+		s.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueHighUsage,
+			fmt.Sprintf("%.1f%% of the %v space is used", ratio*100, s.Name), fmt.Sprintf("plan to add %v", s.Name))
+	}
+}
+
+func (c *Cluster) metaNodesSummary() *proto.SubsystemSummary {
+	inactive := make([]string, 0)
+	total := 0
+	c.metaNodes.Range(func(addr, node interface{}) bool {
+		metaNode := node.(*MetaNode)
+		total++
+		if !metaNode.IsActive {
+			inactive = append(inactive, metaNode.Addr)
+		}
+		return true
+	})
+	s := proto.NewSubsystemSummary(proto.SummaryMetaNodes, total, total-len(inactive))
+	addNodesIssues(s, inactive, c.getBadMetaPartitionsView(), c.metaNodeStatInfo.UsedRatio, proto.AdminDiagnoseMetaPartition)
+	return s
+}
+
+func (c *Cluster) dataNodesSummary() *proto.SubsystemSummary {
+	inactive := make([]string, 0)
+	badDisks := make([]string, 0)
+	total := 0
+	c.dataNodes.Range(func(addr, node interface{}) bool {
+		dataNode := node.(*DataNode)
+		total++
+		if !dataNode.isActive {
+			inactive = append(inactive, dataNode.Addr)
+		}
+		dataNode.RLock()
+		for _, disk := range dataNode.BadDisks {
+			badDisks = append(badDisks, dataNode.Addr+":"+disk)
+		}
+		dataNode.RUnlock()
+		return true
+	})
+	s := proto.NewSubsystemSummary(proto.SummaryDataNodes, total, total-len(inactive))
+	addNodesIssues(s, inactive, c.getBadDataPartitionsView(), c.dataNodeStatInfo.UsedRatio, proto.AdminDiagnoseDataPartition)
+	if len(badDisks) > 0 {
+		s.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueBadDisks,
+			fmt.Sprintf("%v disks are reported broken", len(badDisks)),
+			"decommission the broken disks and replace them", badDisks...)
+	}
+	return s
+}
+
+func (c *Cluster) flashNodesSummary() *proto.SubsystemSummary {
+	inactive := make([]string, 0)
+	total := 0
+	c.flashNodeTopo.flashNodeMap.Range(func(addr, node interface{}) bool {
+		flashNode := node.(*FlashNode)
+		total++
+		flashNode.RLock()
+		if !flashNode.IsActive {
+			inactive = append(inactive, flashNode.Addr)
+		}
+		flashNode.RUnlock()
+		return true
+	})
+	s := proto.NewSubsystemSummary(proto.SummaryFlashNodes, total, total-len(inactive))
+	if total == 0 {
+		s.SetUnknown("no flashnode registered")
+		return s
+	}
+	if len(inactive) == 0 {
+		return s
+	}
+	// the reads fall back to the datanodes, so even no flashnode is not critical
+	s.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueNodesInactive,
+		fmt.Sprintf("%v of %v flashnodes are inactive", len(inactive), total),
+		"restart the inactive flashnodes, the reads they cached go to the datanodes meanwhile", inactive...)
+	return s
+}
+
+func (c *Cluster) objectNodesSummary() *proto.SubsystemSummary {
+	s := proto.NewSubsystemSummary(proto.SummaryObjectNodes, 0, 0)
+	s.SetUnknown("objectnodes do not register with master, monitor them through their metrics")
+	return s
+}
+
+func (c *Cluster) blobStoreSummary() *proto.SubsystemSummary {
+	s := proto.NewSubsystemSummary(proto.SummaryBlobStore, 0, 0)
+	if c.server == nil || c.server.bStoreAddr == "" {
+		s.SetUnknown("no blobstore configured")
+		return s
+	}
+	s.SetUnknown(fmt.Sprintf("blobstore is monitored by its clustermgr, master uses %v", c.server.bStoreAddr))
+	return s
+}
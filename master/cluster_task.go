@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
@@ -105,6 +106,8 @@ func (c *Cluster) migrateMetaPartition(srcAddr, targetAddr string, mp *MetaParti
 		finalHosts      []string
 		oldHosts        []string
 		zones           []string
+		excludeHosts    []string
+		witness         bool
 	)
 
 	log.LogWarnf("action[migrateMetaPartition],volName[%v], migrate from src[%s] to target[%s],partitionID[%v] begin",
@@ -142,11 +145,21 @@ func (c *Cluster) migrateMetaPartition(srcAddr, targetAddr string, mp *MetaParti
 		goto errHandler
 	}
 
+	excludeHosts = oldHosts
+	if vol, e := c.getVol(mp.volName); e == nil {
+		excludeHosts = c.withPlacementExclusion(vol, TypeMetaPartition, excludeHosts)
+		if targetAddr != "" {
+			if err = c.checkPlacementExclusion(vol, TypeMetaPartition, []string{targetAddr}); err != nil {
+				goto errHandler
+			}
+		}
+	}
+
 	if targetAddr != "" {
 		newPeers = []proto.Peer{{
 			Addr: targetAddr,
 		}}
-	} else if _, newPeers, err = ns.getAvailMetaNodeHosts(oldHosts, 1); err != nil {
+	} else if _, newPeers, err = ns.getAvailMetaNodeHosts(excludeHosts, 1); err != nil {
 		if _, ok := c.vols[mp.volName]; !ok {
 			log.LogWarnf("[migrateMetaPartition] clusterID[%v] partitionID:%v  on node:[%v]",
 				c.Name, mp.PartitionID, mp.Hosts)
@@ -159,7 +172,7 @@ func (c *Cluster) migrateMetaPartition(srcAddr, targetAddr string, mp *MetaParti
 		}
 		// choose a meta node in other node set in the same zone
 		excludeNodeSets = append(excludeNodeSets, ns.ID)
-		if _, newPeers, err = zone.getAvailNodeHosts(TypeMetaPartition, excludeNodeSets, oldHosts, 1); err != nil {
+		if _, newPeers, err = zone.getAvailNodeHosts(TypeMetaPartition, excludeNodeSets, excludeHosts, 1); err != nil {
 			zones = mp.getLiveZones(srcAddr)
 			var excludeZone []string
 			if len(zones) == 0 {
@@ -168,7 +181,7 @@ func (c *Cluster) migrateMetaPartition(srcAddr, targetAddr string, mp *MetaParti
 				excludeZone = append(excludeZone, zones[0])
 			}
 			// choose a meta node in other zone
-			if _, newPeers, err = c.getHostFromNormalZone(TypeMetaPartition, excludeZone, excludeNodeSets, oldHosts, 1, 1, "", proto.MediaType_Unspecified); err != nil {
+			if _, newPeers, err = c.getHostFromNormalZone(TypeMetaPartition, excludeZone, excludeNodeSets, excludeHosts, 1, 1, "", proto.MediaType_Unspecified); err != nil {
 				goto errHandler
 			}
 		}
@@ -185,11 +198,13 @@ func (c *Cluster) migrateMetaPartition(srcAddr, targetAddr string, mp *MetaParti
 		return err
 	}
 
+	// the new replica takes the place of the old one, as a witness or not
+	witness = mp.isWitness(srcAddr)
 	if err = c.deleteMetaReplica(mp, srcAddr, false, false); err != nil {
 		goto errHandler
 	}
 
-	if err = c.addMetaReplica(mp, newPeers[0].Addr); err != nil {
+	if err = c.addMetaReplica(mp, newPeers[0].Addr, witness); err != nil {
 		goto errHandler
 	}
 
@@ -202,6 +217,7 @@ func (c *Cluster) migrateMetaPartition(srcAddr, targetAddr string, mp *MetaParti
 
 	Warn(c.Name, fmt.Sprintf("action[migrateMetaPartition] clusterID[%v] vol[%v] meta partition[%v] "+
 		"migrate addr[%v] success,new addr[%v]", c.Name, mp.volName, mp.PartitionID, srcAddr, newPeers[0].Addr))
+	c.recordVolEvent(mp.volName, proto.VolEventDecommission, "mp[%v] replica %v -> %v", mp.PartitionID, srcAddr, newPeers[0].Addr)
 	return
 
 errHandler:
@@ -450,6 +466,7 @@ func (c *Cluster) deleteMetaPartition(partition *MetaPartition, removeMetaNode *
 		return nil
 	}
 	task := mr.createTaskToDeleteReplica(partition.PartitionID)
+	task.Request.(*proto.DeleteMetaPartitionRequest).Tombstone = c.getReplicaTombstoneRetention() > 0
 	partition.removeReplicaByAddr(removeMetaNode.Addr)
 	partition.removeMissingReplica(removeMetaNode.Addr)
 	partition.Unlock()
@@ -510,7 +527,11 @@ func (c *Cluster) removeMetaPartitionRaftMember(partition *MetaPartition, remove
 	if mr.Addr != removePeer.Addr {
 		return
 	}
-	metaNode, err := c.metaNode(partition.Hosts[0])
+	dataHosts := partition.getDataHosts()
+	if len(dataHosts) == 0 {
+		return
+	}
+	metaNode, err := c.metaNode(dataHosts[0])
 	if err != nil {
 		return
 	}
@@ -530,7 +551,9 @@ func (c *Cluster) updateMetaPartitionOfflinePeerIDWithLock(mp *MetaPartition, pe
 	return
 }
 
-func (c *Cluster) addMetaReplica(partition *MetaPartition, addr string) (err error) {
+// addMetaReplica adds a replica on addr, which is a witness holding no data if
+// witness is set.
+func (c *Cluster) addMetaReplica(partition *MetaPartition, addr string, witness bool) (err error) {
 	defer func() {
 		if err != nil {
 			log.LogErrorf("action[addMetaReplica],vol[%v],data partition[%v],err[%v]", partition.volName, partition.PartitionID, err)
@@ -546,7 +569,7 @@ func (c *Cluster) addMetaReplica(partition *MetaPartition, addr string) (err err
 	if err != nil {
 		return
 	}
-	addPeer := proto.Peer{ID: metaNode.ID, Addr: addr, HeartbeatPort: metaNode.HeartbeatPort, ReplicaPort: metaNode.ReplicaPort}
+	addPeer := proto.Peer{ID: metaNode.ID, Addr: addr, HeartbeatPort: metaNode.HeartbeatPort, ReplicaPort: metaNode.ReplicaPort, Witness: witness}
 	if err = c.addMetaPartitionRaftMember(partition, addPeer); err != nil {
 		return
 	}
@@ -656,8 +679,8 @@ func (c *Cluster) loadMetaPartitionAndCheckResponse(mp *MetaPartition) {
 func (c *Cluster) doLoadMetaPartition(mp *MetaPartition) {
 	var wg sync.WaitGroup
 	mp.Lock()
-	hosts := make([]string, len(mp.Hosts))
-	copy(hosts, mp.Hosts)
+	// the witnesses hold no data to compare
+	hosts := mp.getDataHosts()
 	mp.LoadResponse = make([]*proto.MetaPartitionLoadResponse, 0)
 	mp.Unlock()
 	errChannel := make(chan error, len(hosts))
@@ -748,6 +771,7 @@ func (c *Cluster) handleMetaNodeTaskResponse(nodeAddr string, task *proto.AdminT
 	switch task.OpCode {
 	case proto.OpMetaNodeHeartbeat:
 		response := task.Response.(*proto.MetaNodeHeartbeatResponse)
+		c.measureClockSkew(proto.FeatureRoleMetaNode, metaNode.Addr, &metaNode.clock, task, response.Clock)
 		err = c.dealMetaNodeHeartbeatResp(task.OperatorAddr, response)
 	case proto.OpDeleteMetaPartition:
 		response := task.Response.(*proto.DeleteMetaPartitionResponse)
@@ -885,6 +909,10 @@ func (c *Cluster) dealMetaNodeHeartbeatResp(nodeAddr string, resp *proto.MetaNod
 
 	// change cpu util and io used
 	metaNode.CpuUtil.Store(resp.CpuUtil)
+	metaNode.SetResourceThrottle(resp.ResourceThrottle)
+	if resp.ResourceThrottle.Throttled() {
+		log.LogWarnf("[dealMetaNodeHeartbeatResp] metaNode[%v] is throttling itself: %v", metaNode.Addr, resp.ResourceThrottle)
+	}
 	metaNode.updateMetric(resp, c.cfg.MetaNodeThreshold)
 	metaNode.setNodeActive()
 
@@ -972,6 +1000,7 @@ func (c *Cluster) handleDataNodeTaskResponse(nodeAddr string, task *proto.AdminT
 		err = c.handleResponseToLoadDataPartition(task.OperatorAddr, response)
 	case proto.OpDataNodeHeartbeat:
 		response := task.Response.(*proto.DataNodeHeartbeatResponse)
+		c.measureClockSkew(proto.FeatureRoleDataNode, dataNode.Addr, &dataNode.clock, task, response.Clock)
 		err = c.handleDataNodeHeartbeatResp(task.OperatorAddr, response, task.RequestID)
 	case proto.OpVersionOperation:
 		response := task.Response.(*proto.MultiVersionOpResponse)
@@ -1071,13 +1100,20 @@ func (c *Cluster) handleDataNodeHeartbeatResp(nodeAddr string, resp *proto.DataN
 	// change cpu util and io used
 	dataNode.CpuUtil.Store(resp.CpuUtil)
 	dataNode.SetIoUtils(resp.IoUtils)
+	dataNode.SetResourceThrottle(resp.ResourceThrottle)
+	if resp.ResourceThrottle.Throttled() {
+		log.LogWarnf("[handleDataNodeHeartbeatResp] dataNode[%v] is throttling itself: %v", dataNode.Addr, resp.ResourceThrottle)
+	}
+	dataNode.SetLoadProgress(resp.LoadProgress)
+	atomic.StoreUint64(&dataNode.mirrorZonesVersion, resp.DataNodeZonesVersion)
 
 	dataNode.updateNodeMetric(c, resp)
 
 	if err = c.t.putDataNode(dataNode); err != nil {
 		log.LogErrorf("action[handleDataNodeHeartbeatResp] dataNode[%v],zone[%v],node set[%v], err[%v]", dataNode.Addr, dataNode.ZoneName, dataNode.NodeSetID, err)
 	}
-	c.updateDataNode(dataNode, resp.PartitionReports)
+	dataNode.updateStability(c.applyDataNodeReports(dataNode, resp) && len(resp.BadDisks) == 0 &&
+		len(resp.LostDisks) == 0 && dataNode.health.score() >= nodeHealthRecoverScore)
 
 	dataNode.ReceivedForbidWriteOpOfProtoVer0 = resp.ReceivedForbidWriteOpOfProtoVer0
 	if dataNode.ReceivedForbidWriteOpOfProtoVer0 != c.cfg.forbidWriteOpOfProtoVer0 {
@@ -34,6 +34,8 @@ const (
 	colonSplit = ":"
 	commaSplit = ","
 	cfgPeers   = "peers"
+	// the masters of the standby group, raft learners of the peers
+	cfgStandbyPeers = "standbyPeers"
 	// if the data partition has not been reported within this interval  (in terms of seconds), it will be considered as missing.
 	missingDataPartitionInterval        = "missingDataPartitionInterval"
 	cfgDpNoLeaderReportIntervalSec      = "dpNoLeaderReportIntervalSec"
@@ -72,9 +74,31 @@ const (
 	cfgAutoMpMigrate                      = "autoMetaPartitionMigrate"
 	cfgSingleNodeMode                     = "singleNodeMode"
 	cfgMaxWritableDataPartitionCnt        = "maxWritableDataPartitionCnt"
+	cfgAuditReportSignKey                 = "auditReportSignKey"
+
+	cfgRaftBackupEndpoint            = "raftBackupEndpoint"
+	cfgRaftBackupRegion              = "raftBackupRegion"
+	cfgRaftBackupBucket              = "raftBackupBucket"
+	cfgRaftBackupAccessKey           = "raftBackupAccessKey"
+	cfgRaftBackupSecretKey           = "raftBackupSecretKey"
+	cfgRaftBackupPrefix              = "raftBackupPrefix"
+	cfgRaftBackupLogIntervalSec      = "raftBackupLogIntervalSec"
+	cfgRaftBackupSnapshotIntervalSec = "raftBackupSnapshotIntervalSec"
+	cfgRaftBackupBufferMB            = "raftBackupBufferMB"
+	cfgRaftBackupRestore             = "raftBackupRestore"
+	cfgRaftBackupRestoreIndex        = "raftBackupRestoreIndex"
+
+	cfgEventKafkaBrokers = "eventKafkaBrokers"
+	cfgEventKafkaTopic   = "eventKafkaTopic"
+	cfgEventKafkaVersion = "eventKafkaVersion"
 
 	flashNodeHandleReadTimeout   = "flashNodeHandleReadTimeout"
 	flashNodeReadDataNodeTimeout = "flashNodeReadDataNodeTimeout"
+	flashNodePeerFillEnable      = "flashNodePeerFillEnable"
+	flashNodePeerFillTimeout     = "flashNodePeerFillTimeout"
+	flashNodeAdmissionEnable     = "flashNodeAdmissionEnable"
+	flashNodeReadLimitMBps       = "flashNodeReadLimitMBps"
+	flashNodeReadLimitIops       = "flashNodeReadLimitIops"
 )
 
 // default value
@@ -122,6 +146,7 @@ const (
 
 	defaultFlashNodeHandleReadTimeout   = 1000
 	defaultFlashNodeReadDataNodeTimeout = 3000
+	defaultFlashNodePeerFillTimeout     = 500
 
 	defaultMetaNodeGOGC = 100
 	defaultDataNodeGOGC = 100
@@ -159,8 +184,20 @@ type clusterConfig struct {
 	DpMaxRepairErrCnt           uint64
 	DpRepairTimeOut             uint64
 	DpBackupTimeOut             uint64
+	ReplicaTombstoneRetention   uint64 // seconds to keep the data of a dropped replica, 0 deletes it at once
+	ClockSkewWarn               uint64 // milliseconds of clock skew of a node raising an alarm, 0 for the default
+	ClockSkewLimit              uint64 // milliseconds of clock skew of a node giving it no new partitions, 0 disables it
+	HotDpReadQps                uint64 // read qps of a read only data partition given read replicas, 0 disables them
+	HotDpReadReplicas           uint64 // max read replicas of a hot data partition, 0 for the default
+	HeartbeatMaxInterval        uint64 // seconds a stable data node may go without heartbeat, 0 disables the adaptive intervals
+	FlashGroupMinNodesPerZone   uint64 // active flash nodes a flash group keeps in each of its zones, 0 disables it
+	LeaderBalanceMaxTransfers   uint64 // leader transfers of each round of the leader balancer, 0 disables it
+	HotMpSplitQps               uint64 // qps of a meta partition split for being hot, 0 disables it
+	HotMpSplitMemMB             uint64 // memory of a meta partition split for being hot, 0 disables it
+	DecommissionBandwidthMBps   uint64 // bytes of the data partition decommissions started a second, 0 for unlimited
 	peers                       []raftstore.PeerAddress
 	peerAddrs                   []string
+	standbyPeers                []raftstore.PeerAddress
 	heartbeatPort               int64
 	replicaPort                 int64
 	diffReplicaSpaceUsage       uint64
@@ -200,6 +237,11 @@ type clusterConfig struct {
 
 	flashNodeHandleReadTimeout   int
 	flashNodeReadDataNodeTimeout int
+	flashNodePeerFillEnable      bool // try the members of the flash group before the datanode on cache miss
+	flashNodePeerFillTimeout     int
+	flashNodeAdmissionEnable     bool // only cache the missed blocks more popular than the ones they evict
+	flashNodeReadLimitMBps       int  // reads served by each flash node, 0 is unlimited
+	flashNodeReadLimitIops       int
 
 	metaNodeGOGC int
 	dataNodeGOGC int
@@ -211,6 +253,9 @@ type clusterConfig struct {
 	SingleNodeMode     bool
 
 	MaxWritableDataPartitionCnt int
+
+	// key to sign the reports of audit campaigns with, reports are only digested if empty
+	auditReportSignKey string
 }
 
 func newClusterConfig() (cfg *clusterConfig) {
@@ -244,6 +289,7 @@ func newClusterConfig() (cfg *clusterConfig) {
 	cfg.volDelayDeleteTimeHour = defaultVolDelayDeleteTimeHour
 	cfg.flashNodeHandleReadTimeout = defaultFlashNodeHandleReadTimeout
 	cfg.flashNodeReadDataNodeTimeout = defaultFlashNodeReadDataNodeTimeout
+	cfg.flashNodePeerFillTimeout = defaultFlashNodePeerFillTimeout
 	cfg.metaNodeGOGC = defaultMetaNodeGOGC
 	cfg.dataNodeGOGC = defaultDataNodeGOGC
 	cfg.metaNodeMemHighPer = defaultMetaNodeMemHighPer
@@ -282,6 +328,37 @@ func (cfg *clusterConfig) parsePeers(peerStr string) error {
 	return nil
 }
 
+// parseStandbyPeers adds the standby masters to the peers as learners, the peers
+// are parsed before.
+func (cfg *clusterConfig) parseStandbyPeers(peerStr string) error {
+	for _, peerAddr := range strings.Split(peerStr, commaSplit) {
+		id, ip, port, err := parsePeerAddr(peerAddr)
+		if err != nil {
+			return err
+		}
+		for _, peer := range cfg.peers {
+			if peer.ID == id {
+				return fmt.Errorf("standby peer id %v is one of the peers", id)
+			}
+		}
+		peer := raftstore.PeerAddress{Peer: proto.Peer{ID: id, Type: proto.PeerLearner}, Address: ip,
+			HeartbeatPort: int(cfg.heartbeatPort), ReplicaPort: int(cfg.replicaPort)}
+		cfg.peers = append(cfg.peers, peer)
+		cfg.standbyPeers = append(cfg.standbyPeers, peer)
+		AddrDatabase[id] = fmt.Sprintf("%v:%v", ip, port)
+	}
+	return nil
+}
+
+func (cfg *clusterConfig) isStandbyPeer(id uint64) bool {
+	for _, peer := range cfg.standbyPeers {
+		if peer.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (cfg *clusterConfig) checkRaftPartitionCanUseDifferentPort(m *Server, optVal bool) (err error) {
 	cfg.raftPartitionCanUseDifferentPort.Store(optVal)
 	clusterCfg, err := m.rocksDBStore.SeekForPrefix([]byte(clusterPrefix))
@@ -50,6 +50,33 @@ const (
 	forbiddenKey           = "forbidden"
 	deleteVolKey           = "delete"
 
+	interopKeyMappingKey       = "keyMapping"
+	interopMetaSyncKey         = "metaSync"
+	interopRenameVisibilityKey = "renameVisibility"
+
+	sloOpKey        = "op"
+	sloLatencyMsKey = "latencyMs"
+	sloObjectiveKey = "objective"
+
+	placementHostsKey    = "hosts"
+	placementZonesKey    = "zones"
+	placementNodeSetsKey = "nodeSets"
+	placementRequiredKey = "requiredZones"
+	placementSpreadKey   = "spreadZones"
+
+	auditBudgetKey         = "budget"
+	auditConcurrencyKey    = "concurrency"
+	defaultAuditBudgetMins = 60
+
+	mountProfileOptionsKey = "options"
+
+	evictReasonKey = "reason"
+	evictExpireKey = "expire"
+
+	maintenanceScopeKey    = "scope"
+	maintenanceDurationKey = "duration"
+	maintenanceReasonKey   = "reason"
+
 	forceDelVolKey                         = "forceDelVol"
 	ebsBlkSizeKey                          = "ebsBlkSize"
 	clientVersion                          = "version"
@@ -61,6 +88,8 @@ const (
 	authenticateKey                        = "authenticate"
 	akKey                                  = "ak"
 	keywordsKey                            = "keywords"
+	listOffsetKey                          = "offset"
+	listStatusKey                          = "status"
 	zoneNameKey                            = "zoneName"
 	nodesetIdKey                           = "nodesetId"
 	crossZoneKey                           = "crossZone"
@@ -72,6 +101,17 @@ const (
 	nodeAutoRepairRateKey                  = "autoRepairRate"
 	nodeDpRepairTimeOutKey                 = "dpRepairTimeOut"
 	nodeDpBackupKey                        = "dpBackupTimeout"
+	nodeReplicaTombstoneRetentionKey       = "replicaTombstoneRetention"
+	nodeClockSkewWarnKey                   = "clockSkewWarn"
+	nodeClockSkewLimitKey                  = "clockSkewLimit"
+	hotDpReadQpsKey                        = "hotDpReadQps"
+	hotDpReadReplicasKey                   = "hotDpReadReplicas"
+	heartbeatMaxIntervalKey                = "heartbeatMaxInterval"
+	flashGroupMinNodesPerZoneKey           = "flashGroupMinNodesPerZone"
+	leaderBalanceMaxTransfersKey           = "leaderBalanceMaxTransfers"
+	hotMpSplitQpsKey                       = "hotMpSplitQps"
+	hotMpSplitMemMBKey                     = "hotMpSplitMemMB"
+	decommissionBandwidthMBpsKey           = "decommissionBandwidthMBps"
 	nodeDpMaxRepairErrCntKey               = "dpMaxRepairErrCnt"
 	clusterLoadFactorKey                   = "loadFactor"
 	maxDpCntLimitKey                       = "maxDpCntLimit"
@@ -129,6 +169,7 @@ const (
 	ClientIDKey                            = "clientIDKey"
 	verSeqKey                              = "verSeq"
 	Periodic                               = "periodic"
+	immutableSecKey                        = "immutableSec"
 	DecommissionType                       = "decommissionType"
 	decommissionDiskLimit                  = "decommissionDiskLimit"
 	dpRepairBlockSizeKey                   = "dpRepairBlockSize"
@@ -155,6 +196,8 @@ const (
 	quotaClass                             = "quotaClass"
 	quotaOfClass                           = "quotaOfStorageClass"
 	dataMediaTypeKey                       = "dataMediaType"
+	fencePathKey                           = "path"
+	isolationKey                           = "isolation"
 
 	remoteCacheEnable            = "remoteCacheEnable"
 	remoteCacheAutoPrepare       = "remoteCacheAutoPrepare"
@@ -355,6 +398,18 @@ const (
 
 	opSyncAddFlashManualTask    uint32 = 0x72
 	opSyncDeleteFlashManualTask uint32 = 0x73
+
+	opSyncSetMountProfile    uint32 = 0x74
+	opSyncDeleteMountProfile uint32 = 0x75
+
+	opSyncPutClientEviction    uint32 = 0x76
+	opSyncDeleteClientEviction uint32 = 0x77
+
+	opSyncPutVolTimelineEvent    uint32 = 0x78
+	opSyncDeleteVolTimelineEvent uint32 = 0x79
+
+	opSyncPutFlashGroupAudit    uint32 = 0x7A
+	opSyncDeleteFlashGroupAudit uint32 = 0x7B
 )
 
 func init() {
@@ -425,6 +480,18 @@ func init() {
 
 		opSyncS3QosSet,
 		opSyncS3QosDelete,
+
+		opSyncSetMountProfile,
+		opSyncDeleteMountProfile,
+
+		opSyncPutClientEviction,
+		opSyncDeleteClientEviction,
+
+		opSyncPutVolTimelineEvent,
+		opSyncDeleteVolTimelineEvent,
+
+		opSyncPutFlashGroupAudit,
+		opSyncDeleteFlashGroupAudit,
 	} {
 		if _, in := set[op]; in {
 			panic(op)
@@ -494,6 +561,14 @@ const (
 	flashManualTaskPrefix = keySeparator + "flt" + keySeparator
 
 	balanceTaskKey = keySeparator + "balanceTask"
+
+	mountProfilePrefix = keySeparator + "mountProfile" + keySeparator
+
+	clientEvictionPrefix = keySeparator + "clientEviction" + keySeparator
+
+	volTimelinePrefix = keySeparator + "volTimeline" + keySeparator
+
+	flashGroupAuditPrefix = keySeparator + "flashGroupAudit" + keySeparator
 )
 
 // selector enum
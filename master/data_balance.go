@@ -0,0 +1,710 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The data nodes added later, or holding the partitions of the volumes written
+// the most, fill up unevenly. Every dataBalanceInterval the data balancer of
+// the master leader measures the usage ratio of the data nodes of each zone and
+// media type, and moves the replicas of the node the most over the mean of its
+// zone, by more than the threshold, to the nodes under it, preferring the
+// replicas on its most used disk. A move is the decommission of the replica to
+// the node chosen, so that it is throttled and rolled back like the others. At
+// most MaxConcurrency moves run at a time, and the bytes they copy are paced by
+// BandwidthMBps. Pausing or disabling the balancer only stops new moves.
+
+const (
+	dataBalanceInterval           = time.Minute
+	dataBalanceMoveTimeout        = 6 * time.Hour // a move is no longer waited for after
+	dataBalanceHistory            = 64            // the moves finished kept for the status
+	defaultDataBalanceConcurrency = 4
+	maxDataBalanceConcurrency     = 64
+	defaultDataBalanceThreshold   = 0.1
+)
+
+type dataBalanceNode struct {
+	addr     string
+	machine  string
+	total    uint64
+	used     uint64 // the moves planned or running included
+	writable bool
+	disks    []*proto.DataBalanceDisk
+}
+
+func (n *dataBalanceNode) usage() float64 {
+	if n.total == 0 {
+		return 0
+	}
+	return float64(n.used) / float64(n.total)
+}
+
+// the disks of the node from the most used
+func (n *dataBalanceNode) diskRank(path string) int {
+	for i, disk := range n.disks {
+		if disk.Path == path {
+			return i
+		}
+	}
+	return len(n.disks)
+}
+
+// dataBalanceReplica is a replica of a healthy partition that may be moved.
+type dataBalanceReplica struct {
+	partitionID uint64
+	volName     string
+	hosts       []string
+	addr        string
+	disk        string
+	size        uint64
+}
+
+type dataBalanceGroup struct {
+	zone      string
+	mediaType uint32
+	nodes     []*dataBalanceNode
+}
+
+func (g *dataBalanceGroup) totals() (total, used uint64) {
+	for _, n := range g.nodes {
+		total += n.total
+		used += n.used
+	}
+	return
+}
+
+func (g *dataBalanceGroup) mean() float64 {
+	total, used := g.totals()
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total)
+}
+
+// planDataBalanceMoves moves a replica of the node the most over the mean of
+// its group by more than threshold to the least used node under the mean it
+// fits, the replicas of its most used disk and the largest first, while the
+// node stays at least as used as the one it moves to, then of the next node.
+// allow vetoes a node a replica may not be moved to, e.g. by the placement of
+// its volume.
+func planDataBalanceMoves(groups []*dataBalanceGroup, replicas map[string][]*dataBalanceReplica, threshold float64,
+	limit int, allow func(r *dataBalanceReplica, to string) bool,
+) (moves []*proto.DataBalanceMove) {
+	moved := make(map[uint64]bool)
+	for _, g := range groups {
+		mean := g.mean()
+		done := make(map[string]bool)
+		for len(moves) < limit {
+			var from *dataBalanceNode
+			for _, n := range g.nodes {
+				if !done[n.addr] && n.usage() > mean+threshold && (from == nil || n.usage() > from.usage()) {
+					from = n
+				}
+			}
+			if from == nil {
+				break
+			}
+			dsts := make([]*dataBalanceNode, 0, len(g.nodes))
+			for _, n := range g.nodes {
+				if n.writable && n.usage() < mean {
+					dsts = append(dsts, n)
+				}
+			}
+			sort.Slice(dsts, func(i, j int) bool { return dsts[i].usage() < dsts[j].usage() })
+			candidates := replicas[from.addr]
+			sort.SliceStable(candidates, func(i, j int) bool {
+				ri, rj := from.diskRank(candidates[i].disk), from.diskRank(candidates[j].disk)
+				if ri != rj {
+					return ri < rj
+				}
+				return candidates[i].size > candidates[j].size
+			})
+			move := pickDataBalanceMove(from, dsts, candidates, moved, allow)
+			if move == nil {
+				done[from.addr] = true
+				continue
+			}
+			moves = append(moves, move)
+		}
+	}
+	return
+}
+
+func pickDataBalanceMove(from *dataBalanceNode, dsts []*dataBalanceNode, candidates []*dataBalanceReplica,
+	moved map[uint64]bool, allow func(r *dataBalanceReplica, to string) bool,
+) *proto.DataBalanceMove {
+	for _, to := range dsts {
+		for _, r := range candidates {
+			if moved[r.partitionID] || r.size == 0 || to.total-to.used <= r.size {
+				continue
+			}
+			// no overshoot
+			if float64(from.used-r.size)/float64(from.total) < float64(to.used+r.size)/float64(to.total) {
+				continue
+			}
+			if !dataBalanceFits(r, from, to) || (allow != nil && !allow(r, to.addr)) {
+				continue
+			}
+			moved[r.partitionID] = true
+			from.used -= r.size
+			to.used += r.size
+			return &proto.DataBalanceMove{
+				PartitionID: r.partitionID,
+				VolName:     r.volName,
+				From:        from.addr,
+				To:          to.addr,
+				Size:        r.size,
+			}
+		}
+	}
+	return nil
+}
+
+// dataBalanceFits reports whether the node holds no replica of the partition,
+// nor shares a machine with the other replicas.
+func dataBalanceFits(r *dataBalanceReplica, from, to *dataBalanceNode) bool {
+	for _, host := range r.hosts {
+		if host == to.addr {
+			return false
+		}
+		if host == from.addr {
+			continue
+		}
+		if ip, _, err := net.SplitHostPort(host); err == nil && ip == to.machine {
+			return false
+		}
+	}
+	return true
+}
+
+type dataBalancer struct {
+	running  sync.Mutex // held by a round
+	lock     sync.Mutex
+	cfg      proto.DataBalanceConfig
+	moves    []*proto.DataBalanceMove
+	finished []*proto.DataBalanceMove
+	tokens   float64 // the bytes the moves may still start with
+	refilled time.Time
+	round    time.Time
+	// the window of the moves between the zones, and the bytes they moved in it
+	windowOpened time.Time
+	windowMoved  uint64
+}
+
+func newDataBalancer() *dataBalancer {
+	return &dataBalancer{cfg: proto.DataBalanceConfig{
+		MaxConcurrency: defaultDataBalanceConcurrency,
+		Threshold:      defaultDataBalanceThreshold,
+		Zone: proto.ZoneBalanceConfig{
+			MaxTBPerWindow: defaultZoneBalanceTBPerWindow,
+			TargetSpread:   defaultZoneBalanceSpread,
+		},
+	}}
+}
+
+func (b *dataBalancer) getConfig() proto.DataBalanceConfig {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.cfg
+}
+
+// setConfig returns the previous config for rolling back.
+func (b *dataBalancer) setConfig(cfg proto.DataBalanceConfig) (old proto.DataBalanceConfig) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	old = b.cfg
+	b.cfg = cfg
+	return
+}
+
+// loadConfig takes the config persisted, a zero one is left from a master not
+// knowing the balancer.
+func (b *dataBalancer) loadConfig(cfg proto.DataBalanceConfig) {
+	if cfg.MaxConcurrency == 0 {
+		cfg.MaxConcurrency = defaultDataBalanceConcurrency
+	}
+	if cfg.Threshold == 0 {
+		cfg.Threshold = defaultDataBalanceThreshold
+	}
+	if cfg.Zone.MaxTBPerWindow == 0 {
+		cfg.Zone.MaxTBPerWindow = defaultZoneBalanceTBPerWindow
+	}
+	if cfg.Zone.TargetSpread == 0 {
+		cfg.Zone.TargetSpread = defaultZoneBalanceSpread
+	}
+	b.setConfig(cfg)
+}
+
+func (b *dataBalancer) runningMoves() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.moves)
+}
+
+func (b *dataBalancer) finish(move *proto.DataBalanceMove, status, errMsg string) {
+	move.Status, move.Err, move.EndTime = status, errMsg, time.Now().Unix()
+	b.finished = append([]*proto.DataBalanceMove{move}, b.finished...)
+	if len(b.finished) > dataBalanceHistory {
+		b.finished = b.finished[:dataBalanceHistory]
+	}
+}
+
+// refill returns whether the moves may start with the bytes left of the
+// bandwidth, which may be overdrawn by the last move started.
+func (b *dataBalancer) refill(bandwidthMBps int, now time.Time) bool {
+	if bandwidthMBps <= 0 {
+		return true
+	}
+	rate := float64(bandwidthMBps) * util.MB
+	if !b.refilled.IsZero() {
+		b.tokens += rate * now.Sub(b.refilled).Seconds()
+	}
+	if burst := rate * dataBalanceInterval.Seconds(); b.tokens > burst {
+		b.tokens = burst
+	}
+	b.refilled = now
+	return b.tokens > 0
+}
+
+func (c *Cluster) setDataBalanceConfig(cfg proto.DataBalanceConfig) (err error) {
+	old := c.dataBalancer.setConfig(cfg)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setDataBalanceConfig] err[%v]", err)
+		c.dataBalancer.setConfig(old)
+		err = proto.ErrPersistenceByRaft
+	}
+	return
+}
+
+// checkDataBalanceMoves finishes the moves whose replica has moved, or whose
+// decommission failed or was canceled.
+func (c *Cluster) checkDataBalanceMoves() {
+	b := c.dataBalancer
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	running := b.moves[:0]
+	for _, move := range b.moves {
+		dp, err := c.getDataPartitionByID(move.PartitionID)
+		if err != nil {
+			b.finish(move, proto.DataBalanceMoveFailed, err.Error())
+			continue
+		}
+		dp.RLock()
+		moved := dp.hasHost(move.To) && !dp.hasHost(move.From)
+		dp.RUnlock()
+		status := dp.GetDecommissionStatus()
+		switch {
+		case moved:
+			b.finish(move, proto.DataBalanceMoveDone, "")
+			c.recordVolEvent(move.VolName, proto.VolEventDecommission, "dp[%v] replica %v -> %v by the data balancer",
+				move.PartitionID, move.From, move.To)
+		case status == DecommissionFail || status == DecommissionCancel || status == DecommissionInitial:
+			b.finish(move, proto.DataBalanceMoveFailed, fmt.Sprintf("decommission %v %v",
+				GetDecommissionStatusMessage(status), dp.DecommissionErrorMessage))
+		case time.Since(time.Unix(move.StartTime, 0)) > dataBalanceMoveTimeout:
+			b.finish(move, proto.DataBalanceMoveTimeout, "")
+		default:
+			running = append(running, move)
+		}
+	}
+	b.moves = running
+}
+
+// measureDataBalance groups the active data nodes by zone and media type with
+// the running moves taken as done, and lists the replicas of the healthy
+// partitions, out of the moves and the decommissions, that may be moved.
+func (c *Cluster) measureDataBalance() (groups []*dataBalanceGroup, replicas map[string][]*dataBalanceReplica) {
+	type groupKey struct {
+		zone      string
+		mediaType uint32
+	}
+	byKey := make(map[groupKey]*dataBalanceGroup)
+	nodes := make(map[string]*dataBalanceNode)
+	c.dataNodes.Range(func(_, value interface{}) bool {
+		dataNode := value.(*DataNode)
+		dataNode.RLock()
+		if !dataNode.isActive || dataNode.ToBeOffline || dataNode.Total == 0 {
+			dataNode.RUnlock()
+			return true
+		}
+		n := &dataBalanceNode{
+			addr:  dataNode.Addr,
+			total: dataNode.Total,
+			used:  dataNode.Used,
+		}
+		n.writable = dataNode.isWriteAbleWithSizeNoLock(10*util.GB) && !dataNode.RdOnly
+		n.machine, _, _ = net.SplitHostPort(dataNode.Addr)
+		for _, stat := range dataNode.DiskStats {
+			if stat.Total == 0 {
+				continue
+			}
+			n.disks = append(n.disks, &proto.DataBalanceDisk{
+				Path:  stat.DiskPath,
+				Total: stat.Total,
+				Used:  stat.Used,
+				Usage: float64(stat.Used) / float64(stat.Total),
+			})
+		}
+		key := groupKey{zone: dataNode.ZoneName, mediaType: dataNode.MediaType}
+		dataNode.RUnlock()
+		sort.Slice(n.disks, func(i, j int) bool { return n.disks[i].Usage > n.disks[j].Usage })
+		g, ok := byKey[key]
+		if !ok {
+			g = &dataBalanceGroup{zone: key.zone, mediaType: key.mediaType}
+			byKey[key] = g
+		}
+		g.nodes = append(g.nodes, n)
+		nodes[n.addr] = n
+		return true
+	})
+	for _, g := range byKey {
+		sort.Slice(g.nodes, func(i, j int) bool { return g.nodes[i].addr < g.nodes[j].addr })
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].zone != groups[j].zone {
+			return groups[i].zone < groups[j].zone
+		}
+		return groups[i].mediaType < groups[j].mediaType
+	})
+
+	moving := make(map[uint64]bool)
+	c.dataBalancer.lock.Lock()
+	for _, move := range c.dataBalancer.moves {
+		moving[move.PartitionID] = true
+		if from, ok := nodes[move.From]; ok && from.used >= move.Size {
+			from.used -= move.Size
+		}
+		if to, ok := nodes[move.To]; ok {
+			to.used += move.Size
+		}
+	}
+	c.dataBalancer.lock.Unlock()
+
+	replicas = make(map[string][]*dataBalanceReplica)
+	timeout := c.getDataPartitionTimeoutSec()
+	for _, vol := range c.copyVols() {
+		if vol.Status == proto.VolStatusMarkDelete || c.isFaultDomain(vol) {
+			continue
+		}
+		for _, dp := range vol.dataPartitions.clonePartitions() {
+			if moving[dp.PartitionID] {
+				continue
+			}
+			for _, r := range dp.getDataBalanceReplicas(timeout) {
+				if _, ok := nodes[r.addr]; ok {
+					replicas[r.addr] = append(replicas[r.addr], r)
+				}
+			}
+		}
+	}
+	return
+}
+
+// getDataBalanceReplicas returns nil unless all the replicas of the partition
+// are live and it is not being decommissioned or recovered.
+func (partition *DataPartition) getDataBalanceReplicas(timeOutSec int64) (replicas []*dataBalanceReplica) {
+	if !proto.IsNormalDp(partition.PartitionType) || partition.isSpecialReplicaCnt() {
+		return nil
+	}
+	if status := partition.GetDecommissionStatus(); status != DecommissionInitial && status != DecommissionSuccess {
+		return nil
+	}
+	partition.RLock()
+	defer partition.RUnlock()
+	if partition.IsDiscard || partition.isRecover || len(partition.Hosts) != int(partition.ReplicaNum) ||
+		len(partition.getLiveReplicasFromHosts(timeOutSec)) != len(partition.Hosts) {
+		return nil
+	}
+	hosts := append([]string(nil), partition.Hosts...)
+	for _, replica := range partition.Replicas {
+		replicas = append(replicas, &dataBalanceReplica{
+			partitionID: partition.PartitionID,
+			volName:     partition.VolName,
+			hosts:       hosts,
+			addr:        replica.Addr,
+			disk:        replica.DiskPath,
+			size:        replica.Used,
+		})
+	}
+	return
+}
+
+// allowDataBalanceMove keeps the moves within the placement of the volume, and
+// off the nodes in maintenance.
+func (c *Cluster) allowDataBalanceMove(r *dataBalanceReplica, to string) bool {
+	vol, err := c.getVol(r.volName)
+	if err != nil {
+		return false
+	}
+	for _, addr := range []string{r.addr, to} {
+		if dataNode, err := c.dataNode(addr); err != nil || c.dataNodeInMaintenance(dataNode) {
+			return false
+		}
+	}
+	return c.checkPlacementExclusion(vol, TypeDataPartition, []string{to}) == nil
+}
+
+func dataBalanceZones(groups []*dataBalanceGroup, measured map[string]float64) (zones []*proto.DataBalanceZone) {
+	for _, g := range groups {
+		zone := &proto.DataBalanceZone{
+			Zone:      g.zone,
+			MediaType: proto.MediaTypeString(g.mediaType),
+			Mean:      g.mean(),
+		}
+		least, most := 1.0, 0.0
+		for _, n := range g.nodes {
+			node := &proto.DataBalanceNode{
+				Addr:     n.addr,
+				Total:    n.total,
+				Usage:    measured[n.addr],
+				Balanced: n.usage(),
+				Disks:    n.disks,
+			}
+			node.Used = uint64(node.Usage * float64(n.total))
+			if len(n.disks) > 0 {
+				node.DiskSkew = n.disks[0].Usage - n.disks[len(n.disks)-1].Usage
+			}
+			if node.DiskSkew > zone.DiskSkew {
+				zone.DiskSkew = node.DiskSkew
+			}
+			if node.Usage < least {
+				least = node.Usage
+			}
+			if node.Usage > most {
+				most = node.Usage
+			}
+			zone.Nodes = append(zone.Nodes, node)
+		}
+		if most > least {
+			zone.Skew = most - least
+		}
+		zones = append(zones, zone)
+	}
+	return
+}
+
+// balanceData checks the running moves and plans the next ones, the moves
+// between the zones first, which are started unless dryRun, and reports the
+// state of the balancer. A dry run plans the moves disabled too, and the moves
+// between the zones out of the window with the budget of the next one.
+func (c *Cluster) balanceData(dryRun bool) (view *proto.DataBalanceView) {
+	b := c.dataBalancer
+	b.running.Lock()
+	defer b.running.Unlock()
+
+	c.checkDataBalanceMoves()
+	cfg := b.getConfig()
+	groups, replicas := c.measureDataBalance()
+	measured := make(map[string]float64)
+	for _, g := range groups {
+		for _, n := range g.nodes {
+			measured[n.addr] = n.usage()
+		}
+	}
+	spreads := dataBalanceZoneSpreads(groups)
+
+	b.lock.Lock()
+	limit := cfg.MaxConcurrency - len(b.moves)
+	now := time.Now()
+	budget, windowOpen := b.zoneBudget(cfg.Zone, now)
+	if !dryRun {
+		if !(cfg.Enabled || cfg.Zone.Enabled) || cfg.Paused || !b.refill(cfg.BandwidthMBps, now) {
+			limit = 0
+		}
+		b.round = now
+	}
+	b.lock.Unlock()
+
+	var planned []*proto.DataBalanceMove
+	if dryRun || (cfg.Zone.Enabled && windowOpen) {
+		planned = planZoneBalanceMoves(groups, replicas, cfg.Zone, budget, limit, c.zoneBalanceCost(),
+			c.allowZoneBalanceMove)
+	}
+	if dryRun || cfg.Enabled {
+		zoneMoved := make(map[uint64]bool)
+		for _, move := range planned {
+			zoneMoved[move.PartitionID] = true
+		}
+		planned = append(planned, planDataBalanceMoves(groups, replicas, cfg.Threshold, limit-len(planned),
+			func(r *dataBalanceReplica, to string) bool {
+				return !zoneMoved[r.partitionID] && c.allowDataBalanceMove(r, to)
+			})...)
+	}
+	if !dryRun {
+		for _, move := range planned {
+			move.StartTime = now.Unix()
+			if err := c.startDataBalanceMove(move); err != nil {
+				log.LogWarnf("action[balanceData] dp[%v] from[%v] to[%v] err[%v]", move.PartitionID, move.From, move.To, err)
+				b.lock.Lock()
+				b.finish(move, proto.DataBalanceMoveFailed, err.Error())
+				b.lock.Unlock()
+				continue
+			}
+			move.Status = proto.DataBalanceMoveRunning
+			b.lock.Lock()
+			b.moves = append(b.moves, move)
+			b.tokens -= float64(move.Size)
+			if move.ToZone != "" {
+				b.windowMoved += move.Size
+			}
+			exhausted := cfg.BandwidthMBps > 0 && b.tokens <= 0
+			b.lock.Unlock()
+			if exhausted {
+				break
+			}
+		}
+		planned = nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	view = &proto.DataBalanceView{
+		Config:      cfg,
+		Zones:       dataBalanceZones(groups, measured),
+		ZoneSpreads: spreads,
+		WindowOpen:  windowOpen,
+		Running:     append([]*proto.DataBalanceMove(nil), b.moves...),
+		Finished:    append([]*proto.DataBalanceMove(nil), b.finished...),
+		Planned:     planned,
+	}
+	if windowOpen {
+		view.WindowMoved = b.windowMoved
+	}
+	if !b.round.IsZero() {
+		view.LastRound = b.round.Unix()
+	}
+	return
+}
+
+func (c *Cluster) startDataBalanceMove(move *proto.DataBalanceMove) (err error) {
+	dp, err := c.getDataPartitionByID(move.PartitionID)
+	if err != nil {
+		return
+	}
+	src, err := c.dataNode(move.From)
+	if err != nil {
+		return
+	}
+	return c.markDecommissionDataPartition(dp, src, move.To, 0, false, ManualDecommission, 0)
+}
+
+func (c *Cluster) scheduleToBalanceData() {
+	c.runTask(&cTask{
+		tickTime: dataBalanceInterval,
+		name:     "scheduleToBalanceData",
+		function: func() (fin bool) {
+			if c.partition == nil || !c.partition.IsRaftLeader() {
+				return
+			}
+			if cfg := c.dataBalancer.getConfig(); cfg.Enabled || cfg.Zone.Enabled || c.dataBalancer.runningMoves() > 0 {
+				c.balanceData(false)
+			}
+			return
+		},
+	})
+}
+
+// enableDataBalance turns the data balancer on or off and sets its limits,
+// the limits not given are kept.
+func (m *Server) enableDataBalance(w http.ResponseWriter, r *http.Request) {
+	var (
+		enable      common.Bool
+		concurrency common.Int
+		bandwidth   common.Int
+		threshold   common.Float
+		cfg         proto.DataBalanceConfig
+		err         error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminDataBalanceEnable))
+	defer func() {
+		doStatAndMetric(proto.AdminDataBalanceEnable, metric, err, nil)
+		AuditLog(r, proto.AdminDataBalanceEnable, fmt.Sprintf("set data balance to %+v", cfg), err)
+	}()
+	cfg = m.cluster.dataBalancer.getConfig()
+	concurrency.V, bandwidth.V, threshold.V = int64(cfg.MaxConcurrency), int64(cfg.BandwidthMBps), cfg.Threshold
+	if err = parseArgs(r, enable.Enable(), concurrency.Key("maxConcurrency").OmitEmpty(),
+		bandwidth.Key("bandwidthMBps").OmitEmpty(), threshold.Key("threshold").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if concurrency.V < 1 || concurrency.V > maxDataBalanceConcurrency {
+		err = fmt.Errorf("maxConcurrency[%v] is out of [1, %v]", concurrency.V, maxDataBalanceConcurrency)
+	} else if bandwidth.V < 0 {
+		err = fmt.Errorf("bandwidthMBps[%v] is negative", bandwidth.V)
+	} else if threshold.V <= 0 || threshold.V >= 1 {
+		err = fmt.Errorf("threshold[%v] is out of (0, 1)", threshold.V)
+	}
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	cfg.Enabled = enable.V
+	cfg.MaxConcurrency, cfg.BandwidthMBps, cfg.Threshold = int(concurrency.V), int(bandwidth.V), threshold.V
+	if err = m.cluster.setDataBalanceConfig(cfg); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set data balance to %+v successfully", cfg)))
+}
+
+// pauseDataBalance stops the data balancer from starting new moves, or lets
+// it go on.
+func (m *Server) pauseDataBalance(w http.ResponseWriter, r *http.Request) {
+	var (
+		pause common.Bool
+		cfg   proto.DataBalanceConfig
+		err   error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminDataBalancePause))
+	defer func() {
+		doStatAndMetric(proto.AdminDataBalancePause, metric, err, nil)
+		AuditLog(r, proto.AdminDataBalancePause, fmt.Sprintf("set data balance paused[%v]", pause.V), err)
+	}()
+	if err = parseArgs(r, pause.Key("pause")); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	cfg = m.cluster.dataBalancer.getConfig()
+	cfg.Paused = pause.V
+	if err = m.cluster.setDataBalanceConfig(cfg); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set data balance paused[%v] successfully", pause.V)))
+}
+
+// getDataBalanceStatus reports the usage of the data nodes, the moves running
+// and finished, and the moves the next round would start.
+func (m *Server) getDataBalanceStatus(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminDataBalanceStatus))
+	defer func() {
+		doStatAndMetric(proto.AdminDataBalanceStatus, metric, nil, nil)
+	}()
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.balanceData(true)))
+}
@@ -0,0 +1,305 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDataBalanceGroup() (*dataBalanceGroup, map[string][]*dataBalanceReplica) {
+	node := func(addr string, used uint64, writable bool) *dataBalanceNode {
+		return &dataBalanceNode{
+			addr:     addr + ":17310",
+			machine:  addr,
+			total:    100 * util.GB,
+			used:     used * util.GB,
+			writable: writable,
+			disks:    []*proto.DataBalanceDisk{{Path: "/disk1"}, {Path: "/disk2"}},
+		}
+	}
+	g := &dataBalanceGroup{zone: testZone1, nodes: []*dataBalanceNode{
+		node("192.168.0.1", 90, true),
+		node("192.168.0.2", 50, true),
+		node("192.168.0.3", 10, true),
+		node("192.168.0.4", 10, false),
+	}}
+	replica := func(id uint64, disk string, size uint64, hosts ...string) *dataBalanceReplica {
+		return &dataBalanceReplica{
+			partitionID: id,
+			volName:     "vol",
+			hosts:       append([]string{"192.168.0.1:17310"}, hosts...),
+			addr:        "192.168.0.1:17310",
+			disk:        disk,
+			size:        size * util.GB,
+		}
+	}
+	replicas := map[string][]*dataBalanceReplica{"192.168.0.1:17310": {
+		replica(1, "/disk2", 15, "192.168.0.2:17310"),
+		replica(2, "/disk1", 10, "192.168.0.3:17311"), // on the machine of the least used node
+		replica(3, "/disk1", 20, "192.168.0.2:17310"),
+		replica(4, "/disk2", 50, "192.168.0.2:17310"), // overshoots
+	}}
+	return g, replicas
+}
+
+func TestPlanDataBalanceMoves(t *testing.T) {
+	g, replicas := newTestDataBalanceGroup()
+	require.InDelta(t, 0.4, g.mean(), 1e-9)
+	moves := planDataBalanceMoves([]*dataBalanceGroup{g}, replicas, 0.1, 10, nil)
+	require.Len(t, moves, 2)
+	// the replicas of the most used disk first
+	require.Equal(t, uint64(3), moves[0].PartitionID)
+	require.Equal(t, "192.168.0.3:17310", moves[0].To)
+	require.Equal(t, uint64(1), moves[1].PartitionID)
+	require.Equal(t, "192.168.0.3:17310", moves[1].To)
+	require.Equal(t, uint64(55*util.GB), g.nodes[0].used)
+	require.Equal(t, uint64(45*util.GB), g.nodes[2].used)
+
+	g, replicas = newTestDataBalanceGroup()
+	require.Len(t, planDataBalanceMoves([]*dataBalanceGroup{g}, replicas, 0.1, 1, nil), 1)
+
+	g, replicas = newTestDataBalanceGroup()
+	require.Empty(t, planDataBalanceMoves([]*dataBalanceGroup{g}, replicas, 0.6, 10, nil), "within the threshold")
+
+	g, replicas = newTestDataBalanceGroup()
+	moves = planDataBalanceMoves([]*dataBalanceGroup{g}, replicas, 0.1, 10, func(r *dataBalanceReplica, to string) bool {
+		return r.partitionID != 3
+	})
+	require.Len(t, moves, 1)
+	require.Equal(t, uint64(1), moves[0].PartitionID)
+}
+
+func TestDataBalancerRefill(t *testing.T) {
+	b := newDataBalancer()
+	now := time.Now()
+	require.True(t, b.refill(0, now), "unlimited")
+	require.False(t, b.refill(100, now))
+	require.True(t, b.refill(100, now.Add(time.Second)))
+	require.InDelta(t, 100*util.MB, b.tokens, 1)
+	require.True(t, b.refill(100, now.Add(time.Hour)))
+	require.InDelta(t, 100*util.MB*dataBalanceInterval.Seconds(), b.tokens, 1, "one interval at most")
+	b.tokens -= 200 * util.MB * dataBalanceInterval.Seconds()
+	require.False(t, b.refill(100, now.Add(time.Hour+time.Second)), "overdrawn")
+}
+
+func TestDataBalanceAPI(t *testing.T) {
+	old := server.cluster.dataBalancer.getConfig()
+	defer func() { require.NoError(t, server.cluster.setDataBalanceConfig(old)) }()
+
+	require.NoError(t, mc.AdminAPI().PauseDataBalance(true))
+	cfg := &proto.DataBalanceConfig{Enabled: true, MaxConcurrency: 2, BandwidthMBps: 100, Threshold: 0.2}
+	require.NoError(t, mc.AdminAPI().SetDataBalance(cfg))
+	view, err := mc.AdminAPI().GetDataBalanceStatus()
+	require.NoError(t, err)
+	require.Equal(t, proto.DataBalanceConfig{Enabled: true, Paused: true, MaxConcurrency: 2, BandwidthMBps: 100, Threshold: 0.2,
+		Zone: old.Zone}, view.Config)
+	require.NotEmpty(t, view.Zones)
+	require.LessOrEqual(t, len(view.Planned), 2)
+
+	for _, invalid := range []proto.DataBalanceConfig{
+		{MaxConcurrency: 0, Threshold: 0.1},
+		{MaxConcurrency: maxDataBalanceConcurrency + 1, Threshold: 0.1},
+		{MaxConcurrency: 1, Threshold: 1},
+		{MaxConcurrency: 1, BandwidthMBps: -1, Threshold: 0.1},
+	} {
+		require.Error(t, mc.AdminAPI().SetDataBalance(&invalid), "%+v", invalid)
+	}
+
+	// paused, no move is started
+	server.cluster.balanceData(false)
+	require.Zero(t, server.cluster.dataBalancer.runningMoves())
+
+	require.Equal(t, view.Config, newClusterValue(server.cluster).DataBalance)
+	b := newDataBalancer()
+	b.loadConfig(proto.DataBalanceConfig{})
+	require.Equal(t, defaultDataBalanceConcurrency, b.getConfig().MaxConcurrency)
+	require.Equal(t, defaultDataBalanceThreshold, b.getConfig().Threshold)
+	require.Equal(t, proto.ZoneBalanceConfig{MaxTBPerWindow: defaultZoneBalanceTBPerWindow,
+		TargetSpread: defaultZoneBalanceSpread}, b.getConfig().Zone)
+}
+
+func newTestZoneBalanceGroups() ([]*dataBalanceGroup, map[string][]*dataBalanceReplica) {
+	group := func(zone, addr string, used uint64) *dataBalanceGroup {
+		return &dataBalanceGroup{zone: zone, nodes: []*dataBalanceNode{{
+			addr:     addr + ":17310",
+			machine:  addr,
+			total:    100 * util.GB,
+			used:     used * util.GB,
+			writable: true,
+		}}}
+	}
+	groups := []*dataBalanceGroup{
+		group(testZone1, "192.168.0.1", 80),
+		group(testZone2, "192.168.0.2", 20),
+		group(testZone3, "192.168.0.3", 30),
+	}
+	replica := func(id uint64, size uint64) *dataBalanceReplica {
+		return &dataBalanceReplica{
+			partitionID: id,
+			volName:     "vol",
+			hosts:       []string{"192.168.0.1:17310", "192.168.1.1:17310"},
+			addr:        "192.168.0.1:17310",
+			size:        size * util.GB,
+		}
+	}
+	replicas := map[string][]*dataBalanceReplica{"192.168.0.1:17310": {
+		replica(1, 30), // overshoots but to the least used zone
+		replica(2, 10),
+		replica(3, 10),
+		replica(4, 5),
+	}}
+	return groups, replicas
+}
+
+func TestPlanZoneBalanceMoves(t *testing.T) {
+	cost := func(from, to string) float64 {
+		if from > to {
+			from, to = to, from
+		}
+		switch {
+		case from == testZone1 && to == testZone2:
+			return 5
+		case from == testZone1 && to == testZone3:
+			return 1
+		}
+		return 0
+	}
+	cfg := proto.ZoneBalanceConfig{Enabled: true, MaxTBPerWindow: 1, TargetSpread: 0.1}
+	groups, replicas := newTestZoneBalanceGroups()
+	spreads := dataBalanceZoneSpreads(groups)
+	require.Len(t, spreads, 1)
+	require.InDelta(t, 0.6, spreads[0].Spread, 1e-9)
+	require.Equal(t, testZone1, spreads[0].MostUsed)
+	require.Equal(t, testZone2, spreads[0].LeastUsed)
+	moves := planZoneBalanceMoves(groups, replicas, cfg, util.TB, 10, cost, nil)
+	require.Len(t, moves, 3)
+	// the cheapest zone first, then the least used one once the cheapest is over the mean
+	require.Equal(t, uint64(2), moves[0].PartitionID)
+	require.Equal(t, testZone3, moves[0].ToZone)
+	require.Equal(t, uint64(3), moves[1].PartitionID)
+	require.Equal(t, "192.168.0.3:17310", moves[1].To)
+	require.Equal(t, uint64(4), moves[2].PartitionID)
+	require.Equal(t, testZone2, moves[2].ToZone)
+	require.Equal(t, testZone1, moves[2].FromZone)
+	require.Equal(t, uint64(55*util.GB), groups[0].nodes[0].used)
+
+	groups, replicas = newTestZoneBalanceGroups()
+	require.Len(t, planZoneBalanceMoves(groups, replicas, cfg, util.TB, 1, cost, nil), 1)
+
+	groups, replicas = newTestZoneBalanceGroups()
+	moves = planZoneBalanceMoves(groups, replicas, cfg, 15*util.GB, 10, cost, nil)
+	require.Len(t, moves, 2, "within the budget")
+	require.Equal(t, uint64(4), moves[1].PartitionID)
+
+	groups, replicas = newTestZoneBalanceGroups()
+	ceiling := cfg
+	ceiling.MaxZoneCost = 2
+	moves = planZoneBalanceMoves(groups, replicas, ceiling, util.TB, 10, cost, nil)
+	require.Len(t, moves, 2)
+	for _, move := range moves {
+		require.Equal(t, testZone3, move.ToZone, "over the cost ceiling")
+	}
+
+	groups, replicas = newTestZoneBalanceGroups()
+	moves = planZoneBalanceMoves(groups, replicas, cfg, util.TB, 10, cost,
+		func(r *dataBalanceReplica, fromZone, toZone, to string) bool { return toZone != testZone3 })
+	require.Len(t, moves, 1)
+	require.Equal(t, uint64(1), moves[0].PartitionID)
+	require.Equal(t, testZone2, moves[0].ToZone)
+
+	groups, replicas = newTestZoneBalanceGroups()
+	spread := cfg
+	spread.TargetSpread = 0.7
+	require.Empty(t, planZoneBalanceMoves(groups, replicas, spread, util.TB, 10, cost, nil), "within the spread")
+}
+
+func TestZoneBalanceWindow(t *testing.T) {
+	at := func(day, hour, min int) time.Time { return time.Date(2025, 1, day, hour, min, 0, 0, time.Local) }
+	for _, c := range []struct {
+		window string
+		now    time.Time
+		opened time.Time
+		ok     bool
+	}{
+		{"", at(2, 3, 0), at(2, 0, 0), true},
+		{"03:00-03:00", at(2, 1, 0), at(2, 0, 0), true},
+		{"01:00-05:00", at(2, 3, 0), at(2, 1, 0), true},
+		{"01:00-05:00", at(2, 5, 0), time.Time{}, false},
+		{"22:00-02:00", at(2, 23, 0), at(2, 22, 0), true},
+		{"22:00-02:00", at(2, 1, 30), at(1, 22, 0), true},
+		{"22:00-02:00", at(2, 12, 0), time.Time{}, false},
+	} {
+		opened, ok := zoneBalanceWindowOpened(c.window, c.now)
+		require.Equal(t, c.ok, ok, "%v at %v", c.window, c.now)
+		if ok {
+			require.Equal(t, c.opened, opened, "%v at %v", c.window, c.now)
+		}
+	}
+	for _, invalid := range []string{"01:00", "1-2", "25:00-01:00", "01:00-02:00-03:00"} {
+		_, _, err := parseZoneBalanceWindow(invalid)
+		require.Error(t, err, invalid)
+	}
+
+	b := newDataBalancer()
+	cfg := proto.ZoneBalanceConfig{Window: "01:00-05:00", MaxTBPerWindow: 1}
+	budget, open := b.zoneBudget(cfg, at(2, 2, 0))
+	require.True(t, open)
+	require.Equal(t, uint64(util.TB), budget)
+	b.windowMoved += 400 * util.GB
+	budget, _ = b.zoneBudget(cfg, at(2, 3, 0))
+	require.Equal(t, uint64(util.TB-400*util.GB), budget)
+	b.windowMoved += util.TB
+	budget, open = b.zoneBudget(cfg, at(2, 4, 0))
+	require.True(t, open)
+	require.Zero(t, budget, "used up")
+	budget, open = b.zoneBudget(cfg, at(2, 6, 0))
+	require.False(t, open)
+	require.Equal(t, uint64(util.TB), budget, "the budget of the next window")
+	budget, _ = b.zoneBudget(cfg, at(3, 2, 0))
+	require.Equal(t, uint64(util.TB), budget, "a new window")
+}
+
+func TestZoneBalanceAPI(t *testing.T) {
+	old := server.cluster.dataBalancer.getConfig()
+	defer func() { require.NoError(t, server.cluster.setDataBalanceConfig(old)) }()
+
+	cfg := &proto.ZoneBalanceConfig{Enabled: true, Window: "01:00-05:00", MaxTBPerWindow: 2, TargetSpread: 0.2, MaxZoneCost: 3}
+	require.NoError(t, mc.AdminAPI().SetZoneBalance(cfg))
+	view, err := mc.AdminAPI().GetDataBalanceStatus()
+	require.NoError(t, err)
+	require.Equal(t, *cfg, view.Config.Zone)
+	require.Equal(t, old.MaxConcurrency, view.Config.MaxConcurrency)
+
+	// the window is kept
+	cfg.Window, cfg.Enabled = "", false
+	require.NoError(t, mc.AdminAPI().SetZoneBalance(cfg))
+	require.Equal(t, "01:00-05:00", server.cluster.dataBalancer.getConfig().Zone.Window)
+	require.False(t, server.cluster.dataBalancer.getConfig().Zone.Enabled)
+
+	for _, invalid := range []proto.ZoneBalanceConfig{
+		{Window: "1-5", MaxTBPerWindow: 1, TargetSpread: 0.1},
+		{MaxTBPerWindow: 0, TargetSpread: 0.1},
+		{MaxTBPerWindow: 1, TargetSpread: 1},
+		{MaxTBPerWindow: 1, TargetSpread: 0.1, MaxZoneCost: -1},
+	} {
+		require.Error(t, mc.AdminAPI().SetZoneBalance(&invalid), "%+v", invalid)
+	}
+	require.Equal(t, server.cluster.dataBalancer.getConfig(), newClusterValue(server.cluster).DataBalance)
+}
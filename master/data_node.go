@@ -80,14 +80,28 @@ type DataNode struct {
 	DpCntLimit                         uint64             `json:"-"` // max count of data partition in a data node
 	CpuUtil                            atomicutil.Float64 `json:"-"`
 	ioUtils                            atomic.Value       `json:"-"`
+	resourceThrottle                   atomic.Value       `json:"-"`
+	loadProgress                       atomic.Value       `json:"-"`
 	DecommissionDiskList               []string           // NOTE: the disks that running decommission
 	DecommissionDpTotal                int
 	DecommissionSyncMutex              sync.Mutex
 	BackupDataPartitions               []proto.BackupDataPartitionInfo
 	MediaType                          uint32
 	ReceivedForbidWriteOpOfProtoVer0   bool
+	Features                           proto.FeatureBits
 	DiskOpLogs                         []proto.OpLog
 	DpOpLogs                           []proto.OpLog
+	health                             nodeHealth
+	clock                              clockSkew
+	zoneIsolation                      atomicutil.Int32
+	reportLock                         sync.Mutex
+	reports                            map[uint64]*proto.DataPartitionReport // merged from the full and the delta heartbeats
+	reportSeq                          uint64
+	fullReportTime                     time.Time
+	heartbeatInterval                  time.Duration
+	heartbeatSentTime                  time.Time
+	stableHeartbeats                   int
+	mirrorZonesVersion                 uint64 // of the zones of the data nodes the node holds
 }
 
 func newDataNode(addr, raftHeartbeatPort, raftReplicaPort, zoneName, clusterID string, mediaType uint32) (dataNode *DataNode) {
@@ -103,6 +117,7 @@ func newDataNode(addr, raftHeartbeatPort, raftReplicaPort, zoneName, clusterID s
 	dataNode.ZoneName = zoneName
 	dataNode.LastUpdateTime = time.Now().Add(-time.Minute)
 	dataNode.TaskManager = newAdminTaskManager(dataNode.Addr, clusterID)
+	dataNode.TaskManager.health = &dataNode.health
 	dataNode.DecommissionStatus = DecommissionInitial
 	dataNode.DecommissionFirstHostParallelLimit = defaultDecommissionFirstHostParallelLimit
 	dataNode.CpuUtil.Store(0)
@@ -125,16 +140,43 @@ func (dataNode *DataNode) SetIoUtils(used map[string]float64) {
 	dataNode.ioUtils.Store(used)
 }
 
-func (dataNode *DataNode) checkLiveness() {
+func (dataNode *DataNode) GetResourceThrottle() (state proto.ResourceThrottleState) {
+	state, _ = dataNode.resourceThrottle.Load().(proto.ResourceThrottleState)
+	return
+}
+
+func (dataNode *DataNode) SetResourceThrottle(state proto.ResourceThrottleState) {
+	dataNode.resourceThrottle.Store(state)
+}
+
+func (dataNode *DataNode) GetLoadProgress() (p proto.DataNodeLoadProgress) {
+	p, _ = dataNode.loadProgress.Load().(proto.DataNodeLoadProgress)
+	p.Addr = dataNode.Addr
+	return
+}
+
+func (dataNode *DataNode) SetLoadProgress(p proto.DataNodeLoadProgress) {
+	dataNode.loadProgress.Store(p)
+}
+
+// checkLiveness returns true if the data node goes offline by this check.
+func (dataNode *DataNode) checkLiveness() (offline bool) {
 	dataNode.Lock()
 	defer dataNode.Unlock()
-	if time.Since(dataNode.ReportTime) > time.Second*time.Duration(defaultNodeTimeOutSec) {
+	timeout := noHeartBeatTimes * dataNode.getHeartbeatInterval()
+	if time.Since(dataNode.ReportTime) > timeout {
+		if dataNode.isActive {
+			dataNode.health.inactive(time.Now())
+			offline = true
+		}
 		dataNode.isActive = false
+		dataNode.stableHeartbeats = 0
 		msg := fmt.Sprintf("datanode[%v] report time[%v],since report time[%v], need gap [%v]",
-			dataNode.Addr, dataNode.ReportTime, time.Since(dataNode.ReportTime), time.Second*time.Duration(defaultNodeTimeOutSec))
+			dataNode.Addr, dataNode.ReportTime, time.Since(dataNode.ReportTime), timeout)
 		log.LogWarnf("action[checkLiveness]  %v", msg)
 		auditlog.LogMasterOp("DataNodeLive", msg, nil)
 	}
+	return
 }
 
 func (dataNode *DataNode) badPartitions(diskPath string, c *Cluster, ignoreDiscard bool) (partitions []*DataPartition) {
@@ -224,8 +266,8 @@ func (dataNode *DataNode) updateNodeMetric(c *Cluster, resp *proto.DataNodeHeart
 	}
 	dataNode.ZoneName = resp.ZoneName
 	dataNode.DataPartitionCount = resp.CreatedPartitionCnt
-	dataNode.DataPartitionReports = resp.PartitionReports
 	dataNode.TotalPartitionSize = resp.TotalPartitionSize
+	dataNode.Features = resp.Features
 
 	updated, removedDisks := dataNode.updateDisks(resp.AllDisks, resp.BadDisks)
 	dataNode.BadDiskStats = resp.BadDiskStats
@@ -244,6 +286,8 @@ func (dataNode *DataNode) updateNodeMetric(c *Cluster, resp *proto.DataNodeHeart
 	}
 	dataNode.ReportTime = time.Now()
 	dataNode.isActive = true
+	dataNode.health.heartbeat(dataNode.ReportTime, dataNode.getHeartbeatInterval(),
+		len(resp.BadDisks)+len(resp.LostDisks), resp.CpuUtil/100)
 
 	if len(removedDisks) != 0 {
 		log.LogInfof("[updateNodeMetric] dataNode %v removedDisks (%v)", dataNode.Addr, removedDisks)
@@ -404,14 +448,15 @@ func (dataNode *DataNode) GetStorageInfo() string {
 
 func (dataNode *DataNode) isWriteAbleWithSizeNoLock(size uint64) (ok bool) {
 	if dataNode.isActive && dataNode.AvailableSpace > size && !dataNode.RdOnly &&
-		dataNode.Total > dataNode.Used && (dataNode.Total-dataNode.Used) > size {
+		dataNode.Total > dataNode.Used && (dataNode.Total-dataNode.Used) > size && !dataNode.clock.isGated() &&
+		!dataNode.isZoneIsolated() {
 		ok = true
 	}
 	if !ok {
 		log.LogInfof("node %v, isActive %v, RdOnly %v, Total %v AvailableSpace %v, "+
-			"used %v, dp cnt %v required size %v",
+			"used %v, dp cnt %v required size %v clock gated %v zone isolation %v",
 			dataNode.Addr, dataNode.isActive, dataNode.RdOnly, dataNode.Total, dataNode.AvailableSpace, dataNode.Used,
-			dataNode.DataPartitionCount, size)
+			dataNode.DataPartitionCount, size, dataNode.clock.isGated(), proto.ZoneIsolationString(dataNode.zoneIsolation.Load()))
 	}
 
 	return
@@ -824,6 +869,10 @@ func (dataNode *DataNode) createTaskToRecoverBadDisk(diskPath string) (err error
 	return err
 }
 
+func (dataNode *DataNode) getHealth() *nodeHealth {
+	return &dataNode.health
+}
+
 func (dataNode *DataNode) IsOffline() bool {
 	// check old version dataNode
 	if len(dataNode.AllDisks) == 0 {
@@ -0,0 +1,240 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The heartbeats of the data nodes carry the partition reports changed since
+// the last ones the master applied only, the master keeps the others and
+// asks for all of them when it has none, when a delta does not apply on the
+// ones it has and every dataNodeFullReportInterval. A data node stable for a
+// while is sent the heartbeats at longer intervals, up to
+// HeartbeatMaxInterval, and is given as many of them to go inactive.
+const (
+	dataNodeFullReportInterval = 10 * time.Minute
+	// the heartbeat interval of a stable data node doubles every such heartbeats
+	heartbeatStableStep = 10
+)
+
+func (c *Cluster) getHeartbeatMaxInterval() time.Duration {
+	return time.Duration(atomic.LoadUint64(&c.cfg.HeartbeatMaxInterval)) * time.Second
+}
+
+// heartbeatMaxIntervalLimit is the longest interval letting a data node go
+// inactive before its replicas time out.
+func (c *Cluster) heartbeatMaxIntervalLimit() time.Duration {
+	return time.Duration(c.getDataPartitionTimeoutSec()/noHeartBeatTimes) * time.Second
+}
+
+func (c *Cluster) setHeartbeatMaxInterval(val uint64) (err error) {
+	interval := time.Duration(val) * time.Second
+	if val != 0 && (interval < time.Second*defaultIntervalToCheckHeartbeat || interval > c.heartbeatMaxIntervalLimit()) {
+		return fmt.Errorf("%w: heartbeatMaxInterval(%v) is out of [%v, %v]", proto.ErrInvalidCfg,
+			interval, time.Second*defaultIntervalToCheckHeartbeat, c.heartbeatMaxIntervalLimit())
+	}
+	oldVal := atomic.LoadUint64(&c.cfg.HeartbeatMaxInterval)
+	atomic.StoreUint64(&c.cfg.HeartbeatMaxInterval, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setHeartbeatMaxInterval] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.HeartbeatMaxInterval, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+// dataNodeHeartbeatMaxInterval is the longest interval between the heartbeats
+// of a stable data node, the base interval if the adaptive intervals are off.
+func (c *Cluster) dataNodeHeartbeatMaxInterval() time.Duration {
+	base := time.Second * defaultIntervalToCheckHeartbeat
+	interval := c.getHeartbeatMaxInterval()
+	if limit := c.heartbeatMaxIntervalLimit(); interval > limit {
+		interval = limit
+	}
+	if interval < base {
+		return base
+	}
+	return interval
+}
+
+// heartbeatDue tells whether the data node is to be sent a heartbeat now, at
+// an interval doubling every heartbeatStableStep stable heartbeats up to max.
+func (dataNode *DataNode) heartbeatDue(now time.Time, max time.Duration) bool {
+	dataNode.Lock()
+	defer dataNode.Unlock()
+	base := time.Second * defaultIntervalToCheckHeartbeat
+	interval := base
+	for n := heartbeatStableStep; n <= dataNode.stableHeartbeats && interval < max; n += heartbeatStableStep {
+		interval *= 2
+	}
+	if interval > max {
+		interval = max
+	}
+	dataNode.heartbeatInterval = interval
+	// the checks tick every base interval, give or take
+	if !dataNode.heartbeatSentTime.IsZero() && now.Sub(dataNode.heartbeatSentTime) < interval-base/2 {
+		return false
+	}
+	dataNode.heartbeatSentTime = now
+	return true
+}
+
+func (dataNode *DataNode) getHeartbeatInterval() time.Duration {
+	if dataNode.heartbeatInterval < time.Second*defaultIntervalToCheckHeartbeat {
+		return time.Second * defaultIntervalToCheckHeartbeat
+	}
+	return dataNode.heartbeatInterval
+}
+
+// updateStability counts the stable heartbeats in a row, the interval goes
+// back to the base one on an unstable heartbeat.
+func (dataNode *DataNode) updateStability(stable bool) {
+	dataNode.Lock()
+	defer dataNode.Unlock()
+	if stable {
+		dataNode.stableHeartbeats++
+	} else {
+		dataNode.stableHeartbeats = 0
+	}
+}
+
+// reportSeqToAck returns the seq of the partition reports of the node to ask
+// the changes since, 0 to ask for all of them.
+func (dataNode *DataNode) reportSeqToAck(now time.Time) uint64 {
+	dataNode.reportLock.Lock()
+	defer dataNode.reportLock.Unlock()
+	if dataNode.reports == nil || now.Sub(dataNode.fullReportTime) > dataNodeFullReportInterval {
+		return 0
+	}
+	return dataNode.reportSeq
+}
+
+// applyDataNodeReports applies the partition reports of a heartbeat, all of
+// them or the changed ones, and tells whether the partitions of the node are
+// the same as the ones of the last heartbeat.
+func (c *Cluster) applyDataNodeReports(dataNode *DataNode, resp *proto.DataNodeHeartbeatResponse) (samePartitions bool) {
+	dataNode.reportLock.Lock()
+	defer dataNode.reportLock.Unlock()
+	if resp.DeltaBaseSeq == 0 {
+		reports := make(map[uint64]*proto.DataPartitionReport, len(resp.PartitionReports))
+		for _, vr := range resp.PartitionReports {
+			if vr != nil {
+				reports[vr.PartitionID] = vr
+			}
+		}
+		dataNode.reports = reports
+		dataNode.reportSeq = resp.ReportSeq
+		dataNode.fullReportTime = time.Now()
+		c.updateDataNode(dataNode, resp.PartitionReports)
+		dataNode.setPartitionReports(resp.PartitionReports)
+		return false
+	}
+	if dataNode.reports == nil || resp.DeltaBaseSeq != dataNode.reportSeq {
+		// the changed ones are up to date, the others are asked for next
+		log.LogWarnf("action[applyDataNodeReports] dataNode[%v] delta on reports[%v] instead of [%v]",
+			dataNode.Addr, resp.DeltaBaseSeq, dataNode.reportSeq)
+		c.updateDataNode(dataNode, resp.PartitionReports)
+		dataNode.reports = nil
+		return false
+	}
+
+	samePartitions = len(resp.RemovedPartitions) == 0
+	changed := make(map[uint64]struct{}, len(resp.PartitionReports))
+	for _, vr := range resp.PartitionReports {
+		if vr == nil {
+			continue
+		}
+		if _, ok := dataNode.reports[vr.PartitionID]; !ok {
+			samePartitions = false
+		}
+		dataNode.reports[vr.PartitionID] = vr
+		changed[vr.PartitionID] = struct{}{}
+	}
+	for _, id := range resp.RemovedPartitions {
+		delete(dataNode.reports, id)
+	}
+	dataNode.reportSeq = resp.ReportSeq
+	c.updateDataNode(dataNode, resp.PartitionReports)
+
+	reports := make([]*proto.DataPartitionReport, 0, len(dataNode.reports))
+	for id, vr := range dataNode.reports {
+		if _, ok := changed[id]; !ok {
+			c.touchDataPartition(dataNode, vr)
+		}
+		reports = append(reports, vr)
+	}
+	dataNode.setPartitionReports(reports)
+	return
+}
+
+func (dataNode *DataNode) setPartitionReports(reports []*proto.DataPartitionReport) {
+	dataNode.Lock()
+	dataNode.DataPartitionReports = reports
+	dataNode.Unlock()
+}
+
+// touchDataPartition keeps the replica of a partition reported unchanged
+// alive, without going through all of its report again.
+func (c *Cluster) touchDataPartition(dataNode *DataNode, vr *proto.DataPartitionReport) {
+	var (
+		dp  *DataPartition
+		err error
+	)
+	if vr.VolName != "" {
+		var vol *Vol
+		if vol, err = c.getVol(vr.VolName); err != nil {
+			return
+		}
+		dp, err = vol.getDataPartitionByID(vr.PartitionID)
+	} else {
+		dp, err = c.getDataPartitionByID(vr.PartitionID)
+	}
+	if err != nil {
+		return
+	}
+	if vr.ReadReplica {
+		dp.updateReadReplicaMetric(vr, dataNode, c)
+		return
+	}
+	dp.touchReplica(vr, dataNode)
+}
+
+func (partition *DataPartition) touchReplica(vr *proto.DataPartitionReport, dataNode *DataNode) {
+	partition.Lock()
+	defer partition.Unlock()
+	replica, err := partition.getReplica(dataNode.Addr)
+	if err != nil {
+		return
+	}
+	replica.setAlive()
+	if qps, ok := replica.reads.update(vr.ReadOps, replica.ReportTime); ok {
+		replica.ReadQps = qps
+	}
+	if replica.IsLeader {
+		partition.LeaderReportTime = time.Now().Unix()
+	}
+	// the node or the partition may have been made read only since
+	replica.Status = int8(vr.PartitionStatus)
+	replica.ReadOnlyReasons = vr.ReadOnlyReasons
+	partition.checkAndRemoveMissReplica(dataNode.Addr)
+	partition.setReplicaReadOnly(replica)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataNodeHeartbeatDue(t *testing.T) {
+	dataNode := newDataNode("a", "", "", testZone1, "", defaultMediaType)
+	base := time.Second * defaultIntervalToCheckHeartbeat
+	now := time.Now()
+	require.True(t, dataNode.heartbeatDue(now, time.Minute))
+	require.False(t, dataNode.heartbeatDue(now.Add(base/4), time.Minute))
+	now = now.Add(base)
+	require.True(t, dataNode.heartbeatDue(now, time.Minute))
+
+	for i := 0; i < heartbeatStableStep; i++ {
+		dataNode.updateStability(true)
+	}
+	require.False(t, dataNode.heartbeatDue(now.Add(base), time.Minute))
+	require.Equal(t, 2*base, dataNode.getHeartbeatInterval())
+	now = now.Add(2 * base)
+	require.True(t, dataNode.heartbeatDue(now, time.Minute))
+
+	for i := 0; i < 10*heartbeatStableStep; i++ {
+		dataNode.updateStability(true)
+	}
+	require.False(t, dataNode.heartbeatDue(now.Add(time.Minute/2), time.Minute))
+	require.Equal(t, time.Minute, dataNode.getHeartbeatInterval())
+	now = now.Add(time.Minute)
+	require.True(t, dataNode.heartbeatDue(now, time.Minute))
+
+	// back to the base interval on an unstable heartbeat
+	dataNode.updateStability(false)
+	require.True(t, dataNode.heartbeatDue(now.Add(base), time.Minute))
+	require.Equal(t, base, dataNode.getHeartbeatInterval())
+}
+
+func TestApplyDataNodeReports(t *testing.T) {
+	c := server.cluster
+	dp := commonVol.dataPartitions.clonePartitions()[0]
+	dp.RLock()
+	replica := dp.Replicas[0]
+	report := &proto.DataPartitionReport{
+		VolName:         commonVol.Name,
+		PartitionID:     dp.PartitionID,
+		PartitionStatus: int(replica.Status),
+		Total:           replica.Total,
+		Used:            replica.Used,
+		IsLeader:        replica.IsLeader,
+		ReadOps:         replica.reads.ops,
+	}
+	dp.RUnlock()
+	gone := &proto.DataPartitionReport{VolName: commonVol.Name, PartitionID: 1 << 40}
+	// a node of the same address, apart from the one the mock heartbeats go to
+	dataNode := newDataNode(replica.Addr, "", "", testZone1, "", defaultMediaType)
+	now := time.Now()
+	require.Zero(t, dataNode.reportSeqToAck(now))
+
+	require.False(t, c.applyDataNodeReports(dataNode, &proto.DataNodeHeartbeatResponse{
+		ReportSeq: 5, PartitionReports: []*proto.DataPartitionReport{report, gone},
+	}))
+	require.Len(t, dataNode.DataPartitionReports, 2)
+	require.Equal(t, uint64(5), dataNode.reportSeqToAck(now))
+	require.Zero(t, dataNode.reportSeqToAck(now.Add(dataNodeFullReportInterval+time.Second)))
+
+	// unchanged, the replica is kept alive
+	dp.Lock()
+	replica.ReportTime = 0
+	dp.Unlock()
+	require.True(t, c.applyDataNodeReports(dataNode, &proto.DataNodeHeartbeatResponse{ReportSeq: 6, DeltaBaseSeq: 5}))
+	require.Len(t, dataNode.DataPartitionReports, 2)
+	dp.RLock()
+	require.NotZero(t, replica.ReportTime)
+	dp.RUnlock()
+
+	require.False(t, c.applyDataNodeReports(dataNode, &proto.DataNodeHeartbeatResponse{
+		ReportSeq: 7, DeltaBaseSeq: 6, RemovedPartitions: []uint64{gone.PartitionID},
+	}))
+	require.Len(t, dataNode.DataPartitionReports, 1)
+	require.Equal(t, uint64(7), dataNode.reportSeqToAck(now))
+
+	// not on the reports applied, all of them are asked for
+	require.False(t, c.applyDataNodeReports(dataNode, &proto.DataNodeHeartbeatResponse{ReportSeq: 9, DeltaBaseSeq: 8}))
+	require.Zero(t, dataNode.reportSeqToAck(now))
+}
+
+func TestSetHeartbeatMaxInterval(t *testing.T) {
+	c := server.cluster
+	require.True(t, errors.Is(c.setHeartbeatMaxInterval(1), proto.ErrInvalidCfg))
+	require.True(t, errors.Is(c.setHeartbeatMaxInterval(uint64(c.heartbeatMaxIntervalLimit()/time.Second)+1), proto.ErrInvalidCfg))
+	require.Equal(t, time.Second*defaultIntervalToCheckHeartbeat, c.dataNodeHeartbeatMaxInterval())
+
+	require.NoError(t, c.setHeartbeatMaxInterval(60))
+	defer func() {
+		require.NoError(t, c.setHeartbeatMaxInterval(0))
+	}()
+	require.Equal(t, time.Minute, c.dataNodeHeartbeatMaxInterval())
+}
+
+func TestMirrorZonesUpdate(t *testing.T) {
+	var z mirrorZones
+	now := time.Now()
+	v1, zones := z.update(map[string]string{"a": "z1", "b": "z2"}, now)
+	require.NotZero(t, v1)
+	require.Len(t, zones, 2)
+
+	v2, _ := z.update(map[string]string{"a": "z1", "b": "z2"}, now.Add(time.Second))
+	require.Equal(t, v1, v2, "the zones not changed keep their version")
+
+	v3, zones := z.update(map[string]string{"a": "z1", "b": "z1"}, now.Add(-time.Hour))
+	require.Greater(t, v3, v1, "the version goes up even if the clock went back")
+	require.Equal(t, "z1", zones["b"])
+
+	v4, _ := z.update(map[string]string{"a": "z1"}, now)
+	require.Greater(t, v4, v3)
+}
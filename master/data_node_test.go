@@ -21,6 +21,7 @@ func TestDataNode(t *testing.T) {
 	server.cluster.checkDataNodeHeartbeat()
 	time.Sleep(5 * time.Second)
 	getDataNodeInfo(addr, t)
+	getDataNodeLoadProgress(addr, t)
 	updateDisks(addr, t)
 	decommissionDataNode(addr, t)
 	for i := 0; i < 10; i++ { // decommission is async process
@@ -42,6 +43,13 @@ func getDataNodeInfo(addr string, t *testing.T) {
 	process(reqURL, t)
 }
 
+func getDataNodeLoadProgress(addr string, t *testing.T) {
+	reqURL := fmt.Sprintf("%v%v?addr=%v", hostAddr, proto.GetDataNodeLoadProgress, addr)
+	process(reqURL, t)
+	reqURL = fmt.Sprintf("%v%v?loading=true", hostAddr, proto.GetDataNodeLoadProgress)
+	process(reqURL, t)
+}
+
 func decommissionDataNode(addr string, t *testing.T) {
 	reqURL := fmt.Sprintf("%v%v?addr=%v", hostAddr, proto.DecommissionDataNode, addr)
 	process(reqURL, t)
@@ -88,6 +88,9 @@ type DataPartition struct {
 	RestoreReplica                    uint32
 	MediaType                         uint32
 	ForbidWriteOpOfProtoVer0          bool
+	ReadReplicas                      []*DataReadReplica // read replicas of a hot partition, out of the raft group
+	coolSince                         int64              // since when the reads are under half the hot threshold
+	readReplicaBackoff                int64              // no new read replica until then
 }
 
 func newDataPartition(ID uint64, replicaNum uint8, volName string, volID uint64,
@@ -480,6 +483,7 @@ func (partition *DataPartition) convertToDataPartitionResponse() (dpr *proto.Dat
 	dpr.IsRecover = partition.isRecover
 	dpr.IsDiscard = partition.IsDiscard
 	dpr.MediaType = partition.MediaType
+	dpr.ReadReplicas = partition.readyReadReplicas()
 	return
 }
 
@@ -750,6 +754,10 @@ func (partition *DataPartition) update(action, volName string, newPeers []proto.
 }
 
 func (partition *DataPartition) updateMetric(vr *proto.DataPartitionReport, dataNode *DataNode, c *Cluster) {
+	if vr.ReadReplica {
+		partition.updateReadReplicaMetric(vr, dataNode, c)
+		return
+	}
 	if !partition.hasHost(dataNode.Addr) {
 		return
 	}
@@ -767,6 +775,9 @@ func (partition *DataPartition) updateMetric(vr *proto.DataPartitionReport, data
 	partition.setMaxUsed()
 	replica.FileCount = uint32(vr.ExtentCount)
 	replica.setAlive()
+	if qps, ok := replica.reads.update(vr.ReadOps, replica.ReportTime); ok {
+		replica.ReadQps = qps
+	}
 	replica.IsLeader = vr.IsLeader
 	replica.ForbidWriteOpOfProtoVer0 = vr.ForbidWriteOpOfProtoVer0
 	replica.ReadOnlyReasons = vr.ReadOnlyReasons
@@ -820,7 +831,12 @@ func (partition *DataPartition) updateMetric(vr *proto.DataPartitionReport, data
 	}
 
 	partition.checkAndRemoveMissReplica(dataNode.Addr)
+	partition.setReplicaReadOnly(replica)
+}
 
+// setReplicaReadOnly marks the replica read only for its node or the partition
+// being so, on top of the status it reported.
+func (partition *DataPartition) setReplicaReadOnly(replica *DataReplica) {
 	if replica.dataNode.RdOnly {
 		replica.ReadOnlyReasons |= proto.DataNodeRdOnly
 		if replica.Status == proto.ReadWrite {
@@ -828,6 +844,13 @@ func (partition *DataPartition) updateMetric(vr *proto.DataPartitionReport, data
 		}
 	}
 
+	if replica.dataNode.isZoneIsolated() {
+		replica.ReadOnlyReasons |= proto.DpZoneIsolated
+		if replica.Status == proto.ReadWrite {
+			replica.Status = proto.ReadOnly
+		}
+	}
+
 	if partition.RdOnly {
 		replica.ReadOnlyReasons |= proto.PartitionRdOnly
 		if replica.Status == proto.ReadWrite {
@@ -975,6 +998,20 @@ func (partition *DataPartition) activeUsedSimilar() bool {
 // 	return
 // }
 
+// listStatus returns the status of the partition filtered by the list APIs.
+func (partition *DataPartition) listStatus() string {
+	partition.RLock()
+	defer partition.RUnlock()
+	switch partition.Status {
+	case proto.ReadWrite:
+		return listStatusReadWrite
+	case proto.ReadOnly:
+		return listStatusReadOnly
+	default:
+		return listStatusUnavailable
+	}
+}
+
 func (partition *DataPartition) getLiveZones(offlineAddr string) (zones []string) {
 	partition.RLock()
 	defer partition.RUnlock()
@@ -1026,6 +1063,12 @@ func (partition *DataPartition) buildDpInfo(c *Cluster) *proto.DataPartitionInfo
 		}
 	}
 
+	readReplicas := make([]*proto.DataReadReplica, 0, len(partition.ReadReplicas))
+	for _, rr := range partition.ReadReplicas {
+		readReplica := rr.DataReadReplica
+		readReplicas = append(readReplicas, &readReplica)
+	}
+
 	forbidden := true
 	vol, err := c.getVol(partition.VolName)
 	if err == nil {
@@ -1057,6 +1100,8 @@ func (partition *DataPartition) buildDpInfo(c *Cluster) *proto.DataPartitionInfo
 		Forbidden:                forbidden,
 		MediaType:                partition.MediaType,
 		ForbidWriteOpOfProtoVer0: partition.ForbidWriteOpOfProtoVer0,
+		ReadQps:                  partition.readQps(),
+		ReadReplicas:             readReplicas,
 	}
 }
 
@@ -2253,6 +2298,9 @@ func (partition *DataPartition) TryAcquireDecommissionToken(c *Cluster) bool {
 		if partition.DecommissionSrcAddr != "" && !partition.hasHost(partition.DecommissionSrcAddr) {
 			excludeHosts = append(excludeHosts, partition.DecommissionSrcAddr)
 		}
+		if vol, e := c.getVol(partition.VolName); e == nil {
+			excludeHosts = c.withPlacementExclusion(vol, TypeDataPartition, excludeHosts)
+		}
 		log.LogDebugf("action[TryAcquireDecommissionToken]dp %v excludeHosts %v",
 			partition.PartitionID, excludeHosts)
 		// data nodes in a nodeset has the same mediaType
@@ -2312,6 +2360,11 @@ func (partition *DataPartition) TryAcquireDecommissionToken(c *Cluster) bool {
 			return false
 		}
 	} else {
+		if vol, e := c.getVol(partition.VolName); e == nil {
+			if err = c.checkPlacementExclusion(vol, TypeDataPartition, []string{partition.DecommissionDstAddr}); err != nil {
+				goto errHandler
+			}
+		}
 		ns, _, err = getTargetNodeset(partition.DecommissionDstAddr, c)
 		if err != nil {
 			log.LogWarnf("action[TryAcquireDecommissionToken]dp %v find src nodeset failed:%v",
@@ -2799,7 +2852,7 @@ func (partition *DataPartition) checkReplicaMeta(c *Cluster) (err error) {
 				partition.PartitionID, addr)
 			return nil
 		}
-		err = c.markDecommissionDataPartition(partition, node, 0, false, AutoAddReplica, highPriorityDecommissionWeight)
+		err = c.markDecommissionDataPartition(partition, node, "", 0, false, AutoAddReplica, highPriorityDecommissionWeight)
 		auditMsg = fmt.Sprintf("dp(%v) ReplicaNum %v hostsNum %v auto add replica",
 			partition.PartitionID, partition.ReplicaNum, len(partition.Hosts))
 		log.LogDebugf("action[checkReplicaMeta]%v: err %v", auditMsg, err)
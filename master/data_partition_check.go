@@ -133,6 +133,13 @@ func (partition *DataPartition) checkReplicaStatus(timeOutSec int64) {
 			}
 		}
 
+		if replica.dataNode.isZoneIsolated() {
+			replica.ReadOnlyReasons |= proto.DpZoneIsolated
+			if replica.Status == proto.ReadWrite {
+				replica.Status = proto.ReadOnly
+			}
+		}
+
 		if partition.RdOnly {
 			replica.ReadOnlyReasons |= proto.PartitionRdOnly
 			if replica.Status == proto.ReadWrite {
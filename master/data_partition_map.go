@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -322,6 +323,36 @@ func (dpMap *DataPartitionMap) getDataPartitionsView(minPartitionID uint64) (dpR
 	return
 }
 
+// getDataPartitionsPage returns the page of the views of the data partitions
+// in the zone and of the status filtered, by the partition id.
+func (dpMap *DataPartitionMap) getDataPartitionsPage(filter listFilter, page listPage) (dpResps []*proto.DataPartitionResponse) {
+	dps := make([]*DataPartition, 0)
+	dpMap.RLock()
+	for _, dp := range dpMap.partitionMap {
+		if len(dp.Hosts) == 0 {
+			continue
+		}
+		dps = append(dps, dp)
+	}
+	dpMap.RUnlock()
+	sort.Slice(dps, func(i, j int) bool { return dps[i].PartitionID < dps[j].PartitionID })
+	if !filter.empty() {
+		matched := dps[:0]
+		for _, dp := range dps {
+			if filter.matchStatus(dp.listStatus()) && filter.matchZone(dp.getLiveZones("")...) {
+				matched = append(matched, dp)
+			}
+		}
+		dps = matched
+	}
+	begin, end := page.bounds(len(dps))
+	dpResps = make([]*proto.DataPartitionResponse, 0, end-begin)
+	for _, dp := range dps[begin:end] {
+		dpResps = append(dpResps, dp.convertToDataPartitionResponse())
+	}
+	return
+}
+
 func (dpMap *DataPartitionMap) getDataPartitionsToBeReleased(numberOfDataPartitionsToFree int, secondsToFreeDataPartitionAfterLoad int64) (partitions []*DataPartition, startIndex uint64) {
 	partitions = make([]*DataPartition, 0)
 	dpMap.RLock()
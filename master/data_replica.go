@@ -26,6 +26,7 @@ import (
 type DataReplica struct {
 	proto.DataReplica
 	dataNode *DataNode
+	reads    readRate
 	// loc      uint8
 }
 
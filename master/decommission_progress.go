@@ -0,0 +1,336 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The master leader samples the partitions and the bytes left on the data nodes
+// being decommissioned every check of the decommission, and on the meta nodes
+// as their partitions are migrated, and tells the bandwidth and the completion
+// from the samples of the last decommissionRateWindow.
+//
+// DecommissionBandwidthMBps of the cluster paces the start of the data
+// partition decommissions by the bytes of the partitions, as the data balancer
+// does its moves, and takes effect on the decommissions running when changed.
+const decommissionRateWindow = 5 * time.Minute
+
+type decommissionSample struct {
+	at         time.Time
+	partitions int
+	bytes      uint64
+}
+
+type decommissionTracker struct {
+	nodeType   string
+	status     uint32 // of a meta node, a data node keeps its own
+	startTime  time.Time
+	total      int // of a meta node
+	migrated   int
+	failed     int
+	startBytes uint64
+	samples    []decommissionSample
+}
+
+func (t *decommissionTracker) sample(partitions int, bytes uint64, now time.Time) {
+	t.samples = append(t.samples, decommissionSample{at: now, partitions: partitions, bytes: bytes})
+	// keep the last sample before the window to measure the whole window
+	for len(t.samples) > 2 && now.Sub(t.samples[1].at) >= decommissionRateWindow {
+		t.samples = t.samples[1:]
+	}
+}
+
+// rate returns the partitions and the bytes moved a second over the window.
+func (t *decommissionTracker) rate() (partitions, bytes float64) {
+	if len(t.samples) < 2 {
+		return
+	}
+	first, last := t.samples[0], t.samples[len(t.samples)-1]
+	seconds := last.at.Sub(first.at).Seconds()
+	if seconds <= 0 {
+		return
+	}
+	if first.partitions > last.partitions {
+		partitions = float64(first.partitions-last.partitions) / seconds
+	}
+	if first.bytes > last.bytes {
+		bytes = float64(first.bytes-last.bytes) / seconds
+	}
+	return
+}
+
+// estimate fills the bandwidth and the completion of the progress.
+func (t *decommissionTracker) estimate(p *proto.DecommissionNodeProgress, now time.Time) {
+	p.StartTime = t.startTime.Unix()
+	if t.startBytes > p.RemainingBytes {
+		p.MigratedBytes = t.startBytes - p.RemainingBytes
+	}
+	partitionRate, byteRate := t.rate()
+	p.BandwidthMBps, _ = FormatFloatFloor(byteRate/util.MB, 2)
+	var seconds float64
+	if p.RemainingBytes > 0 && byteRate > 0 {
+		seconds = float64(p.RemainingBytes) / byteRate
+	} else if p.RemainingPartitions > 0 && partitionRate > 0 {
+		seconds = float64(p.RemainingPartitions) / partitionRate
+	}
+	if seconds > 0 {
+		p.EstimatedSeconds = int64(math.Ceil(seconds))
+		p.EstimatedCompleteTime = now.Unix() + p.EstimatedSeconds
+	}
+}
+
+type decommissionProgresses struct {
+	sync.Mutex
+	trackers map[string]*decommissionTracker
+}
+
+func newDecommissionProgresses() *decommissionProgresses {
+	return &decommissionProgresses{trackers: make(map[string]*decommissionTracker)}
+}
+
+func (d *decommissionProgresses) sample(addr, nodeType string, partitions int, bytes uint64, now time.Time) {
+	d.Lock()
+	defer d.Unlock()
+	t, ok := d.trackers[addr]
+	if !ok {
+		t = &decommissionTracker{nodeType: nodeType, startTime: now, startBytes: bytes}
+		d.trackers[addr] = t
+	}
+	t.sample(partitions, bytes, now)
+}
+
+func (d *decommissionProgresses) forget(addr string) {
+	d.Lock()
+	defer d.Unlock()
+	delete(d.trackers, addr)
+}
+
+// get returns a copy of the tracker of the node.
+func (d *decommissionProgresses) get(addr string) (t decommissionTracker, ok bool) {
+	d.Lock()
+	defer d.Unlock()
+	tracker, ok := d.trackers[addr]
+	if !ok {
+		return
+	}
+	t = *tracker
+	t.samples = append([]decommissionSample(nil), tracker.samples...)
+	return
+}
+
+func (d *decommissionProgresses) startMeta(addr string, total int, now time.Time) {
+	d.Lock()
+	defer d.Unlock()
+	t := &decommissionTracker{nodeType: proto.MetaNode, status: DecommissionRunning, startTime: now, total: total}
+	t.sample(total, 0, now)
+	d.trackers[addr] = t
+}
+
+func (d *decommissionProgresses) metaMigrated(addr string, err error, now time.Time) {
+	d.Lock()
+	defer d.Unlock()
+	t, ok := d.trackers[addr]
+	if !ok {
+		return
+	}
+	if err != nil {
+		t.failed++
+	} else {
+		t.migrated++
+	}
+	t.sample(t.total-t.migrated-t.failed, 0, now)
+}
+
+func (d *decommissionProgresses) finishMeta(addr string, err error) {
+	d.Lock()
+	defer d.Unlock()
+	t, ok := d.trackers[addr]
+	if !ok {
+		return
+	}
+	t.status = DecommissionSuccess
+	if err != nil {
+		t.status = DecommissionFail
+	}
+}
+
+// decommissionThrottle holds the bytes the data partition decommissions may
+// still start with.
+type decommissionThrottle struct {
+	sync.Mutex
+	tokens   float64
+	refilled time.Time
+}
+
+// allow refills the bytes of the bandwidth and returns whether a decommission
+// may start, the bytes may be overdrawn by the last one started.
+func (t *decommissionThrottle) allow(bandwidthMBps uint64, now time.Time) bool {
+	if bandwidthMBps == 0 {
+		return true
+	}
+	t.Lock()
+	defer t.Unlock()
+	rate := float64(bandwidthMBps) * util.MB
+	if !t.refilled.IsZero() {
+		t.tokens += rate * now.Sub(t.refilled).Seconds()
+	}
+	if burst := rate * DecommissionInterval.Seconds(); t.tokens > burst {
+		t.tokens = burst
+	}
+	t.refilled = now
+	return t.tokens > 0
+}
+
+func (t *decommissionThrottle) consume(bandwidthMBps uint64, size uint64) {
+	if bandwidthMBps == 0 {
+		return
+	}
+	t.Lock()
+	t.tokens -= float64(size)
+	t.Unlock()
+}
+
+func (c *Cluster) getDecommissionBandwidthMBps() uint64 {
+	return atomic.LoadUint64(&c.cfg.DecommissionBandwidthMBps)
+}
+
+func (c *Cluster) setDecommissionBandwidthMBps(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.DecommissionBandwidthMBps)
+	atomic.StoreUint64(&c.cfg.DecommissionBandwidthMBps, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setDecommissionBandwidthMBps] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.DecommissionBandwidthMBps, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+// dataNodeDecommissionProgress counts the partitions of the latest decommission
+// of the data node, the ones left being still on it.
+func (c *Cluster) dataNodeDecommissionProgress(dataNode *DataNode) (p *proto.DecommissionNodeProgress) {
+	status, _ := dataNode.updateDecommissionStatus(c, false, false)
+	p = &proto.DecommissionNodeProgress{
+		Addr:          dataNode.Addr,
+		NodeType:      proto.DataNode,
+		Status:        status,
+		StatusMessage: GetDecommissionStatusMessage(status),
+		ThrottleMBps:  c.getDecommissionBandwidthMBps(),
+	}
+	if status == DecommissionInitial || status == markDecommission {
+		return
+	}
+	if dataNode.DecommissionDpTotal > 0 {
+		p.TotalPartitions = dataNode.DecommissionDpTotal
+	}
+	for _, dp := range dataNode.GetLatestDecommissionDataPartition(c) {
+		if dp.IsRollbackFailed() {
+			p.FailedPartitions++
+			continue
+		}
+		if dp.GetDecommissionStatus() == DecommissionRunning {
+			p.RunningPartitions++
+		}
+		p.RemainingPartitions++
+		p.RemainingBytes += dp.getMaxUsedSpace()
+	}
+	p.FailedPartitions += len(dataNode.getIgnoreDecommissionDpList(c)) + len(dataNode.getResidualDecommissionDpList(c))
+	if migrated := p.TotalPartitions - p.RemainingPartitions - p.FailedPartitions; migrated > 0 {
+		p.MigratedPartitions = migrated
+	}
+	return
+}
+
+// sampleDataNodeDecommission is called by the check of the data node
+// decommission with the status just updated.
+func (c *Cluster) sampleDataNodeDecommission(dataNode *DataNode) {
+	switch dataNode.GetDecommissionStatus() {
+	case DecommissionRunning:
+		p := c.dataNodeDecommissionProgress(dataNode)
+		c.decommissionProgress.sample(dataNode.Addr, proto.DataNode, p.RemainingPartitions, p.RemainingBytes, time.Now())
+	case DecommissionInitial, markDecommission:
+		c.decommissionProgress.forget(dataNode.Addr)
+	}
+}
+
+func (c *Cluster) metaNodeDecommissionProgress(addr string) (p *proto.DecommissionNodeProgress, err error) {
+	t, ok := c.decommissionProgress.get(addr)
+	if !ok || t.nodeType != proto.MetaNode {
+		if _, err = c.metaNode(addr); err != nil {
+			return
+		}
+		t = decommissionTracker{status: DecommissionInitial}
+	}
+	p = &proto.DecommissionNodeProgress{
+		Addr:               addr,
+		NodeType:           proto.MetaNode,
+		Status:             t.status,
+		StatusMessage:      GetDecommissionStatusMessage(t.status),
+		TotalPartitions:    t.total,
+		MigratedPartitions: t.migrated,
+		FailedPartitions:   t.failed,
+	}
+	if t.status == DecommissionInitial {
+		return
+	}
+	if t.status == DecommissionRunning {
+		p.RemainingPartitions = t.total - t.migrated - t.failed
+		p.RunningPartitions = p.RemainingPartitions
+	}
+	t.estimate(p, time.Now())
+	return
+}
+
+// queryDecommissionProgress tells the partitions migrated and left of the
+// decommission of a data or meta node, the bandwidth and the completion time
+// estimated from it.
+func (m *Server) queryDecommissionProgress(w http.ResponseWriter, r *http.Request) {
+	var (
+		addr string
+		p    *proto.DecommissionNodeProgress
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminQueryDecommissionProgress))
+	defer func() {
+		doStatAndMetric(proto.AdminQueryDecommissionProgress, metric, err, nil)
+	}()
+
+	if addr, err = parseReqToDecoDataNodeProgress(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if dataNode, e := m.cluster.dataNode(addr); e == nil {
+		p = m.cluster.dataNodeDecommissionProgress(dataNode)
+		if t, ok := m.cluster.decommissionProgress.get(addr); ok && p.Status != DecommissionInitial && p.Status != markDecommission {
+			t.estimate(p, time.Now())
+		}
+	} else if p, err = m.cluster.metaNodeDecommissionProgress(addr); err != nil {
+		err = fmt.Errorf("no data or meta node of %v", addr)
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(p))
+}
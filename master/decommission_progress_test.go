@@ -0,0 +1,123 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecommissionTrackerEstimate(t *testing.T) {
+	now := time.Unix(100000, 0)
+	tracker := &decommissionTracker{startTime: now, startBytes: 1000 * util.MB}
+	tracker.sample(10, 1000*util.MB, now)
+	p := &proto.DecommissionNodeProgress{RemainingPartitions: 10, RemainingBytes: 1000 * util.MB}
+	tracker.estimate(p, now)
+	require.Zero(t, p.EstimatedSeconds)
+
+	// 120MB a minute, 880MB left
+	tracker.sample(9, 880*util.MB, now.Add(time.Minute))
+	p = &proto.DecommissionNodeProgress{RemainingPartitions: 9, RemainingBytes: 880 * util.MB}
+	tracker.estimate(p, now.Add(time.Minute))
+	require.Equal(t, uint64(120*util.MB), p.MigratedBytes)
+	require.Equal(t, float64(2), p.BandwidthMBps)
+	require.Equal(t, int64(440), p.EstimatedSeconds)
+	require.Equal(t, now.Unix()+60+440, p.EstimatedCompleteTime)
+
+	// the samples out of the window are dropped but the last one before it
+	for i := 2; i <= 10; i++ {
+		tracker.sample(10-i, uint64(1000-100*i)*util.MB, now.Add(time.Duration(i)*time.Minute))
+	}
+	require.Equal(t, now.Add(5*time.Minute), tracker.samples[0].at)
+
+	// empty partitions are estimated by the partitions moved
+	tracker = &decommissionTracker{startTime: now}
+	tracker.sample(4, 0, now)
+	tracker.sample(2, 0, now.Add(32*time.Second))
+	p = &proto.DecommissionNodeProgress{RemainingPartitions: 2}
+	tracker.estimate(p, now.Add(32*time.Second))
+	require.Equal(t, int64(32), p.EstimatedSeconds)
+}
+
+func TestDecommissionThrottle(t *testing.T) {
+	now := time.Unix(100000, 0)
+	throttle := &decommissionThrottle{}
+	require.True(t, throttle.allow(0, now))
+	throttle.consume(0, 1<<40)
+	require.True(t, throttle.allow(0, now))
+
+	// the first partition starts on the bandwidth, the next waits for the bytes it overdrew
+	require.False(t, throttle.allow(10, now))
+	require.True(t, throttle.allow(10, now.Add(time.Second)))
+	throttle.consume(10, 100*util.MB)
+	require.False(t, throttle.allow(10, now.Add(5*time.Second)))
+	require.True(t, throttle.allow(10, now.Add(11*time.Second)))
+	// no more than an interval of the bandwidth is saved up
+	require.True(t, throttle.allow(10, now.Add(time.Hour)))
+	require.Equal(t, 10*util.MB*DecommissionInterval.Seconds(), throttle.tokens)
+}
+
+func TestDecommissionProgress(t *testing.T) {
+	nodeAPI := mc.NodeAPI()
+	progress, err := nodeAPI.QueryDecommissionProgress(mds1Addr)
+	require.NoError(t, err)
+	require.Equal(t, proto.DataNode, progress.NodeType)
+	require.Equal(t, uint32(DecommissionInitial), progress.Status)
+
+	progress, err = nodeAPI.QueryDecommissionProgress(mms1Addr)
+	require.NoError(t, err)
+	require.Equal(t, proto.MetaNode, progress.NodeType)
+	require.Equal(t, uint32(DecommissionInitial), progress.Status)
+
+	_, err = nodeAPI.QueryDecommissionProgress("127.0.0.1:1")
+	require.Error(t, err)
+
+	// a meta node being migrated
+	addr := "127.0.0.1:2"
+	now := time.Now()
+	server.cluster.decommissionProgress.startMeta(addr, 4, now.Add(-64*time.Second))
+	defer server.cluster.decommissionProgress.forget(addr)
+	server.cluster.decommissionProgress.metaMigrated(addr, nil, now.Add(-32*time.Second))
+	server.cluster.decommissionProgress.metaMigrated(addr, errors.New("failed"), now)
+	progress, err = nodeAPI.QueryDecommissionProgress(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint32(DecommissionRunning), progress.Status)
+	require.Equal(t, 1, progress.MigratedPartitions)
+	require.Equal(t, 1, progress.FailedPartitions)
+	require.Equal(t, 2, progress.RemainingPartitions)
+	require.Equal(t, int64(64), progress.EstimatedSeconds)
+	server.cluster.decommissionProgress.finishMeta(addr, nil)
+	progress, err = nodeAPI.QueryDecommissionProgress(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint32(DecommissionSuccess), progress.Status)
+	require.Zero(t, progress.RemainingPartitions)
+
+	// the throttle is changed on the fly
+	process(fmt.Sprintf("%v%v?%v=%v", hostAddr, proto.AdminSetNodeInfo, decommissionBandwidthMBpsKey, 200), t)
+	defer server.cluster.setDecommissionBandwidthMBps(0)
+	require.Equal(t, uint64(200), server.cluster.getDecommissionBandwidthMBps())
+	cv, err := mc.AdminAPI().GetCluster(false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), cv.DecommissionBandwidthMBps)
+	progress, err = nodeAPI.QueryDecommissionProgress(mds1Addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), progress.ThrottleMBps)
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"crypto/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The flash nodes serve the cache of a volume requiring remoteCacheAuth to the
+// clients holding a flash cache token of it only. The master issues the tokens
+// to the clients knowing the owner of the volume, and signs them with the key
+// of the cluster, which it pushes to the flash nodes by the heartbeat.
+
+const (
+	flashCacheTokenTTL = time.Hour
+	flashCacheKeyLen   = 32
+)
+
+// flashCacheKey is created the first time a token is issued or a volume requires
+// them, and persisted with the cluster.
+type flashCacheKey struct {
+	create sync.Mutex
+	key    atomic.Value // []byte
+}
+
+func (k *flashCacheKey) get() []byte {
+	key, _ := k.key.Load().([]byte)
+	return key
+}
+
+func (k *flashCacheKey) load(key []byte) {
+	k.key.Store(key)
+}
+
+func (c *Cluster) getFlashCacheKey() (key []byte, err error) {
+	c.flashCacheKey.create.Lock()
+	defer c.flashCacheKey.create.Unlock()
+	if key = c.flashCacheKey.get(); len(key) > 0 {
+		return
+	}
+	key = make([]byte, flashCacheKeyLen)
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+	c.flashCacheKey.load(key)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[getFlashCacheKey] err[%v]", err)
+		c.flashCacheKey.load(nil)
+		return nil, proto.ErrPersistenceByRaft
+	}
+	log.LogWarnf("action[getFlashCacheKey] the key of the flash cache tokens is created")
+	return
+}
+
+// getRemoteCacheAuthVols returns the volumes whose cache the flash nodes serve
+// with a flash cache token only.
+func (c *Cluster) getRemoteCacheAuthVols() (vols []string) {
+	for name, vol := range c.allVols() {
+		if vol.remoteCacheAuth {
+			vols = append(vols, name)
+		}
+	}
+	sort.Strings(vols)
+	return
+}
+
+// getTLSRequiredVols returns the volumes whose clients are served over TLS only.
+func (c *Cluster) getTLSRequiredVols() (vols []string) {
+	for name, vol := range c.allVols() {
+		if vol.RequireTLS {
+			vols = append(vols, name)
+		}
+	}
+	sort.Strings(vols)
+	return
+}
+
+func (m *Server) getFlashCacheToken(w http.ResponseWriter, r *http.Request) {
+	var (
+		err     error
+		name    string
+		authKey string
+		vol     *Vol
+		key     []byte
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.ClientFlashCacheToken))
+	defer func() {
+		doStatAndMetric(proto.ClientFlashCacheToken, metric, err, map[string]string{exporter.Vol: name})
+	}()
+
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if vol, err = m.cluster.getVol(name); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if key, err = m.cluster.getFlashCacheKey(); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	token := proto.NewFlashCacheToken(key, name, time.Now().Add(flashCacheTokenTTL).Unix())
+	sendOkReply(w, r, newSuccessHTTPReply(token))
+}
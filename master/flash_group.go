@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/cubefs/cubefs/cmd/common"
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
@@ -42,12 +44,24 @@ type flashGroupValue struct {
 	Step         uint32
 	Weight       uint32
 	Status       proto.FlashGroupStatus
+	AutoScaleMin int
+	AutoScaleMax int // 0: not scaled automatically
+
+	AutoHealMinHealthy   int   // 0: not healed automatically
+	AutoHealCooldown     int64 // seconds, 0: flashGroupAutoHealDefaultCooldown
+	AutoHealExcludeHosts []string
+
+	DrainDeadline int64 // unix seconds, the draining group is removed after it
+
+	Volumes []string // the group caches only these volumes, which use no other group. empty: shared
 }
 
 type FlashGroup struct {
 	flashGroupValue
 	lock       sync.RWMutex
 	flashNodes map[string]*FlashNode // key: FlashNodeAddr
+	scaledAt   time.Time
+	healedAt   time.Time
 }
 
 func (fg *FlashGroup) GetStatus() (st proto.FlashGroupStatus) {
@@ -79,6 +93,13 @@ func newFlashGroupFromFgv(fgv flashGroupValue) *FlashGroup {
 	fg.Step = fgv.Step
 	fg.Weight = fgv.Weight
 	fg.Status = fgv.Status
+	fg.AutoScaleMin = fgv.AutoScaleMin
+	fg.AutoScaleMax = fgv.AutoScaleMax
+	fg.AutoHealMinHealthy = fgv.AutoHealMinHealthy
+	fg.AutoHealCooldown = fgv.AutoHealCooldown
+	fg.AutoHealExcludeHosts = fgv.AutoHealExcludeHosts
+	fg.DrainDeadline = fgv.DrainDeadline
+	fg.Volumes = fgv.Volumes
 	fg.flashNodes = make(map[string]*FlashNode)
 	return fg
 }
@@ -95,15 +116,23 @@ func (fg *FlashGroup) removeFlashNode(addr string) {
 	fg.lock.Unlock()
 }
 
-func (fg *FlashGroup) getTargetZoneFlashNodeHosts(targetZone string) (hosts []string) {
+// getTargetZoneFlashNodeHosts returns the flashnodes of the group in the zone,
+// those not active and enabled first, and the count of the others.
+func (fg *FlashGroup) getTargetZoneFlashNodeHosts(targetZone string) (hosts []string, healthy int) {
+	var healthyHosts []string
 	fg.lock.RLock()
 	for _, flashNode := range fg.flashNodes {
-		if flashNode.ZoneName == targetZone {
-			hosts = append(hosts, flashNode.Addr)
+		if flashNode.ZoneName != targetZone {
+			continue
+		}
+		if flashNode.isActiveAndEnable() {
+			healthyHosts = append(healthyHosts, flashNode.Addr)
+			continue
 		}
+		hosts = append(hosts, flashNode.Addr)
 	}
 	fg.lock.RUnlock()
-	return
+	return append(hosts, healthyHosts...), len(healthyHosts)
 }
 
 func (fg *FlashGroup) getFlashNodeHosts(checkStatus bool) (hosts []string) {
@@ -119,16 +148,33 @@ func (fg *FlashGroup) getFlashNodeHosts(checkStatus bool) (hosts []string) {
 	return
 }
 
-func (fg *FlashGroup) getFlashNodeHostsEnabled() (hosts []string) {
+// getFlashNodeHostsEnabled returns the enabled flash nodes for the clients,
+// leaving out those of poor health unless all of them are.
+func (fg *FlashGroup) getFlashNodeHostsEnabled() (hosts []string, hostZones map[string]string) {
 	hosts = make([]string, 0, len(fg.flashNodes))
+	hostZones = make(map[string]string, len(fg.flashNodes))
+	poorHosts := make([]string, 0)
 	fg.lock.RLock()
 	for host, flashNode := range fg.flashNodes {
 		if !flashNode.isEnable() {
 			continue
 		}
+		hostZones[host] = flashNode.ZoneName
+		if flashNode.health.isPoor() {
+			poorHosts = append(poorHosts, host)
+			continue
+		}
 		hosts = append(hosts, host)
 	}
 	fg.lock.RUnlock()
+	sort.Strings(hosts)
+	sort.Strings(poorHosts)
+	if len(hosts) == 0 {
+		return poorHosts, hostZones
+	}
+	for _, host := range poorHosts {
+		delete(hostZones, host)
+	}
 	return
 }
 
@@ -169,11 +215,17 @@ func (fg *FlashGroup) getSlotStatus() (status proto.SlotStatus) {
 }
 
 func (c *Cluster) syncAddFlashGroup(flashGroup *FlashGroup) (err error) {
-	return c.syncPutFlashGroupInfo(opSyncAddFlashGroup, flashGroup)
+	if err = c.syncPutFlashGroupInfo(opSyncAddFlashGroup, flashGroup); err == nil {
+		c.publishEvent(proto.ClusterEventFlashGroupCreate, flashGroup.ID, "", "")
+	}
+	return
 }
 
 func (c *Cluster) syncDeleteFlashGroup(flashGroup *FlashGroup) (err error) {
-	return c.syncPutFlashGroupInfo(opSyncDeleteFlashGroup, flashGroup)
+	if err = c.syncPutFlashGroupInfo(opSyncDeleteFlashGroup, flashGroup); err == nil {
+		c.publishEvent(proto.ClusterEventFlashGroupRemove, flashGroup.ID, "", "")
+	}
+	return
 }
 
 func (c *Cluster) syncUpdateFlashGroup(flashGroup *FlashGroup) (err error) {
@@ -201,11 +253,27 @@ func (fg *FlashGroup) GetAdminView() (view proto.FlashGroupAdminView) {
 		SlotStatus:   fg.SlotStatus,
 		PendingSlots: fg.PendingSlots,
 		Step:         fg.Step,
+		AutoScaleMin: fg.AutoScaleMin,
+		AutoScaleMax: fg.AutoScaleMax,
+
+		AutoHealMinHealthy:   fg.AutoHealMinHealthy,
+		AutoHealCooldown:     fg.AutoHealCooldown,
+		AutoHealExcludeHosts: fg.AutoHealExcludeHosts,
+
+		DrainDeadline: fg.DrainDeadline,
+		Volumes:       fg.Volumes,
 	}
 	view.ZoneFlashNodes = make(map[string][]*proto.FlashNodeViewInfo)
 	view.FlashNodeCount = len(fg.flashNodes)
 	for _, flashNode := range fg.flashNodes {
-		view.ZoneFlashNodes[flashNode.ZoneName] = append(view.ZoneFlashNodes[flashNode.ZoneName], flashNode.getFlashNodeViewInfo())
+		info := flashNode.getFlashNodeViewInfo()
+		view.ZoneFlashNodes[flashNode.ZoneName] = append(view.ZoneFlashNodes[flashNode.ZoneName], info)
+		if info.IsActive && info.IsEnable {
+			view.CacheCapacity += info.CacheCapacity
+			if info.CacheStat != nil {
+				view.CacheStat.Add(info.CacheStat)
+			}
+		}
 	}
 	fg.lock.RUnlock()
 	return
@@ -229,6 +297,7 @@ func (m *Server) turnFlashGroup(w http.ResponseWriter, r *http.Request) {
 	} else {
 		topo.clientOff.Store(topo.clientEmpty)
 	}
+	topo.notifyClientChange()
 	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("turn %v", enabled)))
 }
 
@@ -237,13 +306,23 @@ func (m *Server) createFlashGroup(w http.ResponseWriter, r *http.Request) {
 		err         error
 		setSlots    []uint32
 		setWeight   uint32
+		capacity    uint64
 		gradualFlag bool
 		step        uint32
+		id          uint64
+		dryRun      common.Bool
 	)
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupCreate))
 	defer func() {
 		doStatAndMetric(proto.AdminFlashGroupCreate, metric, err, nil)
+		if !dryRun.V {
+			m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpCreate, id, err)
+		}
 	}()
+	if err = parseArgs(r, dryRun.Key("dryRun").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
 	if setSlots, err = getSetSlots(r); err != nil {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
@@ -254,6 +333,11 @@ func (m *Server) createFlashGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if capacity, err = getSetCacheCapacity(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+
 	if gradualFlag, err = getGradualFlag(r); err != nil {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
@@ -269,16 +353,23 @@ func (m *Server) createFlashGroup(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
 	}
+	if dryRun.V {
+		sendOkReply(w, r, newSuccessHTTPReply(m.cluster.previewCreateFlashGroup(setSlots, setWeight, capacity*util.GB)))
+		return
+	}
 
-	flashGroup, err := m.cluster.createFlashGroup(setSlots, setWeight, gradualFlag, step)
+	flashGroup, err := m.cluster.createFlashGroup(setSlots, setWeight, capacity*util.GB, gradualFlag, step)
 	if err != nil {
 		sendErrReply(w, r, newErrHTTPReply(err))
 		return
 	}
+	id = flashGroup.ID
 	sendOkReply(w, r, newSuccessHTTPReply(flashGroup.GetAdminView()))
 }
 
-func (c *Cluster) createFlashGroup(setSlots []uint32, setWeight uint32, gradualFlag bool, step uint32) (fg *FlashGroup, err error) {
+// createFlashGroup takes the slots set, or allocates the slots of the share
+// of the cache capacity the group is going to provide, or by its weight.
+func (c *Cluster) createFlashGroup(setSlots []uint32, setWeight uint32, capacity uint64, gradualFlag bool, step uint32) (fg *FlashGroup, err error) {
 	defer func() {
 		if err != nil {
 			log.LogErrorf("action[addFlashGroup],clusterID[%v] err:%v ", c.Name, err.Error())
@@ -288,6 +379,13 @@ func (c *Cluster) createFlashGroup(setSlots []uint32, setWeight uint32, gradualF
 	if err != nil {
 		return
 	}
+	if len(setSlots) == 0 && capacity > 0 {
+		if count := c.flashNodeTopo.getSlotsCountOfCapacity(capacity); count > 0 {
+			c.flashNodeTopo.createFlashGroupLock.Lock()
+			setSlots = c.flashNodeTopo.allocateNewSlots(count)
+			c.flashNodeTopo.createFlashGroupLock.Unlock()
+		}
+	}
 	if gradualFlag {
 		if fg, err = c.flashNodeTopo.gradualCreateFlashGroup(id, c, setSlots, setWeight, step); err != nil {
 			return
@@ -309,12 +407,16 @@ func (m *Server) removeFlashGroup(w http.ResponseWriter, r *http.Request) {
 		gradualFlag bool
 		step        uint32
 	)
+	var flashGroupID, drainTTL common.Uint
+	var dryRun common.Bool
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupRemove))
 	defer func() {
 		doStatAndMetric(proto.AdminFlashGroupRemove, metric, err, nil)
+		if !dryRun.V {
+			m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpRemove, flashGroupID.V, err)
+		}
 	}()
-	var flashGroupID common.Uint
-	if err = parseArgs(r, flashGroupID.ID()); err != nil {
+	if err = parseArgs(r, flashGroupID.ID(), drainTTL.Key("drainTTL").OmitEmpty(), dryRun.Key("dryRun").OmitEmpty()); err != nil {
 		sendErrReply(w, r, newErrHTTPReply(err))
 		return
 	}
@@ -334,6 +436,16 @@ func (m *Server) removeFlashGroup(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
 	}
+	if drainTTL.V > 0 && gradualFlag {
+		err = fmt.Errorf("drainTTL and gradualFlag cannot be set together")
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if drainTTL.V > uint64(flashGroupMaxDrainTTL/time.Second) {
+		err = fmt.Errorf("drainTTL(%v) should be at most %v seconds", drainTTL.V, int64(flashGroupMaxDrainTTL/time.Second))
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
 
 	var flashGroup *FlashGroup
 	if flashGroup, err = m.cluster.flashNodeTopo.getFlashGroup(flashGroupID.V); err != nil {
@@ -345,6 +457,19 @@ func (m *Server) removeFlashGroup(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
 		return
 	}
+	if dryRun.V {
+		sendOkReply(w, r, newSuccessHTTPReply(m.cluster.previewRemoveFlashGroup(flashGroup)))
+		return
+	}
+	if drainTTL.V > 0 {
+		if err = m.cluster.drainFlashGroup(flashGroup, time.Duration(drainTTL.V)*time.Second); err != nil {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+			return
+		}
+		sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("flashGroup:%v is draining, it is removed after %vs",
+			flashGroup.ID, drainTTL.V)))
+		return
+	}
 
 	if err = m.cluster.removeFlashGroup(flashGroup, gradualFlag, step); err != nil {
 		sendErrReply(w, r, newErrHTTPReply(err))
@@ -381,6 +506,7 @@ func (m *Server) setFlashGroup(w http.ResponseWriter, r *http.Request) {
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupSet))
 	defer func() {
 		doStatAndMetric(proto.AdminFlashGroupSet, metric, err, nil)
+		m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpSet, flashGroupID.V, err)
 	}()
 
 	var active common.Bool
@@ -398,16 +524,18 @@ func (m *Server) setFlashGroup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	flashGroup.lock.Lock()
-	oldStatus := flashGroup.Status
-	flashGroup.Status = fgStatus
+	oldStatus, oldDrainDeadline := flashGroup.Status, flashGroup.DrainDeadline
+	// setting the status of a draining group cancels its removal
+	flashGroup.Status, flashGroup.DrainDeadline = fgStatus, 0
 	if oldStatus != fgStatus {
 		if err = m.cluster.syncUpdateFlashGroup(flashGroup); err != nil {
-			flashGroup.Status = oldStatus
+			flashGroup.Status, flashGroup.DrainDeadline = oldStatus, oldDrainDeadline
 			flashGroup.lock.Unlock()
 			sendErrReply(w, r, newErrHTTPReply(err))
 			return
 		}
 		m.cluster.flashNodeTopo.updateClientCache()
+		m.cluster.publishEvent(proto.ClusterEventFlashGroupStatus, flashGroup.ID, "", fgStatus.String())
 	}
 	flashGroup.lock.Unlock()
 
@@ -436,10 +564,14 @@ func (m *Server) getFlashGroup(w http.ResponseWriter, r *http.Request) {
 }
 
 func (m *Server) flashGroupAddFlashNode(w http.ResponseWriter, r *http.Request) {
-	var err error
+	var (
+		flashGroupID uint64
+		err          error
+	)
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupNodeAdd))
 	defer func() {
 		doStatAndMetric(proto.AdminFlashGroupNodeAdd, metric, err, nil)
+		m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpNodeAdd, flashGroupID, err)
 	}()
 	flashGroupID, addr, zoneName, count, err := parseArgsFlashGroupNode(r)
 	if err != nil {
@@ -494,6 +626,7 @@ func (c *Cluster) setFlashNodeToFlashGroup(addr string, flashGroupID uint64) (fl
 		flashNode.FlashGroupID = oldFgID
 		return
 	}
+	c.publishEvent(proto.ClusterEventFlashGroupNodeAdd, flashGroupID, addr, "")
 	log.LogInfo(fmt.Sprintf("action[setFlashNodeToFlashGroup] add flash node:%v to flashGroup:%v success", addr, flashGroupID))
 	return
 }
@@ -520,10 +653,14 @@ func (c *Cluster) selectFlashNodesFromZoneAddToFlashGroup(zoneName string, count
 }
 
 func (m *Server) flashGroupRemoveFlashNode(w http.ResponseWriter, r *http.Request) {
-	var err error
+	var (
+		flashGroupID uint64
+		err          error
+	)
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupNodeRemove))
 	defer func() {
 		doStatAndMetric(proto.AdminFlashGroupNodeRemove, metric, err, nil)
+		m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpNodeRemove, flashGroupID, err)
 	}()
 	flashGroupID, addr, zoneName, count, err := parseArgsFlashGroupNode(r)
 	if err != nil {
@@ -536,6 +673,10 @@ func (m *Server) flashGroupRemoveFlashNode(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	if addr != "" {
+		if err = m.cluster.checkFlashNodeRemovable(flashGroup, addr); err != nil {
+			sendErrReply(w, r, newErrHTTPReply(err))
+			return
+		}
 		err = m.cluster.removeFlashNodeFromFlashGroup(addr, flashGroup)
 	} else {
 		err = m.cluster.removeFlashNodesFromTargetZone(zoneName, count, flashGroup)
@@ -573,10 +714,13 @@ func (c *Cluster) removeAllFlashNodeFromFlashGroup(flashGroup *FlashGroup) (err
 }
 
 func (c *Cluster) removeFlashNodesFromTargetZone(zoneName string, count int, flashGroup *FlashGroup) (err error) {
-	flashNodeHosts := flashGroup.getTargetZoneFlashNodeHosts(zoneName)
+	flashNodeHosts, healthy := flashGroup.getTargetZoneFlashNodeHosts(zoneName)
 	if len(flashNodeHosts) < count {
 		return fmt.Errorf("flashNodeHostsCount:%v less than expectCount:%v,flashNodeHosts:%v", len(flashNodeHosts), count, flashNodeHosts)
 	}
+	if err = c.checkFlashGroupZoneMinNodes(flashGroup, zoneName, count-(len(flashNodeHosts)-healthy)); err != nil {
+		return
+	}
 	successHost := make([]string, 0)
 	for _, flashNodeHost := range flashNodeHosts {
 		if err = c.removeFlashNodeFromFlashGroup(flashNodeHost, flashGroup); err != nil {
@@ -609,13 +753,11 @@ func (c *Cluster) setFlashNodeToUnused(addr string, flashGroupID uint64) (flashN
 		flashNode.FlashGroupID = oldFgID
 		return
 	}
+	c.publishEvent(proto.ClusterEventFlashGroupNodeRemove, flashGroupID, addr, "")
 
 	go func() {
 		time.Sleep(time.Duration(defaultWaitClientUpdateFgTimeSec) * time.Second)
-		arr := strings.SplitN(addr, ":", 2)
-		p, _ := strconv.ParseUint(arr[1], 10, 64)
-		addr = fmt.Sprintf("%s:%d", arr[0], p+1)
-		if err = httpclient.New().Addr(addr).FlashNode().EvictAll(); err != nil {
+		if err = httpclient.New().Addr(flashNodeHTTPAddr(addr)).FlashNode().EvictAll(); err != nil {
 			log.LogErrorf("flashNode[%v] evict all failed, err:%v", flashNode.Addr, err)
 			return
 		}
@@ -634,7 +776,7 @@ func (m *Server) listFlashGroups(w http.ResponseWriter, r *http.Request) {
 	defer func() {
 		doStatAndMetric(proto.AdminFlashGroupList, metric, err, nil)
 	}()
-	var active common.Bool
+	var active, draining common.Bool
 	if err = parseArgs(r, active.Enable().OmitEmpty().
 		OnEmpty(func() error {
 			allStatus = true // resp all flash groups
@@ -644,11 +786,35 @@ func (m *Server) listFlashGroups(w http.ResponseWriter, r *http.Request) {
 			fgStatus = argConvertFlashGroupStatus(active.V)
 			return nil
 		}),
+		draining.Key("draining").OmitEmpty(),
 	); err != nil {
 		sendErrReply(w, r, newErrHTTPReply(err))
 		return
 	}
-	fgv := m.cluster.flashNodeTopo.getFlashGroupsAdminView(fgStatus, allStatus)
+	if draining.V {
+		fgStatus, allStatus = proto.FlashGroupStatus_Draining, false
+	}
+	var (
+		page   listPage
+		filter listFilter
+	)
+	if page, err = parseListPage(r); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if filter, err = parseListFilter(r, listStatusActive, listStatusInactive, listStatusDraining); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	switch filter.status {
+	case listStatusActive:
+		fgStatus, allStatus = proto.FlashGroupStatus_Active, false
+	case listStatusInactive:
+		fgStatus, allStatus = proto.FlashGroupStatus_Inactive, false
+	case listStatusDraining:
+		fgStatus, allStatus = proto.FlashGroupStatus_Draining, false
+	}
+	fgv := m.cluster.flashNodeTopo.getFlashGroupsAdminView(fgStatus, allStatus, filter, page)
 	sendOkReply(w, r, newSuccessHTTPReply(fgv))
 }
 
@@ -663,7 +829,18 @@ func (m *Server) clientFlashGroups(w http.ResponseWriter, r *http.Request) {
 		sendErrReply(w, r, newErrHTTPReply(fmt.Errorf("meta not ready")))
 		return
 	}
-	cache := m.cluster.flashNodeTopo.getClientResponse()
+	var version, wait common.Uint
+	var volName common.String
+	if err = parseArgs(r, version.Key("version").OmitEmpty(), volName.Key("volume").OmitEmpty(), wait.Key("wait").OmitEmpty()); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	var cache []byte
+	if wait.V > 0 {
+		cache = m.cluster.flashNodeTopo.waitClientResponse(r.Context(), volName.V, version.V, time.Duration(wait.V)*time.Second)
+	} else {
+		cache = m.cluster.flashNodeTopo.getClientResponse(volName.V, version.V)
+	}
 	if len(cache) == 0 {
 		sendErrReply(w, r, newErrHTTPReply(fmt.Errorf("flash group response cache is empty")))
 		return
@@ -703,6 +880,15 @@ func getSetWeight(r *http.Request) (weight uint32, err error) {
 	return
 }
 
+func getSetCacheCapacity(r *http.Request) (capacity uint64, err error) {
+	r.ParseForm()
+	capacityStr := r.FormValue("cacheCapacity")
+	if capacityStr != "" {
+		capacity, err = strconv.ParseUint(capacityStr, 10, 64)
+	}
+	return
+}
+
 func getGradualFlag(r *http.Request) (gradualCreateFlag bool, err error) {
 	r.ParseForm()
 	flagStr := r.FormValue("gradualFlag")
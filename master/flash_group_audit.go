@@ -0,0 +1,185 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/iputil"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	intervalToCleanFlashGroupAudit = time.Hour
+	flashGroupAuditKeepTime        = 90 * 24 * time.Hour
+	flashGroupAuditKeep            = 4096
+)
+
+// flashGroupAuditStore keeps the admin operations on the flash groups in memory,
+// oldest first. They are persisted by raft and loaded again by the new leader.
+type flashGroupAuditStore struct {
+	sync.RWMutex
+	records []*proto.FlashGroupAuditRecord
+	lastID  int64
+}
+
+func newFlashGroupAuditStore() *flashGroupAuditStore {
+	return &flashGroupAuditStore{records: make([]*proto.FlashGroupAuditRecord, 0)}
+}
+
+// newRecord stamps the record with an id greater than those of the records before.
+func (s *flashGroupAuditStore) newRecord(op string, flashGroupID uint64, operator, args string, err error) *proto.FlashGroupAuditRecord {
+	now := time.Now()
+	s.Lock()
+	defer s.Unlock()
+	id := now.UnixNano()
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+	return &proto.FlashGroupAuditRecord{
+		ID: id, Time: now.Unix(), Op: op, FlashGroupID: flashGroupID,
+		Operator: operator, Args: args, Result: result,
+	}
+}
+
+func (s *flashGroupAuditStore) put(record *proto.FlashGroupAuditRecord) {
+	s.Lock()
+	defer s.Unlock()
+	i := sort.Search(len(s.records), func(i int) bool { return s.records[i].ID >= record.ID })
+	if i < len(s.records) && s.records[i].ID == record.ID {
+		s.records[i] = record
+		return
+	}
+	s.records = append(s.records, nil)
+	copy(s.records[i+1:], s.records[i:])
+	s.records[i] = record
+}
+
+func (s *flashGroupAuditStore) delete(record *proto.FlashGroupAuditRecord) {
+	s.Lock()
+	defer s.Unlock()
+	for i := range s.records {
+		if s.records[i].ID == record.ID {
+			s.records = append(s.records[:i], s.records[i+1:]...)
+			return
+		}
+	}
+}
+
+// list returns the records of the flash group, or of all with id 0, of op, or of
+// all ops if empty, from start to end in unix seconds, oldest first. Zero start
+// or end is unbounded.
+func (s *flashGroupAuditStore) list(flashGroupID uint64, op string, start, end int64) (records []*proto.FlashGroupAuditRecord) {
+	s.RLock()
+	defer s.RUnlock()
+	records = make([]*proto.FlashGroupAuditRecord, 0)
+	for _, record := range s.records {
+		if (flashGroupID == 0 || record.FlashGroupID == flashGroupID) && (op == "" || record.Op == op) &&
+			(start == 0 || record.Time >= start) && (end == 0 || record.Time <= end) {
+			records = append(records, record)
+		}
+	}
+	return
+}
+
+// expired returns the records older than keepTime, and the oldest beyond keep.
+func (s *flashGroupAuditStore) expired(now time.Time) (records []*proto.FlashGroupAuditRecord) {
+	before := now.Add(-flashGroupAuditKeepTime).Unix()
+	s.RLock()
+	defer s.RUnlock()
+	for i, record := range s.records {
+		if record.Time < before || len(s.records)-i > flashGroupAuditKeep {
+			records = append(records, record)
+		}
+	}
+	return
+}
+
+func (s *flashGroupAuditStore) reset(records []*proto.FlashGroupAuditRecord) {
+	s.Lock()
+	defer s.Unlock()
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	s.records = records
+	if len(records) > 0 && records[len(records)-1].ID > s.lastID {
+		s.lastID = records[len(records)-1].ID
+	}
+}
+
+// recordFlashGroupOp adds the admin operation on the flash group to the audit
+// trail, whether it succeeded or not. A record failed to persist is logged and
+// kept by this leader.
+func (c *Cluster) recordFlashGroupOp(r *http.Request, op string, flashGroupID uint64, err error) {
+	_ = r.ParseForm()
+	record := c.flashGroupAudit.newRecord(op, flashGroupID, iputil.GetRealClientIP(r), r.Form.Encode(), err)
+	if perr := c.syncPutFlashGroupAudit(record); perr != nil {
+		log.LogWarnf("action[recordFlashGroupOp] persist record[%v] op[%v] err:%v", record.Key(), op, perr)
+	}
+	c.flashGroupAudit.put(record)
+}
+
+func (c *Cluster) cleanExpiredFlashGroupAudit() {
+	for _, record := range c.flashGroupAudit.expired(time.Now()) {
+		if err := c.syncDeleteFlashGroupAudit(record); err != nil {
+			log.LogWarnf("action[cleanExpiredFlashGroupAudit] delete record[%v] err:%v", record.Key(), err)
+			continue
+		}
+		c.flashGroupAudit.delete(record)
+	}
+}
+
+func (c *Cluster) scheduleToCleanFlashGroupAudit() {
+	c.runTask(&cTask{
+		tickTime: intervalToCleanFlashGroupAudit,
+		name:     "scheduleToCleanFlashGroupAudit",
+		function: func() (fin bool) {
+			if c.partition != nil && c.partition.IsRaftLeader() {
+				c.cleanExpiredFlashGroupAudit()
+			}
+			return
+		},
+	})
+}
+
+// getFlashGroupAudit returns who created, removed, set the flash groups or added,
+// removed their flash nodes, with what and the result.
+func (m *Server) getFlashGroupAudit(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		op           common.String
+		start, end   common.Int
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupAudit))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupAudit, metric, err, nil)
+	}()
+	if err = parseArgs(r, flashGroupID.ID().OmitEmpty(), op.Key("op").OmitEmpty(),
+		start.Key("start").OmitEmpty(), end.Key("end").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.flashGroupAudit.list(flashGroupID.V, op.V, start.V, end.V)))
+}
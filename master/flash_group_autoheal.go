@@ -0,0 +1,234 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	flashGroupAutoHealInterval = time.Minute
+	// a flashnode restarting is not replaced, nor a group healed again before
+	// its clients moved to the new flashnode
+	flashGroupAutoHealDefaultCooldown = 10 * time.Minute
+)
+
+func (fg *FlashGroup) getAutoHealCooldown() time.Duration {
+	if fg.AutoHealCooldown > 0 {
+		return time.Duration(fg.AutoHealCooldown) * time.Second
+	}
+	return flashGroupAutoHealDefaultCooldown
+}
+
+// getHealth returns the count of the active and enabled flashnodes of a group,
+// and of those inactive for longer than inactiveFor, the one that has been
+// inactive the longest, since its last report.
+func (fg *FlashGroup) getHealth(inactiveFor time.Duration) (healthy int, inactive *FlashNode, inactiveSince time.Time) {
+	fg.lock.RLock()
+	defer fg.lock.RUnlock()
+	for _, flashNode := range fg.flashNodes {
+		flashNode.RLock()
+		isActive, isEnable, reportTime := flashNode.IsActive, flashNode.IsEnable, flashNode.ReportTime
+		flashNode.RUnlock()
+		if isActive {
+			if isEnable {
+				healthy++
+			}
+			continue
+		}
+		if time.Since(reportTime) <= inactiveFor {
+			continue
+		}
+		if inactive == nil || reportTime.Before(inactiveSince) ||
+			(reportTime.Equal(inactiveSince) && flashNode.Addr < inactive.Addr) {
+			inactive, inactiveSince = flashNode, reportTime
+		}
+	}
+	return
+}
+
+func (c *Cluster) scheduleToAutoHealFlashGroups() {
+	go func() {
+		ticker := time.NewTicker(flashGroupAutoHealInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopc:
+				return
+			case <-ticker.C:
+				if c.partition != nil && c.partition.IsRaftLeader() {
+					c.autoHealFlashGroups()
+				}
+			}
+		}
+	}()
+}
+
+func (c *Cluster) autoHealFlashGroups() {
+	healed := false
+	c.flashNodeTopo.flashGroupMap.Range(func(_, value interface{}) bool {
+		flashGroup := value.(*FlashGroup)
+		c.flashNodeTopo.createFlashGroupLock.Lock()
+		defer c.flashNodeTopo.createFlashGroupLock.Unlock()
+		if c.autoHealFlashGroup(flashGroup) || c.autoHealFlashGroupZones(flashGroup) {
+			healed = true
+		}
+		return true
+	})
+	if healed {
+		c.flashNodeTopo.updateClientCache()
+	}
+}
+
+// autoHealFlashGroup replaces at most one inactive flashnode of an active group
+// whose slots are settled and which has less healthy flashnodes than its min,
+// by an idle flashnode of the same zone, and records the event.
+func (c *Cluster) autoHealFlashGroup(flashGroup *FlashGroup) (healed bool) {
+	flashGroup.lock.RLock()
+	minHealthy, cooldown, healedAt := flashGroup.AutoHealMinHealthy, flashGroup.getAutoHealCooldown(), flashGroup.healedAt
+	excludeHosts := flashGroup.AutoHealExcludeHosts
+	settled := flashGroup.Status.IsActive() && flashGroup.SlotStatus == proto.SlotStatus_Completed
+	flashGroup.lock.RUnlock()
+	if minHealthy == 0 || !settled || time.Since(healedAt) < cooldown {
+		return
+	}
+	healthy, inactive, inactiveSince := flashGroup.getHealth(cooldown)
+	if healthy >= minHealthy || inactive == nil {
+		return
+	}
+	reason := fmt.Sprintf("%v healthy flashnodes below the min %v, replace inactive flashNode[%v] last reported at %v",
+		healthy, minHealthy, inactive.Addr, inactiveSince.Format(proto.TimeFormat))
+	flashNode := c.selectFlashNodeToHeal(inactive.ZoneName, excludeHosts)
+	if flashNode == nil {
+		log.LogWarnf("action[autoHealFlashGroup] flashGroup[%v] %v, but no idle flashnode in zone[%v]",
+			flashGroup.ID, reason, inactive.ZoneName)
+		return
+	}
+	if err := c.addFlashNodeToFlashGroup(flashNode.Addr, flashGroup); err != nil {
+		log.LogErrorf("action[autoHealFlashGroup] flashGroup[%v] add flashNode[%v] failed, err:%v", flashGroup.ID, flashNode.Addr, err)
+		return
+	}
+	// the replacement is in the group already, the inactive one is removed on the next round if failed
+	if err := c.removeFlashNodeFromFlashGroup(inactive.Addr, flashGroup); err != nil {
+		log.LogErrorf("action[autoHealFlashGroup] flashGroup[%v] remove flashNode[%v] failed, err:%v", flashGroup.ID, inactive.Addr, err)
+	}
+	c.putAutoHealEvent(flashGroup, flashNode, reason)
+	return true
+}
+
+// putAutoHealEvent starts the cooldown of the group healed by the flashnode,
+// and records the event.
+func (c *Cluster) putAutoHealEvent(flashGroup *FlashGroup, flashNode *FlashNode, reason string) {
+	flashGroup.lock.Lock()
+	flashGroup.healedAt = time.Now()
+	flashGroup.lock.Unlock()
+
+	load := flashGroup.getLoad()
+	event := &proto.FlashGroupScaleEvent{
+		Time:         time.Now().Unix(),
+		FlashGroupID: flashGroup.ID,
+		Action:       proto.FlashGroupAutoHeal,
+		FlashNode:    flashNode.Addr,
+		ZoneName:     flashNode.ZoneName,
+		NodeCount:    flashGroup.getFlashNodesCount(),
+		HitRate:      load.hitRate,
+		UsageRatio:   load.usageRatio,
+		Reason:       reason,
+	}
+	c.flashNodeTopo.putScaleEvent(event)
+	msg := fmt.Sprintf("flashGroup[%v] %v flashNode[%v] zone[%v], %v, %v flashnodes now",
+		event.FlashGroupID, event.Action, event.FlashNode, event.ZoneName, event.Reason, event.NodeCount)
+	log.LogInfof("action[autoHealFlashGroup] %v", msg)
+	auditlog.LogMasterOp("autoHealFlashGroup", msg, nil)
+}
+
+// selectFlashNodeToHeal picks an idle flashnode of the zone not in excludeHosts.
+func (c *Cluster) selectFlashNodeToHeal(zoneName string, excludeHosts []string) (selected *FlashNode) {
+	zone, err := c.flashNodeTopo.getZone(zoneName)
+	if err != nil {
+		return
+	}
+	zone.mu.Lock()
+	defer zone.mu.Unlock()
+	zone.flashNode.Range(func(_, value interface{}) bool {
+		flashNode := value.(*FlashNode)
+		if contains(excludeHosts, flashNode.Addr) || !flashNode.isWriteable() || !flashNode.isActiveAndEnable() {
+			return true
+		}
+		if selected == nil || flashNode.Addr < selected.Addr {
+			selected = flashNode
+		}
+		return true
+	})
+	return
+}
+
+func (m *Server) setFlashGroupAutoHeal(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		minHealthy   common.Int
+		cooldown     common.Int
+		excludeHosts common.String
+		flashGroup   *FlashGroup
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupAutoHeal))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupAutoHeal, metric, err, nil)
+	}()
+	if err = parseArgs(r, flashGroupID.ID(), minHealthy.Key("minHealthy"), cooldown.Key("cooldown").OmitEmpty(),
+		excludeHosts.Key("excludeHosts").OmitEmpty()); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if minHealthy.V < 0 || cooldown.V < 0 {
+		err = fmt.Errorf("minHealthy(%v) and cooldown(%v) should not be negative, minHealthy 0 to turn off", minHealthy.V, cooldown.V)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	var hosts []string
+	for _, host := range strings.Split(excludeHosts.V, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	if flashGroup, err = m.cluster.flashNodeTopo.getFlashGroup(flashGroupID.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+
+	flashGroup.lock.Lock()
+	oldMinHealthy, oldCooldown, oldHosts := flashGroup.AutoHealMinHealthy, flashGroup.AutoHealCooldown, flashGroup.AutoHealExcludeHosts
+	flashGroup.AutoHealMinHealthy, flashGroup.AutoHealCooldown, flashGroup.AutoHealExcludeHosts = int(minHealthy.V), cooldown.V, hosts
+	if err = m.cluster.syncUpdateFlashGroup(flashGroup); err != nil {
+		flashGroup.AutoHealMinHealthy, flashGroup.AutoHealCooldown, flashGroup.AutoHealExcludeHosts = oldMinHealthy, oldCooldown, oldHosts
+		flashGroup.lock.Unlock()
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	flashGroup.lock.Unlock()
+	log.LogInfof("action[setFlashGroupAutoHeal] flashGroup[%v] minHealthy[%v] cooldown[%v] excludeHosts%v",
+		flashGroup.ID, minHealthy.V, cooldown.V, hosts)
+	sendOkReply(w, r, newSuccessHTTPReply(flashGroup.GetAdminView()))
+}
@@ -0,0 +1,336 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	flashGroupAutoScaleInterval = time.Minute
+	// the new flashnode starts empty, let the hit rate settle before the next step
+	flashGroupAutoScaleCooldown = 10 * time.Minute
+	flashGroupScaleEventsKeep   = 256
+
+	// a group is overloaded when its cache is full and still misses, its
+	// working set does not fit. It is idle when most of its cache is unused.
+	flashGroupOverloadUsageRatio = 0.9
+	flashGroupOverloadHitRate    = 0.5
+	flashGroupIdleUsageRatio     = 0.3
+)
+
+// flashGroupLoad is the load of a group, from the disks of its active flashnodes.
+type flashGroupLoad struct {
+	disks      int
+	hitRate    float64 // weighted by the read rps
+	usageRatio float64
+}
+
+func (fg *FlashGroup) getLoad() (load flashGroupLoad) {
+	var maxAlloc, hasAlloc, rps int64
+	var hits, hitRates float64
+	fg.lock.RLock()
+	for _, flashNode := range fg.flashNodes {
+		flashNode.RLock()
+		if flashNode.IsActive && flashNode.IsEnable {
+			for _, stat := range flashNode.DiskStat {
+				load.disks++
+				maxAlloc += stat.MaxAlloc
+				hasAlloc += stat.HasAlloc
+				rps += int64(stat.ReadRps)
+				hits += stat.HitRate * float64(stat.ReadRps)
+				hitRates += stat.HitRate
+			}
+		}
+		flashNode.RUnlock()
+	}
+	fg.lock.RUnlock()
+	if load.disks == 0 {
+		return
+	}
+	if maxAlloc > 0 {
+		load.usageRatio = float64(hasAlloc) / float64(maxAlloc)
+	}
+	if rps > 0 {
+		load.hitRate = hits / float64(rps)
+	} else {
+		load.hitRate = hitRates / float64(load.disks)
+	}
+	return
+}
+
+// scaleDelta returns 1 to add a flashnode to a group of count flashnodes, -1
+// to remove one, 0 to keep it as it is.
+func (load flashGroupLoad) scaleDelta(count, min, max int) (delta int, reason string) {
+	switch {
+	case count < min:
+		return 1, fmt.Sprintf("%v flashnodes below the min %v", count, min)
+	case count > max:
+		return -1, fmt.Sprintf("%v flashnodes above the max %v", count, max)
+	case load.disks == 0:
+		return 0, ""
+	case count < max && load.usageRatio >= flashGroupOverloadUsageRatio && load.hitRate < flashGroupOverloadHitRate:
+		return 1, fmt.Sprintf("overloaded, usage %.2f hit rate %.2f", load.usageRatio, load.hitRate)
+	case count > min && load.usageRatio < flashGroupIdleUsageRatio:
+		return -1, fmt.Sprintf("idle, usage %.2f", load.usageRatio)
+	}
+	return 0, ""
+}
+
+func (c *Cluster) scheduleToAutoScaleFlashGroups() {
+	go func() {
+		ticker := time.NewTicker(flashGroupAutoScaleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopc:
+				return
+			case <-ticker.C:
+				if c.partition != nil && c.partition.IsRaftLeader() {
+					c.autoScaleFlashGroups()
+				}
+			}
+		}
+	}()
+}
+
+func (c *Cluster) autoScaleFlashGroups() {
+	scaled := false
+	c.flashNodeTopo.flashGroupMap.Range(func(_, value interface{}) bool {
+		flashGroup := value.(*FlashGroup)
+		c.flashNodeTopo.createFlashGroupLock.Lock()
+		defer c.flashNodeTopo.createFlashGroupLock.Unlock()
+		if c.autoScaleFlashGroup(flashGroup) {
+			scaled = true
+		}
+		return true
+	})
+	if scaled {
+		c.flashNodeTopo.updateClientCache()
+	}
+}
+
+// autoScaleFlashGroup adds or removes at most one flashnode of an active group
+// whose slots are settled, and records the event.
+func (c *Cluster) autoScaleFlashGroup(flashGroup *FlashGroup) (scaled bool) {
+	flashGroup.lock.RLock()
+	min, max, scaledAt := flashGroup.AutoScaleMin, flashGroup.AutoScaleMax, flashGroup.scaledAt
+	settled := flashGroup.Status.IsActive() && flashGroup.SlotStatus == proto.SlotStatus_Completed
+	flashGroup.lock.RUnlock()
+	if max == 0 || !settled || time.Since(scaledAt) < flashGroupAutoScaleCooldown {
+		return
+	}
+	load := flashGroup.getLoad()
+	delta, reason := load.scaleDelta(flashGroup.getFlashNodesCount(), min, max)
+	if delta == 0 {
+		return
+	}
+	var (
+		flashNode *FlashNode
+		action    string
+		err       error
+	)
+	if delta > 0 {
+		action = proto.FlashGroupScaleUp
+		if flashNode = c.selectFlashNodeToScaleUp(flashGroup); flashNode == nil {
+			log.LogWarnf("action[autoScaleFlashGroup] flashGroup[%v] %v, but no idle flashnode", flashGroup.ID, reason)
+			return
+		}
+		err = c.addFlashNodeToFlashGroup(flashNode.Addr, flashGroup)
+	} else {
+		action = proto.FlashGroupScaleDown
+		if flashNode = flashGroup.selectFlashNodeToScaleDown(c.getFlashGroupMinNodesPerZone()); flashNode == nil {
+			return
+		}
+		err = c.removeFlashNodeFromFlashGroup(flashNode.Addr, flashGroup)
+	}
+	if err != nil {
+		log.LogErrorf("action[autoScaleFlashGroup] flashGroup[%v] %v flashNode[%v] failed, err:%v", flashGroup.ID, action, flashNode.Addr, err)
+		return
+	}
+	flashGroup.lock.Lock()
+	flashGroup.scaledAt = time.Now()
+	flashGroup.lock.Unlock()
+
+	event := &proto.FlashGroupScaleEvent{
+		Time:         time.Now().Unix(),
+		FlashGroupID: flashGroup.ID,
+		Action:       action,
+		FlashNode:    flashNode.Addr,
+		ZoneName:     flashNode.ZoneName,
+		NodeCount:    flashGroup.getFlashNodesCount(),
+		HitRate:      load.hitRate,
+		UsageRatio:   load.usageRatio,
+		Reason:       reason,
+	}
+	c.flashNodeTopo.putScaleEvent(event)
+	msg := fmt.Sprintf("flashGroup[%v] %v flashNode[%v] zone[%v], %v, %v flashnodes now",
+		event.FlashGroupID, event.Action, event.FlashNode, event.ZoneName, event.Reason, event.NodeCount)
+	log.LogInfof("action[autoScaleFlashGroup] %v", msg)
+	auditlog.LogMasterOp("autoScaleFlashGroup", msg, nil)
+	return true
+}
+
+// selectFlashNodeToScaleUp picks an idle flashnode, in the zone of the group
+// with the least flashnodes, or in another zone if none of them has one.
+func (c *Cluster) selectFlashNodeToScaleUp(flashGroup *FlashGroup) (selected *FlashNode) {
+	zoneCount := make(map[string]int)
+	flashGroup.lock.RLock()
+	for _, flashNode := range flashGroup.flashNodes {
+		zoneCount[flashNode.ZoneName]++
+	}
+	otherZone := len(flashGroup.flashNodes) + 1
+	flashGroup.lock.RUnlock()
+
+	candidates := make([]*FlashNode, 0)
+	c.flashNodeTopo.flashNodeMap.Range(func(_, value interface{}) bool {
+		flashNode := value.(*FlashNode)
+		if flashNode.isWriteable() && flashNode.isActiveAndEnable() {
+			candidates = append(candidates, flashNode)
+		}
+		return true
+	})
+	rank := func(flashNode *FlashNode) int {
+		if count, ok := zoneCount[flashNode.ZoneName]; ok {
+			return count
+		}
+		return otherZone
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ri, rj := rank(candidates[i]), rank(candidates[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return candidates[i].Addr < candidates[j].Addr
+	})
+	if len(candidates) > 0 {
+		selected = candidates[0]
+	}
+	return
+}
+
+// selectFlashNodeToScaleDown picks an inactive flashnode first, then the one
+// serving the least reads of those whose zone keeps more than minNodesPerZone
+// active flashnodes.
+func (fg *FlashGroup) selectFlashNodeToScaleDown(minNodesPerZone uint64) (selected *FlashNode) {
+	var minRps int64
+	zones := fg.getZonesHealth(0)
+	fg.lock.RLock()
+	defer fg.lock.RUnlock()
+	for _, flashNode := range fg.flashNodes {
+		flashNode.RLock()
+		rps := int64(-1)
+		if flashNode.IsActive && flashNode.IsEnable {
+			rps = 0
+			for _, stat := range flashNode.DiskStat {
+				rps += int64(stat.ReadRps)
+			}
+		}
+		flashNode.RUnlock()
+		if rps >= 0 && minNodesPerZone > 0 && uint64(zones[flashNode.ZoneName].healthy) <= minNodesPerZone {
+			continue
+		}
+		if selected == nil || rps < minRps || (rps == minRps && flashNode.Addr < selected.Addr) {
+			selected, minRps = flashNode, rps
+		}
+	}
+	return
+}
+
+func (t *flashNodeTopology) putScaleEvent(event *proto.FlashGroupScaleEvent) {
+	t.scaleEventsLock.Lock()
+	t.scaleEvents = append(t.scaleEvents, event)
+	if len(t.scaleEvents) > flashGroupScaleEventsKeep {
+		t.scaleEvents = t.scaleEvents[len(t.scaleEvents)-flashGroupScaleEventsKeep:]
+	}
+	t.scaleEventsLock.Unlock()
+}
+
+// getScaleEvents returns the events of a group, or of all with id 0, newest first.
+func (t *flashNodeTopology) getScaleEvents(flashGroupID uint64) (events []*proto.FlashGroupScaleEvent) {
+	events = make([]*proto.FlashGroupScaleEvent, 0)
+	t.scaleEventsLock.Lock()
+	for i := len(t.scaleEvents) - 1; i >= 0; i-- {
+		if flashGroupID == 0 || t.scaleEvents[i].FlashGroupID == flashGroupID {
+			events = append(events, t.scaleEvents[i])
+		}
+	}
+	t.scaleEventsLock.Unlock()
+	return
+}
+
+func (m *Server) setFlashGroupAutoScale(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		min, max     common.Int
+		flashGroup   *FlashGroup
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupAutoScale))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupAutoScale, metric, err, nil)
+	}()
+	if err = parseArgs(r, flashGroupID.ID(), min.Key("min").OmitEmpty(), max.Key("max")); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if max.V < 0 || (max.V > 0 && (min.V < 1 || min.V > max.V)) {
+		err = fmt.Errorf("min(%v) and max(%v) should be 1 <= min <= max, or max 0 to turn off", min.V, max.V)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if flashGroup, err = m.cluster.flashNodeTopo.getFlashGroup(flashGroupID.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+
+	flashGroup.lock.Lock()
+	oldMin, oldMax := flashGroup.AutoScaleMin, flashGroup.AutoScaleMax
+	flashGroup.AutoScaleMin, flashGroup.AutoScaleMax = int(min.V), int(max.V)
+	if err = m.cluster.syncUpdateFlashGroup(flashGroup); err != nil {
+		flashGroup.AutoScaleMin, flashGroup.AutoScaleMax = oldMin, oldMax
+		flashGroup.lock.Unlock()
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	flashGroup.lock.Unlock()
+	log.LogInfof("action[setFlashGroupAutoScale] flashGroup[%v] min[%v] max[%v]", flashGroup.ID, min.V, max.V)
+	sendOkReply(w, r, newSuccessHTTPReply(flashGroup.GetAdminView()))
+}
+
+func (m *Server) getFlashGroupScaleEvents(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupScaleEvent))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupScaleEvent, metric, err, nil)
+	}()
+	if err = parseArgs(r, flashGroupID.ID().OmitEmpty()); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.flashNodeTopo.getScaleEvents(flashGroupID.V)))
+}
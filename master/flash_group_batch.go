@@ -0,0 +1,240 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// the most flash groups created by one batch
+const flashGroupBatchCreateMax = 64
+
+// batchCreateFlashGroups creates the flash groups of a cache tier with their nodes
+// and slots in one call, instead of one create and many node adds per group.
+func (m *Server) batchCreateFlashGroups(w http.ResponseWriter, r *http.Request) {
+	var (
+		count         common.Int
+		nodesPerGroup common.Int
+		zonePolicy    common.String
+		weight        common.Uint
+		groups        []*FlashGroup
+		err           error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupBatchCreate))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupBatchCreate, metric, err, nil)
+		if err != nil {
+			m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpBatchCreate, 0, err)
+			return
+		}
+		for _, fg := range groups {
+			m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpBatchCreate, fg.ID, nil)
+		}
+	}()
+	if err = parseArgs(r, count.Count(), nodesPerGroup.Key("nodesPerGroup"),
+		zonePolicy.Key("zonePolicy").OmitEmpty(), weight.Key("weight").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if count.V <= 0 || count.V > flashGroupBatchCreateMax {
+		err = fmt.Errorf("count(%v) should be in (0, %v]", count.V, flashGroupBatchCreateMax)
+	} else if nodesPerGroup.V <= 0 {
+		err = fmt.Errorf("nodesPerGroup(%v) should be greater than 0", nodesPerGroup.V)
+	} else if weight.V > proto.FlashGroupMaxWeight {
+		err = fmt.Errorf("weight(%v) should not be greater than %v", weight.V, proto.FlashGroupMaxWeight)
+	} else if zonePolicy.V == "" {
+		zonePolicy.V = proto.FlashGroupZoneSpread
+	} else if zonePolicy.V != proto.FlashGroupZoneSpread && zonePolicy.V != proto.FlashGroupZonePack {
+		err = fmt.Errorf("zonePolicy(%v) should be %v or %v", zonePolicy.V, proto.FlashGroupZoneSpread, proto.FlashGroupZonePack)
+	}
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if weight.V == 0 {
+		weight.V = proto.FlashGroupDefaultWeight
+	}
+	if groups, err = m.cluster.batchCreateFlashGroups(int(count.V), int(nodesPerGroup.V), zonePolicy.V, uint32(weight.V)); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	views := make([]proto.FlashGroupAdminView, 0, len(groups))
+	for _, fg := range groups {
+		views = append(views, fg.GetAdminView())
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(views))
+}
+
+// batchCreateFlashGroups creates count inactive flash groups of nodesPerGroup idle
+// flash nodes each, and the slots of weight. The groups and the nodes joining them
+// are committed in one raft command, so either all or none of them are created.
+func (c *Cluster) batchCreateFlashGroups(count, nodesPerGroup int, zonePolicy string, weight uint32) (groups []*FlashGroup, err error) {
+	defer func() {
+		if err != nil {
+			log.LogErrorf("action[batchCreateFlashGroups] clusterID[%v] count[%v] nodesPerGroup[%v] zonePolicy[%v] err:%v",
+				c.Name, count, nodesPerGroup, zonePolicy, err)
+		}
+	}()
+	t := c.flashNodeTopo
+	t.createFlashGroupLock.Lock()
+	defer t.createFlashGroupLock.Unlock()
+
+	plan, err := planFlashGroupNodes(t.getIdleFlashNodes(), count, nodesPerGroup, zonePolicy)
+	if err != nil {
+		return
+	}
+	for _, nodes := range plan {
+		for _, flashNode := range nodes {
+			flashNode.Lock()
+			defer flashNode.Unlock()
+			if flashNode.FlashGroupID != unusedFlashNodeFlashGroupID {
+				err = fmt.Errorf("flashNode[%v] joined flashGroup[%v] meanwhile", flashNode.Addr, flashNode.FlashGroupID)
+				return
+			}
+		}
+	}
+
+	slotsPerGroup := int(weight) * defaultFlashGroupSlotsCount
+	slots := t.allocateNewSlots(count * slotsPerGroup)
+	cmds := make(map[string]*RaftCmd)
+	for i, nodes := range plan {
+		var id uint64
+		if id, err = c.idAlloc.allocateCommonID(); err != nil {
+			return
+		}
+		groupSlots := slots[i*slotsPerGroup : (i+1)*slotsPerGroup]
+		sort.Slice(groupSlots, func(i, j int) bool { return groupSlots[i] < groupSlots[j] })
+		fg := newFlashGroup(id, groupSlots, proto.SlotStatus_Completed, make([]uint32, 0), 0, proto.FlashGroupStatus_Inactive, weight)
+		cmd := &RaftCmd{Op: opSyncAddFlashGroup, K: flashGroupPrefix + strconv.FormatUint(id, 10)}
+		if cmd.V, err = json.Marshal(fg.flashGroupValue); err != nil {
+			return
+		}
+		cmds[cmd.K] = cmd
+		for _, flashNode := range nodes {
+			value := flashNode.flashNodeValue
+			value.FlashGroupID = id
+			cmd = &RaftCmd{Op: opSyncUpdateFlashNode, K: flashNodePrefix + strconv.FormatUint(value.ID, 10) + keySeparator + value.Addr}
+			if cmd.V, err = json.Marshal(value); err != nil {
+				return
+			}
+			cmds[cmd.K] = cmd
+		}
+		groups = append(groups, fg)
+	}
+	if err = c.syncBatchCommitCmd(cmds); err != nil {
+		return
+	}
+
+	for i, fg := range groups {
+		t.flashGroupMap.Store(fg.ID, fg)
+		for _, slot := range fg.Slots {
+			t.slotsMap[slot] = fg.ID
+		}
+		c.publishEvent(proto.ClusterEventFlashGroupCreate, fg.ID, "", "")
+		for _, flashNode := range plan[i] {
+			flashNode.FlashGroupID = fg.ID
+			fg.putFlashNode(flashNode)
+			c.publishEvent(proto.ClusterEventFlashGroupNodeAdd, fg.ID, flashNode.Addr, "")
+		}
+		log.LogInfof("action[batchCreateFlashGroups] clusterID[%v] flashGroup[%v] nodes%v slots[%v]",
+			c.Name, fg.ID, fg.getFlashNodeHosts(false), len(fg.Slots))
+	}
+	t.updateClientCache()
+	return
+}
+
+// getIdleFlashNodes returns the active and enabled flash nodes in no flash group,
+// key: zone name, sorted by address.
+func (t *flashNodeTopology) getIdleFlashNodes() (zoneNodes map[string][]*FlashNode) {
+	zoneNodes = make(map[string][]*FlashNode)
+	t.flashNodeMap.Range(func(_, value interface{}) bool {
+		flashNode := value.(*FlashNode)
+		if flashNode.isWriteable() && flashNode.isActiveAndEnable() {
+			zoneNodes[flashNode.ZoneName] = append(zoneNodes[flashNode.ZoneName], flashNode)
+		}
+		return true
+	})
+	for _, nodes := range zoneNodes {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Addr < nodes[j].Addr })
+	}
+	return
+}
+
+// planFlashGroupNodes picks the nodes of count flash groups of nodesPerGroup each out
+// of the idle nodes of the zones. With FlashGroupZoneSpread the nodes of a group are
+// in as many zones as possible, with FlashGroupZonePack they are all in one zone.
+// The zones having the most idle nodes left are taken first.
+func planFlashGroupNodes(zoneNodes map[string][]*FlashNode, count, nodesPerGroup int, zonePolicy string) (plan [][]*FlashNode, err error) {
+	zones := make([]string, 0, len(zoneNodes))
+	idle := 0
+	for zone, nodes := range zoneNodes {
+		zones = append(zones, zone)
+		idle += len(nodes)
+	}
+	sort.Strings(zones)
+	if idle < count*nodesPerGroup {
+		return nil, fmt.Errorf("%v idle flash nodes, not enough for %v flash groups of %v nodes", idle, count, nodesPerGroup)
+	}
+	used := make(map[string]int, len(zones)) // nodes taken of each zone
+	left := func(zone string) int { return len(zoneNodes[zone]) - used[zone] }
+	take := func(zone string) (flashNode *FlashNode) {
+		flashNode = zoneNodes[zone][used[zone]]
+		used[zone]++
+		return
+	}
+	for i := 0; i < count; i++ {
+		nodes := make([]*FlashNode, 0, nodesPerGroup)
+		if zonePolicy == proto.FlashGroupZonePack {
+			best := ""
+			for _, zone := range zones {
+				if left(zone) >= nodesPerGroup && (best == "" || left(zone) > left(best)) {
+					best = zone
+				}
+			}
+			if best == "" {
+				return nil, fmt.Errorf("no zone has %v idle flash nodes left for flash group %v of %v", nodesPerGroup, i+1, count)
+			}
+			for len(nodes) < nodesPerGroup {
+				nodes = append(nodes, take(best))
+			}
+		} else {
+			inGroup := make(map[string]int, len(zones))
+			for len(nodes) < nodesPerGroup {
+				best := ""
+				for _, zone := range zones {
+					if left(zone) == 0 {
+						continue
+					}
+					if best == "" || inGroup[zone] < inGroup[best] || (inGroup[zone] == inGroup[best] && left(zone) > left(best)) {
+						best = zone
+					}
+				}
+				inGroup[best]++
+				nodes = append(nodes, take(best))
+			}
+		}
+		plan = append(plan, nodes)
+	}
+	return
+}
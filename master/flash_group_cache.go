@@ -0,0 +1,215 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/httpclient"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	flashGroupCacheKeysLimit      = 100
+	flashGroupCacheRequestTimeout = 10 * time.Second
+)
+
+// flashNodeHTTPAddr is the address of the http server of the flashnode, it
+// listens on the port next to the one registered.
+func flashNodeHTTPAddr(addr string) string {
+	arr := strings.SplitN(addr, ":", 2)
+	if len(arr) != 2 {
+		return addr
+	}
+	p, _ := strconv.ParseUint(arr[1], 10, 64)
+	return fmt.Sprintf("%s:%d", arr[0], p+1)
+}
+
+type flashGroupCacheNode struct {
+	flashGroupID uint64
+	addr         string
+}
+
+// flashGroupCacheNodes returns the flashnodes of the flash group, or of all the
+// flash groups with id 0, ordered by address.
+func (c *Cluster) flashGroupCacheNodes(flashGroupID uint64) (nodes []flashGroupCacheNode, err error) {
+	nodes = make([]flashGroupCacheNode, 0)
+	if flashGroupID != 0 {
+		var flashGroup *FlashGroup
+		if flashGroup, err = c.flashNodeTopo.getFlashGroup(flashGroupID); err != nil {
+			return
+		}
+		for _, addr := range flashGroup.getFlashNodeHosts(false) {
+			nodes = append(nodes, flashGroupCacheNode{flashGroupID: flashGroupID, addr: addr})
+		}
+	} else {
+		c.flashNodeTopo.flashNodeMap.Range(func(_, value interface{}) bool {
+			flashNode := value.(*FlashNode)
+			flashNode.RLock()
+			if flashNode.FlashGroupID != unusedFlashNodeFlashGroupID {
+				nodes = append(nodes, flashGroupCacheNode{flashGroupID: flashNode.FlashGroupID, addr: flashNode.Addr})
+			}
+			flashNode.RUnlock()
+			return true
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].addr < nodes[j].addr })
+	return
+}
+
+// forEachFlashGroupCacheNode calls f with the http client of each flashnode at
+// the same time, and waits for all of them.
+func forEachFlashGroupCacheNode(nodes []flashGroupCacheNode, f func(i int, fn httpclient.FlashNode)) {
+	var wg sync.WaitGroup
+	for i := range nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f(i, httpclient.New().Timeout(flashGroupCacheRequestTimeout).Addr(flashNodeHTTPAddr(nodes[i].addr)).FlashNode())
+		}(i)
+	}
+	wg.Wait()
+}
+
+// listFlashGroupCacheKeys merges the most read blocks of the volume, or of all
+// the volumes if it is empty, cached by the flashnodes of the flash groups.
+func (c *Cluster) listFlashGroupCacheKeys(flashGroupID uint64, volume, prefix string, limit int) (view *proto.FlashGroupCacheKeys, err error) {
+	nodes, err := c.flashGroupCacheNodes(flashGroupID)
+	if err != nil {
+		return
+	}
+	view = &proto.FlashGroupCacheKeys{Keys: make([]*proto.FlashGroupCacheKey, 0), Failed: make(map[string]string)}
+	var mu sync.Mutex
+	forEachFlashGroupCacheNode(nodes, func(i int, fn httpclient.FlashNode) {
+		keys, kerr := fn.Keys(volume, prefix, true, limit)
+		mu.Lock()
+		defer mu.Unlock()
+		if kerr != nil {
+			view.Failed[nodes[i].addr] = kerr.Error()
+			return
+		}
+		view.Total += keys.Total
+		for _, key := range keys.Keys {
+			view.Keys = append(view.Keys, &proto.FlashGroupCacheKey{
+				FlashNodeCacheKey: *key, FlashGroupID: nodes[i].flashGroupID, FlashNode: nodes[i].addr,
+			})
+		}
+	})
+	sort.SliceStable(view.Keys, func(i, j int) bool { return view.Keys[i].Hits > view.Keys[j].Hits })
+	if limit > 0 && len(view.Keys) > limit {
+		view.Keys = view.Keys[:limit]
+	}
+	return
+}
+
+// purgeFlashGroupCache evicts the blocks of the volume whose key in the volume
+// starts with the prefix from the flashnodes of the flash groups. The blocks
+// are cached again by the next reads.
+func (c *Cluster) purgeFlashGroupCache(flashGroupID uint64, volume, prefix string) (view *proto.FlashGroupCachePurge, err error) {
+	nodes, err := c.flashGroupCacheNodes(flashGroupID)
+	if err != nil {
+		return
+	}
+	view = &proto.FlashGroupCachePurge{Nodes: make([]*proto.FlashGroupNodePurge, len(nodes))}
+	forEachFlashGroupCacheNode(nodes, func(i int, fn httpclient.FlashNode) {
+		node := &proto.FlashGroupNodePurge{FlashGroupID: nodes[i].flashGroupID, FlashNode: nodes[i].addr}
+		purged, perr := fn.Purge(volume, prefix)
+		if perr != nil {
+			node.Err = perr.Error()
+		} else {
+			node.Purged, node.Failed = purged.Purged, purged.Failed
+		}
+		view.Nodes[i] = node
+	})
+	failed := make([]string, 0)
+	for _, node := range view.Nodes {
+		view.Purged += node.Purged
+		if node.Err != "" || len(node.Failed) > 0 {
+			failed = append(failed, node.FlashNode)
+		}
+	}
+	msg := fmt.Sprintf("flashGroup[%v] volume[%v] prefix[%v] purged[%v] of flashnodes[%v] failed%v",
+		flashGroupID, volume, prefix, view.Purged, len(nodes), failed)
+	log.LogWarnf("action[purgeFlashGroupCache] %v", msg)
+	auditlog.LogMasterOp("purgeFlashGroupCache", msg, nil)
+	return
+}
+
+func (m *Server) getFlashGroupCacheKeys(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		volume       common.String
+		prefix       common.String
+		limit        common.Int
+		view         *proto.FlashGroupCacheKeys
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupCacheKeys))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupCacheKeys, metric, err, nil)
+	}()
+	limit.V = flashGroupCacheKeysLimit
+	if err = parseArgs(r, flashGroupID.ID().OmitEmpty(), volume.Key("volume").OmitEmpty(),
+		prefix.Key("prefix").OmitEmpty(), limit.Key("limit").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if limit.V < 0 || (prefix.V != "" && volume.V == "") {
+		err = fmt.Errorf("limit(%v) is negative or prefix(%v) is set without volume", limit.V, prefix.V)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if view, err = m.cluster.listFlashGroupCacheKeys(flashGroupID.V, volume.V, prefix.V, int(limit.V)); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(view))
+}
+
+// purgeFlashGroupCache evicts the stale or sensitive blocks of a volume from
+// the flash groups on demand.
+func (m *Server) purgeFlashGroupCache(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		volume       common.String
+		prefix       common.String
+		view         *proto.FlashGroupCachePurge
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupPurgeCache))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupPurgeCache, metric, err, nil)
+		m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpPurgeCache, flashGroupID.V, err)
+	}()
+	if err = parseArgs(r, flashGroupID.ID().OmitEmpty(), volume.Key("volume"), prefix.Key("prefix").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if view, err = m.cluster.purgeFlashGroupCache(flashGroupID.V, volume.V, prefix.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(view))
+}
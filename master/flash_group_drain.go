@@ -0,0 +1,132 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	flashGroupDrainCheckInterval = 10 * time.Second
+	flashGroupMaxDrainTTL        = 7 * 24 * time.Hour
+)
+
+func (fg *FlashGroup) isDrained(now time.Time) bool {
+	fg.lock.RLock()
+	defer fg.lock.RUnlock()
+	return fg.Status == proto.FlashGroupStatus_Draining && now.Unix() >= fg.DrainDeadline
+}
+
+// drainFlashGroup is the first phase of the removal of an active group: the
+// clients keep reading the keys it caches but prepare nothing in it, and its
+// flashnodes cache nothing on miss, until it is removed after ttl.
+func (c *Cluster) drainFlashGroup(flashGroup *FlashGroup, ttl time.Duration) (err error) {
+	flashGroup.lock.Lock()
+	oldStatus, oldDrainDeadline := flashGroup.Status, flashGroup.DrainDeadline
+	switch oldStatus {
+	case proto.FlashGroupStatus_Draining:
+		flashGroup.lock.Unlock()
+		return fmt.Errorf("flashGroup(%v) is draining already, it is removed at %v",
+			flashGroup.ID, time.Unix(oldDrainDeadline, 0).Format(proto.TimeFormat))
+	case proto.FlashGroupStatus_Active:
+	default:
+		flashGroup.lock.Unlock()
+		return fmt.Errorf("flashGroup(%v) is %v, it is not read by the clients and can be removed directly", flashGroup.ID, oldStatus)
+	}
+	flashGroup.Status, flashGroup.DrainDeadline = proto.FlashGroupStatus_Draining, time.Now().Add(ttl).Unix()
+	if err = c.syncUpdateFlashGroup(flashGroup); err != nil {
+		flashGroup.Status, flashGroup.DrainDeadline = oldStatus, oldDrainDeadline
+		flashGroup.lock.Unlock()
+		return
+	}
+	msg := fmt.Sprintf("flashGroup[%v] draining, removed at %v", flashGroup.ID,
+		time.Unix(flashGroup.DrainDeadline, 0).Format(proto.TimeFormat))
+	flashGroup.lock.Unlock()
+
+	log.LogInfof("action[drainFlashGroup] %v", msg)
+	auditlog.LogMasterOp("drainFlashGroup", msg, nil)
+	c.flashNodeTopo.updateClientCache()
+	c.publishEvent(proto.ClusterEventFlashGroupStatus, flashGroup.ID, "", proto.FlashGroupStatus_Draining.String())
+	return
+}
+
+// isFlashNodeDraining tells the flashnode in its heartbeat to cache nothing, as
+// it is read only or its flash group is draining.
+func (c *Cluster) isFlashNodeDraining(flashNode *FlashNode) bool {
+	flashNode.RLock()
+	fgID, readOnly := flashNode.FlashGroupID, flashNode.ReadOnly
+	flashNode.RUnlock()
+	if readOnly {
+		return true
+	}
+	if fgID == unusedFlashNodeFlashGroupID {
+		return false
+	}
+	flashGroup, err := c.flashNodeTopo.getFlashGroup(fgID)
+	if err != nil {
+		return false
+	}
+	return flashGroup.GetStatus() == proto.FlashGroupStatus_Draining
+}
+
+func (c *Cluster) scheduleToRemoveDrainedFlashGroups() {
+	go func() {
+		ticker := time.NewTicker(flashGroupDrainCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopc:
+				return
+			case <-ticker.C:
+				if c.partition != nil && c.partition.IsRaftLeader() {
+					c.removeDrainedFlashGroups()
+				}
+			}
+		}
+	}()
+}
+
+// removeDrainedFlashGroups is the second phase, it removes the groups whose
+// drain deadline has passed, as removeFlashGroup does at once.
+func (c *Cluster) removeDrainedFlashGroups() {
+	now := time.Now()
+	drained := make([]*FlashGroup, 0)
+	c.flashNodeTopo.flashGroupMap.Range(func(_, value interface{}) bool {
+		if flashGroup := value.(*FlashGroup); flashGroup.isDrained(now) {
+			drained = append(drained, flashGroup)
+		}
+		return true
+	})
+	removed := false
+	for _, flashGroup := range drained {
+		slots := flashGroup.getSlots()
+		if err := c.removeFlashGroup(flashGroup, false, 0); err != nil {
+			log.LogErrorf("action[removeDrainedFlashGroups] remove flashGroup[%v] failed, err:%v", flashGroup.ID, err)
+			continue
+		}
+		removed = true
+		msg := fmt.Sprintf("flashGroup[%v] drained and removed, slots:%v", flashGroup.ID, slots)
+		log.LogInfof("action[removeDrainedFlashGroups] %v", msg)
+		auditlog.LogMasterOp("removeDrainedFlashGroup", msg, nil)
+	}
+	if removed {
+		c.flashNodeTopo.updateClientCache()
+	}
+}
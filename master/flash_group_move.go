@@ -0,0 +1,160 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// parseFlashGroupSlots parses the slots separated by comma, all of them
+// unlike getSetSlots which keeps the slots of a new group only.
+func parseFlashGroupSlots(value string) (slots []uint32, err error) {
+	slots = make([]uint32, 0)
+	seen := make(map[uint32]struct{})
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		var slot uint64
+		if slot, err = strconv.ParseUint(s, 10, 32); err != nil {
+			return nil, fmt.Errorf("slot(%v) is invalid: %v", s, err)
+		}
+		if _, ok := seen[uint32(slot)]; ok {
+			return nil, fmt.Errorf("slot(%v) is duplicated", slot)
+		}
+		seen[uint32(slot)] = struct{}{}
+		slots = append(slots, uint32(slot))
+	}
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("no slots")
+	}
+	return
+}
+
+// checkFlashGroupSlotsMove tells why the slots can not be moved from src to dst.
+func (t *flashNodeTopology) checkFlashGroupSlotsMove(src, dst *FlashGroup, slots []uint32) error {
+	if src.ID == dst.ID {
+		return fmt.Errorf("flashGroup[%v] is both the source and the destination", src.ID)
+	}
+	for _, fg := range []*FlashGroup{src, dst} {
+		if status := fg.getSlotStatus(); status != proto.SlotStatus_Completed {
+			return fmt.Errorf("flashGroup[%v] is changing its slots, slotStatus[%v]", fg.ID, status)
+		}
+	}
+	if !dst.GetStatus().IsActive() {
+		return fmt.Errorf("flashGroup[%v] is not active", dst.ID)
+	}
+	for _, slot := range slots {
+		if id, ok := t.slotsMap[slot]; !ok || id != src.ID {
+			return fmt.Errorf("slot[%v] does not belong to flashGroup[%v]", slot, src.ID)
+		}
+	}
+	if len(slots) >= src.getSlotsCount() {
+		return fmt.Errorf("flashGroup[%v] would have no slots left, remove it instead", src.ID)
+	}
+	return nil
+}
+
+// moveFlashGroupSlots moves the slots from src to dst at once, the keys of
+// the slots are cached by dst from then on and the other keys stay where they
+// are, unlike a rebalance which moves the slots of all the groups.
+func (c *Cluster) moveFlashGroupSlots(srcID, dstID uint64, slots []uint32) (src, dst *FlashGroup, err error) {
+	t := c.flashNodeTopo
+	t.createFlashGroupLock.Lock()
+	defer t.createFlashGroupLock.Unlock()
+
+	if src, err = t.getFlashGroup(srcID); err != nil {
+		return
+	}
+	if dst, err = t.getFlashGroup(dstID); err != nil {
+		return
+	}
+	if err = t.checkFlashGroupSlotsMove(src, dst, slots); err != nil {
+		return
+	}
+
+	src.lock.Lock()
+	defer src.lock.Unlock()
+	dst.lock.Lock()
+	defer dst.lock.Unlock()
+	srcValue, dstValue := src.flashGroupValue, dst.flashGroupValue
+	srcValue.Slots = getNewSlots(src.Slots, slots, proto.SlotStatus_Deleting)
+	dstValue.Slots = getNewSlots(append([]uint32(nil), dst.Slots...), slots, proto.SlotStatus_Creating)
+	cmds := make(map[string]*RaftCmd)
+	for _, value := range []flashGroupValue{srcValue, dstValue} {
+		cmd := &RaftCmd{Op: opSyncUpdateFlashGroup, K: flashGroupPrefix + strconv.FormatUint(value.ID, 10)}
+		if cmd.V, err = json.Marshal(value); err != nil {
+			return
+		}
+		cmds[cmd.K] = cmd
+	}
+	if err = c.syncBatchCommitCmd(cmds); err != nil {
+		return
+	}
+	src.Slots, dst.Slots = srcValue.Slots, dstValue.Slots
+	for _, slot := range slots {
+		t.slotsMap[slot] = dst.ID
+	}
+
+	sorted := append([]uint32(nil), slots...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	msg := fmt.Sprintf("flashGroup[%v] slots%v to flashGroup[%v]", src.ID, sorted, dst.ID)
+	log.LogInfof("action[moveFlashGroupSlots] %v", msg)
+	auditlog.LogMasterOp("moveFlashGroupSlots", msg, nil)
+	return
+}
+
+// moveFlashGroupSlots moves some slots of a flash group to another for a
+// manual rebalance of the hot keys.
+func (m *Server) moveFlashGroupSlots(w http.ResponseWriter, r *http.Request) {
+	var (
+		srcID    common.Uint
+		dstID    common.Uint
+		slotsStr common.String
+		slots    []uint32
+		src, dst *FlashGroup
+		err      error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupMoveSlots))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupMoveSlots, metric, err, nil)
+		m.cluster.recordFlashGroupOp(r, proto.FlashGroupOpMoveSlots, srcID.V, err)
+	}()
+	if err = parseArgs(r, srcID.Key("srcId"), dstID.Key("dstId"), slotsStr.Key("slots")); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if slots, err = parseFlashGroupSlots(slotsStr.V); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if src, dst, err = m.cluster.moveFlashGroupSlots(srcID.V, dstID.V, slots); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	m.cluster.flashNodeTopo.updateClientCache()
+	sendOkReply(w, r, newSuccessHTTPReply([]proto.FlashGroupAdminView{src.GetAdminView(), dst.GetAdminView()}))
+}
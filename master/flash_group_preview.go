@@ -0,0 +1,184 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+// A key of the cache is owned by the group of the first slot not less than its
+// hash on the ring, or of the least slot if there is none. The previews below
+// compute the slots of the groups once a change completes, without committing
+// it, and how much of the keyspace it moves to another group.
+
+// copySlotsMap returns the owners of the slots, the caller holds createFlashGroupLock.
+func (t *flashNodeTopology) copySlotsMap() (owners map[uint32]uint64) {
+	owners = make(map[uint32]uint64, len(t.slotsMap))
+	for slot, id := range t.slotsMap {
+		owners[slot] = id
+	}
+	return
+}
+
+func sortedSlots(owners map[uint32]uint64) (slots []uint32) {
+	slots = make([]uint32, 0, len(owners))
+	for slot := range owners {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return
+}
+
+// slotOwner returns the owner of the hash by the sorted slots, 0 if none.
+func slotOwner(slots []uint32, owners map[uint32]uint64, hash uint32) uint64 {
+	if len(slots) == 0 {
+		return 0
+	}
+	i := sort.Search(len(slots), func(i int) bool { return slots[i] >= hash })
+	if i == len(slots) {
+		i = 0
+	}
+	return owners[slots[i]]
+}
+
+// remappedPercent returns the percentage of the keyspace owned by a group
+// before, and owned by another group after. The owners of both only change at
+// their slots, so each arc between two adjacent slots of both has one owner.
+func remappedPercent(before, after map[uint32]uint64) float64 {
+	beforeSlots, afterSlots := sortedSlots(before), sortedSlots(after)
+	union := make(map[uint32]uint64, len(before)+len(after))
+	for slot := range before {
+		union[slot] = 0
+	}
+	for slot := range after {
+		union[slot] = 0
+	}
+	points := sortedSlots(union)
+	var remapped uint64
+	for i, point := range points {
+		var length uint64 = 1 << 32
+		if len(points) > 1 {
+			prev := points[(i+len(points)-1)%len(points)]
+			length = uint64(point - prev)
+		}
+		owner := slotOwner(beforeSlots, before, point)
+		if owner != 0 && owner != slotOwner(afterSlots, after, point) {
+			remapped += length
+		}
+	}
+	return math.Round(float64(remapped)*100*100/(1<<32)) / 100
+}
+
+func newFlashGroupSlotsPreview(before, after map[uint32]uint64) (preview *proto.FlashGroupSlotsPreview) {
+	preview = &proto.FlashGroupSlotsPreview{
+		SlotOwners:      after,
+		GroupSlots:      make(map[uint64]int),
+		AddedSlots:      make([]uint32, 0),
+		RemovedSlots:    make([]uint32, 0),
+		RemappedPercent: remappedPercent(before, after),
+	}
+	for slot, id := range after {
+		preview.GroupSlots[id]++
+		if old, ok := before[slot]; !ok || old != id {
+			preview.AddedSlots = append(preview.AddedSlots, slot)
+		}
+	}
+	for slot := range before {
+		if _, ok := after[slot]; !ok {
+			preview.RemovedSlots = append(preview.RemovedSlots, slot)
+		}
+	}
+	sort.Slice(preview.AddedSlots, func(i, j int) bool { return preview.AddedSlots[i] < preview.AddedSlots[j] })
+	sort.Slice(preview.RemovedSlots, func(i, j int) bool { return preview.RemovedSlots[i] < preview.RemovedSlots[j] })
+	return
+}
+
+// previewCreateFlashGroup takes the slots createFlashGroup would take. The new
+// slots are random, so a group created later takes other ones unless they are
+// set to the added slots of the preview.
+func (c *Cluster) previewCreateFlashGroup(setSlots []uint32, setWeight uint32, capacity uint64) *proto.FlashGroupSlotsPreview {
+	t := c.flashNodeTopo
+	t.createFlashGroupLock.Lock()
+	defer t.createFlashGroupLock.Unlock()
+
+	before := t.copySlotsMap()
+	if len(setSlots) == 0 && capacity > 0 {
+		if count := t.getSlotsCountOfCapacity(capacity); count > 0 {
+			setSlots = t.allocateNewSlots(count)
+		}
+	}
+	after := t.copySlotsMap()
+	for _, slot := range t.allocateNewSlotsForCreateFlashGroup(0, setSlots, setWeight) {
+		after[slot] = 0
+	}
+	return newFlashGroupSlotsPreview(before, after)
+}
+
+// previewRemoveFlashGroup drops all the slots of the group, as removing it at
+// once, gradually or after draining it does in the end.
+func (c *Cluster) previewRemoveFlashGroup(flashGroup *FlashGroup) *proto.FlashGroupSlotsPreview {
+	t := c.flashNodeTopo
+	t.createFlashGroupLock.Lock()
+	defer t.createFlashGroupLock.Unlock()
+
+	before := t.copySlotsMap()
+	after := t.copySlotsMap()
+	for _, slot := range flashGroup.getSlots() {
+		delete(after, slot)
+	}
+	return newFlashGroupSlotsPreview(before, after)
+}
+
+// previewRebalanceFlashGroupSlots moves the slots of the groups to their
+// targets as rebalanceFlashGroupSlots does.
+func (c *Cluster) previewRebalanceFlashGroupSlots() (preview *proto.FlashGroupSlotsPreview, err error) {
+	t := c.flashNodeTopo
+	t.createFlashGroupLock.Lock()
+	defer t.createFlashGroupLock.Unlock()
+
+	shares := t.getSlotsShares()
+	computeSlotsTargets(shares)
+	before := t.copySlotsMap()
+	after := t.copySlotsMap()
+	for _, share := range shares {
+		if share.TargetSlots == share.Slots {
+			continue
+		}
+		var flashGroup *FlashGroup
+		if flashGroup, err = t.getFlashGroup(share.FlashGroupID); err != nil {
+			return
+		}
+		if delta := share.TargetSlots - share.Slots; delta > 0 {
+			for added := 0; added < delta; {
+				slot := allocateNewSlot()
+				if _, ok := after[slot]; ok {
+					continue
+				}
+				after[slot] = share.FlashGroupID
+				added++
+			}
+		} else {
+			for _, slot := range pickSlotsToRemove(flashGroup.getSlots(), -delta) {
+				delete(after, slot)
+			}
+		}
+	}
+	preview = newFlashGroupSlotsPreview(before, after)
+	preview.Rebalance = shares
+	return
+}
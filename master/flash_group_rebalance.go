@@ -0,0 +1,196 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auditlog"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// a group is not rebalanced when its slots are this close to its share, so
+// that a small change of capacity does not move keys between groups
+const flashGroupRebalanceTolerance = 0.1
+
+// getCacheCapacity returns the bytes cached by the active and enabled flashnodes.
+func (fg *FlashGroup) getCacheCapacity() (capacity int64) {
+	fg.lock.RLock()
+	defer fg.lock.RUnlock()
+	for _, flashNode := range fg.flashNodes {
+		flashNode.RLock()
+		if flashNode.IsActive && flashNode.IsEnable {
+			capacity += flashNode.CacheCapacity
+		}
+		flashNode.RUnlock()
+	}
+	return
+}
+
+// getSlotsShares returns the slots and the cache capacity of the active groups
+// with settled slots and cached bytes, the ones the slots are shared among.
+func (t *flashNodeTopology) getSlotsShares() (shares []*proto.FlashGroupSlotsRebalance) {
+	shares = make([]*proto.FlashGroupSlotsRebalance, 0)
+	t.flashGroupMap.Range(func(_, value interface{}) bool {
+		flashGroup := value.(*FlashGroup)
+		if !flashGroup.GetStatus().IsActive() || flashGroup.getSlotStatus() != proto.SlotStatus_Completed {
+			return true
+		}
+		capacity := flashGroup.getCacheCapacity()
+		if capacity <= 0 {
+			return true
+		}
+		shares = append(shares, &proto.FlashGroupSlotsRebalance{
+			FlashGroupID:  flashGroup.ID,
+			CacheCapacity: capacity,
+			Slots:         flashGroup.getSlotsCount(),
+		})
+		return true
+	})
+	sort.Slice(shares, func(i, j int) bool { return shares[i].FlashGroupID < shares[j].FlashGroupID })
+	return
+}
+
+// slotsOfCapacity returns the slots of capacity bytes at the slots per byte
+// of the shares, at least 1, or 0 if the shares cache nothing.
+func slotsOfCapacity(shares []*proto.FlashGroupSlotsRebalance, capacity int64) int {
+	var slots, total int64
+	for _, share := range shares {
+		slots += int64(share.Slots)
+		total += share.CacheCapacity
+	}
+	if total == 0 {
+		return 0
+	}
+	count := int(math.Round(float64(slots) * float64(capacity) / float64(total)))
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// getSlotsCountOfCapacity returns the slots of a new group caching capacity
+// bytes, 0 if no group caches anything yet.
+func (t *flashNodeTopology) getSlotsCountOfCapacity(capacity uint64) int {
+	if capacity > math.MaxInt64 {
+		capacity = math.MaxInt64
+	}
+	return slotsOfCapacity(t.getSlotsShares(), int64(capacity))
+}
+
+// computeSlotsTargets keeps the total slots of the groups, and sets the
+// target of each group to its share by cache capacity.
+func computeSlotsTargets(shares []*proto.FlashGroupSlotsRebalance) {
+	for _, share := range shares {
+		share.TargetSlots = share.Slots
+		target := slotsOfCapacity(shares, share.CacheCapacity)
+		if target > 0 && math.Abs(float64(target-share.Slots)) > float64(share.Slots)*flashGroupRebalanceTolerance {
+			share.TargetSlots = target
+		}
+	}
+}
+
+// pickSlotsToRemove spreads the slots removed over the ring.
+func pickSlotsToRemove(slots []uint32, count int) (picked []uint32) {
+	sorted := append([]uint32(nil), slots...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	picked = make([]uint32, 0, count)
+	for i := 0; i < count; i++ {
+		picked = append(picked, sorted[i*len(sorted)/count])
+	}
+	return
+}
+
+// rebalanceFlashGroupSlots moves the slots of the groups to their share, the
+// slots are added or removed step by step by scheduleToUpdateFlashGroupSlots.
+func (c *Cluster) rebalanceFlashGroupSlots(step uint32) (shares []*proto.FlashGroupSlotsRebalance, err error) {
+	t := c.flashNodeTopo
+	t.createFlashGroupLock.Lock()
+	defer t.createFlashGroupLock.Unlock()
+
+	shares = t.getSlotsShares()
+	computeSlotsTargets(shares)
+	for _, share := range shares {
+		if share.TargetSlots == share.Slots {
+			continue
+		}
+		var flashGroup *FlashGroup
+		if flashGroup, err = t.getFlashGroup(share.FlashGroupID); err != nil {
+			return
+		}
+		slotStatus, delta := proto.SlotStatus_Creating, share.TargetSlots-share.Slots
+		var pendingSlots []uint32
+		if delta > 0 {
+			pendingSlots = t.allocateNewSlots(delta)
+		} else {
+			slotStatus, delta = proto.SlotStatus_Deleting, -delta
+			pendingSlots = pickSlotsToRemove(flashGroup.getSlots(), delta)
+		}
+		groupStep := step
+		if groupStep == 0 || groupStep > uint32(delta) {
+			groupStep = uint32(delta)
+		}
+		if err = t.gradualExpandOrShrinkFlashGroupSlots(flashGroup, c, slotStatus, pendingSlots, groupStep); err != nil {
+			return
+		}
+		msg := fmt.Sprintf("flashGroup[%v] cacheCapacity[%v] slots[%v] to [%v] by step[%v]",
+			share.FlashGroupID, share.CacheCapacity, share.Slots, share.TargetSlots, groupStep)
+		log.LogInfof("action[rebalanceFlashGroupSlots] %v", msg)
+		auditlog.LogMasterOp("rebalanceFlashGroupSlots", msg, nil)
+	}
+	return
+}
+
+func (m *Server) rebalanceFlashGroupSlots(w http.ResponseWriter, r *http.Request) {
+	var (
+		step   common.Uint
+		dryRun common.Bool
+		shares []*proto.FlashGroupSlotsRebalance
+		err    error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupRebalance))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupRebalance, metric, err, nil)
+	}()
+	if err = parseArgs(r, step.Key("step").OmitEmpty(), dryRun.Key("dryRun").OmitEmpty()); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if step.V > math.MaxUint32 {
+		err = fmt.Errorf("step(%v) is too large", step.V)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if dryRun.V {
+		var preview *proto.FlashGroupSlotsPreview
+		if preview, err = m.cluster.previewRebalanceFlashGroupSlots(); err != nil {
+			sendErrReply(w, r, newErrHTTPReply(err))
+			return
+		}
+		sendOkReply(w, r, newSuccessHTTPReply(preview))
+		return
+	}
+	if shares, err = m.cluster.rebalanceFlashGroupSlots(uint32(step.V)); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(shares))
+}
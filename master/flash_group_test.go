@@ -15,9 +15,16 @@
 package master
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"math"
+	"net/http"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/cubefs/cubefs/master/mocktest"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +42,7 @@ func createFlashGroups(t *testing.T) (groups []proto.FlashGroupAdminView) {
 		if idx%2 == 0 {
 			slots = "2222222"
 		}
-		fgView, err := mc.AdminAPI().CreateFlashGroup(slots, proto.FlashGroupDefaultWeight, false, 0)
+		fgView, err := mc.AdminAPI().CreateFlashGroup(slots, proto.FlashGroupDefaultWeight, 0, false, 0)
 		if idx == 7 {
 			require.Error(t, err)
 			continue
@@ -59,12 +66,23 @@ func removeFlashGroups(t *testing.T, groups []proto.FlashGroupAdminView) {
 func testFlashGroup(t *testing.T) {
 	t.Run("Turn", testFlashGroupTurn)
 	t.Run("Create", testFlashGroupCreate)
+	t.Run("BatchCreate", testFlashGroupBatchCreate)
 	t.Run("Set", testFlashGroupSet)
 	t.Run("Remove", testFlashGroupRemove)
 	t.Run("Node", testFlashGroupNode)
 	t.Run("Get", testFlashGroupGet)
 	t.Run("List", testFlashGroupList)
 	t.Run("Client", testFlashGroupClient)
+	t.Run("Volumes", testFlashGroupVolumes)
+	t.Run("AutoScale", testFlashGroupAutoScale)
+	t.Run("AutoHeal", testFlashGroupAutoHeal)
+	t.Run("ZoneMinNodes", testFlashGroupZoneMinNodes)
+	t.Run("Rebalance", testFlashGroupRebalance)
+	t.Run("MoveSlots", testFlashGroupMoveSlots)
+	t.Run("Drain", testFlashGroupDrain)
+	t.Run("Audit", testFlashGroupAudit)
+	t.Run("DryRun", testFlashGroupDryRun)
+	t.Run("Cache", testFlashGroupCache)
 }
 
 func testFlashGroupTurn(t *testing.T) {
@@ -93,6 +111,58 @@ func testFlashGroupCreate(t *testing.T) {
 	require.Error(t, err)
 }
 
+func countIdleFlashNodes(zoneNodes map[string][]*FlashNode) (n int) {
+	for _, nodes := range zoneNodes {
+		n += len(nodes)
+	}
+	return
+}
+
+func testFlashGroupBatchCreate(t *testing.T) {
+	topo := server.cluster.flashNodeTopo
+	idle := countIdleFlashNodes(topo.getIdleFlashNodes())
+	require.GreaterOrEqual(t, idle, 2)
+
+	_, err := mc.AdminAPI().BatchCreateFlashGroups(0, 1, "", 0)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().BatchCreateFlashGroups(flashGroupBatchCreateMax+1, 1, "", 0)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().BatchCreateFlashGroups(1, 0, "", 0)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().BatchCreateFlashGroups(1, 1, "noSuchPolicy", 0)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().BatchCreateFlashGroups(1, 1, "", proto.FlashGroupMaxWeight+1)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().BatchCreateFlashGroups(1, idle+1, "", 0)
+	require.Error(t, err)
+	// none created by the failed batches
+	require.Equal(t, idle, countIdleFlashNodes(topo.getIdleFlashNodes()))
+
+	groups, err := mc.AdminAPI().BatchCreateFlashGroups(2, 1, proto.FlashGroupZoneSpread, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(groups))
+	require.Equal(t, idle-2, countIdleFlashNodes(topo.getIdleFlashNodes()))
+	slots := make(map[uint32]bool)
+	for _, g := range groups {
+		require.Equal(t, proto.FlashGroupStatus_Inactive, g.Status)
+		require.Equal(t, 2*defaultFlashGroupSlotsCount, len(g.Slots))
+		require.Equal(t, 1, g.FlashNodeCount)
+		for _, slot := range g.Slots {
+			require.False(t, slots[slot])
+			slots[slot] = true
+		}
+		flashGroup, err := topo.getFlashGroup(g.ID)
+		require.NoError(t, err)
+		for _, host := range flashGroup.getFlashNodeHosts(false) {
+			flashNode, err := server.cluster.peekFlashNode(host)
+			require.NoError(t, err)
+			require.Equal(t, g.ID, flashNode.FlashGroupID)
+		}
+	}
+	removeFlashGroups(t, groups)
+	require.Equal(t, idle, countIdleFlashNodes(topo.getIdleFlashNodes()))
+}
+
 func testFlashGroupSet(t *testing.T) {
 	groups := createFlashGroups(t)
 	defer removeFlashGroups(t, groups)
@@ -181,7 +251,804 @@ func testFlashGroupClient(t *testing.T) {
 	_, err = mc.AdminAPI().FlashGroupAddFlashNode(groups[2].ID, 2, testZone3, "")
 	require.NoError(t, err)
 
-	fgs, err := mc.AdminAPI().ClientFlashGroups()
+	fgs, err := mc.AdminAPI().ClientFlashGroups("")
 	require.NoError(t, err)
 	t.Logf("%+v", fgs)
+
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+	defer mc.AdminAPI().SetFlashGroup(g.ID, false)
+	var info *proto.FlashGroupInfo
+	for _, fg := range server.cluster.flashNodeTopo.getFlashGroupView().FlashGroups {
+		if fg.ID == g.ID {
+			info = fg
+		}
+	}
+	require.NotNil(t, info)
+	require.Equal(t, []string{mfs1Addr}, info.Hosts)
+	require.Equal(t, map[string]string{mfs1Addr: testZone1}, info.HostZones)
+
+	topo := server.cluster.flashNodeTopo
+	full := topo.updateClientResponse()[flashGroupSharedAudience]
+	require.NotNil(t, full)
+	fgv := getClientFlashGroups(t, topo, "", 0)
+	require.Equal(t, full.version, fgv.Version)
+	require.False(t, fgv.NotModified || fgv.Delta)
+	require.True(t, getClientFlashGroups(t, topo, "", fgv.Version).NotModified)
+	require.Equal(t, full, topo.updateClientResponse()[flashGroupSharedAudience])
+
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, false)
+	require.NoError(t, err)
+	cache := topo.updateClientResponse()[flashGroupSharedAudience]
+	require.Greater(t, cache.version, full.version)
+	delta := getClientFlashGroups(t, topo, "", fgv.Version)
+	require.True(t, delta.Delta)
+	require.Equal(t, cache.version, delta.Version)
+	require.Equal(t, []uint64{g.ID}, delta.Removed)
+	require.Empty(t, delta.FlashGroups)
+	fgv.Apply(&delta)
+	for _, fg := range fgv.FlashGroups {
+		require.NotEqual(t, g.ID, fg.ID)
+	}
+	// unknown versions get the full view
+	require.False(t, getClientFlashGroups(t, topo, "", 1).Delta)
+
+	// the waiting clients get the change as soon as the view is updated
+	wait := topo.waitClientResponse(context.Background(), "", cache.version, 100*time.Millisecond)
+	require.Equal(t, cache.since[cache.version], wait)
+	waited := make(chan proto.FlashGroupView, 1)
+	go func() {
+		fgv, err := mc.AdminAPI().ClientFlashGroupsWait("", cache.version, 20)
+		require.NoError(t, err)
+		waited <- fgv
+	}()
+	time.Sleep(200 * time.Millisecond)
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+	topo.updateClientResponse()
+	select {
+	case delta = <-waited:
+		require.True(t, delta.Delta)
+		require.Greater(t, delta.Version, cache.version)
+		require.Len(t, delta.FlashGroups, 1)
+		require.Equal(t, g.ID, delta.FlashGroups[0].ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waiting client is not notified")
+	}
+}
+
+func getClientFlashGroups(t *testing.T, topo *flashNodeTopology, volume string, version uint64) (fgv proto.FlashGroupView) {
+	reply := &proto.HTTPReply{Data: &fgv}
+	require.NoError(t, json.Unmarshal(topo.getClientResponse(volume, version), reply))
+	return
+}
+
+func getClientFlashGroupIDs(t *testing.T, topo *flashNodeTopology, volume string) (ids []uint64) {
+	for _, fg := range getClientFlashGroups(t, topo, volume, 0).FlashGroups {
+		ids = append(ids, fg.ID)
+	}
+	return
+}
+
+func testFlashGroupVolumes(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	bound, shared := groups[0], groups[2]
+
+	_, err := mc.AdminAPI().FlashGroupAddFlashNode(bound.ID, 0, "", mfs1Addr)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().FlashGroupAddFlashNode(shared.ID, 2, testZone3, "")
+	require.NoError(t, err)
+	for _, g := range []proto.FlashGroupAdminView{bound, shared} {
+		_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+		require.NoError(t, err)
+		defer mc.AdminAPI().SetFlashGroup(g.ID, false)
+	}
+
+	_, err = mc.AdminAPI().SetFlashGroupVolumes(bound.ID, []string{"noSuchVolume"})
+	require.Error(t, err)
+	view, err := mc.AdminAPI().SetFlashGroupVolumes(bound.ID, []string{commonVolName, commonVolName})
+	require.NoError(t, err)
+	defer mc.AdminAPI().SetFlashGroupVolumes(bound.ID, nil)
+	require.Equal(t, []string{commonVolName}, view.Volumes)
+
+	topo := server.cluster.flashNodeTopo
+	caches := topo.updateClientResponse()
+	require.Len(t, caches, 2)
+	require.NotEqual(t, caches[commonVolName].version, caches[flashGroupSharedAudience].version)
+	require.Equal(t, []uint64{bound.ID}, getClientFlashGroupIDs(t, topo, commonVolName))
+	sharedIDs := getClientFlashGroupIDs(t, topo, "")
+	require.Contains(t, sharedIDs, shared.ID)
+	require.NotContains(t, sharedIDs, bound.ID)
+	// the volumes bound to no flash group share the view
+	require.Equal(t, getClientFlashGroups(t, topo, "", 0), getClientFlashGroups(t, topo, "otherVol", 0))
+	fgv, err := mc.AdminAPI().ClientFlashGroups(commonVolName)
+	require.NoError(t, err)
+	require.Equal(t, caches[commonVolName].version, fgv.Version)
+
+	// a volume bound to inactive flash groups only reads from none
+	_, err = mc.AdminAPI().SetFlashGroup(bound.ID, false)
+	require.NoError(t, err)
+	topo.updateClientResponse()
+	require.Empty(t, getClientFlashGroupIDs(t, topo, commonVolName))
+
+	view, err = mc.AdminAPI().SetFlashGroupVolumes(bound.ID, nil)
+	require.NoError(t, err)
+	require.Empty(t, view.Volumes)
+	_, err = mc.AdminAPI().SetFlashGroup(bound.ID, true)
+	require.NoError(t, err)
+	caches = topo.updateClientResponse()
+	require.Len(t, caches, 1)
+	require.Contains(t, getClientFlashGroupIDs(t, topo, commonVolName), bound.ID)
+	require.Contains(t, getClientFlashGroupIDs(t, topo, commonVolName), shared.ID)
+}
+
+func testFlashGroupAutoScale(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	g := groups[0]
+
+	_, err := mc.AdminAPI().SetFlashGroupAutoScale(g.ID, 3, 2)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().SetFlashGroupAutoScale(g.ID, 0, 2)
+	require.Error(t, err)
+	fgView, err := mc.AdminAPI().SetFlashGroupAutoScale(g.ID, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, fgView.AutoScaleMin)
+	require.Equal(t, 3, fgView.AutoScaleMax)
+
+	flashGroup, err := server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+	require.NoError(t, err)
+	// inactive groups are left alone
+	require.False(t, server.cluster.autoScaleFlashGroup(flashGroup))
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+
+	// brought up to the min, one flashnode at a time
+	require.True(t, server.cluster.autoScaleFlashGroup(flashGroup))
+	require.False(t, server.cluster.autoScaleFlashGroup(flashGroup))
+	flashGroup.scaledAt = time.Time{}
+	require.True(t, server.cluster.autoScaleFlashGroup(flashGroup))
+	require.Equal(t, 2, flashGroup.getFlashNodesCount())
+
+	events, err := mc.AdminAPI().FlashGroupScaleEvents(g.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+	require.Equal(t, proto.FlashGroupScaleUp, events[0].Action)
+	require.Equal(t, 2, events[0].NodeCount)
+	require.Equal(t, 1, events[1].NodeCount)
+	events, err = mc.AdminAPI().FlashGroupScaleEvents(0)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(events), 2)
+
+	// turned off, the flashnodes are kept
+	fgView, err = mc.AdminAPI().SetFlashGroupAutoScale(g.ID, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, fgView.AutoScaleMax)
+	flashGroup.scaledAt = time.Time{}
+	require.False(t, server.cluster.autoScaleFlashGroup(flashGroup))
+	require.Equal(t, 2, flashGroup.getFlashNodesCount())
+}
+
+func testFlashGroupAutoHeal(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	g := groups[1]
+
+	flashServer := addFlashServer(mfs8Addr, testZone3)
+	_, err := mc.NodeAPI().AddFlashNode(mfs8Addr, testZone3, "")
+	require.NoError(t, err)
+	defer func() {
+		_, err := mc.NodeAPI().RemoveFlashNode(mfs8Addr)
+		require.NoError(t, err)
+	}()
+	flashNode, err := server.cluster.peekFlashNode(mfs8Addr)
+	require.NoError(t, err)
+	flashNode.setActive()
+	_, err = mc.AdminAPI().FlashGroupAddFlashNode(g.ID, 0, "", mfs8Addr)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+
+	_, err = mc.AdminAPI().SetFlashGroupAutoHeal(g.ID, -1, 0, nil)
+	require.Error(t, err)
+	excludeAll := []string{mfs5Addr, mfs6Addr, mfs7Addr}
+	fgView, err := mc.AdminAPI().SetFlashGroupAutoHeal(g.ID, 1, 60, excludeAll)
+	require.NoError(t, err)
+	require.Equal(t, 1, fgView.AutoHealMinHealthy)
+	require.Equal(t, int64(60), fgView.AutoHealCooldown)
+	require.Equal(t, excludeAll, fgView.AutoHealExcludeHosts)
+
+	flashGroup, err := server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+	require.NoError(t, err)
+	// a healthy group is left alone
+	require.False(t, server.cluster.autoHealFlashGroup(flashGroup))
+
+	flashServer.Stop()
+	flashNode.Lock()
+	flashNode.IsActive, flashNode.ReportTime = false, time.Now().Add(-time.Hour)
+	flashNode.Unlock()
+
+	// no idle flashnode of the zone but the excluded ones
+	require.False(t, server.cluster.autoHealFlashGroup(flashGroup))
+	_, err = mc.AdminAPI().SetFlashGroupAutoHeal(g.ID, 1, 60, []string{mfs5Addr})
+	require.NoError(t, err)
+	require.True(t, server.cluster.autoHealFlashGroup(flashGroup))
+	require.False(t, server.cluster.autoHealFlashGroup(flashGroup))
+
+	hosts := flashGroup.getFlashNodeHosts(false)
+	require.Equal(t, 1, len(hosts))
+	require.NotEqual(t, mfs8Addr, hosts[0])
+	require.NotEqual(t, mfs5Addr, hosts[0])
+	events, err := mc.AdminAPI().FlashGroupScaleEvents(g.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(events))
+	require.Equal(t, proto.FlashGroupAutoHeal, events[0].Action)
+	require.Equal(t, hosts[0], events[0].FlashNode)
+	require.Equal(t, testZone3, events[0].ZoneName)
+	require.Contains(t, events[0].Reason, mfs8Addr)
+
+	fgView, err = mc.AdminAPI().SetFlashGroupAutoHeal(g.ID, 0, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, fgView.AutoHealMinHealthy)
+	require.Empty(t, fgView.AutoHealExcludeHosts)
+}
+
+func testFlashGroupZoneMinNodes(t *testing.T) {
+	flashServer := addFlashServer(mfs8Addr, testZone1)
+	defer flashServer.Stop()
+	_, err := mc.NodeAPI().AddFlashNode(mfs8Addr, testZone1, "")
+	require.NoError(t, err)
+	defer func() {
+		_, err := mc.NodeAPI().RemoveFlashNode(mfs8Addr)
+		require.NoError(t, err)
+	}()
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	g := groups[1]
+	for _, addr := range []string{mfs1Addr, mfs2Addr, mfs3Addr} {
+		_, err = mc.AdminAPI().FlashGroupAddFlashNode(g.ID, 0, "", addr)
+		require.NoError(t, err)
+	}
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+	flashGroup, err := server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+	require.NoError(t, err)
+	zoneHosts := func(zoneName string) []string {
+		hosts, _ := flashGroup.getTargetZoneFlashNodeHosts(zoneName)
+		sort.Strings(hosts)
+		return hosts
+	}
+	for _, addr := range []string{mfs1Addr, mfs2Addr, mfs3Addr, mfs4Addr, mfs8Addr} {
+		flashNode, err := server.cluster.peekFlashNode(addr)
+		require.NoError(t, err)
+		flashNode.setActive()
+	}
+
+	require.NoError(t, server.cluster.setFlashGroupMinNodesPerZone(2))
+	defer func() {
+		require.NoError(t, server.cluster.setFlashGroupMinNodesPerZone(0))
+	}()
+	cv, err := mc.AdminAPI().GetCluster(false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), cv.FlashGroupMinNodesPerZone)
+	_, err = mc.AdminAPI().FlashGroupRemoveFlashNode(g.ID, 0, "", mfs1Addr)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().FlashGroupRemoveFlashNode(g.ID, 1, testZone1, "")
+	require.Error(t, err)
+	require.Nil(t, flashGroup.selectFlashNodeToScaleDown(2))
+	require.NotNil(t, flashGroup.selectFlashNodeToScaleDown(1))
+
+	// zone2 is given its idle flashnode
+	server.cluster.flashNodeTopo.createFlashGroupLock.Lock()
+	require.True(t, server.cluster.autoHealFlashGroupZones(flashGroup))
+	require.False(t, server.cluster.autoHealFlashGroupZones(flashGroup))
+	server.cluster.flashNodeTopo.createFlashGroupLock.Unlock()
+	require.Equal(t, []string{mfs3Addr, mfs4Addr}, zoneHosts(testZone2))
+
+	// a flashnode shutting down is replaced by an idle one of its zone
+	require.NoError(t, mc.NodeAPI().OfflineFlashNode(mfs2Addr))
+	require.Equal(t, []string{mfs1Addr, mfs8Addr}, zoneHosts(testZone1))
+	_, err = mc.NodeAPI().AddFlashNode(mfs2Addr, testZone1, "")
+	require.NoError(t, err)
+
+	require.NoError(t, server.cluster.setFlashGroupMinNodesPerZone(1))
+	_, err = mc.AdminAPI().FlashGroupRemoveFlashNode(g.ID, 0, "", mfs1Addr)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().FlashGroupRemoveFlashNode(g.ID, 1, testZone1, "")
+	require.Error(t, err)
+}
+
+func testFlashGroupRebalance(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	small, large := groups[1], groups[2]
+
+	_, err := mc.AdminAPI().FlashGroupAddFlashNode(small.ID, 1, testZone1, "")
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().FlashGroupAddFlashNode(large.ID, 3, testZone3, "")
+	require.NoError(t, err)
+	for _, g := range []proto.FlashGroupAdminView{small, large} {
+		flashGroup, err := server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+		require.NoError(t, err)
+		for _, flashNode := range flashGroup.flashNodes {
+			flashNode.setActive()
+			flashNode.Lock()
+			flashNode.CacheCapacity = mocktest.DefaultFlashCacheCapacity
+			flashNode.Unlock()
+		}
+		_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+		require.NoError(t, err)
+	}
+	fgView, err := mc.AdminAPI().GetFlashGroup(large.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3*mocktest.DefaultFlashCacheCapacity, fgView.CacheCapacity)
+
+	shares, err := mc.AdminAPI().RebalanceFlashGroupSlots(0, true)
+	require.NoError(t, err)
+	targets := make(map[uint64]*proto.FlashGroupSlotsRebalance)
+	for _, share := range shares {
+		targets[share.FlashGroupID] = share
+	}
+	require.Contains(t, targets, small.ID)
+	require.Contains(t, targets, large.ID)
+	require.Less(t, targets[small.ID].TargetSlots, len(small.Slots))
+	require.Greater(t, targets[large.ID].TargetSlots, len(large.Slots))
+	fgView, err = mc.AdminAPI().GetFlashGroup(small.ID)
+	require.NoError(t, err)
+	require.Equal(t, proto.SlotStatus_Completed, fgView.SlotStatus)
+
+	_, err = mc.AdminAPI().RebalanceFlashGroupSlots(0, false)
+	require.NoError(t, err)
+	for _, g := range []proto.FlashGroupAdminView{small, large} {
+		flashGroup, err := server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, proto.SlotStatus_Completed, flashGroup.getSlotStatus())
+		server.cluster.flashNodeTopo.createFlashGroupLock.Lock()
+		err = server.cluster.updateFlashGroupSlots(flashGroup)
+		server.cluster.flashNodeTopo.createFlashGroupLock.Unlock()
+		require.NoError(t, err)
+		require.Equal(t, proto.SlotStatus_Completed, flashGroup.getSlotStatus())
+		require.Equal(t, targets[g.ID].TargetSlots, flashGroup.getSlotsCount())
+	}
+
+	count := server.cluster.flashNodeTopo.getSlotsCountOfCapacity(uint64(mocktest.DefaultFlashCacheCapacity))
+	require.Greater(t, count, 0)
+	fgView, err = mc.AdminAPI().CreateFlashGroup("", proto.FlashGroupDefaultWeight, uint64(mocktest.DefaultFlashCacheCapacity>>30), false, 0)
+	require.NoError(t, err)
+	require.Equal(t, count, len(fgView.Slots))
+	_, err = mc.AdminAPI().RemoveFlashGroup(fgView.ID, false, 0)
+	require.NoError(t, err)
+}
+
+func testFlashGroupMoveSlots(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	src, dst := groups[1], groups[3]
+	require.Equal(t, []uint32{3000, 3333}, dst.Slots)
+	moved := src.Slots[:2]
+
+	_, err := mc.AdminAPI().MoveFlashGroupSlots(src.ID, dst.ID, moved)
+	require.Error(t, err, "destination inactive")
+	_, err = mc.AdminAPI().SetFlashGroup(dst.ID, true)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().MoveFlashGroupSlots(src.ID, src.ID, moved)
+	require.Error(t, err, "same group")
+	_, err = mc.AdminAPI().MoveFlashGroupSlots(src.ID, dst.ID, []uint32{moved[0], 3000})
+	require.Error(t, err, "slot of another group")
+	_, err = mc.AdminAPI().MoveFlashGroupSlots(src.ID, dst.ID, []uint32{moved[0], moved[0]})
+	require.Error(t, err, "duplicated slot")
+	_, err = mc.AdminAPI().MoveFlashGroupSlots(src.ID, dst.ID, src.Slots)
+	require.Error(t, err, "all the slots")
+	_, err = mc.AdminAPI().MoveFlashGroupSlots(src.ID, math.MaxUint64, moved)
+	require.Error(t, err)
+
+	views, err := mc.AdminAPI().MoveFlashGroupSlots(src.ID, dst.ID, moved)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	require.Equal(t, src.Slots[2:], views[0].Slots)
+	require.Len(t, views[1].Slots, 4)
+	for _, slot := range moved {
+		require.Contains(t, views[1].Slots, slot)
+		require.Equal(t, dst.ID, server.cluster.flashNodeTopo.slotsMap[slot])
+	}
+	fgView, err := mc.AdminAPI().GetFlashGroup(dst.ID)
+	require.NoError(t, err)
+	require.Equal(t, views[1].Slots, fgView.Slots)
+
+	records, err := mc.AdminAPI().FlashGroupAudit(src.ID, proto.FlashGroupOpMoveSlots, 0, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+}
+
+func testFlashGroupDrain(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups[1:])
+	g := groups[0]
+
+	_, err := mc.AdminAPI().DrainFlashGroup(g.ID, 3600)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().FlashGroupAddFlashNode(g.ID, 0, "", mfs1Addr)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().DrainFlashGroup(g.ID, uint32(flashGroupMaxDrainTTL/time.Second)+1)
+	require.Error(t, err)
+
+	_, err = mc.AdminAPI().DrainFlashGroup(g.ID, 3600)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().DrainFlashGroup(g.ID, 3600)
+	require.Error(t, err)
+	fgViews, err := mc.AdminAPI().ListDrainingFlashGroups()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(fgViews.FlashGroups))
+	require.Equal(t, g.ID, fgViews.FlashGroups[0].ID)
+	require.Equal(t, proto.FlashGroupStatus_Draining, fgViews.FlashGroups[0].Status)
+	require.Greater(t, fgViews.FlashGroups[0].DrainDeadline, time.Now().Unix())
+
+	draining := func() bool {
+		for _, info := range server.cluster.flashNodeTopo.getFlashGroupView().FlashGroups {
+			if info.ID == g.ID {
+				return info.Draining
+			}
+		}
+		t.Fatalf("flashGroup(%v) not in the client view", g.ID)
+		return false
+	}
+	require.True(t, draining())
+	flashNode, err := server.cluster.peekFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.True(t, server.cluster.isFlashNodeDraining(flashNode))
+
+	// set active again cancels the drain
+	fgView, err := mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), fgView.DrainDeadline)
+	require.False(t, draining())
+	require.False(t, server.cluster.isFlashNodeDraining(flashNode))
+
+	_, err = mc.AdminAPI().DrainFlashGroup(g.ID, 3600)
+	require.NoError(t, err)
+	server.cluster.removeDrainedFlashGroups()
+	_, err = server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+	require.NoError(t, err)
+
+	flashGroup, err := server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+	require.NoError(t, err)
+	flashGroup.lock.Lock()
+	flashGroup.DrainDeadline = time.Now().Unix() - 1
+	flashGroup.lock.Unlock()
+	server.cluster.removeDrainedFlashGroups()
+	_, err = server.cluster.flashNodeTopo.getFlashGroup(g.ID)
+	require.Error(t, err)
+	require.False(t, server.cluster.isFlashNodeDraining(flashNode))
+}
+
+func TestFlashGroupSlotsTargets(t *testing.T) {
+	shares := []*proto.FlashGroupSlotsRebalance{
+		{FlashGroupID: 1, CacheCapacity: 100, Slots: 32},
+		{FlashGroupID: 2, CacheCapacity: 300, Slots: 32},
+		{FlashGroupID: 3, CacheCapacity: 1, Slots: 30},
+		{FlashGroupID: 4, CacheCapacity: 150, Slots: 34},
+	}
+	computeSlotsTargets(shares)
+	require.Equal(t, 23, shares[0].TargetSlots)
+	require.Equal(t, 70, shares[1].TargetSlots)
+	require.Equal(t, 1, shares[2].TargetSlots)
+	// close enough to its share
+	require.Equal(t, 34, shares[3].TargetSlots)
+	require.Equal(t, 23, slotsOfCapacity(shares, 100))
+	require.Equal(t, 0, slotsOfCapacity(nil, 100))
+
+	picked := pickSlotsToRemove([]uint32{40, 10, 30, 20}, 2)
+	require.Equal(t, []uint32{10, 30}, picked)
+}
+
+func TestFlashGroupPlanNodes(t *testing.T) {
+	zoneNodes := make(map[string][]*FlashNode)
+	for zone, n := range map[string]int{"z1": 4, "z2": 2, "z3": 1} {
+		for i := 0; i < n; i++ {
+			zoneNodes[zone] = append(zoneNodes[zone], &FlashNode{flashNodeValue: flashNodeValue{
+				Addr: fmt.Sprintf("%v-%v", zone, i), ZoneName: zone,
+			}})
+		}
+	}
+	zonesOf := func(nodes []*FlashNode) (zones map[string]int) {
+		zones = make(map[string]int)
+		for _, flashNode := range nodes {
+			zones[flashNode.ZoneName]++
+		}
+		return
+	}
+
+	_, err := planFlashGroupNodes(zoneNodes, 3, 3, proto.FlashGroupZoneSpread)
+	require.Error(t, err)
+	plan, err := planFlashGroupNodes(zoneNodes, 2, 3, proto.FlashGroupZoneSpread)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"z1": 1, "z2": 1, "z3": 1}, zonesOf(plan[0]))
+	require.Equal(t, map[string]int{"z1": 2, "z2": 1}, zonesOf(plan[1]))
+
+	_, err = planFlashGroupNodes(zoneNodes, 3, 2, proto.FlashGroupZonePack)
+	require.NoError(t, err)
+	_, err = planFlashGroupNodes(zoneNodes, 2, 3, proto.FlashGroupZonePack)
+	require.Error(t, err)
+	plan, err = planFlashGroupNodes(zoneNodes, 2, 2, proto.FlashGroupZonePack)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"z1": 2}, zonesOf(plan[0]))
+	require.Equal(t, 1, len(zonesOf(plan[1])))
+}
+
+func TestFlashGroupHealth(t *testing.T) {
+	fg := newFlashGroup(1, nil, proto.SlotStatus_Completed, nil, 0, proto.FlashGroupStatus_Active, 1)
+	for addr, inactiveFor := range map[string]time.Duration{"a": 0, "b": time.Minute, "c": time.Hour, "d": 2 * time.Hour} {
+		fn := &FlashNode{IsActive: inactiveFor == 0, ReportTime: time.Now().Add(-inactiveFor)}
+		fn.Addr, fn.IsEnable = addr, true
+		fg.putFlashNode(fn)
+	}
+	healthy, inactive, _ := fg.getHealth(10 * time.Minute)
+	require.Equal(t, 1, healthy)
+	require.Equal(t, "d", inactive.Addr)
+	healthy, inactive, _ = fg.getHealth(3 * time.Hour)
+	require.Equal(t, 1, healthy)
+	require.Nil(t, inactive)
+
+	fg.AutoHealCooldown = 30
+	require.Equal(t, 30*time.Second, fg.getAutoHealCooldown())
+	fg.AutoHealCooldown = 0
+	require.Equal(t, flashGroupAutoHealDefaultCooldown, fg.getAutoHealCooldown())
+}
+
+func TestFlashGroupScaleDelta(t *testing.T) {
+	overloaded := flashGroupLoad{disks: 2, hitRate: 0.2, usageRatio: 0.95}
+	idle := flashGroupLoad{disks: 2, hitRate: 0.9, usageRatio: 0.1}
+	busy := flashGroupLoad{disks: 2, hitRate: 0.9, usageRatio: 0.95}
+	for _, c := range []struct {
+		load            flashGroupLoad
+		count, min, max int
+		delta           int
+	}{
+		{flashGroupLoad{}, 0, 1, 3, 1},
+		{flashGroupLoad{}, 4, 1, 3, -1},
+		{flashGroupLoad{}, 2, 1, 3, 0},
+		{overloaded, 2, 1, 3, 1},
+		{overloaded, 3, 1, 3, 0},
+		{idle, 2, 1, 3, -1},
+		{idle, 1, 1, 3, 0},
+		{busy, 2, 1, 3, 0},
+	} {
+		delta, reason := c.load.scaleDelta(c.count, c.min, c.max)
+		require.Equal(t, c.delta, delta, "%+v", c)
+		require.Equal(t, delta != 0, reason != "", reason)
+	}
+
+	fg := newFlashGroup(1, nil, proto.SlotStatus_Completed, nil, 0, proto.FlashGroupStatus_Active, 1)
+	for addr, rps := range map[string]int{"a": 10, "b": 5, "c": 20} {
+		fn := &FlashNode{IsActive: true}
+		fn.Addr, fn.IsEnable = addr, true
+		fn.DiskStat = []*proto.FlashNodeDiskCacheStat{{MaxAlloc: 100, HasAlloc: 20, HitRate: 0.5, ReadRps: rps}}
+		fg.putFlashNode(fn)
+	}
+	load := fg.getLoad()
+	require.Equal(t, 3, load.disks)
+	require.InDelta(t, 0.2, load.usageRatio, 1e-9)
+	require.InDelta(t, 0.5, load.hitRate, 1e-9)
+	require.Equal(t, "b", fg.selectFlashNodeToScaleDown(0).Addr)
+	fg.flashNodes["c"].IsActive = false
+	require.Equal(t, "c", fg.selectFlashNodeToScaleDown(0).Addr)
+}
+
+func TestFlashManualTaskWarmupConfig(t *testing.T) {
+	fltMgr := newFlashManualTaskManager(nil)
+	vol := &Vol{Name: "warmup"}
+	newTask := func(id, prefix string, inodes ...uint64) *proto.FlashManualTask {
+		return &proto.FlashManualTask{
+			Id: id, VolName: vol.Name, Action: proto.FlashManualWarmupAction,
+			ManualTaskConfig: proto.ManualTaskConfig{Prefix: prefix, Inodes: inodes},
+		}
+	}
+	running := newTask("running", "/a")
+	running.Status = int(proto.Flash_Task_Running)
+	fltMgr.flashManualTasks.Store(running.Id, running)
+
+	require.Error(t, checkManualConfig(newTask("dup", "a/"), vol, fltMgr))
+	require.NoError(t, checkManualConfig(newTask("other", "/b"), vol, fltMgr))
+	require.NoError(t, checkManualConfig(newTask("inodes", "/a", 10, 11), vol, fltMgr))
+	require.Error(t, checkManualConfig(newTask("running", "", 10), vol, fltMgr))
+	require.Error(t, checkManualConfig(newTask("zero", "", 10, 0), vol, fltMgr))
+
+	tooMany := newTask("tooMany", "")
+	for ino := uint64(1); ino <= proto.MaxFlashManualTaskInodes+1; ino++ {
+		tooMany.ManualTaskConfig.Inodes = append(tooMany.ManualTaskConfig.Inodes, ino)
+	}
+	require.Error(t, checkManualConfig(tooMany, vol, fltMgr))
+
+	overflow := newTask("overflow", "", 10)
+	overflow.ManualTaskConfig.Offset, overflow.ManualTaskConfig.Size = math.MaxUint64, 1
+	require.Error(t, checkManualConfig(overflow, vol, fltMgr))
+}
+
+func testFlashGroupAudit(t *testing.T) {
+	start := time.Now().Unix()
+	_, err := mc.AdminAPI().CreateFlashGroup("create,by,error", proto.FlashGroupDefaultWeight, 0, false, 0)
+	require.Error(t, err)
+	fgView, err := mc.AdminAPI().CreateFlashGroup("", proto.FlashGroupDefaultWeight, 0, false, 0)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().SetFlashGroup(fgView.ID, true)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().FlashGroupAddFlashNode(fgView.ID, 1, "", "noSuchNode:18510")
+	require.Error(t, err)
+	_, err = mc.AdminAPI().RemoveFlashGroup(fgView.ID, false, 0)
+	require.NoError(t, err)
+
+	records, err := mc.AdminAPI().FlashGroupAudit(fgView.ID, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	for i, op := range []string{proto.FlashGroupOpCreate, proto.FlashGroupOpSet, proto.FlashGroupOpNodeAdd, proto.FlashGroupOpRemove} {
+		require.Equal(t, op, records[i].Op)
+		require.Equal(t, fgView.ID, records[i].FlashGroupID)
+		require.NotEmpty(t, records[i].Operator)
+		if i > 0 {
+			require.Greater(t, records[i].ID, records[i-1].ID)
+		}
+	}
+	require.Equal(t, "ok", records[1].Result)
+	require.Contains(t, records[1].Args, "enable=true")
+	require.NotEqual(t, "ok", records[2].Result)
+	require.Contains(t, records[2].Args, "noSuchNode")
+
+	created, err := mc.AdminAPI().FlashGroupAudit(0, proto.FlashGroupOpCreate, start, 0)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(created), 2)
+	var failed *proto.FlashGroupAuditRecord
+	for _, record := range created {
+		if record.FlashGroupID == 0 {
+			failed = record
+		}
+	}
+	require.NotNil(t, failed)
+	require.NotEqual(t, "ok", failed.Result)
+
+	// the records are persisted by raft, and age out
+	all, err := mc.AdminAPI().FlashGroupAudit(0, "", 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, server.cluster.loadFlashGroupAudit())
+	loaded, err := mc.AdminAPI().FlashGroupAudit(0, "", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, all, loaded)
+	require.Empty(t, server.cluster.flashGroupAudit.expired(time.Now()))
+	require.Len(t, server.cluster.flashGroupAudit.expired(time.Now().Add(flashGroupAuditKeepTime+time.Hour)), len(all))
+}
+
+func testFlashGroupDryRun(t *testing.T) {
+	slotsCount := func() int {
+		topo := server.cluster.flashNodeTopo
+		topo.createFlashGroupLock.RLock()
+		defer topo.createFlashGroupLock.RUnlock()
+		return len(topo.slotsMap)
+	}
+	count := slotsCount()
+	preview, err := mc.AdminAPI().PreviewCreateFlashGroup("", proto.FlashGroupDefaultWeight, 0)
+	require.NoError(t, err)
+	require.Equal(t, count, slotsCount())
+	require.Len(t, preview.AddedSlots, defaultFlashGroupSlotsCount)
+	require.Equal(t, defaultFlashGroupSlotsCount, preview.GroupSlots[0])
+	require.Len(t, preview.SlotOwners, count+defaultFlashGroupSlotsCount)
+
+	fgView, err := mc.AdminAPI().CreateFlashGroup("", proto.FlashGroupDefaultWeight, 0, false, 0)
+	require.NoError(t, err)
+	preview, err = mc.AdminAPI().PreviewRemoveFlashGroup(fgView.ID)
+	require.NoError(t, err)
+	require.Len(t, preview.RemovedSlots, len(fgView.Slots))
+	require.NotContains(t, preview.GroupSlots, fgView.ID)
+	require.Greater(t, preview.RemappedPercent, float64(0))
+	_, err = mc.AdminAPI().GetFlashGroup(fgView.ID)
+	require.NoError(t, err)
+	records, err := mc.AdminAPI().FlashGroupAudit(fgView.ID, proto.FlashGroupOpRemove, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	preview, err = mc.AdminAPI().PreviewRebalanceFlashGroupSlots()
+	require.NoError(t, err)
+	require.NotNil(t, preview.SlotOwners)
+	_, err = mc.AdminAPI().RemoveFlashGroup(fgView.ID, false, 0)
+	require.NoError(t, err)
+}
+
+func testFlashGroupCache(t *testing.T) {
+	fgView, err := mc.AdminAPI().CreateFlashGroup("", proto.FlashGroupDefaultWeight, 0, false, 0)
+	require.NoError(t, err)
+	defer func() {
+		_, err := mc.AdminAPI().RemoveFlashGroup(fgView.ID, false, 0)
+		require.NoError(t, err)
+	}()
+	flashServer := addFlashServer(mfs8Addr, testZone3)
+	defer flashServer.Stop()
+	_, err = mc.NodeAPI().AddFlashNode(mfs8Addr, testZone3, "")
+	require.NoError(t, err)
+	defer func() {
+		_, err := mc.NodeAPI().RemoveFlashNode(mfs8Addr)
+		require.NoError(t, err)
+	}()
+	flashNode, err := server.cluster.peekFlashNode(mfs8Addr)
+	require.NoError(t, err)
+	flashNode.setActive()
+	_, err = mc.AdminAPI().FlashGroupAddFlashNode(fgView.ID, 0, "", mfs8Addr)
+	require.NoError(t, err)
+	defer func() {
+		_, err := mc.AdminAPI().FlashGroupRemoveFlashNode(fgView.ID, 0, "", mfs8Addr)
+		require.NoError(t, err)
+	}()
+
+	// the http server of the flashnode
+	var purgeQuery string
+	reply := func(w http.ResponseWriter, data interface{}) {
+		body, _ := json.Marshal(proto.HTTPReply{Code: proto.ErrCodeSuccess, Msg: "OK", Data: data})
+		w.Write(body)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "true", r.FormValue("hot"))
+		reply(w, proto.FlashNodeCacheKeys{Total: 3, Keys: []*proto.FlashNodeCacheKey{
+			{Volume: commonVolName, Inode: 1, Hits: 1}, {Volume: commonVolName, Inode: 2, Hits: 5}, {Volume: commonVolName, Inode: 3, Hits: 3},
+		}})
+	})
+	mux.HandleFunc("/purge", func(w http.ResponseWriter, r *http.Request) {
+		purgeQuery = r.URL.RawQuery
+		reply(w, proto.FlashNodeCachePurge{Purged: 2, Failed: []string{}})
+	})
+	httpServer := &http.Server{Addr: flashNodeHTTPAddr(mfs8Addr), Handler: mux}
+	go httpServer.ListenAndServe()
+	defer httpServer.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	keys, err := mc.AdminAPI().FlashGroupCacheKeys(fgView.ID, commonVolName, "", 2)
+	require.NoError(t, err)
+	require.Empty(t, keys.Failed)
+	require.Equal(t, 3, keys.Total)
+	require.Len(t, keys.Keys, 2)
+	require.Equal(t, uint64(5), keys.Keys[0].Hits)
+	require.Equal(t, uint64(3), keys.Keys[1].Hits)
+	require.Equal(t, fgView.ID, keys.Keys[0].FlashGroupID)
+	require.Equal(t, mfs8Addr, keys.Keys[0].FlashNode)
+	_, err = mc.AdminAPI().FlashGroupCacheKeys(fgView.ID, "", "1#", 0)
+	require.Error(t, err)
+	_, err = mc.AdminAPI().FlashGroupCacheKeys(math.MaxUint64, "", "", 0)
+	require.Error(t, err)
+
+	_, err = mc.AdminAPI().PurgeFlashGroupCache(fgView.ID, "", "")
+	require.Error(t, err)
+	purge, err := mc.AdminAPI().PurgeFlashGroupCache(fgView.ID, commonVolName, "1#")
+	require.NoError(t, err)
+	require.Equal(t, 2, purge.Purged)
+	require.Len(t, purge.Nodes, 1)
+	require.Equal(t, mfs8Addr, purge.Nodes[0].FlashNode)
+	require.Empty(t, purge.Nodes[0].Err)
+	require.Contains(t, purgeQuery, "prefix=1%23")
+	records, err := mc.AdminAPI().FlashGroupAudit(fgView.ID, proto.FlashGroupOpPurgeCache, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 2) // the failed one without volume too
+	require.Equal(t, "ok", records[1].Result)
+
+	// the flashnode not reached is reported
+	httpServer.Close()
+	purge, err = mc.AdminAPI().PurgeFlashGroupCache(fgView.ID, commonVolName, "")
+	require.NoError(t, err)
+	require.Equal(t, 0, purge.Purged)
+	require.NotEmpty(t, purge.Nodes[0].Err)
+}
+
+func TestFlashGroupRemappedPercent(t *testing.T) {
+	const quarter = 1 << 30
+	before := map[uint32]uint64{quarter: 1, 2 * quarter: 2, 3 * quarter: 1, 4*quarter - 1: 2}
+	require.Equal(t, float64(0), remappedPercent(before, before))
+	require.Equal(t, float64(0), remappedPercent(map[uint32]uint64{}, before))
+	require.Equal(t, float64(100), remappedPercent(before, map[uint32]uint64{}))
+
+	// the keys of slot 2*quarter move to the group of 3*quarter
+	after := map[uint32]uint64{quarter: 1, 3 * quarter: 1, 4*quarter - 1: 2}
+	require.Equal(t, float64(25), remappedPercent(before, after))
+
+	// a new slot in the middle of the arc of another group takes half of it
+	after = map[uint32]uint64{quarter / 2: 3, quarter: 1, 2 * quarter: 2, 3 * quarter: 1, 4*quarter - 1: 2}
+	require.Equal(t, 12.5, remappedPercent(before, after))
 }
@@ -0,0 +1,80 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+func (fg *FlashGroup) getVolumes() (volumes []string) {
+	fg.lock.RLock()
+	volumes = fg.Volumes
+	fg.lock.RUnlock()
+	return
+}
+
+// setFlashGroupVolumes dedicates the flash group to the volumes, so that the blocks of
+// the other volumes never evict theirs. The volumes read only from their groups then.
+func (m *Server) setFlashGroupVolumes(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		volNames     common.String
+		flashGroup   *FlashGroup
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashGroupVolumes))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashGroupVolumes, metric, err, nil)
+	}()
+	if err = parseArgs(r, flashGroupID.ID(), volNames.Key("volumes").OmitEmpty()); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	var volumes []string
+	for _, name := range strings.Split(volNames.V, ",") {
+		if name = strings.TrimSpace(name); name == "" || contains(volumes, name) {
+			continue
+		}
+		if _, err = m.cluster.getVol(name); err != nil {
+			sendErrReply(w, r, newErrHTTPReply(err))
+			return
+		}
+		volumes = append(volumes, name)
+	}
+	if flashGroup, err = m.cluster.flashNodeTopo.getFlashGroup(flashGroupID.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+
+	flashGroup.lock.Lock()
+	oldVolumes := flashGroup.Volumes
+	flashGroup.Volumes = volumes
+	if err = m.cluster.syncUpdateFlashGroup(flashGroup); err != nil {
+		flashGroup.Volumes = oldVolumes
+		flashGroup.lock.Unlock()
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	flashGroup.lock.Unlock()
+	m.cluster.flashNodeTopo.updateClientCache()
+	log.LogInfof("action[setFlashGroupVolumes] flashGroup[%v] volumes%v", flashGroup.ID, volumes)
+	sendOkReply(w, r, newSuccessHTTPReply(flashGroup.GetAdminView()))
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A flash group keeps at least FlashGroupMinNodesPerZone active and enabled
+// flashnodes in each zone it has flashnodes in, so that the clients of a zone
+// keep reading from a flashnode of their zone. A flashnode is not removed from
+// a group by hand or by auto scaling if its zone would fall under the min, and
+// a zone under the min is given an idle flashnode of the zone by auto healing.
+
+type flashGroupZoneHealth struct {
+	healthy       int
+	restarting    int        // inactive for less than inactiveFor
+	inactive      *FlashNode // the flashnode inactive for the longest
+	inactiveSince time.Time
+}
+
+func (c *Cluster) getFlashGroupMinNodesPerZone() uint64 {
+	return atomic.LoadUint64(&c.cfg.FlashGroupMinNodesPerZone)
+}
+
+func (c *Cluster) setFlashGroupMinNodesPerZone(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.FlashGroupMinNodesPerZone)
+	atomic.StoreUint64(&c.cfg.FlashGroupMinNodesPerZone, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setFlashGroupMinNodesPerZone] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.FlashGroupMinNodesPerZone, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+// getZonesHealth returns, for each zone of the flashnodes of the group, the
+// count of the active and enabled ones, the count of those inactive for less
+// than inactiveFor, and of the others the one that has been inactive the longest.
+func (fg *FlashGroup) getZonesHealth(inactiveFor time.Duration) (zones map[string]*flashGroupZoneHealth) {
+	zones = make(map[string]*flashGroupZoneHealth)
+	fg.lock.RLock()
+	defer fg.lock.RUnlock()
+	for _, flashNode := range fg.flashNodes {
+		flashNode.RLock()
+		isActive, isEnable, reportTime := flashNode.IsActive, flashNode.IsEnable, flashNode.ReportTime
+		flashNode.RUnlock()
+		zone, ok := zones[flashNode.ZoneName]
+		if !ok {
+			zone = &flashGroupZoneHealth{}
+			zones[flashNode.ZoneName] = zone
+		}
+		if isActive {
+			if isEnable {
+				zone.healthy++
+			}
+			continue
+		}
+		if time.Since(reportTime) <= inactiveFor {
+			zone.restarting++
+			continue
+		}
+		if zone.inactive == nil || reportTime.Before(zone.inactiveSince) ||
+			(reportTime.Equal(zone.inactiveSince) && flashNode.Addr < zone.inactive.Addr) {
+			zone.inactive, zone.inactiveSince = flashNode, reportTime
+		}
+	}
+	return
+}
+
+// checkFlashGroupZoneMinNodes returns an error if removing removeHealthy active
+// and enabled flashnodes of the zone from the group leaves it under the min.
+func (c *Cluster) checkFlashGroupZoneMinNodes(flashGroup *FlashGroup, zoneName string, removeHealthy int) (err error) {
+	min := c.getFlashGroupMinNodesPerZone()
+	if min == 0 || removeHealthy <= 0 {
+		return
+	}
+	healthy := 0
+	if zone, ok := flashGroup.getZonesHealth(0)[zoneName]; ok {
+		healthy = zone.healthy
+	}
+	if uint64(healthy) < min+uint64(removeHealthy) {
+		err = fmt.Errorf("flashGroup[%v] keeps at least %v active flashnodes in zone[%v], %v of them now, %v to remove",
+			flashGroup.ID, min, zoneName, healthy, removeHealthy)
+	}
+	return
+}
+
+// checkFlashNodeRemovable returns an error if removing the flashnode from the
+// group leaves its zone under the min.
+func (c *Cluster) checkFlashNodeRemovable(flashGroup *FlashGroup, addr string) (err error) {
+	flashNode, err := c.peekFlashNode(addr)
+	if err != nil || !flashNode.isActiveAndEnable() {
+		return
+	}
+	return c.checkFlashGroupZoneMinNodes(flashGroup, flashNode.ZoneName, 1)
+}
+
+// replaceFlashNodeOfZone adds an idle flashnode of the zone of a flashnode
+// leaving the group, if its zone would fall under the min without it.
+func (c *Cluster) replaceFlashNodeOfZone(flashGroup *FlashGroup, leaving *FlashNode) {
+	if c.checkFlashNodeRemovable(flashGroup, leaving.Addr) == nil {
+		return
+	}
+	flashGroup.lock.RLock()
+	excludeHosts := flashGroup.AutoHealExcludeHosts
+	flashGroup.lock.RUnlock()
+	flashNode := c.selectFlashNodeToHeal(leaving.ZoneName, excludeHosts)
+	if flashNode == nil {
+		log.LogWarnf("action[replaceFlashNodeOfZone] flashGroup[%v] falls under the min flashnodes in zone[%v] without flashNode[%v], but no idle flashnode in the zone",
+			flashGroup.ID, leaving.ZoneName, leaving.Addr)
+		return
+	}
+	if err := c.addFlashNodeToFlashGroup(flashNode.Addr, flashGroup); err != nil {
+		log.LogErrorf("action[replaceFlashNodeOfZone] flashGroup[%v] add flashNode[%v] failed, err:%v", flashGroup.ID, flashNode.Addr, err)
+		return
+	}
+	c.putAutoHealEvent(flashGroup, flashNode, fmt.Sprintf("replace leaving flashNode[%v] to keep the min %v flashnodes in zone[%v]",
+		leaving.Addr, c.getFlashGroupMinNodesPerZone(), leaving.ZoneName))
+}
+
+// autoHealFlashGroupZones gives at most one zone of an active group whose slots
+// are settled, and which has less healthy or restarting flashnodes than the min,
+// an idle flashnode of the zone, in place of its inactive flashnode if any.
+func (c *Cluster) autoHealFlashGroupZones(flashGroup *FlashGroup) (healed bool) {
+	min := c.getFlashGroupMinNodesPerZone()
+	flashGroup.lock.RLock()
+	cooldown, healedAt, excludeHosts := flashGroup.getAutoHealCooldown(), flashGroup.healedAt, flashGroup.AutoHealExcludeHosts
+	settled := flashGroup.Status.IsActive() && flashGroup.SlotStatus == proto.SlotStatus_Completed
+	flashGroup.lock.RUnlock()
+	if min == 0 || !settled || time.Since(healedAt) < cooldown {
+		return
+	}
+	zones := flashGroup.getZonesHealth(cooldown)
+	zoneNames := make([]string, 0, len(zones))
+	for zoneName, zone := range zones {
+		if uint64(zone.healthy+zone.restarting) < min {
+			zoneNames = append(zoneNames, zoneName)
+		}
+	}
+	sort.Strings(zoneNames)
+	for _, zoneName := range zoneNames {
+		zone := zones[zoneName]
+		flashNode := c.selectFlashNodeToHeal(zoneName, excludeHosts)
+		if flashNode == nil {
+			log.LogWarnf("action[autoHealFlashGroupZones] flashGroup[%v] %v healthy flashnodes in zone[%v] below the min %v, but no idle flashnode in the zone",
+				flashGroup.ID, zone.healthy, zoneName, min)
+			continue
+		}
+		if err := c.addFlashNodeToFlashGroup(flashNode.Addr, flashGroup); err != nil {
+			log.LogErrorf("action[autoHealFlashGroupZones] flashGroup[%v] add flashNode[%v] failed, err:%v", flashGroup.ID, flashNode.Addr, err)
+			return
+		}
+		reason := fmt.Sprintf("%v healthy flashnodes in zone[%v] below the min %v", zone.healthy, zoneName, min)
+		if zone.inactive != nil {
+			reason += fmt.Sprintf(", replace inactive flashNode[%v] last reported at %v",
+				zone.inactive.Addr, zone.inactiveSince.Format(proto.TimeFormat))
+			if err := c.removeFlashNodeFromFlashGroup(zone.inactive.Addr, flashGroup); err != nil {
+				log.LogErrorf("action[autoHealFlashGroupZones] flashGroup[%v] remove flashNode[%v] failed, err:%v",
+					flashGroup.ID, zone.inactive.Addr, err)
+			}
+		}
+		c.putAutoHealEvent(flashGroup, flashNode, reason)
+		return true
+	}
+	return
+}
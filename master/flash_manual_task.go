@@ -52,6 +52,9 @@ func checkManualConfig(flt *proto.FlashManualTask, vol *Vol, fltMgr *flashManual
 		if vol.remoteCacheTTL < minRequiredTTLSeconds && vol.remoteCacheTTL != 0 {
 			return fmt.Errorf("cache ttl %v of vol[%v] is too short and warm up can not work", vol.remoteCacheTTL, vol.Name)
 		}
+		if err = checkWarmupRange(&flt.ManualTaskConfig); err != nil {
+			return err
+		}
 		if err = isDuplicated(flt, fltMgr); err != nil {
 			return err
 		}
@@ -65,17 +68,36 @@ func checkManualConfig(flt *proto.FlashManualTask, vol *Vol, fltMgr *flashManual
 	return nil
 }
 
+func checkWarmupRange(config *proto.ManualTaskConfig) error {
+	if len(config.Inodes) > proto.MaxFlashManualTaskInodes {
+		return fmt.Errorf("%v inodes to warm up, should be at most %v", len(config.Inodes), proto.MaxFlashManualTaskInodes)
+	}
+	for _, ino := range config.Inodes {
+		if ino == 0 {
+			return fmt.Errorf("invalid inode 0 to warm up")
+		}
+	}
+	if config.Offset+config.Size < config.Offset {
+		return fmt.Errorf("offset(%v) and size(%v) to warm up overflow", config.Offset, config.Size)
+	}
+	return nil
+}
+
 func isDuplicated(flt *proto.FlashManualTask, fltMgr *flashManualTaskManager) (err error) {
 	if _, exists := fltMgr.flashManualTasks.Load(flt.Id); exists {
 		return fmt.Errorf("flash manual task[%v] already exists", flt.Id)
 	}
+	// the tasks of inodes scan no directory
+	if len(flt.ManualTaskConfig.Inodes) > 0 {
+		return
+	}
 	volName := flt.VolName
 	rootDir := flt.GetPathPrefix()
 
 	var tmpDir string
 	fltMgr.flashManualTasks.Range(func(_, v interface{}) bool {
 		t := v.(*proto.FlashManualTask)
-		if t.VolName != volName {
+		if t.VolName != volName || len(t.ManualTaskConfig.Inodes) > 0 {
 			return true
 		}
 		tmpDir = t.GetPathPrefix()
@@ -19,8 +19,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -49,6 +49,7 @@ type flashNodeValue struct {
 	FlashGroupID   uint64 // 0: have not allocated to flash group
 	IsEnable       bool
 	TaskCountLimit int
+	ReadOnly       bool // serves the hits but caches nothing, to drain its cache before maintenance
 }
 
 type FlashNode struct {
@@ -61,6 +62,12 @@ type FlashNode struct {
 	IsActive      bool
 	LimiterStatus *proto.FlashNodeLimiterStatusInfo
 	WorkRole      string
+	CacheCapacity int64
+	CacheStat     *proto.FlashNodeCacheStat
+	CacheRecovery *proto.FlashNodeCacheRecovery
+	health        nodeHealth
+	clock         clockSkew
+	leaving       bool // shutting down, not put in a flash group until it registers again
 }
 
 func newFlashNode(addr, zoneName, clusterID, version string, isEnable bool) *FlashNode {
@@ -70,6 +77,7 @@ func newFlashNode(addr, zoneName, clusterID, version string, isEnable bool) *Fla
 	node.Version = version
 	node.IsEnable = isEnable
 	node.TaskManager = newAdminTaskManager(addr, clusterID)
+	node.TaskManager.health = &node.health
 	return node
 }
 
@@ -81,13 +89,14 @@ func (flashNode *FlashNode) setActive() {
 	flashNode.Lock()
 	flashNode.ReportTime = time.Now()
 	flashNode.IsActive = true
+	flashNode.health.heartbeat(flashNode.ReportTime, time.Second*defaultIntervalToCheckHeartbeat, 0, 0)
 	flashNode.Unlock()
 }
 
 func (flashNode *FlashNode) isWriteable() (ok bool) {
 	flashNode.RLock()
-	if flashNode.FlashGroupID == unusedFlashNodeFlashGroupID &&
-		time.Since(flashNode.ReportTime) < _defaultNodeTimeoutDuration {
+	if flashNode.FlashGroupID == unusedFlashNodeFlashGroupID && !flashNode.leaving &&
+		time.Since(flashNode.ReportTime) < _defaultNodeTimeoutDuration && !flashNode.clock.isGated() {
 		ok = true
 	}
 	flashNode.RUnlock()
@@ -121,6 +130,12 @@ func (flashNode *FlashNode) getFlashNodeViewInfo() (info *proto.FlashNodeViewInf
 		IsEnable:      flashNode.IsEnable,
 		DiskStat:      flashNode.DiskStat,
 		LimiterStatus: flashNode.LimiterStatus,
+		CacheCapacity: flashNode.CacheCapacity,
+		CacheStat:     flashNode.CacheStat,
+		HealthScore:   flashNode.health.score(),
+		PoorHealth:    flashNode.health.isPoor(),
+		ReadOnly:      flashNode.ReadOnly,
+		CacheRecovery: flashNode.CacheRecovery,
 	}
 	flashNode.RUnlock()
 	return
@@ -132,6 +147,9 @@ func (flashNode *FlashNode) updateFlashNodeStatHeartbeat(resp *proto.FlashNodeHe
 	flashNode.DiskStat = resp.Stat
 	flashNode.LimiterStatus = resp.LimiterStatus
 	flashNode.TaskCountLimit = resp.FlashNodeTaskCountLimit
+	flashNode.CacheCapacity = resp.CacheCapacity
+	flashNode.CacheStat = resp.CacheStat
+	flashNode.CacheRecovery = resp.CacheRecovery
 	flashNode.Unlock()
 }
 
@@ -192,33 +210,129 @@ func (c *Cluster) handleManualTaskProcessing(flashNode *FlashNode, resp *proto.F
 
 func (c *Cluster) checkFlashNodeHeartbeat() {
 	tasks := make([]*proto.AdminTask, 0)
+	var alwaysAdmitVols []string
+	if c.cfg.flashNodeAdmissionEnable {
+		alwaysAdmitVols = c.getAlwaysAdmitVols()
+	}
+	readLimit := proto.FlashReadLimit{MBps: int64(c.cfg.flashNodeReadLimitMBps), Iops: int64(c.cfg.flashNodeReadLimitIops)}
+	volReadLimits := c.getVolFlashReadLimits()
+	var cacheKey []byte
+	authVols := c.getRemoteCacheAuthVols()
+	if len(authVols) > 0 {
+		var err error
+		if cacheKey, err = c.getFlashCacheKey(); err != nil {
+			// the flash nodes refuse the reads of the volumes without the key
+			log.LogErrorf("action[checkFlashNodeHeartbeat] get flash cache key err[%v]", err)
+		}
+	}
+	tlsVols := c.getTLSRequiredVols()
 	c.flashNodeTopo.flashNodeMap.Range(func(addr, flashNode interface{}) bool {
 		node := flashNode.(*FlashNode)
-		node.checkLiveliness()
-		task := node.createHeartbeatTask(c.masterAddr(), c.cfg.flashNodeHandleReadTimeout, c.cfg.flashNodeReadDataNodeTimeout)
+		if node.checkLiveliness() {
+			node.RLock()
+			fgID := node.FlashGroupID
+			node.RUnlock()
+			c.publishEvent(proto.ClusterEventFlashNodeOffline, fgID, node.Addr, "")
+		}
+		var peers []string
+		if c.cfg.flashNodePeerFillEnable {
+			peers = c.getFlashNodePeers(node)
+		}
+		task := node.createHeartbeatTask(c.masterAddr(), c.cfg.flashNodeHandleReadTimeout, c.cfg.flashNodeReadDataNodeTimeout,
+			c.cfg.flashNodePeerFillEnable, c.cfg.flashNodePeerFillTimeout, peers, c.cfg.flashNodeAdmissionEnable, alwaysAdmitVols,
+			c.isFlashNodeDraining(node), readLimit, volReadLimits, authVols, cacheKey, tlsVols)
 		tasks = append(tasks, task)
 		return true
 	})
 	c.addFlashNodeHeartbeatTasks(tasks)
 }
 
-func (flashNode *FlashNode) checkLiveliness() {
+// checkLiveliness returns true if the flash node goes offline by this check.
+func (flashNode *FlashNode) checkLiveliness() (offline bool) {
 	flashNode.Lock()
 	if time.Since(flashNode.ReportTime) > _defaultNodeTimeoutDuration {
 		msg := fmt.Sprintf("flashnode[%v] heartbeat lost, last heartbeat time %v", flashNode.Addr, flashNode.ReportTime)
 		auditlog.LogMasterOp("checkLiveliness", msg, nil)
+		if flashNode.IsActive {
+			flashNode.health.inactive(time.Now())
+			offline = true
+		}
 		flashNode.IsActive = false
 	}
 	flashNode.Unlock()
+	return
+}
+
+// getFlashNodePeers returns the other active members of the flash group of the flash node,
+// which it may fetch the missed blocks from before going to the datanode.
+func (c *Cluster) getFlashNodePeers(flashNode *FlashNode) (peers []string) {
+	flashNode.RLock()
+	fgID := flashNode.FlashGroupID
+	flashNode.RUnlock()
+	if fgID == unusedFlashNodeFlashGroupID {
+		return
+	}
+	flashGroup, err := c.flashNodeTopo.getFlashGroup(fgID)
+	if err != nil {
+		return
+	}
+	for _, host := range flashGroup.getFlashNodeHosts(true) {
+		if host != flashNode.Addr {
+			peers = append(peers, host)
+		}
+	}
+	sort.Strings(peers)
+	return
+}
+
+// getAlwaysAdmitVols returns the volumes whose blocks are cached by the flash nodes
+// without passing the admission filter.
+func (c *Cluster) getAlwaysAdmitVols() (vols []string) {
+	for name, vol := range c.allVols() {
+		if vol.remoteCacheAlwaysAdmit {
+			vols = append(vols, name)
+		}
+	}
+	sort.Strings(vols)
+	return
+}
+
+// getVolFlashReadLimits returns the read limits of the volumes on each flash node.
+func (c *Cluster) getVolFlashReadLimits() (limits map[string]proto.FlashReadLimit) {
+	for name, vol := range c.allVols() {
+		limit := proto.FlashReadLimit{MBps: vol.remoteCacheReadLimitMBps, Iops: vol.remoteCacheReadLimitIops}
+		if limit.Unlimited() {
+			continue
+		}
+		if limits == nil {
+			limits = make(map[string]proto.FlashReadLimit)
+		}
+		limits[name] = limit
+	}
+	return
 }
 
-func (flashNode *FlashNode) createHeartbeatTask(masterAddr string, flashNodeHandleReadTimeout int, flashNodeReadDataNodeTimeout int) (task *proto.AdminTask) {
+func (flashNode *FlashNode) createHeartbeatTask(masterAddr string, flashNodeHandleReadTimeout int, flashNodeReadDataNodeTimeout int,
+	peerFillEnable bool, peerFillTimeout int, peers []string, admissionEnable bool, alwaysAdmitVols []string, draining bool,
+	readLimit proto.FlashReadLimit, volReadLimits map[string]proto.FlashReadLimit, authVols []string, cacheKey []byte, tlsVols []string,
+) (task *proto.AdminTask) {
 	request := &proto.HeartBeatRequest{
 		CurrTime:   time.Now().Unix(),
 		MasterAddr: masterAddr,
 	}
 	request.FlashNodeHandleReadTimeout = flashNodeHandleReadTimeout
 	request.FlashNodeReadDataNodeTimeout = flashNodeReadDataNodeTimeout
+	request.FlashNodePeerFillEnable = peerFillEnable
+	request.FlashNodePeerFillTimeout = peerFillTimeout
+	request.FlashNodePeers = peers
+	request.FlashNodeAdmissionEnable = admissionEnable
+	request.FlashNodeAlwaysAdmitVols = alwaysAdmitVols
+	request.FlashNodeDraining = draining
+	request.FlashNodeReadLimit = readLimit
+	request.FlashNodeVolReadLimits = volReadLimits
+	request.FlashNodeAuthVols = authVols
+	request.FlashNodeCacheKey = cacheKey
+	request.TLSRequiredVols = tlsVols
 
 	task = proto.NewAdminTask(proto.OpFlashNodeHeartbeat, flashNode.Addr, request)
 	return
@@ -287,6 +401,9 @@ func (c *Cluster) addFlashNode(nodeAddr, zoneName, version string) (id uint64, e
 	var flashNode *FlashNode
 	flashNode, err = c.peekFlashNode(nodeAddr)
 	if err == nil {
+		flashNode.Lock()
+		flashNode.leaving = false
+		flashNode.Unlock()
 		return flashNode.ID, nil
 	}
 	flashNode = newFlashNode(nodeAddr, zoneName, c.Name, version, true)
@@ -341,6 +458,51 @@ func (m *Server) listFlashNodes(w http.ResponseWriter, r *http.Request) {
 	sendOkReply(w, r, newSuccessHTTPReply(zoneFlashNodes))
 }
 
+// getFlashNodeStats returns the cache stats of the flashnodes, of a flash
+// group or a zone, so that the operators see whether the cache is effective.
+func (c *Cluster) getFlashNodeStats(flashGroupID uint64, zoneName string) (view *proto.FlashNodeStatsView) {
+	view = &proto.FlashNodeStatsView{FlashNodes: make([]*proto.FlashNodeCacheStatView, 0)}
+	c.flashNodeTopo.flashNodeMap.Range(func(_, value interface{}) bool {
+		info := value.(*FlashNode).getFlashNodeViewInfo()
+		if (flashGroupID != 0 && info.FlashGroupID != flashGroupID) || (zoneName != "" && info.ZoneName != zoneName) {
+			return true
+		}
+		view.FlashNodes = append(view.FlashNodes, &proto.FlashNodeCacheStatView{
+			Addr:          info.Addr,
+			ZoneName:      info.ZoneName,
+			FlashGroupID:  info.FlashGroupID,
+			IsActive:      info.IsActive,
+			IsEnable:      info.IsEnable,
+			ReportTime:    info.ReportTime,
+			CacheCapacity: info.CacheCapacity,
+			CacheStat:     info.CacheStat,
+		})
+		if info.IsActive && info.IsEnable && info.CacheStat != nil {
+			view.Total.Add(info.CacheStat)
+		}
+		return true
+	})
+	sort.Slice(view.FlashNodes, func(i, j int) bool { return view.FlashNodes[i].Addr < view.FlashNodes[j].Addr })
+	return
+}
+
+func (m *Server) getFlashNodeStats(w http.ResponseWriter, r *http.Request) {
+	var (
+		flashGroupID common.Uint
+		zoneName     common.String
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminFlashNodeStats))
+	defer func() {
+		doStatAndMetric(proto.AdminFlashNodeStats, metric, err, nil)
+	}()
+	if err = parseArgs(r, flashGroupID.Key("flashGroupID").OmitEmpty(), zoneName.ZoneName().OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.getFlashNodeStats(flashGroupID.V, zoneName.V)))
+}
+
 func (m *Server) getFlashNode(w http.ResponseWriter, r *http.Request) {
 	var err error
 	metric := exporter.NewTPCnt(apiToMetricsName(proto.FlashNodeGet))
@@ -412,6 +574,58 @@ func (m *Server) removeAllInactiveFlashNodes(w http.ResponseWriter, r *http.Requ
 	sendOkReply(w, r, newSuccessHTTPReply(removeAddresses))
 }
 
+// offlineFlashNode is called by a flash node shutting down. It is removed from its
+// flash group at once, and keeps serving the reads of the clients until they get
+// the flash groups without it.
+func (m *Server) offlineFlashNode(w http.ResponseWriter, r *http.Request) {
+	var err error
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.FlashNodeOffline))
+	defer func() {
+		doStatAndMetric(proto.FlashNodeOffline, metric, err, nil)
+	}()
+	var nodeAddr common.String
+	if err = parseArgs(r, argParserNodeAddr(&nodeAddr)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	var node *FlashNode
+	if node, err = m.cluster.peekFlashNode(nodeAddr.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	var flashGroupID uint64
+	if flashGroupID, err = m.cluster.offlineFlashNode(node); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("flash node [%v] left flash group [%v]", nodeAddr.V, flashGroupID)))
+}
+
+func (c *Cluster) offlineFlashNode(flashNode *FlashNode) (flashGroupID uint64, err error) {
+	flashNode.Lock()
+	flashNode.leaving = true
+	flashGroupID = flashNode.FlashGroupID
+	flashNode.Unlock()
+	if flashGroupID == unusedFlashNodeFlashGroupID {
+		log.LogInfof("action[offlineFlashNode] node[%v] in no flash group", flashNode.Addr)
+		return
+	}
+	var flashGroup *FlashGroup
+	if flashGroup, err = c.flashNodeTopo.getFlashGroup(flashGroupID); err != nil {
+		return
+	}
+	c.flashNodeTopo.createFlashGroupLock.Lock()
+	defer c.flashNodeTopo.createFlashGroupLock.Unlock()
+	// the leaving node is shut down anyway, its zone is replenished if it can be
+	c.replaceFlashNodeOfZone(flashGroup, flashNode)
+	if err = c.removeFlashNodeFromFlashGroup(flashNode.Addr, flashGroup); err != nil {
+		return
+	}
+	c.flashNodeTopo.updateClientCache()
+	log.LogWarnf("action[offlineFlashNode] node[%v] left flashGroup[%v]", flashNode.Addr, flashGroupID)
+	return
+}
+
 func (c *Cluster) removeFlashNode(flashNode *FlashNode) (err error) {
 	log.LogWarnf("action[removeFlashNode], ZoneName[%s] Node[%s] offline", flashNode.ZoneName, flashNode.Addr)
 	var flashGroupID uint64
@@ -429,10 +643,7 @@ func (c *Cluster) removeFlashNode(flashNode *FlashNode) (err error) {
 
 	go func() {
 		time.Sleep(time.Duration(defaultWaitClientUpdateFgTimeSec) * time.Second)
-		arr := strings.SplitN(flashNode.Addr, ":", 2)
-		p, _ := strconv.ParseUint(arr[1], 10, 64)
-		addr := fmt.Sprintf("%s:%d", arr[0], p+1)
-		if err = httpclient.New().Addr(addr).FlashNode().EvictAll(); err != nil {
+		if err = httpclient.New().Addr(flashNodeHTTPAddr(flashNode.Addr)).FlashNode().EvictAll(); err != nil {
 			log.LogErrorf("flashNode[%v] evict all failed, err:%v", flashNode.Addr, err)
 			return
 		}
@@ -504,6 +715,32 @@ func (m *Server) setFlashNode(w http.ResponseWriter, r *http.Request) {
 	sendOkReply(w, r, newSuccessHTTPReply("set flashNode success"))
 }
 
+func (m *Server) setFlashNodeReadOnly(w http.ResponseWriter, r *http.Request) {
+	var (
+		nodeAddr  common.String
+		readOnly  common.Bool
+		flashNode *FlashNode
+		err       error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.FlashNodeSetReadOnly))
+	defer func() {
+		doStatAndMetric(proto.FlashNodeSetReadOnly, metric, err, nil)
+	}()
+	if err = parseArgs(r, argParserNodeAddr(&nodeAddr), readOnly.Key("readOnly")); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if flashNode, err = m.cluster.peekFlashNode(nodeAddr.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if err = m.cluster.setFlashNodeReadOnly(flashNode, readOnly.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set flashNode:%v readOnly:%v", nodeAddr.V, readOnly.V)))
+}
+
 func (m *Server) createFlashNodeManualTask(w http.ResponseWriter, r *http.Request) {
 	var (
 		bytes []byte
@@ -700,6 +937,7 @@ func (c *Cluster) handleFlashNodeTaskResponse(nodeAddr string, task *proto.Admin
 		err = c.handleFlashNodeScanResp(task.OperatorAddr, response)
 	case proto.OpFlashNodeHeartbeat:
 		response := task.Response.(*proto.FlashNodeHeartbeatResponse)
+		c.measureClockSkew("flashnode", flashNode.Addr, &flashNode.clock, task, response.Clock)
 		err = c.handleFlashNodeHeartbeatResp(task.OperatorAddr, response)
 	default:
 		err = fmt.Errorf(fmt.Sprintf("flash unknown operate code %v", task.OpCode))
@@ -749,6 +987,34 @@ func (c *Cluster) updateFlashNode(flashNode *FlashNode, enable bool) (err error)
 	return
 }
 
+func (flashNode *FlashNode) isReadOnly() bool {
+	flashNode.RLock()
+	defer flashNode.RUnlock()
+	return flashNode.ReadOnly
+}
+
+// setFlashNodeReadOnly turns the flashnode read only at its next heartbeat, it
+// keeps serving the blocks it caches while they age out, but caches nothing
+// more on miss or on prepare.
+func (c *Cluster) setFlashNodeReadOnly(flashNode *FlashNode, readOnly bool) (err error) {
+	flashNode.Lock()
+	if flashNode.ReadOnly == readOnly {
+		flashNode.Unlock()
+		return
+	}
+	flashNode.ReadOnly = readOnly
+	if err = c.syncUpdateFlashNode(flashNode); err != nil {
+		flashNode.ReadOnly = !readOnly
+		flashNode.Unlock()
+		return
+	}
+	flashNode.Unlock()
+	msg := fmt.Sprintf("flashNode[%v] readOnly[%v]", flashNode.Addr, readOnly)
+	log.LogInfof("action[setFlashNodeReadOnly] %v", msg)
+	auditlog.LogMasterOp("setFlashNodeReadOnly", msg, nil)
+	return
+}
+
 func (c *Cluster) updateFlashNodeWorkRole(flashNode *FlashNode, workRole string) error {
 	flashNode.Lock()
 	defer flashNode.Unlock()
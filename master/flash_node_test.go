@@ -16,15 +16,26 @@ package master
 
 import (
 	"testing"
+	"time"
 
+	"github.com/cubefs/cubefs/master/mocktest"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
 	"github.com/stretchr/testify/require"
 )
 
 func testFlashNode(t *testing.T) {
 	t.Run("Set", testFlashNodeSet)
 	t.Run("Remove", testFlashNodeRemove)
+	t.Run("Offline", testFlashNodeOffline)
 	t.Run("Get", testFlashNodeGet)
 	t.Run("List", testFlashNodeList)
+	t.Run("Stats", testFlashNodeStats)
+	t.Run("PeerFill", testFlashNodePeerFill)
+	t.Run("Admission", testFlashNodeAdmission)
+	t.Run("ReadLimit", testFlashNodeReadLimit)
+	t.Run("CacheAuth", testFlashNodeCacheAuth)
+	t.Run("ReadOnly", testFlashNodeReadOnly)
 }
 
 func testFlashNodeSet(t *testing.T) {
@@ -64,6 +75,38 @@ func testFlashNodeRemove(t *testing.T) {
 	require.Error(t, err)
 }
 
+func testFlashNodeOffline(t *testing.T) {
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	g := groups[0]
+	_, err := mc.AdminAPI().FlashGroupAddFlashNode(g.ID, 0, "", mfs1Addr)
+	require.NoError(t, err)
+	_, err = mc.AdminAPI().SetFlashGroup(g.ID, true)
+	require.NoError(t, err)
+	defer mc.AdminAPI().SetFlashGroup(g.ID, false)
+	topo := server.cluster.flashNodeTopo
+	before := topo.updateClientResponse()[flashGroupSharedAudience].version
+
+	require.Error(t, mc.NodeAPI().OfflineFlashNode("not-addr"))
+	require.NoError(t, mc.NodeAPI().OfflineFlashNode(mfs1Addr))
+	fnView, err := mc.NodeAPI().GetFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(unusedFlashNodeFlashGroupID), fnView.FlashGroupID)
+	fg, err := topo.getFlashGroup(g.ID)
+	require.NoError(t, err)
+	require.NotContains(t, fg.getFlashNodeHosts(false), mfs1Addr)
+	require.Greater(t, topo.updateClientResponse()[flashGroupSharedAudience].version, before)
+
+	// the leaving node is not put in a flash group until it registers again
+	node, err := server.cluster.peekFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.False(t, node.isWriteable())
+	require.NoError(t, mc.NodeAPI().OfflineFlashNode(mfs1Addr))
+	_, err = mc.NodeAPI().AddFlashNode(mfs1Addr, testZone1, "")
+	require.NoError(t, err)
+	require.True(t, node.isWriteable())
+}
+
 func testFlashNodeGet(t *testing.T) {
 	_, err := mc.NodeAPI().GetFlashNode("not-addr")
 	require.Error(t, err)
@@ -110,3 +153,180 @@ func testFlashNodeList(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(zoneNodes[testZone3]))
 }
+
+func testFlashNodeStats(t *testing.T) {
+	// the same stat as reported by the heartbeats of the mock flashnodes
+	stat := new(proto.FlashNodeCacheStat)
+	stat.AddDisk(mocktest.FlashDiskCacheStat(mocktest.DefaultFlashCacheCapacity))
+	recovery := &proto.FlashNodeCacheRecovery{Time: 1700000000, CostMs: 12, Blocks: 3, Bytes: 3 << 20, Indexed: 2, Dropped: 1}
+	for _, addr := range []string{mfs1Addr, mfs2Addr} {
+		flashNode, err := server.cluster.peekFlashNode(addr)
+		require.NoError(t, err)
+		flashNode.setActive()
+		flashNode.Lock()
+		flashNode.CacheStat = stat
+		flashNode.CacheRecovery = recovery
+		flashNode.Unlock()
+	}
+	fnView, err := mc.NodeAPI().GetFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.Equal(t, stat, fnView.CacheStat)
+	require.Equal(t, recovery, fnView.CacheRecovery)
+
+	view, err := mc.NodeAPI().GetFlashNodeStats(0, testZone1)
+	require.NoError(t, err)
+	require.Len(t, view.FlashNodes, 2)
+	require.Equal(t, mfs1Addr, view.FlashNodes[0].Addr)
+	require.Equal(t, 2*stat.Hits, view.Total.Hits)
+	require.Equal(t, 2*stat.Misses, view.Total.Misses)
+	require.Equal(t, 2*stat.Evicts, view.Total.Evicts)
+	require.Equal(t, 2*stat.OccupiedBytes, view.Total.OccupiedBytes)
+	require.Equal(t, 2*stat.ObjectCount, view.Total.ObjectCount)
+	require.InDelta(t, 0.9, view.Total.HitRate, 1e-9)
+
+	view, err = mc.NodeAPI().GetFlashNodeStats(0, "")
+	require.NoError(t, err)
+	require.Len(t, view.FlashNodes, 7)
+	view, err = mc.NodeAPI().GetFlashNodeStats(1<<32, "")
+	require.NoError(t, err)
+	require.Empty(t, view.FlashNodes)
+	require.Zero(t, view.Total.Hits)
+}
+
+func testFlashNodePeerFill(t *testing.T) {
+	require.Error(t, server.setConfig(flashNodePeerFillTimeout, "0"))
+	require.NoError(t, server.setConfig(flashNodePeerFillTimeout, "200"))
+	require.NoError(t, server.setConfig(flashNodePeerFillEnable, "true"))
+	defer server.setConfig(flashNodePeerFillEnable, "false")
+	value, err := server.getConfig(flashNodePeerFillEnable)
+	require.NoError(t, err)
+	require.Equal(t, "true", value)
+	cv, err := mc.AdminAPI().GetCluster(false)
+	require.NoError(t, err)
+	require.True(t, cv.FlashNodePeerFillEnable)
+	require.Equal(t, 200, cv.FlashNodePeerFillTimeout)
+
+	groups := createFlashGroups(t)
+	defer removeFlashGroups(t, groups)
+	g := groups[0]
+	fgView, err := mc.AdminAPI().FlashGroupAddFlashNode(g.ID, 2, testZone1, "")
+	require.NoError(t, err)
+	defer mc.AdminAPI().FlashGroupRemoveFlashNode(g.ID, 2, testZone1, "")
+	hosts := fgView.ZoneFlashNodes[testZone1]
+	require.Equal(t, 2, len(hosts))
+
+	node, err := server.cluster.peekFlashNode(hosts[0].Addr)
+	require.NoError(t, err)
+	peers := server.cluster.getFlashNodePeers(node)
+	require.Equal(t, []string{hosts[1].Addr}, peers)
+	task := node.createHeartbeatTask(server.cluster.masterAddr(), 0, 0, true, 200, peers, false, nil, false, proto.FlashReadLimit{}, nil, nil, nil, nil)
+	request := task.Request.(*proto.HeartBeatRequest)
+	require.True(t, request.FlashNodePeerFillEnable)
+	require.Equal(t, 200, request.FlashNodePeerFillTimeout)
+	require.Equal(t, peers, request.FlashNodePeers)
+}
+
+func testFlashNodeAdmission(t *testing.T) {
+	require.Error(t, server.setConfig(flashNodeAdmissionEnable, "not-bool"))
+	require.NoError(t, server.setConfig(flashNodeAdmissionEnable, "true"))
+	defer server.setConfig(flashNodeAdmissionEnable, "false")
+	cv, err := mc.AdminAPI().GetCluster(false)
+	require.NoError(t, err)
+	require.True(t, cv.FlashNodeAdmissionEnable)
+
+	vol, err := server.cluster.getVol(commonVolName)
+	require.NoError(t, err)
+	require.NotContains(t, server.cluster.getAlwaysAdmitVols(), commonVolName)
+	vol.remoteCacheAlwaysAdmit = true
+	defer func() { vol.remoteCacheAlwaysAdmit = false }()
+	alwaysAdmitVols := server.cluster.getAlwaysAdmitVols()
+	require.Contains(t, alwaysAdmitVols, commonVolName)
+
+	node, err := server.cluster.peekFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	task := node.createHeartbeatTask(server.cluster.masterAddr(), 0, 0, false, 0, nil, true, alwaysAdmitVols, false, proto.FlashReadLimit{}, nil, nil, nil, nil)
+	request := task.Request.(*proto.HeartBeatRequest)
+	require.True(t, request.FlashNodeAdmissionEnable)
+	require.Equal(t, alwaysAdmitVols, request.FlashNodeAlwaysAdmitVols)
+}
+
+func testFlashNodeReadLimit(t *testing.T) {
+	require.Error(t, server.setConfig(flashNodeReadLimitMBps, "-1"))
+	require.NoError(t, server.setConfig(flashNodeReadLimitMBps, "200"))
+	defer server.setConfig(flashNodeReadLimitMBps, "0")
+	require.NoError(t, server.setConfig(flashNodeReadLimitIops, "5000"))
+	defer server.setConfig(flashNodeReadLimitIops, "0")
+	cv, err := mc.AdminAPI().GetCluster(false)
+	require.NoError(t, err)
+	require.Equal(t, 200, cv.FlashNodeReadLimitMBps)
+	require.Equal(t, 5000, cv.FlashNodeReadLimitIops)
+
+	vol, err := server.cluster.getVol(commonVolName)
+	require.NoError(t, err)
+	require.NotContains(t, server.cluster.getVolFlashReadLimits(), commonVolName)
+	vol.remoteCacheReadLimitMBps = 50
+	defer func() { vol.remoteCacheReadLimitMBps = 0 }()
+	volReadLimits := server.cluster.getVolFlashReadLimits()
+	require.Equal(t, proto.FlashReadLimit{MBps: 50}, volReadLimits[commonVolName])
+
+	node, err := server.cluster.peekFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	readLimit := proto.FlashReadLimit{MBps: 200, Iops: 5000}
+	task := node.createHeartbeatTask(server.cluster.masterAddr(), 0, 0, false, 0, nil, false, nil, false, readLimit, volReadLimits, nil, nil, nil)
+	request := task.Request.(*proto.HeartBeatRequest)
+	require.Equal(t, readLimit, request.FlashNodeReadLimit)
+	require.Equal(t, volReadLimits, request.FlashNodeVolReadLimits)
+}
+
+func testFlashNodeCacheAuth(t *testing.T) {
+	_, err := mc.ClientAPI().GetFlashCacheToken(commonVolName, "bad")
+	require.Error(t, err)
+	vol, err := server.cluster.getVol(commonVolName)
+	require.NoError(t, err)
+	token, err := mc.ClientAPI().GetFlashCacheToken(commonVolName, util.CalcAuthKey(vol.Owner))
+	require.NoError(t, err)
+	key := server.cluster.flashCacheKey.get()
+	require.Len(t, key, flashCacheKeyLen)
+	require.Equal(t, key, newClusterValue(server.cluster).FlashCacheKey)
+	now := time.Now().Unix()
+	require.NoError(t, token.Verify(key, commonVolName, now))
+	require.Error(t, token.Verify(key, commonVolName, token.Expire+1))
+	require.Error(t, token.Verify(key, "other", now))
+	// the key is kept
+	again, err := server.cluster.getFlashCacheKey()
+	require.NoError(t, err)
+	require.Equal(t, key, again)
+
+	old := vol.remoteCacheAuth
+	vol.remoteCacheAuth = true
+	defer func() { vol.remoteCacheAuth = old }()
+	authVols := server.cluster.getRemoteCacheAuthVols()
+	require.Contains(t, authVols, commonVolName)
+	node, err := server.cluster.peekFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	task := node.createHeartbeatTask(server.cluster.masterAddr(), 0, 0, false, 0, nil, false, nil, false, proto.FlashReadLimit{}, nil,
+		authVols, key, []string{commonVolName})
+	request := task.Request.(*proto.HeartBeatRequest)
+	require.Equal(t, authVols, request.FlashNodeAuthVols)
+	require.Equal(t, key, request.FlashNodeCacheKey)
+	require.Equal(t, []string{commonVolName}, request.TLSRequiredVols)
+}
+
+func testFlashNodeReadOnly(t *testing.T) {
+	require.Error(t, mc.NodeAPI().SetFlashNodeReadOnly("noSuchNode:18510", true))
+	node, err := server.cluster.peekFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.False(t, server.cluster.isFlashNodeDraining(node))
+
+	require.NoError(t, mc.NodeAPI().SetFlashNodeReadOnly(mfs1Addr, true))
+	fnView, err := mc.NodeAPI().GetFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.True(t, fnView.ReadOnly)
+	require.True(t, server.cluster.isFlashNodeDraining(node))
+
+	require.NoError(t, mc.NodeAPI().SetFlashNodeReadOnly(mfs1Addr, false))
+	fnView, err = mc.NodeAPI().GetFlashNode(mfs1Addr)
+	require.NoError(t, err)
+	require.False(t, fnView.ReadOnly)
+	require.False(t, server.cluster.isFlashNodeDraining(node))
+}
@@ -15,10 +15,12 @@
 package master
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"hash/crc32"
 	"math"
+	"reflect"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -41,8 +43,40 @@ type flashNodeTopology struct {
 
 	clientEmpty    []byte        // empty response cache
 	clientOff      atomic.Value  // []byte, default nil (on)
-	clientCache    atomic.Value  // []byte
+	clientCache    atomic.Value  // map[string]*flashGroupClientCache, key: audience of the view
 	clientUpdateCh chan struct{} // update client response cache
+	clientChanged  atomic.Value  // chan struct{}, closed on a new version of the client views
+
+	clientLock        sync.Mutex                            // update client response cache
+	clientVersions    map[string][]*flashGroupClientVersion // the latest flashGroupClientVersionsKeep of each audience, oldest first
+	clientLastVersion uint64                                // versions increase across the audiences
+
+	scaleEventsLock sync.Mutex
+	scaleEvents     []*proto.FlashGroupScaleEvent // the latest flashGroupScaleEventsKeep, oldest first
+}
+
+// the versions of the client view kept to send the changes since
+const flashGroupClientVersionsKeep = 8
+
+// the longest a client waits for a change of its view, within the request
+// timeout of the master client
+const maxFlashGroupClientWait = 25 * time.Second
+
+type flashGroupClientVersion struct {
+	version uint64
+	groups  map[uint64]*proto.FlashGroupInfo
+}
+
+// the audience of the client view of the volumes bound to no flash group,
+// the volumes bound to flash groups are the audiences of their own views.
+const flashGroupSharedAudience = ""
+
+// flashGroupClientCache is the response of the current version of the client
+// view, in full and to the clients of the kept versions.
+type flashGroupClientCache struct {
+	version uint64
+	full    []byte
+	since   map[uint64][]byte // key: version of the client
 }
 
 type FlashNodeZone struct {
@@ -93,7 +127,8 @@ func newFlashNodeTopology() (t *flashNodeTopology) {
 		clientUpdateCh: make(chan struct{}, 1),
 	}
 	t.clientOff.Store([]byte(nil))
-	t.clientCache.Store([]byte(nil))
+	t.clientCache.Store(map[string]*flashGroupClientCache(nil))
+	t.clientChanged.Store(make(chan struct{}))
 	return t
 }
 
@@ -112,7 +147,11 @@ func (t *flashNodeTopology) clear() {
 		flashNode.clean()
 		return true
 	})
-	t.clientCache.Store([]byte(nil))
+	t.clientLock.Lock()
+	t.clientVersions = nil
+	t.clientLastVersion = 0
+	t.clientCache.Store(map[string]*flashGroupClientCache(nil))
+	t.clientLock.Unlock()
 }
 
 func (t *flashNodeTopology) getZone(name string) (zone *FlashNodeZone, err error) {
@@ -174,13 +213,22 @@ func (t *flashNodeTopology) allocateNewSlotsForCreateFlashGroup(fgID uint64, set
 	if target64 > math.MaxInt {
 		return nil
 	}
-	target := int(target64)
+	return t.allocateNewSlots(int(target64))
+}
 
-	for len(slots) < target {
+// the function caller should use createFlashGroupLock
+func (t *flashNodeTopology) allocateNewSlots(count int) (slots []uint32) {
+	slots = make([]uint32, 0, count)
+	allocated := make(map[uint32]struct{}, count)
+	for len(slots) < count {
 		slot := allocateNewSlot()
 		if _, ok := t.slotsMap[slot]; ok {
 			continue
 		}
+		if _, ok := allocated[slot]; ok {
+			continue
+		}
+		allocated[slot] = struct{}{}
 		slots = append(slots, slot)
 	}
 	return
@@ -304,21 +352,43 @@ func (t *flashNodeTopology) getFlashGroup(fgID uint64) (flashGroup *FlashGroup,
 	return
 }
 
+// getFlashGroupView returns the client view of the volumes bound to no flash group.
 func (t *flashNodeTopology) getFlashGroupView() (fgv *proto.FlashGroupView) {
-	fgv = new(proto.FlashGroupView)
-	fgv.Enable = true
+	return t.getFlashGroupViews()[flashGroupSharedAudience]
+}
+
+// getFlashGroupViews returns the client view of each audience. A volume bound to flash
+// groups sees only them, even if none of them is active, the others share the unbound groups.
+func (t *flashNodeTopology) getFlashGroupViews() (views map[string]*proto.FlashGroupView) {
+	views = map[string]*proto.FlashGroupView{flashGroupSharedAudience: {Enable: true}}
 	t.flashGroupMap.Range(func(_, value interface{}) bool {
 		fg := value.(*FlashGroup)
-		if fg.GetStatus().IsActive() {
-			hosts := fg.getFlashNodeHostsEnabled()
-			if len(hosts) == 0 {
-				return true
+		volumes := fg.getVolumes()
+		for _, volume := range volumes {
+			if _, ok := views[volume]; !ok {
+				views[volume] = &proto.FlashGroupView{Enable: true}
 			}
-			fgv.FlashGroups = append(fgv.FlashGroups, &proto.FlashGroupInfo{
-				ID:    fg.ID,
-				Slot:  fg.Slots,
-				Hosts: hosts,
-			})
+		}
+		status := fg.GetStatus()
+		if !status.IsActive() && status != proto.FlashGroupStatus_Draining {
+			return true
+		}
+		hosts, hostZones := fg.getFlashNodeHostsEnabled()
+		if len(hosts) == 0 {
+			return true
+		}
+		info := &proto.FlashGroupInfo{
+			ID:        fg.ID,
+			Slot:      fg.Slots,
+			Hosts:     hosts,
+			Draining:  status == proto.FlashGroupStatus_Draining,
+			HostZones: hostZones,
+		}
+		if len(volumes) == 0 {
+			volumes = []string{flashGroupSharedAudience}
+		}
+		for _, volume := range volumes {
+			views[volume].FlashGroups = append(views[volume].FlashGroups, info)
 		}
 		return true
 	})
@@ -340,15 +410,31 @@ func (t *flashNodeTopology) checkForActiveNode() (exists bool) {
 	return
 }
 
-func (t *flashNodeTopology) getFlashGroupsAdminView(fgStatus proto.FlashGroupStatus, allStatus bool) (fgv *proto.FlashGroupsAdminView) {
+// getFlashGroupsAdminView returns the page of the flash groups of the status,
+// with flashnodes in the zone filtered, by the flash group id.
+func (t *flashNodeTopology) getFlashGroupsAdminView(fgStatus proto.FlashGroupStatus, allStatus bool,
+	filter listFilter, page listPage,
+) (fgv *proto.FlashGroupsAdminView) {
 	fgv = new(proto.FlashGroupsAdminView)
 	t.flashGroupMap.Range(func(_, value interface{}) bool {
 		fg := value.(*FlashGroup)
-		if allStatus || fg.GetStatus() == fgStatus {
-			fgv.FlashGroups = append(fgv.FlashGroups, fg.GetAdminView())
+		if !allStatus && fg.GetStatus() != fgStatus {
+			return true
 		}
+		view := fg.GetAdminView()
+		if filter.zone != "" {
+			if _, ok := view.ZoneFlashNodes[filter.zone]; !ok {
+				return true
+			}
+		}
+		fgv.FlashGroups = append(fgv.FlashGroups, view)
 		return true
 	})
+	sort.Slice(fgv.FlashGroups, func(i, j int) bool { return fgv.FlashGroups[i].ID < fgv.FlashGroups[j].ID })
+	if page.paged() {
+		begin, end := page.bounds(len(fgv.FlashGroups))
+		fgv.FlashGroups = fgv.FlashGroups[begin:end]
+	}
 	return
 }
 
@@ -359,24 +445,152 @@ func (t *flashNodeTopology) updateClientCache() {
 	}
 }
 
-func (t *flashNodeTopology) getClientResponse() []byte {
+// notifyClientChange wakes up the clients waiting for a change of their views.
+func (t *flashNodeTopology) notifyClientChange() {
+	close(t.clientChanged.Swap(make(chan struct{})).(chan struct{}))
+}
+
+// getClientResponse returns the client view of the volume, or its changes
+// since the version of the client if it is still kept.
+func (t *flashNodeTopology) getClientResponse(volume string, version uint64) []byte {
+	resp, _ := t.getClientResponseOf(volume, version)
+	return resp
+}
+
+// waitClientResponse is getClientResponse, but waits up to wait for a new
+// version if the view of the client is the current one. The clients are
+// pushed the changes of the flash groups as soon as the views are updated.
+func (t *flashNodeTopology) waitClientResponse(ctx context.Context, volume string, version uint64, wait time.Duration) []byte {
+	if wait > maxFlashGroupClientWait {
+		wait = maxFlashGroupClientWait
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		// taken before the view, not to miss a change in between
+		changed := t.clientChanged.Load().(chan struct{})
+		resp, current := t.getClientResponseOf(volume, version)
+		if !current || version == 0 {
+			return resp
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return resp
+		case <-ctx.Done():
+			return resp
+		}
+	}
+}
+
+// getClientResponseOf returns the response to the version of the client and
+// whether it is the current version.
+func (t *flashNodeTopology) getClientResponseOf(volume string, version uint64) (resp []byte, current bool) {
 	if cache := t.clientOff.Load().([]byte); len(cache) > 0 {
-		return cache
+		return cache, false
 	}
-	if cache := t.clientCache.Load().([]byte); len(cache) > 0 {
-		return cache
+	caches := t.clientCache.Load().(map[string]*flashGroupClientCache)
+	if caches == nil {
+		if caches = t.updateClientResponse(); caches == nil {
+			return nil, false
+		}
+	}
+	cache, ok := caches[volume]
+	if !ok {
+		cache = caches[flashGroupSharedAudience]
+	}
+	if cache == nil {
+		return nil, false
+	}
+	if resp, ok := cache.since[version]; ok {
+		return resp, cache.version == version
+	}
+	return cache.full, false
+}
+
+func (t *flashNodeTopology) updateClientResponse() map[string]*flashGroupClientCache {
+	t.clientLock.Lock()
+	defer t.clientLock.Unlock()
+	views := t.getFlashGroupViews()
+	oldCaches := t.clientCache.Load().(map[string]*flashGroupClientCache)
+	lastVersion := t.clientLastVersion
+	caches := make(map[string]*flashGroupClientCache, len(views))
+	versions := make(map[string][]*flashGroupClientVersion, len(views))
+	for audience, fgv := range views {
+		cache := t.updateClientView(audience, fgv, oldCaches[audience])
+		if cache == nil {
+			return nil
+		}
+		caches[audience] = cache
+		versions[audience] = t.clientVersions[audience]
+	}
+	// the versions of the volumes no longer bound are dropped
+	t.clientVersions = versions
+	t.clientCache.Store(caches)
+	if t.clientLastVersion != lastVersion {
+		t.notifyClientChange()
 	}
-	return t.updateClientResponse()
+	return caches
 }
 
-func (t *flashNodeTopology) updateClientResponse() []byte {
-	cache, err := json.Marshal(newSuccessHTTPReply(t.getFlashGroupView()))
+// updateClientView updates the client view of the audience, the caller should hold clientLock.
+func (t *flashNodeTopology) updateClientView(audience string, fgv *proto.FlashGroupView, cache *flashGroupClientCache) *flashGroupClientCache {
+	groups := make(map[uint64]*proto.FlashGroupInfo, len(fgv.FlashGroups))
+	for _, fg := range fgv.FlashGroups {
+		groups[fg.ID] = fg
+	}
+	clientVersions := t.clientVersions[audience]
+	if n := len(clientVersions); n > 0 && cache != nil && reflect.DeepEqual(clientVersions[n-1].groups, groups) {
+		return cache
+	}
+
+	version := uint64(time.Now().UnixNano())
+	if version <= t.clientLastVersion {
+		version = t.clientLastVersion + 1
+	}
+	t.clientLastVersion = version
+	clientVersions = append(clientVersions, &flashGroupClientVersion{version: version, groups: groups})
+	if len(clientVersions) > flashGroupClientVersionsKeep {
+		clientVersions = clientVersions[len(clientVersions)-flashGroupClientVersionsKeep:]
+	}
+	if t.clientVersions == nil {
+		t.clientVersions = make(map[string][]*flashGroupClientVersion)
+	}
+	t.clientVersions[audience] = clientVersions
+
+	fgv.Version = version
+	full, err := json.Marshal(newSuccessHTTPReply(fgv))
 	if err != nil {
-		log.LogError("action[updateClientResponse] json marshal", err)
+		log.LogError("action[updateClientView] json marshal", err)
 		return nil
 	}
-	t.clientCache.Store(cache)
-	return cache[:]
+	cache = &flashGroupClientCache{version: version, full: full, since: make(map[uint64][]byte, len(clientVersions))}
+	for _, old := range clientVersions {
+		delta := &proto.FlashGroupView{Enable: true, Version: version}
+		if old.version == version {
+			delta.NotModified = true
+		} else {
+			delta.Delta = true
+			for id, fg := range groups {
+				if !reflect.DeepEqual(old.groups[id], fg) {
+					delta.FlashGroups = append(delta.FlashGroups, fg)
+				}
+			}
+			for id := range old.groups {
+				if _, ok := groups[id]; !ok {
+					delta.Removed = append(delta.Removed, id)
+				}
+			}
+		}
+		resp, err := json.Marshal(newSuccessHTTPReply(delta))
+		if err != nil {
+			log.LogError("action[updateClientView] json marshal", err)
+			continue
+		}
+		cache.since[old.version] = resp
+	}
+	log.LogInfof("action[updateClientView] audience(%v) version(%v) flashGroups(%v)", audience, version, len(groups))
+	return cache
 }
 
 func (c *Cluster) loadFlashNodes() (err error) {
@@ -396,6 +610,7 @@ func (c *Cluster) loadFlashNodes() (err error) {
 		flashNode.ID = fnv.ID
 		// load later in loadFlashTopology
 		flashNode.FlashGroupID = fnv.FlashGroupID
+		flashNode.ReadOnly = fnv.ReadOnly
 
 		_, err = c.flashNodeTopo.getZone(flashNode.ZoneName)
 		if err != nil {
@@ -293,6 +293,7 @@ func (m *ClusterService) getTopology(ctx context.Context, args struct{}) (*proto
 		cv.Status = zone.getStatusToString()
 		cv.DataNodesetSelector = zone.GetDataNodesetSelector()
 		cv.MetaNodesetSelector = zone.GetMetaNodesetSelector()
+		cv.Isolation = proto.ZoneIsolationString(zone.getIsolation())
 		tv.Zones = append(tv.Zones, cv)
 		nsc := zone.getAllNodeSet()
 		for _, ns := range nsc {
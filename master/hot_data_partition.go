@@ -0,0 +1,315 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A read only data partition read more than HotDpReadQps is given read replicas: extra copies on other
+// data nodes, out of its raft group, which serve the follower reads as its hosts do. They are removed
+// when the reads cool down, and as soon as a write applied to the partition made them stale.
+
+const (
+	defaultHotDpReadReplicas = 2
+	hotDpCheckInterval       = time.Minute
+	// the reads under half the threshold for so long remove a read replica
+	hotDpCoolDownTime = 10 * 60
+	// the time a read replica is given to copy the partition
+	readReplicaSyncTimeout = 30 * 60
+	// a synced read replica not ready for so long went stale, not just restarted
+	readReplicaStaleTime = 2 * 60
+	// the time a partition gets no new read replica after one went stale
+	readReplicaStaleBackoff = 10 * 60
+)
+
+// readRate turns the reads a data node counted since its start into a rate
+// between two of its heartbeats.
+type readRate struct {
+	ops  uint64
+	time int64
+}
+
+func (r *readRate) update(ops uint64, now int64) (qps uint64, ok bool) {
+	if r.time > 0 && now > r.time && ops >= r.ops {
+		qps = (ops - r.ops) / uint64(now-r.time)
+		ok = true
+	}
+	r.ops, r.time = ops, now
+	return
+}
+
+// DataReadReplica is a read replica of a hot data partition.
+type DataReadReplica struct {
+	proto.DataReadReplica
+	synced        bool // it was ready once, so not being ready means stale
+	notReadySince int64
+	reads         readRate
+}
+
+func newDataReadReplica(addr string, createTime int64) *DataReadReplica {
+	rr := new(DataReadReplica)
+	rr.Addr = addr
+	rr.CreateTime = createTime
+	return rr
+}
+
+func (c *Cluster) getHotDpReadQps() uint64 {
+	return atomic.LoadUint64(&c.cfg.HotDpReadQps)
+}
+
+func (c *Cluster) getHotDpReadReplicas() uint64 {
+	if val := atomic.LoadUint64(&c.cfg.HotDpReadReplicas); val > 0 {
+		return val
+	}
+	return defaultHotDpReadReplicas
+}
+
+func (c *Cluster) setHotDpReadQps(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.HotDpReadQps)
+	atomic.StoreUint64(&c.cfg.HotDpReadQps, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setHotDpReadQps] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.HotDpReadQps, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+func (c *Cluster) setHotDpReadReplicas(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.HotDpReadReplicas)
+	atomic.StoreUint64(&c.cfg.HotDpReadReplicas, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setHotDpReadReplicas] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.HotDpReadReplicas, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+func (partition *DataPartition) getReadReplica(addr string) *DataReadReplica {
+	for _, rr := range partition.ReadReplicas {
+		if rr.Addr == addr {
+			return rr
+		}
+	}
+	return nil
+}
+
+// readQps sums the reads of the replicas and of the read replicas, under the lock.
+func (partition *DataPartition) readQps() (qps uint64) {
+	for _, replica := range partition.Replicas {
+		qps += replica.ReadQps
+	}
+	for _, rr := range partition.ReadReplicas {
+		qps += rr.ReadQps
+	}
+	return
+}
+
+// readyReadReplicas are the read replicas advertised to the clients, under the lock.
+func (partition *DataPartition) readyReadReplicas() (addrs []string) {
+	for _, rr := range partition.ReadReplicas {
+		if rr.Ready {
+			addrs = append(addrs, rr.Addr)
+		}
+	}
+	return
+}
+
+func (partition *DataPartition) updateReadReplicaMetric(vr *proto.DataPartitionReport, dataNode *DataNode, c *Cluster) {
+	partition.Lock()
+	rr := partition.getReadReplica(dataNode.Addr)
+	if rr == nil {
+		partition.Unlock()
+		// left by a removal the data node missed
+		log.LogWarnf("action[updateReadReplicaMetric] dp[%v] unknown read replica on %v, delete it",
+			partition.PartitionID, dataNode.Addr)
+		dataNode.TaskManager.AddTask(partition.createTaskToDeleteDataPartition(dataNode.Addr, false))
+		return
+	}
+	defer partition.Unlock()
+	now := time.Now().Unix()
+	rr.ReportTime = now
+	if qps, ok := rr.reads.update(vr.ReadOps, now); ok {
+		rr.ReadQps = qps
+	}
+	rr.Ready = vr.ReadReplicaReady
+	if rr.Ready {
+		rr.synced = true
+		rr.notReadySince = 0
+	} else if rr.notReadySince == 0 {
+		rr.notReadySince = now
+	}
+}
+
+// checkReadReplicas picks the read replicas to remove and tells whether the
+// partition needs one more, under the lock.
+func (partition *DataPartition) checkReadReplicas(threshold, maxReplicas uint64, now int64,
+	isActive func(addr string) bool,
+) (removed []*DataReadReplica, needMore bool) {
+	hot := threshold > 0 && partition.Status == proto.ReadOnly && !partition.IsDiscard
+	qps := partition.readQps()
+	kept := make([]*DataReadReplica, 0, len(partition.ReadReplicas))
+	for _, rr := range partition.ReadReplicas {
+		reason := ""
+		switch {
+		case !hot:
+			reason = "partition not read only or hot partitions disabled"
+		case rr.synced && !rr.Ready && now-rr.notReadySince > readReplicaStaleTime:
+			reason = "stale"
+			partition.readReplicaBackoff = now + readReplicaStaleBackoff
+		case !rr.synced && now-rr.CreateTime > readReplicaSyncTimeout:
+			reason = "not synced in time"
+		case !isActive(rr.Addr):
+			reason = "data node inactive"
+		}
+		if reason != "" {
+			log.LogWarnf("action[checkReadReplicas] dp[%v] remove read replica %v: %v",
+				partition.PartitionID, rr.Addr, reason)
+			removed = append(removed, rr)
+			continue
+		}
+		kept = append(kept, rr)
+	}
+
+	if qps >= threshold/2 || len(kept) == 0 {
+		partition.coolSince = 0
+	} else if partition.coolSince == 0 {
+		partition.coolSince = now
+	} else if now-partition.coolSince >= hotDpCoolDownTime {
+		rr := kept[len(kept)-1]
+		log.LogInfof("action[checkReadReplicas] dp[%v] read qps %v cooled down, remove read replica %v",
+			partition.PartitionID, qps, rr.Addr)
+		removed = append(removed, rr)
+		kept = kept[:len(kept)-1]
+		partition.coolSince = now
+	}
+	partition.ReadReplicas = kept
+
+	needMore = hot && qps >= threshold && uint64(len(kept)) < maxReplicas && now >= partition.readReplicaBackoff
+	return
+}
+
+func (c *Cluster) scheduleToCheckHotDataPartitions() {
+	c.runTask(&cTask{
+		tickTime: hotDpCheckInterval,
+		name:     "scheduleToCheckHotDataPartitions",
+		function: func() (fin bool) {
+			if c.partition != nil && c.partition.IsRaftLeader() {
+				c.checkHotDataPartitions()
+			}
+			return
+		},
+	})
+}
+
+func (c *Cluster) checkHotDataPartitions() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.LogWarnf("checkHotDataPartitions occurred panic,err[%v]", r)
+			WarnBySpecialKey(fmt.Sprintf("%v_%v_scheduling_job_panic", c.Name, ModuleName),
+				"checkHotDataPartitions occurred panic")
+		}
+	}()
+	threshold := c.getHotDpReadQps()
+	maxReplicas := c.getHotDpReadReplicas()
+	isActive := func(addr string) bool {
+		dataNode, err := c.dataNode(addr)
+		return err == nil && dataNode.isActive
+	}
+	for _, vol := range c.allVols() {
+		for _, dp := range vol.dataPartitions.clonePartitions() {
+			dp.Lock()
+			if threshold == 0 && len(dp.ReadReplicas) == 0 {
+				dp.Unlock()
+				continue
+			}
+			removed, needMore := dp.checkReadReplicas(threshold, maxReplicas, time.Now().Unix(), isActive)
+			if len(removed) > 0 {
+				if err := c.syncUpdateDataPartition(dp); err != nil {
+					log.LogErrorf("action[checkHotDataPartitions] dp[%v] persist read replicas failed: %v", dp.PartitionID, err)
+				}
+			}
+			dp.Unlock()
+			for _, rr := range removed {
+				c.deleteReadReplica(dp, rr.Addr)
+			}
+			if needMore && vol.Status == proto.VolStatusNormal {
+				if err := c.addReadReplica(vol, dp); err != nil {
+					log.LogWarnf("action[checkHotDataPartitions] dp[%v] add read replica failed: %v", dp.PartitionID, err)
+				}
+			}
+		}
+	}
+}
+
+// addReadReplica creates a read replica of the partition on a data node holding none of its copies.
+func (c *Cluster) addReadReplica(vol *Vol, dp *DataPartition) (err error) {
+	dp.RLock()
+	excludeHosts := append(c.placementExcludedHosts(vol, TypeDataPartition), dp.Hosts...)
+	for _, rr := range dp.ReadReplicas {
+		excludeHosts = append(excludeHosts, rr.Addr)
+	}
+	hosts := append([]string(nil), dp.Hosts...)
+	dp.RUnlock()
+
+	targetHosts, _, err := c.getHostFromNormalZone(TypeDataPartition, vol.getPlacementExclusion().Zones, nil,
+		excludeHosts, 1, 1, "", dp.MediaType)
+	if err != nil {
+		return
+	}
+	addr := targetHosts[0]
+	dataNode, err := c.dataNode(addr)
+	if err != nil {
+		return
+	}
+
+	// known before it's created so that its reports are not taken for a leftover
+	dp.Lock()
+	dp.ReadReplicas = append(dp.ReadReplicas, newDataReadReplica(addr, time.Now().Unix()))
+	if err = c.syncUpdateDataPartition(dp); err != nil {
+		dp.ReadReplicas = dp.ReadReplicas[:len(dp.ReadReplicas)-1]
+		dp.Unlock()
+		return
+	}
+	dp.Unlock()
+
+	task := dp.createTaskToCreateDataPartition(addr, vol.dataPartitionSize, nil, hosts,
+		proto.NormalCreateDataPartition, dp.PartitionType, dataNode.getDecommissionedDisks())
+	task.Request.(*proto.CreateDataPartitionRequest).ReadReplica = true
+	if _, err = dataNode.TaskManager.syncSendAdminTask(task); err != nil {
+		// removed on the next check, as it doesn't get synced
+		return
+	}
+	log.LogInfof("action[addReadReplica] dp[%v] read qps over %v, read replica created on %v",
+		dp.PartitionID, c.getHotDpReadQps(), addr)
+	return
+}
+
+func (c *Cluster) deleteReadReplica(dp *DataPartition, addr string) {
+	dataNode, err := c.dataNode(addr)
+	if err != nil {
+		return
+	}
+	dataNode.TaskManager.AddTask(dp.createTaskToDeleteDataPartition(addr, false))
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRate(t *testing.T) {
+	r := &readRate{}
+	_, ok := r.update(100, 1000)
+	require.False(t, ok)
+	qps, ok := r.update(400, 1010)
+	require.True(t, ok)
+	require.EqualValues(t, 30, qps)
+	// the data node restarted
+	_, ok = r.update(10, 1020)
+	require.False(t, ok)
+	qps, ok = r.update(110, 1030)
+	require.True(t, ok)
+	require.EqualValues(t, 10, qps)
+}
+
+func newHotDataPartition(qps uint64) *DataPartition {
+	dp := newDataPartition(1, 3, "vol", 1, proto.PartitionTypeNormal, proto.MediaType_SSD)
+	dp.Status = proto.ReadOnly
+	replica := &DataReplica{}
+	replica.Addr = "host1"
+	replica.ReadQps = qps
+	dp.Replicas = append(dp.Replicas, replica)
+	return dp
+}
+
+func TestCheckReadReplicas(t *testing.T) {
+	allActive := func(string) bool { return true }
+	now := int64(100000)
+
+	dp := newHotDataPartition(500)
+	removed, needMore := dp.checkReadReplicas(0, 2, now, allActive)
+	require.Empty(t, removed)
+	require.False(t, needMore)
+	removed, needMore = dp.checkReadReplicas(1000, 2, now, allActive)
+	require.Empty(t, removed)
+	require.False(t, needMore)
+	removed, needMore = dp.checkReadReplicas(400, 2, now, allActive)
+	require.Empty(t, removed)
+	require.True(t, needMore)
+
+	dp.ReadReplicas = []*DataReadReplica{newDataReadReplica("rr1", now), newDataReadReplica("rr2", now)}
+	_, needMore = dp.checkReadReplicas(400, 2, now, allActive)
+	require.False(t, needMore)
+
+	// a write made rr1 stale
+	dp.ReadReplicas[0].synced = true
+	dp.ReadReplicas[0].notReadySince = now
+	removed, _ = dp.checkReadReplicas(400, 2, now+60, allActive)
+	require.Empty(t, removed)
+	removed, needMore = dp.checkReadReplicas(400, 2, now+readReplicaStaleTime+1, allActive)
+	require.Len(t, removed, 1)
+	require.Equal(t, "rr1", removed[0].Addr)
+	require.False(t, needMore, "no new read replica right after one went stale")
+	_, needMore = dp.checkReadReplicas(400, 2, now+readReplicaStaleTime+readReplicaStaleBackoff+1, allActive)
+	require.True(t, needMore)
+
+	// never synced
+	removed, _ = dp.checkReadReplicas(400, 2, now+readReplicaSyncTimeout+1, allActive)
+	require.Len(t, removed, 1)
+	require.Empty(t, dp.ReadReplicas)
+
+	// the partition got writable again
+	dp.ReadReplicas = []*DataReadReplica{newDataReadReplica("rr1", now)}
+	dp.Status = proto.ReadWrite
+	removed, needMore = dp.checkReadReplicas(400, 2, now, allActive)
+	require.Len(t, removed, 1)
+	require.False(t, needMore)
+}
+
+func TestCheckReadReplicasCoolDown(t *testing.T) {
+	allActive := func(string) bool { return true }
+	now := int64(100000)
+	dp := newHotDataPartition(100)
+	dp.ReadReplicas = []*DataReadReplica{newDataReadReplica("rr1", now), newDataReadReplica("rr2", now)}
+	for _, rr := range dp.ReadReplicas {
+		rr.synced = true
+		rr.Ready = true
+	}
+	require.ElementsMatch(t, []string{"rr1", "rr2"}, dp.readyReadReplicas())
+
+	removed, _ := dp.checkReadReplicas(400, 2, now, allActive)
+	require.Empty(t, removed)
+	removed, _ = dp.checkReadReplicas(400, 2, now+hotDpCoolDownTime-1, allActive)
+	require.Empty(t, removed)
+	removed, _ = dp.checkReadReplicas(400, 2, now+hotDpCoolDownTime, allActive)
+	require.Len(t, removed, 1)
+	require.Equal(t, []string{"rr1"}, dp.readyReadReplicas())
+
+	// the reads come back
+	dp.Replicas[0].ReadQps = 300
+	removed, _ = dp.checkReadReplicas(400, 2, now+3*hotDpCoolDownTime, allActive)
+	require.Empty(t, removed)
+
+	removed, _ = dp.checkReadReplicas(400, 2, now+3*hotDpCoolDownTime, func(addr string) bool { return addr != "rr1" })
+	require.Len(t, removed, 1)
+	require.Empty(t, dp.ReadReplicas)
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A meta partition handling more than HotMpSplitQps ops a second, or using more than
+// HotMpSplitMemMB of the memory of its meta nodes, for hotMpSplitTime is split: the
+// inodes created from then on go to a new partition on the meta nodes picked as for
+// any new one, while the inodes it has stay. The partition of the last inode range is
+// split as by the inode usage, a partition in the middle of the range hands the rest of
+// its range over, if that is a step at least. A split partition is not split again for
+// hotMpSplitCoolDown as the inodes it keeps may keep it hot.
+
+const (
+	hotMpCheckInterval = time.Minute
+	// the seconds a partition stays over the thresholds before split
+	hotMpSplitTime = 5 * 60
+	// the seconds a split partition is not split again
+	hotMpSplitCoolDown = 60 * 60
+	// the share of a step left to the inodes created before the replicas get the split
+	hotMpSplitMarginRatio = 16
+)
+
+func (c *Cluster) getHotMpSplitQps() uint64 {
+	return atomic.LoadUint64(&c.cfg.HotMpSplitQps)
+}
+
+func (c *Cluster) getHotMpSplitMemMB() uint64 {
+	return atomic.LoadUint64(&c.cfg.HotMpSplitMemMB)
+}
+
+func (c *Cluster) setHotMpSplitQps(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.HotMpSplitQps)
+	atomic.StoreUint64(&c.cfg.HotMpSplitQps, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setHotMpSplitQps] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.HotMpSplitQps, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+func (c *Cluster) setHotMpSplitMemMB(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.HotMpSplitMemMB)
+	atomic.StoreUint64(&c.cfg.HotMpSplitMemMB, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setHotMpSplitMemMB] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.HotMpSplitMemMB, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+// hotStat returns the ops a second of the replicas and the most memory used by one
+// of them, under the lock.
+func (mp *MetaPartition) hotStat() (qps, memUsed uint64) {
+	for _, mr := range mp.Replicas {
+		qps += mr.Qps
+		if mr.MemUsed > memUsed {
+			memUsed = mr.MemUsed
+		}
+	}
+	return
+}
+
+// checkHot tells whether the partition has been hot for long enough to be split, under
+// the lock. A threshold of 0 is disabled.
+func (mp *MetaPartition) checkHot(qpsThreshold, memThreshold uint64, now int64) (split bool) {
+	qps, memUsed := mp.hotStat()
+	hot := (qpsThreshold > 0 && qps >= qpsThreshold) || (memThreshold > 0 && memUsed >= memThreshold)
+	if !hot {
+		mp.hotSince = 0
+		return
+	}
+	if mp.hotSince == 0 {
+		mp.hotSince = now
+	}
+	return now-mp.hotSince >= hotMpSplitTime && now-mp.hotSplitTime >= hotMpSplitCoolDown
+}
+
+// hotSplitStart returns where the partition is split for being hot, under the lock.
+func (mp *MetaPartition) hotSplitStart(step uint64, isRear bool) (at uint64, ok bool) {
+	at = mp.MaxInodeID + step/hotMpSplitMarginRatio
+	if at < mp.Start {
+		at = mp.Start + step/hotMpSplitMarginRatio
+	}
+	if isRear {
+		return at, true
+	}
+	return at, at < mp.End && mp.End-at >= step
+}
+
+func (c *Cluster) scheduleToCheckHotMetaPartitions() {
+	c.runTask(&cTask{
+		tickTime: hotMpCheckInterval,
+		name:     "scheduleToCheckHotMetaPartitions",
+		function: func() (fin bool) {
+			if c.partition != nil && c.partition.IsRaftLeader() {
+				c.checkHotMetaPartitions()
+			}
+			return
+		},
+	})
+}
+
+func (c *Cluster) checkHotMetaPartitions() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.LogWarnf("checkHotMetaPartitions occurred panic,err[%v]", r)
+			WarnBySpecialKey(fmt.Sprintf("%v_%v_scheduling_job_panic", c.Name, ModuleName),
+				"checkHotMetaPartitions occurred panic")
+		}
+	}()
+	qpsThreshold := c.getHotMpSplitQps()
+	memThreshold := c.getHotMpSplitMemMB() * util.MB
+	if (qpsThreshold == 0 && memThreshold == 0) || c.DisableAutoAllocate || c.cfg.DisableAutoCreate {
+		return
+	}
+	step := gConfig.MetaPartitionInodeIdStep
+	now := time.Now().Unix()
+	for _, vol := range c.allVols() {
+		if vol.status() == proto.VolStatusMarkDelete || vol.Forbidden {
+			continue
+		}
+		rearID := vol.maxMetaPartitionID()
+		for _, mp := range vol.cloneMetaPartitionMap() {
+			mp.Lock()
+			if !mp.checkHot(qpsThreshold, memThreshold, now) {
+				mp.Unlock()
+				continue
+			}
+			qps, memUsed := mp.hotStat()
+			at, ok := mp.hotSplitStart(step, mp.PartitionID == rearID)
+			// checked again once the cool down is over
+			mp.hotSplitTime = now
+			mp.Unlock()
+			if !ok {
+				log.LogInfof("action[checkHotMetaPartitions] vol[%v] mp[%v] qps[%v] mem[%v] hot, but too little inode range left to split",
+					vol.Name, mp.PartitionID, qps, memUsed)
+				continue
+			}
+			nextMp, err := vol.splitMetaPartitionAt(c, mp, at)
+			if err != nil {
+				Warn(c.Name, fmt.Sprintf("action[checkHotMetaPartitions] vol[%v] split hot mp[%v] at[%v] failed, err[%v]",
+					vol.Name, mp.PartitionID, at, err))
+				continue
+			}
+			log.LogWarnf("action[checkHotMetaPartitions] vol[%v] mp[%v] qps[%v] mem[%v] hot, split at[%v] into mp[%v]",
+				vol.Name, mp.PartitionID, qps, memUsed, at, nextMp.PartitionID)
+		}
+	}
+}
+
+// splitMetaPartitionAt splits the partition anywhere in the inode range, the inodes
+// from at+1 on go to a new partition.
+func (vol *Vol) splitMetaPartitionAt(c *Cluster, mp *MetaPartition, at uint64) (nextMp *MetaPartition, err error) {
+	if vol.Forbidden {
+		err = fmt.Errorf("volume %v is forbidden", vol.Name)
+		return
+	}
+
+	vol.createMpMutex.Lock()
+	defer vol.createMpMutex.Unlock()
+
+	if _, err = vol.metaPartition(mp.PartitionID); err != nil {
+		return
+	}
+	if nextMp, err = vol.doSplitMetaPartition(c, mp, at, gConfig.MetaPartitionInodeIdStep, false); err != nil {
+		return
+	}
+
+	vol.addMetaPartition(nextMp)
+	log.LogWarnf("action[splitMetaPartitionAt],next partition[%v],start[%v],end[%v]", nextMp.PartitionID, nextMp.Start, nextMp.End)
+	c.recordVolEvent(vol.Name, proto.VolEventAddMetaPartition, "mp[%v] inodes [%v, %v] hosts%v split from mp[%v] at %v",
+		nextMp.PartitionID, nextMp.Start, nextMp.End, nextMp.Hosts, mp.PartitionID, at)
+	return
+}
+
+func (m *Server) splitMetaPartitionAt(w http.ResponseWriter, r *http.Request) {
+	var (
+		volName common.String
+		id      common.Uint
+		at      common.Uint
+		vol     *Vol
+		mp      *MetaPartition
+		nextMp  *MetaPartition
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminSplitMetaPartitionAt))
+	defer func() {
+		doStatAndMetric(proto.AdminSplitMetaPartitionAt, metric, err, map[string]string{exporter.Vol: volName.V})
+		AuditLog(r, proto.AdminSplitMetaPartitionAt, fmt.Sprintf("split vol(%s) meta partition %d at %d", volName.V, id.V, at.V), err)
+	}()
+	if err = parseArgs(r, volName.Key(nameKey), id.Key(idKey), at.Key("at")); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if vol, err = m.cluster.getVol(volName.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
+		return
+	}
+	if vol.status() == proto.VolStatusMarkDelete {
+		err = fmt.Errorf("volume %v is marked delete", vol.Name)
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if mp, err = vol.metaPartition(id.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(proto.ErrMetaPartitionNotExists))
+		return
+	}
+	if nextMp, err = vol.splitMetaPartitionAt(m.cluster, mp, at.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	mp.Lock()
+	mp.hotSplitTime = time.Now().Unix()
+	mp.Unlock()
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("meta partition %v split at %v, inodes [%v, %v] go to meta partition %v",
+		mp.PartitionID, at.V, nextMp.Start, nextMp.End, nextMp.PartitionID)))
+}
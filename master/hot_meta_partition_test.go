@@ -0,0 +1,80 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHotMetaPartition(t *testing.T) {
+	mp := newMetaPartition(1, 1, defaultMaxMetaPartitionInodeID, 3, "vol", 1, 0)
+	mp.Replicas = []*MetaReplica{{Addr: "a", Qps: 600, MemUsed: 1 << 30}, {Addr: "b", Qps: 500, MemUsed: 2 << 30}}
+	qps, memUsed := mp.hotStat()
+	require.Equal(t, uint64(1100), qps)
+	require.Equal(t, uint64(2<<30), memUsed)
+
+	now := int64(100000)
+	require.False(t, mp.checkHot(0, 0, now))
+	require.Zero(t, mp.hotSince)
+	// hot by qps, split once it stays hot long enough
+	require.False(t, mp.checkHot(1000, 0, now))
+	require.False(t, mp.checkHot(1000, 0, now+hotMpSplitTime-1))
+	require.True(t, mp.checkHot(1000, 0, now+hotMpSplitTime))
+	// cooling down resets it
+	require.False(t, mp.checkHot(2000, 0, now+hotMpSplitTime))
+	require.Zero(t, mp.hotSince)
+	// hot by memory, not split again in the cool down after a split
+	mp.hotSplitTime = now
+	require.False(t, mp.checkHot(0, 1<<30, now))
+	require.False(t, mp.checkHot(0, 1<<30, now+hotMpSplitTime))
+	require.True(t, mp.checkHot(0, 1<<30, now+hotMpSplitCoolDown))
+}
+
+func TestHotMetaPartitionSplitStart(t *testing.T) {
+	step := uint64(1 << 24)
+	mp := newMetaPartition(1, 1, defaultMaxMetaPartitionInodeID, 3, "vol", 1, 0)
+	mp.MaxInodeID = 1000
+	at, ok := mp.hotSplitStart(step, true)
+	require.True(t, ok)
+	require.Equal(t, 1000+step/hotMpSplitMarginRatio, at)
+
+	// a partition in the middle of the range hands a step at least over
+	mp.End = 2 * step
+	at, ok = mp.hotSplitStart(step, false)
+	require.True(t, ok)
+	require.Less(t, at, mp.End)
+	mp.End = step
+	_, ok = mp.hotSplitStart(step, false)
+	require.False(t, ok)
+
+	// no inode created yet
+	mp = newMetaPartition(2, 4*step, defaultMaxMetaPartitionInodeID, 3, "vol", 1, 0)
+	at, _ = mp.hotSplitStart(step, true)
+	require.Equal(t, 4*step+step/hotMpSplitMarginRatio, at)
+}
+
+func TestMaxMetaPartitionIDOfSplitVol(t *testing.T) {
+	vol := newVol(volValue{ID: 1, Name: "splitVol", Owner: "splitVol", ReplicaNum: 3, DpReplicaNum: 3})
+	require.Zero(t, vol.maxMetaPartitionID())
+	vol.MetaPartitions[1] = newMetaPartition(1, 1, 100, 3, "vol", 1, 0)
+	vol.MetaPartitions[2] = newMetaPartition(2, 101, defaultMaxMetaPartitionInodeID, 3, "vol", 1, 0)
+	require.Equal(t, uint64(2), vol.maxMetaPartitionID())
+	// mp 1 split in the middle, the last range stays with mp 2
+	vol.MetaPartitions[1].End = 50
+	vol.MetaPartitions[3] = newMetaPartition(3, 51, 100, 3, "vol", 1, 0)
+	require.Equal(t, uint64(2), vol.maxMetaPartitionID())
+}
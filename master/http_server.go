@@ -29,6 +29,7 @@ import (
 	"github.com/samsarahq/thunder/graphql/introspection"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auth"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
@@ -38,6 +39,12 @@ func (m *Server) startHTTPService(modulename string, cfg *config.Config) {
 	router := mux.NewRouter().SkipClean(true)
 	m.registerAPIRoutes(router)
 	m.registerAPIMiddleware(router)
+	if m.adminSigV4 {
+		m.registerSigV4Middleware(router)
+	}
+	if m.adminRBAC {
+		m.registerRBACMiddleware(router)
+	}
 	if m.cluster.authenticate {
 		m.registerAuthenticationMiddleware(router)
 	}
@@ -69,7 +76,7 @@ func (m *Server) isClientPartitionsReq(r *http.Request) bool {
 }
 
 func (m *Server) isFollowerRead(r *http.Request) (followerRead bool) {
-	if r.URL.Path == proto.AdminChangeMasterLeader || r.URL.Path == "/metrics" {
+	if r.URL.Path == proto.AdminChangeMasterLeader || r.URL.Path == "/metrics" || isStandbyAPI(r.URL.Path) {
 		return true
 	}
 
@@ -78,7 +85,8 @@ func (m *Server) isFollowerRead(r *http.Request) (followerRead bool) {
 	}
 
 	followerRead = false
-	if r.URL.Path == proto.ClientDataPartitions && !m.partition.IsRaftLeader() {
+	// the followers only keep the whole views of the data partitions
+	if r.URL.Path == proto.ClientDataPartitions && !m.partition.IsRaftLeader() && !isListRequest(r) {
 		if volName, err := parseAndExtractName(r); err == nil {
 			log.LogInfof("action[interceptor] followerRead vol[%v]", volName)
 			if followerRead = m.cluster.followerReadManager.IsVolViewReady(volName); followerRead {
@@ -98,7 +106,13 @@ func (m *Server) registerAPIMiddleware(route *mux.Router) {
 	var interceptor mux.MiddlewareFunc = func(next http.Handler) http.Handler {
 		return http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
-				log.LogDebugf("action[interceptor] request, method[%v] path[%v] query[%v]", r.Method, r.URL.Path, r.URL.Query())
+				reqID := setRequestID(w, r)
+				log.LogDebugf("action[interceptor] request, requestID[%v] method[%v] path[%v] query[%v]", reqID, r.Method, r.URL.Path, r.URL.Query())
+
+				if m.fenced.Load() && !isStandbyAPI(r.URL.Path) {
+					http.Error(w, errMasterFenced.Error(), http.StatusServiceUnavailable)
+					return
+				}
 
 				if m.partition.IsRaftLeader() {
 					if err := m.cluster.apiLimiter.Wait(r.URL.Path); err != nil {
@@ -118,8 +132,8 @@ func (m *Server) registerAPIMiddleware(route *mux.Router) {
 					}
 				}
 
-				log.LogInfof("action[interceptor] request, remote[%v] method[%v] path[%v] query[%v]",
-					r.RemoteAddr, r.Method, r.URL.Path, r.URL.Query())
+				log.LogInfof("action[interceptor] request, requestID[%v] remote[%v] method[%v] path[%v] query[%v]",
+					reqID, r.RemoteAddr, r.Method, r.URL.Path, r.URL.Query())
 				if mux.CurrentRoute(r).GetName() == proto.AdminGetIP {
 					next.ServeHTTP(w, r)
 					return
@@ -136,7 +150,7 @@ func (m *Server) registerAPIMiddleware(route *mux.Router) {
 					http.Error(w, m.leaderInfo.addr, http.StatusBadRequest)
 					return
 				} else if m.leaderInfo.addr != "" {
-					if m.isClientPartitionsReq(r) && m.cluster.cfg.EnableFollowerCache {
+					if m.isClientPartitionsReq(r) && !isListRequest(r) && m.cluster.cfg.EnableFollowerCache {
 						log.LogErrorf("action[interceptor] request, method[%v] path[%v] query[%v] status [%v]", r.Method, r.URL.Path, r.URL.Query(), isFollowerRead)
 						http.Error(w, m.leaderInfo.addr, http.StatusBadRequest)
 						return
@@ -237,7 +251,7 @@ func (m *Server) registerAuthenticationMiddleware(router *mux.Router) {
 				split := strings.Split(r.RequestURI, "?")
 				uriPath := split[0]
 				msgType, match := AuthenticationUri2MsgTypeMap[uriPath]
-				if match {
+				if match && sigV4User(r) == "" {
 					if err := m.cluster.parseAndCheckClientIDKey(r, msgType); err != nil {
 						log.LogInfof("action[AuthenticationInterceptor] parseAndCheckClientKey failed, RequestURI[%v], err[%v]",
 							r.RequestURI, err)
@@ -251,6 +265,85 @@ func (m *Server) registerAuthenticationMiddleware(router *mux.Router) {
 	router.Use(authenticationInterceptor)
 }
 
+type sigV4UserKey struct{}
+
+type sigV4Signer struct {
+	userID string
+	role   proto.AdminRole
+}
+
+// sigV4User returns the admin user who signed the request, the signed requests
+// do not need the clientIDKey of authnode.
+func sigV4User(r *http.Request) string {
+	if signer, ok := r.Context().Value(sigV4UserKey{}).(*sigV4Signer); ok {
+		return signer.userID
+	}
+	return ""
+}
+
+// sigV4Role returns the admin role of the user who signed the request.
+func sigV4Role(r *http.Request) proto.AdminRole {
+	if signer, ok := r.Context().Value(sigV4UserKey{}).(*sigV4Signer); ok {
+		return signer.role
+	}
+	return proto.AdminRoleNone
+}
+
+// registerSigV4Middleware checks the requests signed with the access key of a
+// cluster admin, or of any user with an admin role when the rbac is on. The
+// requests which are not signed are left to the other authentication, so the
+// signatures can be adopted by the tools one by one.
+func (m *Server) registerSigV4Middleware(router *mux.Router) {
+	sigV4Interceptor := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if !auth.IsSigV4Signed(r) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				signer, err := m.checkSigV4(r)
+				if err != nil {
+					log.LogWarnf("action[sigV4Interceptor] remote[%v] path[%v] err[%v]", r.RemoteAddr, r.URL.Path, err)
+					sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeNoPermission, Msg: err.Error()})
+					return
+				}
+				log.LogInfof("action[sigV4Interceptor] remote[%v] path[%v] signed by user[%v] role[%v]",
+					r.RemoteAddr, r.URL.Path, signer.userID, signer.role)
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), sigV4UserKey{}, signer)))
+			})
+	}
+	router.Use(sigV4Interceptor)
+}
+
+func (m *Server) checkSigV4(r *http.Request) (signer *sigV4Signer, err error) {
+	var sig *auth.SigV4Authorization
+	if sig, err = auth.ParseSigV4Authorization(r.Header.Get("Authorization")); err != nil {
+		return
+	}
+	// a signature made for another service with the same key, like the s3 of
+	// objectnode, must not be accepted by master
+	if sig.Region != auth.SigV4Region || sig.Service != auth.SigV4ServiceMaster {
+		return nil, auth.ErrSigV4Scope
+	}
+	var userInfo *proto.UserInfo
+	if userInfo, err = m.user.getKeyInfo(sig.AccessKey); err != nil {
+		return
+	}
+	// without the rbac a signed request may call any api
+	role := userInfo.EffectiveAdminRole()
+	if !m.adminRBAC && role != proto.AdminRoleClusterAdmin {
+		return nil, fmt.Errorf("user[%v] is %v of role %v, only the cluster admins can sign admin requests",
+			userInfo.UserID, userInfo.UserType, role)
+	}
+	if m.adminRBAC && !role.Covers(proto.AdminRoleViewer) {
+		return nil, fmt.Errorf("user[%v] has no admin role to sign admin requests", userInfo.UserID)
+	}
+	if err = auth.VerifySigV4(r, sig, userInfo.SecretKey, time.Now()); err != nil {
+		return
+	}
+	return &sigV4Signer{userID: userInfo.UserID, role: role}, nil
+}
+
 func (m *Server) registerAPIRoutes(router *mux.Router) {
 	// graphql api for cluster
 	cs := &ClusterService{user: m.user, cluster: m.cluster, conf: m.config, leaderInfo: m.leaderInfo}
@@ -307,6 +400,84 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminVolForbidden).
 		HandlerFunc(m.forbidVolume)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolPublish).
+		HandlerFunc(m.publishVolume)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolSetInterop).
+		HandlerFunc(m.setVolInterop)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolSetPlacementExclusion).
+		HandlerFunc(m.setVolPlacementExclusion)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolSetPlacementPolicy).
+		HandlerFunc(m.setVolPlacementPolicy)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolSetSLO).
+		HandlerFunc(m.setVolSLO)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminVolSLO).
+		HandlerFunc(m.getVolSLO)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminVolReclaim).
+		HandlerFunc(m.getVolReclaim)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolReclaimBoost).
+		HandlerFunc(m.boostVolReclaim)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolForkAdd).
+		HandlerFunc(m.addVolFork)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolForkRemove).
+		HandlerFunc(m.removeVolFork)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminVolTimeline).
+		HandlerFunc(m.getVolTimeline)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminVolFenceAcquire).
+		HandlerFunc(m.acquireVolFence)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminVolFenceList).
+		HandlerFunc(m.listVolFences)
+	router.NewRoute().Methods(http.MethodPost).
+		Path(proto.AdminSLOReport).
+		HandlerFunc(m.reportSLO)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminAuditCampaignCreate).
+		HandlerFunc(m.createAuditCampaign)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminAuditCampaignGet).
+		HandlerFunc(m.getAuditCampaign)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminAuditCampaignList).
+		HandlerFunc(m.listAuditCampaigns)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminAuditCampaignCancel).
+		HandlerFunc(m.cancelAuditCampaign)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminAuditCampaignReport).
+		HandlerFunc(m.getAuditCampaignReport)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminMountProfileSet).
+		HandlerFunc(m.setMountProfile)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminMountProfileGet).
+		HandlerFunc(m.getMountProfile)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminMountProfileList).
+		HandlerFunc(m.listMountProfiles)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminMountProfileDelete).
+		HandlerFunc(m.deleteMountProfile)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminClientEvict).
+		HandlerFunc(m.evictClient)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminClientEvictRemove).
+		HandlerFunc(m.removeClientEviction)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminClientEvictList).
+		HandlerFunc(m.listClientEvictions)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminVolEnableAuditLog).
 		HandlerFunc(m.setEnableAuditLogForVolume)
@@ -322,7 +493,15 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.RaftStatus).
 		HandlerFunc(m.getRaftStatus)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminMasterStandbyStatus).HandlerFunc(m.getMasterStandbyStatus)
+	router.NewRoute().Methods(http.MethodPost).Path(proto.AdminMasterStandbyFence).HandlerFunc(m.fenceMaster)
+	router.NewRoute().Methods(http.MethodPost).Path(proto.AdminMasterStandbyPromote).HandlerFunc(m.promoteMasterStandby)
 	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminClusterStat).HandlerFunc(m.clusterStat)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminClusterFeatures).HandlerFunc(m.clusterFeatures)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminClusterClockSkew).HandlerFunc(m.getClockSkew)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminClusterEvents).HandlerFunc(m.getClusterEvents)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminSummary).HandlerFunc(m.getSummary)
+	router.NewRoute().Methods(http.MethodPost).Path(proto.AdminPlacementSimulate).HandlerFunc(m.simulatePlacement)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminSetCheckDataReplicasEnable).
 		HandlerFunc(m.setCheckDataReplicasEnable)
@@ -353,6 +532,9 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.AdminQueryDecommissionLimit).
 		HandlerFunc(m.queryDecommissionLimit)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminQueryDecommissionProgress).
+		HandlerFunc(m.queryDecommissionProgress)
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.AdminQueryDiskDecommissionInfoStat).
 		HandlerFunc(m.queryDiskDecommissionInfoStat)
@@ -368,6 +550,12 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.AdminGetFileStats).
 		HandlerFunc(m.getFileStats)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminSetZoneCost).
+		HandlerFunc(m.setZoneCost)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminGetZoneCost).
+		HandlerFunc(m.getZoneCost)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminSetClusterUuidEnable).
 		HandlerFunc(m.setClusterUuidEnable)
@@ -431,6 +619,9 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.ClientVolStat).
 		HandlerFunc(m.getVolStatInfo)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.ClientZoneCost).
+		HandlerFunc(m.clientZoneCost)
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.GetAllClients).
 		HandlerFunc(m.getAllClients)
@@ -563,6 +754,9 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminCreateMetaPartition).
 		HandlerFunc(m.createMetaPartition)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminSplitMetaPartitionAt).
+		HandlerFunc(m.splitMetaPartitionAt)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminAddMetaReplica).
 		HandlerFunc(m.addMetaReplica)
@@ -628,6 +822,9 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.ClientDiskDataPartitions).
 		HandlerFunc(m.getDiskDataPartitions)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.ClientForkPartitions).
+		HandlerFunc(m.getForkPartitions)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.AdminResetDataPartitionDecommissionStatus).
 		HandlerFunc(m.resetDataPartitionDecommissionStatus)
@@ -727,6 +924,9 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.GetDataNode).
 		HandlerFunc(m.getDataNode)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.GetDataNodeLoadProgress).
+		HandlerFunc(m.getDataNodeLoadProgress)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
 		Path(proto.SetDpCntLimit).
 		HandlerFunc(m.setDpCntLimit)
@@ -879,6 +1079,33 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.GetAllZones).
 		HandlerFunc(m.listZone)
+	router.NewRoute().Methods(http.MethodPost).
+		Path(proto.AdminSetZoneIsolation).
+		HandlerFunc(m.setZoneIsolation)
+	router.NewRoute().Methods(http.MethodPost).
+		Path(proto.AdminBalanceLeaders).
+		HandlerFunc(m.balanceLeaders)
+	router.NewRoute().Methods(http.MethodPost).
+		Path(proto.AdminMaintenanceStart).
+		HandlerFunc(m.startMaintenance)
+	router.NewRoute().Methods(http.MethodPost).
+		Path(proto.AdminMaintenanceStop).
+		HandlerFunc(m.stopMaintenance)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminMaintenanceList).
+		HandlerFunc(m.listMaintenances)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminDataBalanceEnable).
+		HandlerFunc(m.enableDataBalance)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminDataBalancePause).
+		HandlerFunc(m.pauseDataBalance)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminDataBalanceZone).
+		HandlerFunc(m.setZoneBalance)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminDataBalanceStatus).
+		HandlerFunc(m.getDataBalanceStatus)
 	router.NewRoute().Methods(http.MethodGet).
 		Path(proto.GetAllNodeSets).
 		HandlerFunc(m.listNodeSets)
@@ -922,15 +1149,27 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	router.NewRoute().Methods(http.MethodDelete, http.MethodPost).
 		Path(proto.S3QoSDelete).
 		HandlerFunc(m.S3QosDelete)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.S3NodeHeartbeat).
+		HandlerFunc(m.s3NodeHeartbeat)
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.S3NodeList).
+		HandlerFunc(m.listS3Nodes)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.S3BucketChanged).
+		HandlerFunc(m.s3BucketChanged)
 
 	// APIs for FlashNode
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeAdd).HandlerFunc(m.addFlashNode)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeSet).HandlerFunc(m.setFlashNode)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeRemove).HandlerFunc(m.removeFlashNode)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeRemoveAllInactive).HandlerFunc(m.removeAllInactiveFlashNodes)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeOffline).HandlerFunc(m.offlineFlashNode)
 	router.NewRoute().Methods(http.MethodGet).Path(proto.FlashNodeGet).HandlerFunc(m.getFlashNode)
 	router.NewRoute().Methods(http.MethodGet).Path(proto.FlashNodeList).HandlerFunc(m.listFlashNodes)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminFlashNodeStats).HandlerFunc(m.getFlashNodeStats)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeSetReadIOLimits).HandlerFunc(m.setFlashNodeReadIOLimits)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeSetReadOnly).HandlerFunc(m.setFlashNodeReadOnly)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.FlashNodeSetWriteIOLimits).HandlerFunc(m.setFlashNodeWriteIOLimits)
 
 	// APIs for FlashNode manual tasks
@@ -947,13 +1186,24 @@ func (m *Server) registerAPIRoutes(router *mux.Router) {
 	// APIs for FlashGroup
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupTurn).HandlerFunc(m.turnFlashGroup)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupCreate).HandlerFunc(m.createFlashGroup)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupBatchCreate).HandlerFunc(m.batchCreateFlashGroups)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupSet).HandlerFunc(m.setFlashGroup)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupRemove).HandlerFunc(m.removeFlashGroup)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupNodeAdd).HandlerFunc(m.flashGroupAddFlashNode)
 	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupNodeRemove).HandlerFunc(m.flashGroupRemoveFlashNode)
 	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminFlashGroupGet).HandlerFunc(m.getFlashGroup)
 	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminFlashGroupList).HandlerFunc(m.listFlashGroups)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupAutoScale).HandlerFunc(m.setFlashGroupAutoScale)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminFlashGroupScaleEvent).HandlerFunc(m.getFlashGroupScaleEvents)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminFlashGroupAudit).HandlerFunc(m.getFlashGroupAudit)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupAutoHeal).HandlerFunc(m.setFlashGroupAutoHeal)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupVolumes).HandlerFunc(m.setFlashGroupVolumes)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).Path(proto.AdminFlashGroupRebalance).HandlerFunc(m.rebalanceFlashGroupSlots)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.AdminFlashGroupCacheKeys).HandlerFunc(m.getFlashGroupCacheKeys)
+	router.NewRoute().Methods(http.MethodPost).Path(proto.AdminFlashGroupPurgeCache).HandlerFunc(m.purgeFlashGroupCache)
+	router.NewRoute().Methods(http.MethodPost).Path(proto.AdminFlashGroupMoveSlots).HandlerFunc(m.moveFlashGroupSlots)
 	router.NewRoute().Methods(http.MethodGet).Path(proto.ClientFlashGroups).HandlerFunc(m.clientFlashGroups)
+	router.NewRoute().Methods(http.MethodGet).Path(proto.ClientFlashCacheToken).HandlerFunc(m.getFlashCacheToken)
 }
 
 func (m *Server) registerHandler(router *mux.Router, model string, schema *graphql.Schema) {
@@ -1011,10 +1261,31 @@ func (m *Server) newReverseProxy() *httputil.ReverseProxy {
 			request.URL.Scheme = "http"
 			request.URL.Host = m.leaderInfo.addr
 		},
+		// the leader takes the request id forwarded, which the response has already
+		ModifyResponse: func(response *http.Response) error {
+			response.Header.Del(proto.HeaderRequestID)
+			return nil
+		},
 		Transport: tr,
 	}
 }
 
+// setRequestID honors the request id given by the client or generates one, and
+// returns it in the response, so that the logs and the audit of the request on
+// the follower and on the leader it is proxied to are found by it.
+func setRequestID(w http.ResponseWriter, r *http.Request) (reqID string) {
+	if reqID = r.Header.Get(proto.HeaderRequestID); !proto.ValidRequestID(reqID) {
+		reqID = proto.NewRequestID()
+		r.Header.Set(proto.HeaderRequestID, reqID)
+	}
+	w.Header().Set(proto.HeaderRequestID, reqID)
+	return
+}
+
+func getRequestID(r *http.Request) string {
+	return r.Header.Get(proto.HeaderRequestID)
+}
+
 func (m *Server) proxy(w http.ResponseWriter, r *http.Request) {
 	m.reverseProxy.ServeHTTP(w, r)
 }
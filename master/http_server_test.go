@@ -0,0 +1,159 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auth"
+)
+
+func TestAdminSigV4(t *testing.T) {
+	admin, err := server.user.createKey(&proto.UserCreateParam{ID: "sigv4admin", Type: proto.UserTypeAdmin})
+	require.NoError(t, err)
+	defer server.user.deleteKey(admin.UserID)
+
+	router := mux.NewRouter()
+	router.NewRoute().Path(proto.AdminGetCluster).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("user:" + sigV4User(r)))
+	})
+	server.registerSigV4Middleware(router)
+	sendScoped := func(ak, sk, region, service string) string {
+		r := httptest.NewRequest(http.MethodGet, hostAddr+proto.AdminGetCluster+"?name=test", nil)
+		if ak != "" {
+			auth.SignV4(r, nil, ak, sk, region, service, time.Now())
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Body.String()
+	}
+	send := func(ak, sk string) string {
+		return sendScoped(ak, sk, auth.SigV4Region, auth.SigV4ServiceMaster)
+	}
+
+	require.Equal(t, "user:", send("", ""))
+	require.Equal(t, "user:"+admin.UserID, send(admin.AccessKey, admin.SecretKey))
+	require.Contains(t, send(admin.AccessKey, "wrong"), auth.ErrSigV4SignatureWrong.Error())
+	require.Contains(t, send("unknown", "wrong"), proto.ErrAccessKeyNotExists.Error())
+	// the normal users can not sign admin requests
+	require.Contains(t, send(cfsUser.AccessKey, cfsUser.SecretKey), "only the cluster admins")
+	// a valid signature for another scope is not bound to master
+	require.Contains(t, sendScoped(admin.AccessKey, admin.SecretKey, auth.SigV4Region, "s3"), auth.ErrSigV4Scope.Error())
+	require.Contains(t, sendScoped(admin.AccessKey, admin.SecretKey, "us-east-1", auth.SigV4ServiceMaster), auth.ErrSigV4Scope.Error())
+}
+
+func TestAdminRBAC(t *testing.T) {
+	viewer, err := server.user.createKey(&proto.UserCreateParam{ID: "rbacviewer", Type: proto.UserTypeNormal,
+		AdminRole: proto.AdminRoleViewer})
+	require.NoError(t, err)
+	defer server.user.deleteKey(viewer.UserID)
+	volAdmin, err := server.user.createKey(&proto.UserCreateParam{ID: "rbacvoladmin", Type: proto.UserTypeAdmin,
+		AdminRole: proto.AdminRoleVolumeAdmin})
+	require.NoError(t, err)
+	defer server.user.deleteKey(volAdmin.UserID)
+	admin, err := server.user.createKey(&proto.UserCreateParam{ID: "rbacadmin", Type: proto.UserTypeAdmin})
+	require.NoError(t, err)
+	defer server.user.deleteKey(admin.UserID)
+	_, err = server.user.createKey(&proto.UserCreateParam{ID: "rbacinvalid", Type: proto.UserTypeNormal, AdminRole: "root"})
+	require.ErrorIs(t, err, proto.ErrInvalidAdminRole)
+
+	// the viewers can't sign without the rbac
+	router := mux.NewRouter()
+	router.NewRoute().Path(proto.AdminGetClusterMetaNodes).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server.registerSigV4Middleware(router)
+	r := httptest.NewRequest(http.MethodGet, hostAddr+proto.AdminGetClusterMetaNodes, nil)
+	auth.SignV4(r, nil, viewer.AccessKey, viewer.SecretKey, auth.SigV4Region, auth.SigV4ServiceMaster, time.Now())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Contains(t, w.Body.String(), "only the cluster admins")
+
+	server.adminRBAC = true
+	defer func() {
+		server.adminRBAC = false
+		server.rbacTrustedHosts = nil
+	}()
+	server.rbacTrustedHosts, err = parseTrustedHosts("10.0.0.0/8, 192.0.2.9")
+	require.NoError(t, err)
+	_, err = parseTrustedHosts("10.0.0")
+	require.Error(t, err)
+
+	router = mux.NewRouter()
+	for _, path := range []string{proto.ClientVol, proto.AdminGetClusterMetaNodes, proto.AdminCreateVol, proto.AdminSetNodeInfo} {
+		router.NewRoute().Path(path).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		})
+	}
+	router.NewRoute().Path(proto.UserGetAKInfo).HandlerFunc(server.getUserAKInfo)
+	server.registerSigV4Middleware(router)
+	server.registerRBACMiddleware(router)
+	send := func(path, remote, forwarded string, user *proto.UserInfo) string {
+		r := httptest.NewRequest(http.MethodGet, hostAddr+path, nil)
+		r.RemoteAddr = remote
+		if forwarded != "" {
+			r.Header.Set("X-Forwarded-For", forwarded)
+		}
+		if user != nil {
+			auth.SignV4(r, nil, user.AccessKey, user.SecretKey, auth.SigV4Region, auth.SigV4ServiceMaster, time.Now())
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Body.String()
+	}
+	untrusted := "192.0.2.1:1234"
+
+	require.Equal(t, "ok", send(proto.ClientVol, untrusted, "", nil))
+	require.Contains(t, send(proto.AdminGetClusterMetaNodes, untrusted, "", nil), "requires viewer")
+
+	require.Equal(t, "ok", send(proto.AdminGetClusterMetaNodes, untrusted, "", viewer))
+	require.Contains(t, send(proto.AdminCreateVol, untrusted, "", viewer), "requires volume-admin")
+
+	require.Equal(t, "ok", send(proto.AdminCreateVol, untrusted, "", volAdmin))
+	require.Contains(t, send(proto.AdminSetNodeInfo, untrusted, "", volAdmin), "requires cluster-admin")
+
+	// the admin users without a role are cluster admins
+	require.Equal(t, "ok", send(proto.AdminSetNodeInfo, untrusted, "", admin))
+
+	// the secret keys are only read by the cluster admins
+	akInfo := proto.UserGetAKInfo + "?ak=" + admin.AccessKey
+	for _, user := range []*proto.UserInfo{nil, viewer, volAdmin} {
+		reply := send(akInfo, untrusted, "", user)
+		require.Contains(t, reply, "requires cluster-admin")
+		require.NotContains(t, reply, admin.SecretKey)
+	}
+	require.Contains(t, send(akInfo, untrusted, "", admin), admin.SecretKey)
+	require.Contains(t, send(akInfo, "10.1.2.3:1234", "", nil), admin.SecretKey)
+
+	// the masters, the trusted hosts and what the masters forward for them
+	require.Equal(t, "ok", send(proto.AdminSetNodeInfo, "127.0.0.1:17010", "", nil))
+	require.Equal(t, "ok", send(proto.AdminSetNodeInfo, "10.1.2.3:1234", "", nil))
+	require.Equal(t, "ok", send(proto.AdminSetNodeInfo, "192.0.2.9:1234", "", nil))
+	require.Equal(t, "ok", send(proto.AdminSetNodeInfo, "127.0.0.1:17010", "192.0.2.1, 10.1.2.3", nil))
+	require.Contains(t, send(proto.AdminSetNodeInfo, "127.0.0.1:17010", "10.1.2.3, 192.0.2.1", nil), "requires cluster-admin")
+	require.Contains(t, send(proto.AdminSetNodeInfo, untrusted, "10.1.2.3", nil), "requires cluster-admin")
+
+	// revoke the role
+	_, err = server.user.updateKey(&proto.UserUpdateParam{UserID: viewer.UserID, AdminRole: proto.AdminRoleNone})
+	require.NoError(t, err)
+	require.Contains(t, send(proto.AdminGetClusterMetaNodes, untrusted, "", viewer), "has no admin role")
+}
@@ -0,0 +1,387 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The raft leaders of the partitions pile onto the nodes restarted first. The
+// leader balancer gives each node its share of the leaders, the sum over the
+// partitions it may lead of one out of the live replicas that may lead each of
+// them, by asking the replicas of the nodes under their share to take over the
+// leaders of the nodes over it. It runs every leaderBalanceInterval with at most
+// LeaderBalanceMaxTransfers transfers, 0 disables it, unless a maintenance is
+// on, and on demand.
+
+const (
+	leaderBalanceInterval     = 5 * time.Minute
+	leaderBalanceCooldown     = 30 * time.Minute // the leader of a partition moved is not moved again before
+	defaultLeaderBalanceLimit = 32
+	maxLeaderBalanceLimit     = 1024
+	leaderShareEpsilon        = 1e-6 // the shares are sums of fractions
+)
+
+type leaderBalanceKey struct {
+	kind string
+	id   uint64
+}
+
+type leaderBalancePartition struct {
+	leaderBalanceKey
+	leader   string
+	voters   []string // the live replicas that may lead, the leader included
+	transfer func(host string) error
+}
+
+type leaderBalancer struct {
+	running sync.Mutex
+	lock    sync.Mutex
+	moved   map[leaderBalanceKey]time.Time
+}
+
+func newLeaderBalancer() *leaderBalancer {
+	return &leaderBalancer{moved: make(map[leaderBalanceKey]time.Time)}
+}
+
+func (lb *leaderBalancer) movedOfLate(key leaderBalanceKey) bool {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	movedAt, ok := lb.moved[key]
+	return ok && time.Since(movedAt) < leaderBalanceCooldown
+}
+
+func (lb *leaderBalancer) recordMoved(key leaderBalanceKey) {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	for k, movedAt := range lb.moved {
+		if time.Since(movedAt) >= leaderBalanceCooldown {
+			delete(lb.moved, k)
+		}
+	}
+	lb.moved[key] = time.Now()
+}
+
+func (c *Cluster) getLeaderBalanceMaxTransfers() uint64 {
+	return atomic.LoadUint64(&c.cfg.LeaderBalanceMaxTransfers)
+}
+
+func (c *Cluster) setLeaderBalanceMaxTransfers(val uint64) (err error) {
+	oldVal := atomic.LoadUint64(&c.cfg.LeaderBalanceMaxTransfers)
+	atomic.StoreUint64(&c.cfg.LeaderBalanceMaxTransfers, val)
+	if err = c.syncPutCluster(); err != nil {
+		log.LogErrorf("action[setLeaderBalanceMaxTransfers] err[%v]", err)
+		atomic.StoreUint64(&c.cfg.LeaderBalanceMaxTransfers, oldVal)
+		err = proto.ErrPersistenceByRaft
+		return
+	}
+	return
+}
+
+// getLeaderBalancePartition returns nil if the partition is being decommissioned,
+// or has no leader or a single replica that may lead among the live replicas
+// out of the isolated zones.
+func (partition *DataPartition) getLeaderBalancePartition(timeOutSec int64) *leaderBalancePartition {
+	if partition.IsDecommissionRunning() || partition.IsDecommissionPrepare() {
+		return nil
+	}
+	partition.RLock()
+	defer partition.RUnlock()
+	if partition.IsDiscard {
+		return nil
+	}
+	p := &leaderBalancePartition{
+		leaderBalanceKey: leaderBalanceKey{kind: proto.LeaderBalanceDataPartition, id: partition.PartitionID},
+		transfer:         partition.tryToChangeLeaderByHost,
+	}
+	for _, replica := range partition.Replicas {
+		if !replica.isLive(partition.PartitionID, timeOutSec) || replica.dataNode.isZoneIsolated() {
+			continue
+		}
+		if replica.IsLeader {
+			p.leader = replica.Addr
+		}
+		p.voters = append(p.voters, replica.Addr)
+	}
+	if p.leader == "" || len(p.voters) < 2 {
+		return nil
+	}
+	return p
+}
+
+func (mp *MetaPartition) getLeaderBalancePartition(timeOutSec int64) *leaderBalancePartition {
+	mp.RLock()
+	defer mp.RUnlock()
+	if mp.IsRecover {
+		return nil
+	}
+	p := &leaderBalancePartition{
+		leaderBalanceKey: leaderBalanceKey{kind: proto.LeaderBalanceMetaPartition, id: mp.PartitionID},
+		transfer:         mp.tryToChangeLeaderByHost,
+	}
+	for _, mr := range mp.Replicas {
+		if !mr.isActive(timeOutSec) || mr.metaNode.isZoneIsolated() || mp.isWitness(mr.Addr) {
+			continue
+		}
+		if mr.IsLeader {
+			p.leader = mr.Addr
+		}
+		p.voters = append(p.voters, mr.Addr)
+	}
+	if p.leader == "" || len(p.voters) < 2 {
+		return nil
+	}
+	return p
+}
+
+func (c *Cluster) getLeaderBalancePartitions() (dps, mps []*leaderBalancePartition) {
+	dpTimeout, mpTimeout := c.getDataPartitionTimeoutSec(), c.getMetaPartitionTimeoutSec()
+	for _, vol := range c.copyVols() {
+		for _, dp := range vol.dataPartitions.clonePartitions() {
+			if p := dp.getLeaderBalancePartition(dpTimeout); p != nil {
+				dps = append(dps, p)
+			}
+		}
+		for _, mp := range vol.cloneMetaPartitionMap() {
+			if p := mp.getLeaderBalancePartition(mpTimeout); p != nil {
+				mps = append(mps, p)
+			}
+		}
+	}
+	sort.Slice(dps, func(i, j int) bool { return dps[i].id < dps[j].id })
+	sort.Slice(mps, func(i, j int) bool { return mps[i].id < mps[j].id })
+	return
+}
+
+func measureLeaders(partitions []*leaderBalancePartition) (nodes map[string]*proto.LeaderBalanceNode) {
+	nodes = make(map[string]*proto.LeaderBalanceNode)
+	getNode := func(addr string) *proto.LeaderBalanceNode {
+		node, ok := nodes[addr]
+		if !ok {
+			node = &proto.LeaderBalanceNode{Addr: addr}
+			nodes[addr] = node
+		}
+		return node
+	}
+	for _, p := range partitions {
+		for _, addr := range p.voters {
+			getNode(addr).Share += 1 / float64(len(p.voters))
+		}
+		getNode(p.leader).Leaders++
+	}
+	for _, node := range nodes {
+		node.Balanced = node.Leaders
+	}
+	return
+}
+
+type leaderBalanceTransfer struct {
+	partition *leaderBalancePartition
+	from, to  string
+}
+
+// planLeaderTransfers moves the leaders of the node the most over its share,
+// while it is at least one leader over it, each to the replica the most under
+// its share if the two are at least two leaders apart, then of the next node.
+// Each transfer lowers the spread of the leaders around the shares. The leaders
+// moved of late are not moved again to keep them from flapping.
+func planLeaderTransfers(partitions []*leaderBalancePartition, nodes map[string]*proto.LeaderBalanceNode, limit int,
+	skip func(key leaderBalanceKey) bool,
+) (transfers []*leaderBalanceTransfer) {
+	byLeader := make(map[string][]*leaderBalancePartition)
+	for _, p := range partitions {
+		if skip == nil || !skip(p.leaderBalanceKey) {
+			byLeader[p.leader] = append(byLeader[p.leader], p)
+		}
+	}
+	addrs := make([]string, 0, len(nodes))
+	for addr := range nodes {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	surplus := func(addr string) float64 {
+		return float64(nodes[addr].Balanced) - nodes[addr].Share
+	}
+	done := make(map[string]bool)
+	for len(transfers) < limit {
+		from := ""
+		for _, addr := range addrs {
+			if !done[addr] && surplus(addr) > 1-leaderShareEpsilon && (from == "" || surplus(addr) > surplus(from)) {
+				from = addr
+			}
+		}
+		if from == "" {
+			return
+		}
+		var (
+			best      int
+			to        string
+			surplusTo float64
+		)
+		for i, p := range byLeader[from] {
+			for _, addr := range p.voters {
+				if addr != from && (to == "" || surplus(addr) < surplusTo) {
+					best, to, surplusTo = i, addr, surplus(addr)
+				}
+			}
+		}
+		if to == "" || surplus(from)-surplusTo < 2-leaderShareEpsilon {
+			done[from] = true
+			continue
+		}
+		p := byLeader[from][best]
+		byLeader[from] = append(byLeader[from][:best], byLeader[from][best+1:]...)
+		nodes[from].Balanced--
+		nodes[to].Balanced++
+		transfers = append(transfers, &leaderBalanceTransfer{partition: p, from: from, to: to})
+	}
+	return
+}
+
+func sortedLeaderBalanceNodes(nodes map[string]*proto.LeaderBalanceNode) (list []*proto.LeaderBalanceNode) {
+	list = make([]*proto.LeaderBalanceNode, 0, len(nodes))
+	for _, node := range nodes {
+		list = append(list, node)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Addr < list[j].Addr })
+	return
+}
+
+// balanceLeaders plans at most limit leader transfers, taken from the data
+// and the meta partitions in turn, and requests them unless dryRun.
+func (c *Cluster) balanceLeaders(limit int, dryRun bool) (view *proto.LeaderBalanceView, err error) {
+	if !c.leaderBalancer.running.TryLock() {
+		return nil, fmt.Errorf("the leaders are being balanced")
+	}
+	defer c.leaderBalancer.running.Unlock()
+
+	dps, mps := c.getLeaderBalancePartitions()
+	dataNodes, metaNodes := measureLeaders(dps), measureLeaders(mps)
+	dpTransfers := planLeaderTransfers(dps, dataNodes, limit, c.leaderBalancer.movedOfLate)
+	mpTransfers := planLeaderTransfers(mps, metaNodes, limit, c.leaderBalancer.movedOfLate)
+	// give the nodes back the transfers left out by the limit
+	transfers := make([]*leaderBalanceTransfer, 0, limit)
+	for i := 0; i < len(dpTransfers) || i < len(mpTransfers); i++ {
+		for _, planned := range [][]*leaderBalanceTransfer{dpTransfers, mpTransfers} {
+			if i >= len(planned) {
+				continue
+			}
+			if len(transfers) < limit {
+				transfers = append(transfers, planned[i])
+				continue
+			}
+			nodes := dataNodes
+			if planned[i].partition.kind == proto.LeaderBalanceMetaPartition {
+				nodes = metaNodes
+			}
+			nodes[planned[i].from].Balanced++
+			nodes[planned[i].to].Balanced--
+		}
+	}
+
+	view = &proto.LeaderBalanceView{
+		DryRun:    dryRun,
+		DataNodes: sortedLeaderBalanceNodes(dataNodes),
+		MetaNodes: sortedLeaderBalanceNodes(metaNodes),
+		Transfers: make([]*proto.LeaderTransfer, 0, len(transfers)),
+	}
+	var failed int
+	for _, t := range transfers {
+		transfer := &proto.LeaderTransfer{Type: t.partition.kind, PartitionID: t.partition.id, From: t.from, To: t.to}
+		view.Transfers = append(view.Transfers, transfer)
+		if dryRun {
+			continue
+		}
+		if err := t.partition.transfer(t.to); err != nil {
+			log.LogWarnf("action[balanceLeaders] %v partition[%v] from[%v] to[%v] err[%v]",
+				t.partition.kind, t.partition.id, t.from, t.to, err)
+			transfer.Err = err.Error()
+			failed++
+			continue
+		}
+		c.leaderBalancer.recordMoved(t.partition.leaderBalanceKey)
+	}
+	if !dryRun && len(transfers) > 0 {
+		log.LogWarnf("action[balanceLeaders] requested[%v] failed[%v] leader transfers", len(transfers), failed)
+	}
+	return
+}
+
+func (c *Cluster) scheduleToBalanceLeaders() {
+	c.runTask(&cTask{
+		tickTime: leaderBalanceInterval,
+		name:     "scheduleToBalanceLeaders",
+		function: func() (fin bool) {
+			if c.partition == nil || !c.partition.IsRaftLeader() {
+				return
+			}
+			if len(c.activeMaintenances(time.Now().Unix())) > 0 {
+				return
+			}
+			if limit := c.getLeaderBalanceMaxTransfers(); limit > 0 {
+				if _, err := c.balanceLeaders(int(limit), false); err != nil {
+					log.LogWarnf("action[scheduleToBalanceLeaders] err[%v]", err)
+				}
+			}
+			return
+		},
+	})
+}
+
+// balanceLeaders reports the leader distribution of the data and meta nodes
+// and requests the leader transfers evening it out, or only plans them with
+// dryRun.
+func (m *Server) balanceLeaders(w http.ResponseWriter, r *http.Request) {
+	var (
+		dryRun common.Bool
+		limit  common.Uint
+		view   *proto.LeaderBalanceView
+		err    error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminBalanceLeaders))
+	defer func() {
+		doStatAndMetric(proto.AdminBalanceLeaders, metric, err, nil)
+		if !dryRun.V {
+			AuditLog(r, proto.AdminBalanceLeaders, fmt.Sprintf("balance leaders with limit[%v]", limit.V), err)
+		}
+	}()
+	if err = parseArgs(r, dryRun.Key("dryRun").OmitEmpty(), limit.Key(Limit).OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if limit.V == 0 {
+		limit.V = defaultLeaderBalanceLimit
+	}
+	if limit.V > maxLeaderBalanceLimit {
+		err = fmt.Errorf("limit[%v] is over %v", limit.V, maxLeaderBalanceLimit)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if view, err = m.cluster.balanceLeaders(int(limit.V), dryRun.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(view))
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLeaderBalancePartitions(leaders ...string) (partitions []*leaderBalancePartition) {
+	for i, leader := range leaders {
+		partitions = append(partitions, &leaderBalancePartition{
+			leaderBalanceKey: leaderBalanceKey{kind: proto.LeaderBalanceDataPartition, id: uint64(i + 1)},
+			leader:           leader,
+			voters:           []string{"a", "b", "c"},
+		})
+	}
+	return
+}
+
+func TestPlanLeaderTransfers(t *testing.T) {
+	partitions := newTestLeaderBalancePartitions("a", "a", "a", "a", "a", "a", "a", "a", "a")
+	nodes := measureLeaders(partitions)
+	require.Equal(t, 9, nodes["a"].Leaders)
+	require.InDelta(t, 3, nodes["b"].Share, 1e-9)
+
+	transfers := planLeaderTransfers(partitions, nodes, 100, nil)
+	require.Len(t, transfers, 6)
+	for _, addr := range []string{"a", "b", "c"} {
+		require.Equal(t, 3, nodes[addr].Balanced, addr)
+	}
+	for _, transfer := range transfers {
+		require.Equal(t, "a", transfer.from)
+	}
+
+	nodes = measureLeaders(partitions)
+	require.Len(t, planLeaderTransfers(partitions, nodes, 2, nil), 2)
+	require.Equal(t, 7, nodes["a"].Balanced)
+
+	// the leaders moved of late stay
+	nodes = measureLeaders(partitions)
+	transfers = planLeaderTransfers(partitions, nodes, 100, func(key leaderBalanceKey) bool { return key.id > 2 })
+	require.Len(t, transfers, 2)
+	require.Equal(t, 7, nodes["a"].Balanced)
+
+	partitions = newTestLeaderBalancePartitions("a", "a", "a", "a", "b", "b", "b", "c", "c")
+	transfers = planLeaderTransfers(partitions, measureLeaders(partitions), 100, nil)
+	require.Len(t, transfers, 1)
+	require.Equal(t, "c", transfers[0].to)
+	partitions = newTestLeaderBalancePartitions("a", "a", "a", "b", "b", "b", "c", "c", "c")
+	require.Empty(t, planLeaderTransfers(partitions, measureLeaders(partitions), 100, nil))
+}
+
+func TestDataPartitionLeaderBalancePartition(t *testing.T) {
+	dp := &DataPartition{PartitionID: 1}
+	for _, addr := range []string{"a", "b", "c"} {
+		dataNode := newDataNode(addr, "", "", testZone1, "", defaultMediaType)
+		dataNode.isActive = true
+		dp.Replicas = append(dp.Replicas, newDataReplica(dataNode))
+	}
+	require.Nil(t, dp.getLeaderBalancePartition(60), "no leader")
+
+	dp.Replicas[0].IsLeader = true
+	p := dp.getLeaderBalancePartition(60)
+	require.NotNil(t, p)
+	require.Equal(t, "a", p.leader)
+	require.Equal(t, []string{"a", "b", "c"}, p.voters)
+
+	dp.Replicas[1].dataNode.zoneIsolation.Store(proto.ZoneIsolationReadOnly)
+	require.Equal(t, []string{"a", "c"}, dp.getLeaderBalancePartition(60).voters)
+
+	dp.Replicas[2].dataNode.isActive = false
+	require.Nil(t, dp.getLeaderBalancePartition(60), "a single replica may lead")
+}
+
+func TestBalanceLeaders(t *testing.T) {
+	view, err := mc.AdminAPI().BalanceLeaders(0, true)
+	require.NoError(t, err)
+	require.True(t, view.DryRun)
+	require.LessOrEqual(t, len(view.Transfers), defaultLeaderBalanceLimit)
+	for _, nodes := range [][]*proto.LeaderBalanceNode{view.DataNodes, view.MetaNodes} {
+		var leaders, balanced int
+		for _, node := range nodes {
+			leaders += node.Leaders
+			balanced += node.Balanced
+		}
+		require.Equal(t, leaders, balanced)
+	}
+
+	view, err = mc.AdminAPI().BalanceLeaders(1, false)
+	require.NoError(t, err)
+	require.False(t, view.DryRun)
+	require.LessOrEqual(t, len(view.Transfers), 1)
+
+	_, err = mc.AdminAPI().BalanceLeaders(maxLeaderBalanceLimit+1, true)
+	require.Error(t, err)
+
+	require.NoError(t, server.cluster.setLeaderBalanceMaxTransfers(8))
+	defer func() { require.NoError(t, server.cluster.setLeaderBalanceMaxTransfers(0)) }()
+	cv, err := mc.AdminAPI().GetCluster(false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), cv.LeaderBalanceMaxTransfers)
+}
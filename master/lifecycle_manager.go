@@ -19,6 +19,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -411,6 +412,172 @@ func getLcStopUrl(node, id string) (url string) {
 	return
 }
 
+// controlLcScan forwards pause, resume or scan limit changes to the lcnodes running the matched tasks
+func (lcMgr *lifecycleManager) controlLcScan(vol, rid, op, limit string) (success bool, msg string) {
+	log.LogInfof("controlLcScan received, op: %v, vol: %v, ruleid: %v, limit: %v", op, vol, rid, limit)
+	var path string
+	query := url.Values{}
+	switch op {
+	case "pause":
+		path = "/pauseScanner"
+	case "resume":
+		path = "/resumeScanner"
+	case "limit":
+		v, err := strconv.ParseFloat(limit, 64)
+		if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return false, fmt.Sprintf("controlLcScan failed: invalid limit(%v)", limit)
+		}
+		path = "/setScanLimit"
+		query.Set("limit", limit)
+	default:
+		return false, fmt.Sprintf("controlLcScan failed: invalid op(%v)", op)
+	}
+	if vol == "" {
+		return false, "controlLcScan failed: invalid vol name"
+	}
+	var tid string
+	if rid != "" {
+		tid = fmt.Sprintf("%s:%s", vol, rid)
+	}
+
+	var doing []*proto.LcNodeRuleTaskResponse
+	lcMgr.lcRuleTaskStatus.RLock()
+	for id, result := range lcMgr.lcRuleTaskStatus.Results {
+		if !result.Done && vol == result.Volume && (tid == "" || tid == id) {
+			doing = append(doing, result)
+		}
+	}
+	lcMgr.lcRuleTaskStatus.RUnlock()
+	if len(doing) == 0 {
+		return false, fmt.Sprintf("controlLcScan failed: no running tasks in vol(%v), ruleid(%v)", vol, rid)
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+	var errs []error
+	var done []string
+	for _, d := range doing {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("id", d.ID)
+		if err := doRequestLcScanner(client, getLcScannerUrl(d.LcNode, path, q)); err != nil {
+			errs = append(errs, fmt.Errorf("task(%v) in lcnode(%v): %v", d.ID, d.LcNode, err))
+			continue
+		}
+		done = append(done, d.ID)
+	}
+	if len(errs) > 0 {
+		msg = fmt.Sprintf("controlLcScan %v failed: %v, already success: %v, please retry", op, errs, done)
+		log.LogWarn(msg)
+		return false, msg
+	}
+	msg = fmt.Sprintf("controlLcScan %v success: %v", op, done)
+	log.LogInfo(msg)
+	return true, msg
+}
+
+// rerunLcScan schedules an enabled rule again, optionally restricted to a prefix under the rule prefix
+func (lcMgr *lifecycleManager) rerunLcScan(vol, rid, prefix string) (success bool, msg string) {
+	now := time.Now()
+	if lcMgr.startTime != nil && now.Before(lcMgr.startTime.Add(time.Second*12)) {
+		msg = fmt.Sprintf("rerunLcScan failed: master restart or leader change just now, wait %v", lcMgr.startTime.Add(time.Second*12).Sub(now))
+		log.LogInfo(msg)
+		return
+	}
+	log.LogInfof("rerunLcScan received, vol: %v, ruleid: %v, prefix: %v", vol, rid, prefix)
+	if vol == "" || rid == "" {
+		return false, "rerunLcScan failed: vol and ruleid are required"
+	}
+	tid := fmt.Sprintf("%s:%s", vol, rid)
+	task := lcMgr.genRuleTask(vol, tid)
+	if task == nil {
+		return false, fmt.Sprintf("rerunLcScan failed: enabled rule(%v) not found", tid)
+	}
+	if lcMgr.cluster.volDelete(vol) {
+		return false, fmt.Sprintf("rerunLcScan failed: vol(%v) already deleted", vol)
+	}
+	if prefix != "" {
+		if !strings.HasPrefix(prefix, task.Rule.GetPrefix()) {
+			return false, fmt.Sprintf("rerunLcScan failed: prefix(%v) is not under rule prefix(%v)", prefix, task.Rule.GetPrefix())
+		}
+		rule := *task.Rule
+		filter := proto.Filter{}
+		if rule.Filter != nil {
+			filter = *rule.Filter
+		}
+		filter.Prefix = prefix
+		rule.Filter = &filter
+		task.Rule = &rule
+	}
+
+	var doing []*proto.LcNodeRuleTaskResponse
+	var todo []*proto.RuleTask
+	lcMgr.lcRuleTaskStatus.Lock()
+	for _, result := range lcMgr.lcRuleTaskStatus.Results {
+		if !result.Done {
+			doing = append(doing, result)
+		}
+	}
+	for _, t := range lcMgr.lcRuleTaskStatus.ToBeScanned {
+		todo = append(todo, t)
+	}
+	if exist(task, doing, todo) {
+		lcMgr.lcRuleTaskStatus.Unlock()
+		return false, fmt.Sprintf("rerunLcScan failed: task(%v) or an overlapping task is todo or doing", tid)
+	}
+	if result, ok := lcMgr.lcRuleTaskStatus.Results[tid]; ok {
+		if err := lcMgr.cluster.syncDeleteLcResult(result); err != nil {
+			lcMgr.lcRuleTaskStatus.Unlock()
+			msg = fmt.Sprintf("rerunLcScan failed: syncDeleteLcResult: %v err: %v, need retry", tid, err)
+			log.LogError(msg)
+			return false, msg
+		}
+		delete(lcMgr.lcRuleTaskStatus.Results, tid)
+	}
+	lcMgr.lcRuleTaskStatus.Unlock()
+
+	lcMgr.lcRuleTaskStatus.RedoTask(task)
+	if err := lcMgr.cluster.syncAddLcTask(task); err != nil {
+		log.LogWarnf("rerunLcScan syncAddLcTask: %v err: %v", task.Id, err)
+	}
+	msg = fmt.Sprintf("rerunLcScan success: task(%v) prefix(%v) added", tid, task.Rule.GetPrefix())
+	log.LogInfo(msg)
+	return true, msg
+}
+
+func doRequestLcScanner(cli *http.Client, url string) error {
+	if url == "" {
+		return fmt.Errorf("invalid lcnode url")
+	}
+	resp, err := cli.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status(%v) %v", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+func getLcScannerUrl(node, path string, query url.Values) string {
+	s := strings.Split(node, ":")
+	if len(s) != 2 {
+		log.LogErrorf("getLcScannerUrl invalid LcNode addr: %v", node)
+		return ""
+	}
+	portInt, err := strconv.Atoi(s[1])
+	if err != nil {
+		log.LogErrorf("getLcScannerUrl node: %v err: %v", node, err)
+		return ""
+	}
+	return fmt.Sprintf("http://%v:%v%v?%v", s[0], portInt+1, path, query.Encode())
+}
+
 func (lcMgr *lifecycleManager) checkLcRuleTaskResults() {
 	log.LogInfo("lifecycleManager checkLcRuleTaskResults start")
 	if lcMgr.lcRuleTaskStatus.StartTime == nil {
@@ -543,6 +710,7 @@ func (lcMgr *lifecycleManager) process() {
 			t := time.Now()
 			lcMgr.lcRuleTaskStatus.AddResult(&proto.LcNodeRuleTaskResponse{ID: task.Id, LcNode: nodeAddr, UpdateTime: &t, Volume: task.VolName, Rule: task.Rule})
 			log.LogInfof("add lifecycle scan task(%v) to lcnode(%v)", *task, nodeAddr)
+			lcMgr.cluster.recordVolEvent(task.VolName, proto.VolEventLifecycle, "task[%v] started on lcnode[%v]", task.Id, nodeAddr)
 		}
 	}
 }
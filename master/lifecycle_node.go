@@ -30,6 +30,7 @@ type LcNode struct {
 	ReportTime  time.Time
 	IsActive    bool
 	TaskManager *AdminTaskManager
+	clock       clockSkew
 	sync.RWMutex
 }
 
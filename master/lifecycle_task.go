@@ -45,6 +45,7 @@ func (c *Cluster) handleLcNodeTaskResponse(nodeAddr string, task *proto.AdminTas
 	switch task.OpCode {
 	case proto.OpLcNodeHeartbeat:
 		response := task.Response.(*proto.LcNodeHeartbeatResponse)
+		c.measureClockSkew("lcnode", lcNode.Addr, &lcNode.clock, task, response.Clock)
 		err = c.handleLcNodeHeartbeatResp(task.OperatorAddr, response)
 	case proto.OpLcNodeScan:
 		response := task.Response.(*proto.LcNodeRuleTaskResponse)
@@ -154,6 +155,7 @@ func (c *Cluster) handleLcNodeLcScanResp(nodeAddr string, resp *proto.LcNodeRule
 		}
 		msg := fmt.Sprintf("scanning failed: %+v", resp)
 		auditlog.LogMasterOp("HandleLcNodeLcScanResp", msg, nil)
+		c.recordVolEvent(resp.Volume, proto.VolEventLifecycle, "task[%v] failed on lcnode[%v]: %v", resp.ID, nodeAddr, resp.StartErr)
 		return
 	case proto.TaskSucceeds:
 		c.lcMgr.lcRuleTaskStatus.AddResult(resp)
@@ -163,6 +165,8 @@ func (c *Cluster) handleLcNodeLcScanResp(nodeAddr string, resp *proto.LcNodeRule
 		}
 		msg := fmt.Sprintf("scanning completed: %+v", resp)
 		auditlog.LogMasterOp("HandleLcNodeLcScanResp", msg, nil)
+		c.recordVolEvent(resp.Volume, proto.VolEventLifecycle, "task[%v] completed on lcnode[%v], files scanned[%v] expired[%v] deleted[%v]",
+			resp.ID, nodeAddr, resp.TotalFileScannedNum, resp.TotalFileExpiredNum, resp.ExpiredDeleteNum)
 		return
 	default:
 		log.LogInfof("action[handleLcNodeLcScanResp] scanning received, resp(%v)", resp)
@@ -0,0 +1,232 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// While the whole cluster, a zone or a node set is in maintenance, the master
+// leader does not decommission the bad disks of its data nodes, repair the
+// metadata of the data partitions on them, or move data partition replicas off
+// or onto them, and the data and meta nodes are told on the heartbeats to defer
+// the replica repair and the deletion of the freed extents. The leader balance
+// and the meta partition balance, which move across the zones, stop while any
+// maintenance is on. A maintenance ends when stopped or at its end time.
+
+// getMaintenances returns the maintenances set, the ended ones included, which
+// must not be modified.
+func (c *Cluster) getMaintenances() []proto.Maintenance {
+	maintenances, _ := c.maintenances.Load().([]proto.Maintenance)
+	return maintenances
+}
+
+func (c *Cluster) activeMaintenances(now int64) (active []proto.Maintenance) {
+	for _, m := range c.getMaintenances() {
+		if m.Active(now) {
+			active = append(active, m)
+		}
+	}
+	return
+}
+
+func inMaintenance(maintenances []proto.Maintenance, zoneName string, nodeSetID uint64) bool {
+	for i := range maintenances {
+		if maintenances[i].Covers(zoneName, nodeSetID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cluster) dataNodeInMaintenance(dataNode *DataNode) bool {
+	return inMaintenance(c.activeMaintenances(time.Now().Unix()), dataNode.ZoneName, dataNode.NodeSetID)
+}
+
+// inMaintenance tells whether a replica of the partition is on a node in maintenance.
+func (partition *DataPartition) inMaintenance(maintenances []proto.Maintenance) bool {
+	if len(maintenances) == 0 {
+		return false
+	}
+	partition.RLock()
+	defer partition.RUnlock()
+	for _, replica := range partition.Replicas {
+		if replica.dataNode != nil && inMaintenance(maintenances, replica.dataNode.ZoneName, replica.dataNode.NodeSetID) {
+			return true
+		}
+	}
+	return false
+}
+
+// setMaintenances drops the ended maintenances and applies update to the rest.
+func (c *Cluster) setMaintenances(update func(active []proto.Maintenance) []proto.Maintenance) (err error) {
+	c.maintenanceLock.Lock()
+	defer c.maintenanceLock.Unlock()
+	old := c.getMaintenances()
+	c.maintenances.Store(update(c.activeMaintenances(time.Now().Unix())))
+	if err = c.syncPutCluster(); err != nil {
+		c.maintenances.Store(old)
+		return proto.ErrPersistenceByRaft
+	}
+	return
+}
+
+// startMaintenance starts the maintenance, or replaces the one of the same target.
+func (c *Cluster) startMaintenance(m proto.Maintenance) (err error) {
+	if err = c.setMaintenances(func(active []proto.Maintenance) []proto.Maintenance {
+		maintenances := make([]proto.Maintenance, 0, len(active)+1)
+		for _, a := range active {
+			if !a.SameTarget(&m) {
+				maintenances = append(maintenances, a)
+			}
+		}
+		return append(maintenances, m)
+	}); err != nil {
+		log.LogErrorf("action[startMaintenance] %v err[%v]", m.Target(), err)
+		return
+	}
+	log.LogWarnf("action[startMaintenance] %v reason[%v] end[%v]", m.Target(), m.Reason, m.EndTime)
+	return
+}
+
+func (c *Cluster) stopMaintenance(m proto.Maintenance) (err error) {
+	found := false
+	if err = c.setMaintenances(func(active []proto.Maintenance) []proto.Maintenance {
+		maintenances := make([]proto.Maintenance, 0, len(active))
+		for _, a := range active {
+			if a.SameTarget(&m) {
+				found = true
+				continue
+			}
+			maintenances = append(maintenances, a)
+		}
+		return maintenances
+	}); err != nil {
+		log.LogErrorf("action[stopMaintenance] %v err[%v]", m.Target(), err)
+		return
+	}
+	if !found {
+		return fmt.Errorf("%v is not in maintenance", m.Target())
+	}
+	log.LogWarnf("action[stopMaintenance] %v", m.Target())
+	return
+}
+
+func (m *Server) parseMaintenance(r *http.Request) (maintenance proto.Maintenance, err error) {
+	var (
+		scope     common.String
+		zoneName  common.String
+		nodeSetID common.Uint
+	)
+	if err = parseArgs(r, scope.Key(maintenanceScopeKey), zoneName.Key(zoneNameKey).OmitEmpty(),
+		nodeSetID.Key(nodesetIdKey).OmitEmpty()); err != nil {
+		return
+	}
+	if err = proto.CheckMaintenanceScope(scope.V); err != nil {
+		return
+	}
+	maintenance.Scope = scope.V
+	switch scope.V {
+	case proto.MaintenanceScopeZone:
+		if _, err = m.cluster.t.getZone(zoneName.V); err != nil {
+			return
+		}
+		maintenance.ZoneName = zoneName.V
+	case proto.MaintenanceScopeNodeSet:
+		if _, err = m.cluster.t.getNodeSetByNodeSetId(nodeSetID.V); err != nil {
+			return
+		}
+		maintenance.NodeSetID = nodeSetID.V
+	}
+	return
+}
+
+// startMaintenance puts the cluster, a zone or a node set into maintenance
+// before a planned network maintenance, for the duration if given.
+func (m *Server) startMaintenance(w http.ResponseWriter, r *http.Request) {
+	var (
+		maintenance proto.Maintenance
+		duration    common.String
+		reason      common.String
+		err         error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMaintenanceStart))
+	defer func() {
+		doStatAndMetric(proto.AdminMaintenanceStart, metric, err, nil)
+		AuditLog(r, proto.AdminMaintenanceStart, fmt.Sprintf("start maintenance of %v for [%v] reason[%v]",
+			maintenance.Target(), duration.V, reason.V), err)
+	}()
+	if maintenance, err = m.parseMaintenance(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if err = parseArgs(r, duration.Key(maintenanceDurationKey).OmitEmpty(), reason.Key(maintenanceReasonKey).OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	now := time.Now().Unix()
+	if duration.V != "" {
+		var d time.Duration
+		if d, err = time.ParseDuration(duration.V); err != nil || d <= 0 {
+			err = fmt.Errorf("duration(%v) should be a positive duration", duration.V)
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+			return
+		}
+		maintenance.EndTime = now + int64(d/time.Second)
+	}
+	maintenance.Reason = reason.V
+	maintenance.StartTime = now
+	if err = m.cluster.startMaintenance(maintenance); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("%v is in maintenance", maintenance.Target())))
+}
+
+func (m *Server) stopMaintenance(w http.ResponseWriter, r *http.Request) {
+	var (
+		maintenance proto.Maintenance
+		err         error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMaintenanceStop))
+	defer func() {
+		doStatAndMetric(proto.AdminMaintenanceStop, metric, err, nil)
+		AuditLog(r, proto.AdminMaintenanceStop, fmt.Sprintf("stop maintenance of %v", maintenance.Target()), err)
+	}()
+	if maintenance, err = m.parseMaintenance(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if err = m.cluster.stopMaintenance(maintenance); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("%v is out of maintenance", maintenance.Target())))
+}
+
+func (m *Server) listMaintenances(w http.ResponseWriter, r *http.Request) {
+	maintenances := m.cluster.activeMaintenances(time.Now().Unix())
+	if maintenances == nil {
+		maintenances = make([]proto.Maintenance, 0)
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(maintenances))
+}
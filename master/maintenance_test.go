@@ -0,0 +1,78 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPartitionInMaintenance(t *testing.T) {
+	dp := &DataPartition{PartitionID: 1}
+	for _, zone := range []string{testZone1, testZone2} {
+		dataNode := newDataNode(zone, "", "", zone, "", defaultMediaType)
+		dataNode.NodeSetID = 1
+		dp.Replicas = append(dp.Replicas, newDataReplica(dataNode))
+	}
+	require.False(t, dp.inMaintenance(nil))
+	require.True(t, dp.inMaintenance([]proto.Maintenance{{Scope: proto.MaintenanceScopeZone, ZoneName: testZone2}}))
+	require.False(t, dp.inMaintenance([]proto.Maintenance{{Scope: proto.MaintenanceScopeNodeSet, NodeSetID: 2}}))
+}
+
+func TestMaintenance(t *testing.T) {
+	api := mc.AdminAPI()
+	require.Error(t, api.StartMaintenance("node", "", 0, "", ""))
+	require.Error(t, api.StartMaintenance(proto.MaintenanceScopeZone, "noSuchZone", 0, "", ""))
+	require.Error(t, api.StartMaintenance(proto.MaintenanceScopeZone, testZone2, 0, "-1h", ""))
+	require.Error(t, api.StopMaintenance(proto.MaintenanceScopeZone, testZone2, 0))
+
+	require.NoError(t, api.StartMaintenance(proto.MaintenanceScopeZone, testZone2, 0, "", "switch upgrade"))
+	require.NoError(t, api.StartMaintenance(proto.MaintenanceScopeZone, testZone2, 0, "2h", "switch upgrade"))
+	maintenances, err := api.ListMaintenances()
+	require.NoError(t, err)
+	require.Len(t, maintenances, 1)
+	require.Equal(t, testZone2, maintenances[0].ZoneName)
+	require.NotZero(t, maintenances[0].EndTime)
+
+	dataNode, err := server.cluster.dataNode(mds3Addr)
+	require.NoError(t, err)
+	require.True(t, server.cluster.dataNodeInMaintenance(dataNode))
+	dataNode, err = server.cluster.dataNode(mds1Addr)
+	require.NoError(t, err)
+	require.False(t, server.cluster.dataNodeInMaintenance(dataNode))
+
+	// persisted with the cluster value
+	values, err := server.cluster.fsm.store.SeekForPrefix([]byte(clusterPrefix))
+	require.NoError(t, err)
+	for _, value := range values {
+		cv := &clusterValue{}
+		require.NoError(t, json.Unmarshal(value, cv))
+		require.Len(t, cv.Maintenances, 1)
+	}
+
+	require.NoError(t, api.StopMaintenance(proto.MaintenanceScopeZone, testZone2, 0))
+	maintenances, err = api.ListMaintenances()
+	require.NoError(t, err)
+	require.Empty(t, maintenances)
+
+	// an ended one no longer counts
+	server.cluster.maintenances.Store([]proto.Maintenance{{Scope: proto.MaintenanceScopeCluster, EndTime: time.Now().Unix() - 1}})
+	defer server.cluster.maintenances.Store([]proto.Maintenance(nil))
+	require.False(t, server.cluster.dataNodeInMaintenance(dataNode))
+}
@@ -81,10 +81,15 @@ func (m *Server) handleLeaderChange(leader uint64) {
 		m.cluster.lcMgr.startLcScanHandleLeaderChange()
 		m.cluster.flashManMgr.startFlashScanHandleLeaderChange()
 		m.cluster.followerReadManager.reSet()
+		m.cluster.sloTracker.clear()
+		m.cluster.s3Gateways.reset()
 	} else {
 		Warn(m.clusterName, fmt.Sprintf("clusterID[%v] leader is changed to %v",
 			m.clusterName, m.leaderInfo.addr))
 		m.clearMetadata()
+		if m.cluster.auditMgr != nil {
+			m.cluster.auditMgr.cancelAll()
+		}
 		if m.cluster.lcMgr != nil {
 			close(m.cluster.lcMgr.exitCh)
 			m.cluster.lcMgr = newLifecycleManager()
@@ -128,6 +133,9 @@ func (m *Server) handlePeerChange(confChange *proto.ConfChange) (err error) {
 func (m *Server) handleApplySnapshot() {
 	m.fsm.restore()
 	m.restoreIDAlloc()
+	if m.raftBackup != nil {
+		m.raftBackup.reset(m.fsm.applied)
+	}
 }
 
 func (m *Server) handleRaftUserCmd(opt uint32, key string, cmdMap map[string][]byte) (err error) {
@@ -299,6 +307,30 @@ func (m *Server) loadMetadata() {
 	}
 	log.LogInfo("action[loadS3QoSInfo] end")
 
+	log.LogInfo("action[loadMountProfiles] begin")
+	if err = m.cluster.loadMountProfiles(); err != nil {
+		panic(err)
+	}
+	log.LogInfo("action[loadMountProfiles] end")
+
+	log.LogInfo("action[loadClientEvictions] begin")
+	if err = m.cluster.loadClientEvictions(); err != nil {
+		panic(err)
+	}
+	log.LogInfo("action[loadClientEvictions] end")
+
+	log.LogInfo("action[loadVolTimeline] begin")
+	if err = m.cluster.loadVolTimeline(); err != nil {
+		panic(err)
+	}
+	log.LogInfo("action[loadVolTimeline] end")
+
+	log.LogInfo("action[loadFlashGroupAudit] begin")
+	if err = m.cluster.loadFlashGroupAudit(); err != nil {
+		panic(err)
+	}
+	log.LogInfo("action[loadFlashGroupAudit] end")
+
 	m.cluster.checkMediaVaild()
 
 	log.LogInfo("action[loadMetadata] end")
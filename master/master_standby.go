@@ -0,0 +1,337 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	raftProto "github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/raftstore"
+	"github.com/cubefs/cubefs/util/atomicutil"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/iputil"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The masters of the standby group, in another failure domain, are raft learners
+// of the primary group. They apply what the primary group commits but neither
+// vote nor count in its quorum. Promoting the standby group fences the primary
+// masters, waits for the standby to apply what they committed, and resets the
+// peers of every standby master to the standby group, which elects a leader of
+// its own.
+
+const (
+	masterFenceFile             = "FENCED"
+	defaultStandbyPromoteWait   = 30
+	standbyPromoteCheckInterval = 100 * time.Millisecond
+)
+
+var errMasterFenced = errors.New("master is fenced by the promotion of the standby group")
+
+// masterPartition rejects the proposals of a fenced master, so the primary group
+// commits nothing once it is fenced. Its raft keeps running to replicate what it
+// committed before to the standby masters.
+type masterPartition struct {
+	raftstore.Partition
+	fenced *atomicutil.Bool
+}
+
+func (p *masterPartition) Submit(cmd []byte) (resp interface{}, err error) {
+	if p.fenced.Load() {
+		return nil, errMasterFenced
+	}
+	return p.Partition.Submit(cmd)
+}
+
+func (p *masterPartition) ChangeMember(changeType raftProto.ConfChangeType, peer raftProto.Peer, context []byte) (
+	resp interface{}, err error,
+) {
+	if p.fenced.Load() {
+		return nil, errMasterFenced
+	}
+	return p.Partition.ChangeMember(changeType, peer, context)
+}
+
+type masterFence struct {
+	Time     int64
+	Operator string
+}
+
+// checkMasterFence refuses to start a fenced master, which must not rejoin the
+// primary group after the standby group took over.
+func checkMasterFence(walDir string) error {
+	fencePath := path.Join(walDir, masterFenceFile)
+	data, err := os.ReadFile(fencePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%v %s, remove %v to start it again", errMasterFenced, data, fencePath)
+}
+
+// isStandbyAPI returns whether the api is served by the master requested, even
+// a standby or a fenced one.
+func isStandbyAPI(path string) bool {
+	return path == proto.AdminMasterStandbyStatus || path == proto.AdminMasterStandbyFence ||
+		path == proto.AdminMasterStandbyPromote
+}
+
+func (m *Server) isStandby() bool {
+	return m.config.isStandbyPeer(m.id) && !m.promoted.Load()
+}
+
+func (m *Server) masterRole() string {
+	switch {
+	case m.isStandby():
+		return proto.MasterRoleStandby
+	case m.promoted.Load():
+		return proto.MasterRolePromoted
+	default:
+		return proto.MasterRolePrimary
+	}
+}
+
+func (m *Server) standbyStatus() *proto.MasterStandbyStatus {
+	leaderID, term := m.partition.LeaderTerm()
+	status := &proto.MasterStandbyStatus{
+		ID:      m.id,
+		Addr:    m.getCurrAddr(),
+		Role:    m.masterRole(),
+		Fenced:  m.fenced.Load(),
+		Leader:  AddrDatabase[leaderID],
+		Term:    term,
+		Commit:  m.partition.CommittedIndex(),
+		Applied: m.partition.AppliedIndex(),
+		Peers:   make([]proto.MasterStandbyPeer, 0, len(m.config.peers)),
+	}
+	for _, peer := range m.config.peers {
+		status.Peers = append(status.Peers, proto.MasterStandbyPeer{
+			ID: peer.ID, Addr: AddrDatabase[peer.ID], Standby: peer.Type == raftProto.PeerLearner,
+		})
+	}
+	return status
+}
+
+// fence stops the master of the primary group from committing anything, and
+// from starting again until the fence file is removed.
+func (m *Server) fence(operator string) (err error) {
+	if m.config.isStandbyPeer(m.id) {
+		return fmt.Errorf("master %v is a standby master", m.id)
+	}
+	data, _ := json.Marshal(&masterFence{Time: time.Now().Unix(), Operator: operator})
+	if err = os.WriteFile(path.Join(m.walDir, masterFenceFile), data, 0o644); err != nil {
+		return
+	}
+	m.fenced.Store(true)
+	log.LogWarnf("action[fence] master %v is fenced by %v", m.id, operator)
+	return
+}
+
+// resetToStandbyGroup makes the standby masters the voters of the group of this
+// master, without the primary masters.
+func (m *Server) resetToStandbyGroup() (err error) {
+	peers := make([]raftProto.Peer, 0, len(m.config.standbyPeers))
+	for _, peer := range m.config.standbyPeers {
+		peers = append(peers, raftProto.Peer{ID: peer.ID})
+	}
+	if err = m.partition.ResetPeers(peers); err != nil {
+		return
+	}
+	m.promoted.Store(true)
+	log.LogWarnf("action[resetToStandbyGroup] master %v is promoted, peers %v", m.id, peers)
+	return
+}
+
+// promoteStandby promotes the standby group from this standby master. Without
+// force, it fails before fencing anything if a master of either group is down,
+// and before promoting anything if the standby does not catch up in time. With
+// force, the primary masters down are left unfenced, and at least a quorum of
+// the standby masters is promoted.
+func (m *Server) promoteStandby(force bool, wait time.Duration) (result *proto.MasterPromoteResult, err error) {
+	if !m.isStandby() {
+		return nil, fmt.Errorf("master %v is not a standby master", m.id)
+	}
+	api := m.cluster.masterClient.AdminAPI()
+	result = &proto.MasterPromoteResult{
+		Fenced: make([]string, 0), Unfenced: make([]string, 0), Promoted: make([]string, 0), NotPromoted: make([]string, 0),
+	}
+
+	standbys := make([]string, 0, len(m.config.standbyPeers))
+	for _, peer := range m.config.standbyPeers {
+		addr := AddrDatabase[peer.ID]
+		if peer.ID == m.id {
+			standbys = append(standbys, addr)
+			continue
+		}
+		status, serr := api.GetMasterStandbyStatus(addr)
+		if serr == nil && status.Role != proto.MasterRoleStandby {
+			serr = fmt.Errorf("role %v", status.Role)
+		}
+		if serr != nil {
+			if !force {
+				return nil, fmt.Errorf("standby master %v is not ready: %v", addr, serr)
+			}
+			result.NotPromoted = append(result.NotPromoted, addr)
+			continue
+		}
+		standbys = append(standbys, addr)
+	}
+	if len(standbys) < len(m.config.standbyPeers)/2+1 {
+		return nil, fmt.Errorf("only %v of %v standby masters are ready", len(standbys), len(m.config.standbyPeers))
+	}
+
+	primaries := make([]string, 0, len(m.config.peers))
+	for _, peer := range m.config.peers {
+		if peer.Type == raftProto.PeerLearner {
+			continue
+		}
+		addr := AddrDatabase[peer.ID]
+		if _, serr := api.GetMasterStandbyStatus(addr); serr != nil {
+			if !force {
+				return nil, fmt.Errorf("primary master %v can not be fenced: %v", addr, serr)
+			}
+			result.Unfenced = append(result.Unfenced, addr)
+			continue
+		}
+		primaries = append(primaries, addr)
+	}
+	for _, addr := range primaries {
+		status, ferr := api.FenceMaster(addr)
+		if ferr != nil {
+			if !force {
+				return nil, fmt.Errorf("fence primary master %v: %v", addr, ferr)
+			}
+			result.Unfenced = append(result.Unfenced, addr)
+			continue
+		}
+		result.Fenced = append(result.Fenced, addr)
+		if status.Commit > result.PrimaryCommit {
+			result.PrimaryCommit = status.Commit
+		}
+	}
+
+	deadline := time.Now().Add(wait)
+	for m.partition.AppliedIndex() < result.PrimaryCommit && time.Now().Before(deadline) {
+		time.Sleep(standbyPromoteCheckInterval)
+	}
+	result.Applied = m.partition.AppliedIndex()
+	if result.Applied < result.PrimaryCommit {
+		if !force {
+			return nil, fmt.Errorf("standby applied %v, primary committed %v", result.Applied, result.PrimaryCommit)
+		}
+		result.LostEntries = result.PrimaryCommit - result.Applied
+	}
+
+	for _, addr := range standbys {
+		if addr == m.getCurrAddr() {
+			continue
+		}
+		if _, perr := api.PromoteMasterStandbyLocal(addr); perr != nil {
+			if !force {
+				return nil, fmt.Errorf("promote standby master %v: %v", addr, perr)
+			}
+			result.NotPromoted = append(result.NotPromoted, addr)
+			continue
+		}
+		result.Promoted = append(result.Promoted, addr)
+	}
+	if err = m.resetToStandbyGroup(); err != nil {
+		return
+	}
+	result.Promoted = append(result.Promoted, m.getCurrAddr())
+	if err = m.partition.TryToLeader(m.id); err != nil {
+		log.LogWarnf("action[promoteStandby] master %v try to leader err: %v", m.id, err)
+		err = nil
+	}
+	return
+}
+
+// getMasterStandbyStatus returns the role, the raft progress and the peers of
+// the master requested.
+func (m *Server) getMasterStandbyStatus(w http.ResponseWriter, r *http.Request) {
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMasterStandbyStatus))
+	defer func() {
+		doStatAndMetric(proto.AdminMasterStandbyStatus, metric, nil, nil)
+	}()
+	sendOkReply(w, r, newSuccessHTTPReply(m.standbyStatus()))
+}
+
+// fenceMaster fences the master requested of the primary group.
+func (m *Server) fenceMaster(w http.ResponseWriter, r *http.Request) {
+	var err error
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMasterStandbyFence))
+	defer func() {
+		doStatAndMetric(proto.AdminMasterStandbyFence, metric, err, nil)
+		AuditLog(r, proto.AdminMasterStandbyFence, fmt.Sprintf("fence master %v", m.id), err)
+	}()
+	if err = m.fence(iputil.GetRealClientIP(r)); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.standbyStatus()))
+}
+
+// promoteMasterStandby promotes the standby group from the standby master
+// requested, or with local only resets the peers of the master requested.
+func (m *Server) promoteMasterStandby(w http.ResponseWriter, r *http.Request) {
+	var (
+		force, local common.Bool
+		waitSec      common.Int
+		result       *proto.MasterPromoteResult
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminMasterStandbyPromote))
+	defer func() {
+		doStatAndMetric(proto.AdminMasterStandbyPromote, metric, err, nil)
+		AuditLog(r, proto.AdminMasterStandbyPromote, fmt.Sprintf("promote standby master %v force %v local %v",
+			m.id, force.V, local.V), err)
+	}()
+	if err = parseArgs(r, force.Key(forceKey).OmitEmpty(), local.Key("local").OmitEmpty(),
+		waitSec.Key("waitSec").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if local.V {
+		if !m.isStandby() {
+			err = fmt.Errorf("master %v is not a standby master", m.id)
+		} else {
+			err = m.resetToStandbyGroup()
+		}
+		if err != nil {
+			sendErrReply(w, r, newErrHTTPReply(err))
+			return
+		}
+		sendOkReply(w, r, newSuccessHTTPReply(m.standbyStatus()))
+		return
+	}
+	if waitSec.V <= 0 {
+		waitSec.V = defaultStandbyPromoteWait
+	}
+	if result, err = m.promoteStandby(force.V, time.Duration(waitSec.V)*time.Second); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(result))
+}
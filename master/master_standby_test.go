@@ -0,0 +1,85 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+
+	raftProto "github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/raftstore"
+	masterSDK "github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/util/atomicutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStandbyPeers(t *testing.T) {
+	cfg := newClusterConfig()
+	cfg.peers = []raftstore.PeerAddress{{Peer: raftProto.Peer{ID: 100}, Address: "127.0.0.100"}}
+	defer func() {
+		delete(AddrDatabase, 101)
+		delete(AddrDatabase, 102)
+	}()
+	require.NoError(t, cfg.parseStandbyPeers("101:127.0.0.101:17010,102:127.0.0.102:17010"))
+	require.Len(t, cfg.peers, 3)
+	require.Len(t, cfg.standbyPeers, 2)
+	for _, peer := range cfg.standbyPeers {
+		require.Equal(t, raftProto.PeerLearner, peer.Type)
+	}
+	require.Equal(t, "127.0.0.101:17010", AddrDatabase[101])
+	require.True(t, cfg.isStandbyPeer(102))
+	require.False(t, cfg.isStandbyPeer(100))
+
+	require.Error(t, cfg.parseStandbyPeers("100:127.0.0.100:17010"))
+}
+
+func TestMasterFence(t *testing.T) {
+	dir := t.TempDir()
+	m := &Server{id: 100, walDir: dir, config: newClusterConfig()}
+	require.NoError(t, checkMasterFence(dir))
+	require.NoError(t, m.fence("127.0.0.1"))
+	require.True(t, m.fenced.Load())
+	require.Error(t, checkMasterFence(dir))
+
+	// a standby master is never fenced
+	standby := &Server{id: 101, walDir: t.TempDir(), config: newClusterConfig()}
+	standby.config.standbyPeers = []raftstore.PeerAddress{{Peer: raftProto.Peer{ID: 101, Type: raftProto.PeerLearner}}}
+	require.Error(t, standby.fence("127.0.0.1"))
+	require.NoError(t, checkMasterFence(standby.walDir))
+
+	var fenced atomicutil.Bool
+	fenced.Store(true)
+	p := &masterPartition{fenced: &fenced}
+	_, err := p.Submit([]byte("cmd"))
+	require.ErrorIs(t, err, errMasterFenced)
+	_, err = p.ChangeMember(raftProto.ConfAddNode, raftProto.Peer{ID: 102}, nil)
+	require.ErrorIs(t, err, errMasterFenced)
+}
+
+func TestMasterStandbyStatus(t *testing.T) {
+	api := masterSDK.NewMasterClient(nil, false).AdminAPI()
+	status, err := api.GetMasterStandbyStatus(masterAddr)
+	require.NoError(t, err)
+	require.Equal(t, proto.MasterRolePrimary, status.Role)
+	require.False(t, status.Fenced)
+	require.Equal(t, server.id, status.ID)
+	require.NotEmpty(t, status.Peers)
+
+	// only a standby master promotes the standby group
+	_, err = api.PromoteMasterStandby(masterAddr, false, 0)
+	require.Error(t, err)
+	_, err = api.PromoteMasterStandbyLocal(masterAddr)
+	require.Error(t, err)
+}
@@ -17,6 +17,7 @@ package master
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
@@ -52,9 +53,14 @@ type MetaNode struct {
 	MigrateLock                      sync.RWMutex
 	MpCntLimit                       uint64             `json:"-"` // max count of meta partition in a meta node
 	CpuUtil                          atomicutil.Float64 `json:"-"`
+	resourceThrottle                 atomic.Value       `json:"-"`
 	HeartbeatPort                    string             `json:"HeartbeatPort"`
 	ReplicaPort                      string             `json:"ReplicaPort"`
 	ReceivedForbidWriteOpOfProtoVer0 bool
+	Features                         proto.FeatureBits
+	health                           nodeHealth
+	clock                            clockSkew
+	zoneIsolation                    atomicutil.Int32
 }
 
 func newMetaNode(addr, heartbeatPort, replicaPort, zoneName, clusterID string) (node *MetaNode) {
@@ -65,10 +71,20 @@ func newMetaNode(addr, heartbeatPort, replicaPort, zoneName, clusterID string) (
 		ZoneName:      zoneName,
 		Sender:        newAdminTaskManager(addr, clusterID),
 	}
+	node.Sender.health = &node.health
 	node.CpuUtil.Store(0)
 	return
 }
 
+func (metaNode *MetaNode) GetResourceThrottle() (state proto.ResourceThrottleState) {
+	state, _ = metaNode.resourceThrottle.Load().(proto.ResourceThrottleState)
+	return
+}
+
+func (metaNode *MetaNode) SetResourceThrottle(state proto.ResourceThrottleState) {
+	metaNode.resourceThrottle.Store(state)
+}
+
 func (metaNode *MetaNode) IsActiveNode() bool {
 	return metaNode.IsActive
 }
@@ -141,7 +157,7 @@ func (metaNode *MetaNode) IsWriteAble() (ok bool) {
 	defer metaNode.RUnlock()
 	if metaNode.IsActive && metaNode.MaxMemAvailWeight > gConfig.metaNodeReservedMem &&
 		!metaNode.reachesThreshold() && metaNode.MetaPartitionCount < defaultMaxMetaPartitionCountOnEachNode &&
-		!metaNode.RdOnly {
+		!metaNode.RdOnly && !metaNode.clock.isGated() && !metaNode.isZoneIsolated() {
 		ok = true
 	}
 	return
@@ -152,6 +168,7 @@ func (metaNode *MetaNode) setNodeActive() {
 	defer metaNode.Unlock()
 	metaNode.ReportTime = time.Now()
 	metaNode.IsActive = true
+	metaNode.health.heartbeat(metaNode.ReportTime, time.Second*defaultIntervalToCheckHeartbeat, 0, metaNode.CpuUtil.Load()/100)
 }
 
 func (metaNode *MetaNode) updateMetric(resp *proto.MetaNodeHeartbeatResponse, threshold float32) {
@@ -178,6 +195,7 @@ func (metaNode *MetaNode) updateMetric(resp *proto.MetaNodeHeartbeatResponse, th
 	metaNode.Threshold = threshold
 	metaNode.NodeMemTotal = resp.NodeMemTotal
 	metaNode.NodeMemUsed = resp.NodeMemUsed
+	metaNode.Features = resp.Features
 }
 
 func (metaNode *MetaNode) reachesThreshold() bool {
@@ -215,12 +233,18 @@ func (metaNode *MetaNode) createVersionTask(volume string, version uint64, op ui
 	return
 }
 
-func (metaNode *MetaNode) checkHeartbeat() {
+// checkHeartbeat returns true if the meta node goes offline by this check.
+func (metaNode *MetaNode) checkHeartbeat() (offline bool) {
 	metaNode.Lock()
 	defer metaNode.Unlock()
 	if time.Since(metaNode.ReportTime) > time.Second*time.Duration(defaultNodeTimeOutSec) {
+		if metaNode.IsActive {
+			metaNode.health.inactive(time.Now())
+			offline = true
+		}
 		metaNode.IsActive = false
 	}
+	return
 }
 
 func (metaNode *MetaNode) GetPartitionLimitCnt() uint64 {
@@ -241,6 +265,10 @@ func (metaNode *MetaNode) IsOffline() bool {
 	return metaNode.ToBeOffline
 }
 
+func (metaNode *MetaNode) getHealth() *nodeHealth {
+	return &metaNode.health
+}
+
 // LeaderMetaNode define the leader metaPartitions in meta node
 type LeaderMetaNode struct {
 	addr           string
@@ -290,6 +318,9 @@ func (s *sortLeaderMetaNode) changeLeader(l *LeaderMetaNode) {
 		addr := oldLeader.Addr
 		s.mu.RLock()
 		for i := 0; i < len(mp.Replicas); i++ {
+			if mp.isWitness(mp.Replicas[i].Addr) {
+				continue
+			}
 			if s.leaderCountM[mp.Replicas[i].Addr] < s.leaderCountM[oldLeader.Addr]-1 {
 				addr = mp.Replicas[i].Addr
 			}
@@ -48,6 +48,10 @@ type MetaReplica struct {
 	StatByMigrateStorageClass []*proto.StatOfStorageClass
 	metaNode                  *MetaNode
 	ReadOnlyReasons           uint32
+	reclaim                   *proto.MetaPartitionReclaim // reported by the leader only
+	Qps                       uint64
+	MemUsed                   uint64
+	ops                       readRate
 }
 
 // MetaPartition defines the structure of a meta partition
@@ -86,6 +90,8 @@ type MetaPartition struct {
 	sync.RWMutex
 
 	LastDelReplicaTime int64
+	hotSince           int64 // unix time the partition went over the hot thresholds
+	hotSplitTime       int64 // unix time the partition was split for being hot
 }
 
 func newMetaReplica(start, end uint64, metaNode *MetaNode) (mr *MetaReplica) {
@@ -125,6 +131,51 @@ func (mp *MetaPartition) setPeers(peers []proto.Peer) {
 	mp.Peers = peers
 }
 
+// withWitnesses makes the last witnessNum peers the witnesses, which vote in the
+// raft group but hold no data. A majority of the replicas always hold the data.
+func withWitnesses(peers []proto.Peer, witnessNum int, replicaNum uint8) []proto.Peer {
+	if max := proto.MaxMetaWitnessNum(replicaNum); witnessNum > max {
+		witnessNum = max
+	}
+	for i := len(peers) - witnessNum; i < len(peers); i++ {
+		if i >= 0 {
+			peers[i].Witness = true
+		}
+	}
+	return peers
+}
+
+// isWitness tells if the replica on addr is a witness, it is never the leader and
+// has nothing to compare or to read.
+func (mp *MetaPartition) isWitness(addr string) bool {
+	for _, peer := range mp.Peers {
+		if peer.Addr == addr {
+			return peer.Witness
+		}
+	}
+	return false
+}
+
+func (mp *MetaPartition) getWitnesses() (witnesses []string) {
+	for _, peer := range mp.Peers {
+		if peer.Witness {
+			witnesses = append(witnesses, peer.Addr)
+		}
+	}
+	return
+}
+
+// getDataHosts returns the hosts of the replicas holding the data.
+func (mp *MetaPartition) getDataHosts() (hosts []string) {
+	hosts = make([]string, 0, len(mp.Hosts))
+	for _, host := range mp.Hosts {
+		if !mp.isWitness(host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return
+}
+
 func (mp *MetaPartition) setHosts(hosts []string) {
 	mp.Hosts = hosts
 }
@@ -362,7 +413,7 @@ func (mp *MetaPartition) checkStatus(clusterID string, writeLog bool, replicaNum
 		}
 	}
 
-	if mp.PartitionID >= maxPartitionID && mp.Status == proto.ReadOnly && !forbiddenVol {
+	if mp.PartitionID == maxPartitionID && mp.Status == proto.ReadOnly && !forbiddenVol {
 		mp.Status = proto.ReadWrite
 	}
 
@@ -684,6 +735,9 @@ func (mp *MetaPartition) tryToChangeLeader(c *Cluster, metaNode *MetaNode) (err
 }
 
 func (mp *MetaPartition) tryToChangeLeaderByHost(host string) (err error) {
+	if mp.isWitness(host) {
+		return fmt.Errorf("host[%v] is a witness", host)
+	}
 	var metaNode *MetaNode
 	for _, r := range mp.Replicas {
 		if host == r.Addr {
@@ -798,9 +852,14 @@ func (mr *MetaReplica) updateMetric(mgr *proto.MetaPartitionReport) {
 	mr.TxRbInoCnt = mgr.TxRbInoCnt
 	mr.TxRbDenCnt = mgr.TxRbDenCnt
 	mr.FreeListLen = mgr.FreeListLen
+	mr.reclaim = mgr.Reclaim
 	mr.dataSize = mgr.Size
 	mr.ForbidWriteOpOfProtoVer0 = mgr.ForbidWriteOpOfProtoVer0
 	mr.ReadOnlyReasons = mgr.ReadOnlyReasons
+	mr.MemUsed = mgr.MemUsed
+	if qps, ok := mr.ops.update(mgr.Ops, time.Now().Unix()); ok {
+		mr.Qps = qps
+	}
 
 	if mgr.StatByStorageClass != nil {
 		mr.StatByStorageClass = mgr.StatByStorageClass
@@ -823,6 +882,13 @@ func (mr *MetaReplica) updateMetric(mgr *proto.MetaPartitionReport) {
 			mr.Status = proto.ReadOnly
 		}
 	}
+
+	if mr.metaNode.isZoneIsolated() {
+		mr.ReadOnlyReasons |= proto.MpZoneIsolated
+		if mr.Status == proto.ReadWrite {
+			mr.Status = proto.ReadOnly
+		}
+	}
 }
 
 func (mr *MetaReplica) createTaskToFreezeReplica(partitionID uint64, freeze bool) (t *proto.AdminTask) {
@@ -875,9 +941,13 @@ func (mp *MetaPartition) getMinusOfMaxInodeID() (minus float64) {
 	mp.RLock()
 	defer mp.RUnlock()
 	var sentry float64
-	for index, replica := range mp.Replicas {
-		if index == 0 {
-			sentry = float64(replica.MaxInodeID)
+	first := true
+	for _, replica := range mp.Replicas {
+		if mp.isWitness(replica.Addr) {
+			continue
+		}
+		if first {
+			sentry, first = float64(replica.MaxInodeID), false
 			continue
 		}
 		diff := math.Abs(float64(replica.MaxInodeID) - sentry)
@@ -891,10 +961,14 @@ func (mp *MetaPartition) getMinusOfMaxInodeID() (minus float64) {
 func (mp *MetaPartition) activeMaxInodeSimilar() bool {
 	minus := float64(0)
 	var sentry float64
+	first := true
 	replicas := mp.getLiveReplicas(defaultMetaPartitionTimeOutSec)
-	for index, replica := range replicas {
-		if index == 0 {
-			sentry = float64(replica.MaxInodeID)
+	for _, replica := range replicas {
+		if mp.isWitness(replica.Addr) {
+			continue
+		}
+		if first {
+			sentry, first = float64(replica.MaxInodeID), false
 			continue
 		}
 		diff := math.Abs(float64(replica.MaxInodeID) - sentry)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cubefs/cubefs/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetaPartition(t *testing.T) {
@@ -28,6 +29,35 @@ func TestMetaPartition(t *testing.T) {
 	decommissionMetaPartition(commonVol, maxPartitionID, t)
 }
 
+func TestMetaPartitionWitness(t *testing.T) {
+	peers := withWitnesses([]proto.Peer{{ID: 1, Addr: "a"}, {ID: 2, Addr: "b"}, {ID: 3, Addr: "c"}}, 2, 3)
+	assert.Equal(t, []bool{false, false, true}, []bool{peers[0].Witness, peers[1].Witness, peers[2].Witness})
+
+	mp := newMetaPartition(1, 1, defaultMaxMetaPartitionInodeID, 3, "vol", 1, 0)
+	mp.setHosts([]string{"a", "b", "c"})
+	mp.setPeers(peers)
+	assert.True(t, mp.isWitness("c"))
+	assert.False(t, mp.isWitness("a"))
+	assert.Equal(t, []string{"a", "b"}, mp.getDataHosts())
+	assert.Equal(t, []string{"c"}, mp.getWitnesses())
+	assert.Error(t, mp.tryToChangeLeaderByHost("c"))
+
+	// the witness holds no inode, it is not behind the others
+	mp.Replicas = []*MetaReplica{{Addr: "a", MaxInodeID: 100}, {Addr: "b", MaxInodeID: 100}, {Addr: "c"}}
+	assert.Zero(t, mp.getMinusOfMaxInodeID())
+
+	name := "mpWitnessVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer delVol(name, t)
+	vol, err := server.cluster.getVol(name)
+	require.NoError(t, err)
+	vol.MpWitnessNum = 1
+	mp, err = vol.doCreateMetaPartition(server.cluster, defaultMaxMetaPartitionInodeID+1, defaultMaxMetaPartitionInodeID+2)
+	require.NoError(t, err)
+	assert.Len(t, mp.getWitnesses(), 1)
+	assert.Len(t, mp.getDataHosts(), len(mp.Hosts)-1)
+}
+
 func createMetaPartition(vol *Vol, t *testing.T) {
 	count := 3
 	vol.mpsLock.RLock()
@@ -41,6 +41,8 @@ type raftUserCmdApplyHandler func(opt uint32, key string, cmdMap map[string][]by
 
 type raftApplySnapshotHandler func()
 
+type raftCmdAppliedHandler func(command []byte, index uint64)
+
 // MetadataFsm represents the finite state machine of a metadata partition
 type MetadataFsm struct {
 	store               *raftstore.RocksDBStore
@@ -51,6 +53,7 @@ type MetadataFsm struct {
 	peerChangeHandler   raftPeerChangeHandler
 	snapshotHandler     raftApplySnapshotHandler
 	UserAppCmdHandler   raftUserCmdApplyHandler
+	cmdAppliedHandler   raftCmdAppliedHandler
 	onSnapshot          bool
 	raftLk              sync.Mutex
 }
@@ -83,6 +86,11 @@ func (mf *MetadataFsm) registerRaftUserCmdApplyHandler(handler raftUserCmdApplyH
 	mf.UserAppCmdHandler = handler
 }
 
+// Called with every command applied, in the order of the raft log.
+func (mf *MetadataFsm) registerCmdAppliedHandler(handler raftCmdAppliedHandler) {
+	mf.cmdAppliedHandler = handler
+}
+
 func (mf *MetadataFsm) restore() {
 	mf.restoreApplied()
 }
@@ -142,7 +150,8 @@ func (mf *MetadataFsm) Apply(command []byte, index uint64) (resp interface{}, er
 			switch cmd.Op {
 			case opSyncDeleteDataNode, opSyncDeleteMetaNode, opSyncDeleteVol, opSyncDeleteDataPartition, opSyncDeleteMetaPartition,
 				opSyncDeleteUserInfo, opSyncDeleteAKUser, opSyncDeleteVolUser, opSyncDeleteQuota, opSyncDeleteLcNode,
-				opSyncDeleteLcConf, opSyncDeleteLcTask, opSyncDeleteLcResult, opSyncS3QosDelete, opSyncDeleteDecommissionDisk:
+				opSyncDeleteLcConf, opSyncDeleteLcTask, opSyncDeleteLcResult, opSyncS3QosDelete, opSyncDeleteDecommissionDisk,
+				opSyncDeleteMountProfile, opSyncDeleteClientEviction, opSyncDeleteVolTimelineEvent, opSyncDeleteFlashGroupAudit:
 				deleteSet[cmdK] = util.Null{}
 			// NOTE: opSyncPutFollowerApiLimiterInfo, opSyncPutApiLimiterInfo need special handle?
 			default:
@@ -156,7 +165,8 @@ func (mf *MetadataFsm) Apply(command []byte, index uint64) (resp interface{}, er
 	case opSyncDeleteDataNode, opSyncDeleteMetaNode, opSyncDeleteVol, opSyncDeleteDataPartition, opSyncDeleteMetaPartition,
 		opSyncDeleteUserInfo, opSyncDeleteAKUser, opSyncDeleteVolUser, opSyncDeleteQuota, opSyncDeleteLcNode,
 		opSyncDeleteLcConf, opSyncDeleteLcTask, opSyncDeleteLcResult, opSyncS3QosDelete, opSyncDeleteDecommissionDisk,
-		opSyncDeleteFlashNode, opSyncDeleteFlashGroup, opSyncDeleteFlashManualTask:
+		opSyncDeleteFlashNode, opSyncDeleteFlashGroup, opSyncDeleteFlashManualTask, opSyncDeleteMountProfile,
+		opSyncDeleteClientEviction, opSyncDeleteVolTimelineEvent, opSyncDeleteFlashGroupAudit:
 		if err = mf.delKeyAndPutIndex(cmd.K, cmdMap); err != nil {
 			panic(err)
 		}
@@ -176,6 +186,9 @@ func (mf *MetadataFsm) Apply(command []byte, index uint64) (resp interface{}, er
 	}
 	log.LogDebugf("action[Apply],persist index[%v]", string(cmdMap[applied]))
 	mf.applied = index
+	if mf.cmdAppliedHandler != nil {
+		mf.cmdAppliedHandler(command, index)
+	}
 
 	if mf.applied > 0 && (mf.applied%mf.retainLogs) == 0 {
 		log.LogWarnf("action[Apply],truncate raft log,retainLogs[%v],index[%v]", mf.retainLogs, mf.applied)
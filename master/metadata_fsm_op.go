@@ -63,6 +63,19 @@ type clusterValue struct {
 	DpMaxRepairErrCnt                      uint64
 	DpRepairTimeOut                        uint64
 	DpBackupTimeOut                        uint64
+	ReplicaTombstoneRetention              uint64
+	ClockSkewWarn                          uint64
+	ClockSkewLimit                         uint64
+	HotDpReadQps                           uint64
+	HotDpReadReplicas                      uint64
+	HeartbeatMaxInterval                   uint64
+	FlashGroupMinNodesPerZone              uint64
+	LeaderBalanceMaxTransfers              uint64
+	HotMpSplitQps                          uint64
+	HotMpSplitMemMB                        uint64
+	DecommissionBandwidthMBps              uint64
+	DataBalance                            proto.DataBalanceConfig
+	FlashCacheKey                          []byte
 	EnableAutoDecommissionDisk             bool
 	AutoDecommissionDiskInterval           int64
 	DecommissionDiskLimit                  uint32
@@ -82,6 +95,13 @@ type clusterValue struct {
 	AutoMpMigrate                          bool
 	FlashNodeHandleReadTimeout             int
 	FlashNodeReadDataNodeTimeout           int
+	FlashNodePeerFillEnable                bool
+	FlashNodePeerFillTimeout               int
+	FlashNodeAdmissionEnable               bool
+	FlashNodeReadLimitMBps                 int
+	FlashNodeReadLimitIops                 int
+	ZoneCosts                              []proto.ZoneCost
+	Maintenances                           []proto.Maintenance
 }
 
 func newClusterValue(c *Cluster) (cv *clusterValue) {
@@ -114,6 +134,19 @@ func newClusterValue(c *Cluster) (cv *clusterValue) {
 		DpMaxRepairErrCnt:                      c.cfg.DpMaxRepairErrCnt,
 		DpRepairTimeOut:                        c.cfg.DpRepairTimeOut,
 		DpBackupTimeOut:                        c.cfg.DpBackupTimeOut,
+		ReplicaTombstoneRetention:              atomic.LoadUint64(&c.cfg.ReplicaTombstoneRetention),
+		ClockSkewWarn:                          atomic.LoadUint64(&c.cfg.ClockSkewWarn),
+		ClockSkewLimit:                         atomic.LoadUint64(&c.cfg.ClockSkewLimit),
+		HotDpReadQps:                           atomic.LoadUint64(&c.cfg.HotDpReadQps),
+		HotDpReadReplicas:                      atomic.LoadUint64(&c.cfg.HotDpReadReplicas),
+		HeartbeatMaxInterval:                   atomic.LoadUint64(&c.cfg.HeartbeatMaxInterval),
+		FlashGroupMinNodesPerZone:              atomic.LoadUint64(&c.cfg.FlashGroupMinNodesPerZone),
+		LeaderBalanceMaxTransfers:              atomic.LoadUint64(&c.cfg.LeaderBalanceMaxTransfers),
+		HotMpSplitQps:                          atomic.LoadUint64(&c.cfg.HotMpSplitQps),
+		HotMpSplitMemMB:                        atomic.LoadUint64(&c.cfg.HotMpSplitMemMB),
+		DecommissionBandwidthMBps:              atomic.LoadUint64(&c.cfg.DecommissionBandwidthMBps),
+		DataBalance:                            c.dataBalancer.getConfig(),
+		FlashCacheKey:                          c.flashCacheKey.get(),
 		EnableAutoDecommissionDisk:             c.EnableAutoDecommissionDisk.Load(),
 		AutoDecommissionDiskInterval:           c.AutoDecommissionInterval.Load(),
 		DecommissionDiskLimit:                  c.GetDecommissionDiskLimit(),
@@ -133,6 +166,13 @@ func newClusterValue(c *Cluster) (cv *clusterValue) {
 		AutoMpMigrate:                          c.cfg.AutoMpMigrate,
 		FlashNodeHandleReadTimeout:             c.cfg.flashNodeHandleReadTimeout,
 		FlashNodeReadDataNodeTimeout:           c.cfg.flashNodeReadDataNodeTimeout,
+		FlashNodePeerFillEnable:                c.cfg.flashNodePeerFillEnable,
+		FlashNodePeerFillTimeout:               c.cfg.flashNodePeerFillTimeout,
+		FlashNodeAdmissionEnable:               c.cfg.flashNodeAdmissionEnable,
+		FlashNodeReadLimitMBps:                 c.cfg.flashNodeReadLimitMBps,
+		FlashNodeReadLimitIops:                 c.cfg.flashNodeReadLimitIops,
+		ZoneCosts:                              c.getZoneCosts(),
+		Maintenances:                           c.getMaintenances(),
 	}
 	return cv
 }
@@ -209,6 +249,7 @@ type dataPartitionValue struct {
 	DecommissionType               uint32
 	RestoreReplica                 uint32
 	MediaType                      uint32
+	ReadReplicas                   []*readReplicaValue
 }
 
 func (dpv *dataPartitionValue) Restore(c *Cluster) (dp *DataPartition) {
@@ -262,6 +303,9 @@ func (dpv *dataPartitionValue) Restore(c *Cluster) (dp *DataPartition) {
 	for disk, retryTimes := range dpv.DecommissionDiskRetryMap {
 		dp.DecommissionDiskRetryMap[disk] = retryTimes
 	}
+	for _, rv := range dpv.ReadReplicas {
+		dp.ReadReplicas = append(dp.ReadReplicas, newDataReadReplica(rv.Addr, rv.CreateTime))
+	}
 	return dp
 }
 
@@ -270,6 +314,11 @@ type replicaValue struct {
 	DiskPath string
 }
 
+type readReplicaValue struct {
+	Addr       string
+	CreateTime int64
+}
+
 func newDataPartitionValue(dp *DataPartition) (dpv *dataPartitionValue) {
 	dpv = &dataPartitionValue{
 		PartitionID:                    dp.PartitionID,
@@ -312,6 +361,9 @@ func newDataPartitionValue(dp *DataPartition) (dpv *dataPartitionValue) {
 		rv := &replicaValue{Addr: replica.Addr, DiskPath: replica.DiskPath}
 		dpv.Replicas = append(dpv.Replicas, rv)
 	}
+	for _, rr := range dp.ReadReplicas {
+		dpv.ReadReplicas = append(dpv.ReadReplicas, &readReplicaValue{Addr: rr.Addr, CreateTime: rr.CreateTime})
+	}
 	retryTimesMap := dp.cloneDecommissionDiskRetryMap()
 	for disk, retryTimes := range retryTimesMap {
 		dpv.DecommissionDiskRetryMap[disk] = retryTimes
@@ -332,6 +384,12 @@ type volValue struct {
 	MetaFollowerRead      bool
 	DirectRead            bool
 	IgnoreTinyRecover     bool
+	SyncMirrorWrite       bool
+	RequireTLS            bool
+	InlineDataThreshold   int64
+	SmallFileThreshold    int64
+	ConsistencyMode       string `json:",omitempty"`
+	MpWitnessNum          uint8
 	MaximallyRead         bool
 	Authenticate          bool
 	DpReadOnlyWhenVolFull bool
@@ -377,6 +435,15 @@ type volValue struct {
 	EnablePersistAccessTime                                bool
 
 	Forbidden            bool
+	Published            bool
+	PublishTime          int64
+	Interop              proto.InteropPolicy
+	SLO                  map[string]*proto.SLOTarget
+	PlacementExclusion   proto.PlacementExclusion
+	PlacementPolicy      proto.PlacementPolicy
+	Fences               []*proto.VolFence
+	ReclaimBoost         *proto.ReclaimBoost `json:",omitempty"`
+	ForkSources          []string            `json:",omitempty"`
 	DpRepairBlockSize    uint64
 	EnableAutoMetaRepair bool
 
@@ -393,9 +460,17 @@ type volValue struct {
 	RemoteCacheMaxFileSizeGB     int64
 	RemoteCacheOnlyForNotSSD     bool
 	RemoteCacheMultiRead         bool
+	RemoteCacheAlwaysAdmit       bool
+	RemoteCacheAuth              bool
+	RemoteCacheReadAheadMB       int64
+	RemoteCacheMetaTTL           int64
+	RemoteCacheReadLimitMBps     int64
+	RemoteCacheReadLimitIops     int64
 	FlashNodeTimeoutCount        int64
 	RemoteCacheSameZoneTimeout   int64
 	RemoteCacheSameRegionTimeout int64
+	RemoteCacheHedgeDelay        int64
+	RemoteCacheFallbackTimeout   int64
 }
 
 func (v *volValue) Bytes() (raw []byte, err error) {
@@ -422,6 +497,12 @@ func newVolValue(vol *Vol) (vv *volValue) {
 		MetaFollowerRead:        vol.MetaFollowerRead,
 		DirectRead:              vol.DirectRead,
 		IgnoreTinyRecover:       vol.IgnoreTinyRecover,
+		SyncMirrorWrite:         vol.SyncMirrorWrite,
+		RequireTLS:              vol.RequireTLS,
+		InlineDataThreshold:     vol.InlineDataThreshold,
+		SmallFileThreshold:      vol.SmallFileThreshold,
+		ConsistencyMode:         vol.ConsistencyMode,
+		MpWitnessNum:            vol.MpWitnessNum,
 		MaximallyRead:           vol.MaximallyRead,
 		LeaderRetryTimeOut:      vol.LeaderRetryTimeout,
 		Authenticate:            vol.authenticate,
@@ -462,6 +543,15 @@ func newVolValue(vol *Vol) (vv *volValue) {
 		TrashInterval:           vol.TrashInterval,
 		DisableAuditLog:         vol.DisableAuditLog,
 		Forbidden:               vol.Forbidden,
+		Published:               vol.Published,
+		PublishTime:             vol.PublishTime,
+		Interop:                 vol.interop,
+		SLO:                     vol.getSLO(),
+		PlacementExclusion:      vol.getPlacementExclusion(),
+		PlacementPolicy:         vol.getPlacementPolicy(),
+		Fences:                  vol.getFences(),
+		ReclaimBoost:            vol.getReclaimBoost(),
+		ForkSources:             vol.getForkSources(),
 		AuthKey:                 vol.authKey,
 		DeleteExecTime:          vol.DeleteExecTime,
 		User:                    vol.user,
@@ -481,9 +571,17 @@ func newVolValue(vol *Vol) (vv *volValue) {
 		RemoteCacheMaxFileSizeGB:     vol.remoteCacheMaxFileSizeGB,
 		RemoteCacheOnlyForNotSSD:     vol.remoteCacheOnlyForNotSSD,
 		RemoteCacheMultiRead:         vol.remoteCacheMultiRead,
+		RemoteCacheAlwaysAdmit:       vol.remoteCacheAlwaysAdmit,
+		RemoteCacheAuth:              vol.remoteCacheAuth,
+		RemoteCacheReadAheadMB:       vol.remoteCacheReadAheadMB,
+		RemoteCacheMetaTTL:           vol.remoteCacheMetaTTL,
+		RemoteCacheReadLimitMBps:     vol.remoteCacheReadLimitMBps,
+		RemoteCacheReadLimitIops:     vol.remoteCacheReadLimitIops,
 		FlashNodeTimeoutCount:        vol.flashNodeTimeoutCount,
 		RemoteCacheSameZoneTimeout:   vol.remoteCacheSameZoneTimeout,
 		RemoteCacheSameRegionTimeout: vol.remoteCacheSameRegionTimeout,
+		RemoteCacheHedgeDelay:        vol.remoteCacheHedgeDelay,
+		RemoteCacheFallbackTimeout:   vol.remoteCacheFallbackTimeout,
 	}
 	vv.AllowedStorageClass = make([]uint32, len(vol.allowedStorageClass))
 	copy(vv.AllowedStorageClass, vol.allowedStorageClass)
@@ -1154,6 +1252,10 @@ func (c *Cluster) updateDataPartitionBackupTimeOut(val uint64) {
 	atomic.StoreUint64(&c.cfg.DpBackupTimeOut, val)
 }
 
+func (c *Cluster) updateReplicaTombstoneRetention(val uint64) {
+	atomic.StoreUint64(&c.cfg.ReplicaTombstoneRetention, val)
+}
+
 func (c *Cluster) updateDataPartitionTimeoutSec(val int64) {
 	atomic.StoreInt64(&c.cfg.DataPartitionTimeOutSec, val)
 }
@@ -1243,6 +1345,7 @@ func (c *Cluster) loadZoneValue() (err error) {
 		if !proto.IsValidMediaType(zone.dataMediaType) {
 			zone.SetDataMediaType(c.legacyDataMediaType)
 		}
+		zone.setIsolation(cv.Isolation)
 
 		log.LogInfof("action[loadZoneValue] load zoneName[%v] with limit [%v,%v,%v,%v], dataMediaType[%v]",
 			zone.name, cv.QosFlowRLimit, cv.QosIopsWLimit, cv.QosFlowWLimit, cv.QosIopsRLimit,
@@ -1338,6 +1441,8 @@ func (c *Cluster) loadClusterValue() (err error) {
 		c.DecommissionFirstHostDiskParallelLimit = cv.DecommissionFirstHostDiskParallelLimit
 		c.fileStatsEnable = cv.FileStatsEnable
 		c.fileStatsThresholds = cv.FileStatsThresholds
+		c.zoneCosts.Store(cv.ZoneCosts)
+		c.maintenances.Store(cv.Maintenances)
 		c.clusterUuid = cv.ClusterUuid
 		c.clusterUuidEnable = cv.ClusterUuidEnable
 		c.DecommissionLimit = cv.DecommissionLimit
@@ -1378,6 +1483,19 @@ func (c *Cluster) loadClusterValue() (err error) {
 		c.updateDataPartitionMaxRepairErrCnt(cv.DpMaxRepairErrCnt)
 		c.updateDataPartitionRepairTimeOut(cv.DpRepairTimeOut)
 		c.updateDataPartitionBackupTimeOut(cv.DpBackupTimeOut)
+		c.updateReplicaTombstoneRetention(cv.ReplicaTombstoneRetention)
+		atomic.StoreUint64(&c.cfg.ClockSkewWarn, cv.ClockSkewWarn)
+		atomic.StoreUint64(&c.cfg.ClockSkewLimit, cv.ClockSkewLimit)
+		atomic.StoreUint64(&c.cfg.HotDpReadQps, cv.HotDpReadQps)
+		atomic.StoreUint64(&c.cfg.HotDpReadReplicas, cv.HotDpReadReplicas)
+		atomic.StoreUint64(&c.cfg.HeartbeatMaxInterval, cv.HeartbeatMaxInterval)
+		atomic.StoreUint64(&c.cfg.FlashGroupMinNodesPerZone, cv.FlashGroupMinNodesPerZone)
+		atomic.StoreUint64(&c.cfg.LeaderBalanceMaxTransfers, cv.LeaderBalanceMaxTransfers)
+		atomic.StoreUint64(&c.cfg.HotMpSplitQps, cv.HotMpSplitQps)
+		atomic.StoreUint64(&c.cfg.HotMpSplitMemMB, cv.HotMpSplitMemMB)
+		atomic.StoreUint64(&c.cfg.DecommissionBandwidthMBps, cv.DecommissionBandwidthMBps)
+		c.dataBalancer.loadConfig(cv.DataBalance)
+		c.flashCacheKey.load(cv.FlashCacheKey)
 		c.updateMaxDpCntLimit(cv.MaxDpCntLimit)
 		c.updateMaxMpCntLimit(cv.MaxMpCntLimit)
 		if cv.MetaPartitionInodeIdStep == 0 {
@@ -1421,6 +1539,20 @@ func (c *Cluster) loadClusterValue() (err error) {
 		c.cfg.flashNodeReadDataNodeTimeout = cv.FlashNodeReadDataNodeTimeout
 		log.LogInfof("action[loadClusterValue] flashNodeHandleReadTimeout %v(ms), flashNodeReadDataNodeTimeout%v(ms)",
 			cv.FlashNodeHandleReadTimeout, cv.FlashNodeReadDataNodeTimeout)
+
+		if cv.FlashNodePeerFillTimeout == 0 {
+			cv.FlashNodePeerFillTimeout = defaultFlashNodePeerFillTimeout
+		}
+		c.cfg.flashNodePeerFillTimeout = cv.FlashNodePeerFillTimeout
+		c.cfg.flashNodePeerFillEnable = cv.FlashNodePeerFillEnable
+		log.LogInfof("action[loadClusterValue] flashNodePeerFillEnable %v, flashNodePeerFillTimeout %v(ms)",
+			cv.FlashNodePeerFillEnable, cv.FlashNodePeerFillTimeout)
+		c.cfg.flashNodeAdmissionEnable = cv.FlashNodeAdmissionEnable
+		log.LogInfof("action[loadClusterValue] flashNodeAdmissionEnable %v", cv.FlashNodeAdmissionEnable)
+		c.cfg.flashNodeReadLimitMBps = cv.FlashNodeReadLimitMBps
+		c.cfg.flashNodeReadLimitIops = cv.FlashNodeReadLimitIops
+		log.LogInfof("action[loadClusterValue] flashNodeReadLimitMBps %v, flashNodeReadLimitIops %v",
+			cv.FlashNodeReadLimitMBps, cv.FlashNodeReadLimitIops)
 	}
 
 	return
@@ -2346,3 +2478,163 @@ func (c *Cluster) loadFlashManualTasks() (err error) {
 	}
 	return
 }
+
+func (c *Cluster) syncSetMountProfile(profile *proto.MountProfile) (err error) {
+	return c.syncPutMountProfile(opSyncSetMountProfile, profile)
+}
+
+func (c *Cluster) syncDeleteMountProfile(profile *proto.MountProfile) (err error) {
+	return c.syncPutMountProfile(opSyncDeleteMountProfile, profile)
+}
+
+func (c *Cluster) syncPutMountProfile(opType uint32, profile *proto.MountProfile) (err error) {
+	metadata := new(RaftCmd)
+	metadata.Op = opType
+	metadata.K = mountProfilePrefix + profile.Name
+	metadata.V, err = json.Marshal(profile)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return c.submit(metadata)
+}
+
+func (c *Cluster) loadMountProfiles() (err error) {
+	result, err := c.fsm.store.SeekForPrefix([]byte(mountProfilePrefix))
+	if err != nil {
+		err = fmt.Errorf("action[loadMountProfiles],err:%v", err.Error())
+		return err
+	}
+
+	profiles := make([]*proto.MountProfile, 0, len(result))
+	for _, value := range result {
+		profile := &proto.MountProfile{}
+		if err = json.Unmarshal(value, profile); err != nil {
+			err = fmt.Errorf("action[loadMountProfiles],value:%v,unmarshal err:%v", string(value), err)
+			return
+		}
+		profiles = append(profiles, profile)
+		log.LogInfof("action[loadMountProfiles],profile[%v]", profile.Name)
+	}
+	c.mountProfiles.reset(profiles)
+	return
+}
+
+func (c *Cluster) syncPutClientEviction(e *proto.ClientEviction) (err error) {
+	return c.syncClientEviction(opSyncPutClientEviction, e)
+}
+
+func (c *Cluster) syncDeleteClientEviction(e *proto.ClientEviction) (err error) {
+	return c.syncClientEviction(opSyncDeleteClientEviction, e)
+}
+
+func (c *Cluster) syncClientEviction(opType uint32, e *proto.ClientEviction) (err error) {
+	metadata := new(RaftCmd)
+	metadata.Op = opType
+	metadata.K = clientEvictionPrefix + e.Key()
+	metadata.V, err = json.Marshal(e)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return c.submit(metadata)
+}
+
+func (c *Cluster) loadClientEvictions() (err error) {
+	result, err := c.fsm.store.SeekForPrefix([]byte(clientEvictionPrefix))
+	if err != nil {
+		err = fmt.Errorf("action[loadClientEvictions],err:%v", err.Error())
+		return err
+	}
+
+	evictions := make([]*proto.ClientEviction, 0, len(result))
+	for _, value := range result {
+		e := &proto.ClientEviction{}
+		if err = json.Unmarshal(value, e); err != nil {
+			err = fmt.Errorf("action[loadClientEvictions],value:%v,unmarshal err:%v", string(value), err)
+			return
+		}
+		evictions = append(evictions, e)
+		log.LogInfof("action[loadClientEvictions],eviction[%v]", e.Key())
+	}
+	c.clientEvictions.reset(evictions)
+	return
+}
+
+func (c *Cluster) syncPutVolTimelineEvent(e *proto.VolTimelineEvent) (err error) {
+	return c.syncVolTimelineEvent(opSyncPutVolTimelineEvent, e)
+}
+
+func (c *Cluster) syncDeleteVolTimelineEvent(e *proto.VolTimelineEvent) (err error) {
+	return c.syncVolTimelineEvent(opSyncDeleteVolTimelineEvent, e)
+}
+
+func (c *Cluster) syncVolTimelineEvent(opType uint32, e *proto.VolTimelineEvent) (err error) {
+	metadata := new(RaftCmd)
+	metadata.Op = opType
+	metadata.K = volTimelinePrefix + e.Key()
+	metadata.V, err = json.Marshal(e)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return c.submit(metadata)
+}
+
+func (c *Cluster) loadVolTimeline() (err error) {
+	result, err := c.fsm.store.SeekForPrefix([]byte(volTimelinePrefix))
+	if err != nil {
+		err = fmt.Errorf("action[loadVolTimeline],err:%v", err.Error())
+		return err
+	}
+
+	events := make([]*proto.VolTimelineEvent, 0, len(result))
+	for _, value := range result {
+		e := &proto.VolTimelineEvent{}
+		if err = json.Unmarshal(value, e); err != nil {
+			err = fmt.Errorf("action[loadVolTimeline],value:%v,unmarshal err:%v", string(value), err)
+			return
+		}
+		events = append(events, e)
+	}
+	log.LogInfof("action[loadVolTimeline],events[%v]", len(events))
+	c.volTimeline.reset(events)
+	return
+}
+
+func (c *Cluster) syncPutFlashGroupAudit(record *proto.FlashGroupAuditRecord) (err error) {
+	return c.syncFlashGroupAudit(opSyncPutFlashGroupAudit, record)
+}
+
+func (c *Cluster) syncDeleteFlashGroupAudit(record *proto.FlashGroupAuditRecord) (err error) {
+	return c.syncFlashGroupAudit(opSyncDeleteFlashGroupAudit, record)
+}
+
+func (c *Cluster) syncFlashGroupAudit(opType uint32, record *proto.FlashGroupAuditRecord) (err error) {
+	metadata := new(RaftCmd)
+	metadata.Op = opType
+	metadata.K = flashGroupAuditPrefix + record.Key()
+	metadata.V, err = json.Marshal(record)
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return c.submit(metadata)
+}
+
+func (c *Cluster) loadFlashGroupAudit() (err error) {
+	result, err := c.fsm.store.SeekForPrefix([]byte(flashGroupAuditPrefix))
+	if err != nil {
+		err = fmt.Errorf("action[loadFlashGroupAudit],err:%v", err.Error())
+		return err
+	}
+
+	records := make([]*proto.FlashGroupAuditRecord, 0, len(result))
+	for _, value := range result {
+		record := &proto.FlashGroupAuditRecord{}
+		if err = json.Unmarshal(value, record); err != nil {
+			err = fmt.Errorf("action[loadFlashGroupAudit],value:%v,unmarshal err:%v", string(value), err)
+			return
+		}
+		records = append(records, record)
+	}
+	log.LogInfof("action[loadFlashGroupAudit],records[%v]", len(records))
+	c.flashGroupAudit.reset(records)
+	return
+}
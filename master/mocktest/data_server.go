@@ -277,6 +277,7 @@ func (mds *MockDataServer) checkVolDpRepairBlockSize(repairSize map[string]uint6
 func (mds *MockDataServer) handleHeartbeats(conn net.Conn, pkg *proto.Packet, task *proto.AdminTask) (err error) {
 	responseAckOKToMaster(conn, pkg, nil)
 	response := &proto.DataNodeHeartbeatResponse{}
+	response.Clock.RecvTime = time.Now().UnixNano()
 	req := &proto.HeartBeatRequest{}
 	reqData, err := json.Marshal(task.Request)
 	if err != nil {
@@ -297,6 +298,7 @@ func (mds *MockDataServer) handleHeartbeats(conn net.Conn, pkg *proto.Packet, ta
 	response.TotalPartitionSize = 120 * util.GB
 	response.MaxCapacity = 800 * util.GB
 	response.RemainingCapacity = 800 * util.GB
+	response.Features = proto.DataNodeFeatures
 
 	response.ZoneName = mds.zoneName
 	response.PartitionReports = make([]*proto.DataPartitionReport, 0)
@@ -326,6 +328,7 @@ func (mds *MockDataServer) handleHeartbeats(conn net.Conn, pkg *proto.Packet, ta
 	}
 	mds.RUnlock()
 
+	response.Clock.ReplyTime = time.Now().UnixNano()
 	task.Response = response
 end:
 	if err = mds.mc.NodeAPI().ResponseDataNodeTask(task); err != nil {
@@ -24,6 +24,25 @@ import (
 	"github.com/cubefs/cubefs/sdk/master"
 )
 
+// DefaultFlashCacheCapacity is the cache capacity a mock flashnode reports.
+const DefaultFlashCacheCapacity int64 = 100 << 30
+
+// FlashDiskCacheStat is the cache stat of the disk a mock flashnode reports.
+func FlashDiskCacheStat(capacity int64) *proto.FlashNodeDiskCacheStat {
+	return &proto.FlashNodeDiskCacheStat{
+		DataPath:  "/cfs/flash",
+		Total:     capacity,
+		MaxAlloc:  capacity,
+		HasAlloc:  capacity / 10,
+		FreeSpace: capacity - capacity/10,
+		HitRate:   0.9,
+		Hits:      90,
+		Misses:    10,
+		Evicts:    2,
+		KeyNum:    100,
+	}
+}
+
 type MockFlashServer struct {
 	nodeID    uint64
 	TCPAddr   string
@@ -31,16 +50,19 @@ type MockFlashServer struct {
 	Available uint64
 	zoneName  string
 
+	CacheCapacity int64
+
 	stopCh chan struct{}
 	mc     *master.MasterClient
 }
 
 func NewMockFlashServer(addr, zoneName string) *MockFlashServer {
 	return &MockFlashServer{
-		TCPAddr:  addr,
-		zoneName: zoneName,
-		mc:       master.NewMasterClient([]string{hostAddr}, false),
-		stopCh:   make(chan struct{}),
+		TCPAddr:       addr,
+		zoneName:      zoneName,
+		CacheCapacity: DefaultFlashCacheCapacity,
+		mc:            master.NewMasterClient([]string{hostAddr}, false),
+		stopCh:        make(chan struct{}),
 	}
 }
 
@@ -129,9 +151,12 @@ func (mfs *MockFlashServer) handleHeartbeats(conn net.Conn, pkg *proto.Packet, t
 		return
 	}
 	resp := &proto.FlashNodeHeartbeatResponse{}
-	resp.Stat = make([]*proto.FlashNodeDiskCacheStat, 0)
+	resp.Stat = []*proto.FlashNodeDiskCacheStat{FlashDiskCacheStat(mfs.CacheCapacity)}
+	resp.CacheStat = new(proto.FlashNodeCacheStat)
+	resp.CacheStat.AddDisk(resp.Stat[0])
 	resp.LimiterStatus = &proto.FlashNodeLimiterStatusInfo{}
 	resp.FlashNodeTaskCountLimit = 8
+	resp.CacheCapacity = mfs.CacheCapacity
 	resp.ManualScanningTasks = make(map[string]*proto.FlashNodeManualTaskResponse)
 	resp.Status = proto.TaskSucceeds
 	task.Response = resp
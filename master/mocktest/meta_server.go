@@ -267,6 +267,7 @@ func (mms *MockMetaServer) handleHeartbeats(conn net.Conn, p *proto.Packet, admi
 	}
 	resp.Total = 10 * util.GB
 	resp.Used = 1 * util.GB
+	resp.Features = proto.MetaNodeFeatures
 	// every partition used
 	mms.RLock()
 	for id, partition := range mms.partitions {
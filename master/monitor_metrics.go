@@ -97,6 +97,10 @@ const (
 	MetricLcVolMigrateBytes = "lc_vol_migrate_bytes"
 	MetricLcVolError        = "lc_vol_error"
 
+	MetricVolSLOAttainment  = "vol_slo_attainment"
+	MetricVolSLOErrorBudget = "vol_slo_error_budget_remaining"
+	MetricVolSLOViolated    = "vol_slo_violated"
+
 	MetricDiskDecommissionSuccess = "disk_decommission_success"
 )
 
@@ -175,6 +179,10 @@ type monitorMetrics struct {
 	lcVolMigrateBytes *exporter.GaugeVec
 	lcVolError        *exporter.GaugeVec
 
+	volSLOAttainment  *exporter.GaugeVec
+	volSLOErrorBudget *exporter.GaugeVec
+	volSLOViolated    *exporter.GaugeVec
+
 	diskDecommissionSuccess *exporter.GaugeVec
 }
 
@@ -555,6 +563,10 @@ func (mm *monitorMetrics) start() {
 	mm.lcVolMigrateBytes = exporter.NewGaugeVec(MetricLcVolMigrateBytes, "", []string{"id", "type"})
 	mm.lcVolError = exporter.NewGaugeVec(MetricLcVolError, "", []string{"id", "type"})
 
+	mm.volSLOAttainment = exporter.NewGaugeVec(MetricVolSLOAttainment, "", []string{"volName", "op"})
+	mm.volSLOErrorBudget = exporter.NewGaugeVec(MetricVolSLOErrorBudget, "", []string{"volName", "op"})
+	mm.volSLOViolated = exporter.NewGaugeVec(MetricVolSLOViolated, "", []string{"volName", "op"})
+
 	mm.diskDecommissionSuccess = exporter.NewGaugeVec(MetricDiskDecommissionSuccess, "", []string{"addr", "path"})
 	go mm.statMetrics()
 }
@@ -628,6 +640,7 @@ func (mm *monitorMetrics) doStat() {
 	mm.setMpAndDpMetrics()
 	mm.setNodesetMetrics()
 	mm.setLcMetrics()
+	mm.setSLOMetrics()
 	mm.setDiskDecommissionedMetric()
 	mm.updateDataNodesStat()
 	mm.updateMetaNodesStat()
@@ -1229,6 +1242,33 @@ func (mm *monitorMetrics) setLcMetrics() {
 	}
 }
 
+func (mm *monitorMetrics) setSLOMetrics() {
+	mm.clearSLOMetrics()
+	now := time.Now()
+	for _, vol := range mm.cluster.allVols() {
+		slo := vol.getSLO()
+		if len(slo) == 0 {
+			continue
+		}
+		st := mm.cluster.sloTracker.status(now, vol.Name, slo)
+		for op, opStat := range st.Ops {
+			mm.volSLOAttainment.SetWithLabelValues(opStat.Attainment, vol.Name, op)
+			mm.volSLOErrorBudget.SetWithLabelValues(opStat.ErrorBudgetRemaining, vol.Name, op)
+			violated := 0.0
+			if opStat.Violated {
+				violated = 1
+			}
+			mm.volSLOViolated.SetWithLabelValues(violated, vol.Name, op)
+		}
+	}
+}
+
+func (mm *monitorMetrics) clearSLOMetrics() {
+	mm.volSLOAttainment.Reset()
+	mm.volSLOErrorBudget.Reset()
+	mm.volSLOViolated.Reset()
+}
+
 func (mm *monitorMetrics) clearLcMetrics() {
 	for vol := range mm.lcId {
 		mm.deleteS3LcVolMetric(vol)
@@ -1350,6 +1390,7 @@ func (mm *monitorMetrics) resetAllLeaderMetrics() {
 	mm.metaEqualCheckFail.Reset()
 	mm.clearNodesetMetrics()
 	mm.clearLcMetrics()
+	mm.clearSLOMetrics()
 
 	mm.dataNodesCount.Set(0)
 	mm.metaNodesCount.Set(0)
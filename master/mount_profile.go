@@ -0,0 +1,117 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// mountProfileStore keeps the mount profiles in memory, they are persisted by raft
+// and loaded again by the new leader.
+type mountProfileStore struct {
+	sync.RWMutex
+	profiles map[string]*proto.MountProfile
+}
+
+func newMountProfileStore() *mountProfileStore {
+	return &mountProfileStore{profiles: make(map[string]*proto.MountProfile)}
+}
+
+func (s *mountProfileStore) get(name string) (profile *proto.MountProfile, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	profile, ok = s.profiles[name]
+	return
+}
+
+func (s *mountProfileStore) put(profile *proto.MountProfile) {
+	s.Lock()
+	defer s.Unlock()
+	s.profiles[profile.Name] = profile
+}
+
+func (s *mountProfileStore) delete(name string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.profiles, name)
+}
+
+func (s *mountProfileStore) list() (profiles []*proto.MountProfile) {
+	s.RLock()
+	defer s.RUnlock()
+	profiles = make([]*proto.MountProfile, 0, len(s.profiles))
+	for _, profile := range s.profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return
+}
+
+func (s *mountProfileStore) reset(profiles []*proto.MountProfile) {
+	s.Lock()
+	defer s.Unlock()
+	s.profiles = make(map[string]*proto.MountProfile, len(profiles))
+	for _, profile := range profiles {
+		s.profiles[profile.Name] = profile
+	}
+}
+
+func (c *Cluster) getMountProfile(name string) (profile *proto.MountProfile, err error) {
+	profile, ok := c.mountProfiles.get(name)
+	if !ok {
+		return nil, fmt.Errorf("mount profile[%v] not found", name)
+	}
+	return
+}
+
+// setMountProfile creates the profile or replaces the options of the existing one,
+// clients pick the change up when they mount next time.
+func (c *Cluster) setMountProfile(name string, options map[string]string) (profile *proto.MountProfile, err error) {
+	profile = &proto.MountProfile{
+		Name:       name,
+		Options:    options,
+		UpdateTime: time.Now().Unix(),
+	}
+	if err = profile.Validate(); err != nil {
+		return nil, err
+	}
+	if err = c.syncSetMountProfile(profile); err != nil {
+		log.LogErrorf("action[setMountProfile] persist profile[%v] err:%v", name, err)
+		return nil, err
+	}
+	c.mountProfiles.put(profile)
+	log.LogInfof("action[setMountProfile] profile[%v] options[%v]", name, proto.FormatMountProfileOptions(options))
+	return
+}
+
+func (c *Cluster) deleteMountProfile(name string) (err error) {
+	profile, err := c.getMountProfile(name)
+	if err != nil {
+		return
+	}
+	if err = c.syncDeleteMountProfile(profile); err != nil {
+		log.LogErrorf("action[deleteMountProfile] delete profile[%v] err:%v", name, err)
+		return
+	}
+	c.mountProfiles.delete(name)
+	log.LogInfof("action[deleteMountProfile] profile[%v]", name)
+	return
+}
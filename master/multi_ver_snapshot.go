@@ -210,6 +210,16 @@ func (verMgr *VolVersionManager) SetVerStrategy(strategy proto.VolumeVerStrategy
 		if isForce {
 			verMgr.strategy.ForceUpdate = strategy.ForceUpdate
 		}
+		immutableSec := verMgr.strategy.ImmutableSec
+		if strategy.ImmutableSec >= 0 {
+			immutableSec = strategy.ImmutableSec
+		}
+		// the volume would never be mutable with a window of the period
+		if immutableSec >= verMgr.strategy.GetPeriodicSecond() {
+			return fmt.Errorf("SetVerStrategy.vol %v immutableSec %v need less than periodic %v hours",
+				verMgr.vol.Name, immutableSec, verMgr.strategy.GetPeriodic())
+		}
+		verMgr.strategy.ImmutableSec = immutableSec
 	}
 
 	verMgr.strategy.Enable = strategy.Enable
@@ -245,12 +255,29 @@ func (verMgr *VolVersionManager) checkCreateStrategy(c *Cluster) {
 			return
 		}
 		verMgr.strategy.UTime = time.Now()
+		if verMgr.strategy.ImmutableSec > 0 {
+			verMgr.strategy.ImmutableUntil = verMgr.strategy.UTime.Unix() + int64(verMgr.strategy.ImmutableSec)
+			log.LogWarnf("checkSnapshotStrategy.vol %v immutable until %v", verMgr.vol.Name,
+				time.Unix(verMgr.strategy.ImmutableUntil, 0).Format(proto.TimeFormat))
+		}
 		if err := verMgr.Persist(); err != nil {
 			log.LogErrorf("vol %v call persist error %v", verMgr.vol.Name, err)
 		}
 	}
 }
 
+// immutableUntil returns the unix second until which the metadata of the volume
+// is immutable after the last snapshot of the strategy, 0 if it is mutable. The
+// window is kept once started, even if the strategy is changed.
+func (verMgr *VolVersionManager) immutableUntil(now time.Time) int64 {
+	verMgr.RLock()
+	defer verMgr.RUnlock()
+	if !verMgr.strategy.IsImmutable(now) {
+		return 0
+	}
+	return verMgr.strategy.ImmutableUntil
+}
+
 func (verMgr *VolVersionManager) checkDeleteStrategy(c *Cluster) {
 	verMgr.RLock()
 	log.LogDebugf("checkSnapshotStrategy.vol %v try delete snapshot nLen %v, keep cnt %v", verMgr.vol.Name, len(verMgr.multiVersionList)-1, verMgr.strategy.KeepVerCnt)
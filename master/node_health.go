@@ -0,0 +1,155 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	nodeHealthMaxScore = 100
+	// a node scored below is poor, only used when there are not enough
+	// others, until its score recovers
+	nodeHealthPoorScore    = 50
+	nodeHealthRecoverScore = 70
+	// the flaps and the task results count half as much after it
+	nodeHealthHalfLife = time.Hour
+	// the admin tasks a failure rate is judged on at least
+	nodeHealthMinTasks = 5
+
+	nodeHealthJitterPenalty = 25
+	nodeHealthFlapPenalty   = 30
+	nodeHealthTaskPenalty   = 20
+	nodeHealthDiskPenalty   = 15
+	nodeHealthLoadPenalty   = 10
+
+	nodeHealthFlapsToMax      = 3   // flaps to take the full penalty
+	nodeHealthDiskErrorsToMax = 2   // bad disks to take the full penalty
+	nodeHealthLoadedRatio     = 0.8 // load penalized above
+	nodeHealthJitterAlpha     = 0.2 // weight of the last heartbeat in the jitter
+)
+
+// nodeHealth scores a node from 0 to nodeHealthMaxScore on how it behaved
+// lately, rather than only on whether its last heartbeat is recent: the jitter
+// of its heartbeats, how often it went inactive, the failure rate of the
+// admin tasks sent to it, its bad disks and its load. The history decays, so
+// a node flapping in and out of service keeps a low score for a while instead
+// of being used again on its first heartbeat. The zero value is a healthy
+// node without history.
+type nodeHealth struct {
+	sync.Mutex
+	lastReport time.Time
+	interval   time.Duration // between the heartbeats expected
+	jitter     float64       // moving average of the deviation of the heartbeat interval, in seconds
+	flaps      float64
+	tasks      float64
+	taskFails  float64
+	decayedAt  time.Time
+	diskErrors int
+	load       float64 // 0 idle to 1 saturated
+	poor       bool
+}
+
+func (h *nodeHealth) decay(now time.Time) {
+	if !h.decayedAt.IsZero() {
+		factor := math.Exp2(-float64(now.Sub(h.decayedAt)) / float64(nodeHealthHalfLife))
+		h.flaps *= factor
+		h.tasks *= factor
+		h.taskFails *= factor
+	}
+	h.decayedAt = now
+}
+
+// heartbeat records a heartbeat of the node, expected every interval.
+func (h *nodeHealth) heartbeat(now time.Time, interval time.Duration, diskErrors int, load float64) {
+	h.Lock()
+	defer h.Unlock()
+	if !h.lastReport.IsZero() {
+		deviation := math.Abs((now.Sub(h.lastReport) - interval).Seconds())
+		h.jitter += nodeHealthJitterAlpha * (deviation - h.jitter)
+	}
+	h.lastReport = now
+	h.interval = interval
+	h.diskErrors = diskErrors
+	h.load = load
+	h.decay(now)
+	h.refresh()
+}
+
+// inactive records the node going inactive on missing heartbeats.
+func (h *nodeHealth) inactive(now time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	h.decay(now)
+	h.flaps++
+	h.refresh()
+}
+
+// taskDone records the result of an admin task sent to the node.
+func (h *nodeHealth) taskDone(now time.Time, failed bool) {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	h.decay(now)
+	h.tasks++
+	if failed {
+		h.taskFails++
+	}
+	h.refresh()
+}
+
+func (h *nodeHealth) computeScore() int {
+	interval := h.interval
+	if interval <= 0 {
+		interval = time.Second * defaultIntervalToCheckHeartbeat
+	}
+	penalty := nodeHealthJitterPenalty * math.Min(1, h.jitter/interval.Seconds())
+	penalty += nodeHealthFlapPenalty * math.Min(1, h.flaps/nodeHealthFlapsToMax)
+	if h.tasks >= nodeHealthMinTasks {
+		penalty += nodeHealthTaskPenalty * h.taskFails / h.tasks
+	}
+	penalty += nodeHealthDiskPenalty * math.Min(1, float64(h.diskErrors)/nodeHealthDiskErrorsToMax)
+	if h.load > nodeHealthLoadedRatio {
+		penalty += nodeHealthLoadPenalty * math.Min(1, (h.load-nodeHealthLoadedRatio)/(1-nodeHealthLoadedRatio))
+	}
+	return nodeHealthMaxScore - int(math.Round(penalty))
+}
+
+// refresh moves the node in or out of the poor ones, with a margin so that a
+// score around the bound does not make it oscillate.
+func (h *nodeHealth) refresh() {
+	score := h.computeScore()
+	if score < nodeHealthPoorScore {
+		h.poor = true
+	} else if score >= nodeHealthRecoverScore {
+		h.poor = false
+	}
+}
+
+func (h *nodeHealth) score() int {
+	h.Lock()
+	defer h.Unlock()
+	return h.computeScore()
+}
+
+func (h *nodeHealth) isPoor() bool {
+	h.Lock()
+	defer h.Unlock()
+	return h.poor
+}
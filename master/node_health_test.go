@@ -0,0 +1,104 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeHealthScore(t *testing.T) {
+	interval := time.Second * defaultIntervalToCheckHeartbeat
+	now := time.Now()
+	h := &nodeHealth{}
+	require.Equal(t, nodeHealthMaxScore, h.score())
+
+	// steady heartbeats
+	for i := 0; i < 10; i++ {
+		now = now.Add(interval)
+		h.heartbeat(now, interval, 0, 0.1)
+	}
+	require.Equal(t, nodeHealthMaxScore, h.score())
+	require.False(t, h.isPoor())
+
+	// a single flap lowers the score, but the node is still used
+	h.inactive(now)
+	now = now.Add(10 * interval)
+	h.heartbeat(now, interval, 0, 0.1)
+	require.Less(t, h.score(), nodeHealthMaxScore)
+	require.False(t, h.isPoor())
+
+	// flapping makes it poor
+	for i := 0; i < 3; i++ {
+		h.inactive(now)
+		now = now.Add(10 * interval)
+		h.heartbeat(now, interval, 0, 0.1)
+	}
+	require.Less(t, h.score(), nodeHealthPoorScore)
+	require.True(t, h.isPoor())
+
+	// steady again with a bad disk, it stays poor until the score passes the
+	// recover bound
+	for i := 0; i < 30; i++ {
+		now = now.Add(interval)
+		h.heartbeat(now, interval, 1, 0.1)
+	}
+	require.GreaterOrEqual(t, h.score(), nodeHealthPoorScore)
+	require.Less(t, h.score(), nodeHealthRecoverScore)
+	require.True(t, h.isPoor())
+	now = now.Add(2 * nodeHealthHalfLife)
+	h.heartbeat(now, interval, 1, 0.1)
+	for i := 0; i < 30; i++ {
+		now = now.Add(interval)
+		h.heartbeat(now, interval, 1, 0.1)
+	}
+	require.GreaterOrEqual(t, h.score(), nodeHealthRecoverScore)
+	require.False(t, h.isPoor())
+
+	// failed tasks, bad disks and load
+	h = &nodeHealth{}
+	for i := 0; i < nodeHealthMinTasks; i++ {
+		h.taskDone(now, true)
+	}
+	require.Equal(t, nodeHealthMaxScore-nodeHealthTaskPenalty, h.score())
+	h.heartbeat(now, interval, nodeHealthDiskErrorsToMax, 1)
+	require.Equal(t, nodeHealthMaxScore-nodeHealthTaskPenalty-nodeHealthDiskPenalty-nodeHealthLoadPenalty, h.score())
+
+	var untracked *nodeHealth
+	untracked.taskDone(now, true)
+}
+
+func TestNodeSelectorPreferHealthy(t *testing.T) {
+	nset := prepareDataNodesForBench(4, 100*util.GB, 100*util.GB)
+	val, _ := nset.dataNodes.Load("Datanode: 3")
+	poor := val.(*DataNode)
+	poor.health.poor = true
+
+	for _, name := range []string{CarryWeightNodeSelectorName, AvailableSpaceFirstNodeSelectorName, StrawNodeSelectorName} {
+		selector := NewNodeSelector(name, DataNodeType)
+		for i := 0; i < 10; i++ {
+			hosts, _, err := selector.Select(nset, nil, 3)
+			require.NoError(t, err)
+			require.NotContains(t, hosts, poor.Addr, name)
+		}
+		// used if there are not enough others
+		hosts, _, err := selector.Select(nset, nil, 4)
+		require.NoError(t, err)
+		require.Contains(t, hosts, poor.Addr, name)
+	}
+}
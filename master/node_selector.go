@@ -78,6 +78,7 @@ type Node interface {
 	GetStorageInfo() string
 	IsOffline() bool
 	GetZoneName() string
+	getHealth() *nodeHealth
 }
 
 // SortedWeightedNodes defines an array sorted by carry
@@ -95,10 +96,53 @@ func (nodes SortedWeightedNodes) Swap(i, j int) {
 	nodes[i], nodes[j] = nodes[j], nodes[i]
 }
 
+// preferHealthy leaves out the nodes of poor health like preferHealthyNodes,
+// and recounts the carry nodes.
+func (nodes SortedWeightedNodes) preferHealthy(replicaNum, carryCount int) (SortedWeightedNodes, int) {
+	healthy := make(SortedWeightedNodes, 0, len(nodes))
+	healthyCarryCount := 0
+	for _, nt := range nodes {
+		if nt.Ptr.getHealth().isPoor() {
+			continue
+		}
+		healthy = append(healthy, nt)
+		if nt.Carry >= 1.0 {
+			healthyCarryCount++
+		}
+	}
+	if len(healthy) < replicaNum || len(healthy) == len(nodes) {
+		return nodes, carryCount
+	}
+	log.LogInfof("[preferHealthy] left out %v nodes of poor health", len(nodes)-len(healthy))
+	return healthy, healthyCarryCount
+}
+
 func canAllocPartition(node Node) bool {
 	return node.IsWriteAble() && node.PartitionCntLimited()
 }
 
+// nodeHealthWeight scales the weight of a node by its health score, never to
+// zero so that the carry of a node still grows.
+func nodeHealthWeight(node Node) float64 {
+	return math.Max(0.1, float64(node.getHealth().score())/nodeHealthMaxScore)
+}
+
+// preferHealthyNodes leaves out the nodes of poor health, unless there are not
+// enough others to place the replicas on.
+func preferHealthyNodes(nodes []Node, replicaNum int) []Node {
+	healthy := make([]Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !node.getHealth().isPoor() {
+			healthy = append(healthy, node)
+		}
+	}
+	if len(healthy) < replicaNum || len(healthy) == len(nodes) {
+		return nodes
+	}
+	log.LogInfof("[preferHealthyNodes] left out %v nodes of poor health", len(nodes)-len(healthy))
+	return healthy
+}
+
 func asNodeWrap(node interface{}, nodeType NodeType) Node {
 	switch nodeType {
 	case DataNodeType:
@@ -179,7 +223,7 @@ func (s *CarryWeightNodeSelector) getCarryNodes(nset *nodeSet, maxTotal uint64,
 
 		nt := new(weightedNode)
 		nt.Carry = s.carry[node.GetID()]
-		nt.Weight = float64(node.GetTotal()-node.GetUsed()) / float64(maxTotal)
+		nt.Weight = float64(node.GetTotal()-node.GetUsed()) / float64(maxTotal) * nodeHealthWeight(node)
 		nt.Ptr = node
 		nodeTabs = append(nodeTabs, nt)
 		return true
@@ -231,6 +275,7 @@ func (s *CarryWeightNodeSelector) Select(ns *nodeSet, excludeHosts []string, rep
 			s.GetName(), replicaNum, len(weightedNodes))
 		return
 	}
+	weightedNodes, count = weightedNodes.preferHealthy(replicaNum, count)
 	// create enough carry nodes
 	// we say a node is "carry node", when its carry >= 1.0
 	s.setNodeCarry(weightedNodes, count, replicaNum)
@@ -300,6 +345,7 @@ func (s *AvailableSpaceFirstNodeSelector) Select(ns *nodeSet, excludeHosts []str
 			s.GetName(), replicaNum, len(sortedNodes))
 		return
 	}
+	sortedNodes = preferHealthyNodes(sortedNodes, replicaNum)
 	// sort nodes by available space
 	sort.Slice(sortedNodes, func(i, j int) bool {
 		return s.getNodeAvailableSpace(sortedNodes[i]) > s.getNodeAvailableSpace(sortedNodes[j])
@@ -463,7 +509,7 @@ func (s *StrawNodeSelector) GetName() string {
 }
 
 func (s *StrawNodeSelector) getWeight(node Node) float64 {
-	return float64(node.GetAvailableSpace()) / util.GB
+	return float64(node.GetAvailableSpace()) / util.GB * nodeHealthWeight(node)
 }
 
 // select a node with max straw and it's ip didn't exist in excludedIpSet
@@ -515,6 +561,7 @@ func (s *StrawNodeSelector) Select(ns *nodeSet, excludeHosts []string, replicaNu
 			s.GetName(), replicaNum, len(nodes))
 		return
 	}
+	nodes = preferHealthyNodes(nodes, replicaNum)
 
 	distinctIpSet := make(map[string]struct{})
 	orderHosts := make([]string, 0)
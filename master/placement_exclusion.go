@@ -0,0 +1,154 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// placementExcludedHosts expands the placement exclusion of the volume, and the zones out of
+// the required zones of its placement policy, into the addresses of the data or meta nodes which
+// must not hold its replicas, so that it can be passed as the excluded hosts to the node selectors.
+func (c *Cluster) placementExcludedHosts(vol *Vol, nodeType uint32) (hosts []string) {
+	exclusion := vol.getPlacementExclusion()
+	policy := vol.getPlacementPolicy()
+	if exclusion.IsEmpty() && len(policy.RequiredZones) == 0 {
+		return
+	}
+	excludes := func(addr, zoneName string, nodeSetID uint64) bool {
+		return exclusion.Excludes(addr, zoneName, nodeSetID) || !policy.AllowsZone(zoneName)
+	}
+	if nodeType == TypeDataPartition {
+		c.dataNodes.Range(func(_, value interface{}) bool {
+			node := value.(*DataNode)
+			if excludes(node.Addr, node.ZoneName, node.NodeSetID) {
+				hosts = append(hosts, node.Addr)
+			}
+			return true
+		})
+		return
+	}
+	c.metaNodes.Range(func(_, value interface{}) bool {
+		node := value.(*MetaNode)
+		if excludes(node.Addr, node.ZoneName, node.NodeSetID) {
+			hosts = append(hosts, node.Addr)
+		}
+		return true
+	})
+	return
+}
+
+// withPlacementExclusion returns excludeHosts with the hosts excluded by the volume appended,
+// excludeHosts itself is left untouched as it is usually the hosts of a partition.
+func (c *Cluster) withPlacementExclusion(vol *Vol, nodeType uint32, excludeHosts []string) []string {
+	excluded := c.placementExcludedHosts(vol, nodeType)
+	if len(excluded) == 0 {
+		return excludeHosts
+	}
+	hosts := make([]string, 0, len(excludeHosts)+len(excluded))
+	hosts = append(hosts, excludeHosts...)
+	return append(hosts, excluded...)
+}
+
+// placementZones decides the zones the partitions of the volume are created in and the number
+// of zones their replicas spread across, the required zones of the placement policy take the
+// place of the zones the volume is created with.
+func (c *Cluster) placementZones(vol *Vol, mediaType uint32) (zoneName string, zoneNum int) {
+	policy := vol.getPlacementPolicy()
+	zoneName = vol.zoneName
+	if len(policy.RequiredZones) > 0 {
+		zoneName = strings.Join(policy.RequiredZones, ",")
+	}
+	if policy.SpreadZones > 0 {
+		return zoneName, policy.SpreadZones
+	}
+	return zoneName, c.decideZoneNum(vol, mediaType) // zoneNum scope [1,3]
+}
+
+// checkPlacementExclusion is the last guard before the replicas are created on hosts, the
+// selectors may still choose an excluded zone when it is the only one left. The placement
+// policy is checked as well, the zone spread only for the hosts of a new partition.
+func (c *Cluster) checkPlacementExclusion(vol *Vol, nodeType uint32, hosts []string) (err error) {
+	exclusion := vol.getPlacementExclusion()
+	policy := vol.getPlacementPolicy()
+	if exclusion.IsEmpty() && policy.IsEmpty() {
+		return
+	}
+	zones := make(map[string]struct{})
+	for _, host := range hosts {
+		var (
+			zoneName  string
+			nodeSetID uint64
+		)
+		if nodeType == TypeDataPartition {
+			var node *DataNode
+			if node, err = c.dataNode(host); err != nil {
+				return
+			}
+			zoneName, nodeSetID = node.ZoneName, node.NodeSetID
+		} else {
+			var node *MetaNode
+			if node, err = c.metaNode(host); err != nil {
+				return
+			}
+			zoneName, nodeSetID = node.ZoneName, node.NodeSetID
+		}
+		if exclusion.Excludes(host, zoneName, nodeSetID) {
+			err = fmt.Errorf("vol[%v] placement exclusion %v forbids host[%v] zone[%v] nodeSet[%v]",
+				vol.Name, exclusion, host, zoneName, nodeSetID)
+			log.LogWarnf("action[checkPlacementExclusion] %v", err)
+			return
+		}
+		if !policy.AllowsZone(zoneName) {
+			err = fmt.Errorf("vol[%v] placement policy %v forbids host[%v] zone[%v]", vol.Name, policy, host, zoneName)
+			log.LogWarnf("action[checkPlacementExclusion] %v", err)
+			return
+		}
+		zones[zoneName] = struct{}{}
+	}
+	if spread := policy.SpreadZones; len(hosts) > 1 && spread > 0 {
+		if spread > len(hosts) {
+			spread = len(hosts)
+		}
+		if len(zones) < spread {
+			err = fmt.Errorf("vol[%v] placement policy %v needs the hosts%v across %v zones, got %v",
+				vol.Name, policy, hosts, spread, len(zones))
+			log.LogWarnf("action[checkPlacementExclusion] %v", err)
+			return
+		}
+	}
+	return
+}
+
+// checkPlacementPolicy rejects a policy whose required zones are unknown or excluded by the volume,
+// or which spreads across more zones than the volume is created in.
+func (c *Cluster) checkPlacementPolicy(vol *Vol, policy *proto.PlacementPolicy) (err error) {
+	for _, zone := range policy.RequiredZones {
+		if _, err = c.t.getZone(zone); err != nil {
+			return
+		}
+	}
+	if len(policy.RequiredZones) == 0 && vol.zoneName != "" {
+		if zoneNum := len(strings.Split(vol.zoneName, ",")); policy.SpreadZones > zoneNum {
+			return fmt.Errorf("spread zones %v is more than the %v zones of vol[%v]", policy.SpreadZones, zoneNum, vol.Name)
+		}
+	}
+	exclusion := vol.getPlacementExclusion()
+	return policy.CheckExclusion(&exclusion)
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+// simNode is the copy of a datanode the simulation places replicas on.
+type simNode struct {
+	addr      string
+	zoneName  string
+	total     uint64
+	used      uint64
+	writable  bool
+	simulated bool
+	removed   bool
+}
+
+func (n *simNode) usedRatio() float64 {
+	if n.total == 0 {
+		return 1
+	}
+	return float64(n.used) / float64(n.total)
+}
+
+func (n *simNode) release(size uint64) {
+	if size > n.used {
+		size = n.used
+	}
+	n.used -= size
+}
+
+func (n *simNode) canHold(size uint64) bool {
+	return n.writable && !n.removed && float64(n.used+size) <= float64(n.total)*spaceAvailableRate
+}
+
+type placementSimulator struct {
+	nodes  map[string]*simNode
+	zones  map[string]*proto.ZonePlacementSimulation
+	result *proto.PlacementSimulation
+}
+
+// simulatePlacement applies the change to copies of the datanodes and the
+// data partitions, so that nothing of the cluster is modified. The moved and
+// the new replicas are placed greedily on the least used writable datanode of
+// the zones of their volume, which follows the spirit of the real selection
+// without replaying its nodesets and weights.
+func (c *Cluster) simulatePlacement(req *proto.PlacementSimulationRequest) (result *proto.PlacementSimulation, err error) {
+	if err = req.Validate(); err != nil {
+		return
+	}
+	s := &placementSimulator{
+		nodes: make(map[string]*simNode),
+		zones: make(map[string]*proto.ZonePlacementSimulation),
+		result: &proto.PlacementSimulation{
+			UnplaceablePartitions: make([]uint64, 0),
+		},
+	}
+	c.dataNodes.Range(func(_, value interface{}) bool {
+		dataNode := value.(*DataNode)
+		dataNode.RLock()
+		s.nodes[dataNode.Addr] = &simNode{
+			addr:     dataNode.Addr,
+			zoneName: dataNode.ZoneName,
+			total:    dataNode.Total,
+			used:     dataNode.Used,
+			writable: dataNode.isActive && !dataNode.ToBeOffline,
+		}
+		dataNode.RUnlock()
+		return true
+	})
+	for _, node := range s.nodes {
+		s.zone(node.zoneName).Before.AddNode(node.total, node.used, spaceAvailableRate)
+	}
+	if err = s.addNodes(req.AddNodes); err != nil {
+		return
+	}
+	for _, addr := range req.RemoveNodes {
+		node, ok := s.nodes[addr]
+		if !ok || node.simulated {
+			return nil, fmt.Errorf("datanode[%v] to remove not found", addr)
+		}
+		node.removed = true
+	}
+	replicaNums := make(map[string]int, len(req.ReplicaNums))
+	for _, replicaNum := range req.ReplicaNums {
+		if _, err = c.getVol(replicaNum.Volume); err != nil {
+			return
+		}
+		replicaNums[replicaNum.Volume] = replicaNum.ReplicaNum
+	}
+
+	vols := c.allVols()
+	names := make([]string, 0, len(vols))
+	for name := range vols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		vol := vols[name]
+		var zones []string
+		if vol.zoneName != "" {
+			zones = strings.Split(vol.zoneName, ",")
+		}
+		partitions := vol.dataPartitions.clonePartitions()
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i].PartitionID < partitions[j].PartitionID })
+		for _, dp := range partitions {
+			dp.RLock()
+			hosts := append([]string(nil), dp.Hosts...)
+			replicaNum := int(dp.ReplicaNum)
+			dp.RUnlock()
+			if n, ok := replicaNums[name]; ok {
+				replicaNum = n
+			}
+			s.placePartition(dp.PartitionID, hosts, replicaNum, dp.getMaxUsedSpace(), zones)
+		}
+	}
+
+	for _, node := range s.nodes {
+		if !node.removed {
+			s.zone(node.zoneName).After.AddNode(node.total, node.used, spaceAvailableRate)
+		}
+	}
+	s.result.Zones = make([]*proto.ZonePlacementSimulation, 0, len(s.zones))
+	for _, zone := range s.zones {
+		s.result.Zones = append(s.result.Zones, zone)
+	}
+	sort.Slice(s.result.Zones, func(i, j int) bool { return s.result.Zones[i].ZoneName < s.result.Zones[j].ZoneName })
+	return s.result, nil
+}
+
+func (s *placementSimulator) zone(name string) *proto.ZonePlacementSimulation {
+	zone, ok := s.zones[name]
+	if !ok {
+		zone = &proto.ZonePlacementSimulation{ZoneName: name}
+		s.zones[name] = zone
+	}
+	return zone
+}
+
+func (s *placementSimulator) addNodes(adds []*proto.SimulatedNodes) error {
+	for _, add := range adds {
+		capacity := add.Capacity
+		if capacity == 0 {
+			var total uint64
+			count := 0
+			for _, node := range s.nodes {
+				if node.zoneName == add.ZoneName && !node.simulated {
+					total += node.total
+					count++
+				}
+			}
+			if count == 0 {
+				return fmt.Errorf("zone[%v] has no datanode, the capacity of the nodes to add is required", add.ZoneName)
+			}
+			capacity = total / uint64(count)
+		}
+		for i := 0; i < add.Count; i++ {
+			addr := fmt.Sprintf("simulated-%v-%v", add.ZoneName, len(s.nodes))
+			s.nodes[addr] = &simNode{
+				addr:      addr,
+				zoneName:  add.ZoneName,
+				total:     capacity,
+				writable:  true,
+				simulated: true,
+			}
+		}
+	}
+	return nil
+}
+
+// placePartition moves the replicas off the removed nodes and adds or drops
+// replicas to reach replicaNum.
+func (s *placementSimulator) placePartition(id uint64, hosts []string, replicaNum int, size uint64, zones []string) {
+	kept := make([]string, 0, len(hosts))
+	moved := 0
+	for _, host := range hosts {
+		if node, ok := s.nodes[host]; ok && node.removed {
+			node.release(size)
+			moved++
+			continue
+		}
+		kept = append(kept, host)
+	}
+	for len(kept) > replicaNum {
+		// drop the replica of the most used node
+		drop, maxRatio := 0, -1.0
+		for i, host := range kept {
+			ratio := 0.0
+			if node, ok := s.nodes[host]; ok {
+				ratio = node.usedRatio()
+			}
+			if ratio > maxRatio {
+				drop, maxRatio = i, ratio
+			}
+		}
+		if node, ok := s.nodes[kept[drop]]; ok {
+			node.release(size)
+		}
+		kept = append(kept[:drop], kept[drop+1:]...)
+		s.result.DroppedReplicas++
+		s.result.ReleasedBytes += size
+	}
+
+	unplaceable := false
+	for i := len(kept); i < replicaNum; i++ {
+		target := s.selectNode(hosts, kept, size, zones)
+		if target == nil {
+			unplaceable = true
+			break
+		}
+		target.used += size
+		kept = append(kept, target.addr)
+		zone := s.zone(target.zoneName)
+		zone.InReplicas++
+		zone.InBytes += size
+		if moved > 0 {
+			moved--
+			s.result.MigratedReplicas++
+			s.result.MigratedBytes += size
+		} else {
+			s.result.AddedReplicas++
+			s.result.AddedBytes += size
+		}
+	}
+	if unplaceable {
+		s.result.UnplaceablePartitions = append(s.result.UnplaceablePartitions, id)
+	}
+}
+
+func (s *placementSimulator) selectNode(hosts, kept []string, size uint64, zones []string) (selected *simNode) {
+	for _, node := range s.nodes {
+		if !node.canHold(size) || contains(hosts, node.addr) || contains(kept, node.addr) {
+			continue
+		}
+		if len(zones) > 0 && !contains(zones, node.zoneName) {
+			continue
+		}
+		if selected == nil || node.usedRatio() < selected.usedRatio() ||
+			(node.usedRatio() == selected.usedRatio() && node.addr < selected.addr) {
+			selected = node
+		}
+	}
+	return
+}
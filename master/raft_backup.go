@@ -0,0 +1,556 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cubefs/cubefs/raftstore/raftstore_db"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/config"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	defaultRaftBackupLogInterval      = 60
+	defaultRaftBackupSnapshotInterval = 6 * 3600
+	defaultRaftBackupBufferMB         = 64
+	raftBackupSegmentSize             = 8 * util.MB
+
+	raftBackupPositionKey  = "position"
+	raftBackupLogDir       = "log/"
+	raftBackupSnapshotDir  = "snapshot/"
+	raftBackupSnapshotTemp = "raft_backup_snapshot"
+)
+
+// raftBackupStore is the object storage the raft backup is shipped to.
+type raftBackupStore interface {
+	Put(key string, body io.ReadSeeker) error
+	// Get returns os.ErrNotExist if there is no such key.
+	Get(key string) (io.ReadCloser, error)
+	List(prefix string) ([]string, error)
+}
+
+type s3BackupStore struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+func newS3BackupStore(endpoint, region, bucket, accessKey, secretKey, prefix string) (store *s3BackupStore, err error) {
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String(region),
+		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return
+	}
+	return &s3BackupStore{client: s3.New(sess), bucket: bucket, prefix: strings.TrimSuffix(prefix, "/") + "/"}, nil
+}
+
+func (s *s3BackupStore) Put(key string, body io.ReadSeeker) (err error) {
+	_, err = s.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+		Body:   body,
+	})
+	return
+}
+
+func (s *s3BackupStore) Get(key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, os.ErrNotExist
+		}
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (s *s3BackupStore) List(prefix string) (keys []string, err error) {
+	err = s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix + prefix),
+	}, func(page *s3.ListObjectsV2Output, last bool) bool {
+		for _, obj := range page.Contents {
+			keys = append(keys, strings.TrimPrefix(aws.StringValue(obj.Key), s.prefix))
+		}
+		return true
+	})
+	return
+}
+
+// raftBackupPosition is what the backup holds. The log segments cover every
+// command applied after SnapshotIndex up to LogIndex without holes.
+type raftBackupPosition struct {
+	SnapshotIndex uint64 `json:"snapshotIndex"`
+	SnapshotTime  int64  `json:"snapshotTime"`
+	LogIndex      uint64 `json:"logIndex"`
+}
+
+type raftBackupEntry struct {
+	index uint64
+	data  []byte
+}
+
+// raftBackup continuously ships the commands applied by the master fsm and
+// periodic snapshots of its store to an object storage, from which a lost
+// control plane can be restored up to any shipped raft index. Every master
+// buffers the commands it applies, and the leader ships them.
+type raftBackup struct {
+	sync.Mutex
+	store            raftBackupStore
+	fsm              *MetadataFsm
+	isLeader         func() bool
+	tempDir          string
+	logInterval      time.Duration
+	snapshotInterval time.Duration
+	bufferSize       int
+
+	// entries holds the commands applied after from
+	entries []raftBackupEntry
+	size    int
+	from    uint64
+
+	// pos is nil until the leader reads it back from the store
+	pos   *raftBackupPosition
+	stopC chan struct{}
+}
+
+func newRaftBackup(cfg *config.Config, clusterName, tempDir string) (b *raftBackup, err error) {
+	endpoint := cfg.GetString(cfgRaftBackupEndpoint)
+	if endpoint == "" {
+		return nil, nil
+	}
+	prefix := cfg.GetString(cfgRaftBackupPrefix)
+	if prefix == "" {
+		prefix = clusterName
+	}
+	region := cfg.GetString(cfgRaftBackupRegion)
+	if region == "" {
+		region = "default"
+	}
+	store, err := newS3BackupStore(endpoint, region,
+		cfg.GetString(cfgRaftBackupBucket), cfg.GetString(cfgRaftBackupAccessKey), cfg.GetString(cfgRaftBackupSecretKey), prefix)
+	if err != nil {
+		return
+	}
+	b = &raftBackup{
+		store:            store,
+		tempDir:          tempDir,
+		logInterval:      time.Duration(cfg.GetIntWithDefault(cfgRaftBackupLogIntervalSec, defaultRaftBackupLogInterval)) * time.Second,
+		snapshotInterval: time.Duration(cfg.GetIntWithDefault(cfgRaftBackupSnapshotIntervalSec, defaultRaftBackupSnapshotInterval)) * time.Second,
+		bufferSize:       cfg.GetIntWithDefault(cfgRaftBackupBufferMB, defaultRaftBackupBufferMB) * util.MB,
+		stopC:            make(chan struct{}),
+	}
+	return
+}
+
+// onApplied is called by the fsm for every command it applies.
+func (b *raftBackup) onApplied(command []byte, index uint64) {
+	b.Lock()
+	defer b.Unlock()
+	b.entries = append(b.entries, raftBackupEntry{index: index, data: append([]byte(nil), command...)})
+	b.size += len(command)
+	n := 0
+	for size := b.size; size > b.bufferSize && n < len(b.entries)-1; n++ {
+		size -= len(b.entries[n].data)
+	}
+	if n > 0 {
+		b.dropFront(n)
+	}
+}
+
+// dropFront drops the n oldest commands. The slice is only moved on, the
+// backing array is released once append has to grow it.
+func (b *raftBackup) dropFront(n int) {
+	for i := range b.entries[:n] {
+		b.size -= len(b.entries[i].data)
+		b.entries[i].data = nil
+	}
+	b.from = b.entries[n-1].index
+	b.entries = b.entries[n:]
+}
+
+// reset drops the buffer after the fsm applied a raft snapshot.
+func (b *raftBackup) reset(applied uint64) {
+	b.Lock()
+	b.entries = nil
+	b.size = 0
+	b.from = applied
+	b.Unlock()
+}
+
+func (b *raftBackup) start(fsm *MetadataFsm, isLeader func() bool) {
+	b.fsm = fsm
+	b.isLeader = isLeader
+	go func() {
+		ticker := time.NewTicker(b.logInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.stopC:
+				return
+			case <-ticker.C:
+				if err := b.ship(); err != nil {
+					log.LogErrorf("action[raftBackup] ship failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (b *raftBackup) stop() {
+	close(b.stopC)
+}
+
+func (b *raftBackup) ship() (err error) {
+	if !b.isLeader() {
+		b.pos = nil
+		return
+	}
+	if b.pos == nil {
+		if b.pos, err = b.readPosition(); err != nil {
+			return
+		}
+	}
+
+	b.Lock()
+	from := b.from
+	b.Unlock()
+	// the buffer no longer goes on from the backup, which a new snapshot fixes
+	if b.pos.SnapshotIndex == 0 || b.pos.LogIndex < from ||
+		time.Since(time.Unix(b.pos.SnapshotTime, 0)) >= b.snapshotInterval {
+		if err = b.shipSnapshot(); err != nil {
+			return
+		}
+	}
+	return b.shipLogs()
+}
+
+func (b *raftBackup) readPosition() (pos *raftBackupPosition, err error) {
+	pos = &raftBackupPosition{}
+	r, err := b.store.Get(raftBackupPositionKey)
+	if err == os.ErrNotExist {
+		return pos, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	if err = json.NewDecoder(r).Decode(pos); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (b *raftBackup) writePosition(pos raftBackupPosition) (err error) {
+	data, err := json.Marshal(pos)
+	if err != nil {
+		return
+	}
+	if err = b.store.Put(raftBackupPositionKey, bytes.NewReader(data)); err != nil {
+		return
+	}
+	*b.pos = pos
+	return
+}
+
+// shipSnapshot dumps the records of a snapshot of the fsm into a temporary
+// file, each one prefixed with its length, and uploads it.
+func (b *raftBackup) shipSnapshot() (err error) {
+	snap, err := b.fsm.Snapshot()
+	if err != nil {
+		return
+	}
+	defer snap.Close()
+	f, err := os.CreateTemp(b.tempDir, raftBackupSnapshotTemp)
+	if err != nil {
+		return
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+	w := bufio.NewWriter(f)
+	var data []byte
+	for {
+		if data, err = snap.Next(); err == io.EOF {
+			break
+		}
+		if err != nil {
+			return
+		}
+		if err = writeBackupRecord(w, 0, data); err != nil {
+			return
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	index := snap.ApplyIndex()
+	if err = b.store.Put(fmt.Sprintf("%s%016x", raftBackupSnapshotDir, index), f); err != nil {
+		return
+	}
+	pos := *b.pos
+	pos.SnapshotIndex = index
+	pos.SnapshotTime = time.Now().Unix()
+	if pos.LogIndex < index {
+		pos.LogIndex = index
+	}
+	log.LogInfof("action[raftBackup] shipped snapshot at index %v", index)
+	return b.writePosition(pos)
+}
+
+// shipLogs uploads the buffered commands after the backup position as log
+// segments named by the range of raft indexes they cover.
+func (b *raftBackup) shipLogs() (err error) {
+	b.Lock()
+	start := sort.Search(len(b.entries), func(i int) bool { return b.entries[i].index > b.pos.LogIndex })
+	entries := b.entries[start:]
+	b.Unlock()
+
+	for len(entries) > 0 {
+		var buf bytes.Buffer
+		n := 0
+		for n < len(entries) && buf.Len() < raftBackupSegmentSize {
+			if err = writeBackupRecord(&buf, entries[n].index, entries[n].data); err != nil {
+				return
+			}
+			n++
+		}
+		last := entries[n-1].index
+		key := fmt.Sprintf("%s%016x-%016x", raftBackupLogDir, b.pos.LogIndex+1, last)
+		if err = b.store.Put(key, bytes.NewReader(buf.Bytes())); err != nil {
+			return
+		}
+		pos := *b.pos
+		pos.LogIndex = last
+		if err = b.writePosition(pos); err != nil {
+			return
+		}
+		entries = entries[n:]
+	}
+
+	// the shipped commands are no longer needed by this master
+	b.Lock()
+	if n := sort.Search(len(b.entries), func(i int) bool { return b.entries[i].index > b.pos.LogIndex }); n > 0 {
+		b.dropFront(n)
+	}
+	b.Unlock()
+	return
+}
+
+func writeBackupRecord(w io.Writer, index uint64, data []byte) (err error) {
+	header := make([]byte, 0, 2*binary.MaxVarintLen64)
+	header = binary.AppendUvarint(header, index)
+	header = binary.AppendUvarint(header, uint64(len(data)))
+	if _, err = w.Write(header); err != nil {
+		return
+	}
+	_, err = w.Write(data)
+	return
+}
+
+func readBackupRecord(r *bufio.Reader) (index uint64, data []byte, err error) {
+	if index, err = binary.ReadUvarint(r); err != nil {
+		return
+	}
+	var size uint64
+	if size, err = binary.ReadUvarint(r); err != nil {
+		return
+	}
+	data = make([]byte, size)
+	if _, err = io.ReadFull(r, data); err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return
+}
+
+// backupSnapshotIterator feeds a shipped snapshot to MetadataFsm.ApplySnapshot.
+type backupSnapshotIterator struct {
+	r *bufio.Reader
+}
+
+func (it *backupSnapshotIterator) Next() (data []byte, err error) {
+	_, data, err = readBackupRecord(it.r)
+	return
+}
+
+type backupLogSegment struct {
+	key         string
+	first, last uint64
+}
+
+func listBackupLogSegments(store raftBackupStore) (segments []backupLogSegment, err error) {
+	keys, err := store.List(raftBackupLogDir)
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		s := backupLogSegment{key: key}
+		if _, err := fmt.Sscanf(strings.TrimPrefix(key, raftBackupLogDir), "%016x-%016x", &s.first, &s.last); err != nil {
+			continue
+		}
+		segments = append(segments, s)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].first < segments[j].first })
+	return
+}
+
+// restoreFromRaftBackup rebuilds the fsm store from the latest shipped
+// snapshot at or before target and the log segments after it, up to target,
+// or up to the end of the backup if target is 0.
+func restoreFromRaftBackup(store raftBackupStore, db *raftstore_db.RocksDBStore, target uint64) (index uint64, err error) {
+	keys, err := store.List(raftBackupSnapshotDir)
+	if err != nil {
+		return
+	}
+	var snapIndex uint64
+	for _, key := range keys {
+		i, e := strconv.ParseUint(strings.TrimPrefix(key, raftBackupSnapshotDir), 16, 64)
+		if e == nil && i > snapIndex && (target == 0 || i <= target) {
+			snapIndex = i
+		}
+	}
+	if snapIndex == 0 {
+		return 0, fmt.Errorf("no snapshot in the backup before index %v", target)
+	}
+
+	fsm := newMetadataFsm(db, math.MaxUint64, nil)
+	fsm.registerApplySnapshotHandler(fsm.restore)
+	fsm.registerRaftUserCmdApplyHandler(func(opt uint32, key string, cmdMap map[string][]byte) error { return nil })
+	r, err := store.Get(fmt.Sprintf("%s%016x", raftBackupSnapshotDir, snapIndex))
+	if err != nil {
+		return
+	}
+	err = fsm.ApplySnapshot(nil, &backupSnapshotIterator{r: bufio.NewReader(r)})
+	r.Close()
+	if err != nil {
+		return
+	}
+	index = snapIndex
+	log.LogWarnf("action[restoreFromRaftBackup] restored snapshot at index %v", snapIndex)
+
+	segments, err := listBackupLogSegments(store)
+	if err != nil {
+		return
+	}
+	for _, s := range segments {
+		if s.last <= index {
+			continue
+		}
+		if target != 0 && index >= target {
+			break
+		}
+		if s.first > index+1 {
+			log.LogWarnf("action[restoreFromRaftBackup] the log segments miss the indexes from %v to %v", index+1, s.first-1)
+			break
+		}
+		if r, err = store.Get(s.key); err != nil {
+			return
+		}
+		err = func() error {
+			defer r.Close()
+			br := bufio.NewReader(r)
+			for {
+				i, data, err := readBackupRecord(br)
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if i <= index {
+					continue
+				}
+				if target != 0 && i > target {
+					return nil
+				}
+				if _, err = fsm.Apply(data, i); err != nil {
+					return err
+				}
+			}
+		}()
+		if err != nil {
+			return
+		}
+		index = s.last
+		if target != 0 && index > target {
+			index = target
+		}
+	}
+	if target != 0 && index < target {
+		return index, fmt.Errorf("the backup only goes on to index %v", index)
+	}
+	log.LogWarnf("action[restoreFromRaftBackup] restored up to index %v", index)
+	return
+}
+
+// restoreRaftBackup restores the store of a new master from the raft backup
+// when it is asked to by the config. The store and the raft log of the master
+// must be empty, so a restored master does not take in anything else.
+func (m *Server) restoreRaftBackup(cfg *config.Config) (err error) {
+	if !cfg.GetBool(cfgRaftBackupRestore) {
+		return
+	}
+	b, err := newRaftBackup(cfg, cfg.GetString(ClusterName), "")
+	if err != nil {
+		return
+	}
+	if b == nil {
+		return fmt.Errorf("%v is set without %v", cfgRaftBackupRestore, cfgRaftBackupEndpoint)
+	}
+	for _, dir := range []string{m.storeDir, path.Join(cfg.GetString(WalDir), strconv.FormatUint(GroupID, 10))} {
+		if names, e := os.ReadDir(dir); e == nil && len(names) > 0 {
+			return fmt.Errorf("can not restore the raft backup into %v, which is not empty", dir)
+		}
+	}
+	db, err := raftstore_db.NewRocksDBStore(m.storeDir, LRUCacheSize, WriteBufferSize)
+	if err != nil {
+		return
+	}
+	defer db.Close()
+	target := uint64(cfg.GetInt64(cfgRaftBackupRestoreIndex))
+	_, err = restoreFromRaftBackup(b.store, db, target)
+	return
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/raftstore/raftstore_db"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+type memBackupStore struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memBackupStore) Put(key string, body io.ReadSeeker) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	s.objects[key] = data
+	s.Unlock()
+	return nil
+}
+
+func (s *memBackupStore) Get(key string) (io.ReadCloser, error) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memBackupStore) List(prefix string) (keys []string, err error) {
+	s.Lock()
+	defer s.Unlock()
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+func newRaftBackupTestFsm(t *testing.T) *MetadataFsm {
+	db, err := raftstore_db.NewRocksDBStore(t.TempDir(), LRUCacheSize, WriteBufferSize)
+	require.NoError(t, err)
+	fsm := newMetadataFsm(db, math.MaxUint64, nil)
+	fsm.registerApplySnapshotHandler(fsm.restore)
+	return fsm
+}
+
+func applyRaftBackupTestCmd(t *testing.T, fsm *MetadataFsm, index uint64) {
+	cmd := &RaftCmd{Op: opSyncPutCluster, K: "key" + strconv.FormatUint(index%3, 10), V: []byte(strconv.FormatUint(index, 10))}
+	data, err := cmd.Marshal()
+	require.NoError(t, err)
+	_, err = fsm.Apply(data, index)
+	require.NoError(t, err)
+}
+
+func TestRaftBackupShipAndRestore(t *testing.T) {
+	store := &memBackupStore{objects: make(map[string][]byte)}
+	fsm := newRaftBackupTestFsm(t)
+	leader := true
+	b := &raftBackup{
+		store:            store,
+		tempDir:          t.TempDir(),
+		snapshotInterval: time.Hour,
+		bufferSize:       util.MB,
+		stopC:            make(chan struct{}),
+	}
+	fsm.registerCmdAppliedHandler(b.onApplied)
+	b.fsm = fsm
+	b.isLeader = func() bool { return leader }
+
+	for i := uint64(1); i <= 5; i++ {
+		applyRaftBackupTestCmd(t, fsm, i)
+	}
+	// the first round ships a snapshot, and the logs after it
+	require.NoError(t, b.ship())
+	require.Equal(t, raftBackupPosition{SnapshotIndex: 5, SnapshotTime: b.pos.SnapshotTime, LogIndex: 5}, *b.pos)
+	// the index of a member change is not applied by the fsm
+	for _, i := range []uint64{6, 8, 9} {
+		applyRaftBackupTestCmd(t, fsm, i)
+	}
+	require.NoError(t, b.ship())
+	require.Equal(t, uint64(9), b.pos.LogIndex)
+	require.Empty(t, b.entries)
+	applyRaftBackupTestCmd(t, fsm, 10)
+	require.NoError(t, b.ship())
+
+	keys, err := store.List(raftBackupLogDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"log/0000000000000006-0000000000000009", "log/000000000000000a-000000000000000a"}, keys)
+
+	// a follower does not ship, and reads the position back once it leads
+	leader = false
+	require.NoError(t, b.ship())
+	require.Nil(t, b.pos)
+	leader = true
+	require.NoError(t, b.ship())
+	require.Equal(t, uint64(10), b.pos.LogIndex)
+
+	restored := newRaftBackupTestFsm(t)
+	index, err := restoreFromRaftBackup(store, restored.store, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+	value, err := restored.store.Get(applied)
+	require.NoError(t, err)
+	require.Equal(t, []byte("10"), value)
+	value, err = restored.store.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("10"), value)
+
+	// back to a point in time
+	restored = newRaftBackupTestFsm(t)
+	index, err = restoreFromRaftBackup(store, restored.store, 8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), index)
+	value, err = restored.store.Get("key2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("8"), value)
+	value, err = restored.store.Get("key0")
+	require.NoError(t, err)
+	require.Equal(t, []byte("6"), value)
+
+	_, err = restoreFromRaftBackup(store, newRaftBackupTestFsm(t).store, 3)
+	require.Error(t, err)
+	_, err = restoreFromRaftBackup(store, newRaftBackupTestFsm(t).store, 20)
+	require.Error(t, err)
+}
+
+func TestRaftBackupBuffer(t *testing.T) {
+	store := &memBackupStore{objects: make(map[string][]byte)}
+	fsm := newRaftBackupTestFsm(t)
+	b := &raftBackup{store: store, snapshotInterval: time.Hour, bufferSize: 64, tempDir: t.TempDir()}
+	fsm.registerCmdAppliedHandler(b.onApplied)
+	b.fsm = fsm
+	b.isLeader = func() bool { return true }
+	for i := uint64(1); i <= 10; i++ {
+		applyRaftBackupTestCmd(t, fsm, i)
+	}
+	// the oldest commands are dropped beyond the buffer size
+	require.Less(t, len(b.entries), 10)
+	require.Equal(t, b.entries[0].index, b.from+1)
+	require.NoError(t, b.ship())
+	require.Equal(t, uint64(10), b.pos.SnapshotIndex)
+
+	// the buffer can not go on from the backup after a raft snapshot
+	b.reset(20)
+	applyRaftBackupTestCmd(t, fsm, 21)
+	require.NoError(t, b.ship())
+	require.Equal(t, uint64(21), b.pos.SnapshotIndex)
+	require.Equal(t, uint64(21), b.pos.LogIndex)
+}
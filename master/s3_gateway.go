@@ -0,0 +1,176 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// an objectnode heartbeats every 10s, it is dead after missing a few
+const s3NodeHeartbeatTimeout = 30 * time.Second
+
+// s3GatewayRegistry keeps the objectnodes alive and the versions of the bucket
+// configurations they cache. It only lives in the memory of the leader, a new
+// leader starts with a new epoch, and the gateways reload all their buckets.
+type s3GatewayRegistry struct {
+	sync.Mutex
+	epoch    int64
+	nodes    map[string]time.Time // addr -> last heartbeat
+	versions map[string]uint64    // bucket -> version
+}
+
+func newS3GatewayRegistry() *s3GatewayRegistry {
+	g := &s3GatewayRegistry{}
+	g.reset()
+	return g
+}
+
+func (g *s3GatewayRegistry) reset() {
+	g.Lock()
+	g.epoch = time.Now().UnixNano()
+	g.nodes = make(map[string]time.Time)
+	g.versions = make(map[string]uint64)
+	g.Unlock()
+}
+
+func (g *s3GatewayRegistry) liveNodesLocked(now time.Time) []string {
+	nodes := make([]string, 0, len(g.nodes))
+	for addr, last := range g.nodes {
+		if now.Sub(last) > s3NodeHeartbeatTimeout {
+			delete(g.nodes, addr)
+			continue
+		}
+		nodes = append(nodes, addr)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+func (g *s3GatewayRegistry) liveNodes(now time.Time) []string {
+	g.Lock()
+	defer g.Unlock()
+	return g.liveNodesLocked(now)
+}
+
+func (g *s3GatewayRegistry) heartbeat(addr string, now time.Time) *proto.S3NodeHeartbeatResponse {
+	g.Lock()
+	defer g.Unlock()
+	if _, ok := g.nodes[addr]; !ok {
+		log.LogInfof("action[s3NodeHeartbeat] objectnode[%v] joined", addr)
+	}
+	g.nodes[addr] = now
+	resp := &proto.S3NodeHeartbeatResponse{
+		Epoch:          g.epoch,
+		Nodes:          g.liveNodesLocked(now),
+		BucketVersions: make(map[string]uint64, len(g.versions)),
+	}
+	for bucket, version := range g.versions {
+		resp.BucketVersions[bucket] = version
+	}
+	return resp
+}
+
+func (g *s3GatewayRegistry) bucketChanged(bucket string) uint64 {
+	g.Lock()
+	defer g.Unlock()
+	g.versions[bucket]++
+	return g.versions[bucket]
+}
+
+func (g *s3GatewayRegistry) removeBucket(bucket string) {
+	if g == nil {
+		return
+	}
+	g.Lock()
+	delete(g.versions, bucket)
+	g.Unlock()
+}
+
+// s3NodeHeartbeat registers the objectnode and replies the state the gateways share.
+func (m *Server) s3NodeHeartbeat(w http.ResponseWriter, r *http.Request) {
+	var (
+		addr common.String
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.S3NodeHeartbeat))
+	defer func() {
+		doStatAndMetric(proto.S3NodeHeartbeat, metric, err, nil)
+	}()
+	if err = parseArgs(r, addr.Addr()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if _, _, err = net.SplitHostPort(addr.V); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: fmt.Sprintf("invalid addr[%v]: %v", addr.V, err)})
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.s3Gateways.heartbeat(addr.V, time.Now())))
+}
+
+// listS3Nodes replies the live objectnodes, and the one the bucket is routed to if given.
+func (m *Server) listS3Nodes(w http.ResponseWriter, r *http.Request) {
+	var (
+		bucket common.String
+		err    error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.S3NodeList))
+	defer func() {
+		doStatAndMetric(proto.S3NodeList, metric, err, nil)
+	}()
+	if err = parseArgs(r, bucket.Key("bucket").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	view := &proto.S3NodesView{Nodes: m.cluster.s3Gateways.liveNodes(time.Now())}
+	if bucket.V != "" {
+		view.Bucket, view.Owner = bucket.V, proto.S3BucketOwner(view.Nodes, bucket.V)
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(view))
+}
+
+// s3BucketChanged bumps the version of the bucket configuration, the other
+// gateways reload it on their next heartbeat.
+func (m *Server) s3BucketChanged(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		version uint64
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.S3BucketChanged))
+	defer func() {
+		doStatAndMetric(proto.S3BucketChanged, metric, err, nil)
+	}()
+	if name, err = parseAndExtractName(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if _, err = m.cluster.getVol(name); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
+		return
+	}
+	version = m.cluster.s3Gateways.bucketChanged(name)
+	log.LogDebugf("action[s3BucketChanged] bucket[%v] version[%v]", name, version)
+	sendOkReply(w, r, newSuccessHTTPReply(version))
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	syslog "log"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"regexp"
@@ -29,6 +30,8 @@ import (
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/raftstore"
 	"github.com/cubefs/cubefs/raftstore/raftstore_db"
+	"github.com/cubefs/cubefs/util/atomicutil"
+	"github.com/cubefs/cubefs/util/bundle"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/cryptoutil"
 	"github.com/cubefs/cubefs/util/errors"
@@ -65,6 +68,9 @@ const (
 	AuthNodeHost             = "authNodeHost"
 	AuthNodeEnableHTTPS      = "authNodeEnableHTTPS"
 	AuthNodeCertFile         = "authNodeCertFile"
+	AdminSigV4               = "adminSigV4"
+	AdminRBAC                = "adminRBAC"
+	AdminRBACTrustedHosts    = "adminRBACTrustedHosts"
 )
 
 var (
@@ -99,34 +105,41 @@ func setOverSoldFactor(factor float32) {
 
 // Server represents the server in a cluster
 type Server struct {
-	id              uint64
-	clusterName     string
-	ip              string
-	bindIp          bool
-	port            string
-	logDir          string
-	walDir          string
-	storeDir        string
-	bStoreAddr      string
-	servicePath     string
-	retainLogs      uint64
-	tickInterval    int
-	raftRecvBufSize int
-	electionTick    int
-	leaderInfo      *LeaderInfo
-	config          *clusterConfig
-	cluster         *Cluster
-	user            *User
-	rocksDBStore    *raftstore_db.RocksDBStore
-	raftStore       raftstore.RaftStore
-	fsm             *MetadataFsm
-	partition       raftstore.Partition
-	wg              sync.WaitGroup
-	reverseProxy    *httputil.ReverseProxy
-	metaReady       bool
-	apiServer       *http.Server
-	cliMgr          *ClientMgr
-	leaderChangeLk  sync.RWMutex
+	id               uint64
+	clusterName      string
+	ip               string
+	bindIp           bool
+	port             string
+	logDir           string
+	walDir           string
+	storeDir         string
+	bStoreAddr       string
+	servicePath      string
+	retainLogs       uint64
+	tickInterval     int
+	raftRecvBufSize  int
+	electionTick     int
+	leaderInfo       *LeaderInfo
+	config           *clusterConfig
+	cluster          *Cluster
+	user             *User
+	rocksDBStore     *raftstore_db.RocksDBStore
+	raftStore        raftstore.RaftStore
+	fsm              *MetadataFsm
+	partition        raftstore.Partition
+	wg               sync.WaitGroup
+	reverseProxy     *httputil.ReverseProxy
+	adminSigV4       bool // accept the admin requests signed with sigv4
+	adminRBAC        bool // check the admin roles of the callers
+	rbacTrustedHosts []*net.IPNet
+	metaReady        bool
+	apiServer        *http.Server
+	cliMgr           *ClientMgr
+	leaderChangeLk   sync.RWMutex
+	raftBackup       *raftBackup
+	eventSink        *clusterEventKafkaSink
+	promoted         atomicutil.Bool // a standby master of the promoted standby group
+	fenced           atomicutil.Bool // fenced by the promotion of the standby group
 }
 
 // NewServer creates a new server
@@ -143,6 +156,10 @@ func (m *Server) Start(cfg *config.Config) (err error) {
 	if m.storeDir == "" {
 		return fmt.Errorf("store dir is empty")
 	}
+	if err = m.restoreRaftBackup(cfg); err != nil {
+		log.LogError(errors.Stack(err))
+		return
+	}
 	if m.rocksDBStore, err = raftstore_db.NewRocksDBStoreAndRecovery(m.storeDir, LRUCacheSize, WriteBufferSize); err != nil {
 		return
 	}
@@ -151,13 +168,24 @@ func (m *Server) Start(cfg *config.Config) (err error) {
 		log.LogError(errors.Stack(err))
 		return
 	}
+	if err = checkMasterFence(m.walDir); err != nil {
+		log.LogError(errors.Stack(err))
+		return
+	}
 	m.reverseProxy = m.newReverseProxy()
 	m.cliMgr = newClientMgr()
 
+	if m.raftBackup, err = newRaftBackup(cfg, m.clusterName, m.walDir); err != nil {
+		log.LogError(errors.Stack(err))
+		return
+	}
 	if err = m.createRaftServer(cfg); err != nil {
 		log.LogError(errors.Stack(err))
 		return
 	}
+	if m.raftBackup != nil {
+		m.raftBackup.start(m.fsm, m.partition.IsRaftLeader)
+	}
 
 	m.initCluster()
 	m.initUser()
@@ -172,9 +200,24 @@ func (m *Server) Start(cfg *config.Config) (err error) {
 	if m.cluster.authenticate {
 		m.cluster.initAuthentication(cfg)
 	}
+	m.adminSigV4 = cfg.GetBool(AdminSigV4)
+	// the roles are taken from the signatures
+	if m.adminRBAC = cfg.GetBool(AdminRBAC); m.adminRBAC {
+		m.adminSigV4 = true
+		if m.rbacTrustedHosts, err = parseTrustedHosts(cfg.GetString(AdminRBACTrustedHosts)); err != nil {
+			return fmt.Errorf("action[Start] failed %v, err: %v", proto.ErrInvalidCfg, err)
+		}
+	}
 	WarnMetrics = newWarningMetrics(m.cluster)
 	m.cluster.scheduleTask()
+	// the events are still served by the api without the kafka sink
+	if m.eventSink, err = newClusterEventKafkaSink(cfg); err != nil {
+		log.LogErrorf("action[Start] create cluster event kafka sink failed, err: %v", err)
+	} else if m.eventSink != nil {
+		m.eventSink.start(m.cluster.events)
+	}
 	m.startHTTPService(ModuleName, cfg)
+	m.registerBundleStates()
 	exporter.RegistConsul(m.clusterName, ModuleName, cfg)
 	metricsService := newMonitorMetrics(m.cluster)
 	metricsService.start()
@@ -189,6 +232,20 @@ func (m *Server) Start(cfg *config.Config) (err error) {
 	return err
 }
 
+// registerBundleStates puts what master keeps in memory about the cluster in
+// the diagnostics bundle of the debug port.
+func (m *Server) registerBundleStates() {
+	bundle.RegisterState("summary", func() interface{} { return m.cluster.summary() })
+	bundle.RegisterState("raft", func() interface{} {
+		return map[string]interface{}{
+			"leader":   m.leaderInfo.addr,
+			"isLeader": m.partition.IsRaftLeader(),
+			"status":   m.partition.Status(),
+		}
+	})
+	bundle.RegisterState("volumes", func() interface{} { return m.cluster.allVolNames() })
+}
+
 // Shutdown closes the server
 func (m *Server) Shutdown() {
 	var err error
@@ -209,6 +266,12 @@ func (m *Server) Shutdown() {
 	}
 	stat.CloseStat()
 
+	if m.raftBackup != nil {
+		m.raftBackup.stop()
+	}
+	if m.eventSink != nil {
+		m.eventSink.stop()
+	}
 	// stop raftServer first
 	if m.fsm != nil {
 		m.fsm.Stop()
@@ -276,6 +339,12 @@ func (m *Server) checkConfig(cfg *config.Config) (err error) {
 	if err = m.config.parsePeers(peerAddrs); err != nil {
 		return
 	}
+	if standbyPeers := cfg.GetString(cfgStandbyPeers); standbyPeers != "" {
+		if err = m.config.parseStandbyPeers(standbyPeers); err != nil {
+			return
+		}
+		syslog.Printf("standbyPeers[%v], standby[%v]\n", m.config.standbyPeers, m.config.isStandbyPeer(m.id))
+	}
 	nodeSetCapacity := cfg.GetString(nodeSetCapacity)
 	if nodeSetCapacity != "" {
 		if m.config.nodeSetCapacity, err = strconv.Atoi(nodeSetCapacity); err != nil {
@@ -438,6 +507,7 @@ func (m *Server) checkConfig(cfg *config.Config) (err error) {
 	m.config.SingleNodeMode = cfg.GetBoolWithDefault(cfgSingleNodeMode, false)
 
 	m.config.MaxWritableDataPartitionCnt = cfg.GetIntWithDefault(cfgMaxWritableDataPartitionCnt, 1000)
+	m.config.auditReportSignKey = cfg.GetString(cfgAuditReportSignKey)
 	return
 }
 
@@ -464,9 +534,11 @@ func (m *Server) createRaftServer(cfg *config.Config) (err error) {
 		Applied: m.fsm.applied,
 		SM:      m.fsm,
 	}
-	if m.partition, err = m.raftStore.CreatePartition(partitionCfg); err != nil {
+	partition, err := m.raftStore.CreatePartition(partitionCfg)
+	if err != nil {
 		return errors.Trace(err, "CreatePartition failed")
 	}
+	m.partition = &masterPartition{Partition: partition, fenced: &m.fenced}
 	return
 }
 
@@ -479,6 +551,10 @@ func (m *Server) initFsm() {
 	m.fsm.registerApplySnapshotHandler(m.handleApplySnapshot)
 	m.fsm.registerRaftUserCmdApplyHandler(m.handleRaftUserCmd)
 	m.fsm.restore()
+	if m.raftBackup != nil {
+		m.raftBackup.reset(m.fsm.applied)
+		m.fsm.registerCmdAppliedHandler(m.raftBackup.onApplied)
+	}
 }
 
 func (m *Server) initCluster() {
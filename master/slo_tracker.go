@@ -0,0 +1,118 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+// sloWindowMinutes is the sliding window the attainment of volume SLOs is computed over.
+const sloWindowMinutes = 60
+
+// sloWindow keeps the latency samples of one op of a volume in per-minute slots.
+type sloWindow struct {
+	minutes [sloWindowMinutes]int64
+	slots   [sloWindowMinutes]*proto.LatencyHistogram
+}
+
+func (w *sloWindow) add(minute int64, h *proto.LatencyHistogram) {
+	i := minute % sloWindowMinutes
+	if w.minutes[i] != minute || w.slots[i] == nil {
+		w.minutes[i] = minute
+		w.slots[i] = proto.NewLatencyHistogram()
+	}
+	w.slots[i].Merge(h)
+}
+
+func (w *sloWindow) sum(minute int64) *proto.LatencyHistogram {
+	total := proto.NewLatencyHistogram()
+	for i, slot := range w.slots {
+		if slot != nil && minute-w.minutes[i] < sloWindowMinutes {
+			total.Merge(slot)
+		}
+	}
+	return total
+}
+
+// sloTracker aggregates the latency samples reported by clients and servers.
+// The samples only live in the memory of the leader, a new leader starts with an empty window.
+type sloTracker struct {
+	sync.Mutex
+	vols map[string]map[string]*sloWindow
+}
+
+func newSLOTracker() *sloTracker {
+	return &sloTracker{vols: make(map[string]map[string]*sloWindow)}
+}
+
+func (t *sloTracker) add(now time.Time, report *proto.SLOReport) {
+	minute := now.Unix() / 60
+	t.Lock()
+	defer t.Unlock()
+	ops, ok := t.vols[report.Volume]
+	if !ok {
+		ops = make(map[string]*sloWindow)
+		t.vols[report.Volume] = ops
+	}
+	for op, h := range report.Ops {
+		if h == nil || !proto.IsValidSLOOp(op) {
+			continue
+		}
+		w, ok := ops[op]
+		if !ok {
+			w = &sloWindow{}
+			ops[op] = w
+		}
+		w.add(minute, h)
+	}
+}
+
+func (t *sloTracker) status(now time.Time, volName string, slo map[string]*proto.SLOTarget) *proto.VolumeSLOStatus {
+	minute := now.Unix() / 60
+	st := &proto.VolumeSLOStatus{
+		Volume:        volName,
+		WindowMinutes: sloWindowMinutes,
+		Ops:           make(map[string]*proto.SLOOpStatus, len(slo)),
+	}
+	t.Lock()
+	defer t.Unlock()
+	ops := t.vols[volName]
+	for op, target := range slo {
+		var h *proto.LatencyHistogram
+		if w, ok := ops[op]; ok {
+			h = w.sum(minute)
+		}
+		st.Ops[op] = proto.NewSLOOpStatus(*target, h)
+	}
+	return st
+}
+
+func (t *sloTracker) remove(volName string) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	delete(t.vols, volName)
+	t.Unlock()
+}
+
+func (t *sloTracker) clear() {
+	t.Lock()
+	t.vols = make(map[string]map[string]*sloWindow)
+	t.Unlock()
+}
@@ -1403,6 +1403,12 @@ func (t *topology) pickUpZonesByNodeType(zones []*Zone, nodeType NodeType, dataM
 			continue
 		}
 
+		if zone.getIsolation() != proto.ZoneIsolationNone {
+			log.LogDebugf("[pickUpZonesByNodeType] skip zone(%v), for isolation(%v)",
+				zone.name, proto.ZoneIsolationString(zone.getIsolation()))
+			continue
+		}
+
 		if dataMediaType != proto.MediaType_Unspecified && zone.dataMediaType != dataMediaType {
 			log.LogDebugf("[pickUpZonesByNodeType] skip zone(%v), zoneDataMediaType(%v), require mediaType(%v)",
 				zone.name, proto.MediaTypeString(zone.dataMediaType), proto.MediaTypeString(dataMediaType))
@@ -1453,7 +1459,7 @@ func (t *topology) allocZonesForNode(rsMgr *rsManager, zoneNumNeed, replicaNum i
 	demandWriteNodesCntPerZone := calculateDemandWriteNodes(zoneNumNeed, replicaNum, len(specialZones) > 1)
 
 	for _, zone := range zones {
-		if zone.status == unavailableZone {
+		if zone.status == unavailableZone || zone.getIsolation() != proto.ZoneIsolationNone {
 			continue
 		}
 		if contains(excludeZone, zone.name) {
@@ -1510,6 +1516,7 @@ type Zone struct {
 	QosFlowRLimit           uint64
 	QosFlowWLimit           uint64
 	dataMediaType           uint32
+	isolation               int32
 	sync.RWMutex
 }
 
@@ -1522,6 +1529,7 @@ type zoneValue struct {
 	DataNodesetSelector string
 	MetaNodesetSelector string
 	DataMediaType       uint32
+	Isolation           int32
 }
 
 func newZone(name string, dataMediaType uint32) (zone *Zone) {
@@ -1583,6 +1591,7 @@ func (zone *Zone) getFsmValue() *zoneValue {
 		DataNodesetSelector: zone.GetDataNodesetSelector(),
 		MetaNodesetSelector: zone.GetMetaNodesetSelector(),
 		DataMediaType:       zone.GetDataMediaType(),
+		Isolation:           zone.getIsolation(),
 	}
 }
 
@@ -1724,6 +1733,7 @@ func (zone *Zone) putDataNode(dataNode *DataNode) (err error) {
 		return
 	}
 	ns.putDataNode(dataNode)
+	dataNode.zoneIsolation.Store(zone.getIsolation())
 	zone.dataNodes.Store(dataNode.Addr, dataNode)
 	return
 }
@@ -1745,6 +1755,7 @@ func (zone *Zone) putMetaNode(metaNode *MetaNode) (err error) {
 		return
 	}
 	ns.putMetaNode(metaNode)
+	metaNode.zoneIsolation.Store(zone.getIsolation())
 	zone.metaNodes.Store(metaNode.Addr, metaNode)
 	return
 }
@@ -2412,8 +2423,14 @@ func (l *DecommissionDataPartitionList) handleDpTraverseToDecommission(dp *DataP
 			log.LogWarnf("[traverse] dp %v should wait for decommissionRetry,lastDecommissionRetryTime %v", dp.PartitionID, dp.DecommissionRetryTime)
 			return
 		}
+		bandwidth := c.getDecommissionBandwidthMBps()
+		if !c.decommissionThrottle.allow(bandwidth, time.Now()) {
+			log.LogDebugf("[traverse] dp %v waits for the decommission bandwidth %vMB/s", dp.PartitionID, bandwidth)
+			return
+		}
 		if dp.AcquireDecommissionFirstHostToken(c) {
 			if dp.TryAcquireDecommissionToken(c) {
+				c.decommissionThrottle.consume(bandwidth, dp.getMaxUsedSpace())
 				go func(dp *DataPartition) {
 					dp.TryToDecommission(c)
 				}(dp) // special replica cnt cost some time from prepare to running
@@ -54,6 +54,10 @@ func (u *User) createKey(param *proto.UserCreateParam) (userInfo *proto.UserInfo
 		err = proto.ErrInvalidUserType
 		return
 	}
+	if param.AdminRole != "" && !param.AdminRole.Valid() {
+		err = proto.ErrInvalidAdminRole
+		return
+	}
 
 	userID := param.ID
 	password := param.Password
@@ -80,6 +84,7 @@ func (u *User) createKey(param *proto.UserCreateParam) (userInfo *proto.UserInfo
 	}
 	userType := param.Type
 	description := param.Description
+	adminRole := param.AdminRole
 	u.userStoreMutex.Lock()
 	defer u.userStoreMutex.Unlock()
 	u.AKStoreMutex.Lock()
@@ -98,6 +103,7 @@ func (u *User) createKey(param *proto.UserCreateParam) (userInfo *proto.UserInfo
 	userInfo = &proto.UserInfo{
 		UserID: userID, AccessKey: accessKey, SecretKey: secretKey, Policy: userPolicy,
 		UserType: userType, CreateTime: time.Unix(time.Now().Unix(), 0).Format(proto.TimeFormat), Description: description,
+		AdminRole: adminRole,
 	}
 	AKUser = &proto.AKUser{AccessKey: accessKey, UserID: userID, Password: encodingPassword(password)}
 	if err = u.syncAddUserInfo(userInfo); err != nil {
@@ -180,7 +186,7 @@ func (u *User) updateKey(param *proto.UserUpdateParam) (userInfo *proto.UserInfo
 		return
 	}
 	formerAK := userInfo.AccessKey
-	var akMark, skMark, typeMark, describeMark int
+	var akMark, skMark, typeMark, describeMark, roleMark int
 	if param.AccessKey != "" {
 		if !proto.IsValidAK(param.AccessKey) {
 			err = proto.ErrInvalidAccessKey
@@ -211,6 +217,15 @@ func (u *User) updateKey(param *proto.UserUpdateParam) (userInfo *proto.UserInfo
 	if param.Description != "" {
 		describeMark = 1
 	}
+	// AdminRole == "", do not modify the role
+	if param.AdminRole != "" {
+		if param.AdminRole.Valid() {
+			roleMark = 1
+		} else {
+			err = proto.ErrInvalidAdminRole
+			return
+		}
+	}
 
 	var akUserBef *proto.AKUser
 	var akUserAft *proto.AKUser
@@ -233,6 +248,9 @@ func (u *User) updateKey(param *proto.UserUpdateParam) (userInfo *proto.UserInfo
 	if describeMark == 1 {
 		userInfo.Description = param.Description
 	}
+	if roleMark == 1 {
+		userInfo.AdminRole = param.AdminRole
+	}
 
 	if len(strings.TrimSpace(param.Password)) != 0 {
 		akUserBef.Password = encodingPassword(param.Password)
@@ -20,6 +20,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -41,6 +42,12 @@ type VolVarargs struct {
 	metaFollowerRead         bool
 	directRead               bool
 	ignoreTinyRecover        bool
+	syncMirrorWrite          bool
+	requireTLS               bool
+	inlineDataThreshold      int64
+	smallFileThreshold       int64
+	consistencyMode          string
+	mpWitnessNum             uint8
 	maximallyRead            bool
 	authenticate             bool
 	dpSelectorName           string
@@ -75,9 +82,17 @@ type VolVarargs struct {
 	remoteCacheMaxFileSizeGB     int64
 	remoteCacheOnlyForNotSSD     bool
 	remoteCacheMultiRead         bool
+	remoteCacheAlwaysAdmit       bool
+	remoteCacheAuth              bool
+	remoteCacheReadAheadMB       int64
+	remoteCacheMetaTTL           int64
+	remoteCacheReadLimitMBps     int64
+	remoteCacheReadLimitIops     int64
 	flashNodeTimeoutCount        int64
 	remoteCacheSameZoneTimeout   int64 // microsecond
 	remoteCacheSameRegionTimeout int64 // ms
+	remoteCacheHedgeDelay        int64
+	remoteCacheFallbackTimeout   int64
 }
 
 // nolint: structcheck
@@ -95,9 +110,17 @@ type TxSubItem struct {
 	remoteCacheMaxFileSizeGB     int64
 	remoteCacheOnlyForNotSSD     bool
 	remoteCacheMultiRead         bool
+	remoteCacheAlwaysAdmit       bool  // blocks of the volume bypass the admission filter of flash nodes
+	remoteCacheAuth              bool  // the flash nodes serve the clients holding a flash cache token only
+	remoteCacheReadAheadMB       int64 // clients prepare the blocks ahead of sequential reads
+	remoteCacheMetaTTL           int64 // seconds the flash groups cache the lookups and listings of the clients, 0 disables
+	remoteCacheReadLimitMBps     int64 // reads of the volume served by each flash node, 0 is unlimited
+	remoteCacheReadLimitIops     int64
 	flashNodeTimeoutCount        int64
 	remoteCacheSameZoneTimeout   int64 // microsecond
 	remoteCacheSameRegionTimeout int64 // ms
+	remoteCacheHedgeDelay        int64 // ms the clients hedging the flash reads wait for the first host
+	remoteCacheFallbackTimeout   int64 // ms the clients falling back to the data nodes wait for the flash reads
 
 	PreloadCacheOn          bool
 	NeedToLowerReplica      bool
@@ -167,6 +190,12 @@ type Vol struct {
 	MetaFollowerRead         bool
 	DirectRead               bool
 	IgnoreTinyRecover        bool
+	SyncMirrorWrite          bool   // writes are acked by a replica in another zone
+	RequireTLS               bool   // the data and meta nodes serve the clients of the volume over TLS only
+	InlineDataThreshold      int64  // files not larger are stored in their inode, 0 to disable
+	SmallFileThreshold       int64  // files not larger are packed into the container extents, 0 to disable
+	ConsistencyMode          string // visibility of the dentries changed by the other clients, "" for relaxed
+	MpWitnessNum             uint8  // replicas of the new meta partitions that are witnesses
 	MaximallyRead            bool
 	enableQuota              bool
 	DisableAuditLog          bool
@@ -178,6 +207,28 @@ type Vol struct {
 	LeaderRetryTimeout       int64 // s
 	EnableAutoMetaRepair     atomicutil.Bool
 	ForbidWriteOpOfProtoVer0 atomicutil.Bool
+	// a published volume is a finalized dataset, it stays read-only for good
+	Published   bool
+	PublishTime int64
+	// how the object and POSIX views of the volume interact
+	interop proto.InteropPolicy
+	// latency SLO targets keyed by op class, the map is replaced as a whole under sloLock
+	slo     map[string]*proto.SLOTarget
+	sloLock sync.RWMutex
+	// where the replicas must never be placed, replaced as a whole under placementLock
+	placementExclusion proto.PlacementExclusion
+	// the zones the replicas are pinned to and spread across, replaced as a whole under placementLock
+	placementPolicy proto.PlacementPolicy
+	placementLock   sync.RWMutex
+	// fencing tokens keyed by path, the map is replaced as a whole under fenceLock
+	fences    map[string]*proto.VolFence
+	fenceLock sync.RWMutex
+	// the deletions of the volume are boosted until it expires, replaced as a whole under reclaimLock
+	reclaimBoost *proto.ReclaimBoost
+	reclaimLock  sync.RWMutex
+	// the volumes the files of the volume are forked from, replaced as a whole under forkLock
+	forkSources []string
+	forkLock    sync.RWMutex
 
 	TopoSubItem
 	CacheSubItem
@@ -209,6 +260,14 @@ func newVol(vv volValue) (vol *Vol) {
 	vol.dataPartitions = newDataPartitionMap(vv.Name)
 	vol.VersionMgr = newVersionMgr(vol)
 	vol.dpReplicaNum = vv.DpReplicaNum
+	vol.interop = vv.Interop
+	vol.interop.Normalize()
+	vol.slo = vv.SLO
+	vol.fences = newVolFences(vv.Fences)
+	vol.placementExclusion = vv.PlacementExclusion
+	vol.placementPolicy = vv.PlacementPolicy
+	vol.reclaimBoost = vv.ReclaimBoost
+	vol.forkSources = vv.ForkSources
 	vol.mpReplicaNum = vv.ReplicaNum
 	vol.Owner = vv.Owner
 
@@ -218,6 +277,12 @@ func newVol(vv volValue) (vol *Vol) {
 	vol.MetaFollowerRead = vv.MetaFollowerRead
 	vol.DirectRead = vv.DirectRead
 	vol.IgnoreTinyRecover = vv.IgnoreTinyRecover
+	vol.SyncMirrorWrite = vv.SyncMirrorWrite
+	vol.RequireTLS = vv.RequireTLS
+	vol.InlineDataThreshold = vv.InlineDataThreshold
+	vol.SmallFileThreshold = vv.SmallFileThreshold
+	vol.ConsistencyMode = vv.ConsistencyMode
+	vol.MpWitnessNum = vv.MpWitnessNum
 	vol.MaximallyRead = vv.MaximallyRead
 	vol.LeaderRetryTimeout = vv.LeaderRetryTimeOut
 	vol.authenticate = vv.Authenticate
@@ -249,9 +314,17 @@ func newVol(vv volValue) (vol *Vol) {
 	vol.remoteCacheMaxFileSizeGB = vv.RemoteCacheMaxFileSizeGB
 	vol.remoteCacheOnlyForNotSSD = vv.RemoteCacheOnlyForNotSSD
 	vol.remoteCacheMultiRead = vv.RemoteCacheMultiRead
+	vol.remoteCacheAlwaysAdmit = vv.RemoteCacheAlwaysAdmit
+	vol.remoteCacheAuth = vv.RemoteCacheAuth
+	vol.remoteCacheReadAheadMB = vv.RemoteCacheReadAheadMB
+	vol.remoteCacheMetaTTL = vv.RemoteCacheMetaTTL
+	vol.remoteCacheReadLimitMBps = vv.RemoteCacheReadLimitMBps
+	vol.remoteCacheReadLimitIops = vv.RemoteCacheReadLimitIops
 	vol.flashNodeTimeoutCount = vv.FlashNodeTimeoutCount
 	vol.remoteCacheSameZoneTimeout = vv.RemoteCacheSameZoneTimeout
 	vol.remoteCacheSameRegionTimeout = vv.RemoteCacheSameRegionTimeout
+	vol.remoteCacheHedgeDelay = vv.RemoteCacheHedgeDelay
+	vol.remoteCacheFallbackTimeout = vv.RemoteCacheFallbackTimeout
 
 	limitQosVal := &qosArgs{
 		qosEnable:     vv.VolQosEnable,
@@ -321,6 +394,8 @@ func newVolFromVolValue(vv *volValue) (vol *Vol) {
 	vol.TrashInterval = vv.TrashInterval
 	vol.DisableAuditLog = vv.DisableAuditLog
 	vol.Forbidden = vv.Forbidden
+	vol.Published = vv.Published
+	vol.PublishTime = vv.PublishTime
 	vol.authKey = vv.AuthKey
 	vol.DeleteExecTime = vv.DeleteExecTime
 	vol.user = vv.User
@@ -354,6 +429,12 @@ func newVolFromVolValue(vv *volValue) (vol *Vol) {
 	if vol.remoteCacheSameRegionTimeout == 0 {
 		vol.remoteCacheSameRegionTimeout = proto.DefaultRemoteCacheSameRegionTimeout
 	}
+	if vol.remoteCacheHedgeDelay == 0 {
+		vol.remoteCacheHedgeDelay = proto.DefaultRemoteCacheHedgeDelay
+	}
+	if vol.remoteCacheFallbackTimeout == 0 {
+		vol.remoteCacheFallbackTimeout = proto.DefaultRemoteCacheFallbackTimeout
+	}
 	return vol
 }
 
@@ -550,12 +631,15 @@ func (vol *Vol) metaPartition(partitionID uint64) (mp *MetaPartition, err error)
 	return
 }
 
+// maxMetaPartitionID returns the id of the partition of the last inode range, the max id
+// unless a partition was split in the middle of the range.
 func (vol *Vol) maxMetaPartitionID() (maxPartitionID uint64) {
 	vol.mpsLock.RLock()
 	defer vol.mpsLock.RUnlock()
-	for id := range vol.MetaPartitions {
-		if id > maxPartitionID {
-			maxPartitionID = id
+	var maxStart uint64
+	for id, mp := range vol.MetaPartitions {
+		if maxPartitionID == 0 || mp.Start > maxStart {
+			maxPartitionID, maxStart = id, mp.Start
 		}
 	}
 	return
@@ -688,7 +772,9 @@ func (vol *Vol) initMetaPartitions(c *Cluster, count int) (err error) {
 
 func (vol *Vol) checkDataPartitions(c *Cluster) (cnt int) {
 	shouldDpInhibitWriteByVolFull := vol.shouldInhibitWriteBySpaceFull()
-	vol.SetReadOnlyForVolFull(shouldDpInhibitWriteByVolFull)
+	if vol.SetReadOnlyForVolFull(shouldDpInhibitWriteByVolFull) {
+		c.recordVolEvent(vol.Name, proto.VolEventReadOnly, "read only for full[%v]", vol.IsReadOnlyForVolFull())
+	}
 
 	statsByClass := vol.getStorageStatWithClass()
 	for _, stat := range statsByClass {
@@ -1089,6 +1175,69 @@ func (vol *Vol) AllPartitionForbidVer0() bool {
 	return true
 }
 
+func (vol *Vol) getSLO() map[string]*proto.SLOTarget {
+	vol.sloLock.RLock()
+	defer vol.sloLock.RUnlock()
+	return vol.slo
+}
+
+// setSLO sets the target of op, a nil target removes it. It returns the previous targets for rolling back.
+func (vol *Vol) setSLO(op string, target *proto.SLOTarget) (old map[string]*proto.SLOTarget) {
+	vol.sloLock.Lock()
+	defer vol.sloLock.Unlock()
+	old = vol.slo
+	slo := make(map[string]*proto.SLOTarget, len(old)+1)
+	for k, v := range old {
+		slo[k] = v
+	}
+	if target == nil {
+		delete(slo, op)
+	} else {
+		slo[op] = target
+	}
+	if len(slo) == 0 {
+		slo = nil
+	}
+	vol.slo = slo
+	return
+}
+
+func (vol *Vol) restoreSLO(old map[string]*proto.SLOTarget) {
+	vol.sloLock.Lock()
+	vol.slo = old
+	vol.sloLock.Unlock()
+}
+
+func (vol *Vol) getPlacementExclusion() proto.PlacementExclusion {
+	vol.placementLock.RLock()
+	defer vol.placementLock.RUnlock()
+	return vol.placementExclusion
+}
+
+// setPlacementExclusion returns the previous exclusion for rolling back.
+func (vol *Vol) setPlacementExclusion(exclusion proto.PlacementExclusion) (old proto.PlacementExclusion) {
+	vol.placementLock.Lock()
+	defer vol.placementLock.Unlock()
+	old = vol.placementExclusion
+	vol.placementExclusion = exclusion
+	return
+}
+
+func (vol *Vol) getPlacementPolicy() proto.PlacementPolicy {
+	vol.placementLock.RLock()
+	defer vol.placementLock.RUnlock()
+	return vol.placementPolicy
+}
+
+// setPlacementPolicy returns the previous policy for rolling back.
+func (vol *Vol) setPlacementPolicy(policy proto.PlacementPolicy) (old proto.PlacementPolicy) {
+	vol.placementLock.Lock()
+	defer vol.placementLock.Unlock()
+	old = vol.placementPolicy
+	vol.placementPolicy = policy
+	return
+}
+
 func (vol *Vol) setStatus(status uint8) {
 	vol.volLock.Lock()
 	defer vol.volLock.Unlock()
@@ -1101,16 +1250,33 @@ func (vol *Vol) status() uint8 {
 	return vol.Status
 }
 
+// matchListFilter returns whether the volume is of the owner, in the zone and
+// of the status filtered.
+func (vol *Vol) matchListFilter(filter listFilter) bool {
+	vol.volLock.RLock()
+	defer vol.volLock.RUnlock()
+	if filter.owner != "" && vol.Owner != filter.owner {
+		return false
+	}
+	status := listStatusNormal
+	if vol.Status == proto.VolStatusMarkDelete {
+		status = listStatusMarkDelete
+	}
+	return filter.matchStatus(status) && filter.matchZone(strings.Split(vol.zoneName, ",")...)
+}
+
 func (vol *Vol) capacity() uint64 {
 	vol.volLock.RLock()
 	defer vol.volLock.RUnlock()
 	return vol.Capacity
 }
 
-func (vol *Vol) SetReadOnlyForVolFull(isFull bool) {
+// SetReadOnlyForVolFull tells whether the read only state of the volume changed.
+func (vol *Vol) SetReadOnlyForVolFull(isFull bool) (changed bool) {
 	vol.volLock.Lock()
 	defer vol.volLock.Unlock()
 
+	old := vol.ReadOnlyForVolFull
 	if isFull {
 		if vol.DpReadOnlyWhenVolFull {
 			vol.ReadOnlyForVolFull = isFull
@@ -1118,6 +1284,7 @@ func (vol *Vol) SetReadOnlyForVolFull(isFull bool) {
 	} else {
 		vol.ReadOnlyForVolFull = isFull
 	}
+	return old != vol.ReadOnlyForVolFull
 }
 
 func (vol *Vol) IsReadOnlyForVolFull() bool {
@@ -1589,6 +1756,9 @@ func (vol *Vol) getTasksToDeleteDataPartitions() (tasks []*proto.AdminTask) {
 		for _, replica := range dp.Replicas {
 			tasks = append(tasks, dp.createTaskToDeleteDataPartition(replica.Addr, false))
 		}
+		for _, rr := range dp.ReadReplicas {
+			tasks = append(tasks, dp.createTaskToDeleteDataPartition(rr.Addr, false))
+		}
 	}
 	return
 }
@@ -1606,12 +1776,18 @@ func (vol *Vol) String() string {
 }
 
 func (vol *Vol) doSplitMetaPartition(c *Cluster, mp *MetaPartition, end uint64, metaPartitionInodeIdStep uint64, ignoreNoLeader bool) (nextMp *MetaPartition, err error) {
+	// a partition split in the middle of the range hands the rest of its range over
+	isRear := vol.maxMetaPartitionID() == mp.PartitionID
 	mp.Lock()
 	defer mp.Unlock()
 
 	if err = mp.canSplit(end, metaPartitionInodeIdStep, ignoreNoLeader); err != nil {
 		return
 	}
+	if !isRear && end >= mp.End {
+		err = fmt.Errorf("next meta partition start must be less than %v", mp.End)
+		return
+	}
 
 	log.LogWarnf("action[splitMetaPartition],partition[%v],start[%v],end[%v],new end[%v]", mp.PartitionID, mp.Start, mp.End, end)
 	cmdMap := make(map[string]*RaftCmd)
@@ -1624,7 +1800,11 @@ func (vol *Vol) doSplitMetaPartition(c *Cluster, mp *MetaPartition, end uint64,
 	}
 
 	cmdMap[updateMpRaftCmd.K] = updateMpRaftCmd
-	if nextMp, err = vol.doCreateMetaPartition(c, mp.End+1, defaultMaxMetaPartitionInodeID); err != nil {
+	nextEnd := uint64(defaultMaxMetaPartitionInodeID)
+	if !isRear {
+		nextEnd = oldEnd
+	}
+	if nextMp, err = vol.doCreateMetaPartition(c, mp.End+1, nextEnd); err != nil {
 		Warn(c.Name, fmt.Sprintf("action[updateEnd] clusterID[%v] partitionID[%v] create meta partition err[%v]",
 			c.Name, mp.PartitionID, err))
 		log.LogErrorf("action[updateEnd] partitionID[%v] err[%v]", mp.PartitionID, err)
@@ -1673,6 +1853,8 @@ func (vol *Vol) splitMetaPartition(c *Cluster, mp *MetaPartition, end uint64, me
 
 	vol.addMetaPartition(nextMp)
 	log.LogWarnf("action[splitMetaPartition],next partition[%v],start[%v],end[%v]", nextMp.PartitionID, nextMp.Start, nextMp.End)
+	c.recordVolEvent(vol.Name, proto.VolEventAddMetaPartition, "mp[%v] inodes [%v, %v] hosts%v split from mp[%v]",
+		nextMp.PartitionID, nextMp.Start, nextMp.End, nextMp.Hosts, mp.PartitionID)
 	return
 }
 
@@ -1685,6 +1867,7 @@ func (vol *Vol) createMetaPartition(c *Cluster, start, end uint64) (err error) {
 		return errors.NewError(err)
 	}
 	vol.addMetaPartition(mp)
+	c.recordVolEvent(vol.Name, proto.VolEventAddMetaPartition, "mp[%v] inodes [%v, %v] hosts%v", mp.PartitionID, start, end, mp.Hosts)
 	return
 }
 
@@ -1704,10 +1887,10 @@ func (vol *Vol) doCreateMetaPartition(c *Cluster, start, end uint64) (mp *MetaPa
 			return nil, errors.NewError(err)
 		}
 	} else {
-		var excludeZone []string
-		zoneNum := c.decideZoneNum(vol, proto.StorageClass_Unspecified)
-		if hosts, peers, err = c.getHostFromNormalZone(TypeMetaPartition, excludeZone, nil, nil,
-			int(vol.mpReplicaNum), zoneNum, vol.zoneName, proto.StorageClass_Unspecified); err != nil {
+		excludeZone := vol.getPlacementExclusion().Zones
+		zoneName, zoneNum := c.placementZones(vol, proto.StorageClass_Unspecified)
+		if hosts, peers, err = c.getHostFromNormalZone(TypeMetaPartition, excludeZone, nil, c.placementExcludedHosts(vol, TypeMetaPartition),
+			int(vol.mpReplicaNum), zoneNum, zoneName, proto.StorageClass_Unspecified); err != nil {
 			log.LogErrorf("action[doCreateMetaPartition] getHostFromNormalZone err[%v]", err)
 			return nil, errors.NewError(err)
 		}
@@ -1716,6 +1899,9 @@ func (vol *Vol) doCreateMetaPartition(c *Cluster, start, end uint64) (mp *MetaPa
 	if err = c.checkMultipleReplicasOnSameMachine(hosts); err != nil {
 		return nil, err
 	}
+	if err = c.checkPlacementExclusion(vol, TypeMetaPartition, hosts); err != nil {
+		return nil, err
+	}
 
 	log.LogInfof("target meta hosts:%v,peers:%v", hosts, peers)
 	if partitionID, err = c.idAlloc.allocateMetaPartitionID(); err != nil {
@@ -1724,7 +1910,7 @@ func (vol *Vol) doCreateMetaPartition(c *Cluster, start, end uint64) (mp *MetaPa
 
 	mp = newMetaPartition(partitionID, start, end, vol.mpReplicaNum, vol.Name, vol.ID, vol.VersionMgr.getLatestVer())
 	mp.setHosts(hosts)
-	mp.setPeers(peers)
+	mp.setPeers(withWitnesses(peers, int(vol.MpWitnessNum), vol.mpReplicaNum))
 
 	for _, host := range hosts {
 		wg.Add(1)
@@ -1782,6 +1968,12 @@ func setVolFromArgs(args *VolVarargs, vol *Vol) {
 	vol.MetaFollowerRead = args.metaFollowerRead
 	vol.DirectRead = args.directRead
 	vol.IgnoreTinyRecover = args.ignoreTinyRecover
+	vol.SyncMirrorWrite = args.syncMirrorWrite
+	vol.RequireTLS = args.requireTLS
+	vol.InlineDataThreshold = args.inlineDataThreshold
+	vol.SmallFileThreshold = args.smallFileThreshold
+	vol.ConsistencyMode = args.consistencyMode
+	vol.MpWitnessNum = args.mpWitnessNum
 	vol.MaximallyRead = args.maximallyRead
 	vol.authenticate = args.authenticate
 	vol.enablePosixAcl = args.enablePosixAcl
@@ -1832,9 +2024,17 @@ func setVolFromArgs(args *VolVarargs, vol *Vol) {
 	vol.remoteCacheMaxFileSizeGB = args.remoteCacheMaxFileSizeGB
 	vol.remoteCacheOnlyForNotSSD = args.remoteCacheOnlyForNotSSD
 	vol.remoteCacheMultiRead = args.remoteCacheMultiRead
+	vol.remoteCacheAlwaysAdmit = args.remoteCacheAlwaysAdmit
+	vol.remoteCacheAuth = args.remoteCacheAuth
+	vol.remoteCacheReadAheadMB = args.remoteCacheReadAheadMB
+	vol.remoteCacheMetaTTL = args.remoteCacheMetaTTL
+	vol.remoteCacheReadLimitMBps = args.remoteCacheReadLimitMBps
+	vol.remoteCacheReadLimitIops = args.remoteCacheReadLimitIops
 	vol.flashNodeTimeoutCount = args.flashNodeTimeoutCount
 	vol.remoteCacheSameZoneTimeout = args.remoteCacheSameZoneTimeout
 	vol.remoteCacheSameRegionTimeout = args.remoteCacheSameRegionTimeout
+	vol.remoteCacheHedgeDelay = args.remoteCacheHedgeDelay
+	vol.remoteCacheFallbackTimeout = args.remoteCacheFallbackTimeout
 }
 
 func getVolVarargs(vol *Vol) *VolVarargs {
@@ -1860,6 +2060,12 @@ func getVolVarargs(vol *Vol) *VolVarargs {
 		metaFollowerRead:         vol.MetaFollowerRead,
 		directRead:               vol.DirectRead,
 		ignoreTinyRecover:        vol.IgnoreTinyRecover,
+		syncMirrorWrite:          vol.SyncMirrorWrite,
+		requireTLS:               vol.RequireTLS,
+		inlineDataThreshold:      vol.InlineDataThreshold,
+		smallFileThreshold:       vol.SmallFileThreshold,
+		consistencyMode:          vol.ConsistencyMode,
+		mpWitnessNum:             vol.MpWitnessNum,
 		maximallyRead:            vol.MaximallyRead,
 		leaderRetryTimeout:       vol.LeaderRetryTimeout,
 		authenticate:             vol.authenticate,
@@ -1892,9 +2098,17 @@ func getVolVarargs(vol *Vol) *VolVarargs {
 		remoteCacheMaxFileSizeGB:     vol.remoteCacheMaxFileSizeGB,
 		remoteCacheOnlyForNotSSD:     vol.remoteCacheOnlyForNotSSD,
 		remoteCacheMultiRead:         vol.remoteCacheMultiRead,
+		remoteCacheAlwaysAdmit:       vol.remoteCacheAlwaysAdmit,
+		remoteCacheAuth:              vol.remoteCacheAuth,
+		remoteCacheReadAheadMB:       vol.remoteCacheReadAheadMB,
+		remoteCacheMetaTTL:           vol.remoteCacheMetaTTL,
+		remoteCacheReadLimitMBps:     vol.remoteCacheReadLimitMBps,
+		remoteCacheReadLimitIops:     vol.remoteCacheReadLimitIops,
 		flashNodeTimeoutCount:        vol.flashNodeTimeoutCount,
 		remoteCacheSameZoneTimeout:   vol.remoteCacheSameZoneTimeout,
 		remoteCacheSameRegionTimeout: vol.remoteCacheSameRegionTimeout,
+		remoteCacheHedgeDelay:        vol.remoteCacheHedgeDelay,
+		remoteCacheFallbackTimeout:   vol.remoteCacheFallbackTimeout,
 	}
 }
 
@@ -1934,9 +2148,10 @@ func (vol *Vol) checkDataReplicaMeta(c *Cluster) (cnt int) {
 	checkMetaPool := routinepool.NewRoutinePool(c.GetAutoDpMetaRepairParallelCnt())
 	defer checkMetaPool.WaitAndClose()
 	var checkMetaDpWg sync.WaitGroup
+	maintenances := c.activeMaintenances(time.Now().Unix())
 
 	for _, dp := range partitions {
-		if dp.IsDiscard {
+		if dp.IsDiscard || dp.inMaintenance(maintenances) {
 			continue
 		}
 		// NOTE: cluster or enable meta repair
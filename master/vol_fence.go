@@ -0,0 +1,166 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+)
+
+// The fences of a volume are persisted with the volume and sent to the metanodes
+// and datanodes by the heartbeats, so an acquired token takes effect on a node at
+// its next heartbeat. The tokens of a volume only grow, a newly acquired one is
+// greater than all the tokens of the volume.
+
+func newVolFences(fences []*proto.VolFence) map[string]*proto.VolFence {
+	if len(fences) == 0 {
+		return nil
+	}
+	m := make(map[string]*proto.VolFence, len(fences))
+	for _, f := range fences {
+		m[f.Path] = f
+	}
+	return m
+}
+
+// getFences returns the fences of the volume sorted by path.
+func (vol *Vol) getFences() (fences []*proto.VolFence) {
+	vol.fenceLock.RLock()
+	defer vol.fenceLock.RUnlock()
+	if len(vol.fences) == 0 {
+		return nil
+	}
+	fences = make([]*proto.VolFence, 0, len(vol.fences))
+	for _, f := range vol.fences {
+		fences = append(fences, f)
+	}
+	sort.Slice(fences, func(i, j int) bool { return fences[i].Path < fences[j].Path })
+	return
+}
+
+// acquireFence gives path a token greater than all the tokens of the volume. It
+// returns the previous fences for rolling back.
+func (vol *Vol) acquireFence(path string) (fence *proto.VolFence, old map[string]*proto.VolFence) {
+	vol.fenceLock.Lock()
+	defer vol.fenceLock.Unlock()
+	old = vol.fences
+	fences := make(map[string]*proto.VolFence, len(old)+1)
+	var max uint64
+	for k, v := range old {
+		fences[k] = v
+		if v.Token > max {
+			max = v.Token
+		}
+	}
+	fence = &proto.VolFence{Volume: vol.Name, Path: path, Token: max + 1, UpdateTime: time.Now().Unix()}
+	fences[path] = fence
+	vol.fences = fences
+	return
+}
+
+func (vol *Vol) restoreFences(old map[string]*proto.VolFence) {
+	vol.fenceLock.Lock()
+	vol.fences = old
+	vol.fenceLock.Unlock()
+}
+
+func (c *Cluster) acquireVolFence(vol *Vol, path string) (fence *proto.VolFence, err error) {
+	fence, old := vol.acquireFence(path)
+	if err = c.syncUpdateVol(vol); err != nil {
+		vol.restoreFences(old)
+		return nil, err
+	}
+	c.recordVolEvent(vol.Name, proto.VolEventFence, "path %v fenced with token %v", path, fence.Token)
+	return
+}
+
+// allVolFences returns the fences of all the volumes sent by the heartbeats.
+func (c *Cluster) allVolFences() (fences []*proto.VolFence) {
+	for _, vol := range c.allVols() {
+		fences = append(fences, vol.getFences()...)
+	}
+	return
+}
+
+// acquireVolFence fences the writers of the volume, or of a subtree of it, holding
+// an older token.
+func (m *Server) acquireVolFence(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    string
+		authKey string
+		path    string
+		fence   *proto.VolFence
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolFenceAcquire))
+	defer func() {
+		doStatAndMetric(proto.AdminVolFenceAcquire, metric, err, nil)
+		AuditLog(r, proto.AdminVolFenceAcquire, fmt.Sprintf("acquire volume(%s) fence of path(%v)", name, path), err)
+	}()
+	if name, authKey, err = parseRequestToVolOwnerOp(r); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if path, err = proto.CleanFencePath(r.FormValue(fencePathKey)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(vol.Owner, authKey) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if fence, err = m.cluster.acquireVolFence(vol, path); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fence))
+}
+
+func (m *Server) listVolFences(w http.ResponseWriter, r *http.Request) {
+	var (
+		name common.String
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolFenceList))
+	defer func() {
+		doStatAndMetric(proto.AdminVolFenceList, metric, err, nil)
+	}()
+	if err = parseArgs(r, name.Key(nameKey)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name.V)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	fences := vol.getFences()
+	if fences == nil {
+		fences = make([]*proto.VolFence, 0)
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fences))
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A volume forked from another one imports the metadata of a subtree of it, its
+// files refer to the extents of the source without copying the data. While
+// forked, the source is pinned: its clients overwrite by append instead of in
+// place and its meta nodes keep the extents of the deleted files, so that the
+// data the forks refer to never changes. The forks read the partitions of their
+// sources, listed by /client/forkPartitions, and overwrite the shared extents
+// by append as well but never delete them. A fork is removed once the target
+// does not refer to the data of the source any more, or with the target.
+
+// forkLock serializes the changes of the forks, not to chain them.
+var forkLock sync.Mutex
+
+func (vol *Vol) getForkSources() []string {
+	vol.forkLock.RLock()
+	defer vol.forkLock.RUnlock()
+	return vol.forkSources
+}
+
+// setForkSources sets the fork sources of the volume. It returns the previous
+// ones for rolling back.
+func (vol *Vol) setForkSources(sources []string) (old []string) {
+	vol.forkLock.Lock()
+	defer vol.forkLock.Unlock()
+	old, vol.forkSources = vol.forkSources, sources
+	return
+}
+
+func (vol *Vol) isForkedFrom(source string) bool {
+	for _, name := range vol.getForkSources() {
+		if name == source {
+			return true
+		}
+	}
+	return false
+}
+
+// getVolForkTargets returns the volumes forked from the volume, including the
+// ones marked deleted but not removed yet.
+func (c *Cluster) getVolForkTargets(name string) (targets []string) {
+	c.volMutex.RLock()
+	defer c.volMutex.RUnlock()
+	for _, vol := range c.vols {
+		if vol.isForkedFrom(name) {
+			targets = append(targets, vol.Name)
+		}
+	}
+	sort.Strings(targets)
+	return
+}
+
+func (c *Cluster) addVolFork(target, source *Vol) (err error) {
+	forkLock.Lock()
+	defer forkLock.Unlock()
+	if target.Name == source.Name {
+		return fmt.Errorf("vol %v can not be forked from itself", target.Name)
+	}
+	if !proto.IsHot(target.VolType) || !proto.IsHot(source.VolType) {
+		return fmt.Errorf("only the replica volumes can be forked")
+	}
+	if source.Status == proto.VolStatusMarkDelete || target.Status == proto.VolStatusMarkDelete {
+		return proto.ErrVolHasDeleted
+	}
+	if target.isForkedFrom(source.Name) {
+		return
+	}
+	// the forks are not chained, the partitions of the sources are not listed recursively
+	if len(source.getForkSources()) > 0 {
+		return fmt.Errorf("vol %v is forked from %v, a fork can not be forked", source.Name, source.getForkSources())
+	}
+	if targets := c.getVolForkTargets(target.Name); len(targets) > 0 {
+		return fmt.Errorf("vol %v is forked by %v, a fork source can not be forked", target.Name, targets)
+	}
+	cur := target.getForkSources()
+	sources := make([]string, 0, len(cur)+1)
+	sources = append(append(sources, cur...), source.Name)
+	sort.Strings(sources)
+	old := target.setForkSources(sources)
+	if err = c.syncUpdateVol(target); err != nil {
+		target.setForkSources(old)
+		return
+	}
+	log.LogWarnf("action[addVolFork] vol(%v) fork sources %v -> %v", target.Name, old, sources)
+	c.recordVolEvent(target.Name, proto.VolEventFork, "forked from %v", source.Name)
+	c.recordVolEvent(source.Name, proto.VolEventFork, "forked by %v", target.Name)
+	return
+}
+
+func (c *Cluster) removeVolFork(target *Vol, source string) (err error) {
+	forkLock.Lock()
+	defer forkLock.Unlock()
+	if !target.isForkedFrom(source) {
+		return fmt.Errorf("vol %v is not forked from %v", target.Name, source)
+	}
+	sources := make([]string, 0)
+	for _, name := range target.getForkSources() {
+		if name != source {
+			sources = append(sources, name)
+		}
+	}
+	if len(sources) == 0 {
+		sources = nil
+	}
+	old := target.setForkSources(sources)
+	if err = c.syncUpdateVol(target); err != nil {
+		target.setForkSources(old)
+		return
+	}
+	log.LogWarnf("action[removeVolFork] vol(%v) fork sources %v -> %v", target.Name, old, sources)
+	c.recordVolEvent(target.Name, proto.VolEventFork, "fork from %v removed", source)
+	c.recordVolEvent(source, proto.VolEventFork, "fork by %v removed", target.Name)
+	return
+}
+
+// addVolFork registers the volume as forked from the source, which pins the
+// source. It takes the authKey of the source, whose owner lends its data.
+func (m *Server) addVolFork(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    common.String
+		source  common.String
+		authKey common.String
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolForkAdd))
+	defer func() {
+		doStatAndMetric(proto.AdminVolForkAdd, metric, err, nil)
+		AuditLog(r, proto.AdminVolForkAdd, fmt.Sprintf("fork volume(%s) from volume(%s)", name.V, source.V), err)
+	}()
+	if err = parseArgs(r, name.Key(nameKey), source.Key("source"), authKey.Key(volAuthKey)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	target, err := m.cluster.getVol(name.V)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	src, err := m.cluster.getVol(source.V)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(src.Owner, authKey.V) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if err = m.cluster.addVolFork(target, src); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("fork vol[%v] from vol[%v] successfully", name.V, source.V)))
+}
+
+// removeVolFork unpins the source. It takes the authKey of the target, whose
+// files must not refer to the data of the source any more.
+func (m *Server) removeVolFork(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    common.String
+		source  common.String
+		authKey common.String
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolForkRemove))
+	defer func() {
+		doStatAndMetric(proto.AdminVolForkRemove, metric, err, nil)
+		AuditLog(r, proto.AdminVolForkRemove, fmt.Sprintf("remove fork of volume(%s) from volume(%s)", name.V, source.V), err)
+	}()
+	if err = parseArgs(r, name.Key(nameKey), source.Key("source"), authKey.Key(volAuthKey)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	target, err := m.cluster.getVol(name.V)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if !matchKey(target.Owner, authKey.V) {
+		err = proto.ErrVolAuthKeyNotMatch
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if err = m.cluster.removeVolFork(target, source.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("remove fork of vol[%v] from vol[%v] successfully", name.V, source.V)))
+}
+
+// getForkPartitions returns the partitions of the fork sources of the volume
+// to its clients and meta nodes.
+func (m *Server) getForkPartitions(w http.ResponseWriter, r *http.Request) {
+	var (
+		name common.String
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.ClientForkPartitions))
+	defer func() {
+		doStatAndMetric(proto.ClientForkPartitions, metric, err, map[string]string{exporter.Vol: name.V})
+	}()
+	if err = parseArgs(r, name.Key(nameKey)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name.V)
+	if err != nil {
+		sendErrReply(w, r, newErrHTTPReply(proto.ErrVolNotExists))
+		return
+	}
+	view := proto.NewDataPartitionsView()
+	view.ForkPartitions = make([]*proto.DataPartitionResponse, 0)
+	for _, source := range vol.getForkSources() {
+		src, srcErr := m.cluster.getVol(source)
+		if srcErr != nil {
+			log.LogWarnf("action[getForkPartitions] fork source %v of vol %v: %v", source, name.V, srcErr)
+			continue
+		}
+		view.ForkPartitions = append(view.ForkPartitions, src.dataPartitions.getDataPartitionsView(0)...)
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(view))
+}
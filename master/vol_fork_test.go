@@ -0,0 +1,80 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolFork(t *testing.T) {
+	name := "forkVol"
+	createVol(map[string]interface{}{nameKey: name}, t)
+	defer func() {
+		reqURL := fmt.Sprintf("%v%v?name=%v&authKey=%v", hostAddr, proto.AdminDeleteVol, name, buildAuthKey(testOwner))
+		process(reqURL, t)
+	}()
+
+	api := mc.AdminAPI()
+	source, err := server.cluster.getVol(commonVolName)
+	require.NoError(t, err)
+	// the fork is added with the key of the source and removed with the key of the target
+	srcKey, authKey := buildAuthKey(source.Owner), buildAuthKey(testOwner)
+	require.Error(t, api.AddVolumeFork(name, commonVolName, "wrongKey"))
+	require.Error(t, api.AddVolumeFork(name, name, authKey))
+	require.Error(t, api.AddVolumeFork(name, "noSuchVol", srcKey))
+	require.NoError(t, api.AddVolumeFork(name, commonVolName, srcKey))
+	defer api.RemoveVolumeFork(name, commonVolName, authKey)
+	require.NoError(t, api.AddVolumeFork(name, commonVolName, srcKey))
+	// the forks are not chained
+	require.Error(t, api.AddVolumeFork(commonVolName, name, authKey))
+
+	view, err := api.GetVolumeSimpleInfo(name)
+	require.NoError(t, err)
+	require.Equal(t, []string{commonVolName}, view.ForkSources)
+	require.Empty(t, view.ForkTargets)
+	view, err = api.GetVolumeSimpleInfo(commonVolName)
+	require.NoError(t, err)
+	require.Equal(t, []string{name}, view.ForkTargets)
+
+	dpv, err := mc.ClientAPI().GetForkPartitions(name)
+	require.NoError(t, err)
+	require.Empty(t, dpv.DataPartitions)
+	require.Len(t, dpv.ForkPartitions, len(source.dataPartitions.getDataPartitionsView(0)))
+
+	// the forks are persisted with the volume
+	err, views := server.cluster.loadVolsViews()
+	require.NoError(t, err)
+	for _, vv := range views {
+		if vv.Name == name {
+			require.Equal(t, []string{commonVolName}, newVol(*vv).getForkSources())
+		}
+	}
+
+	// a fork source can not be deleted
+	require.Error(t, server.cluster.markDeleteVol(commonVolName, srcKey, false, true))
+	require.Equal(t, uint8(proto.VolStatusNormal), source.Status)
+
+	require.Error(t, api.RemoveVolumeFork(name, commonVolName, "wrongKey"))
+	require.NoError(t, api.RemoveVolumeFork(name, commonVolName, authKey))
+	require.Error(t, api.RemoveVolumeFork(name, commonVolName, authKey))
+	dpv, err = mc.ClientAPI().GetForkPartitions(name)
+	require.NoError(t, err)
+	require.Empty(t, dpv.ForkPartitions)
+	require.Empty(t, server.cluster.getVolForkTargets(commonVolName))
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The space of the deleted files returns to the data nodes once the meta
+// nodes delete their extents, at the pace of the delete workers set by the
+// batchCount and deleteWorkerSleepMs of the meta nodes. The leaders of the
+// meta partitions report the space not returned yet on the heartbeats, and a
+// volume can be boosted for a while: its delete workers take larger batches
+// and skip the sleeps. The boosts are capped in batch count, in duration and
+// in the number of volumes boosted at once, so that the data nodes are not
+// flooded with deletions.
+
+const (
+	defaultReclaimBoostBatchCount  = 1024
+	maxReclaimBoostBatchCount      = 8192
+	defaultReclaimBoostDurationSec = 3600
+	maxReclaimBoostDurationSec     = 24 * 3600
+	maxReclaimBoostVols            = 4
+)
+
+// reclaimBoostLock serializes the boosts, not to boost more volumes than the cap.
+var reclaimBoostLock sync.Mutex
+
+func (vol *Vol) getReclaimBoost() *proto.ReclaimBoost {
+	vol.reclaimLock.RLock()
+	defer vol.reclaimLock.RUnlock()
+	return vol.reclaimBoost
+}
+
+// setReclaimBoost sets the boost of the volume, nil removes it. It returns the
+// previous boost for rolling back.
+func (vol *Vol) setReclaimBoost(boost *proto.ReclaimBoost) (old *proto.ReclaimBoost) {
+	vol.reclaimLock.Lock()
+	defer vol.reclaimLock.Unlock()
+	old, vol.reclaimBoost = vol.reclaimBoost, boost
+	return
+}
+
+// getReclaimView sums up the reports of the leaders of the meta partitions of the volume.
+func (vol *Vol) getReclaimView(now time.Time) (view *proto.VolReclaimView) {
+	view = &proto.VolReclaimView{Name: vol.Name, Partitions: make([]*proto.MetaPartitionReclaim, 0)}
+	if boost := vol.getReclaimBoost(); boost.Active(now) {
+		view.Boost = boost
+	}
+	for _, mp := range vol.cloneMetaPartitionMap() {
+		reclaim := mp.getReclaim()
+		if reclaim == nil {
+			continue
+		}
+		view.FreeInodes += reclaim.FreeInodes
+		view.FreeBytes += reclaim.FreeBytes
+		view.DelayedInodes += reclaim.DelayedInodes
+		view.DelayedBytes += reclaim.DelayedBytes
+		view.DelExtents += reclaim.DelExtents
+		view.DelExtentBytes += reclaim.DelExtentBytes
+		if reclaim.PendingBytes() > 0 || reclaim.FreeInodes > 0 || reclaim.DelExtents > 0 {
+			view.Partitions = append(view.Partitions, reclaim)
+		}
+	}
+	view.PendingBytes = view.MetaPartitionReclaim.PendingBytes()
+	sort.Slice(view.Partitions, func(i, j int) bool {
+		if pi, pj := view.Partitions[i].PendingBytes(), view.Partitions[j].PendingBytes(); pi != pj {
+			return pi > pj
+		}
+		return view.Partitions[i].PartitionID < view.Partitions[j].PartitionID
+	})
+	return
+}
+
+// getReclaim returns the last report of the leader of the partition, nil if none.
+func (mp *MetaPartition) getReclaim() *proto.MetaPartitionReclaim {
+	mp.RLock()
+	defer mp.RUnlock()
+	for _, mr := range mp.Replicas {
+		if mr.IsLeader && mr.reclaim != nil {
+			return mr.reclaim
+		}
+	}
+	return nil
+}
+
+// boostVolReclaim boosts the deletions of the volume for the duration, 0 removes the boost.
+func (c *Cluster) boostVolReclaim(vol *Vol, batchCount, durationSec uint64) (boost *proto.ReclaimBoost, err error) {
+	reclaimBoostLock.Lock()
+	defer reclaimBoostLock.Unlock()
+	now := time.Now()
+	if durationSec > 0 {
+		boosted := make([]string, 0)
+		for _, v := range c.allVols() {
+			if v.Name != vol.Name && v.getReclaimBoost().Active(now) {
+				boosted = append(boosted, v.Name)
+			}
+		}
+		if len(boosted) >= maxReclaimBoostVols {
+			sort.Strings(boosted)
+			return nil, fmt.Errorf("%v volumes are boosted already: %v", len(boosted), boosted)
+		}
+		boost = &proto.ReclaimBoost{BatchCount: batchCount, Until: now.Unix() + int64(durationSec)}
+	}
+	old := vol.setReclaimBoost(boost)
+	if err = c.syncUpdateVol(vol); err != nil {
+		vol.setReclaimBoost(old)
+		return nil, err
+	}
+	log.LogWarnf("action[boostVolReclaim] vol(%v) reclaim boost(%+v) -> (%+v)", vol.Name, old, boost)
+	return
+}
+
+// getVolReclaim returns the space of the deleted files of the volume not
+// returned yet, or of all volumes with such space if name is not given.
+func (m *Server) getVolReclaim(w http.ResponseWriter, r *http.Request) {
+	var (
+		name common.String
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolReclaim))
+	defer func() {
+		doStatAndMetric(proto.AdminVolReclaim, metric, err, nil)
+	}()
+	if err = parseArgs(r, name.Key(nameKey).OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	now := time.Now()
+	if name.V != "" {
+		var vol *Vol
+		if vol, err = m.cluster.getVol(name.V); err != nil {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+			return
+		}
+		sendOkReply(w, r, newSuccessHTTPReply(vol.getReclaimView(now)))
+		return
+	}
+	views := make([]*proto.VolReclaimView, 0)
+	for _, vol := range m.cluster.allVols() {
+		if view := vol.getReclaimView(now); view.PendingBytes > 0 || view.FreeInodes > 0 || view.Boost != nil {
+			view.Partitions = nil
+			views = append(views, view)
+		}
+	}
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].PendingBytes != views[j].PendingBytes {
+			return views[i].PendingBytes > views[j].PendingBytes
+		}
+		return views[i].Name < views[j].Name
+	})
+	sendOkReply(w, r, newSuccessHTTPReply(views))
+}
+
+func (m *Server) boostVolReclaim(w http.ResponseWriter, r *http.Request) {
+	var (
+		name        common.String
+		batchCount  = common.Uint{V: defaultReclaimBoostBatchCount}
+		durationSec = common.Uint{V: defaultReclaimBoostDurationSec}
+		boost       *proto.ReclaimBoost
+		err         error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolReclaimBoost))
+	defer func() {
+		doStatAndMetric(proto.AdminVolReclaimBoost, metric, err, nil)
+		AuditLog(r, proto.AdminVolReclaimBoost, fmt.Sprintf("boost volume(%s) reclaim with batchCount(%v) for durationSec(%v)",
+			name.V, batchCount.V, durationSec.V), err)
+	}()
+	if err = parseArgs(r, name.Key(nameKey), batchCount.Key("batchCount").OmitEmpty(),
+		durationSec.Key("durationSec").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if batchCount.V == 0 || batchCount.V > maxReclaimBoostBatchCount {
+		err = fmt.Errorf("batchCount[%v] is not in [1, %v]", batchCount.V, maxReclaimBoostBatchCount)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if durationSec.V > maxReclaimBoostDurationSec {
+		err = fmt.Errorf("durationSec[%v] is over %v", durationSec.V, maxReclaimBoostDurationSec)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	vol, err := m.cluster.getVol(name.V)
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+		return
+	}
+	if boost, err = m.cluster.boostVolReclaim(vol, batchCount.V, durationSec.V); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if boost == nil {
+		sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("remove vol[%v] reclaim boost successfully", name.V)))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("boost vol[%v] reclaim with batchCount[%v] until %v successfully",
+		name.V, boost.BatchCount, time.Unix(boost.Until, 0).Format(proto.TimeFormat))))
+}
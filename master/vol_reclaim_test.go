@@ -0,0 +1,120 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolReclaimView(t *testing.T) {
+	vol := newVol(volValue{ID: 1, Name: "reclaimVol", Owner: "reclaimVol", ReplicaNum: 3, DpReplicaNum: 3})
+	reclaims := []*proto.MetaPartitionReclaim{
+		{PartitionID: 1, FreeInodes: 2, FreeBytes: 100, DelayedInodes: 1, DelayedBytes: 10},
+		{PartitionID: 2, DelExtents: 3, DelExtentBytes: 1000},
+		{PartitionID: 3},
+	}
+	for _, reclaim := range reclaims {
+		mp := newMetaPartition(reclaim.PartitionID, 0, 0, 3, vol.Name, vol.ID, 0)
+		leader := newMetaReplica(0, 0, newMetaNode(fmt.Sprintf("leader%v", reclaim.PartitionID), "", "", testZone1, ""))
+		leader.IsLeader = true
+		leader.reclaim = reclaim
+		// a stale report of a former leader is ignored
+		follower := newMetaReplica(0, 0, newMetaNode(fmt.Sprintf("follower%v", reclaim.PartitionID), "", "", testZone1, ""))
+		follower.reclaim = &proto.MetaPartitionReclaim{PartitionID: reclaim.PartitionID, FreeInodes: 1 << 20, FreeBytes: 1 << 40}
+		mp.addReplica(follower)
+		mp.addReplica(leader)
+		vol.MetaPartitions[mp.PartitionID] = mp
+	}
+	mp := newMetaPartition(4, 0, 0, 3, vol.Name, vol.ID, 0)
+	mp.addReplica(newMetaReplica(0, 0, newMetaNode("noLeader", "", "", testZone1, "")))
+	vol.MetaPartitions[mp.PartitionID] = mp
+
+	now := time.Now()
+	view := vol.getReclaimView(now)
+	require.Equal(t, vol.Name, view.Name)
+	require.EqualValues(t, 2, view.FreeInodes)
+	require.EqualValues(t, 100, view.FreeBytes)
+	require.EqualValues(t, 1, view.DelayedInodes)
+	require.EqualValues(t, 10, view.DelayedBytes)
+	require.EqualValues(t, 3, view.DelExtents)
+	require.EqualValues(t, 1000, view.DelExtentBytes)
+	require.EqualValues(t, 1100, view.PendingBytes)
+	require.Nil(t, view.Boost)
+	require.Len(t, view.Partitions, 2)
+	require.EqualValues(t, 2, view.Partitions[0].PartitionID)
+	require.EqualValues(t, 1, view.Partitions[1].PartitionID)
+
+	vol.setReclaimBoost(&proto.ReclaimBoost{BatchCount: 2048, Until: now.Unix() - 1})
+	require.Nil(t, vol.getReclaimView(now).Boost)
+	vol.setReclaimBoost(&proto.ReclaimBoost{BatchCount: 2048, Until: now.Unix() + 60})
+	require.EqualValues(t, 2048, vol.getReclaimView(now).Boost.BatchCount)
+}
+
+func TestVolReclaimBoost(t *testing.T) {
+	api := mc.AdminAPI()
+	require.NoError(t, api.BoostVolumeReclaim(commonVolName, 0, 60))
+	vol, err := server.cluster.getVol(commonVolName)
+	require.NoError(t, err)
+	boost := vol.getReclaimBoost()
+	require.True(t, boost.Active(time.Now()))
+	require.EqualValues(t, defaultReclaimBoostBatchCount, boost.BatchCount)
+
+	view, err := api.GetVolumeReclaim(commonVolName)
+	require.NoError(t, err)
+	require.Equal(t, commonVolName, view.Name)
+	require.NotNil(t, view.Boost)
+	views, err := api.ListVolumeReclaim()
+	require.NoError(t, err)
+	found := false
+	for _, v := range views {
+		if v.Name == commonVolName {
+			found = true
+			require.Nil(t, v.Partitions)
+		}
+	}
+	require.True(t, found)
+
+	require.Error(t, api.BoostVolumeReclaim(commonVolName, maxReclaimBoostBatchCount+1, 60))
+	require.Error(t, api.BoostVolumeReclaim(commonVolName, 2048, maxReclaimBoostDurationSec+1))
+	require.Error(t, api.BoostVolumeReclaim("noSuchVol", 2048, 60))
+	_, err = api.GetVolumeReclaim("noSuchVol")
+	require.Error(t, err)
+	require.Equal(t, boost, vol.getReclaimBoost())
+
+	// the boosts are capped in the number of volumes boosted at once
+	others := make([]*Vol, 0)
+	for _, v := range server.cluster.allVols() {
+		if v.Name != commonVolName && len(others) < maxReclaimBoostVols {
+			others = append(others, v)
+		}
+	}
+	if len(others) == maxReclaimBoostVols {
+		for _, v := range others {
+			v.setReclaimBoost(&proto.ReclaimBoost{BatchCount: 2048, Until: time.Now().Unix() + 60})
+		}
+		require.Error(t, api.BoostVolumeReclaim(commonVolName, 2048, 60))
+		for _, v := range others {
+			v.setReclaimBoost(nil)
+		}
+	}
+
+	require.NoError(t, api.BoostVolumeReclaim(commonVolName, 2048, 0))
+	require.Nil(t, vol.getReclaimBoost())
+}
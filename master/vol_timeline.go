@@ -0,0 +1,208 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	intervalToCleanVolTimeline = time.Hour
+	volTimelineKeepTime        = 30 * 24 * time.Hour
+	volTimelineKeepPerVol      = 1024
+)
+
+// volTimelineStore keeps the events of the volumes in memory, oldest first. They
+// are persisted by raft and loaded again by the new leader, the events of deleted
+// volumes are kept until they age out.
+type volTimelineStore struct {
+	sync.RWMutex
+	events map[string][]*proto.VolTimelineEvent
+	lastID int64
+}
+
+func newVolTimelineStore() *volTimelineStore {
+	return &volTimelineStore{events: make(map[string][]*proto.VolTimelineEvent)}
+}
+
+// newEvent stamps the event with an id greater than those of the events before.
+func (s *volTimelineStore) newEvent(volName, kind, detail string) (e *proto.VolTimelineEvent) {
+	now := time.Now()
+	s.Lock()
+	defer s.Unlock()
+	id := now.UnixNano()
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return &proto.VolTimelineEvent{ID: id, Time: now.Unix(), Volume: volName, Kind: kind, Detail: detail}
+}
+
+func (s *volTimelineStore) put(e *proto.VolTimelineEvent) {
+	s.Lock()
+	defer s.Unlock()
+	events := s.events[e.Volume]
+	i := sort.Search(len(events), func(i int) bool { return events[i].ID >= e.ID })
+	if i < len(events) && events[i].ID == e.ID {
+		events[i] = e
+		return
+	}
+	events = append(events, nil)
+	copy(events[i+1:], events[i:])
+	events[i] = e
+	s.events[e.Volume] = events
+}
+
+func (s *volTimelineStore) delete(e *proto.VolTimelineEvent) {
+	s.Lock()
+	defer s.Unlock()
+	events := s.events[e.Volume]
+	for i := range events {
+		if events[i].ID == e.ID {
+			events = append(events[:i], events[i+1:]...)
+			break
+		}
+	}
+	if len(events) == 0 {
+		delete(s.events, e.Volume)
+		return
+	}
+	s.events[e.Volume] = events
+}
+
+// list returns the events of the volume of kind, or of all kinds if empty, from
+// start to end in unix seconds, oldest first. Zero start or end is unbounded.
+func (s *volTimelineStore) list(volName, kind string, start, end int64) (events []*proto.VolTimelineEvent) {
+	s.RLock()
+	defer s.RUnlock()
+	events = make([]*proto.VolTimelineEvent, 0)
+	for _, e := range s.events[volName] {
+		if (kind == "" || e.Kind == kind) && (start == 0 || e.Time >= start) && (end == 0 || e.Time <= end) {
+			events = append(events, e)
+		}
+	}
+	return
+}
+
+// expired returns the events older than keepTime, and the oldest beyond keep of
+// each volume.
+func (s *volTimelineStore) expired(now time.Time) (events []*proto.VolTimelineEvent) {
+	before := now.Add(-volTimelineKeepTime).Unix()
+	s.RLock()
+	defer s.RUnlock()
+	for _, volEvents := range s.events {
+		for i, e := range volEvents {
+			if e.Time < before || len(volEvents)-i > volTimelineKeepPerVol {
+				events = append(events, e)
+			}
+		}
+	}
+	return
+}
+
+func (s *volTimelineStore) count() (n int) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, events := range s.events {
+		n += len(events)
+	}
+	return
+}
+
+func (s *volTimelineStore) reset(events []*proto.VolTimelineEvent) {
+	s.Lock()
+	defer s.Unlock()
+	s.events = make(map[string][]*proto.VolTimelineEvent)
+	for _, e := range events {
+		s.events[e.Volume] = append(s.events[e.Volume], e)
+		if e.ID > s.lastID {
+			s.lastID = e.ID
+		}
+	}
+	for _, volEvents := range s.events {
+		sort.Slice(volEvents, func(i, j int) bool { return volEvents[i].ID < volEvents[j].ID })
+	}
+}
+
+// recordVolEvent adds an event to the timeline of the volume. The timeline is for
+// humans only, an event failed to persist is logged and kept by this leader.
+func (c *Cluster) recordVolEvent(volName, kind, format string, args ...interface{}) {
+	e := c.volTimeline.newEvent(volName, kind, fmt.Sprintf(format, args...))
+	if err := c.syncPutVolTimelineEvent(e); err != nil {
+		log.LogWarnf("action[recordVolEvent] persist event[%v] kind[%v] detail[%v] err:%v", e.Key(), e.Kind, e.Detail, err)
+	}
+	c.volTimeline.put(e)
+	log.LogDebugf("action[recordVolEvent] vol[%v] kind[%v] detail[%v]", volName, kind, e.Detail)
+}
+
+func (c *Cluster) cleanExpiredVolTimeline() {
+	for _, e := range c.volTimeline.expired(time.Now()) {
+		if err := c.syncDeleteVolTimelineEvent(e); err != nil {
+			log.LogWarnf("action[cleanExpiredVolTimeline] delete event[%v] err:%v", e.Key(), err)
+			continue
+		}
+		c.volTimeline.delete(e)
+	}
+}
+
+func (c *Cluster) scheduleToCleanVolTimeline() {
+	c.runTask(&cTask{
+		tickTime: intervalToCleanVolTimeline,
+		name:     "scheduleToCleanVolTimeline",
+		function: func() (fin bool) {
+			if c.partition != nil && c.partition.IsRaftLeader() {
+				c.cleanExpiredVolTimeline()
+			}
+			return
+		},
+	})
+}
+
+// getVolTimeline returns what happened to the volume, the volume may be deleted
+// already as long as its events are kept.
+func (m *Server) getVolTimeline(w http.ResponseWriter, r *http.Request) {
+	var (
+		name       common.String
+		kind       common.String
+		start, end common.Int
+		err        error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminVolTimeline))
+	defer func() {
+		doStatAndMetric(proto.AdminVolTimeline, metric, err, nil)
+	}()
+	if err = parseArgs(r, name.Key(nameKey), kind.Key("kind").OmitEmpty(),
+		start.Key("start").OmitEmpty(), end.Key("end").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	events := m.cluster.volTimeline.list(name.V, kind.V, start.V, end.V)
+	if len(events) == 0 {
+		if _, err = m.cluster.getVol(name.V); err != nil {
+			sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeVolNotExists, Msg: err.Error()})
+			return
+		}
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(events))
+}
@@ -0,0 +1,366 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/exporter"
+)
+
+// The zones added to a cluster start empty, and the volumes spread over the
+// zones fill them unevenly. Once the policy is enabled, the data balancer also
+// moves the replicas of the most used zone of a media type to the zones under
+// the mean of the media type, the cheapest first by the costs between the
+// zones, while the usage of the zone is over the least used one by more than
+// TargetSpread. The moves start only in the window of the day, and the bytes
+// they move in a window are at most MaxTBPerWindow, counted by the leader. They
+// are planned before the moves within the zones, and share their concurrency,
+// bandwidth and pause.
+
+const (
+	defaultZoneBalanceTBPerWindow = 1
+	defaultZoneBalanceSpread      = 0.1
+)
+
+// parseZoneBalanceWindow parses "15:04-15:04" into the offsets of its ends
+// from midnight, the empty window is all day.
+func parseZoneBalanceWindow(window string) (start, end time.Duration, err error) {
+	if window == "" {
+		return
+	}
+	ends := strings.Split(window, "-")
+	if len(ends) != 2 {
+		err = fmt.Errorf("window[%v] is not like 01:00-05:00", window)
+		return
+	}
+	offsets := make([]time.Duration, 2)
+	for i, s := range ends {
+		t, e := time.Parse("15:04", strings.TrimSpace(s))
+		if e != nil {
+			err = fmt.Errorf("window[%v] is not like 01:00-05:00", window)
+			return
+		}
+		offsets[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return offsets[0], offsets[1], nil
+}
+
+// zoneBalanceWindowOpened returns when the window now is in opened, a window
+// ending before it starts spans midnight, and the window of all day opens at
+// midnight.
+func zoneBalanceWindowOpened(window string, now time.Time) (opened time.Time, ok bool) {
+	start, end, err := parseZoneBalanceWindow(window)
+	if err != nil {
+		return
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := now.Sub(midnight)
+	switch {
+	case start == end:
+		return midnight, true
+	case start < end:
+		return midnight.Add(start), offset >= start && offset < end
+	case offset >= start:
+		return midnight.Add(start), true
+	default:
+		return midnight.AddDate(0, 0, -1).Add(start), offset < end
+	}
+}
+
+// zoneBudget returns the bytes the moves between the zones may still move in
+// the window now is in, or in the next one if it is closed.
+func (b *dataBalancer) zoneBudget(cfg proto.ZoneBalanceConfig, now time.Time) (budget uint64, open bool) {
+	limit := uint64(cfg.MaxTBPerWindow * util.TB)
+	opened, open := zoneBalanceWindowOpened(cfg.Window, now)
+	if !open {
+		return limit, false
+	}
+	if !opened.Equal(b.windowOpened) {
+		b.windowOpened, b.windowMoved = opened, 0
+	}
+	if b.windowMoved >= limit {
+		return 0, true
+	}
+	return limit - b.windowMoved, true
+}
+
+func dataBalanceMediaGroups(groups []*dataBalanceGroup) (mediaTypes []uint32, byMedia map[uint32][]*dataBalanceGroup) {
+	byMedia = make(map[uint32][]*dataBalanceGroup)
+	for _, g := range groups {
+		if _, ok := byMedia[g.mediaType]; !ok {
+			mediaTypes = append(mediaTypes, g.mediaType)
+		}
+		byMedia[g.mediaType] = append(byMedia[g.mediaType], g)
+	}
+	sort.Slice(mediaTypes, func(i, j int) bool { return mediaTypes[i] < mediaTypes[j] })
+	return
+}
+
+func dataBalanceUsage(groups []*dataBalanceGroup) float64 {
+	var total, used uint64
+	for _, g := range groups {
+		t, u := g.totals()
+		total += t
+		used += u
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total)
+}
+
+// dataBalanceZoneSpreads returns the spread of the zones of each media type in
+// more than one zone.
+func dataBalanceZoneSpreads(groups []*dataBalanceGroup) (spreads []*proto.DataBalanceZoneSpread) {
+	mediaTypes, byMedia := dataBalanceMediaGroups(groups)
+	for _, mediaType := range mediaTypes {
+		zones := byMedia[mediaType]
+		if len(zones) < 2 {
+			continue
+		}
+		most, least := zones[0], zones[0]
+		for _, g := range zones[1:] {
+			if g.mean() > most.mean() {
+				most = g
+			}
+			if g.mean() < least.mean() {
+				least = g
+			}
+		}
+		spreads = append(spreads, &proto.DataBalanceZoneSpread{
+			MediaType: proto.MediaTypeString(mediaType),
+			Spread:    most.mean() - least.mean(),
+			MostUsed:  most.zone,
+			LeastUsed: least.zone,
+		})
+	}
+	return
+}
+
+// planZoneBalanceMoves moves a replica of the most used zone of a media type,
+// while it is over the least used zone by more than the target spread, to the
+// zones under the mean of the media type within the cost ceiling, the cheapest
+// and the least used first. The replica is taken from the most used node of the
+// zone, the largest first, and moved to the least used node of the zone it fits,
+// while the zone stays at least as used as the one it moves to, until the moves
+// reach limit or the bytes of the budget. allow vetoes a node of a zone a
+// replica may not be moved to, e.g. by the zones of its volume.
+func planZoneBalanceMoves(groups []*dataBalanceGroup, replicas map[string][]*dataBalanceReplica,
+	cfg proto.ZoneBalanceConfig, budget uint64, limit int, cost func(from, to string) float64,
+	allow func(r *dataBalanceReplica, fromZone, toZone, to string) bool,
+) (moves []*proto.DataBalanceMove) {
+	moved := make(map[uint64]bool)
+	mediaTypes, byMedia := dataBalanceMediaGroups(groups)
+	for _, mediaType := range mediaTypes {
+		zones := byMedia[mediaType]
+		done := make(map[string]bool)
+		for len(moves) < limit && budget > 0 {
+			mean := dataBalanceUsage(zones)
+			var from, least *dataBalanceGroup
+			for _, g := range zones {
+				if !done[g.zone] && (from == nil || g.mean() > from.mean()) {
+					from = g
+				}
+				if least == nil || g.mean() < least.mean() {
+					least = g
+				}
+			}
+			if from == nil || from.mean()-least.mean() <= cfg.TargetSpread {
+				break
+			}
+			dsts := make([]*dataBalanceGroup, 0, len(zones))
+			for _, g := range zones {
+				if g.mean() < mean && (cfg.MaxZoneCost <= 0 || cost(from.zone, g.zone) <= cfg.MaxZoneCost) {
+					dsts = append(dsts, g)
+				}
+			}
+			sort.SliceStable(dsts, func(i, j int) bool {
+				ci, cj := cost(from.zone, dsts[i].zone), cost(from.zone, dsts[j].zone)
+				if ci != cj {
+					return ci < cj
+				}
+				return dsts[i].mean() < dsts[j].mean()
+			})
+			move := pickZoneBalanceMove(from, dsts, replicas, moved, budget, allow)
+			if move == nil {
+				done[from.zone] = true
+				continue
+			}
+			budget -= move.Size
+			moves = append(moves, move)
+		}
+	}
+	return
+}
+
+func pickZoneBalanceMove(from *dataBalanceGroup, dsts []*dataBalanceGroup, replicas map[string][]*dataBalanceReplica,
+	moved map[uint64]bool, budget uint64, allow func(r *dataBalanceReplica, fromZone, toZone, to string) bool,
+) *proto.DataBalanceMove {
+	srcs := append([]*dataBalanceNode(nil), from.nodes...)
+	sort.SliceStable(srcs, func(i, j int) bool { return srcs[i].usage() > srcs[j].usage() })
+	fromTotal, fromUsed := from.totals()
+	for _, dst := range dsts {
+		toTotal, toUsed := dst.totals()
+		nodes := make([]*dataBalanceNode, 0, len(dst.nodes))
+		for _, n := range dst.nodes {
+			if n.writable {
+				nodes = append(nodes, n)
+			}
+		}
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].usage() < nodes[j].usage() })
+		for _, src := range srcs {
+			candidates := replicas[src.addr]
+			sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].size > candidates[j].size })
+			for _, to := range nodes {
+				for _, r := range candidates {
+					if moved[r.partitionID] || r.size == 0 || r.size > budget || to.total-to.used <= r.size {
+						continue
+					}
+					// no overshoot of the zones
+					if float64(fromUsed-r.size)/float64(fromTotal) < float64(toUsed+r.size)/float64(toTotal) {
+						continue
+					}
+					if !dataBalanceFits(r, src, to) || (allow != nil && !allow(r, from.zone, dst.zone, to.addr)) {
+						continue
+					}
+					moved[r.partitionID] = true
+					src.used -= r.size
+					to.used += r.size
+					return &proto.DataBalanceMove{
+						PartitionID: r.partitionID,
+						VolName:     r.volName,
+						From:        src.addr,
+						To:          to.addr,
+						Size:        r.size,
+						FromZone:    from.zone,
+						ToZone:      dst.zone,
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// zoneBalanceCost returns the cost between two zones, a pair not set costs
+// nothing.
+func (c *Cluster) zoneBalanceCost() func(from, to string) float64 {
+	costs := c.getZoneCosts()
+	return func(from, to string) float64 {
+		if from > to {
+			from, to = to, from
+		}
+		for _, zc := range costs {
+			if zc.From == from && zc.To == to {
+				return zc.Cost
+			}
+		}
+		return 0
+	}
+}
+
+// allowZoneBalanceMove keeps the moves within the zones of the volume, and the
+// replicas of a partition in as many zones as before.
+func (c *Cluster) allowZoneBalanceMove(r *dataBalanceReplica, fromZone, toZone, to string) bool {
+	vol, err := c.getVol(r.volName)
+	if err != nil {
+		return false
+	}
+	inVolZones := false
+	for _, zone := range strings.Split(vol.zoneName, ",") {
+		if zone == toZone {
+			inVolZones = true
+			break
+		}
+	}
+	if !inVolZones {
+		return false
+	}
+	before, after := make(map[string]bool), make(map[string]bool)
+	for _, host := range r.hosts {
+		dataNode, err := c.dataNode(host)
+		if err != nil {
+			return false
+		}
+		before[dataNode.ZoneName] = true
+		if host != r.addr {
+			after[dataNode.ZoneName] = true
+		}
+	}
+	after[toZone] = true
+	if len(after) < len(before) {
+		return false
+	}
+	return c.allowDataBalanceMove(r, to)
+}
+
+// setZoneBalance turns the moves of the data balancer between the zones on or
+// off and sets their policy, the fields not given are kept.
+func (m *Server) setZoneBalance(w http.ResponseWriter, r *http.Request) {
+	var (
+		enable  common.Bool
+		window  common.String
+		maxTB   common.Float
+		spread  common.Float
+		maxCost common.Float
+		cfg     proto.DataBalanceConfig
+		err     error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminDataBalanceZone))
+	defer func() {
+		doStatAndMetric(proto.AdminDataBalanceZone, metric, err, nil)
+		AuditLog(r, proto.AdminDataBalanceZone, fmt.Sprintf("set zone balance to %+v", cfg.Zone), err)
+	}()
+	cfg = m.cluster.dataBalancer.getConfig()
+	window.V, maxTB.V, spread.V, maxCost.V = cfg.Zone.Window, cfg.Zone.MaxTBPerWindow, cfg.Zone.TargetSpread, cfg.Zone.MaxZoneCost
+	if err = parseArgs(r, enable.Enable(), window.Key("window").OmitEmpty(), maxTB.Key("maxTBPerWindow").OmitEmpty(),
+		spread.Key("targetSpread").OmitEmpty(), maxCost.Key("maxZoneCost").OmitEmpty()); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if _, _, err = parseZoneBalanceWindow(window.V); err == nil {
+		if maxTB.V <= 0 {
+			err = fmt.Errorf("maxTBPerWindow[%v] is not positive", maxTB.V)
+		} else if spread.V <= 0 || spread.V >= 1 {
+			err = fmt.Errorf("targetSpread[%v] is out of (0, 1)", spread.V)
+		} else if maxCost.V < 0 {
+			err = fmt.Errorf("maxZoneCost[%v] is negative", maxCost.V)
+		}
+	}
+	if err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	cfg.Zone = proto.ZoneBalanceConfig{
+		Enabled:        enable.V,
+		Window:         strings.TrimSpace(window.V),
+		MaxTBPerWindow: maxTB.V,
+		TargetSpread:   spread.V,
+		MaxZoneCost:    maxCost.V,
+	}
+	if err = m.cluster.setDataBalanceConfig(cfg); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set zone balance to %+v successfully", cfg.Zone)))
+}
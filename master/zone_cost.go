@@ -0,0 +1,143 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// getZoneCosts returns the costs sorted by zones, which must not be modified.
+func (c *Cluster) getZoneCosts() []proto.ZoneCost {
+	costs, _ := c.zoneCosts.Load().([]proto.ZoneCost)
+	return costs
+}
+
+// setZoneCost sets the cost between two zones, removed with cost 0.
+func (c *Cluster) setZoneCost(from, to string, cost float64) (err error) {
+	if from > to {
+		from, to = to, from
+	}
+	c.zoneCostLock.Lock()
+	defer c.zoneCostLock.Unlock()
+	oldCosts := c.getZoneCosts()
+	costs := make([]proto.ZoneCost, 0, len(oldCosts)+1)
+	for _, zc := range oldCosts {
+		if zc.From != from || zc.To != to {
+			costs = append(costs, zc)
+		}
+	}
+	if cost > 0 {
+		costs = append(costs, proto.ZoneCost{From: from, To: to, Cost: cost})
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].From != costs[j].From {
+			return costs[i].From < costs[j].From
+		}
+		return costs[i].To < costs[j].To
+	})
+	c.zoneCosts.Store(costs)
+	if err = c.syncPutCluster(); err != nil {
+		c.zoneCosts.Store(oldCosts)
+		return
+	}
+	return
+}
+
+// getZoneCostView returns the costs from zone, and the zones of the nodes a
+// client reads from.
+func (c *Cluster) getZoneCostView(zone string) (view *proto.ZoneCostView) {
+	view = &proto.ZoneCostView{
+		Zone:      zone,
+		Costs:     make(map[string]float64),
+		HostZones: make(map[string]string),
+	}
+	for _, zc := range c.getZoneCosts() {
+		if zc.From == zone {
+			view.Costs[zc.To] = zc.Cost
+		} else if zc.To == zone {
+			view.Costs[zc.From] = zc.Cost
+		}
+	}
+	c.dataNodes.Range(func(addr, node interface{}) bool {
+		dataNode := node.(*DataNode)
+		view.HostZones[dataNode.Addr] = dataNode.ZoneName
+		return true
+	})
+	c.flashNodeTopo.flashNodeMap.Range(func(addr, node interface{}) bool {
+		flashNode := node.(*FlashNode)
+		view.HostZones[flashNode.Addr] = flashNode.ZoneName
+		return true
+	})
+	return
+}
+
+func (m *Server) setZoneCost(w http.ResponseWriter, r *http.Request) {
+	var (
+		from, to common.String
+		cost     common.Float
+		err      error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminSetZoneCost))
+	defer func() {
+		doStatAndMetric(proto.AdminSetZoneCost, metric, err, nil)
+	}()
+	if err = parseArgs(r, from.Key("from"), to.Key("to"), cost.Key("cost")); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	if from.V == "" || to.V == "" || from.V == to.V || cost.V < 0 {
+		err = fmt.Errorf("from(%v) and to(%v) should be two zones, and cost(%v) not negative", from.V, to.V, cost.V)
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if err = m.cluster.setZoneCost(from.V, to.V, cost.V); err != nil {
+		log.LogErrorf("action[setZoneCost] syncPutCluster failed %v", err)
+		sendErrReply(w, r, newErrHTTPReply(proto.ErrPersistenceByRaft))
+		return
+	}
+	AuditLog(r, proto.AdminSetZoneCost, fmt.Sprintf("set cost between zone[%v] and zone[%v] to [%v]", from.V, to.V, cost.V), nil)
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.getZoneCosts()))
+}
+
+func (m *Server) getZoneCost(w http.ResponseWriter, r *http.Request) {
+	costs := m.cluster.getZoneCosts()
+	if costs == nil {
+		costs = make([]proto.ZoneCost, 0)
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(costs))
+}
+
+func (m *Server) clientZoneCost(w http.ResponseWriter, r *http.Request) {
+	var (
+		zone common.String
+		err  error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.ClientZoneCost))
+	defer func() {
+		doStatAndMetric(proto.ClientZoneCost, metric, err, nil)
+	}()
+	if err = parseArgs(r, zone.ZoneName()); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(m.cluster.getZoneCostView(zone.V)))
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const zoneFenceCheckInterval = time.Minute
+
+func (zone *Zone) getIsolation() int32 {
+	return atomic.LoadInt32(&zone.isolation)
+}
+
+// setIsolation isolates the zone and its nodes, the nodes added later inherit
+// the isolation in putDataNode and putMetaNode.
+func (zone *Zone) setIsolation(isolation int32) {
+	atomic.StoreInt32(&zone.isolation, isolation)
+	zone.dataNodes.Range(func(_, value interface{}) bool {
+		value.(*DataNode).zoneIsolation.Store(isolation)
+		return true
+	})
+	zone.metaNodes.Range(func(_, value interface{}) bool {
+		value.(*MetaNode).zoneIsolation.Store(isolation)
+		return true
+	})
+}
+
+// isZoneIsolated tells whether the zone of the node is read-only or fenced,
+// the node gets no new partitions and its replicas are read-only.
+func (dataNode *DataNode) isZoneIsolated() bool {
+	return dataNode.zoneIsolation.Load() != proto.ZoneIsolationNone
+}
+
+func (metaNode *MetaNode) isZoneIsolated() bool {
+	return metaNode.zoneIsolation.Load() != proto.ZoneIsolationNone
+}
+
+func (c *Cluster) setZoneIsolation(name string, isolation int32) (err error) {
+	zone, err := c.t.getZone(name)
+	if err != nil {
+		return
+	}
+	old := zone.getIsolation()
+	zone.setIsolation(isolation)
+	if err = c.sycnPutZoneInfo(zone); err != nil {
+		log.LogErrorf("action[setZoneIsolation] zone[%v] isolation[%v] err[%v]",
+			name, proto.ZoneIsolationString(isolation), err)
+		zone.setIsolation(old)
+		return proto.ErrPersistenceByRaft
+	}
+	log.LogWarnf("action[setZoneIsolation] zone[%v] isolation from [%v] to [%v]",
+		name, proto.ZoneIsolationString(old), proto.ZoneIsolationString(isolation))
+	if isolation == proto.ZoneIsolationFenced {
+		go c.moveLeadersOutOfFencedZones()
+	}
+	return
+}
+
+func (c *Cluster) scheduleToMoveLeadersOutOfFencedZones() {
+	c.runTask(&cTask{
+		tickTime: zoneFenceCheckInterval,
+		name:     "scheduleToMoveLeadersOutOfFencedZones",
+		function: func() (fin bool) {
+			if c.partition != nil && c.partition.IsRaftLeader() {
+				c.moveLeadersOutOfFencedZones()
+			}
+			return
+		},
+	})
+}
+
+func (c *Cluster) hasFencedZone() bool {
+	for _, zone := range c.t.getAllZones() {
+		if zone.getIsolation() == proto.ZoneIsolationFenced {
+			return true
+		}
+	}
+	return false
+}
+
+// moveLeadersOutOfFencedZones asks a live replica out of the fenced zones to
+// take over the leader of each partition led in a fenced zone. The leaders may
+// be elected back in a fenced zone while it is partitioned, they are moved
+// again at the next check.
+func (c *Cluster) moveLeadersOutOfFencedZones() {
+	if !c.hasFencedZone() {
+		return
+	}
+	var moved, failed int
+	dpTimeout, mpTimeout := c.getDataPartitionTimeoutSec(), c.getMetaPartitionTimeoutSec()
+	for _, vol := range c.copyVols() {
+		for _, dp := range vol.dataPartitions.clonePartitions() {
+			host := dp.getLeaderOutOfFencedZones(dpTimeout)
+			if host == "" {
+				continue
+			}
+			if err := dp.tryToChangeLeaderByHost(host); err != nil {
+				log.LogWarnf("action[moveLeadersOutOfFencedZones] dp[%v] to host[%v] err[%v]", dp.PartitionID, host, err)
+				failed++
+				continue
+			}
+			moved++
+		}
+		for _, mp := range vol.cloneMetaPartitionMap() {
+			host := mp.getLeaderOutOfFencedZones(mpTimeout)
+			if host == "" {
+				continue
+			}
+			if err := mp.tryToChangeLeaderByHost(host); err != nil {
+				log.LogWarnf("action[moveLeadersOutOfFencedZones] mp[%v] to host[%v] err[%v]", mp.PartitionID, host, err)
+				failed++
+				continue
+			}
+			moved++
+		}
+	}
+	if moved > 0 || failed > 0 {
+		log.LogWarnf("action[moveLeadersOutOfFencedZones] moved[%v] failed[%v] leaders out of the fenced zones", moved, failed)
+	}
+}
+
+// getLeaderOutOfFencedZones returns the replica to take over the leader if the
+// leader is in a fenced zone, empty if it is not or there is no such replica.
+func (partition *DataPartition) getLeaderOutOfFencedZones(timeOutSec int64) (host string) {
+	partition.RLock()
+	defer partition.RUnlock()
+	var leader *DataReplica
+	for _, replica := range partition.Replicas {
+		if replica.IsLeader {
+			leader = replica
+		}
+	}
+	if leader == nil || leader.dataNode.zoneIsolation.Load() != proto.ZoneIsolationFenced {
+		return
+	}
+	for _, replica := range partition.Replicas {
+		if replica.dataNode.zoneIsolation.Load() != proto.ZoneIsolationFenced &&
+			replica.isLive(partition.PartitionID, timeOutSec) {
+			return replica.Addr
+		}
+	}
+	return
+}
+
+func (mp *MetaPartition) getLeaderOutOfFencedZones(timeOutSec int64) (host string) {
+	mp.RLock()
+	defer mp.RUnlock()
+	leader, err := mp.getMetaReplicaLeader()
+	if err != nil || leader.metaNode.zoneIsolation.Load() != proto.ZoneIsolationFenced {
+		return
+	}
+	for _, mr := range mp.Replicas {
+		if mr.metaNode.zoneIsolation.Load() != proto.ZoneIsolationFenced && !mp.isWitness(mr.Addr) &&
+			mr.isActive(timeOutSec) {
+			return mr.Addr
+		}
+	}
+	return
+}
+
+// setZoneIsolation makes a zone read-only or fenced during a partial outage or
+// a network partition, or lifts the isolation once the zone recovered.
+func (m *Server) setZoneIsolation(w http.ResponseWriter, r *http.Request) {
+	var (
+		name         common.String
+		isolationStr common.String
+		isolation    int32
+		err          error
+	)
+	metric := exporter.NewTPCnt(apiToMetricsName(proto.AdminSetZoneIsolation))
+	defer func() {
+		doStatAndMetric(proto.AdminSetZoneIsolation, metric, err, nil)
+		AuditLog(r, proto.AdminSetZoneIsolation, fmt.Sprintf("set zone(%v) isolation to [%v]", name.V, isolationStr.V), err)
+	}()
+	if err = parseArgs(r, name.Key(nameKey), isolationStr.Key(isolationKey)); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if isolation, err = proto.ParseZoneIsolation(isolationStr.V); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeParamError, Msg: err.Error()})
+		return
+	}
+	if _, err = m.cluster.t.getZone(name.V); err != nil {
+		sendErrReply(w, r, &proto.HTTPReply{Code: proto.ErrCodeZoneNotExists, Msg: err.Error()})
+		return
+	}
+	if err = m.cluster.setZoneIsolation(name.V, isolation); err != nil {
+		sendErrReply(w, r, newErrHTTPReply(err))
+		return
+	}
+	sendOkReply(w, r, newSuccessHTTPReply(fmt.Sprintf("set zone(%v) isolation to [%v] successfully", name.V, isolationStr.V)))
+}
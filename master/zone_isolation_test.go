@@ -0,0 +1,82 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPartitionLeaderOutOfFencedZones(t *testing.T) {
+	dp := &DataPartition{PartitionID: 1}
+	for _, addr := range []string{"a", "b", "c"} {
+		dataNode := newDataNode(addr, "", "", testZone1, "", defaultMediaType)
+		dataNode.isActive = true
+		dp.Replicas = append(dp.Replicas, newDataReplica(dataNode))
+	}
+	dp.Replicas[0].IsLeader = true
+	require.Equal(t, "", dp.getLeaderOutOfFencedZones(60))
+
+	dp.Replicas[0].dataNode.zoneIsolation.Store(proto.ZoneIsolationReadOnly)
+	require.Equal(t, "", dp.getLeaderOutOfFencedZones(60), "leaders may stay in a read-only zone")
+
+	dp.Replicas[0].dataNode.zoneIsolation.Store(proto.ZoneIsolationFenced)
+	dp.Replicas[1].dataNode.zoneIsolation.Store(proto.ZoneIsolationFenced)
+	require.Equal(t, "c", dp.getLeaderOutOfFencedZones(60))
+
+	dp.Replicas[2].dataNode.isActive = false
+	require.Equal(t, "", dp.getLeaderOutOfFencedZones(60))
+}
+
+func TestSetZoneIsolation(t *testing.T) {
+	require.Error(t, mc.AdminAPI().SetZoneIsolation(testZone2, "offline"))
+	require.Error(t, mc.AdminAPI().SetZoneIsolation("noSuchZone", "readOnly"))
+
+	require.NoError(t, mc.AdminAPI().SetZoneIsolation(testZone2, "readOnly"))
+	defer func() {
+		require.NoError(t, mc.AdminAPI().SetZoneIsolation(testZone2, "none"))
+		dataNode, err := server.cluster.dataNode(mds3Addr)
+		require.NoError(t, err)
+		require.False(t, dataNode.isZoneIsolated())
+	}()
+
+	zones, err := mc.AdminAPI().ListZones()
+	require.NoError(t, err)
+	for _, zone := range zones {
+		if zone.Name == testZone2 {
+			require.Equal(t, "readOnly", zone.Isolation)
+		} else {
+			require.Equal(t, "none", zone.Isolation, zone.Name)
+		}
+	}
+
+	dataNode, err := server.cluster.dataNode(mds3Addr)
+	require.NoError(t, err)
+	require.False(t, dataNode.IsWriteAble())
+	metaNode, err := server.cluster.metaNode(mms3Addr)
+	require.NoError(t, err)
+	require.False(t, metaNode.IsWriteAble())
+	zone, err := server.cluster.t.getZone(testZone2)
+	require.NoError(t, err)
+	require.False(t, zone.canWriteForNode(DataNodeType, 1))
+
+	// reloaded from the store
+	zone.setIsolation(proto.ZoneIsolationNone)
+	require.NoError(t, server.cluster.loadZoneValue())
+	require.Equal(t, proto.ZoneIsolationReadOnly, zone.getIsolation())
+	require.True(t, metaNode.isZoneIsolated())
+}
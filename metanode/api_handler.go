@@ -29,9 +29,11 @@ import (
 
 	"github.com/cubefs/cubefs/cmd/common"
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/bundle"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/log"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 const (
@@ -88,6 +90,9 @@ func (m *MetaNode) registerAPIHandler() (err error) {
 	http.HandleFunc("/getInodeAccessTime", m.getInodeAccessTimeHandler)
 	// for hybrid cloud debug
 	http.HandleFunc("/getInodeWithExtentKey", m.getInodeWithExtentKeyHandler)
+	http.HandleFunc("/getFragmentedInodes", m.getFragmentedInodesHandler)
+	// export inodes and dentries for the external indexes
+	http.HandleFunc("/exportMeta", m.exportMetaHandler)
 	// http.HandleFunc("/setInodeCreateTime", m.setInodeCreateTimeHandler)
 	// http.HandleFunc("/deleteMigrateExtentKey", m.deleteMigrateExtentKeyHandler)
 	// http.HandleFunc("/updateExtentKeyAfterMigration", m.updateExtentKeyAfterMigrationHandler)
@@ -98,6 +103,18 @@ func (m *MetaNode) registerAPIHandler() (err error) {
 	http.HandleFunc("/setQosEnable", m.setQosEnableHandler)
 	http.HandleFunc("/setMetaQos", m.setMetaQosHandler)
 	http.HandleFunc("/getMetaQos", m.getMetaQosHandler)
+
+	bundle.RegisterState("partitions", func() interface{} { return m.metadataManager })
+	bundle.RegisterState("leaderPartitions", func() interface{} { return m.metadataManager.GetLeaderPartitions() })
+	bundle.RegisterState("params", func() interface{} {
+		return map[string]interface{}{metaNodeDeleteBatchCountKey: DeleteBatchCount()}
+	})
+	bundle.RegisterState("smux", func() interface{} {
+		if smuxPool == nil {
+			return nil
+		}
+		return smuxPool.GetStat()
+	})
 	return
 }
 
@@ -494,6 +511,104 @@ func (m *MetaNode) getExtentsByInodeHandler(w http.ResponseWriter,
 	}
 }
 
+func (m *MetaNode) getFragmentedInodesHandler(w http.ResponseWriter, r *http.Request) {
+	resp := NewAPIResponse(http.StatusBadRequest, "")
+	defer func() {
+		data, _ := resp.Marshal()
+		if _, err := w.Write(data); err != nil {
+			log.LogErrorf("[getFragmentedInodesHandler] response %s", err)
+		}
+	}()
+	var pid, minExtents, limit common.Uint
+	if err := parseArgs(r, pid.PID(),
+		minExtents.Key("minExtents").OmitEmpty(),
+		limit.Key("limit").OmitEmpty()); err != nil {
+		resp.Msg = err.Error()
+		return
+	}
+	if minExtents.V == 0 {
+		minExtents.V = defaultFragmentedExtentCount
+	}
+	if limit.V == 0 {
+		limit.V = defaultFragmentedReportLimit
+	}
+	mp, err := m.metadataManager.GetPartition(pid.V)
+	if err != nil {
+		resp.Code = http.StatusNotFound
+		resp.Msg = err.Error()
+		return
+	}
+	resp.Code = http.StatusOK
+	resp.Data = mp.GetFragmentedInodes(int(minExtents.V), int(limit.V))
+}
+
+func (m *MetaNode) exportMetaHandler(w http.ResponseWriter, r *http.Request) {
+	resp := NewAPIResponse(http.StatusBadRequest, "")
+	var (
+		pid, since, limit common.Uint
+		marker, format    common.String
+	)
+	err := parseArgs(r, pid.PID(),
+		since.Key("since").OmitEmpty(),
+		marker.Key("marker").OmitEmpty(),
+		limit.Key("limit").OmitEmpty(),
+		format.Key("format").OmitEmpty())
+	if err == nil && format.V == "" {
+		format.V = exportFormatJSONL
+	}
+	if err == nil && format.V != exportFormatJSONL && format.V != exportFormatProtobuf {
+		err = fmt.Errorf("unknown export format %s", format.V)
+	}
+	if limit.V == 0 {
+		limit.V = defaultExportLimit
+	} else if limit.V > maxExportLimit {
+		limit.V = maxExportLimit
+	}
+	var page *exportPage
+	if err == nil {
+		var mp MetaPartition
+		if mp, err = m.metadataManager.GetPartition(pid.V); err != nil {
+			resp.Code = http.StatusNotFound
+		} else {
+			page, err = mp.ExportRecords(since.V, marker.V, int(limit.V))
+		}
+	}
+	if err != nil {
+		resp.Msg = err.Error()
+		data, _ := resp.Marshal()
+		if _, err = w.Write(data); err != nil {
+			log.LogErrorf("[exportMetaHandler] response %s", err)
+		}
+		return
+	}
+
+	w.Header().Set("X-Export-Apply-Id", strconv.FormatUint(page.ApplyID, 10))
+	w.Header().Set("X-Export-Full", strconv.FormatBool(page.Full))
+	w.Header().Set("X-Export-Marker", page.Marker)
+	var buf []byte
+	if format.V == exportFormatProtobuf {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		var msg []byte
+		for _, record := range page.Records {
+			msg = record.appendProto(msg[:0])
+			buf = protowire.AppendBytes(buf, msg)
+		}
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for _, record := range page.Records {
+			data, e := json.Marshal(record)
+			if e != nil {
+				log.LogErrorf("[exportMetaHandler] failed to marshal to json: %v", e)
+				return
+			}
+			buf = append(append(buf, data...), '\n')
+		}
+	}
+	if _, err = w.Write(buf); err != nil {
+		log.LogErrorf("[exportMetaHandler] response %s", err)
+	}
+}
+
 func (m *MetaNode) getDentryHandler(w http.ResponseWriter, r *http.Request) {
 	resp := NewAPIResponse(http.StatusBadRequest, "")
 	defer func() {
@@ -31,6 +31,9 @@ type (
 type BTree struct {
 	sync.RWMutex
 	tree *btree.BTree
+	// onChange, if set, is called with the items handed out for update or
+	// removed by the tree. It is not inherited by the clones of GetTree.
+	onChange func(item BtreeItem)
 }
 
 // NewBtree creates a new btree.
@@ -52,6 +55,7 @@ func (b *BTree) CopyGet(key BtreeItem) (item BtreeItem) {
 	b.Lock()
 	item = b.tree.CopyGet(key)
 	b.Unlock()
+	b.changed(item)
 	return
 }
 
@@ -71,6 +75,7 @@ func (b *BTree) CopyFind(key BtreeItem, fn func(i BtreeItem)) {
 	item := b.tree.CopyGet(key)
 	fn(item)
 	b.Unlock()
+	b.changed(item)
 }
 
 // Has checks if the key exists in the btree.
@@ -86,6 +91,7 @@ func (b *BTree) Delete(key BtreeItem) (item BtreeItem) {
 	b.Lock()
 	item = b.tree.Delete(key)
 	b.Unlock()
+	b.changed(item)
 	return
 }
 
@@ -101,6 +107,7 @@ func (b *BTree) ReplaceOrInsert(key BtreeItem, replace bool) (item BtreeItem, ok
 	if replace {
 		item = b.tree.ReplaceOrInsert(key)
 		b.Unlock()
+		b.changed(key)
 		ok = true
 		return
 	}
@@ -109,6 +116,7 @@ func (b *BTree) ReplaceOrInsert(key BtreeItem, replace bool) (item BtreeItem, ok
 	if item == nil {
 		item = b.tree.ReplaceOrInsert(key)
 		b.Unlock()
+		b.changed(key)
 		ok = true
 		return
 	}
@@ -150,6 +158,12 @@ func (b *BTree) GetTree() *BTree {
 	return nb
 }
 
+func (b *BTree) changed(item BtreeItem) {
+	if b.onChange != nil && item != nil {
+		b.onChange(item)
+	}
+}
+
 // Reset resets the current btree.
 func (b *BTree) Reset() {
 	b.Lock()
@@ -209,6 +209,10 @@ const (
 
 	// freeze meta partition
 	opFSMSetFreeze = 92
+
+	opFSMBatchMoveDentry = 93
+	opFSMSwapExtents     = 94
+	opFSMSetInlineData   = 95
 )
 
 // new inode opCode
@@ -251,6 +255,9 @@ const (
 	CfgGcRecyclePercent          = "gcRecyclePercent"
 	cfsQosEnable                 = "qosEnable"   // bool
 	cfgReadDirIops               = "readDirIops" // int
+	// shed load when the usage reaches this ratio of the cgroup limit, 0 disables it
+	cfgCgroupMemThrottleRatio = "cgroupMemThrottleRatio" // float
+	cfgCgroupCpuThrottleRatio = "cgroupCpuThrottleRatio" // float
 
 	metaNodeDeleteBatchCountKey = "batchCount"
 	configNameResolveInterval   = "nameResolveInterval" // int
@@ -270,6 +277,8 @@ const (
 	defaultSyncInodeAtimeCnt           = 102400
 	RaftCommitDiffMax                  = 100
 	DefaultGOGCValue                   = 100
+	defaultFragmentedExtentCount       = 1024
+	defaultFragmentedReportLimit       = 100
 )
 
 const (
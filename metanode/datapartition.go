@@ -30,6 +30,8 @@ type DataPartition struct {
 	PartitionType string
 	Hosts         []string
 	IsDiscard     bool
+	// of a fork source of the volume, the extents in it are never deleted by the volume
+	Fork bool
 }
 
 // GetAllAddrs returns all addresses of the data partition.
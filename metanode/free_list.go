@@ -65,6 +65,17 @@ func (fl *freeList) Remove(ino uint64) {
 	}
 }
 
+// Inodes returns the inodes of the list, the first to pop first.
+func (fl *freeList) Inodes() []uint64 {
+	fl.Lock()
+	defer fl.Unlock()
+	inos := make([]uint64, 0, len(fl.index))
+	for item := fl.list.Front(); item != nil; item = item.Next() {
+		inos = append(inos, item.Value.(uint64))
+	}
+	return inos
+}
+
 func (fl *freeList) Len() int {
 	fl.Lock()
 	defer fl.Unlock()
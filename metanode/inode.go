@@ -38,6 +38,7 @@ const (
 	DeleteMarkFlag               = 1 << 0
 	InodeDelTop                  = 1 << 1
 	DeleteMigrationExtentKeyFlag = 1 << 2 // only delete migration ek by delay
+	DirStorageClassFlag          = 1 << 3 // the inodes created in the dir take its StorageClass
 )
 
 const (
@@ -47,6 +48,7 @@ const (
 	V4EnableHybridCloud   uint64 = 0x08
 	// V4EBSExtentsFlag       uint64 = 0x20
 	V4MigrationExtentsFlag uint64 = 0x40
+	V4InlineDataFlag       uint64 = 0x80
 )
 
 // Inode wraps necessary properties of `Inode` information in the file system.
@@ -98,6 +100,7 @@ type Inode struct {
 
 	// pointer
 	LinkTarget []byte // SymLink target name
+	InlineData []byte // data of a tiny file without extents
 	// Snapshot
 	multiSnap                   *InodeMultiSnap
 	HybridCloudExtents          *SortedHybridCloudExtents
@@ -478,6 +481,7 @@ func (i *Inode) String() string {
 	buff.WriteString(fmt.Sprintf("AT[%d]", i.AccessTime))
 	buff.WriteString(fmt.Sprintf("MT[%d]", i.ModifyTime))
 	buff.WriteString(fmt.Sprintf("LinkT[%s]", i.LinkTarget))
+	buff.WriteString(fmt.Sprintf("InlineData[%d]", len(i.InlineData)))
 	buff.WriteString(fmt.Sprintf("NLink[%d]", i.NLink))
 	buff.WriteString(fmt.Sprintf("Flag[%d]", i.Flag))
 	buff.WriteString(fmt.Sprintf("Reserved[%d]", i.Reserved))
@@ -554,6 +558,10 @@ func (i *Inode) Copy() BtreeItem {
 		newIno.LinkTarget = make([]byte, size)
 		copy(newIno.LinkTarget, i.LinkTarget)
 	}
+	if size := len(i.InlineData); size > 0 {
+		newIno.InlineData = make([]byte, size)
+		copy(newIno.InlineData, i.InlineData)
+	}
 	newIno.NLink = i.NLink
 	newIno.Flag = i.Flag
 	newIno.Reserved = i.Reserved
@@ -609,6 +617,10 @@ func (i *Inode) CopyDirectly() BtreeItem {
 		newIno.LinkTarget = make([]byte, size)
 		copy(newIno.LinkTarget, i.LinkTarget)
 	}
+	if size := len(i.InlineData); size > 0 {
+		newIno.InlineData = make([]byte, size)
+		copy(newIno.InlineData, i.InlineData)
+	}
 	newIno.NLink = i.NLink
 	newIno.Flag = i.Flag
 	newIno.Reserved = i.Reserved
@@ -897,6 +909,10 @@ func (i *Inode) MarshalInodeValue(buff *buf.ByteBufExt) {
 		}
 	}
 
+	if len(i.InlineData) > 0 {
+		reserved |= V4InlineDataFlag
+	}
+
 	if log.EnableDebug() {
 		log.LogDebugf("MarshalInodeValue ino(%v) storageClass(%v) Reserved(%v) ClientID(%v) LeaseExpireTime(%v)",
 			i.Inode, i.StorageClass, reserved, i.ClientID, i.LeaseExpireTime)
@@ -971,6 +987,15 @@ func (i *Inode) MarshalInodeValue(buff *buf.ByteBufExt) {
 		panic(err)
 	}
 
+	if reserved&V4InlineDataFlag > 0 {
+		if err = buff.PutUint32(uint32(len(i.InlineData))); err != nil {
+			panic(err)
+		}
+		if _, err = buff.Write(i.InlineData); err != nil {
+			panic(err)
+		}
+	}
+
 	if reserved&V4MigrationExtentsFlag > 0 {
 		sem := i.HybridCloudExtentsMigration
 
@@ -1315,6 +1340,22 @@ func (i *Inode) UnmarshalInodeValueV2(buff *buf.ReadByteBuff) (err error) {
 			i.StorageClass = proto.StorageClass_BlobStore
 		}
 
+		if i.Reserved&V4InlineDataFlag > 0 {
+			dataSize := uint32(0)
+			if dataSize, err = buff.ReadUint32(); err != nil {
+				err = UnmarshalInodeFiledError("InlineData.size(v4)", err)
+				return
+			}
+			if dataSize > proto.MaxBufferSize {
+				return proto.ErrBufferSizeExceedMaximum
+			}
+			i.InlineData = make([]byte, dataSize)
+			if _, err = io.ReadFull(buff, i.InlineData); err != nil {
+				err = UnmarshalInodeFiledError("InlineData(v4)", err)
+				return
+			}
+		}
+
 		if i.Reserved&V4MigrationExtentsFlag > 0 {
 			if i.HybridCloudExtentsMigration == nil {
 				i.HybridCloudExtentsMigration = NewSortedHybridCloudExtentsMigration()
@@ -1478,6 +1519,8 @@ func (i *Inode) AppendExtents(eks []proto.ExtentKey, ct int64, volType int) (del
 		}
 		delExtents = append(delExtents, delItems...)
 	}
+	// the client has moved the inline data to the extents before
+	i.InlineData = nil
 	i.Generation++
 	i.ModifyTime = ct
 
@@ -2240,6 +2283,7 @@ func (i *Inode) AppendExtentWithCheck(param *AppendExtParam) (delExtents []proto
 		if i.Size < size {
 			i.Size = size
 		}
+		i.InlineData = nil
 		i.Generation++
 		i.ModifyTime = param.ct
 	}
@@ -2250,6 +2294,9 @@ func (i *Inode) ExtentsTruncate(length uint64, ct int64, insertRefMap func(ek *p
 	if i.HybridCloudExtents.sortedEks != nil {
 		extents := i.HybridCloudExtents.sortedEks.(*SortedExtents)
 		delExtents = extents.Truncate(length, insertRefMap)
+		if uint64(len(i.InlineData)) > length {
+			i.InlineData = i.InlineData[:length]
+		}
 		i.Size = length
 		i.ModifyTime = ct
 		i.Generation++
@@ -2476,10 +2523,69 @@ func (i *Inode) SetAttr(req *SetattrRequest) {
 	if req.Valid&proto.AttrModifyTime != 0 {
 		i.ModifyTime = req.ModifyTime
 	}
+	if req.Valid&proto.AttrStorageClass != 0 {
+		i.setStorageClass(req.StorageClass)
+	}
 
 	i.Unlock()
 }
 
+// checkSetStorageClass tells whether the StorageClass of the inode can be set:
+// a directory takes any, or unspecified to unset it, a file only while it is
+// empty, the data of a file is moved to another StorageClass by migration.
+func (i *Inode) checkSetStorageClass(storageClass uint32) error {
+	switch {
+	case proto.IsDir(i.Type):
+		if storageClass != proto.StorageClass_Unspecified && !proto.IsValidStorageClass(storageClass) {
+			return fmt.Errorf("invalid storageClass(%v)", storageClass)
+		}
+	case i.IsFile():
+		if !proto.IsValidStorageClass(storageClass) {
+			return fmt.Errorf("invalid storageClass(%v)", storageClass)
+		}
+		if i.Size > 0 || !i.EmptyHybridExtents() || i.HybridCloudExtentsMigration.sortedEks != nil || !i.isEmptyVerList() {
+			return fmt.Errorf("ino(%v) has data, its storageClass is changed by migration", i.Inode)
+		}
+	default:
+		return fmt.Errorf("ino(%v) is neither a file nor a directory", i.Inode)
+	}
+	return nil
+}
+
+func (i *Inode) setStorageClass(storageClass uint32) {
+	if err := i.checkSetStorageClass(storageClass); err != nil {
+		log.LogWarnf("action[setStorageClass] skipped, %v", err)
+		return
+	}
+	if proto.IsDir(i.Type) {
+		if storageClass == proto.StorageClass_Unspecified {
+			i.Flag &^= DirStorageClassFlag
+			return
+		}
+		i.StorageClass = storageClass
+		i.Flag |= DirStorageClassFlag
+		return
+	}
+	i.StorageClass = storageClass
+	if i.HybridCloudExtents == nil {
+		i.HybridCloudExtents = NewSortedHybridCloudExtents()
+	}
+	if proto.IsStorageClassReplica(storageClass) {
+		i.HybridCloudExtents.sortedEks = NewSortedExtents()
+	} else {
+		i.HybridCloudExtents.sortedEks = NewSortedObjExtents()
+	}
+}
+
+// getDirStorageClass returns the StorageClass of the inodes created in the dir,
+// unspecified if it is not set.
+func (i *Inode) getDirStorageClass() uint32 {
+	if proto.IsDir(i.Type) && i.Flag&DirStorageClassFlag != 0 {
+		return i.StorageClass
+	}
+	return proto.StorageClass_Unspecified
+}
+
 func (i *Inode) DoWriteFunc(fn func()) {
 	i.Lock()
 	defer i.Unlock()
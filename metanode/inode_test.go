@@ -457,3 +457,43 @@ func BenchmarkInodeUnmarshal(b *testing.B) {
 
 	_ = newInode
 }
+
+func TestInodeSetStorageClass(t *testing.T) {
+	dir := NewInode(1024, uint32(os.ModeDir))
+	dir.StorageClass = proto.StorageClass_Replica_HDD
+	require.Equal(t, proto.StorageClass_Unspecified, dir.getDirStorageClass())
+	require.Error(t, dir.checkSetStorageClass(proto.StorageClass_BlobStore+1))
+
+	req := &SetattrRequest{Inode: dir.Inode, Valid: proto.AttrStorageClass, StorageClass: proto.StorageClass_Replica_SSD}
+	dir.SetAttr(req)
+	require.Equal(t, proto.StorageClass_Replica_SSD, dir.getDirStorageClass())
+	data, err := dir.Marshal()
+	require.NoError(t, err)
+	targetDir := NewInode(0, 0)
+	require.NoError(t, targetDir.Unmarshal(data))
+	require.Equal(t, proto.StorageClass_Replica_SSD, targetDir.getDirStorageClass())
+
+	req.StorageClass = proto.StorageClass_Unspecified
+	dir.SetAttr(req)
+	require.Equal(t, proto.StorageClass_Unspecified, dir.getDirStorageClass())
+
+	file := NewInode(1025, 0)
+	file.StorageClass = proto.StorageClass_Replica_HDD
+	file.HybridCloudExtents.sortedEks = NewSortedExtents()
+	require.Error(t, file.checkSetStorageClass(proto.StorageClass_Unspecified))
+	req = &SetattrRequest{Inode: file.Inode, Valid: proto.AttrStorageClass, StorageClass: proto.StorageClass_BlobStore}
+	file.SetAttr(req)
+	require.Equal(t, proto.StorageClass_BlobStore, file.StorageClass)
+	require.True(t, file.EmptyHybridExtents())
+	require.Equal(t, proto.StorageClass_Unspecified, file.getDirStorageClass())
+
+	req.StorageClass = proto.StorageClass_Replica_SSD
+	file.SetAttr(req)
+	require.Equal(t, proto.StorageClass_Replica_SSD, file.StorageClass)
+	file.HybridCloudExtents.sortedEks = NewSortedExtentsFromEks([]proto.ExtentKey{{FileOffset: 0, PartitionId: 1, ExtentId: 1, Size: 4096}})
+	file.Size = 4096
+	require.Error(t, file.checkSetStorageClass(proto.StorageClass_Replica_HDD))
+	req.StorageClass = proto.StorageClass_Replica_HDD
+	file.SetAttr(req)
+	require.Equal(t, proto.StorageClass_Replica_SSD, file.StorageClass)
+}
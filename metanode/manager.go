@@ -33,10 +33,13 @@ import (
 	"github.com/cubefs/cubefs/raftstore"
 	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/atomicutil"
+	"github.com/cubefs/cubefs/util/cgroup"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
+	"github.com/cubefs/cubefs/util/faultinject"
 	"github.com/cubefs/cubefs/util/loadutil"
 	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/slo"
 	"github.com/cubefs/cubefs/util/strutil"
 	"golang.org/x/time/rate"
 )
@@ -44,12 +47,17 @@ import (
 const (
 	partitionPrefix        = "partition_"
 	ExpiredPartitionPrefix = "expired_"
+	// TombstonePartitionPrefix marks the partitions dropped by decommission
+	// or migration, which are kept for the retention set by master.
+	TombstonePartitionPrefix = "tombstone_"
 )
 
 const sampleDuration = 1 * time.Second
 
 const UpdateVolTicket = 2 * time.Minute
 
+const tombstoneCheckInterval = 5 * time.Minute
+
 const (
 	gcTimerDuration         = 10 * time.Second
 	defaultGcRecyclePercent = 0.90
@@ -80,6 +88,7 @@ type MetadataManagerConfig struct {
 	EnableGcTimer    bool
 	GcRecyclePercent float64
 	RaftStore        raftstore.RaftStore
+	ThrottleConf     cgroup.ThrottleConfig
 }
 
 type verOp2Phase struct {
@@ -113,7 +122,18 @@ type metadataManager struct {
 	gogcValue            int
 	gcRecyclePercent     float64
 	gcTimer              *util.RecycleTimer
+	sloRecorder          *slo.Recorder
 	limitFactor          map[uint32]*rate.Limiter
+	throttleConf         cgroup.ThrottleConfig
+	throttler            *cgroup.Throttler
+	clientFence          atomic.Value    // *proto.ClientFence, the clients evicted by master
+	volFences            atomic.Value    // *proto.FenceTokens, the fencing tokens of the volumes
+	tlsRequiredVols      atomic.Value    // map[string]struct{}, the volumes whose clients are served over TLS only
+	immutableVols        atomic.Value    // map[string]int64, the volumes immutable until the unix second
+	reclaimBoostVols     atomic.Value    // map[string]*proto.ReclaimBoost, the volumes whose deletions are boosted
+	forkSourceVols       atomic.Value    // map[string]struct{}, the volumes forked by others, whose extents are kept
+	tombstoneRetention   int64           // nanoseconds to keep the dropped partitions, set by master
+	maintenance          atomicutil.Bool // set by master, the deletion of the freed extents is deferred
 }
 
 func (m *metadataManager) GetAllVolumes() (volumes *util.Set) {
@@ -152,10 +172,27 @@ func (m *metadataManager) getVolumeUpdateInfo(volName string) (dataView *proto.D
 		err = errors.NewErrorf("vol %v is already deleted", volName)
 		return
 	}
-	dataView, err = m.getDataPartitions(volName)
+	if dataView, err = m.getDataPartitions(volName); err != nil {
+		return
+	}
+	m.getForkPartitions(volView, dataView)
 	return
 }
 
+// getForkPartitions adds the partitions of the fork sources of the volume to
+// its view, the extents of the forked files in them are never deleted.
+func (m *metadataManager) getForkPartitions(volView *proto.SimpleVolView, dataView *proto.DataPartitionsView) {
+	if len(volView.ForkSources) == 0 {
+		return
+	}
+	view, err := masterClient.ClientAPI().GetForkPartitions(volView.Name)
+	if err != nil {
+		log.LogWarnf("action[getForkPartitions]: failed to get fork partitions of volume %v, err %v", volView.Name, err)
+		return
+	}
+	dataView.ForkPartitions = view.ForkPartitions
+}
+
 func (m *metadataManager) updateVolumes() {
 	volumes := m.GetAllVolumes()
 	dataViews := make(map[string]*proto.DataPartitionsView)
@@ -224,6 +261,171 @@ func (m *metadataManager) checkForbidWriteOpOfProtoVer0(pktProtoVersion uint32,
 	return nil
 }
 
+func (m *metadataManager) setClientFence(evictions []*proto.ClientEviction) {
+	fence := proto.NewClientFence(evictions, time.Now().Unix())
+	if old, _ := m.clientFence.Load().(*proto.ClientFence); old.Len() != fence.Len() {
+		log.LogWarnf("[setClientFence] evicted clients change from %v to %v", old.Len(), fence.Len())
+	}
+	m.clientFence.Store(fence)
+}
+
+// isClientEvicted reports whether the packet comes from a client evicted by master,
+// whose requests are rejected until master lifts the eviction.
+func (m *metadataManager) isClientEvicted(p *Packet, remoteAddr string) bool {
+	fence, _ := m.clientFence.Load().(*proto.ClientFence)
+	if !fence.HasIP(remoteAddr) || p.AdminOp() || p.Opcode == proto.OpMetaNodeHeartbeat {
+		return false
+	}
+	var vol string
+	if mp, err := m.getPartition(p.PartitionID); err == nil {
+		vol = mp.GetBaseConfig().VolName
+	}
+	return fence.Evicted(remoteAddr, vol)
+}
+
+func (m *metadataManager) setVolFences(fences []*proto.VolFence) {
+	tokens := proto.NewFenceTokens(fences)
+	if old, _ := m.volFences.Load().(*proto.FenceTokens); old.Len() != tokens.Len() {
+		log.LogWarnf("[setVolFences] fenced volumes change from %v to %v", old.Len(), tokens.Len())
+	}
+	m.volFences.Store(tokens)
+}
+
+// isFenceStale reports whether the packet writes with a token older than the
+// fence of its volume.
+func (m *metadataManager) isFenceStale(p *Packet) bool {
+	if p.ExtentType&proto.FenceTokenFlag == 0 || p.IsReadMetaPkt() {
+		return false
+	}
+	tokens, _ := m.volFences.Load().(*proto.FenceTokens)
+	if tokens.Len() == 0 {
+		return false
+	}
+	mp, err := m.getPartition(p.PartitionID)
+	if err != nil {
+		return false
+	}
+	return tokens.Stale(mp.GetBaseConfig().VolName, p.FenceToken)
+}
+
+func (m *metadataManager) setTLSRequiredVols(vols []string) {
+	required := make(map[string]struct{}, len(vols))
+	for _, vol := range vols {
+		required[vol] = struct{}{}
+	}
+	if old, _ := m.tlsRequiredVols.Load().(map[string]struct{}); len(old) != len(required) {
+		log.LogWarnf("[setTLSRequiredVols] vols requiring TLS change to %v", vols)
+	}
+	m.tlsRequiredVols.Store(required)
+}
+
+// isTLSRequired reports whether the volume of the packet serves its clients over TLS only.
+// The peers of the partition proxying to the leader, and the transaction ops sent between
+// the meta nodes, do not come from the clients.
+func (m *metadataManager) isTLSRequired(p *Packet, remoteAddr string) bool {
+	vols, _ := m.tlsRequiredVols.Load().(map[string]struct{})
+	if len(vols) == 0 || p.AdminOp() || p.Opcode == proto.OpMetaNodeHeartbeat {
+		return false
+	}
+	switch p.Opcode {
+	case proto.OpMetaTxCreate, proto.OpMetaTxGet, proto.OpTxCommitRM, proto.OpTxRollbackRM:
+		return false
+	}
+	mp, err := m.getPartition(p.PartitionID)
+	if err != nil {
+		return false
+	}
+	conf := mp.GetBaseConfig()
+	if _, ok := vols[conf.VolName]; !ok {
+		return false
+	}
+	host, _, _ := net.SplitHostPort(remoteAddr)
+	for _, peer := range conf.Peers {
+		if peerHost, _, _ := net.SplitHostPort(peer.Addr); peerHost == host {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *metadataManager) setImmutableVols(vols map[string]int64) {
+	if vols == nil {
+		vols = make(map[string]int64)
+	}
+	if old, _ := m.immutableVols.Load().(map[string]int64); len(old) != len(vols) {
+		log.LogWarnf("[setImmutableVols] immutable vols change to %v", vols)
+	}
+	m.immutableVols.Store(vols)
+}
+
+func (m *metadataManager) setReclaimBoostVols(vols map[string]*proto.ReclaimBoost) {
+	if old, _ := m.reclaimBoostVols.Load().(map[string]*proto.ReclaimBoost); len(old) != len(vols) {
+		log.LogWarnf("[setReclaimBoostVols] reclaim boosted vols change to %v", len(vols))
+	}
+	m.reclaimBoostVols.Store(vols)
+}
+
+func (m *metadataManager) setForkSourceVols(vols []string) {
+	sources := make(map[string]struct{}, len(vols))
+	for _, vol := range vols {
+		sources[vol] = struct{}{}
+	}
+	if old, _ := m.forkSourceVols.Load().(map[string]struct{}); len(old) != len(sources) {
+		log.LogWarnf("[setForkSourceVols] fork source vols change to %v", vols)
+	}
+	m.forkSourceVols.Store(sources)
+}
+
+// isVolImmutable reports whether the op writes the metadata of a volume within
+// the immutable window after its snapshot. The window ends on time even if the
+// heartbeats of the master are lost.
+func (m *metadataManager) isVolImmutable(p *Packet, now time.Time) bool {
+	vols, _ := m.immutableVols.Load().(map[string]int64)
+	if len(vols) == 0 || p.AdminOp() || !isImmutableOp(p.Opcode) {
+		return false
+	}
+	mp, err := m.getPartition(p.PartitionID)
+	if err != nil {
+		return false
+	}
+	until, ok := vols[mp.GetBaseConfig().VolName]
+	return ok && now.Unix() < until
+}
+
+// isLowPriorityOp reports whether the op is an expensive read that the client can
+// retry, these ops are rejected while the metanode throttles itself.
+func isLowPriorityOp(opcode uint8) bool {
+	switch opcode {
+	case proto.OpMetaReadDir, proto.OpMetaReadDirLimit, proto.OpMetaReadDirOnly,
+		proto.OpMetaBatchInodeGet, proto.OpMetaBatchGetXAttr, proto.OpListMultiparts:
+		return true
+	}
+	return false
+}
+
+// injectFault applies the faults injected into the ops of the partitions, the
+// admin ops are not affected. It returns true if the op is not handled.
+func (m *metadataManager) injectFault(conn net.Conn, p *Packet, remoteAddr string) bool {
+	if p.AdminOp() || !faultinject.Active() {
+		return false
+	}
+	var rootDir string
+	if mp, err := m.getPartition(p.PartitionID); err == nil {
+		rootDir = mp.GetBaseConfig().RootDir
+	}
+	switch faultinject.Inject(p.PartitionID, rootDir) {
+	case faultinject.Drop:
+		log.LogInfof("HandleMetadataOperation drop (%s), remote %s, fault injected", p.String(), remoteAddr)
+		return true
+	case faultinject.EIO:
+		p.PacketErrorWithBody(proto.OpErr, []byte(faultinject.ErrEIO.Error()))
+		m.respondToClient(conn, p)
+		log.LogInfof("HandleMetadataOperation reject (%s), remote %s, fault injected", p.String(), remoteAddr)
+		return true
+	}
+	return false
+}
+
 // HandleMetadataOperation handles the metadata operations.
 func (m *metadataManager) HandleMetadataOperation(conn net.Conn, p *Packet, remoteAddr string) (err error) {
 	start := time.Now()
@@ -233,10 +435,57 @@ func (m *metadataManager) HandleMetadataOperation(conn net.Conn, p *Packet, remo
 		log.LogInfof("HandleMetadataOperation input info op (%s), data %s, remote %s", p.String(), string(p.Data), remoteAddr)
 	}
 
+	if isLowPriorityOp(p.Opcode) && m.throttler.ShouldReject() {
+		p.PacketErrorWithBody(proto.OpAgain, []byte(fmt.Sprintf("metanode throttled: %v", m.throttler.State())))
+		m.respondToClient(conn, p)
+		log.LogInfof("HandleMetadataOperation reject (%s), remote %s, throttled", p.String(), remoteAddr)
+		return
+	}
+
+	if m.isClientEvicted(p, remoteAddr) {
+		p.PacketErrorWithBody(proto.OpForbidErr, []byte(proto.ErrClientEvicted.Error()))
+		m.respondToClient(conn, p)
+		log.LogWarnf("HandleMetadataOperation reject (%s), remote %s, client evicted", p.String(), remoteAddr)
+		return
+	}
+
+	if m.isFenceStale(p) {
+		p.PacketErrorWithBody(proto.OpForbidErr, []byte(proto.ErrStaleFenceToken.Error()))
+		m.respondToClient(conn, p)
+		log.LogWarnf("HandleMetadataOperation reject (%s), remote %s, stale fence token %v", p.String(), remoteAddr, p.FenceToken)
+		return
+	}
+
+	if !util.IsTLSConn(conn) && m.isTLSRequired(p, remoteAddr) {
+		p.PacketErrorWithBody(proto.OpForbidErr, []byte(proto.ErrTLSRequired.Error()))
+		m.respondToClient(conn, p)
+		log.LogWarnf("HandleMetadataOperation reject (%s), remote %s, volume requires TLS", p.String(), remoteAddr)
+		return
+	}
+
+	if m.isVolImmutable(p, start) {
+		p.PacketErrorWithBody(proto.OpNotPerm, []byte(proto.ErrVolImmutable.Error()))
+		m.respondToClient(conn, p)
+		log.LogWarnf("HandleMetadataOperation reject (%s), remote %s, volume immutable", p.String(), remoteAddr)
+		return
+	}
+
+	if m.injectFault(conn, p, remoteAddr) {
+		return
+	}
+
 	metric := exporter.NewTPCnt(p.GetOpMsg())
 	labels := m.getPacketLabels(p)
+	if !p.AdminOp() {
+		if mp, e := m.getPartition(p.PartitionID); e == nil {
+			mp.countOp()
+		}
+	}
 	defer func() {
 		metric.SetWithLabels(err, labels)
+		if !p.AdminOp() {
+			m.sloRecorder.Observe(labels[exporter.Vol], proto.SLOOpMeta, time.Since(start), err != nil || p.ResultCode == proto.OpErr)
+		}
 		if err != nil {
 			log.LogWarnf("HandleMetadataOperation output (%s), remote %s, err %s", p.String(), remoteAddr, err.Error())
 			return
@@ -276,6 +525,16 @@ func (m *metadataManager) HandleMetadataOperation(conn net.Conn, p *Packet, remo
 		err = m.opDeleteDentry(conn, p, remoteAddr)
 	case proto.OpMetaBatchDeleteDentry:
 		err = m.opBatchDeleteDentry(conn, p, remoteAddr)
+	case proto.OpMetaBatchMoveDentry:
+		err = m.opBatchMoveDentry(conn, p, remoteAddr)
+	case proto.OpMetaSwapExtents:
+		err = m.opMetaSwapExtents(conn, p, remoteAddr)
+	case proto.OpMetaSetInlineData:
+		err = m.opMetaSetInlineData(conn, p, remoteAddr)
+	case proto.OpMetaGetDirVersion:
+		err = m.opMetaGetDirVersion(conn, p, remoteAddr)
+	case proto.OpNegotiateFeatures:
+		err = m.opNegotiateFeatures(conn, p, remoteAddr)
 	case proto.OpMetaUpdateDentry:
 		err = m.opUpdateDentry(conn, p, remoteAddr)
 	case proto.OpMetaReadDir:
@@ -549,9 +808,15 @@ func (m *metadataManager) onStart() (err error) {
 	m.stopC = make(chan struct{})
 	// start sampler
 	m.startCpuSample()
+	// throttle itself when running close to the cgroup limits
+	m.throttler = cgroup.NewThrottler(m.throttleConf, nil)
+	m.throttler.Start(m.stopC)
 	m.startSnapshotVersionPromote()
 	m.startUpdateVolumes()
 	m.startGcTimer()
+	m.startTombstoneCleaner()
+	m.sloRecorder = slo.NewRecorder(fmt.Sprintf("metanode(%v)", m.metaNode.localAddr))
+	m.sloRecorder.Start(slo.DefaultReportInterval, masterClient.AdminAPI().ReportSLO)
 	return
 }
 
@@ -566,6 +831,10 @@ func (m *metadataManager) onStop() {
 	if m.gcTimer != nil {
 		m.gcTimer.Stop()
 	}
+
+	if m.sloRecorder != nil {
+		m.sloRecorder.Stop()
+	}
 }
 
 // LoadMetaPartition returns the meta partition with the specified volName.
@@ -730,6 +999,7 @@ func (m *metadataManager) forceUpdateVolumeView(partition MetaPartition) error {
 		log.LogWarnf("action[forceUpdateVolumeView]: failed to get partitions, vol %v, err %s", volName, err1.Error())
 		paritionView = proto.NewDataPartitionsView()
 	}
+	m.getForkPartitions(volView, paritionView)
 
 	partition.UpdateVolumeView(paritionView, volView)
 	return nil
@@ -923,12 +1193,92 @@ func NewMetadataManager(conf MetadataManagerConfig, metaNode *MetaNode) Metadata
 		enableGcTimer:        conf.EnableGcTimer,
 		gcRecyclePercent:     conf.GcRecyclePercent,
 		limitFactor:          make(map[uint32]*rate.Limiter),
+		throttleConf:         conf.ThrottleConf,
 	}
 	m.limitFactor[readDirIops] = rate.NewLimiter(rate.Limit(metaNode.readDirIops), metaNode.readDirIops/2)
 
 	return m
 }
 
+// tombstonePartition renames the directory of a dropped partition instead of
+// removing it, so that it can be recovered until its retention expires.
+func (m *metadataManager) tombstonePartition(rootDir string) (err error) {
+	newName := path.Join(path.Dir(rootDir),
+		TombstonePartitionPrefix+path.Base(rootDir)+"_"+time.Now().Format(StaleMetadataTimeFormat))
+	if err = os.Rename(rootDir, newName); err != nil {
+		return
+	}
+	log.LogWarnf("[tombstonePartition] rename %v to %v", rootDir, newName)
+	return
+}
+
+func (m *metadataManager) setMaintenance(maintenance bool) {
+	if m.maintenance.Load() != maintenance {
+		log.LogWarnf("[setMaintenance] change from %v to %v", m.maintenance.Load(), maintenance)
+		m.maintenance.Store(maintenance)
+	}
+}
+
+func (m *metadataManager) setTombstoneRetention(retention string) {
+	d, err := time.ParseDuration(retention)
+	if err != nil {
+		return
+	}
+	if old := atomic.SwapInt64(&m.tombstoneRetention, int64(d)); old != int64(d) {
+		log.LogWarnf("[setTombstoneRetention] change from %v to %v", time.Duration(old), d)
+	}
+}
+
+// deleteExpiredTombstones removes the tombstones older than the retention. The
+// tombstones left when master turns the retention off are kept as long as the
+// data partition backups.
+func (m *metadataManager) deleteExpiredTombstones(now time.Time) {
+	retention := time.Duration(atomic.LoadInt64(&m.tombstoneRetention))
+	if retention <= 0 {
+		retention = proto.DefaultDataPartitionBackupTimeOut
+	}
+	fileInfoList, err := os.ReadDir(m.rootDir)
+	if err != nil {
+		log.LogErrorf("[deleteExpiredTombstones] read dir(%v) err(%v)", m.rootDir, err)
+		return
+	}
+	for _, fileInfo := range fileInfoList {
+		name := fileInfo.Name()
+		if !fileInfo.IsDir() || !strings.HasPrefix(name, TombstonePartitionPrefix) {
+			continue
+		}
+		idx := strings.LastIndex(name, "_")
+		createTime, err := time.ParseInLocation(StaleMetadataTimeFormat, name[idx+1:], time.Local)
+		if err != nil {
+			log.LogWarnf("[deleteExpiredTombstones] unknown tombstone(%v) err(%v)", name, err)
+			continue
+		}
+		if now.Sub(createTime) <= retention {
+			continue
+		}
+		if err = os.RemoveAll(path.Join(m.rootDir, name)); err != nil {
+			log.LogErrorf("[deleteExpiredTombstones] remove %v err(%v)", name, err)
+			continue
+		}
+		log.LogWarnf("[deleteExpiredTombstones] removed %v created at %v", name, createTime)
+	}
+}
+
+func (m *metadataManager) startTombstoneCleaner() {
+	go func() {
+		ticker := time.NewTicker(tombstoneCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stopC:
+				return
+			case now := <-ticker.C:
+				m.deleteExpiredTombstones(now)
+			}
+		}
+	}()
+}
+
 // isExpiredPartition return whether one partition is expired
 // if one partition does not exist in master, we decided that it is one expired partition
 func isExpiredPartition(fileName string, partitions []uint64) (expiredPartition bool) {
@@ -121,6 +121,7 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 		thresholdsChange             bool
 	)
 	start := time.Now()
+	resp.Clock.RecvTime = start.UnixNano()
 	go func() {
 		decode := json.NewDecoder(bytes.NewBuffer(data))
 		decode.UseNumber()
@@ -176,6 +177,14 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 		m.metaNode.VolsForbidWriteOpOfProtoVer0 = volsForbidWriteOpOfProtoVer0
 		log.LogDebugf("[opMasterHeartbeat] from master, volumes forbid write operate of proto version-0: %v",
 			req.VolsForbidWriteOpOfProtoVer0)
+		m.setClientFence(req.EvictedClients)
+		m.setVolFences(req.VolFences)
+		m.setTLSRequiredVols(req.TLSRequiredVols)
+		m.setImmutableVols(req.ImmutableVols)
+		m.setReclaimBoostVols(req.ReclaimBoostVols)
+		m.setForkSourceVols(req.ForkSourceVols)
+		m.setTombstoneRetention(req.ReplicaTombstoneRetention)
+		m.setMaintenance(req.Maintenance)
 
 		// collect memory info
 		resp.Total = configTotalMem
@@ -191,6 +200,7 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 		}
 		// set cpu util and io used in here
 		resp.CpuUtil = m.cpuUtil.Load()
+		resp.ResourceThrottle = m.throttler.State()
 
 		m.Range(true, func(id uint64, partition MetaPartition) bool {
 			m.checkFollowerRead(req.FLReadVols, partition)
@@ -215,6 +225,7 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 				InodeCnt:                  uint64(partition.GetInodeTreeLen()),
 				DentryCnt:                 uint64(partition.GetDentryTreeLen()),
 				FreeListLen:               uint64(partition.GetFreeListLen()),
+				Ops:                       partition.OpCount(),
 				UidInfo:                   partition.GetUidInfo(),
 				QuotaReportInfos:          partition.getQuotaReportInfos(),
 				StatByStorageClass:        partition.GetStatByStorageClass(),
@@ -229,7 +240,7 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 				mpr.Status = proto.ReadOnly
 				mpr.ReadOnlyReasons |= proto.MpCursorOutOfRange
 			}
-			if resp.Used > uint64(float64(resp.Total)*MaxUsedMemFactor) {
+			if resp.Used > uint64(float64(resp.Total)*MaxUsedMemFactor) || m.throttler.MemoryThrottled() {
 				mpr.Status = proto.ReadOnly
 				mpr.ReadOnlyReasons |= proto.MetaMemUseLimit
 			}
@@ -239,15 +250,21 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 				mpr.Status = proto.Unavailable
 			}
 			mpr.IsLeader = isLeader
+			if isLeader {
+				mpr.Reclaim = partition.GetReclaim()
+			}
 
 			resp.MetaPartitionReports = append(resp.MetaPartitionReports, mpr)
 			return true
 		})
+		shareMemUsed(resp.Used, resp.MetaPartitionReports)
 		resp.ZoneName = m.zoneName
 		resp.ReceivedForbidWriteOpOfProtoVer0 = m.metaNode.nodeForbidWriteOpOfProtoVer0
+		resp.Features = proto.MetaNodeFeatures
 		resp.Status = proto.TaskSucceeds
 	end:
 		adminTask.Request = nil
+		resp.Clock.ReplyTime = time.Now().UnixNano()
 		adminTask.Response = resp
 		m.respondToMaster(adminTask)
 		if log.EnableInfo() {
@@ -259,6 +276,21 @@ func (m *metadataManager) opMasterHeartbeat(conn net.Conn, p *Packet,
 	return
 }
 
+// shareMemUsed estimates the memory used by each partition as its share of the memory
+// used by the node by the count of its inodes and dentries.
+func shareMemUsed(used uint64, reports []*proto.MetaPartitionReport) {
+	var items uint64
+	for _, mpr := range reports {
+		items += mpr.InodeCnt + mpr.DentryCnt
+	}
+	if items == 0 {
+		return
+	}
+	for _, mpr := range reports {
+		mpr.MemUsed = uint64(float64(used) * float64(mpr.InodeCnt+mpr.DentryCnt) / float64(items))
+	}
+}
+
 func (m *metadataManager) opCreateMetaPartition(conn net.Conn, p *Packet,
 	remoteAddr string,
 ) (err error) {
@@ -846,6 +878,140 @@ func (m *metadataManager) opBatchDeleteDentry(conn net.Conn, p *Packet,
 	return
 }
 
+func (m *metadataManager) opBatchMoveDentry(conn net.Conn, p *Packet, remoteAddr string) (err error) {
+	req := &proto.BatchMoveDentryRequest{}
+	if err = json.Unmarshal(p.Data, req); err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClientWithVer(conn, p)
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		return
+	}
+	mp, err := m.getPartition(req.PartitionID)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClientWithVer(conn, p)
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		return
+	}
+	if !m.serveProxy(conn, mp, p) {
+		return
+	}
+
+	if err = m.checkMultiVersionStatus(mp, p); err != nil {
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		m.respondToClientWithVer(conn, p)
+		return
+	}
+
+	err = mp.BatchMoveDentry(req, p, remoteAddr)
+	m.updatePackRspSeq(mp, p)
+	m.respondToClientWithVer(conn, p)
+	log.LogDebugf("%s [opBatchMoveDentry] req: %d - spino(%v) dpino(%v) count(%v), resp: %v",
+		remoteAddr, p.GetReqID(), req.SrcParentID, req.DstParentID, len(req.Items), p.GetResultMsg())
+	return
+}
+
+// opNegotiateFeatures replies with the features of this metanode, the
+// features of the peer in the request are only logged.
+func (m *metadataManager) opNegotiateFeatures(conn net.Conn, p *Packet, remoteAddr string) (err error) {
+	req := &proto.FeatureNegotiation{}
+	if len(p.Data) > 0 {
+		if err = json.Unmarshal(p.Data, req); err != nil {
+			p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+			m.respondToClient(conn, p)
+			return
+		}
+	}
+	reply, err := json.Marshal(&proto.FeatureNegotiation{Features: proto.MetaNodeFeatures, Version: proto.Version})
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClient(conn, p)
+		return
+	}
+	p.PacketOkWithBody(reply)
+	m.respondToClient(conn, p)
+	log.LogDebugf("%s [opNegotiateFeatures] req: %d - peer features(%v) version(%v)",
+		remoteAddr, p.GetReqID(), req.Features, req.Version)
+	return
+}
+
+func (m *metadataManager) opMetaSwapExtents(conn net.Conn, p *Packet, remoteAddr string) (err error) {
+	req := &proto.SwapExtentsRequest{}
+	if err = json.Unmarshal(p.Data, req); err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClientWithVer(conn, p)
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		return
+	}
+	mp, err := m.getPartition(req.PartitionID)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClientWithVer(conn, p)
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		return
+	}
+	if !m.serveProxy(conn, mp, p) {
+		return
+	}
+
+	err = mp.SwapExtents(req, p)
+	m.respondToClientWithVer(conn, p)
+	log.LogDebugf("%s [opMetaSwapExtents] req: %d - %v, resp: %v",
+		remoteAddr, p.GetReqID(), req, p.GetResultMsg())
+	return
+}
+
+func (m *metadataManager) opMetaSetInlineData(conn net.Conn, p *Packet, remoteAddr string) (err error) {
+	req := &proto.SetInlineDataRequest{}
+	if err = json.Unmarshal(p.Data, req); err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClientWithVer(conn, p)
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		return
+	}
+	mp, err := m.getPartition(req.PartitionID)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClientWithVer(conn, p)
+		err = errors.NewErrorf("[%v],req[%v],err[%v]", p.GetOpMsgWithReqAndResult(), req, string(p.Data))
+		return
+	}
+	if !m.serveProxy(conn, mp, p) {
+		return
+	}
+
+	err = mp.SetInlineData(req, p)
+	m.respondToClientWithVer(conn, p)
+	log.LogDebugf("%s [opMetaSetInlineData] req: %d - ino(%v) len(%v) size(%v), resp: %v",
+		remoteAddr, p.GetReqID(), req.Inode, len(req.Data), req.Size, p.GetResultMsg())
+	return
+}
+
+func (m *metadataManager) opMetaGetDirVersion(conn net.Conn, p *Packet, remoteAddr string) (err error) {
+	req := &proto.GetDirVersionRequest{}
+	if err = json.Unmarshal(p.Data, req); err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClient(conn, p)
+		err = errors.NewErrorf("[%v] req: %v, resp: %v", p.GetOpMsgWithReqAndResult(), req, err.Error())
+		return
+	}
+	mp, err := m.getPartition(req.PartitionID)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
+		m.respondToClient(conn, p)
+		err = errors.NewErrorf("[%v] req: %v, resp: %v", p.GetOpMsgWithReqAndResult(), req, err.Error())
+		return
+	}
+	if !m.serveProxy(conn, mp, p) {
+		return
+	}
+	err = mp.GetDirVersion(req, p)
+	m.respondToClient(conn, p)
+	log.LogDebugf("%s [opMetaGetDirVersion] req: %d - %v, resp: %v, body: %s",
+		remoteAddr, p.GetReqID(), req, p.GetResultMsg(), p.Data)
+	return
+}
+
 func (m *metadataManager) opTxUpdateDentry(conn net.Conn, p *Packet, remoteAddr string) (err error) {
 	req := &proto.TxUpdateDentryRequest{}
 	if err = json.Unmarshal(p.Data, req); err != nil {
@@ -1537,7 +1703,9 @@ func (m *metadataManager) opDeleteMetaPartition(conn net.Conn,
 	mp.Stop()
 	mp.DeleteRaft()
 	m.deletePartition(mp.GetBaseConfig().PartitionId)
-	os.RemoveAll(conf.RootDir)
+	if !req.Tombstone || m.tombstonePartition(conf.RootDir) != nil {
+		os.RemoveAll(conf.RootDir)
+	}
 	p.PacketOkReply()
 	m.respondToClientWithVer(conn, p)
 	go func() {
@@ -1614,7 +1782,7 @@ func (m *metadataManager) opLoadMetaPartition(conn net.Conn, p *Packet,
 		err = errors.NewErrorf("[%v] req: %v, resp: %v", p.GetOpMsgWithReqAndResult(), req, err.Error())
 		return
 	}
-	if err = mp.ResponseLoadMetaPartition(p); err != nil {
+	if err = mp.ResponseLoadMetaPartition(p, req); err != nil {
 		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
 		log.LogErrorf("%s [opLoadMetaPartition] req[%v], "+
 			"response marshal[%v]", remoteAddr, req, err.Error())
@@ -1668,8 +1836,7 @@ func (m *metadataManager) opDecommissionMetaPartition(conn net.Conn,
 		m.respondToClientWithVer(conn, p)
 		return
 	}
-	_, err = mp.ChangeMember(raftProto.ConfAddNode,
-		raftProto.Peer{ID: req.AddPeer.ID}, reqData)
+	_, err = mp.ChangeMember(raftProto.ConfAddNode, raftPeer(req.AddPeer), reqData)
 	if err != nil {
 		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
 		m.respondToClientWithVer(conn, p)
@@ -1746,8 +1913,7 @@ func (m *metadataManager) opAddMetaPartitionRaftMember(conn net.Conn,
 		m.respondToClientWithVer(conn, p)
 		return
 	}
-	_, err = mp.ChangeMember(raftProto.ConfAddNode,
-		raftProto.Peer{ID: req.AddPeer.ID}, reqData)
+	_, err = mp.ChangeMember(raftProto.ConfAddNode, raftPeer(req.AddPeer), reqData)
 	if err != nil {
 		p.PacketErrorWithBody(proto.OpErr, ([]byte)(err.Error()))
 		m.respondToClientWithVer(conn, p)
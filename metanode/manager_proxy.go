@@ -33,6 +33,20 @@ func (m *metadataManager) IsForbiddenOp(mp MetaPartition, reqOp uint8) bool {
 	if !mp.IsForbidden() {
 		return false
 	}
+	return isForbiddenOp(reqOp)
+}
+
+// isImmutableOp reports whether the op changes the metadata of an immutable
+// volume. The lookups and the evictions of the inodes already unlinked do not.
+func isImmutableOp(reqOp uint8) bool {
+	switch reqOp {
+	case proto.OpMetaLookup, proto.OpMetaEvictInode, proto.OpMetaBatchEvictInode, proto.OpMetaClearInodeCache:
+		return false
+	}
+	return isForbiddenOp(reqOp)
+}
+
+func isForbiddenOp(reqOp uint8) bool {
 	switch reqOp {
 	case
 		// dentry
@@ -44,6 +58,7 @@ func (m *metadataManager) IsForbiddenOp(mp MetaPartition, reqOp uint8) bool {
 		proto.OpMetaBatchDeleteDentry,
 		proto.OpMetaUpdateDentry,
 		proto.OpMetaTxUpdateDentry,
+		proto.OpMetaBatchMoveDentry,
 		// extend
 		proto.OpMetaUpdateXAttr,
 		proto.OpMetaSetXAttr,
@@ -57,6 +72,8 @@ func (m *metadataManager) IsForbiddenOp(mp MetaPartition, reqOp uint8) bool {
 		proto.OpMetaBatchObjExtentsAdd,
 		proto.OpMetaBatchExtentsAdd,
 		proto.OpMetaExtentsDel,
+		proto.OpMetaSwapExtents,
+		proto.OpMetaSetInlineData,
 		// inode
 		proto.OpMetaCreateInode,
 		proto.OpQuotaCreateInode,
@@ -108,7 +125,8 @@ func (m *metadataManager) serveProxy(conn net.Conn, mp MetaPartition,
 	}
 
 	followerRead := func() bool {
-		if !p.IsReadMetaPkt() {
+		// a witness has nothing to read
+		if !p.IsReadMetaPkt() || mp.IsWitness() {
 			return false
 		}
 
@@ -0,0 +1,117 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstonePartition(t *testing.T) {
+	m := &metadataManager{rootDir: t.TempDir()}
+	rootDir := path.Join(m.rootDir, partitionPrefix+"100")
+	require.NoError(t, os.MkdirAll(rootDir, 0o755))
+	require.NoError(t, os.WriteFile(path.Join(rootDir, "meta"), []byte("meta"), 0o644))
+	require.NoError(t, m.tombstonePartition(rootDir))
+
+	entries, err := os.ReadDir(m.rootDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	tombstone := entries[0].Name()
+	require.True(t, strings.HasPrefix(tombstone, TombstonePartitionPrefix+partitionPrefix+"100_"))
+	// not loaded as a partition on restart
+	require.False(t, strings.HasPrefix(tombstone, partitionPrefix))
+
+	m.setTombstoneRetention(time.Hour.String())
+	m.deleteExpiredTombstones(time.Now())
+	_, err = os.Stat(path.Join(m.rootDir, tombstone, "meta"))
+	require.NoError(t, err)
+
+	// an unset retention keeps the tombstones as long as the backups
+	m.setTombstoneRetention(time.Duration(0).String())
+	m.deleteExpiredTombstones(time.Now().Add(2 * time.Hour))
+	_, err = os.Stat(path.Join(m.rootDir, tombstone))
+	require.NoError(t, err)
+
+	m.setTombstoneRetention(time.Hour.String())
+	m.deleteExpiredTombstones(time.Now().Add(2 * time.Hour))
+	_, err = os.Stat(path.Join(m.rootDir, tombstone))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestTLSRequired(t *testing.T) {
+	m := &metadataManager{partitions: make(map[uint64]MetaPartition)}
+	mp := newMetaPartition(1, m)
+	mp.config.Peers = []proto.Peer{{ID: 1, Addr: "10.0.0.1:17210"}, {ID: 2, Addr: "10.0.0.2:17210"}}
+	m.partitions[1] = mp
+	p := &Packet{}
+	p.PartitionID = 1
+	p.Opcode = proto.OpMetaCreateInode
+
+	client := "10.0.0.9:50000"
+	require.False(t, m.isTLSRequired(p, client))
+	m.setTLSRequiredVols([]string{mp.config.VolName})
+	require.True(t, m.isTLSRequired(p, client))
+	// the peers proxying to the leader and the transactions between meta nodes are not clients
+	require.False(t, m.isTLSRequired(p, "10.0.0.2:41000"))
+	p.Opcode = proto.OpTxCommitRM
+	require.False(t, m.isTLSRequired(p, client))
+	p.Opcode = proto.OpMetaCreateInode
+	p.PartitionID = 2
+	require.False(t, m.isTLSRequired(p, client))
+	m.setTLSRequiredVols(nil)
+	p.PartitionID = 1
+	require.False(t, m.isTLSRequired(p, client))
+}
+
+func TestVolImmutable(t *testing.T) {
+	m := &metadataManager{partitions: make(map[uint64]MetaPartition)}
+	mp := newMetaPartition(1, m)
+	m.partitions[1] = mp
+	p := &Packet{}
+	p.PartitionID = 1
+	p.Opcode = proto.OpMetaUnlinkInode
+
+	now := time.Now()
+	require.False(t, m.isVolImmutable(p, now))
+	m.setImmutableVols(map[string]int64{mp.config.VolName: now.Unix() + 60})
+	require.True(t, m.isVolImmutable(p, now))
+	p.Opcode = proto.OpMetaExtentsAdd
+	require.True(t, m.isVolImmutable(p, now))
+	p.Opcode = proto.OpMetaBatchMoveDentry
+	require.True(t, m.isVolImmutable(p, now))
+	// nor are the batch moves taken by a forbidden partition
+	mp.SetForbidden(true)
+	require.True(t, m.IsForbiddenOp(mp, proto.OpMetaBatchMoveDentry))
+	mp.SetForbidden(false)
+	// the reads and the evictions of the unlinked inodes go on
+	p.Opcode = proto.OpMetaLookup
+	require.False(t, m.isVolImmutable(p, now))
+	p.Opcode = proto.OpMetaEvictInode
+	require.False(t, m.isVolImmutable(p, now))
+	p.Opcode = proto.OpMetaInodeGet
+	require.False(t, m.isVolImmutable(p, now))
+	// the window ends without the heartbeats of the master
+	p.Opcode = proto.OpMetaUnlinkInode
+	require.False(t, m.isVolImmutable(p, now.Add(time.Minute)))
+	m.setImmutableVols(nil)
+	require.False(t, m.isVolImmutable(p, now))
+}
@@ -15,6 +15,7 @@
 package metanode
 
 import (
+	"crypto/tls"
 	"fmt"
 	syslog "log"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/cubefs/cubefs/raftstore"
 	masterSDK "github.com/cubefs/cubefs/sdk/master"
 	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/cgroup"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
@@ -90,6 +92,7 @@ type MetaNode struct {
 	VolsForbidWriteOpOfProtoVer0       map[string]struct{} // whether forbid by volume granularity,
 	qosEnable                          bool
 	readDirIops                        int
+	tlsConfig                          *tls.Config // the TLS clients are served if set
 
 	control common.Control
 }
@@ -234,6 +237,12 @@ func (m *MetaNode) parseConfig(cfg *config.Config) (err error) {
 
 	m.serviceIDKey = cfg.GetString(cfgServiceIDKey)
 
+	if certFile := cfg.GetString(proto.TLSCertFileKey); certFile != "" {
+		if m.tlsConfig, err = util.NewServerTLSConfig(certFile, cfg.GetString(proto.TLSKeyFileKey)); err != nil {
+			return fmt.Errorf("parseConfig: load tls certificate: %v", err)
+		}
+	}
+
 	total, _, err := util.GetMemInfo()
 	if err != nil {
 		log.LogErrorf("get total mem failed, err %s", err.Error())
@@ -462,6 +471,14 @@ func (m *MetaNode) newMetaManager(cfg *config.Config) (err error) {
 		}
 	}
 
+	throttleConf := cgroup.ThrottleConfig{}
+	if throttleConf.MemoryRatio, err = parseThrottleRatio(cfg, cfgCgroupMemThrottleRatio, cgroup.DefaultMemoryThrottleRatio); err != nil {
+		return
+	}
+	if throttleConf.CPURatio, err = parseThrottleRatio(cfg, cfgCgroupCpuThrottleRatio, cgroup.DefaultCPUThrottleRatio); err != nil {
+		return
+	}
+
 	// load metadataManager
 	conf := MetadataManagerConfig{
 		NodeID:           m.nodeId,
@@ -470,11 +487,24 @@ func (m *MetaNode) newMetaManager(cfg *config.Config) (err error) {
 		ZoneName:         m.zoneName,
 		EnableGcTimer:    cfg.GetBoolWithDefault(cfgEnableGcTimer, false),
 		GcRecyclePercent: gcRecyclePercent,
+		ThrottleConf:     throttleConf,
 	}
 	m.metadataManager = NewMetadataManager(conf, m)
 	return
 }
 
+func parseThrottleRatio(cfg *config.Config, key string, defaultRatio float64) (ratio float64, err error) {
+	ratioStr := cfg.GetString(key)
+	if ratioStr == "" {
+		return defaultRatio, nil
+	}
+	if ratio, err = strconv.ParseFloat(ratioStr, 64); err != nil {
+		err = fmt.Errorf("parse configKey[%v] failed: %v", key, err.Error())
+		log.LogError(err.Error())
+	}
+	return
+}
+
 func (m *MetaNode) startMetaManager() (err error) {
 	if err = m.metadataManager.Start(); err == nil {
 		log.LogInfof("[startMetaManager] manager start finish.")
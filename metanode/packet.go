@@ -34,10 +34,16 @@ func NewPacketToDeleteExtent(dp *DataPartition, ext *proto.ExtentKey) (p *Packet
 	p.Opcode = proto.OpMarkDelete
 	p.ExtentType = proto.NormalExtentType
 	p.PartitionID = dp.PartitionID
-	if storage.IsTinyExtent(ext.ExtentId) {
+	if storage.IsTinyExtent(ext.ExtentId) || proto.IsContainerExtent(ext.ExtentId) {
 		p.ExtentType = proto.TinyExtentType
 	}
 	log.LogDebugf("NewPacketToDeleteExtent. ext %v", ext)
+	if ext.IsSplit() && proto.IsContainerExtent(ext.ExtentId) {
+		// a record is only released with the last key of it
+		invalid = true
+		log.LogDebugf("NewPacketToDeleteExtent. ext %v of a container invalid to punch hole", ext)
+		return
+	}
 	if ext.IsSplit() {
 		var (
 			newOff  = ext.ExtentOffset
@@ -173,6 +173,7 @@ type OpDentry interface {
 	CreateDentry(req *CreateDentryReq, p *Packet, remoteAddr string) (err error)
 	DeleteDentry(req *DeleteDentryReq, p *Packet, remoteAddr string) (err error)
 	DeleteDentryBatch(req *BatchDeleteDentryReq, p *Packet, remoteAddr string) (err error)
+	BatchMoveDentry(req *proto.BatchMoveDentryRequest, p *Packet, remoteAddr string) (err error)
 	UpdateDentry(req *UpdateDentryReq, p *Packet, remoteAddr string) (err error)
 	ReadDir(req *ReadDirReq, p *Packet) (err error)
 	ReadDirLimit(req *ReadDirLimitReq, p *Packet) (err error)
@@ -184,6 +185,7 @@ type OpDentry interface {
 	TxDeleteDentry(req *proto.TxDeleteDentryRequest, p *Packet, remoteAddr string) (err error)
 	TxUpdateDentry(req *proto.TxUpdateDentryRequest, p *Packet, remoteAddr string) (err error)
 	QuotaCreateDentry(req *proto.QuotaCreateDentryRequest, p *Packet, remoteAddr string) (err error)
+	GetDirVersion(req *proto.GetDirVersionRequest, p *Packet) (err error)
 }
 
 type OpTransaction interface {
@@ -203,6 +205,9 @@ type OpExtent interface {
 	ExtentAppendWithCheck(req *proto.AppendExtentKeyWithCheckRequest, p *Packet, remoteAddr string) (err error)
 	BatchObjExtentAppend(req *proto.AppendObjExtentKeysRequest, p *Packet) (err error)
 	ExtentsList(req *proto.GetExtentsRequest, p *Packet) (err error)
+	SwapExtents(req *proto.SwapExtentsRequest, p *Packet) (err error)
+	SetInlineData(req *proto.SetInlineDataRequest, p *Packet) (err error)
+	GetFragmentedInodes(minExtents, limit int) *proto.FragmentedInodesResponse
 	ObjExtentsList(req *proto.GetExtentsRequest, p *Packet) (err error)
 	ExtentsTruncate(req *ExtentsTruncateReq, p *Packet, remoteAddr string) (err error)
 	BatchExtentAppend(req *proto.AppendExtentKeysRequest, p *Packet) (err error)
@@ -255,11 +260,13 @@ type OpPartition interface {
 	LeaderTerm() (leaderID, term uint64)
 	GetCursor() uint64
 	GetAppliedID() uint64
+	ExportRecords(since uint64, marker string, limit int) (*exportPage, error)
 	GetUniqId() uint64
 	IsFollowerRead() bool
 	SetFollowerRead(bool)
+	IsWitness() bool
 	GetBaseConfig() MetaPartitionConfig
-	ResponseLoadMetaPartition(p *Packet) (err error)
+	ResponseLoadMetaPartition(p *Packet, req *proto.MetaPartitionLoadRequest) (err error)
 	PersistMetadata() (err error)
 	RenameStaleMetadata() (err error)
 	ChangeMember(changeType raftproto.ConfChangeType, peer raftproto.Peer, context []byte) (resp interface{}, err error)
@@ -279,7 +286,10 @@ type MetaPartition interface {
 	Start(isCreate bool) error
 	Stop()
 	DataSize() uint64
+	OpCount() uint64
+	countOp()
 	GetFreeListLen() int
+	GetReclaim() *proto.MetaPartitionReclaim
 	OpMeta
 	LoadSnapshot(path string) error
 	ForceSetMetaPartitionToLoadding()
@@ -484,6 +494,7 @@ func (ew *BlobStoreClientWrapper) getBlobStoreClient() (blobClient *blobstore.Bl
 type metaPartition struct {
 	config                    *MetaPartitionConfig
 	size                      uint64                // For partition all file size
+	ops                       uint64                // client ops handled since loaded
 	applyID                   uint64                // Inode/Dentry max applyID, this index will be update after restoring from the dumped data.
 	storedApplyId             uint64                // update after store snapshot to disk
 	dentryTree                *BTree                // btree for dentries
@@ -498,6 +509,8 @@ type metaPartition struct {
 	delInodeFp                *os.File
 	freeList                  *freeList // free inode list
 	freeHybridList            *freeList // to store inode delay to delete migration keys
+	reclaim                   reclaimStat
+	dirVersions               dirVersions
 	extDelCh                  chan []proto.ExtentKey
 	extReset                  chan struct{}
 	vol                       *Vol
@@ -522,6 +535,7 @@ type metaPartition struct {
 	statByStorageClass        []*proto.StatOfStorageClass
 	statByMigrateStorageClass []*proto.StatOfStorageClass
 	syncAtimeCh               chan uint64
+	changes                   *changeJournal // recent inode/dentry changes for the metadata export
 }
 
 // IsLeader returns the raft leader address and if the current meta partition is the leader.
@@ -705,6 +719,15 @@ func (mp *metaPartition) DataSize() uint64 {
 	return mp.size
 }
 
+// OpCount returns the client ops the partition handled since loaded.
+func (mp *metaPartition) OpCount() uint64 {
+	return atomic.LoadUint64(&mp.ops)
+}
+
+func (mp *metaPartition) countOp() {
+	atomic.AddUint64(&mp.ops, 1)
+}
+
 func (mp *metaPartition) GetFreeListLen() int {
 	return mp.freeList.Len()
 }
@@ -869,6 +892,7 @@ func (mp *metaPartition) onStart(isCreate bool) (err error) {
 
 	go mp.startCheckerEvict()
 
+	mp.watchTreeChanges(mp.applyID, mp.inodeTree, mp.dentryTree)
 	log.LogWarnf("[before raft] get mp[%v] applied(%d),inodeCount(%d),dentryCount(%d)", mp.config.PartitionId, mp.applyID, mp.inodeTree.Len(), mp.dentryTree.Len())
 
 	if err = mp.startRaft(isCreate); err != nil {
@@ -923,9 +947,7 @@ func (mp *metaPartition) startRaft(isCreate bool) (err error) {
 
 		addr := strings.Split(peer.Addr, ":")[0]
 		rp := raftstore.PeerAddress{
-			Peer: raftproto.Peer{
-				ID: peer.ID,
-			},
+			Peer:          raftPeer(peer),
 			Address:       addr,
 			HeartbeatPort: heartbeatPort,
 			ReplicaPort:   replicaPort,
@@ -1018,6 +1040,7 @@ func NewMetaPartition(conf *MetaPartitionConfig, manager *metadataManager) MetaP
 	if manager != nil {
 		mp.config.ForbidWriteOpOfProtoVer0 = manager.isVolForbidWriteOpOfProtoVer0(mp.config.VolName)
 	}
+	mp.dirVersions.base = uint64(time.Now().UnixNano())
 	mp.txProcessor = NewTransactionProcessor(mp)
 	go mp.batchSyncInodeAtime()
 	return mp
@@ -1434,7 +1457,7 @@ func (mp *metaPartition) TryToLeader(groupID uint64) error {
 }
 
 // ResponseLoadMetaPartition loads the snapshot signature. TODO remove? no usage?
-func (mp *metaPartition) ResponseLoadMetaPartition(p *Packet) (err error) {
+func (mp *metaPartition) ResponseLoadMetaPartition(p *Packet, req *proto.MetaPartitionLoadRequest) (err error) {
 	resp := &proto.MetaPartitionLoadResponse{
 		PartitionID: mp.config.PartitionId,
 		DoCompare:   true,
@@ -1452,6 +1475,10 @@ func (mp *metaPartition) ResponseLoadMetaPartition(p *Packet) (err error) {
 	}
 	resp.RaftInfo.Hosts = mp.config.Peers
 
+	if req.Checksum {
+		resp.ApplyID, resp.InodeCrc, resp.DentryCrc = mp.treeChecksum()
+	}
+
 	if err != nil {
 		err = errors.Trace(err,
 			"[ResponseLoadMetaPartition] check snapshot")
@@ -1482,6 +1509,9 @@ func (mp *metaPartition) Reset() (err error) {
 	mp.config.UniqId = 0
 	mp.applyID = 0
 	mp.txProcessor.Reset()
+	if mp.changes != nil {
+		mp.changes.reset(0)
+	}
 
 	// remove files
 	filenames := []string{applyIDFile, dentryFile, inodeFile, extendFile, multipartFile, verdataFile, txInfoFile, txRbInodeFile, txRbDentryFile, TxIDFile}
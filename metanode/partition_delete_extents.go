@@ -203,6 +203,10 @@ func (mp *metaPartition) batchDeleteExtentsByDp(dpId uint64, extents []*proto.De
 		retryExtents = extents
 		return
 	}
+	if dp.Fork {
+		log.LogInfof("[batchDeleteExtentsByDp] mp(%v) dp(%v) is of a fork source, skip extents cnt(%v)", mp.config.PartitionId, dpId, len(extents))
+		return
+	}
 	batchCnt := DeleteBatchCount()
 	for i := uint64(0); i < uint64(len(extents)); i += batchCnt {
 		limit := i + batchCnt
@@ -246,7 +250,7 @@ func (mp *metaPartition) deleteExtentsFromList(fileList *synclist.SyncList) {
 	)
 	for {
 		// DeleteWorkerSleepMs()
-		time.Sleep(1 * time.Minute)
+		time.Sleep(mp.delExtentsInterval())
 		select {
 		case <-mp.stopC:
 			return
@@ -270,6 +274,11 @@ func (mp *metaPartition) deleteExtentsFromList(fileList *synclist.SyncList) {
 				"not raft leader,please ignore", mp.config.PartitionId)
 			continue
 		}
+		// the forks refer to the extents queued for deletion
+		if mp.isForkSource() {
+			log.LogDebugf("[deleteExtentsFromList] mp(%v) vol(%v) is a fork source, keep its extents", mp.config.PartitionId, mp.GetVolName())
+			continue
+		}
 		// leader do delete extent for EXTENT_DEL_* file
 
 		// read delete extents from file
@@ -0,0 +1,56 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+// The version of a directory changes whenever one of its dentries is created,
+// deleted or updated on this replica. The clients of the volumes in the strict
+// consistency mode revalidate the lookups and the listings they cache with it.
+// The versions are kept in memory only, hashed into dirVersionSlots counters: a
+// change of a directory changes the versions of the others of its slot too,
+// which only costs their clients a reload. They start from the time the
+// partition is created in memory, so that they never repeat after a restart or
+// on another replica becoming the leader.
+const dirVersionSlots = 1024
+
+type dirVersions struct {
+	base  uint64
+	slots [dirVersionSlots]uint64
+}
+
+func (mp *metaPartition) bumpDirVersion(parent uint64) {
+	atomic.AddUint64(&mp.dirVersions.slots[parent%dirVersionSlots], 1)
+}
+
+func (mp *metaPartition) getDirVersion(parent uint64) uint64 {
+	return mp.dirVersions.base + atomic.LoadUint64(&mp.dirVersions.slots[parent%dirVersionSlots])
+}
+
+// GetDirVersion replies the version of the dentries of the directory.
+func (mp *metaPartition) GetDirVersion(req *proto.GetDirVersionRequest, p *Packet) (err error) {
+	reply, err := json.Marshal(&proto.GetDirVersionResponse{Version: mp.getDirVersion(req.ParentID)})
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
+		return
+	}
+	p.PacketOkWithBody(reply)
+	return
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirVersion(t *testing.T) {
+	mp := newMetaPartition(20002, &metadataManager{})
+	for _, ino := range []uint64{1001, 1002} {
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(ino, DirModeType)))
+	}
+	for _, ino := range []uint64{1003, 1004} {
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(ino, FileModeType)))
+	}
+	getVersion := func(parent uint64) uint64 {
+		p := &Packet{}
+		require.NoError(t, mp.GetDirVersion(&proto.GetDirVersionRequest{ParentID: parent}, p))
+		require.Equal(t, proto.OpOk, p.ResultCode)
+		resp := &proto.GetDirVersionResponse{}
+		require.NoError(t, json.Unmarshal(p.Data, resp))
+		return resp.Version
+	}
+
+	v, other := getVersion(1001), getVersion(1002)
+	d := &Dentry{ParentId: 1001, Name: "a", Inode: 1003, Type: FileModeType, multiSnap: NewDentrySnap(0)}
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateDentry(d, false))
+	require.NotEqual(t, v, getVersion(1001))
+	require.Equal(t, other, getVersion(1002))
+
+	v = getVersion(1001)
+	resp := mp.fsmUpdateDentry(&Dentry{ParentId: 1001, Name: "a", Inode: 1004})
+	require.Equal(t, uint8(proto.OpOk), resp.Status)
+	require.NotEqual(t, v, getVersion(1001))
+
+	// reading does not change the version
+	v = getVersion(1001)
+	_, status := mp.getDentry(&Dentry{ParentId: 1001, Name: "a"})
+	require.Equal(t, uint8(proto.OpOk), status)
+	require.Equal(t, v, getVersion(1001))
+
+	resp = mp.fsmDeleteDentry(&Dentry{ParentId: 1001, Name: "a"}, false)
+	require.Equal(t, uint8(proto.OpOk), resp.Status)
+	require.NotEqual(t, v, getVersion(1001))
+	require.Equal(t, other, getVersion(1002))
+
+	// deleting a dentry not found does not change it
+	v = getVersion(1001)
+	resp = mp.fsmDeleteDentry(&Dentry{ParentId: 1001, Name: "a"}, false)
+	require.Equal(t, uint8(proto.OpNotExistErr), resp.Status)
+	require.Equal(t, v, getVersion(1001))
+}
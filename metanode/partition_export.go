@@ -0,0 +1,400 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	defaultExportJournalSize = 64 * 1024
+	defaultExportLimit       = 1000
+	maxExportLimit           = 100000
+
+	exportFormatJSONL    = "jsonl"
+	exportFormatProtobuf = "protobuf"
+
+	exportKindInode  = "inode"
+	exportKindDentry = "dentry"
+)
+
+// changeKey identifies an inode, or a dentry when dentry is set.
+type changeKey struct {
+	dentry   bool
+	ino      uint64
+	parentID uint64
+	name     string
+}
+
+func newChangeKey(item BtreeItem) (key changeKey, ok bool) {
+	switch v := item.(type) {
+	case *Inode:
+		return changeKey{ino: v.Inode}, true
+	case *Dentry:
+		return changeKey{dentry: true, parentID: v.ParentId, name: v.Name}, true
+	}
+	return
+}
+
+// less orders the inodes before the dentries, and each kind by its tree key.
+func (k changeKey) less(o changeKey) bool {
+	if k.dentry != o.dentry {
+		return !k.dentry
+	}
+	if !k.dentry {
+		return k.ino < o.ino
+	}
+	if k.parentID != o.parentID {
+		return k.parentID < o.parentID
+	}
+	return k.name < o.name
+}
+
+type changeEntry struct {
+	applyID uint64
+	key     changeKey
+}
+
+// changeJournal keeps the keys of the latest inode and dentry changes of a
+// partition in memory, stamped with the apply ID the partition had when they
+// were made. It is a ring, so the oldest changes are dropped once it is full,
+// and it starts over when the partition is loaded or its trees are replaced.
+type changeJournal struct {
+	sync.Mutex
+	entries []changeEntry
+	head    int
+	size    int
+	// every change made at or after floor is still in the journal
+	floor uint64
+}
+
+func newChangeJournal(size int) *changeJournal {
+	return &changeJournal{size: size}
+}
+
+func (j *changeJournal) reset(applyID uint64) {
+	j.Lock()
+	j.entries = j.entries[:0]
+	j.head = 0
+	j.floor = applyID
+	j.Unlock()
+}
+
+func (j *changeJournal) record(key changeKey, applyID uint64) {
+	j.Lock()
+	defer j.Unlock()
+	entry := changeEntry{applyID: applyID, key: key}
+	if len(j.entries) < j.size {
+		j.entries = append(j.entries, entry)
+		return
+	}
+	if dropped := j.entries[j.head].applyID; dropped >= j.floor {
+		j.floor = dropped + 1
+	}
+	j.entries[j.head] = entry
+	j.head = (j.head + 1) % j.size
+}
+
+// changedSince returns the sorted keys changed at or after the given apply ID,
+// or false if some of those changes are no longer in the journal.
+func (j *changeJournal) changedSince(applyID uint64) (keys []changeKey, ok bool) {
+	j.Lock()
+	if applyID < j.floor {
+		j.Unlock()
+		return nil, false
+	}
+	seen := make(map[changeKey]struct{})
+	for _, entry := range j.entries {
+		if entry.applyID < applyID {
+			continue
+		}
+		if _, ok := seen[entry.key]; !ok {
+			seen[entry.key] = struct{}{}
+			keys = append(keys, entry.key)
+		}
+	}
+	j.Unlock()
+	sort.Slice(keys, func(a, b int) bool { return keys[a].less(keys[b]) })
+	return keys, true
+}
+
+// watchTreeChanges makes the given inode and dentry trees report their changes
+// to the journal, which starts over from the apply ID the trees are at.
+func (mp *metaPartition) watchTreeChanges(applyID uint64, trees ...*BTree) {
+	if mp.changes == nil {
+		mp.changes = newChangeJournal(defaultExportJournalSize)
+	}
+	for _, tree := range trees {
+		tree.onChange = mp.recordChange
+	}
+	mp.changes.reset(applyID)
+}
+
+// recordChange records a change of an inode or a dentry. The fsm calls it for
+// the items it updates in place without the update accessors of the trees.
+func (mp *metaPartition) recordChange(item BtreeItem) {
+	if mp.changes == nil {
+		return
+	}
+	if key, ok := newChangeKey(item); ok {
+		mp.changes.record(key, mp.getApplyID())
+	}
+}
+
+// exportRecord is an inode or a dentry as seen by the external indexes. A
+// deleted record only carries its key.
+type exportRecord struct {
+	Kind         string `json:"kind"`
+	Deleted      bool   `json:"deleted,omitempty"`
+	Inode        uint64 `json:"ino"`
+	ParentID     uint64 `json:"parentId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Type         uint32 `json:"type,omitempty"`
+	Size         uint64 `json:"size,omitempty"`
+	NLink        uint32 `json:"nlink,omitempty"`
+	Uid          uint32 `json:"uid,omitempty"`
+	Gid          uint32 `json:"gid,omitempty"`
+	Generation   uint64 `json:"generation,omitempty"`
+	CreateTime   int64  `json:"createTime,omitempty"`
+	AccessTime   int64  `json:"accessTime,omitempty"`
+	ModifyTime   int64  `json:"modifyTime,omitempty"`
+	StorageClass uint32 `json:"storageClass,omitempty"`
+	LinkTarget   []byte `json:"linkTarget,omitempty"`
+}
+
+func newInodeExportRecord(i *Inode) *exportRecord {
+	i.RLock()
+	defer i.RUnlock()
+	return &exportRecord{
+		Kind:         exportKindInode,
+		Deleted:      i.Flag&DeleteMarkFlag == DeleteMarkFlag,
+		Inode:        i.Inode,
+		Type:         i.Type,
+		Size:         i.Size,
+		NLink:        i.NLink,
+		Uid:          i.Uid,
+		Gid:          i.Gid,
+		Generation:   i.Generation,
+		CreateTime:   i.CreateTime,
+		AccessTime:   i.AccessTime,
+		ModifyTime:   i.ModifyTime,
+		StorageClass: i.StorageClass,
+		LinkTarget:   i.LinkTarget,
+	}
+}
+
+func newDentryExportRecord(d *Dentry) *exportRecord {
+	return &exportRecord{
+		Kind:     exportKindDentry,
+		Deleted:  d.isDeleted(),
+		Inode:    d.Inode,
+		ParentID: d.ParentId,
+		Name:     d.Name,
+		Type:     d.Type,
+	}
+}
+
+func newDeletedExportRecord(key changeKey) *exportRecord {
+	if key.dentry {
+		return &exportRecord{Kind: exportKindDentry, Deleted: true, ParentID: key.parentID, Name: key.name}
+	}
+	return &exportRecord{Kind: exportKindInode, Deleted: true, Inode: key.ino}
+}
+
+func (r *exportRecord) key() changeKey {
+	if r.Kind == exportKindDentry {
+		return changeKey{dentry: true, parentID: r.ParentID, name: r.Name}
+	}
+	return changeKey{ino: r.Inode}
+}
+
+// appendProto appends the record encoded as the ExportRecord message described
+// in the metanode API docs, where kind is 1 for an inode and 2 for a dentry.
+func (r *exportRecord) appendProto(b []byte) []byte {
+	appendVarint := func(num protowire.Number, v uint64) {
+		if v != 0 {
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, v)
+		}
+	}
+	appendBytes := func(num protowire.Number, v []byte) {
+		if len(v) != 0 {
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendBytes(b, v)
+		}
+	}
+	kind := uint64(1)
+	if r.Kind == exportKindDentry {
+		kind = 2
+	}
+	appendVarint(1, kind)
+	appendVarint(2, protowire.EncodeBool(r.Deleted))
+	appendVarint(3, r.Inode)
+	appendVarint(4, r.ParentID)
+	appendBytes(5, []byte(r.Name))
+	appendVarint(6, uint64(r.Type))
+	appendVarint(7, r.Size)
+	appendVarint(8, uint64(r.NLink))
+	appendVarint(9, uint64(r.Uid))
+	appendVarint(10, uint64(r.Gid))
+	appendVarint(11, r.Generation)
+	appendVarint(12, uint64(r.CreateTime))
+	appendVarint(13, uint64(r.AccessTime))
+	appendVarint(14, uint64(r.ModifyTime))
+	appendVarint(15, uint64(r.StorageClass))
+	appendBytes(16, r.LinkTarget)
+	return b
+}
+
+// exportPage is a page of an export round. All the pages of a round report the
+// apply ID of its first page, which is the since of the next round.
+type exportPage struct {
+	Records []*exportRecord
+	ApplyID uint64
+	Full    bool
+	// Marker resumes the round on the next page, it is empty on the last page.
+	Marker string
+}
+
+func formatExportMarker(applyID uint64, key changeKey) string {
+	if key.dentry {
+		return fmt.Sprintf("%d:d:%d:%s", applyID, key.parentID, key.name)
+	}
+	return fmt.Sprintf("%d:i:%d", applyID, key.ino)
+}
+
+func parseExportMarker(marker string) (applyID uint64, key changeKey, err error) {
+	parts := strings.SplitN(marker, ":", 4)
+	if len(parts) < 3 {
+		err = fmt.Errorf("invalid export marker %q", marker)
+		return
+	}
+	if applyID, err = strconv.ParseUint(parts[0], 10, 64); err != nil {
+		return
+	}
+	var id uint64
+	if id, err = strconv.ParseUint(parts[2], 10, 64); err != nil {
+		return
+	}
+	switch {
+	case parts[1] == "i" && len(parts) == 3:
+		key = changeKey{ino: id}
+	case parts[1] == "d" && len(parts) == 4:
+		key = changeKey{dentry: true, parentID: id, name: parts[3]}
+	default:
+		err = fmt.Errorf("invalid export marker %q", marker)
+	}
+	return
+}
+
+// ExportRecords returns a page of the inodes and dentries changed at or after
+// the since apply ID, starting after the marker of the previous page. All the
+// records are exported if since is 0 or older than the change journal, so the
+// records of a round may repeat and the consumers should upsert them.
+func (mp *metaPartition) ExportRecords(since uint64, marker string, limit int) (page *exportPage, err error) {
+	page = &exportPage{}
+	var (
+		after    changeKey
+		hasAfter bool
+	)
+	if marker != "" {
+		if page.ApplyID, after, err = parseExportMarker(marker); err != nil {
+			return nil, err
+		}
+		hasAfter = true
+	} else {
+		page.ApplyID = mp.GetAppliedID()
+	}
+	inodeTree := mp.GetInodeTree()
+	dentryTree := mp.GetDentryTree()
+
+	var keys []changeKey
+	page.Full = true
+	if since != 0 && mp.changes != nil {
+		var ok bool
+		keys, ok = mp.changes.changedSince(since)
+		page.Full = !ok
+	}
+
+	more := false
+	add := func(record *exportRecord) bool {
+		if len(page.Records) == limit {
+			more = true
+			return false
+		}
+		page.Records = append(page.Records, record)
+		return true
+	}
+
+	if !page.Full {
+		for _, key := range keys {
+			if hasAfter && !after.less(key) {
+				continue
+			}
+			var item BtreeItem
+			if key.dentry {
+				item = dentryTree.Get(&Dentry{ParentId: key.parentID, Name: key.name})
+			} else {
+				item = inodeTree.Get(NewInode(key.ino, 0))
+			}
+			var record *exportRecord
+			switch v := item.(type) {
+			case *Inode:
+				record = newInodeExportRecord(v)
+			case *Dentry:
+				record = newDentryExportRecord(v)
+			default:
+				record = newDeletedExportRecord(key)
+			}
+			if !add(record) {
+				break
+			}
+		}
+	} else {
+		if !hasAfter || !after.dentry {
+			pivot := NewInode(0, 0)
+			if hasAfter {
+				pivot.Inode = after.ino + 1
+			}
+			inodeTree.AscendGreaterOrEqual(pivot, func(i BtreeItem) bool {
+				return add(newInodeExportRecord(i.(*Inode)))
+			})
+		}
+		if !more {
+			pivot := &Dentry{}
+			if hasAfter && after.dentry {
+				pivot = &Dentry{ParentId: after.parentID, Name: after.name}
+			}
+			dentryTree.AscendGreaterOrEqual(pivot, func(i BtreeItem) bool {
+				d := i.(*Dentry)
+				if hasAfter && after.dentry && d.ParentId == after.parentID && d.Name == after.name {
+					return true
+				}
+				return add(newDentryExportRecord(d))
+			})
+		}
+	}
+
+	if more {
+		page.Marker = formatExportMarker(page.ApplyID, page.Records[len(page.Records)-1].key())
+	}
+	return
+}
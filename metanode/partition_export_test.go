@@ -0,0 +1,113 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestExportRecords(t *testing.T) {
+	mp := newMetaPartition(20002, &metadataManager{})
+	mp.uploadApplyID(10)
+	mp.watchTreeChanges(mp.getApplyID(), mp.inodeTree, mp.dentryTree)
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(1, DirModeType)))
+	for ino := uint64(2); ino <= 4; ino++ {
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(ino, FileModeType)))
+	}
+	d := &Dentry{ParentId: 1, Name: "a", Inode: 2, Type: FileModeType, multiSnap: NewDentrySnap(0)}
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateDentry(d, false))
+
+	// a full round in two pages
+	page, err := mp.ExportRecords(0, "", 3)
+	require.NoError(t, err)
+	require.True(t, page.Full)
+	require.Equal(t, uint64(10), page.ApplyID)
+	require.Len(t, page.Records, 3)
+	require.Equal(t, "10:i:3", page.Marker)
+
+	mp.uploadApplyID(20)
+	page, err = mp.ExportRecords(0, page.Marker, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), page.ApplyID)
+	require.Empty(t, page.Marker)
+	require.Len(t, page.Records, 2)
+	require.Equal(t, uint64(4), page.Records[0].Inode)
+	require.Equal(t, exportKindDentry, page.Records[1].Kind)
+	require.Equal(t, "a", page.Records[1].Name)
+
+	// only the changes made since the last round, with the deleted records
+	require.Equal(t, uint8(proto.OpOk), mp.fsmDeleteDentry(d, false).Status)
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(5, FileModeType)))
+	page, err = mp.ExportRecords(20, "", 10)
+	require.NoError(t, err)
+	require.False(t, page.Full)
+	require.Len(t, page.Records, 3)
+	require.Equal(t, uint64(1), page.Records[0].Inode)
+	require.Equal(t, uint64(5), page.Records[1].Inode)
+	require.True(t, page.Records[2].Deleted)
+	require.Equal(t, "a", page.Records[2].Name)
+
+	// the changes before the journal are exported in full
+	page, err = mp.ExportRecords(5, "", 10)
+	require.NoError(t, err)
+	require.True(t, page.Full)
+	require.Len(t, page.Records, 5)
+
+	_, err = mp.ExportRecords(20, "20:x:1", 10)
+	require.Error(t, err)
+}
+
+func TestChangeJournal(t *testing.T) {
+	j := newChangeJournal(2)
+	j.reset(1)
+	j.record(changeKey{ino: 2}, 1)
+	j.record(changeKey{ino: 1}, 2)
+	keys, ok := j.changedSince(1)
+	require.True(t, ok)
+	require.Equal(t, []changeKey{{ino: 1}, {ino: 2}}, keys)
+
+	// the change at 1 is dropped
+	j.record(changeKey{dentry: true, parentID: 1, name: "a"}, 3)
+	_, ok = j.changedSince(1)
+	require.False(t, ok)
+	keys, ok = j.changedSince(2)
+	require.True(t, ok)
+	require.Equal(t, []changeKey{{ino: 1}, {dentry: true, parentID: 1, name: "a"}}, keys)
+}
+
+func TestExportRecordProto(t *testing.T) {
+	r := &exportRecord{Kind: exportKindDentry, Inode: 3, ParentID: 1, Name: "a"}
+	fields := make(map[protowire.Number]interface{})
+	b := r.appendProto(nil)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.True(t, n > 0)
+		b = b[n:]
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			fields[num] = string(v)
+			b = b[n:]
+		} else {
+			v, n := protowire.ConsumeVarint(b)
+			fields[num] = v
+			b = b[n:]
+		}
+	}
+	require.Equal(t, map[protowire.Number]interface{}{1: uint64(2), 3: uint64(3), 4: uint64(1), 5: "a"}, fields)
+}
@@ -100,6 +100,19 @@ func (mp *metaPartition) UpdateVolumeView(dataView *proto.DataPartitionsView, vo
 				IsDiscard:   view.DataPartitions[i].IsDiscard,
 			}
 		}
+		for _, dp := range view.ForkPartitions {
+			if len(dp.Hosts) < 1 {
+				continue
+			}
+			newView.DataPartitions = append(newView.DataPartitions, &DataPartition{
+				PartitionID: dp.PartitionID,
+				Status:      dp.Status,
+				Hosts:       dp.Hosts,
+				ReplicaNum:  dp.ReplicaNum,
+				IsDiscard:   dp.IsDiscard,
+				Fork:        true,
+			})
+		}
 		return newView
 	}
 	mp.vol.UpdatePartitions(convert(dataView))
@@ -186,10 +199,24 @@ func (mp *metaPartition) deleteWorker() {
 			continue
 		}
 
+		// the forks refer to the extents of the deleted files
+		if mp.isForkSource() {
+			time.Sleep(AsyncDeleteInterval)
+			continue
+		}
+
+		if mp.inMaintenance() {
+			time.Sleep(AsyncDeleteInterval)
+			continue
+		}
+
+		boostBatchCount, boosted := mp.reclaimBoost()
 		// add sleep time value
-		DeleteWorkerSleepMs()
+		if !boosted {
+			DeleteWorkerSleepMs()
+		}
 
-		isForceDeleted := sleepCnt%MaxSleepCnt == 0
+		isForceDeleted := boosted || sleepCnt%MaxSleepCnt == 0
 		if !isForceDeleted && mp.freeList.Len() < MinDeleteBatchCounts {
 			time.Sleep(AsyncDeleteInterval)
 			sleepCnt++
@@ -204,6 +231,9 @@ func (mp *metaPartition) deleteWorker() {
 		}
 
 		batchCount := DeleteBatchCount()
+		if boosted && boostBatchCount > batchCount {
+			batchCount = boostBatchCount
+		}
 		delayDeleteInos := make([]uint64, 0)
 		for idx = 0; idx < int(batchCount); idx++ {
 			// batch get free inode from the freeList
@@ -243,6 +273,12 @@ func (mp *metaPartition) deleteWorker() {
 		}
 		log.LogDebugf("[deleteWorker] metaPartition[%v] should delete inodes:[%v]", mp.config.PartitionId, len(buffSlice))
 
+		// the boosted worker does not wait on the inodes all kept for the delay
+		if boosted && len(buffSlice) == 0 {
+			time.Sleep(AsyncDeleteInterval)
+			continue
+		}
+
 		mp.persistDeletedInodes(buffSlice)
 		mp.deleteMarkedInodes(buffSlice)
 		sleepCnt++
@@ -279,6 +315,11 @@ func (mp *metaPartition) batchDeleteExtentsByPartition(partitionDeleteExtents ma
 			log.LogWarnf("action[batchDeleteExtentsByPartition] dp(%v) is discard, skip extents count(%v)", partitionID, len(extents))
 			continue
 		}
+		// the extents of the fork source stay with it
+		if dp != nil && dp.Fork {
+			log.LogInfof("action[batchDeleteExtentsByPartition] dp(%v) is of a fork source, skip extents count(%v)", partitionID, len(extents))
+			continue
+		}
 		if log.EnableDebug() {
 			log.LogDebugf("batchDeleteExtentsByPartition partitionID %v extents %v", partitionID, len(extents))
 		}
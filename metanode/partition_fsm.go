@@ -56,6 +56,10 @@ func (mp *metaPartition) Apply(command []byte, index uint64) (resp interface{},
 	mp.nonIdempotent.Lock()
 	defer mp.nonIdempotent.Unlock()
 
+	if mp.IsWitness() && !witnessApplies(msg.Op) {
+		return
+	}
+
 	switch msg.Op {
 	case opFSMCreateInode:
 		ino := NewInode(0, 0)
@@ -291,6 +295,10 @@ func (mp *metaPartition) Apply(command []byte, index uint64) (resp interface{},
 		if extend, err = NewExtendFromBytes(msg.V); err != nil {
 			return
 		}
+		if status := mp.fsmCheckDirShardLayout(extend); status != proto.OpOk {
+			resp = &ExtendOpResult{Status: status}
+			return
+		}
 		err = mp.fsmSetXAttr(extend)
 	case opFSMRemoveXAttr:
 		var extend *Extend
@@ -495,6 +503,24 @@ func (mp *metaPartition) Apply(command []byte, index uint64) (resp interface{},
 			return
 		}
 		resp, err = mp.fsmSetFreeze(req.Freeze)
+	case opFSMBatchMoveDentry:
+		req := &proto.BatchMoveDentryRequest{}
+		if err = json.Unmarshal(msg.V, req); err != nil {
+			return
+		}
+		resp = mp.fsmBatchMoveDentry(req)
+	case opFSMSwapExtents:
+		req := &proto.SwapExtentsRequest{}
+		if err = json.Unmarshal(msg.V, req); err != nil {
+			return
+		}
+		resp = mp.fsmSwapExtents(req)
+	case opFSMSetInlineData:
+		req := &proto.SetInlineDataRequest{}
+		if err = json.Unmarshal(msg.V, req); err != nil {
+			return
+		}
+		resp = mp.fsmSetInlineData(req)
 	default:
 		// do nothing
 	case opFSMSyncInodeAccessTime:
@@ -695,6 +721,7 @@ func (mp *metaPartition) ApplySnapshot(peers []raftproto.Peer, iter raftproto.Sn
 		txRbDentryTree = NewBtree()
 		uniqChecker    = newUniqChecker()
 		verList        []*proto.VolVersionInfo
+		witness        = mp.IsWitness()
 	)
 
 	blockUntilStoreSnapshot := func() {
@@ -737,6 +764,7 @@ func (mp *metaPartition) ApplySnapshot(peers []raftproto.Peer, iter raftproto.Sn
 
 	defer func() {
 		if err == io.EOF {
+			mp.watchTreeChanges(appIndexID, inodeTree, dentryTree)
 			mp.applyID = appIndexID
 			mp.config.UniqId = uniqID
 			mp.txProcessor.txManager.txIdAlloc.setTransactionID(txID)
@@ -837,6 +865,9 @@ func (mp *metaPartition) ApplySnapshot(peers []raftproto.Peer, iter raftproto.Sn
 		}
 
 		index++
+		if witness && !witnessLoadsSnap(snap.Op) {
+			continue
+		}
 		switch snap.Op {
 		case opFSMApplyId:
 			appIndexID = binary.BigEndian.Uint64(snap.V)
@@ -98,8 +98,14 @@ func (mp *metaPartition) fsmCreateDentry(dentry *Dentry,
 			status = proto.OpArgMismatchErr
 			return
 		}
+		if mp.isDirShardSealed(dentry.ParentId) {
+			log.LogWarnf("action[fsmCreateDentry] mp[%v] ParentId [%v] is a sealed dir shard, dentry name [%v], inode[%v]", mp.config.PartitionId, dentry.ParentId, dentry.Name, dentry.Inode)
+			status = proto.OpNotExistErr
+			return
+		}
 	}
 
+	mp.bumpDirVersion(dentry.ParentId)
 	if item, ok := mp.dentryTree.ReplaceOrInsert(dentry, false); !ok {
 		// do not allow directories and files to overwrite each
 		// other when renaming
@@ -221,6 +227,7 @@ func (mp *metaPartition) fsmTxDeleteDentry(txDentry *TxDentry) (resp *DentryResp
 	}
 
 	mp.dentryTree.Delete(tmpDen)
+	mp.bumpDirVersion(tmpDen.ParentId)
 	// parent link count not change
 	resp.Msg = item.(*Dentry)
 	return
@@ -271,6 +278,11 @@ func (mp *metaPartition) fsmDeleteDentry(denParm *Dentry, checkInode bool) (resp
 		}
 	}
 
+	if denFound != nil {
+		mp.recordChange(denFound)
+		mp.bumpDirVersion(denParm.ParentId)
+	}
+
 	if item != nil && (clean || (item.(*Dentry).getSnapListLen() == 0 && item.(*Dentry).isDeleted())) {
 		log.LogDebugf("action[fsmDeleteDentry] mp[%v] dnetry %v really be deleted", mp.config.PartitionId, item.(*Dentry))
 		item = mp.dentryTree.Delete(item.(*Dentry))
@@ -322,6 +334,82 @@ func (mp *metaPartition) fsmBatchDeleteDentry(db DentryBatch) []*DentryResponse
 	return result
 }
 
+// fsmBatchMoveDentry moves every item independently, a failed item does not
+// roll back the items moved before it. Parent link counts follow the moves.
+// A directory moved to another parent fails with OpNotPerm, the client moves
+// it by rename once it has checked the paths.
+func (mp *metaPartition) fsmBatchMoveDentry(req *proto.BatchMoveDentryRequest) (resp *proto.BatchMoveDentryResponse) {
+	resp = &proto.BatchMoveDentryResponse{
+		Results: make([]proto.MoveDentryResult, 0, len(req.Items)),
+	}
+	var srcParIno, dstParIno *Inode
+	if item := mp.inodeTree.CopyGet(NewInode(req.SrcParentID, 0)); item != nil {
+		srcParIno = item.(*Inode)
+	}
+	if item := mp.inodeTree.CopyGet(NewInode(req.DstParentID, 0)); item != nil {
+		dstParIno = item.(*Inode)
+	}
+	for _, item := range req.Items {
+		result := proto.MoveDentryResult{SrcName: item.SrcName}
+		if srcParIno == nil || dstParIno == nil {
+			result.Status = proto.OpNotExistErr
+		} else {
+			result.Inode, result.Status = mp.moveDentry(srcParIno, dstParIno, item)
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	log.LogDebugf("action[fsmBatchMoveDentry] mp[%v] spino(%v) dpino(%v) items(%v)",
+		mp.config.PartitionId, req.SrcParentID, req.DstParentID, len(req.Items))
+	return
+}
+
+func (mp *metaPartition) moveDentry(srcParIno, dstParIno *Inode, item proto.MoveDentryItem) (ino uint64, status uint8) {
+	if status = mp.dentryInTx(srcParIno.Inode, item.SrcName); status != proto.OpOk {
+		return
+	}
+	if status = mp.dentryInTx(dstParIno.Inode, item.DstName); status != proto.OpOk {
+		return
+	}
+	found := mp.dentryTree.CopyGet(&Dentry{ParentId: srcParIno.Inode, Name: item.SrcName})
+	if found == nil || found.(*Dentry).isDeleted() {
+		return 0, proto.OpNotExistErr
+	}
+	src := found.(*Dentry)
+	ino = src.Inode
+	if srcParIno.Inode == dstParIno.Inode && item.SrcName == item.DstName {
+		return ino, proto.OpOk
+	}
+	if src.Inode == dstParIno.Inode {
+		return ino, proto.OpArgMismatchErr
+	}
+	// a directory moved under one of its descendants would detach the subtree
+	// from the root, and the partition can't see the ancestors of the parent,
+	// so the directories are only renamed within their parent here
+	if proto.IsDir(src.Type) && srcParIno.Inode != dstParIno.Inode {
+		return ino, proto.OpNotPerm
+	}
+	if found := mp.dentryTree.Get(&Dentry{ParentId: dstParIno.Inode, Name: item.DstName}); found != nil && !found.(*Dentry).isDeleted() {
+		return ino, proto.OpExistErr
+	}
+
+	// both go through the paths of create and delete, which keep the versions
+	// of the dentries for the snapshots and the link counts of the parents
+	if status = mp.fsmCreateDentry(&Dentry{
+		ParentId:  dstParIno.Inode,
+		Name:      item.DstName,
+		Inode:     src.Inode,
+		Type:      src.Type,
+		multiSnap: NewDentrySnap(mp.verSeq),
+	}, false); status != proto.OpOk {
+		return
+	}
+	if resp := mp.fsmDeleteDentry(&Dentry{ParentId: srcParIno.Inode, Name: item.SrcName, Inode: src.Inode}, true); resp.Status != proto.OpOk {
+		log.LogErrorf("action[moveDentry] mp[%v] delete src dentry parent(%v) name(%v) status(%v)",
+			mp.config.PartitionId, srcParIno.Inode, item.SrcName, resp.Status)
+	}
+	return ino, proto.OpOk
+}
+
 func (mp *metaPartition) fsmTxUpdateDentry(txUpDateDentry *TxUpdateDentry) (resp *DentryResponse) {
 	resp = NewDentryResponse()
 	resp.Status = proto.OpOk
@@ -362,6 +450,7 @@ func (mp *metaPartition) fsmTxUpdateDentry(txUpDateDentry *TxUpdateDentry) (resp
 
 	d := item.(*Dentry)
 	d.Inode, newDen.Inode = newDen.Inode, d.Inode
+	mp.bumpDirVersion(d.ParentId)
 	resp.Msg = newDen
 	return
 }
@@ -387,6 +476,7 @@ func (mp *metaPartition) fsmUpdateDentry(dentry *Dentry) (
 			d.multiSnap.dentryList = append([]*Dentry{dn.(*Dentry)}, d.multiSnap.dentryList...)
 		}
 		d.Inode, dentry.Inode = dentry.Inode, d.Inode
+		mp.bumpDirVersion(d.ParentId)
 		resp.Msg = dentry
 	})
 	return
@@ -449,11 +539,23 @@ func (mp *metaPartition) readDir(req *ReadDirReq) (resp *ReadDirResp) {
 	return
 }
 
+// prefixRangeEnd returns the least name after all the names with prefix,
+// false if there is none and the range goes to the end of the directory.
+func prefixRangeEnd(prefix string) (string, bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			return prefix[:i] + string([]byte{prefix[i] + 1}), true
+		}
+	}
+	return "", false
+}
+
 // Read dentry from btree by limit count
 // if req.Marker == "" and req.Limit == 0, it becomes readDir
 // else if req.Marker != "" and req.Limit == 0, return dentries from pid:name to pid+1
 // else if req.Marker == "" and req.Limit != 0, return dentries from pid with limit count
 // else if req.Marker != "" and req.Limit != 0, return dentries from pid:marker to pid:xxxx with limit count
+// and with req.Prefix, only the range of the names starting with it is read
 func (mp *metaPartition) readDirLimit(req *ReadDirLimitReq) (resp *ReadDirLimitResp) {
 	log.LogDebugf("action[readDirLimit] mp[%v] req %v", mp.config.PartitionId, req)
 	resp = &ReadDirLimitResp{}
@@ -463,9 +565,16 @@ func (mp *metaPartition) readDirLimit(req *ReadDirLimitReq) (resp *ReadDirLimitR
 	if len(req.Marker) > 0 {
 		startDentry.Name = req.Marker
 	}
+	// the names with the prefix are next to each other, from the prefix on
+	if startDentry.Name < req.Prefix {
+		startDentry.Name = req.Prefix
+	}
 	endDentry := &Dentry{
 		ParentId: req.ParentID + 1,
 	}
+	if end, ok := prefixRangeEnd(req.Prefix); ok {
+		endDentry = &Dentry{ParentId: req.ParentID, Name: end}
+	}
 	mp.dentryTree.AscendRange(startDentry, endDentry, func(i BtreeItem) bool {
 		if !proto.IsDir(i.(*Dentry).Type) && (req.VerOpt&uint8(proto.FlagsSnapshotDel) > 0) {
 			if req.VerOpt&uint8(proto.FlagsSnapshotDelDir) > 0 {
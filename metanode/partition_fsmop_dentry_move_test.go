@@ -0,0 +1,216 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFsmBatchMoveDentry(t *testing.T) {
+	mp := newMetaPartition(20001, &metadataManager{})
+	createInode := func(ino uint64, mode uint32) *Inode {
+		inode := NewInode(ino, mode)
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(inode))
+		return inode
+	}
+	createDentry := func(parent, ino uint64, name string, mode uint32) {
+		d := &Dentry{ParentId: parent, Name: name, Inode: ino, Type: mode, multiSnap: NewDentrySnap(0)}
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateDentry(d, false))
+	}
+
+	src := createInode(1001, DirModeType)
+	dst := createInode(1002, DirModeType)
+	createInode(1003, FileModeType)
+	createInode(1004, FileModeType)
+	createInode(1005, FileModeType)
+	createDentry(src.Inode, 1003, "a", FileModeType)
+	createDentry(src.Inode, 1004, "b", FileModeType)
+	createDentry(dst.Inode, 1005, "b", FileModeType)
+	createDentry(src.Inode, dst.Inode, "dst", DirModeType)
+	srcNLink, dstNLink := src.NLink, dst.NLink
+
+	resp := mp.fsmBatchMoveDentry(&proto.BatchMoveDentryRequest{
+		SrcParentID: src.Inode,
+		DstParentID: dst.Inode,
+		Items: []proto.MoveDentryItem{
+			{SrcName: "a", DstName: "a1"},
+			{SrcName: "b", DstName: "b"},
+			{SrcName: "c", DstName: "c"},
+			{SrcName: "dst", DstName: "dst"},
+		},
+	})
+	require.Len(t, resp.Results, 4)
+	require.Equal(t, uint8(proto.OpOk), resp.Results[0].Status)
+	require.Equal(t, uint64(1003), resp.Results[0].Inode)
+	require.Equal(t, uint8(proto.OpExistErr), resp.Results[1].Status)
+	require.Equal(t, uint8(proto.OpNotExistErr), resp.Results[2].Status)
+	require.Equal(t, uint8(proto.OpArgMismatchErr), resp.Results[3].Status)
+
+	require.Nil(t, mp.dentryTree.Get(&Dentry{ParentId: src.Inode, Name: "a"}))
+	moved := mp.dentryTree.Get(&Dentry{ParentId: dst.Inode, Name: "a1"})
+	require.NotNil(t, moved)
+	require.Equal(t, uint64(1003), moved.(*Dentry).Inode)
+	require.NotNil(t, mp.dentryTree.Get(&Dentry{ParentId: src.Inode, Name: "b"}))
+
+	src = mp.inodeTree.Get(NewInode(src.Inode, 0)).(*Inode)
+	dst = mp.inodeTree.Get(NewInode(dst.Inode, 0)).(*Inode)
+	require.Equal(t, srcNLink-1, src.NLink)
+	require.Equal(t, dstNLink+1, dst.NLink)
+}
+
+func TestFsmBatchMoveDentryDirCycle(t *testing.T) {
+	mp := newMetaPartition(20002, &metadataManager{})
+	for _, ino := range []uint64{1001, 1006, 1007} {
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(ino, DirModeType)))
+	}
+	createDentry := func(parent, ino uint64, name string) {
+		d := &Dentry{ParentId: parent, Name: name, Inode: ino, Type: DirModeType, multiSnap: NewDentrySnap(0)}
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateDentry(d, false))
+	}
+	// 1001/x/y
+	createDentry(1001, 1006, "x")
+	createDentry(1006, 1007, "y")
+
+	// x under its own child y would leave x/y/x detached from the root
+	resp := mp.fsmBatchMoveDentry(&proto.BatchMoveDentryRequest{
+		SrcParentID: 1001,
+		DstParentID: 1007,
+		Items:       []proto.MoveDentryItem{{SrcName: "x", DstName: "x"}},
+	})
+	require.Equal(t, uint8(proto.OpNotPerm), resp.Results[0].Status)
+	require.NotNil(t, mp.dentryTree.Get(&Dentry{ParentId: 1001, Name: "x"}))
+	require.Nil(t, mp.dentryTree.Get(&Dentry{ParentId: 1007, Name: "x"}))
+
+	// a directory is still renamed within its parent
+	resp = mp.fsmBatchMoveDentry(&proto.BatchMoveDentryRequest{
+		SrcParentID: 1001,
+		DstParentID: 1001,
+		Items:       []proto.MoveDentryItem{{SrcName: "x", DstName: "x1"}},
+	})
+	require.Equal(t, uint8(proto.OpOk), resp.Results[0].Status)
+	require.NotNil(t, mp.dentryTree.Get(&Dentry{ParentId: 1001, Name: "x1"}))
+}
+
+func TestFsmBatchMoveDentrySealedShard(t *testing.T) {
+	mp := newMetaPartition(20003, &metadataManager{})
+	src := NewInode(1001, DirModeType)
+	shard := NewInode(1002, DirModeType)
+	for _, ino := range []*Inode{src, shard, NewInode(1003, FileModeType)} {
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(ino))
+	}
+	d := &Dentry{ParentId: src.Inode, Name: "a", Inode: 1003, Type: FileModeType, multiSnap: NewDentrySnap(0)}
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateDentry(d, false))
+	extend := NewExtend(shard.Inode)
+	extend.Put([]byte(proto.DirShardSealedKey), []byte("1"), 0)
+	require.NoError(t, mp.fsmSetXAttr(extend))
+
+	resp := mp.fsmBatchMoveDentry(&proto.BatchMoveDentryRequest{
+		SrcParentID: src.Inode,
+		DstParentID: shard.Inode,
+		Items:       []proto.MoveDentryItem{{SrcName: "a", DstName: "a"}},
+	})
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, uint8(proto.OpNotExistErr), resp.Results[0].Status)
+	require.NotNil(t, mp.dentryTree.Get(&Dentry{ParentId: src.Inode, Name: "a"}))
+	require.Nil(t, mp.dentryTree.Get(&Dentry{ParentId: shard.Inode, Name: "a"}))
+}
+
+func TestFsmBatchMoveDentryWithSnapshot(t *testing.T) {
+	mp := newMetaPartition(20004, &metadataManager{})
+	mp.multiVersionList = &proto.VolVersionInfoList{}
+	newVer := func(ver uint64) {
+		mp.multiVersionList.VerList = append(mp.multiVersionList.VerList,
+			&proto.VolVersionInfo{Ver: ver, Status: proto.VersionNormal})
+		mp.verSeq = ver
+	}
+	readDir := func(parent, verSeq uint64) (names []string) {
+		resp := mp.readDirLimit(&ReadDirLimitReq{ParentID: parent, Limit: math.MaxUint64, VerSeq: verSeq})
+		for _, d := range resp.Children {
+			names = append(names, d.Name)
+		}
+		return
+	}
+
+	newVer(10)
+	src := NewInode(1001, DirModeType)
+	dst := NewInode(1002, DirModeType)
+	for _, ino := range []*Inode{src, dst, NewInode(1003, FileModeType)} {
+		ino.setVer(mp.verSeq)
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(ino))
+	}
+	d := &Dentry{ParentId: src.Inode, Name: "a", Inode: 1003, Type: FileModeType, multiSnap: NewDentrySnap(mp.verSeq)}
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateDentry(d, false))
+	srcNLink, dstNLink := src.NLink, dst.NLink
+
+	// the snapshot 10 is taken, the file moves in the version 20
+	newVer(20)
+	resp := mp.fsmBatchMoveDentry(&proto.BatchMoveDentryRequest{
+		SrcParentID: src.Inode,
+		DstParentID: dst.Inode,
+		Items:       []proto.MoveDentryItem{{SrcName: "a", DstName: "b"}},
+	})
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, uint8(proto.OpOk), resp.Results[0].Status)
+
+	require.Empty(t, readDir(src.Inode, 0))
+	require.Equal(t, []string{"b"}, readDir(dst.Inode, 0))
+	// the snapshot still sees the file where it was
+	require.Equal(t, []string{"a"}, readDir(src.Inode, 10))
+	require.Empty(t, readDir(dst.Inode, 10))
+
+	src = mp.inodeTree.Get(NewInode(src.Inode, 0)).(*Inode)
+	dst = mp.inodeTree.Get(NewInode(dst.Inode, 0)).(*Inode)
+	require.Equal(t, srcNLink-1, src.NLink)
+	require.Equal(t, dstNLink+1, dst.NLink)
+
+	// the name freed in the current version can be moved back to
+	resp = mp.fsmBatchMoveDentry(&proto.BatchMoveDentryRequest{
+		SrcParentID: dst.Inode,
+		DstParentID: src.Inode,
+		Items:       []proto.MoveDentryItem{{SrcName: "b", DstName: "a"}},
+	})
+	require.Equal(t, uint8(proto.OpOk), resp.Results[0].Status)
+	require.Equal(t, []string{"a"}, readDir(src.Inode, 0))
+	require.Empty(t, readDir(dst.Inode, 0))
+}
+
+func TestBatchMoveDentryQuota(t *testing.T) {
+	mp := newMetaPartition(20005, &metadataManager{})
+	for _, ino := range []uint64{1001, 1002, 1003} {
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(NewInode(ino, DirModeType)))
+	}
+	mp.setInodeQuota([]uint32{1}, 1001)
+	mp.setInodeQuota([]uint32{2}, 1002)
+	mp.setInodeQuota([]uint32{1}, 1003)
+	require.True(t, mp.isSameQuota(1001, 1003))
+	require.False(t, mp.isSameQuota(1001, 1002))
+
+	req := &proto.BatchMoveDentryRequest{
+		SrcParentID: 1001,
+		DstParentID: 1002,
+		Items:       []proto.MoveDentryItem{{SrcName: "a", DstName: "a"}},
+	}
+	p := &Packet{}
+	require.Error(t, mp.BatchMoveDentry(req, p, ""))
+	require.Equal(t, proto.OpForbidErr, p.ResultCode, "the usage would be left in the old quota")
+
+	// a directory out of the quotas is not moved into one either
+	mp.setInodeQuota(nil, 1002)
+	require.False(t, mp.isSameQuota(1001, 1002))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFsmSetInlineData(t *testing.T) {
+	mp := newMetaPartition(20003, &metadataManager{})
+	file := NewInode(4001, FileModeType)
+	file.StorageClass = proto.StorageClass_Replica_HDD
+	file.HybridCloudExtents.sortedEks = NewSortedExtents()
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(file))
+	dir := NewInode(4002, uint32(0o40755))
+	require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(dir))
+
+	resp := mp.fsmSetInlineData(&proto.SetInlineDataRequest{Inode: dir.Inode, Data: []byte("x"), Size: 1})
+	require.Equal(t, uint8(proto.OpNotPerm), resp.Status)
+	resp = mp.fsmSetInlineData(&proto.SetInlineDataRequest{Inode: 4003, Data: []byte("x"), Size: 1})
+	require.Equal(t, uint8(proto.OpNotExistErr), resp.Status)
+
+	gen := file.Generation
+	data := []byte("key = value\n")
+	resp = mp.fsmSetInlineData(&proto.SetInlineDataRequest{Inode: file.Inode, Data: data, Size: 16, ModifyTime: 100})
+	require.Equal(t, uint8(proto.OpOk), resp.Status)
+	file = mp.inodeTree.Get(NewInode(file.Inode, 0)).(*Inode)
+	require.Equal(t, data, file.InlineData)
+	require.Equal(t, uint64(16), file.Size)
+	require.Equal(t, gen+1, file.Generation)
+	require.Equal(t, int64(100), file.ModifyTime)
+
+	// the inline data survives the persistence of the inode
+	restored := NewInode(0, 0)
+	raw, err := file.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, restored.Unmarshal(raw))
+	require.Equal(t, data, restored.InlineData)
+	require.NotZero(t, restored.Reserved&V4InlineDataFlag)
+	require.Equal(t, data, file.Copy().(*Inode).InlineData)
+
+	file.ExtentsTruncate(4, 200, nil)
+	require.Equal(t, []byte("key "), file.InlineData)
+	require.Equal(t, uint64(4), file.Size)
+
+	// the extents of the file replace its inline data
+	file.AppendExtents([]proto.ExtentKey{{FileOffset: 0, PartitionId: 1, ExtentId: 1, Size: 8192}}, 300, 0)
+	require.Nil(t, file.InlineData)
+	require.Equal(t, uint64(8192), file.Size)
+	resp = mp.fsmSetInlineData(&proto.SetInlineDataRequest{Inode: file.Inode, Data: data, Size: uint64(len(data))})
+	require.Equal(t, uint8(proto.OpNotPerm), resp.Status)
+
+	raw, err = file.Marshal()
+	require.NoError(t, err)
+	restored = NewInode(0, 0)
+	require.NoError(t, restored.Unmarshal(raw))
+	require.Nil(t, restored.InlineData)
+	require.Zero(t, restored.Reserved&V4InlineDataFlag)
+}
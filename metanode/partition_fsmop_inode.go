@@ -580,7 +580,7 @@ func (mp *metaPartition) fsmAppendExtentsWithCheck(ino *Inode, isSplit bool) (st
 		// conflict need delete eks[0], to clear garbage data
 		if status == proto.OpConflictExtentsErr {
 			log.LogWarnf("action[fsmAppendExtentsWithCheck] mp[%v] OpConflictExtentsErr [%v]", mp.config.PartitionId, eks[:1])
-			if !storage.IsTinyExtent(eks[0].ExtentId) && eks[0].ExtentOffset >= util.ExtentSize && clusterEnableSnapshot {
+			if !storage.IsTinyExtent(eks[0].ExtentId) && !proto.IsContainerExtent(eks[0].ExtentId) && eks[0].ExtentOffset >= util.ExtentSize && clusterEnableSnapshot {
 				eks[0].SetSplit(true)
 			}
 			mp.extDelCh <- eks[:1]
@@ -658,6 +658,7 @@ func (mp *metaPartition) fsmExtentsTruncate(ino *Inode) (resp *InodeResponse) {
 		return
 	}
 	i := item.(*Inode)
+	mp.recordChange(i)
 	if !proto.IsStorageClassReplica(i.StorageClass) {
 		log.LogWarnf("[fsmExtentsTruncate] mpId(%v) ino(%v) inoParamStorageClass(%v), but actual storageClass is %v, not allowed truncate. ",
 			mp.config.PartitionId, i.Inode, proto.StorageClassString(i.StorageClass), proto.StorageClassString(i.StorageClass))
@@ -1080,6 +1081,8 @@ func (mp *metaPartition) fsmUpdateExtentKeyAfterMigration(inoParam *Inode) (resp
 	// store new storage ek  in HybridCloudExtents
 	i.StorageClass = inoParam.HybridCloudExtentsMigration.storageClass
 	i.HybridCloudExtents.sortedEks = inoParam.HybridCloudExtentsMigration.sortedEks
+	// the migrated extents hold the inline data of a tiny file
+	i.InlineData = nil
 	// delete migration ek in future
 	i.Flag |= DeleteMigrationExtentKeyFlag
 	log.LogInfof("action[fsmUpdateExtentKeyAfterMigration] mp(%v) inode(%v) storage class change from %v to %v",
@@ -1235,3 +1238,93 @@ func (mp *metaPartition) fsmUpdateInodeMeta(req *UpdateInodeMetaRequest) (err er
 	i.ModifyTime = ino.ModifyTime
 	return
 }
+
+func (mp *metaPartition) fsmSwapExtents(req *proto.SwapExtentsRequest) (resp *InodeResponse) {
+	resp = NewInodeResponse()
+	resp.Status = proto.OpOk
+
+	item := mp.inodeTree.CopyGet(NewInode(req.Inode, 0))
+	tmpItem := mp.inodeTree.CopyGet(NewInode(req.TmpInode, 0))
+	if item == nil || tmpItem == nil {
+		log.LogWarnf("[fsmSwapExtents] mp(%v) ino(%v) tmpIno(%v) not exist", mp.config.PartitionId, req.Inode, req.TmpInode)
+		resp.Status = proto.OpNotExistErr
+		return
+	}
+	i, t := item.(*Inode), tmpItem.(*Inode)
+	if i.ShouldDelete() || t.ShouldDelete() {
+		resp.Status = proto.OpNotExistErr
+		return
+	}
+
+	first, second := i, t
+	if first.Inode > second.Inode {
+		first, second = second, first
+	}
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	if i.Generation != req.Generation {
+		log.LogWarnf("[fsmSwapExtents] mp(%v) ino(%v) modified during defrag, gen(%v) reqGen(%v)",
+			mp.config.PartitionId, i.Inode, i.Generation, req.Generation)
+		resp.Status = proto.OpConflictExtentsErr
+		return
+	}
+	if !proto.IsStorageClassReplica(i.StorageClass) || i.StorageClass != t.StorageClass ||
+		!i.HybridCloudExtentsMigration.Empty() || i.getLayerLen() > 0 || t.getLayerLen() > 0 {
+		log.LogWarnf("[fsmSwapExtents] mp(%v) ino(%v) storageClass(%v) tmpIno(%v) storageClass(%v) can not swap extents",
+			mp.config.PartitionId, i.Inode, i.StorageClass, t.Inode, t.StorageClass)
+		resp.Status = proto.OpNotPerm
+		return
+	}
+	if i.Size != t.Size || i.GetExtents().Size() != t.GetExtents().Size() {
+		log.LogWarnf("[fsmSwapExtents] mp(%v) ino(%v) size(%v) tmpIno(%v) size(%v) mismatch",
+			mp.config.PartitionId, i.Inode, i.Size, t.Inode, t.Size)
+		resp.Status = proto.OpArgMismatchErr
+		return
+	}
+
+	i.HybridCloudExtents.sortedEks, t.HybridCloudExtents.sortedEks = t.HybridCloudExtents.sortedEks, i.HybridCloudExtents.sortedEks
+	i.Generation++
+	t.Generation++
+	log.LogInfof("[fsmSwapExtents] mp(%v) ino(%v) swapped extents with tmpIno(%v), gen(%v)",
+		mp.config.PartitionId, i.Inode, t.Inode, i.Generation)
+	return
+}
+
+func (mp *metaPartition) fsmSetInlineData(req *proto.SetInlineDataRequest) (resp *InodeResponse) {
+	resp = NewInodeResponse()
+	resp.Status = proto.OpOk
+
+	item := mp.inodeTree.CopyGet(NewInode(req.Inode, 0))
+	if item == nil {
+		resp.Status = proto.OpNotExistErr
+		return
+	}
+	i := item.(*Inode)
+	if i.ShouldDelete() {
+		resp.Status = proto.OpNotExistErr
+		return
+	}
+
+	i.Lock()
+	defer i.Unlock()
+	if !proto.IsRegular(i.Type) || !proto.IsStorageClassReplica(i.StorageClass) || i.getLayerLen() > 0 ||
+		i.GetExtents().Len() > 0 || !i.HybridCloudExtentsMigration.Empty() {
+		log.LogWarnf("[fsmSetInlineData] mp(%v) ino(%v) storageClass(%v) has extents, can not set inline data",
+			mp.config.PartitionId, i.Inode, i.StorageClass)
+		resp.Status = proto.OpNotPerm
+		return
+	}
+
+	i.InlineData = req.Data
+	i.Size = req.Size
+	i.Generation++
+	i.ModifyTime = req.ModifyTime
+	if log.EnableDebug() {
+		log.LogDebugf("[fsmSetInlineData] mp(%v) ino(%v) len(%v) size(%v) gen(%v)",
+			mp.config.PartitionId, i.Inode, len(i.InlineData), i.Size, i.Generation)
+	}
+	return
+}
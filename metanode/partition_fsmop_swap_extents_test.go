@@ -0,0 +1,72 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFsmSwapExtents(t *testing.T) {
+	mp := newMetaPartition(20002, &metadataManager{})
+	createFile := func(ino uint64, extents int, extentSize uint32) *Inode {
+		inode := NewInode(ino, FileModeType)
+		inode.StorageClass = proto.StorageClass_Replica_HDD
+		se := NewSortedExtents()
+		for i := 0; i < extents; i++ {
+			se.eks = append(se.eks, proto.ExtentKey{
+				FileOffset:  uint64(i) * uint64(extentSize),
+				PartitionId: 1,
+				ExtentId:    uint64(ino*1000) + uint64(i),
+				Size:        extentSize,
+			})
+		}
+		inode.HybridCloudExtents.sortedEks = se
+		inode.Size = uint64(extents) * uint64(extentSize)
+		require.Equal(t, uint8(proto.OpOk), mp.fsmCreateInode(inode))
+		return inode
+	}
+
+	file := createFile(3001, 8, 4096)
+	tmp := createFile(3002, 1, 8*4096)
+	createFile(3003, 2, 4096)
+
+	report := mp.GetFragmentedInodes(2, 0)
+	require.Equal(t, uint64(3), report.Scanned)
+	require.Len(t, report.Inodes, 2)
+	require.Equal(t, file.Inode, report.Inodes[0].Inode)
+	require.Equal(t, 8, report.Inodes[0].ExtentCount)
+	require.Len(t, mp.GetFragmentedInodes(2, 1).Inodes, 1)
+
+	gen := file.Generation
+	resp := mp.fsmSwapExtents(&proto.SwapExtentsRequest{Inode: file.Inode, TmpInode: tmp.Inode, Generation: gen + 1})
+	require.Equal(t, uint8(proto.OpConflictExtentsErr), resp.Status)
+
+	resp = mp.fsmSwapExtents(&proto.SwapExtentsRequest{Inode: file.Inode, TmpInode: 3003, Generation: gen})
+	require.Equal(t, uint8(proto.OpArgMismatchErr), resp.Status)
+
+	resp = mp.fsmSwapExtents(&proto.SwapExtentsRequest{Inode: file.Inode, TmpInode: tmp.Inode, Generation: gen})
+	require.Equal(t, uint8(proto.OpOk), resp.Status)
+
+	file = mp.inodeTree.Get(NewInode(file.Inode, 0)).(*Inode)
+	tmp = mp.inodeTree.Get(NewInode(tmp.Inode, 0)).(*Inode)
+	require.Equal(t, 1, file.GetExtents().Len())
+	require.Equal(t, 8, tmp.GetExtents().Len())
+	require.Equal(t, gen+1, file.Generation)
+	require.Equal(t, uint64(8*4096), file.Size)
+	require.Len(t, mp.GetFragmentedInodes(3, 0).Inodes, 1)
+}
@@ -43,6 +43,9 @@ func (mp *metaPartition) TxCreateDentry(req *proto.TxCreateDentryRequest, p *Pac
 		p.PacketErrorWithBody(proto.OpExistErr, []byte(err.Error()))
 		return
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 
 	for _, quotaId := range req.QuotaIds {
 		status := mp.mqMgr.IsOverQuota(false, true, quotaId)
@@ -100,6 +103,9 @@ func (mp *metaPartition) CreateDentry(req *CreateDentryReq, p *Packet, remoteAdd
 		p.PacketErrorWithBody(proto.OpExistErr, []byte(err.Error()))
 		return
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 
 	item := mp.inodeTree.CopyGet(NewInode(req.ParentID, 0))
 	if item == nil {
@@ -148,6 +154,9 @@ func (mp *metaPartition) QuotaCreateDentry(req *proto.QuotaCreateDentryRequest,
 		p.PacketErrorWithBody(proto.OpExistErr, []byte(err.Error()))
 		return
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	for _, quotaId := range req.QuotaIds {
 		status := mp.mqMgr.IsOverQuota(false, true, quotaId)
 		if status != 0 {
@@ -204,6 +213,9 @@ func (mp *metaPartition) TxDeleteDentry(req *proto.TxDeleteDentryRequest, p *Pac
 			auditlog.LogDentryOp(remoteAddr, mp.GetVolName(), opMsg, req.Name, req.GetFullPath(), err, time.Since(start).Milliseconds(), req.Ino, req.ParentID)
 		}()
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	txInfo := req.TxInfo.GetCopy()
 	den := &Dentry{
 		ParentId: req.ParentID,
@@ -285,6 +297,9 @@ func (mp *metaPartition) DeleteDentry(req *DeleteDentryReq, p *Packet, remoteAdd
 			auditlog.LogDentryOp(remoteAddr, mp.GetVolName(), p.GetOpMsg(), req.Name, req.GetFullPath(), err, time.Since(start).Milliseconds(), dentry.Inode, req.ParentID)
 		}()
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	if req.InodeCreateTime > 0 {
 		if mp.vol.volDeleteLockTime > 0 && req.InodeCreateTime+mp.vol.volDeleteLockTime*60*60 > time.Now().Unix() {
 			err = errors.NewErrorf("the current Inode[%v] is still locked for deletion", req.Name)
@@ -400,6 +415,76 @@ func (mp *metaPartition) DeleteDentryBatch(req *BatchDeleteDentryReq, p *Packet,
 	return
 }
 
+// BatchMoveDentry re-parents a batch of dentries between two directories of
+// the partition with a single raft proposal, so rotating a directory with many
+// entries no longer costs one rename round trip per entry.
+func (mp *metaPartition) BatchMoveDentry(req *proto.BatchMoveDentryRequest, p *Packet, remoteAddr string) (err error) {
+	start := time.Now()
+	if mp.IsEnableAuditLog() {
+		defer func() {
+			auditlog.LogDentryOp(remoteAddr, mp.GetVolName(), p.GetOpMsg(), fmt.Sprintf("items(%v)", len(req.Items)), "",
+				err, time.Since(start).Milliseconds(), req.DstParentID, req.SrcParentID)
+		}()
+	}
+	if len(req.Items) == 0 || len(req.Items) > proto.MaxBatchMoveDentryCount {
+		err = fmt.Errorf("invalid batch size %v", len(req.Items))
+		p.PacketErrorWithBody(proto.OpArgMismatchErr, []byte(err.Error()))
+		return
+	}
+	if mp.GetVerSeq() > 0 {
+		err = fmt.Errorf("batch move is not supported when snapshot is enabled")
+		p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+		return
+	}
+	if mp.replyIfDirSharded(req.SrcParentID, p) || mp.replyIfDirSharded(req.DstParentID, p) {
+		return
+	}
+	for _, parentID := range []uint64{req.SrcParentID, req.DstParentID} {
+		item := mp.inodeTree.Get(NewInode(parentID, 0))
+		if item == nil || item.(*Inode).ShouldDelete() {
+			err = fmt.Errorf("parent inode %v not exists", parentID)
+			p.PacketErrorWithBody(proto.OpNotExistErr, []byte(err.Error()))
+			return
+		}
+		if !proto.IsDir(item.(*Inode).Type) {
+			err = fmt.Errorf("parent inode %v is not a directory", parentID)
+			p.PacketErrorWithBody(proto.OpArgMismatchErr, []byte(err.Error()))
+			return
+		}
+		if parentID == req.DstParentID && req.SrcParentID != req.DstParentID {
+			quota := atomic.LoadUint32(&dirChildrenNumLimit)
+			if item.(*Inode).NLink+uint32(len(req.Items)) > quota {
+				err = fmt.Errorf("parent dir quota limitation reached")
+				p.PacketErrorWithBody(proto.OpDirQuota, []byte(err.Error()))
+				return
+			}
+		}
+	}
+	if req.SrcParentID != req.DstParentID && !mp.isSameQuota(req.SrcParentID, req.DstParentID) {
+		err = fmt.Errorf("parent inodes %v and %v are under different quotas", req.SrcParentID, req.DstParentID)
+		p.PacketErrorWithBody(proto.OpForbidErr, []byte(err.Error()))
+		return
+	}
+
+	val, err := json.Marshal(req)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
+		return
+	}
+	r, err := mp.submit(opFSMBatchMoveDentry, val)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpAgain, []byte(err.Error()))
+		return
+	}
+	reply, err := json.Marshal(r.(*proto.BatchMoveDentryResponse))
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
+		return
+	}
+	p.PacketOkWithBody(reply)
+	return
+}
+
 func (mp *metaPartition) TxUpdateDentry(req *proto.TxUpdateDentryRequest, p *Packet, remoteAddr string) (err error) {
 	start := time.Now()
 	if mp.IsEnableAuditLog() {
@@ -416,6 +501,9 @@ func (mp *metaPartition) TxUpdateDentry(req *proto.TxUpdateDentryRequest, p *Pac
 		p.PacketErrorWithBody(proto.OpExistErr, []byte(err.Error()))
 		return
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 
 	txInfo := req.TxInfo.GetCopy()
 
@@ -487,6 +575,9 @@ func (mp *metaPartition) UpdateDentry(req *UpdateDentryReq, p *Packet, remoteAdd
 		p.PacketErrorWithBody(proto.OpExistErr, []byte(err.Error()))
 		return
 	}
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 
 	dentry := &Dentry{
 		ParentId: req.ParentID,
@@ -518,6 +609,9 @@ func (mp *metaPartition) UpdateDentry(req *UpdateDentryReq, p *Packet, remoteAdd
 }
 
 func (mp *metaPartition) ReadDirOnly(req *ReadDirOnlyReq, p *Packet) (err error) {
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	resp := mp.readDirOnly(req)
 	reply, err := json.Marshal(resp)
 	if err != nil {
@@ -530,6 +624,9 @@ func (mp *metaPartition) ReadDirOnly(req *ReadDirOnlyReq, p *Packet) (err error)
 
 // ReadDir reads the directory based on the given request.
 func (mp *metaPartition) ReadDir(req *ReadDirReq, p *Packet) (err error) {
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	resp := mp.readDir(req)
 	reply, err := json.Marshal(resp)
 	if err != nil {
@@ -542,6 +639,9 @@ func (mp *metaPartition) ReadDir(req *ReadDirReq, p *Packet) (err error) {
 
 func (mp *metaPartition) ReadDirLimit(req *ReadDirLimitReq, p *Packet) (err error) {
 	log.LogInfof("action[ReadDirLimit] read seq [%v], request[%v]", req.VerSeq, req)
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	resp := mp.readDirLimit(req)
 	reply, err := json.Marshal(resp)
 	if err != nil {
@@ -554,6 +654,9 @@ func (mp *metaPartition) ReadDirLimit(req *ReadDirLimitReq, p *Packet) (err erro
 
 // Lookup looks up the given dentry from the request.
 func (mp *metaPartition) Lookup(req *LookupReq, p *Packet) (err error) {
+	if mp.replyIfDirSharded(req.ParentID, p) {
+		return
+	}
 	dentry := &Dentry{
 		ParentId: req.ParentID,
 		Name:     req.Name,
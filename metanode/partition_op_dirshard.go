@@ -0,0 +1,113 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"fmt"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// isDirSharded reports whether the directory owns a shard layout. The dentries
+// of a sharded directory live under its shard inodes, so dentry operations
+// addressed to the directory itself are rejected with OpDirShardedErr and the
+// client is expected to reload the layout and route by name hash.
+func (mp *metaPartition) isDirSharded(ino uint64) bool {
+	return mp.hasXAttr(ino, proto.DirShardLayoutKey)
+}
+
+// isDirShardSealed reports whether the shard is sealed by the rmdir of its
+// directory, no dentry is created under it any more.
+func (mp *metaPartition) isDirShardSealed(ino uint64) bool {
+	return mp.hasXAttr(ino, proto.DirShardSealedKey)
+}
+
+func (mp *metaPartition) hasXAttr(ino uint64, key string) bool {
+	item := mp.extendTree.Get(NewExtend(ino))
+	if item == nil {
+		return false
+	}
+	_, ok := item.(*Extend).Get([]byte(key))
+	return ok
+}
+
+func (mp *metaPartition) replyIfDirSharded(parentID uint64, p *Packet) bool {
+	if !mp.isDirSharded(parentID) {
+		return false
+	}
+	log.LogDebugf("action[replyIfDirSharded] mp(%v) parent(%v) is sharded, op(%v)",
+		mp.config.PartitionId, parentID, p.GetOpMsg())
+	p.PacketErrorWithBody(proto.OpDirShardedErr, []byte(fmt.Sprintf("dir %v is sharded", parentID)))
+	return true
+}
+
+func (mp *metaPartition) hasChildDentry(ino uint64) (found bool) {
+	mp.dentryTree.AscendGreaterOrEqual(&Dentry{ParentId: ino}, func(i BtreeItem) bool {
+		d := i.(*Dentry)
+		if d.ParentId != ino {
+			return false
+		}
+		if d.isDeleted() {
+			return true
+		}
+		found = true
+		return false
+	})
+	return
+}
+
+// checkDirShardLayout validates a shard layout before it is attached to a
+// directory. Only an empty directory can be sharded, and a layout can never be
+// replaced, because the dentries already placed by the old hash would be lost.
+func (mp *metaPartition) checkDirShardLayout(ino uint64, value string) (status uint8, err error) {
+	layout, err := proto.UnmarshalDirShardLayout([]byte(value))
+	if err != nil {
+		return proto.OpArgMismatchErr, err
+	}
+	if layout.ParentIno != ino {
+		return proto.OpArgMismatchErr, fmt.Errorf("layout parent %v mismatch inode %v", layout.ParentIno, ino)
+	}
+	item := mp.inodeTree.Get(NewInode(ino, 0))
+	if item == nil || item.(*Inode).ShouldDelete() {
+		return proto.OpNotExistErr, fmt.Errorf("inode %v not exist", ino)
+	}
+	if !proto.IsDir(item.(*Inode).Type) {
+		return proto.OpArgMismatchErr, fmt.Errorf("inode %v is not a directory", ino)
+	}
+	if mp.isDirSharded(ino) {
+		return proto.OpExistErr, fmt.Errorf("dir %v is already sharded", ino)
+	}
+	if mp.hasChildDentry(ino) {
+		return proto.OpNotEmpty, fmt.Errorf("dir %v is not empty", ino)
+	}
+	return proto.OpOk, nil
+}
+
+// fsmCheckDirShardLayout checks a layout again when it is applied, a dentry
+// may be created under the directory after the leader checked it, and would
+// be hidden by the layout.
+func (mp *metaPartition) fsmCheckDirShardLayout(extend *Extend) uint8 {
+	value, ok := extend.Get([]byte(proto.DirShardLayoutKey))
+	if !ok {
+		return proto.OpOk
+	}
+	status, err := mp.checkDirShardLayout(extend.GetInode(), string(value))
+	if err != nil {
+		log.LogWarnf("action[fsmCheckDirShardLayout] mp(%v) reject layout of inode(%v): %v",
+			mp.config.PartitionId, extend.GetInode(), err)
+	}
+	return status
+}
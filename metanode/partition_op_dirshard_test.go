@@ -0,0 +1,138 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cubefs/cubefs/proto"
+)
+
+func TestDirShardLayoutCheck(t *testing.T) {
+	initMp(t)
+	dir := testCreateInode(t, DirModeType)
+	file := testCreateInode(t, FileModeType)
+
+	layout := proto.NewDirShardLayout(dir.Inode, []uint64{dir.Inode + 100, dir.Inode + 101})
+	value, err := layout.Marshal()
+	require.NoError(t, err)
+
+	status, err := mp.checkDirShardLayout(file.Inode, string(value))
+	require.Error(t, err)
+	require.Equal(t, proto.OpArgMismatchErr, status)
+
+	testCreateDentry(t, dir.Inode, file.Inode, "f1", FileModeType)
+	status, err = mp.checkDirShardLayout(dir.Inode, string(value))
+	require.Error(t, err)
+	require.Equal(t, proto.OpNotEmpty, status)
+
+	empty := testCreateInode(t, DirModeType)
+	layout.ParentIno = empty.Inode
+	value, err = layout.Marshal()
+	require.NoError(t, err)
+	status, err = mp.checkDirShardLayout(empty.Inode, string(value))
+	require.NoError(t, err)
+	require.Equal(t, proto.OpOk, status)
+
+	extend := NewExtend(empty.Inode)
+	extend.Put([]byte(proto.DirShardLayoutKey), value, mp.verSeq)
+	require.NoError(t, mp.fsmSetXAttr(extend))
+	require.True(t, mp.isDirSharded(empty.Inode))
+	require.False(t, mp.isDirSharded(dir.Inode))
+
+	status, _ = mp.checkDirShardLayout(empty.Inode, string(value))
+	require.Equal(t, proto.OpExistErr, status)
+
+	p := &Packet{}
+	require.NoError(t, mp.Lookup(&LookupReq{ParentID: empty.Inode, Name: "f1"}, p))
+	require.Equal(t, proto.OpDirShardedErr, p.ResultCode)
+
+	p = &Packet{}
+	require.Error(t, mp.RemoveXAttr(&proto.RemoveXAttrRequest{Inode: empty.Inode, Key: proto.DirShardLayoutKey}, p))
+	require.Equal(t, proto.OpNotPerm, p.ResultCode)
+}
+
+func TestDirShardLayoutApplyCheck(t *testing.T) {
+	initMp(t)
+	dir := testCreateInode(t, DirModeType)
+	file := testCreateInode(t, FileModeType)
+	value, err := proto.NewDirShardLayout(dir.Inode, []uint64{dir.Inode + 100, dir.Inode + 101}).Marshal()
+	require.NoError(t, err)
+	status, err := mp.checkDirShardLayout(dir.Inode, string(value))
+	require.NoError(t, err)
+	require.Equal(t, proto.OpOk, status)
+
+	// a dentry created after the leader checked the layout
+	testCreateDentry(t, dir.Inode, file.Inode, "f1", FileModeType)
+	extend := NewExtend(dir.Inode)
+	extend.Put([]byte(proto.DirShardLayoutKey), value, mp.verSeq)
+	raw, err := extend.Bytes()
+	require.NoError(t, err)
+	cmd, err := NewMetaItem(opFSMSetXAttr, nil, raw).MarshalJson()
+	require.NoError(t, err)
+	resp, err := mp.Apply(cmd, mp.getApplyID()+1)
+	require.NoError(t, err)
+	require.Equal(t, proto.OpNotEmpty, resp.(*ExtendOpResult).Status)
+	require.False(t, mp.isDirSharded(dir.Inode))
+
+	// the other xattrs are applied as before
+	extend = NewExtend(dir.Inode)
+	extend.Put([]byte("user.k"), []byte("v"), mp.verSeq)
+	raw, err = extend.Bytes()
+	require.NoError(t, err)
+	cmd, err = NewMetaItem(opFSMSetXAttr, nil, raw).MarshalJson()
+	require.NoError(t, err)
+	resp, err = mp.Apply(cmd, mp.getApplyID()+1)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+}
+
+func TestDirShardSealed(t *testing.T) {
+	initMp(t)
+	shard := testCreateInode(t, DirModeType)
+	file := testCreateInode(t, FileModeType)
+
+	extend := NewExtend(shard.Inode)
+	extend.Put([]byte(proto.DirShardSealedKey), []byte("1"), mp.verSeq)
+	raw, err := extend.Bytes()
+	require.NoError(t, err)
+	cmd, err := NewMetaItem(opFSMSetXAttr, nil, raw).MarshalJson()
+	require.NoError(t, err)
+	_, err = mp.Apply(cmd, mp.getApplyID()+1)
+	require.NoError(t, err)
+	require.True(t, mp.isDirShardSealed(shard.Inode))
+
+	// an rmdir is checking the shard, nothing can be created under it
+	require.Equal(t, proto.OpNotExistErr, mp.fsmCreateDentry(&Dentry{
+		ParentId: shard.Inode, Name: "f1", Inode: file.Inode, Type: FileModeType,
+	}, false))
+	require.False(t, mp.hasChildDentry(shard.Inode))
+
+	// and can again once the rmdir failed and unsealed it
+	extend = NewExtend(shard.Inode)
+	extend.Put([]byte(proto.DirShardSealedKey), nil, mp.verSeq)
+	raw, err = extend.Bytes()
+	require.NoError(t, err)
+	cmd, err = NewMetaItem(opFSMRemoveXAttr, nil, raw).MarshalJson()
+	require.NoError(t, err)
+	_, err = mp.Apply(cmd, mp.getApplyID()+1)
+	require.NoError(t, err)
+	require.False(t, mp.isDirShardSealed(shard.Inode))
+	require.Equal(t, proto.OpOk, mp.fsmCreateDentry(&Dentry{
+		ParentId: shard.Inode, Name: "f1", Inode: file.Inode, Type: FileModeType,
+	}, false))
+}
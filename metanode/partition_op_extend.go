@@ -16,6 +16,7 @@ package metanode
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
@@ -29,26 +30,50 @@ func (mp *metaPartition) UpdateXAttr(req *proto.UpdateXAttrRequest, p *Packet) (
 }
 
 func (mp *metaPartition) SetXAttr(req *proto.SetXAttrRequest, p *Packet) (err error) {
+	if req.Key == proto.DirShardLayoutKey {
+		var status uint8
+		if status, err = mp.checkDirShardLayout(req.Inode, req.Value); err != nil {
+			p.PacketErrorWithBody(status, []byte(err.Error()))
+			return
+		}
+	}
 	extend := NewExtend(req.Inode)
 	extend.Put([]byte(req.Key), []byte(req.Value), mp.verSeq)
-	if _, err = mp.putExtend(opFSMSetXAttr, extend); err != nil {
+	var resp interface{}
+	if resp, err = mp.putExtend(opFSMSetXAttr, extend); err != nil {
 		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
 		return
 	}
+	if r, ok := resp.(*ExtendOpResult); ok && r.Status != proto.OpOk {
+		p.PacketErrorWithBody(r.Status, []byte(fmt.Sprintf("set xattr of inode %v rejected", req.Inode)))
+		return
+	}
 	p.PacketOkReply()
 	return
 }
 
 func (mp *metaPartition) BatchSetXAttr(req *proto.BatchSetXAttrRequest, p *Packet) (err error) {
+	if value, ok := req.Attrs[proto.DirShardLayoutKey]; ok {
+		var status uint8
+		if status, err = mp.checkDirShardLayout(req.Inode, value); err != nil {
+			p.PacketErrorWithBody(status, []byte(err.Error()))
+			return
+		}
+	}
 	extend := NewExtend(req.Inode)
 	for key, val := range req.Attrs {
 		extend.Put([]byte(key), []byte(val), mp.verSeq)
 	}
 
-	if _, err = mp.putExtend(opFSMSetXAttr, extend); err != nil {
+	var resp interface{}
+	if resp, err = mp.putExtend(opFSMSetXAttr, extend); err != nil {
 		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
 		return
 	}
+	if r, ok := resp.(*ExtendOpResult); ok && r.Status != proto.OpOk {
+		p.PacketErrorWithBody(r.Status, []byte(fmt.Sprintf("set xattrs of inode %v rejected", req.Inode)))
+		return
+	}
 	p.PacketOkReply()
 	return
 }
@@ -138,6 +163,11 @@ func (mp *metaPartition) BatchGetXAttr(req *proto.BatchGetXAttrRequest, p *Packe
 }
 
 func (mp *metaPartition) RemoveXAttr(req *proto.RemoveXAttrRequest, p *Packet) (err error) {
+	if req.Key == proto.DirShardLayoutKey {
+		err = fmt.Errorf("dir shard layout of inode %v can not be removed", req.Inode)
+		p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+		return
+	}
 	extend := NewExtend(req.Inode)
 	extend.Put([]byte(req.Key), nil, req.VerSeq)
 	if _, err = mp.putExtend(opFSMRemoveXAttr, extend); err != nil {
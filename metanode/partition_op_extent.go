@@ -502,6 +502,7 @@ func (mp *metaPartition) ExtentsList(req *proto.GetExtentsRequest, p *Packet) (e
 						return true
 					})
 				}
+				resp.InlineData = ino.InlineData
 			})
 		}
 	}
@@ -902,3 +903,129 @@ func (mp *metaPartition) batchSyncInodeAtime() {
 		}
 	}
 }
+
+// SwapExtents replaces the extents of a file with the ones of a scratch inode
+// holding a defragmented copy. The scratch inode takes over the old extents
+// and is expected to be unlinked and evicted by the caller afterwards.
+func (mp *metaPartition) SwapExtents(req *proto.SwapExtentsRequest, p *Packet) (err error) {
+	if req.Inode == req.TmpInode {
+		err = fmt.Errorf("inode[%v] can not swap extents with itself", req.Inode)
+		p.PacketErrorWithBody(proto.OpArgMismatchErr, []byte(err.Error()))
+		return
+	}
+	if mp.GetVerSeq() != 0 {
+		err = fmt.Errorf("mp(%v) has snapshot enabled, swap extents is not supported", mp.config.PartitionId)
+		p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+		return
+	}
+	for _, ino := range []uint64{req.Inode, req.TmpInode} {
+		retMsg := mp.getInode(NewInode(ino, 0), false)
+		if retMsg.Status != proto.OpOk {
+			err = fmt.Errorf("inode[%v] is not exist", ino)
+			p.PacketErrorWithBody(retMsg.Status, []byte(err.Error()))
+			return
+		}
+		if !proto.IsRegular(retMsg.Msg.Type) || !proto.IsStorageClassReplica(retMsg.Msg.StorageClass) {
+			err = fmt.Errorf("inode[%v] type(%v) storageClass(%v) do not support swap extents",
+				ino, retMsg.Msg.Type, proto.StorageClassString(retMsg.Msg.StorageClass))
+			p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+			return
+		}
+	}
+
+	val, err := json.Marshal(req)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
+		return
+	}
+	resp, err := mp.submit(opFSMSwapExtents, val)
+	if err != nil {
+		log.LogErrorf("[SwapExtents] mpId(%v) ino(%v) submit fsm return err: %v",
+			mp.config.PartitionId, req.Inode, err)
+		p.PacketErrorWithBody(proto.OpAgain, []byte(err.Error()))
+		return
+	}
+	msg := resp.(*InodeResponse)
+	p.PacketErrorWithBody(msg.Status, nil)
+	return
+}
+
+// GetFragmentedInodes reports the regular files holding at least minExtents
+// extents, most fragmented first.
+func (mp *metaPartition) GetFragmentedInodes(minExtents, limit int) *proto.FragmentedInodesResponse {
+	resp := &proto.FragmentedInodesResponse{
+		PartitionID: mp.config.PartitionId,
+		Inodes:      make([]*proto.FragmentedInode, 0),
+	}
+	mp.GetInodeTree().Ascend(func(item BtreeItem) bool {
+		ino := item.(*Inode)
+		resp.Scanned++
+		if !proto.IsRegular(ino.Type) || !proto.IsStorageClassReplica(ino.StorageClass) || ino.ShouldDelete() {
+			return true
+		}
+		cnt := ino.GetExtents().Len()
+		if cnt < minExtents || cnt == 0 {
+			return true
+		}
+		resp.Inodes = append(resp.Inodes, &proto.FragmentedInode{
+			Inode:       ino.Inode,
+			Size:        ino.Size,
+			ExtentCount: cnt,
+			AvgExtent:   ino.Size / uint64(cnt),
+		})
+		return true
+	})
+	sort.Slice(resp.Inodes, func(i, j int) bool {
+		return resp.Inodes[i].ExtentCount > resp.Inodes[j].ExtentCount
+	})
+	if limit > 0 && len(resp.Inodes) > limit {
+		resp.Inodes = resp.Inodes[:limit]
+	}
+	return resp
+}
+
+// SetInlineData stores the whole content of a tiny file in its inode, which
+// is then read without any extent. It is refused once the file has extents,
+// the client moves the inline data to the extents as the file grows.
+func (mp *metaPartition) SetInlineData(req *proto.SetInlineDataRequest, p *Packet) (err error) {
+	if len(req.Data) > proto.MaxInlineDataThreshold || uint64(len(req.Data)) > req.Size {
+		err = fmt.Errorf("inode[%v] inline data len(%v) size(%v) exceed the limit(%v)",
+			req.Inode, len(req.Data), req.Size, proto.MaxInlineDataThreshold)
+		p.PacketErrorWithBody(proto.OpArgMismatchErr, []byte(err.Error()))
+		return
+	}
+	if mp.GetVerSeq() != 0 {
+		err = fmt.Errorf("mp(%v) has snapshot enabled, inline data is not supported", mp.config.PartitionId)
+		p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+		return
+	}
+	retMsg := mp.getInode(NewInode(req.Inode, 0), false)
+	if retMsg.Status != proto.OpOk {
+		err = fmt.Errorf("inode[%v] is not exist", req.Inode)
+		p.PacketErrorWithBody(retMsg.Status, []byte(err.Error()))
+		return
+	}
+	if !proto.IsRegular(retMsg.Msg.Type) || !proto.IsStorageClassReplica(retMsg.Msg.StorageClass) {
+		err = fmt.Errorf("inode[%v] type(%v) storageClass(%v) do not support inline data",
+			req.Inode, retMsg.Msg.Type, proto.StorageClassString(retMsg.Msg.StorageClass))
+		p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+		return
+	}
+
+	req.ModifyTime = timeutil.GetCurrentTimeUnix()
+	val, err := json.Marshal(req)
+	if err != nil {
+		p.PacketErrorWithBody(proto.OpErr, []byte(err.Error()))
+		return
+	}
+	resp, err := mp.submit(opFSMSetInlineData, val)
+	if err != nil {
+		log.LogErrorf("[SetInlineData] mpId(%v) ino(%v) submit fsm return err: %v",
+			mp.config.PartitionId, req.Inode, err)
+		p.PacketErrorWithBody(proto.OpAgain, []byte(err.Error()))
+		return
+	}
+	msg := resp.(*InodeResponse)
+	p.PacketErrorWithBody(msg.Status, nil)
+	return
+}
@@ -48,6 +48,7 @@ func replyInfoNoCheck(info *proto.InodeInfo, ino *Inode) bool {
 	info.AccessTime = time.Unix(ino.AccessTime, 0)
 	info.ModifyTime = time.Unix(ino.ModifyTime, 0)
 	info.StorageClass = ino.StorageClass
+	info.DirStorageClass = ino.getDirStorageClass()
 	info.MigrationStorageClass = ino.HybridCloudExtentsMigration.storageClass
 	info.LeaseExpireTime = ino.LeaseExpireTime
 	info.ForbiddenLc = ino.LeaseNotExpire()
@@ -77,6 +78,7 @@ func replyInfo(info *proto.InodeInfo, ino *Inode, quotaInfos map[uint32]*proto.M
 	info.ModifyTime = time.Unix(ino.ModifyTime, 0)
 	info.QuotaInfos = quotaInfos
 	info.StorageClass = ino.StorageClass
+	info.DirStorageClass = ino.getDirStorageClass()
 	info.LeaseExpireTime = ino.LeaseExpireTime
 	info.ForbiddenLc = ino.LeaseNotExpire()
 
@@ -105,6 +107,7 @@ func txReplyInfo(inode *Inode, txInfo *proto.TransactionInfo, quotaInfos map[uin
 		StorageClass:          inode.StorageClass,
 		LeaseExpireTime:       inode.LeaseExpireTime,
 		MigrationStorageClass: inode.HybridCloudExtentsMigration.storageClass,
+		DirStorageClass:       inode.getDirStorageClass(),
 	}
 
 	inoInfo.ForbiddenLc = inode.LeaseNotExpire()
@@ -187,6 +190,9 @@ func (mp *metaPartition) CreateInode(req *CreateInoReq, p *Packet, remoteAddr st
 	ino.setVer(mp.verSeq)
 	ino.LinkTarget = req.Target
 	ino.StorageClass = requiredStorageClass
+	if req.SetDirStorageClass && proto.IsDir(req.Mode) {
+		ino.Flag |= DirStorageClassFlag
+	}
 
 	if proto.IsStorageClassReplica(ino.StorageClass) {
 		ino.HybridCloudExtents.sortedEks = NewSortedExtents()
@@ -257,6 +263,9 @@ func (mp *metaPartition) QuotaCreateInode(req *proto.QuotaCreateInodeRequest, p
 	ino.Gid = req.Gid
 	ino.LinkTarget = req.Target
 	ino.StorageClass = requiredStorageClass
+	if req.SetDirStorageClass && proto.IsDir(req.Mode) {
+		ino.Flag |= DirStorageClassFlag
+	}
 
 	for _, quotaId := range req.QuotaIds {
 		status = mp.mqMgr.IsOverQuota(false, true, quotaId)
@@ -849,6 +858,21 @@ func (mp *metaPartition) EvictInodeBatch(req *BatchEvictInodeReq, p *Packet, rem
 
 // SetAttr set the inode attributes.
 func (mp *metaPartition) SetAttr(req *SetattrRequest, reqData []byte, p *Packet) (err error) {
+	if req.Valid&proto.AttrStorageClass != 0 {
+		item := mp.inodeTree.Get(NewInode(req.Inode, 0))
+		if item == nil {
+			p.PacketErrorWithBody(proto.OpNotExistErr, []byte(fmt.Sprintf("ino(%v) not exist", req.Inode)))
+			return
+		}
+		ino := item.(*Inode)
+		ino.RLock()
+		err = ino.checkSetStorageClass(req.StorageClass)
+		ino.RUnlock()
+		if err != nil {
+			p.PacketErrorWithBody(proto.OpNotPerm, []byte(err.Error()))
+			return
+		}
+	}
 	if mp.verSeq != 0 {
 		req.VerSeq = mp.GetVerSeq()
 		reqData, err = json.Marshal(req)
@@ -985,6 +1009,9 @@ func (mp *metaPartition) TxCreateInode(req *proto.TxCreateInodeRequest, p *Packe
 	txIno.Inode.Gid = req.Gid
 	txIno.Inode.LinkTarget = req.Target
 	txIno.Inode.StorageClass = requiredStorageClass
+	if req.SetDirStorageClass && proto.IsDir(req.Mode) {
+		txIno.Inode.Flag |= DirStorageClassFlag
+	}
 
 	if log.EnableDebug() {
 		log.LogDebugf("NewTxInode: TxInode: %v", txIno)
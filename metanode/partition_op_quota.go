@@ -170,6 +170,27 @@ func (mp *metaPartition) isExistQuota(ino uint64) (quotaIds []uint32, isFind boo
 	return
 }
 
+// isSameQuota reports whether the inodes are under the same quotas. The entries
+// moved between the directories of different quotas would leave their usage in
+// the old ones.
+func (mp *metaPartition) isSameQuota(ino1, ino2 uint64) bool {
+	quotaIds1, _ := mp.isExistQuota(ino1)
+	quotaIds2, _ := mp.isExistQuota(ino2)
+	if len(quotaIds1) != len(quotaIds2) {
+		return false
+	}
+	ids := make(map[uint32]struct{}, len(quotaIds1))
+	for _, id := range quotaIds1 {
+		ids[id] = struct{}{}
+	}
+	for _, id := range quotaIds2 {
+		if _, ok := ids[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (mp *metaPartition) isOverQuota(ino uint64, size bool, files bool) (status uint8) {
 	quotaIds, isFind := mp.isExistQuota(ino)
 	if isFind {
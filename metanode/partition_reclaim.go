@@ -0,0 +1,175 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	// the reclaim report walks the free list and reads the extent delete
+	// files, it is counted again after the interval only
+	reclaimStatInterval = time.Minute
+	// the extent delete files of a boosted volume are read at this interval
+	// instead of every minute
+	reclaimBoostDelExtentsInterval = 5 * time.Second
+)
+
+type reclaimStat struct {
+	sync.Mutex
+	stat       *proto.MetaPartitionReclaim
+	updateTime time.Time
+}
+
+// GetReclaim returns the space of the deleted files of the partition not
+// returned to the data nodes yet.
+func (mp *metaPartition) GetReclaim() *proto.MetaPartitionReclaim {
+	mp.reclaim.Lock()
+	defer mp.reclaim.Unlock()
+	if mp.reclaim.stat == nil || time.Since(mp.reclaim.updateTime) >= reclaimStatInterval {
+		stat := &proto.MetaPartitionReclaim{PartitionID: mp.config.PartitionId}
+		mp.countFreeListReclaim(stat)
+		mp.countDelExtentsReclaim(stat)
+		mp.reclaim.stat, mp.reclaim.updateTime = stat, time.Now()
+	}
+	stat := *mp.reclaim.stat
+	return &stat
+}
+
+func (mp *metaPartition) countFreeListReclaim(stat *proto.MetaPartitionReclaim) {
+	inoKey := NewInode(0, 0)
+	for _, ino := range mp.freeList.Inodes() {
+		inoKey.Inode = ino
+		inode, ok := mp.inodeTree.Get(inoKey).(*Inode)
+		if !ok {
+			continue
+		}
+		inode.RLock()
+		size := inode.Size
+		inode.RUnlock()
+		stat.FreeInodes++
+		stat.FreeBytes += size
+		if inode.ShouldDelayDelete() {
+			stat.DelayedInodes++
+			stat.DelayedBytes += size
+		}
+	}
+}
+
+// countDelExtentsReclaim counts the extent keys of the extent delete files
+// past their cursors.
+func (mp *metaPartition) countDelExtentsReclaim(stat *proto.MetaPartitionReclaim) {
+	finfos, err := os.ReadDir(mp.config.RootDir)
+	if err != nil {
+		log.LogWarnf("[countDelExtentsReclaim] mp(%v) read dir err(%v)", mp.config.PartitionId, err)
+		return
+	}
+	for _, finfo := range finfos {
+		if finfo.IsDir() || !strings.HasPrefix(finfo.Name(), prefixDelExtent) {
+			continue
+		}
+		data, err := readDelExtentsFile(path.Join(mp.config.RootDir, finfo.Name()))
+		if err != nil {
+			log.LogWarnf("[countDelExtentsReclaim] mp(%v) file(%v) err(%v)", mp.config.PartitionId, finfo.Name(), err)
+			continue
+		}
+		extentV2 := strings.HasPrefix(finfo.Name(), prefixDelExtentV2)
+		buff := bytes.NewBuffer(data)
+		for buff.Len() > 0 {
+			ek := proto.ExtentKey{}
+			if extentV2 {
+				if err = ek.UnmarshalBinaryWithCheckSum(buff); err != nil {
+					break
+				}
+			} else {
+				if buff.Len() < proto.ExtentLength {
+					break
+				}
+				tmpBuff := GetReadBuf(buff.Next(proto.ExtentLength))
+				err = ek.UnmarshalBinary(tmpBuff, false)
+				PutReadBuf(tmpBuff)
+				if err != nil {
+					break
+				}
+			}
+			stat.DelExtents++
+			stat.DelExtentBytes += uint64(ek.Size)
+		}
+	}
+}
+
+// readDelExtentsFile returns the part of an extent delete file past its cursor.
+func readDelExtentsFile(name string) (data []byte, err error) {
+	fp, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer fp.Close()
+	cursor := make([]byte, len(extentsFileHeader))
+	if _, err = io.ReadFull(fp, cursor); err != nil {
+		return
+	}
+	if _, err = fp.Seek(int64(binary.BigEndian.Uint64(cursor)), io.SeekStart); err != nil {
+		return
+	}
+	return io.ReadAll(fp)
+}
+
+// reclaimBoost returns the batch count of the deletions of the partition while
+// its volume is boosted by the master.
+func (mp *metaPartition) reclaimBoost() (batchCount uint64, boosted bool) {
+	if mp.manager == nil {
+		return
+	}
+	vols, _ := mp.manager.reclaimBoostVols.Load().(map[string]*proto.ReclaimBoost)
+	if boost := vols[mp.config.VolName]; boost.Active(time.Now()) {
+		return boost.BatchCount, true
+	}
+	return
+}
+
+func (mp *metaPartition) delExtentsInterval() time.Duration {
+	if _, boosted := mp.reclaimBoost(); boosted {
+		return reclaimBoostDelExtentsInterval
+	}
+	return time.Minute
+}
+
+// isForkSource reports whether the volume of the partition is forked by others,
+// whose files refer to the extents of the deleted files of the volume.
+// inMaintenance tells whether the meta node is in a maintenance, which defers
+// the deletions on the data nodes.
+func (mp *metaPartition) inMaintenance() bool {
+	return mp.manager != nil && mp.manager.maintenance.Load()
+}
+
+func (mp *metaPartition) isForkSource() bool {
+	if mp.manager == nil {
+		return false
+	}
+	vols, _ := mp.manager.forkSourceVols.Load().(map[string]struct{})
+	_, ok := vols[mp.config.VolName]
+	return ok
+}
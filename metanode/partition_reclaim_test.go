@@ -0,0 +1,132 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"encoding/binary"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaPartitionReclaim(t *testing.T) {
+	config := &MetaPartitionConfig{
+		PartitionId:   10001,
+		VolName:       VolNameForTest,
+		PartitionType: proto.VolumeTypeHot,
+		RootDir:       t.TempDir(),
+	}
+	manager := &metadataManager{partitions: make(map[uint64]MetaPartition), fileStatsConfig: &fileStatsConfig{}}
+	mp := newPartitionForFreeList(config, manager)
+
+	deleted := NewInode(1, proto.Mode(os.ModePerm))
+	deleted.Size = 100
+	deleted.Flag |= DeleteMarkFlag
+	unlinked := NewInode(2, proto.Mode(os.ModePerm))
+	unlinked.Size = 10
+	unlinked.NLink = 0
+	unlinked.AccessTime = timeutil.GetCurrentTimeUnix()
+	for _, ino := range []*Inode{deleted, unlinked} {
+		mp.inodeTree.ReplaceOrInsert(ino, true)
+		mp.freeList.Push(ino.Inode)
+	}
+	mp.freeList.Push(3) // gone
+
+	// the first key is deleted already
+	data := make([]byte, len(extentsFileHeader))
+	for i, size := range []uint32{1000, 2000, 3000} {
+		ek := proto.ExtentKey{PartitionId: 1, ExtentId: uint64(i + 1), Size: size}
+		key, err := ek.MarshalBinaryWithCheckSum(false)
+		require.NoError(t, err)
+		if i == 1 {
+			binary.BigEndian.PutUint64(data, uint64(len(data)))
+		}
+		data = append(data, key...)
+	}
+	require.NoError(t, os.WriteFile(path.Join(config.RootDir, prefixDelExtentV2+"_0"), data, 0o644))
+
+	stat := mp.GetReclaim()
+	require.Equal(t, &proto.MetaPartitionReclaim{
+		PartitionID:    config.PartitionId,
+		FreeInodes:     2,
+		FreeBytes:      110,
+		DelayedInodes:  1,
+		DelayedBytes:   10,
+		DelExtents:     2,
+		DelExtentBytes: 5000,
+	}, stat)
+	require.Equal(t, uint64(5110), stat.PendingBytes())
+
+	// counted once per interval
+	mp.freeList.Remove(deleted.Inode)
+	require.Equal(t, stat, mp.GetReclaim())
+	mp.reclaim.updateTime = time.Now().Add(-reclaimStatInterval)
+	require.Equal(t, uint64(1), mp.GetReclaim().FreeInodes)
+
+	_, boosted := mp.reclaimBoost()
+	require.False(t, boosted)
+	require.Equal(t, time.Minute, mp.delExtentsInterval())
+	manager.setReclaimBoostVols(map[string]*proto.ReclaimBoost{
+		VolNameForTest: {BatchCount: 1024, Until: time.Now().Add(time.Minute).Unix()},
+	})
+	batchCount, boosted := mp.reclaimBoost()
+	require.True(t, boosted)
+	require.Equal(t, uint64(1024), batchCount)
+	require.Equal(t, reclaimBoostDelExtentsInterval, mp.delExtentsInterval())
+	manager.setReclaimBoostVols(map[string]*proto.ReclaimBoost{
+		VolNameForTest: {BatchCount: 1024, Until: time.Now().Add(-time.Second).Unix()},
+	})
+	_, boosted = mp.reclaimBoost()
+	require.False(t, boosted)
+}
+
+func TestMetaPartitionFork(t *testing.T) {
+	config := &MetaPartitionConfig{
+		PartitionId:   10002,
+		VolName:       VolNameForTest,
+		PartitionType: proto.VolumeTypeHot,
+		RootDir:       t.TempDir(),
+	}
+	manager := &metadataManager{partitions: make(map[uint64]MetaPartition), fileStatsConfig: &fileStatsConfig{}}
+	mp := newPartitionForFreeList(config, manager)
+
+	dataView := proto.NewDataPartitionsView()
+	dataView.DataPartitions = append(dataView.DataPartitions, &proto.DataPartitionResponse{PartitionID: 1, Hosts: []string{"127.0.0.1:17310"}})
+	dataView.ForkPartitions = []*proto.DataPartitionResponse{
+		{PartitionID: 2, Hosts: []string{"127.0.0.2:17310"}},
+		{PartitionID: 3},
+	}
+	mp.UpdateVolumeView(dataView, &proto.SimpleVolView{Name: VolNameForTest, ForkSources: []string{"source"}})
+	require.False(t, mp.vol.GetPartition(1).Fork)
+	require.True(t, mp.vol.GetPartition(2).Fork)
+	require.Nil(t, mp.vol.GetPartition(3))
+
+	// the extents of the fork source are never deleted by the fork
+	extents := []*proto.DelExtentParam{{ExtentKey: &proto.ExtentKey{PartitionId: 2, ExtentId: 1025, Size: 100}}}
+	retry, err := mp.batchDeleteExtentsByDp(2, extents)
+	require.NoError(t, err)
+	require.Empty(t, retry)
+
+	require.False(t, mp.isForkSource())
+	manager.setForkSourceVols([]string{VolNameForTest})
+	require.True(t, mp.isForkSource())
+	manager.setForkSourceVols(nil)
+	require.False(t, mp.isForkSource())
+}
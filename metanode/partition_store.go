@@ -1246,6 +1246,48 @@ func (mp *metaPartition) storeInode(rootDir string,
 	return
 }
 
+// treeChecksum computes the crc of the inode and dentry trees the same way as they are
+// stored in snapshots, replicas at the same apply id are expected to get the same crc.
+func (mp *metaPartition) treeChecksum() (applyID uint64, inodeCrc, dentryCrc uint32) {
+	mp.nonIdempotent.Lock()
+	applyID = mp.getApplyID()
+	inodeTree := mp.inodeTree.GetTree()
+	dentryTree := mp.dentryTree.GetTree()
+	mp.nonIdempotent.Unlock()
+
+	lenBuf := make([]byte, 4)
+	sign := crc32.NewIEEE()
+	inodeTree.Ascend(func(i BtreeItem) bool {
+		buf := GetInodeBuf()
+		defer PutInodeBuf(buf)
+		if err := i.(*Inode).MarshalV2(buf); err != nil {
+			log.LogWarnf("treeChecksum: partitionID(%v) marshal inode err(%v)", mp.config.PartitionId, err)
+			return true
+		}
+		binary.BigEndian.PutUint32(lenBuf, uint32(buf.Len()))
+		sign.Write(lenBuf)
+		sign.Write(buf.Bytes())
+		return true
+	})
+	inodeCrc = sign.Sum32()
+
+	sign.Reset()
+	dentryTree.Ascend(func(i BtreeItem) bool {
+		buf := GetDentryBuf()
+		defer PutDentryBuf(buf)
+		if err := i.(*Dentry).MarshalV2(buf); err != nil {
+			log.LogWarnf("treeChecksum: partitionID(%v) marshal dentry err(%v)", mp.config.PartitionId, err)
+			return true
+		}
+		binary.BigEndian.PutUint32(lenBuf, uint32(buf.Len()))
+		sign.Write(lenBuf)
+		sign.Write(buf.Bytes())
+		return true
+	})
+	dentryCrc = sign.Sum32()
+	return
+}
+
 func (mp *metaPartition) storeDentry(rootDir string,
 	sm *storeMsg,
 ) (crc uint32, err error) {
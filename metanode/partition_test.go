@@ -350,3 +350,47 @@ func TestLimitReadDir(t *testing.T) {
 
 	require.True(t, costTime1 > costTime2)
 }
+
+func TestReadDirLimitPrefix(t *testing.T) {
+	mp := NewMetaPartitionForTest()
+	for i, name := range []string{"a", "b0", "b1", "b2", "ba", "c"} {
+		mp.dentryTree.ReplaceOrInsert(&Dentry{ParentId: 1, Name: name, Inode: uint64(10 + i), Type: uint32(os.ModeDir)}, true)
+	}
+	mp.dentryTree.ReplaceOrInsert(&Dentry{ParentId: 2, Name: "b3", Inode: 20, Type: uint32(os.ModeDir)}, true)
+	names := func(req *ReadDirLimitReq) (names []string) {
+		for _, child := range mp.readDirLimit(req).Children {
+			names = append(names, child.Name)
+		}
+		return
+	}
+
+	req := &ReadDirLimitReq{ParentID: 1, Prefix: "b", Limit: math.MaxUint64}
+	require.Equal(t, []string{"b0", "b1", "b2", "ba"}, names(req))
+	req.Marker = "b1"
+	require.Equal(t, []string{"b1", "b2", "ba"}, names(req))
+	req.Limit = 2
+	require.Equal(t, []string{"b1", "b2"}, names(req))
+	req.Marker, req.Prefix = "", "b2"
+	require.Equal(t, []string{"b2"}, names(req))
+	req.Prefix = "d"
+	require.Empty(t, names(req))
+	req.Prefix, req.Limit = "", 0
+	require.Equal(t, []string{"a", "b0", "b1", "b2", "ba", "c"}, names(req))
+
+	// the range ends right after the prefix, past the names of 0xff bytes
+	for i, name := range []string{"c\xff", "c\xff\xff", "c\xff\xff1", "d"} {
+		mp.dentryTree.ReplaceOrInsert(&Dentry{ParentId: 1, Name: name, Inode: uint64(30 + i)}, true)
+	}
+	req.Prefix = "c\xff"
+	require.Equal(t, []string{"c\xff", "c\xff\xff", "c\xff\xff1"}, names(req))
+	req.Prefix = "\xff"
+	require.Empty(t, names(req))
+
+	end, ok := prefixRangeEnd("b")
+	require.True(t, ok)
+	require.Equal(t, "c", end)
+	end, _ = prefixRangeEnd("a\xff\xff")
+	require.Equal(t, "b", end)
+	_, ok = prefixRangeEnd("\xff\xff")
+	require.False(t, ok)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	raftproto "github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
+	"github.com/cubefs/cubefs/proto"
+)
+
+// A witness replica votes in the raft group of the partition but keeps none of its
+// inodes, dentries and the like, so 2 data replicas and a witness tolerate one
+// failure at the memory cost of 2 replicas. It applies the log to follow the
+// applied index and the partition itself, and never becomes the leader.
+
+// IsWitness tells if the replica on this node is a witness.
+func (mp *metaPartition) IsWitness() bool {
+	for _, peer := range mp.config.Peers {
+		if peer.ID == mp.config.NodeId {
+			return peer.Witness
+		}
+	}
+	return false
+}
+
+// raftPeer is the raft peer of a replica, a witness is an arbiter of raft.
+func raftPeer(peer proto.Peer) raftproto.Peer {
+	rp := raftproto.Peer{ID: peer.ID}
+	if peer.Witness {
+		rp.Type = raftproto.PeerArbiter
+	}
+	return rp
+}
+
+// witnessApplies tells if a witness applies the op of the log, only those about
+// the partition itself are.
+func witnessApplies(op uint32) bool {
+	switch op {
+	case opFSMUpdatePartition, opFSMStoreTick, opFSMSyncCursor, opFSMSyncTxID, opFSMSetFreeze:
+		return true
+	default:
+		return false
+	}
+}
+
+// witnessLoadsSnap tells if a witness loads the item of a snapshot, the data are
+// skipped.
+func witnessLoadsSnap(op uint32) bool {
+	switch op {
+	case opFSMCreateInode, opFSMCreateDentry, opFSMSetXAttr, opFSMCreateMultipart, opFSMTxSnapshot,
+		opFSMTxRbInodeSnapshot, opFSMTxRbDentrySnapshot, opExtentFileSnapshot:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metanode
+
+import (
+	"encoding/binary"
+	"testing"
+
+	raftproto "github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWitnessApply(t *testing.T) {
+	mp := newMetaPartition(20004, &metadataManager{})
+	mp.config.NodeId = 2
+	mp.config.Peers = []proto.Peer{{ID: 1}, {ID: 2, Witness: true}}
+	require.True(t, mp.IsWitness())
+	require.Equal(t, raftproto.PeerArbiter, raftPeer(mp.config.Peers[1]).Type)
+	require.Equal(t, raftproto.PeerNormal, raftPeer(mp.config.Peers[0]).Type)
+
+	apply := func(op uint32, v []byte, index uint64) {
+		cmd, err := NewMetaItem(op, nil, v).MarshalJson()
+		require.NoError(t, err)
+		_, err = mp.Apply(cmd, index)
+		require.NoError(t, err)
+		require.Equal(t, index, mp.getApplyID())
+	}
+
+	// the witness follows the applied index and the cursor, but keeps no inode
+	ino := NewInode(1001, FileModeType)
+	raw, err := ino.Marshal()
+	require.NoError(t, err)
+	apply(opFSMCreateInode, raw, 1)
+	require.Zero(t, mp.inodeTree.Len())
+	cursor := make([]byte, 8)
+	binary.BigEndian.PutUint64(cursor, 1001)
+	apply(opFSMSyncCursor, cursor, 2)
+	require.Equal(t, uint64(1001), mp.config.Cursor)
+
+	// a data replica keeps the inode
+	mp.config.Peers[1].Witness = false
+	require.False(t, mp.IsWitness())
+	apply(opFSMCreateInode, raw, 3)
+	require.Equal(t, 1, mp.inodeTree.Len())
+}
@@ -82,6 +82,17 @@ func (m *MetaNode) serveConn(conn net.Conn, stopC chan uint8) {
 	c.SetKeepAlive(true)
 	c.SetNoDelay(true)
 	remoteAddr := conn.RemoteAddr().String()
+	sc, secure, err := util.AcceptTLSConn(conn, m.tlsConfig)
+	if err == nil && !secure {
+		sc, err = util.AcceptCompressConn(sc)
+	}
+	if err != nil {
+		if err != io.EOF {
+			log.LogWarnf("serve MetaNode: accept conn from %v: %v", remoteAddr, err)
+		}
+		return
+	}
+	conn = sc
 	for {
 		select {
 		case <-stopC:
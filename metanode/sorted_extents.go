@@ -141,13 +141,24 @@ func (se *SortedExtents) Append(ek proto.ExtentKey) (deleteExtents []proto.Exten
 	// check if ek and key are the same extent file with size extented
 	deleteExtents = make([]proto.ExtentKey, 0, len(invalidExtents))
 	for _, key := range invalidExtents {
-		if key.PartitionId != ek.PartitionId || key.ExtentId != ek.ExtentId {
+		if key.PartitionId != ek.PartitionId || key.ExtentId != ek.ExtentId || !sameContainerRecord(&key, &ek) {
 			deleteExtents = append(deleteExtents, key)
 		}
 	}
 	return
 }
 
+// sameContainerRecord tells whether the keys of the same extent are in the same
+// record, the records of a container are the files packed in it.
+func sameContainerRecord(key, ek *proto.ExtentKey) bool {
+	if !proto.IsContainerExtent(ek.ExtentId) {
+		return true
+	}
+	keyRecord, _ := proto.ParseContainerOffset(key.ExtentOffset)
+	ekRecord, _ := proto.ParseContainerOffset(ek.ExtentOffset)
+	return keyRecord == ekRecord
+}
+
 func storeEkSplit(mpId uint64, inodeID uint64, ekRef *sync.Map, ek *proto.ExtentKey) (id uint64) {
 	if !clusterEnableSnapshot {
 		return
@@ -82,6 +82,23 @@ func TestAppend04(t *testing.T) {
 	t.Logf("%v\n", se.Size())
 }
 
+// A file packed again into the same container takes another record of it.
+func TestAppendContainerRecord(t *testing.T) {
+	se := NewSortedExtents()
+	extentID := uint64(proto.ContainerExtentStartID)
+	delExtents := se.Append(proto.ExtentKey{FileOffset: 0, Size: 1000, PartitionId: 1, ExtentId: extentID,
+		ExtentOffset: proto.ContainerOffset(1, 0)})
+	if len(delExtents) != 0 {
+		t.Fail()
+	}
+	delExtents = se.Append(proto.ExtentKey{FileOffset: 0, Size: 2000, PartitionId: 1, ExtentId: extentID,
+		ExtentOffset: proto.ContainerOffset(2, 0)})
+	if len(delExtents) != 1 || delExtents[0].ExtentOffset != proto.ContainerOffset(1, 0) ||
+		len(se.eks) != 1 || se.Size() != 2000 {
+		t.Fail()
+	}
+}
+
 func TestTruncate01(t *testing.T) {
 	se := NewSortedExtents()
 	delExtents, _ := se.AppendWithCheck(0, proto.ExtentKey{FileOffset: 0, Size: 1000, ExtentId: 1}, nil, nil)
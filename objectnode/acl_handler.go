@@ -103,6 +103,7 @@ func (o *ObjectNode) putBucketACLHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	vol.metaLoader.storeACL(acl)
+	o.notifyBucketChanged(vol.name)
 }
 
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAcl.html
@@ -111,7 +111,7 @@ func (o *ObjectNode) getVol(bucket string) (vol *Volume, err error) {
 	vol, err = o.vm.Volume(bucket)
 	if err != nil {
 		log.LogErrorf("getVol: load Volume fail, bucket(%v) err(%v)", bucket, err)
-		if err == proto.ErrVolNotExists {
+		if errors.Is(err, proto.ErrVolNotExists) {
 			err = NoSuchBucket
 			return
 		}
@@ -19,9 +19,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/cubefs/cubefs/proto"
@@ -123,7 +125,7 @@ func (o *ObjectNode) createBucketHandler(w http.ResponseWriter, r *http.Request)
 	if err = o.mc.AdminAPI().CreateDefaultVolume(bucket, userInfo.UserID); err != nil {
 		log.LogErrorf("createBucketHandler: create bucket fail: requestID(%v) volume(%v) accessKey(%v) err(%v)",
 			GetRequestID(r), bucket, param.AccessKey(), err)
-		if err == proto.ErrDuplicateVol {
+		if errors.Is(err, proto.ErrDuplicateVol) {
 			err = DuplicateVol
 		} else {
 			err = InternalErrorCode(err)
@@ -321,6 +323,64 @@ func (o *ObjectNode) getBucketLocationHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseXML(w, response)
 }
 
+// Check bucket interop
+// Scans one page of the bucket and reports the keys which violate the mapping
+// rules between the object and POSIX views of the volume.
+func (o *ObjectNode) checkBucketInteropHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err       error
+		errorCode *ErrorCode
+	)
+	defer func() {
+		o.errorResponse(w, r, err, errorCode)
+	}()
+
+	var vol *Volume
+	param := ParseRequestParam(r)
+	if param.Bucket() == "" {
+		errorCode = InvalidBucketName
+		return
+	}
+	if vol, err = o.getVol(param.Bucket()); err != nil {
+		log.LogErrorf("checkBucketInteropHandler: load volume fail: requestID(%v) volume(%v) err(%v)",
+			GetRequestID(r), param.Bucket(), err)
+		return
+	}
+
+	maxKeys := uint64(MaxKeys)
+	if raw := r.URL.Query().Get(ParamMaxKeys); raw != "" {
+		if maxKeys, err = strconv.ParseUint(raw, 10, 16); err != nil {
+			errorCode = InvalidArgument
+			return
+		}
+		if maxKeys > MaxKeys {
+			maxKeys = MaxKeys
+		}
+	}
+
+	// QPS and Concurrency Limit
+	rateLimit := o.AcquireRateLimiter()
+	if err = rateLimit.AcquireLimitResource(vol.owner, param.apiName); err != nil {
+		return
+	}
+	defer rateLimit.ReleaseLimitResource(vol.owner, param.apiName)
+
+	result, err := vol.CheckInterop(r.URL.Query().Get(ParamPrefix), r.URL.Query().Get(ParamMarker), maxKeys)
+	if err != nil {
+		log.LogErrorf("checkBucketInteropHandler: check fail: requestID(%v) volume(%v) err(%v)",
+			GetRequestID(r), param.Bucket(), err)
+		return
+	}
+	response, err := MarshalXMLEntity(result)
+	if err != nil {
+		log.LogErrorf("checkBucketInteropHandler: xml marshal fail: requestID(%v) result(%v) err(%v)",
+			GetRequestID(r), result, err)
+		return
+	}
+
+	writeSuccessResponseXML(w, response)
+}
+
 // Get bucket tagging
 // API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketTagging.html
 func (o *ObjectNode) getBucketTaggingHandler(w http.ResponseWriter, r *http.Request) {
@@ -542,6 +602,7 @@ func (o *ObjectNode) putObjectLockConfigurationHandler(w http.ResponseWriter, r
 		return
 	}
 	vol.metaLoader.storeObjectLock(config)
+	o.notifyBucketChanged(vol.name)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -592,7 +653,7 @@ func (o *ObjectNode) getObjectLockConfigurationHandler(w http.ResponseWriter, r
 
 func (o *ObjectNode) getUserInfoByAccessKeyV2(accessKey string) (userInfo *proto.UserInfo, err error) {
 	userInfo, err = o.userStore.LoadUser(accessKey)
-	if err == proto.ErrUserNotExists || err == proto.ErrAccessKeyNotExists || err == proto.ErrParamError {
+	if errors.Is(err, proto.ErrUserNotExists) || errors.Is(err, proto.ErrAccessKeyNotExists) || errors.Is(err, proto.ErrParamError) {
 		err = InvalidAccessKeyId
 	}
 	return
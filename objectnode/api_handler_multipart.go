@@ -68,6 +68,9 @@ func (o *ObjectNode) createMultipleUploadHandler(w http.ResponseWriter, r *http.
 			GetRequestID(r), err)
 		return
 	}
+	if err = vol.checkObjectKey(param.Object()); err != nil {
+		return
+	}
 
 	// QPS and Concurrency Limit
 	rateLimit := o.AcquireRateLimiter()
@@ -252,7 +255,7 @@ func (o *ObjectNode) uploadPartHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Write Part
 	start := time.Now()
-	fsFileInfo, err := vol.WritePart(param.Object(), uploadId, partNumberInt, reader)
+	fsFileInfo, err := vol.WritePart(param.Object(), uploadId, partNumberInt, reader, length)
 	span.AppendTrackLog("part.w", start, err)
 	if err != nil {
 		log.LogErrorf("uploadPartHandler: write part fail: requestID(%v) volume(%v) path(%v) uploadId(%v) part(%v) err(%v)",
@@ -398,7 +401,7 @@ func (o *ObjectNode) uploadPartCopyHandler(w http.ResponseWriter, r *http.Reques
 		rd = reader
 	}
 	start = time.Now()
-	fsFileInfo, err := vol.WritePart(param.Object(), uploadId, partNumberInt, rd)
+	fsFileInfo, err := vol.WritePart(param.Object(), uploadId, partNumberInt, rd, copyLength)
 	span.AppendTrackLog("part.w", start, err)
 	if err != nil {
 		log.LogErrorf("uploadPartCopyHandler: write part fail: requestID(%v) volume(%v) path(%v) uploadId(%v) part(%v) err(%v)",
@@ -434,6 +437,9 @@ func handleWritePartErr(err error) error {
 	if err == io.ErrUnexpectedEOF {
 		return EntityTooSmall
 	}
+	if err == ErrPutMemoryExhausted {
+		return TooManyRequests
+	}
 	return err
 }
 
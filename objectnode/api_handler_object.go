@@ -746,6 +746,9 @@ func (o *ObjectNode) copyObjectHandler(w http.ResponseWriter, r *http.Request) {
 			GetRequestID(r), param.Bucket(), err)
 		return
 	}
+	if err = vol.checkObjectKey(param.Object()); err != nil {
+		return
+	}
 
 	// QPS and Concurrency Limit
 	rateLimit := o.AcquireRateLimiter()
@@ -1271,6 +1274,9 @@ func (o *ObjectNode) putObjectHandler(w http.ResponseWriter, r *http.Request) {
 			GetRequestID(r), param.Bucket(), err)
 		return
 	}
+	if err = vol.checkObjectKey(param.Object()); err != nil {
+		return
+	}
 
 	// Get request MD5, if request MD5 is not empty, compute and verify it.
 	requestMD5 := r.Header.Get(ContentMD5)
@@ -1385,6 +1391,7 @@ func (o *ObjectNode) putObjectHandler(w http.ResponseWriter, r *http.Request) {
 		Expires:      expires,
 		ACL:          acl,
 		ObjectLock:   objetLock,
+		Size:         length,
 	}
 	start := time.Now()
 	fsFileInfo, err := vol.PutObject(param.Object(), reader, opt)
@@ -1496,6 +1503,9 @@ func (o *ObjectNode) postObjectHandler(w http.ResponseWriter, r *http.Request) {
 		errorCode.ErrorMessage = fmt.Sprintf("%s (%s)", errorCode.ErrorMessage, "Invalid utf8 string or the key is too long")
 		return
 	}
+	if err = vol.checkObjectKey(key); err != nil {
+		return
+	}
 
 	var aclInfo *AccessControlPolicy
 	if acl := formReq.MultipartFormValue("acl"); acl != "" {
@@ -1613,6 +1623,7 @@ func (o *ObjectNode) postObjectHandler(w http.ResponseWriter, r *http.Request) {
 		Expires:      expires,
 		ACL:          aclInfo,
 		ObjectLock:   objetLock,
+		Size:         size,
 	}
 	start := time.Now()
 	fsFileInfo, err := vol.PutObject(key, reader, putOpt)
@@ -1672,6 +1683,9 @@ func handlePutObjectErr(err error) error {
 	if err == io.ErrUnexpectedEOF {
 		return EntityTooSmall
 	}
+	if err == ErrPutMemoryExhausted {
+		return TooManyRequests
+	}
 	return err
 }
 
@@ -74,7 +74,9 @@ func (o *ObjectNode) auditMiddleware(next http.Handler) http.Handler {
 
 // TraceMiddleware returns a middleware handler to trace request.
 // After receiving the request, the handler will assign a unique RequestID to
-// the request and record the processing time of the request.
+// the request, or take the one in the X-Request-Id header of it, return it in
+// the x-amz-request-id and X-Request-Id headers and record the processing time
+// of the request.
 func (o *ObjectNode) traceMiddleware(next http.Handler) http.Handler {
 	generateRequestID := func() (string, error) {
 		var uUID uuid.UUID
@@ -99,7 +101,12 @@ func (o *ObjectNode) traceMiddleware(next http.Handler) http.Handler {
 			}
 		}()
 
-		requestID, err := generateRequestID()
+		// honor the request id of the client, as of a proxy in front, or generate one
+		requestID := r.Header.Get(proto.HeaderRequestID)
+		var err error
+		if !proto.ValidRequestID(requestID) {
+			requestID, err = generateRequestID()
+		}
 		if err != nil {
 			log.LogErrorf("traceMiddleware: generate request ID fail, remote(%v) url(%v) err(%v)",
 				r.RemoteAddr, r.URL.String(), err)
@@ -112,6 +119,7 @@ func (o *ObjectNode) traceMiddleware(next http.Handler) http.Handler {
 		// store request ID to context and write to header
 		SetRequestID(r, requestID)
 		w.Header().Set(XAmzRequestId, requestID)
+		w.Header().Set(proto.HeaderRequestID, requestID)
 		w.Header().Set(Server, ValueServer)
 
 		if connHeader := r.Header.Get(Connection); strings.EqualFold(connHeader, "close") {
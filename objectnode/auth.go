@@ -15,6 +15,7 @@
 package objectnode
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -277,7 +278,7 @@ func (o *ObjectNode) getUidSecretKeyWithCheckVol(r *http.Request, ak string, ck
 		uid, sk = info.UserID, info.SecretKey
 		return
 	}
-	if err == proto.ErrUserNotExists || err == proto.ErrAccessKeyNotExists || err == proto.ErrParamError {
+	if errors.Is(err, proto.ErrUserNotExists) || errors.Is(err, proto.ErrAccessKeyNotExists) || errors.Is(err, proto.ErrParamError) {
 		bucket := mux.Vars(r)[ContextKeyBucket]
 		if len(bucket) > 0 && ck && GetActionFromContext(r) != proto.OSSCreateBucketAction {
 			// In order to be directly compatible with the signature verification of version 1.5
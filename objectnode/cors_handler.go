@@ -122,6 +122,7 @@ func (o *ObjectNode) putBucketCorsHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 	vol.metaLoader.storeCORS(corsConfig)
+	o.notifyBucketChanged(vol.name)
 }
 
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketCors.html
@@ -151,6 +152,7 @@ func (o *ObjectNode) deleteBucketCorsHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	vol.metaLoader.storeCORS(nil)
+	o.notifyBucketChanged(vol.name)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -15,7 +15,9 @@
 package objectnode
 
 import (
+	"errors"
 	"hash/crc32"
+	"sort"
 	"sync"
 	"time"
 
@@ -76,6 +78,16 @@ func (loader *VolumeLoader) Release(volName string) {
 	}
 }
 
+func (loader *VolumeLoader) volumeNames() []string {
+	loader.volMu.RLock()
+	defer loader.volMu.RUnlock()
+	names := make([]string, 0, len(loader.volumes))
+	for name := range loader.volumes {
+		names = append(names, name)
+	}
+	return names
+}
+
 func (loader *VolumeLoader) Volume(volName string) (*Volume, error) {
 	return loader.loadVolume(volName)
 }
@@ -121,8 +133,8 @@ func (loader *VolumeLoader) loadVolumeWithoutBlacklist(volName string, init bool
 	loader.volMu.RUnlock()
 
 	var onAsyncTaskError AsyncTaskErrorFunc = func(err error) {
-		switch err {
-		case proto.ErrVolNotExists:
+		switch {
+		case errors.Is(err, proto.ErrVolNotExists):
 			loader.Release(volName)
 		default:
 		}
@@ -135,7 +147,7 @@ func (loader *VolumeLoader) loadVolumeWithoutBlacklist(volName string, init bool
 		MetaStrict:       loader.metaStrict,
 	}
 	if volume, err = NewVolume(config); err != nil {
-		if err != proto.ErrVolNotExists {
+		if !errors.Is(err, proto.ErrVolNotExists) {
 			log.LogErrorf("loadVolume: init volume fail: volume(%v) err(%v)", volume, err)
 		}
 		release()
@@ -182,8 +194,8 @@ func (loader *VolumeLoader) loadVolume(volName string) (*Volume, error) {
 		loader.volMu.RUnlock()
 
 		var onAsyncTaskError AsyncTaskErrorFunc = func(err error) {
-			switch err {
-			case proto.ErrVolNotExists:
+			switch {
+			case errors.Is(err, proto.ErrVolNotExists):
 				loader.Release(volName)
 				// Add to blacklist
 				loader.blacklist.Store(volName, time.Now())
@@ -199,7 +211,7 @@ func (loader *VolumeLoader) loadVolume(volName string) (*Volume, error) {
 		}
 		if volume, err = NewVolume(config); err != nil {
 			log.LogDebugf("loadVolume: new volume fail, add to blacklist: volume(%v) err(%v)", volName, err)
-			if err != proto.ErrVolNotExists {
+			if !errors.Is(err, proto.ErrVolNotExists) {
 				log.LogErrorf("loadVolume: new volume fail: volume(%v) config(%+v) err(%v)", volume, config, err)
 			}
 			release()
@@ -277,6 +289,16 @@ func (m *VolumeManager) Close() {
 	})
 }
 
+// LoadedVolumes returns the names of the volumes loaded, sorted.
+func (m *VolumeManager) LoadedVolumes() []string {
+	names := make([]string, 0)
+	for _, loader := range m.loaders {
+		names = append(names, loader.volumeNames()...)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (m *VolumeManager) Release(volName string) {
 	m.selectLoader(volName).Release(volName)
 }
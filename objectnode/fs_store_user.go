@@ -15,6 +15,7 @@
 package objectnode
 
 import (
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"sync"
@@ -44,7 +45,7 @@ type StrictUserInfoStore struct {
 func (s *StrictUserInfoStore) LoadUser(accessKey string) (*proto.UserInfo, error) {
 	// if error occurred when loading user, and error is not NotExist, output an ump log
 	userInfo, err := s.mc.UserAPI().GetAKInfo(accessKey)
-	if err != nil && err != proto.ErrUserNotExists && err != proto.ErrAccessKeyNotExists {
+	if err != nil && !errors.Is(err, proto.ErrUserNotExists) && !errors.Is(err, proto.ErrAccessKeyNotExists) {
 		log.LogErrorf("LoadUser: fetch user info fail: err(%v)", err)
 		exporter.Warning(fmt.Sprintf("StrictUserInfoStore load user fail: accessKey(%v) err(%v)", accessKey, err))
 	}
@@ -153,7 +154,7 @@ func (us *CacheUserInfoLoader) scheduleUpdate() {
 		us.akInfoMutex.RUnlock()
 		for _, ak := range aks {
 			akPolicy, err := us.mc.UserAPI().GetAKInfo(ak)
-			if err == proto.ErrUserNotExists || err == proto.ErrAccessKeyNotExists {
+			if errors.Is(err, proto.ErrUserNotExists) || errors.Is(err, proto.ErrAccessKeyNotExists) {
 				us.akInfoMutex.Lock()
 				delete(us.akInfoStore, ak)
 				us.akInfoMutex.Unlock()
@@ -215,7 +216,7 @@ func (us *CacheUserInfoLoader) LoadUser(accessKey string) (*proto.UserInfo, erro
 
 		userInfo, err = us.mc.UserAPI().GetAKInfo(accessKey)
 		if err != nil {
-			if err != proto.ErrUserNotExists && err != proto.ErrAccessKeyNotExists {
+			if !errors.Is(err, proto.ErrUserNotExists) && !errors.Is(err, proto.ErrAccessKeyNotExists) {
 				log.LogErrorf("LoadUser: fetch user info fail: err(%v)", err)
 				// if error occurred when loading user, and error is not NotExist, output an ump log
 				exporter.Warning(fmt.Sprintf("CacheUserInfoLoader load user info fail: accessKey(%v) err(%v)", accessKey, err))
@@ -30,6 +30,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -93,6 +94,7 @@ type PutFileOption struct {
 	CacheControl string
 	Expires      string
 	ObjectLock   *ObjectLockConfig
+	Size         int64 // expected size of the object, -1 if unknown
 }
 
 type ListFilesV1Option struct {
@@ -132,6 +134,7 @@ type ListFilesV2Result struct {
 // Volume escapes high-level object storage semantics to low-level POSIX semantics.
 type Volume struct {
 	mw         *meta.MetaWrapper
+	dirReader  prefixDirReader // the directories scanned by the listings, mw
 	ec         *stream.ExtentClient
 	mc         *master.MasterClient
 	store      Store // Storage for ACP management
@@ -143,6 +146,7 @@ type Volume struct {
 
 	volType      int
 	ebsBlockSize int
+	interop      atomic.Value // proto.InteropPolicy
 
 	closeOnce sync.Once
 	closeCh   chan struct{}
@@ -213,6 +217,7 @@ func (v *Volume) loadOSSMeta() {
 		return
 	}
 	v.metaLoader.storeObjectLock(objectlock)
+	v.storeInteropPolicy(volumeInfo.Interop)
 	v.metaLoader.setSynced()
 }
 
@@ -738,7 +743,11 @@ func (v *Volume) PutObject(path string, reader io.Reader, opt *PutFileOption) (f
 			return
 		}
 	} else {
-		if _, err = v.streamWrite(invisibleTempDataInode.Inode, reader, md5Hash, invisibleTempDataInode.StorageClass); err != nil {
+		sizeHint := int64(-1)
+		if opt != nil {
+			sizeHint = opt.Size
+		}
+		if _, err = v.streamWrite(invisibleTempDataInode.Inode, reader, md5Hash, invisibleTempDataInode.StorageClass, sizeHint); err != nil {
 			log.LogErrorf("PutObject: stream write fail: volume(%v) path(%v) inode(%v) err(%v)",
 				v.name, path, invisibleTempDataInode.Inode, err)
 			return
@@ -1020,7 +1029,9 @@ func (v *Volume) InitMultipart(path string, opt *PutFileOption) (multipartID str
 	return multipartID, nil
 }
 
-func (v *Volume) WritePart(path string, multipartId string, partId uint16, reader io.Reader) (*FSFileInfo, error) {
+// WritePart writes a part of a multipart upload, partSize is the expected size of
+// the part, or -1 if unknown.
+func (v *Volume) WritePart(path string, multipartId string, partId uint16, reader io.Reader, partSize int64) (*FSFileInfo, error) {
 	var exist bool
 	var err error
 	defer func() {
@@ -1092,7 +1103,7 @@ func (v *Volume) WritePart(path string, multipartId string, partId uint16, reade
 		}
 	} else {
 		// Write data to data node
-		if size, err = v.streamWrite(tempInodeInfo.Inode, reader, md5Hash, tempInodeInfo.StorageClass); err != nil {
+		if size, err = v.streamWrite(tempInodeInfo.Inode, reader, md5Hash, tempInodeInfo.StorageClass, partSize); err != nil {
 			log.LogErrorf("WritePart: stream write fail: volume(%v) inode(%v) multipartID(%v) partID(%v) err(%v)",
 				v.name, tempInodeInfo.Inode, multipartId, partId, err)
 			return nil, err
@@ -1391,14 +1402,29 @@ func (v *Volume) ebsWrite(inode uint64, reader io.Reader, h hash.Hash, storageCl
 	return
 }
 
-func (v *Volume) streamWrite(inode uint64, reader io.Reader, h hash.Hash, storageClass uint32) (size uint64, err error) {
+// streamWrite writes the data into extents as it arrives. The memory of the request
+// is bounded by one block buffer and the packets it may have in flight, and the
+// reader is not read while they are in use.
+func (v *Volume) streamWrite(inode uint64, reader io.Reader, h hash.Hash, storageClass uint32, sizeHint int64) (size uint64, err error) {
+	buf, err := writeBuffers.get()
+	if err != nil {
+		log.LogWarnf("streamWrite: get write buffer fail: volume(%v) inode(%v) err(%v)", v.name, inode, err)
+		return
+	}
+	defer writeBuffers.put(buf)
+
+	// A large object goes to normal extents from the start, instead of filling a
+	// tiny extent with its first megabyte.
+	var flags int
+	if sizeHint > util.DefaultTinySizeLimit {
+		flags |= proto.FlagsLargeWrite
+	}
 	var (
-		buf                   = make([]byte, 2*util.BlockSize)
 		teeReader             = io.TeeReader(reader, h)
 		readN, writeN, offset int
 	)
 	for {
-		readN, err = teeReader.Read(buf)
+		readN, err = fillBuffer(teeReader, buf)
 		if err != nil && err != io.EOF {
 			return
 		}
@@ -1417,7 +1443,7 @@ func (v *Volume) streamWrite(inode uint64, reader io.Reader, h hash.Hash, storag
 				}
 				return nil
 			}
-			if writeN, err = v.ec.Write(inode, offset, buf[:readN], 0, checkFunc, storageClass, false); err != nil {
+			if writeN, err = v.ec.Write(inode, offset, buf[:readN], flags, checkFunc, storageClass, false); err != nil {
 				log.LogErrorf("streamWrite: data write tmp file fail, inode(%v) offset(%v) err(%v)", inode, offset, err)
 				exporter.Warning(fmt.Sprintf("write data fail: volume(%v) inode(%v) offset(%v) size(%v) err(%v)",
 					v.name, inode, offset, readN, err))
@@ -1736,7 +1762,7 @@ func (v *Volume) ObjectMeta(path string) (info *FSFileInfo, xattr *proto.XAttrIn
 		expires      string
 	)
 
-	if objMetaCache != nil {
+	if objMetaCache != nil && !v.interopPolicy().IsImmediateMetaSync() {
 		attrItem, needRefresh := objMetaCache.GetAttr(v.name, inode)
 		if attrItem == nil || needRefresh {
 			log.LogDebugf("ObjectMeta: get attr in cache miss: volume(%v) inode(%v) attrItem(%v), needRefresh(%v)",
@@ -1857,6 +1883,10 @@ func (v *Volume) recursiveLookupTarget(path string, notUseCache bool) (parent ui
 	}
 
 	cacheUsed := false
+	// renames done through the POSIX view must be visible at once
+	if v.interopPolicy().IsImmediateRename() {
+		notUseCache = true
+	}
 
 	if objMetaCache != nil && !notUseCache {
 		for pathIterator.HasNext() {
@@ -2150,7 +2180,7 @@ func (v *Volume) listFilesV1(prefix, marker, delimiter string, maxKeys uint64, o
 	var rc uint64
 	// recursion scan
 	infos, prefixMap, nextMarker, _, err = v.recursiveScan(infos, prefixMap, parentId, maxKeys, maxKeys, rc, dirs,
-		prefix, marker, delimiter, onlyObject, true)
+		prefix, marker, delimiter, onlyObject, true, time.Time{})
 	if err != nil {
 		log.LogErrorf("listFilesV1: volume list dir fail: Volume(%v) err(%v)", v.name, err)
 		return
@@ -2203,9 +2233,15 @@ func (v *Volume) listFilesV2(prefix, startAfter, contToken, delimiter string, ma
 	// Check this value when adding key to contents or common prefix,
 	// return if it reach to max keys
 	var rc uint64
+	// The page is cut short at the deadline, the scan goes on from nextMarker
+	// with the next request.
+	var deadline time.Time
+	if listObjectsTimeout > 0 {
+		deadline = time.Now().Add(listObjectsTimeout)
+	}
 	// recursion scan
 	infos, prefixMap, nextMarker, _, err = v.recursiveScan(infos, prefixMap, parentId, maxKeys, maxKeys, rc, dirs,
-		prefix, marker, delimiter, true, true)
+		prefix, marker, delimiter, true, true, deadline)
 	if err != nil {
 		log.LogErrorf("listFilesV2: Volume list dir fail, Volume(%v) err(%v)", v.name, err)
 		return
@@ -2274,11 +2310,20 @@ func (v *Volume) findParentId(prefix string) (inode uint64, prefixDirs []string,
 	return
 }
 
+// prefixDirReader reads the entries of a directory with the names of a prefix.
+type prefixDirReader interface {
+	ReadDirPrefix_ll(parentID uint64, prefix, from string, limit uint64) ([]proto.Dentry, error)
+}
+
+func listDeadlineExceeded(deadline time.Time) bool {
+	return !deadline.IsZero() && time.Now().After(deadline)
+}
+
 // Recursive scan of the directory starting from the given parentID. Match files and directories
-// that match the prefix and delimiter criteria. Stop when the number of matches reaches a threshold
-// or all files and directories are scanned.
+// that match the prefix and delimiter criteria. Stop when the number of matches reaches a threshold,
+// when the deadline is passed before entering a directory, or all files and directories are scanned.
 func (v *Volume) recursiveScan(fileInfos []*FSFileInfo, prefixMap PrefixMap, parentId, maxKeys, readLimit, rc uint64, dirs []string,
-	prefix, marker, delimiter string, onlyObject, firstEnter bool,
+	prefix, marker, delimiter string, onlyObject, firstEnter bool, deadline time.Time,
 ) ([]*FSFileInfo, PrefixMap, string, uint64, error) {
 	var err error
 	var nextMarker string
@@ -2329,8 +2374,15 @@ func (v *Volume) recursiveScan(fileInfos []*FSFileInfo, prefixMap PrefixMap, par
 	// At this time, stops process and returns success.
 	var children []proto.Dentry
 
+	// The names with the prefix are read as a range of the dentries, in the layer of the last part
+	// of it, the directories above it are looked up and the ones below it all have it.
+	namePrefix := ""
+	if !strings.Contains(prefixMarker, pathSep) {
+		namePrefix = prefixMarker
+	}
+
 readDir:
-	children, err = v.mw.ReadDirLimit_ll(parentId, fromName, readLimit+1) // one more for nextMarker
+	children, err = v.dirReader.ReadDirPrefix_ll(parentId, namePrefix, fromName, readLimit+1) // one more for nextMarker
 	if err != nil && err != syscall.ENOENT {
 		return fileInfos, prefixMap, "", 0, err
 	}
@@ -2349,9 +2401,6 @@ readDir:
 		if os.FileMode(child.Type).IsDir() {
 			path += pathSep
 		}
-		if prefix != "" && !strings.HasPrefix(path, prefix) {
-			continue
-		}
 
 		if marker != "" {
 			if !os.FileMode(child.Type).IsDir() && path < marker {
@@ -2359,11 +2408,11 @@ readDir:
 			}
 			if os.FileMode(child.Type).IsDir() && strings.HasPrefix(marker, path) {
 				fileInfos, prefixMap, nextMarker, rc, err = v.recursiveScan(fileInfos, prefixMap, child.Inode, maxKeys,
-					readLimit, rc, append(dirs, child.Name), prefix, marker, delimiter, onlyObject, false)
+					readLimit, rc, append(dirs, child.Name), prefix, marker, delimiter, onlyObject, false, deadline)
 				if err != nil {
 					return fileInfos, prefixMap, nextMarker, rc, err
 				}
-				if rc >= maxKeys && nextMarker != "" {
+				if (rc >= maxKeys || listDeadlineExceeded(deadline)) && nextMarker != "" {
 					return fileInfos, prefixMap, nextMarker, rc, err
 				}
 				continue
@@ -2404,12 +2453,17 @@ readDir:
 
 		if os.FileMode(child.Type).IsDir() {
 			nextMarker = fmt.Sprintf("%v%v%v", currentPath, child.Name, pathSep)
+			// The directory is scanned from its start with the next request, which makes progress
+			// as this one did not resume from within it.
+			if listDeadlineExceeded(deadline) {
+				return fileInfos, prefixMap, nextMarker, rc, nil
+			}
 			fileInfos, prefixMap, nextMarker, rc, err = v.recursiveScan(fileInfos, prefixMap, child.Inode, maxKeys,
-				readLimit, rc, append(dirs, child.Name), prefix, nextMarker, delimiter, onlyObject, false)
+				readLimit, rc, append(dirs, child.Name), prefix, nextMarker, delimiter, onlyObject, false, deadline)
 			if err != nil {
 				return fileInfos, prefixMap, nextMarker, rc, err
 			}
-			if rc >= maxKeys && nextMarker != "" {
+			if (rc >= maxKeys || listDeadlineExceeded(deadline)) && nextMarker != "" {
 				return fileInfos, prefixMap, nextMarker, rc, err
 			}
 		}
@@ -2838,7 +2892,11 @@ func (v *Volume) CopyFile(sv *Volume, sourcePath, targetPath, metaDirective stri
 		readSize    int
 		rest        int
 		buf         = make([]byte, 2*util.BlockSize)
+		writeFlags  int
 	)
+	if fileSize > util.DefaultTinySizeLimit {
+		writeFlags = proto.FlagsLargeWrite
+	}
 
 	var sctx context.Context
 	var ebsReader *blobstore.Reader
@@ -2874,7 +2932,7 @@ func (v *Volume) CopyFile(sv *Volume, sourcePath, targetPath, metaDirective stri
 			if proto.IsCold(v.volType) || proto.IsStorageClassBlobStore(tInodeInfo.StorageClass) {
 				writeN, err = ebsWriter.WriteWithoutPool(tctx, writeOffset, buf[:readN])
 			} else {
-				writeN, err = v.ec.Write(tInodeInfo.Inode, writeOffset, buf[:readN], 0, nil, tInodeInfo.StorageClass, false)
+				writeN, err = v.ec.Write(tInodeInfo.Inode, writeOffset, buf[:readN], writeFlags, nil, tInodeInfo.StorageClass, false)
 			}
 			if err != nil {
 				log.LogErrorf("CopyFile: write target path from volume (%v) path(%v) fail, volume(%v) path(%v) inode(%v) target offset(%v) err(%v)",
@@ -3079,6 +3137,7 @@ func NewVolume(config *VolumeConfig) (*Volume, error) {
 		VolAllowedStorageClass:      volumeInfo.AllowedStorageClass,
 		OnForbiddenMigration:        metaWrapper.ForbiddenMigration,
 		MetaWrapper:                 metaWrapper,
+		MaxInflightPackets:          maxInflightPackets,
 	}
 
 	if proto.IsCold(volumeInfo.VolType) || proto.IsStorageClassBlobStore(volumeInfo.VolStorageClass) {
@@ -3098,6 +3157,7 @@ func NewVolume(config *VolumeConfig) (*Volume, error) {
 
 	v := &Volume{
 		mw:           metaWrapper,
+		dirReader:    metaWrapper,
 		ec:           extentClient,
 		mc:           mc,
 		name:         config.Volume,
@@ -3113,6 +3173,7 @@ func NewVolume(config *VolumeConfig) (*Volume, error) {
 			}
 		},
 	}
+	v.storeInteropPolicy(volumeInfo.Interop)
 	if config.MetaStrict {
 		v.metaLoader = &strictMetaLoader{v: v}
 	} else {
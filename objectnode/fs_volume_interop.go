@@ -0,0 +1,84 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+type InteropViolation struct {
+	Key    string `xml:"Key"`
+	Reason string `xml:"Reason"`
+}
+
+func (v *Volume) interopPolicy() proto.InteropPolicy {
+	if p, ok := v.interop.Load().(proto.InteropPolicy); ok {
+		return p
+	}
+	return proto.DefaultInteropPolicy()
+}
+
+func (v *Volume) storeInteropPolicy(p proto.InteropPolicy) {
+	p.Normalize()
+	v.interop.Store(p)
+}
+
+// checkObjectKey rejects the keys which would be normalized into another path
+// when the volume maps object keys to paths strictly.
+func (v *Volume) checkObjectKey(key string) error {
+	policy := v.interopPolicy()
+	if !policy.IsStrictKeyMapping() {
+		return nil
+	}
+	if reason := proto.CheckObjectKeyMapping(key); reason != "" {
+		log.LogWarnf("checkObjectKey: volume(%v) key(%v) violates strict key mapping: %v", v.name, key, reason)
+		return InvalidKeyMapping
+	}
+	return nil
+}
+
+// CheckInterop scans one page of the namespace and reports the entries which
+// can not be served consistently through both the object and POSIX views.
+func (v *Volume) CheckInterop(prefix, marker string, maxKeys uint64) (result *InteropCheckResult, err error) {
+	policy := v.interopPolicy()
+	listed, err := v.ListFilesV1(&ListFilesV1Option{
+		Prefix:  prefix,
+		Marker:  marker,
+		MaxKeys: maxKeys,
+	})
+	if err != nil {
+		return
+	}
+	result = &InteropCheckResult{
+		Bucket:      v.name,
+		Prefix:      prefix,
+		KeyMapping:  policy.KeyMapping,
+		Scanned:     uint64(len(listed.Files)),
+		IsTruncated: listed.Truncated,
+		NextMarker:  listed.NextMarker,
+		Violations:  make([]*InteropViolation, 0),
+	}
+	for _, info := range listed.Files {
+		reason := proto.CheckPathMapping(info.Path)
+		if reason == "" && policy.IsStrictKeyMapping() {
+			reason = proto.CheckObjectKeyMapping(info.Path)
+		}
+		if reason != "" {
+			result.Violations = append(result.Violations, &InteropViolation{Key: info.Path, Reason: reason})
+		}
+	}
+	return
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeCheckObjectKey(t *testing.T) {
+	v := &Volume{name: "interop"}
+	require.Equal(t, proto.ObjKeyMappingCompat, v.interopPolicy().KeyMapping)
+	require.NoError(t, v.checkObjectKey("a//b"))
+
+	v.storeInteropPolicy(proto.InteropPolicy{KeyMapping: proto.ObjKeyMappingStrict})
+	require.True(t, v.interopPolicy().IsStrictKeyMapping())
+	require.Equal(t, proto.InteropSyncCached, v.interopPolicy().RenameVisibility)
+	require.NoError(t, v.checkObjectKey("a/b/"))
+	require.Equal(t, InvalidKeyMapping, v.checkObjectKey("a//b"))
+	require.Equal(t, InvalidKeyMapping, v.checkObjectKey("/a/b"))
+	require.Equal(t, InvalidKeyMapping, v.checkObjectKey("a/../b"))
+}
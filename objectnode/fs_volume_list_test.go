@@ -0,0 +1,81 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDirReader map[uint64][]proto.Dentry
+
+func (f fakeDirReader) ReadDirPrefix_ll(parentID uint64, prefix, from string, limit uint64) ([]proto.Dentry, error) {
+	children := f[parentID]
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	var dentries []proto.Dentry
+	for _, child := range children {
+		if child.Name >= from && strings.HasPrefix(child.Name, prefix) && uint64(len(dentries)) < limit {
+			dentries = append(dentries, child)
+		}
+	}
+	return dentries, nil
+}
+
+func listAllFiles(t *testing.T, v *Volume, maxKeys uint64, deadline time.Time) (paths []string, pages int) {
+	var marker string
+	for pages < 100 {
+		pages++
+		infos, _, nextMarker, _, err := v.recursiveScan(nil, PrefixMap{}, proto.RootIno, maxKeys, maxKeys, 0, nil,
+			"", marker, "", true, true, deadline)
+		require.NoError(t, err)
+		for _, info := range infos {
+			paths = append(paths, info.Path)
+		}
+		if nextMarker == "" {
+			return
+		}
+		marker = nextMarker
+	}
+	t.Fatalf("listing makes no progress from %v", marker)
+	return
+}
+
+func TestListFilesDeadline(t *testing.T) {
+	dir, file := uint32(os.ModeDir|0o755), uint32(0o644)
+	v := &Volume{dirReader: fakeDirReader{
+		proto.RootIno: {{Name: "a", Inode: 2, Type: file}, {Name: "d0", Inode: 10, Type: dir}, {Name: "d1", Inode: 11, Type: dir}, {Name: "z", Inode: 3, Type: file}},
+		10:            {{Name: "x", Inode: 12, Type: file}, {Name: "y", Inode: 13, Type: file}},
+		11:            {{Name: "x", Inode: 14, Type: file}},
+	}}
+	all := []string{"a", "d0/x", "d0/y", "d1/x", "z"}
+
+	paths, pages := listAllFiles(t, v, 1000, time.Time{})
+	require.Equal(t, all, paths)
+	require.Equal(t, 1, pages)
+
+	// past the deadline the pages are cut short before each directory, and the
+	// continuation token goes on from there without losing or repeating keys
+	paths, pages = listAllFiles(t, v, 1000, time.Now().Add(-time.Second))
+	require.Equal(t, all, paths)
+	require.Equal(t, 3, pages)
+
+	require.Equal(t, 3*time.Second, defaultListObjectsTimeout, "the scans are bounded by default")
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const s3NodeHeartbeatInterval = 10 * time.Second
+
+// gatewaySync keeps the objectnodes behind a load balancer in step through the
+// master: each one heartbeats, reloads the configuration of a bucket another
+// one changed, and splits the rate limits among the live ones. The multipart
+// uploads need nothing, they are kept by the metanodes.
+type gatewaySync struct {
+	addr      string
+	epoch     int64
+	versions  map[string]uint64
+	liveNodes uint64
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+}
+
+func newGatewaySync(addr string) *gatewaySync {
+	return &gatewaySync{
+		addr:     addr,
+		versions: make(map[string]uint64),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// apply takes a heartbeat response, and returns the buckets changed since the
+// last one, or all if the master leader changed in between.
+func (g *gatewaySync) apply(resp *proto.S3NodeHeartbeatResponse) (changed []string, all bool, nodesChanged bool) {
+	if g.epoch != 0 && g.epoch != resp.Epoch {
+		all = true
+	} else if g.epoch != 0 {
+		for bucket, version := range resp.BucketVersions {
+			if g.versions[bucket] != version {
+				changed = append(changed, bucket)
+			}
+		}
+	}
+	g.epoch = resp.Epoch
+	g.versions = resp.BucketVersions
+	if g.versions == nil {
+		g.versions = make(map[string]uint64)
+	}
+	nodes := uint64(len(resp.Nodes))
+	nodesChanged, g.liveNodes = g.liveNodes != nodes, nodes
+	return
+}
+
+func (g *gatewaySync) stop() {
+	g.stopOnce.Do(func() { close(g.stopCh) })
+}
+
+func (o *ObjectNode) startGatewaySync(addr string) {
+	o.gateway = newGatewaySync(addr)
+	o.closes = append(o.closes, o.gateway.stop)
+	o.gatewayHeartbeat()
+	go func() {
+		ticker := time.NewTicker(s3NodeHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-o.gateway.stopCh:
+				return
+			case <-ticker.C:
+				o.gatewayHeartbeat()
+			}
+		}
+	}()
+}
+
+func (o *ObjectNode) gatewayHeartbeat() {
+	resp, err := o.mc.AdminAPI().S3NodeHeartbeat(o.gateway.addr)
+	if err != nil {
+		log.LogWarnf("gatewayHeartbeat: heartbeat to master fail: addr(%v) err(%v)", o.gateway.addr, err)
+		return
+	}
+	changed, all, nodesChanged := o.gateway.apply(resp)
+	if all {
+		changed = o.vm.LoadedVolumes()
+	}
+	for _, bucket := range changed {
+		vol, err := o.vm.VolumeWithoutBlacklist(bucket, false)
+		if err != nil {
+			continue
+		}
+		log.LogInfof("gatewayHeartbeat: reload configuration of bucket(%v)", bucket)
+		vol.loadOSSMeta()
+	}
+	if nodesChanged {
+		log.LogInfof("gatewayHeartbeat: live objectnodes changed to %v", resp.Nodes)
+		data, err := o.requestRemote()
+		if err != nil {
+			log.LogWarnf("gatewayHeartbeat: get s3 qos info fail: err(%v)", err)
+			return
+		}
+		if err = o.Reload(data); err != nil {
+			log.LogWarnf("gatewayHeartbeat: reload s3 qos fail: err(%v)", err)
+		}
+	}
+}
+
+// notifyBucketChanged has the other objectnodes reload the configuration of
+// the bucket changed by this one.
+func (o *ObjectNode) notifyBucketChanged(bucket string) {
+	if o.gateway == nil {
+		return
+	}
+	if err := o.mc.AdminAPI().S3BucketChanged(bucket); err != nil {
+		log.LogWarnf("notifyBucketChanged: notify master fail: bucket(%v) err(%v)", bucket, err)
+	}
+}
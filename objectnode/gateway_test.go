@@ -0,0 +1,67 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewaySyncApply(t *testing.T) {
+	g := newGatewaySync("192.168.0.1:80")
+	changed, all, nodesChanged := g.apply(&proto.S3NodeHeartbeatResponse{
+		Epoch:          1,
+		Nodes:          []string{"192.168.0.1:80"},
+		BucketVersions: map[string]uint64{"a": 1},
+	})
+	require.Empty(t, changed)
+	require.False(t, all)
+	require.True(t, nodesChanged)
+
+	changed, all, nodesChanged = g.apply(&proto.S3NodeHeartbeatResponse{
+		Epoch:          1,
+		Nodes:          []string{"192.168.0.1:80"},
+		BucketVersions: map[string]uint64{"a": 2, "b": 1, "c": 0},
+	})
+	sort.Strings(changed)
+	require.Equal(t, []string{"a", "b"}, changed)
+	require.False(t, all)
+	require.False(t, nodesChanged)
+
+	changed, all, nodesChanged = g.apply(&proto.S3NodeHeartbeatResponse{
+		Epoch: 2,
+		Nodes: []string{"192.168.0.1:80", "192.168.0.2:80"},
+	})
+	require.Empty(t, changed)
+	require.True(t, all)
+	require.True(t, nodesChanged)
+	require.NotNil(t, g.versions)
+}
+
+func TestReloadSplitsQuotaAmongLiveNodes(t *testing.T) {
+	o := &ObjectNode{}
+	data := []byte(`{"user_limit_conf":{"getobject":{"qps_quota":{"default":90}}},"nodes":3,"liveNodes":2}`)
+	require.NoError(t, o.Reload(data))
+	rl := o.AcquireRateLimiter().(*RateLimit)
+	require.EqualValues(t, 45, rl.ApiLimitConf["getobject"].QPSQuota[proto.DefaultUid])
+
+	data = []byte(`{"user_limit_conf":{"getobject":{"qps_quota":{"default":90}}},"nodes":0,"liveNodes":2}`)
+	require.NoError(t, o.Reload(data))
+	_, ok := o.AcquireRateLimiter().(*NullRateLimit)
+	require.True(t, ok)
+}
@@ -16,6 +16,7 @@ package objectnode
 
 import (
 	"encoding/xml"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -59,7 +60,7 @@ func (o *ObjectNode) getBucketLifecycleConfigurationHandler(w http.ResponseWrite
 	var lcConf *proto.LcConfiguration
 	if lcConf, err = o.mc.AdminAPI().GetBucketLifecycle(param.Bucket()); err != nil {
 		log.LogErrorf("getBucketLifecycle failed: requestID(%v) bucket[%v] err(%v)", GetRequestID(r), param.Bucket(), err)
-		if err.Error() == proto.ErrNoSuchLifecycleConfiguration.Error() {
+		if errors.Is(err, proto.ErrNoSuchLifecycleConfiguration) {
 			errorCode = NoSuchLifecycleConfiguration
 		}
 		return
@@ -129,7 +130,7 @@ func (o *ObjectNode) putBucketLifecycleConfigurationHandler(w http.ResponseWrite
 	}
 	if err = o.mc.AdminAPI().SetBucketLifecycle(&req); err != nil {
 		log.LogErrorf("putBucketLifecycle failed: SetBucketLifecycle err: requestID(%v) bucket[%v] err(%v)", GetRequestID(r), param.Bucket(), err)
-		if err.Error() == proto.ErrNoSupportStorageClass.Error() {
+		if errors.Is(err, proto.ErrNoSupportStorageClass) {
 			errorCode = LifeCycleErrMalformedXML
 		}
 		return
@@ -117,6 +117,7 @@ func (o *ObjectNode) putBucketPolicyHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	vol.metaLoader.storePolicy(policy)
+	o.notifyBucketChanged(vol.name)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -149,6 +150,7 @@ func (o *ObjectNode) deleteBucketPolicyHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 	vol.metaLoader.storePolicy(nil)
+	o.notifyBucketChanged(vol.name)
 
 	w.WriteHeader(http.StatusNoContent)
 }
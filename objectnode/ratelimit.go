@@ -268,6 +268,9 @@ func (o *ObjectNode) Reload(data []byte) error {
 	}
 	apiLimitConf := s3QosResponse.ApiLimitConf
 	s3NodeNum := s3QosResponse.Nodes
+	if s3NodeNum != 0 && s3QosResponse.LiveNodes != 0 {
+		s3NodeNum = s3QosResponse.LiveNodes
+	}
 	if s3NodeNum == 0 {
 		o.limitMutex.Lock()
 		o.rateLimit = &NullRateLimit{}
@@ -383,6 +383,17 @@ type GetXAttrOutput struct {
 	XAttr   *XAttr   `xml:"XAttr"`
 }
 
+type InteropCheckResult struct {
+	XMLName     xml.Name            `xml:"InteropCheckResult"`
+	Bucket      string              `xml:"Bucket"`
+	Prefix      string              `xml:"Prefix"`
+	KeyMapping  string              `xml:"KeyMapping"`
+	Scanned     uint64              `xml:"Scanned"`
+	IsTruncated bool                `xml:"IsTruncated"`
+	NextMarker  string              `xml:"NextMarker,omitempty"`
+	Violations  []*InteropViolation `xml:"Violation"`
+}
+
 type ListXAttrsOutput struct {
 	XMLName xml.Name `xml:"ListXAttrsResult"`
 	Keys    []string `xml:"Keys"`
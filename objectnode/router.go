@@ -148,6 +148,13 @@ func (o *ObjectNode) registerApiRouters(router *mux.Router) {
 			Queries("location", "").
 			HandlerFunc(o.getBucketLocationHandler)
 
+		// Check bucket interop
+		// Notes: CubeFS extension, reports keys violating the S3 and POSIX mapping rules
+		r.NewRoute().Name(ActionToUniqueRouteName(proto.OSSCheckBucketInteropAction)).
+			Methods(http.MethodGet).
+			Queries("interop-check", "").
+			HandlerFunc(o.checkBucketInteropHandler)
+
 		// Get bucket policy
 		// API reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketPolicy.html
 		r.NewRoute().Name(ActionToUniqueRouteName(proto.OSSGetBucketPolicyAction)).
@@ -34,6 +34,8 @@ import (
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/data/blobstore"
 	"github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/bundle"
 	"github.com/cubefs/cubefs/util/config"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
@@ -154,6 +156,28 @@ const (
 
 	// s3 QoS config refresh interval
 	s3QoSRefreshIntervalSec = "s3QoSRefreshIntervalSec"
+
+	// Limit the memory held by the data of put requests. Each put request can keep up
+	// to putMaxInflightPackets packets (128KB each) in flight to the datanodes, requests
+	// beyond the memory limit wait up to putMemoryWaitSec and then fail with 429.
+	// Example:
+	//		{
+	//			"putMemoryLimitMB": 4096,
+	//			"putMaxInflightPackets": 32,
+	//			"putMemoryWaitSec": 30
+	//		}
+	configPutMemoryLimitMB      = "putMemoryLimitMB"
+	configPutMaxInflightPackets = "putMaxInflightPackets"
+	configPutMemoryWaitSec      = "putMemoryWaitSec"
+
+	// Bound the time a ListObjectsV2 request scans the directories of a bucket. The page
+	// is cut short with less than max-keys at the timeout, and the next request goes on
+	// from where it stopped. 0 scans until the page is full.
+	// Example:
+	//		{
+	//			"listObjectsTimeoutMs": 3000
+	//		}
+	configListObjectsTimeoutMs = "listObjectsTimeoutMs"
 )
 
 // Default of configuration value
@@ -164,6 +188,7 @@ const (
 	defaultMaxInodeAttrCacheNum   = 1000000
 	defaultS3QoSReloadIntervalSec = 300
 	defaultS3QoSConfName          = "s3qosInfo.conf"
+	defaultListObjectsTimeout     = 3 * time.Second
 	// ebs
 	MaxSizePutOnce = int64(1) << 23
 )
@@ -178,6 +203,11 @@ var (
 	writeThreads     = 4
 	readThreads      = 4
 	enableBlockcache bool
+	// bounds the memory of put requests, see configPutMemoryLimitMB
+	writeBuffers       = newWriteBufferPool(defaultPutMemoryLimit, defaultPutMaxInflightPackets, defaultPutMemoryWaitTimeout)
+	maxInflightPackets = defaultPutMaxInflightPackets
+	// bounds the scan of a ListObjectsV2 request, see configListObjectsTimeoutMs
+	listObjectsTimeout = defaultListObjectsTimeout
 )
 
 type ObjectNode struct {
@@ -204,6 +234,7 @@ type ObjectNode struct {
 	control                 common.Control
 	rateLimit               RateLimiter
 	limitMutex              sync.RWMutex
+	gateway                 *gatewaySync
 	disableCreateBucketByS3 bool
 }
 
@@ -326,6 +357,29 @@ func (o *ObjectNode) loadConfig(cfg *config.Config) (err error) {
 		blockCache = bcache.NewBcacheClient()
 	}
 
+	putMemoryLimit := cfg.GetInt64WithDefault(configPutMemoryLimitMB, defaultPutMemoryLimit/util.MB) * util.MB
+	maxInflightPackets = cfg.GetIntWithDefault(configPutMaxInflightPackets, defaultPutMaxInflightPackets)
+	putMemoryWait := time.Duration(cfg.GetIntWithDefault(configPutMemoryWaitSec, int(defaultPutMemoryWaitTimeout/time.Second))) * time.Second
+	for key, illegal := range map[string]bool{
+		configPutMemoryLimitMB:      putMemoryLimit <= 0,
+		configPutMaxInflightPackets: maxInflightPackets <= 0,
+		configPutMemoryWaitSec:      putMemoryWait <= 0,
+	} {
+		if illegal {
+			return config.NewIllegalConfigError(key)
+		}
+	}
+	writeBuffers = newWriteBufferPool(putMemoryLimit, maxInflightPackets, putMemoryWait)
+	log.LogInfof("loadConfig: putMemoryLimit(%v) putMaxInflightPackets(%v) putMemoryWait(%v)",
+		putMemoryLimit, maxInflightPackets, putMemoryWait)
+
+	listObjectsTimeoutMs := cfg.GetInt64WithDefault(configListObjectsTimeoutMs, defaultListObjectsTimeout.Milliseconds())
+	if listObjectsTimeoutMs < 0 {
+		return config.NewIllegalConfigError(configListObjectsTimeoutMs)
+	}
+	listObjectsTimeout = time.Duration(listObjectsTimeoutMs) * time.Millisecond
+	log.LogInfof("loadConfig: listObjectsTimeout(%v)", listObjectsTimeout)
+
 	return
 }
 
@@ -420,8 +474,15 @@ func handleStart(s common.Server, cfg *config.Config) (err error) {
 		o.limitMutex.Unlock()
 	}
 
+	o.startGatewaySync(fmt.Sprintf("%v:%v", ci.Ip, o.listen))
+
 	exporter.RegistConsul(ci.Cluster, cfg.GetString("role"), cfg)
 
+	bundle.RegisterState("volumes", func() interface{} { return o.vm.LoadedVolumes() })
+	bundle.RegisterState("server", func() interface{} {
+		return map[string]interface{}{"listen": o.listen, "region": o.region, "domains": o.domains}
+	})
+
 	// start rest api
 	if err = o.startMuxRestAPI(); err != nil {
 		log.LogInfof("handleStart: start rest api fail: err(%v)", err)
@@ -0,0 +1,90 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/util"
+)
+
+const (
+	defaultPutMemoryLimit        = 4 * util.GB
+	defaultPutMemoryWaitTimeout  = 30 * time.Second
+	defaultPutMaxInflightPackets = 32
+)
+
+var ErrPutMemoryExhausted = errors.New("no memory for put request")
+
+// writeBufferPool caps the memory held by the data of in-flight put requests.
+// Each request takes one slot for its whole life, which covers its read buffer
+// and the packets it may have in flight to the datanodes. Requests beyond the
+// limit wait for a free slot, and so stop reading their body, which pushes the
+// backpressure to the clients over tcp.
+type writeBufferPool struct {
+	slots       chan struct{}
+	buffers     sync.Pool
+	waitTimeout time.Duration
+}
+
+func newWriteBufferPool(memoryLimit int64, maxInflightPackets int, waitTimeout time.Duration) *writeBufferPool {
+	perRequest := int64(maxInflightPackets+1) * util.BlockSize
+	count := memoryLimit / perRequest
+	if count <= 0 {
+		count = 1
+	}
+	return &writeBufferPool{
+		slots: make(chan struct{}, count),
+		buffers: sync.Pool{New: func() interface{} {
+			return make([]byte, util.BlockSize)
+		}},
+		waitTimeout: waitTimeout,
+	}
+}
+
+func (p *writeBufferPool) get() (buf []byte, err error) {
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		timer := time.NewTimer(p.waitTimeout)
+		defer timer.Stop()
+		select {
+		case p.slots <- struct{}{}:
+		case <-timer.C:
+			return nil, ErrPutMemoryExhausted
+		}
+	}
+	return p.buffers.Get().([]byte), nil
+}
+
+func (p *writeBufferPool) put(buf []byte) {
+	p.buffers.Put(buf) // nolint: staticcheck
+	<-p.slots
+}
+
+// fillBuffer reads until buf is full or the reader ends. Unlike io.ReadFull, it
+// returns io.EOF for a short last block and keeps the errors of the reader as
+// they are, so a body shorter than its content length is still reported.
+func fillBuffer(r io.Reader, buf []byte) (n int, err error) {
+	for n < len(buf) && err == nil {
+		var nn int
+		nn, err = r.Read(buf[n:])
+		n += nn
+	}
+	return
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package objectnode
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBufferPool(t *testing.T) {
+	// room for two requests with one packet in flight each
+	pool := newWriteBufferPool(4*util.BlockSize, 1, 50*time.Millisecond)
+	buf1, err := pool.get()
+	require.NoError(t, err)
+	require.Len(t, buf1, util.BlockSize)
+	buf2, err := pool.get()
+	require.NoError(t, err)
+
+	_, err = pool.get()
+	require.Equal(t, ErrPutMemoryExhausted, err)
+
+	// a waiting request goes on once a slot is released
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.put(buf1)
+	}()
+	buf3, err := pool.get()
+	require.NoError(t, err)
+	pool.put(buf2)
+	pool.put(buf3)
+
+	// at least one request is always allowed
+	pool = newWriteBufferPool(1, 32, time.Millisecond)
+	buf, err := pool.get()
+	require.NoError(t, err)
+	pool.put(buf)
+}
+
+func TestFillBuffer(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 10)
+	buf := make([]byte, 4)
+	r := iotest.OneByteReader(bytes.NewReader(data))
+	var sizes []int
+	for {
+		n, err := fillBuffer(r, buf)
+		sizes = append(sizes, n)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	require.Equal(t, []int{4, 4, 2}, sizes)
+
+	// errors of the reader other than EOF are kept
+	n, err := fillBuffer(iotest.ErrReader(io.ErrUnexpectedEOF), buf)
+	require.Zero(t, n)
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+}
@@ -39,6 +39,8 @@ const (
 	AdminGetApiQpsLimit                               = "/admin/getApiQpsLimit"
 	AdminRemoveApiQpsLimit                            = "/admin/rmApiQpsLimit"
 	AdminGetCluster                                   = "/admin/getCluster"
+	AdminSummary                                      = "/admin/summary"
+	AdminPlacementSimulate                            = "/admin/placement/simulate"
 	AdminSetClusterInfo                               = "/admin/setClusterInfo"
 	AdminGetMonitorPushAddr                           = "/admin/getMonitorPushAddr"
 	AdminGetClusterDataNodes                          = "/admin/cluster/getAllDataNodes"
@@ -61,6 +63,32 @@ const (
 	AdminVolShrink                                    = "/vol/shrink"
 	AdminVolExpand                                    = "/vol/expand"
 	AdminVolForbidden                                 = "/vol/forbidden"
+	AdminVolPublish                                   = "/vol/publish"
+	AdminVolSetInterop                                = "/vol/setInterop"
+	AdminVolSetPlacementExclusion                     = "/vol/setPlacementExclusion"
+	AdminVolSetPlacementPolicy                        = "/vol/setPlacementPolicy"
+	AdminVolSetSLO                                    = "/vol/slo/set"
+	AdminVolSLO                                       = "/vol/slo"
+	AdminVolTimeline                                  = "/vol/timeline"
+	AdminVolReclaim                                   = "/vol/reclaim"
+	AdminVolReclaimBoost                              = "/vol/reclaim/boost"
+	AdminVolForkAdd                                   = "/vol/fork/add"
+	AdminVolForkRemove                                = "/vol/fork/remove"
+	AdminVolFenceAcquire                              = "/vol/fence/acquire"
+	AdminVolFenceList                                 = "/vol/fence/list"
+	AdminSLOReport                                    = "/slo/report"
+	AdminAuditCampaignCreate                          = "/audit/campaign/create"
+	AdminAuditCampaignGet                             = "/audit/campaign/get"
+	AdminAuditCampaignList                            = "/audit/campaign/list"
+	AdminAuditCampaignCancel                          = "/audit/campaign/cancel"
+	AdminAuditCampaignReport                          = "/audit/campaign/report"
+	AdminMountProfileSet                              = "/mountProfile/set"
+	AdminMountProfileGet                              = "/mountProfile/get"
+	AdminMountProfileList                             = "/mountProfile/list"
+	AdminMountProfileDelete                           = "/mountProfile/delete"
+	AdminClientEvict                                  = "/clientEviction/add"
+	AdminClientEvictRemove                            = "/clientEviction/remove"
+	AdminClientEvictList                              = "/clientEviction/list"
 	AdminVolEnableAuditLog                            = "/vol/auditlog"
 	AdminVolSetDpRepairBlockSize                      = "/vol/setDpRepairBlockSize"
 	AdminCreateVol                                    = "/admin/createVol"
@@ -68,9 +96,13 @@ const (
 	AdminClusterFreeze                                = "/cluster/freeze"
 	AdminClusterForbidMpDecommission                  = "/cluster/forbidMetaPartitionDecommission"
 	AdminClusterStat                                  = "/cluster/stat"
+	AdminClusterFeatures                              = "/cluster/features"
+	AdminClusterClockSkew                             = "/cluster/clockSkew"
+	AdminClusterEvents                                = "/cluster/events"
 	AdminSetCheckDataReplicasEnable                   = "/cluster/setCheckDataReplicasEnable"
 	AdminGetIP                                        = "/admin/getIp"
 	AdminCreateMetaPartition                          = "/metaPartition/create"
+	AdminSplitMetaPartitionAt                         = "/metaPartition/splitAt"
 	AdminSetMetaNodeThreshold                         = "/threshold/set"
 	AdminSetMasterVolDeletionDelayTime                = "/volDeletionDelayTime/set"
 	AdminSetMetaNodeGOGC                              = "/metaNodeGOGC/set"
@@ -100,6 +132,7 @@ const (
 	AdminQueryDecommissionFirstHostParallelInfo       = "/admin/queryDecommissionFirstHostParallelInfo"
 	AdminUpdateDecommissionLimit                      = "/admin/updateDecommissionLimit"
 	AdminQueryDecommissionLimit                       = "/admin/queryDecommissionLimit"
+	AdminQueryDecommissionProgress                    = "/admin/queryDecommissionProgress"
 	AdminQueryDecommissionFailedDisk                  = "/admin/queryDecommissionFailedDisk"
 	AdminAbortDecommissionDisk                        = "/admin/abortDecommissionDisk"
 	AdminResetDataPartitionRestoreStatus              = "/admin/resetDataPartitionRestoreStatus"
@@ -111,6 +144,8 @@ const (
 	AdminQueryDataNodeDecommissionInfoStat = "/admin/queryDataNodeDecommissionInfoStat"
 	AdminSetFileStats                      = "/admin/setFileStats"
 	AdminGetFileStats                      = "/admin/getFileStats"
+	AdminSetZoneCost                       = "/admin/setZoneCost"
+	AdminGetZoneCost                       = "/admin/getZoneCost"
 	AdminGetClusterValue                   = "/admin/getClusterValue"
 	AdminSetClusterUuidEnable              = "/admin/setClusterUuidEnable"
 	AdminGetClusterUuid                    = "/admin/getClusterUuid"
@@ -150,6 +185,8 @@ const (
 	ClientMetaPartition      = "/metaPartition/get"
 	ClientVolStat            = "/client/volStat"
 	ClientMetaPartitions     = "/client/metaPartitions"
+	ClientZoneCost           = "/client/zoneCost"
+	ClientForkPartitions     = "/client/forkPartitions"
 	GetAllClients            = "/getAllClients"
 
 	// qos api
@@ -173,6 +210,11 @@ const (
 	RemoveRaftNode = "/raftNode/remove"
 	RaftStatus     = "/get/raftStatus"
 
+	// standby master APIs, served by the master requested
+	AdminMasterStandbyStatus  = "/master/standby/status"
+	AdminMasterStandbyFence   = "/master/standby/fence"
+	AdminMasterStandbyPromote = "/master/standby/promote"
+
 	// node APIs
 
 	AddDataNode                        = "/dataNode/add"
@@ -202,6 +244,7 @@ const (
 	DeleteBackupDirectories            = "/disk/deleteBackupDirectories"
 	QueryBackupDirectories             = "/disk/queryBackupDirectories"
 	GetDataNode                        = "/dataNode/get"
+	GetDataNodeLoadProgress            = "/dataNode/loadProgress"
 	SetDpCntLimit                      = "/dataNode/setDpCntLimit"
 	AddMetaNode                        = "/metaNode/add"
 	SetMpCntLimit                      = "/metaNode/setMpCntLimit"
@@ -261,6 +304,18 @@ const (
 	GetNodeSet      = "/nodeSet/get"
 	UpdateNodeSet   = "/nodeSet/update"
 
+	AdminSetZoneIsolation = "/zone/setIsolation"
+	AdminBalanceLeaders   = "/admin/balanceLeaders"
+
+	AdminDataBalanceEnable = "/dataBalance/enable"
+	AdminDataBalancePause  = "/dataBalance/pause"
+	AdminDataBalanceStatus = "/dataBalance/status"
+	AdminDataBalanceZone   = "/dataBalance/zone"
+
+	AdminMaintenanceStart = "/maintenance/start"
+	AdminMaintenanceStop  = "/maintenance/stop"
+	AdminMaintenanceList  = "/maintenance/list"
+
 	// Header keys
 	SkipOwnerValidation = "Skip-Owner-Validation"
 	ForceDelete         = "Force-Delete"
@@ -298,6 +353,9 @@ const (
 	S3QoSSet                     = "/s3/qos/set"
 	S3QoSGet                     = "/s3/qos/get"
 	S3QoSDelete                  = "/s3/qos/delete"
+	S3NodeHeartbeat              = "/s3/node/heartbeat"
+	S3NodeList                   = "/s3/node/list"
+	S3BucketChanged              = "/s3/bucket/changed"
 	AdminEnablePersistAccessTime = "/vol/enablePersistAccessTime"
 
 	AdminVolAddAllowedStorageClass = "/vol/addAllowedStorageClass"
@@ -306,10 +364,13 @@ const (
 	FlashNodeSet               = "/flashNode/set"
 	FlashNodeRemove            = "/flashNode/remove"
 	FlashNodeRemoveAllInactive = "/flashNode/removeAllInactive"
+	FlashNodeOffline           = "/flashNode/offline"
 	FlashNodeGet               = "/flashNode/get"
 	FlashNodeList              = "/flashNode/list"
+	AdminFlashNodeStats        = "/flashNode/stats"
 	FlashNodeSetReadIOLimits   = "/flashNode/setReadIOLimits"
 	FlashNodeSetWriteIOLimits  = "/flashNode/SetWriteIOLimits"
+	FlashNodeSetReadOnly       = "/flashNode/setReadOnly"
 
 	// Flash Mannual Task
 	CreateFlashNodeManualTask = "/flashNode/createFlashManualTask"
@@ -318,15 +379,26 @@ const (
 	GetFlashNodeTaskResponse = "/flashNode/response"
 
 	// FlashGroup API
-	AdminFlashGroupTurn       = "/flashGroup/turn"
-	AdminFlashGroupCreate     = "/flashGroup/create"
-	AdminFlashGroupSet        = "/flashGroup/set"
-	AdminFlashGroupRemove     = "/flashGroup/remove"
-	AdminFlashGroupNodeAdd    = "/flashGroup/addFlashNode"
-	AdminFlashGroupNodeRemove = "/flashGroup/removeFlashNode"
-	AdminFlashGroupGet        = "/flashGroup/get"
-	AdminFlashGroupList       = "/flashGroup/list"
-	ClientFlashGroups         = "/client/flashGroups"
+	AdminFlashGroupTurn        = "/flashGroup/turn"
+	AdminFlashGroupCreate      = "/flashGroup/create"
+	AdminFlashGroupBatchCreate = "/flashGroup/batchCreate"
+	AdminFlashGroupSet         = "/flashGroup/set"
+	AdminFlashGroupRemove      = "/flashGroup/remove"
+	AdminFlashGroupNodeAdd     = "/flashGroup/addFlashNode"
+	AdminFlashGroupNodeRemove  = "/flashGroup/removeFlashNode"
+	AdminFlashGroupGet         = "/flashGroup/get"
+	AdminFlashGroupList        = "/flashGroup/list"
+	AdminFlashGroupAutoScale   = "/flashGroup/autoScale"
+	AdminFlashGroupScaleEvent  = "/flashGroup/scaleEvents"
+	AdminFlashGroupAudit       = "/flashGroup/audit"
+	AdminFlashGroupAutoHeal    = "/flashGroup/autoHeal"
+	AdminFlashGroupRebalance   = "/flashGroup/rebalanceSlots"
+	AdminFlashGroupVolumes     = "/flashGroup/setVolumes"
+	AdminFlashGroupCacheKeys   = "/flashGroup/cacheKeys"
+	AdminFlashGroupPurgeCache  = "/flashGroup/purgeCache"
+	AdminFlashGroupMoveSlots   = "/flashGroup/moveSlots"
+	ClientFlashGroups          = "/client/flashGroups"
+	ClientFlashCacheToken      = "/client/flashCacheToken"
 )
 
 var GApiInfo map[string]string = map[string]string{
@@ -442,11 +514,18 @@ const (
 	LeaderRetryTimeoutKey  = "leaderRetryTimeout"
 	VolEnableDirectRead    = "directRead"
 	VolIgnoreTinyRecover   = "ignoreTinyRecover"
+	VolSyncMirrorWrite     = "syncMirrorWrite"
+	VolRequireTLS          = "requireTLS"
+	VolInlineDataThreshold = "inlineDataThreshold"
+	VolSmallFileThreshold  = "smallFileThreshold"
+	VolConsistencyMode     = "consistencyMode"
+	VolMetaWitnessNum      = "mpWitnessNum"
 	HostKey                = "host"
 	ClientVerKey           = "clientVer"
 	RoleKey                = "role"
 	BcacheOnlyForNotSSDKey = "enableBcacheNotSSD"
 	EnableRemoteCache      = "enableRemoteCache"
+	MountIDKey             = "mountId"
 )
 
 // const TimeFormat = "2006-01-02 15:04:05"
@@ -599,6 +678,9 @@ type VolumeVerStrategy struct {
 	Enable      bool
 	ForceUpdate bool
 	UTime       time.Time
+	// the metadata of the volume is immutable for the seconds after each snapshot of the strategy
+	ImmutableSec   int
+	ImmutableUntil int64 // unix second
 }
 
 func (v *VolumeVerStrategy) GetPeriodic() int {
@@ -614,6 +696,10 @@ func (v *VolumeVerStrategy) TimeUp(curTime time.Time) bool {
 	return v.UTime.Add(time.Second * time.Duration(v.GetPeriodicSecond())).Before(curTime)
 }
 
+func (v *VolumeVerStrategy) IsImmutable(curTime time.Time) bool {
+	return curTime.Unix() < v.ImmutableUntil
+}
+
 type VolumeVerInfo struct {
 	Name             string
 	VerSeq           uint64
@@ -638,6 +724,7 @@ type ClusterInfo struct {
 	ClusterUuidEnable                  bool
 	ClusterEnableSnapshot              bool
 	RaftPartitionCanUsingDifferentPort bool
+	Features                           FeatureBits // features enabled on the whole cluster
 }
 
 // CreateDataPartitionRequest defines the request to create a data partition.
@@ -655,6 +742,9 @@ type CreateDataPartitionRequest struct {
 	DecommissionedDisks []string
 	IsMultiVer          bool
 	VerSeq              uint64
+	// a read only copy of the partition out of its raft group, synced from
+	// the Hosts to spread the reads of a hot partition
+	ReadReplica bool
 }
 
 // CreateDataPartitionResponse defines the response to the request of creating a data partition.
@@ -671,6 +761,7 @@ type DeleteDataPartitionRequest struct {
 	PartitionSize     int
 	Force             bool
 	DecommissionType  uint32
+	Tombstone         bool // keep the data as a tombstone, the replica is dropped by decommission or migration
 }
 
 // DeleteDataPartitionResponse defines the response to the request of deleting a data partition.
@@ -835,6 +926,26 @@ type TxInfos struct {
 type FlashNodeHeartBeatInfos struct {
 	FlashNodeHandleReadTimeout   int
 	FlashNodeReadDataNodeTimeout int
+	FlashNodePeerFillEnable      bool
+	FlashNodePeerFillTimeout     int
+	FlashNodePeers               []string // other active members of the flash group of the flash node
+	FlashNodeAdmissionEnable     bool
+	FlashNodeAlwaysAdmitVols     []string // volumes whose blocks bypass the admission filter
+	FlashNodeDraining            bool     // the flash node is read only or its flash group is draining, nothing is cached
+	FlashNodeReadLimit           FlashReadLimit
+	FlashNodeVolReadLimits       map[string]FlashReadLimit // volumes whose reads are limited on each flash node
+	FlashNodeAuthVols            []string                  // volumes whose cache is read with a flash cache token only
+	FlashNodeCacheKey            []byte                    // verifies the flash cache tokens, set if FlashNodeAuthVols is not empty
+}
+
+// FlashReadLimit limits the reads a flash node serves, zero is unlimited.
+type FlashReadLimit struct {
+	MBps int64 // bandwidth in MB per second
+	Iops int64
+}
+
+func (l FlashReadLimit) Unlimited() bool {
+	return l.MBps <= 0 && l.Iops <= 0
 }
 
 // HeartBeatRequest define the heartbeat request.
@@ -854,14 +965,32 @@ type HeartBeatRequest struct {
 	DecommissionDisks    []string // NOTE: for datanode
 	VolDpRepairBlockSize map[string]uint64
 	DpBackupTimeout      string
+	// the dropped replicas are kept as tombstones for the retention, if not 0
+	ReplicaTombstoneRetention string
 
 	NotifyForbidWriteOpOfProtoVer0 bool     // whether forbid by node granularity, will notify to nodes
 	VolsForbidWriteOpOfProtoVer0   []string // whether forbid by volume granularity, will notify to partitions of volume in nodes
 	DirectReadVols                 []string
 	IgnoreTinyRecoverVols          []string
+	SyncMirrorWriteVols            []string
+	DataNodeZones                  map[string]string // addr -> zone, only sent to the nodes not holding DataNodeZonesVersion
+	DataNodeZonesVersion           uint64            // the version of DataNodeZones, sent with SyncMirrorWriteVols
+	TLSRequiredVols                []string          // the clients of these volumes are served over TLS only
 	MetaNodeGOGC                   int
 	DataNodeGOGC                   int
 	FlashNodeHeartBeatInfos
+	EvictedClients []*ClientEviction
+	VolFences      []*VolFence      // the writes with an older token of the volume are rejected
+	ImmutableVols  map[string]int64 // the volume is immutable until the unix second, its metadata and extents
+	// the deleted files of the volume are deleted faster while boosted
+	ReclaimBoostVols map[string]*ReclaimBoost
+	// the volumes forked by others, whose extents are not deleted while forked
+	ForkSourceVols []string
+	// the seq of the last partition reports of the data node the master
+	// applied, the node replies the changes since them only, 0 asks for all
+	ReportSeq uint64
+	// the node is in a maintenance and defers its background work
+	Maintenance bool
 }
 
 // DataPartitionReport defines the partition report.
@@ -882,6 +1011,9 @@ type DataPartitionReport struct {
 	ReadOnlyReasons            uint32
 	IsMissingTinyExtent        bool
 	IsRepairing                bool
+	ReadOps                    uint64 // stream reads served since the data node started
+	ReadReplica                bool
+	ReadReplicaReady           bool // synced and no write applied to the partition since
 }
 
 type DataNodeQosResponse struct {
@@ -939,6 +1071,100 @@ type DataNodeHeartbeatResponse struct {
 	DiskOpLogs                       []OpLog `json:"DiskOpLog"`
 	DpOpLogs                         []OpLog `json:"DpOpLog"`
 	ReceivedForbidWriteOpOfProtoVer0 bool
+	Features                         FeatureBits
+	ResourceThrottle                 ResourceThrottleState `json:"resourceThrottle"`
+	Clock                            NodeClock             `json:"clock"`
+	LoadProgress                     DataNodeLoadProgress  `json:"loadProgress"`
+	// ReportSeq identifies the partition reports, DeltaBaseSeq is the seq of
+	// the reports they are the changes since, 0 if they are all of them
+	ReportSeq         uint64   `json:",omitempty"`
+	DeltaBaseSeq      uint64   `json:",omitempty"`
+	RemovedPartitions []uint64 `json:",omitempty"` // reported by DeltaBaseSeq and gone since
+	// the version of the zones of the data nodes the node holds, see
+	// HeartBeatRequest.DataNodeZonesVersion
+	DataNodeZonesVersion uint64 `json:",omitempty"`
+}
+
+// NodeClock stamps a heartbeat with the clock of the node, for the master to
+// measure how far it is from its own.
+type NodeClock struct {
+	RecvTime  int64 `json:"recvTime"`  // unix nanoseconds the node received the heartbeat
+	ReplyTime int64 `json:"replyTime"` // unix nanoseconds the node replied to it
+}
+
+// NodeClockSkew is the clock skew of a node measured by the master, positive
+// if the clock of the node is ahead.
+type NodeClockSkew struct {
+	Addr       string `json:"addr"`
+	Role       string `json:"role"`
+	SkewMs     int64  `json:"skewMs"`
+	DelayMs    int64  `json:"delayMs"`    // round trip of the heartbeat the skew is measured on, bounding its error
+	MeasuredAt int64  `json:"measuredAt"` // unix time
+	Exceeded   bool   `json:"exceeded"`   // over the warning threshold
+	Gated      bool   `json:"gated"`      // over the limit, the node is given no new partitions
+}
+
+// ClockSkewView lists the clock skew of the nodes, the most skewed first.
+type ClockSkewView struct {
+	WarnMs  uint64           `json:"warnMs"`
+	LimitMs uint64           `json:"limitMs"` // 0 if the nodes are not gated
+	Nodes   []*NodeClockSkew `json:"nodes"`
+}
+
+// ResourceThrottleState reports how close a node is to the limits of its cgroup,
+// and whether it sheds low priority ops to stay away from them.
+type ResourceThrottleState struct {
+	MemoryThrottled bool    `json:"memoryThrottled"`
+	CPUThrottled    bool    `json:"cpuThrottled"`
+	MemoryLimit     uint64  `json:"memoryLimit"` // 0 if not limited
+	MemoryUsed      uint64  `json:"memoryUsed"`
+	CPULimit        float64 `json:"cpuLimit"` // cores, 0 if not limited
+	CPUUsed         float64 `json:"cpuUsed"`
+	Since           int64   `json:"since"` // unix time throttling started
+	RejectedOps     uint64  `json:"rejectedOps"`
+}
+
+func (s ResourceThrottleState) Throttled() bool {
+	return s.MemoryThrottled || s.CPUThrottled
+}
+
+func (s ResourceThrottleState) String() string {
+	if s.MemoryLimit == 0 && s.CPULimit == 0 {
+		return "no cgroup limit"
+	}
+	status := "normal"
+	if s.Throttled() {
+		status = fmt.Sprintf("throttled(memory:%v cpu:%v) since %v rejected %v ops",
+			s.MemoryThrottled, s.CPUThrottled, time.Unix(s.Since, 0).Format(TimeFormat), s.RejectedOps)
+	}
+	return fmt.Sprintf("%v, memory %v/%v, cpu %.2f/%.2f cores", status, s.MemoryUsed, s.MemoryLimit, s.CPUUsed, s.CPULimit)
+}
+
+// DataNodeLoadProgress reports how far a data node is in loading its partitions
+// since it started. The extents of a partition loaded from its checkpoint are
+// verified in background, the partition is not repaired until they are.
+type DataNodeLoadProgress struct {
+	Addr                string `json:"addr,omitempty"`
+	StartTime           int64  `json:"startTime"`  // unix time
+	FinishTime          int64  `json:"finishTime"` // unix time all the partitions are loaded and verified, 0 if not yet
+	Partitions          int    `json:"partitions"` // found on the disks
+	LoadedPartitions    int    `json:"loadedPartitions"`
+	VerifyingPartitions int    `json:"verifyingPartitions"`
+}
+
+func (p DataNodeLoadProgress) Done() bool {
+	return p.FinishTime != 0
+}
+
+func (p DataNodeLoadProgress) String() string {
+	if p.StartTime == 0 {
+		return "unknown"
+	}
+	if p.Done() {
+		return fmt.Sprintf("loaded %v partitions in %v", p.Partitions, time.Duration(p.FinishTime-p.StartTime)*time.Second)
+	}
+	return fmt.Sprintf("loading %v/%v partitions, verifying %v, since %v", p.LoadedPartitions, p.Partitions,
+		p.VerifyingPartitions, time.Unix(p.StartTime, 0).Format(TimeFormat))
 }
 
 type OpLog struct {
@@ -970,6 +1196,9 @@ type MetaPartitionReport struct {
 	StatByMigrateStorageClass []*StatOfStorageClass
 	LocalPeers                []Peer
 	ReadOnlyReasons           uint32
+	Reclaim                   *MetaPartitionReclaim `json:",omitempty"`
+	Ops                       uint64                // client ops handled since loaded
+	MemUsed                   uint64                // estimated share of the memory used by the node
 }
 
 // MetaNodeHeartbeatResponse defines the response to the meta node heartbeat request.
@@ -984,6 +1213,9 @@ type MetaNodeHeartbeatResponse struct {
 	Result                           string
 	CpuUtil                          float64 `json:"cpuUtil"`
 	ReceivedForbidWriteOpOfProtoVer0 bool
+	Features                         FeatureBits
+	ResourceThrottle                 ResourceThrottleState `json:"resourceThrottle"`
+	Clock                            NodeClock             `json:"clock"`
 }
 
 // LcNodeHeartbeatResponse defines the response to the lc node heartbeat.
@@ -993,6 +1225,7 @@ type LcNodeHeartbeatResponse struct {
 	LcTaskCountLimit      int
 	LcScanningTasks       map[string]*LcNodeRuleTaskResponse
 	SnapshotScanningTasks map[string]*SnapshotVerDelTaskResponse
+	Clock                 NodeClock `json:"clock"`
 }
 
 type FlashNodeDiskCacheStat struct {
@@ -1003,6 +1236,8 @@ type FlashNodeDiskCacheStat struct {
 	HasAlloc  int64
 	FreeSpace int64
 	HitRate   float64
+	Hits      int64
+	Misses    int64
 	Evicts    int
 	ReadRps   int
 	KeyNum    int
@@ -1019,6 +1254,10 @@ type FlashNodeHeartbeatResponse struct {
 	LimiterStatus           *FlashNodeLimiterStatusInfo
 	FlashNodeTaskCountLimit int
 	ManualScanningTasks     map[string]*FlashNodeManualTaskResponse
+	CacheCapacity           int64 // bytes the flashnode can cache on all its disks
+	CacheStat               *FlashNodeCacheStat
+	CacheRecovery           *FlashNodeCacheRecovery `json:",omitempty"`
+	Clock                   NodeClock               `json:"clock"`
 }
 
 type FlashNodeLimiterStatus struct {
@@ -1049,6 +1288,7 @@ type DeleteFileResponse struct {
 // DeleteMetaPartitionRequest defines the request of deleting a meta partition.
 type DeleteMetaPartitionRequest struct {
 	PartitionID uint64
+	Tombstone   bool // keep the data as a tombstone, the replica is dropped by decommission or migration
 }
 
 // DeleteMetaPartitionResponse defines the response to the request of deleting a meta partition.
@@ -1094,6 +1334,7 @@ type MetaPartitionDecommissionResponse struct {
 // MetaPartitionLoadRequest defines the request to load meta partition.
 type MetaPartitionLoadRequest struct {
 	PartitionID uint64
+	Checksum    bool // also compute the crc of the inode and dentry trees, used by audit campaigns
 }
 
 type RaftInfo struct {
@@ -1114,6 +1355,9 @@ type MetaPartitionLoadResponse struct {
 	InodeCount  uint64
 	Addr        string
 	RaftInfo    RaftInfo
+	// set when the request asks for the checksum, ApplyID is the one the trees were taken at
+	InodeCrc  uint32
+	DentryCrc uint32
 }
 
 // DataPartitionResponse defines the response from a data node to the master that is related to a data partition.
@@ -1128,6 +1372,9 @@ type DataPartitionResponse struct {
 	IsRecover     bool
 	IsDiscard     bool
 	MediaType     uint32
+	// the read replicas of a hot partition, which serve the follower reads
+	// as the Hosts do
+	ReadReplicas []string `json:",omitempty"`
 }
 
 // DataPartitionsView defines the view of a data partition
@@ -1135,6 +1382,9 @@ type DataPartitionsView struct {
 	DataPartitions []*DataPartitionResponse
 	VolReadOnly    bool // if true, refresh dps even rw count less than 1
 	StatByClass    []*StatOfStorageClass
+	// the partitions of the fork sources of the volume, which it reads but never
+	// writes to nor deletes from, only returned by /client/forkPartitions
+	ForkPartitions []*DataPartitionResponse `json:",omitempty"`
 }
 
 type DiskDataPartitionsView struct {
@@ -1162,6 +1412,7 @@ type MetaPartitionView struct {
 	TxRbDenCnt         uint64
 	IsRecover          bool
 	Members            []string
+	Witnesses          []string // members that vote but hold no data, never read from
 	LeaderAddr         string
 	Status             int8
 	Freeze             int8
@@ -1253,6 +1504,37 @@ const (
 
 const DefaultDataPartitionBackupTimeOut = time.Hour * 24 * 7
 
+// The files not larger than the inline data threshold of the volume are stored
+// in their inode by the client, and read from the MetaNode without any extent.
+// The threshold is limited, as the data is kept in the memory of the MetaNodes.
+const MaxInlineDataThreshold = 4 * util.KB
+
+// The files not larger than the small file threshold of the volume are packed
+// as records into the container extents of the DataNodes, instead of taking a
+// tiny extent range each. The threshold is limited by the tiny extent writes.
+const MaxSmallFileThreshold = util.DefaultTinySizeLimit
+
+// The consistency mode of a volume is the visibility of the dentries changed by
+// the other clients to its lookups and listings. In the relaxed mode, the
+// default, a client caches them for a while. In the strict mode, it revalidates
+// what it caches with the version of the directory on the MetaNode, so that the
+// changes of the other clients are seen at once.
+const (
+	ConsistencyModeRelaxed = "relaxed"
+	ConsistencyModeStrict  = "strict"
+)
+
+func IsValidConsistencyMode(mode string) bool {
+	return mode == ConsistencyModeRelaxed || mode == ConsistencyModeStrict
+}
+
+// The witnesses of a meta partition vote in its raft group but hold no data, so
+// 2 replicas and a witness tolerate one failure at the memory cost of 2 replicas.
+// A majority of the replicas hold the data, so that every quorum has one of them.
+func MaxMetaWitnessNum(replicaNum uint8) int {
+	return (int(replicaNum) - 1) / 2
+}
+
 func QosTypeString(factorType uint32) string {
 	switch factorType {
 	case IopsReadType:
@@ -1340,6 +1622,12 @@ type SimpleVolView struct {
 	MetaFollowerRead        bool
 	DirectRead              bool
 	IgnoreTinyRecover       bool
+	SyncMirrorWrite         bool
+	RequireTLS              bool   // the data and meta nodes serve the clients over TLS only
+	InlineDataThreshold     int64  // 0 disables inline data
+	SmallFileThreshold      int64  // 0 disables small file packing
+	ConsistencyMode         string // "" for ConsistencyModeRelaxed
+	MpWitnessNum            uint8  // replicas of the new meta partitions that are witnesses
 	MaximallyRead           bool
 	NeedToLowerReplica      bool
 	Authenticate            bool
@@ -1373,6 +1661,11 @@ type SimpleVolView struct {
 	// multi version snapshot
 	LatestVer               uint64
 	Forbidden               bool
+	Published               bool
+	PublishTime             int64
+	Interop                 InteropPolicy
+	PlacementExclusion      PlacementExclusion
+	PlacementPolicy         PlacementPolicy
 	DisableAuditLog         bool
 	DeleteExecTime          time.Time
 	DpRepairBlockSize       uint64
@@ -1380,6 +1673,10 @@ type SimpleVolView struct {
 	AccessTimeInterval      int64
 	EnablePersistAccessTime bool
 
+	// the volumes the files of the volume are forked from, and the volumes forked from it
+	ForkSources []string `json:",omitempty"`
+	ForkTargets []string `json:",omitempty"`
+
 	// hybrid cloud
 	VolStorageClass          uint32
 	AllowedStorageClass      []uint32
@@ -1394,9 +1691,17 @@ type SimpleVolView struct {
 	RemoteCacheMaxFileSizeGB     int64
 	RemoteCacheOnlyForNotSSD     bool
 	RemoteCacheMultiRead         bool
+	RemoteCacheAlwaysAdmit       bool
+	RemoteCacheAuth              bool  // the flash nodes serve the clients holding a flash cache token only
+	RemoteCacheReadAheadMB       int64 // blocks prepared ahead of sequential reads, 0 disables
+	RemoteCacheMetaTTL           int64 // seconds the flash groups cache the lookups and listings of the clients, 0 disables
+	RemoteCacheReadLimitMBps     int64 // reads of the volume served by each flash node, 0 is unlimited
+	RemoteCacheReadLimitIops     int64
 	FlashNodeTimeoutCount        int64
 	RemoteCacheSameZoneTimeout   int64 // microsecond
 	RemoteCacheSameRegionTimeout int64 // ms
+	RemoteCacheHedgeDelay        int64 // ms, see the remoteCacheReadPolicy mount option
+	RemoteCacheFallbackTimeout   int64 // ms
 
 	QosInfo QosSimpleInfo // qos status
 
@@ -1486,6 +1791,7 @@ type ZoneView struct {
 	MetaNodesetSelector string
 	NodeSet             map[uint64]*NodeSetView
 	DataMediaType       string
+	Isolation           string
 }
 
 type NodeSetView struct {
@@ -1663,6 +1969,24 @@ func IsStorageClassBlobStore(storageClass uint32) bool {
 	return storageClass == StorageClass_BlobStore
 }
 
+// StorageClassKey is the xattr accepted by the client to set the storageClass of
+// an empty file, or of the inodes created in a directory.
+const StorageClassKey = "cfs.storageclass"
+
+// ParseStorageClass parses a storageClass from its name, case insensitive, or its number.
+func ParseStorageClass(value string) (storageClass uint32, err error) {
+	for class, name := range storageClassStringMap {
+		if strings.EqualFold(value, name) {
+			return class, nil
+		}
+	}
+	v, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || (uint32(v) != StorageClass_Unspecified && !IsValidStorageClass(uint32(v))) {
+		return 0, fmt.Errorf("invalid storageClass(%v)", value)
+	}
+	return uint32(v), nil
+}
+
 func IsVolSupportStorageClass(allowedStorageClass []uint32, storeClass uint32) bool {
 	for _, storageClass := range allowedStorageClass {
 		if storageClass == storeClass {
@@ -1747,12 +2071,22 @@ type FlashNodeSetIOLimitsRequest struct {
 	Factor int
 }
 
+// IsFlashNodeLimitError reports whether the client reads the data nodes instead
+// after the error, without taking the flash node as a failed one.
 func IsFlashNodeLimitError(err error) bool {
+	// the token is refreshed from the master for the next reads
+	if strings.HasPrefix(err.Error(), ErrFlashCacheTokenInvalid.Error()) {
+		return true
+	}
 	if strings.Compare(err.Error(), util.LimitedRunError.Error()) == 0 ||
 		strings.Compare(err.Error(), util.LimitedFlowError.Error()) == 0 ||
 		strings.Compare(err.Error(), util.LimitedIoError.Error()) == 0 ||
 		strings.Compare(err.Error(), "context deadline exceeded") == 0 ||
-		strings.Compare(err.Error(), "require data is caching") == 0 {
+		strings.Compare(err.Error(), "require data is caching") == 0 ||
+		strings.Compare(err.Error(), ErrFlashNodeNotAdmitted.Error()) == 0 ||
+		strings.Compare(err.Error(), ErrFlashGroupDraining.Error()) == 0 ||
+		strings.Compare(err.Error(), ErrFlashNodeReadLimited.Error()) == 0 ||
+		strings.Compare(err.Error(), ErrTLSRequired.Error()) == 0 {
 		return true
 	}
 	return false
@@ -51,3 +51,21 @@ func TestHTTPReplyRaw(t *testing.T) {
 	require.Equal(t, int(177), aStruct.Outter)
 	require.Equal(t, "", aStruct.inner)
 }
+
+func TestParseStorageClass(t *testing.T) {
+	for value, expected := range map[string]uint32{
+		"ReplicaSSD":  StorageClass_Replica_SSD,
+		"replicahdd":  StorageClass_Replica_HDD,
+		"3":           StorageClass_BlobStore,
+		"Unspecified": StorageClass_Unspecified,
+		"0":           StorageClass_Unspecified,
+	} {
+		storageClass, err := ParseStorageClass(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, storageClass, value)
+	}
+	for _, value := range []string{"", "4", "-1", "nvme"} {
+		_, err := ParseStorageClass(value)
+		require.Error(t, err, value)
+	}
+}
@@ -41,6 +41,7 @@ type AdminTask struct {
 	OperatorAddr string
 	Status       int8
 	SendTime     int64
+	SendTimeNano int64 `json:",omitempty"` // unix nanoseconds of the last send, echoed by the nodes
 	CreateTime   int64
 	SendCount    uint8
 	Request      interface{}
@@ -0,0 +1,124 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	AuditCampaignRunning   = "running"
+	AuditCampaignCompleted = "completed"
+	AuditCampaignExpired   = "expired" // the time budget ran out before every partition was verified
+	AuditCampaignCanceled  = "canceled"
+
+	AuditPartitionData = "data"
+	AuditPartitionMeta = "meta"
+
+	AuditResultPending = "pending"
+	AuditResultPassed  = "passed"
+	AuditResultFailed  = "failed"
+	AuditResultSkipped = "skipped" // the partition could not be verified, e.g. it was recovering
+
+	AuditSignHMACSHA256 = "HMAC-SHA256"
+	AuditSignSHA256     = "SHA256" // digest only, no signing key is configured on the master
+)
+
+// AuditPartitionResult is the verification result of one partition in an audit campaign.
+type AuditPartitionResult struct {
+	PartitionID uint64
+	Type        string
+	Result      string
+	Checked     uint64   // extents compared for data partitions, inodes and dentries for meta partitions
+	Mismatches  []string `json:",omitempty"`
+	Reason      string   `json:",omitempty"`
+	FinishTime  int64    `json:",omitempty"`
+}
+
+type AuditCampaignView struct {
+	ID          string
+	Volume      string
+	Status      string
+	CreateTime  int64
+	Deadline    int64
+	FinishTime  int64
+	Concurrency int
+	Total       int
+	Done        int
+	Passed      int
+	Failed      int
+	Skipped     int
+	Partitions  []*AuditPartitionResult `json:",omitempty"`
+}
+
+// AuditReport is the final report of a finished campaign. Signature covers the json
+// encoding of the report with Signature left empty.
+type AuditReport struct {
+	CampaignID     string
+	Cluster        string
+	Volume         string
+	Status         string
+	StartTime      int64
+	FinishTime     int64
+	DataPartitions int
+	MetaPartitions int
+	Passed         int
+	Failed         int
+	Skipped        int
+	Failures       []*AuditPartitionResult
+	Unverified     []*AuditPartitionResult
+	SignAlgorithm  string
+	Signature      string
+}
+
+func (r *AuditReport) digest(key []byte) (sign string, err error) {
+	unsigned := *r
+	unsigned.Signature = ""
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return
+	}
+	if len(key) == 0 {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Sign signs the report with HMAC-SHA256, or only digests it with SHA256 if key is empty.
+func (r *AuditReport) Sign(key []byte) (err error) {
+	r.SignAlgorithm = AuditSignSHA256
+	if len(key) > 0 {
+		r.SignAlgorithm = AuditSignHMACSHA256
+	}
+	r.Signature, err = r.digest(key)
+	return
+}
+
+func (r *AuditReport) Verify(key []byte) bool {
+	if (r.SignAlgorithm == AuditSignHMACSHA256) != (len(key) > 0) {
+		return false
+	}
+	sign, err := r.digest(key)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(sign), []byte(r.Signature))
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditReportSign(t *testing.T) {
+	report := &AuditReport{CampaignID: "vol_1", Volume: "vol", Status: AuditCampaignCompleted, Passed: 3}
+	require.NoError(t, report.Sign(nil))
+	require.Equal(t, AuditSignSHA256, report.SignAlgorithm)
+	require.True(t, report.Verify(nil))
+	require.False(t, report.Verify([]byte("key")))
+
+	key := []byte("secret")
+	require.NoError(t, report.Sign(key))
+	require.Equal(t, AuditSignHMACSHA256, report.SignAlgorithm)
+	require.True(t, report.Verify(key))
+	require.False(t, report.Verify([]byte("other")))
+	require.False(t, report.Verify(nil))
+
+	report.Failures = append(report.Failures, &AuditPartitionResult{PartitionID: 1, Type: AuditPartitionData})
+	require.False(t, report.Verify(key))
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"net"
+)
+
+// ClientEviction fences a client out of the cluster. Metanodes and datanodes
+// reject every request coming from IP, or only the requests to Volume if it is
+// set. MountID records the mount the eviction was asked for, if any.
+type ClientEviction struct {
+	IP         string `json:"ip"`
+	Volume     string `json:"vol,omitempty"`
+	MountID    string `json:"mountId,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	CreateTime int64  `json:"createTime"`
+	ExpireTime int64  `json:"expireTime,omitempty"` // unix seconds, 0 keeps it until removed
+}
+
+// Key identifies the eviction, there is at most one for an IP and volume.
+func (e *ClientEviction) Key() string {
+	if e.Volume == "" {
+		return e.IP
+	}
+	return e.IP + "/" + e.Volume
+}
+
+func (e *ClientEviction) Expired(now int64) bool {
+	return e.ExpireTime > 0 && now >= e.ExpireTime
+}
+
+type clientFenceEntry struct {
+	all  bool
+	vols map[string]struct{}
+}
+
+// ClientFence answers whether a request comes from an evicted client. A nil
+// fence evicts nobody.
+type ClientFence struct {
+	clients map[string]*clientFenceEntry
+}
+
+// NewClientFence builds the fence from the evictions sent by master, the ones
+// expired at now are left out.
+func NewClientFence(evictions []*ClientEviction, now int64) *ClientFence {
+	f := &ClientFence{clients: make(map[string]*clientFenceEntry)}
+	for _, e := range evictions {
+		if e.Expired(now) {
+			continue
+		}
+		entry, ok := f.clients[e.IP]
+		if !ok {
+			entry = &clientFenceEntry{vols: make(map[string]struct{})}
+			f.clients[e.IP] = entry
+		}
+		if e.Volume == "" {
+			entry.all = true
+		} else {
+			entry.vols[e.Volume] = struct{}{}
+		}
+	}
+	return f
+}
+
+func (f *ClientFence) Len() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.clients)
+}
+
+// HasIP tells whether some eviction covers requests from remoteAddr, which is
+// an IP or a host:port address, so that the volume need only be looked up then.
+func (f *ClientFence) HasIP(remoteAddr string) bool {
+	if f.Len() == 0 {
+		return false
+	}
+	_, ok := f.clients[fenceHost(remoteAddr)]
+	return ok
+}
+
+// Evicted tells whether requests from remoteAddr to the volume are rejected.
+func (f *ClientFence) Evicted(remoteAddr, vol string) bool {
+	if f.Len() == 0 {
+		return false
+	}
+	entry, ok := f.clients[fenceHost(remoteAddr)]
+	if !ok {
+		return false
+	}
+	if entry.all {
+		return true
+	}
+	_, ok = entry.vols[vol]
+	return ok
+}
+
+func fenceHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
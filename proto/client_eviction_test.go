@@ -0,0 +1,42 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFence(t *testing.T) {
+	var fence *ClientFence
+	require.False(t, fence.Evicted("192.168.0.1:1234", "vol1"))
+
+	fence = NewClientFence([]*ClientEviction{
+		{IP: "192.168.0.1"},
+		{IP: "192.168.0.2", Volume: "vol1"},
+		{IP: "192.168.0.3", ExpireTime: 100},
+	}, 100)
+	require.Equal(t, 2, fence.Len())
+	require.True(t, fence.Evicted("192.168.0.1:1234", "vol2"))
+	require.True(t, fence.Evicted("192.168.0.2:1234", "vol1"))
+	require.False(t, fence.Evicted("192.168.0.2:1234", "vol2"))
+	require.True(t, fence.HasIP("192.168.0.2"))
+	require.False(t, fence.HasIP("192.168.0.3:1234"))
+	require.False(t, fence.Evicted("192.168.0.3:1234", "vol1"))
+
+	require.Equal(t, "192.168.0.2/vol1", (&ClientEviction{IP: "192.168.0.2", Volume: "vol1"}).Key())
+	require.False(t, (&ClientEviction{}).Expired(100))
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+// Types of the cluster events.
+const (
+	ClusterEventFlashGroupCreate     = "flashGroupCreate"
+	ClusterEventFlashGroupRemove     = "flashGroupRemove"
+	ClusterEventFlashGroupStatus     = "flashGroupStatus"
+	ClusterEventFlashGroupNodeAdd    = "flashGroupNodeAdd"
+	ClusterEventFlashGroupNodeRemove = "flashGroupNodeRemove"
+	ClusterEventFlashNodeOffline     = "flashNodeOffline"
+	ClusterEventDataNodeOffline      = "dataNodeOffline"
+	ClusterEventMetaNodeOffline      = "metaNodeOffline"
+)
+
+// ClusterEvent is a change of the cluster topology published by the master
+// leader, for the automation to react to without polling the admin APIs.
+type ClusterEvent struct {
+	ID           int64 // unix nano of the event, increasing on a leader
+	Time         int64 // unix second
+	Type         string
+	FlashGroupID uint64 `json:",omitempty"`
+	Addr         string `json:",omitempty"` // the node of the event
+	Status       string `json:",omitempty"` // the new status of the flash group
+}
+
+// ClusterEventsView is the events after the id asked, oldest first. Last is the
+// id to ask the next events after, and Lost is true if some events after the id
+// asked were dropped by the master before they were read.
+type ClusterEventsView struct {
+	Events []*ClusterEvent
+	Last   int64
+	Lost   bool
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+const (
+	DataBalanceMoveRunning = "running"
+	DataBalanceMoveDone    = "done"
+	DataBalanceMoveFailed  = "failed"
+	DataBalanceMoveTimeout = "timeout"
+)
+
+// DataBalanceConfig controls the data balancer of the master, which moves the
+// data partition replicas of the most used data nodes of a zone to the least
+// used ones.
+type DataBalanceConfig struct {
+	Enabled        bool
+	Paused         bool    // no new move is started, the running ones go on
+	MaxConcurrency int     // moves running at a time
+	BandwidthMBps  int     // the bytes the moves copy per second, 0 for unlimited
+	Threshold      float64 // the usage ratio a node may be over the mean of its zone
+	Zone           ZoneBalanceConfig
+}
+
+// ZoneBalanceConfig controls the moves of the data balancer between the zones,
+// which share the concurrency and the bandwidth of the moves within the zones.
+type ZoneBalanceConfig struct {
+	Enabled        bool
+	Window         string  // the local time "15:04-15:04" the moves start in, all day if the ends are equal
+	MaxTBPerWindow float64 // the bytes moved in a window
+	TargetSpread   float64 // the usage ratio the most used zone may be over the least used one
+	MaxZoneCost    float64 // the highest cost between the zones of a move, 0 for no ceiling
+}
+
+type DataBalanceDisk struct {
+	Path  string
+	Total uint64
+	Used  uint64
+	Usage float64
+}
+
+type DataBalanceNode struct {
+	Addr     string
+	Total    uint64
+	Used     uint64
+	Usage    float64
+	Balanced float64 // the usage once the running and planned moves succeed
+	DiskSkew float64 // the most used disk minus the least used one
+	Disks    []*DataBalanceDisk
+}
+
+// DataBalanceZone is the usage of the data nodes of a media type in a zone,
+// the replicas are only moved among them.
+type DataBalanceZone struct {
+	Zone      string
+	MediaType string
+	Mean      float64
+	Skew      float64 // the most used node minus the least used one
+	DiskSkew  float64 // the largest disk skew of the nodes
+	Nodes     []*DataBalanceNode
+}
+
+type DataBalanceMove struct {
+	PartitionID uint64
+	VolName     string
+	From        string
+	To          string
+	Size        uint64
+	FromZone    string `json:",omitempty"` // set for the moves between the zones
+	ToZone      string `json:",omitempty"`
+	Status      string
+	StartTime   int64
+	EndTime     int64  `json:",omitempty"`
+	Err         string `json:",omitempty"`
+}
+
+// DataBalanceZoneSpread is the usage of the most used zone of a media type
+// minus the least used one.
+type DataBalanceZoneSpread struct {
+	MediaType string
+	Spread    float64
+	MostUsed  string
+	LeastUsed string
+}
+
+// DataBalanceView is the state of the data balancer, Planned is the moves its
+// next round would start.
+type DataBalanceView struct {
+	Config      DataBalanceConfig
+	LastRound   int64
+	Zones       []*DataBalanceZone
+	ZoneSpreads []*DataBalanceZoneSpread
+	WindowOpen  bool
+	WindowMoved uint64 // the bytes of the moves between the zones started in the window
+	Running     []*DataBalanceMove
+	Finished    []*DataBalanceMove // the latest first
+	Planned     []*DataBalanceMove
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import "sort"
+
+// BlockLocality is the hosts holding a range of a file. The ranges are aligned
+// on the cache blocks of the flash nodes, the adjacent ones held by the same
+// hosts are merged.
+type BlockLocality struct {
+	Offset uint64 `json:"offset"`
+	Size   uint64 `json:"size"`
+	// the replicas of the data partitions, the leaders first
+	DataHosts []string `json:"dataHosts"`
+	// the flash group caching the range, if the remote cache is enabled
+	FlashHosts []string `json:"flashHosts,omitempty"`
+}
+
+// FileLocality is the hosts holding the data of a file or of an object, whose
+// key is its path in the volume of the bucket, for the compute schedulers to
+// run the tasks close to the data they read. The holes and the data stored in
+// the blobstore have no block.
+type FileLocality struct {
+	Volume string           `json:"volume"`
+	Path   string           `json:"path"`
+	Inode  uint64           `json:"inode"`
+	Size   uint64           `json:"size"`
+	Blocks []*BlockLocality `json:"blocks"`
+}
+
+// Hosts returns the distinct hosts of the blocks, the data hosts first, each
+// ordered by the bytes of the file they hold.
+func (l *FileLocality) Hosts() []string {
+	bytes := make(map[string]uint64)
+	collect := func(hostsOf func(b *BlockLocality) []string) []string {
+		hosts := make([]string, 0)
+		for _, b := range l.Blocks {
+			for _, host := range hostsOf(b) {
+				if _, ok := bytes[host]; !ok {
+					hosts = append(hosts, host)
+				}
+				bytes[host] += b.Size
+			}
+		}
+		sort.SliceStable(hosts, func(i, j int) bool { return bytes[hosts[i]] > bytes[hosts[j]] })
+		return hosts
+	}
+	dataHosts := collect(func(b *BlockLocality) []string { return b.DataHosts })
+	return append(dataHosts, collect(func(b *BlockLocality) []string { return b.FlashHosts })...)
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLocalityHosts(t *testing.T) {
+	l := &FileLocality{Blocks: []*BlockLocality{
+		{Offset: 0, Size: 1, DataHosts: []string{"a", "b"}, FlashHosts: []string{"f1"}},
+		{Offset: 1, Size: 4, DataHosts: []string{"c", "b"}, FlashHosts: []string{"f2"}},
+	}}
+	require.Equal(t, []string{"b", "c", "a", "f2", "f1"}, l.Hosts())
+	require.Empty(t, (&FileLocality{}).Hosts())
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+)
+
+const (
+	// DirShardLayoutKey is the reserved xattr of a directory which holds its
+	// shard layout. The dentries of a sharded directory are never stored under
+	// the directory inode itself but under the shard inode chosen by name hash.
+	DirShardLayoutKey = "cfs.dirshard.layout"
+	// DirShardCountKey is the xattr accepted by the client to shard an empty
+	// directory into the given number of shards. A directory is not sharded
+	// otherwise, nor resharded once it is.
+	DirShardCountKey = "cfs.dirshard.count"
+	// DirShardSealedKey is the reserved xattr of a shard inode set by the rmdir
+	// of its directory, the metanode creates no dentry under a sealed shard.
+	DirShardSealedKey = "cfs.dirshard.sealed"
+
+	MinDirShardCount = 2
+	MaxDirShardCount = 1024
+)
+
+// DirShardLayout describes how the dentries of a large directory are spread
+// over shard inodes. Shard inodes are allocated on different meta partitions
+// so that a single directory no longer concentrates load on one partition.
+type DirShardLayout struct {
+	ParentIno uint64   `json:"pino"`
+	Shards    []uint64 `json:"shards"`
+}
+
+func NewDirShardLayout(parentIno uint64, shards []uint64) *DirShardLayout {
+	return &DirShardLayout{
+		ParentIno: parentIno,
+		Shards:    shards,
+	}
+}
+
+func (l *DirShardLayout) Validate() error {
+	if l.ParentIno == 0 {
+		return fmt.Errorf("dir shard layout: invalid parent inode")
+	}
+	if len(l.Shards) < MinDirShardCount || len(l.Shards) > MaxDirShardCount {
+		return fmt.Errorf("dir shard layout: shard count %v out of range [%v, %v]",
+			len(l.Shards), MinDirShardCount, MaxDirShardCount)
+	}
+	seen := make(map[uint64]struct{}, len(l.Shards))
+	for _, ino := range l.Shards {
+		if ino == 0 || ino == l.ParentIno {
+			return fmt.Errorf("dir shard layout: invalid shard inode %v", ino)
+		}
+		if _, ok := seen[ino]; ok {
+			return fmt.Errorf("dir shard layout: duplicate shard inode %v", ino)
+		}
+		seen[ino] = struct{}{}
+	}
+	return nil
+}
+
+// ShardIndex returns the index of the shard which stores the dentry name.
+func (l *DirShardLayout) ShardIndex(name string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int(h.Sum32() % uint32(len(l.Shards)))
+}
+
+// ShardOf returns the shard inode which stores the dentry name.
+func (l *DirShardLayout) ShardOf(name string) uint64 {
+	return l.Shards[l.ShardIndex(name)]
+}
+
+func (l *DirShardLayout) Marshal() ([]byte, error) {
+	return json.Marshal(l)
+}
+
+func UnmarshalDirShardLayout(data []byte) (l *DirShardLayout, err error) {
+	l = &DirShardLayout{}
+	if err = json.Unmarshal(data, l); err != nil {
+		return nil, err
+	}
+	if err = l.Validate(); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirShardLayout(t *testing.T) {
+	layout := NewDirShardLayout(1, []uint64{10, 11, 12, 13})
+	require.NoError(t, layout.Validate())
+
+	data, err := layout.Marshal()
+	require.NoError(t, err)
+	got, err := UnmarshalDirShardLayout(data)
+	require.NoError(t, err)
+	require.Equal(t, layout, got)
+
+	hits := make(map[uint64]int)
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("file_%d", i)
+		shard := layout.ShardOf(name)
+		require.Equal(t, shard, got.ShardOf(name))
+		hits[shard]++
+	}
+	require.Len(t, hits, len(layout.Shards))
+
+	require.Error(t, NewDirShardLayout(1, []uint64{10}).Validate())
+	require.Error(t, NewDirShardLayout(1, []uint64{10, 10}).Validate())
+	require.Error(t, NewDirShardLayout(1, []uint64{1, 10}).Validate())
+	require.Error(t, NewDirShardLayout(0, []uint64{10, 11}).Validate())
+	_, err = UnmarshalDirShardLayout([]byte(`{"pino":1,"shards":[2]}`))
+	require.Error(t, err)
+}
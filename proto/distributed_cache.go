@@ -3,6 +3,7 @@ package proto
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -19,9 +20,23 @@ const (
 	FlashGroupMaxWeight     = 30
 )
 
+// the zone policies of the nodes of the flash groups created in batch
+const (
+	FlashGroupZoneSpread = "spread" // the nodes of a group in as many zones as possible
+	FlashGroupZonePack   = "pack"   // the nodes of a group in one zone
+)
+
+// the policies of the clients on a slow or failed read of a flash group
+const (
+	RemoteCacheReadHedge    = "hedge"    // read a second node of the group after the hedge delay
+	RemoteCacheReadFallback = "fallback" // read the data nodes after the fallback timeout
+)
+
 const (
 	FlashGroupStatus_Inactive FlashGroupStatus = 0x0
 	FlashGroupStatus_Active   FlashGroupStatus = 0x1
+	// the clients read the group but cache nothing in it, until it is removed
+	FlashGroupStatus_Draining FlashGroupStatus = 0x2
 )
 
 const (
@@ -46,6 +61,8 @@ func (status FlashGroupStatus) String() string {
 		return "Inactive"
 	case FlashGroupStatus_Active:
 		return "Active"
+	case FlashGroupStatus_Draining:
+		return "Draining"
 	default:
 		return "Unknown"
 	}
@@ -67,6 +84,25 @@ const (
 	Flash_Task_Stop
 )
 
+func (status ManualTaskStatus) String() string {
+	switch status {
+	case Flash_Task_Init:
+		return "Init"
+	case Flash_Task_Running:
+		return "Running"
+	case Flash_Task_Pause:
+		return "Pause"
+	case Flash_Task_Success:
+		return "Success"
+	case Flash_Task_Failed:
+		return "Failed"
+	case Flash_Task_Stop:
+		return "Stop"
+	default:
+		return "Unknown"
+	}
+}
+
 func ManualTaskDone(status int) bool {
 	success, failed, stop := int(Flash_Task_Success), int(Flash_Task_Failed), int(Flash_Task_Stop)
 	return status == success || status == failed || status == stop
@@ -78,14 +114,52 @@ func ManualTaskIsRunning(status int) bool {
 }
 
 type FlashGroupInfo struct {
-	ID    uint64   `json:"i"`
-	Slot  []uint32 `json:"s"` // FlashGroup's position in hasher ring
-	Hosts []string `json:"h"`
-}
-
+	ID       uint64   `json:"i"`
+	Slot     []uint32 `json:"s"` // FlashGroup's position in hasher ring
+	Hosts    []string `json:"h"`
+	Draining bool     `json:"d,omitempty"` // read only, the clients prepare nothing in it
+	// zone of the hosts, the clients prefer the hosts of their own zone
+	HostZones map[string]string `json:"z,omitempty"`
+}
+
+// FlashGroupView is the flash group topology sent to the clients. A client
+// passing the version of its last view gets either NotModified, or a Delta of
+// the changed and Removed flash groups, or the full view if the version is no
+// longer known to the master.
 type FlashGroupView struct {
 	Enable      bool
 	FlashGroups []*FlashGroupInfo
+	Version     uint64   `json:",omitempty"`
+	NotModified bool     `json:",omitempty"`
+	Delta       bool     `json:",omitempty"`
+	Removed     []uint64 `json:",omitempty"` // IDs of the flash groups removed by a delta
+}
+
+// Apply merges a view got for the version of this one into it.
+func (v *FlashGroupView) Apply(got *FlashGroupView) {
+	switch {
+	case got.NotModified:
+	case got.Delta:
+		removed := make(map[uint64]bool, len(got.Removed)+len(got.FlashGroups))
+		for _, id := range got.Removed {
+			removed[id] = true
+		}
+		for _, fg := range got.FlashGroups {
+			removed[fg.ID] = true
+		}
+		groups := make([]*FlashGroupInfo, 0, len(v.FlashGroups)+len(got.FlashGroups))
+		for _, fg := range v.FlashGroups {
+			if !removed[fg.ID] {
+				groups = append(groups, fg)
+			}
+		}
+		v.Enable = got.Enable
+		v.FlashGroups = append(groups, got.FlashGroups...)
+	default:
+		v.Enable = got.Enable
+		v.FlashGroups = got.FlashGroups
+	}
+	v.Version = got.Version
 }
 
 func (f *FlashGroupInfo) String() string {
@@ -261,6 +335,90 @@ type FlashGroupAdminView struct {
 	Step           uint32
 	FlashNodeCount int
 	ZoneFlashNodes map[string][]*FlashNodeViewInfo
+	AutoScaleMin   int
+	AutoScaleMax   int // 0 for not scaled automatically
+
+	AutoHealMinHealthy   int   // 0 for not healed automatically
+	AutoHealCooldown     int64 // seconds, 0 for the default
+	AutoHealExcludeHosts []string
+
+	CacheCapacity int64 // bytes cached by the active flashnodes of the group
+	CacheStat     FlashNodeCacheStat
+
+	DrainDeadline int64 // unix seconds the draining group is removed at
+
+	Volumes []string // the volumes the group is dedicated to, empty if shared
+}
+
+const (
+	FlashGroupScaleUp   = "add"
+	FlashGroupScaleDown = "remove"
+	FlashGroupAutoHeal  = "heal"
+)
+
+// FlashGroupScaleEvent is a flashnode added to or removed from a flash group
+// by the auto scaling, or added in place of an inactive one by the auto
+// healing, with the load of the group that triggered it.
+type FlashGroupScaleEvent struct {
+	Time         int64
+	FlashGroupID uint64
+	Action       string
+	FlashNode    string
+	ZoneName     string
+	NodeCount    int // after the action
+	HitRate      float64
+	UsageRatio   float64
+	Reason       string
+}
+
+// Ops of the flash group audit trail.
+const (
+	FlashGroupOpCreate      = "create"
+	FlashGroupOpBatchCreate = "batchCreate"
+	FlashGroupOpRemove      = "remove"
+	FlashGroupOpSet         = "set"
+	FlashGroupOpNodeAdd     = "nodeAdd"
+	FlashGroupOpNodeRemove  = "nodeRemove"
+	FlashGroupOpPurgeCache  = "purgeCache"
+	FlashGroupOpMoveSlots   = "moveSlots"
+)
+
+// FlashGroupAuditRecord is an admin operation on the flash groups, kept by the
+// master to trace the changes of the cache topology.
+type FlashGroupAuditRecord struct {
+	ID           int64 // unix nano of the operation, increasing on a leader
+	Time         int64 // unix second
+	Op           string
+	FlashGroupID uint64 // 0 if the op failed before the group is known
+	Operator     string // ip of the operator
+	Args         string // the query of the request
+	Result       string // "ok" or the error
+}
+
+func (r *FlashGroupAuditRecord) Key() string {
+	return fmt.Sprintf("%020d", r.ID)
+}
+
+// FlashGroupSlotsRebalance is the slots count of a flash group moved to its
+// share of the cache capacity of the groups.
+type FlashGroupSlotsRebalance struct {
+	FlashGroupID  uint64
+	CacheCapacity int64
+	Slots         int
+	TargetSlots   int
+}
+
+// FlashGroupSlotsPreview is the slot ownership after a change of the flash group
+// slots, previewed with dryRun without committing the change. A group to create
+// is shown with id 0.
+type FlashGroupSlotsPreview struct {
+	SlotOwners   map[uint32]uint64 // the flash group owning each slot
+	GroupSlots   map[uint64]int    // the slots count of each flash group
+	AddedSlots   []uint32
+	RemovedSlots []uint32
+	// the percentage of the keyspace owned by a group before, owned by another one after
+	RemappedPercent float64
+	Rebalance       []*FlashGroupSlotsRebalance `json:",omitempty"`
 }
 
 type FlashNodeViewInfo struct {
@@ -274,6 +432,76 @@ type FlashNodeViewInfo struct {
 	IsEnable      bool
 	DiskStat      []*FlashNodeDiskCacheStat
 	LimiterStatus *FlashNodeLimiterStatusInfo
+	CacheCapacity int64
+	CacheStat     *FlashNodeCacheStat     // nil if not reported by the flashnode
+	HealthScore   int                     // 0 to 100, on the heartbeats and tasks of late
+	PoorHealth    bool                    // left out of the flash groups for the clients if others are not
+	ReadOnly      bool                    // serves the hits but caches nothing
+	CacheRecovery *FlashNodeCacheRecovery // nil with the cache in memory or not reported
+}
+
+// FlashNodeCacheRecovery is the cache a flashnode recovered from its disks on
+// start, from the cache index saved before, or from the headers of the blocks
+// not in it.
+type FlashNodeCacheRecovery struct {
+	Time    int64 // unix seconds the recovery ended
+	CostMs  int64
+	Blocks  int64 // blocks recovered
+	Bytes   int64 // bytes of the data of the blocks recovered
+	Indexed int64 // blocks recovered from the index without reading their headers
+	Dropped int64 // blocks deleted, expired or invalid
+}
+
+// FlashNodeCacheStat sums the cache of the disks of flashnodes, the hits, the
+// misses and the evictions are of the last minute.
+type FlashNodeCacheStat struct {
+	Hits          int64
+	Misses        int64
+	HitRate       float64
+	Evicts        int64
+	OccupiedBytes int64
+	ObjectCount   int64
+}
+
+// AddDisk counts the cache of a disk in.
+func (s *FlashNodeCacheStat) AddDisk(disk *FlashNodeDiskCacheStat) {
+	s.add(disk.Hits, disk.Misses, int64(disk.Evicts), disk.HasAlloc, int64(disk.KeyNum))
+}
+
+// Add counts the cache of another stat in.
+func (s *FlashNodeCacheStat) Add(o *FlashNodeCacheStat) {
+	s.add(o.Hits, o.Misses, o.Evicts, o.OccupiedBytes, o.ObjectCount)
+}
+
+func (s *FlashNodeCacheStat) add(hits, misses, evicts, occupied, objects int64) {
+	s.Hits += hits
+	s.Misses += misses
+	s.Evicts += evicts
+	s.OccupiedBytes += occupied
+	s.ObjectCount += objects
+	s.HitRate = 0
+	if s.Hits+s.Misses > 0 {
+		s.HitRate = math.Trunc(float64(s.Hits)/float64(s.Hits+s.Misses)*1e4+0.5) * 1e-4
+	}
+}
+
+// FlashNodeCacheStatView is the cache stat of a flashnode.
+type FlashNodeCacheStatView struct {
+	Addr          string
+	ZoneName      string
+	FlashGroupID  uint64
+	IsActive      bool
+	IsEnable      bool
+	ReportTime    time.Time
+	CacheCapacity int64
+	CacheStat     *FlashNodeCacheStat
+}
+
+// FlashNodeStatsView is the cache stats of the flashnodes, Total sums the
+// active and enabled ones.
+type FlashNodeStatsView struct {
+	Total      FlashNodeCacheStat
+	FlashNodes []*FlashNodeCacheStatView
 }
 
 type FlashNodeStat struct {
@@ -281,6 +509,147 @@ type FlashNodeStat struct {
 	NodeLimit         uint64
 	VolLimit          map[string]uint64
 	CacheStatus       []*CacheStatus
+	Admission         *FlashNodeAdmissionStat
+	HotTier           *FlashNodeHotTierStat
+	MetaCache         *FlashNodeMetaCacheStat
+	EvictPolicy       string
+	VolEvictPolicy    map[string]string
+	ReadLimit         FlashReadLimit
+	VolReadLimit      map[string]FlashReadLimit
+}
+
+// FlashNodeAdmissionStat counts the decisions of the cache admission filter, which
+// is only consulted when caching a missed block has to evict another one.
+type FlashNodeAdmissionStat struct {
+	Enable          bool     `json:"enable"`
+	Admitted        uint64   `json:"admitted"`
+	Rejected        uint64   `json:"rejected"`
+	AlwaysAdmitted  uint64   `json:"always_admitted"`
+	AlwaysAdmitVols []string `json:"always_admit_vols"`
+}
+
+// FlashNodeHotTierStat is the usage of the memory tier keeping the blocks read
+// the most in front of the disks.
+type FlashNodeHotTierStat struct {
+	Enable      bool   `json:"enable"`
+	MaxBytes    int64  `json:"max_bytes"`
+	UsedBytes   int64  `json:"used_bytes"`
+	Blocks      int    `json:"blocks"`
+	PromoteHits int    `json:"promote_hits"`
+	Hits        uint64 `json:"hits"`
+	Promoted    uint64 `json:"promoted"`
+	Demoted     uint64 `json:"demoted"`
+}
+
+// FlashNodeMetaCacheStat is the usage of the memory keeping the metadata put by
+// the clients.
+type FlashNodeMetaCacheStat struct {
+	Enable    bool   `json:"enable"`
+	MaxBytes  int64  `json:"max_bytes"`
+	UsedBytes int64  `json:"used_bytes"`
+	Entries   int    `json:"entries"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Puts      uint64 `json:"puts"`
+	Evicted   uint64 `json:"evicted"` // to fit in MaxBytes
+}
+
+// FlashMetaCacheRequest is the metadata of the directory Parent of a volume
+// cached by the flash nodes, the lookup of each name, or the listing of the
+// directory with an empty name. Get and put take one name, the metadata put
+// are in the data of the packet.
+type FlashMetaCacheRequest struct {
+	Volume string   `json:"volume"`
+	Parent uint64   `json:"parent"`
+	Names  []string `json:"names"`
+	TTLSec int64    `json:"ttl_sec,omitempty"` // to put
+	Token  []byte   `json:"token,omitempty"`   // the flash cache token of the volume requiring remoteCacheAuth
+}
+
+func FlashMetaCacheKey(volume string, parent uint64, name string) string {
+	return fmt.Sprintf("%s/%d/%s", volume, parent, name)
+}
+
+// ComputeMetaCacheSlot is the slot of the metadata of a directory, its lookups
+// and listing are cached by the same flash group.
+func ComputeMetaCacheSlot(volume string, parent uint64) uint32 {
+	volLen := len(volume)
+	buf := make([]byte, volLen+8)
+	copy(buf[:volLen], volume)
+	binary.BigEndian.PutUint64(buf[volLen:], parent)
+	return fastcrc32.Checksum(buf)
+}
+
+// FlashNodeCacheKey is a block cached by a flash node.
+type FlashNodeCacheKey struct {
+	Key       string `json:"key"`
+	Volume    string `json:"volume"`
+	Inode     uint64 `json:"inode"`
+	Offset    uint64 `json:"offset"` // offset of the block in the file
+	Version   uint32 `json:"version"`
+	Size      int64  `json:"size"` // bytes cached so far
+	AllocSize int64  `json:"alloc_size"`
+	DataPath  string `json:"data_path"`
+	Ready     bool   `json:"ready"`
+	Hot       bool   `json:"hot"` // in the memory tier
+	AgeSec    int64  `json:"age_sec"`
+	ExpireSec int64  `json:"expire_sec"` // until it expires
+	Hits      uint64 `json:"hits"`       // reads served since it was cached
+}
+
+// FlashNodeCacheKeys is the blocks cached by a flash node, Total counts all
+// the ones matched even if only Limit of them are listed.
+type FlashNodeCacheKeys struct {
+	Total int                  `json:"total"`
+	Limit int                  `json:"limit"`
+	Keys  []*FlashNodeCacheKey `json:"keys"`
+}
+
+// FlashNodeCachePurge is the blocks evicted by a purge of a flash node, and the
+// keys failed to evict.
+type FlashNodeCachePurge struct {
+	Purged int      `json:"purged"`
+	Failed []string `json:"failed"`
+}
+
+// FlashGroupCacheKey is a block cached by a flashnode of a flash group.
+type FlashGroupCacheKey struct {
+	FlashNodeCacheKey
+	FlashGroupID uint64 `json:"flash_group_id"`
+	FlashNode    string `json:"flash_node"`
+}
+
+// FlashGroupCacheKeys is the most read blocks cached by the flashnodes of the
+// flash groups, Total counts all the blocks matched. Failed is the error of the
+// flashnodes not listed.
+type FlashGroupCacheKeys struct {
+	Total  int
+	Keys   []*FlashGroupCacheKey
+	Failed map[string]string `json:",omitempty"`
+}
+
+// FlashGroupNodePurge is the purge of the cache of a flashnode, Err is set if
+// the flashnode is not purged.
+type FlashGroupNodePurge struct {
+	FlashGroupID uint64
+	FlashNode    string
+	Purged       int
+	Failed       []string `json:",omitempty"` // the keys failed to evict
+	Err          string   `json:",omitempty"`
+}
+
+// FlashGroupCachePurge is the blocks evicted by a purge of the flash groups.
+type FlashGroupCachePurge struct {
+	Purged int
+	Nodes  []*FlashGroupNodePurge
+}
+
+// CacheEvictRequest asks a flash node to evict the blocks of a file overwritten,
+// Offsets are the fixed offsets of the blocks in the file.
+type CacheEvictRequest struct {
+	Volume  string   `json:"volume"`
+	Inode   uint64   `json:"inode"`
+	Offsets []uint64 `json:"offsets"`
 }
 
 type CacheStatus struct {
@@ -291,6 +660,8 @@ type CacheStatus struct {
 	Used     int64    `json:"used"`
 	Total    int64    `json:"total"`
 	HitRate  float64  `json:"hit_rate"`
+	Hits     int64    `json:"hits"`
+	Misses   int64    `json:"misses"`
 	Evicts   int      `json:"evicts"`
 	Num      int      `json:"num"`
 	Capacity int      `json:"capacity"`
@@ -351,11 +722,40 @@ type FlashManualTask struct {
 	sync.Mutex
 }
 
+// MaxFlashManualTaskInodes limits the inodes a warm up task is given.
+const MaxFlashManualTaskInodes = 10000
+
 type ManualTaskConfig struct {
 	Prefix                  string
 	TraverseFileConcurrency int
 	HandlerFileConcurrency  int
 	TotalFileSizeLimit      int64
+	// Inodes are warmed up instead of the files under Prefix if set.
+	Inodes []uint64 `json:",omitempty"`
+	// Offset and Size select the bytes of each file to warm up, up to the end
+	// of the file if Size is 0.
+	Offset uint64 `json:",omitempty"`
+	Size   uint64 `json:",omitempty"`
+}
+
+// ClipExtent returns the part of ek in the bytes of the file to warm up, ok
+// is false if there is none.
+func (c *ManualTaskConfig) ClipExtent(ek ExtentKey) (clipped ExtentKey, ok bool) {
+	start, end := ek.FileOffset, ek.FileOffset+uint64(ek.Size)
+	if start < c.Offset {
+		start = c.Offset
+	}
+	if c.Size != 0 && end > c.Offset+c.Size {
+		end = c.Offset + c.Size
+	}
+	if start >= end {
+		return ek, false
+	}
+	clipped = ek
+	clipped.ExtentOffset += start - ek.FileOffset
+	clipped.FileOffset = start
+	clipped.Size = uint32(end - start)
+	return clipped, true
 }
 
 type ManualTaskStatistics struct {
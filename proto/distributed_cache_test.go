@@ -5,6 +5,8 @@ import (
 	"math/rand"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 func generateRandomSources(numOfSources int) []*DataSource {
@@ -139,3 +141,76 @@ func cacheReadRequestProtobufDecodeBase(b *testing.B, num int) {
 	}
 	b.ReportAllocs()
 }
+
+func TestFlashNodeCacheStat(t *testing.T) {
+	stat := new(FlashNodeCacheStat)
+	stat.AddDisk(&FlashNodeDiskCacheStat{Hits: 3, Misses: 1, Evicts: 2, HasAlloc: 100, KeyNum: 10})
+	require.InDelta(t, 0.75, stat.HitRate, 1e-9)
+	stat.AddDisk(&FlashNodeDiskCacheStat{Hits: 0, Misses: 4, Evicts: 1, HasAlloc: 50, KeyNum: 5})
+	require.Equal(t, FlashNodeCacheStat{Hits: 3, Misses: 5, HitRate: stat.HitRate, Evicts: 3, OccupiedBytes: 150, ObjectCount: 15}, *stat)
+	require.InDelta(t, 0.375, stat.HitRate, 1e-9)
+
+	total := new(FlashNodeCacheStat)
+	total.Add(stat)
+	total.Add(stat)
+	require.Equal(t, int64(6), total.Hits)
+	require.Equal(t, int64(300), total.OccupiedBytes)
+	require.InDelta(t, 0.375, total.HitRate, 1e-9)
+
+	// no op in the last minute
+	empty := new(FlashNodeCacheStat)
+	empty.AddDisk(&FlashNodeDiskCacheStat{HasAlloc: 10})
+	require.Zero(t, empty.HitRate)
+}
+
+func TestManualTaskConfigClipExtent(t *testing.T) {
+	ek := ExtentKey{FileOffset: 1000, PartitionId: 1, ExtentId: 2, ExtentOffset: 100, Size: 1000}
+	for _, c := range []struct {
+		offset, size           uint64
+		ok                     bool
+		fileOffset, extentOffs uint64
+		clippedSize            uint32
+	}{
+		{0, 0, true, 1000, 100, 1000},
+		{1500, 0, true, 1500, 600, 500},
+		{0, 1200, true, 1000, 100, 200},
+		{1200, 300, true, 1200, 300, 300},
+		{2000, 0, false, 0, 0, 0},
+		{0, 1000, false, 0, 0, 0},
+	} {
+		config := &ManualTaskConfig{Offset: c.offset, Size: c.size}
+		clipped, ok := config.ClipExtent(ek)
+		require.Equal(t, c.ok, ok, "%+v", c)
+		if !ok {
+			continue
+		}
+		require.Equal(t, c.fileOffset, clipped.FileOffset, "%+v", c)
+		require.Equal(t, c.extentOffs, clipped.ExtentOffset, "%+v", c)
+		require.Equal(t, c.clippedSize, clipped.Size, "%+v", c)
+		require.Equal(t, ek.ExtentId, clipped.ExtentId)
+	}
+}
+
+func TestFlashGroupViewApply(t *testing.T) {
+	fg1 := &FlashGroupInfo{ID: 1, Slot: []uint32{1}, Hosts: []string{"a"}}
+	fg2 := &FlashGroupInfo{ID: 2, Slot: []uint32{2}, Hosts: []string{"b"}}
+	fg3 := &FlashGroupInfo{ID: 3, Slot: []uint32{3}, Hosts: []string{"c"}}
+	v := &FlashGroupView{}
+	v.Apply(&FlashGroupView{Enable: true, FlashGroups: []*FlashGroupInfo{fg1, fg2}, Version: 10})
+	require.True(t, v.Enable)
+	require.Equal(t, uint64(10), v.Version)
+	require.Len(t, v.FlashGroups, 2)
+
+	v.Apply(&FlashGroupView{Enable: true, NotModified: true, Version: 10})
+	require.Len(t, v.FlashGroups, 2)
+
+	fg2b := &FlashGroupInfo{ID: 2, Slot: []uint32{2}, Hosts: []string{"b", "d"}}
+	v.Apply(&FlashGroupView{Enable: true, Delta: true, Version: 11, FlashGroups: []*FlashGroupInfo{fg2b, fg3}, Removed: []uint64{1}})
+	require.Equal(t, uint64(11), v.Version)
+	require.ElementsMatch(t, []*FlashGroupInfo{fg2b, fg3}, v.FlashGroups)
+
+	// a master without versions sends the full view
+	v.Apply(&FlashGroupView{Enable: true, FlashGroups: []*FlashGroupInfo{fg1}})
+	require.Equal(t, uint64(0), v.Version)
+	require.Equal(t, []*FlashGroupInfo{fg1}, v.FlashGroups)
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"errors"
+	"syscall"
+)
+
+// CodeError is an error replied with its code, the clients branch on the code
+// by errors.Is with the error of the code instead of matching the message,
+// which may carry the details of the error.
+type CodeError struct {
+	Code int32
+	Msg  string
+}
+
+func NewCodeError(code int32, msg string) *CodeError {
+	return &CodeError{Code: code, Msg: msg}
+}
+
+func (e *CodeError) Error() string {
+	if e.Msg == "" {
+		return ParseErrorCode(e.Code).Error()
+	}
+	return e.Msg
+}
+
+// Is tells whether the target is the error of the code, or a CodeError of the
+// same code.
+func (e *CodeError) Is(target error) bool {
+	if t, ok := target.(*CodeError); ok {
+		return t.Code == e.Code
+	}
+	err, ok := code2ErrMap[e.Code]
+	return ok && err == target
+}
+
+// Errno returns the errno of the code, see ErrCodeErrno.
+func (e *CodeError) Errno() syscall.Errno {
+	return ErrCodeErrno(e.Code)
+}
+
+// ErrorCode returns the code of the error, of the error it wraps or of the
+// CodeError it is, ErrCodeInternalError if it has no code.
+func ErrorCode(err error) int32 {
+	if err == nil {
+		return ErrCodeSuccess
+	}
+	if code, ok := Err2CodeMap[err]; ok {
+		return code
+	}
+	var ce *CodeError
+	if errors.As(err, &ce) {
+		return ce.Code
+	}
+	for e, code := range Err2CodeMap {
+		if errors.Is(err, e) {
+			return code
+		}
+	}
+	return ErrCodeInternalError
+}
+
+// ErrCodeErrno maps the code to the errno a posix client returns for it, EIO
+// if there is none closer.
+func ErrCodeErrno(code int32) syscall.Errno {
+	switch code {
+	case ErrCodeSuccess:
+		return 0
+	case ErrCodeParamError, ErrCodeInvalidCfg, ErrCodeInvalidMpStart, ErrCodeZoneNumError, ErrCodeInvalidAdminRole:
+		return syscall.EINVAL
+	case ErrCodeVolNotExists, ErrCodeVolHasDeleted, ErrCodeMetaPartitionNotExists, ErrCodeDataPartitionNotExists,
+		ErrCodeDataNodeNotExists, ErrCodeMetaNodeNotExists, ErrCodeAccessKeyNotExists, ErrCodeUserNotExists,
+		ErrCodeVolPolicyNotExists, ErrCodeZoneNotExists, ErrCodeTokenNotExist, ErrCodeNodeSetNotExists,
+		ErrCodeNoSuchLifecycleConfiguration, ErrCodeQuotaNotExists, ErrCodeDiskNotExists, ErrCodeKeyNotExists:
+		return syscall.ENOENT
+	case ErrCodeDuplicateVol, ErrCodeDuplicateUserID, ErrCodeDuplicateAccessKey, ErrCodeOwnVolExists,
+		ErrCodeSuperAdminExists, ErrCodeIsOwner, ErrCodeDuplicateKey:
+		return syscall.EEXIST
+	case ErrCodeNoPermission, ErrCodeNoAclPermission, ErrCodeClientEvicted, ErrCodeStaleFenceToken:
+		return syscall.EPERM
+	case ErrCodeVolAuthKeyNotMatch, ErrCodeInvalidTicket, ErrCodeInvalidClientIDKey, ErrCodeExpiredTicket,
+		ErrCodeInvalidAccessKey, ErrCodeInvalidSecretKey, ErrCodeTLSRequired:
+		return syscall.EACCES
+	case ErrCodeVolNoAvailableSpace, ErrCodeTmpfsNoSpace:
+		return syscall.ENOSPC
+	case ErrCodeNoLeader, ErrCodePersistenceByRaft, ErrCodeMemberChange, ErrCodeFlashGroupDraining:
+		return syscall.EAGAIN
+	case ErrCodeNoSupportStorageClass, ErrCodeSnapshotNotEnabled:
+		return syscall.ENOTSUP
+	case ErrCodeVolImmutable:
+		return syscall.EROFS
+	default:
+		return syscall.EIO
+	}
+}
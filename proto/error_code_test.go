@@ -0,0 +1,77 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeStable(t *testing.T) {
+	// the codes are replied to the clients, they must never change
+	require.EqualValues(t, 0, ErrCodeSuccess)
+	require.EqualValues(t, 1, ErrCodeInternalError)
+	require.EqualValues(t, 2, ErrCodeParamError)
+	require.EqualValues(t, 7, ErrCodeVolNotExists)
+	require.EqualValues(t, 33, ErrCodeNoLeader)
+	require.EqualValues(t, 57, ErrCodeNoPermission)
+	require.EqualValues(t, 67, ErrCodeTmpfsNoSpace)
+	require.EqualValues(t, 68, ErrCodeVolNoAvailableSpace)
+	require.EqualValues(t, 78, ErrCodeClientEvicted)
+	require.EqualValues(t, 82, ErrCodeVolImmutable)
+	require.EqualValues(t, 83, ErrCodeInvalidAdminRole)
+
+	for code, err := range code2ErrMap {
+		require.Equal(t, code, Err2CodeMap[err], err.Error())
+	}
+	for err, code := range Err2CodeMap {
+		require.Equal(t, err, code2ErrMap[code], err.Error())
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	require.Equal(t, int32(ErrCodeSuccess), ErrorCode(nil))
+	require.Equal(t, int32(ErrCodeVolNotExists), ErrorCode(ErrVolNotExists))
+	require.Equal(t, int32(ErrCodeVolNotExists), ErrorCode(fmt.Errorf("vol(%v): %w", "a", ErrVolNotExists)))
+	require.Equal(t, int32(ErrCodeInternalError), ErrorCode(errors.New("vol not exists")))
+	require.Equal(t, int32(ErrCodeNoLeader), ErrorCode(fmt.Errorf("wrap: %w", NewCodeError(ErrCodeNoLeader, "no leader of x"))))
+
+	err := NewCodeError(ErrCodeVolNotExists, "vol(a) not exists")
+	require.Equal(t, "vol(a) not exists", err.Error())
+	require.ErrorIs(t, err, ErrVolNotExists)
+	require.ErrorIs(t, fmt.Errorf("wrap: %w", err), ErrVolNotExists)
+	require.ErrorIs(t, err, NewCodeError(ErrCodeVolNotExists, ""))
+	require.NotErrorIs(t, err, ErrDuplicateVol)
+	require.Equal(t, syscall.ENOENT, err.Errno())
+
+	require.Equal(t, ErrVolImmutable.Error(), NewCodeError(ErrCodeVolImmutable, "").Error())
+	require.Equal(t, ErrInternalError.Error(), NewCodeError(1000, "").Error())
+}
+
+func TestErrCodeErrno(t *testing.T) {
+	require.Equal(t, syscall.Errno(0), ErrCodeErrno(ErrCodeSuccess))
+	require.Equal(t, syscall.EINVAL, ErrCodeErrno(ErrCodeParamError))
+	require.Equal(t, syscall.EEXIST, ErrCodeErrno(ErrCodeDuplicateVol))
+	require.Equal(t, syscall.EACCES, ErrCodeErrno(ErrCodeVolAuthKeyNotMatch))
+	require.Equal(t, syscall.ENOSPC, ErrCodeErrno(ErrCodeVolNoAvailableSpace))
+	require.Equal(t, syscall.EAGAIN, ErrCodeErrno(ErrCodeNoLeader))
+	require.Equal(t, syscall.EROFS, ErrCodeErrno(ErrCodeVolImmutable))
+	require.Equal(t, syscall.EIO, ErrCodeErrno(ErrCodeInternalError))
+	require.Equal(t, syscall.EIO, ErrCodeErrno(1000))
+}
@@ -118,9 +118,20 @@ var (
 	ErrNoMpMigratePlan                         = errors.New("no meta partition migrate plan")
 	ErrFlashNodeFlowLimited                    = errors.New("flow limited")
 	ErrFlashNodeRunLimited                     = errors.New("run limited")
+	ErrClientEvicted                           = errors.New("client evicted")
+	ErrTLSRequired                             = errors.New("volume requires TLS connection")
+	ErrStaleFenceToken                         = errors.New("stale fence token")
+	ErrFlashNodeNotAdmitted                    = errors.New("cache block not admitted")
+	ErrFlashGroupDraining                      = errors.New("flash group draining")
+	ErrFlashNodeReadLimited                    = errors.New("flash node read limited")
+	ErrFlashCacheTokenInvalid                  = errors.New("flash cache token invalid")
+	ErrVolImmutable                            = errors.New("volume is immutable after the snapshot")
+	ErrInvalidAdminRole                        = errors.New("invalid admin role")
 )
 
-// http response error code and error message definitions
+// http response error code and error message definitions, the codes are
+// replied to the clients and must not change, new codes are appended only.
+// The codes are listed with their errno in error-codes.md of the docs.
 const (
 	ErrCodeSuccess = iota
 	ErrCodeInternalError
@@ -190,6 +201,22 @@ const (
 	ErrCodeNoSuchLifecycleConfiguration
 	ErrCodeNoSupportStorageClass
 	ErrCodeTmpfsNoSpace
+	ErrCodeVolNoAvailableSpace
+	ErrCodeNoAclPermission
+	ErrCodeQuotaNotExists
+	ErrCodeDiskNotExists
+	ErrCodeKeyNotExists
+	ErrCodeDuplicateKey
+	ErrCodeSnapshotNotEnabled
+	ErrCodeMemberChange
+	ErrCodeNeedForbidVer0
+	ErrCodeNoMpMigratePlan
+	ErrCodeClientEvicted
+	ErrCodeTLSRequired
+	ErrCodeStaleFenceToken
+	ErrCodeFlashGroupDraining
+	ErrCodeVolImmutable
+	ErrCodeInvalidAdminRole
 )
 
 // Err2CodeMap error map to code
@@ -258,6 +285,24 @@ var Err2CodeMap = map[error]int32{
 	ErrNoSuchLifecycleConfiguration:    ErrCodeNoSuchLifecycleConfiguration,
 	ErrNoSupportStorageClass:           ErrCodeNoSupportStorageClass,
 	ErrTmpfsNoSpace:                    ErrCodeTmpfsNoSpace,
+	ErrVolNotDelete:                    ErrCodeVolNotDelete,
+	ErrVolHasDeleted:                   ErrCodeVolHasDeleted,
+	ErrVolNoAvailableSpace:             ErrCodeVolNoAvailableSpace,
+	ErrNoAclPermission:                 ErrCodeNoAclPermission,
+	ErrQuotaNotExists:                  ErrCodeQuotaNotExists,
+	ErrDiskNotExists:                   ErrCodeDiskNotExists,
+	ErrKeyNotExists:                    ErrCodeKeyNotExists,
+	ErrDuplicateKey:                    ErrCodeDuplicateKey,
+	ErrSnapshotNotEnabled:              ErrCodeSnapshotNotEnabled,
+	ErrMemberChange:                    ErrCodeMemberChange,
+	ErrNeedForbidVer0:                  ErrCodeNeedForbidVer0,
+	ErrNoMpMigratePlan:                 ErrCodeNoMpMigratePlan,
+	ErrClientEvicted:                   ErrCodeClientEvicted,
+	ErrTLSRequired:                     ErrCodeTLSRequired,
+	ErrStaleFenceToken:                 ErrCodeStaleFenceToken,
+	ErrFlashGroupDraining:              ErrCodeFlashGroupDraining,
+	ErrVolImmutable:                    ErrCodeVolImmutable,
+	ErrInvalidAdminRole:                ErrCodeInvalidAdminRole,
 }
 
 func ParseErrorCode(code int32) error {
@@ -335,6 +380,22 @@ var code2ErrMap = map[int32]error{
 	ErrCodeNoSuchLifecycleConfiguration:    ErrNoSuchLifecycleConfiguration,
 	ErrCodeNoSupportStorageClass:           ErrNoSupportStorageClass,
 	ErrCodeTmpfsNoSpace:                    ErrTmpfsNoSpace,
+	ErrCodeVolNoAvailableSpace:             ErrVolNoAvailableSpace,
+	ErrCodeNoAclPermission:                 ErrNoAclPermission,
+	ErrCodeQuotaNotExists:                  ErrQuotaNotExists,
+	ErrCodeDiskNotExists:                   ErrDiskNotExists,
+	ErrCodeKeyNotExists:                    ErrKeyNotExists,
+	ErrCodeDuplicateKey:                    ErrDuplicateKey,
+	ErrCodeSnapshotNotEnabled:              ErrSnapshotNotEnabled,
+	ErrCodeMemberChange:                    ErrMemberChange,
+	ErrCodeNeedForbidVer0:                  ErrNeedForbidVer0,
+	ErrCodeNoMpMigratePlan:                 ErrNoMpMigratePlan,
+	ErrCodeClientEvicted:                   ErrClientEvicted,
+	ErrCodeTLSRequired:                     ErrTLSRequired,
+	ErrCodeStaleFenceToken:                 ErrStaleFenceToken,
+	ErrCodeFlashGroupDraining:              ErrFlashGroupDraining,
+	ErrCodeVolImmutable:                    ErrVolImmutable,
+	ErrCodeInvalidAdminRole:                ErrInvalidAdminRole,
 }
 
 type GeneralResp struct {
@@ -463,6 +463,43 @@ func (k *ExtentKey) GetExtentKey() (m string) {
 	return fmt.Sprintf("%v_%v_%v_%v_%v", k.PartitionId, k.FileOffset, k.ExtentId, k.ExtentOffset, k.Size)
 }
 
+// The extents of ids [TinyExtentStartID, TinyExtentStartID+TinyExtentCount) of
+// a data partition are the tiny extents.
+const (
+	TinyExtentCount   = 64
+	TinyExtentStartID = 1
+)
+
+// IsTinyExtent checks if the given extent is tiny extent.
+func IsTinyExtent(extentID uint64) bool {
+	return extentID >= TinyExtentStartID && extentID < TinyExtentStartID+TinyExtentCount
+}
+
+// The extents of ids [ContainerExtentStartID, math.MaxUint32] of a data partition
+// are the container extents, which pack the small files of a volume as records.
+// The extent offset of a key in a container addresses a record and a position
+// in it, a record never crosses ContainerRecordSpan.
+const (
+	ContainerExtentStartID = 1 << 31
+	ContainerRecordSpan    = 16 * util.MB
+)
+
+// IsContainerExtent checks if the given extent is a container extent.
+func IsContainerExtent(extentID uint64) bool {
+	return extentID >= ContainerExtentStartID && extentID <= math.MaxUint32
+}
+
+// ContainerOffset returns the extent offset of the delta byte of a record.
+func ContainerOffset(recordID, delta uint64) uint64 {
+	return recordID*ContainerRecordSpan + delta
+}
+
+// ParseContainerOffset returns the record and the position in it addressed by
+// the extent offset of a container.
+func ParseContainerOffset(offset uint64) (recordID, delta uint64) {
+	return offset / ContainerRecordSpan, offset % ContainerRecordSpan
+}
+
 type TinyExtentDeleteRecord struct {
 	FileOffset   uint64
 	PartitionId  uint64
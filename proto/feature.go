@@ -0,0 +1,145 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"sort"
+	"strings"
+)
+
+// FeatureBits is a set of protocol features a component understands. Nodes
+// report their bits in heartbeats and answer OpNegotiateFeatures, so that a
+// feature is only used once every peer involved supports it, e.g. during a
+// rolling upgrade of a mixed-version cluster.
+type FeatureBits uint64
+
+const (
+	FeatureNegotiate       FeatureBits = 1 << 0 // answers OpNegotiateFeatures
+	FeatureDirShard        FeatureBits = 1 << 1 // sharded directories across meta partitions
+	FeatureBatchMoveDentry FeatureBits = 1 << 2 // OpMetaBatchMoveDentry
+	FeatureSwapExtents     FeatureBits = 1 << 3 // OpMetaSwapExtents, online defrag
+	FeatureInlineData      FeatureBits = 1 << 4 // OpMetaSetInlineData, tiny files in the inode
+	FeatureDirVersion      FeatureBits = 1 << 5 // OpMetaGetDirVersion, strict consistency mode
+	FeatureSmallFilePack   FeatureBits = 1 << 6 // ContainerExtentFlag, small files packed into container extents
+)
+
+const (
+	FeatureRoleMetaNode = "metanode"
+	FeatureRoleDataNode = "datanode"
+)
+
+// FeatureDesc describes a feature and the roles which must all support it
+// before the feature is enabled for the cluster.
+type FeatureDesc struct {
+	Bit   FeatureBits
+	Name  string
+	Roles []string
+}
+
+// KnownFeatures lists the features of this build.
+var KnownFeatures = []FeatureDesc{
+	{FeatureNegotiate, "negotiate", []string{FeatureRoleMetaNode, FeatureRoleDataNode}},
+	{FeatureDirShard, "dirShard", []string{FeatureRoleMetaNode}},
+	{FeatureBatchMoveDentry, "batchMoveDentry", []string{FeatureRoleMetaNode}},
+	{FeatureSwapExtents, "swapExtents", []string{FeatureRoleMetaNode}},
+	{FeatureInlineData, "inlineData", []string{FeatureRoleMetaNode}},
+	{FeatureDirVersion, "dirVersion", []string{FeatureRoleMetaNode}},
+	{FeatureSmallFilePack, "smallFilePack", []string{FeatureRoleDataNode}},
+}
+
+// MetaNodeFeatures and DataNodeFeatures are the features served by this build.
+var (
+	MetaNodeFeatures = FeatureNegotiate | FeatureDirShard | FeatureBatchMoveDentry | FeatureSwapExtents | FeatureInlineData |
+		FeatureDirVersion
+	DataNodeFeatures = FeatureNegotiate | FeatureSmallFilePack
+)
+
+func (f FeatureBits) Has(bits FeatureBits) bool {
+	return f&bits == bits
+}
+
+// Names returns the names of the known features in f.
+func (f FeatureBits) Names() []string {
+	names := make([]string, 0)
+	for _, desc := range KnownFeatures {
+		if f.Has(desc.Bit) {
+			names = append(names, desc.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f FeatureBits) String() string {
+	return strings.Join(f.Names(), ",")
+}
+
+// FeatureNegotiation is the body of OpNegotiateFeatures in both directions.
+type FeatureNegotiation struct {
+	Features FeatureBits `json:"features"`
+	Version  string      `json:"version"`
+}
+
+// FeatureRoleStat counts the active nodes of a role supporting a feature.
+type FeatureRoleStat struct {
+	Role        string   `json:"role"`
+	Total       int      `json:"total"`
+	Supported   int      `json:"supported"`
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+// FeatureStatus is one row of the cluster feature matrix.
+type FeatureStatus struct {
+	Name    string             `json:"name"`
+	Bit     FeatureBits        `json:"bit"`
+	Enabled bool               `json:"enabled"`
+	Roles   []*FeatureRoleStat `json:"roles"`
+}
+
+// ClusterFeatureMatrix reports which features every relevant node supports.
+type ClusterFeatureMatrix struct {
+	Enabled  FeatureBits      `json:"enabled"`
+	Features []*FeatureStatus `json:"features"`
+}
+
+// BuildFeatureMatrix computes the matrix from the features reported by the
+// nodes of each role, keyed by role and then by node address.
+func BuildFeatureMatrix(nodes map[string]map[string]FeatureBits) *ClusterFeatureMatrix {
+	matrix := &ClusterFeatureMatrix{Features: make([]*FeatureStatus, 0, len(KnownFeatures))}
+	for _, desc := range KnownFeatures {
+		status := &FeatureStatus{Name: desc.Name, Bit: desc.Bit, Enabled: true}
+		for _, role := range desc.Roles {
+			stat := &FeatureRoleStat{Role: role}
+			for addr, bits := range nodes[role] {
+				stat.Total++
+				if bits.Has(desc.Bit) {
+					stat.Supported++
+				} else {
+					stat.Unsupported = append(stat.Unsupported, addr)
+				}
+			}
+			sort.Strings(stat.Unsupported)
+			if stat.Supported < stat.Total || stat.Total == 0 {
+				status.Enabled = false
+			}
+			status.Roles = append(status.Roles, stat)
+		}
+		if status.Enabled {
+			matrix.Enabled |= desc.Bit
+		}
+		matrix.Features = append(matrix.Features, status)
+	}
+	return matrix
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFeatureMatrix(t *testing.T) {
+	legacy := proto.FeatureNegotiate | proto.FeatureDirShard
+	nodes := map[string]map[string]proto.FeatureBits{
+		proto.FeatureRoleMetaNode: {
+			"192.168.0.1:17210": proto.MetaNodeFeatures,
+			"192.168.0.2:17210": legacy,
+		},
+		proto.FeatureRoleDataNode: {
+			"192.168.0.3:17310": proto.DataNodeFeatures,
+		},
+	}
+	matrix := proto.BuildFeatureMatrix(nodes)
+	require.Len(t, matrix.Features, len(proto.KnownFeatures))
+	require.True(t, matrix.Enabled.Has(proto.FeatureNegotiate|proto.FeatureDirShard))
+	require.False(t, matrix.Enabled.Has(proto.FeatureBatchMoveDentry))
+	require.False(t, matrix.Enabled.Has(proto.FeatureSwapExtents))
+
+	for _, feature := range matrix.Features {
+		if feature.Bit != proto.FeatureSwapExtents {
+			continue
+		}
+		require.Len(t, feature.Roles, 1)
+		require.Equal(t, 2, feature.Roles[0].Total)
+		require.Equal(t, 1, feature.Roles[0].Supported)
+		require.Equal(t, []string{"192.168.0.2:17210"}, feature.Roles[0].Unsupported)
+	}
+
+	// upgrading the last metanode enables everything
+	nodes[proto.FeatureRoleMetaNode]["192.168.0.2:17210"] = proto.MetaNodeFeatures
+	require.Equal(t, proto.MetaNodeFeatures|proto.DataNodeFeatures, proto.BuildFeatureMatrix(nodes).Enabled)
+
+	// a role without any node never enables its features
+	delete(nodes, proto.FeatureRoleDataNode)
+	require.False(t, proto.BuildFeatureMatrix(nodes).Enabled.Has(proto.FeatureNegotiate))
+	require.Equal(t, "batchMoveDentry,dirShard", (proto.FeatureDirShard | proto.FeatureBatchMoveDentry).String())
+}
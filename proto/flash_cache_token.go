@@ -0,0 +1,81 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// FlashCacheTokenMetadataKey is the gRPC metadata key of the flash cache token,
+// which the packets carry in their Arg instead.
+const FlashCacheTokenMetadataKey = "cubefs-flash-cache-token"
+
+// FlashCacheToken lets its holder read and prepare the flash cache of a volume
+// requiring remoteCacheAuth until Expire. The master issues it to the clients
+// knowing the owner of the volume, and signs it with the key it pushes to the
+// flash nodes, so that they verify it without asking the master.
+type FlashCacheToken struct {
+	Volume string
+	Expire int64 // unix second
+	Sign   string
+}
+
+func NewFlashCacheToken(key []byte, volume string, expire int64) *FlashCacheToken {
+	t := &FlashCacheToken{Volume: volume, Expire: expire}
+	t.Sign = t.sign(key)
+	return t
+}
+
+func (t *FlashCacheToken) sign(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s\n%d", t.Volume, t.Expire)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks that the token is signed by key for volume and not expired at now.
+func (t *FlashCacheToken) Verify(key []byte, volume string, now int64) error {
+	switch {
+	case len(key) == 0:
+		return fmt.Errorf("%w: no key", ErrFlashCacheTokenInvalid)
+	case t.Volume != volume:
+		return fmt.Errorf("%w: volume %v", ErrFlashCacheTokenInvalid, t.Volume)
+	case t.Expire < now:
+		return fmt.Errorf("%w: expired", ErrFlashCacheTokenInvalid)
+	case !hmac.Equal([]byte(t.sign(key)), []byte(t.Sign)):
+		return fmt.Errorf("%w: bad sign", ErrFlashCacheTokenInvalid)
+	}
+	return nil
+}
+
+func (t *FlashCacheToken) Encode() []byte {
+	data, _ := json.Marshal(t)
+	return data
+}
+
+// DecodeFlashCacheToken decodes the token carried by a request, none is an invalid token.
+func DecodeFlashCacheToken(data []byte) (t *FlashCacheToken, err error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: none", ErrFlashCacheTokenInvalid)
+	}
+	t = new(FlashCacheToken)
+	if err = json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFlashCacheTokenInvalid, err)
+	}
+	return
+}
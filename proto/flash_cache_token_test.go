@@ -0,0 +1,46 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlashCacheToken(t *testing.T) {
+	key := []byte("key")
+	token, err := DecodeFlashCacheToken(NewFlashCacheToken(key, "vol", 100).Encode())
+	require.NoError(t, err)
+	require.NoError(t, token.Verify(key, "vol", 100))
+
+	for _, err = range []error{
+		token.Verify(nil, "vol", 100),
+		token.Verify([]byte("other"), "vol", 100),
+		token.Verify(key, "vol2", 100),
+		token.Verify(key, "vol", 101),
+	} {
+		require.True(t, errors.Is(err, ErrFlashCacheTokenInvalid))
+		require.True(t, IsFlashNodeLimitError(err), "the client reads the data nodes instead")
+	}
+	token.Expire = 200
+	require.Error(t, token.Verify(key, "vol", 100), "the expire is signed")
+
+	for _, data := range [][]byte{nil, []byte("{")} {
+		_, err = DecodeFlashCacheToken(data)
+		require.True(t, errors.Is(err, ErrFlashCacheTokenInvalid))
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+// ForkRecord is the metadata of a directory, a file or a symlink of a subtree
+// exported to fork it into another volume. The files refer to the extents of
+// the source volume, the data is not copied.
+type ForkRecord struct {
+	Path       string // relative to the root of the subtree, the parents come first
+	Mode       uint32
+	Uid        uint32
+	Gid        uint32
+	Size       uint64
+	ModifyTime int64       // unix second
+	AccessTime int64       // unix second
+	Target     []byte      `json:",omitempty"` // of a symlink
+	Inline     []byte      `json:",omitempty"` // of a file whose data is stored in its inode
+	Extents    []ExtentKey `json:",omitempty"`
+}
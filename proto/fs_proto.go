@@ -33,6 +33,9 @@ const (
 	FlagsSyncWrite int = 1 << iota
 	FlagsAppend
 	FlagsCache
+	// the file is written sequentially and is known to outgrow a tiny extent,
+	// so it goes to normal extents from the first write
+	FlagsLargeWrite
 )
 
 const (
@@ -103,6 +106,8 @@ type InodeInfo struct {
 	MigrationStorageClass         uint32    `json:"migrationStorageClass"`
 	HasMigrationEk                bool      `json:"hasMigrationEk"`
 	MigrationExtentKeyExpiredTime time.Time `json:"mekExpiredTime"`
+	// of a directory, the storageClass of the inodes created in it, unspecified if it is not set
+	DirStorageClass uint32 `json:"dirStorageClass,omitempty"`
 }
 
 type SimpleExtInfo struct {
@@ -216,6 +221,8 @@ type QuotaCreateInodeRequest struct {
 	QuotaIds    []uint32 `json:"qids"`
 	RequestExtend
 	StorageType uint32 `json:"storageType"`
+	// the directory created takes StorageType as the storageClass of the inodes created in it
+	SetDirStorageClass bool `json:"setDirSc,omitempty"`
 }
 
 type CreateInodeRequest struct {
@@ -226,7 +233,8 @@ type CreateInodeRequest struct {
 	Gid         uint32 `json:"gid"`
 	Target      []byte `json:"tgt"`
 	RequestExtend
-	StorageType uint32 `json:"storageType"`
+	StorageType        uint32 `json:"storageType"`
+	SetDirStorageClass bool   `json:"setDirSc,omitempty"`
 }
 
 // CreateInodeResponse defines the response to the request of creating an inode.
@@ -262,6 +270,7 @@ type TxCreateInodeRequest struct {
 	TxInfo      *TransactionInfo `json:"tx"`
 	StorageType uint32           `json:"storageType"`
 	RequestExtend
+	SetDirStorageClass bool `json:"setDirSc,omitempty"`
 }
 
 // TxCreateInodeResponse defines the response with transaction info to the request of creating an inode.
@@ -552,6 +561,86 @@ type BatchDeleteDentryResponse struct {
 	} `json:"items"`
 }
 
+// MaxBatchMoveDentryCount is the max number of dentries moved by one request.
+const MaxBatchMoveDentryCount = 1024
+
+// MoveDentryItem renames SrcName under the source parent to DstName under the
+// destination parent.
+type MoveDentryItem struct {
+	SrcName string `json:"src"`
+	DstName string `json:"dst"`
+}
+
+// BatchMoveDentryRequest re-parents a batch of dentries on the metanode side.
+// Both parents must belong to the partition the request is sent to.
+type BatchMoveDentryRequest struct {
+	VolName     string           `json:"vol"`
+	PartitionID uint64           `json:"pid"`
+	SrcParentID uint64           `json:"spino"`
+	DstParentID uint64           `json:"dpino"`
+	Items       []MoveDentryItem `json:"items"`
+}
+
+type MoveDentryResult struct {
+	SrcName string `json:"src"`
+	Inode   uint64 `json:"ino"`
+	Status  uint8  `json:"status"`
+}
+
+// BatchMoveDentryResponse carries the result of every item in request order.
+type BatchMoveDentryResponse struct {
+	Results []MoveDentryResult `json:"results"`
+}
+
+// SwapExtentsRequest replaces the extents of Inode with those of TmpInode,
+// a scratch inode holding a defragmented copy of the same data. The swap is
+// only applied if Inode has not been modified since Generation was read.
+type SwapExtentsRequest struct {
+	VolName     string `json:"vol"`
+	PartitionID uint64 `json:"pid"`
+	Inode       uint64 `json:"ino"`
+	TmpInode    uint64 `json:"tmpIno"`
+	Generation  uint64 `json:"gen"`
+}
+
+// SetInlineDataRequest stores Data, the whole content of a tiny file without
+// extents, in the inode. Size is the file size, the data past Data is zero.
+type SetInlineDataRequest struct {
+	VolName     string `json:"vol"`
+	PartitionID uint64 `json:"pid"`
+	Inode       uint64 `json:"ino"`
+	Data        []byte `json:"data"`
+	Size        uint64 `json:"sz"`
+	ModifyTime  int64  `json:"mt"` // set by the leader
+}
+
+// GetDirVersionRequest gets the version of the dentries of a directory, which
+// changes whenever one of them is created, deleted or updated.
+type GetDirVersionRequest struct {
+	VolName     string `json:"vol"`
+	PartitionID uint64 `json:"pid"`
+	ParentID    uint64 `json:"pino"`
+}
+
+type GetDirVersionResponse struct {
+	Version uint64 `json:"ver"`
+}
+
+// FragmentedInode describes a file whose extent count exceeds the report threshold.
+type FragmentedInode struct {
+	Inode       uint64 `json:"ino"`
+	Size        uint64 `json:"size"`
+	ExtentCount int    `json:"extentCount"`
+	AvgExtent   uint64 `json:"avgExtentSize"`
+}
+
+// FragmentedInodesResponse is the result of a fragmentation report on one meta partition.
+type FragmentedInodesResponse struct {
+	PartitionID uint64             `json:"pid"`
+	Scanned     uint64             `json:"scanned"`
+	Inodes      []*FragmentedInode `json:"inodes"`
+}
+
 // LookupRequest defines the request for lookup.
 type LookupRequest struct {
 	VolName     string `json:"vol"`
@@ -661,6 +750,7 @@ type ReadDirLimitRequest struct {
 	Limit       uint64 `json:"limit"`
 	VerSeq      uint64 `json:"seq"`
 	VerOpt      uint8  `json:"VerOpt"`
+	Prefix      string `json:"prefix,omitempty"` // only the names with it, ignored by the old metanodes
 }
 
 type ReadDirLimitResponse struct {
@@ -740,6 +830,8 @@ type GetExtentsResponse struct {
 	LayerInfo       []LayerInfo `json:"layer"`
 	Status          int
 	LeaseExpireTime uint64 `json:"leaseExpireTime"`
+	// the data of a tiny file stored in the inode, set only if it has no extents
+	InlineData []byte `json:"inline,omitempty"`
 }
 
 // TruncateRequest defines the request to truncate.
@@ -783,6 +875,8 @@ type SetAttrRequest struct {
 	AccessTime  int64  `json:"at"`
 	Valid       uint32 `json:"valid"`
 	VerSeq      uint64 `json:"seq"`
+	// set with AttrStorageClass, of an empty file or of the inodes created in a directory
+	StorageClass uint32 `json:"storageClass,omitempty"`
 }
 
 const (
@@ -791,6 +885,7 @@ const (
 	AttrGid
 	AttrModifyTime
 	AttrAccessTime
+	AttrStorageClass
 )
 
 // DeleteInodeRequest defines the request to delete an inode.
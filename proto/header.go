@@ -14,7 +14,41 @@
 
 package proto
 
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
 const (
 	HeaderAcceptEncoding  = "x-cfs-Accept-Encoding"
 	HeaderContentEncoding = "x-cfs-Content-Encoding"
+	// HeaderRequestID carries the id of a request given by the client, or
+	// generated by the server, in the request and the response.
+	HeaderRequestID = "X-Request-Id"
 )
+
+const maxRequestIDLen = 64
+
+// NewRequestID returns a random id of a request.
+func NewRequestID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// ValidRequestID returns whether the request id given by a client is taken,
+// which is of no more than 64 letters, digits, '-', '_', '.' and ':', so that
+// it is safe in the logs and the headers.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	id := NewRequestID()
+	require.Len(t, id, 32)
+	require.True(t, ValidRequestID(id))
+	require.NotEqual(t, id, NewRequestID())
+
+	require.True(t, ValidRequestID("trace-1_a.b:c"))
+	require.True(t, ValidRequestID(strings.Repeat("a", 64)))
+	require.False(t, ValidRequestID(""))
+	require.False(t, ValidRequestID(strings.Repeat("a", 65)))
+	require.False(t, ValidRequestID("a b"))
+	require.False(t, ValidRequestID("a\nb"))
+	require.False(t, ValidRequestID("<script>"))
+}
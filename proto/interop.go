@@ -0,0 +1,143 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Interop settings control how the object (S3) view and the POSIX view of a
+// volume interact with each other.
+const (
+	// ObjKeyMappingCompat normalizes object keys into paths, leading and
+	// duplicated separators are dropped, so several keys may alias one path.
+	ObjKeyMappingCompat = "compat"
+	// ObjKeyMappingStrict rejects object keys which do not map one to one to
+	// a POSIX path.
+	ObjKeyMappingStrict = "strict"
+
+	// InteropSyncCached lets objectnode serve metadata and dentries from its
+	// cache, changes made through the POSIX view become visible on refresh.
+	InteropSyncCached = "cached"
+	// InteropSyncImmediate bypasses the objectnode cache so that changes made
+	// through the POSIX view are visible to the next object request.
+	InteropSyncImmediate = "immediate"
+
+	MaxObjectKeyLength = 1024
+	MaxPathNameLength  = 255
+)
+
+type InteropPolicy struct {
+	KeyMapping       string `json:"keyMapping"`
+	MetaSync         string `json:"metaSync"`
+	RenameVisibility string `json:"renameVisibility"`
+}
+
+func DefaultInteropPolicy() InteropPolicy {
+	return InteropPolicy{
+		KeyMapping:       ObjKeyMappingCompat,
+		MetaSync:         InteropSyncCached,
+		RenameVisibility: InteropSyncCached,
+	}
+}
+
+// Normalize fills the settings left empty, volumes created before the
+// interop settings existed behave as the defaults.
+func (p *InteropPolicy) Normalize() {
+	def := DefaultInteropPolicy()
+	if p.KeyMapping == "" {
+		p.KeyMapping = def.KeyMapping
+	}
+	if p.MetaSync == "" {
+		p.MetaSync = def.MetaSync
+	}
+	if p.RenameVisibility == "" {
+		p.RenameVisibility = def.RenameVisibility
+	}
+}
+
+func (p *InteropPolicy) Validate() error {
+	if p.KeyMapping != ObjKeyMappingCompat && p.KeyMapping != ObjKeyMappingStrict {
+		return fmt.Errorf("invalid key mapping %q, expect %v or %v", p.KeyMapping, ObjKeyMappingCompat, ObjKeyMappingStrict)
+	}
+	for name, v := range map[string]string{"meta sync": p.MetaSync, "rename visibility": p.RenameVisibility} {
+		if v != InteropSyncCached && v != InteropSyncImmediate {
+			return fmt.Errorf("invalid %v %q, expect %v or %v", name, v, InteropSyncCached, InteropSyncImmediate)
+		}
+	}
+	return nil
+}
+
+func (p InteropPolicy) IsStrictKeyMapping() bool {
+	return p.KeyMapping == ObjKeyMappingStrict
+}
+
+func (p InteropPolicy) IsImmediateMetaSync() bool {
+	return p.MetaSync == InteropSyncImmediate
+}
+
+func (p InteropPolicy) IsImmediateRename() bool {
+	return p.RenameVisibility == InteropSyncImmediate
+}
+
+// CheckObjectKeyMapping returns the reason why the object key can not be
+// stored as the POSIX path of the same name, or an empty string.
+func CheckObjectKeyMapping(key string) string {
+	if reason := checkKeyChars(key); reason != "" {
+		return reason
+	}
+	if strings.HasPrefix(key, "/") {
+		return "leading separator"
+	}
+	segments := strings.Split(strings.TrimSuffix(key, "/"), "/")
+	for _, seg := range segments {
+		switch {
+		case seg == "":
+			return "empty path segment"
+		case seg == "." || seg == "..":
+			return "relative path segment"
+		case len(seg) > MaxPathNameLength:
+			return "path segment too long"
+		}
+	}
+	return ""
+}
+
+// CheckPathMapping returns the reason why the POSIX path can not be served as
+// an object key, or an empty string. The path is relative to the volume root.
+func CheckPathMapping(path string) string {
+	return checkKeyChars(strings.TrimPrefix(path, "/"))
+}
+
+func checkKeyChars(key string) string {
+	if key == "" {
+		return "empty key"
+	}
+	if len(key) > MaxObjectKeyLength {
+		return "key too long"
+	}
+	if !utf8.ValidString(key) {
+		return "invalid utf-8"
+	}
+	for _, r := range key {
+		if unicode.IsControl(r) {
+			return "control character"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteropPolicy(t *testing.T) {
+	p := InteropPolicy{MetaSync: InteropSyncImmediate}
+	p.Normalize()
+	require.NoError(t, p.Validate())
+	require.False(t, p.IsStrictKeyMapping())
+	require.True(t, p.IsImmediateMetaSync())
+	require.False(t, p.IsImmediateRename())
+
+	p.RenameVisibility = "eventual"
+	require.Error(t, p.Validate())
+	p.RenameVisibility = InteropSyncCached
+	p.KeyMapping = "loose"
+	require.Error(t, p.Validate())
+}
+
+func TestInteropKeyMapping(t *testing.T) {
+	for key, ok := range map[string]bool{
+		"a/b/c":                   true,
+		"a/b/":                    true,
+		"/a/b":                    false,
+		"a//b":                    false,
+		"a/./b":                   false,
+		"a/../b":                  false,
+		"a/\x01b":                 false,
+		"a/\xffb":                 false,
+		strings.Repeat("x", 256):  false,
+		strings.Repeat("x/", 600): false,
+	} {
+		require.Equal(t, ok, CheckObjectKeyMapping(key) == "", key)
+	}
+	require.Empty(t, CheckPathMapping("/a/b"))
+	require.NotEmpty(t, CheckPathMapping("/a/\tb"))
+	require.NotEmpty(t, CheckPathMapping("/"+strings.Repeat("x", MaxObjectKeyLength+1)))
+}
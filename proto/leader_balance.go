@@ -0,0 +1,47 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+const (
+	LeaderBalanceDataPartition = "data"
+	LeaderBalanceMetaPartition = "meta"
+)
+
+// LeaderBalanceNode is the raft leaders a node holds and its share of them,
+// the sum over the partitions it may lead of one out of the replicas that may
+// lead each of them.
+type LeaderBalanceNode struct {
+	Addr     string
+	Leaders  int
+	Share    float64
+	Balanced int // the leaders once the transfers succeed
+}
+
+type LeaderTransfer struct {
+	Type        string // data or meta
+	PartitionID uint64
+	From        string
+	To          string
+	Err         string `json:",omitempty"`
+}
+
+// LeaderBalanceView is the leader distribution of the data and meta nodes and
+// the leader transfers requested, or only planned with DryRun.
+type LeaderBalanceView struct {
+	DryRun    bool
+	DataNodes []*LeaderBalanceNode
+	MetaNodes []*LeaderBalanceNode
+	Transfers []*LeaderTransfer
+}
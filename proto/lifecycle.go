@@ -390,6 +390,16 @@ type LcNodeRuleTaskResponse struct {
 	RcvStop    bool
 	Rule       *Rule
 	LcNodeRuleTaskStatistics
+	LcNodeRuleTaskProgress
+}
+
+// LcNodeRuleTaskProgress is the live state of a running scanner reported by lcnode heartbeats.
+type LcNodeRuleTaskProgress struct {
+	Paused       bool
+	ScanLimit    float64 // files per second, 0 means unlimited
+	DirQueueLen  int
+	FileQueueLen int
+	RecentErrors []string
 }
 
 type LcNodeRuleTaskStatistics struct {
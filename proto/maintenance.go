@@ -0,0 +1,78 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+)
+
+// The maintenance of the whole cluster, a zone or a node set holds off the
+// automatic decommission, replica repair and rebalancing of the nodes it
+// covers, and the nodes defer their background work, so that the nodes cut off
+// by a planned network maintenance do not set off a repair storm.
+const (
+	MaintenanceScopeCluster = "cluster"
+	MaintenanceScopeZone    = "zone"
+	MaintenanceScopeNodeSet = "nodeset"
+)
+
+type Maintenance struct {
+	Scope     string
+	ZoneName  string `json:",omitempty"` // of the zone scope
+	NodeSetID uint64 `json:",omitempty"` // of the node set scope
+	Reason    string
+	StartTime int64
+	EndTime   int64 // the unix second it ends by itself, 0 for never
+}
+
+func CheckMaintenanceScope(scope string) error {
+	switch scope {
+	case MaintenanceScopeCluster, MaintenanceScopeZone, MaintenanceScopeNodeSet:
+		return nil
+	}
+	return fmt.Errorf("maintenance scope(%v) is not one of cluster, zone and nodeset", scope)
+}
+
+// SameTarget tells whether both are of the same cluster, zone or node set.
+func (m *Maintenance) SameTarget(o *Maintenance) bool {
+	return m.Scope == o.Scope && m.ZoneName == o.ZoneName && m.NodeSetID == o.NodeSetID
+}
+
+func (m *Maintenance) Active(now int64) bool {
+	return m.EndTime == 0 || now < m.EndTime
+}
+
+// Covers tells whether a node of the zone and the node set is in the maintenance.
+func (m *Maintenance) Covers(zoneName string, nodeSetID uint64) bool {
+	switch m.Scope {
+	case MaintenanceScopeCluster:
+		return true
+	case MaintenanceScopeZone:
+		return m.ZoneName == zoneName
+	case MaintenanceScopeNodeSet:
+		return m.NodeSetID == nodeSetID
+	}
+	return false
+}
+
+func (m *Maintenance) Target() string {
+	switch m.Scope {
+	case MaintenanceScopeZone:
+		return fmt.Sprintf("zone(%v)", m.ZoneName)
+	case MaintenanceScopeNodeSet:
+		return fmt.Sprintf("nodeset(%v)", m.NodeSetID)
+	}
+	return m.Scope
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	require.NoError(t, CheckMaintenanceScope(MaintenanceScopeNodeSet))
+	require.Error(t, CheckMaintenanceScope("node"))
+
+	cluster := &Maintenance{Scope: MaintenanceScopeCluster}
+	zone := &Maintenance{Scope: MaintenanceScopeZone, ZoneName: "z1", EndTime: 100}
+	nodeSet := &Maintenance{Scope: MaintenanceScopeNodeSet, NodeSetID: 3}
+	require.True(t, cluster.Covers("z2", 4))
+	require.True(t, zone.Covers("z1", 4))
+	require.False(t, zone.Covers("z2", 3))
+	require.True(t, nodeSet.Covers("z2", 3))
+	require.False(t, nodeSet.Covers("z1", 4))
+
+	require.True(t, cluster.Active(1<<40))
+	require.True(t, zone.Active(99))
+	require.False(t, zone.Active(100))
+
+	require.True(t, zone.SameTarget(&Maintenance{Scope: MaintenanceScopeZone, ZoneName: "z1"}))
+	require.False(t, zone.SameTarget(&Maintenance{Scope: MaintenanceScopeZone, ZoneName: "z2"}))
+	require.Equal(t, "zone(z1)", zone.Target())
+	require.Equal(t, "nodeset(3)", nodeSet.Target())
+}
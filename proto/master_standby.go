@@ -0,0 +1,58 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+// The roles of a master. A standby master is a raft learner of the primary
+// group, it becomes promoted once the standby group takes over.
+const (
+	MasterRolePrimary  = "primary"
+	MasterRoleStandby  = "standby"
+	MasterRolePromoted = "promoted"
+)
+
+type MasterStandbyPeer struct {
+	ID      uint64
+	Addr    string
+	Standby bool
+}
+
+// MasterStandbyStatus is what a master knows about the primary and the standby
+// groups, the commit and applied index tell how far a standby lags behind.
+type MasterStandbyStatus struct {
+	ID      uint64
+	Addr    string
+	Role    string
+	Fenced  bool
+	Leader  string
+	Term    uint64
+	Commit  uint64
+	Applied uint64
+	Peers   []MasterStandbyPeer
+}
+
+// MasterPromoteResult is the result of promoting the standby group. The masters
+// of the primary group not fenced are only left out with force, and must be
+// kept down by the operator.
+type MasterPromoteResult struct {
+	Fenced        []string
+	Unfenced      []string
+	Promoted      []string
+	NotPromoted   []string
+	PrimaryCommit uint64
+	Applied       uint64
+	// LostEntries is how many entries committed by the primary group the
+	// standby has not applied, only not 0 with force.
+	LostEntries uint64
+}
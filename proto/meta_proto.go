@@ -38,6 +38,7 @@ type Peer struct {
 	Addr          string `json:"addr"`
 	HeartbeatPort string `json:"raftHeartbeat"`
 	ReplicaPort   string `json:"raftReplica"`
+	Witness       bool   `json:"witness,omitempty"` // votes in raft but holds no data
 }
 
 // CreateMetaPartitionRequest defines the request to create a meta partition.
@@ -48,8 +48,11 @@ type MetaNodeInfo struct {
 	PersistenceMetaPartitions []uint64
 	RdOnly                    bool
 	CanAllowPartition         bool
-	MaxMpCntLimit             uint64  `json:"maxMpCntLimit"`
-	CpuUtil                   float64 `json:"cpuUtil"`
+	MaxMpCntLimit             uint64                `json:"maxMpCntLimit"`
+	CpuUtil                   float64               `json:"cpuUtil"`
+	ResourceThrottle          ResourceThrottleState `json:"resourceThrottle"`
+	HealthScore               int                   `json:"healthScore"` // 0 to 100, on the heartbeats, tasks and load of late
+	PoorHealth                bool                  `json:"poorHealth"`  // used for new partitions only if there are not enough others
 }
 
 // DataNode stores all the information about a data node
@@ -79,15 +82,19 @@ type DataNodeInfo struct {
 	LostDisks                             []string
 	RdOnly                                bool
 	CanAllocPartition                     bool
-	MaxDpCntLimit                         uint64             `json:"maxDpCntLimit"`
-	CpuUtil                               float64            `json:"cpuUtil"`
-	IoUtils                               map[string]float64 `json:"ioUtil"`
+	MaxDpCntLimit                         uint64                `json:"maxDpCntLimit"`
+	CpuUtil                               float64               `json:"cpuUtil"`
+	IoUtils                               map[string]float64    `json:"ioUtil"`
+	ResourceThrottle                      ResourceThrottleState `json:"resourceThrottle"`
+	LoadProgress                          DataNodeLoadProgress  `json:"loadProgress"`
 	DecommissionedDisk                    []string
 	DecommissionSuccessDisk               []string
 	BackupDataPartitions                  []uint64
 	MediaType                             uint32
 	DiskOpLogs                            []OpLog
 	DpOpLogs                              []OpLog
+	HealthScore                           int  `json:"healthScore"` // 0 to 100, on the heartbeats, tasks, disks and load of late
+	PoorHealth                            bool `json:"poorHealth"`  // used for new partitions only if there are not enough others
 }
 
 // MetaPartition defines the structure of a meta partition
@@ -115,6 +122,8 @@ type MetaPartitionInfo struct {
 	StatByStorageClass        []*StatOfStorageClass
 	StatByMigrateStorageClass []*StatOfStorageClass
 	ForbidWriteOpOfProtoVer0  bool
+	Qps                       uint64 // ops a second of all the replicas
+	MemUsed                   uint64 // estimated memory used by a replica
 }
 
 // MetaReplica defines the replica of a meta partition
@@ -157,6 +166,18 @@ type ClusterView struct {
 	DecommissionDiskLimit                     uint32
 	DpRepairTimeout                           string
 	DpBackupTimeout                           string
+	ReplicaTombstoneRetention                 string
+	ClockSkewWarn                             string
+	ClockSkewLimit                            string
+	HotDpReadQps                              uint64
+	HotDpReadReplicas                         uint64
+	HeartbeatMaxInterval                      string
+	FlashGroupMinNodesPerZone                 uint64
+	LeaderBalanceMaxTransfers                 uint64
+	HotMpSplitQps                             uint64
+	HotMpSplitMemMB                           uint64
+	DecommissionBandwidthMBps                 uint64
+	Maintenances                              []Maintenance
 	DpTimeout                                 string
 	MpTimeout                                 string
 	DataNodeStatInfo                          *NodeStatInfo
@@ -175,6 +196,11 @@ type ClusterView struct {
 	FlashNodes                                []NodeView
 	FlashNodeHandleReadTimeout                int
 	FlashNodeReadDataNodeTimeout              int
+	FlashNodePeerFillEnable                   bool
+	FlashNodePeerFillTimeout                  int
+	FlashNodeAdmissionEnable                  bool
+	FlashNodeReadLimitMBps                    int
+	FlashNodeReadLimitIops                    int
 }
 
 // ClusterNode defines the structure of a cluster node
@@ -347,6 +373,18 @@ type DataPartitionInfo struct {
 	Forbidden                bool
 	MediaType                uint32
 	ForbidWriteOpOfProtoVer0 bool
+	ReadQps                  uint64
+	ReadReplicas             []*DataReadReplica
+}
+
+// DataReadReplica is a temporary read only copy of a hot data partition, on a
+// data node out of its raft group.
+type DataReadReplica struct {
+	Addr       string
+	CreateTime int64
+	ReportTime int64
+	Ready      bool // advertised to the clients
+	ReadQps    uint64
 }
 
 // FileInCore define file in data partition
@@ -384,6 +422,7 @@ type DataReplica struct {
 	ReadOnlyReasons            uint32
 	IsMissingTinyExtent        bool
 	IsRepairing                bool
+	ReadQps                    uint64
 }
 
 // data partition diagnosis represents the inactive data nodes, corrupt data partitions, and data partitions lack of replicas
@@ -462,6 +501,30 @@ type DataDecommissionProgress struct {
 	ResidualDps   []IgnoreDecommissionDP
 }
 
+// DecommissionNodeProgress tells how far the decommission of a data or meta node
+// is. The bandwidth is of the bytes moved off the data node in the last minutes,
+// and the completion is estimated from it, or from the partitions moved off a
+// meta node. The master leader keeps the samples in memory, so they start over
+// on a leader change.
+type DecommissionNodeProgress struct {
+	Addr                  string
+	NodeType              string
+	Status                uint32
+	StatusMessage         string
+	StartTime             int64 // the unix second the leader saw it start
+	TotalPartitions       int
+	MigratedPartitions    int
+	RemainingPartitions   int
+	RunningPartitions     int
+	FailedPartitions      int
+	MigratedBytes         uint64 // since the start time
+	RemainingBytes        uint64
+	BandwidthMBps         float64
+	ThrottleMBps          uint64 // the decommission bandwidth of the cluster, 0 for unlimited
+	EstimatedSeconds      int64  // 0 if not known
+	EstimatedCompleteTime int64
+}
+
 type DiskInfo struct {
 	NodeId  uint64
 	Address string
@@ -88,15 +88,40 @@ const (
 	// remotecache
 	ForceRemoteCache
 
+	PacketCompressThreshold
+	EnableTLS
+	TLSCAFile
+
+	WriteCoalesceSize
+	WriteCoalesceWindowMs
+
+	SessionResumeTimeout
+
+	RemoteCacheReadPolicy
+
+	ZoneName
+
+	StatfsMode
+
+	MountProfileName
+
 	MaxMountOption
 )
 
+// How statfs reports the space and the inodes of a mount, e.g. for df in a container.
+const (
+	StatfsModeQuota  = "quota"  // of the innermost quota covering the subdir mounted, of the volume if none
+	StatfsModeVolume = "volume" // of the whole volume
+)
+
 // For server
 const (
 	MasterAddr       = "masterAddr"
 	ListenPort       = "listen"
 	ObjectNodeDomain = "objectNodeDomain"
 	BindIpKey        = "bindIp"
+	TLSCertFileKey   = "tlsCertFile" // the data and meta nodes serve TLS clients with this certificate
+	TLSKeyFileKey    = "tlsKeyFile"
 )
 
 type MountOption struct {
@@ -188,6 +213,23 @@ func InitMountOptions(opts []MountOption) {
 	opts[AheadReadWindowCnt] = MountOption{"aheadReadWindowCnt", "ahead read window block count", "", int64(8)}
 
 	opts[ForceRemoteCache] = MountOption{"forceRemoteCache", "All read requests are handled by the remote cache.", "", false}
+
+	opts[PacketCompressThreshold] = MountOption{"packetCompressThreshold", "Compress the packets to data and meta nodes not smaller than this size in bytes, 0 disables", "", int64(0)}
+	opts[EnableTLS] = MountOption{"enableTLS", "Talk to data and meta nodes over TLS, always on if the volume requires TLS", "", false}
+	opts[TLSCAFile] = MountOption{"tlsCAFile", "The CA certificates in PEM to verify data and meta nodes with, the system ones if empty", "", ""}
+
+	opts[WriteCoalesceSize] = MountOption{"writeCoalesceSize", "Gather the appends smaller than this size in bytes into one extent write, 0 disables", "", int64(0)}
+	opts[WriteCoalesceWindowMs] = MountOption{"writeCoalesceWindowMs", "How long the gathered appends may wait to be written, ms", "", int64(10)}
+
+	opts[RemoteCacheReadPolicy] = MountOption{"remoteCacheReadPolicy", "On a slow or failed flash read, hedge to a second flash node or fallback to the data nodes, empty retries as the volume sets", "", ""}
+
+	opts[SessionResumeTimeout] = MountOption{"sessionResumeTimeout", "How long the writes of a file wait for the session with the meta and data nodes to be resumed after a network failure, s, 0 disables", "", int64(0)}
+
+	opts[ZoneName] = MountOption{"zoneName", "Zone of the client, to read from the cheapest replicas and flash nodes by the zone costs set on master", "", ""}
+
+	opts[StatfsMode] = MountOption{"statfsMode", "Report the space and inodes of the quota covering the subdir mounted with quota, or of the volume with volume", "", StatfsModeVolume}
+
+	opts[MountProfileName] = MountOption{"mountProfile", "Name of the mount profile on master to take unset options from", "", ""}
 	for i := 0; i < MaxMountOption; i++ {
 		flag.StringVar(&opts[i].cmdlineValue, opts[i].keyword, "", opts[i].description)
 	}
@@ -238,6 +280,29 @@ func ParseMountOptions(opts []MountOption, cfg *config.Config) {
 	}
 }
 
+// ApplyMountProfile sets the options given neither on the command line nor in cfg
+// to the values of the profile, and returns the keywords of them.
+func ApplyMountProfile(opts []MountOption, cfg *config.Config, profile *MountProfile) (applied []string) {
+	for i := 0; i < MaxMountOption; i++ {
+		value, ok := profile.Options[opts[i].keyword]
+		if !ok || opts[i].cmdlineValue != "" || cfg.HasKey(opts[i].keyword) {
+			continue
+		}
+		switch opts[i].value.(type) {
+		case string:
+			opts[i].value = value
+		case int64:
+			opts[i].value = parseInt64(value)
+		case bool:
+			opts[i].value = parseBool(value)
+		default:
+			continue
+		}
+		applied = append(applied, opts[i].keyword)
+	}
+	return
+}
+
 func parseInt64(s string) int64 {
 	var ret int64 = -1
 
@@ -368,4 +433,21 @@ type MountOptions struct {
 
 	// remote cache
 	ForceRemoteCache bool
+
+	PacketCompressThreshold int64
+	EnableTLS               bool
+	TLSCAFile               string
+
+	WriteCoalesceSize     int64
+	WriteCoalesceWindowMs int64
+
+	SessionResumeTimeout int64
+
+	RemoteCacheReadPolicy string
+
+	ZoneName string
+
+	StatfsMode string
+
+	MountProfile string
 }
@@ -0,0 +1,151 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MountProfile is a named set of client mount options kept by the master, clients
+// mounting with the profile take the options not set in their own config from it.
+type MountProfile struct {
+	Name       string            `json:"name"`
+	Options    map[string]string `json:"options"`
+	UpdateTime int64             `json:"updateTime"`
+}
+
+type mountProfileOptionKind int
+
+const (
+	profileInt mountProfileOptionKind = iota
+	profileBool
+	profileString
+)
+
+// mountProfileOptions are the mount options which tune a client rather than
+// identify the mount, only these can be set by a profile.
+var mountProfileOptions = map[string]mountProfileOptionKind{
+	// cache
+	"icacheTimeout":         profileInt,
+	"lookupValid":           profileInt,
+	"attrValid":             profileInt,
+	"disableDcache":         profileBool,
+	"keepcache":             profileBool,
+	"buffersTotalLimit":     profileInt,
+	"maxStreamerLimit":      profileInt,
+	"bcacheFilterFiles":     profileString,
+	"bcacheBatchCnt":        profileInt,
+	"bcacheCheckIntervalS":  profileInt,
+	"aheadReadEnable":       profileBool,
+	"aheadReadTotalMemGB":   profileInt,
+	"aheadReadBlockTimeOut": profileInt,
+	"aheadReadWindowCnt":    profileInt,
+	"writeCoalesceSize":     profileInt,
+	"writeCoalesceWindowMs": profileInt,
+	// qos
+	"readRate":  profileInt,
+	"writeRate": profileInt,
+	// flash
+	"forceRemoteCache":      profileBool,
+	"remoteCacheReadPolicy": profileString,
+	// read policy
+	"followerRead":  profileBool,
+	"nearRead":      profileBool,
+	"maximallyRead": profileBool,
+	// network
+	"packetCompressThreshold": profileInt,
+	"enableTLS":               profileBool,
+	"tlsCAFile":               profileString,
+	// retry
+	"streamRetryTimeout":   profileInt,
+	"clientOpTimeOut":      profileInt,
+	"requestTimeout":       profileInt,
+	"metaSendTimeout":      profileInt,
+	"sessionResumeTimeout": profileInt,
+
+	"enableAudit": profileBool,
+}
+
+var mountProfileNameRegexp = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_-]{0,63}$")
+
+// MountProfileOptionKeys returns the options a profile can set, sorted.
+func MountProfileOptionKeys() (keys []string) {
+	for key := range mountProfileOptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// Validate checks the name and the options of the profile, and rewrites the
+// values in the form the client parses them.
+func (p *MountProfile) Validate() error {
+	if !mountProfileNameRegexp.MatchString(p.Name) {
+		return fmt.Errorf("invalid mount profile name %q", p.Name)
+	}
+	for key, value := range p.Options {
+		kind, ok := mountProfileOptions[key]
+		if !ok {
+			return fmt.Errorf("option %v can not be set by mount profile", key)
+		}
+		value = strings.TrimSpace(value)
+		switch kind {
+		case profileInt:
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value %q of option %v, expect integer", value, key)
+			}
+			value = strconv.FormatInt(v, 10)
+		case profileBool:
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q of option %v, expect bool", value, key)
+			}
+			value = strconv.FormatBool(v)
+		}
+		p.Options[key] = value
+	}
+	return nil
+}
+
+// ParseMountProfileOptions parses options given as comma separated key=value pairs.
+func ParseMountProfileOptions(s string) (options map[string]string, err error) {
+	options = make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid option %q, expect key=value", pair)
+		}
+		options[strings.TrimSpace(kv[0])] = kv[1]
+	}
+	return
+}
+
+// FormatMountProfileOptions is the reverse of ParseMountProfileOptions, the keys are sorted.
+func FormatMountProfileOptions(options map[string]string) string {
+	pairs := make([]string, 0, len(options))
+	for key, value := range options {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/util/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMountProfile(t *testing.T) {
+	options, err := ParseMountProfileOptions("readRate= 100 ,nearRead=1,bcacheFilterFiles=py;sh")
+	require.NoError(t, err)
+	p := &MountProfile{Name: "gpu-train", Options: options}
+	require.NoError(t, p.Validate())
+	require.Equal(t, "bcacheFilterFiles=py;sh,nearRead=true,readRate=100", FormatMountProfileOptions(p.Options))
+
+	_, err = ParseMountProfileOptions("readRate")
+	require.Error(t, err)
+	require.Error(t, (&MountProfile{Name: "-x"}).Validate())
+	require.Error(t, (&MountProfile{Name: "x", Options: map[string]string{"volName": "v"}}).Validate())
+	require.Error(t, (&MountProfile{Name: "x", Options: map[string]string{"writeRate": "fast"}}).Validate())
+
+	opts := NewMountOptions()
+	opts[ReadRate] = MountOption{"readRate", "", "", int64(200)}
+	opts[WriteRate] = MountOption{"writeRate", "", "10", int64(10)}
+	opts[NearRead] = MountOption{"nearRead", "", "", false}
+	opts[BcacheFilterFiles] = MountOption{"bcacheFilterFiles", "", "", "log"}
+	p.Options["writeRate"] = "300"
+	cfg := config.LoadConfigString(`{"readRate": 200}`)
+	applied := ApplyMountProfile(opts, cfg, p)
+	require.ElementsMatch(t, []string{"nearRead", "bcacheFilterFiles"}, applied)
+	require.Equal(t, int64(200), opts[ReadRate].GetInt64())
+	require.Equal(t, int64(10), opts[WriteRate].GetInt64())
+	require.True(t, opts[NearRead].GetBool())
+	require.Equal(t, "py;sh", opts[BcacheFilterFiles].GetString())
+}
@@ -78,6 +78,12 @@ const (
 	OpSnapshotExtentRepairRsp        uint8 = 0x18
 	// 0x19 is occupied by OpMetaUpdateExtentKeyAfterMigration
 
+	// Operations: Client -> DataNode/MetaNode, exchange supported FeatureBits.
+	OpNegotiateFeatures uint8 = 0x1A
+
+	// Operations: DataNode -> DataNode, the indexes of the container extents.
+	OpGetContainerIndex uint8 = 0x1B
+
 	// Operations: Client -> MetaNode.
 	OpMetaCreateInode   uint8 = 0x20
 	OpMetaUnlinkInode   uint8 = 0x21
@@ -183,6 +189,18 @@ const (
 	OpMetaBatchUnlinkInode  uint8 = 0x92
 	OpMetaBatchEvictInode   uint8 = 0x93
 
+	// Operations: Client -> MetaNode, re-parent dentries within a partition.
+	OpMetaBatchMoveDentry uint8 = 0x94
+
+	// Operations: Client -> MetaNode, replace the extents of a defragmented file.
+	OpMetaSwapExtents uint8 = 0x95
+
+	// Operations: Client -> MetaNode, store the data of a tiny file in its inode.
+	OpMetaSetInlineData uint8 = 0x96
+
+	// Operations: Client -> MetaNode, get the version of the dentries of a directory.
+	OpMetaGetDirVersion uint8 = 0x97
+
 	// Transaction Operations: Client -> MetaNode.
 	OpMetaTxCreate       uint8 = 0xA0
 	OpMetaTxCreateInode  uint8 = 0xA1
@@ -294,15 +312,23 @@ const (
 	OpLeaseGenerationNotMatch           uint8 = 0x87
 	OpWriteOpOfProtoVerForbidden        uint8 = 0x88
 	OpMetaForbiddenMigration            uint8 = 0x89
+
+	// directory sharding
+	OpDirShardedErr uint8 = 0x8D
 	// Distributed cache related OP codes.
 	OpFlashNodeHeartbeat        uint8 = 0xDA
 	OpFlashNodeCachePrepare     uint8 = 0xDB
 	OpFlashNodeCacheRead        uint8 = 0xDC
+	OpFlashNodeCachePeerRead    uint8 = 0xD9
+	OpFlashNodeCacheEvict       uint8 = 0xD8
 	OpFlashNodeSetReadIOLimits  uint8 = 0xED
 	OpFlashNodeSetWriteIOLimits uint8 = 0xEE
 	OpFlashNodeScan             uint8 = 0xD4
 	OpFlashNodeTaskCommand      uint8 = 0xD5
 	OpFlashSDKHeartbeat         uint8 = 0xCB
+	OpFlashNodeMetaGet          uint8 = 0xCA
+	OpFlashNodeMetaPut          uint8 = 0xC9
+	OpFlashNodeMetaEvict        uint8 = 0xC8
 )
 
 const (
@@ -317,6 +343,8 @@ const (
 	MultiVersionFlag                          = 0x80
 	VersionListFlag                           = 0x40
 	PacketProtocolVersionFlag                 = 0x10
+	FenceTokenFlag                            = 0x20 // with PacketProtocolVersionFlag only
+	ContainerExtentFlag                       = 0x08 // a tiny write packed into a container extent
 
 	DefaultRemoteCacheTTL               = 5 * 24 * 3600
 	DefaultRemoteCacheClientReadTimeout = 100 // ms
@@ -326,6 +354,11 @@ const (
 	DefaultRemoteCacheExtentReadTimeout = 3000
 	DefaultRemoteCacheSameZoneTimeout   = 400 // microsecond
 	DefaultRemoteCacheSameRegionTimeout = 2   // ms
+	DefaultRemoteCacheHedgeDelay        = 20  // ms
+	DefaultRemoteCacheFallbackTimeout   = 30  // ms
+	MaxRemoteCacheReadAheadMB           = 256
+	MaxRemoteCacheMetaTTL               = 3600
+	MaxRemoteCacheMetaSize              = 16 * 1024 * 1024 // of the metadata of a key put by a client
 )
 
 // multi version operation
@@ -391,6 +424,7 @@ type Packet struct {
 	mesg               string
 	HasPrepare         bool
 	VerSeq             uint64 // only used in mod request to datanode
+	FenceToken         uint64 // the fencing token of the writer, sent with FenceTokenFlag
 
 	// protocol version of packet
 	// version-0: before v3.4
@@ -571,6 +605,14 @@ func (p *Packet) GetOpMsg() (m string) {
 		m = "OpMetaEvictInode"
 	case OpMetaBatchEvictInode:
 		m = "OpMetaBatchEvictInode"
+	case OpMetaBatchMoveDentry:
+		m = "OpMetaBatchMoveDentry"
+	case OpMetaSwapExtents:
+		m = "OpMetaSwapExtents"
+	case OpMetaSetInlineData:
+		m = "OpMetaSetInlineData"
+	case OpMetaGetDirVersion:
+		m = "OpMetaGetDirVersion"
 	case OpMetaSetattr:
 		m = "OpMetaSetattr"
 	case OpCreateMetaPartition:
@@ -617,6 +659,10 @@ func (p *Packet) GetOpMsg() (m string) {
 		m = "OpReadTinyDeleteRecord"
 	case OpPing:
 		m = "OpPing"
+	case OpNegotiateFeatures:
+		m = "OpNegotiateFeatures"
+	case OpGetContainerIndex:
+		m = "OpGetContainerIndex"
 	case OpTinyExtentRepairRead:
 		m = "OpTinyExtentRepairRead"
 	case OpSnapshotExtentRepairRead:
@@ -733,6 +779,10 @@ func (p *Packet) GetOpMsg() (m string) {
 		m = "OpFlashNodeCachePrepare"
 	case OpFlashNodeCacheRead:
 		m = "OpFlashNodeCacheRead"
+	case OpFlashNodeCachePeerRead:
+		m = "OpFlashNodeCachePeerRead"
+	case OpFlashNodeCacheEvict:
+		m = "OpFlashNodeCacheEvict"
 	case OpFlashNodeSetReadIOLimits:
 		m = "OpFlashNodeSetReadIOLimits"
 	case OpFlashNodeSetWriteIOLimits:
@@ -753,6 +803,12 @@ func (p *Packet) GetOpMsg() (m string) {
 		m = "OpIsRaftStatusOk"
 	case OpFlashSDKHeartbeat:
 		m = "OpFlashSDKHeartbeat"
+	case OpFlashNodeMetaGet:
+		m = "OpFlashNodeMetaGet"
+	case OpFlashNodeMetaPut:
+		m = "OpFlashNodeMetaPut"
+	case OpFlashNodeMetaEvict:
+		m = "OpFlashNodeMetaEvict"
 	default:
 		m = fmt.Sprintf("op:%v not found", p.Opcode)
 	}
@@ -854,6 +910,8 @@ func (p *Packet) GetResultMsg() (m string) {
 		m = "OpLeaseGenerationNotMatch"
 	case OpWriteOpOfProtoVerForbidden:
 		m = "OpWriteOpOfProtoVerForbidden"
+	case OpDirShardedErr:
+		m = "OpDirShardedErr"
 	default:
 		return fmt.Sprintf("Unknown ResultCode(%v)", p.ResultCode)
 	}
@@ -887,11 +945,26 @@ func (p *Packet) MarshalHeader(out []byte) {
 
 		p.ProtoVersion = PacketProtoVersion1
 		binary.BigEndian.PutUint32(out[curSize:curSize+util.PacketProtoVerFiledLen], p.ProtoVersion)
+		curSize = curSize + util.PacketProtoVerFiledLen
+
+		if p.ExtentType&FenceTokenFlag > 0 {
+			binary.BigEndian.PutUint64(out[curSize:curSize+util.PacketFenceTokenFiledLen], p.FenceToken)
+		}
 	} else if p.Opcode == OpRandomWriteVer || p.ExtentType&MultiVersionFlag > 0 {
 		binary.BigEndian.PutUint64(out[curSize:curSize+util.PacketVerSeqFiledLen], p.VerSeq)
 	}
 }
 
+// SetFenceToken makes the packet carry the fencing token of the writer, a
+// zero token is no token.
+func (p *Packet) SetFenceToken(token uint64) {
+	if token == 0 {
+		return
+	}
+	p.ExtentType |= PacketProtocolVersionFlag | FenceTokenFlag
+	p.FenceToken = token
+}
+
 func (p *Packet) IsVersionList() bool {
 	return p.ExtentType&VersionListFlag == VersionListFlag
 }
@@ -925,7 +998,7 @@ func (p *Packet) UnmarshalHeader(in []byte) error {
 
 func (p *Packet) TryReadExtraFieldsFromConn(c net.Conn) (err error) {
 	if p.ExtentType&PacketProtocolVersionFlag > 0 {
-		buf := bytespool.Alloc(12)
+		buf := bytespool.Alloc(20)
 		defer bytespool.Free(buf)
 		if _, err = io.ReadFull(c, buf[:8]); err != nil {
 			return
@@ -936,6 +1009,13 @@ func (p *Packet) TryReadExtraFieldsFromConn(c net.Conn) (err error) {
 			return
 		}
 		p.ProtoVersion = binary.BigEndian.Uint32(buf[8:12])
+
+		if p.ExtentType&FenceTokenFlag > 0 {
+			if _, err = io.ReadFull(c, buf[12:20]); err != nil {
+				return
+			}
+			p.FenceToken = binary.BigEndian.Uint64(buf[12:20])
+		}
 	} else if p.ExtentType&MultiVersionFlag > 0 {
 		buf := bytespool.Alloc(8)
 		defer bytespool.Free(buf)
@@ -1029,6 +1109,7 @@ func (p *Packet) UnmarshalDataPb(m pb.Message) error {
 
 // WriteToNoDeadLineConn writes through the connection without deadline.
 func (p *Packet) WriteToNoDeadLineConn(c net.Conn) (err error) {
+	c = util.PacketConnOf(c)
 	header, err := Buffers.Get(util.PacketHeaderSize)
 	if err != nil {
 		header = make([]byte, util.PacketHeaderSize)
@@ -1052,6 +1133,9 @@ func (p *Packet) CalcPacketHeaderSize() (headerSize int) {
 
 	if p.ExtentType&PacketProtocolVersionFlag > 0 {
 		headerSize = util.PacketHeaderProtoVerSize
+		if p.ExtentType&FenceTokenFlag > 0 {
+			headerSize = util.PacketHeaderFenceSize
+		}
 	} else if p.Opcode == OpRandomWriteVer || p.ExtentType&MultiVersionFlag > 0 {
 		headerSize = util.PacketHeaderVerSize
 	}
@@ -1061,6 +1145,7 @@ func (p *Packet) CalcPacketHeaderSize() (headerSize int) {
 
 // WriteToConn writes through the given connection.
 func (p *Packet) WriteToConn(c net.Conn) (err error) {
+	c = util.PacketConnOf(c)
 	headSize := p.CalcPacketHeaderSize()
 	header, err := Buffers.Get(headSize)
 	if err != nil {
@@ -1096,6 +1181,7 @@ func (p *Packet) WriteToConn(c net.Conn) (err error) {
 
 // ReadFull is a wrapper function of io.ReadFull.
 func ReadFull(c net.Conn, buf *[]byte, readSize int) (err error) {
+	c = util.PacketConnOf(c)
 	*buf = make([]byte, readSize)
 	_, err = io.ReadFull(c, (*buf)[:readSize])
 	return
@@ -1126,6 +1212,7 @@ func (p *Packet) IsReadMetaPkt() bool {
 // Recognize the version bit and parse out version,
 // to avoid version field rsp back , the rsp of random write from datanode with replace OpRandomWriteVer to OpRandomWriteVerRsp
 func (p *Packet) ReadFromConnWithVer(c net.Conn, timeoutSec int) (err error) {
+	c = util.PacketConnOf(c)
 	if timeoutSec != NoReadDeadlineTime {
 		c.SetReadDeadline(time.Now().Add(time.Second * time.Duration(timeoutSec)))
 	} else {
@@ -1219,6 +1306,7 @@ func (p *Packet) ReadFromConnWithVer(c net.Conn, timeoutSec int) (err error) {
 
 // ReadFromConn reads the data from the given connection.
 func (p *Packet) ReadFromConn(c net.Conn, timeoutSec int) (err error) {
+	c = util.PacketConnOf(c)
 	if timeoutSec != NoReadDeadlineTime {
 		c.SetReadDeadline(time.Now().Add(time.Second * time.Duration(timeoutSec)))
 	} else {
@@ -1267,6 +1355,7 @@ func (p *Packet) ReadFromConn(c net.Conn, timeoutSec int) (err error) {
 }
 
 func (p *Packet) ReadFromConnExt(c net.Conn, timeoutMillSec int) (err error) {
+	c = util.PacketConnOf(c)
 	if timeoutMillSec != NoReadDeadlineTime {
 		c.SetReadDeadline(time.Now().Add(time.Millisecond * time.Duration(timeoutMillSec)))
 	} else {
@@ -99,6 +99,9 @@ const (
 	// Bucket location
 	OSSGetBucketLocationAction Action = OSSActionPrefix + "GetBucketLocation"
 
+	// S3 and POSIX interop consistency check
+	OSSCheckBucketInteropAction Action = OSSActionPrefix + "CheckBucketInterop"
+
 	// Object extend attributes (xattr)
 	OSSGetObjectXAttrAction    Action = OSSActionPrefix + "GetObjectXAttr"
 	OSSPutObjectXAttrAction    Action = OSSActionPrefix + "PutObjectXAttr"
@@ -208,6 +211,7 @@ var AllActions = []Action{
 	OSSCompleteMultipartUploadAction,
 	OSSAbortMultipartUploadAction,
 	OSSGetBucketLocationAction,
+	OSSCheckBucketInteropAction,
 	OSSGetObjectXAttrAction,
 	OSSPutObjectXAttrAction,
 	OSSListObjectXAttrsAction,
@@ -0,0 +1,191 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// PlacementExclusion lists where the replicas of the partitions of a volume
+// must never be placed, e.g. a suspect hardware batch, or the locations a
+// dataset is not allowed to be stored by regulation.
+type PlacementExclusion struct {
+	// node addresses, an ip without port excludes all the nodes of the machine
+	Hosts    []string `json:"hosts,omitempty"`
+	Zones    []string `json:"zones,omitempty"`
+	NodeSets []uint64 `json:"nodeSets,omitempty"`
+}
+
+func (e *PlacementExclusion) IsEmpty() bool {
+	return len(e.Hosts) == 0 && len(e.Zones) == 0 && len(e.NodeSets) == 0
+}
+
+// Normalize trims, sorts and deduplicates the rules.
+func (e *PlacementExclusion) Normalize() {
+	e.Hosts = normalizeStrings(e.Hosts)
+	e.Zones = normalizeStrings(e.Zones)
+	if len(e.NodeSets) == 0 {
+		e.NodeSets = nil
+		return
+	}
+	sort.Slice(e.NodeSets, func(i, j int) bool { return e.NodeSets[i] < e.NodeSets[j] })
+	nodeSets := e.NodeSets[:1]
+	for _, id := range e.NodeSets[1:] {
+		if id != nodeSets[len(nodeSets)-1] {
+			nodeSets = append(nodeSets, id)
+		}
+	}
+	e.NodeSets = nodeSets
+}
+
+func (e *PlacementExclusion) Validate() error {
+	for _, host := range e.Hosts {
+		ip := host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			ip = h
+		}
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid host %q, expect ip or ip:port", host)
+		}
+	}
+	for _, id := range e.NodeSets {
+		if id == 0 {
+			return fmt.Errorf("invalid node set id 0")
+		}
+	}
+	return nil
+}
+
+// ExcludesHost reports whether addr, or the machine of it, is excluded.
+func (e *PlacementExclusion) ExcludesHost(addr string) bool {
+	ip := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		ip = h
+	}
+	for _, host := range e.Hosts {
+		if host == addr || host == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// Excludes reports whether a replica may not be placed on the node.
+func (e *PlacementExclusion) Excludes(addr, zoneName string, nodeSetID uint64) bool {
+	if e.ExcludesHost(addr) {
+		return true
+	}
+	for _, zone := range e.Zones {
+		if zone == zoneName {
+			return true
+		}
+	}
+	for _, id := range e.NodeSets {
+		if id == nodeSetID {
+			return true
+		}
+	}
+	return false
+}
+
+func (e PlacementExclusion) String() string {
+	if e.IsEmpty() {
+		return "none"
+	}
+	return fmt.Sprintf("hosts(%v) zones(%v) nodeSets(%v)",
+		strings.Join(e.Hosts, ","), strings.Join(e.Zones, ","), e.NodeSets)
+}
+
+// MaxPlacementSpreadZones bounds PlacementPolicy.SpreadZones by the default replica number.
+const MaxPlacementSpreadZones = 3
+
+// PlacementPolicy pins the replicas of the partitions of a volume to some failure
+// domains, together with the PlacementExclusion of the volume.
+type PlacementPolicy struct {
+	// the replicas are only placed in these zones, empty for any zone
+	RequiredZones []string `json:"requiredZones,omitempty"`
+	// the least number of distinct zones the replicas of a partition spread across, 0 for no requirement
+	SpreadZones int `json:"spreadZones,omitempty"`
+}
+
+func (p *PlacementPolicy) IsEmpty() bool {
+	return len(p.RequiredZones) == 0 && p.SpreadZones == 0
+}
+
+// Normalize trims, sorts and deduplicates the required zones.
+func (p *PlacementPolicy) Normalize() {
+	p.RequiredZones = normalizeStrings(p.RequiredZones)
+}
+
+func (p *PlacementPolicy) Validate() error {
+	if p.SpreadZones < 0 || p.SpreadZones > MaxPlacementSpreadZones {
+		return fmt.Errorf("invalid spread zones %v, expect [0, %v]", p.SpreadZones, MaxPlacementSpreadZones)
+	}
+	if len(p.RequiredZones) > 0 && p.SpreadZones > len(p.RequiredZones) {
+		return fmt.Errorf("spread zones %v is more than the %v required zones", p.SpreadZones, len(p.RequiredZones))
+	}
+	return nil
+}
+
+// CheckExclusion rejects a required zone which is excluded too.
+func (p *PlacementPolicy) CheckExclusion(e *PlacementExclusion) error {
+	for _, zone := range p.RequiredZones {
+		for _, excluded := range e.Zones {
+			if zone == excluded {
+				return fmt.Errorf("zone %q is both required and excluded", zone)
+			}
+		}
+	}
+	return nil
+}
+
+// AllowsZone reports whether a replica may be placed in the zone.
+func (p *PlacementPolicy) AllowsZone(zoneName string) bool {
+	if len(p.RequiredZones) == 0 {
+		return true
+	}
+	for _, zone := range p.RequiredZones {
+		if zone == zoneName {
+			return true
+		}
+	}
+	return false
+}
+
+func (p PlacementPolicy) String() string {
+	if p.IsEmpty() {
+		return "none"
+	}
+	return fmt.Sprintf("requiredZones(%v) spreadZones(%v)", strings.Join(p.RequiredZones, ","), p.SpreadZones)
+}
+
+func normalizeStrings(values []string) (result []string) {
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	sort.Strings(result)
+	return
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"strings"
+)
+
+const PlacementSimulationMaxReplicaNum = 16
+
+// PlacementSimulationRequest describes a hypothetical change of the datanodes
+// and the volumes, of which master simulates the data placement.
+type PlacementSimulationRequest struct {
+	AddNodes    []*SimulatedNodes      `json:"addNodes,omitempty"`
+	RemoveNodes []string               `json:"removeNodes,omitempty"`
+	ReplicaNums []*SimulatedReplicaNum `json:"replicaNums,omitempty"`
+}
+
+// SimulatedNodes adds Count datanodes of Capacity bytes each to a zone,
+// Capacity 0 is the average capacity of the datanodes of the zone.
+type SimulatedNodes struct {
+	ZoneName string `json:"zoneName"`
+	Count    int    `json:"count"`
+	Capacity uint64 `json:"capacity,omitempty"`
+}
+
+// SimulatedReplicaNum changes the replica number of the data partitions of a volume.
+type SimulatedReplicaNum struct {
+	Volume     string `json:"volume"`
+	ReplicaNum int    `json:"replicaNum"`
+}
+
+func (req *PlacementSimulationRequest) IsEmpty() bool {
+	return len(req.AddNodes) == 0 && len(req.RemoveNodes) == 0 && len(req.ReplicaNums) == 0
+}
+
+func (req *PlacementSimulationRequest) Validate() error {
+	if req.IsEmpty() {
+		return fmt.Errorf("no change to simulate")
+	}
+	for _, nodes := range req.AddNodes {
+		if nodes == nil || strings.TrimSpace(nodes.ZoneName) == "" {
+			return fmt.Errorf("zone of the nodes to add is empty")
+		}
+		if nodes.Count <= 0 {
+			return fmt.Errorf("count(%v) of the nodes to add to zone[%v] should be positive", nodes.Count, nodes.ZoneName)
+		}
+	}
+	removed := make(map[string]struct{}, len(req.RemoveNodes))
+	for _, addr := range req.RemoveNodes {
+		if strings.TrimSpace(addr) == "" {
+			return fmt.Errorf("address of the node to remove is empty")
+		}
+		if _, ok := removed[addr]; ok {
+			return fmt.Errorf("node[%v] is removed twice", addr)
+		}
+		removed[addr] = struct{}{}
+	}
+	volumes := make(map[string]struct{}, len(req.ReplicaNums))
+	for _, replicaNum := range req.ReplicaNums {
+		if replicaNum == nil || strings.TrimSpace(replicaNum.Volume) == "" {
+			return fmt.Errorf("volume of the replica number to change is empty")
+		}
+		if _, ok := volumes[replicaNum.Volume]; ok {
+			return fmt.Errorf("replica number of vol[%v] is changed twice", replicaNum.Volume)
+		}
+		volumes[replicaNum.Volume] = struct{}{}
+		if replicaNum.ReplicaNum <= 0 || replicaNum.ReplicaNum > PlacementSimulationMaxReplicaNum {
+			return fmt.Errorf("replicaNum(%v) of vol[%v] should be 1 to %v",
+				replicaNum.ReplicaNum, replicaNum.Volume, PlacementSimulationMaxReplicaNum)
+		}
+	}
+	return nil
+}
+
+// ZoneCapacity is the capacity of the datanodes of a zone, Headroom is the
+// space left to write before the datanodes are considered full.
+type ZoneCapacity struct {
+	Nodes            int     `json:"nodes"`
+	Total            uint64  `json:"total"`
+	Used             uint64  `json:"used"`
+	Headroom         uint64  `json:"headroom"`
+	UsedRatio        float64 `json:"usedRatio"`
+	MaxNodeUsedRatio float64 `json:"maxNodeUsedRatio"`
+}
+
+// AddNode counts a datanode in, fullRatio is the used ratio at which it is full.
+func (z *ZoneCapacity) AddNode(total, used uint64, fullRatio float64) {
+	z.Nodes++
+	z.Total += total
+	z.Used += used
+	if limit := uint64(float64(total) * fullRatio); limit > used {
+		z.Headroom += limit - used
+	}
+	if total > 0 {
+		if ratio := float64(used) / float64(total); ratio > z.MaxNodeUsedRatio {
+			z.MaxNodeUsedRatio = ratio
+		}
+	}
+	if z.Total > 0 {
+		z.UsedRatio = float64(z.Used) / float64(z.Total)
+	}
+}
+
+type ZonePlacementSimulation struct {
+	ZoneName string       `json:"zoneName"`
+	Before   ZoneCapacity `json:"before"`
+	After    ZoneCapacity `json:"after"`
+	// the replicas and their bytes written to the zone by the change
+	InReplicas int    `json:"inReplicas"`
+	InBytes    uint64 `json:"inBytes"`
+}
+
+// PlacementSimulation is the result of a simulation, nothing of it is applied.
+type PlacementSimulation struct {
+	Zones []*ZonePlacementSimulation `json:"zones"`
+	// replicas moved away from the removed nodes
+	MigratedReplicas int    `json:"migratedReplicas"`
+	MigratedBytes    uint64 `json:"migratedBytes"`
+	// replicas created or deleted by the replica number changes
+	AddedReplicas   int    `json:"addedReplicas"`
+	AddedBytes      uint64 `json:"addedBytes"`
+	DroppedReplicas int    `json:"droppedReplicas"`
+	ReleasedBytes   uint64 `json:"releasedBytes"`
+	// partitions lacking a node with enough space for some of their replicas
+	UnplaceablePartitions []uint64 `json:"unplaceablePartitions"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementSimulationRequestValidate(t *testing.T) {
+	require.Error(t, (&proto.PlacementSimulationRequest{}).Validate())
+	require.Error(t, (&proto.PlacementSimulationRequest{AddNodes: []*proto.SimulatedNodes{{ZoneName: "z1"}}}).Validate())
+	require.Error(t, (&proto.PlacementSimulationRequest{RemoveNodes: []string{"n1", "n1"}}).Validate())
+	require.Error(t, (&proto.PlacementSimulationRequest{
+		ReplicaNums: []*proto.SimulatedReplicaNum{{Volume: "v1", ReplicaNum: proto.PlacementSimulationMaxReplicaNum + 1}},
+	}).Validate())
+	require.NoError(t, (&proto.PlacementSimulationRequest{
+		AddNodes:    []*proto.SimulatedNodes{{ZoneName: "z1", Count: 2}},
+		RemoveNodes: []string{"n1"},
+		ReplicaNums: []*proto.SimulatedReplicaNum{{Volume: "v1", ReplicaNum: 2}},
+	}).Validate())
+}
+
+func TestZoneCapacity(t *testing.T) {
+	z := proto.ZoneCapacity{}
+	z.AddNode(100, 50, 0.9)
+	z.AddNode(100, 95, 0.9)
+	require.Equal(t, 2, z.Nodes)
+	require.EqualValues(t, 40, z.Headroom)
+	require.InDelta(t, 0.725, z.UsedRatio, 1e-9)
+	require.InDelta(t, 0.95, z.MaxNodeUsedRatio, 1e-9)
+}
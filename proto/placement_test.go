@@ -0,0 +1,68 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementExclusion(t *testing.T) {
+	e := PlacementExclusion{
+		Hosts:    []string{" 192.168.0.2 ", "192.168.0.1:17310", "", "192.168.0.2"},
+		Zones:    []string{"zone2", "zone1", "zone2"},
+		NodeSets: []uint64{3, 1, 3},
+	}
+	e.Normalize()
+	require.NoError(t, e.Validate())
+	require.Equal(t, []string{"192.168.0.1:17310", "192.168.0.2"}, e.Hosts)
+	require.Equal(t, []string{"zone1", "zone2"}, e.Zones)
+	require.Equal(t, []uint64{1, 3}, e.NodeSets)
+
+	require.True(t, e.ExcludesHost("192.168.0.1:17310"))
+	require.False(t, e.ExcludesHost("192.168.0.1:17210"))
+	require.True(t, e.ExcludesHost("192.168.0.2:17210"))
+	require.True(t, e.Excludes("192.168.0.3:17310", "zone1", 2))
+	require.True(t, e.Excludes("192.168.0.3:17310", "zone3", 3))
+	require.False(t, e.Excludes("192.168.0.3:17310", "zone3", 2))
+
+	empty := PlacementExclusion{}
+	empty.Normalize()
+	require.True(t, empty.IsEmpty())
+	require.False(t, empty.Excludes("192.168.0.1:17310", "zone1", 1))
+
+	require.Error(t, (&PlacementExclusion{Hosts: []string{"host-a"}}).Validate())
+	require.Error(t, (&PlacementExclusion{NodeSets: []uint64{0}}).Validate())
+}
+
+func TestPlacementPolicy(t *testing.T) {
+	p := PlacementPolicy{RequiredZones: []string{"zone2", " zone1", "zone2"}, SpreadZones: 2}
+	p.Normalize()
+	require.NoError(t, p.Validate())
+	require.Equal(t, []string{"zone1", "zone2"}, p.RequiredZones)
+	require.True(t, p.AllowsZone("zone1"))
+	require.False(t, p.AllowsZone("zone3"))
+	require.Error(t, p.CheckExclusion(&PlacementExclusion{Zones: []string{"zone2"}}))
+	require.NoError(t, p.CheckExclusion(&PlacementExclusion{Zones: []string{"zone3"}}))
+
+	require.Error(t, (&PlacementPolicy{RequiredZones: []string{"zone1"}, SpreadZones: 2}).Validate())
+	require.Error(t, (&PlacementPolicy{SpreadZones: MaxPlacementSpreadZones + 1}).Validate())
+	require.NoError(t, (&PlacementPolicy{SpreadZones: MaxPlacementSpreadZones}).Validate())
+
+	empty := PlacementPolicy{}
+	require.True(t, empty.IsEmpty())
+	require.True(t, empty.AllowsZone("zone3"))
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import "time"
+
+// MetaPartitionReclaim is the space of the deleted files of a meta partition
+// not returned to the data nodes yet.
+type MetaPartitionReclaim struct {
+	PartitionID uint64
+	// the deleted files whose extents are not deleted yet
+	FreeInodes uint64
+	FreeBytes  uint64
+	// of the deleted files, the unlinked ones kept for the delay before deletion
+	DelayedInodes uint64
+	DelayedBytes  uint64
+	// the extents of the truncated and overwritten files queued for deletion
+	DelExtents     uint64
+	DelExtentBytes uint64
+}
+
+func (r *MetaPartitionReclaim) PendingBytes() uint64 {
+	return r.FreeBytes + r.DelExtentBytes
+}
+
+// ReclaimBoost speeds up the deletion of the deleted files of a volume by the
+// meta nodes until the unix second.
+type ReclaimBoost struct {
+	BatchCount uint64 // the inodes deleted at once by each meta partition
+	Until      int64  // unix second
+}
+
+func (b *ReclaimBoost) Active(now time.Time) bool {
+	return b != nil && now.Unix() < b.Until
+}
+
+// VolReclaimView is the space of the deleted files of a volume not returned
+// yet, as last reported by the leaders of its meta partitions.
+type VolReclaimView struct {
+	Name string
+	MetaPartitionReclaim
+	PendingBytes uint64
+	Boost        *ReclaimBoost `json:",omitempty"`
+	// the meta partitions with pending space, the most first
+	Partitions []*MetaPartitionReclaim
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import "hash/fnv"
+
+// S3NodeHeartbeatResponse is the state the objectnodes share through the master.
+// Epoch changes with the master leader, the versions are lost then and every
+// gateway reloads the buckets it serves.
+type S3NodeHeartbeatResponse struct {
+	Epoch int64    `json:"epoch"`
+	Nodes []string `json:"nodes"` // the live gateways, sorted
+	// bucket -> version, bumped whenever a gateway changes the policy, ACL,
+	// CORS or object lock configuration of the bucket
+	BucketVersions map[string]uint64 `json:"bucketVersions"`
+}
+
+type S3NodesView struct {
+	Nodes  []string `json:"nodes"`
+	Bucket string   `json:"bucket,omitempty"`
+	Owner  string   `json:"owner,omitempty"`
+}
+
+// S3BucketOwner picks the gateway a load balancer routes the requests of the
+// bucket to by rendezvous hashing, so that only the buckets of a gateway
+// joining or leaving move.
+func S3BucketOwner(nodes []string, bucket string) (owner string) {
+	var max uint64
+	for _, node := range nodes {
+		h := fnv.New64a()
+		h.Write([]byte(node))
+		h.Write([]byte{0})
+		h.Write([]byte(bucket))
+		// fnv mixes the last bytes poorly into the high bits, finalize it as splitmix64
+		sum := h.Sum64()
+		sum = (sum ^ (sum >> 30)) * 0xbf58476d1ce4e5b9
+		sum = (sum ^ (sum >> 27)) * 0x94d049bb133111eb
+		sum ^= sum >> 31
+		if owner == "" || sum > max {
+			owner, max = node, sum
+		}
+	}
+	return
+}
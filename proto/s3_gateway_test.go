@@ -0,0 +1,43 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3BucketOwner(t *testing.T) {
+	require.Empty(t, S3BucketOwner(nil, "bucket"))
+	nodes := []string{"192.168.0.1:80", "192.168.0.2:80", "192.168.0.3:80"}
+	owners := make(map[string]string)
+	used := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		bucket := fmt.Sprintf("bucket%v", i)
+		owners[bucket] = S3BucketOwner(nodes, bucket)
+		used[owners[bucket]] = true
+		require.Equal(t, owners[bucket], S3BucketOwner([]string{nodes[2], nodes[0], nodes[1]}, bucket))
+	}
+	require.Len(t, used, len(nodes))
+
+	// only the buckets of the gateway leaving move
+	for bucket, owner := range owners {
+		if owner != nodes[1] {
+			require.Equal(t, owner, S3BucketOwner([]string{nodes[0], nodes[2]}, bucket))
+		}
+	}
+}
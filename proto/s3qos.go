@@ -41,6 +41,9 @@ type S3QosRequest struct {
 type S3QoSResponse struct {
 	ApiLimitConf map[string]*UserLimitConf `json:"user_limit_conf"` // api --> userLimitConf
 	Nodes        uint64                    `json:"nodes"`
+	// the gateways heartbeating to the master, the quotas are split among
+	// them instead of Nodes if it is not 0
+	LiveNodes uint64 `json:"liveNodes,omitempty"`
 }
 
 func IsS3PutApi(api string) bool {
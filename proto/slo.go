@@ -0,0 +1,155 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"time"
+)
+
+// Op classes a volume SLO can be declared for.
+const (
+	SLOOpRead  = "read"
+	SLOOpWrite = "write"
+	SLOOpMeta  = "meta"
+)
+
+// SLOLatencyBoundsMs are the upper bounds of the latency histogram buckets,
+// the thresholds of SLO targets are compared against them.
+var SLOLatencyBoundsMs = []int64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000}
+
+func IsValidSLOOp(op string) bool {
+	return op == SLOOpRead || op == SLOOpWrite || op == SLOOpMeta
+}
+
+// SLOTarget declares that Objective of the ops should succeed within LatencyMs.
+type SLOTarget struct {
+	LatencyMs int64   `json:"latencyMs"`
+	Objective float64 `json:"objective"`
+}
+
+func (t SLOTarget) Validate() error {
+	if t.LatencyMs <= 0 {
+		return fmt.Errorf("invalid latencyMs(%v)", t.LatencyMs)
+	}
+	if t.Objective <= 0 || t.Objective >= 1 {
+		return fmt.Errorf("objective(%v) must be in (0, 1)", t.Objective)
+	}
+	return nil
+}
+
+// LatencyHistogram counts successful ops by latency and failed ops apart.
+// Buckets[i] counts ops within SLOLatencyBoundsMs[i], the extra last bucket counts slower ones.
+type LatencyHistogram struct {
+	Buckets []uint64 `json:"buckets"`
+	Errors  uint64   `json:"errors"`
+}
+
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{Buckets: make([]uint64, len(SLOLatencyBoundsMs)+1)}
+}
+
+func (h *LatencyHistogram) Observe(cost time.Duration, failed bool) {
+	if failed {
+		h.Errors++
+		return
+	}
+	ms := cost.Milliseconds()
+	i := 0
+	for i < len(SLOLatencyBoundsMs) && ms > SLOLatencyBoundsMs[i] {
+		i++
+	}
+	h.Buckets[i]++
+}
+
+func (h *LatencyHistogram) Merge(o *LatencyHistogram) {
+	if o == nil {
+		return
+	}
+	for len(h.Buckets) < len(o.Buckets) {
+		h.Buckets = append(h.Buckets, 0)
+	}
+	for i, n := range o.Buckets {
+		h.Buckets[i] += n
+	}
+	h.Errors += o.Errors
+}
+
+func (h *LatencyHistogram) Total() (total uint64) {
+	for _, n := range h.Buckets {
+		total += n
+	}
+	return total + h.Errors
+}
+
+// Within returns the successful ops whose bucket bound does not exceed latencyMs.
+func (h *LatencyHistogram) Within(latencyMs int64) (good uint64) {
+	for i, n := range h.Buckets {
+		if i >= len(SLOLatencyBoundsMs) || SLOLatencyBoundsMs[i] > latencyMs {
+			break
+		}
+		good += n
+	}
+	return
+}
+
+// SLOReport carries the latency samples of a volume collected by a client or a server since its last report.
+type SLOReport struct {
+	Volume string                       `json:"volume"`
+	Source string                       `json:"source"`
+	Ops    map[string]*LatencyHistogram `json:"ops"`
+}
+
+type SLOOpStatus struct {
+	Target               SLOTarget
+	Total                uint64
+	Good                 uint64
+	Errors               uint64
+	Attainment           float64 // Good / Total
+	Availability         float64 // 1 - Errors / Total
+	ErrorBudgetRemaining float64 // 1 means untouched, negative means overspent
+	Violated             bool
+}
+
+func NewSLOOpStatus(target SLOTarget, h *LatencyHistogram) *SLOOpStatus {
+	st := &SLOOpStatus{
+		Target:               target,
+		Attainment:           1,
+		Availability:         1,
+		ErrorBudgetRemaining: 1,
+	}
+	if h == nil {
+		return st
+	}
+	st.Total = h.Total()
+	if st.Total == 0 {
+		return st
+	}
+	st.Good = h.Within(target.LatencyMs)
+	st.Errors = h.Errors
+	total := float64(st.Total)
+	st.Attainment = float64(st.Good) / total
+	st.Availability = 1 - float64(st.Errors)/total
+	budget := (1 - target.Objective) * total
+	st.ErrorBudgetRemaining = 1 - float64(st.Total-st.Good)/budget
+	st.Violated = st.Attainment < target.Objective
+	return st
+}
+
+type VolumeSLOStatus struct {
+	Volume        string
+	WindowMinutes int
+	Ops           map[string]*SLOOpStatus
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	h := NewLatencyHistogram()
+	h.Observe(500*time.Microsecond, false)
+	h.Observe(3*time.Millisecond, false)
+	h.Observe(10*time.Millisecond, false)
+	h.Observe(time.Minute, false)
+	h.Observe(time.Millisecond, true)
+	require.EqualValues(t, 5, h.Total())
+	require.EqualValues(t, 1, h.Within(1))
+	require.EqualValues(t, 1, h.Within(4))
+	require.EqualValues(t, 3, h.Within(10))
+	require.EqualValues(t, 3, h.Within(100000))
+	require.EqualValues(t, 1, h.Buckets[len(SLOLatencyBoundsMs)])
+
+	o := NewLatencyHistogram()
+	o.Observe(time.Millisecond, false)
+	o.Observe(time.Millisecond, true)
+	h.Merge(o)
+	require.EqualValues(t, 7, h.Total())
+	require.EqualValues(t, 2, h.Errors)
+	require.EqualValues(t, 2, h.Within(1))
+}
+
+func TestSLOOpStatus(t *testing.T) {
+	target := SLOTarget{LatencyMs: 10, Objective: 0.99}
+	require.NoError(t, target.Validate())
+	require.Error(t, SLOTarget{LatencyMs: 10, Objective: 1}.Validate())
+	require.Error(t, SLOTarget{Objective: 0.9}.Validate())
+
+	st := NewSLOOpStatus(target, nil)
+	require.EqualValues(t, 1, st.ErrorBudgetRemaining)
+	require.False(t, st.Violated)
+
+	h := NewLatencyHistogram()
+	for i := 0; i < 199; i++ {
+		h.Observe(time.Millisecond, false)
+	}
+	h.Observe(time.Second, false)
+	st = NewSLOOpStatus(target, h)
+	require.EqualValues(t, 200, st.Total)
+	require.EqualValues(t, 199, st.Good)
+	require.InDelta(t, 0.995, st.Attainment, 1e-9)
+	require.InDelta(t, 1, st.Availability, 1e-9)
+	require.InDelta(t, 0.5, st.ErrorBudgetRemaining, 1e-9)
+	require.False(t, st.Violated)
+
+	h.Observe(time.Millisecond, true)
+	h.Observe(time.Millisecond, true)
+	st = NewSLOOpStatus(target, h)
+	require.True(t, st.Violated)
+	require.Less(t, st.ErrorBudgetRemaining, 0.0)
+}
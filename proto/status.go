@@ -45,6 +45,8 @@ const (
 	DpReplicaMissing    uint32 = 1 << 4
 	DataNodeRdOnly      uint32 = 1 << 5
 	PartitionRdOnly     uint32 = 1 << 6
+	DpReadReplica       uint32 = 1 << 7
+	DpZoneIsolated      uint32 = 1 << 8
 )
 
 var DpReasonMessages = map[uint32]string{
@@ -55,6 +57,8 @@ var DpReasonMessages = map[uint32]string{
 	DpReplicaMissing:    "replica missing",
 	DataNodeRdOnly:      "dataNode is read-only",
 	PartitionRdOnly:     "partition is read-only",
+	DpReadReplica:       "read replica of a hot partition",
+	DpZoneIsolated:      "zone of the replica is isolated",
 }
 
 // mp readOnly reason
@@ -62,10 +66,12 @@ const (
 	MpCursorOutOfRange uint32 = 1 << 0
 	MetaMemUseLimit    uint32 = 1 << 1
 	MetaNodeReadOnly   uint32 = 1 << 2
+	MpZoneIsolated     uint32 = 1 << 3
 )
 
 var MpReasonMessages = map[uint32]string{
 	MpCursorOutOfRange: "mp cursor out of Range",
 	MetaMemUseLimit:    "meta mem use reached maximum limit",
 	MetaNodeReadOnly:   "MetaNode is read-only",
+	MpZoneIsolated:     "zone of the replica is isolated",
 }
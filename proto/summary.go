@@ -0,0 +1,187 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import "sort"
+
+// The summary is read by the console and by dashboards, so the fields, the
+// subsystem names, the status values and the issue codes only ever grow.
+// ClusterSummaryVersion is raised if one of them has to change its meaning.
+const ClusterSummaryVersion = 1
+
+const (
+	SummaryMasters     = "masters"
+	SummaryMetaNodes   = "metanodes"
+	SummaryDataNodes   = "datanodes"
+	SummaryFlashNodes  = "flashnodes"
+	SummaryObjectNodes = "objectnodes"
+	SummaryBlobStore   = "blobstore"
+)
+
+const (
+	SummaryStatusHealthy  = "healthy"
+	SummaryStatusDegraded = "degraded"
+	SummaryStatusCritical = "critical"
+	SummaryStatusUnknown  = "unknown" // not monitored by master, not scored
+
+	SummarySeverityWarning  = "warning"
+	SummarySeverityCritical = "critical"
+)
+
+const (
+	SummaryIssueNoLeader      = "no_leader"
+	SummaryIssueNoNodes       = "no_nodes"
+	SummaryIssueNodesInactive = "nodes_inactive"
+	SummaryIssueBadPartitions = "bad_partitions"
+	SummaryIssueBadDisks      = "bad_disks"
+	SummaryIssueHighUsage     = "high_usage"
+	SummaryIssueQuorumAtRisk  = "quorum_at_risk"
+)
+
+const (
+	SummaryTopIssues     = 10
+	SummaryMaxIssueNodes = 10
+
+	summaryScoreMax        = 100
+	summaryScoreUnknown    = -1
+	summaryWarningPenalty  = 15
+	summaryCriticalPenalty = 40
+)
+
+// SummaryIssue is a problem found on a subsystem and the action to take.
+type SummaryIssue struct {
+	Subsystem string   `json:"subsystem"`
+	Severity  string   `json:"severity"`
+	Code      string   `json:"code"`
+	Message   string   `json:"message"`
+	Action    string   `json:"action"`
+	Nodes     []string `json:"nodes,omitempty"` // at most SummaryMaxIssueNodes
+}
+
+// SubsystemSummary is the health of one kind of service. Score is between 0
+// and 100, and -1 for the subsystems master does not monitor.
+type SubsystemSummary struct {
+	Name   string          `json:"name"`
+	Score  int             `json:"score"`
+	Status string          `json:"status"`
+	Total  int             `json:"total"`
+	Active int             `json:"active"`
+	Detail string          `json:"detail,omitempty"`
+	Issues []*SummaryIssue `json:"issues"`
+}
+
+// ClusterSummary is the health scored overview returned by /admin/summary.
+type ClusterSummary struct {
+	Version    int                 `json:"version"`
+	Cluster    string              `json:"cluster"`
+	LeaderAddr string              `json:"leaderAddr"`
+	Time       int64               `json:"time"`
+	Score      int                 `json:"score"`
+	Status     string              `json:"status"`
+	Subsystems []*SubsystemSummary `json:"subsystems"`
+	TopIssues  []*SummaryIssue     `json:"topIssues"`
+}
+
+func NewSubsystemSummary(name string, total, active int) *SubsystemSummary {
+	return &SubsystemSummary{Name: name, Total: total, Active: active, Issues: make([]*SummaryIssue, 0)}
+}
+
+// AddIssue records an issue, keeping the first SummaryMaxIssueNodes nodes.
+func (s *SubsystemSummary) AddIssue(severity, code, message, action string, nodes ...string) {
+	if len(nodes) > SummaryMaxIssueNodes {
+		sort.Strings(nodes)
+		nodes = nodes[:SummaryMaxIssueNodes]
+	}
+	s.Issues = append(s.Issues, &SummaryIssue{
+		Subsystem: s.Name, Severity: severity, Code: code, Message: message, Action: action, Nodes: nodes,
+	})
+}
+
+// SetUnknown marks a subsystem master does not monitor.
+func (s *SubsystemSummary) SetUnknown(detail string) {
+	s.Status, s.Score, s.Detail = SummaryStatusUnknown, summaryScoreUnknown, detail
+}
+
+// score takes a penalty per issue. The status is critical with a critical
+// issue and degraded with a warning, whatever the score.
+func (s *SubsystemSummary) score() {
+	if s.Status == SummaryStatusUnknown {
+		return
+	}
+	s.Score, s.Status = summaryScoreMax, SummaryStatusHealthy
+	for _, issue := range s.Issues {
+		if issue.Severity == SummarySeverityCritical {
+			s.Score -= summaryCriticalPenalty
+			s.Status = SummaryStatusCritical
+			continue
+		}
+		s.Score -= summaryWarningPenalty
+		if s.Status == SummaryStatusHealthy {
+			s.Status = SummaryStatusDegraded
+		}
+	}
+	if s.Score < 0 {
+		s.Score = 0
+	}
+}
+
+func summaryStatusRank(status string) int {
+	switch status {
+	case SummaryStatusHealthy:
+		return 1
+	case SummaryStatusDegraded:
+		return 2
+	case SummaryStatusCritical:
+		return 3
+	}
+	return 0
+}
+
+// BuildClusterSummary scores the subsystems. The cluster takes the lowest
+// score and the worst status of the monitored subsystems, and the top issues
+// are the critical ones first, in the order of the subsystems.
+func BuildClusterSummary(cluster, leaderAddr string, now int64, subsystems []*SubsystemSummary) *ClusterSummary {
+	summary := &ClusterSummary{
+		Version:    ClusterSummaryVersion,
+		Cluster:    cluster,
+		LeaderAddr: leaderAddr,
+		Time:       now,
+		Score:      summaryScoreMax,
+		Status:     SummaryStatusHealthy,
+		Subsystems: subsystems,
+		TopIssues:  make([]*SummaryIssue, 0),
+	}
+	for _, s := range subsystems {
+		s.score()
+		if s.Status == SummaryStatusUnknown {
+			continue
+		}
+		if s.Score < summary.Score {
+			summary.Score = s.Score
+		}
+		if summaryStatusRank(s.Status) > summaryStatusRank(summary.Status) {
+			summary.Status = s.Status
+		}
+		summary.TopIssues = append(summary.TopIssues, s.Issues...)
+	}
+	sort.SliceStable(summary.TopIssues, func(i, j int) bool {
+		return summary.TopIssues[i].Severity == SummarySeverityCritical &&
+			summary.TopIssues[j].Severity != SummarySeverityCritical
+	})
+	if len(summary.TopIssues) > SummaryTopIssues {
+		summary.TopIssues = summary.TopIssues[:SummaryTopIssues]
+	}
+	return summary
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildClusterSummary(t *testing.T) {
+	masters := proto.NewSubsystemSummary(proto.SummaryMasters, 3, 3)
+	dataNodes := proto.NewSubsystemSummary(proto.SummaryDataNodes, 20, 19)
+	nodes := make([]string, 0)
+	for i := 0; i < 20; i++ {
+		nodes = append(nodes, fmt.Sprintf("192.168.0.%v:17310", i))
+	}
+	dataNodes.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueNodesInactive, "inactive", "restart", nodes...)
+	metaNodes := proto.NewSubsystemSummary(proto.SummaryMetaNodes, 3, 3)
+	metaNodes.AddIssue(proto.SummarySeverityWarning, proto.SummaryIssueBadPartitions, "bad", "diagnose")
+	metaNodes.AddIssue(proto.SummarySeverityCritical, proto.SummaryIssueHighUsage, "full", "add")
+	objectNodes := proto.NewSubsystemSummary(proto.SummaryObjectNodes, 0, 0)
+	objectNodes.SetUnknown("not monitored")
+
+	summary := proto.BuildClusterSummary("test", "127.0.0.1:17010", 1, []*proto.SubsystemSummary{
+		masters, metaNodes, dataNodes, objectNodes,
+	})
+	require.Equal(t, proto.ClusterSummaryVersion, summary.Version)
+	require.Equal(t, 100, masters.Score)
+	require.Equal(t, proto.SummaryStatusHealthy, masters.Status)
+	require.Equal(t, 85, dataNodes.Score)
+	require.Equal(t, proto.SummaryStatusDegraded, dataNodes.Status)
+	require.Len(t, dataNodes.Issues[0].Nodes, proto.SummaryMaxIssueNodes)
+	require.Equal(t, 45, metaNodes.Score)
+	require.Equal(t, proto.SummaryStatusCritical, metaNodes.Status)
+	require.Equal(t, -1, objectNodes.Score)
+	require.Equal(t, proto.SummaryStatusUnknown, objectNodes.Status)
+
+	require.Equal(t, 45, summary.Score)
+	require.Equal(t, proto.SummaryStatusCritical, summary.Status)
+	require.Len(t, summary.TopIssues, 3)
+	require.Equal(t, proto.SummaryIssueHighUsage, summary.TopIssues[0].Code)
+	require.Equal(t, proto.SummaryIssueBadPartitions, summary.TopIssues[1].Code)
+	require.Equal(t, proto.SummaryDataNodes, summary.TopIssues[2].Subsystem)
+}
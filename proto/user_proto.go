@@ -75,6 +75,35 @@ func UserTypeFromString(name string) UserType {
 	return UserTypeInvalid
 }
 
+// AdminRole is the role of a user on the admin apis of master when the rbac is
+// on, each role covers the ones before it.
+type AdminRole string
+
+const (
+	AdminRoleNone         AdminRole = "none"
+	AdminRoleViewer       AdminRole = "viewer"
+	AdminRoleVolumeAdmin  AdminRole = "volume-admin"
+	AdminRoleClusterAdmin AdminRole = "cluster-admin"
+)
+
+var adminRoleRanks = map[AdminRole]int{
+	AdminRoleViewer:       1,
+	AdminRoleVolumeAdmin:  2,
+	AdminRoleClusterAdmin: 3,
+}
+
+func (r AdminRole) Valid() bool {
+	_, ok := adminRoleRanks[r]
+	return ok || r == AdminRoleNone
+}
+
+// Covers tells whether the role is allowed to call the apis which require the
+// given role.
+func (r AdminRole) Covers(required AdminRole) bool {
+	rank, ok := adminRoleRanks[r]
+	return ok && rank >= adminRoleRanks[required]
+}
+
 func IsValidAK(ak string) bool {
 	if AKRegexp.MatchString(ak) {
 		return true
@@ -105,6 +134,7 @@ type UserInfo struct {
 	UserType    UserType     `json:"user_type" graphql:"user_type"`
 	CreateTime  string       `json:"create_time" graphql:"create_time"`
 	Description string       `json:"description" graphql:"description"`
+	AdminRole   AdminRole    `json:"admin_role,omitempty" graphql:"admin_role"`
 	Mu          sync.RWMutex `json:"-" graphql:"-"`
 	EMPTY       bool         // graphql need ???
 }
@@ -117,6 +147,22 @@ func (i *UserInfo) String() string {
 		i.UserID, i.AccessKey, i.SecretKey, i.UserType)
 }
 
+// EffectiveAdminRole returns the role of the user on the admin apis. The root
+// user is always a cluster admin, the admin users without a role given are
+// cluster admins as before the roles and the normal ones have none.
+func (i *UserInfo) EffectiveAdminRole() AdminRole {
+	if i.UserType == UserTypeRoot {
+		return AdminRoleClusterAdmin
+	}
+	if i.AdminRole != "" {
+		return i.AdminRole
+	}
+	if i.UserType == UserTypeAdmin {
+		return AdminRoleClusterAdmin
+	}
+	return AdminRoleNone
+}
+
 func NewUserInfo() *UserInfo {
 	return &UserInfo{Policy: NewUserPolicy()}
 }
@@ -328,12 +374,13 @@ func CleanPolicy(policy *UserPolicy) (newUserPolicy *UserPolicy) {
 }
 
 type UserCreateParam struct {
-	ID          string   `json:"id"`
-	Password    string   `json:"pwd"`
-	AccessKey   string   `json:"ak"`
-	SecretKey   string   `json:"sk"`
-	Type        UserType `json:"type"`
-	Description string   `json:"description"`
+	ID          string    `json:"id"`
+	Password    string    `json:"pwd"`
+	AccessKey   string    `json:"ak"`
+	SecretKey   string    `json:"sk"`
+	Type        UserType  `json:"type"`
+	Description string    `json:"description"`
+	AdminRole   AdminRole `json:"admin_role"`
 }
 
 type UserPermUpdateParam struct {
@@ -368,10 +415,11 @@ type UserTransferVolParam struct {
 }
 
 type UserUpdateParam struct {
-	UserID      string   `json:"user_id"`
-	AccessKey   string   `json:"access_key"`
-	SecretKey   string   `json:"secret_key"`
-	Type        UserType `json:"type"`
-	Password    string   `json:"password"`
-	Description string   `json:"description"`
+	UserID      string    `json:"user_id"`
+	AccessKey   string    `json:"access_key"`
+	SecretKey   string    `json:"secret_key"`
+	Type        UserType  `json:"type"`
+	Password    string    `json:"password"`
+	Description string    `json:"description"`
+	AdminRole   AdminRole `json:"admin_role"`
 }
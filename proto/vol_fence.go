@@ -0,0 +1,95 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// VolFence is the fencing token of a volume, or of a subtree of it if Path is not
+// "/". An orchestrator acquires a new token from master on failover and hands it
+// to the new writer, metanodes and datanodes then reject the writes carrying an
+// older token of the volume. The tokens of a volume grow across its paths, so a
+// token identifies its path.
+type VolFence struct {
+	Volume     string `json:"vol"`
+	Path       string `json:"path"`
+	Token      uint64 `json:"token"`
+	UpdateTime int64  `json:"updateTime"`
+}
+
+// CleanFencePath returns the canonical form of the path of a fence.
+func CleanFencePath(p string) (string, error) {
+	if p == "" {
+		return "/", nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("fence path[%v] is not absolute", p)
+	}
+	return path.Clean(p), nil
+}
+
+type volFenceTokens struct {
+	current map[uint64]struct{}
+	max     uint64
+}
+
+// FenceTokens answers whether the token of a write is stale, which is the case
+// once master acquired a newer token for its path. A token newer than all the
+// known ones was acquired since master sent the fences and is taken. A nil
+// FenceTokens fences nothing.
+type FenceTokens struct {
+	vols map[string]*volFenceTokens
+}
+
+// NewFenceTokens builds the tokens from the fences sent by master.
+func NewFenceTokens(fences []*VolFence) *FenceTokens {
+	f := &FenceTokens{vols: make(map[string]*volFenceTokens)}
+	for _, fence := range fences {
+		v, ok := f.vols[fence.Volume]
+		if !ok {
+			v = &volFenceTokens{current: make(map[uint64]struct{})}
+			f.vols[fence.Volume] = v
+		}
+		v.current[fence.Token] = struct{}{}
+		if fence.Token > v.max {
+			v.max = fence.Token
+		}
+	}
+	return f
+}
+
+func (f *FenceTokens) Len() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.vols)
+}
+
+// Stale tells whether the writes to the volume with token are rejected, a zero
+// token is no token and is never rejected.
+func (f *FenceTokens) Stale(vol string, token uint64) bool {
+	if f.Len() == 0 || token == 0 {
+		return false
+	}
+	v, ok := f.vols[vol]
+	if !ok || token > v.max {
+		return false
+	}
+	_, ok = v.current[token]
+	return !ok
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFenceTokens(t *testing.T) {
+	var tokens *FenceTokens
+	require.False(t, tokens.Stale("vol1", 1))
+
+	// vol1 fenced / with token 3 after 1, and /db with token 2
+	tokens = NewFenceTokens([]*VolFence{
+		{Volume: "vol1", Path: "/", Token: 3},
+		{Volume: "vol1", Path: "/db", Token: 2},
+		{Volume: "vol2", Path: "/", Token: 1},
+	})
+	require.Equal(t, 2, tokens.Len())
+	require.True(t, tokens.Stale("vol1", 1))
+	require.False(t, tokens.Stale("vol1", 2))
+	require.False(t, tokens.Stale("vol1", 3))
+	require.False(t, tokens.Stale("vol1", 4), "acquired since the fences were sent")
+	require.False(t, tokens.Stale("vol1", 0))
+	require.False(t, tokens.Stale("vol3", 1))
+
+	for in, want := range map[string]string{"": "/", "/": "/", "/db/": "/db", "/a//b/../c": "/a/c"} {
+		got, err := CleanFencePath(in)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+	_, err := CleanFencePath("db")
+	require.Error(t, err)
+}
+
+func TestPacketFenceToken(t *testing.T) {
+	InitBufferPool(32768)
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	for _, token := range []uint64{0, 7} {
+		p := NewPacket()
+		p.Opcode = OpMetaCreateInode
+		p.ExtentType |= PacketProtocolVersionFlag
+		p.SetFenceToken(token)
+		p.Data = []byte("data")
+		p.Size = uint32(len(p.Data))
+		go func() { require.NoError(t, p.WriteToConn(client)) }()
+
+		got := NewPacket()
+		require.NoError(t, got.ReadFromConnWithVer(server, NoReadDeadlineTime))
+		require.Equal(t, token, got.FenceToken)
+		require.Equal(t, token != 0, got.ExtentType&FenceTokenFlag > 0)
+		require.Equal(t, "data", string(got.Data))
+	}
+}
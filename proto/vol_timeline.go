@@ -0,0 +1,47 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import "fmt"
+
+// Kinds of the events of the volume timeline.
+const (
+	VolEventCreate           = "create"
+	VolEventDelete           = "delete"
+	VolEventCapacity         = "capacity"
+	VolEventAddDataPartition = "addDataPartition"
+	VolEventAddMetaPartition = "addMetaPartition"
+	VolEventDecommission     = "decommission"
+	VolEventLifecycle        = "lifecycle"
+	VolEventReadOnly         = "readOnly"
+	VolEventForbidden        = "forbidden"
+	VolEventFence            = "fence"
+	VolEventFork             = "fork"
+)
+
+// VolTimelineEvent is a significant event of a volume recorded by the master, the
+// timeline of a volume is its events in the order they happened.
+type VolTimelineEvent struct {
+	ID     int64 // unix nano of the event, increasing on a leader
+	Time   int64 // unix second
+	Volume string
+	Kind   string
+	Detail string
+}
+
+// Key is unique to the event, and sorts the events of a volume by time.
+func (e *VolTimelineEvent) Key() string {
+	return fmt.Sprintf("%v#%020d", e.Volume, e.ID)
+}
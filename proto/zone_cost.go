@@ -0,0 +1,48 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+// ZoneCost is the cost of the traffic between two zones, for example the price
+// per GB of the cloud between two availability zones. Only the ratios matter,
+// it is the same in both directions, and the zones without cost are free.
+type ZoneCost struct {
+	From string
+	To   string
+	Cost float64
+}
+
+// ZoneCostView is what a client in Zone needs to route its reads: the cost of
+// reading from every other zone, and the zone of the datanodes and flashnodes.
+type ZoneCostView struct {
+	Zone      string
+	Costs     map[string]float64 // key: zone
+	HostZones map[string]string  // key: addr of datanode or flashnode
+}
+
+// HostCost returns the cost of reading from addr, 0 if it is in the zone of
+// the client or its zone is unknown.
+func (v *ZoneCostView) HostCost(addr string) float64 {
+	zone, ok := v.HostZones[addr]
+	if !ok || zone == v.Zone {
+		return 0
+	}
+	return v.Costs[zone]
+}
+
+// IsCrossZone tells whether addr is known to be in another zone.
+func (v *ZoneCostView) IsCrossZone(addr string) bool {
+	zone, ok := v.HostZones[addr]
+	return ok && zone != "" && zone != v.Zone
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"fmt"
+)
+
+// The isolation of a zone contains the blast radius of a partial outage or a
+// network partition while the zone recovers. A read-only zone gets no new
+// partitions and its replicas reject the writes, a fenced zone also gives up
+// the raft leaders of its partitions.
+const (
+	ZoneIsolationNone int32 = iota
+	ZoneIsolationReadOnly
+	ZoneIsolationFenced
+)
+
+var zoneIsolationNames = map[int32]string{
+	ZoneIsolationNone:     "none",
+	ZoneIsolationReadOnly: "readOnly",
+	ZoneIsolationFenced:   "fenced",
+}
+
+func ZoneIsolationString(isolation int32) string {
+	if name, ok := zoneIsolationNames[isolation]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func ParseZoneIsolation(name string) (int32, error) {
+	for isolation, n := range zoneIsolationNames {
+		if n == name {
+			return isolation, nil
+		}
+	}
+	return 0, fmt.Errorf("zone isolation(%v) is not one of none, readOnly and fenced", name)
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneIsolation(t *testing.T) {
+	for _, isolation := range []int32{ZoneIsolationNone, ZoneIsolationReadOnly, ZoneIsolationFenced} {
+		parsed, err := ParseZoneIsolation(ZoneIsolationString(isolation))
+		require.NoError(t, err)
+		require.Equal(t, isolation, parsed)
+	}
+	_, err := ParseZoneIsolation("offline")
+	require.Error(t, err)
+	require.Equal(t, "unknown", ZoneIsolationString(3))
+}
@@ -71,6 +71,9 @@ type Partition interface {
 	TryToLeader(nodeID uint64) error
 	IsOfflinePeer() bool
 
+	// ResetPeers replaces the peers of this member without a conf change.
+	ResetPeers(peers []proto.Peer) error
+
 	// CloseAndBackup closes the partition and backup the wal.
 	CloseAndBackup() error
 }
@@ -108,6 +111,12 @@ func (p *partition) TryToLeader(nodeID uint64) (err error) {
 	return
 }
 
+func (p *partition) ResetPeers(peers []proto.Peer) (err error) {
+	future := p.raft.ResetPeers(p.id, peers)
+	_, err = future.Response()
+	return
+}
+
 // Delete stops and deletes the partition.
 func (p *partition) Delete() (err error) {
 	if err = p.Stop(); err != nil {
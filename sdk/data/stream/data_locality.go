@@ -0,0 +1,112 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"sort"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// Locality returns the hosts holding the range [offset, offset+size) of the
+// inode, whose extent keys are eks.
+func (client *ExtentClient) Locality(inode uint64, eks []proto.ExtentKey, offset, size uint64) []*proto.BlockLocality {
+	dataHosts := func(partitionID uint64) []string {
+		dp, ok := client.dataWrapper.TryGetPartition(partitionID)
+		if !ok {
+			log.LogWarnf("Locality: inode(%v) partitionId(%v) not exist", inode, partitionID)
+			return nil
+		}
+		return dp.Hosts
+	}
+	var flashHosts func(blockOffset uint64) []string
+	if client.IsRemoteCacheEnabled() {
+		flashHosts = func(blockOffset uint64) []string {
+			slot := proto.ComputeCacheBlockSlot(client.dataWrapper.VolName, inode, blockOffset)
+			if fg, _ := client.RemoteCache.GetFlashGroupBySlot(slot); fg != nil {
+				return fg.Hosts
+			}
+			return nil
+		}
+	}
+	return blockLocality(eks, offset, size, dataHosts, flashHosts)
+}
+
+// blockLocality splits the range on the cache blocks and merges the adjacent
+// ones held by the same hosts, the blocks holding no extent are skipped.
+func blockLocality(eks []proto.ExtentKey, offset, size uint64, dataHosts func(partitionID uint64) []string,
+	flashHosts func(blockOffset uint64) []string,
+) []*proto.BlockLocality {
+	sorted := make([]proto.ExtentKey, 0, len(eks))
+	for _, ek := range eks {
+		if ek.PartitionId != 0 && ek.Size > 0 {
+			sorted = append(sorted, ek)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].FileOffset < sorted[j].FileOffset })
+
+	blocks := make([]*proto.BlockLocality, 0)
+	end := offset + size
+	first := 0
+	for start := offset / proto.CACHE_BLOCK_SIZE * proto.CACHE_BLOCK_SIZE; start < end; start += proto.CACHE_BLOCK_SIZE {
+		blockStart, blockEnd := start, start+proto.CACHE_BLOCK_SIZE
+		if blockStart < offset {
+			blockStart = offset
+		}
+		if blockEnd > end {
+			blockEnd = end
+		}
+		for first < len(sorted) && sorted[first].FileOffset+uint64(sorted[first].Size) <= blockStart {
+			first++
+		}
+		var hosts []string
+		seen := make(map[string]bool)
+		for i := first; i < len(sorted) && sorted[i].FileOffset < blockEnd; i++ {
+			for _, host := range dataHosts(sorted[i].PartitionId) {
+				if !seen[host] {
+					seen[host] = true
+					hosts = append(hosts, host)
+				}
+			}
+		}
+		if len(hosts) == 0 {
+			continue
+		}
+		block := &proto.BlockLocality{Offset: blockStart, Size: blockEnd - blockStart, DataHosts: hosts}
+		if flashHosts != nil {
+			block.FlashHosts = flashHosts(start)
+		}
+		if n := len(blocks); n > 0 && blocks[n-1].Offset+blocks[n-1].Size == block.Offset &&
+			sameHosts(blocks[n-1].DataHosts, block.DataHosts) && sameHosts(blocks[n-1].FlashHosts, block.FlashHosts) {
+			blocks[n-1].Size += block.Size
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func sameHosts(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+package stream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockLocality(t *testing.T) {
+	const block = proto.CACHE_BLOCK_SIZE
+	eks := []proto.ExtentKey{
+		{FileOffset: 2 * block, PartitionId: 2, Size: block / 2},
+		{FileOffset: 0, PartitionId: 1, Size: 2 * block},
+		// a hole in the third block, then a temporary extent
+		{FileOffset: 4 * block, PartitionId: 0, Size: block},
+		{FileOffset: 5 * block, PartitionId: 3, Size: block},
+	}
+	dataHosts := func(partitionID uint64) []string {
+		return []string{fmt.Sprintf("dn%d-a", partitionID), fmt.Sprintf("dn%d-b", partitionID)}
+	}
+
+	blocks := blockLocality(eks, 0, 6*block, dataHosts, nil)
+	require.Equal(t, []*proto.BlockLocality{
+		{Offset: 0, Size: 2 * block, DataHosts: []string{"dn1-a", "dn1-b"}},
+		{Offset: 2 * block, Size: block, DataHosts: []string{"dn2-a", "dn2-b"}},
+		{Offset: 5 * block, Size: block, DataHosts: []string{"dn3-a", "dn3-b"}},
+	}, blocks)
+
+	// an unaligned range spanning two partitions, the flash group of each block
+	flashHosts := func(blockOffset uint64) []string {
+		return []string{fmt.Sprintf("fn%d", blockOffset/block)}
+	}
+	blocks = blockLocality(eks, block+block/2, block, dataHosts, flashHosts)
+	require.Equal(t, []*proto.BlockLocality{
+		{Offset: block + block/2, Size: block / 2, DataHosts: []string{"dn1-a", "dn1-b"}, FlashHosts: []string{"fn1"}},
+		{Offset: 2 * block, Size: block / 2, DataHosts: []string{"dn2-a", "dn2-b"}, FlashHosts: []string{"fn2"}},
+	}, blocks)
+
+	require.Empty(t, blockLocality(eks, 6*block, block, dataHosts, nil))
+	require.Empty(t, blockLocality(nil, 0, block, dataHosts, nil))
+}
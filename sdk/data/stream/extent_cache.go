@@ -340,6 +340,13 @@ func (cache *ExtentCache) Max() *proto.ExtentKey {
 	return ek
 }
 
+// Len returns the number of extent keys in the cache.
+func (cache *ExtentCache) Len() int {
+	cache.RLock()
+	defer cache.RUnlock()
+	return cache.root.Len()
+}
+
 // Size returns the size of the cache.
 func (cache *ExtentCache) Size() (size int, gen uint64) {
 	cache.RLock()
@@ -28,7 +28,6 @@ import (
 
 	syslog "log"
 
-	"github.com/cubefs/cubefs/depends/bazil.org/fuse"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/data/manager"
 	"github.com/cubefs/cubefs/sdk/data/wrapper"
@@ -65,6 +64,8 @@ type (
 	RenewalForbiddenMigrationFunc func(inode uint64) error
 	ForbiddenMigrationFunc        func(inode uint64) error
 	GetInodeInfoFunc              func(ino uint64) (*proto.InodeInfo, error)
+	GetInlineDataFunc             func(ino uint64) (gen uint64, data []byte, err error)
+	SetInlineDataFunc             func(ino uint64, data []byte, size uint64) error
 )
 
 const (
@@ -128,6 +129,7 @@ type ExtentConfig struct {
 	Masters           []string
 	FollowerRead      bool
 	NearRead          bool
+	ZoneName          string
 	MaximallyRead     bool
 	Preload           bool
 	ReadRate          int64
@@ -149,6 +151,13 @@ type ExtentConfig struct {
 
 	DisableMetaCache   bool
 	StreamRetryTimeout int
+	// max packets in flight per extent handler, 0 means no limit
+	MaxInflightPackets int
+	// appends smaller than this are gathered into one extent write, 0 disables
+	WriteCoalesceSize     int
+	WriteCoalesceWindowMs int
+	// how long a streamer which lost its session may be resumed, s, 0 disables
+	SessionResumeTimeout int
 
 	OnRenewalForbiddenMigration RenewalForbiddenMigrationFunc
 	OnForbiddenMigration        ForbiddenMigrationFunc
@@ -167,6 +176,8 @@ type ExtentConfig struct {
 	NeedRemoteCache  bool
 	ForceRemoteCache bool
 	HeartBeatPing    bool
+	// hedge or fallback on a slow or failed flash read, see RemoteCache.Read
+	RemoteCacheReadPolicy string
 }
 
 type MultiVerMgr struct {
@@ -186,6 +197,7 @@ type ExtentClient struct {
 	writeLimiter       *rate.Limiter
 	disableMetaCache   bool
 	streamRetryTimeout time.Duration
+	maxInflightPackets int32
 	volumeType         int
 	volumeName         string
 	bcacheEnable       bool
@@ -204,12 +216,23 @@ type ExtentClient struct {
 	cacheBcache        CacheBcacheFunc
 	evictBcache        EvictBacheFunc
 
+	// small appends gathered per streamer, see coalescedWrite
+	writeCoalesceSize   int
+	writeCoalesceWindow time.Duration
+
+	// see Streamer.suspend
+	sessionResumeTimeout time.Duration
+
 	inflightL1cache           sync.Map
 	inflightL1BigBlock        int32
 	multiVerMgr               *MultiVerMgr
 	renewalForbiddenMigration RenewalForbiddenMigrationFunc
 	forbiddenMigration        ForbiddenMigrationFunc
 	getInodeInfo              GetInodeInfoFunc
+	getInlineData             GetInlineDataFunc // nil without a meta wrapper
+	setInlineData             SetInlineDataFunc
+	inlineDataEnabled         func(ino uint64) bool
+	smallFilePackEnabled      func() bool
 	bcacheOnlyForNotSSD       bool
 	AheadRead                 *AheadReadCache
 
@@ -336,6 +359,9 @@ retry:
 	client.evictIcache = config.OnEvictIcache
 	client.dataWrapper.InitFollowerRead(config.FollowerRead)
 	client.dataWrapper.SetNearRead(config.NearRead)
+	if config.ZoneName != "" {
+		client.dataWrapper.SetZoneName(config.ZoneName)
+	}
 	client.dataWrapper.SetMaximallyRead(config.MaximallyRead)
 	client.loadBcache = config.OnLoadBcache
 	client.cacheBcache = config.OnCacheBcache
@@ -359,6 +385,20 @@ retry:
 		client.streamRetryTimeout = time.Duration(config.StreamRetryTimeout) * time.Second
 	}
 	log.LogInfof("stream retry timeout %d ms", client.streamRetryTimeout.Milliseconds())
+	client.maxInflightPackets = int32(config.MaxInflightPackets)
+	client.writeCoalesceSize = config.WriteCoalesceSize
+	if config.WriteCoalesceWindowMs > 0 {
+		client.writeCoalesceWindow = time.Duration(config.WriteCoalesceWindowMs) * time.Millisecond
+	} else {
+		client.writeCoalesceWindow = defaultWriteCoalesceWindow
+	}
+	if client.writeCoalesceSize > 0 {
+		log.LogInfof("write coalesce size %d window %d ms", client.writeCoalesceSize, client.writeCoalesceWindow.Milliseconds())
+	}
+	client.sessionResumeTimeout = time.Duration(config.SessionResumeTimeout) * time.Second
+	if client.sessionResumeTimeout > 0 {
+		log.LogInfof("session resume timeout %v", client.sessionResumeTimeout)
+	}
 
 	var readLimit, writeLimit rate.Limit
 	if config.ReadRate <= 0 {
@@ -395,6 +435,12 @@ retry:
 
 	client.stopCh = make(chan struct{})
 	client.metaWrapper = config.MetaWrapper
+	if client.metaWrapper != nil {
+		client.getInlineData = client.metaWrapper.GetInlineData
+		client.setInlineData = client.metaWrapper.SetInlineData_ll
+		client.inlineDataEnabled = client.metaWrapper.InlineDataEnabled
+		client.smallFilePackEnabled = client.metaWrapper.SmallFilePackEnabled
+	}
 
 	if client.metaWrapper != nil {
 		client.metaWrapper.RemoteCacheBloom = client.RemoteCacheBloom
@@ -418,6 +464,11 @@ func (client *ExtentClient) GetEnablePosixAcl() bool {
 	return client.dataWrapper.EnablePosixAcl
 }
 
+// IsStrictConsistency tells whether the volume is in the strict consistency mode.
+func (client *ExtentClient) IsStrictConsistency() bool {
+	return client.dataWrapper.IsStrictConsistency()
+}
+
 func (client *ExtentClient) GetFlowInfo() (*proto.ClientReportLimitInfo, bool) {
 	log.LogInfof("action[ExtentClient.GetFlowInfo]")
 	return client.LimitManager.GetFlowInfo()
@@ -539,7 +590,7 @@ func (client *ExtentClient) OpenStreamRdonly(inode uint64, rdonly bool, fullPath
 		// stream is rdonly, but open again by writable, return err
 		if !rdonly {
 			log.LogErrorf("OpenStreamRdonly: rdonly stream can't be open again for write, s %s, rdonly %v", s.String(), rdonly)
-			return fuse.EPERM
+			return syscall.EPERM
 		}
 
 		s.refcnt++
@@ -903,6 +954,11 @@ func (client *ExtentClient) SetWriteRate(val int) string {
 	return setRate(client.writeLimiter, val)
 }
 
+// SetFenceToken sets the fencing token of the writes to the datanodes.
+func (client *ExtentClient) SetFenceToken(token uint64) {
+	client.dataWrapper.SetFenceToken(token)
+}
+
 func setRate(lim *rate.Limiter, val int) string {
 	if val > 0 {
 		lim.SetLimit(rate.Limit(val))
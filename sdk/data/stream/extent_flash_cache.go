@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/data/wrapper"
 	"github.com/cubefs/cubefs/sdk/master"
 	"github.com/cubefs/cubefs/sdk/meta"
 	"github.com/cubefs/cubefs/util"
@@ -48,6 +49,7 @@ const (
 	_connIdelTimeout = 30 // 30 second
 
 	RefreshFlashNodesInterval  = time.Minute
+	WatchFlashGroupsWait       = 20 * time.Second
 	RefreshHostLatencyInterval = 20 * time.Second
 
 	sameZoneWeight = 70
@@ -78,6 +80,8 @@ type RemoteCache struct {
 	conns       *util.ConnectPool
 	hostLatency sync.Map
 	flashGroups *btree.BTree
+	fgView      proto.FlashGroupView // the last got, to ask the master for the changes since
+	fgLock      sync.Mutex           // updates of the flash groups
 	stopOnce    sync.Once
 	stopC       chan struct{}
 	wg          sync.WaitGroup
@@ -100,11 +104,24 @@ type RemoteCache struct {
 	remoteCacheMaxFileSizeGB int64
 	remoteCacheOnlyForNotSSD bool
 	remoteCacheMultiRead     bool
+	remoteCacheReadAheadMB   int64
+	metaTTL                  int64 // second, the lookups and listings are not cached with 0
 	flashNodeTimeoutCount    int32
 	sameZoneTimeout          int64 // microsecond
 	sameRegionTimeout        int64 // ms
+	readPolicy               string
+	hedgeDelay               int64 // ms
+	fallbackTimeout          int64 // ms
+
+	authEnabled  bool // the volume requires a flash cache token
+	tokenLock    sync.Mutex
+	token        []byte // encoded
+	tokenExpire  int64  // unix second
+	tokenRetryAt int64  // unix second
 
 	AddressPingMap sync.Map
+	zoneCosts      *wrapper.ZoneCosts
+	zoneName       string // zone of the client, empty if unknown
 }
 
 type AddressPingStats struct {
@@ -148,6 +165,12 @@ func (rc *RemoteCache) UpdateRemoteCacheConfig(client *ExtentClient, view *proto
 	if view.RemoteCacheSameRegionTimeout <= 0 {
 		view.RemoteCacheSameRegionTimeout = proto.DefaultRemoteCacheSameRegionTimeout
 	}
+	if view.RemoteCacheHedgeDelay <= 0 {
+		view.RemoteCacheHedgeDelay = proto.DefaultRemoteCacheHedgeDelay
+	}
+	if view.RemoteCacheFallbackTimeout <= 0 {
+		view.RemoteCacheFallbackTimeout = proto.DefaultRemoteCacheFallbackTimeout
+	}
 	if rc.VolumeEnabled != view.RemoteCacheEnable {
 		log.LogInfof("RcVolumeEnabled: %v -> %v", rc.VolumeEnabled, view.RemoteCacheEnable)
 		rc.VolumeEnabled = view.RemoteCacheEnable
@@ -155,7 +178,7 @@ func (rc *RemoteCache) UpdateRemoteCacheConfig(client *ExtentClient, view *proto
 
 	// check if RemoteCache.ClusterEnabled is set to true after it has been set to false last time
 	if !client.RemoteCache.ClusterEnabled && rc.mc != nil {
-		if fgv, err := rc.mc.AdminAPI().ClientFlashGroups(); err != nil {
+		if fgv, err := rc.mc.AdminAPI().ClientFlashGroups(client.extentConfig.Volume); err != nil {
 			log.LogWarnf("updateFlashGroups: err(%v)", err)
 			return
 		} else {
@@ -211,6 +234,16 @@ func (rc *RemoteCache) UpdateRemoteCacheConfig(client *ExtentClient, view *proto
 		rc.remoteCacheMultiRead = view.RemoteCacheMultiRead
 	}
 
+	if rc.remoteCacheReadAheadMB != view.RemoteCacheReadAheadMB {
+		log.LogInfof("RcReadAheadMB: %d(MB) -> %d(MB)", rc.remoteCacheReadAheadMB, view.RemoteCacheReadAheadMB)
+		rc.remoteCacheReadAheadMB = view.RemoteCacheReadAheadMB
+	}
+
+	if rc.metaTTL != view.RemoteCacheMetaTTL {
+		log.LogInfof("RcMetaTTL: %d(s) -> %d(s)", rc.metaTTL, view.RemoteCacheMetaTTL)
+		rc.metaTTL = view.RemoteCacheMetaTTL
+	}
+
 	if rc.flashNodeTimeoutCount != int32(view.FlashNodeTimeoutCount) {
 		log.LogInfof("RcFlashNodeTimeoutCount: %d -> %d", rc.flashNodeTimeoutCount, int32(view.FlashNodeTimeoutCount))
 		rc.flashNodeTimeoutCount = int32(view.FlashNodeTimeoutCount)
@@ -223,6 +256,18 @@ func (rc *RemoteCache) UpdateRemoteCacheConfig(client *ExtentClient, view *proto
 		log.LogInfof("RcSameRegionTimeout: %d -> %d", rc.sameRegionTimeout, view.RemoteCacheSameRegionTimeout)
 		rc.sameRegionTimeout = view.RemoteCacheSameRegionTimeout
 	}
+	if rc.hedgeDelay != view.RemoteCacheHedgeDelay {
+		log.LogInfof("RcHedgeDelay: %d(ms) -> %d(ms)", rc.hedgeDelay, view.RemoteCacheHedgeDelay)
+		rc.hedgeDelay = view.RemoteCacheHedgeDelay
+	}
+	if rc.fallbackTimeout != view.RemoteCacheFallbackTimeout {
+		log.LogInfof("RcFallbackTimeout: %d(ms) -> %d(ms)", rc.fallbackTimeout, view.RemoteCacheFallbackTimeout)
+		rc.fallbackTimeout = view.RemoteCacheFallbackTimeout
+	}
+	if rc.authEnabled != view.RemoteCacheAuth {
+		log.LogInfof("RcAuth: %v -> %v", rc.authEnabled, view.RemoteCacheAuth)
+		rc.authEnabled = view.RemoteCacheAuth
+	}
 }
 
 func (rc *RemoteCache) DoRemoteCachePrepare(c *ExtentClient) {
@@ -260,12 +305,19 @@ func (rc *RemoteCache) Init(client *ExtentClient) (err error) {
 	rc.volname = client.extentConfig.Volume
 	rc.metaWrapper = client.metaWrapper
 	rc.flashGroups = btree.New(32)
+	rc.fgView = proto.FlashGroupView{}
 	rc.ReadTimeout = proto.DefaultRemoteCacheClientReadTimeout
 	rc.sameZoneTimeout = proto.DefaultRemoteCacheSameZoneTimeout
 	rc.sameRegionTimeout = proto.DefaultRemoteCacheSameRegionTimeout
+	rc.readPolicy = client.extentConfig.RemoteCacheReadPolicy
+	rc.hedgeDelay = proto.DefaultRemoteCacheHedgeDelay
+	rc.fallbackTimeout = proto.DefaultRemoteCacheFallbackTimeout
 	rc.clusterEnable = client.enableRemoteCacheCluster
+	rc.zoneCosts = &client.dataWrapper.ZoneCosts
+	rc.zoneName = client.extentConfig.ZoneName
 	rc.mc = master.NewMasterClient(client.extentConfig.Masters, false)
 	rc.conns = util.NewConnectPoolWithTimeoutAndCap(5, 500, _connIdelTimeout, 1)
+	rc.conns.SetTLSConfig(packetTLSConfig)
 
 	err = rc.updateFlashGroups()
 	if err != nil {
@@ -275,6 +327,7 @@ func (rc *RemoteCache) Init(client *ExtentClient) (err error) {
 	rc.cacheBloom = bloom.New(BloomBits, BloomHashNum)
 	rc.wg.Add(1)
 	go rc.refresh()
+	go rc.watchFlashGroups(rc.stopC)
 
 	rc.PrepareCh = make(chan *PrepareRemoteCacheRequest, 1024)
 	client.wg.Add(1)
@@ -287,10 +340,11 @@ func (rc *RemoteCache) Init(client *ExtentClient) (err error) {
 
 func (rc *RemoteCache) Read(ctx context.Context, fg *FlashGroup, inode uint64, req *CacheReadRequest) (read int, err error) {
 	var (
-		conn      *net.TCPConn
-		moved     bool
-		addr      string
-		reqPacket *Packet
+		conn          *net.TCPConn
+		moved         bool
+		addr          string
+		costBlindAddr string
+		reqPacket     *Packet
 	)
 	bgTime := stat.BeginStat()
 	defer func() {
@@ -305,8 +359,16 @@ func (rc *RemoteCache) Read(ctx context.Context, fg *FlashGroup, inode uint64, r
 		}
 		stat.EndStat("flashNode", err, bgTime, 1)
 	}()
+	switch rc.readPolicy {
+	case proto.RemoteCacheReadHedge:
+		addr, read, err = rc.readHedged(fg, inode, req)
+		return
+	case proto.RemoteCacheReadFallback:
+		addr, read, err = rc.readOrFallback(fg, inode, req)
+		return
+	}
 	for {
-		addr = fg.getFlashHost()
+		addr, costBlindAddr = fg.getFlashHost()
 		if addr == "" {
 			err = fmt.Errorf("no available host")
 			log.LogWarnf("FlashGroup Read failed: fg(%v) err(%v)", fg, err)
@@ -317,6 +379,7 @@ func (rc *RemoteCache) Read(ctx context.Context, fg *FlashGroup, inode uint64, r
 			log.LogWarnf("FlashGroup Read: failed to MarshalData (%+v). err(%v)", req, err)
 			return
 		}
+		rc.setCacheToken(reqPacket)
 		if conn, err = rc.conns.GetConnect(addr); err != nil {
 			log.LogWarnf("FlashGroup Read: get connection failed, addr(%v) reqPacket(%v) err(%v) remoteCacheMultiRead(%v)", addr, req, err, rc.remoteCacheMultiRead)
 			moved = fg.moveToUnknownRank(addr, err, rc.flashNodeTimeoutCount)
@@ -337,7 +400,7 @@ func (rc *RemoteCache) Read(ctx context.Context, fg *FlashGroup, inode uint64, r
 			}
 			return
 		}
-		if read, err = rc.getReadReply(conn, reqPacket, req); err != nil {
+		if read, err = rc.getReadReply(conn, reqPacket, req, int(rc.ReadTimeout)); err != nil {
 			// TODO: may try other replica in future
 			if proto.IsFlashNodeLimitError(err) {
 				break
@@ -352,19 +415,22 @@ func (rc *RemoteCache) Read(ctx context.Context, fg *FlashGroup, inode uint64, r
 		}
 		break
 	}
+	if err == nil {
+		rc.zoneCosts.AddReadBytes(rc.volname, addr, costBlindAddr, read)
+	}
 
 	log.LogDebugf("FlashGroup Read: flashGroup(%v) addr(%v) CacheReadRequest(%v) reqPacket(%v) err(%v) moved(%v) remoteCacheMultiRead(%v)", fg, addr, req, reqPacket, err, moved, rc.remoteCacheMultiRead)
 	return
 }
 
-func (rc *RemoteCache) getReadReply(conn *net.TCPConn, reqPacket *Packet, req *CacheReadRequest) (readBytes int, err error) {
+func (rc *RemoteCache) getReadReply(conn *net.TCPConn, reqPacket *Packet, req *CacheReadRequest, timeoutMs int) (readBytes int, err error) {
 	for readBytes < int(req.Size_) {
 		replyPacket := NewFlashCacheReply()
 		start := time.Now()
-		err = replyPacket.ReadFromConnExt(conn, int(rc.ReadTimeout))
+		err = replyPacket.ReadFromConnExt(conn, timeoutMs)
 		if err != nil {
 			log.LogWarnf("getReadReply: failed to read from connect, req(%v) readBytes(%v) err(%v) cost %v ReadTimeout %v",
-				reqPacket, readBytes, err, time.Since(start).String(), rc.ReadTimeout)
+				reqPacket, readBytes, err, time.Since(start).String(), timeoutMs)
 			return
 		}
 		if replyPacket.ResultCode != proto.OpOk {
@@ -395,7 +461,7 @@ func (rc *RemoteCache) Prepare(ctx context.Context, fg *FlashGroup, inode uint64
 	defer func() {
 		defer stat.EndStat("prepareCacheBlock", err, bg, 1)
 	}()
-	addr := fg.getFlashHost()
+	addr, _ := fg.getFlashHost()
 	if addr == "" {
 		err = fmt.Errorf("getFlashHost failed: can not find host")
 		log.LogWarnf("FlashGroup prepare failed: err(%v)", err)
@@ -406,6 +472,7 @@ func (rc *RemoteCache) Prepare(ctx context.Context, fg *FlashGroup, inode uint64
 		log.LogWarnf("FlashGroup Prepare: failed to MarshalData (%v), err(%v)", req, err)
 		return
 	}
+	rc.setCacheToken(reqPacket)
 	defer func() {
 		if err != nil {
 			moved = fg.moveToUnknownRank(addr, err, rc.flashNodeTimeoutCount)
@@ -438,6 +505,57 @@ func (rc *RemoteCache) Prepare(ctx context.Context, fg *FlashGroup, inode uint64
 	return
 }
 
+// Evict evicts the blocks of the request from all the hosts of the flash group, any of them may cache them.
+func (rc *RemoteCache) Evict(fg *FlashGroup, inode uint64, req *proto.CacheEvictRequest) (err error) {
+	bg := stat.BeginStat()
+	defer func() {
+		stat.EndStat("evictCacheBlock", err, bg, 1)
+	}()
+	var (
+		wg      sync.WaitGroup
+		errLock sync.Mutex
+	)
+	for _, addr := range fg.Hosts {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			if e := rc.evictFromHost(addr, inode, req); e != nil {
+				log.LogWarnf("FlashGroup Evict: flashGroup(%v) addr(%v) req(%v) err(%v)", fg, addr, req, e)
+				errLock.Lock()
+				err = e
+				errLock.Unlock()
+			}
+		}(addr)
+	}
+	wg.Wait()
+	return
+}
+
+func (rc *RemoteCache) evictFromHost(addr string, inode uint64, req *proto.CacheEvictRequest) (err error) {
+	reqPacket := NewFlashCachePacket(inode, proto.OpFlashNodeCacheEvict)
+	if err = reqPacket.MarshalData(req); err != nil {
+		return
+	}
+	conn, err := rc.conns.GetConnect(addr)
+	if err != nil {
+		return
+	}
+	defer func() {
+		rc.conns.PutConnect(conn, err != nil)
+	}()
+	if err = reqPacket.WriteToConn(conn); err != nil {
+		return
+	}
+	replyPacket := NewFlashCacheReply()
+	if err = replyPacket.readFromConn(conn, proto.WriteDeadlineTime); err != nil {
+		return
+	}
+	if replyPacket.ResultCode != proto.OpOk {
+		err = fmt.Errorf("ResultCode NOK (%v)", replyPacket.ResultCode)
+	}
+	return
+}
+
 func (rc *RemoteCache) Stop() {
 	rc.stopOnce.Do(func() {
 		rc.Started = false
@@ -545,15 +663,66 @@ func (rc *RemoteCache) refreshWithRecover() (panicErr error) {
 }
 
 func (rc *RemoteCache) updateFlashGroups() (err error) {
-	var (
-		fgv            proto.FlashGroupView
-		newFlashGroups = btree.New(32)
-	)
-	if fgv, err = rc.mc.AdminAPI().ClientFlashGroups(); err != nil {
+	rc.fgLock.Lock()
+	defer rc.fgLock.Unlock()
+	var fgv proto.FlashGroupView
+	if fgv, err = rc.mc.AdminAPI().ClientFlashGroupsSince(rc.volname, rc.fgView.Version); err != nil {
 		log.LogWarnf("updateFlashGroups: err(%v)", err)
 		return
 	}
-	log.LogDebugf("updateFlashGroups. get flashGroupView [%v]", fgv)
+	log.LogDebugf("updateFlashGroups. get flashGroupView [%v] since version(%v)", fgv, rc.fgView.Version)
+	rc.applyFlashGroups(&fgv)
+	return
+}
+
+// watchFlashGroups waits on the master for the changes of the flash groups,
+// so that the inactive and the removed ones are left at once instead of on
+// the read timeouts. The refresh ticker stays for the masters not holding the
+// requests, and while the flash groups are turned off.
+func (rc *RemoteCache) watchFlashGroups(stopC chan struct{}) {
+	for {
+		rc.fgLock.Lock()
+		version := rc.fgView.Version
+		rc.fgLock.Unlock()
+		begin := time.Now()
+		fgv, err := rc.mc.AdminAPI().ClientFlashGroupsWait(rc.volname, version, uint64(WatchFlashGroupsWait/time.Second))
+		select {
+		case <-stopC:
+			log.LogInfof("watchFlashGroups: remote stop")
+			return
+		default:
+		}
+		if err == nil && fgv.Version != version {
+			log.LogInfof("watchFlashGroups: flash groups changed, version(%v) -> (%v)", version, fgv.Version)
+			rc.fgLock.Lock()
+			// got since the version of another update otherwise, left to the next wait
+			if rc.fgView.Version == version {
+				rc.applyFlashGroups(&fgv)
+			}
+			rc.fgLock.Unlock()
+			continue
+		}
+		if err == nil && time.Since(begin) >= WatchFlashGroupsWait/2 {
+			continue
+		}
+		if err != nil {
+			log.LogWarnf("watchFlashGroups: err(%v)", err)
+		}
+		select {
+		case <-stopC:
+			log.LogInfof("watchFlashGroups: remote stop")
+			return
+		case <-time.After(RefreshFlashNodesInterval):
+		}
+	}
+}
+
+// applyFlashGroups applies the view got to the flash groups, the caller
+// should hold fgLock.
+func (rc *RemoteCache) applyFlashGroups(fgv *proto.FlashGroupView) {
+	newFlashGroups := btree.New(32)
+	rc.fgView.Apply(fgv)
+	fgv = &rc.fgView
 	rc.clusterEnable(fgv.Enable && len(fgv.FlashGroups) != 0)
 	if !fgv.Enable {
 		rc.flashGroups = newFlashGroups
@@ -570,9 +739,9 @@ func (rc *RemoteCache) updateFlashGroups() (err error) {
 		log.LogDebugf("updateFlashGroups: fgID(%v) newAdded hosts: %v", fg.ID, newAdded)
 
 		rc.updateHostLatency(newAdded)
-		sortedHosts := rc.ClassifyHostsByAvgDelay(fg.ID, fg.Hosts)
+		sortedHosts := rc.ClassifyHostsByAvgDelay(fg.ID, fg.Hosts, fg.HostZones)
 
-		flashGroup := NewFlashGroup(fg, sortedHosts)
+		flashGroup := NewFlashGroup(fg, sortedHosts, rc.zoneCosts)
 		for _, slot := range fg.Slot {
 			slotItem := &SlotItem{
 				slot:       slot,
@@ -582,11 +751,13 @@ func (rc *RemoteCache) updateFlashGroups() (err error) {
 		}
 	}
 	rc.flashGroups = newFlashGroups
-
-	return
 }
 
-func (rc *RemoteCache) ClassifyHostsByAvgDelay(fgID uint64, hosts []string) (sortedHosts map[ZoneRankType][]string) {
+// ClassifyHostsByAvgDelay ranks the hosts by their ping delay. If both the
+// zone of the client and of a reachable host are known, the host is ranked by
+// the zone instead: of the same zone it is preferred, of another zone it is at
+// best in the same region, however near it seems.
+func (rc *RemoteCache) ClassifyHostsByAvgDelay(fgID uint64, hosts []string, hostZones map[string]string) (sortedHosts map[ZoneRankType][]string) {
 	sortedHosts = make(map[ZoneRankType][]string)
 	sameZoneTimeout := time.Duration(rc.sameZoneTimeout) * time.Microsecond
 	sameRegionTimeout := time.Duration(rc.sameRegionTimeout) * time.Millisecond
@@ -596,9 +767,14 @@ func (rc *RemoteCache) ClassifyHostsByAvgDelay(fgID uint64, hosts []string) (sor
 		if ok {
 			avgTime = v.(time.Duration)
 		}
+		zone := hostZones[host]
 		if avgTime <= time.Duration(0) {
 			sortedHosts[UnknownZoneRank] = append(sortedHosts[UnknownZoneRank], host)
 			auditlog.LogOpMsg("ClassifyHostsByAvgDelay", fmt.Sprintf("add host %v to unknown region", host), nil)
+		} else if rc.zoneName != "" && zone == rc.zoneName {
+			sortedHosts[SameZoneRank] = append(sortedHosts[SameZoneRank], host)
+		} else if rc.zoneName != "" && zone != "" && avgTime <= sameRegionTimeout {
+			sortedHosts[SameRegionRank] = append(sortedHosts[SameRegionRank], host)
 		} else if avgTime <= sameZoneTimeout {
 			sortedHosts[SameZoneRank] = append(sortedHosts[SameZoneRank], host)
 		} else if avgTime <= sameRegionTimeout {
@@ -0,0 +1,185 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/btree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyHostsByZone(t *testing.T) {
+	rc := &RemoteCache{
+		sameZoneTimeout:   proto.DefaultRemoteCacheSameZoneTimeout,
+		sameRegionTimeout: proto.DefaultRemoteCacheSameRegionTimeout,
+	}
+	near := time.Duration(rc.sameZoneTimeout) * time.Microsecond / 2
+	far := time.Duration(rc.sameRegionTimeout) * time.Millisecond / 2
+	rc.hostLatency.Store("a", near)
+	rc.hostLatency.Store("b", near)
+	rc.hostLatency.Store("c", far)
+	rc.hostLatency.Store("d", near)
+	hosts := []string{"a", "b", "c", "d", "e"}
+	hostZones := map[string]string{"a": "z1", "b": "z2", "c": "z1", "e": "z1"}
+
+	// without the zone of the client the delay decides
+	ranked := rc.ClassifyHostsByAvgDelay(1, hosts, hostZones)
+	require.Equal(t, []string{"a", "b", "d"}, ranked[SameZoneRank])
+	require.Equal(t, []string{"c"}, ranked[SameRegionRank])
+	require.Equal(t, []string{"e"}, ranked[UnknownZoneRank])
+
+	rc.zoneName = "z1"
+	ranked = rc.ClassifyHostsByAvgDelay(1, hosts, hostZones)
+	require.Equal(t, []string{"a", "c", "d"}, ranked[SameZoneRank])
+	require.Equal(t, []string{"b"}, ranked[SameRegionRank])
+	require.Equal(t, []string{"e"}, ranked[UnknownZoneRank])
+
+	// a master not sending the zones
+	ranked = rc.ClassifyHostsByAvgDelay(1, hosts, nil)
+	require.Equal(t, []string{"a", "b", "d"}, ranked[SameZoneRank])
+}
+
+// newFakeFlashNode answers the evictions with resultCode and sends the requests to reqs.
+func newFakeFlashNode(t *testing.T, resultCode uint8, reqs chan<- *proto.CacheEvictRequest) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				for {
+					p := proto.NewPacket()
+					if err := p.ReadFromConn(conn, proto.NoReadDeadlineTime); err != nil {
+						return
+					}
+					req := new(proto.CacheEvictRequest)
+					if p.Opcode == proto.OpFlashNodeCacheEvict && p.UnmarshalData(req) == nil {
+						reqs <- req
+					}
+					p.ResultCode = resultCode
+					p.Size = 0
+					p.Data = nil
+					if err := p.WriteToConn(conn); err != nil {
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestRemoteCacheEvict(t *testing.T) {
+	proto.InitBufferPool(32768)
+	reqs := make(chan *proto.CacheEvictRequest, 4)
+	okHost := newFakeFlashNode(t, proto.OpOk, reqs)
+	errHost := newFakeFlashNode(t, proto.OpErr, reqs)
+	rc := &RemoteCache{conns: util.NewConnectPoolWithTimeoutAndCap(5, 500, _connIdelTimeout, 1)}
+	req := &proto.CacheEvictRequest{Volume: "vol", Inode: 1, Offsets: []uint64{0, proto.CACHE_BLOCK_SIZE}}
+
+	fg := NewFlashGroup(&proto.FlashGroupInfo{ID: 1, Hosts: []string{okHost}}, nil, nil)
+	require.NoError(t, rc.Evict(fg, 1, req))
+	require.Equal(t, req, <-reqs)
+
+	// sent to all the hosts even if one fails
+	fg = NewFlashGroup(&proto.FlashGroupInfo{ID: 2, Hosts: []string{errHost, okHost}}, nil, nil)
+	require.Error(t, rc.Evict(fg, 1, req))
+	require.Equal(t, req, <-reqs)
+	require.Equal(t, req, <-reqs)
+}
+
+func TestRemoteCacheWatchFlashGroups(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		version = uint64(1)
+		changed = make(chan struct{})
+		done    = make(chan struct{})
+		waits   int
+	)
+	groups := []*proto.FlashGroupInfo{{ID: 1, Slot: []uint32{100}}, {ID: 2, Slot: []uint32{200}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := strconv.ParseUint(r.FormValue("version"), 10, 64)
+		lock.Lock()
+		current, change := version, changed
+		if r.FormValue("wait") != "" {
+			waits++
+		}
+		lock.Unlock()
+		if got == current && r.FormValue("wait") != "" {
+			select {
+			case <-change:
+			case <-done:
+			}
+			lock.Lock()
+			current = version
+			lock.Unlock()
+		}
+		fgv := &proto.FlashGroupView{Enable: true, Version: current, NotModified: got == current}
+		if !fgv.NotModified {
+			fgv.FlashGroups = groups[:current]
+		}
+		reply, _ := json.Marshal(&proto.HTTPReply{Code: proto.ErrCodeSuccess, Data: fgv})
+		w.Write(reply)
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(done) })
+
+	rc := &RemoteCache{
+		volname:       "vol",
+		mc:            master.NewMasterClient([]string{strings.TrimPrefix(server.URL, "http://")}, false),
+		flashGroups:   btree.New(32),
+		clusterEnable: func(bool) {},
+	}
+	require.NoError(t, rc.updateFlashGroups())
+	require.Equal(t, uint64(1), rc.fgView.Version)
+	require.Equal(t, 1, rc.flashGroups.Len())
+
+	stopC := make(chan struct{})
+	defer close(stopC)
+	go rc.watchFlashGroups(stopC)
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return waits > 0
+	}, 3*time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	version = 2
+	close(changed)
+	changed = make(chan struct{})
+	lock.Unlock()
+	require.Eventually(t, func() bool {
+		rc.fgLock.Lock()
+		defer rc.fgLock.Unlock()
+		return rc.fgView.Version == 2 && rc.flashGroups.Len() == 2
+	}, 3*time.Second, 10*time.Millisecond)
+}
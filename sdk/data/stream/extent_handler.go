@@ -146,6 +146,10 @@ type ExtentHandler struct {
 	// To wake up *waitForFlush*.
 	empty chan struct{}
 
+	// Issue a signal to this channel when *inflight* decreases.
+	// To wake up *waitForInflight*.
+	released chan struct{}
+
 	// Created and updated in *receiver* ONLY.
 	// Not protected by lock, therefore can be used ONLY when there is no
 	// pending and new packets.
@@ -202,6 +206,7 @@ func NewExtentHandler(stream *Streamer, offset int, storeMode int, size int,
 		size:               size,
 		storeMode:          storeMode,
 		empty:              make(chan struct{}, 1024),
+		released:           make(chan struct{}, 1),
 		request:            make(chan *Packet, 1024),
 		reply:              make(chan *Packet, 1024),
 		doneSender:         make(chan struct{}),
@@ -271,6 +276,7 @@ func (eh *ExtentHandler) write(data []byte, offset, size int, direct bool) (ek *
 		}
 
 		if int(eh.packet.Size) >= blksize {
+			eh.waitForInflight()
 			eh.flushPacket()
 		}
 	}
@@ -329,6 +335,11 @@ func (eh *ExtentHandler) sender() {
 			packet.PartitionID = eh.dp.PartitionID
 			packet.ExtentType = uint8(eh.storeMode)
 			packet.ExtentType |= proto.PacketProtocolVersionFlag
+			if eh.storeMode == proto.TinyExtentType &&
+				eh.stream.smallFilePackable(int(packet.KernelOffset), int(packet.Size), eh.isMigration) {
+				packet.ExtentType |= proto.ContainerExtentFlag
+			}
+			packet.SetFenceToken(eh.stream.client.dataWrapper.FenceToken())
 			packet.ExtentID = uint64(eh.extID)
 			packet.ExtentOffset = int64(extOffset)
 			packet.Arg = ([]byte)(eh.dp.GetAllAddrs())
@@ -376,6 +387,10 @@ func (eh *ExtentHandler) processReply(packet *Packet) {
 		if atomic.AddInt32(&eh.inflight, -1) <= 0 {
 			eh.empty <- struct{}{}
 		}
+		select {
+		case eh.released <- struct{}{}:
+		default:
+		}
 	}()
 
 	status := eh.getStatus()
@@ -671,12 +686,36 @@ func (eh *ExtentHandler) waitForFlush() (err error) {
 	}
 }
 
+// waitForInflight blocks the writer while the handler has as many packets in flight
+// as the client allows, so that a fast writer does not queue up the whole file in memory.
+func (eh *ExtentHandler) waitForInflight() {
+	limit := eh.stream.client.maxInflightPackets
+	if limit <= 0 {
+		return
+	}
+	for atomic.LoadInt32(&eh.inflight) >= limit {
+		select {
+		case <-eh.released:
+		case <-eh.stop:
+			return
+		}
+	}
+}
+
 func (eh *ExtentHandler) recoverPacket(packet *Packet) error {
 	log.LogDebugf("ExtentHandler recoverPacket: eh(%v), packet(%v)", eh, packet)
 	packet.errCount++
-	if packet.errCount >= MaxPacketErrorCount || proto.IsCold(eh.stream.client.volumeType) || proto.IsStorageClassBlobStore(eh.storageClass) {
+	if packet.errCount == 1 {
+		packet.errSince = time.Now()
+	}
+	if proto.IsCold(eh.stream.client.volumeType) || proto.IsStorageClassBlobStore(eh.storageClass) ||
+		packet.errCount >= MaxPacketErrorCount && !eh.stream.client.packetWithinResume(packet.errSince) {
 		return errors.New(fmt.Sprintf("recoverPacket failed: reach max error limit, eh(%v) packet(%v)", eh, packet))
 	}
+	if packet.errCount >= MaxPacketErrorCount {
+		// the data nodes may be back within the session resume timeout
+		time.Sleep(sessionResumeRetryInterval)
+	}
 
 	handler := eh.recoverHandler
 	if handler == nil {
@@ -115,6 +115,9 @@ func (reader *ExtentReader) Read(req *ExtentRequest) (readBytes int, err error)
 		return nil, false
 	})
 
+	if err == nil {
+		reader.dp.ClientWrapper.ZoneCosts.AddReadBytes(reader.dp.ClientWrapper.VolName, sc.currAddr, sc.costBlindAddr, readBytes)
+	}
 	if err != nil {
 		// if cold vol and cach is invaild
 		if !reader.retryRead && (err == TryOtherAddrError || strings.Contains(err.Error(), "ExistErr")) {
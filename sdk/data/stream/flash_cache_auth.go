@@ -0,0 +1,63 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"time"
+
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	// the token is refreshed before it expires, so that the flash nodes never see an expired one
+	cacheTokenRefreshAhead = 5 * 60 // second
+	// the master is not asked again for a while after it fails to issue the token
+	cacheTokenRetryInterval = 10 // second
+)
+
+// cacheToken returns the encoded flash cache token carried by the cache requests of
+// the volume, nil if the volume requires none or the master fails to issue it.
+func (rc *RemoteCache) cacheToken() []byte {
+	if !rc.authEnabled || rc.mc == nil {
+		return nil
+	}
+	rc.tokenLock.Lock()
+	defer rc.tokenLock.Unlock()
+	now := time.Now().Unix()
+	if rc.token != nil && now < rc.tokenExpire-cacheTokenRefreshAhead {
+		return rc.token
+	}
+	if now < rc.tokenRetryAt {
+		return rc.token
+	}
+	t, err := rc.mc.ClientAPI().GetFlashCacheToken(rc.volname, util.CalcAuthKey(rc.metaWrapper.Owner()))
+	if err != nil {
+		rc.tokenRetryAt = now + cacheTokenRetryInterval
+		log.LogWarnf("cacheToken: vol(%v) err(%v)", rc.volname, err)
+		return rc.token
+	}
+	rc.token = t.Encode()
+	rc.tokenExpire = t.Expire
+	return rc.token
+}
+
+// setCacheToken lets the cache request packet carry the token in its arg.
+func (rc *RemoteCache) setCacheToken(p *Packet) {
+	if token := rc.cacheToken(); token != nil {
+		p.Arg = token
+		p.ArgLen = uint32(len(token))
+	}
+}
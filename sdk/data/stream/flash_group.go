@@ -23,6 +23,7 @@ import (
 	"syscall"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/data/wrapper"
 	"github.com/cubefs/cubefs/util/btree"
 	"github.com/cubefs/cubefs/util/log"
 )
@@ -33,6 +34,7 @@ type FlashGroup struct {
 	hostLock         sync.RWMutex
 	epoch            uint64
 	hostTimeoutCount map[string]int32
+	zoneCosts        *wrapper.ZoneCosts
 }
 
 func (fg *FlashGroup) String() string {
@@ -42,15 +44,18 @@ func (fg *FlashGroup) String() string {
 	return fmt.Sprintf("flashGroup[fgId(%v) Hosts(%v)]", fg.ID, fg.Hosts)
 }
 
-func NewFlashGroup(flashGroupInfo *proto.FlashGroupInfo, rankedHost map[ZoneRankType][]string) *FlashGroup {
+func NewFlashGroup(flashGroupInfo *proto.FlashGroupInfo, rankedHost map[ZoneRankType][]string, zoneCosts *wrapper.ZoneCosts) *FlashGroup {
 	return &FlashGroup{
 		FlashGroupInfo:   flashGroupInfo,
 		rankedHost:       rankedHost,
 		hostTimeoutCount: make(map[string]int32),
+		zoneCosts:        zoneCosts,
 	}
 }
 
-func (fg *FlashGroup) getFlashHost() (host string) {
+// getFlashHost picks a rank by weight, then the cheapest hosts of the rank
+// round robin. costBlindHost is the host picked regardless of the zone costs.
+func (fg *FlashGroup) getFlashHost() (host, costBlindHost string) {
 	fg.hostLock.RLock()
 	defer fg.hostLock.RUnlock()
 
@@ -65,18 +70,11 @@ func (fg *FlashGroup) getFlashHost() (host string) {
 	if sameZoneLen == 0 && sameRegionLen == 0 {
 		return
 	}
-	if sameZoneLen == 0 {
-		host = sameRegionHosts[epoch%sameRegionLen]
-	} else if sameRegionLen == 0 {
-		host = sameZoneHosts[epoch%sameZoneLen]
-	} else {
-		if epoch%100 < uint64(sameZoneWeight) {
-			host = sameZoneHosts[epoch%sameZoneLen]
-		} else {
-			host = sameRegionHosts[epoch%sameRegionLen]
-		}
+	hosts := sameZoneHosts
+	if sameZoneLen == 0 || (sameRegionLen > 0 && epoch%100 >= uint64(sameZoneWeight)) {
+		hosts = sameRegionHosts
 	}
-	return
+	return fg.zoneCosts.Select(hosts, epoch)
 }
 
 func (fg *FlashGroup) moveToUnknownRank(addr string, err error, timeoutCount int32) bool {
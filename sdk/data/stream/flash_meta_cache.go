@@ -0,0 +1,135 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+	"github.com/cubefs/cubefs/util/stat"
+)
+
+// IsRemoteMetaCacheEnabled reports whether the lookups and the listings of the
+// directories of the volume are cached by the flash groups.
+func (client *ExtentClient) IsRemoteMetaCacheEnabled() bool {
+	return client.IsRemoteCacheEnabled() && client.RemoteCache.Started && client.RemoteCache.metaTTL > 0
+}
+
+// metaCacheHost is the flashnode caching the metadata of the directory. All the
+// clients choose the same one of the flash group, which caches the metadata
+// put by any of them only once.
+func (rc *RemoteCache) metaCacheHost(parent uint64) (fg *FlashGroup, addr string) {
+	slot := proto.ComputeMetaCacheSlot(rc.volname, parent)
+	if fg, _ = rc.GetFlashGroupBySlot(slot); fg == nil || len(fg.Hosts) == 0 {
+		return nil, ""
+	}
+	return fg, fg.Hosts[slot%uint32(len(fg.Hosts))]
+}
+
+// GetMeta returns the metadata of the name in the directory parent put by the
+// clients, the listing of the directory with an empty name. It tells false if
+// the metadata are not cached, the caller gets them from the metanodes then.
+func (rc *RemoteCache) GetMeta(parent uint64, name string) (data []byte, ok bool) {
+	var err error
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("flashNode:metaGet", err, bgTime, 1)
+	}()
+	_, addr := rc.metaCacheHost(parent)
+	if addr == "" {
+		return nil, false
+	}
+	req := &proto.FlashMetaCacheRequest{Volume: rc.volname, Parent: parent, Names: []string{name}}
+	reply, err := rc.sendMeta(addr, proto.OpFlashNodeMetaGet, req, nil, int(rc.ReadTimeout))
+	if err != nil {
+		log.LogWarnf("GetMeta: addr(%v) parent(%v) name(%v) err(%v)", addr, parent, name, err)
+		return nil, false
+	}
+	if reply.ResultCode != proto.OpOk {
+		return nil, false
+	}
+	return reply.Data, true
+}
+
+// PutMeta caches the metadata of the name in the directory parent for the ttl of
+// the volume.
+func (rc *RemoteCache) PutMeta(parent uint64, name string, data []byte) {
+	if len(data) > proto.MaxRemoteCacheMetaSize {
+		return
+	}
+	_, addr := rc.metaCacheHost(parent)
+	if addr == "" {
+		return
+	}
+	req := &proto.FlashMetaCacheRequest{Volume: rc.volname, Parent: parent, Names: []string{name}, TTLSec: rc.metaTTL}
+	if _, err := rc.sendMeta(addr, proto.OpFlashNodeMetaPut, req, data, proto.WriteDeadlineTime*1000); err != nil {
+		log.LogWarnf("PutMeta: addr(%v) parent(%v) name(%v) err(%v)", addr, parent, name, err)
+	}
+}
+
+// EvictMeta evicts the metadata of the names and the listing of the directory
+// parent changed by this client from all the hosts of its flash group, the
+// other clients see the changes once the metadata cached expire.
+func (rc *RemoteCache) EvictMeta(parent uint64, names ...string) {
+	fg, _ := rc.metaCacheHost(parent)
+	if fg == nil {
+		return
+	}
+	req := &proto.FlashMetaCacheRequest{Volume: rc.volname, Parent: parent, Names: append(names, "")}
+	var wg sync.WaitGroup
+	for _, addr := range fg.Hosts {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			if _, err := rc.sendMeta(addr, proto.OpFlashNodeMetaEvict, req, nil, proto.WriteDeadlineTime*1000); err != nil {
+				log.LogWarnf("EvictMeta: addr(%v) parent(%v) names(%v) err(%v)", addr, parent, names, err)
+			}
+		}(addr)
+	}
+	wg.Wait()
+}
+
+func (rc *RemoteCache) sendMeta(addr string, op uint8, req *proto.FlashMetaCacheRequest, data []byte, timeoutMs int) (reply *Packet, err error) {
+	reqPacket := NewFlashCachePacket(req.Parent, op)
+	withToken := *req
+	withToken.Token = rc.cacheToken()
+	if reqPacket.Arg, err = json.Marshal(&withToken); err != nil {
+		return
+	}
+	reqPacket.ArgLen = uint32(len(reqPacket.Arg))
+	reqPacket.Data = data
+	reqPacket.Size = uint32(len(data))
+	conn, err := rc.conns.GetConnect(addr)
+	if err != nil {
+		return
+	}
+	defer func() {
+		rc.conns.PutConnect(conn, err != nil)
+	}()
+	if err = reqPacket.WriteToConn(conn); err != nil {
+		return
+	}
+	reply = NewFlashCacheReply()
+	if err = reply.ReadFromConnExt(conn, timeoutMs); err != nil {
+		return
+	}
+	if reply.ResultCode != proto.OpOk && reply.ResultCode != proto.OpNotExistErr {
+		err = fmt.Errorf("ResultCode NOK (%v) %v", reply.ResultCode, string(reply.Data))
+	}
+	return
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// The read policy of the client, set by the remoteCacheReadPolicy mount
+// option, replaces the retries of remoteCacheMultiRead on a slow or failed
+// read of a flash group:
+//   - hedge reads a second node of the group if the first one does not reply
+//     within the hedge delay of the volume or fails, the first reply wins.
+//   - fallback reads one node only and gives up after the fallback timeout of
+//     the volume, the stream then reads the data nodes.
+
+type flashReadResult struct {
+	addr          string
+	costBlindAddr string
+	data          []byte
+	read          int
+	err           error
+}
+
+// getHedgeHost returns a host of the group other than the one read first,
+// the same zone ones first.
+func (fg *FlashGroup) getHedgeHost(first string) string {
+	fg.hostLock.RLock()
+	defer fg.hostLock.RUnlock()
+	for rank := SameZoneRank; rank <= SameRegionRank; rank++ {
+		for _, host := range fg.rankedHost[rank] {
+			if host != first {
+				return host
+			}
+		}
+	}
+	return ""
+}
+
+// readFromHost reads the request from one host with a timeout per reply.
+func (rc *RemoteCache) readFromHost(fg *FlashGroup, addr string, inode uint64, req *CacheReadRequest, timeoutMs int64) (read int, err error) {
+	var conn *net.TCPConn
+	defer func() {
+		rc.conns.PutConnect(conn, err != nil && !proto.IsFlashNodeLimitError(err))
+		// a read past the fallback timeout is slow, the host is not demoted for it
+		if err != nil && !proto.IsFlashNodeLimitError(err) && (timeoutMs >= rc.ReadTimeout || !os.IsTimeout(err)) {
+			fg.moveToUnknownRank(addr, err, rc.flashNodeTimeoutCount)
+		}
+	}()
+	reqPacket := NewFlashCachePacket(inode, proto.OpFlashNodeCacheRead)
+	if err = reqPacket.MarshalDataPb(&req.CacheReadRequest); err != nil {
+		return
+	}
+	rc.setCacheToken(reqPacket)
+	if conn, err = rc.conns.GetConnect(addr); err != nil {
+		return
+	}
+	if err = reqPacket.WriteToConn(conn); err != nil {
+		return
+	}
+	return rc.getReadReply(conn, reqPacket, req, int(timeoutMs))
+}
+
+// readHedged reads the request from a host of the group and from a second one
+// if the first does not reply within the hedge delay or fails.
+func (rc *RemoteCache) readHedged(fg *FlashGroup, inode uint64, req *CacheReadRequest) (addr string, read int, err error) {
+	addr, costBlindAddr := fg.getFlashHost()
+	if addr == "" {
+		err = fmt.Errorf("no available host")
+		log.LogWarnf("FlashGroup readHedged failed: fg(%v) err(%v)", fg, err)
+		return
+	}
+	// each read fills its own buffer, the slower one may still be running
+	// after the faster one is returned
+	results := make(chan *flashReadResult, 2)
+	readFrom := func(addr, costBlindAddr string) {
+		hostReq := &CacheReadRequest{CacheReadRequest: req.CacheReadRequest, Data: make([]byte, len(req.Data))}
+		res := &flashReadResult{addr: addr, costBlindAddr: costBlindAddr, data: hostReq.Data}
+		res.read, res.err = rc.readFromHost(fg, addr, inode, hostReq, rc.ReadTimeout)
+		results <- res
+	}
+	go readFrom(addr, costBlindAddr)
+	pending, hedged := 1, false
+	hedge := func() {
+		if hedged {
+			return
+		}
+		hedged = true
+		if second := fg.getHedgeHost(addr); second != "" {
+			log.LogDebugf("FlashGroup readHedged: fg(%v) ino(%v) hedge from addr(%v) to addr(%v)", fg, inode, addr, second)
+			pending++
+			go readFrom(second, second)
+		}
+	}
+	timer := time.NewTimer(time.Duration(rc.hedgeDelay) * time.Millisecond)
+	defer timer.Stop()
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			hedge()
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				copy(req.Data, res.data[:res.read])
+				rc.zoneCosts.AddReadBytes(rc.volname, res.addr, res.costBlindAddr, res.read)
+				return res.addr, res.read, nil
+			}
+			addr, err = res.addr, res.err
+			log.LogWarnf("FlashGroup readHedged: fg(%v) ino(%v) addr(%v) err(%v)", fg, inode, res.addr, res.err)
+			if !proto.IsFlashNodeLimitError(res.err) {
+				hedge()
+			}
+		}
+	}
+	return
+}
+
+// readOrFallback reads the request from a host of the group within the
+// fallback timeout, the caller reads the data nodes on an error.
+func (rc *RemoteCache) readOrFallback(fg *FlashGroup, inode uint64, req *CacheReadRequest) (addr string, read int, err error) {
+	addr, costBlindAddr := fg.getFlashHost()
+	if addr == "" {
+		err = fmt.Errorf("no available host")
+		log.LogWarnf("FlashGroup readOrFallback failed: fg(%v) err(%v)", fg, err)
+		return
+	}
+	if read, err = rc.readFromHost(fg, addr, inode, req, rc.fallbackTimeout); err != nil {
+		if !proto.IsFlashNodeLimitError(err) {
+			log.LogWarnf("FlashGroup readOrFallback: fg(%v) ino(%v) addr(%v) fallback to datanode, err(%v)", fg, inode, addr, err)
+		}
+		return
+	}
+	rc.zoneCosts.AddReadBytes(rc.volname, addr, costBlindAddr, read)
+	return
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"hash/crc32"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeFlashReadNode answers the reads after delay with fill, or with an
+// error if fill is 0.
+func newFakeFlashReadNode(t *testing.T, delay time.Duration, fill byte) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				for {
+					p := proto.NewPacket()
+					if err := p.ReadFromConn(conn, proto.NoReadDeadlineTime); err != nil {
+						return
+					}
+					req := new(proto.CacheReadRequest)
+					if err := req.Unmarshal(p.Data); err != nil {
+						return
+					}
+					time.Sleep(delay)
+					if fill == 0 {
+						p.ResultCode = proto.OpErr
+						p.Data = []byte("read failed")
+					} else {
+						p.ResultCode = proto.OpOk
+						p.Data = bytes.Repeat([]byte{fill}, int(req.Size_))
+						p.CRC = crc32.ChecksumIEEE(p.Data)
+					}
+					p.Size = uint32(len(p.Data))
+					if err := p.WriteToConn(conn); err != nil {
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestRemoteCacheReadPolicy(t *testing.T) {
+	if proto.Buffers == nil {
+		proto.InitBufferPool(32768)
+	}
+	slowHost := newFakeFlashReadNode(t, 500*time.Millisecond, 's')
+	fastHost := newFakeFlashReadNode(t, 0, 'f')
+	errHost := newFakeFlashReadNode(t, 0, 0)
+	rc := &RemoteCache{
+		conns:                 util.NewConnectPoolWithTimeoutAndCap(5, 500, _connIdelTimeout, 1),
+		ReadTimeout:           2000,
+		hedgeDelay:            20,
+		fallbackTimeout:       30,
+		flashNodeTimeoutCount: 1,
+	}
+	newFlashGroup := func(first, second string) *FlashGroup {
+		// the first pick of getFlashHost is in the same zone
+		return NewFlashGroup(&proto.FlashGroupInfo{ID: 1, Hosts: []string{first, second}},
+			map[ZoneRankType][]string{SameZoneRank: {first}, SameRegionRank: {second}}, nil)
+	}
+	read := func(fg *FlashGroup) (data []byte, cost time.Duration, err error) {
+		req := &CacheReadRequest{
+			CacheReadRequest: proto.CacheReadRequest{CacheRequest: &proto.CacheRequest{Volume: "vol", Inode: 1}, Size_: 4096},
+			Data:             make([]byte, 4096),
+		}
+		begin := time.Now()
+		n, err := rc.Read(context.Background(), fg, 1, req)
+		return req.Data[:n], time.Since(begin), err
+	}
+
+	// slow first node, the second one replies first
+	rc.readPolicy = proto.RemoteCacheReadHedge
+	data, cost, err := read(newFlashGroup(slowHost, fastHost))
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{'f'}, 4096), data)
+	require.Less(t, cost, 400*time.Millisecond)
+
+	// failed first node, hedged at once
+	rc.hedgeDelay = 10000
+	data, cost, err = read(newFlashGroup(errHost, fastHost))
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{'f'}, 4096), data)
+	require.Less(t, cost, 400*time.Millisecond)
+
+	// no second node
+	_, _, err = read(NewFlashGroup(&proto.FlashGroupInfo{ID: 1, Hosts: []string{errHost}},
+		map[ZoneRankType][]string{SameZoneRank: {errHost}}, nil))
+	require.Error(t, err)
+
+	// gives up on the slow node without demoting it
+	rc.readPolicy = proto.RemoteCacheReadFallback
+	fg := newFlashGroup(slowHost, fastHost)
+	_, cost, err = read(fg)
+	require.Error(t, err)
+	require.Less(t, cost, 400*time.Millisecond)
+	require.Equal(t, []string{slowHost}, fg.rankedHost[SameZoneRank])
+
+	data, _, err = read(newFlashGroup(fastHost, slowHost))
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{'f'}, 4096), data)
+}
@@ -32,6 +32,7 @@ type Packet struct {
 	proto.Packet
 	inode    uint64
 	errCount int
+	errSince time.Time // the first error, see recoverPacket
 }
 
 // String returns the string format of the packet.
@@ -220,6 +221,7 @@ func (p *Packet) writeToConn(conn net.Conn) error {
 }
 
 func (p *Packet) readFromConn(c net.Conn, deadlineTime time.Duration) (err error) {
+	c = util.PacketConnOf(c)
 	if deadlineTime != proto.NoReadDeadlineTime {
 		c.SetReadDeadline(time.Now().Add(deadlineTime * time.Second))
 	}
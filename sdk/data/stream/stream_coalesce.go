@@ -0,0 +1,121 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const defaultWriteCoalesceWindow = 10 * time.Millisecond
+
+// coalescedWrite gathers the small appends to the end of a file, e.g. the
+// lines of a log, and writes them to an extent as one request once the
+// coalesce size of the client would be exceeded, the coalesce window elapses,
+// or the file is flushed. It is only touched by the server routine of the
+// streamer, except size which tells the readers to flush first.
+type coalescedWrite struct {
+	data         []byte
+	offset       int
+	storageClass uint32
+	timer        *time.Timer
+	expire       <-chan time.Time // nil while nothing is buffered
+	size         int64
+}
+
+// coalescable tells whether a write is gathered with the neighbouring ones,
+// which is the case for the small buffered appends to the end of the file.
+func (s *Streamer) coalescable(offset, size int, direct bool, storageClass uint32, isMigration bool) bool {
+	limit := s.client.writeCoalesceSize
+	if limit <= 0 || size >= limit || direct || isMigration || !proto.IsStorageClassReplica(storageClass) {
+		return false
+	}
+	filesize, _ := s.extents.Size()
+	return offset == filesize
+}
+
+func (s *Streamer) writeCoalesced(data []byte, offset, size int, storageClass uint32, checkFunc func() error) (total int, err error) {
+	c := &s.coalesce
+	if len(c.data) > 0 && (offset != c.offset+len(c.data) || storageClass != c.storageClass ||
+		len(c.data)+size > s.client.writeCoalesceSize) {
+		if err = s.flushCoalesced(); err != nil {
+			return
+		}
+	}
+	if checkFunc != nil {
+		if err = checkFunc(); err != nil {
+			return
+		}
+	}
+	if len(c.data) == 0 {
+		c.data = make([]byte, 0, s.client.writeCoalesceSize)
+		c.offset, c.storageClass = offset, storageClass
+		c.arm(s.client.writeCoalesceWindow)
+	}
+	c.data = append(c.data, data[:size]...)
+	atomic.StoreInt64(&c.size, int64(len(c.data)))
+
+	if filesize, _ := s.extents.Size(); offset+size > filesize {
+		s.extents.SetSize(uint64(offset+size), false)
+	}
+	log.LogDebugf("Streamer writeCoalesced: ino(%v) offset(%v) size(%v) buffered(%v)", s.inode, offset, size, len(c.data))
+	return size, nil
+}
+
+// flushCoalesced writes the gathered appends to an extent. They are kept on
+// failure, to be retried by the next flush.
+func (s *Streamer) flushCoalesced() (err error) {
+	c := &s.coalesce
+	if len(c.data) == 0 {
+		return nil
+	}
+	c.disarm()
+	req := NewExtentRequest(c.offset, len(c.data), c.data, nil)
+	if _, err = s.doWriteAppend(req, false, c.storageClass, false); err != nil {
+		log.LogErrorf("Streamer flushCoalesced: ino(%v) offset(%v) size(%v) err(%v)", s.inode, c.offset, len(c.data), err)
+		c.arm(s.client.writeCoalesceWindow)
+		return
+	}
+	log.LogDebugf("Streamer flushCoalesced: ino(%v) offset(%v) size(%v)", s.inode, c.offset, len(c.data))
+	c.data = nil
+	atomic.StoreInt64(&c.size, 0)
+	return
+}
+
+func (s *Streamer) hasCoalescedWrites() bool {
+	return atomic.LoadInt64(&s.coalesce.size) > 0
+}
+
+func (c *coalescedWrite) arm(window time.Duration) {
+	if c.timer == nil {
+		c.timer = time.NewTimer(window)
+	} else {
+		c.timer.Reset(window)
+	}
+	c.expire = c.timer.C
+}
+
+func (c *coalescedWrite) disarm() {
+	if c.timer != nil && !c.timer.Stop() {
+		select {
+		case <-c.timer.C:
+		default:
+		}
+	}
+	c.expire = nil
+}
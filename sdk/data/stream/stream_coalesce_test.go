@@ -0,0 +1,72 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamerCoalescedWrites(t *testing.T) {
+	client := &ExtentClient{}
+	s := &Streamer{client: client, inode: 1, extents: NewExtentCache(1), dirtylist: NewDirtyExtentList()}
+	hdd := proto.StorageClass_Replica_HDD
+
+	// disabled by default
+	require.False(t, s.coalescable(0, 5, false, hdd, false))
+
+	client.writeCoalesceSize = 16
+	client.writeCoalesceWindow = time.Hour
+	require.True(t, s.coalescable(0, 5, false, hdd, false))
+	require.False(t, s.coalescable(0, 16, false, hdd, false))
+	require.False(t, s.coalescable(0, 5, true, hdd, false))
+	require.False(t, s.coalescable(0, 5, false, hdd, true))
+	require.False(t, s.coalescable(0, 5, false, proto.StorageClass_BlobStore, false))
+	require.False(t, s.coalescable(3, 5, false, hdd, false))
+
+	n, err := s.writeCoalesced([]byte("hello"), 0, 5, hdd, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.True(t, s.coalescable(5, 6, false, hdd, false))
+	n, err = s.writeCoalesced([]byte(" world"), 5, 6, hdd, nil)
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+
+	require.Equal(t, []byte("hello world"), s.coalesce.data)
+	require.Equal(t, 0, s.coalesce.offset)
+	require.True(t, s.hasCoalescedWrites())
+	require.NotNil(t, s.coalesce.expire)
+	size, _ := s.extents.Size()
+	require.Equal(t, 11, size)
+
+	// a failed check leaves the buffer untouched
+	_, err = s.writeCoalesced([]byte("!"), 11, 1, hdd, func() error { return syscall.EPERM })
+	require.Equal(t, syscall.EPERM, err)
+	require.Equal(t, []byte("hello world"), s.coalesce.data)
+
+	s.coalesce.disarm()
+	require.Nil(t, s.coalesce.expire)
+	s.coalesce.arm(time.Millisecond)
+	select {
+	case <-s.coalesce.expire:
+	case <-time.After(time.Second):
+		t.Fatal("coalesce window did not expire")
+	}
+	s.coalesce.disarm()
+}
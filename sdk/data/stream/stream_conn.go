@@ -15,6 +15,7 @@
 package stream
 
 import (
+	"crypto/tls"
 	"encoding/binary"
 	"fmt"
 	"math/rand"
@@ -52,6 +53,8 @@ type GetReplyFunc func(conn *net.TCPConn) (err error, again bool)
 type StreamConn struct {
 	dp       *wrapper.DataPartition
 	currAddr string
+	// the host that would be read without the zone costs
+	costBlindAddr string
 
 	maxRetryTimeout time.Duration
 }
@@ -59,8 +62,26 @@ type StreamConn struct {
 var (
 	StreamConnPool      = util.NewConnectPool()
 	StreamWriteConnPool = util.NewConnectPool()
+
+	// packetTLSConfig is also used for the connections to the flash nodes
+	packetTLSConfig *tls.Config
 )
 
+// SetPacketCompressThreshold lets the packets to the data nodes not smaller
+// than threshold be compressed, for the clients on slow links.
+func SetPacketCompressThreshold(threshold int) {
+	StreamConnPool.SetCompressThreshold(threshold)
+	StreamWriteConnPool.SetCompressThreshold(threshold)
+}
+
+// SetPacketTLSConfig lets the packets to the data nodes be sent over TLS,
+// verifying the data nodes with config, and so are the ones to the flash nodes.
+func SetPacketTLSConfig(config *tls.Config) {
+	packetTLSConfig = config
+	StreamConnPool.SetTLSConfig(config)
+	StreamWriteConnPool.SetTLSConfig(config)
+}
+
 // NewStreamConn returns a new stream connection.
 func NewStreamConn(dp *wrapper.DataPartition, follower bool, timeout time.Duration) (sc *StreamConn) {
 	defer func() {
@@ -93,25 +114,28 @@ func NewStreamConn(dp *wrapper.DataPartition, follower bool, timeout time.Durati
 	}()
 
 	if dp.ClientWrapper.NearRead() {
+		currAddr, costBlindAddr := getNearestHost(dp)
 		sc = &StreamConn{
-			dp:       dp,
-			currAddr: getNearestHost(dp),
+			dp:            dp,
+			currAddr:      currAddr,
+			costBlindAddr: costBlindAddr,
 		}
 		return
 	}
 
 	epoch := atomic.AddUint64(&dp.Epoch, 1)
 	hosts := sortByStatus(dp, false)
-	choice := len(hosts)
-	currAddr := dp.LeaderAddr
-	if choice > 0 {
-		index := int(epoch) % choice
-		currAddr = hosts[index]
+	currAddr, costBlindAddr := dp.LeaderAddr, dp.LeaderAddr
+	if len(hosts) > 0 {
+		// a read replica refusing the read falls back to the hosts
+		hosts = append(hosts, activeReadReplicas(dp)...)
+		currAddr, costBlindAddr = dp.ClientWrapper.ZoneCosts.Select(hosts, epoch)
 	}
 
 	sc = &StreamConn{
-		dp:       dp,
-		currAddr: currAddr,
+		dp:            dp,
+		currAddr:      currAddr,
+		costBlindAddr: costBlindAddr,
 	}
 	return
 }
@@ -135,6 +159,9 @@ func (sc *StreamConn) Send(retry *bool, req *Packet, getReply GetReplyFunc) (err
 	if req.IsReadOperation() && !sc.dp.ClientWrapper.InnerReq() && !sc.dp.ClientWrapper.FollowerRead() {
 		return sc.sendReadToDP(sc.dp, req, retry, getReply)
 	}
+	if !req.IsReadOperation() {
+		req.SetFenceToken(sc.dp.ClientWrapper.FenceToken())
+	}
 	return sc.sendToDataPartitionLeader(req, retry, getReply)
 }
 
@@ -397,8 +424,27 @@ func sortByStatus(dp *wrapper.DataPartition, selectAll bool) (hosts []string) {
 	return
 }
 
-func getNearestHost(dp *wrapper.DataPartition) string {
+// activeReadReplicas returns the read replicas of a hot partition on the
+// active data nodes.
+func activeReadReplicas(dp *wrapper.DataPartition) (hosts []string) {
+	hostsStatus := dp.ClientWrapper.HostsStatus
+	for _, addr := range dp.ReadReplicas {
+		if hostsStatus[addr] {
+			hosts = append(hosts, addr)
+		}
+	}
+	return
+}
+
+// getNearestHost returns the cheapest of the nearest active hosts, and the
+// first of them which is read without the zone costs.
+func getNearestHost(dp *wrapper.DataPartition) (host, costBlindHost string) {
+	var (
+		nearest     []string
+		minDistance int
+	)
 	hostsStatus := dp.ClientWrapper.HostsStatus
+	zoneCosts := &dp.ClientWrapper.ZoneCosts
 	for _, addr := range dp.NearHosts {
 		status, ok := hostsStatus[addr]
 		if ok {
@@ -406,9 +452,20 @@ func getNearestHost(dp *wrapper.DataPartition) string {
 				continue
 			}
 		}
-		return addr
+		if !zoneCosts.Enabled() {
+			return addr, addr
+		}
+		distance := wrapper.DistanceFromLocal(addr)
+		if len(nearest) > 0 && distance > minDistance {
+			break
+		}
+		minDistance = distance
+		nearest = append(nearest, addr)
+	}
+	if len(nearest) == 0 {
+		return dp.LeaderAddr, dp.LeaderAddr
 	}
-	return dp.LeaderAddr
+	return zoneCosts.Cheapest(nearest)[0], nearest[0]
 }
 
 func getDpHosts(dp *wrapper.DataPartition) (activeHosts, quorumHosts []string) {
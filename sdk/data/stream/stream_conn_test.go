@@ -87,3 +87,34 @@ func TestSortByStatus(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, hosts)
 	}
 }
+
+func TestNewStreamConnReadReplicas(t *testing.T) {
+	dp := &wrapper.DataPartition{
+		ClientWrapper: &wrapper.Wrapper{
+			HostsStatus: map[string]bool{
+				"host1": true,
+				"host2": true,
+				"rr1":   true,
+				"rr2":   false,
+			},
+		},
+	}
+	dp.PartitionID = 1
+	dp.Hosts = []string{"host1", "host2"}
+	dp.ReadReplicas = []string{"rr1", "rr2"}
+
+	picked := make(map[string]bool)
+	for i := 0; i < 6; i++ {
+		picked[NewStreamConn(dp, true, 0).currAddr] = true
+	}
+	expected := map[string]bool{"host1": true, "host2": true, "rr1": true}
+	if !reflect.DeepEqual(picked, expected) {
+		t.Errorf("Expected %v, got %v", expected, picked)
+	}
+
+	// the read replicas are not read from when no host is active
+	dp.ClientWrapper.HostsStatus = map[string]bool{"rr1": true}
+	if sc := NewStreamConn(dp, true, 0); sc.currAddr == "rr1" {
+		t.Errorf("Expected a host, got %v", sc.currAddr)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"io"
+	"sync"
+	"syscall"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// inlineData is the content of a tiny file kept in its inode instead of
+// extents. The writes under the inline data threshold of the volume go to the
+// buffer and are stored in the inode on flush. A write beyond the threshold
+// first moves the buffer to an extent, the MetaNode drops the inline data of
+// the inode once an extent is appended.
+type inlineData struct {
+	sync.Mutex
+	data         []byte
+	gen          uint64 // generation of the inode the data was loaded at
+	loaded       bool
+	dirty        bool
+	storageClass uint32
+}
+
+func (s *Streamer) inlineThreshold() int {
+	if s.client == nil || s.client.dataWrapper == nil || s.client.setInlineData == nil {
+		return 0
+	}
+	return s.client.dataWrapper.InlineDataThreshold()
+}
+
+// inlineWritable tells whether a write is kept in the inline data, which is
+// only the case as long as the file has no extent.
+func (s *Streamer) inlineWritable(offset, size int, storageClass uint32, isMigration bool) bool {
+	threshold := s.inlineThreshold()
+	if threshold <= 0 || offset+size > threshold || isMigration || s.verSeq != 0 || !proto.IsStorageClassReplica(storageClass) {
+		return false
+	}
+	if s.handler != nil || s.dirtylist.Len() > 0 || s.extents.Len() > 0 {
+		return false
+	}
+	return s.client.inlineDataEnabled(s.inode)
+}
+
+// loadInlineData reads the inline data of a file without extents, unless it is
+// already loaded at the generation of the extent cache.
+func (s *Streamer) loadInlineData() error {
+	if s.client == nil || s.client.getInlineData == nil {
+		return nil
+	}
+	size, gen := s.extents.Size()
+	s.inline.Lock()
+	defer s.inline.Unlock()
+	if s.inline.dirty || (s.inline.loaded && s.inline.gen >= gen) {
+		return nil
+	}
+	if size == 0 || s.extents.Len() > 0 {
+		s.inline.data, s.inline.gen, s.inline.loaded = nil, gen, true
+		return nil
+	}
+	inodeGen, data, err := s.client.getInlineData(s.inode)
+	if err != nil {
+		log.LogErrorf("loadInlineData: ino(%v) err(%v)", s.inode, err)
+		return err
+	}
+	s.inline.data, s.inline.gen, s.inline.loaded = data, inodeGen, true
+	return nil
+}
+
+func (s *Streamer) writeInline(data []byte, offset, size int, direct bool, storageClass uint32, checkFunc func() error) (total int, err error) {
+	if err = s.loadInlineData(); err != nil {
+		return
+	}
+	if checkFunc != nil {
+		if err = checkFunc(); err != nil {
+			return
+		}
+	}
+	s.inline.Lock()
+	if end := offset + size; end > len(s.inline.data) {
+		buf := make([]byte, end)
+		copy(buf, s.inline.data)
+		s.inline.data = buf
+	}
+	copy(s.inline.data[offset:], data[:size])
+	s.inline.loaded = true
+	s.inline.dirty = true
+	s.inline.storageClass = storageClass
+	s.inline.Unlock()
+
+	if filesize, _ := s.extents.Size(); offset+size > filesize {
+		s.extents.SetSize(uint64(offset+size), false)
+	}
+	if direct {
+		if err = s.flushInlineData(); err != nil {
+			return
+		}
+	}
+	log.LogDebugf("Streamer writeInline: ino(%v) offset(%v) size(%v)", s.inode, offset, size)
+	return size, nil
+}
+
+// moveInlineToExtents writes the inline data of the file to an extent, before
+// a write which can not be kept inline.
+func (s *Streamer) moveInlineToExtents(storageClass uint32, isMigration bool) (err error) {
+	if isMigration {
+		return nil
+	}
+	if err = s.loadInlineData(); err != nil {
+		return
+	}
+	s.inline.Lock()
+	data := s.inline.data
+	s.inline.data, s.inline.dirty = nil, false
+	s.inline.Unlock()
+	if len(data) == 0 {
+		return nil
+	}
+	log.LogDebugf("Streamer moveInlineToExtents: ino(%v) size(%v)", s.inode, len(data))
+	if _, err = s.doWriteAppend(NewExtentRequest(0, len(data), data, nil), false, storageClass, false); err != nil {
+		log.LogErrorf("Streamer moveInlineToExtents: ino(%v) err(%v)", s.inode, err)
+		s.inline.Lock()
+		s.inline.data, s.inline.dirty = data, true
+		s.inline.Unlock()
+	}
+	return
+}
+
+// flushInlineData stores the dirty inline data in the inode. If the MetaNode
+// refuses it, e.g. the file got extents from another client meanwhile, the
+// data is written to an extent instead.
+func (s *Streamer) flushInlineData() (err error) {
+	s.inline.Lock()
+	if !s.inline.dirty {
+		s.inline.Unlock()
+		return nil
+	}
+	data, storageClass := s.inline.data, s.inline.storageClass
+	size, _ := s.extents.Size()
+	if err = s.client.setInlineData(s.inode, data, uint64(size)); err == nil {
+		s.inline.dirty = false
+		s.inline.Unlock()
+		return nil
+	}
+	s.inline.Unlock()
+	if err != syscall.EPERM && err != syscall.EOPNOTSUPP {
+		log.LogErrorf("Streamer flushInlineData: ino(%v) size(%v) err(%v)", s.inode, size, err)
+		return
+	}
+	log.LogWarnf("Streamer flushInlineData: ino(%v) inline data refused(%v), write to extents", s.inode, err)
+	if err = s.moveInlineToExtents(storageClass, false); err != nil {
+		return
+	}
+	return s.flush()
+}
+
+// readInline serves a read of a file without extents from its inline data,
+// the rest of the file up to its size is a hole.
+func (s *Streamer) readInline(data []byte, offset, size int) (total int, err error) {
+	if err = s.loadInlineData(); err != nil {
+		return
+	}
+	filesize, _ := s.extents.Size()
+	if offset > filesize {
+		return
+	}
+	end := offset + size
+	if end > filesize {
+		end = filesize
+		err = io.EOF
+	}
+	total = end - offset
+	n := 0
+	s.inline.Lock()
+	if offset < len(s.inline.data) {
+		n = copy(data[:total], s.inline.data[offset:])
+	}
+	s.inline.Unlock()
+	for i := n; i < total; i++ {
+		data[i] = 0
+	}
+	return
+}
+
+func (s *Streamer) truncateInline(size int) {
+	s.inline.Lock()
+	if size < len(s.inline.data) {
+		s.inline.data = s.inline.data[:size]
+	}
+	s.inline.Unlock()
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamerInlineData(t *testing.T) {
+	var (
+		stored    = []byte("hello")
+		storedGen = uint64(2)
+		setErr    error
+		loads     int
+	)
+	client := &ExtentClient{
+		getInlineData: func(ino uint64) (uint64, []byte, error) {
+			loads++
+			return storedGen, stored, nil
+		},
+		setInlineData: func(ino uint64, data []byte, size uint64) error {
+			if setErr != nil {
+				return setErr
+			}
+			stored = append([]byte(nil), data...)
+			storedGen++
+			return nil
+		},
+		inlineDataEnabled: func(ino uint64) bool { return true },
+	}
+	s := &Streamer{client: client, inode: 1, extents: NewExtentCache(1), dirtylist: NewDirtyExtentList()}
+	s.extents.update(storedGen, 10, false, nil)
+
+	// without a data wrapper the volume has no inline data threshold
+	require.False(t, s.inlineWritable(0, 5, proto.StorageClass_Replica_HDD, false))
+
+	data := make([]byte, 16)
+	n, err := s.readInline(data, 0, 16)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 10, n)
+	require.Equal(t, append([]byte("hello"), make([]byte, 5)...), data[:n])
+	n, err = s.readInline(data, 3, 4)
+	require.NoError(t, err)
+	require.Equal(t, []byte("lo\x00\x00"), data[:n])
+	require.Equal(t, 1, loads)
+
+	n, err = s.writeInline([]byte("abcd"), 8, 4, false, proto.StorageClass_Replica_HDD, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	size, _ := s.extents.Size()
+	require.Equal(t, 12, size)
+
+	setErr = syscall.EIO
+	require.Error(t, s.flushInlineData())
+	require.True(t, s.inline.dirty)
+	setErr = nil
+	require.NoError(t, s.flushInlineData())
+	require.False(t, s.inline.dirty)
+	require.Equal(t, []byte("hello\x00\x00\x00abcd"), stored)
+
+	s.truncateInline(3)
+	s.extents.SetSize(3, false)
+	n, err = s.readInline(data, 0, 16)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, []byte("hel"), data[:n])
+
+	// a newer generation of the inode reloads the data
+	s.extents.update(storedGen, uint64(len(stored)), false, nil)
+	n, _ = s.readInline(data, 0, 16)
+	require.Equal(t, stored, data[:n])
+	require.Equal(t, 2, loads)
+}
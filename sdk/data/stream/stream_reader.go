@@ -64,6 +64,11 @@ type Streamer struct {
 	aheadReadEnable      bool
 	aheadReadWindow      *AheadReadWindow
 	fullPath             string
+	largeWrite           bool // set by FlagsLargeWrite, skip the tiny extent
+	rcReadAhead          remoteCacheReadAhead
+	inline               inlineData
+	coalesce             coalescedWrite
+	suspendedAt          int64 // unix nanoseconds the session was lost, see suspend
 }
 
 type bcacheKey struct {
@@ -97,7 +102,7 @@ func NewStreamer(client *ExtentClient, inode uint64, openForWrite, isCache bool,
 		err := s.client.forbiddenMigration(s.inode)
 		if err != nil {
 			log.LogWarnf("ino(%v) forbiddenMigration failed err %v", s.inode, err.Error())
-			s.setError()
+			s.suspend(err)
 		}
 	}
 	if client.AheadRead != nil {
@@ -184,6 +189,18 @@ func (s *Streamer) read(data []byte, offset int, size int, storageClass uint32)
 		s.client.readLimiter.Wait(ctx)
 	}
 	s.client.LimitManager.ReadAlloc(ctx, size)
+	if s.hasCoalescedWrites() {
+		s.writeLock.Lock()
+		if err = s.IssueFlushRequest(); err != nil {
+			log.LogErrorf("[read] failed to flush coalesced writes, ino(%v) offset(%v) size(%v) err(%v)", s.inode, offset, size, err)
+			s.writeLock.Unlock()
+			return 0, err
+		}
+		s.writeLock.Unlock()
+	}
+	if s.client.getInlineData != nil && s.extents.Len() == 0 {
+		return s.readInline(data, offset, size)
+	}
 	requests = s.extents.PrepareReadRequests(offset, size, data)
 	for _, req := range requests {
 		if req.ExtentKey == nil {
@@ -291,6 +308,7 @@ func (s *Streamer) read(data []byte, offset int, size int, storageClass uint32)
 					var cacheReadRequests []*CacheReadRequest
 					cacheReadRequests, err = s.prepareCacheRequests(uint64(offset), uint64(size), data, inodeInfo.Generation)
 					if err == nil {
+						s.readAheadRemoteCache(uint64(offset), uint64(size), inodeInfo.Generation)
 						var read int
 						remoteCacheMetric := exporter.NewCounter("readRemoteCache")
 						remoteCacheMetric.AddWithLabels(1, map[string]string{exporter.Vol: s.client.volumeName})
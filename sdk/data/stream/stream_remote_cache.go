@@ -17,8 +17,10 @@ package stream
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/exporter"
 	"github.com/cubefs/cubefs/util/log"
@@ -65,6 +67,59 @@ func (s *Streamer) enableRemoteCacheAutoPrepare() bool {
 	return s.enableRemoteCache() && s.client.RemoteCache.AutoPrepare
 }
 
+// remoteCacheReadAhead tracks the sequential reads of a stream from the remote cache.
+type remoteCacheReadAhead struct {
+	sync.Mutex
+	lastEnd     uint64 // where the last read ended
+	preparedEnd uint64 // where the blocks prepared ahead end
+}
+
+// next returns the range to prepare after a read of [offset, offset+size): the
+// window following the read, once the reads are sequential and less than half
+// of the window prepared before is left.
+func (ra *remoteCacheReadAhead) next(offset, size, window, fileSize uint64) (start, end uint64) {
+	ra.Lock()
+	defer ra.Unlock()
+	sequential := offset == ra.lastEnd
+	ra.lastEnd = offset + size
+	if !sequential {
+		ra.preparedEnd = 0
+		return
+	}
+	readEnd := (offset + size + proto.CACHE_BLOCK_SIZE - 1) / proto.CACHE_BLOCK_SIZE * proto.CACHE_BLOCK_SIZE
+	if ra.preparedEnd > readEnd+window/2 {
+		return
+	}
+	start, end = readEnd, readEnd+window
+	if ra.preparedEnd > start {
+		start = ra.preparedEnd
+	}
+	if end > fileSize {
+		end = fileSize
+	}
+	if start >= end {
+		return 0, 0
+	}
+	ra.preparedEnd = end
+	return
+}
+
+// readAheadRemoteCache has the flashnodes cache the blocks ahead of the
+// sequential reads of the stream, so that the next reads hit.
+func (s *Streamer) readAheadRemoteCache(offset, size, gen uint64) {
+	window := uint64(s.client.RemoteCache.remoteCacheReadAheadMB) * util.MB
+	if window == 0 {
+		return
+	}
+	fileSize, _ := s.extents.Size()
+	start, end := s.rcReadAhead.next(offset, size, window, uint64(fileSize))
+	if start == end {
+		return
+	}
+	ek := proto.ExtentKey{FileOffset: start, Size: uint32(end - start)}
+	s.sendToPrepareRomoteCacheChan(NewPrepareRemoteCacheRequest(s.inode, ek, true, gen))
+}
+
 func (s *Streamer) sendToPrepareRomoteCacheChan(req *PrepareRemoteCacheRequest) {
 	select {
 	case s.client.RemoteCache.PrepareCh <- req:
@@ -86,6 +141,10 @@ func (s *Streamer) prepareRemoteCache(ctx context.Context, ek *proto.ExtentKey,
 			log.LogWarnf("Streamer prepareRemoteCache failed: %v", err)
 			break
 		}
+		if fg.Draining {
+			// the group is removed soon, it serves what it caches but caches nothing more
+			continue
+		}
 		req.CacheRequest.Slot = uint64(slot)<<32 | uint64(ownerSlot)
 		prepareReq := &proto.CachePrepareRequest{
 			CacheRequest: req.CacheRequest,
@@ -135,6 +194,30 @@ func (s *Streamer) readFromRemoteCache(ctx context.Context, offset, size uint64,
 	return total, nil
 }
 
+// evictRemoteCache evicts the blocks of the range overwritten from the flash groups of their slots.
+// The overwrite changes the version of the blocks, but the readers with the inode cached before it
+// still compute the old ones until their cache expires.
+func (s *Streamer) evictRemoteCache(offset, size uint64) {
+	reqs := make(map[*FlashGroup]*proto.CacheEvictRequest)
+	for fixedOff := offset / proto.CACHE_BLOCK_SIZE * proto.CACHE_BLOCK_SIZE; fixedOff < offset+size; fixedOff += proto.CACHE_BLOCK_SIZE {
+		_, fg, _ := s.getFlashGroup(fixedOff)
+		if fg == nil {
+			continue
+		}
+		req, ok := reqs[fg]
+		if !ok {
+			req = &proto.CacheEvictRequest{Volume: s.client.dataWrapper.VolName, Inode: s.inode}
+			reqs[fg] = req
+		}
+		req.Offsets = append(req.Offsets, fixedOff)
+	}
+	for fg, req := range reqs {
+		if err := s.client.RemoteCache.Evict(fg, s.inode, req); err != nil {
+			log.LogWarnf("evictRemoteCache: ino(%v) offset(%v) size(%v) fg(%v) err(%v)", s.inode, offset, size, fg, err)
+		}
+	}
+}
+
 func (s *Streamer) getFlashGroup(fixedFileOffset uint64) (uint32, *FlashGroup, uint32) {
 	slot := proto.ComputeCacheBlockSlot(s.client.dataWrapper.VolName, s.inode, fixedFileOffset)
 	fg, ownerSlot := s.client.RemoteCache.GetFlashGroupBySlot(slot)
@@ -0,0 +1,48 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+package stream
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteCacheReadAhead(t *testing.T) {
+	const (
+		block    = proto.CACHE_BLOCK_SIZE
+		window   = 4 * block
+		fileSize = 10 * block
+	)
+	ra := &remoteCacheReadAhead{}
+	next := func(offset, size uint64) [2]uint64 {
+		start, end := ra.next(offset, size, window, fileSize)
+		return [2]uint64{start, end}
+	}
+	// the first read from the start is sequential
+	require.Equal(t, [2]uint64{block, 5 * block}, next(0, block))
+	// more than half of the window is left
+	require.Equal(t, [2]uint64{}, next(block, block/2))
+	require.Equal(t, [2]uint64{}, next(block+block/2, block/2))
+	// the window slides on from where it was prepared
+	require.Equal(t, [2]uint64{5 * block, 7 * block}, next(2*block, block))
+	// capped by the file size
+	require.Equal(t, [2]uint64{7 * block, fileSize}, next(3*block, 3*block))
+	require.Equal(t, [2]uint64{}, next(6*block, 4*block))
+
+	// a random read resets the window
+	require.Equal(t, [2]uint64{}, next(block, block))
+	require.Equal(t, [2]uint64{3 * block, 7 * block}, next(2*block, block))
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const sessionResumeRetryInterval = time.Second
+
+// A streamer which loses its session with the meta nodes, the forbidden
+// migration lease not taken or renewed or the extent keys of written data not
+// appended, is suspended instead of set in error if the client has a session
+// resume timeout. Writes, truncates and flushes on a suspended streamer first
+// resume the session: the inode is revalidated, the lease taken again and the
+// pending extent keys appended. They wait for it up to the timeout since the
+// session was lost, after which the streamer is set in error as before. The
+// packets in flight to the data nodes are likewise retried up to the timeout
+// rather than up to MaxPacketErrorCount.
+
+// suspend suspends the streamer, or sets it in error without a session resume
+// timeout or if the inode is gone.
+func (s *Streamer) suspend(err error) {
+	if s.client.sessionResumeTimeout <= 0 || err == syscall.ENOENT {
+		s.setError()
+		return
+	}
+	if atomic.CompareAndSwapInt64(&s.suspendedAt, 0, time.Now().UnixNano()) {
+		log.LogWarnf("suspend: ino(%v) session lost, resume within %v, err(%v)", s.inode, s.client.sessionResumeTimeout, err)
+	}
+}
+
+func (s *Streamer) isSuspended() bool {
+	return atomic.LoadInt64(&s.suspendedAt) != 0
+}
+
+// resume resumes the session of a suspended streamer, it retries until the
+// session resume timeout. It is called from the streamer server only.
+func (s *Streamer) resume() (err error) {
+	suspendedAt := atomic.LoadInt64(&s.suspendedAt)
+	if suspendedAt == 0 {
+		return nil
+	}
+	deadline := time.Unix(0, suspendedAt).Add(s.client.sessionResumeTimeout)
+	for {
+		if atomic.LoadInt32(&s.status) >= StreamerError {
+			return errors.New(fmt.Sprintf("resume: stream writer in error status, ino(%v)", s.inode))
+		}
+		if err = s.tryResume(); err == nil {
+			return nil
+		}
+		if err == syscall.ENOENT || time.Now().After(deadline) {
+			log.LogErrorf("resume: ino(%v) session not resumed since %v, err(%v)",
+				s.inode, time.Unix(0, suspendedAt), err)
+			s.setError()
+			atomic.StoreInt64(&s.suspendedAt, 0)
+			return
+		}
+		time.Sleep(sessionResumeRetryInterval)
+	}
+}
+
+// tryResume makes one attempt to resume the session.
+func (s *Streamer) tryResume() (err error) {
+	if !s.isSuspended() {
+		return nil
+	}
+	if s.client.getInodeInfo != nil {
+		if _, err = s.client.getInodeInfo(s.inode); err != nil {
+			log.LogWarnf("tryResume: ino(%v) revalidate inode failed, err(%v)", s.inode, err)
+			return
+		}
+	}
+	if s.openForWrite && s.client.forbiddenMigration != nil {
+		if err = s.client.forbiddenMigration(s.inode); err != nil {
+			log.LogWarnf("tryResume: ino(%v) forbiddenMigration failed, err(%v)", s.inode, err)
+			return
+		}
+	}
+	// the closed handlers with all packets replied, the others are left to
+	// traverse and flush
+	s.dirtylist.RLock()
+	handlers := make([]*ExtentHandler, 0, s.dirtylist.list.Len())
+	for element := s.dirtylist.list.Front(); element != nil; element = element.Next() {
+		handlers = append(handlers, element.Value.(*ExtentHandler))
+	}
+	s.dirtylist.RUnlock()
+	for _, eh := range handlers {
+		if eh.getStatus() < ExtentStatusClosed || atomic.LoadInt32(&eh.inflight) > 0 {
+			continue
+		}
+		if err = eh.appendExtentKey(); err != nil {
+			log.LogWarnf("tryResume: appendExtentKey failed, eh(%v) err(%v)", eh, err)
+			return
+		}
+	}
+	suspendedAt := atomic.SwapInt64(&s.suspendedAt, 0)
+	log.LogWarnf("tryResume: ino(%v) session resumed after %v", s.inode, time.Since(time.Unix(0, suspendedAt)))
+	return nil
+}
+
+// packetWithinResume returns whether a packet failing since errSince is still
+// retried past MaxPacketErrorCount.
+func (client *ExtentClient) packetWithinResume(errSince time.Time) bool {
+	return client.sessionResumeTimeout > 0 && time.Since(errSince) < client.sessionResumeTimeout
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package stream
+
+import (
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamerSessionResume(t *testing.T) {
+	client := &ExtentClient{}
+	newStreamer := func() *Streamer {
+		return &Streamer{client: client, inode: 1, extents: NewExtentCache(1), dirtylist: NewDirtyExtentList(), openForWrite: true}
+	}
+
+	// disabled by default
+	s := newStreamer()
+	s.suspend(syscall.EIO)
+	require.False(t, s.isSuspended())
+	require.Equal(t, StreamerError, atomic.LoadInt32(&s.status))
+
+	client.sessionResumeTimeout = time.Minute
+	s = newStreamer()
+	s.suspend(syscall.ENOENT)
+	require.Equal(t, StreamerError, atomic.LoadInt32(&s.status), "the inode is gone")
+
+	var inodeErr, leaseErr error
+	var leases int
+	client.getInodeInfo = func(ino uint64) (*proto.InodeInfo, error) { return &proto.InodeInfo{Inode: ino}, inodeErr }
+	client.forbiddenMigration = func(ino uint64) error {
+		leases++
+		return leaseErr
+	}
+
+	s = newStreamer()
+	require.NoError(t, s.resume(), "not suspended")
+	s.suspend(syscall.EIO)
+	require.True(t, s.isSuspended())
+	require.Equal(t, StreamerNormal, atomic.LoadInt32(&s.status))
+	suspendedAt := atomic.LoadInt64(&s.suspendedAt)
+	s.suspend(syscall.EIO)
+	require.Equal(t, suspendedAt, atomic.LoadInt64(&s.suspendedAt), "suspended since the first failure")
+
+	inodeErr = syscall.EIO
+	require.Error(t, s.tryResume())
+	require.Zero(t, leases)
+	inodeErr, leaseErr = nil, syscall.EIO
+	require.Error(t, s.tryResume())
+	require.Equal(t, 1, leases)
+	require.True(t, s.isSuspended())
+
+	leaseErr = nil
+	require.NoError(t, s.resume())
+	require.False(t, s.isSuspended())
+	require.Equal(t, StreamerNormal, atomic.LoadInt32(&s.status))
+	require.Equal(t, 2, leases)
+
+	// past the timeout
+	leaseErr = syscall.EIO
+	s.suspend(syscall.EIO)
+	atomic.StoreInt64(&s.suspendedAt, time.Now().Add(-2*time.Minute).UnixNano())
+	require.Error(t, s.resume())
+	require.Equal(t, StreamerError, atomic.LoadInt32(&s.status))
+	require.False(t, s.isSuspended())
+
+	// deleted meanwhile
+	s = newStreamer()
+	s.suspend(syscall.EIO)
+	inodeErr = syscall.ENOENT
+	require.Equal(t, syscall.ENOENT, s.resume())
+	require.Equal(t, StreamerError, atomic.LoadInt32(&s.status))
+
+	require.True(t, client.packetWithinResume(time.Now().Add(-time.Second)))
+	require.False(t, client.packetWithinResume(time.Now().Add(-2*time.Minute)))
+	client.sessionResumeTimeout = 0
+	require.False(t, client.packetWithinResume(time.Now()))
+}
@@ -23,7 +23,6 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/cubefs/cubefs/datanode/storage"
 	"github.com/cubefs/cubefs/proto"
 	"github.com/cubefs/cubefs/sdk/data/wrapper"
 	"github.com/cubefs/cubefs/util"
@@ -191,7 +190,7 @@ func (s *Streamer) IssueEvictRequest() error {
 func (s *Streamer) GetStoreMod(offset int, size int) (storeMode int) {
 	// Small files are usually written in a single write, so use tiny extent
 	// store only for the first write operation.
-	if offset+size > s.tinySizeLimit() {
+	if s.largeWrite || offset+size > s.tinySizeLimit() {
 		storeMode = proto.NormalExtentType
 	} else {
 		storeMode = proto.TinyExtentType
@@ -199,6 +198,20 @@ func (s *Streamer) GetStoreMod(offset int, size int) (storeMode int) {
 	return
 }
 
+// smallFilePackable tells whether a tiny write is packed into a container
+// extent, which is the case for the files under the small file threshold of
+// the volume.
+func (s *Streamer) smallFilePackable(offset, size int, isMigration bool) bool {
+	if s.client == nil || s.client.dataWrapper == nil || s.client.smallFilePackEnabled == nil {
+		return false
+	}
+	threshold := s.client.dataWrapper.SmallFileThreshold()
+	if threshold <= 0 || offset+size > threshold || isMigration || s.verSeq != 0 {
+		return false
+	}
+	return s.client.smallFilePackEnabled()
+}
+
 func (s *Streamer) server() {
 	t := time.NewTicker(2 * time.Second)
 	defer t.Stop()
@@ -212,6 +225,10 @@ func (s *Streamer) server() {
 			s.handleRequest(request)
 			s.idle = 0
 			s.traversed = 0
+		case <-s.coalesce.expire:
+			if err := s.flushCoalesced(); err != nil {
+				log.LogWarnf("server: flush coalesced writes failed, ino(%v) err(%v)", s.inode, err)
+			}
 		case <-s.done:
 			s.abort()
 			log.LogDebugf("done server: evict, streamer(%v)", s)
@@ -221,6 +238,9 @@ func (s *Streamer) server() {
 				log.LogDebugf("server: rdonly stream no need to start server routine. ino %d", s.inode)
 				return
 			}
+			if s.isSuspended() {
+				s.tryResume()
+			}
 			s.traverse()
 			s.client.streamerLock.Lock()
 			if atomic.LoadInt32(&s.refcnt) <= 0 {
@@ -251,7 +271,7 @@ func (s *Streamer) server() {
 				err := s.client.renewalForbiddenMigration(s.inode)
 				if err != nil {
 					log.LogWarnf("ino(%v) renewalForbiddenMigration failed err %v", s.inode, err.Error())
-					s.setError()
+					s.suspend(err)
 				}
 			}
 		}
@@ -332,12 +352,18 @@ func (s *Streamer) write(data []byte, offset, size, flags int, checkFunc func()
 		direct     bool
 		retryTimes int8
 	)
+	if err = s.resume(); err != nil {
+		return
+	}
 	if atomic.LoadInt32(&s.status) >= StreamerError {
 		return 0, errors.New(fmt.Sprintf("IssueWriteRequest: stream writer in error status, ino(%v)", s.inode))
 	}
 	if flags&proto.FlagsSyncWrite != 0 {
 		direct = true
 	}
+	if flags&proto.FlagsLargeWrite != 0 {
+		s.largeWrite = true
+	}
 begin:
 	if flags&proto.FlagsAppend != 0 {
 		filesize, _ := s.extents.Size()
@@ -351,6 +377,19 @@ begin:
 	s.client.writeLimiter.Wait(ctx)
 	s.client.LimitManager.WriteAlloc(ctx, size)
 
+	if s.inlineWritable(offset, size, storageClass, isMigration) {
+		return s.writeInline(data, offset, size, direct, storageClass, checkFunc)
+	}
+	if err = s.moveInlineToExtents(storageClass, isMigration); err != nil {
+		return
+	}
+	if s.coalescable(offset, size, direct, storageClass, isMigration) {
+		return s.writeCoalesced(data, offset, size, storageClass, checkFunc)
+	}
+	if err = s.flushCoalesced(); err != nil {
+		return
+	}
+
 	requests := s.extents.PrepareWriteRequests(offset, size, data)
 	log.LogDebugf("Streamer write: ino(%v) prepared requests(%v)", s.inode, requests)
 
@@ -386,7 +425,8 @@ begin:
 			}
 			log.LogDebugf("action[streamer.write] inode [%v] latest seq [%v] extentkey seq [%v]  info [%v] before compare seq",
 				s.inode, s.verSeq, req.ExtentKey.GetSeq(), req.ExtentKey)
-			if req.ExtentKey.GetSeq() == s.verSeq {
+			// the extents shared by the forks are overwritten by append as the ones of the snapshots
+			if req.ExtentKey.GetSeq() == s.verSeq && !s.client.dataWrapper.IsForkShared(req.ExtentKey.PartitionId) {
 				writeSize, err = s.doOverwrite(req, direct, storageClass)
 				if err == proto.ErrCodeVersionOp {
 					log.LogDebugf("action[streamer.write] write need version update")
@@ -485,7 +525,7 @@ func (s *Streamer) doDirectWriteByAppend(req *ExtentRequest, direct bool, op uin
 	log.LogDebugf("action[doDirectWriteByAppend] inode %v  data process", s.inode)
 
 	addr := dp.LeaderAddr
-	if storage.IsTinyExtent(req.ExtentKey.ExtentId) {
+	if proto.IsTinyExtent(req.ExtentKey.ExtentId) {
 		addr = dp.Hosts[0]
 		reqPacket = NewWriteTinyDirectly(s.inode, req.ExtentKey.PartitionId, req.FileOffset, dp)
 	} else {
@@ -512,7 +552,7 @@ func (s *Streamer) doDirectWriteByAppend(req *ExtentRequest, direct bool, op uin
 		if direct {
 			reqPacket.Opcode = op
 		}
-		if req.ExtentKey.ExtentId <= storage.TinyExtentCount {
+		if req.ExtentKey.ExtentId <= proto.TinyExtentCount {
 			reqPacket.ExtentType = proto.TinyExtentType
 		}
 
@@ -635,7 +675,8 @@ func (s *Streamer) doOverwrite(req *ExtentRequest, direct bool, storageClass uin
 	// update generation first, if fails, there is no need to update
 	// remote cache.Even if the random write fails, it won't cause the
 	// remote cache to fail to cache the latest data.
-	if s.enableRemoteCache() {
+	remoteCache := s.enableRemoteCache()
+	if remoteCache {
 		err = s.client.metaWrapper.UpdateInodeMeta(s.inode)
 		if err != nil {
 			return
@@ -645,6 +686,14 @@ func (s *Streamer) doOverwrite(req *ExtentRequest, direct bool, storageClass uin
 
 	offset := req.FileOffset
 	size := req.Size
+	if remoteCache {
+		// even if the write failed in the middle
+		defer func() {
+			if total > 0 {
+				s.evictRemoteCache(uint64(offset), uint64(total))
+			}
+		}()
+	}
 
 	// the extent key needs to be updated because when preparing the requests,
 	// the obtained extent key could be a local key which can be inconsistent with the remote key.
@@ -751,7 +800,9 @@ func (s *Streamer) doOverwrite(req *ExtentRequest, direct bool, storageClass uin
 func (s *Streamer) tryInitExtentHandlerByLastEk(offset, size int, isMigration bool) (isLastEkVerNotEqual bool) {
 	storeMode := s.GetStoreMod(offset, size)
 	getEndEkFunc := func() *proto.ExtentKey {
-		if ek := s.extents.GetEndForAppendWrite(uint64(offset), s.verSeq, false); ek != nil && !storage.IsTinyExtent(ek.ExtentId) {
+		// the source of a fork appends to the extents of its files itself
+		if ek := s.extents.GetEndForAppendWrite(uint64(offset), s.verSeq, false); ek != nil && !proto.IsTinyExtent(ek.ExtentId) &&
+			!s.client.dataWrapper.IsForkPartition(ek.PartitionId) {
 			return ek
 		}
 		return nil
@@ -934,6 +985,12 @@ func (s *Streamer) doWriteAppendEx(data []byte, offset, size int, direct bool, r
 }
 
 func (s *Streamer) flush() (err error) {
+	if err = s.resume(); err != nil {
+		return
+	}
+	if err = s.flushCoalesced(); err != nil {
+		return
+	}
 	for {
 		element := s.dirtylist.Get()
 		if element == nil {
@@ -958,7 +1015,7 @@ func (s *Streamer) flush() (err error) {
 		}
 		log.LogDebugf("Streamer flush end: eh(%v)", eh)
 	}
-	return
+	return s.flushInlineData()
 }
 
 func (s *Streamer) traverse() (err error) {
@@ -983,9 +1040,10 @@ func (s *Streamer) traverse() (err error) {
 			err = eh.appendExtentKey()
 			if err != nil {
 				log.LogWarnf("Streamer traverse abort: appendExtentKey failed, eh(%v) err(%v)", eh, err)
-				// set the streamer to error status to avoid further writes
+				// suspend the streamer to hold further writes until the extent
+				// keys are appended
 				if err == syscall.EIO {
-					eh.stream.setError()
+					eh.stream.suspend(err)
 				}
 				return
 			}
@@ -1002,6 +1060,11 @@ func (s *Streamer) traverse() (err error) {
 		}
 		log.LogDebugf("Streamer traverse end: eh(%v)", eh)
 	}
+	if s.traversed >= streamWriterFlushPeriod {
+		if err = s.flushInlineData(); err != nil {
+			log.LogWarnf("Streamer traverse flush inline data: ino(%v) err(%v)", s.inode, err)
+		}
+	}
 	return
 }
 
@@ -1013,6 +1076,10 @@ func (s *Streamer) closeOpenHandler() (err error) {
 		log.LogDebugf("closeOpenHandler: close success, stream(%v)", s)
 	}()
 
+	if err = s.flushCoalesced(); err != nil {
+		return
+	}
+
 	handler := s.handler
 	if handler != nil {
 		log.LogDebugf("closeOpenHandler: flush open handler now, eh(%v)", handler)
@@ -1087,6 +1154,9 @@ func (s *Streamer) abort() {
 }
 
 func (s *Streamer) truncate(size int, fullPath string) error {
+	if err := s.resume(); err != nil {
+		return err
+	}
 	if atomic.LoadInt32(&s.status) >= StreamerError {
 		return errors.New(fmt.Sprintf("IssueWriteRequest: stream writer in error status, ino(%v)", s.inode))
 	}
@@ -1099,6 +1169,7 @@ func (s *Streamer) truncate(size int, fullPath string) error {
 	if err != nil {
 		return err
 	}
+	s.truncateInline(size)
 
 	oldsize, _ := s.extents.Size()
 	if oldsize <= size {
@@ -21,6 +21,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
@@ -70,6 +71,9 @@ type Wrapper struct {
 	dpSelectorChanged      bool
 	dpSelectorName         string
 	dpSelectorParm         string
+	inlineDataThreshold    int64
+	smallFileThreshold     int64
+	strictConsistency      int32
 	mc                     *masterSDK.MasterClient
 	stopOnce               sync.Once
 	stopC                  chan struct{}
@@ -91,8 +95,18 @@ type Wrapper struct {
 	volAllowedStorageClass []uint32
 	volStatByClass         map[uint32]*proto.StatOfStorageClass
 	HostsDelay             sync.Map
+	zoneName               atomic.Value // string
+	ZoneCosts              ZoneCosts
 
 	readFailedHosts map[uint64]map[string]time.Time
+	// the fencing token sent with the writes, 0 for none
+	fenceToken uint64
+
+	// the fork sources of the volume and their partitions, and whether the volume
+	// is forked by others, the extents shared by the forks are overwritten by append
+	forkSources    []string
+	forkPartitions map[uint64]struct{}
+	forkSource     bool
 }
 
 // NewDataPartitionWrapper returns a new data partition wrapper.
@@ -173,6 +187,33 @@ func (w *Wrapper) FollowerRead() bool {
 	return w.followerRead
 }
 
+// IsStrictConsistency tells whether the lookups and the listings cached must be
+// revalidated with the versions of the directories, see proto.ConsistencyModeStrict.
+func (w *Wrapper) IsStrictConsistency() bool {
+	return atomic.LoadInt32(&w.strictConsistency) == 1
+}
+
+// setConsistencyMode returns whether the mode was strict before.
+func (w *Wrapper) setConsistencyMode(mode string) (wasStrict bool) {
+	var strict int32
+	if mode == proto.ConsistencyModeStrict {
+		strict = 1
+	}
+	return atomic.SwapInt32(&w.strictConsistency, strict) == 1
+}
+
+// InlineDataThreshold returns the size up to which a file is stored in its
+// inode, 0 if inline data is disabled.
+func (w *Wrapper) InlineDataThreshold() int {
+	return int(atomic.LoadInt64(&w.inlineDataThreshold))
+}
+
+// SmallFileThreshold returns the size up to which a file is packed into the
+// container extents, 0 if small file packing is disabled.
+func (w *Wrapper) SmallFileThreshold() int {
+	return int(atomic.LoadInt64(&w.smallFileThreshold))
+}
+
 func (w *Wrapper) SetMaximallyRead(maximallyRead bool) {
 	w.maximallyReadClientCfg = maximallyRead
 	w.maximallyRead = w.maximallyReadClientCfg || w.maximallyRead
@@ -191,6 +232,16 @@ func (w *Wrapper) InnerReq() bool {
 	return w.innerReq
 }
 
+// SetFenceToken sets the fencing token of the writes to the datanodes, which
+// reject them once master fences the volume with a newer token.
+func (w *Wrapper) SetFenceToken(token uint64) {
+	atomic.StoreUint64(&w.fenceToken, token)
+}
+
+func (w *Wrapper) FenceToken() uint64 {
+	return atomic.LoadUint64(&w.fenceToken)
+}
+
 func (w *Wrapper) TryGetPartition(index uint64) (partition *DataPartition, ok bool) {
 	w.Lock.RLock()
 	defer w.Lock.RUnlock()
@@ -244,8 +295,12 @@ func (w *Wrapper) GetSimpleVolView() (err error) {
 	w.dpSelectorParm = view.DpSelectorParm
 	w.volType = view.VolType
 	w.EnablePosixAcl = view.EnablePosixAcl
+	atomic.StoreInt64(&w.inlineDataThreshold, view.InlineDataThreshold)
+	atomic.StoreInt64(&w.smallFileThreshold, view.SmallFileThreshold)
+	w.setConsistencyMode(view.ConsistencyMode)
 
 	w.UpdateUidsView(view)
+	w.setForkView(view)
 
 	log.LogDebugf("GetSimpleVolView: get volume simple info: ID(%v) name(%v) owner(%v) status(%v) capacity(%v) "+
 		"metaReplicas(%v) dataReplicas(%v) mpCnt(%v) dpCnt(%v) followerRead(%v) createTime(%v) dpSelectorName(%v) "+
@@ -282,6 +337,7 @@ func (w *Wrapper) update(clientInfo SimpleClientInfo) {
 	ticker := time.NewTicker(time.Minute)
 	taskFunc := func() {
 		w.updateSimpleVolView(clientInfo)
+		w.updateZoneCost()
 		w.updateDataPartition(false)
 		w.updateDataNodeStatus()
 		w.CheckPermission()
@@ -387,6 +443,20 @@ func (w *Wrapper) updateSimpleVolView(clientInfo SimpleClientInfo) (err error) {
 		w.maximallyRead = view.MaximallyRead
 	}
 
+	if old := atomic.SwapInt64(&w.inlineDataThreshold, view.InlineDataThreshold); old != view.InlineDataThreshold {
+		log.LogInfof("UpdateSimpleVolView: update inlineDataThreshold from old(%v) to new(%v)",
+			old, view.InlineDataThreshold)
+	}
+
+	if old := atomic.SwapInt64(&w.smallFileThreshold, view.SmallFileThreshold); old != view.SmallFileThreshold {
+		log.LogInfof("UpdateSimpleVolView: update smallFileThreshold from old(%v) to new(%v)",
+			old, view.SmallFileThreshold)
+	}
+
+	if old := w.setConsistencyMode(view.ConsistencyMode); old != w.IsStrictConsistency() {
+		log.LogInfof("UpdateSimpleVolView: update consistencyMode to %v", view.ConsistencyMode)
+	}
+
 	if w.dpSelectorName != view.DpSelectorName || w.dpSelectorParm != view.DpSelectorParm {
 		log.LogDebugf("UpdateSimpleVolView: update dpSelector from old(%v %v) to new(%v %v)",
 			w.dpSelectorName, w.dpSelectorParm, view.DpSelectorName, view.DpSelectorParm)
@@ -396,10 +466,76 @@ func (w *Wrapper) updateSimpleVolView(clientInfo SimpleClientInfo) (err error) {
 		w.dpSelectorChanged = true
 		w.Lock.Unlock()
 	}
+	w.setForkView(view)
 	clientInfo.UpdateRemoteCacheConfig(view)
 	return nil
 }
 
+// setForkView keeps the fork sources of the volume and whether it is forked by others.
+func (w *Wrapper) setForkView(view *proto.SimpleVolView) {
+	forkSource := len(view.ForkTargets) > 0
+	w.Lock.Lock()
+	defer w.Lock.Unlock()
+	if w.forkSource != forkSource || len(w.forkSources) != len(view.ForkSources) {
+		log.LogWarnf("setForkView: volume(%v) fork sources(%v) forked by(%v)", w.VolName, view.ForkSources, view.ForkTargets)
+	}
+	w.forkSources = view.ForkSources
+	w.forkSource = forkSource
+}
+
+// updateForkPartitions adds the partitions of the fork sources of the volume,
+// which the forked files are read from but are never selected for the writes.
+func (w *Wrapper) updateForkPartitions() {
+	w.Lock.RLock()
+	forked := len(w.forkSources) > 0
+	w.Lock.RUnlock()
+	if !forked {
+		return
+	}
+	dpv, err := w.mc.ClientAPI().GetForkPartitions(w.VolName)
+	if err != nil {
+		log.LogWarnf("updateForkPartitions: get fork partitions fail: volume(%v) err(%v)", w.VolName, err)
+		return
+	}
+	ids := make(map[uint64]struct{}, len(dpv.ForkPartitions))
+	for _, partition := range dpv.ForkPartitions {
+		if partition == nil {
+			continue
+		}
+		dp := &DataPartition{DataPartitionResponse: *partition, ClientWrapper: w}
+		if w.followerRead && w.nearRead {
+			dp.NearHosts = w.sortHostsByDistance(dp.Hosts)
+		}
+		w.replaceOrInsertPartition(dp)
+		ids[dp.PartitionID] = struct{}{}
+	}
+	w.Lock.Lock()
+	w.forkPartitions = ids
+	w.Lock.Unlock()
+	log.LogInfof("updateForkPartitions: volume(%v) fork partitions(%v)", w.VolName, len(ids))
+}
+
+// IsForkPartition reports whether the partition is of a fork source of the volume.
+func (w *Wrapper) IsForkPartition(partitionID uint64) bool {
+	w.Lock.RLock()
+	defer w.Lock.RUnlock()
+	_, ok := w.forkPartitions[partitionID]
+	return ok
+}
+
+// IsForkShared reports whether the extents in the partition may be shared by
+// the forks, of a fork source or of a volume forked by others. They are never
+// overwritten in place.
+func (w *Wrapper) IsForkShared(partitionID uint64) bool {
+	w.Lock.RLock()
+	defer w.Lock.RUnlock()
+	if w.forkSource {
+		return true
+	}
+	_, ok := w.forkPartitions[partitionID]
+	return ok
+}
+
 func (w *Wrapper) updateDataPartitionByRsp(forceUpdate bool, refreshPolicy RefreshDpPolicy, DataPartitions []*proto.DataPartitionResponse) (err error) {
 	convert := func(response *proto.DataPartitionResponse) *DataPartition {
 		return &DataPartition{
@@ -495,7 +631,9 @@ func (w *Wrapper) updateDataPartition(isInit bool) (err error) {
 	w.volStatByClass = m
 	w.Lock.Unlock()
 
-	return w.updateDataPartitionByRsp(forceUpdate, UpdateDpPolicy, dpv.DataPartitions)
+	err = w.updateDataPartitionByRsp(forceUpdate, UpdateDpPolicy, dpv.DataPartitions)
+	w.updateForkPartitions()
+	return err
 }
 
 func (w *Wrapper) CanWriteByClass(class uint32) bool {
@@ -567,6 +705,7 @@ func (w *Wrapper) replaceOrInsertPartition(dp *DataPartition) {
 		old.Hosts = dp.Hosts
 		old.IsDiscard = dp.IsDiscard
 		old.NearHosts = dp.NearHosts
+		old.ReadReplicas = dp.ReadReplicas
 
 		dp.Metrics = old.Metrics
 	} else {
@@ -682,6 +821,29 @@ func (w *Wrapper) NearRead() bool {
 	return w.nearRead
 }
 
+// SetZoneName sets the zone of the client, to read from the cheapest hosts by
+// the costs between the zones set on master.
+func (w *Wrapper) SetZoneName(zone string) {
+	w.zoneName.Store(zone)
+	log.LogInfof("SetZoneName: set zoneName to %v", zone)
+	w.updateZoneCost()
+}
+
+func (w *Wrapper) updateZoneCost() (err error) {
+	zone, _ := w.zoneName.Load().(string)
+	if zone == "" {
+		return
+	}
+	var view *proto.ZoneCostView
+	if view, err = w.mc.ClientAPI().GetZoneCost(zone); err != nil {
+		log.LogWarnf("updateZoneCost: get zone cost fail: zone(%v) err(%v)", zone, err)
+		return
+	}
+	w.ZoneCosts.Update(view)
+	log.LogInfof("updateZoneCost: zone(%v) costs(%v) hosts(%v)", zone, view.Costs, len(view.HostZones))
+	return
+}
+
 // Sort hosts by distance form local
 func (w *Wrapper) sortHostsByDistance(srcHosts []string) []string {
 	hosts := make([]string, len(srcHosts))
@@ -689,7 +851,7 @@ func (w *Wrapper) sortHostsByDistance(srcHosts []string) []string {
 
 	for i := 0; i < len(hosts); i++ {
 		for j := i + 1; j < len(hosts); j++ {
-			if distanceFromLocal(hosts[i]) > distanceFromLocal(hosts[j]) {
+			if DistanceFromLocal(hosts[i]) > DistanceFromLocal(hosts[j]) {
 				hosts[i], hosts[j] = hosts[j], hosts[i]
 			}
 		}
@@ -697,7 +859,8 @@ func (w *Wrapper) sortHostsByDistance(srcHosts []string) []string {
 	return hosts
 }
 
-func distanceFromLocal(b string) int {
+// DistanceFromLocal returns the network distance from the client to b.
+func DistanceFromLocal(b string) int {
 	remote := strings.Split(b, ":")[0]
 
 	return iputil.GetDistance(net.ParseIP(LocalIP), net.ParseIP(remote))
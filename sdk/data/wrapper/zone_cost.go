@@ -0,0 +1,109 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package wrapper
+
+import (
+	"sync/atomic"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/exporter"
+)
+
+const (
+	metricCrossZoneReadBytes      = "crossZoneReadBytes"
+	metricCrossZoneReadBytesSaved = "crossZoneReadBytesSaved"
+)
+
+// ZoneCosts is the cost of the traffic from the zone of the client to the
+// zones of the nodes, set on master. Of the hosts a read policy finds equally
+// good, the reads go to the cheapest ones. A nil ZoneCosts has no cost.
+type ZoneCosts struct {
+	view atomic.Value // *proto.ZoneCostView
+}
+
+func (zc *ZoneCosts) load() *proto.ZoneCostView {
+	if zc == nil {
+		return nil
+	}
+	view, _ := zc.view.Load().(*proto.ZoneCostView)
+	return view
+}
+
+func (zc *ZoneCosts) Update(view *proto.ZoneCostView) {
+	zc.view.Store(view)
+}
+
+// Enabled tells whether there is a cost to save.
+func (zc *ZoneCosts) Enabled() bool {
+	view := zc.load()
+	return view != nil && len(view.Costs) > 0
+}
+
+func (zc *ZoneCosts) HostCost(addr string) float64 {
+	view := zc.load()
+	if view == nil {
+		return 0
+	}
+	return view.HostCost(addr)
+}
+
+// Cheapest returns the hosts of the lowest cost, in their order.
+func (zc *ZoneCosts) Cheapest(hosts []string) []string {
+	view := zc.load()
+	if view == nil || len(view.Costs) == 0 || len(hosts) < 2 {
+		return hosts
+	}
+	cheapest := make([]string, 0, len(hosts))
+	var minCost float64
+	for _, host := range hosts {
+		cost := view.HostCost(host)
+		if len(cheapest) == 0 || cost < minCost {
+			minCost = cost
+			cheapest = append(cheapest[:0], host)
+		} else if cost == minCost {
+			cheapest = append(cheapest, host)
+		}
+	}
+	return cheapest
+}
+
+// Select picks a host of the cheapest ones round robin by epoch. costBlind is
+// the host picked the same way regardless of the costs, to count the bytes
+// saved.
+func (zc *ZoneCosts) Select(hosts []string, epoch uint64) (host, costBlind string) {
+	if len(hosts) == 0 {
+		return
+	}
+	costBlind = hosts[epoch%uint64(len(hosts))]
+	cheapest := zc.Cheapest(hosts)
+	host = cheapest[epoch%uint64(len(cheapest))]
+	return
+}
+
+// AddReadBytes counts the bytes read from addr in another zone, and the bytes
+// read from addr cheaper than costBlind, which would have been read otherwise.
+func (zc *ZoneCosts) AddReadBytes(volume, addr, costBlind string, n int) {
+	view := zc.load()
+	if view == nil || n <= 0 {
+		return
+	}
+	labels := map[string]string{exporter.Vol: volume}
+	if view.IsCrossZone(addr) {
+		exporter.NewCounter(metricCrossZoneReadBytes).AddWithLabels(int64(n), labels)
+	}
+	if costBlind != "" && costBlind != addr && view.HostCost(addr) < view.HostCost(costBlind) {
+		exporter.NewCounter(metricCrossZoneReadBytesSaved).AddWithLabels(int64(n), labels)
+	}
+}
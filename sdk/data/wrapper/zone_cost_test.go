@@ -0,0 +1,59 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneCostsSelect(t *testing.T) {
+	hosts := []string{"a:1", "b:1", "c:1", "d:1"}
+
+	var nilCosts *ZoneCosts
+	host, costBlind := nilCosts.Select(hosts, 1)
+	require.Equal(t, "b:1", host)
+	require.Equal(t, "b:1", costBlind)
+
+	zc := &ZoneCosts{}
+	zc.Update(&proto.ZoneCostView{
+		Zone:      "z1",
+		Costs:     map[string]float64{"z2": 1, "z3": 2},
+		HostZones: map[string]string{"a:1": "z3", "b:1": "z2", "c:1": "z3", "d:1": "z2"},
+	})
+	require.True(t, zc.Enabled())
+	require.Equal(t, []string{"b:1", "d:1"}, zc.Cheapest(hosts))
+	for epoch := uint64(0); epoch < 4; epoch++ {
+		host, costBlind = zc.Select(hosts, epoch)
+		require.Equal(t, hosts[epoch%4], costBlind)
+		require.Equal(t, []string{"b:1", "d:1"}[epoch%2], host)
+	}
+
+	// a host in the zone of the client or in an unknown zone is free
+	zc.Update(&proto.ZoneCostView{
+		Zone:      "z1",
+		Costs:     map[string]float64{"z2": 1},
+		HostZones: map[string]string{"a:1": "z2", "b:1": "z1"},
+	})
+	require.Equal(t, []string{"b:1", "c:1", "d:1"}, zc.Cheapest(hosts))
+	require.Equal(t, 1.0, zc.HostCost("a:1"))
+	require.Equal(t, 0.0, zc.HostCost("c:1"))
+
+	zc.Update(&proto.ZoneCostView{Zone: "z1"})
+	require.False(t, zc.Enabled())
+	require.Equal(t, hosts, zc.Cheapest(hosts))
+}
@@ -15,6 +15,8 @@
 package httpclient
 
 import (
+	"strconv"
+
 	"github.com/cubefs/cubefs/proto"
 )
 
@@ -25,6 +27,8 @@ type FlashNode interface {
 	StatAll() (proto.FlashNodeStat, error)
 	InactiveDisk(dataPath string) error
 	SlotStat() (proto.FlashNodeSlotStat, error)
+	Keys(volume, prefix string, hot bool, limit int) (proto.FlashNodeCacheKeys, error)
+	Purge(volume, prefix string) (proto.FlashNodeCachePurge, error)
 }
 
 type flashNode struct {
@@ -65,3 +69,21 @@ func (f *flashNode) SlotStat() (st proto.FlashNodeSlotStat, err error) {
 	err = f.client.serveWith(&st, newRequest(get, "/slotStat"))
 	return
 }
+
+func (f *flashNode) Keys(volume, prefix string, hot bool, limit int) (keys proto.FlashNodeCacheKeys, err error) {
+	r := newRequest(get, "/keys")
+	r.params.Add("volume", volume)
+	r.params.Add("prefix", prefix)
+	r.params.Add("hot", strconv.FormatBool(hot))
+	r.params.Add("limit", strconv.Itoa(limit))
+	err = f.client.serveWith(&keys, r)
+	return
+}
+
+func (f *flashNode) Purge(volume, prefix string) (purged proto.FlashNodeCachePurge, err error) {
+	r := newRequest(post, "/purge")
+	r.params.Add("volume", volume)
+	r.params.Add("prefix", prefix)
+	err = f.client.serveWith(&purged, r)
+	return
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
@@ -63,14 +64,26 @@ func (api *AdminAPI) GetCluster(volStorageClass bool) (cv *proto.ClusterView, er
 }
 
 func (api *AdminAPI) GetClusterDataNodes() (nodes []proto.NodeView, err error) {
+	return api.GetClusterDataNodesWithOption(ListOption{})
+}
+
+// GetClusterDataNodesWithOption lists the data nodes by the id, in the zone and
+// of the status, active or inactive, of the option.
+func (api *AdminAPI) GetClusterDataNodesWithOption(opt ListOption) (nodes []proto.NodeView, err error) {
 	nodes = []proto.NodeView{}
-	err = api.mc.requestWith(&nodes, newRequest(get, proto.AdminGetClusterDataNodes).Header(api.h))
+	err = api.mc.requestWith(&nodes, newRequest(get, proto.AdminGetClusterDataNodes).Header(api.h).listOption(opt))
 	return
 }
 
 func (api *AdminAPI) GetClusterMetaNodes() (nodes []proto.NodeView, err error) {
+	return api.GetClusterMetaNodesWithOption(ListOption{})
+}
+
+// GetClusterMetaNodesWithOption lists the meta nodes by the id, in the zone and
+// of the status, active or inactive, of the option.
+func (api *AdminAPI) GetClusterMetaNodesWithOption(opt ListOption) (nodes []proto.NodeView, err error) {
 	nodes = []proto.NodeView{}
-	err = api.mc.requestWith(&nodes, newRequest(get, proto.AdminGetClusterMetaNodes).Header(api.h))
+	err = api.mc.requestWith(&nodes, newRequest(get, proto.AdminGetClusterMetaNodes).Header(api.h).listOption(opt))
 	return
 }
 
@@ -92,6 +105,59 @@ func (api *AdminAPI) GetClusterStat() (cs *proto.ClusterStatInfo, err error) {
 	return
 }
 
+func (api *AdminAPI) GetClusterFeatures() (matrix *proto.ClusterFeatureMatrix, err error) {
+	matrix = &proto.ClusterFeatureMatrix{}
+	err = api.mc.requestWith(matrix, newRequest(get, proto.AdminClusterFeatures).Header(api.h))
+	return
+}
+
+// GetClusterClockSkew returns the clock skew of the nodes measured by the master.
+func (api *AdminAPI) GetClusterClockSkew() (view *proto.ClockSkewView, err error) {
+	view = &proto.ClockSkewView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.AdminClusterClockSkew).Header(api.h))
+	return
+}
+
+// GetClusterEvents returns the cluster events after the id of the types, or of
+// all types if empty, waiting up to timeoutSec for one.
+func (api *AdminAPI) GetClusterEvents(after int64, types []string, timeoutSec int) (view *proto.ClusterEventsView, err error) {
+	view = &proto.ClusterEventsView{}
+	request := newRequest(get, proto.AdminClusterEvents).Header(api.h).
+		addParamAny("after", after).addParamAny("timeoutSec", timeoutSec)
+	if len(types) > 0 {
+		request.addParam("types", strings.Join(types, ","))
+	}
+	if timeoutSec > 0 {
+		request.NoTimeout()
+	}
+	err = api.mc.requestWith(view, request)
+	return
+}
+
+func (api *AdminAPI) GetClusterSummary() (summary *proto.ClusterSummary, err error) {
+	summary = &proto.ClusterSummary{}
+	err = api.mc.requestWith(summary, newRequest(get, proto.AdminSummary).Header(api.h))
+	return
+}
+
+// SimulatePlacement returns the placement of the data partitions after the change, which is not applied.
+func (api *AdminAPI) SimulatePlacement(req *proto.PlacementSimulationRequest) (result *proto.PlacementSimulation, err error) {
+	result = &proto.PlacementSimulation{}
+	err = api.mc.requestWith(result, newRequest(post, proto.AdminPlacementSimulate).Header(api.h).Body(req))
+	return
+}
+
+func (api *AdminAPI) SetZoneCost(from, to string, cost float64) (costs []proto.ZoneCost, err error) {
+	err = api.mc.requestWith(&costs, newRequest(post, proto.AdminSetZoneCost).
+		Header(api.h).Param(anyParam{"from", from}, anyParam{"to", to}, anyParam{"cost", cost}))
+	return
+}
+
+func (api *AdminAPI) GetZoneCost() (costs []proto.ZoneCost, err error) {
+	err = api.mc.requestWith(&costs, newRequest(get, proto.AdminGetZoneCost).Header(api.h))
+	return
+}
+
 func (api *AdminAPI) ListZones() (zoneViews []*proto.ZoneView, err error) {
 	zoneViews = make([]*proto.ZoneView, 0)
 	err = api.mc.requestWith(&zoneViews, newRequest(get, proto.GetAllZones).Header(api.h))
@@ -134,6 +200,91 @@ func (api *AdminAPI) UpdateZone(name string, enable bool, dataNodesetSelector st
 	))
 }
 
+// SetZoneIsolation makes the zone none, readOnly or fenced.
+func (api *AdminAPI) SetZoneIsolation(name string, isolation string) (err error) {
+	return api.mc.request(newRequest(post, proto.AdminSetZoneIsolation).Header(api.h).Param(
+		anyParam{"name", name},
+		anyParam{"isolation", isolation},
+	))
+}
+
+// BalanceLeaders requests up to limit leader transfers evening out the raft leaders
+// of the data and meta nodes, 0 for the default, or only plans them with dryRun.
+func (api *AdminAPI) BalanceLeaders(limit uint64, dryRun bool) (view *proto.LeaderBalanceView, err error) {
+	view = &proto.LeaderBalanceView{}
+	err = api.mc.requestWith(view, newRequest(post, proto.AdminBalanceLeaders).Header(api.h).Param(
+		anyParam{"limit", limit},
+		anyParam{"dryRun", dryRun},
+	))
+	return
+}
+
+// StartMaintenance puts the cluster, a zone or a node set into maintenance, for the
+// duration unless empty.
+func (api *AdminAPI) StartMaintenance(scope, zoneName string, nodeSetID uint64, duration, reason string) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminMaintenanceStart).Header(api.h).Param(
+		anyParam{"scope", scope},
+		anyParam{"zoneName", zoneName},
+		anyParam{"nodesetId", nodeSetID},
+		anyParam{"duration", duration},
+		anyParam{"reason", reason},
+	))
+	return
+}
+
+func (api *AdminAPI) StopMaintenance(scope, zoneName string, nodeSetID uint64) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminMaintenanceStop).Header(api.h).Param(
+		anyParam{"scope", scope},
+		anyParam{"zoneName", zoneName},
+		anyParam{"nodesetId", nodeSetID},
+	))
+	return
+}
+
+func (api *AdminAPI) ListMaintenances() (maintenances []proto.Maintenance, err error) {
+	err = api.mc.requestWith(&maintenances, newRequest(get, proto.AdminMaintenanceList).Header(api.h))
+	return
+}
+
+// SetDataBalance turns the data balancer on or off with the limits of cfg, Paused is left as is.
+func (api *AdminAPI) SetDataBalance(cfg *proto.DataBalanceConfig) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminDataBalanceEnable).Header(api.h).Param(
+		anyParam{"enable", cfg.Enabled},
+		anyParam{"maxConcurrency", cfg.MaxConcurrency},
+		anyParam{"bandwidthMBps", cfg.BandwidthMBps},
+		anyParam{"threshold", cfg.Threshold},
+	))
+	return
+}
+
+// PauseDataBalance stops the data balancer from starting new moves, or lets it go on.
+func (api *AdminAPI) PauseDataBalance(pause bool) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminDataBalancePause).Header(api.h).Param(
+		anyParam{"pause", pause},
+	))
+	return
+}
+
+// SetZoneBalance turns the moves of the data balancer between the zones on or off with the policy of cfg,
+// an empty Window keeps the one set.
+func (api *AdminAPI) SetZoneBalance(cfg *proto.ZoneBalanceConfig) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminDataBalanceZone).Header(api.h).Param(
+		anyParam{"enable", cfg.Enabled},
+		anyParam{"window", cfg.Window},
+		anyParam{"maxTBPerWindow", cfg.MaxTBPerWindow},
+		anyParam{"targetSpread", cfg.TargetSpread},
+		anyParam{"maxZoneCost", cfg.MaxZoneCost},
+	))
+	return
+}
+
+// GetDataBalanceStatus returns the usage of the data nodes and the moves of the data balancer.
+func (api *AdminAPI) GetDataBalanceStatus() (view *proto.DataBalanceView, err error) {
+	view = &proto.DataBalanceView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.AdminDataBalanceStatus).Header(api.h))
+	return
+}
+
 func (api *AdminAPI) Topo() (topo *proto.TopologyView, err error) {
 	topo = &proto.TopologyView{}
 	err = api.mc.requestWith(topo, newRequest(get, proto.GetTopologyView).Header(api.h))
@@ -317,6 +468,12 @@ func (api *AdminAPI) UpdateVolume(
 	request.addParam(proto.MetaFollowerReadKey, strconv.FormatBool(vv.MetaFollowerRead))
 	request.addParam(proto.VolEnableDirectRead, strconv.FormatBool(vv.DirectRead))
 	request.addParam(proto.VolIgnoreTinyRecover, strconv.FormatBool(vv.IgnoreTinyRecover))
+	request.addParam(proto.VolSyncMirrorWrite, strconv.FormatBool(vv.SyncMirrorWrite))
+	request.addParam(proto.VolRequireTLS, strconv.FormatBool(vv.RequireTLS))
+	request.addParam(proto.VolInlineDataThreshold, strconv.FormatInt(vv.InlineDataThreshold, 10))
+	request.addParam(proto.VolSmallFileThreshold, strconv.FormatInt(vv.SmallFileThreshold, 10))
+	request.addParam(proto.VolConsistencyMode, vv.ConsistencyMode)
+	request.addParam(proto.VolMetaWitnessNum, strconv.Itoa(int(vv.MpWitnessNum)))
 	request.addParam(proto.MaximallyReadKey, strconv.FormatBool(vv.MaximallyRead))
 	request.addParam("ebsBlkSize", strconv.Itoa(vv.ObjBlockSize))
 	request.addParam("dpReadOnlyWhenVolFull", strconv.FormatBool(vv.DpReadOnlyWhenVolFull))
@@ -340,9 +497,17 @@ func (api *AdminAPI) UpdateVolume(
 	request.addParam("remoteCacheMaxFileSizeGB", strconv.FormatInt(vv.RemoteCacheMaxFileSizeGB, 10))
 	request.addParamAny("remoteCacheOnlyForNotSSD", vv.RemoteCacheOnlyForNotSSD)
 	request.addParamAny("remoteCacheMultiRead", vv.RemoteCacheMultiRead)
+	request.addParamAny("remoteCacheAlwaysAdmit", vv.RemoteCacheAlwaysAdmit)
+	request.addParamAny("remoteCacheAuth", vv.RemoteCacheAuth)
+	request.addParamAny("remoteCacheReadAheadMB", vv.RemoteCacheReadAheadMB)
+	request.addParamAny("remoteCacheMetaTTL", vv.RemoteCacheMetaTTL)
+	request.addParamAny("remoteCacheReadLimitMBps", vv.RemoteCacheReadLimitMBps)
+	request.addParamAny("remoteCacheReadLimitIops", vv.RemoteCacheReadLimitIops)
 	request.addParamAny("flashNodeTimeoutCount", vv.FlashNodeTimeoutCount)
 	request.addParamAny("remoteCacheSameZoneTimeout", vv.RemoteCacheSameZoneTimeout)
 	request.addParamAny("remoteCacheSameRegionTimeout", vv.RemoteCacheSameRegionTimeout)
+	request.addParamAny("remoteCacheHedgeDelay", vv.RemoteCacheHedgeDelay)
+	request.addParamAny("remoteCacheFallbackTimeout", vv.RemoteCacheFallbackTimeout)
 
 	if txMask != "" {
 		request.addParam("enableTxMask", txMask)
@@ -488,6 +653,281 @@ func (api *AdminAPI) SetVolumeForbidden(volName string, forbidden bool) (err err
 	return
 }
 
+func (api *AdminAPI) PublishVolume(volName, authKey string) (err error) {
+	request := newRequest(post, proto.AdminVolPublish).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// SetVolumeInterop updates the interop policy of the volume, the settings left
+// empty in policy are kept unchanged.
+func (api *AdminAPI) SetVolumeInterop(volName, authKey string, policy *proto.InteropPolicy) (err error) {
+	request := newRequest(post, proto.AdminVolSetInterop).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	if policy.KeyMapping != "" {
+		request.addParam("keyMapping", policy.KeyMapping)
+	}
+	if policy.MetaSync != "" {
+		request.addParam("metaSync", policy.MetaSync)
+	}
+	if policy.RenameVisibility != "" {
+		request.addParam("renameVisibility", policy.RenameVisibility)
+	}
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// SetVolumePlacementExclusion replaces the placement exclusion of the volume, an empty
+// exclusion clears it.
+func (api *AdminAPI) SetVolumePlacementExclusion(volName, authKey string, exclusion *proto.PlacementExclusion) (err error) {
+	request := newRequest(post, proto.AdminVolSetPlacementExclusion).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	if len(exclusion.Hosts) > 0 {
+		request.addParam("hosts", strings.Join(exclusion.Hosts, ","))
+	}
+	if len(exclusion.Zones) > 0 {
+		request.addParam("zones", strings.Join(exclusion.Zones, ","))
+	}
+	if len(exclusion.NodeSets) > 0 {
+		ids := make([]string, 0, len(exclusion.NodeSets))
+		for _, id := range exclusion.NodeSets {
+			ids = append(ids, strconv.FormatUint(id, 10))
+		}
+		request.addParam("nodeSets", strings.Join(ids, ","))
+	}
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// SetVolumePlacementPolicy replaces the placement policy of the volume, an empty policy
+// clears it.
+func (api *AdminAPI) SetVolumePlacementPolicy(volName, authKey string, policy *proto.PlacementPolicy) (err error) {
+	request := newRequest(post, proto.AdminVolSetPlacementPolicy).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	if len(policy.RequiredZones) > 0 {
+		request.addParam("requiredZones", strings.Join(policy.RequiredZones, ","))
+	}
+	if policy.SpreadZones > 0 {
+		request.addParam("spreadZones", strconv.Itoa(policy.SpreadZones))
+	}
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// SetVolumeSLO sets the latency SLO of op on the volume, a nil target removes it.
+func (api *AdminAPI) SetVolumeSLO(volName, authKey, op string, target *proto.SLOTarget) (err error) {
+	request := newRequest(post, proto.AdminVolSetSLO).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	request.addParam("op", op)
+	if target == nil {
+		request.addParam("objective", "0")
+	} else {
+		request.addParam("latencyMs", strconv.FormatInt(target.LatencyMs, 10))
+		request.addParam("objective", strconv.FormatFloat(target.Objective, 'f', -1, 64))
+	}
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+func (api *AdminAPI) GetVolumeSLO(volName string) (status *proto.VolumeSLOStatus, err error) {
+	status = &proto.VolumeSLOStatus{}
+	err = api.mc.requestWith(status, newRequest(get, proto.AdminVolSLO).Header(api.h).Param(anyParam{"name", volName}))
+	return
+}
+
+// GetVolumeTimeline returns the events of the volume of kind, or of all kinds if empty,
+// from start to end in unix seconds, oldest first. Zero start or end is unbounded.
+func (api *AdminAPI) GetVolumeTimeline(volName, kind string, start, end int64) (events []*proto.VolTimelineEvent, err error) {
+	events = make([]*proto.VolTimelineEvent, 0)
+	request := newRequest(get, proto.AdminVolTimeline).Header(api.h).Param(anyParam{"name", volName})
+	if kind != "" {
+		request.addParam("kind", kind)
+	}
+	if start > 0 {
+		request.addParamAny("start", start)
+	}
+	if end > 0 {
+		request.addParamAny("end", end)
+	}
+	err = api.mc.requestWith(&events, request)
+	return
+}
+
+// AcquireVolumeFence fences the writers of the volume, or of the subtree at path
+// if it is not empty, with an older token and returns the new token.
+func (api *AdminAPI) AcquireVolumeFence(volName, authKey, path string) (fence *proto.VolFence, err error) {
+	fence = &proto.VolFence{}
+	request := newRequest(post, proto.AdminVolFenceAcquire).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	if path != "" {
+		request.addParam("path", path)
+	}
+	err = api.mc.requestWith(fence, request)
+	return
+}
+
+func (api *AdminAPI) ListVolumeFences(volName string) (fences []*proto.VolFence, err error) {
+	fences = make([]*proto.VolFence, 0)
+	err = api.mc.requestWith(&fences, newRequest(get, proto.AdminVolFenceList).Header(api.h).Param(anyParam{"name", volName}))
+	return
+}
+
+func (api *AdminAPI) ListVolumeSLO() (statuses []*proto.VolumeSLOStatus, err error) {
+	statuses = make([]*proto.VolumeSLOStatus, 0)
+	err = api.mc.requestWith(&statuses, newRequest(get, proto.AdminVolSLO).Header(api.h))
+	return
+}
+
+// ReportSLO sends the latency samples collected by util/slo.Recorder to the master.
+func (api *AdminAPI) ReportSLO(reports []*proto.SLOReport) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminSLOReport).Header(api.h).Body(reports))
+	return
+}
+
+// GetVolumeReclaim returns the space of the deleted files of the volume not returned to the data nodes yet.
+func (api *AdminAPI) GetVolumeReclaim(volName string) (view *proto.VolReclaimView, err error) {
+	view = &proto.VolReclaimView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.AdminVolReclaim).Header(api.h).Param(anyParam{"name", volName}))
+	return
+}
+
+func (api *AdminAPI) ListVolumeReclaim() (views []*proto.VolReclaimView, err error) {
+	views = make([]*proto.VolReclaimView, 0)
+	err = api.mc.requestWith(&views, newRequest(get, proto.AdminVolReclaim).Header(api.h))
+	return
+}
+
+// BoostVolumeReclaim boosts the deletions of the volume with batchCount for durationSec, 0 removes the boost.
+func (api *AdminAPI) BoostVolumeReclaim(volName string, batchCount, durationSec uint64) (err error) {
+	request := newRequest(post, proto.AdminVolReclaimBoost).Header(api.h)
+	request.addParam("name", volName)
+	if batchCount > 0 {
+		request.addParamAny("batchCount", batchCount)
+	}
+	request.addParamAny("durationSec", durationSec)
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// AddVolumeFork registers volName as forked from source with the authKey of source, which pins source.
+func (api *AdminAPI) AddVolumeFork(volName, source, authKey string) (err error) {
+	request := newRequest(post, proto.AdminVolForkAdd).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("source", source)
+	request.addParam("authKey", authKey)
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// RemoveVolumeFork unpins source with the authKey of volName, whose files must not refer to its data any more.
+func (api *AdminAPI) RemoveVolumeFork(volName, source, authKey string) (err error) {
+	request := newRequest(post, proto.AdminVolForkRemove).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("source", source)
+	request.addParam("authKey", authKey)
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+// CreateAuditCampaign starts verifying the checksums of every partition of the volume within budget.
+func (api *AdminAPI) CreateAuditCampaign(volName, authKey string, budget time.Duration, concurrency int) (view *proto.AuditCampaignView, err error) {
+	request := newRequest(post, proto.AdminAuditCampaignCreate).Header(api.h)
+	request.addParam("name", volName)
+	request.addParam("authKey", authKey)
+	request.addParam("budget", strconv.FormatInt(int64(budget/time.Minute), 10))
+	request.addParam("concurrency", strconv.Itoa(concurrency))
+	view = &proto.AuditCampaignView{}
+	err = api.mc.requestWith(view, request)
+	return
+}
+
+func (api *AdminAPI) GetAuditCampaign(id string) (view *proto.AuditCampaignView, err error) {
+	view = &proto.AuditCampaignView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.AdminAuditCampaignGet).Header(api.h).Param(anyParam{"id", id}))
+	return
+}
+
+func (api *AdminAPI) ListAuditCampaigns() (views []*proto.AuditCampaignView, err error) {
+	views = make([]*proto.AuditCampaignView, 0)
+	err = api.mc.requestWith(&views, newRequest(get, proto.AdminAuditCampaignList).Header(api.h))
+	return
+}
+
+func (api *AdminAPI) CancelAuditCampaign(id string) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminAuditCampaignCancel).Header(api.h).Param(anyParam{"id", id}))
+	return
+}
+
+// GetAuditCampaignReport returns the signed report of a finished campaign.
+func (api *AdminAPI) GetAuditCampaignReport(id string) (report *proto.AuditReport, err error) {
+	report = &proto.AuditReport{}
+	err = api.mc.requestWith(report, newRequest(get, proto.AdminAuditCampaignReport).Header(api.h).Param(anyParam{"id", id}))
+	return
+}
+
+// SetMountProfile creates the mount profile or replaces all the options of it.
+func (api *AdminAPI) SetMountProfile(name string, options map[string]string) (err error) {
+	request := newRequest(post, proto.AdminMountProfileSet).Header(api.h)
+	request.addParam("name", name)
+	request.addParam("options", proto.FormatMountProfileOptions(options))
+	_, err = api.mc.serveRequest(request)
+	return
+}
+
+func (api *AdminAPI) GetMountProfile(name string) (profile *proto.MountProfile, err error) {
+	profile = &proto.MountProfile{}
+	err = api.mc.requestWith(profile, newRequest(get, proto.AdminMountProfileGet).Header(api.h).Param(anyParam{"name", name}))
+	return
+}
+
+func (api *AdminAPI) ListMountProfiles() (profiles []*proto.MountProfile, err error) {
+	profiles = make([]*proto.MountProfile, 0)
+	err = api.mc.requestWith(&profiles, newRequest(get, proto.AdminMountProfileList).Header(api.h))
+	return
+}
+
+func (api *AdminAPI) DeleteMountProfile(name string) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.AdminMountProfileDelete).Header(api.h).Param(anyParam{"name", name}))
+	return
+}
+
+// EvictClient fences the client of ip, or the mount of mountID, out of the volume or
+// the whole cluster if volName is empty. expire is the seconds it lasts, 0 for ever.
+func (api *AdminAPI) EvictClient(ip, mountID, volName, reason string, expire uint64) (eviction *proto.ClientEviction, err error) {
+	eviction = &proto.ClientEviction{}
+	request := newRequest(post, proto.AdminClientEvict).Header(api.h)
+	request.addParam("ip", ip)
+	request.addParam("mountId", mountID)
+	request.addParam("name", volName)
+	request.addParam("reason", reason)
+	request.addParamAny("expire", expire)
+	err = api.mc.requestWith(eviction, request)
+	return
+}
+
+func (api *AdminAPI) RemoveClientEviction(ip, mountID, volName string) (removed []*proto.ClientEviction, err error) {
+	removed = make([]*proto.ClientEviction, 0)
+	request := newRequest(post, proto.AdminClientEvictRemove).Header(api.h)
+	request.addParam("ip", ip)
+	request.addParam("mountId", mountID)
+	request.addParam("name", volName)
+	err = api.mc.requestWith(&removed, request)
+	return
+}
+
+func (api *AdminAPI) ListClientEvictions() (evictions []*proto.ClientEviction, err error) {
+	evictions = make([]*proto.ClientEviction, 0)
+	err = api.mc.requestWith(&evictions, newRequest(get, proto.AdminClientEvictList).Header(api.h))
+	return
+}
+
 func (api *AdminAPI) SetVolumeAuditLog(volName string, enable bool) (err error) {
 	request := newRequest(post, proto.AdminVolEnableAuditLog).Header(api.h)
 	request.addParam("name", volName)
@@ -556,10 +996,27 @@ func (api *AdminAPI) CreateMetaPartition(volName string, count int, clientIDKey
 	return
 }
 
+// SplitMetaPartitionAt splits the meta partition of the volume, the inodes from at+1 on
+// go to a new meta partition.
+func (api *AdminAPI) SplitMetaPartitionAt(volName string, partitionID uint64, at uint64) (msg string, err error) {
+	err = api.mc.requestWith(&msg, newRequest(post, proto.AdminSplitMetaPartitionAt).Header(api.h).Param(
+		anyParam{"name", volName},
+		anyParam{"id", partitionID},
+		anyParam{"at", at},
+	))
+	return
+}
+
 func (api *AdminAPI) ListVols(keywords string) (volsInfo []*proto.VolInfo, err error) {
+	return api.ListVolsWithOption(keywords, ListOption{})
+}
+
+// ListVolsWithOption lists the volumes by the name, of the owner, in the zone
+// and of the status, normal or markDelete, of the option.
+func (api *AdminAPI) ListVolsWithOption(keywords string, opt ListOption) (volsInfo []*proto.VolInfo, err error) {
 	volsInfo = make([]*proto.VolInfo, 0)
 	err = api.mc.requestWith(&volsInfo, newRequest(get, proto.AdminListVols).
-		Header(api.h).addParam("keywords", keywords))
+		Header(api.h).addParam("keywords", keywords).listOption(opt))
 	return
 }
 
@@ -598,7 +1055,11 @@ func (api *AdminAPI) SetClusterParas(batchCount, markDeleteRate, deleteWorkerSle
 	enableAutoDpMetaRepair string, autoDpMetaRepairParallelCnt string,
 	dpRepairTimeout string, dpTimeout string, mpTimeout string, dpBackupTimeout string,
 	decommissionDpLimit, decommissionDiskLimit, forbidWriteOpOfProtoVersion0 string, mediaType string,
-	handleTimeout string, readDataNodeTimeout string,
+	handleTimeout string, readDataNodeTimeout string, peerFillEnable string, peerFillTimeout string, admissionEnable string,
+	replicaTombstoneRetention string, clockSkewWarn string, clockSkewLimit string,
+	hotDpReadQps string, hotDpReadReplicas string, flashNodeReadLimitMBps string, flashNodeReadLimitIops string,
+	heartbeatMaxInterval string, flashGroupMinNodesPerZone string, leaderBalanceMaxTransfers string,
+	hotMpSplitQps string, hotMpSplitMemMB string, decommissionBandwidthMBps string,
 ) (err error) {
 	request := newRequest(get, proto.AdminSetNodeInfo).Header(api.h)
 	request.addParam("batchCount", batchCount)
@@ -659,6 +1120,54 @@ func (api *AdminAPI) SetClusterParas(batchCount, markDeleteRate, deleteWorkerSle
 	if readDataNodeTimeout != "" {
 		request.addParam("flashNodeReadDataNodeTimeout", readDataNodeTimeout)
 	}
+	if peerFillEnable != "" {
+		request.addParam("flashNodePeerFillEnable", peerFillEnable)
+	}
+	if peerFillTimeout != "" {
+		request.addParam("flashNodePeerFillTimeout", peerFillTimeout)
+	}
+	if admissionEnable != "" {
+		request.addParam("flashNodeAdmissionEnable", admissionEnable)
+	}
+	if replicaTombstoneRetention != "" {
+		request.addParam("replicaTombstoneRetention", replicaTombstoneRetention)
+	}
+	if clockSkewWarn != "" {
+		request.addParam("clockSkewWarn", clockSkewWarn)
+	}
+	if clockSkewLimit != "" {
+		request.addParam("clockSkewLimit", clockSkewLimit)
+	}
+	if hotDpReadQps != "" {
+		request.addParam("hotDpReadQps", hotDpReadQps)
+	}
+	if hotDpReadReplicas != "" {
+		request.addParam("hotDpReadReplicas", hotDpReadReplicas)
+	}
+	if flashNodeReadLimitMBps != "" {
+		request.addParam("flashNodeReadLimitMBps", flashNodeReadLimitMBps)
+	}
+	if flashNodeReadLimitIops != "" {
+		request.addParam("flashNodeReadLimitIops", flashNodeReadLimitIops)
+	}
+	if heartbeatMaxInterval != "" {
+		request.addParam("heartbeatMaxInterval", heartbeatMaxInterval)
+	}
+	if flashGroupMinNodesPerZone != "" {
+		request.addParam("flashGroupMinNodesPerZone", flashGroupMinNodesPerZone)
+	}
+	if leaderBalanceMaxTransfers != "" {
+		request.addParam("leaderBalanceMaxTransfers", leaderBalanceMaxTransfers)
+	}
+	if hotMpSplitQps != "" {
+		request.addParam("hotMpSplitQps", hotMpSplitQps)
+	}
+	if hotMpSplitMemMB != "" {
+		request.addParam("hotMpSplitMemMB", hotMpSplitMemMB)
+	}
+	if decommissionBandwidthMBps != "" {
+		request.addParam("decommissionBandwidthMBps", decommissionBandwidthMBps)
+	}
 
 	_, err = api.mc.serveRequest(request)
 	return
@@ -806,13 +1315,18 @@ func (api *AdminAPI) DeleteVersion(volName string, verSeq string) (err error) {
 	return
 }
 
-func (api *AdminAPI) SetStrategy(volName string, periodic string, count string, enable string, force string) (err error) {
+// SetStrategy sets the snapshot strategy of the volume, an empty immutableSec
+// keeps the immutable window after each snapshot.
+func (api *AdminAPI) SetStrategy(volName string, periodic string, count string, enable string, force string, immutableSec string) (err error) {
 	request := newRequest(get, proto.AdminSetVerStrategy).Header(api.h)
 	request.addParam("name", volName)
 	request.addParam("periodic", periodic)
 	request.addParam("count", count)
 	request.addParam("enable", enable)
 	request.addParam("force", force)
+	if immutableSec != "" {
+		request.addParam("immutableSec", immutableSec)
+	}
 	_, err = api.mc.serveRequest(request)
 	return
 }
@@ -864,6 +1378,31 @@ func (api *AdminAPI) GetS3QoSInfo() (data []byte, err error) {
 	return api.mc.serveRequest(newRequest(get, proto.S3QoSGet).Header(api.h))
 }
 
+// S3NodeHeartbeat registers the objectnode addr, and gets the live objectnodes
+// and the versions of the bucket configurations.
+func (api *AdminAPI) S3NodeHeartbeat(addr string) (resp *proto.S3NodeHeartbeatResponse, err error) {
+	resp = &proto.S3NodeHeartbeatResponse{}
+	err = api.mc.requestWith(resp, newRequest(post, proto.S3NodeHeartbeat).Header(api.h).addParam("addr", addr))
+	return
+}
+
+// ListS3Nodes gets the live objectnodes, and the one the bucket is routed to if not empty.
+func (api *AdminAPI) ListS3Nodes(bucket string) (view *proto.S3NodesView, err error) {
+	view = &proto.S3NodesView{}
+	request := newRequest(get, proto.S3NodeList).Header(api.h)
+	if bucket != "" {
+		request.addParam("bucket", bucket)
+	}
+	err = api.mc.requestWith(view, request)
+	return
+}
+
+// S3BucketChanged tells the other objectnodes to reload the configuration of the bucket.
+func (api *AdminAPI) S3BucketChanged(bucket string) (err error) {
+	_, err = api.mc.serveRequest(newRequest(post, proto.S3BucketChanged).Header(api.h).addParam("name", bucket))
+	return
+}
+
 func (api *AdminAPI) SetAutoDecommissionDisk(enable bool) (err error) {
 	request := newRequest(post, proto.AdminEnableAutoDecommissionDisk)
 	request.addParam("enable", strconv.FormatBool(enable))
@@ -950,16 +1489,78 @@ func (api *AdminAPI) ChangeMasterLeader(leaderAddr string) (err error) {
 	return
 }
 
+// GetMasterStandbyStatus returns the role, the raft progress and the peers of the master at addr.
+func (api *AdminAPI) GetMasterStandbyStatus(addr string) (status *proto.MasterStandbyStatus, err error) {
+	data, err := api.mc.requestOnce(newRequest(get, proto.AdminMasterStandbyStatus).Header(api.h), addr)
+	if err != nil {
+		return
+	}
+	status = &proto.MasterStandbyStatus{}
+	err = json.Unmarshal(data, status)
+	return
+}
+
+// FenceMaster fences the master of the primary group at addr, it commits nothing and does not
+// start again until its fence file is removed.
+func (api *AdminAPI) FenceMaster(addr string) (status *proto.MasterStandbyStatus, err error) {
+	data, err := api.mc.requestOnce(newRequest(post, proto.AdminMasterStandbyFence).Header(api.h), addr)
+	if err != nil {
+		return
+	}
+	status = &proto.MasterStandbyStatus{}
+	err = json.Unmarshal(data, status)
+	return
+}
+
+// PromoteMasterStandby promotes the standby group from the standby master at addr, waiting
+// waitSec for it to apply what the primary group committed, 0 for the default.
+func (api *AdminAPI) PromoteMasterStandby(addr string, force bool, waitSec int) (result *proto.MasterPromoteResult, err error) {
+	request := newRequest(post, proto.AdminMasterStandbyPromote).Header(api.h).addParamAny("force", force)
+	if waitSec > 0 {
+		request.addParamAny("waitSec", waitSec)
+	}
+	data, err := api.mc.requestOnce(request, addr)
+	if err != nil {
+		return
+	}
+	result = &proto.MasterPromoteResult{}
+	err = json.Unmarshal(data, result)
+	return
+}
+
+// PromoteMasterStandbyLocal only resets the peers of the standby master at addr to the standby group.
+func (api *AdminAPI) PromoteMasterStandbyLocal(addr string) (status *proto.MasterStandbyStatus, err error) {
+	data, err := api.mc.requestOnce(newRequest(post, proto.AdminMasterStandbyPromote).Header(api.h).
+		addParamAny("local", true), addr)
+	if err != nil {
+		return
+	}
+	status = &proto.MasterStandbyStatus{}
+	err = json.Unmarshal(data, status)
+	return
+}
+
 func (api *AdminAPI) TurnFlashGroup(enable bool) (result string, err error) {
 	request := newRequest(post, proto.AdminFlashGroupTurn).Header(api.h).addParamAny("enable", enable)
 	data, err := api.mc.serveRequest(request)
 	return string(data), err
 }
 
-func (api *AdminAPI) CreateFlashGroup(slots string, weight int, gradualFlag bool, step uint32) (fgView proto.FlashGroupAdminView, err error) {
+// CreateFlashGroup creates a flash group, of which the slots are the share of cacheCapacity GB if it is
+// not 0 and other groups cache something, or 32*weight.
+func (api *AdminAPI) CreateFlashGroup(slots string, weight int, cacheCapacity uint64, gradualFlag bool, step uint32) (fgView proto.FlashGroupAdminView, err error) {
 	err = api.mc.requestWith(&fgView, newRequest(post, proto.AdminFlashGroupCreate).
-		Header(api.h).Param(anyParam{"slots", slots}, anyParam{"weight", weight}, anyParam{"gradualFlag", gradualFlag},
-		anyParam{"step", step}))
+		Header(api.h).Param(anyParam{"slots", slots}, anyParam{"weight", weight}, anyParam{"cacheCapacity", cacheCapacity},
+		anyParam{"gradualFlag", gradualFlag}, anyParam{"step", step}))
+	return
+}
+
+// PreviewCreateFlashGroup returns the slots of the flash groups once the group is created, without creating it.
+func (api *AdminAPI) PreviewCreateFlashGroup(slots string, weight int, cacheCapacity uint64) (preview *proto.FlashGroupSlotsPreview, err error) {
+	preview = &proto.FlashGroupSlotsPreview{}
+	err = api.mc.requestWith(preview, newRequest(post, proto.AdminFlashGroupCreate).
+		Header(api.h).Param(anyParam{"slots", slots}, anyParam{"weight", weight}, anyParam{"cacheCapacity", cacheCapacity},
+		anyParam{"dryRun", true}))
 	return
 }
 
@@ -976,6 +1577,23 @@ func (api *AdminAPI) RemoveFlashGroup(flashGroupID uint64, gradualFlag bool, ste
 	return string(data), err
 }
 
+// PreviewRemoveFlashGroup returns the slots of the flash groups once the group is removed, without removing it.
+func (api *AdminAPI) PreviewRemoveFlashGroup(flashGroupID uint64) (preview *proto.FlashGroupSlotsPreview, err error) {
+	preview = &proto.FlashGroupSlotsPreview{}
+	err = api.mc.requestWith(preview, newRequest(post, proto.AdminFlashGroupRemove).Header(api.h).
+		Param(anyParam{"id", flashGroupID}, anyParam{"dryRun", true}))
+	return
+}
+
+// DrainFlashGroup removes a flash group after drainTTL seconds, meanwhile the clients read what it
+// caches but cache nothing more in it.
+func (api *AdminAPI) DrainFlashGroup(flashGroupID uint64, drainTTL uint32) (result string, err error) {
+	request := newRequest(post, proto.AdminFlashGroupRemove).Header(api.h).Param(anyParam{"id", flashGroupID},
+		anyParam{"drainTTL", drainTTL})
+	data, err := api.mc.serveRequest(request)
+	return string(data), err
+}
+
 func (api *AdminAPI) flashGroupFlashNodes(uri string, flashGroupID uint64, count int, zoneName, addr string,
 ) (fgView proto.FlashGroupAdminView, err error) {
 	err = api.mc.requestWith(&fgView, newRequest(post, uri).Header(api.h).Param(
@@ -1005,13 +1623,173 @@ func (api *AdminAPI) ListFlashGroup(isActive bool) (fgView proto.FlashGroupsAdmi
 	return
 }
 
+func (api *AdminAPI) ListDrainingFlashGroups() (fgView proto.FlashGroupsAdminView, err error) {
+	err = api.mc.requestWith(&fgView, newRequest(get, proto.AdminFlashGroupList).
+		Header(api.h).Param(anyParam{"draining", true}))
+	return
+}
+
 func (api *AdminAPI) ListFlashGroups() (fgView proto.FlashGroupsAdminView, err error) {
 	err = api.mc.requestWith(&fgView, newRequest(get, proto.AdminFlashGroupList).Header(api.h))
 	return
 }
 
-func (api *AdminAPI) ClientFlashGroups() (fgView proto.FlashGroupView, err error) {
-	err = api.mc.requestWith(&fgView, newRequest(get, proto.ClientFlashGroups).Header(api.h))
+// ListFlashGroupsWithOption lists the flash groups by the id, with flashnodes in
+// the zone and of the status, active, inactive or draining, of the option.
+func (api *AdminAPI) ListFlashGroupsWithOption(opt ListOption) (fgView proto.FlashGroupsAdminView, err error) {
+	err = api.mc.requestWith(&fgView, newRequest(get, proto.AdminFlashGroupList).Header(api.h).listOption(opt))
+	return
+}
+
+func (api *AdminAPI) SetFlashGroupAutoScale(flashGroupID uint64, min, max int) (fgView proto.FlashGroupAdminView, err error) {
+	err = api.mc.requestWith(&fgView, newRequest(post, proto.AdminFlashGroupAutoScale).
+		Header(api.h).Param(anyParam{"id", flashGroupID}, anyParam{"min", min}, anyParam{"max", max}))
+	return
+}
+
+// SetFlashGroupAutoHeal sets the min healthy flashnodes of a flash group, 0 to turn off, below which its
+// inactive flashnodes are replaced, the cooldown in seconds, 0 for the default, and the hosts not to take.
+func (api *AdminAPI) SetFlashGroupAutoHeal(flashGroupID uint64, minHealthy int, cooldown int64, excludeHosts []string) (fgView proto.FlashGroupAdminView, err error) {
+	err = api.mc.requestWith(&fgView, newRequest(post, proto.AdminFlashGroupAutoHeal).Header(api.h).
+		Param(anyParam{"id", flashGroupID}, anyParam{"minHealthy", minHealthy}, anyParam{"cooldown", cooldown},
+			anyParam{"excludeHosts", strings.Join(excludeHosts, ",")}))
+	return
+}
+
+// SetFlashGroupVolumes dedicates a flash group to the volumes, which then read only from their
+// groups, no volumes to share it again.
+func (api *AdminAPI) SetFlashGroupVolumes(flashGroupID uint64, volumes []string) (fgView proto.FlashGroupAdminView, err error) {
+	err = api.mc.requestWith(&fgView, newRequest(post, proto.AdminFlashGroupVolumes).Header(api.h).
+		Param(anyParam{"id", flashGroupID}, anyParam{"volumes", strings.Join(volumes, ",")}))
+	return
+}
+
+// BatchCreateFlashGroups creates count flash groups of nodesPerGroup idle flash nodes each,
+// picked by the zone policy, with their slots in one call.
+func (api *AdminAPI) BatchCreateFlashGroups(count, nodesPerGroup int, zonePolicy string, weight int) (views []proto.FlashGroupAdminView, err error) {
+	err = api.mc.requestWith(&views, newRequest(post, proto.AdminFlashGroupBatchCreate).Header(api.h).
+		Param(anyParam{"count", count}, anyParam{"nodesPerGroup", nodesPerGroup},
+			anyParam{"zonePolicy", zonePolicy}, anyParam{"weight", weight}))
+	return
+}
+
+// RebalanceFlashGroupSlots moves the slots count of the active flash groups to their share of the cache capacity.
+func (api *AdminAPI) RebalanceFlashGroupSlots(step uint32, dryRun bool) (shares []*proto.FlashGroupSlotsRebalance, err error) {
+	if dryRun {
+		var preview *proto.FlashGroupSlotsPreview
+		if preview, err = api.PreviewRebalanceFlashGroupSlots(); err != nil {
+			return
+		}
+		return preview.Rebalance, nil
+	}
+	err = api.mc.requestWith(&shares, newRequest(post, proto.AdminFlashGroupRebalance).Header(api.h).
+		Param(anyParam{"step", step}))
+	return
+}
+
+// PreviewRebalanceFlashGroupSlots returns the slots of the flash groups once they are rebalanced, without rebalancing them.
+func (api *AdminAPI) PreviewRebalanceFlashGroupSlots() (preview *proto.FlashGroupSlotsPreview, err error) {
+	preview = &proto.FlashGroupSlotsPreview{}
+	err = api.mc.requestWith(preview, newRequest(post, proto.AdminFlashGroupRebalance).Header(api.h).
+		Param(anyParam{"dryRun", true}))
+	return
+}
+
+// FlashGroupScaleEvents returns the auto scaling events of a flash group, or of all with id 0.
+func (api *AdminAPI) FlashGroupScaleEvents(flashGroupID uint64) (events []*proto.FlashGroupScaleEvent, err error) {
+	request := newRequest(get, proto.AdminFlashGroupScaleEvent).Header(api.h)
+	if flashGroupID > 0 {
+		request.addParamAny("id", flashGroupID)
+	}
+	err = api.mc.requestWith(&events, request)
+	return
+}
+
+// FlashGroupAudit returns the admin operations on a flash group, or on all with id 0,
+// of op, or of all ops if empty, from start to end in unix seconds.
+func (api *AdminAPI) FlashGroupAudit(flashGroupID uint64, op string, start, end int64) (records []*proto.FlashGroupAuditRecord, err error) {
+	records = make([]*proto.FlashGroupAuditRecord, 0)
+	request := newRequest(get, proto.AdminFlashGroupAudit).Header(api.h)
+	if flashGroupID > 0 {
+		request.addParamAny("id", flashGroupID)
+	}
+	if op != "" {
+		request.addParam("op", op)
+	}
+	if start > 0 {
+		request.addParamAny("start", start)
+	}
+	if end > 0 {
+		request.addParamAny("end", end)
+	}
+	err = api.mc.requestWith(&records, request)
+	return
+}
+
+// FlashGroupCacheKeys returns up to limit of the most read blocks cached by a flash group, or by all
+// with id 0, of the volume, or of all volumes if it is empty, whose key in the volume starts with the prefix.
+func (api *AdminAPI) FlashGroupCacheKeys(flashGroupID uint64, volume, prefix string, limit int) (keys *proto.FlashGroupCacheKeys, err error) {
+	keys = &proto.FlashGroupCacheKeys{}
+	// the params are not escaped by the client, and the prefix of the keys ends with "#"
+	request := newRequest(get, proto.AdminFlashGroupCacheKeys).Header(api.h).
+		Param(anyParam{"volume", volume}, anyParam{"prefix", prefix}, anyParam{"limit", limit})
+	if flashGroupID > 0 {
+		request.addParamAny("id", flashGroupID)
+	}
+	err = api.mc.requestWith(keys, request)
+	return
+}
+
+// PurgeFlashGroupCache evicts the blocks of the volume whose key in the volume starts with the prefix,
+// all of the volume if it is empty, from a flash group, or from all with id 0.
+func (api *AdminAPI) PurgeFlashGroupCache(flashGroupID uint64, volume, prefix string) (purge *proto.FlashGroupCachePurge, err error) {
+	purge = &proto.FlashGroupCachePurge{}
+	request := newRequest(post, proto.AdminFlashGroupPurgeCache).Header(api.h).
+		Param(anyParam{"volume", volume}, anyParam{"prefix", prefix})
+	if flashGroupID > 0 {
+		request.addParamAny("id", flashGroupID)
+	}
+	err = api.mc.requestWith(purge, request)
+	return
+}
+
+// MoveFlashGroupSlots moves the slots of the source flash group to the destination one, and returns
+// the views of both.
+func (api *AdminAPI) MoveFlashGroupSlots(srcID, dstID uint64, slots []uint32) (views []proto.FlashGroupAdminView, err error) {
+	strs := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		strs = append(strs, strconv.FormatUint(uint64(slot), 10))
+	}
+	err = api.mc.requestWith(&views, newRequest(post, proto.AdminFlashGroupMoveSlots).Header(api.h).
+		Param(anyParam{"srcId", srcID}, anyParam{"dstId", dstID}, anyParam{"slots", strings.Join(strs, ",")}))
+	return
+}
+
+// ClientFlashGroups returns the flash groups the volume reads from, the shared ones if it is empty.
+func (api *AdminAPI) ClientFlashGroups(volume string) (fgView proto.FlashGroupView, err error) {
+	return api.ClientFlashGroupsSince(volume, 0)
+}
+
+// ClientFlashGroupsSince returns the changes of the flash groups of the volume since the
+// version of the view of the client, see proto.FlashGroupView.Apply.
+func (api *AdminAPI) ClientFlashGroupsSince(volume string, version uint64) (fgView proto.FlashGroupView, err error) {
+	return api.ClientFlashGroupsWait(volume, version, 0)
+}
+
+// ClientFlashGroupsWait is ClientFlashGroupsSince, but the master holds the request up to
+// wait seconds until the flash groups change if the version is the current one.
+func (api *AdminAPI) ClientFlashGroupsWait(volume string, version uint64, wait uint64) (fgView proto.FlashGroupView, err error) {
+	request := newRequest(get, proto.ClientFlashGroups).Header(api.h)
+	if volume != "" {
+		request.addParam("volume", volume)
+	}
+	if version > 0 {
+		request.addParamAny("version", version)
+	}
+	if wait > 0 {
+		request.addParamAny("wait", wait)
+	}
+	err = api.mc.requestWith(&fgView, request)
 	return
 }
 
@@ -25,6 +25,10 @@ import (
 
 var BcacheOnlyForNotSSD, ClientRCacheEnable bool
 
+// ClientMountID is reported to master with the volume stat requests, so that the
+// mount can be evicted by it.
+var ClientMountID string
+
 type Decoder func([]byte) ([]byte, error)
 
 func (d Decoder) Decode(raw []byte) ([]byte, error) {
@@ -55,6 +59,15 @@ func (api *ClientAPI) GetVolume(volName string, authKey string) (vv *proto.VolVi
 	return
 }
 
+// GetFlashCacheToken returns the token to read the flash cache of the volume with,
+// required by the flash nodes if the volume requires remoteCacheAuth.
+func (api *ClientAPI) GetFlashCacheToken(volName string, authKey string) (token *proto.FlashCacheToken, err error) {
+	token = &proto.FlashCacheToken{}
+	err = api.mc.requestWith(token, newRequest(get, proto.ClientFlashCacheToken).
+		Header(api.h).Param(anyParam{"name", volName}, anyParam{"authKey", authKey}))
+	return
+}
+
 func (api *ClientAPI) GetVolumeWithoutAuthKey(volName string) (vv *proto.VolView, err error) {
 	vv = &proto.VolView{}
 	err = api.mc.requestWith(vv, newRequest(post, proto.ClientVol).
@@ -94,6 +107,7 @@ func (api *ClientAPI) GetVolumeStat(volName string) (info *proto.VolStatInfo, er
 		anyParam{proto.RoleKey, proto.Role},
 		anyParam{proto.BcacheOnlyForNotSSDKey, BcacheOnlyForNotSSD},
 		anyParam{proto.EnableRemoteCache, ClientRCacheEnable},
+		anyParam{proto.MountIDKey, ClientMountID},
 	))
 	return
 }
@@ -125,6 +139,16 @@ func (api *ClientAPI) GetDataPartitionsFromLeader(volName string) (view *proto.D
 	return
 }
 
+// GetDataPartitionsWithOption lists the data partitions of the volume by the id,
+// with replicas in the zone and of the status, readWrite, readOnly or
+// unavailable, of the option, from the leader.
+func (api *ClientAPI) GetDataPartitionsWithOption(volName string, opt ListOption) (view *proto.DataPartitionsView, err error) {
+	view = &proto.DataPartitionsView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.ClientDataPartitions).
+		Header(api.h).addParam("name", volName).listOption(opt))
+	return
+}
+
 func (api *ClientAPI) GetDataPartitions(volName string) (view *proto.DataPartitionsView, err error) {
 	lastLeader := api.mc.Leader()
 	defer api.mc.SetLeader(lastLeader)
@@ -147,6 +171,22 @@ func (api *ClientAPI) GetDiskDataPartitions(addr string, diskPath string) (view
 	return
 }
 
+// GetZoneCost returns the cost of reading from the other zones for the clients in zone.
+func (api *ClientAPI) GetZoneCost(zone string) (view *proto.ZoneCostView, err error) {
+	view = &proto.ZoneCostView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.ClientZoneCost).
+		Header(api.h).addParam("zoneName", zone))
+	return
+}
+
+// GetForkPartitions returns the partitions of the fork sources of the volume in ForkPartitions.
+func (api *ClientAPI) GetForkPartitions(volName string) (view *proto.DataPartitionsView, err error) {
+	view = &proto.DataPartitionsView{}
+	err = api.mc.requestWith(view, newRequest(get, proto.ClientForkPartitions).
+		Header(api.h).addParam("name", volName))
+	return
+}
+
 func (api *ClientAPI) GetPreLoadDataPartitions(volName string) (view *proto.DataPartitionsView, err error) {
 	view = &proto.DataPartitionsView{}
 	err = api.mc.requestWith(view, newRequest(get, proto.ClientDataPartitions).
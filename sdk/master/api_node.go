@@ -18,6 +18,7 @@ import (
 	"strconv"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/google/uuid"
 )
 
 type NodeAPI struct {
@@ -99,6 +100,20 @@ func (api *NodeAPI) GetDataNode(serverHost string) (node *proto.DataNodeInfo, er
 	return
 }
 
+// GetDataNodeLoadProgress returns the load progress of the data node of addr, of all the
+// data nodes if addr is empty, only the ones still loading if loading is true.
+func (api *NodeAPI) GetDataNodeLoadProgress(addr string, loading bool) (progresses []proto.DataNodeLoadProgress, err error) {
+	request := newRequest(get, proto.GetDataNodeLoadProgress).Header(api.h)
+	if addr != "" {
+		request.addParam("addr", addr)
+	}
+	if loading {
+		request.addParam("loading", "true")
+	}
+	err = api.mc.requestWith(&progresses, request)
+	return
+}
+
 func (api *NodeAPI) GetMetaNode(serverHost string) (node *proto.MetaNodeInfo, err error) {
 	node = &proto.MetaNodeInfo{}
 	err = api.mc.requestWith(node, newRequest(get, proto.GetMetaNode).Header(api.h).addParam("addr", serverHost))
@@ -199,6 +214,14 @@ func (api *NodeAPI) QueryDataNodeDecommissionProgress(addr string) (progress *pr
 	return
 }
 
+// QueryDecommissionProgress returns the partitions migrated and left, the bandwidth and the
+// estimated completion of the decommission of the data or meta node.
+func (api *NodeAPI) QueryDecommissionProgress(addr string) (progress *proto.DecommissionNodeProgress, err error) {
+	progress = &proto.DecommissionNodeProgress{}
+	err = api.mc.requestWith(progress, newRequest(get, proto.AdminQueryDecommissionProgress).Header(api.h).addParam("addr", addr))
+	return
+}
+
 func (api *NodeAPI) AddFlashNode(serverAddr, zoneName, version string) (id uint64, err error) {
 	request := newRequest(post, proto.FlashNodeAdd).Header(api.h).
 		addParam("addr", serverAddr).addParam("zoneName", zoneName).addParam("version", version)
@@ -215,12 +238,24 @@ func (api *NodeAPI) SetFlashNode(addr string, enable bool) (err error) {
 		Header(api.h).Param(anyParam{"addr", addr}, anyParam{"enable", enable}))
 }
 
+// SetFlashNodeReadOnly makes the flash node serve the blocks it caches but cache nothing more, or cache again.
+func (api *NodeAPI) SetFlashNodeReadOnly(addr string, readOnly bool) (err error) {
+	return api.mc.request(newRequest(post, proto.FlashNodeSetReadOnly).
+		Header(api.h).Param(anyParam{"addr", addr}, anyParam{"readOnly", readOnly}))
+}
+
 func (api *NodeAPI) RemoveFlashNode(nodeAddr string) (result string, err error) {
 	request := newRequest(post, proto.FlashNodeRemove).Header(api.h).addParam("addr", nodeAddr).NoTimeout()
 	data, err := api.mc.serveRequest(request)
 	return string(data), err
 }
 
+// OfflineFlashNode tells master the flash node is shutting down, which removes it
+// from its flash group.
+func (api *NodeAPI) OfflineFlashNode(nodeAddr string) (err error) {
+	return api.mc.request(newRequest(post, proto.FlashNodeOffline).Header(api.h).addParam("addr", nodeAddr))
+}
+
 func (api *NodeAPI) RemoveAllInactiveFlashNodes() (rmNodes []string, err error) {
 	err = api.mc.requestWith(&rmNodes, newRequest(get, proto.FlashNodeRemoveAllInactive).Header(api.h))
 	return
@@ -237,6 +272,50 @@ func (api *NodeAPI) ListFlashNodes(active int) (zoneFlashNodes map[string][]*pro
 	return
 }
 
+// GetFlashNodeStats returns the cache stats of the flashnodes, of all the
+// groups if flashGroupID is 0 and of all the zones if zoneName is empty.
+func (api *NodeAPI) GetFlashNodeStats(flashGroupID uint64, zoneName string) (view *proto.FlashNodeStatsView, err error) {
+	view = &proto.FlashNodeStatsView{}
+	request := newRequest(get, proto.AdminFlashNodeStats).Header(api.h)
+	if flashGroupID != 0 {
+		request.addParamAny("flashGroupID", flashGroupID)
+	}
+	if zoneName != "" {
+		request.addParam("zoneName", zoneName)
+	}
+	err = api.mc.requestWith(view, request)
+	return
+}
+
+// CreateFlashManualTask creates a task to warm up the files of a volume in the
+// flash groups, an id is given to it if it has none.
+func (api *NodeAPI) CreateFlashManualTask(task *proto.FlashManualTask) (err error) {
+	if task.Id == "" {
+		task.Id = uuid.New().String()
+	}
+	return api.mc.request(newRequest(post, proto.CreateFlashNodeManualTask).Header(api.h).Body(task))
+}
+
+// ListFlashManualTasks returns the tasks of the volume, or of all the volumes
+// if volName is empty, or the task tid only.
+func (api *NodeAPI) ListFlashManualTasks(volName, tid string) (tasks []*proto.FlashManualTask, err error) {
+	request := newRequest(get, proto.AdminFlashManualTask).Header(api.h).addParam("op", "info")
+	if volName != "" {
+		request.addParam("vol", volName)
+	}
+	if tid != "" {
+		request.addParam("tid", tid)
+	}
+	err = api.mc.requestWith(&tasks, request)
+	return
+}
+
+// OpFlashManualTask stops, pauses, resumes or deletes the task tid.
+func (api *NodeAPI) OpFlashManualTask(tid, op string) (err error) {
+	return api.mc.request(newRequest(get, proto.AdminFlashManualTask).Header(api.h).
+		addParam("op", op).addParam("tid", tid))
+}
+
 func (api *NodeAPI) ResponseFlashNodeTask(task *proto.AdminTask) (err error) {
 	return api.mc.request(newRequest(post, proto.GetFlashNodeTaskResponse).Header(api.h).Body(task))
 }
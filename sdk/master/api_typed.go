@@ -0,0 +1,46 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import "encoding/json"
+
+//go:generate go run ../../tool/openapi -root ../..
+
+// TypedAdminAPI has one method per master route, generated from the route table
+// together with docs/source/dev-guide/admin-api/master/openapi.json. Every method
+// returns the raw data of the reply envelope.
+type TypedAdminAPI struct {
+	mc *MasterClient
+	h  map[string]string // extra headers
+}
+
+func (api *TypedAdminAPI) WithHeader(key, val string) *TypedAdminAPI {
+	return &TypedAdminAPI{mc: api.mc, h: mergeHeader(api.h, key, val)}
+}
+
+func (c *MasterClient) TypedAdminAPI() *TypedAdminAPI {
+	return &TypedAdminAPI{mc: c}
+}
+
+func (api *TypedAdminAPI) do(req *request) (json.RawMessage, error) {
+	if req.err != nil {
+		return nil, req.err
+	}
+	data, err := api.mc.serveRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
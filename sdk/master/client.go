@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/auth"
 	"github.com/cubefs/cubefs/util/compressor"
 	"github.com/cubefs/cubefs/util/log"
 )
@@ -64,6 +66,10 @@ type MasterClient struct {
 	clientIDKey string
 	client      *http.Client
 
+	// the requests are signed with sigv4 if the access key is set
+	accessKey string
+	secretKey string
+
 	adminAPI  *AdminAPI
 	clientAPI *ClientAPI
 	nodeAPI   *NodeAPI
@@ -146,7 +152,21 @@ func (c *MasterClient) SetClientIDKey(clientIDKey string) {
 	c.Unlock()
 }
 
+func (c *MasterClient) SetSigV4Credentials(accessKey, secretKey string) {
+	c.Lock()
+	c.accessKey, c.secretKey = accessKey, secretKey
+	c.Unlock()
+}
+
 func (c *MasterClient) serveRequest(r *request) (repsData []byte, err error) {
+	// one request id for the retries on the masters, which logs it with the request
+	if r.header == nil {
+		r.header = make(map[string]string)
+	}
+	if r.header[proto.HeaderRequestID] == "" {
+		r.header[proto.HeaderRequestID] = proto.NewRequestID()
+	}
+	reqID := r.header[proto.HeaderRequestID]
 	leaderAddr, nodes := c.prepareRequest()
 	host := leaderAddr
 	for i := -1; i < len(nodes); i++ {
@@ -165,7 +185,7 @@ func (c *MasterClient) serveRequest(r *request) (repsData []byte, err error) {
 		url := fmt.Sprintf("%s://%s%s", schema, host, r.path)
 		resp, err = c.httpRequest(r.method, url, r)
 		if err != nil {
-			log.LogErrorf("serveRequest: send http request fail: method(%v) url(%v) err(%v)", r.method, url, err)
+			log.LogErrorf("serveRequest: send http request fail: method(%v) url(%v) requestID(%v) err(%v)", r.method, url, reqID, err)
 			continue
 		}
 		stateCode := resp.StatusCode
@@ -204,19 +224,13 @@ func (c *MasterClient) serveRequest(r *request) (repsData []byte, err error) {
 				return nil, fmt.Errorf("unmarshal response body err:%v", err)
 			}
 			if body.Code != proto.ErrCodeSuccess {
-				log.LogWarnf("serveRequest: code[%v], msg[%v], data[%v] ", body.Code, body.Msg, string(body.Data))
-				if body.Code == proto.ErrCodeInternalError && len(body.Msg) != 0 {
-					return nil, errors.New(body.Msg)
-				} else if CliPrint && body.Code == proto.ErrCodeParamError && len(body.Msg) != 0 {
-					return nil, errors.New(body.Msg)
-				} else {
-					return nil, proto.ParseErrorCode(body.Code)
-				}
+				log.LogWarnf("serveRequest: requestID[%v] code[%v], msg[%v], data[%v] ", reqID, body.Code, body.Msg, string(body.Data))
+				return nil, proto.NewCodeError(body.Code, body.Msg)
 			}
 			return body.Bytes(), nil
 		default:
-			msg := fmt.Sprintf("serveRequest: unknown status: host(%v) uri(%v) status(%v) body(%s).",
-				resp.Request.URL.String(), host, stateCode, strings.Replace(string(repsData), "\n", "", -1))
+			msg := fmt.Sprintf("serveRequest: unknown status: host(%v) uri(%v) requestID(%v) status(%v) body(%s).",
+				resp.Request.URL.String(), host, reqID, stateCode, strings.Replace(string(repsData), "\n", "", -1))
 			err = errors.New(msg)
 			log.LogErrorf(msg)
 			continue
@@ -286,7 +300,7 @@ func (c *MasterClient) requestOnce(r *request, host string) (data []byte, err er
 	}
 	if body.Code != proto.ErrCodeSuccess {
 		log.LogWarnf("serveRequest: code[%v], msg[%v], data[%v] ", body.Code, body.Msg, body.Data)
-		err = errors.New(body.Msg)
+		err = proto.NewCodeError(body.Code, body.Msg)
 		return
 	}
 	data = body.Bytes()
@@ -334,6 +348,12 @@ func (c *MasterClient) httpRequest(method, url string, r *request) (resp *http.R
 	for k, v := range r.header {
 		req.Header.Set(k, v)
 	}
+	c.RLock()
+	accessKey, secretKey := c.accessKey, c.secretKey
+	c.RUnlock()
+	if accessKey != "" {
+		auth.SignV4(req, r.body, accessKey, secretKey, auth.SigV4Region, auth.SigV4ServiceMaster, time.Now())
+	}
 	resp, err = client.Do(req)
 	return
 }
@@ -352,9 +372,11 @@ func (c *MasterClient) updateMaster(address string) {
 	c.leaderAddr = address
 }
 
-func (c *MasterClient) mergeRequestUrl(url string, params map[string]string) string {
+// mergeRequestUrl appends the params to the url as its query, escaped so that a free-text
+// value like a reason with a space, '&' or '#' reaches the master as it is.
+func (c *MasterClient) mergeRequestUrl(reqUrl string, params map[string]string) string {
 	if len(params) > 0 {
-		buff := bytes.NewBuffer([]byte(url))
+		buff := bytes.NewBuffer([]byte(reqUrl))
 		isFirstParam := true
 		for k, v := range params {
 			if isFirstParam {
@@ -363,13 +385,13 @@ func (c *MasterClient) mergeRequestUrl(url string, params map[string]string) str
 			} else {
 				buff.WriteString("&")
 			}
-			buff.WriteString(k)
+			buff.WriteString(url.QueryEscape(k))
 			buff.WriteString("=")
-			buff.WriteString(v)
+			buff.WriteString(url.QueryEscape(v))
 		}
 		return buff.String()
 	}
-	return url
+	return reqUrl
 }
 
 func NewMasterCLientWithResolver(masters []string, useSSL bool, updateInverval int) *MasterCLientWithResolver {
@@ -0,0 +1,56 @@
+// Copyright 2023 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRequestUrlEscapesParams(t *testing.T) {
+	mc := NewMasterClient(nil, false)
+	reqUrl := mc.mergeRequestUrl("http://master/admin/maintenance/start",
+		map[string]string{"reason": "switch upgrade & reboot #2"})
+	u, err := url.Parse(reqUrl)
+	require.NoError(t, err)
+	require.Empty(t, u.Fragment)
+	require.Equal(t, "switch upgrade & reboot #2", u.Query().Get("reason"))
+}
+
+func TestStartMaintenanceWithMultiWordReason(t *testing.T) {
+	const reason = "switch upgrade & reboot #2"
+	var got url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		got = r.Form
+		w.Write([]byte(`{"code":0,"msg":"success","data":null}`))
+	}))
+	defer ts.Close()
+
+	mc := NewMasterClient([]string{strings.TrimPrefix(ts.URL, "http://")}, false)
+	require.NoError(t, mc.AdminAPI().StartMaintenance(proto.MaintenanceScopeZone, "zone 1", 0, "2h", reason))
+	require.Equal(t, reason, got.Get("reason"))
+	require.Equal(t, "zone 1", got.Get("zoneName"))
+	require.Equal(t, "2h", got.Get("duration"))
+}
@@ -38,6 +38,16 @@ type anyParam struct {
 	val interface{}
 }
 
+// ListOption is the page and the filters of a list API, the zero value lists
+// all the items.
+type ListOption struct {
+	Offset int
+	Limit  int
+	Zone   string
+	Owner  string // of the volumes
+	Status string
+}
+
 var ReqHeaderUA = fmt.Sprintf("cubefs-sdk/%v (commit %v)", proto.Version, proto.CommitID)
 
 func (r *request) addParamAny(key string, value interface{}) *request {
@@ -57,6 +67,25 @@ func (r *request) Param(params ...anyParam) *request {
 	return r
 }
 
+func (r *request) listOption(opt ListOption) *request {
+	if opt.Offset > 0 {
+		r.addParamAny("offset", opt.Offset)
+	}
+	if opt.Limit > 0 {
+		r.addParamAny("limit", opt.Limit)
+	}
+	if opt.Zone != "" {
+		r.addParam("zoneName", opt.Zone)
+	}
+	if opt.Owner != "" {
+		r.addParam("owner", opt.Owner)
+	}
+	if opt.Status != "" {
+		r.addParam("status", opt.Status)
+	}
+	return r
+}
+
 func (r *request) Header(headers map[string]string, added ...string) *request {
 	if len(added)%2 == 1 {
 		added = added[:len(added)-1]
@@ -119,11 +119,16 @@ func (mw *MetaWrapper) Create_ll(parentID uint64, name string, mode, uid, gid ui
 		txMask = proto.TxOpMaskMknod
 	}
 	txType := proto.TxMaskToType(txMask)
-	if mw.enableTx(txMask) && txType != proto.TxTypeUndefined {
-		return mw.txCreate_ll(parentID, name, mode, uid, gid, target, txType, fullPath, ignoreExist)
-	} else {
-		return mw.create_ll(parentID, name, mode, uid, gid, target, fullPath, ignoreExist)
-	}
+	var info *proto.InodeInfo
+	err := mw.withDirShard(parentID, name, func(holder uint64) (err error) {
+		if mw.enableTx(txMask) && txType != proto.TxTypeUndefined {
+			info, err = mw.txCreate_ll(holder, name, mode, uid, gid, target, txType, fullPath, ignoreExist)
+		} else {
+			info, err = mw.create_ll(holder, name, mode, uid, gid, target, fullPath, ignoreExist)
+		}
+		return
+	})
+	return info, err
 }
 
 func (mw *MetaWrapper) txCreate_ll(parentID uint64, name string, mode, uid, gid uint32, target []byte, txType uint32,
@@ -143,6 +148,12 @@ func (mw *MetaWrapper) txCreate_ll(parentID uint64, name string, mode, uid, gid
 		return nil, syscall.ENOENT
 	}
 
+	// the inode takes the storageClass of the parent if it is set
+	dirStorageClass, err := mw.dirStorageClass(parentMP, parentID)
+	if err != nil {
+		return nil, err
+	}
+
 	var quotaIds []uint32
 	if mw.EnableQuota {
 		var quotaInfos map[uint32]*proto.MetaQuotaInfo
@@ -176,7 +187,7 @@ func (mw *MetaWrapper) txCreate_ll(parentID uint64, name string, mode, uid, gid
 			return nil, syscall.EAGAIN
 		}
 
-		status, info, err = mw.txIcreate(tx, mp, mode, uid, gid, target, quotaIds, fullPath)
+		status, info, err = mw.txIcreate(tx, mp, mode, uid, gid, target, quotaIds, fullPath, dirStorageClass)
 		if err == nil && status == statusOK {
 			goto create_dentry
 		} else if status == statusNoSpace || status == statusForbid {
@@ -240,6 +251,8 @@ func (mw *MetaWrapper) create_ll(parentID uint64, name string, mode, uid, gid ui
 		log.LogErrorf("Create_ll: parent inode's nlink quota reached, parentID(%v)", parentID)
 		return nil, syscall.EDQUOT
 	}
+	dirStorageClass := info.DirStorageClass
+	mw.sc.Put(parentID, dirStorageClass)
 
 get_rwmp:
 	rwPartitions = mw.getRWPartitions()
@@ -260,7 +273,7 @@ get_rwmp:
 		for i := 0; i < length; i++ {
 			index := (int(epoch) + i) % length
 			mp = rwPartitions[index]
-			status, info, err = mw.quotaIcreate(mp, mode, uid, gid, target, quotaIds, fullPath)
+			status, info, err = mw.quotaIcreate(mp, mode, uid, gid, target, quotaIds, fullPath, dirStorageClass)
 			if err == nil && status == statusOK {
 				goto create_dentry
 			} else if status == statusFull {
@@ -283,7 +296,7 @@ get_rwmp:
 		for i := 0; i < length; i++ {
 			index := (int(epoch) + i) % length
 			mp = rwPartitions[index]
-			status, info, err = mw.icreate(mp, mode, uid, gid, target, fullPath)
+			status, info, err = mw.icreate(mp, mode, uid, gid, target, fullPath, dirStorageClass)
 			if err == nil && status == statusOK {
 				goto create_dentry
 			} else if status == statusFull {
@@ -338,15 +351,21 @@ func (mw *MetaWrapper) Lookup_ll(parentID uint64, name string) (inode uint64, mo
 		//}
 	}()
 
-	parentMP := mw.getPartitionByInode(parentID)
-	if parentMP == nil {
-		log.LogErrorf("Lookup_ll: No parent partition, parentID(%v) name(%v)", parentID, name)
-		return 0, 0, syscall.ENOENT
-	}
-
-	status, inode, mode, err := mw.lookup(parentMP, parentID, name, mw.VerReadSeq)
-	if err != nil || status != statusOK {
-		return 0, 0, statusToErrno(status)
+	err = mw.withDirShard(parentID, name, func(holder uint64) error {
+		parentMP := mw.getPartitionByInode(holder)
+		if parentMP == nil {
+			log.LogErrorf("Lookup_ll: No parent partition, parentID(%v) name(%v)", holder, name)
+			return syscall.ENOENT
+		}
+		status, ino, m, err := mw.lookup(parentMP, holder, name, mw.VerReadSeq)
+		if err != nil || status != statusOK {
+			return statusToErrno(status)
+		}
+		inode, mode = ino, m
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
 	}
 	// only save dir
 	if proto.IsDir(mode) {
@@ -630,15 +649,19 @@ func (mw *MetaWrapper) shouldNotMoveToTrash(parentMP *MetaPartition, parentIno u
  * and the caller should make sure InodeInfo is valid before using it.
  */
 func (mw *MetaWrapper) Delete_ll(parentID uint64, name string, isDir bool, fullPath string) (*proto.InodeInfo, error) {
-	if mw.enableTx(proto.TxOpMaskRemove) {
-		return mw.txDelete_ll(parentID, name, isDir, fullPath)
-	} else {
-		return mw.Delete_ll_EX(parentID, name, isDir, 0, fullPath)
-	}
+	return mw.deleteWithDirShard(parentID, name, isDir, func(holder uint64) (*proto.InodeInfo, error) {
+		if mw.enableTx(proto.TxOpMaskRemove) {
+			return mw.txDelete_ll(holder, name, isDir, fullPath)
+		} else {
+			return mw.Delete_ll_EX(holder, name, isDir, 0, fullPath)
+		}
+	})
 }
 
 func (mw *MetaWrapper) DeleteWithCond_ll(parentID, cond uint64, name string, isDir bool, fullPath string) (*proto.InodeInfo, error) {
-	return mw.deletewithcond_ll(parentID, cond, name, isDir, fullPath)
+	return mw.deleteWithDirShard(parentID, name, isDir, func(holder uint64) (*proto.InodeInfo, error) {
+		return mw.deletewithcond_ll(holder, cond, name, isDir, fullPath)
+	})
 }
 
 func (mw *MetaWrapper) Delete_Ver_ll(parentID uint64, name string, isDir bool, verSeq uint64, fullPath string) (*proto.InodeInfo, error) {
@@ -1047,11 +1070,15 @@ func (mw *MetaWrapper) deletewithcond_ll(parentID, cond uint64, name string, isD
 }
 
 func (mw *MetaWrapper) Rename_ll(srcParentID uint64, srcName string, dstParentID uint64, dstName string, srcFullPath string, dstFullPath string, overwritten bool) (err error) {
-	if mw.enableTx(proto.TxOpMaskRename) {
-		return mw.txRename_ll(srcParentID, srcName, dstParentID, dstName, srcFullPath, dstFullPath, overwritten)
-	} else {
-		return mw.rename_ll(srcParentID, srcName, dstParentID, dstName, srcFullPath, dstFullPath, overwritten)
-	}
+	return mw.withDirShard(srcParentID, srcName, func(srcHolder uint64) error {
+		return mw.withDirShard(dstParentID, dstName, func(dstHolder uint64) error {
+			if mw.enableTx(proto.TxOpMaskRename) {
+				return mw.txRename_ll(srcHolder, srcName, dstHolder, dstName, srcFullPath, dstFullPath, overwritten)
+			} else {
+				return mw.rename_ll(srcHolder, srcName, dstHolder, dstName, srcFullPath, dstFullPath, overwritten)
+			}
+		})
+	})
 }
 
 func (mw *MetaWrapper) txRename_ll(srcParentID uint64, srcName string, dstParentID uint64, dstName string, srcFullPath string, dstFullPath string, overwritten bool) (err error) {
@@ -1326,6 +1353,338 @@ func (mw *MetaWrapper) ReadDir_ll(parentID uint64) ([]proto.Dentry, error) {
 	return children, nil
 }
 
+// BatchMove_ll moves the entries of srcParentID to dstParentID, keeping the
+// overwrite semantics of rename disabled. When both directories live on the
+// same meta partition the entries are moved in batches with one proposal per
+// batch, otherwise every entry falls back to Rename_ll. The result of each
+// entry is reported in order, err only reflects a failure of the whole call.
+//
+// The metanode can't see the ancestors of dstParentID, so a directory moved to
+// another parent is renamed by the client, and only if srcPath and dstPath,
+// the full paths of the parents, show that it is not an ancestor of
+// dstParentID. Without the paths the directories are left in place with
+// OpNotPerm, and one moved under itself fails with OpArgMismatchErr.
+//
+// On a volume with quotas, the entries are moved only between directories
+// under the same quotas, EPERM otherwise, and srcPath is required. Like a
+// rename, an entry holding the root of a quota is left in place with OpNotPerm.
+func (mw *MetaWrapper) BatchMove_ll(srcParentID, dstParentID uint64, srcPath, dstPath string,
+	items []proto.MoveDentryItem,
+) ([]proto.MoveDentryResult, error) {
+	return mw.moveDentries(mw, srcParentID, dstParentID, srcPath, dstPath, items)
+}
+
+// dentryMover sends the requests of BatchMove_ll to the metanodes.
+type dentryMover interface {
+	batchMoveDentry(mp *MetaPartition, srcParentID, dstParentID uint64, items []proto.MoveDentryItem) (int, []proto.MoveDentryResult, error)
+	Lookup_ll(parentID uint64, name string) (uint64, uint32, error)
+	Rename_ll(srcParentID uint64, srcName string, dstParentID uint64, dstName string, srcFullPath string, dstFullPath string, overwritten bool) error
+	GetInodeQuota_ll(inode uint64) (map[uint32]*proto.MetaQuotaInfo, error)
+}
+
+func (mw *MetaWrapper) moveDentries(m dentryMover, srcParentID, dstParentID uint64, srcPath, dstPath string,
+	items []proto.MoveDentryItem,
+) ([]proto.MoveDentryResult, error) {
+	results := make([]proto.MoveDentryResult, 0, len(items))
+	srcMP := mw.getPartitionByInode(srcParentID)
+	dstMP := mw.getPartitionByInode(dstParentID)
+	if srcMP == nil || dstMP == nil {
+		return nil, syscall.ENOENT
+	}
+	quotaPaths, err := mw.moveQuotaPaths(m, srcParentID, dstParentID, srcPath)
+	if err != nil {
+		return nil, err
+	}
+	_, srcSharded := mw.dirShards.Load(srcParentID)
+	_, dstSharded := mw.dirShards.Load(dstParentID)
+	batched := srcMP == dstMP && !srcSharded && !dstSharded && mw.FeatureEnabled(srcMP, proto.FeatureBatchMoveDentry)
+
+	for len(items) > 0 {
+		n := len(items)
+		if n > proto.MaxBatchMoveDentryCount {
+			n = proto.MaxBatchMoveDentryCount
+		}
+		batch := items[:n]
+		items = items[n:]
+
+		if batched {
+			sent := make([]proto.MoveDentryItem, 0, len(batch))
+			for _, item := range batch {
+				if !holdsQuota(srcPath, item.SrcName, quotaPaths) {
+					sent = append(sent, item)
+				}
+			}
+			if len(sent) == 0 {
+				results = append(results, quotaMoveResults(srcPath, batch, quotaPaths, nil)...)
+				continue
+			}
+			status, rets, err := m.batchMoveDentry(srcMP, srcParentID, dstParentID, sent)
+			if err == nil && status == statusOK {
+				for i := range rets {
+					// the directories moved to another parent
+					if rets[i].Status == proto.OpNotPerm && i < len(sent) {
+						rets[i].Status = renameDentry(m, srcParentID, dstParentID, srcPath, dstPath, sent[i], true)
+					}
+				}
+				results = append(results, quotaMoveResults(srcPath, batch, quotaPaths, rets)...)
+				continue
+			}
+			if err != nil || (status != statusNotPerm && status != statusDirSharded) {
+				log.LogErrorf("BatchMove_ll: spino(%v) dpino(%v) status(%v) err(%v)", srcParentID, dstParentID, status, err)
+				return results, statusToErrno(status)
+			}
+			// snapshot enabled or directory sharded, move one by one from now on
+			batched = false
+		}
+		for _, item := range batch {
+			status := uint8(proto.OpNotPerm)
+			if !holdsQuota(srcPath, item.SrcName, quotaPaths) {
+				status = renameDentry(m, srcParentID, dstParentID, srcPath, dstPath, item, false)
+			}
+			results = append(results, proto.MoveDentryResult{SrcName: item.SrcName, Status: status})
+		}
+	}
+	return results, nil
+}
+
+// moveQuotaPaths returns the full paths of the quota roots of the volume, the
+// entries of srcPath holding one are not moved, see canRenameByQuota of the
+// fuse client. The entries are moved only between the directories under the
+// same quotas, the usage of a quota is not handed over to another.
+func (mw *MetaWrapper) moveQuotaPaths(m dentryMover, srcParentID, dstParentID uint64, srcPath string) ([]string, error) {
+	if !mw.EnableQuota {
+		return nil, nil
+	}
+	quotaPaths := mw.GetQuotaFullPaths()
+	if len(quotaPaths) == 0 {
+		return nil, nil
+	}
+	if srcPath == "" {
+		log.LogErrorf("BatchMove_ll: spino(%v) dpino(%v) the path is required on a volume with quotas", srcParentID, dstParentID)
+		return nil, syscall.EPERM
+	}
+	if srcParentID == dstParentID {
+		return quotaPaths, nil
+	}
+	srcQuotas, err := m.GetInodeQuota_ll(srcParentID)
+	if err != nil {
+		return nil, syscall.EAGAIN
+	}
+	dstQuotas, err := m.GetInodeQuota_ll(dstParentID)
+	if err != nil {
+		return nil, syscall.EAGAIN
+	}
+	same := len(srcQuotas) == len(dstQuotas)
+	for id := range srcQuotas {
+		if _, ok := dstQuotas[id]; !ok {
+			same = false
+		}
+	}
+	if !same {
+		log.LogWarnf("BatchMove_ll: spino(%v) dpino(%v) under different quotas", srcParentID, dstParentID)
+		return nil, syscall.EPERM
+	}
+	return quotaPaths, nil
+}
+
+// holdsQuota reports whether the entry name of srcPath is the root of a quota
+// or one of its ancestors.
+func holdsQuota(srcPath, name string, quotaPaths []string) bool {
+	for _, quotaPath := range quotaPaths {
+		if proto.IsAncestor(path.Join(srcPath, name), quotaPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaMoveResults puts the results of the items sent back in the order of
+// batch, the ones holding a quota were not sent.
+func quotaMoveResults(srcPath string, batch []proto.MoveDentryItem, quotaPaths []string,
+	rets []proto.MoveDentryResult,
+) []proto.MoveDentryResult {
+	if len(quotaPaths) == 0 {
+		return rets
+	}
+	results := make([]proto.MoveDentryResult, 0, len(batch))
+	for _, item := range batch {
+		if holdsQuota(srcPath, item.SrcName, quotaPaths) {
+			results = append(results, proto.MoveDentryResult{SrcName: item.SrcName, Status: proto.OpNotPerm})
+		} else if len(rets) > 0 {
+			results = append(results, rets[0])
+			rets = rets[1:]
+		}
+	}
+	return results
+}
+
+// renameDentry moves an entry of BatchMove_ll by Rename_ll, the directories
+// to another parent only if the paths show they are not ancestors of the
+// destination.
+func renameDentry(m dentryMover, srcParentID, dstParentID uint64, srcPath, dstPath string,
+	item proto.MoveDentryItem, isDir bool,
+) uint8 {
+	var srcFullPath, dstFullPath string
+	if srcPath != "" && dstPath != "" {
+		srcFullPath, dstFullPath = path.Join(srcPath, item.SrcName), path.Join(dstPath, item.DstName)
+	}
+	if srcParentID != dstParentID {
+		if srcFullPath != "" {
+			if proto.IsAncestor(srcFullPath, dstPath) {
+				return proto.OpArgMismatchErr
+			}
+		} else if isDir {
+			return proto.OpNotPerm
+		} else {
+			_, mode, err := m.Lookup_ll(srcParentID, item.SrcName)
+			if err != nil {
+				return errnoToOpStatus(err)
+			}
+			if proto.IsDir(mode) {
+				return proto.OpNotPerm
+			}
+		}
+	}
+	if err := m.Rename_ll(srcParentID, item.SrcName, dstParentID, item.DstName, srcFullPath, dstFullPath, false); err != nil {
+		return errnoToOpStatus(err)
+	}
+	return proto.OpOk
+}
+
+// MoveDirEntries_ll moves all entries of srcDir into dstDir under the same
+// names, the subdirectories only with the paths of both, see BatchMove_ll.
+// Entries which already exist in dstDir are left in place.
+func (mw *MetaWrapper) MoveDirEntries_ll(srcDir, dstDir uint64, srcPath, dstPath string) (moved int, err error) {
+	if srcDir == dstDir {
+		return 0, syscall.EINVAL
+	}
+	children, err := mw.ReadDir_ll(srcDir)
+	if err != nil {
+		return
+	}
+	items := make([]proto.MoveDentryItem, 0, len(children))
+	for _, child := range children {
+		items = append(items, proto.MoveDentryItem{SrcName: child.Name, DstName: child.Name})
+	}
+	results, err := mw.BatchMove_ll(srcDir, dstDir, srcPath, dstPath, items)
+	for _, r := range results {
+		if r.Status == proto.OpOk {
+			moved++
+		}
+	}
+	log.LogDebugf("MoveDirEntries_ll: src(%v) dst(%v) moved(%v/%v) err(%v)", srcDir, dstDir, moved, len(items), err)
+	return
+}
+
+// DefragInodeCreate_ll creates an unlinked regular inode on the meta partition
+// of ino, used as scratch space to rewrite ino into large extents.
+func (mw *MetaWrapper) DefragInodeCreate_ll(ino uint64, fullPath string) (*proto.InodeInfo, error) {
+	mp := mw.getPartitionByInode(ino)
+	if mp == nil {
+		return nil, syscall.ENOENT
+	}
+	if !mw.FeatureEnabled(mp, proto.FeatureSwapExtents) {
+		return nil, syscall.EOPNOTSUPP
+	}
+	status, info, err := mw.icreate(mp, uint32(0o600), 0, 0, nil, fullPath, proto.StorageClass_Unspecified)
+	if err != nil || status != statusOK {
+		log.LogErrorf("DefragInodeCreate_ll: ino(%v) status(%v) err(%v)", ino, status, err)
+		return nil, statusErrToErrno(status, err)
+	}
+	return info, nil
+}
+
+// SwapExtents_ll hands the extents of tmpIno over to ino and the other way
+// round. It fails with EBUSY if ino was modified after generation gen.
+func (mw *MetaWrapper) SwapExtents_ll(ino, tmpIno, gen uint64) error {
+	mp := mw.getPartitionByInode(ino)
+	if mp == nil {
+		return syscall.ENOENT
+	}
+	if !mw.FeatureEnabled(mp, proto.FeatureSwapExtents) {
+		return syscall.EOPNOTSUPP
+	}
+	status, err := mw.swapExtents(mp, ino, tmpIno, gen)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case statusOK:
+		return nil
+	case StatusConflictExtents:
+		return syscall.EBUSY
+	default:
+		return statusToErrno(status)
+	}
+}
+
+// GetDirVersion_ll returns the version of the dentries of the directory, which
+// changes whenever one of them is created, deleted or updated by any client.
+// It fails with EOPNOTSUPP if the meta nodes do not keep the versions.
+func (mw *MetaWrapper) GetDirVersion_ll(parentID uint64) (uint64, error) {
+	if v, ok := mw.dirShards.Load(parentID); ok {
+		return mw.getDirShardsVersion(v.(*proto.DirShardLayout))
+	}
+	mp := mw.getPartitionByInode(parentID)
+	if mp == nil {
+		return 0, syscall.ENOENT
+	}
+	if !mw.FeatureEnabled(mp, proto.FeatureDirVersion) {
+		return 0, syscall.EOPNOTSUPP
+	}
+	status, version, err := mw.getDirVersion(mp, parentID)
+	if err != nil {
+		return 0, err
+	}
+	if status != statusOK {
+		return 0, statusToErrno(status)
+	}
+	return version, nil
+}
+
+// InlineDataEnabled tells whether the meta partition of ino stores inline data.
+func (mw *MetaWrapper) InlineDataEnabled(ino uint64) bool {
+	mp := mw.getPartitionByInode(ino)
+	return mp != nil && mw.FeatureEnabled(mp, proto.FeatureInlineData)
+}
+
+// SetInlineData_ll stores data, the whole content of a file of size bytes, in
+// its inode. It fails with EPERM if the file has extents.
+func (mw *MetaWrapper) SetInlineData_ll(ino uint64, data []byte, size uint64) error {
+	mp := mw.getPartitionByInode(ino)
+	if mp == nil {
+		return syscall.ENOENT
+	}
+	if !mw.FeatureEnabled(mp, proto.FeatureInlineData) {
+		return syscall.EOPNOTSUPP
+	}
+	status, err := mw.setInlineData(mp, ino, data, size)
+	if err != nil {
+		return err
+	}
+	if status != statusOK {
+		return statusToErrno(status)
+	}
+	return nil
+}
+
+// GetInlineData returns the data stored in the inode of a file without extents.
+func (mw *MetaWrapper) GetInlineData(ino uint64) (gen uint64, data []byte, err error) {
+	mp := mw.getPartitionByInode(ino)
+	if mp == nil {
+		return 0, nil, syscall.ENOENT
+	}
+	resp, err := mw.getExtents(mp, ino, false, false, false)
+	if err != nil {
+		if resp != nil {
+			err = statusToErrno(resp.Status)
+		}
+		log.LogErrorf("GetInlineData: ino(%v) err(%v)", ino, err)
+		return 0, nil, err
+	}
+	return resp.Generation, resp.InlineData, nil
+}
+
 // Read limit count dentries with parentID, start from string
 func (mw *MetaWrapper) ReadDirLimitForSnapShotClean(parentID uint64, from string, limit uint64, verSeq uint64, idDir bool) ([]proto.Dentry, error) {
 	if verSeq == 0 {
@@ -1341,7 +1700,7 @@ func (mw *MetaWrapper) ReadDirLimitForSnapShotClean(parentID uint64, from string
 	if idDir {
 		opt |= uint8(proto.FlagsSnapshotDelDir)
 	}
-	status, children, err := mw.readDirLimit(parentMP, parentID, from, limit, verSeq, opt)
+	status, children, err := mw.readDirLimit(parentMP, parentID, "", from, limit, verSeq, opt)
 	if err != nil || status != statusOK {
 		return nil, statusToErrno(status)
 	}
@@ -1353,44 +1712,75 @@ func (mw *MetaWrapper) ReadDirLimitForSnapShotClean(parentID uint64, from string
 
 // Read limit count dentries with parentID, start from string
 func (mw *MetaWrapper) ReadDirLimit_ll(parentID uint64, from string, limit uint64) ([]proto.Dentry, error) {
-	log.LogDebugf("action[ReadDirLimit_ll] parentID %v from %v limit %v", parentID, from, limit)
+	return mw.ReadDirPrefix_ll(parentID, "", from, limit)
+}
+
+// ReadDirPrefix_ll reads limit count dentries with parentID and names starting
+// with prefix, start from string. The dentries are read as a range from the
+// prefix on, the metanodes not knowing the prefix return the names after the
+// range too, which are cut here.
+func (mw *MetaWrapper) ReadDirPrefix_ll(parentID uint64, prefix, from string, limit uint64) ([]proto.Dentry, error) {
+	log.LogDebugf("action[ReadDirPrefix_ll] parentID %v prefix %v from %v limit %v", parentID, prefix, from, limit)
+	if from < prefix {
+		from = prefix
+	}
+	if v, ok := mw.dirShards.Load(parentID); ok {
+		return mw.readDirLimitShards(v.(*proto.DirShardLayout), prefix, from, limit)
+	}
 	parentMP := mw.getPartitionByInode(parentID)
 	if parentMP == nil {
 		return nil, syscall.ENOENT
 	}
 
-	status, children, err := mw.readDirLimit(parentMP, parentID, from, limit, mw.VerReadSeq, 0)
+	status, children, err := mw.readDirLimit(parentMP, parentID, prefix, from, limit, mw.VerReadSeq, 0)
+	if status == statusDirSharded {
+		if layout, _ := mw.GetDirShardLayout(parentID); layout != nil {
+			return mw.readDirLimitShards(layout, prefix, from, limit)
+		}
+	}
 	if err != nil || status != statusOK {
 		return nil, statusToErrno(status)
 	}
-	return children, nil
+	return cutPrefixRange(children, prefix), nil
 }
 
-func (mw *MetaWrapper) DentryCreate_ll(parentID uint64, name string, inode uint64, mode uint32, fullPath string) error {
-	parentMP := mw.getPartitionByInode(parentID)
-	if parentMP == nil {
-		return syscall.ENOENT
-	}
-	var err error
-	var status int
-	if status, err = mw.dcreate(parentMP, parentID, name, inode, mode, fullPath, false); err != nil || status != statusOK {
-		return statusToErrno(status)
+// cutPrefixRange cuts the sorted dentries read from the prefix on at the first
+// name without it.
+func cutPrefixRange(children []proto.Dentry, prefix string) []proto.Dentry {
+	for i := range children {
+		if !strings.HasPrefix(children[i].Name, prefix) {
+			return children[:i]
+		}
 	}
-	return nil
+	return children
+}
+
+func (mw *MetaWrapper) DentryCreate_ll(parentID uint64, name string, inode uint64, mode uint32, fullPath string) error {
+	return mw.withDirShard(parentID, name, func(holder uint64) error {
+		parentMP := mw.getPartitionByInode(holder)
+		if parentMP == nil {
+			return syscall.ENOENT
+		}
+		if status, err := mw.dcreate(parentMP, holder, name, inode, mode, fullPath, false); err != nil || status != statusOK {
+			return statusToErrno(status)
+		}
+		return nil
+	})
 }
 
 func (mw *MetaWrapper) DentryUpdate_ll(parentID uint64, name string, inode uint64, fullPath string) (oldInode uint64, err error) {
-	parentMP := mw.getPartitionByInode(parentID)
-	if parentMP == nil {
-		err = syscall.ENOENT
-		return
-	}
-	var status int
-	status, oldInode, err = mw.dupdate(parentMP, parentID, name, inode, fullPath)
-	if err != nil || status != statusOK {
-		err = statusToErrno(status)
-		return
-	}
+	err = mw.withDirShard(parentID, name, func(holder uint64) error {
+		parentMP := mw.getPartitionByInode(holder)
+		if parentMP == nil {
+			return syscall.ENOENT
+		}
+		status, old, err := mw.dupdate(parentMP, holder, name, inode, fullPath)
+		if err != nil || status != statusOK {
+			return statusToErrno(status)
+		}
+		oldInode = old
+		return nil
+	})
 	return
 }
 
@@ -1524,12 +1914,17 @@ func (mw *MetaWrapper) Truncate(inode, size uint64, fullPath string) error {
 }
 
 func (mw *MetaWrapper) Link(parentID uint64, name string, ino uint64, fullPath string) (*proto.InodeInfo, error) {
-	// if mw.EnableTransaction {
-	if mw.EnableTransaction&proto.TxOpMaskLink > 0 {
-		return mw.txLink(parentID, name, ino, fullPath)
-	} else {
-		return mw.link(parentID, name, ino, fullPath)
-	}
+	var info *proto.InodeInfo
+	err := mw.withDirShard(parentID, name, func(holder uint64) (err error) {
+		// if mw.EnableTransaction {
+		if mw.EnableTransaction&proto.TxOpMaskLink > 0 {
+			info, err = mw.txLink(holder, name, ino, fullPath)
+		} else {
+			info, err = mw.link(holder, name, ino, fullPath)
+		}
+		return
+	})
+	return info, err
 }
 
 func (mw *MetaWrapper) txLink(parentID uint64, name string, ino uint64, fullPath string) (info *proto.InodeInfo, err error) {
@@ -1712,6 +2107,25 @@ func (mw *MetaWrapper) Setattr(inode uint64, valid, mode, uid, gid uint32, atime
 	return nil
 }
 
+// SetStorageClass_ll sets the storageClass of an empty file, or the storageClass of the inodes created in a
+// directory, which the directories created in it take too, unspecified to unset it.
+func (mw *MetaWrapper) SetStorageClass_ll(inode uint64, storageClass uint32) error {
+	mp := mw.getPartitionByInode(inode)
+	if mp == nil {
+		log.LogErrorf("SetStorageClass_ll: No such partition, ino(%v)", inode)
+		return syscall.EINVAL
+	}
+
+	status, err := mw.setStorageClass(mp, inode, storageClass)
+	mw.sc.Delete(inode)
+	if err != nil || status != statusOK {
+		log.LogErrorf("SetStorageClass_ll: ino(%v) storageClass(%v) err(%v) status(%v)", inode, storageClass, err, status)
+		return statusToErrno(status)
+	}
+
+	return nil
+}
+
 func (mw *MetaWrapper) InodeCreate_ll(parentID uint64, mode, uid, gid uint32, target []byte, quotaIds []uint64, fullPath string) (*proto.InodeInfo, error) {
 	var (
 		status       int
@@ -1744,7 +2158,7 @@ get_rwmp:
 		for i := 0; i < length; i++ {
 			index := (int(epoch) + i) % length
 			mp = rwPartitions[index]
-			status, info, err = mw.quotaIcreate(mp, mode, uid, gid, target, quotaIds, fullPath)
+			status, info, err = mw.quotaIcreate(mp, mode, uid, gid, target, quotaIds, fullPath, proto.StorageClass_Unspecified)
 			if err == nil && status == statusOK {
 				return info, nil
 			} else if status == statusFull {
@@ -1767,7 +2181,7 @@ get_rwmp:
 		for i := 0; i < length; i++ {
 			index := (int(epoch) + i) % length
 			mp = rwPartitions[index]
-			status, info, err = mw.icreate(mp, mode, uid, gid, target, fullPath)
+			status, info, err = mw.icreate(mp, mode, uid, gid, target, fullPath, proto.StorageClass_Unspecified)
 			if err == nil && status == statusOK {
 				return info, nil
 			} else if status == statusFull {
@@ -3025,6 +3439,29 @@ func (mw *MetaWrapper) GetStorageClass() uint32 {
 	return atomic.LoadUint32(&mw.DefaultStorageClass)
 }
 
+// dirStorageClass returns the storageClass the inodes created in the directory
+// ino take, the cached one if it is not expired.
+func (mw *MetaWrapper) dirStorageClass(mp *MetaPartition, ino uint64) (uint32, error) {
+	if storageClass, ok := mw.sc.Get(ino); ok {
+		return storageClass, nil
+	}
+	status, info, err := mw.iget(mp, ino, mw.LastVerSeq)
+	if err != nil || status != statusOK {
+		return 0, statusToErrno(status)
+	}
+	mw.sc.Put(ino, info.DirStorageClass)
+	return info.DirStorageClass, nil
+}
+
+// createStorageClass returns the storageClass of an inode created in a directory of dirStorageClass, and
+// whether the directory created takes it for its inodes too.
+func (mw *MetaWrapper) createStorageClass(mode, dirStorageClass uint32) (storageClass uint32, setDirStorageClass bool) {
+	if dirStorageClass == proto.StorageClass_Unspecified {
+		return mw.GetStorageClass(), false
+	}
+	return dirStorageClass, proto.IsDir(mode)
+}
+
 func (mw *MetaWrapper) RenewalForbiddenMigration(inode uint64) error {
 	mp := mw.getPartitionByInode(inode)
 	if mp == nil {
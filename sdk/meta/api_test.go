@@ -0,0 +1,37 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCutPrefixRange(t *testing.T) {
+	dentries := func(names ...string) []proto.Dentry {
+		children := make([]proto.Dentry, 0, len(names))
+		for _, name := range names {
+			children = append(children, proto.Dentry{Name: name})
+		}
+		return children
+	}
+	// an old metanode reads on past the prefix
+	require.Equal(t, dentries("b0", "b1"), cutPrefixRange(dentries("b0", "b1", "c", "d"), "b"))
+	require.Equal(t, dentries("b0", "b1"), cutPrefixRange(dentries("b0", "b1"), "b"))
+	require.Empty(t, cutPrefixRange(dentries("c"), "b"))
+	require.Equal(t, dentries("a", "b"), cutPrefixRange(dentries("a", "b"), ""))
+}
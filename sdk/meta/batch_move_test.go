@@ -0,0 +1,252 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/btree"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMover struct {
+	batches [][]proto.MoveDentryItem
+	renames []string
+	dirs    map[string]bool
+	// status of the whole request, statusOK if zero
+	status int
+	// result of the batched items by name, OpOk if missing
+	results map[string]uint8
+	errs    map[string]error
+	// quota ids of the parents
+	quotas map[uint64][]uint32
+}
+
+func (f *fakeMover) batchMoveDentry(mp *MetaPartition, srcParentID, dstParentID uint64, items []proto.MoveDentryItem) (int, []proto.MoveDentryResult, error) {
+	f.batches = append(f.batches, items)
+	if f.status != 0 && f.status != statusOK {
+		return f.status, nil, nil
+	}
+	rets := make([]proto.MoveDentryResult, 0, len(items))
+	for _, item := range items {
+		status, ok := f.results[item.SrcName]
+		if !ok {
+			status = proto.OpOk
+		}
+		if f.dirs[item.SrcName] && srcParentID != dstParentID {
+			status = proto.OpNotPerm
+		}
+		rets = append(rets, proto.MoveDentryResult{SrcName: item.SrcName, Status: status})
+	}
+	return statusOK, rets, nil
+}
+
+func (f *fakeMover) Lookup_ll(parentID uint64, name string) (uint64, uint32, error) {
+	if f.dirs[name] {
+		return 1, proto.Mode(os.ModeDir | 0o755), nil
+	}
+	return 1, proto.Mode(0o644), nil
+}
+
+func (f *fakeMover) Rename_ll(srcParentID uint64, srcName string, dstParentID uint64, dstName string, srcFullPath string, dstFullPath string, overwritten bool) error {
+	f.renames = append(f.renames, fmt.Sprintf("%v/%v>%v/%v", srcParentID, srcName, dstParentID, dstName))
+	return f.errs[srcName]
+}
+
+func (f *fakeMover) GetInodeQuota_ll(inode uint64) (map[uint32]*proto.MetaQuotaInfo, error) {
+	infos := make(map[uint32]*proto.MetaQuotaInfo)
+	for _, id := range f.quotas[inode] {
+		infos[id] = &proto.MetaQuotaInfo{}
+	}
+	return infos, nil
+}
+
+func newBatchMoveWrapper() *MetaWrapper {
+	mw := &MetaWrapper{partitions: make(map[uint64]*MetaPartition), ranges: btree.New(32)}
+	mw.addPartition(&MetaPartition{PartitionID: 1, Start: 1, End: 1000})
+	mw.addPartition(&MetaPartition{PartitionID: 2, Start: 1001, End: 2000})
+	mw.clusterFeatures = uint64(proto.FeatureBatchMoveDentry)
+	return mw
+}
+
+func moveItems(names ...string) []proto.MoveDentryItem {
+	items := make([]proto.MoveDentryItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, proto.MoveDentryItem{SrcName: name, DstName: name})
+	}
+	return items
+}
+
+func moveStatus(results []proto.MoveDentryResult) map[string]uint8 {
+	m := make(map[string]uint8, len(results))
+	for _, r := range results {
+		m[r.SrcName] = r.Status
+	}
+	return m
+}
+
+func TestBatchMovePartialFailure(t *testing.T) {
+	mw := newBatchMoveWrapper()
+	f := &fakeMover{results: map[string]uint8{"b": proto.OpExistErr}}
+	results, err := mw.moveDentries(f, 10, 20, "", "", moveItems("a", "b", "c"))
+	require.NoError(t, err)
+	require.Len(t, f.batches, 1)
+	require.Empty(t, f.renames)
+	require.Equal(t, []proto.MoveDentryResult{
+		{SrcName: "a", Status: proto.OpOk},
+		{SrcName: "b", Status: proto.OpExistErr},
+		{SrcName: "c", Status: proto.OpOk},
+	}, results, "a failed item doesn't stop the batch")
+
+	// the items are split over the requests
+	f = &fakeMover{}
+	items := make([]proto.MoveDentryItem, proto.MaxBatchMoveDentryCount+1)
+	for i := range items {
+		items[i] = proto.MoveDentryItem{SrcName: fmt.Sprint(i), DstName: fmt.Sprint(i)}
+	}
+	results, err = mw.moveDentries(f, 10, 20, "", "", items)
+	require.NoError(t, err)
+	require.Len(t, results, len(items))
+	require.Len(t, f.batches, 2)
+	require.Len(t, f.batches[1], 1)
+
+	// the whole request failing
+	f = &fakeMover{status: statusNoSpace}
+	_, err = mw.moveDentries(f, 10, 20, "", "", moveItems("a"))
+	require.Equal(t, syscall.ENOSPC, err)
+}
+
+func TestBatchMoveFallback(t *testing.T) {
+	mw := newBatchMoveWrapper()
+
+	// the destination on another partition is never batched
+	f := &fakeMover{errs: map[string]error{"b": syscall.EEXIST}}
+	results, err := mw.moveDentries(f, 10, 1010, "", "", moveItems("a", "b"))
+	require.NoError(t, err)
+	require.Empty(t, f.batches)
+	require.Equal(t, []string{"10/a>1010/a", "10/b>1010/b"}, f.renames)
+	require.Equal(t, map[string]uint8{"a": proto.OpOk, "b": proto.OpExistErr}, moveStatus(results))
+
+	// nor the one the metanodes don't know
+	mw.clusterFeatures = uint64(proto.FeatureDirShard)
+	f = &fakeMover{}
+	_, err = mw.moveDentries(f, 10, 20, "", "", moveItems("a"))
+	require.NoError(t, err)
+	require.Empty(t, f.batches)
+	require.Len(t, f.renames, 1)
+	mw.clusterFeatures = uint64(proto.FeatureBatchMoveDentry)
+
+	// the partition refuses the batch, the rest is renamed one by one
+	f = &fakeMover{status: statusNotPerm}
+	items := make([]proto.MoveDentryItem, proto.MaxBatchMoveDentryCount+1)
+	for i := range items {
+		items[i] = proto.MoveDentryItem{SrcName: fmt.Sprint(i), DstName: fmt.Sprint(i)}
+	}
+	results, err = mw.moveDentries(f, 10, 20, "", "", items)
+	require.NoError(t, err)
+	require.Len(t, f.batches, 1)
+	require.Len(t, f.renames, len(items))
+	require.Len(t, results, len(items))
+
+	_, err = mw.moveDentries(f, 10, 3000, "", "", moveItems("a"))
+	require.Equal(t, syscall.ENOENT, err)
+}
+
+func TestBatchMoveDirCycle(t *testing.T) {
+	mw := newBatchMoveWrapper()
+
+	// the directories are left in place without the paths
+	f := &fakeMover{dirs: map[string]bool{"x": true}}
+	results, err := mw.moveDentries(f, 10, 20, "", "", moveItems("x", "a"))
+	require.NoError(t, err)
+	require.Empty(t, f.renames)
+	require.Equal(t, map[string]uint8{"x": proto.OpNotPerm, "a": proto.OpOk}, moveStatus(results))
+
+	f = &fakeMover{dirs: map[string]bool{"x": true}}
+	results, err = mw.moveDentries(f, 10, 1010, "", "", moveItems("x", "a"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10/a>1010/a"}, f.renames)
+	require.Equal(t, map[string]uint8{"x": proto.OpNotPerm, "a": proto.OpOk}, moveStatus(results))
+
+	// a directory moved under itself
+	f = &fakeMover{dirs: map[string]bool{"x": true}}
+	results, err = mw.moveDentries(f, 10, 20, "/p", "/p/x/y", moveItems("x"))
+	require.NoError(t, err)
+	require.Empty(t, f.renames)
+	require.Equal(t, proto.OpArgMismatchErr, results[0].Status)
+
+	f = &fakeMover{dirs: map[string]bool{"x": true}}
+	results, err = mw.moveDentries(f, 10, 1010, "/p", "/p/x", moveItems("x"))
+	require.NoError(t, err)
+	require.Empty(t, f.renames)
+	require.Equal(t, proto.OpArgMismatchErr, results[0].Status)
+
+	// elsewhere it is renamed
+	f = &fakeMover{dirs: map[string]bool{"x": true}}
+	results, err = mw.moveDentries(f, 10, 20, "/p", "/p/xy", moveItems("x"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10/x>20/x"}, f.renames)
+	require.Equal(t, proto.OpOk, results[0].Status)
+}
+
+func TestBatchMoveQuota(t *testing.T) {
+	mw := newBatchMoveWrapper()
+	mw.EnableQuota = true
+	mw.QuotaInfoMap = map[uint32]*proto.QuotaInfo{
+		1: {QuotaId: 1, PathInfos: []proto.QuotaPathInfo{{FullPath: "/p/q"}}},
+	}
+
+	// the usage would be left in the quota of the source
+	f := &fakeMover{quotas: map[uint64][]uint32{10: {1}}}
+	_, err := mw.moveDentries(f, 10, 20, "/p/q", "/p", moveItems("a"))
+	require.Equal(t, syscall.EPERM, err)
+	require.Empty(t, f.batches)
+	_, err = mw.moveDentries(f, 20, 10, "/p", "/p/q", moveItems("a"))
+	require.Equal(t, syscall.EPERM, err)
+	_, err = mw.moveDentries(f, 10, 20, "", "", moveItems("a"))
+	require.Equal(t, syscall.EPERM, err, "the path is required to find the quota roots")
+
+	// under the same quotas
+	f = &fakeMover{quotas: map[uint64][]uint32{10: {1}, 20: {1}}}
+	results, err := mw.moveDentries(f, 10, 20, "/p/q/x", "/p/q/y", moveItems("a", "b"))
+	require.NoError(t, err)
+	require.Len(t, f.batches, 1)
+	require.Equal(t, map[string]uint8{"a": proto.OpOk, "b": proto.OpOk}, moveStatus(results))
+
+	// the root of a quota and its ancestors stay in place, in the order of the items
+	f = &fakeMover{}
+	results, err = mw.moveDentries(f, 10, 20, "/p", "/r", moveItems("a", "q", "b"))
+	require.NoError(t, err)
+	require.Equal(t, []proto.MoveDentryItem{{SrcName: "a", DstName: "a"}, {SrcName: "b", DstName: "b"}}, f.batches[0])
+	require.Equal(t, []proto.MoveDentryResult{
+		{SrcName: "a", Status: proto.OpOk},
+		{SrcName: "q", Status: proto.OpNotPerm},
+		{SrcName: "b", Status: proto.OpOk},
+	}, results)
+	f = &fakeMover{}
+	results, err = mw.moveDentries(f, 10, 1010, "/", "/r", moveItems("p", "c"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10/c>1010/c"}, f.renames)
+	require.Equal(t, map[string]uint8{"p": proto.OpNotPerm, "c": proto.OpOk}, moveStatus(results))
+
+	// the metanode refuses the parents of different quotas
+	f = &fakeMover{status: statusForbid}
+	_, err = mw.moveDentries(f, 10, 20, "/p", "/r", moveItems("a"))
+	require.Equal(t, syscall.EPERM, err)
+}
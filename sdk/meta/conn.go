@@ -79,6 +79,9 @@ func (mw *MetaWrapper) sendToMetaPartitionLeader(mp *MetaPartition, req *proto.P
 	log.LogDebugf("mw.metaSendTimeout: %v s, sendTimeLimit: %v ms, delta: %v ms, req %v", mw.metaSendTimeout, sendTimeLimit, delta, req)
 
 	req.ExtentType |= proto.PacketProtocolVersionFlag
+	if !req.IsReadMetaPkt() {
+		req.SetFenceToken(mw.FenceToken())
+	}
 
 	errs := make(map[int]error, len(mp.Members))
 	var j int
@@ -275,6 +278,9 @@ func (mw *MetaWrapper) getMpHosts(mp *MetaPartition) (activeHosts, QuorumHosts [
 	)
 
 	for _, addr := range mp.Members {
+		if mp.isWitness(addr) {
+			continue
+		}
 		wg.Add(1)
 		go func(curAddr string) {
 			defer wg.Done()
@@ -0,0 +1,349 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"bytes"
+	"sort"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// errDirSharded is returned by the dentry operations when metanode reports
+// that the parent directory has been sharded, see proto.DirShardLayout.
+var errDirSharded = syscall.ESTALE
+
+// EnableDirShard_ll spreads the dentries of an empty directory over count
+// shard inodes, each on a different writable meta partition, and fails with
+// ENOSPC if there are not as many. Once sharded, all dentry operations on the
+// directory are routed by name hash transparently.
+//
+// A directory is only sharded on request, by the cfs.dirshard.count xattr set
+// while it is empty. It is not sharded as it grows, and a sharded directory is
+// never resharded, since the dentries placed by one hash can't be moved.
+//
+// The shard inodes are unlinked if the layout is not set, only a client dying
+// in between leaves them allocated, like the inode of a file whose dentry was
+// not created.
+func (mw *MetaWrapper) EnableDirShard_ll(ino uint64, count int) (err error) {
+	return mw.enableDirShard(mw, ino, count)
+}
+
+// dirShardPlacer is the part of the meta wrapper sharding a directory.
+type dirShardPlacer interface {
+	InodeGet_ll(inode uint64) (*proto.InodeInfo, error)
+	ReadDirLimit_ll(parentID uint64, from string, limit uint64) ([]proto.Dentry, error)
+	XAttrSet_ll(inode uint64, name, value []byte) error
+	XAttrGet_ll(inode uint64, name string) (*proto.XAttrInfo, error)
+	createDirShard(mp *MetaPartition, dirInfo *proto.InodeInfo, quotaIds []uint32) (uint64, error)
+	releaseDirShards(shards []uint64)
+}
+
+// enableDirShard shards ino by s. The shard inodes are created before the
+// layout is set, the ones placed are unlinked if it is not.
+func (mw *MetaWrapper) enableDirShard(s dirShardPlacer, ino uint64, count int) (err error) {
+	if count < proto.MinDirShardCount || count > proto.MaxDirShardCount {
+		return syscall.EINVAL
+	}
+	dirInfo, err := s.InodeGet_ll(ino)
+	if err != nil {
+		return
+	}
+	if !proto.IsDir(dirInfo.Mode) {
+		return syscall.ENOTDIR
+	}
+	if !mw.FeatureEnabled(mw.getPartitionByInode(ino), proto.FeatureDirShard) {
+		return syscall.EOPNOTSUPP
+	}
+	children, err := s.ReadDirLimit_ll(ino, "", 1)
+	if err != nil {
+		return
+	}
+	if len(children) > 0 {
+		return syscall.ENOTEMPTY
+	}
+
+	var quotaIds []uint32
+	if mp := mw.getPartitionByInode(ino); mw.EnableQuota && mp != nil {
+		quotaInfos, e := mw.getInodeQuota(mp, ino)
+		if e != nil {
+			log.LogErrorf("EnableDirShard_ll: get quota failed, ino(%v) err(%v)", ino, e)
+			return syscall.ENOENT
+		}
+		for quotaId := range quotaInfos {
+			quotaIds = append(quotaIds, quotaId)
+		}
+	}
+	var shards []uint64
+	defer func() {
+		if err != nil && len(shards) > 0 {
+			log.LogWarnf("EnableDirShard_ll: ino(%v) release shards %v, err(%v)", ino, shards, err)
+			s.releaseDirShards(shards)
+		}
+	}()
+	shards, err = placeDirShards(mw.getRWPartitions(), count, atomic.AddUint64(&mw.epoch, 1),
+		func(mp *MetaPartition) (uint64, error) {
+			return s.createDirShard(mp, dirInfo, quotaIds)
+		})
+	if err != nil {
+		log.LogErrorf("EnableDirShard_ll: create shard inodes failed, ino(%v) err(%v)", ino, err)
+		return
+	}
+
+	layout := proto.NewDirShardLayout(ino, shards)
+	value, err := layout.Marshal()
+	if err != nil {
+		return
+	}
+	if err = s.XAttrSet_ll(ino, []byte(proto.DirShardLayoutKey), value); err != nil {
+		log.LogErrorf("EnableDirShard_ll: set layout failed, ino(%v) err(%v)", ino, err)
+		// the layout may have been set though the reply was lost, its shards
+		// are not released then
+		if xattr, e := s.XAttrGet_ll(ino, proto.DirShardLayoutKey); e == nil && bytes.Equal(xattr.Get(proto.DirShardLayoutKey), value) {
+			err = nil
+		} else {
+			return
+		}
+	}
+	mw.dirShards.Store(ino, layout)
+	log.LogInfof("EnableDirShard_ll: ino(%v) sharded into %v", ino, shards)
+	return nil
+}
+
+// createDirShard creates a shard inode of the directory dirInfo on mp.
+func (mw *MetaWrapper) createDirShard(mp *MetaPartition, dirInfo *proto.InodeInfo, quotaIds []uint32) (uint64, error) {
+	var (
+		status int
+		info   *proto.InodeInfo
+		err    error
+	)
+	if len(quotaIds) > 0 {
+		status, info, err = mw.quotaIcreate(mp, dirInfo.Mode, dirInfo.Uid, dirInfo.Gid, nil, quotaIds, "", proto.StorageClass_Unspecified)
+	} else {
+		status, info, err = mw.icreate(mp, dirInfo.Mode, dirInfo.Uid, dirInfo.Gid, nil, "", proto.StorageClass_Unspecified)
+	}
+	if err == nil && status != statusOK {
+		err = statusToErrno(status)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Inode, nil
+}
+
+// placeDirShards creates count shards by create, each on a different
+// partition of rwPartitions tried from epoch on, and returns the shards
+// created with ENOSPC if the partitions are not enough.
+func placeDirShards(rwPartitions []*MetaPartition, count int, epoch uint64,
+	create func(mp *MetaPartition) (uint64, error),
+) (shards []uint64, err error) {
+	tried := make(map[uint64]bool, len(rwPartitions))
+	for i := 0; i < len(rwPartitions) && len(shards) < count; i++ {
+		mp := rwPartitions[(int(epoch)+i)%len(rwPartitions)]
+		if tried[mp.PartitionID] {
+			continue
+		}
+		tried[mp.PartitionID] = true
+		shard, e := create(mp)
+		if e != nil {
+			log.LogWarnf("placeDirShards: create shard on mp(%v) err(%v)", mp.PartitionID, e)
+			continue
+		}
+		shards = append(shards, shard)
+	}
+	if len(shards) < count {
+		return shards, syscall.ENOSPC
+	}
+	return shards, nil
+}
+
+// GetDirShardLayout returns the shard layout of the directory, or nil if the
+// directory is not sharded.
+func (mw *MetaWrapper) GetDirShardLayout(ino uint64) (*proto.DirShardLayout, error) {
+	if v, ok := mw.dirShards.Load(ino); ok {
+		return v.(*proto.DirShardLayout), nil
+	}
+	xattr, err := mw.XAttrGet_ll(ino, proto.DirShardLayoutKey)
+	if err != nil {
+		return nil, err
+	}
+	value := xattr.Get(proto.DirShardLayoutKey)
+	if len(value) == 0 {
+		return nil, nil
+	}
+	layout, err := proto.UnmarshalDirShardLayout(value)
+	if err != nil {
+		log.LogErrorf("GetDirShardLayout: bad layout, ino(%v) err(%v)", ino, err)
+		return nil, syscall.EIO
+	}
+	mw.dirShards.Store(ino, layout)
+	return layout, nil
+}
+
+// dirShardOf returns the inode which holds the dentry name of the directory.
+func (mw *MetaWrapper) dirShardOf(parentID uint64, name string) uint64 {
+	if v, ok := mw.dirShards.Load(parentID); ok {
+		return v.(*proto.DirShardLayout).ShardOf(name)
+	}
+	return parentID
+}
+
+// withDirShard runs op against the dentry holder of (parentID, name), and
+// retries once with a freshly loaded layout if the directory has been sharded
+// behind our back.
+func (mw *MetaWrapper) withDirShard(parentID uint64, name string, op func(holder uint64) error) error {
+	err := op(mw.dirShardOf(parentID, name))
+	if err != errDirSharded {
+		return err
+	}
+	if layout, _ := mw.GetDirShardLayout(parentID); layout == nil {
+		return err
+	}
+	return op(mw.dirShardOf(parentID, name))
+}
+
+// readDirLimitShards merges the sorted batches of all shards, so callers see
+// a single ordered stream as if the directory were not sharded.
+func (mw *MetaWrapper) readDirLimitShards(layout *proto.DirShardLayout, prefix, from string, limit uint64) ([]proto.Dentry, error) {
+	children := make([]proto.Dentry, 0)
+	for _, shard := range layout.Shards {
+		batch, err := mw.ReadDirPrefix_ll(shard, prefix, from, limit)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, batch...)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Name < children[j].Name
+	})
+	if uint64(len(children)) > limit {
+		children = children[:limit]
+	}
+	return children, nil
+}
+
+// getDirShardsVersion combines the versions of all shards, so that it changes
+// whenever one of them does.
+func (mw *MetaWrapper) getDirShardsVersion(layout *proto.DirShardLayout) (version uint64, err error) {
+	for _, shard := range layout.Shards {
+		var v uint64
+		if v, err = mw.GetDirVersion_ll(shard); err != nil {
+			return 0, err
+		}
+		version = version*31 + v
+	}
+	return
+}
+
+// dirShardOps looks up and seals the shards of a directory removed.
+type dirShardOps interface {
+	Lookup_ll(parentID uint64, name string) (uint64, uint32, error)
+	GetDirShardLayout(ino uint64) (*proto.DirShardLayout, error)
+	XAttrSet_ll(inode uint64, name, value []byte) error
+	XAttrDel_ll(inode uint64, name string) error
+	ReadDirLimit_ll(parentID uint64, from string, limit uint64) ([]proto.Dentry, error)
+}
+
+// sealDirShards keeps rmdir POSIX compliant: the dentries of a sharded
+// directory are not accounted in its nlink, so the shards are checked here.
+// They are sealed before, a sealed shard takes no new dentry, so that no entry
+// is created between the check and the removal and orphaned. The layout is
+// returned with the shards sealed, which must be unsealed if the removal fails,
+// or nil if the directory is not sharded.
+func sealDirShards(s dirShardOps, holder uint64, name string) (layout *proto.DirShardLayout, err error) {
+	ino, mode, err := s.Lookup_ll(holder, name)
+	if err != nil {
+		return nil, err
+	}
+	if !proto.IsDir(mode) {
+		return nil, nil
+	}
+	if layout, err = s.GetDirShardLayout(ino); err != nil || layout == nil {
+		return nil, err
+	}
+	sealed := 0
+	defer func() {
+		if err != nil {
+			unsealDirShards(s, layout.Shards[:sealed])
+			layout = nil
+		}
+	}()
+	for _, shard := range layout.Shards {
+		if err = s.XAttrSet_ll(shard, []byte(proto.DirShardSealedKey), []byte("1")); err != nil {
+			log.LogErrorf("sealDirShards: seal shard(%v) of ino(%v) err(%v)", shard, ino, err)
+			return
+		}
+		sealed++
+	}
+	for _, shard := range layout.Shards {
+		var children []proto.Dentry
+		if children, err = s.ReadDirLimit_ll(shard, "", 1); err != nil {
+			return
+		}
+		if len(children) > 0 {
+			err = syscall.ENOTEMPTY
+			return
+		}
+	}
+	return layout, nil
+}
+
+func unsealDirShards(s dirShardOps, shards []uint64) {
+	for _, shard := range shards {
+		if err := s.XAttrDel_ll(shard, proto.DirShardSealedKey); err != nil {
+			log.LogWarnf("unsealDirShards: unseal shard(%v) err(%v)", shard, err)
+		}
+	}
+}
+
+func (mw *MetaWrapper) deleteWithDirShard(parentID uint64, name string, isDir bool,
+	op func(holder uint64) (*proto.InodeInfo, error),
+) (info *proto.InodeInfo, err error) {
+	err = mw.withDirShard(parentID, name, func(holder uint64) (e error) {
+		var layout *proto.DirShardLayout
+		if isDir {
+			if layout, e = sealDirShards(mw, holder, name); e != nil {
+				return
+			}
+		}
+		info, e = op(holder)
+		if layout == nil {
+			return
+		}
+		if e != nil {
+			unsealDirShards(mw, layout.Shards)
+			return
+		}
+		mw.dirShards.Delete(layout.ParentIno)
+		mw.releaseDirShards(layout.Shards)
+		return
+	})
+	return
+}
+
+func (mw *MetaWrapper) releaseDirShards(shards []uint64) {
+	for _, shard := range shards {
+		if _, err := mw.InodeUnlink_ll(shard, ""); err != nil {
+			log.LogWarnf("releaseDirShards: unlink shard(%v) err(%v)", shard, err)
+			continue
+		}
+		if err := mw.Evict(shard, ""); err != nil {
+			log.LogWarnf("releaseDirShards: evict shard(%v) err(%v)", shard, err)
+		}
+	}
+}
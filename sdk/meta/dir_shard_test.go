@@ -0,0 +1,226 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceDirShards(t *testing.T) {
+	rw := []*MetaPartition{{PartitionID: 1}, {PartitionID: 2}, {PartitionID: 3}, {PartitionID: 2}, {PartitionID: 4}}
+	var placed []uint64
+	create := func(full uint64) func(mp *MetaPartition) (uint64, error) {
+		placed = placed[:0]
+		return func(mp *MetaPartition) (uint64, error) {
+			if mp.PartitionID == full {
+				return 0, syscall.ENOSPC
+			}
+			placed = append(placed, mp.PartitionID)
+			return mp.PartitionID * 100, nil
+		}
+	}
+
+	shards, err := placeDirShards(rw, 3, 1, create(0))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{200, 300, 400}, shards)
+	require.Equal(t, []uint64{2, 3, 4}, placed, "a partition listed twice holds one shard")
+
+	// a partition failing is skipped, the shards stay on different partitions
+	shards, err = placeDirShards(rw, 3, 0, create(2))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3, 4}, placed)
+	require.Len(t, shards, 3)
+
+	// the partitions are not enough
+	shards, err = placeDirShards(rw, 4, 0, create(3))
+	require.Equal(t, syscall.ENOSPC, err)
+	require.Equal(t, []uint64{100, 200, 400}, shards, "returned to be released")
+
+	_, err = placeDirShards(nil, 2, 0, create(0))
+	require.Equal(t, syscall.ENOSPC, err)
+}
+
+type fakeDirShards struct {
+	lookupErr error
+	layout    *proto.DirShardLayout
+	layoutErr error
+	sealErr   map[uint64]error
+	children  map[uint64][]proto.Dentry
+	sealed    map[uint64]bool
+}
+
+func (f *fakeDirShards) Lookup_ll(parentID uint64, name string) (uint64, uint32, error) {
+	if f.lookupErr != nil {
+		return 0, 0, f.lookupErr
+	}
+	return 10, proto.Mode(os.ModeDir | 0o755), nil
+}
+
+func (f *fakeDirShards) GetDirShardLayout(ino uint64) (*proto.DirShardLayout, error) {
+	return f.layout, f.layoutErr
+}
+
+func (f *fakeDirShards) XAttrSet_ll(inode uint64, name, value []byte) error {
+	if err := f.sealErr[inode]; err != nil {
+		return err
+	}
+	f.sealed[inode] = true
+	return nil
+}
+
+func (f *fakeDirShards) XAttrDel_ll(inode uint64, name string) error {
+	delete(f.sealed, inode)
+	return nil
+}
+
+func (f *fakeDirShards) ReadDirLimit_ll(parentID uint64, from string, limit uint64) ([]proto.Dentry, error) {
+	return f.children[parentID], nil
+}
+
+func TestSealDirShards(t *testing.T) {
+	newFake := func() *fakeDirShards {
+		return &fakeDirShards{
+			layout:   proto.NewDirShardLayout(10, []uint64{11, 12, 13}),
+			sealErr:  make(map[uint64]error),
+			children: make(map[uint64][]proto.Dentry),
+			sealed:   make(map[uint64]bool),
+		}
+	}
+
+	f := newFake()
+	layout, err := sealDirShards(f, 1, "d")
+	require.NoError(t, err)
+	require.Equal(t, f.layout, layout)
+	require.Equal(t, map[uint64]bool{11: true, 12: true, 13: true}, f.sealed)
+
+	// a transient error must not pass the directory off as unsharded
+	f = newFake()
+	f.layoutErr = syscall.EAGAIN
+	layout, err = sealDirShards(f, 1, "d")
+	require.Equal(t, syscall.EAGAIN, err)
+	require.Nil(t, layout)
+	f = newFake()
+	f.lookupErr = syscall.EIO
+	_, err = sealDirShards(f, 1, "d")
+	require.Equal(t, syscall.EIO, err)
+
+	f = newFake()
+	f.layout = nil
+	layout, err = sealDirShards(f, 1, "d")
+	require.NoError(t, err)
+	require.Nil(t, layout)
+
+	f = newFake()
+	f.children[12] = []proto.Dentry{{Name: "f1"}}
+	layout, err = sealDirShards(f, 1, "d")
+	require.Equal(t, syscall.ENOTEMPTY, err)
+	require.Nil(t, layout)
+	require.Empty(t, f.sealed)
+
+	f = newFake()
+	f.sealErr[13] = syscall.EIO
+	_, err = sealDirShards(f, 1, "d")
+	require.Equal(t, syscall.EIO, err)
+	require.Empty(t, f.sealed)
+}
+
+type fakeShardPlacer struct {
+	next     uint64
+	failOn   map[uint64]bool // partitions failing the creations
+	setErr   error
+	lost     bool // the layout is set though setErr is returned
+	xattrs   map[string][]byte
+	released []uint64
+}
+
+func (f *fakeShardPlacer) InodeGet_ll(inode uint64) (*proto.InodeInfo, error) {
+	return &proto.InodeInfo{Inode: inode, Mode: proto.Mode(os.ModeDir | 0o755)}, nil
+}
+
+func (f *fakeShardPlacer) ReadDirLimit_ll(parentID uint64, from string, limit uint64) ([]proto.Dentry, error) {
+	return nil, nil
+}
+
+func (f *fakeShardPlacer) XAttrSet_ll(inode uint64, name, value []byte) error {
+	if f.setErr != nil && !f.lost {
+		return f.setErr
+	}
+	f.xattrs[string(name)] = value
+	return f.setErr
+}
+
+func (f *fakeShardPlacer) XAttrGet_ll(inode uint64, name string) (*proto.XAttrInfo, error) {
+	info := &proto.XAttrInfo{Inode: inode, XAttrs: make(map[string]string)}
+	if value, ok := f.xattrs[name]; ok {
+		info.XAttrs[name] = string(value)
+	}
+	return info, nil
+}
+
+func (f *fakeShardPlacer) createDirShard(mp *MetaPartition, dirInfo *proto.InodeInfo, quotaIds []uint32) (uint64, error) {
+	if f.failOn[mp.PartitionID] {
+		return 0, syscall.EIO
+	}
+	f.next++
+	return 100 + f.next, nil
+}
+
+func (f *fakeShardPlacer) releaseDirShards(shards []uint64) {
+	f.released = append(f.released, shards...)
+}
+
+func TestEnableDirShardFailure(t *testing.T) {
+	mw := newBatchMoveWrapper()
+	mw.addPartition(&MetaPartition{PartitionID: 3, Start: 2001, End: 3000})
+	mw.clusterFeatures = uint64(proto.FeatureDirShard)
+	newFake := func() *fakeShardPlacer {
+		return &fakeShardPlacer{failOn: make(map[uint64]bool), xattrs: make(map[string][]byte)}
+	}
+
+	f := newFake()
+	require.NoError(t, mw.enableDirShard(f, 10, 3))
+	require.Empty(t, f.released)
+	layout, ok := mw.dirShards.Load(uint64(10))
+	require.True(t, ok)
+	require.Len(t, layout.(*proto.DirShardLayout).Shards, 3)
+
+	// the shards placed before the partitions ran out are unlinked
+	f = newFake()
+	f.failOn[2] = true
+	require.Equal(t, syscall.ENOSPC, mw.enableDirShard(f, 20, 3))
+	require.Len(t, f.released, 2)
+	_, ok = mw.dirShards.Load(uint64(20))
+	require.False(t, ok)
+
+	// and the ones of a layout that could not be set
+	f = newFake()
+	f.setErr = syscall.EAGAIN
+	require.Equal(t, syscall.EAGAIN, mw.enableDirShard(f, 30, 3))
+	require.Len(t, f.released, 3)
+	require.Empty(t, f.xattrs)
+
+	// but not the ones of a layout set whose reply was lost
+	f = newFake()
+	f.setErr, f.lost = syscall.EAGAIN, true
+	require.NoError(t, mw.enableDirShard(f, 40, 3))
+	require.Empty(t, f.released)
+	_, ok = mw.dirShards.Load(uint64(40))
+	require.True(t, ok)
+}
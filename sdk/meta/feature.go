@@ -0,0 +1,92 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const featureNegotiateTTL = 5 * time.Minute
+
+type negotiatedFeatures struct {
+	bits   proto.FeatureBits
+	expire time.Time
+}
+
+// FeatureEnabled tells whether the operations guarded by bits may be sent to
+// mp. The cluster feature matrix of master is authoritative; if master does
+// not report one, the leader of mp is asked directly.
+func (mw *MetaWrapper) FeatureEnabled(mp *MetaPartition, bits proto.FeatureBits) bool {
+	if cluster := proto.FeatureBits(atomic.LoadUint64(&mw.clusterFeatures)); cluster != 0 {
+		return cluster.Has(bits)
+	}
+	if mp == nil || mp.LeaderAddr == "" {
+		return false
+	}
+	return mw.getPeerFeatures(mp.LeaderAddr).Has(bits)
+}
+
+// SmallFilePackEnabled tells whether the small files may be packed into the
+// container extents, which takes every DataNode of the cluster to support it.
+func (mw *MetaWrapper) SmallFilePackEnabled() bool {
+	return mw.FeatureEnabled(nil, proto.FeatureSmallFilePack)
+}
+
+func (mw *MetaWrapper) getPeerFeatures(addr string) proto.FeatureBits {
+	if v, ok := mw.peerFeatures.Load(addr); ok {
+		if nf := v.(*negotiatedFeatures); time.Now().Before(nf.expire) {
+			return nf.bits
+		}
+	}
+	bits, err := mw.negotiateFeatures(addr)
+	if err != nil {
+		// metanodes without negotiation drop the request, treat them as legacy
+		log.LogWarnf("getPeerFeatures: addr(%v) err(%v), assume no features", addr, err)
+	}
+	mw.peerFeatures.Store(addr, &negotiatedFeatures{bits: bits, expire: time.Now().Add(featureNegotiateTTL)})
+	return bits
+}
+
+func (mw *MetaWrapper) negotiateFeatures(addr string) (bits proto.FeatureBits, err error) {
+	packet := proto.NewPacketReqID()
+	packet.Opcode = proto.OpNegotiateFeatures
+	if err = packet.MarshalData(&proto.FeatureNegotiation{Features: proto.MetaNodeFeatures, Version: proto.Version}); err != nil {
+		return
+	}
+	mc, err := mw.getConn(0, addr)
+	if err != nil {
+		return
+	}
+	resp, err := mc.send(packet)
+	mw.putConn(mc, err)
+	if err != nil {
+		return
+	}
+	if resp.ResultCode != proto.OpOk {
+		log.LogInfof("negotiateFeatures: addr(%v) result(%v)", addr, resp.GetResultMsg())
+		return
+	}
+	reply := &proto.FeatureNegotiation{}
+	if err = json.Unmarshal(resp.Data, reply); err != nil {
+		return
+	}
+	log.LogInfof("negotiateFeatures: addr(%v) version(%v) features(%v)", addr, reply.Version, reply.Features)
+	return reply.Features, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"syscall"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+// A subtree is forked into another volume of the cluster by exporting its
+// metadata with ExportTree from the source and importing it with a
+// ForkImporter into the target, once the master registers the target as
+// forked from the source. The imported files refer to the extents of the
+// source, so the fork takes no time nor space whatever the size of the data.
+
+const (
+	forkReadDirLimit    = 1024
+	forkAppendExtentNum = 1024
+)
+
+// ExportTree calls fn with the record of every directory, file and symlink
+// under the directory subdir, the parents before their children. The hard
+// links are exported as copies.
+func (mw *MetaWrapper) ExportTree(subdir string, fn func(record *proto.ForkRecord) error) error {
+	ino, err := mw.LookupPath(subdir)
+	if err != nil {
+		return err
+	}
+	info, err := mw.InodeGet_ll(ino)
+	if err != nil {
+		return err
+	}
+	if !proto.IsDir(info.Mode) {
+		return syscall.ENOTDIR
+	}
+	return mw.exportDir(ino, "", fn)
+}
+
+func (mw *MetaWrapper) exportDir(ino uint64, dir string, fn func(record *proto.ForkRecord) error) error {
+	marker := ""
+	for {
+		children, err := mw.ReadDirLimit_ll(ino, marker, forkReadDirLimit)
+		if err != nil && err != syscall.ENOENT {
+			return err
+		}
+		if marker != "" && len(children) > 0 && children[0].Name == marker {
+			children = children[1:]
+		}
+		if len(children) == 0 {
+			return nil
+		}
+		for _, child := range children {
+			if err = mw.exportInode(child.Inode, path.Join(dir, child.Name), fn); err != nil {
+				return err
+			}
+		}
+		marker = children[len(children)-1].Name
+	}
+}
+
+func (mw *MetaWrapper) exportInode(ino uint64, relPath string, fn func(record *proto.ForkRecord) error) error {
+	info, err := mw.InodeGet_ll(ino)
+	if err != nil {
+		return fmt.Errorf("export %v: %v", relPath, err)
+	}
+	record := &proto.ForkRecord{
+		Path:       relPath,
+		Mode:       info.Mode,
+		Uid:        info.Uid,
+		Gid:        info.Gid,
+		Size:       info.Size,
+		ModifyTime: info.ModifyTime.Unix(),
+		AccessTime: info.AccessTime.Unix(),
+	}
+	switch {
+	case proto.IsDir(info.Mode):
+		if err = fn(record); err != nil {
+			return err
+		}
+		return mw.exportDir(ino, relPath, fn)
+	case proto.IsSymlink(info.Mode):
+		record.Target = info.Target
+	case proto.IsRegular(info.Mode):
+		if proto.IsStorageClassBlobStore(info.StorageClass) {
+			return fmt.Errorf("export %v: the files in blobstore can not be forked", relPath)
+		}
+		if err = mw.exportExtents(ino, record); err != nil {
+			return fmt.Errorf("export %v: %v", relPath, err)
+		}
+	}
+	return fn(record)
+}
+
+// exportExtents sets the extents or the inline data of the file to the record,
+// without the snapshot info of the extents which is of the source.
+func (mw *MetaWrapper) exportExtents(ino uint64, record *proto.ForkRecord) error {
+	mp := mw.getPartitionByInode(ino)
+	if mp == nil {
+		return syscall.ENOENT
+	}
+	resp, err := mw.getExtents(mp, ino, false, false, false)
+	if err != nil {
+		if resp != nil {
+			err = statusToErrno(resp.Status)
+		}
+		return err
+	}
+	record.Size = resp.Size
+	record.Inline = resp.InlineData
+	record.Extents = make([]proto.ExtentKey, 0, len(resp.Extents))
+	for _, ek := range resp.Extents {
+		record.Extents = append(record.Extents, proto.ExtentKey{
+			FileOffset:   ek.FileOffset,
+			PartitionId:  ek.PartitionId,
+			ExtentId:     ek.ExtentId,
+			ExtentOffset: ek.ExtentOffset,
+			Size:         ek.Size,
+			CRC:          ek.CRC,
+		})
+	}
+	return nil
+}
+
+// ForkImporter imports the records exported by ExportTree under a directory
+// of the volume, which must be forked from the volume of the records.
+type ForkImporter struct {
+	mw       *MetaWrapper
+	root     uint64
+	rootPath string
+	dirs     map[string]uint64 // the imported directories by their paths
+}
+
+// NewForkImporter returns an importer of the records under the directory ino at rootPath.
+func (mw *MetaWrapper) NewForkImporter(ino uint64, rootPath string) *ForkImporter {
+	return &ForkImporter{mw: mw, root: ino, rootPath: rootPath, dirs: make(map[string]uint64)}
+}
+
+// Import creates the record under the root of the importer, its parent must be
+// imported before. It fails with EEXIST if the record exists.
+func (im *ForkImporter) Import(record *proto.ForkRecord) (err error) {
+	dir, name := path.Split(record.Path)
+	if name == "" || strings.HasPrefix(dir, "/") {
+		return fmt.Errorf("import %v: invalid path", record.Path)
+	}
+	parent := im.root
+	if dir = strings.TrimSuffix(dir, "/"); dir != "" {
+		var ok bool
+		if parent, ok = im.dirs[dir]; !ok {
+			return fmt.Errorf("import %v: parent not imported", record.Path)
+		}
+	}
+	fullPath := path.Join(im.rootPath, record.Path)
+	info, err := im.mw.Create_ll(parent, name, record.Mode, record.Uid, record.Gid, record.Target, fullPath, false)
+	if err != nil {
+		return fmt.Errorf("import %v: %v", record.Path, err)
+	}
+	ino := info.Inode
+	switch {
+	case proto.IsDir(record.Mode):
+		im.dirs[record.Path] = ino
+	case proto.IsRegular(record.Mode):
+		if err = im.importData(info, record); err != nil {
+			return fmt.Errorf("import %v: %v", record.Path, err)
+		}
+	}
+	if err = im.mw.Setattr(ino, proto.AttrModifyTime|proto.AttrAccessTime, 0, 0, 0,
+		record.AccessTime, record.ModifyTime); err != nil {
+		log.LogWarnf("ForkImporter: import %v set times err(%v)", record.Path, err)
+	}
+	return nil
+}
+
+func (im *ForkImporter) importData(info *proto.InodeInfo, record *proto.ForkRecord) (err error) {
+	if len(record.Inline) > 0 {
+		return im.mw.SetInlineData_ll(info.Inode, record.Inline, record.Size)
+	}
+	end := uint64(0)
+	for i := 0; i < len(record.Extents); i += forkAppendExtentNum {
+		eks := record.Extents[i:]
+		if len(eks) > forkAppendExtentNum {
+			eks = eks[:forkAppendExtentNum]
+		}
+		if err = im.mw.AppendExtentKeys(info.Inode, eks, info.StorageClass); err != nil {
+			return
+		}
+		for _, ek := range eks {
+			if ekEnd := ek.FileOffset + uint64(ek.Size); ekEnd > end {
+				end = ekEnd
+			}
+		}
+	}
+	// the hole at the end of the file
+	if record.Size > end {
+		err = im.mw.Truncate(info.Inode, record.Size, path.Join(im.rootPath, record.Path))
+	}
+	return
+}
@@ -15,8 +15,11 @@
 package meta
 
 import (
+	"crypto/tls"
+	goerrors "errors"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -61,6 +64,7 @@ const (
 	statusNotEmpty
 	statusLeaseOccupiedByOthers
 	statusLeaseGenerationNotMatch
+	statusDirSharded
 )
 
 const (
@@ -103,6 +107,9 @@ type MetaConfig struct {
 	VerReadSeq           uint64
 	InnerReq             bool
 	DisableTrashByClient bool
+
+	PacketCompressThreshold int
+	TLSConfig               *tls.Config // talk to the meta nodes over TLS if set
 }
 
 type MetaWrapper struct {
@@ -172,6 +179,7 @@ type MetaWrapper struct {
 	uniqidRangeMutex sync.Mutex
 
 	qc *QuotaCache
+	sc *DirStorageClassCache
 	// trash
 	TrashInterval int64
 	trashPolicy   *Trash
@@ -192,6 +200,17 @@ type MetaWrapper struct {
 	FollowerRead        bool
 
 	RemoteCacheBloom func() *bloom.BloomFilter
+
+	// shard layouts of large directories, indexed by directory inode
+	dirShards sync.Map
+
+	// features enabled on the whole cluster as reported by master, and the
+	// ones negotiated with single metanodes, indexed by address
+	clusterFeatures uint64
+	peerFeatures    sync.Map
+
+	// the fencing token sent with the writes, 0 for none
+	fenceToken uint64
 }
 
 type uniqidRange struct {
@@ -240,6 +259,8 @@ func NewMetaWrapper(config *MetaConfig) (*MetaWrapper, error) {
 	mw.onAsyncTaskError = config.OnAsyncTaskError
 	mw.metaSendTimeout = config.MetaSendTimeout
 	mw.conns = util.NewConnectPool()
+	mw.conns.SetCompressThreshold(config.PacketCompressThreshold)
+	mw.conns.SetTLSConfig(config.TLSConfig)
 	mw.partitions = make(map[uint64]*MetaPartition)
 	mw.ranges = btree.New(32)
 	mw.rwPartitions = make([]*MetaPartition, 0)
@@ -249,6 +270,7 @@ func NewMetaWrapper(config *MetaConfig) (*MetaWrapper, error) {
 	mw.DirChildrenNumLimit = proto.DefaultDirChildrenNumLimit
 	mw.uniqidRangeMap = make(map[uint64]*uniqidRange)
 	mw.qc = NewQuotaCache(DefaultQuotaExpiration, MaxQuotaCache)
+	mw.sc = NewDirStorageClassCache(DefaultDirStorageClassExpiration, MaxDirStorageClassCache)
 	mw.VerReadSeq = config.VerReadSeq
 	mw.dirCache = make(map[uint64]dirInfoCache)
 	mw.subDir = config.SubDir
@@ -268,7 +290,7 @@ func NewMetaWrapper(config *MetaConfig) (*MetaWrapper, error) {
 			}
 			log.LogErrorf("NewMetaWrapper: init meta wrapper failed: volume(%v) err(%v)", mw.volname, err)
 		}
-		if err == proto.ErrVolNotExists {
+		if goerrors.Is(err, proto.ErrVolNotExists) {
 			return nil, err
 		}
 		if err != nil {
@@ -369,6 +391,16 @@ func (mw *MetaWrapper) LocalIP() string {
 	return mw.localIP
 }
 
+// SetFenceToken sets the fencing token of the writes to the metanodes, which
+// reject them once master fences the volume with a newer token.
+func (mw *MetaWrapper) SetFenceToken(token uint64) {
+	atomic.StoreUint64(&mw.fenceToken, token)
+}
+
+func (mw *MetaWrapper) FenceToken() uint64 {
+	return atomic.LoadUint64(&mw.fenceToken)
+}
+
 // Proto ResultCode to status
 func parseStatus(result uint8) (status int) {
 	switch result {
@@ -408,6 +440,8 @@ func parseStatus(result uint8) (status int) {
 		status = statusLeaseOccupiedByOthers
 	case proto.OpLeaseGenerationNotMatch:
 		status = statusLeaseGenerationNotMatch
+	case proto.OpDirShardedErr:
+		status = statusDirSharded
 	default:
 		status = statusError
 	}
@@ -463,7 +497,32 @@ func statusToErrno(status int) error {
 		return errors.New("lease occupied by others")
 	case statusLeaseGenerationNotMatch:
 		return errors.New("lease generation not match")
+	case statusDirSharded:
+		return errDirSharded
 	default:
 	}
 	return syscall.EIO
 }
+
+// errnoToOpStatus reports the errno of a per-entry fallback operation with the
+// result code metanode would have used, see BatchMove_ll.
+func errnoToOpStatus(err error) uint8 {
+	switch err {
+	case nil:
+		return proto.OpOk
+	case syscall.EEXIST:
+		return proto.OpExistErr
+	case syscall.ENOENT:
+		return proto.OpNotExistErr
+	case syscall.ENOTEMPTY:
+		return proto.OpNotEmpty
+	case syscall.EINVAL:
+		return proto.OpArgMismatchErr
+	case syscall.EPERM:
+		return proto.OpNotPerm
+	case syscall.EDQUOT:
+		return proto.OpDirQuota
+	default:
+	}
+	return proto.OpErr
+}
@@ -31,7 +31,7 @@ import (
 //
 // txIcreate create inode and tx together
 func (mw *MetaWrapper) txIcreate(tx *Transaction, mp *MetaPartition, mode, uid, gid uint32,
-	target []byte, quotaIds []uint32, fullPath string,
+	target []byte, quotaIds []uint32, fullPath string, dirStorageClass uint32,
 ) (status int, info *proto.InodeInfo, err error) {
 	bgTime := stat.BeginStat()
 	defer func() {
@@ -49,8 +49,8 @@ func (mw *MetaWrapper) txIcreate(tx *Transaction, mp *MetaPartition, mode, uid,
 		Target:      target,
 		QuotaIds:    quotaIds,
 		TxInfo:      tx.txInfo,
-		StorageType: mw.DefaultStorageClass,
 	}
+	req.StorageType, req.SetDirStorageClass = mw.createStorageClass(mode, dirStorageClass)
 	req.FullPaths = []string{fullPath}
 
 	resp := new(proto.TxCreateInodeResponse)
@@ -108,7 +108,8 @@ func (mw *MetaWrapper) txIcreate(tx *Transaction, mp *MetaPartition, mode, uid,
 	return status, resp.Info, nil
 }
 
-func (mw *MetaWrapper) quotaIcreate(mp *MetaPartition, mode, uid, gid uint32, target []byte, quotaIds []uint32, fullPath string) (status int,
+func (mw *MetaWrapper) quotaIcreate(mp *MetaPartition, mode, uid, gid uint32, target []byte, quotaIds []uint32, fullPath string,
+	dirStorageClass uint32) (status int,
 	info *proto.InodeInfo, err error,
 ) {
 	bgTime := stat.BeginStat()
@@ -124,8 +125,8 @@ func (mw *MetaWrapper) quotaIcreate(mp *MetaPartition, mode, uid, gid uint32, ta
 		Gid:         gid,
 		Target:      target,
 		QuotaIds:    quotaIds,
-		StorageType: mw.DefaultStorageClass,
 	}
+	req.StorageType, req.SetDirStorageClass = mw.createStorageClass(mode, dirStorageClass)
 	req.FullPaths = []string{fullPath}
 
 	packet := proto.NewPacketReqID()
@@ -170,7 +171,8 @@ func (mw *MetaWrapper) quotaIcreate(mp *MetaPartition, mode, uid, gid uint32, ta
 	return statusOK, resp.Info, nil
 }
 
-func (mw *MetaWrapper) icreate(mp *MetaPartition, mode, uid, gid uint32, target []byte, fullPath string) (status int,
+func (mw *MetaWrapper) icreate(mp *MetaPartition, mode, uid, gid uint32, target []byte, fullPath string,
+	dirStorageClass uint32) (status int,
 	info *proto.InodeInfo, err error,
 ) {
 	bgTime := stat.BeginStat()
@@ -185,8 +187,8 @@ func (mw *MetaWrapper) icreate(mp *MetaPartition, mode, uid, gid uint32, target
 		Uid:         uid,
 		Gid:         gid,
 		Target:      target,
-		StorageType: mw.DefaultStorageClass,
 	}
+	req.StorageType, req.SetDirStorageClass = mw.createStorageClass(mode, dirStorageClass)
 
 	req.FullPaths = []string{fullPath}
 
@@ -1146,7 +1148,188 @@ func (mw *MetaWrapper) batchIget(wg *sync.WaitGroup, mp *MetaPartition, inodes [
 }
 
 // read limit dentries start from
-func (mw *MetaWrapper) readDirLimit(mp *MetaPartition, parentID uint64, from string, limit uint64, verSeq uint64, verOpt uint8) (status int, children []proto.Dentry, err error) {
+func (mw *MetaWrapper) batchMoveDentry(mp *MetaPartition, srcParentID, dstParentID uint64, items []proto.MoveDentryItem) (status int, results []proto.MoveDentryResult, err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("batchMoveDentry", err, bgTime, 1)
+	}()
+
+	req := &proto.BatchMoveDentryRequest{
+		VolName:     mw.volname,
+		PartitionID: mp.PartitionID,
+		SrcParentID: srcParentID,
+		DstParentID: dstParentID,
+		Items:       items,
+	}
+
+	packet := proto.NewPacketReqID()
+	packet.Opcode = proto.OpMetaBatchMoveDentry
+	packet.PartitionID = mp.PartitionID
+	err = packet.MarshalData(req)
+	if err != nil {
+		log.LogErrorf("batchMoveDentry: req(%v) err(%v)", *req, err)
+		return
+	}
+
+	metric := exporter.NewTPCnt(packet.GetOpMsg())
+	defer func() {
+		metric.SetWithLabels(err, map[string]string{exporter.Vol: mw.volname})
+	}()
+
+	packet, err = mw.sendToMetaPartition(mp, packet)
+	if err != nil {
+		log.LogErrorf("batchMoveDentry: packet(%v) mp(%v) spino(%v) dpino(%v) err(%v)", packet, mp, srcParentID, dstParentID, err)
+		return
+	}
+
+	status = parseStatus(packet.ResultCode)
+	if status != statusOK {
+		log.LogErrorf("batchMoveDentry: packet(%v) mp(%v) spino(%v) dpino(%v) result(%v)", packet, mp, srcParentID, dstParentID, packet.GetResultMsg())
+		return
+	}
+
+	resp := new(proto.BatchMoveDentryResponse)
+	err = packet.UnmarshalData(resp)
+	if err != nil {
+		log.LogErrorf("batchMoveDentry: packet(%v) mp(%v) err(%v) PacketData(%v)", packet, mp, err, string(packet.Data))
+		return
+	}
+	log.LogDebugf("batchMoveDentry: packet(%v) mp(%v) spino(%v) dpino(%v) items(%v)", packet, mp, srcParentID, dstParentID, len(items))
+	return statusOK, resp.Results, nil
+}
+
+func (mw *MetaWrapper) swapExtents(mp *MetaPartition, inode, tmpInode, gen uint64) (status int, err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("swapExtents", err, bgTime, 1)
+	}()
+
+	req := &proto.SwapExtentsRequest{
+		VolName:     mw.volname,
+		PartitionID: mp.PartitionID,
+		Inode:       inode,
+		TmpInode:    tmpInode,
+		Generation:  gen,
+	}
+
+	packet := proto.NewPacketReqID()
+	packet.Opcode = proto.OpMetaSwapExtents
+	packet.PartitionID = mp.PartitionID
+	err = packet.MarshalData(req)
+	if err != nil {
+		log.LogErrorf("swapExtents: req(%v) err(%v)", *req, err)
+		return
+	}
+
+	metric := exporter.NewTPCnt(packet.GetOpMsg())
+	defer func() {
+		metric.SetWithLabels(err, map[string]string{exporter.Vol: mw.volname})
+	}()
+
+	packet, err = mw.sendToMetaPartition(mp, packet)
+	if err != nil {
+		log.LogErrorf("swapExtents: packet(%v) mp(%v) req(%v) err(%v)", packet, mp, *req, err)
+		return
+	}
+
+	status = parseStatus(packet.ResultCode)
+	if status != statusOK {
+		log.LogWarnf("swapExtents: packet(%v) mp(%v) req(%v) result(%v)", packet, mp, *req, packet.GetResultMsg())
+		return
+	}
+	log.LogDebugf("swapExtents: packet(%v) mp(%v) req(%v)", packet, mp, *req)
+	return statusOK, nil
+}
+
+func (mw *MetaWrapper) setInlineData(mp *MetaPartition, inode uint64, data []byte, size uint64) (status int, err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("setInlineData", err, bgTime, 1)
+	}()
+
+	req := &proto.SetInlineDataRequest{
+		VolName:     mw.volname,
+		PartitionID: mp.PartitionID,
+		Inode:       inode,
+		Data:        data,
+		Size:        size,
+	}
+
+	packet := proto.NewPacketReqID()
+	packet.Opcode = proto.OpMetaSetInlineData
+	packet.PartitionID = mp.PartitionID
+	err = packet.MarshalData(req)
+	if err != nil {
+		log.LogErrorf("setInlineData: ino(%v) err(%v)", inode, err)
+		return
+	}
+
+	metric := exporter.NewTPCnt(packet.GetOpMsg())
+	defer func() {
+		metric.SetWithLabels(err, map[string]string{exporter.Vol: mw.volname})
+	}()
+
+	packet, err = mw.sendToMetaPartition(mp, packet)
+	if err != nil {
+		log.LogErrorf("setInlineData: packet(%v) mp(%v) ino(%v) err(%v)", packet, mp, inode, err)
+		return
+	}
+
+	status = parseStatus(packet.ResultCode)
+	if status != statusOK {
+		log.LogWarnf("setInlineData: packet(%v) mp(%v) ino(%v) result(%v)", packet, mp, inode, packet.GetResultMsg())
+		return
+	}
+	log.LogDebugf("setInlineData: packet(%v) mp(%v) ino(%v) len(%v) size(%v)", packet, mp, inode, len(data), size)
+	return statusOK, nil
+}
+
+func (mw *MetaWrapper) getDirVersion(mp *MetaPartition, parentID uint64) (status int, version uint64, err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("getDirVersion", err, bgTime, 1)
+	}()
+
+	req := &proto.GetDirVersionRequest{
+		VolName:     mw.volname,
+		PartitionID: mp.PartitionID,
+		ParentID:    parentID,
+	}
+
+	packet := proto.NewPacketReqID()
+	packet.Opcode = proto.OpMetaGetDirVersion
+	packet.PartitionID = mp.PartitionID
+	err = packet.MarshalData(req)
+	if err != nil {
+		log.LogErrorf("getDirVersion: parent(%v) err(%v)", parentID, err)
+		return
+	}
+
+	metric := exporter.NewTPCnt(packet.GetOpMsg())
+	defer func() {
+		metric.SetWithLabels(err, map[string]string{exporter.Vol: mw.volname})
+	}()
+
+	packet, err = mw.sendToMetaPartition(mp, packet)
+	if err != nil {
+		log.LogErrorf("getDirVersion: packet(%v) mp(%v) parent(%v) err(%v)", packet, mp, parentID, err)
+		return
+	}
+
+	status = parseStatus(packet.ResultCode)
+	if status != statusOK {
+		log.LogWarnf("getDirVersion: packet(%v) mp(%v) parent(%v) result(%v)", packet, mp, parentID, packet.GetResultMsg())
+		return
+	}
+	resp := new(proto.GetDirVersionResponse)
+	if err = packet.UnmarshalData(resp); err != nil {
+		log.LogErrorf("getDirVersion: packet(%v) mp(%v) parent(%v) err(%v) PacketData(%v)", packet, mp, parentID, err, string(packet.Data))
+		return
+	}
+	return statusOK, resp.Version, nil
+}
+
+func (mw *MetaWrapper) readDirLimit(mp *MetaPartition, parentID uint64, prefix, from string, limit uint64, verSeq uint64, verOpt uint8) (status int, children []proto.Dentry, err error) {
 	bgTime := stat.BeginStat()
 	defer func() {
 		stat.EndStat("readDirLimit", err, bgTime, 1)
@@ -1168,6 +1351,7 @@ func (mw *MetaWrapper) readDirLimit(mp *MetaPartition, parentID uint64, from str
 		Limit:       limit,
 		VerSeq:      verSeq,
 		VerOpt:      verOpt,
+		Prefix:      prefix,
 	}
 
 	packet := proto.NewPacketReqID()
@@ -1601,6 +1785,51 @@ func (mw *MetaWrapper) setattr(mp *MetaPartition, inode uint64, valid, mode, uid
 	return statusOK, nil
 }
 
+func (mw *MetaWrapper) setStorageClass(mp *MetaPartition, inode uint64, storageClass uint32) (status int, err error) {
+	bgTime := stat.BeginStat()
+	defer func() {
+		stat.EndStat("setStorageClass", err, bgTime, 1)
+	}()
+
+	req := &proto.SetAttrRequest{
+		VolName:      mw.volname,
+		PartitionID:  mp.PartitionID,
+		Inode:        inode,
+		Valid:        proto.AttrStorageClass,
+		StorageClass: storageClass,
+	}
+
+	packet := proto.NewPacketReqID()
+	packet.Opcode = proto.OpMetaSetattr
+	packet.PartitionID = mp.PartitionID
+	err = packet.MarshalData(req)
+	if err != nil {
+		log.LogErrorf("setStorageClass: err(%v)", err)
+		return
+	}
+
+	metric := exporter.NewTPCnt(packet.GetOpMsg())
+	defer func() {
+		metric.SetWithLabels(err, map[string]string{exporter.Vol: mw.volname})
+	}()
+
+	packet, err = mw.sendToMetaPartition(mp, packet)
+	if err != nil {
+		log.LogErrorf("setStorageClass: packet(%v) mp(%v) req(%v) err(%v)", packet, mp, *req, err)
+		return
+	}
+
+	status = parseStatus(packet.ResultCode)
+	if status != statusOK {
+		err = errors.New(packet.GetResultMsg())
+		log.LogErrorf("setStorageClass: packet(%v) mp(%v) req(%v) result(%v)", packet, mp, *req, packet.GetResultMsg())
+		return
+	}
+
+	log.LogDebugf("setStorageClass exit: packet(%v) mp(%v) req(%v)", packet, mp, *req)
+	return statusOK, nil
+}
+
 func (mw *MetaWrapper) createMultipart(mp *MetaPartition, path string, extend map[string]string) (status int, multipartId string, err error) {
 	bgTime := stat.BeginStat()
 	defer func() {
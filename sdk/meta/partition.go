@@ -25,6 +25,7 @@ type MetaPartition struct {
 	Start       uint64
 	End         uint64
 	Members     []string
+	Witnesses   []string // members holding no data, never read from
 	LeaderAddr  string
 	Status      int8
 }
@@ -34,6 +35,15 @@ func (mp *MetaPartition) Less(than btree.Item) bool {
 	return mp.Start < that.Start
 }
 
+func (mp *MetaPartition) isWitness(addr string) bool {
+	for _, witness := range mp.Witnesses {
+		if witness == addr {
+			return true
+		}
+	}
+	return false
+}
+
 func (mp *MetaPartition) Quorum() int {
 	return len(mp.Members)/2 + 1
 }
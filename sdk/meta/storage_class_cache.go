@@ -0,0 +1,99 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+package meta
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultDirStorageClassExpiration = 60 * time.Second
+	MaxDirStorageClassCache          = 100000
+)
+
+// DirStorageClassCache caches the storageClass the inodes created in a directory
+// take, so that a creation does not get its parent from the metanode each time.
+// The one set by another client is taken once the cached one expires.
+type DirStorageClassCache struct {
+	sync.Mutex
+	cache       map[uint64]*list.Element
+	lruList     *list.List
+	expiration  time.Duration
+	maxElements int
+}
+
+type dirStorageClassInfo struct {
+	inode        uint64
+	storageClass uint32
+	expiration   int64
+}
+
+func NewDirStorageClassCache(exp time.Duration, maxElements int) *DirStorageClassCache {
+	return &DirStorageClassCache{
+		cache:       make(map[uint64]*list.Element),
+		lruList:     list.New(),
+		expiration:  exp,
+		maxElements: maxElements,
+	}
+}
+
+func (sc *DirStorageClassCache) Put(ino uint64, storageClass uint32) {
+	sc.Lock()
+	defer sc.Unlock()
+	if old, ok := sc.cache[ino]; ok {
+		sc.lruList.Remove(old)
+		delete(sc.cache, ino)
+	}
+	for sc.lruList.Len() >= sc.maxElements {
+		element := sc.lruList.Back()
+		sc.lruList.Remove(element)
+		delete(sc.cache, element.Value.(*dirStorageClassInfo).inode)
+	}
+	info := &dirStorageClassInfo{
+		inode:        ino,
+		storageClass: storageClass,
+		expiration:   time.Now().Add(sc.expiration).UnixNano(),
+	}
+	sc.cache[ino] = sc.lruList.PushFront(info)
+}
+
+// Get returns the storageClass of the directory ino, false if it is not cached
+// or expired.
+func (sc *DirStorageClassCache) Get(ino uint64) (storageClass uint32, ok bool) {
+	sc.Lock()
+	defer sc.Unlock()
+	element, ok := sc.cache[ino]
+	if !ok {
+		return
+	}
+	info := element.Value.(*dirStorageClassInfo)
+	if time.Now().UnixNano() > info.expiration {
+		sc.lruList.Remove(element)
+		delete(sc.cache, ino)
+		return 0, false
+	}
+	sc.lruList.MoveToFront(element)
+	return info.storageClass, true
+}
+
+func (sc *DirStorageClassCache) Delete(ino uint64) {
+	sc.Lock()
+	defer sc.Unlock()
+	if element, ok := sc.cache[ino]; ok {
+		sc.lruList.Remove(element)
+		delete(sc.cache, ino)
+	}
+}
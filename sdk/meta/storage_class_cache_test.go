@@ -0,0 +1,60 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirStorageClassCache(t *testing.T) {
+	sc := NewDirStorageClassCache(time.Minute, 2)
+	sc.Put(1, proto.StorageClass_Replica_SSD)
+	sc.Put(2, proto.StorageClass_Unspecified)
+	got, ok := sc.Get(1)
+	require.True(t, ok)
+	require.Equal(t, proto.StorageClass_Replica_SSD, got)
+
+	// the least recently used one is evicted
+	sc.Put(3, proto.StorageClass_Replica_HDD)
+	_, ok = sc.Get(2)
+	require.False(t, ok)
+	_, ok = sc.Get(1)
+	require.True(t, ok)
+
+	sc.Delete(1)
+	_, ok = sc.Get(1)
+	require.False(t, ok)
+
+	sc = NewDirStorageClassCache(-time.Second, 2)
+	sc.Put(1, proto.StorageClass_Replica_SSD)
+	_, ok = sc.Get(1)
+	require.False(t, ok, "expired")
+}
+
+func TestCreateDirStorageClassCached(t *testing.T) {
+	mw := &MetaWrapper{sc: NewDirStorageClassCache(time.Minute, 10)}
+	mw.sc.Put(10, proto.StorageClass_Replica_HDD)
+	mw.sc.Put(20, proto.StorageClass_Unspecified)
+	// no partition is asked, nil would panic
+	got, err := mw.dirStorageClass(nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, proto.StorageClass_Replica_HDD, got)
+	got, err = mw.dirStorageClass(nil, 20)
+	require.NoError(t, err)
+	require.Equal(t, proto.StorageClass_Unspecified, got)
+}
@@ -19,8 +19,10 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"os"
+	"path"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -30,7 +32,6 @@ import (
 	"github.com/cubefs/cubefs/util/cryptoutil"
 	"github.com/cubefs/cubefs/util/errors"
 	"github.com/cubefs/cubefs/util/log"
-	"github.com/jacobsa/daemonize"
 )
 
 const (
@@ -110,6 +111,7 @@ func (mw *MetaWrapper) fetchVolumeView() (view *VolumeView, err error) {
 				Start:       mp.Start,
 				End:         mp.End,
 				Members:     mp.Members,
+				Witnesses:   mp.Witnesses,
 				LeaderAddr:  mp.LeaderAddr,
 				Status:      mp.Status,
 			}
@@ -132,6 +134,7 @@ func (mw *MetaWrapper) updateClusterInfo() (err error) {
 	mw.cluster = info.Cluster
 	mw.localIP = info.Ip
 	mw.IsSnapshotEnabled = info.ClusterEnableSnapshot
+	atomic.StoreUint64(&mw.clusterFeatures, uint64(info.Features))
 	return
 }
 
@@ -141,6 +144,7 @@ func (mw *MetaWrapper) updateDirChildrenNumLimit() (err error) {
 	if err != nil {
 		return
 	}
+	atomic.StoreUint64(&mw.clusterFeatures, uint64(clusterInfo.Features))
 
 	if clusterInfo.DirChildrenNumLimit < proto.MinDirChildrenNumLimit {
 		log.LogWarnf("updateDirChildrenNumLimit: DirChildrenNumLimit probably not enabled on master, set to default value(%v)",
@@ -201,20 +205,20 @@ func (mw *MetaWrapper) updateMetaPartitions() error {
 	view, err := mw.fetchVolumeView()
 	if err != nil {
 		log.LogInfof("updateMetaPartition volume(%v) error: %v", mw.volname, err.Error())
-		switch err {
-		case proto.ErrExpiredTicket:
+		switch {
+		case goerrors.Is(err, proto.ErrExpiredTicket):
 			// TODO: bad logic, remove later (Mofei Zhang)
 			if e := mw.updateTicket(); e != nil {
 				log.LogFlush()
-				daemonize.SignalOutcome(err)
+				signalOutcome(err)
 				os.Exit(1)
 			}
 			log.LogInfof("updateTicket: ok!")
 			return err
-		case proto.ErrInvalidTicket:
+		case goerrors.Is(err, proto.ErrInvalidTicket):
 			// TODO: bad logic, remove later (Mofei Zhang)
 			log.LogFlush()
-			daemonize.SignalOutcome(err)
+			signalOutcome(err)
 			os.Exit(1)
 		default:
 			return err
@@ -464,6 +468,30 @@ func (mw *MetaWrapper) GetQuotaFullPaths() (fullPaths []string) {
 	return fullPaths
 }
 
+// MountQuota returns the innermost quota covering the subdir mounted, nil if none
+// does or the volume does not enable quota.
+func (mw *MetaWrapper) MountQuota() (quota *proto.QuotaInfo) {
+	if !mw.EnableQuota {
+		return nil
+	}
+	mount := path.Clean("/" + mw.subDir)
+	depth := -1
+	mw.QuotaLock.RLock()
+	defer mw.QuotaLock.RUnlock()
+	for _, info := range mw.QuotaInfoMap {
+		for _, pathInfo := range info.PathInfos {
+			p := path.Clean("/" + pathInfo.FullPath)
+			if p != "/" && p != mount && !strings.HasPrefix(mount, p+"/") {
+				continue
+			}
+			if len(p) > depth {
+				quota, depth = info, len(p)
+			}
+		}
+	}
+	return
+}
+
 func (mw *MetaWrapper) IsQuotaLimitedById(inodeId uint64, size bool, files bool) bool {
 	mp := mw.getPartitionByInode(inodeId)
 	if mp == nil {
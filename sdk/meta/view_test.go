@@ -0,0 +1,44 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMountQuota(t *testing.T) {
+	newQuota := func(id uint32, path string) *proto.QuotaInfo {
+		return &proto.QuotaInfo{QuotaId: id, PathInfos: []proto.QuotaPathInfo{{FullPath: path}}}
+	}
+	mw := &MetaWrapper{subDir: "a/b", QuotaInfoMap: map[uint32]*proto.QuotaInfo{
+		1: newQuota(1, "/a"),
+		2: newQuota(2, "/a/b"),
+		3: newQuota(3, "/a/b/c"),
+		4: newQuota(4, "/ab"),
+	}}
+	require.Nil(t, mw.MountQuota())
+
+	mw.EnableQuota = true
+	require.EqualValues(t, 2, mw.MountQuota().QuotaId)
+
+	delete(mw.QuotaInfoMap, 2)
+	require.EqualValues(t, 1, mw.MountQuota().QuotaId)
+
+	mw.subDir = "/x"
+	require.Nil(t, mw.MountQuota())
+}
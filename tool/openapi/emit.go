@@ -0,0 +1,334 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+)
+
+const generatedNotice = "Code generated by tool/openapi. DO NOT EDIT."
+
+// operationID derives a stable name from the path, e.g. /admin/getVol -> AdminGetVol.
+func operationID(path string) string {
+	var b strings.Builder
+	for _, part := range splitWords(path) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func splitWords(s string) (words []string) {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func goFieldName(param string) string {
+	name := operationID(param)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "P" + name
+	}
+	return name
+}
+
+func pythonName(s string) string {
+	var b strings.Builder
+	rs := []rune(s)
+	for i, r := range rs {
+		switch {
+		case unicode.IsUpper(r):
+			// split before an upper case letter that starts a word, e.g. SetIOLimits -> set_io_limits
+			if i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]) ||
+				(unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	name := strings.Trim(b.String(), "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) || pythonKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+var pythonKeywords = map[string]bool{
+	"and": true, "as": true, "assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true, "except": true, "finally": true,
+	"for": true, "from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true, "self": true, "body": true,
+}
+
+// BuildSpec renders the routes as an OpenAPI 3 document.
+func BuildSpec(routes []*Route, version string) ([]byte, error) {
+	paths := make(map[string]interface{}, len(routes))
+	for _, r := range routes {
+		ops := make(map[string]interface{}, len(r.Methods))
+		for i, m := range r.Methods {
+			id := operationID(r.Path)
+			if i > 0 {
+				id += strings.ToUpper(m[:1]) + strings.ToLower(m[1:])
+			}
+			params := make([]interface{}, 0, len(r.Params))
+			for _, p := range r.Params {
+				params = append(params, map[string]interface{}{
+					"name":     p.Name,
+					"in":       "query",
+					"required": p.Required,
+					"schema":   map[string]string{"type": p.Type},
+				})
+			}
+			op := map[string]interface{}{
+				"operationId": id,
+				"tags":        []string{splitWords(r.Path)[0]},
+				"parameters":  params,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "reply envelope, code 0 means success",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]string{"$ref": "#/components/schemas/HTTPReply"},
+							},
+						},
+					},
+				},
+			}
+			if r.Handler != "" {
+				op["x-handler"] = r.Handler
+			}
+			if r.Body {
+				op["requestBody"] = map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]string{"type": "object"},
+						},
+					},
+				}
+			}
+			ops[strings.ToLower(m)] = op
+		}
+		paths[r.Path] = ops
+	}
+	spec := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]string{
+			"title":       "CubeFS Master Admin API",
+			"version":     version,
+			"description": generatedNotice,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"HTTPReply": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"code": map[string]string{"type": "integer"},
+						"msg":  map[string]string{"type": "string"},
+						"data": map[string]string{},
+					},
+				},
+			},
+		},
+	}
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+var goTypes = map[string]string{
+	"string":  "string",
+	"integer": "*int64",
+	"number":  "*float64",
+	"boolean": "*bool",
+}
+
+// BuildGoClient renders the typed client methods of sdk/master.
+func BuildGoClient(routes []*Route, header string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n\n// %s\n\npackage master\n\n", header, generatedNotice)
+	b.WriteString("import (\n\t\"encoding/json\"\n\n\t\"github.com/cubefs/cubefs/proto\"\n)\n\n")
+	for _, r := range routes {
+		id := operationID(r.Path)
+		method := "get"
+		if !contains(r.Methods, "GET") || (r.Body && contains(r.Methods, "POST")) {
+			method = "post"
+		}
+		path := fmt.Sprintf("%q", r.Path)
+		if r.PathConst != "" {
+			path = "proto." + r.PathConst
+		}
+		args := make([]string, 0, 2)
+		if len(r.Params) > 0 {
+			fmt.Fprintf(&b, "// %sParams are the query parameters of %s.\ntype %sParams struct {\n", id, r.Path, id)
+			used := make(map[string]bool)
+			for _, p := range r.Params {
+				field := uniqueName(goFieldName(p.Name), used)
+				comment := ""
+				if p.Required {
+					comment = " // required"
+				}
+				fmt.Fprintf(&b, "\t%s %s `json:%q`%s\n", field, goTypes[p.Type], p.Name, comment)
+			}
+			b.WriteString("}\n\n")
+			args = append(args, "p *"+id+"Params")
+		}
+		if r.Body {
+			args = append(args, "body interface{}")
+		}
+		fmt.Fprintf(&b, "// %s calls %s %s.\n", id, strings.ToUpper(method), r.Path)
+		fmt.Fprintf(&b, "func (api *TypedAdminAPI) %s(%s) (json.RawMessage, error) {\n", id, strings.Join(args, ", "))
+		fmt.Fprintf(&b, "\treq := newRequest(%s, %s).Header(api.h)\n", method, path)
+		if len(r.Params) > 0 {
+			b.WriteString("\tif p != nil {\n")
+			used := make(map[string]bool)
+			for _, p := range r.Params {
+				field := uniqueName(goFieldName(p.Name), used)
+				if p.Type == "string" {
+					fmt.Fprintf(&b, "\t\tif p.%s != \"\" {\n\t\t\treq.addParam(%q, p.%s)\n\t\t}\n", field, p.Name, field)
+				} else {
+					fmt.Fprintf(&b, "\t\tif p.%s != nil {\n\t\t\treq.addParamAny(%q, p.%s)\n\t\t}\n", field, p.Name, field)
+				}
+			}
+			b.WriteString("\t}\n")
+		}
+		if r.Body {
+			b.WriteString("\tif body != nil {\n\t\treq.Body(body)\n\t}\n")
+		}
+		b.WriteString("\treturn api.do(req)\n}\n\n")
+	}
+	return format.Source(b.Bytes())
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	n := name
+	for i := 2; used[n]; i++ {
+		n = fmt.Sprintf("%s%d", name, i)
+	}
+	used[n] = true
+	return n
+}
+
+// BuildPythonClient renders a dependency free python client.
+func BuildPythonClient(routes []*Route, header string) []byte {
+	var b bytes.Buffer
+	for _, line := range strings.Split(strings.TrimSpace(header), "\n") {
+		b.WriteString("#" + strings.TrimPrefix(line, "//") + "\n")
+	}
+	fmt.Fprintf(&b, "\n# %s\n\n", generatedNotice)
+	b.WriteString(`"""Typed client of the CubeFS master admin API."""
+
+import json
+import urllib.parse
+import urllib.request
+
+
+class AdminAPIError(Exception):
+    def __init__(self, code, msg):
+        super().__init__("code(%s) msg(%s)" % (code, msg))
+        self.code = code
+        self.msg = msg
+
+
+class AdminClient(object):
+    def __init__(self, addr, timeout=30, headers=None):
+        if "://" not in addr:
+            addr = "http://" + addr
+        self.addr = addr.rstrip("/")
+        self.timeout = timeout
+        self.headers = dict(headers or {})
+
+    def _request(self, method, path, params, body=None):
+        query = {}
+        for key, val in params.items():
+            if val is None:
+                continue
+            if isinstance(val, bool):
+                val = "true" if val else "false"
+            query[key] = str(val)
+        url = self.addr + path
+        if query:
+            url += "?" + urllib.parse.urlencode(query)
+        data = None
+        headers = dict(self.headers)
+        if body is not None:
+            data = body if isinstance(body, bytes) else json.dumps(body).encode()
+            headers["Content-Type"] = "application/json"
+        req = urllib.request.Request(url, data=data, headers=headers, method=method)
+        with urllib.request.urlopen(req, timeout=self.timeout) as resp:
+            reply = json.loads(resp.read().decode() or "{}")
+        if reply.get("code", 0) != 0:
+            raise AdminAPIError(reply.get("code"), reply.get("msg"))
+        return reply.get("data")
+`)
+	for _, r := range routes {
+		method := "GET"
+		if !contains(r.Methods, "GET") || (r.Body && contains(r.Methods, "POST")) {
+			method = "POST"
+		}
+		args := []string{"self"}
+		used := map[string]bool{}
+		names := make([]string, len(r.Params))
+		var optional []string
+		for i, p := range r.Params {
+			names[i] = uniqueName(pythonName(p.Name), used)
+			if p.Required {
+				args = append(args, names[i])
+			} else {
+				optional = append(optional, names[i]+"=None")
+			}
+		}
+		args = append(args, optional...)
+		if r.Body {
+			args = append(args, "body=None")
+		}
+		fmt.Fprintf(&b, "\n    def %s(%s):\n", pythonName(operationID(r.Path)), strings.Join(args, ", "))
+		fmt.Fprintf(&b, "        \"\"\"%s %s\"\"\"\n", method, r.Path)
+		b.WriteString("        params = {")
+		for i, p := range r.Params {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%q: %s", p.Name, names[i])
+		}
+		b.WriteString("}\n")
+		body := "None"
+		if r.Body {
+			body = "body"
+		}
+		fmt.Fprintf(&b, "        return self._request(%q, %q, params, %s)\n", method, r.Path, body)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Command openapi generates the OpenAPI document of the master admin API and the
+// typed Go and python clients from the routes registered in master/http_server.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	specFile   = "docs/source/dev-guide/admin-api/master/openapi.json"
+	goFile     = "sdk/master/api_typed_gen.go"
+	pythonFile = "sdk/python/cubefs_admin.py"
+	apiVersion = "1"
+)
+
+// Generate returns the generated files keyed by their path relative to root.
+func Generate(root string) (files map[string][]byte, err error) {
+	src, err := loadSource(root)
+	if err != nil {
+		return
+	}
+	routes := src.Routes()
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no routes found under %v", root)
+	}
+	header, err := licenseHeader(filepath.Join(root, "tool/openapi/main.go"))
+	if err != nil {
+		return
+	}
+	files = make(map[string][]byte)
+	if files[specFile], err = BuildSpec(routes, apiVersion); err != nil {
+		return
+	}
+	if files[goFile], err = BuildGoClient(routes, header); err != nil {
+		return
+	}
+	files[pythonFile] = BuildPythonClient(routes, header)
+	return
+}
+
+// licenseHeader reuses the leading comment block of the given file.
+func licenseHeader(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func main() {
+	root := flag.String("root", ".", "root directory of the cubefs source tree")
+	check := flag.Bool("check", false, "only check that the generated files are up to date")
+	flag.Parse()
+
+	files, err := Generate(*root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+		os.Exit(1)
+	}
+	stale := false
+	for name, data := range files {
+		path := filepath.Join(*root, name)
+		if *check {
+			old, _ := os.ReadFile(path)
+			if !bytes.Equal(old, data) {
+				fmt.Fprintf(os.Stderr, "%v is out of date, run go generate ./sdk/master\n", name)
+				stale = true
+			}
+			continue
+		}
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if stale {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, root, name, content string) {
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestParseRoutes(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "tool/openapi/main.go", "// header\npackage main\n")
+	writeFile(t, root, "proto/admin.go", `package proto
+const (
+	AdminPrefix = "/admin"
+	AdminGetVol = AdminPrefix + "/getVol"
+	AdminSetQos = "/qos/set"
+	AdminGetNode = "/node/get"
+)
+`)
+	writeFile(t, root, "cmd/common/args.go", `package common
+type Uint struct{ V uint64 }
+func (u *Uint) Key(key string) *Argument { return NewArgument(key, &u.V) }
+func (u *Uint) ID() *Argument { return u.Key("id") }
+`)
+	writeFile(t, root, "master/http_server.go", `package master
+import (
+	"net/http"
+	"strconv"
+	"github.com/cubefs/cubefs/cmd/common"
+	"github.com/cubefs/cubefs/proto"
+)
+const nameKey = "name"
+func (m *Server) registerAPIRoutes(router *mux.Router) {
+	router.NewRoute().Methods(http.MethodGet).
+		Path(proto.AdminGetVol).
+		HandlerFunc(m.getVol)
+	router.NewRoute().Methods(http.MethodPost).
+		Path(proto.AdminSetQos).
+		HandlerFunc(m.setQos)
+	router.NewRoute().Methods(http.MethodGet, http.MethodPost).
+		Path(proto.AdminGetNode).
+		HandlerFunc(m.getNode)
+}
+func (m *Server) getVol(w http.ResponseWriter, r *http.Request) {
+	name, err := extractName(r)
+	limit, err := extractUintWithDefault(r, "limit", 10)
+	_ = r.URL.Query().Get("verbose")
+}
+func extractName(r *http.Request) (name string, err error) {
+	if name = r.FormValue(nameKey); name == "" {
+		err = keyNotFound(nameKey)
+	}
+	return
+}
+func extractUintWithDefault(r *http.Request, key string, def int) (int, error) {
+	return strconv.Atoi(r.FormValue(key))
+}
+func (m *Server) setQos(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+}
+func (m *Server) getNode(w http.ResponseWriter, r *http.Request) {
+	var id common.Uint
+	err := parseArgs(r, id.ID(), newArg("zone", &zone).OmitEmpty())
+}
+`)
+	src, err := loadSource(root)
+	require.NoError(t, err)
+	routes := src.Routes()
+	require.Len(t, routes, 3)
+
+	require.Equal(t, "/admin/getVol", routes[0].Path)
+	require.Equal(t, "AdminGetVol", routes[0].PathConst)
+	require.Equal(t, []string{"GET"}, routes[0].Methods)
+	require.Equal(t, []*Param{
+		{Name: "limit", Type: "integer"},
+		{Name: "name", Type: "string", Required: true},
+		{Name: "verbose", Type: "string"},
+	}, routes[0].Params)
+
+	require.Equal(t, "/node/get", routes[1].Path)
+	require.Equal(t, []*Param{
+		{Name: "id", Type: "integer", Required: true},
+		{Name: "zone", Type: "string"},
+	}, routes[1].Params)
+
+	require.Equal(t, "/qos/set", routes[2].Path)
+	require.True(t, routes[2].Body)
+	require.Empty(t, routes[2].Params)
+
+	files, err := Generate(root)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	require.Contains(t, string(files[goFile]), "func (api *TypedAdminAPI) AdminGetVol(p *AdminGetVolParams) (json.RawMessage, error)")
+	require.Contains(t, string(files[goFile]), "req := newRequest(post, proto.AdminSetQos)")
+	require.Contains(t, string(files[pythonFile]), "def admin_get_vol(self, name, limit=None, verbose=None):")
+}
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "AdminGetVol", operationID("/admin/getVol"))
+	require.Equal(t, "FlashNodeSetWriteIOLimits", operationID("/flashNode/SetWriteIOLimits"))
+	require.Equal(t, "admin_get_vol", pythonName("AdminGetVol"))
+	require.Equal(t, "set_write_io_limits", pythonName("SetWriteIOLimits"))
+	require.Equal(t, "from_", pythonName("from"))
+	require.Equal(t, "P2fa", goFieldName("2fa"))
+}
+
+// TestGeneratedUpToDate fails when master routes change without running go generate ./sdk/master.
+func TestGeneratedUpToDate(t *testing.T) {
+	files, err := Generate("../..")
+	require.NoError(t, err)
+	for name, data := range files {
+		old, err := os.ReadFile(filepath.Join("../..", name))
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(old, data), "%v is out of date, run go generate ./sdk/master", name)
+	}
+}
@@ -0,0 +1,589 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxCallDepth = 6
+
+// Param is a query parameter read by a route handler.
+type Param struct {
+	Name     string
+	Type     string // string, integer, number or boolean
+	Required bool
+}
+
+// Route is a master HTTP route and the inputs its handler reads.
+type Route struct {
+	Path      string
+	PathConst string // name of the proto constant, empty if the path is a literal
+	Methods   []string
+	Handler   string
+	Params    []*Param
+	Body      bool
+}
+
+type source struct {
+	protoConsts  map[string]string
+	masterConsts map[string]string
+	funcs        map[string]*ast.FuncDecl
+	methods      map[string]*ast.FuncDecl
+	registers    []*ast.FuncDecl
+	argKeys      map[string]string // "Uint.ID" -> "id", shortcuts of cmd/common arguments
+}
+
+// argTypes maps the value types of cmd/common arguments to schema types.
+var argTypes = map[string]string{
+	"Bool":   "boolean",
+	"Int":    "integer",
+	"Uint":   "integer",
+	"Float":  "number",
+	"String": "string",
+}
+
+func parseDir(dir string) (files []*ast.File, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		var f *ast.File
+		if f, err = parser.ParseFile(fset, filepath.Join(dir, name), nil, 0); err != nil {
+			return
+		}
+		files = append(files, f)
+	}
+	return
+}
+
+func collectConsts(files []*ast.File, consts map[string]string, resolve func(ast.Expr) (string, bool)) {
+	// constants may refer to each other, resolve until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for _, f := range files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for i, name := range vs.Names {
+						if i >= len(vs.Values) {
+							continue
+						}
+						if _, ok := consts[name.Name]; ok {
+							continue
+						}
+						if v, ok := resolve(vs.Values[i]); ok {
+							consts[name.Name] = v
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func loadSource(root string) (src *source, err error) {
+	src = &source{
+		protoConsts:  make(map[string]string),
+		masterConsts: make(map[string]string),
+		funcs:        make(map[string]*ast.FuncDecl),
+		methods:      make(map[string]*ast.FuncDecl),
+		argKeys:      make(map[string]string),
+	}
+	commonFiles, err := parseDir(filepath.Join(root, "cmd/common"))
+	if err != nil {
+		return
+	}
+	src.collectArgKeys(commonFiles)
+	protoFiles, err := parseDir(filepath.Join(root, "proto"))
+	if err != nil {
+		return
+	}
+	collectConsts(protoFiles, src.protoConsts, func(e ast.Expr) (string, bool) {
+		return src.resolve(e, src.protoConsts, nil)
+	})
+	masterFiles, err := parseDir(filepath.Join(root, "master"))
+	if err != nil {
+		return
+	}
+	collectConsts(masterFiles, src.masterConsts, func(e ast.Expr) (string, bool) {
+		return src.resolve(e, src.masterConsts, nil)
+	})
+	for _, f := range masterFiles {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			if fd.Recv == nil {
+				src.funcs[fd.Name.Name] = fd
+				continue
+			}
+			recv := receiverName(fd)
+			if _, ok := src.methods[fd.Name.Name]; !ok || recv == "Server" {
+				src.methods[fd.Name.Name] = fd
+			}
+			if recv == "Server" && strings.HasPrefix(fd.Name.Name, "register") {
+				src.registers = append(src.registers, fd)
+			}
+		}
+	}
+	return
+}
+
+func (src *source) collectArgKeys(files []*ast.File) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || fd.Body == nil || len(fd.Body.List) != 1 {
+				continue
+			}
+			ret, ok := fd.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+			call, ok := ret.Results[0].(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				continue
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Key" {
+				continue
+			}
+			if key, ok := src.resolve(call.Args[0], nil, nil); ok {
+				src.argKeys[receiverName(fd)+"."+fd.Name.Name] = key
+			}
+		}
+	}
+}
+
+func receiverName(fd *ast.FuncDecl) string {
+	t := fd.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// resolve evaluates a constant string expression, local names are looked up in bindings first.
+func (src *source) resolve(e ast.Expr, local map[string]string, bindings map[string]string) (string, bool) {
+	switch v := e.(type) {
+	case *ast.BasicLit:
+		if v.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(v.Value)
+		return s, err == nil
+	case *ast.Ident:
+		if s, ok := bindings[v.Name]; ok {
+			return s, true
+		}
+		s, ok := local[v.Name]
+		return s, ok
+	case *ast.SelectorExpr:
+		if pkg, ok := v.X.(*ast.Ident); ok && pkg.Name == "proto" {
+			s, ok := src.protoConsts[v.Sel.Name]
+			return s, ok
+		}
+	case *ast.BinaryExpr:
+		if v.Op != token.ADD {
+			return "", false
+		}
+		x, ok1 := src.resolve(v.X, local, bindings)
+		y, ok2 := src.resolve(v.Y, local, bindings)
+		return x + y, ok1 && ok2
+	case *ast.ParenExpr:
+		return src.resolve(v.X, local, bindings)
+	}
+	return "", false
+}
+
+// Routes returns the routes registered by the master, sorted by path.
+func (src *source) Routes() (routes []*Route) {
+	seen := make(map[string]bool)
+	for _, fd := range src.registers {
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "HandlerFunc" || len(call.Args) != 1 {
+				return true
+			}
+			route := src.parseRoute(sel.X, call.Args[0])
+			if route == nil || seen[route.Path] {
+				return true
+			}
+			seen[route.Path] = true
+			routes = append(routes, route)
+			return true
+		})
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	return
+}
+
+func (src *source) parseRoute(chain ast.Expr, handler ast.Expr) *Route {
+	route := &Route{}
+	for {
+		call, ok := chain.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		switch sel.Sel.Name {
+		case "Path":
+			if len(call.Args) == 1 {
+				route.Path, _ = src.resolve(call.Args[0], src.masterConsts, nil)
+				if ps, ok := call.Args[0].(*ast.SelectorExpr); ok {
+					route.PathConst = ps.Sel.Name
+				}
+			}
+		case "Methods":
+			for _, arg := range call.Args {
+				if ms, ok := arg.(*ast.SelectorExpr); ok && strings.HasPrefix(ms.Sel.Name, "Method") {
+					route.Methods = append(route.Methods, strings.ToUpper(strings.TrimPrefix(ms.Sel.Name, "Method")))
+				}
+			}
+		}
+		chain = sel.X
+	}
+	if route.Path == "" || len(route.Methods) == 0 {
+		return nil
+	}
+
+	a := &analyzer{src: src, params: make(map[string]*Param), required: make(map[string]bool), visited: make(map[*ast.FuncDecl]bool)}
+	switch h := handler.(type) {
+	case *ast.SelectorExpr:
+		route.Handler = h.Sel.Name
+		if fd := src.methods[h.Sel.Name]; fd != nil {
+			a.analyze(fd, nil, 0)
+		}
+	case *ast.FuncLit:
+		a.analyzeBody(h.Type, h.Body, nil, 0)
+	}
+	for name, p := range a.params {
+		p.Required = a.required[name]
+		route.Params = append(route.Params, p)
+	}
+	sort.Slice(route.Params, func(i, j int) bool { return route.Params[i].Name < route.Params[j].Name })
+	route.Body = a.body
+	return route
+}
+
+type analyzer struct {
+	src      *source
+	params   map[string]*Param
+	required map[string]bool
+	body     bool
+	visited  map[*ast.FuncDecl]bool
+	scope    map[string]string // argument variables of the function being analyzed
+}
+
+func (a *analyzer) analyze(fd *ast.FuncDecl, bindings map[string]string, depth int) {
+	if depth > maxCallDepth || (a.visited[fd] && bindings == nil) {
+		return
+	}
+	a.visited[fd] = true
+	a.analyzeBody(fd.Type, fd.Body, bindings, depth)
+}
+
+func requestParam(ft *ast.FuncType) string {
+	for _, field := range ft.Params.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Request" {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "http" && len(field.Names) > 0 {
+			return field.Names[0].Name
+		}
+	}
+	return ""
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// isRequestField reports whether e is req.<field>.
+func isRequestField(e ast.Expr, req, field string) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == field && isIdent(sel.X, req)
+}
+
+func (a *analyzer) analyzeBody(ft *ast.FuncType, body *ast.BlockStmt, bindings map[string]string, depth int) {
+	req := requestParam(ft)
+	if req == "" {
+		return
+	}
+	scope := a.scope
+	a.scope = argVars(ft, body)
+	defer func() { a.scope = scope }()
+	var read []string
+	valueType := "string"
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.SelectorExpr:
+			if isRequestField(v, req, "Body") {
+				a.body = true
+			}
+		case *ast.IndexExpr:
+			if isRequestField(v.X, req, "Form") {
+				if name, ok := a.src.resolve(v.Index, a.src.masterConsts, bindings); ok {
+					read = append(read, name)
+				}
+			}
+		case *ast.CallExpr:
+			a.inspectCall(v, req, bindings, depth, &read, &valueType)
+		}
+		return true
+	})
+	for _, name := range read {
+		p, ok := a.params[name]
+		if !ok {
+			p = &Param{Name: name, Type: "string"}
+			a.params[name] = p
+		}
+		// only trust the conversion in helpers that read a single value
+		if len(read) == 1 && p.Type == "string" {
+			p.Type = valueType
+		}
+	}
+}
+
+func (a *analyzer) inspectCall(call *ast.CallExpr, req string, bindings map[string]string, depth int, read *[]string, valueType *string) {
+	resolve := func(e ast.Expr) (string, bool) {
+		return a.src.resolve(e, a.src.masterConsts, bindings)
+	}
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		switch fn.Sel.Name {
+		case "FormValue", "PostFormValue":
+			if isIdent(fn.X, req) && len(call.Args) == 1 {
+				if name, ok := resolve(call.Args[0]); ok {
+					*read = append(*read, name)
+				}
+				return
+			}
+		case "Get":
+			// req.Form.Get(key) or req.URL.Query().Get(key)
+			if len(call.Args) == 1 && (isRequestField(fn.X, req, "Form") || isQueryCall(fn.X, req)) {
+				if name, ok := resolve(call.Args[0]); ok {
+					*read = append(*read, name)
+				}
+				return
+			}
+		}
+		if pkg, ok := fn.X.(*ast.Ident); ok && pkg.Name == "strconv" {
+			switch fn.Sel.Name {
+			case "ParseBool":
+				*valueType = "boolean"
+			case "ParseInt", "ParseUint", "Atoi":
+				*valueType = "integer"
+			case "ParseFloat":
+				*valueType = "number"
+			}
+		}
+	case *ast.Ident:
+		if fn.Name == "parseArgs" {
+			a.parseArguments(call.Args, bindings)
+			return
+		}
+		if fn.Name == "keyNotFound" && len(call.Args) == 1 {
+			if name, ok := resolve(call.Args[0]); ok {
+				a.required[name] = true
+			}
+			return
+		}
+	}
+
+	// follow helpers which receive the request
+	passed := false
+	for _, arg := range call.Args {
+		if isIdent(arg, req) {
+			passed = true
+			break
+		}
+	}
+	if !passed {
+		return
+	}
+	var callee *ast.FuncDecl
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		callee = a.src.funcs[fn.Name]
+	case *ast.SelectorExpr:
+		callee = a.src.methods[fn.Sel.Name]
+	}
+	if callee == nil {
+		return
+	}
+	var calleeBindings map[string]string
+	i := 0
+	for _, field := range callee.Type.Params.List {
+		for _, name := range field.Names {
+			if i < len(call.Args) {
+				if v, ok := resolve(call.Args[i]); ok {
+					if calleeBindings == nil {
+						calleeBindings = make(map[string]string)
+					}
+					calleeBindings[name.Name] = v
+				}
+			}
+			i++
+		}
+	}
+	a.analyze(callee, calleeBindings, depth+1)
+}
+
+func isQueryCall(e ast.Expr, req string) bool {
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Query" && isRequestField(sel.X, req, "URL")
+}
+
+// parseArguments records the cmd/common arguments passed to parseArgs(r, args...).
+func (a *analyzer) parseArguments(args []ast.Expr, bindings map[string]string) {
+	for _, arg := range args {
+		name, typ, optional, ok := a.argument(arg, a.scope, bindings, 0)
+		if !ok {
+			continue
+		}
+		p, exist := a.params[name]
+		if !exist {
+			p = &Param{Name: name, Type: typ}
+			a.params[name] = p
+		}
+		if !optional {
+			a.required[name] = true
+		}
+	}
+}
+
+// argument evaluates an argument chain such as idV.ID().OmitEmpty() or newArg("key", &v).
+func (a *analyzer) argument(e ast.Expr, vars map[string]string, bindings map[string]string, depth int) (name, typ string, optional, ok bool) {
+	call, isCall := e.(*ast.CallExpr)
+	if !isCall || depth > maxCallDepth {
+		return
+	}
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		switch fn.Sel.Name {
+		case "OmitEmpty":
+			name, typ, _, ok = a.argument(fn.X, vars, bindings, depth+1)
+			return name, typ, true, ok
+		case "OmitError", "OnEmpty", "OnError", "OnValue":
+			return a.argument(fn.X, vars, bindings, depth+1)
+		case "NewArgument":
+			if len(call.Args) == 2 {
+				name, ok = a.src.resolve(call.Args[0], a.src.masterConsts, bindings)
+				return name, "string", false, ok
+			}
+			return
+		}
+		recv, isIdent := fn.X.(*ast.Ident)
+		if !isIdent {
+			return
+		}
+		valueType, known := vars[recv.Name]
+		if !known {
+			return
+		}
+		if fn.Sel.Name == "Key" && len(call.Args) == 1 {
+			name, ok = a.src.resolve(call.Args[0], a.src.masterConsts, bindings)
+		} else {
+			name, ok = a.src.argKeys[valueType+"."+fn.Sel.Name]
+		}
+		return name, argTypes[valueType], false, ok
+	case *ast.Ident:
+		if fn.Name == "newArg" && len(call.Args) == 2 {
+			name, ok = a.src.resolve(call.Args[0], a.src.masterConsts, bindings)
+			return name, "string", false, ok
+		}
+		// helpers returning an argument, e.g. argParserNodeAddr(&addr)
+		fd := a.src.funcs[fn.Name]
+		if fd == nil || len(fd.Body.List) == 0 {
+			return
+		}
+		ret, isRet := fd.Body.List[len(fd.Body.List)-1].(*ast.ReturnStmt)
+		if !isRet || len(ret.Results) != 1 {
+			return
+		}
+		return a.argument(ret.Results[0], argVars(fd.Type, fd.Body), nil, depth+1)
+	}
+	return
+}
+
+// argVars returns the variables of cmd/common argument types declared by a function.
+func argVars(ft *ast.FuncType, body *ast.BlockStmt) map[string]string {
+	vars := make(map[string]string)
+	add := func(names []*ast.Ident, t ast.Expr) {
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		sel, ok := t.(*ast.SelectorExpr)
+		if !ok || !isIdent(sel.X, "common") {
+			return
+		}
+		if _, ok = argTypes[sel.Sel.Name]; !ok {
+			return
+		}
+		for _, n := range names {
+			vars[n.Name] = sel.Sel.Name
+		}
+	}
+	for _, field := range ft.Params.List {
+		add(field.Names, field.Type)
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		if vs, ok := n.(*ast.ValueSpec); ok && vs.Type != nil {
+			add(vs.Names, vs.Type)
+		}
+		return true
+	})
+	return vars
+}
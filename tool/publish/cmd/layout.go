@@ -0,0 +1,95 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"sort"
+)
+
+// DigestXAttrKey holds the hex sha256 of the file content on every published
+// inode, so readers and caches can address the content instead of the path.
+const DigestXAttrKey = "cfs.publish.sha256"
+
+type fileEntry struct {
+	ParentID uint64 `json:"-"`
+	Name     string `json:"-"`
+	Path     string `json:"path"`
+	Inode    uint64 `json:"ino"`
+	Size     uint64 `json:"size"`
+	Mode     uint32 `json:"-"`
+	Uid      uint32 `json:"-"`
+	Gid      uint32 `json:"-"`
+	Digest   string `json:"sha256"`
+}
+
+// contentKey groups the entries which can share one inode. Attributes kept on
+// the inode are part of the key, so merging never changes what a reader sees.
+type contentKey struct {
+	digest string
+	size   uint64
+	mode   uint32
+	uid    uint32
+	gid    uint32
+}
+
+func (e *fileEntry) key() contentKey {
+	return contentKey{digest: e.Digest, size: e.Size, mode: e.Mode, uid: e.Uid, gid: e.Gid}
+}
+
+type dedupPlan struct {
+	// canonical inode of every content, the smallest inode of its group
+	canonical map[contentKey]uint64
+	// entries which must be re-pointed to the canonical inode of their content
+	moves []*fileEntry
+
+	Files       int    `json:"files"`
+	Contents    int    `json:"contents"`
+	Duplicates  int    `json:"duplicates"`
+	TotalBytes  uint64 `json:"totalBytes"`
+	UniqueBytes uint64 `json:"uniqueBytes"`
+}
+
+func buildDedupPlan(entries []*fileEntry) *dedupPlan {
+	plan := &dedupPlan{canonical: make(map[contentKey]uint64)}
+	uniqueInodes := make(map[uint64]struct{})
+	for _, e := range entries {
+		plan.Files++
+		plan.TotalBytes += e.Size
+		k := e.key()
+		if ino, ok := plan.canonical[k]; !ok || e.Inode < ino {
+			plan.canonical[k] = e.Inode
+		}
+		uniqueInodes[e.Inode] = struct{}{}
+	}
+	for k := range plan.canonical {
+		plan.Contents++
+		plan.UniqueBytes += k.size
+	}
+	for _, e := range entries {
+		if plan.canonical[e.key()] != e.Inode {
+			plan.moves = append(plan.moves, e)
+		}
+	}
+	sort.Slice(plan.moves, func(i, j int) bool {
+		return plan.moves[i].Path < plan.moves[j].Path
+	})
+	// inodes which lose all their dentries, already hard linked ones excluded
+	plan.Duplicates = len(uniqueInodes) - plan.Contents
+	return plan
+}
+
+func (p *dedupPlan) canonicalOf(e *fileEntry) uint64 {
+	return p.canonical[e.key()]
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDedupPlan(t *testing.T) {
+	entries := []*fileEntry{
+		{Path: "/a", Inode: 12, Size: 10, Mode: 0o644, Digest: "d1"},
+		{Path: "/b", Inode: 11, Size: 10, Mode: 0o644, Digest: "d1"},
+		{Path: "/c", Inode: 11, Size: 10, Mode: 0o644, Digest: "d1"},
+		{Path: "/d", Inode: 13, Size: 10, Mode: 0o600, Digest: "d1"},
+		{Path: "/e", Inode: 14, Size: 20, Mode: 0o644, Digest: "d2"},
+	}
+	plan := buildDedupPlan(entries)
+	require.Equal(t, 5, plan.Files)
+	require.Equal(t, 3, plan.Contents)
+	require.Equal(t, 1, plan.Duplicates)
+	require.Equal(t, uint64(60), plan.TotalBytes)
+	require.Equal(t, uint64(40), plan.UniqueBytes)
+
+	// only /a moves, /c is already a hard link of the canonical inode and
+	// /d differs in mode so it keeps its own inode
+	require.Len(t, plan.moves, 1)
+	require.Equal(t, "/a", plan.moves[0].Path)
+	require.Equal(t, uint64(11), plan.canonicalOf(plan.moves[0]))
+	require.Equal(t, uint64(13), plan.canonicalOf(entries[3]))
+}
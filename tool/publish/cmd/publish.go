@@ -0,0 +1,303 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/sdk/data/stream"
+	masterSDK "github.com/cubefs/cubefs/sdk/master"
+	"github.com/cubefs/cubefs/sdk/meta"
+	"github.com/cubefs/cubefs/util"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const publishFullPath = "(Publish Cmd)"
+
+type publisher struct {
+	mc *masterSDK.MasterClient
+	mw *meta.MetaWrapper
+	ec *stream.ExtentClient
+}
+
+func Publish() (err error) {
+	defer log.LogFlush()
+
+	if MasterAddr == "" || VolName == "" {
+		return fmt.Errorf("Lack of parameters: master(%v) vol(%v)", MasterAddr, VolName)
+	}
+	if Concurrency <= 0 {
+		Concurrency = 1
+	}
+	if _, err = log.InitLog(LogDir, "publish", log.InfoLevel, nil, log.DefaultLogLeftSpaceLimitRatio); err != nil {
+		return fmt.Errorf("Init log failed: %v", err)
+	}
+
+	p, err := newPublisher()
+	if err != nil {
+		return
+	}
+	defer p.close()
+
+	svv, err := p.mc.AdminAPI().GetVolumeSimpleInfo(VolName)
+	if err != nil {
+		return fmt.Errorf("Get volume failed: %v", err)
+	}
+	if svv.Published {
+		fmt.Printf("Volume %v has already been published\n", VolName)
+		return nil
+	}
+
+	entries, err := p.walk()
+	if err != nil {
+		return fmt.Errorf("Walk volume failed: %v", err)
+	}
+	if err = p.hash(entries); err != nil {
+		return fmt.Errorf("Hash files failed: %v", err)
+	}
+	plan := buildDedupPlan(entries)
+	fmt.Printf("files: %v, contents: %v, duplicated inodes: %v, bytes: %v -> %v\n",
+		plan.Files, plan.Contents, plan.Duplicates, plan.TotalBytes, plan.UniqueBytes)
+	if DryRun {
+		return nil
+	}
+
+	if err = p.apply(plan); err != nil {
+		return fmt.Errorf("Deduplicate failed: %v", err)
+	}
+	if ManifestFile != "" {
+		if err = writeManifest(ManifestFile, entries, plan); err != nil {
+			return fmt.Errorf("Write manifest failed: %v", err)
+		}
+	}
+	if err = p.mc.AdminAPI().PublishVolume(VolName, util.CalcAuthKey(svv.Owner)); err != nil {
+		return fmt.Errorf("Freeze volume failed: %v", err)
+	}
+	fmt.Printf("Volume %v has been published\n", VolName)
+	return nil
+}
+
+func newPublisher() (p *publisher, err error) {
+	masters := strings.Split(MasterAddr, meta.HostsSeparator)
+	p = &publisher{mc: masterSDK.NewMasterClient(masters, false)}
+	if p.mw, err = meta.NewMetaWrapper(&meta.MetaConfig{Volume: VolName, Masters: masters}); err != nil {
+		return nil, fmt.Errorf("NewMetaWrapper failed: %v", err)
+	}
+	view, err := p.mc.AdminAPI().GetVolumeSimpleInfo(VolName)
+	if err != nil {
+		p.mw.Close()
+		return nil, fmt.Errorf("Get volume failed: %v", err)
+	}
+	if p.ec, err = stream.NewExtentClient(&stream.ExtentConfig{
+		Volume:                      VolName,
+		Masters:                     masters,
+		OnAppendExtentKey:           p.mw.AppendExtentKey,
+		OnSplitExtentKey:            p.mw.SplitExtentKey,
+		OnGetExtents:                p.mw.GetExtents,
+		OnTruncate:                  p.mw.Truncate,
+		OnRenewalForbiddenMigration: p.mw.RenewalForbiddenMigration,
+		OnForbiddenMigration:        p.mw.ForbiddenMigration,
+		VolStorageClass:             view.VolStorageClass,
+		VolAllowedStorageClass:      view.AllowedStorageClass,
+		DisableMetaCache:            true,
+		MetaWrapper:                 p.mw,
+	}); err != nil {
+		p.mw.Close()
+		return nil, fmt.Errorf("NewExtentClient failed: %v", err)
+	}
+	return
+}
+
+func (p *publisher) close() {
+	p.ec.Close()
+	p.mw.Close()
+}
+
+// walk lists every regular file of the volume. Symlinks and special files are
+// left untouched since they carry no data worth deduplicating.
+func (p *publisher) walk() (entries []*fileEntry, err error) {
+	type dir struct {
+		ino  uint64
+		path string
+	}
+	dirs := []dir{{ino: proto.RootIno, path: "/"}}
+	for len(dirs) > 0 {
+		d := dirs[0]
+		dirs = dirs[1:]
+		children, err := p.mw.ReadDir_ll(d.ino)
+		if err != nil {
+			return nil, fmt.Errorf("readdir %v: %v", d.path, err)
+		}
+		files := make(map[uint64][]proto.Dentry)
+		inodes := make([]uint64, 0, len(children))
+		for _, child := range children {
+			if proto.IsDir(child.Type) {
+				dirs = append(dirs, dir{ino: child.Inode, path: path.Join(d.path, child.Name)})
+				continue
+			}
+			if !proto.IsRegular(child.Type) {
+				continue
+			}
+			if _, ok := files[child.Inode]; !ok {
+				inodes = append(inodes, child.Inode)
+			}
+			files[child.Inode] = append(files[child.Inode], child)
+		}
+		if len(inodes) == 0 {
+			continue
+		}
+		infos := p.mw.BatchInodeGet(inodes)
+		if len(infos) != len(inodes) {
+			return nil, fmt.Errorf("get inodes of %v: expect %v got %v", d.path, len(inodes), len(infos))
+		}
+		for _, info := range infos {
+			for _, den := range files[info.Inode] {
+				entries = append(entries, &fileEntry{
+					ParentID: d.ino,
+					Name:     den.Name,
+					Path:     path.Join(d.path, den.Name),
+					Inode:    info.Inode,
+					Size:     info.Size,
+					Mode:     info.Mode,
+					Uid:      info.Uid,
+					Gid:      info.Gid,
+				})
+			}
+		}
+	}
+	log.LogInfof("publish: vol(%v) walked %v files", VolName, len(entries))
+	return
+}
+
+// hash computes the content digest of every inode once, hard links included.
+func (p *publisher) hash(entries []*fileEntry) error {
+	byInode := make(map[uint64][]*fileEntry)
+	for _, e := range entries {
+		byInode[e.Inode] = append(byInode[e.Inode], e)
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		inodeC   = make(chan uint64, Concurrency)
+	)
+	for i := 0; i < Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ino := range inodeC {
+				digest, err := p.digest(ino, byInode[ino][0].Size)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("hash %v: %v", byInode[ino][0].Path, err)
+				}
+				for _, e := range byInode[ino] {
+					e.Digest = digest
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for ino := range byInode {
+		inodeC <- ino
+	}
+	close(inodeC)
+	wg.Wait()
+	return firstErr
+}
+
+func (p *publisher) digest(ino uint64, size uint64) (digest string, err error) {
+	if err = p.ec.OpenStream(ino, false, false, publishFullPath); err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := p.ec.CloseStream(ino); closeErr != nil {
+			log.LogWarnf("publish: CloseStream ino(%v) err(%v)", ino, closeErr)
+		}
+	}()
+	h := sha256.New()
+	buf := make([]byte, 2*util.BlockSize)
+	for offset := 0; uint64(offset) < size; {
+		readSize := len(buf)
+		if rest := int(size) - offset; rest < readSize {
+			readSize = rest
+		}
+		n, err := p.ec.Read(ino, buf[:readSize], offset, readSize, proto.StorageClass_Unspecified, false)
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		h.Write(buf[:n])
+		offset += n
+		if err == io.EOF || n == 0 {
+			break
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// apply re-points the duplicated dentries to the canonical inode of their
+// content, tags the canonical inodes and releases the orphaned ones.
+func (p *publisher) apply(plan *dedupPlan) (err error) {
+	for _, e := range plan.moves {
+		canonical := plan.canonicalOf(e)
+		if _, err = p.mw.InodeLink_ll(canonical, e.Path); err != nil {
+			return fmt.Errorf("link %v: %v", e.Path, err)
+		}
+		var old uint64
+		if old, err = p.mw.DentryUpdate_ll(e.ParentID, e.Name, canonical, e.Path); err != nil {
+			if _, unlinkErr := p.mw.InodeUnlink_ll(canonical, e.Path); unlinkErr != nil {
+				log.LogWarnf("publish: rollback link of ino(%v) err(%v)", canonical, unlinkErr)
+			}
+			return fmt.Errorf("update dentry %v: %v", e.Path, err)
+		}
+		info, unlinkErr := p.mw.InodeUnlink_ll(old, e.Path)
+		if unlinkErr != nil {
+			log.LogWarnf("publish: unlink replaced ino(%v) of %v err(%v)", old, e.Path, unlinkErr)
+		} else if info != nil && info.Nlink == 0 {
+			if evictErr := p.mw.Evict(old, e.Path); evictErr != nil {
+				log.LogWarnf("publish: evict ino(%v) err(%v)", old, evictErr)
+			}
+		}
+		log.LogDebugf("publish: %v ino(%v) -> ino(%v)", e.Path, old, canonical)
+		e.Inode = canonical
+	}
+	for k, ino := range plan.canonical {
+		if err = p.mw.XAttrSet_ll(ino, []byte(DigestXAttrKey), []byte(k.digest)); err != nil {
+			return fmt.Errorf("tag ino(%v): %v", ino, err)
+		}
+	}
+	return nil
+}
+
+func writeManifest(name string, entries []*fileEntry, plan *dedupPlan) error {
+	data, err := json.MarshalIndent(struct {
+		Volume string       `json:"volume"`
+		Stat   *dedupPlan   `json:"stat"`
+		Files  []*fileEntry `json:"files"`
+	}{VolName, plan, entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	MasterAddr   string
+	VolName      string
+	LogDir       string
+	ManifestFile string
+	Concurrency  int
+	DryRun       bool
+)
+
+func NewRootCmd() *cobra.Command {
+	var optShowVersion bool
+	c := &cobra.Command{
+		Use:   path.Base(os.Args[0]),
+		Short: "CubeFS dataset publish tool",
+		Long: `Convert a finalized dataset volume into a read-only, content-addressed layout.
+Files with identical content are merged into a single inode, every remaining
+inode is tagged with its content digest, and the volume is frozen for good.`,
+		Args: cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if optShowVersion {
+				fmt.Fprintln(os.Stdout, proto.DumpVersion("PUBLISH"))
+				return nil
+			}
+			return Publish()
+		},
+	}
+
+	c.Flags().StringVarP(&MasterAddr, "master", "m", "", "master addresses")
+	c.Flags().StringVarP(&VolName, "vol", "V", "", "volume name")
+	c.Flags().StringVarP(&LogDir, "log-dir", "", ".", "directory of the log files")
+	c.Flags().StringVarP(&ManifestFile, "manifest", "", "", "write the digest manifest of the volume to this local file")
+	c.Flags().IntVarP(&Concurrency, "concurrency", "c", 8, "number of files hashed concurrently")
+	c.Flags().BoolVarP(&DryRun, "dry-run", "", false, "only report the deduplication, do not modify the volume")
+	c.Flags().BoolVarP(&optShowVersion, "version", "v", false, "Show version information")
+
+	return c
+}
@@ -30,7 +30,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/cubefs/cubefs/util/fileutil"
@@ -499,8 +498,8 @@ func InitAuditWithHeadRoom(dir, logModule string, logMaxSize int64, logLeftSpace
 		gAdt = adt
 	}
 
-	fs := syscall.Statfs_t{}
-	if err := syscall.Statfs(dir, &fs); err != nil {
+	fs, err := fileutil.Statfs(dir)
+	if err != nil {
 		return nil, fmt.Errorf("[InitLog] stats disk space: %s", err.Error())
 	}
 	minLogLeftSpaceLimit := float64(fs.Blocks*uint64(fs.Bsize)) * logLeftSpaceLimitRatio / 1024 / 1024
@@ -760,7 +759,7 @@ func (a *Audit) shiftFiles() error {
 		return nil
 	}
 
-	if syscall.Access(a.logFileName, F_OK) == nil {
+	if _, err = os.Stat(a.logFileName); err == nil {
 		logNewFileName := a.logFileName + "." + time.Now().Format(FileNameDateFormat) + ShiftedExtension
 
 		a.writer.Flush()
@@ -0,0 +1,228 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The header based AWS signature version 4, so that the requests to the
+// admin APIs can be signed by the S3 tools with an access key, like
+// curl --aws-sigv4 "aws:amz:cfs:master" --user "ak:sk".
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+const (
+	SigV4Algorithm       = "AWS4-HMAC-SHA256"
+	SigV4Region          = "cfs"
+	SigV4ServiceMaster   = "master"
+	SigV4MaxClockSkew    = 15 * time.Minute
+	SigV4UnsignedPayload = "UNSIGNED-PAYLOAD"
+	// the body of a signed request is held in memory to be hashed before the
+	// signature is checked, the admin requests are far smaller
+	SigV4MaxBodySize = 16 << 20
+
+	sigV4TimeFormat     = "20060102T150405Z"
+	sigV4DateFormat     = "20060102"
+	sigV4Request        = "aws4_request"
+	headerAmzDate       = "X-Amz-Date"
+	headerAmzSha256     = "X-Amz-Content-Sha256"
+	headerAuthorization = "Authorization"
+)
+
+var (
+	ErrSigV4Malformed      = errors.New("malformed sigv4 authorization")
+	ErrSigV4Expired        = errors.New("sigv4 request time too skewed")
+	ErrSigV4PayloadHash    = errors.New("sigv4 payload hash mismatch")
+	ErrSigV4SignatureWrong = errors.New("sigv4 signature does not match")
+	ErrSigV4Scope          = errors.New("sigv4 credential scope mismatch")
+)
+
+// SigV4Authorization is the parsed Authorization header of a signed request.
+type SigV4Authorization struct {
+	AccessKey     string
+	Date          string
+	Region        string
+	Service       string
+	SignedHeaders []string
+	Signature     string
+}
+
+// IsSigV4Signed tells whether the request claims a sigv4 signature.
+func IsSigV4Signed(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get(headerAuthorization), SigV4Algorithm+" ")
+}
+
+// ParseSigV4Authorization parses
+// AWS4-HMAC-SHA256 Credential=ak/date/region/service/aws4_request, SignedHeaders=a;b, Signature=hex
+func ParseSigV4Authorization(header string) (auth *SigV4Authorization, err error) {
+	if !strings.HasPrefix(header, SigV4Algorithm+" ") {
+		return nil, ErrSigV4Malformed
+	}
+	auth = &SigV4Authorization{}
+	for _, field := range strings.Split(strings.TrimPrefix(header, SigV4Algorithm+" "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			return nil, ErrSigV4Malformed
+		}
+		switch kv[0] {
+		case "Credential":
+			scope := strings.Split(kv[1], "/")
+			if len(scope) != 5 || scope[4] != sigV4Request {
+				return nil, ErrSigV4Malformed
+			}
+			auth.AccessKey, auth.Date, auth.Region, auth.Service = scope[0], scope[1], scope[2], scope[3]
+		case "SignedHeaders":
+			auth.SignedHeaders = strings.Split(kv[1], ";")
+		case "Signature":
+			auth.Signature = kv[1]
+		}
+	}
+	if auth.AccessKey == "" || auth.Signature == "" || len(auth.SignedHeaders) == 0 {
+		return nil, ErrSigV4Malformed
+	}
+	return
+}
+
+// VerifySigV4 checks the signature of r with the secret key of the access key
+// the request was signed with. The host and the date must be signed, and the
+// body is read and put back.
+func VerifySigV4(r *http.Request, auth *SigV4Authorization, secretKey string, now time.Time) (err error) {
+	signed := make(map[string]bool, len(auth.SignedHeaders))
+	for _, h := range auth.SignedHeaders {
+		signed[h] = true
+	}
+	if !signed["host"] || !signed[strings.ToLower(headerAmzDate)] {
+		return ErrSigV4Malformed
+	}
+	amzDate := r.Header.Get(headerAmzDate)
+	ts, err := time.Parse(sigV4TimeFormat, amzDate)
+	if err != nil || ts.Format(sigV4DateFormat) != auth.Date {
+		return ErrSigV4Malformed
+	}
+	if skew := now.Sub(ts); skew > SigV4MaxClockSkew || skew < -SigV4MaxClockSkew {
+		return ErrSigV4Expired
+	}
+	payloadHash, err := sigV4PayloadHash(r)
+	if err != nil {
+		return
+	}
+	signature := sigV4Signature(r, auth.SignedHeaders, payloadHash, amzDate, auth.Date, auth.Region, auth.Service, secretKey)
+	if !hmac.Equal([]byte(signature), []byte(auth.Signature)) {
+		return ErrSigV4SignatureWrong
+	}
+	return nil
+}
+
+// SignV4 signs r, whose body is given apart as the requests do not keep it.
+func SignV4(r *http.Request, body []byte, accessKey, secretKey, region, service string, now time.Time) {
+	amzDate := now.UTC().Format(sigV4TimeFormat)
+	date := now.UTC().Format(sigV4DateFormat)
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	r.Header.Set(headerAmzDate, amzDate)
+	r.Header.Set(headerAmzSha256, payloadHash)
+	signedHeaders := []string{"host", strings.ToLower(headerAmzSha256), strings.ToLower(headerAmzDate)}
+	signature := sigV4Signature(r, signedHeaders, payloadHash, amzDate, date, region, service, secretKey)
+	r.Header.Set(headerAuthorization, fmt.Sprintf("%s Credential=%s/%s/%s/%s/%s, SignedHeaders=%s, Signature=%s",
+		SigV4Algorithm, accessKey, date, region, service, sigV4Request, strings.Join(signedHeaders, ";"), signature))
+}
+
+// sigV4PayloadHash returns the hash the payload was signed with. An unsigned
+// payload is only accepted without a body, so that the body can't be changed.
+// The bodies larger than SigV4MaxBodySize are rejected before they are read
+// whole, the caller is not authenticated yet.
+func sigV4PayloadHash(r *http.Request) (hash string, err error) {
+	var body []byte
+	if r.Body != nil {
+		if body, err = io.ReadAll(http.MaxBytesReader(nil, r.Body, SigV4MaxBodySize)); err != nil {
+			return
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	sum := sha256.Sum256(body)
+	hash = hex.EncodeToString(sum[:])
+	claimed := r.Header.Get(headerAmzSha256)
+	switch {
+	case claimed == "" || claimed == hash:
+		return hash, nil
+	case claimed == SigV4UnsignedPayload && len(body) == 0:
+		return claimed, nil
+	}
+	return "", ErrSigV4PayloadHash
+}
+
+func sigV4Signature(r *http.Request, signedHeaders []string, payloadHash, amzDate, date, region, service, secretKey string) string {
+	headers := append([]string{}, signedHeaders...)
+	sort.Strings(headers)
+	canonicalHeaders := make([]string, 0, len(headers))
+	for _, h := range headers {
+		var value string
+		if h == "host" {
+			value = r.Host
+			if value == "" {
+				value = r.URL.Host
+			}
+		} else {
+			value = strings.Join(r.Header.Values(h), ",")
+		}
+		canonicalHeaders = append(canonicalHeaders, h+":"+strings.Join(strings.Fields(value), " "))
+	}
+	path := r.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		path,
+		sigV4CanonicalQuery(r),
+		strings.Join(canonicalHeaders, "\n") + "\n",
+		strings.Join(headers, ";"),
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{date, region, service, sigV4Request}, "/")
+	sum := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{SigV4Algorithm, amzDate, scope, hex.EncodeToString(sum[:])}, "\n")
+
+	key := hmacSha256([]byte("AWS4"+secretKey), date)
+	key = hmacSha256(key, region)
+	key = hmacSha256(key, service)
+	key = hmacSha256(key, sigV4Request)
+	return hex.EncodeToString(hmacSha256(key, stringToSign))
+}
+
+// sigV4CanonicalQuery sorts the parameters by name then by value.
+func sigV4CanonicalQuery(r *http.Request) string {
+	query := r.URL.Query()
+	for _, values := range query {
+		sort.Strings(values)
+	}
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigV4(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"name":"test"}`)
+	var verified error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var auth *SigV4Authorization
+		if auth, verified = ParseSigV4Authorization(r.Header.Get("Authorization")); verified != nil {
+			return
+		}
+		require.Equal(t, "ak", auth.AccessKey)
+		require.Equal(t, SigV4ServiceMaster, auth.Service)
+		if verified = VerifySigV4(r, auth, "sk", now); verified == nil {
+			got, _ := io.ReadAll(r.Body)
+			require.Equal(t, body, got)
+		}
+	}))
+	defer srv.Close()
+
+	send := func(url string, body []byte, sk string, at time.Time, tamper func(r *http.Request)) error {
+		r, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		require.NoError(t, err)
+		SignV4(r, body, "ak", sk, SigV4Region, SigV4ServiceMaster, at)
+		require.True(t, IsSigV4Signed(r))
+		if tamper != nil {
+			tamper(r)
+		}
+		verified = ErrSigV4Malformed
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return verified
+	}
+	url := srv.URL + "/admin/setNodeInfo?batchCount=10&dpBackupTimeout=1h%20&z=a+b"
+	require.NoError(t, send(url, body, "sk", now, nil))
+	require.Equal(t, ErrSigV4SignatureWrong, send(url, body, "bad", now, nil))
+	require.Equal(t, ErrSigV4Expired, send(url, body, "sk", now.Add(-time.Hour), nil))
+	require.Equal(t, ErrSigV4SignatureWrong, send(url, body, "sk", now, func(r *http.Request) {
+		r.URL.RawQuery += "&batchCount=1000"
+	}))
+	require.Equal(t, ErrSigV4PayloadHash, send(url, body, "sk", now, func(r *http.Request) {
+		r.Body = io.NopCloser(bytes.NewReader([]byte(`{"name":"tset"}`)))
+	}))
+
+	// the body is not held whole before the signature is checked
+	large := make([]byte, SigV4MaxBodySize+1)
+	err := send(url, large, "sk", now, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too large")
+
+	_, err = ParseSigV4Authorization(SigV4Algorithm + " Credential=ak/20250101/cfs/master, Signature=00")
+	require.Equal(t, ErrSigV4Malformed, err)
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package bundle packs what a support case needs from a server into one
+// tar.gz: the goroutines, the memory stats, the config without its secrets,
+// the tail of the logs and the internal state the server registers, like
+// its partitions and cache stats. It is served on the debug port at Path.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/util/config"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	Path = "/debug/bundle"
+
+	DefaultLogBytes = 4 << 20 // of each log file
+	MaxLogBytes     = 64 << 20
+
+	paramLogBytes = "logBytes"
+	redacted      = "******"
+)
+
+// the config keys with one of them in lower case are not put in the bundle
+var secretKeyWords = []string{"secret", "password", "passwd", "token", "accesskey", "privatekey", "authkey", "servicekey"}
+
+// StateFunc returns a state put in the bundle as json.
+type StateFunc func() interface{}
+
+var (
+	mu      sync.RWMutex
+	role    string
+	version string
+	values  map[string]interface{}
+	states  = make(map[string]StateFunc)
+)
+
+// Init sets the role, the version and the config of the server.
+func Init(r, v string, cfg *config.Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	role, version = r, v
+	if cfg != nil {
+		values = redact(cfg.Values())
+	}
+}
+
+// RegisterState registers a state of the server, put in state/name.json.
+// A state registered again replaces the former one.
+func RegisterState(name string, fn StateFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	states[name] = fn
+}
+
+// Handler serves the bundle, with the last logBytes of each log file.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	logBytes := int64(DefaultLogBytes)
+	if v := r.FormValue(paramLogBytes); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			http.Error(w, fmt.Sprintf("invalid %v: %v", paramLogBytes, v), http.StatusBadRequest)
+			return
+		}
+		if n > MaxLogBytes {
+			n = MaxLogBytes
+		}
+		logBytes = n
+	}
+	mu.RLock()
+	name := role
+	mu.RUnlock()
+	hostname, _ := os.Hostname()
+	fileName := fmt.Sprintf("%v_%v_%v.tar.gz", name, hostname, time.Now().Format("20060102150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	if err := Write(w, logBytes); err != nil {
+		log.LogWarnf("bundle: write to %v failed: %v", r.RemoteAddr, err)
+	}
+}
+
+// Write writes the bundle to w. A state that panics is recorded with its
+// error, so that the rest of the bundle is still collected.
+func Write(w io.Writer, logBytes int64) (err error) {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	addJSON := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			data, _ = json.Marshal(map[string]string{"error": err.Error()})
+		}
+		return add(name, data)
+	}
+
+	mu.RLock()
+	info := map[string]interface{}{
+		"role":         role,
+		"version":      version,
+		"pid":          os.Getpid(),
+		"time":         now.Format(time.RFC3339),
+		"goVersion":    runtime.Version(),
+		"numCPU":       runtime.NumCPU(),
+		"numGoroutine": runtime.NumGoroutine(),
+	}
+	info["hostname"], _ = os.Hostname()
+	cfgValues := values
+	names := make([]string, 0, len(states))
+	fns := make(map[string]StateFunc, len(states))
+	for name, fn := range states {
+		names = append(names, name)
+		fns[name] = fn
+	}
+	mu.RUnlock()
+	sort.Strings(names)
+
+	if err = addJSON("info.json", info); err != nil {
+		return
+	}
+	goroutines := new(bytes.Buffer)
+	if err = pprof.Lookup("goroutine").WriteTo(goroutines, 2); err != nil {
+		return
+	}
+	if err = add("goroutines.txt", goroutines.Bytes()); err != nil {
+		return
+	}
+	memStats := new(runtime.MemStats)
+	runtime.ReadMemStats(memStats)
+	if err = addJSON("memstats.json", memStats); err != nil {
+		return
+	}
+	if err = addJSON("config.json", cfgValues); err != nil {
+		return
+	}
+	for _, name := range names {
+		if err = addJSON("state/"+name+".json", collectState(fns[name])); err != nil {
+			return
+		}
+	}
+	if err = addLogs(add, logBytes); err != nil {
+		return
+	}
+	if err = tw.Close(); err != nil {
+		return
+	}
+	return gw.Close()
+}
+
+func collectState(fn StateFunc) (state interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			state = map[string]string{"error": fmt.Sprintf("panic: %v", r)}
+		}
+	}()
+	return fn()
+}
+
+// addLogs adds the tail of the current log files, starting at a new line.
+func addLogs(add func(string, []byte) error, logBytes int64) error {
+	if log.LogDir == "" || logBytes == 0 {
+		return nil
+	}
+	log.LogFlush()
+	files, err := filepath.Glob(filepath.Join(log.LogDir, "*.log"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		data, err := tailFile(file, logBytes)
+		if err != nil {
+			data = []byte(fmt.Sprintf("read %v failed: %v\n", file, err))
+		}
+		if err = add("logs/"+filepath.Base(file), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tailFile(file string, n int64) (data []byte, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return
+	}
+	offset := fi.Size() - n
+	if offset < 0 {
+		offset = 0
+	}
+	if data, err = io.ReadAll(io.NewSectionReader(f, offset, fi.Size()-offset)); err != nil {
+		return
+	}
+	if offset > 0 {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return
+}
+
+func redact(values map[string]interface{}) map[string]interface{} {
+	for k, v := range values {
+		if isSecret(k) {
+			values[k] = redacted
+			continue
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			copied := make(map[string]interface{}, len(m))
+			for mk, mv := range m {
+				copied[mk] = mv
+			}
+			values[k] = redact(copied)
+		}
+	}
+	return values
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range secretKeyWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cubefs/cubefs/util/config"
+	"github.com/cubefs/cubefs/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func readBundle(t *testing.T, r io.Reader) map[string][]byte {
+	gr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = data
+	}
+	return files
+}
+
+func TestBundle(t *testing.T) {
+	logDir := t.TempDir()
+	oldLogDir := log.LogDir
+	log.LogDir = logDir
+	defer func() { log.LogDir = oldLogDir }()
+	lines := "line1\nline2\nline3\n"
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "test_info.log"), []byte(lines), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "test_info.log.20250101"), []byte(lines), 0o644))
+
+	Init("test", "v1", config.LoadConfigString(`{"role":"test","masterServiceKey":"abc","audit":{"saslPassword":"x","topic":"t"}}`))
+	RegisterState("partitions", func() interface{} { return []uint64{1, 2} })
+	RegisterState("broken", func() interface{} { panic("boom") })
+	defer func() {
+		mu.Lock()
+		states = make(map[string]StateFunc)
+		mu.Unlock()
+	}()
+
+	w := httptest.NewRecorder()
+	Handler(w, httptest.NewRequest(http.MethodGet, Path+"?logBytes=10", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Disposition"), "test_")
+	files := readBundle(t, w.Body)
+
+	require.Contains(t, string(files["goroutines.txt"]), "goroutine")
+	require.Contains(t, files, "info.json")
+	require.Contains(t, files, "memstats.json")
+
+	cfg := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(files["config.json"], &cfg))
+	require.Equal(t, "test", cfg["role"])
+	require.Equal(t, redacted, cfg["masterServiceKey"])
+	require.Equal(t, map[string]interface{}{"saslPassword": redacted, "topic": "t"}, cfg["audit"])
+
+	require.JSONEq(t, `[1, 2]`, string(files["state/partitions.json"]))
+	require.True(t, strings.Contains(string(files["state/broken.json"]), "boom"))
+
+	// the tail starts at a new line, and the rotated logs are not taken
+	require.Equal(t, "line3\n", string(files["logs/test_info.log"]))
+	require.NotContains(t, files, "logs/test_info.log.20250101")
+
+	w = httptest.NewRecorder()
+	Handler(w, httptest.NewRequest(http.MethodGet, Path+"?logBytes=-1", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package cgroup reads the memory and cpu limits of the cgroup the process runs in,
+// and throttles the process before it reaches them.
+package cgroup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	procSelfCgroup = "/proc/self/cgroup"
+	defaultRoot    = "/sys/fs/cgroup"
+
+	// cgroup v1 reports a page aligned max int64 when memory is not limited
+	v1UnlimitedMemory = uint64(1) << 62
+)
+
+var ErrNoCgroup = errors.New("no cgroup found")
+
+// Cgroup locates the memory and cpu controllers of the cgroup of the process.
+type Cgroup struct {
+	v2     bool
+	memDir string
+	cpuDir string
+	// cpuacct may be mounted apart from cpu in cgroup v1
+	cpuAcctDir string
+}
+
+// Detect finds the cgroup of the current process.
+func Detect() (*Cgroup, error) {
+	return detect(procSelfCgroup, defaultRoot)
+}
+
+func detect(cgroupFile, root string) (cg *Cgroup, err error) {
+	f, err := os.Open(cgroupFile)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	var v2Path string
+	v1Paths := make(map[string]string)
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scan.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			v2Path = parts[2]
+			continue
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			v1Paths[controller] = parts[2]
+		}
+	}
+	if err = scan.Err(); err != nil {
+		return
+	}
+
+	cg = &Cgroup{}
+	if _, ok := v1Paths["memory"]; ok {
+		cg.memDir = resolveDir(filepath.Join(root, "memory"), v1Paths["memory"])
+		cg.cpuDir = resolveDir(firstExisting(filepath.Join(root, "cpu,cpuacct"), filepath.Join(root, "cpu")), v1Paths["cpu"])
+		cg.cpuAcctDir = resolveDir(firstExisting(filepath.Join(root, "cpu,cpuacct"), filepath.Join(root, "cpuacct")), v1Paths["cpuacct"])
+		return
+	}
+	if _, err = os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil || v2Path == "" {
+		return nil, ErrNoCgroup
+	}
+	cg.v2 = true
+	cg.memDir = resolveDir(root, v2Path)
+	cg.cpuDir = cg.memDir
+	cg.cpuAcctDir = cg.memDir
+	return
+}
+
+// resolveDir returns the directory of the cgroup under the mount point. In a
+// container the mount point usually is the cgroup itself, while the path read
+// from /proc is the one of the host.
+func resolveDir(mount, path string) string {
+	dir := filepath.Join(mount, path)
+	if _, err := os.Stat(dir); err == nil {
+		return dir
+	}
+	return mount
+}
+
+func firstExisting(dirs ...string) string {
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+	}
+	return dirs[len(dirs)-1]
+}
+
+func (cg *Cgroup) String() string {
+	version := "v1"
+	if cg.v2 {
+		version = "v2"
+	}
+	return fmt.Sprintf("cgroup %v memory(%v) cpu(%v)", version, cg.memDir, cg.cpuDir)
+}
+
+// MemoryLimit returns the memory limit in bytes, 0 if memory is not limited.
+func (cg *Cgroup) MemoryLimit() (limit uint64, err error) {
+	if cg.v2 {
+		var value string
+		if value, err = readString(filepath.Join(cg.memDir, "memory.max")); err != nil || value == "max" {
+			return
+		}
+		return strconv.ParseUint(value, 10, 64)
+	}
+	if limit, err = readUint(filepath.Join(cg.memDir, "memory.limit_in_bytes")); err != nil {
+		return
+	}
+	if limit >= v1UnlimitedMemory {
+		limit = 0
+	}
+	return
+}
+
+// MemoryUsed returns the working set of the cgroup, which is what the OOM killer
+// compares against the limit: the usage excluding the inactive page cache.
+func (cg *Cgroup) MemoryUsed() (used uint64, err error) {
+	usageFile, inactiveKey := "memory.usage_in_bytes", "total_inactive_file"
+	if cg.v2 {
+		usageFile, inactiveKey = "memory.current", "inactive_file"
+	}
+	if used, err = readUint(filepath.Join(cg.memDir, usageFile)); err != nil {
+		return
+	}
+	stat, err := readKeyValues(filepath.Join(cg.memDir, "memory.stat"))
+	if err != nil {
+		return
+	}
+	if inactive := stat[inactiveKey]; inactive < used {
+		used -= inactive
+	}
+	return
+}
+
+// CPULimit returns the cpu quota in cores, 0 if cpu is not limited.
+func (cg *Cgroup) CPULimit() (cores float64, err error) {
+	if cg.v2 {
+		var value string
+		if value, err = readString(filepath.Join(cg.cpuDir, "cpu.max")); err != nil {
+			return
+		}
+		// $MAX $PERIOD
+		fields := strings.Fields(value)
+		if len(fields) != 2 || fields[0] == "max" {
+			return
+		}
+		var quota, period uint64
+		if quota, err = strconv.ParseUint(fields[0], 10, 64); err != nil {
+			return
+		}
+		if period, err = strconv.ParseUint(fields[1], 10, 64); err != nil || period == 0 {
+			return
+		}
+		return float64(quota) / float64(period), nil
+	}
+	value, err := readString(filepath.Join(cg.cpuDir, "cpu.cfs_quota_us"))
+	if err != nil {
+		return
+	}
+	quota, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || quota <= 0 {
+		return
+	}
+	period, err := readUint(filepath.Join(cg.cpuDir, "cpu.cfs_period_us"))
+	if err != nil || period == 0 {
+		return
+	}
+	return float64(quota) / float64(period), nil
+}
+
+// CPUUsage returns the cpu time consumed by the cgroup since it was created.
+func (cg *Cgroup) CPUUsage() (usage time.Duration, err error) {
+	if cg.v2 {
+		var stat map[string]uint64
+		if stat, err = readKeyValues(filepath.Join(cg.cpuAcctDir, "cpu.stat")); err != nil {
+			return
+		}
+		return time.Duration(stat["usage_usec"]) * time.Microsecond, nil
+	}
+	ns, err := readUint(filepath.Join(cg.cpuAcctDir, "cpuacct.usage"))
+	return time.Duration(ns), err
+}
+
+func readString(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readUint(path string) (uint64, error) {
+	value, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func readKeyValues(path string) (kv map[string]uint64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	kv = make(map[string]uint64)
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if value, e := strconv.ParseUint(fields[1], 10, 64); e == nil {
+			kv[fields[0]] = value
+		}
+	}
+	return kv, scan.Err()
+}
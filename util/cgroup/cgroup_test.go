@@ -0,0 +1,152 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestCgroupV2(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"proc":                              "0::/kubepods/pod1\n",
+		"root/cgroup.controllers":           "cpu memory\n",
+		"root/kubepods/pod1/memory.max":     "1073741824\n",
+		"root/kubepods/pod1/memory.current": "600000000\n",
+		"root/kubepods/pod1/memory.stat":    "anon 400000000\ninactive_file 100000000\n",
+		"root/kubepods/pod1/cpu.max":        "200000 100000\n",
+		"root/kubepods/pod1/cpu.stat":       "usage_usec 5000000\nuser_usec 4000000\n",
+	})
+	cg, err := detect(filepath.Join(dir, "proc"), filepath.Join(dir, "root"))
+	require.NoError(t, err)
+	limit, err := cg.MemoryLimit()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<30), limit)
+	used, err := cg.MemoryUsed()
+	require.NoError(t, err)
+	require.Equal(t, uint64(500000000), used)
+	cores, err := cg.CPULimit()
+	require.NoError(t, err)
+	require.Equal(t, 2.0, cores)
+	usage, err := cg.CPUUsage()
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, usage)
+
+	writeFiles(t, dir, map[string]string{
+		"root/kubepods/pod1/memory.max": "max\n",
+		"root/kubepods/pod1/cpu.max":    "max 100000\n",
+	})
+	limit, err = cg.MemoryLimit()
+	require.NoError(t, err)
+	require.Zero(t, limit)
+	cores, err = cg.CPULimit()
+	require.NoError(t, err)
+	require.Zero(t, cores)
+}
+
+func TestCgroupV1(t *testing.T) {
+	dir := t.TempDir()
+	// the host path is not visible inside the container, the mount point is used
+	writeFiles(t, dir, map[string]string{
+		"proc":                               "12:memory:/docker/abc\n4:cpu,cpuacct:/docker/abc\n",
+		"root/memory/memory.limit_in_bytes":  "9223372036854771712\n",
+		"root/memory/memory.usage_in_bytes":  "300\n",
+		"root/memory/memory.stat":            "cache 100\ntotal_inactive_file 100\n",
+		"root/cpu,cpuacct/cpu.cfs_quota_us":  "50000\n",
+		"root/cpu,cpuacct/cpu.cfs_period_us": "100000\n",
+		"root/cpu,cpuacct/cpuacct.usage":     "1000000000\n",
+	})
+	cg, err := detect(filepath.Join(dir, "proc"), filepath.Join(dir, "root"))
+	require.NoError(t, err)
+	limit, err := cg.MemoryLimit()
+	require.NoError(t, err)
+	require.Zero(t, limit)
+	used, err := cg.MemoryUsed()
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), used)
+	cores, err := cg.CPULimit()
+	require.NoError(t, err)
+	require.Equal(t, 0.5, cores)
+	usage, err := cg.CPUUsage()
+	require.NoError(t, err)
+	require.Equal(t, time.Second, usage)
+
+	_, err = detect(filepath.Join(dir, "none"), filepath.Join(dir, "root"))
+	require.Error(t, err)
+}
+
+func TestThrottler(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"proc":                    "0::/\n",
+		"root/cgroup.controllers": "cpu memory\n",
+		"root/memory.max":         "1000\n",
+		"root/memory.current":     "500\n",
+		"root/memory.stat":        "inactive_file 0\n",
+		"root/cpu.max":            "100000 100000\n",
+		"root/cpu.stat":           "usage_usec 0\n",
+	})
+	cg, err := detect(filepath.Join(dir, "proc"), filepath.Join(dir, "root"))
+	require.NoError(t, err)
+	shrinks := 0
+	th := newThrottler(cg, ThrottleConfig{MemoryRatio: 0.85, CPURatio: 0.9}, func() { shrinks++ })
+	require.NotNil(t, th)
+
+	now := time.Now()
+	th.sample(now)
+	require.False(t, th.Throttled())
+	require.False(t, th.ShouldReject())
+
+	writeFiles(t, dir, map[string]string{"root/memory.current": "900\n"})
+	th.sample(now.Add(time.Second))
+	require.True(t, th.MemoryThrottled())
+	require.True(t, th.ShouldReject())
+	require.Equal(t, 1, shrinks)
+
+	// stays throttled until the usage falls below ratio - gap
+	writeFiles(t, dir, map[string]string{"root/memory.current": "820\n"})
+	th.sample(now.Add(2 * time.Second))
+	require.True(t, th.MemoryThrottled())
+	writeFiles(t, dir, map[string]string{"root/memory.current": "700\n", "root/cpu.stat": "usage_usec 2950000\n"})
+	th.sample(now.Add(5 * time.Second))
+	require.False(t, th.MemoryThrottled())
+	require.True(t, th.Throttled())
+
+	state := th.State()
+	require.True(t, state.CPUThrottled)
+	require.InDelta(t, 0.98, state.CPUUsed, 0.01)
+	require.Equal(t, uint64(1), state.RejectedOps)
+	require.Equal(t, now.Add(time.Second).Unix(), state.Since)
+
+	var nilThrottler *Throttler
+	require.False(t, nilThrottler.ShouldReject())
+	require.False(t, nilThrottler.State().Throttled())
+
+	writeFiles(t, dir, map[string]string{"root/memory.max": "max\n", "root/cpu.max": "max 100000\n"})
+	require.Nil(t, newThrottler(cg, ThrottleConfig{}, nil))
+}
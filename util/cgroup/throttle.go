@@ -0,0 +1,215 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cgroup
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	DefaultMemoryThrottleRatio = 0.85
+	DefaultCPUThrottleRatio    = 0.9
+	DefaultThrottleInterval    = 5 * time.Second
+
+	// throttling stops once the usage falls this far below the ratio, so that
+	// it does not flap around the threshold
+	throttleRecoverGap = 0.05
+	// forcing a GC on a large heap is expensive, do it at most this often
+	freeOSMemoryInterval = time.Minute
+)
+
+type ThrottleConfig struct {
+	// ratio of the limit above which the throttling starts, <= 0 disables it
+	MemoryRatio float64
+	CPURatio    float64
+	Interval    time.Duration
+}
+
+// Throttler samples the usage of the cgroup and tells the node to shed load when
+// it approaches the limits. A nil Throttler never throttles.
+type Throttler struct {
+	cg     *Cgroup
+	conf   ThrottleConfig
+	shrink func()
+
+	memThrottled int32
+	cpuThrottled int32
+	rejected     uint64
+
+	lock      sync.RWMutex
+	state     proto.ResourceThrottleState
+	lastUsage time.Duration
+	lastTime  time.Time
+	lastFree  time.Time
+}
+
+// NewThrottler returns nil if the process is not limited by a cgroup. shrink is
+// called to release caches while memory is throttled.
+func NewThrottler(conf ThrottleConfig, shrink func()) *Throttler {
+	cg, err := Detect()
+	if err != nil {
+		log.LogInfof("[NewThrottler] cgroup not detected, self throttling disabled: %v", err)
+		return nil
+	}
+	return newThrottler(cg, conf, shrink)
+}
+
+func newThrottler(cg *Cgroup, conf ThrottleConfig, shrink func()) *Throttler {
+	if conf.Interval <= 0 {
+		conf.Interval = DefaultThrottleInterval
+	}
+	t := &Throttler{cg: cg, conf: conf, shrink: shrink}
+	t.refreshLimits()
+	if t.state.MemoryLimit == 0 && t.state.CPULimit == 0 {
+		log.LogInfof("[NewThrottler] %v has no memory or cpu limit, self throttling disabled", cg)
+		return nil
+	}
+	log.LogInfof("[NewThrottler] %v memory limit %v cpu limit %v, throttle ratio memory %v cpu %v",
+		cg, t.state.MemoryLimit, t.state.CPULimit, conf.MemoryRatio, conf.CPURatio)
+	return t
+}
+
+func (t *Throttler) refreshLimits() {
+	memLimit, err := t.cg.MemoryLimit()
+	if err != nil {
+		log.LogWarnf("[refreshLimits] read memory limit of %v: %v", t.cg, err)
+	}
+	cpuLimit, err := t.cg.CPULimit()
+	if err != nil {
+		log.LogWarnf("[refreshLimits] read cpu limit of %v: %v", t.cg, err)
+	}
+	t.lock.Lock()
+	t.state.MemoryLimit, t.state.CPULimit = memLimit, cpuLimit
+	t.lock.Unlock()
+}
+
+// Start samples the cgroup until stopC is closed.
+func (t *Throttler) Start(stopC chan struct{}) {
+	if t == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(t.conf.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				// limits can be changed on a running container
+				t.refreshLimits()
+				t.sample(time.Now())
+			}
+		}
+	}()
+}
+
+func (t *Throttler) sample(now time.Time) {
+	memUsed, err := t.cg.MemoryUsed()
+	if err != nil {
+		log.LogWarnf("[sample] read memory usage of %v: %v", t.cg, err)
+	}
+	cpuUsage, err := t.cg.CPUUsage()
+	if err != nil {
+		log.LogWarnf("[sample] read cpu usage of %v: %v", t.cg, err)
+	}
+
+	t.lock.Lock()
+	var cpuUsed float64
+	if !t.lastTime.IsZero() && cpuUsage >= t.lastUsage {
+		cpuUsed = float64(cpuUsage-t.lastUsage) / float64(now.Sub(t.lastTime))
+	}
+	t.lastUsage, t.lastTime = cpuUsage, now
+	t.state.MemoryUsed, t.state.CPUUsed = memUsed, cpuUsed
+	memThrottled := throttled(t.state.MemoryThrottled, float64(memUsed), float64(t.state.MemoryLimit), t.conf.MemoryRatio)
+	cpuThrottled := throttled(t.state.CPUThrottled, cpuUsed, t.state.CPULimit, t.conf.CPURatio)
+	if memThrottled != t.state.MemoryThrottled || cpuThrottled != t.state.CPUThrottled {
+		log.LogWarnf("[sample] throttle memory %v->%v (%v/%v) cpu %v->%v (%.2f/%.2f cores)",
+			t.state.MemoryThrottled, memThrottled, memUsed, t.state.MemoryLimit,
+			t.state.CPUThrottled, cpuThrottled, cpuUsed, t.state.CPULimit)
+	}
+	if !t.state.Throttled() && (memThrottled || cpuThrottled) {
+		t.state.Since = now.Unix()
+	}
+	t.state.MemoryThrottled, t.state.CPUThrottled = memThrottled, cpuThrottled
+	freeOSMemory := memThrottled && now.Sub(t.lastFree) >= freeOSMemoryInterval
+	if freeOSMemory {
+		t.lastFree = now
+	}
+	t.lock.Unlock()
+
+	atomic.StoreInt32(&t.memThrottled, boolToInt32(memThrottled))
+	atomic.StoreInt32(&t.cpuThrottled, boolToInt32(cpuThrottled))
+	if memThrottled {
+		if t.shrink != nil {
+			t.shrink()
+		}
+		if freeOSMemory {
+			debug.FreeOSMemory()
+		}
+	}
+}
+
+func throttled(was bool, used, limit, ratio float64) bool {
+	if limit <= 0 || ratio <= 0 {
+		return false
+	}
+	if was {
+		return used >= limit*(ratio-throttleRecoverGap)
+	}
+	return used >= limit*ratio
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (t *Throttler) MemoryThrottled() bool {
+	return t != nil && atomic.LoadInt32(&t.memThrottled) == 1
+}
+
+func (t *Throttler) Throttled() bool {
+	return t != nil && (atomic.LoadInt32(&t.memThrottled) == 1 || atomic.LoadInt32(&t.cpuThrottled) == 1)
+}
+
+// ShouldReject reports whether a low priority op should be rejected, and counts it.
+func (t *Throttler) ShouldReject() bool {
+	if !t.Throttled() {
+		return false
+	}
+	atomic.AddUint64(&t.rejected, 1)
+	return true
+}
+
+// State is reported to the master in the heartbeat.
+func (t *Throttler) State() (state proto.ResourceThrottleState) {
+	if t == nil {
+		return
+	}
+	t.lock.RLock()
+	state = t.state
+	t.lock.RUnlock()
+	state.RejectedOps = atomic.LoadUint64(&t.rejected)
+	return
+}
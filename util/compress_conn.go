@@ -0,0 +1,299 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pierrec/lz4"
+)
+
+// The client asks for the compression by sending a preamble before the first
+// packet of a connection. The preamble is as long as a packet header, so that
+// an old server fails on the bad magic and closes the connection at once.
+//
+//	| magic "CFSZ" | version | algorithm | threshold uint32 | padding |
+//
+// The server answers with the accepted algorithm in one byte, 0 means none.
+// Then each write on either side is sent as a frame:
+//
+//	| flag | wire length uint32 | raw length uint32 | payload |
+const (
+	CompressAlgoNone = 0
+	CompressAlgoLZ4  = 1
+
+	compressMagic           = "CFSZ"
+	compressVersion         = 1
+	compressFrameRaw        = 0
+	compressFrameLZ4        = 1
+	compressFrameHeaderSize = 9
+	compressMaxFrameSize    = 256 * MB
+	compressMinThreshold    = 64
+	compressKeepBufferSize  = 1 * MB
+	compressHandshakeTime   = 5 * time.Second
+	compressRetryInterval   = 600 // seconds before retrying a server which refused
+)
+
+var ErrCompressPreamble = errors.New("invalid compress preamble")
+
+// CompressConn compresses with lz4 the writes not smaller than the threshold.
+type CompressConn struct {
+	net.Conn
+	threshold int
+
+	wmu  sync.Mutex
+	wbuf []byte
+
+	hdr     [compressFrameHeaderSize]byte
+	cbuf    []byte
+	rbuf    []byte // decompressed payload not read yet
+	roff    int
+	rawLeft int // bytes of the current raw frame not read yet
+}
+
+func NewCompressConn(conn net.Conn, threshold int) *CompressConn {
+	if threshold < compressMinThreshold {
+		threshold = compressMinThreshold
+	}
+	return &CompressConn{Conn: conn, threshold: threshold}
+}
+
+func (c *CompressConn) Write(b []byte) (n int, err error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if len(b) >= c.threshold {
+		size := compressFrameHeaderSize + len(b)
+		if cap(c.wbuf) < size {
+			c.wbuf = make([]byte, size)
+		}
+		wbuf := c.wbuf[:size]
+		// the destination is smaller than the bound, incompressible data returns 0
+		var cn int
+		if cn, err = lz4.CompressBlock(b, wbuf[compressFrameHeaderSize:size-1], nil); err == nil && cn > 0 {
+			putFrameHeader(wbuf, compressFrameLZ4, cn, len(b))
+			_, err = c.Conn.Write(wbuf[:compressFrameHeaderSize+cn])
+			c.releaseWriteBuffer()
+			if err != nil {
+				return 0, err
+			}
+			return len(b), nil
+		}
+		c.releaseWriteBuffer()
+	}
+	var hdr [compressFrameHeaderSize]byte
+	putFrameHeader(hdr[:], compressFrameRaw, len(b), len(b))
+	buffers := net.Buffers{hdr[:], b}
+	if _, err = buffers.WriteTo(c.Conn); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c *CompressConn) Read(b []byte) (n int, err error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	for c.rawLeft == 0 && c.roff == len(c.rbuf) {
+		if err = c.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	if c.rawLeft > 0 {
+		if len(b) > c.rawLeft {
+			b = b[:c.rawLeft]
+		}
+		n, err = c.Conn.Read(b)
+		c.rawLeft -= n
+		return
+	}
+	n = copy(b, c.rbuf[c.roff:])
+	c.roff += n
+	if c.roff == len(c.rbuf) && cap(c.rbuf) > compressKeepBufferSize {
+		c.rbuf, c.cbuf, c.roff = nil, nil, 0
+	}
+	return
+}
+
+func (c *CompressConn) readFrame() (err error) {
+	if _, err = io.ReadFull(c.Conn, c.hdr[:]); err != nil {
+		return
+	}
+	flag := c.hdr[0]
+	wireLen := int(binary.BigEndian.Uint32(c.hdr[1:5]))
+	rawLen := int(binary.BigEndian.Uint32(c.hdr[5:9]))
+	if wireLen > compressMaxFrameSize || rawLen > compressMaxFrameSize {
+		return errors.New("compress frame too large")
+	}
+	switch flag {
+	case compressFrameRaw:
+		c.rawLeft = wireLen
+	case compressFrameLZ4:
+		if cap(c.cbuf) < wireLen {
+			c.cbuf = make([]byte, wireLen)
+		}
+		if _, err = io.ReadFull(c.Conn, c.cbuf[:wireLen]); err != nil {
+			return
+		}
+		if cap(c.rbuf) < rawLen {
+			c.rbuf = make([]byte, rawLen)
+		}
+		var n int
+		if n, err = lz4.UncompressBlock(c.cbuf[:wireLen], c.rbuf[:rawLen]); err != nil {
+			return
+		}
+		if n != rawLen {
+			return errors.New("compress frame length mismatch")
+		}
+		c.rbuf, c.roff = c.rbuf[:rawLen], 0
+	default:
+		return errors.New("unknown compress frame")
+	}
+	return
+}
+
+func (c *CompressConn) releaseWriteBuffer() {
+	if cap(c.wbuf) > compressKeepBufferSize {
+		c.wbuf = nil
+	}
+}
+
+func (c *CompressConn) Close() error {
+	unregisterPacketConn(c.Conn)
+	return c.Conn.Close()
+}
+
+func putFrameHeader(b []byte, flag byte, wireLen, rawLen int) {
+	b[0] = flag
+	binary.BigEndian.PutUint32(b[1:5], uint32(wireLen))
+	binary.BigEndian.PutUint32(b[5:9], uint32(rawLen))
+}
+
+// The connection pools hand out *net.TCPConn, so the compressed and the TLS connections
+// of the clients are kept aside and looked up by the packet reads and writes.
+var (
+	packetConns    sync.Map // *net.TCPConn -> *CompressConn or *tls.Conn
+	packetConnsCnt int64
+)
+
+// PacketConnOf returns the connection to read and write the packets of c on.
+func PacketConnOf(c net.Conn) net.Conn {
+	if atomic.LoadInt64(&packetConnsCnt) == 0 {
+		return c
+	}
+	if pc, ok := packetConns.Load(c); ok {
+		return pc.(net.Conn)
+	}
+	return c
+}
+
+func registerPacketConn(c *net.TCPConn, pc net.Conn) {
+	packetConns.Store(c, pc)
+	atomic.AddInt64(&packetConnsCnt, 1)
+}
+
+func unregisterPacketConn(c net.Conn) {
+	if atomic.LoadInt64(&packetConnsCnt) == 0 {
+		return
+	}
+	if _, ok := packetConns.LoadAndDelete(c); ok {
+		atomic.AddInt64(&packetConnsCnt, -1)
+	}
+}
+
+func closeConn(c *net.TCPConn) error {
+	unregisterPacketConn(c)
+	return c.Close()
+}
+
+// NegotiateCompress asks the server to compress the packets on c not smaller
+// than threshold. The connection is left plain if the server declines.
+func NegotiateCompress(c *net.TCPConn, threshold int) (accepted bool, err error) {
+	preamble := make([]byte, PacketHeaderSize)
+	copy(preamble, compressMagic)
+	preamble[4] = compressVersion
+	preamble[5] = CompressAlgoLZ4
+	binary.BigEndian.PutUint32(preamble[6:10], uint32(threshold))
+	c.SetDeadline(time.Now().Add(compressHandshakeTime))
+	defer c.SetDeadline(time.Time{})
+	if _, err = c.Write(preamble); err != nil {
+		return
+	}
+	var algo [1]byte
+	if _, err = io.ReadFull(c, algo[:]); err != nil {
+		return
+	}
+	if algo[0] != CompressAlgoLZ4 {
+		return false, nil
+	}
+	registerPacketConn(c, NewCompressConn(c, threshold))
+	return true, nil
+}
+
+// prefixConn gives back the first byte read by AcceptCompressConn or AcceptTLSConn.
+type prefixConn struct {
+	net.Conn
+	prefix []byte
+}
+
+func (c *prefixConn) Read(b []byte) (n int, err error) {
+	if len(c.prefix) > 0 {
+		n = copy(b, c.prefix)
+		c.prefix = c.prefix[n:]
+		return
+	}
+	return c.Conn.Read(b)
+}
+
+// AcceptCompressConn answers the compress preamble if the client sent one on
+// the new connection, and returns the connection to serve the packets on.
+func AcceptCompressConn(conn net.Conn) (net.Conn, error) {
+	first := make([]byte, 1)
+	if _, err := io.ReadFull(conn, first); err != nil {
+		return nil, err
+	}
+	if first[0] != compressMagic[0] {
+		return &prefixConn{Conn: conn, prefix: first}, nil
+	}
+	preamble := make([]byte, PacketHeaderSize)
+	preamble[0] = first[0]
+	conn.SetDeadline(time.Now().Add(compressHandshakeTime))
+	defer conn.SetDeadline(time.Time{})
+	if _, err := io.ReadFull(conn, preamble[1:]); err != nil {
+		return nil, err
+	}
+	if string(preamble[:4]) != compressMagic || preamble[4] != compressVersion {
+		return nil, ErrCompressPreamble
+	}
+	algo := preamble[5]
+	if algo != CompressAlgoLZ4 {
+		algo = CompressAlgoNone
+	}
+	if _, err := conn.Write([]byte{algo}); err != nil {
+		return nil, err
+	}
+	if algo == CompressAlgoNone {
+		return conn, nil
+	}
+	return NewCompressConn(conn, int(binary.BigEndian.Uint32(preamble[6:10]))), nil
+}
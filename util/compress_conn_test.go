@@ -0,0 +1,148 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type bufferConn struct {
+	net.Conn
+	bytes.Buffer
+}
+
+func (c *bufferConn) Read(b []byte) (int, error)  { return c.Buffer.Read(b) }
+func (c *bufferConn) Write(b []byte) (int, error) { return c.Buffer.Write(b) }
+
+func TestCompressConnFrames(t *testing.T) {
+	raw := &bufferConn{}
+	cc := NewCompressConn(raw, 1024)
+
+	small := []byte("small packet header")
+	text := bytes.Repeat([]byte("cubefs compress "), 8192)
+	random := make([]byte, 64*KB)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+
+	for _, b := range [][]byte{small, text, random} {
+		n, err := cc.Write(b)
+		require.NoError(t, err)
+		require.Equal(t, len(b), n)
+	}
+	// the text is compressed, the small and the random writes are sent as is
+	wire := raw.Len()
+	require.Less(t, wire, len(small)+len(text)/4+len(random)+3*compressFrameHeaderSize)
+	require.Greater(t, wire, len(small)+len(random))
+
+	for _, b := range [][]byte{small, text, random} {
+		got := make([]byte, len(b))
+		_, err = io.ReadFull(cc, got)
+		require.NoError(t, err)
+		require.Equal(t, b, got)
+	}
+	_, err = cc.Read(make([]byte, 1))
+	require.Equal(t, io.EOF, err)
+
+	raw.Write([]byte{9, 0, 0, 0, 1, 0, 0, 0, 1, 0})
+	_, err = cc.Read(make([]byte, 1))
+	require.Error(t, err)
+}
+
+// startCompressServer echoes on the accepted connections. An old server which
+// does not know the preamble fails on the bad magic and closes the connection.
+func startCompressServer(t *testing.T, old bool) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if old {
+					header := make([]byte, PacketHeaderSize)
+					if _, err := io.ReadFull(conn, header); err != nil || header[0] != 0xFF {
+						return
+					}
+					conn.Write(header)
+					io.Copy(conn, conn)
+					return
+				}
+				sc, err := AcceptCompressConn(conn)
+				if err != nil {
+					return
+				}
+				io.Copy(sc, sc)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func echo(t *testing.T, c net.Conn, b []byte) {
+	c = PacketConnOf(c)
+	_, err := c.Write(b)
+	require.NoError(t, err)
+	got := make([]byte, len(b))
+	_, err = io.ReadFull(c, got)
+	require.NoError(t, err)
+	require.Equal(t, b, got)
+}
+
+func TestCompressConnNegotiate(t *testing.T) {
+	packet := append([]byte{0xFF}, bytes.Repeat([]byte("data"), 16*KB)...)
+
+	// plain clients are served as before
+	addr := startCompressServer(t, false)
+	plain := NewConnectPoolWithTimeoutAndCap(0, 10, ConnectIdleTime, 1)
+	defer plain.Close()
+	c, err := plain.GetConnect(addr)
+	require.NoError(t, err)
+	require.Equal(t, net.Conn(c), PacketConnOf(c))
+	echo(t, c, packet)
+	plain.PutConnect(c, true)
+
+	pool := NewConnectPoolWithTimeoutAndCap(0, 10, ConnectIdleTime, 1)
+	pool.SetCompressThreshold(4 * KB)
+	defer pool.Close()
+	c, err = pool.GetConnect(addr)
+	require.NoError(t, err)
+	require.IsType(t, &CompressConn{}, PacketConnOf(c))
+	echo(t, c, packet)
+	echo(t, c, packet[:100])
+	pool.PutConnect(c, false)
+	c, err = pool.GetConnect(addr)
+	require.NoError(t, err)
+	echo(t, c, packet)
+	pool.PutConnect(c, true)
+	require.Equal(t, net.Conn(c), PacketConnOf(c))
+
+	// the old servers get plain connections
+	oldAddr := startCompressServer(t, true)
+	c, err = pool.GetConnect(oldAddr)
+	require.NoError(t, err)
+	require.Equal(t, net.Conn(c), PacketConnOf(c))
+	echo(t, c, packet[:PacketHeaderSize])
+	pool.PutConnect(c, true)
+}
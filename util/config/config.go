@@ -85,6 +85,15 @@ func (c *Config) GetValue(key string) interface{} {
 	return c.data[key]
 }
 
+// Values returns a copy of the top level config keys and values.
+func (c *Config) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(c.data))
+	for k, v := range c.data {
+		values[k] = v
+	}
+	return values
+}
+
 // GetString returns a string for the config key.
 func (c *Config) GetString(key string) string {
 	x, present := c.data[key]
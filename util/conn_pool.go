@@ -15,9 +15,11 @@
 package util
 
 import (
+	"crypto/tls"
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -40,6 +42,10 @@ type ConnectPool struct {
 	connectTimeout int64
 	closeCh        chan struct{}
 	closeOnce      sync.Once
+	// the packets not smaller than it are compressed if the server agrees, 0 disables
+	compressThreshold int
+	// the connections are TLS ones if set, which are not compressed
+	tlsConfig *tls.Config
 }
 
 func NewConnectPool() (cp *ConnectPool) {
@@ -73,6 +79,18 @@ func NewConnectPoolWithTimeoutAndCap(minCap, maxCap int, idleConnTimeout time.Du
 	return cp
 }
 
+// SetCompressThreshold enables the packet compression on the connections
+// created afterwards. It should be called before the pool is used.
+func (cp *ConnectPool) SetCompressThreshold(threshold int) {
+	cp.compressThreshold = threshold
+}
+
+// SetTLSConfig makes the connections created afterwards TLS ones, verified with
+// config. It should be called before the pool is used.
+func (cp *ConnectPool) SetTLSConfig(config *tls.Config) {
+	cp.tlsConfig = config
+}
+
 func DailTimeOut(target string, timeout time.Duration) (c *net.TCPConn, err error) {
 	var connect net.Conn
 	connect, err = net.DialTimeout("tcp", target, timeout)
@@ -90,7 +108,7 @@ func (cp *ConnectPool) GetConnect(targetAddr string) (c *net.TCPConn, err error)
 	pool, ok := cp.pools[targetAddr]
 	cp.RUnlock()
 	if !ok {
-		newPool := NewPool(cp.mincap, cp.maxcap, cp.timeout, cp.connectTimeout, targetAddr)
+		newPool := newPoolWithConfig(cp.mincap, cp.maxcap, cp.timeout, cp.connectTimeout, targetAddr, cp.compressThreshold, cp.tlsConfig)
 		cp.Lock()
 		pool, ok = cp.pools[targetAddr]
 		if !ok {
@@ -126,12 +144,12 @@ func (cp *ConnectPool) PutConnectV2(c *net.TCPConn, forceClose bool, addr string
 		return
 	}
 	if forceClose {
-		_ = c.Close()
+		_ = closeConn(c)
 		return
 	}
 	select {
 	case <-cp.closeCh:
-		_ = c.Close()
+		_ = closeConn(c)
 		return
 	default:
 	}
@@ -142,7 +160,7 @@ func (cp *ConnectPool) PutConnectV2(c *net.TCPConn, forceClose bool, addr string
 	pool, ok := cp.pools[addr]
 	cp.RUnlock()
 	if !ok {
-		c.Close()
+		closeConn(c)
 		return
 	}
 	object := &Object{conn: c, idle: time.Now().UnixNano()}
@@ -209,9 +227,17 @@ type Pool struct {
 	target         string
 	timeout        int64
 	connectTimeout int64
+
+	compressThreshold int
+	compressRefusedAt int64
+	tlsConfig         *tls.Config
 }
 
 func NewPool(min, max int, timeout, connectTimeout int64, target string) (p *Pool) {
+	return newPoolWithConfig(min, max, timeout, connectTimeout, target, 0, nil)
+}
+
+func newPoolWithConfig(min, max int, timeout, connectTimeout int64, target string, compressThreshold int, tlsConfig *tls.Config) (p *Pool) {
 	p = new(Pool)
 	p.mincap = min
 	p.maxcap = max
@@ -219,6 +245,8 @@ func NewPool(min, max int, timeout, connectTimeout int64, target string) (p *Poo
 	p.objects = make(chan *Object, max)
 	p.timeout = timeout
 	p.connectTimeout = connectTimeout
+	p.compressThreshold = compressThreshold
+	p.tlsConfig = tlsConfigFor(tlsConfig, target)
 	p.initAllConnect()
 	return p
 }
@@ -230,6 +258,9 @@ func (p *Pool) initAllConnect() {
 			conn := c.(*net.TCPConn)
 			conn.SetKeepAlive(true)
 			conn.SetNoDelay(true)
+			if conn, err = p.negotiate(conn); err != nil {
+				continue
+			}
 			o := &Object{conn: conn, idle: time.Now().UnixNano()}
 			p.PutConnectObjectToPool(o)
 		}
@@ -242,7 +273,7 @@ func (p *Pool) PutConnectObjectToPool(o *Object) {
 		return
 	default:
 		if o.conn != nil {
-			o.conn.Close()
+			closeConn(o.conn)
 		}
 		return
 	}
@@ -254,7 +285,7 @@ func (p *Pool) autoRelease() {
 		select {
 		case o := <-p.objects:
 			if time.Now().UnixNano()-int64(o.idle) > p.timeout {
-				o.conn.Close()
+				closeConn(o.conn)
 			} else {
 				p.PutConnectObjectToPool(o)
 			}
@@ -269,7 +300,7 @@ func (p *Pool) ReleaseAll() {
 	for i := 0; i < connectLen; i++ {
 		select {
 		case o := <-p.objects:
-			o.conn.Close()
+			closeConn(o.conn)
 		default:
 			return
 		}
@@ -277,6 +308,13 @@ func (p *Pool) ReleaseAll() {
 }
 
 func (p *Pool) NewConnect(target string) (c *net.TCPConn, err error) {
+	if c, err = p.dial(); err != nil {
+		return
+	}
+	return p.negotiate(c)
+}
+
+func (p *Pool) dial() (c *net.TCPConn, err error) {
 	var connect net.Conn
 	connect, err = net.DialTimeout("tcp", p.target, time.Duration(p.connectTimeout)*time.Second)
 	if err == nil {
@@ -297,10 +335,38 @@ func (p *Pool) GetConnectFromPool() (c *net.TCPConn, err error) {
 			return p.NewConnect(p.target)
 		}
 		if time.Now().UnixNano()-int64(o.idle) > p.timeout {
-			_ = o.conn.Close()
+			_ = closeConn(o.conn)
 			o = nil
 			continue
 		}
 		return o.conn, nil
 	}
 }
+
+// negotiate secures the new connection if the pool is a TLS one, or compresses it.
+// A TLS connection is never left plain, the server failing the handshake.
+func (p *Pool) negotiate(c *net.TCPConn) (*net.TCPConn, error) {
+	if p.tlsConfig == nil {
+		return p.tryCompress(c)
+	}
+	if err := NegotiateTLS(c, p.tlsConfig); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// tryCompress negotiates the packet compression on the new connection. A server
+// not supporting it closes the connection, then a plain one is dialed instead
+// and the server is not asked again for a while.
+func (p *Pool) tryCompress(c *net.TCPConn) (*net.TCPConn, error) {
+	if p.compressThreshold <= 0 || time.Now().Unix()-atomic.LoadInt64(&p.compressRefusedAt) < compressRetryInterval {
+		return c, nil
+	}
+	if _, err := NegotiateCompress(c, p.compressThreshold); err == nil {
+		return c, nil
+	}
+	c.Close()
+	atomic.StoreInt64(&p.compressRefusedAt, time.Now().Unix())
+	return p.dial()
+}
@@ -0,0 +1,350 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package faultinject injects faults into the ops a server handles, so that
+// resilience tests and chaos drills run against a real cluster: a rule delays,
+// drops or fails with EIO a percent of the ops, of a partition or of the
+// partitions under a disk path. The rules are set on the debug port, and only
+// when the server is started with enableFaultInjection, they expire after a
+// TTL so that a forgotten drill does not hurt the cluster for long.
+package faultinject
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/cubefs/cubefs/util/errors"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	Path      = "/debug/fault"
+	SetPath   = "/debug/fault/set"
+	ClearPath = "/debug/fault/clear"
+
+	ConfigKeyEnable = "enableFaultInjection"
+
+	KindDelay = "delay"
+	KindDrop  = "drop"
+	KindEIO   = "eio"
+
+	DefaultTTL = 10 * time.Minute
+	MaxTTL     = 24 * time.Hour
+	MaxDelay   = time.Minute
+)
+
+// Fault is what the server does with an op instead of handling it.
+type Fault uint8
+
+const (
+	None Fault = iota
+	// Drop: the op is not handled and not replied to.
+	Drop
+	// EIO: the op fails with ErrEIO, nothing is marked broken.
+	EIO
+)
+
+var ErrEIO = errors.NewErrorf("fault injected: %v", syscall.EIO)
+
+// Rule matches the ops of PartitionID, or of all the partitions if it is 0,
+// under the path Disk, or under any path if it is empty.
+type Rule struct {
+	ID          uint64    `json:"id"`
+	Kind        string    `json:"kind"`
+	Percent     float64   `json:"percent"`
+	DelayMs     int64     `json:"delayMs,omitempty"`
+	PartitionID uint64    `json:"partitionID,omitempty"`
+	Disk        string    `json:"disk,omitempty"`
+	ExpireAt    time.Time `json:"expireAt"`
+	Hits        uint64    `json:"hits"`
+}
+
+func (r *Rule) match(partitionID uint64, path string, now time.Time) bool {
+	if now.After(r.ExpireAt) {
+		return false
+	}
+	if r.PartitionID != 0 && r.PartitionID != partitionID {
+		return false
+	}
+	if r.Disk != "" && !strings.HasPrefix(path, r.Disk) {
+		return false
+	}
+	return rand.Float64()*100 < r.Percent
+}
+
+var (
+	enabled int32
+	// the rules set, so that the ops check nothing more while there is none
+	active int32
+	mu     sync.RWMutex
+	nextID uint64
+	rules  []*Rule
+)
+
+// SetEnabled turns the injection on or off, the rules are cleared when off.
+func SetEnabled(enable bool) {
+	if enable {
+		atomic.StoreInt32(&enabled, 1)
+		return
+	}
+	atomic.StoreInt32(&enabled, 0)
+	Clear(0)
+}
+
+func Enabled() bool {
+	return atomic.LoadInt32(&enabled) == 1
+}
+
+// Active reports whether a rule is set, a server checks it before looking up
+// the partition and the disk of an op.
+func Active() bool {
+	return atomic.LoadInt32(&active) == 1
+}
+
+// Add validates and sets a rule, it expires after ttl, DefaultTTL if 0.
+func Add(rule *Rule, ttl time.Duration) (added *Rule, err error) {
+	if !Enabled() {
+		return nil, fmt.Errorf("fault injection is not enabled, set %v in the config", ConfigKeyEnable)
+	}
+	switch rule.Kind {
+	case KindDelay:
+		if rule.DelayMs <= 0 || time.Duration(rule.DelayMs)*time.Millisecond > MaxDelay {
+			return nil, fmt.Errorf("delayMs(%v) should be 1 to %v", rule.DelayMs, MaxDelay.Milliseconds())
+		}
+	case KindDrop, KindEIO:
+		rule.DelayMs = 0
+	default:
+		return nil, fmt.Errorf("unknown kind(%v), should be %v, %v or %v", rule.Kind, KindDelay, KindDrop, KindEIO)
+	}
+	if rule.Percent <= 0 || rule.Percent > 100 {
+		return nil, fmt.Errorf("percent(%v) should be in (0, 100]", rule.Percent)
+	}
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	if ttl < 0 || ttl > MaxTTL {
+		return nil, fmt.Errorf("ttl(%v) should be at most %v", ttl, MaxTTL)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	nextID++
+	set := &Rule{
+		ID:          nextID,
+		Kind:        rule.Kind,
+		Percent:     rule.Percent,
+		DelayMs:     rule.DelayMs,
+		PartitionID: rule.PartitionID,
+		Disk:        rule.Disk,
+		ExpireAt:    time.Now().Add(ttl),
+	}
+	copied := *set
+	rules = append(rules, set)
+	atomic.StoreInt32(&active, 1)
+	log.LogWarnf("faultinject: add rule %+v", copied)
+	return &copied, nil
+}
+
+// Clear removes the rule id, or all the rules if id is 0, it returns the
+// number of rules removed.
+func Clear(id uint64) (removed int) {
+	mu.Lock()
+	defer mu.Unlock()
+	kept := rules[:0]
+	for _, rule := range rules {
+		if id == 0 || rule.ID == id {
+			removed++
+			continue
+		}
+		kept = append(kept, rule)
+	}
+	rules = kept
+	pruneLocked(time.Now())
+	if removed > 0 {
+		log.LogWarnf("faultinject: clear rule(%v), %v removed", id, removed)
+	}
+	return
+}
+
+// List returns a copy of the rules not expired.
+func List() []Rule {
+	mu.Lock()
+	defer mu.Unlock()
+	pruneLocked(time.Now())
+	list := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		r := *rule
+		r.Hits = atomic.LoadUint64(&rule.Hits)
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func pruneLocked(now time.Time) {
+	kept := rules[:0]
+	for _, rule := range rules {
+		if !now.After(rule.ExpireAt) {
+			kept = append(kept, rule)
+		}
+	}
+	for i := len(kept); i < len(rules); i++ {
+		rules[i] = nil
+	}
+	rules = kept
+	if len(rules) == 0 {
+		atomic.StoreInt32(&active, 0)
+	}
+}
+
+// Inject applies the rules matching an op of the partition under path: it
+// sleeps for the longest delay matched, and returns the fault of the op, a
+// drop winning over an EIO.
+func Inject(partitionID uint64, path string) (fault Fault) {
+	if !Active() {
+		return None
+	}
+	var (
+		delay   time.Duration
+		expired bool
+		now     = time.Now()
+	)
+	mu.RLock()
+	for _, rule := range rules {
+		if now.After(rule.ExpireAt) {
+			expired = true
+			continue
+		}
+		if !rule.match(partitionID, path, now) {
+			continue
+		}
+		atomic.AddUint64(&rule.Hits, 1)
+		switch rule.Kind {
+		case KindDelay:
+			if d := time.Duration(rule.DelayMs) * time.Millisecond; d > delay {
+				delay = d
+			}
+		case KindDrop:
+			fault = Drop
+		case KindEIO:
+			if fault == None {
+				fault = EIO
+			}
+		}
+	}
+	mu.RUnlock()
+	if expired {
+		mu.Lock()
+		pruneLocked(now)
+		mu.Unlock()
+	}
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return
+}
+
+func reply(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func replyErr(w http.ResponseWriter, code int, err error) {
+	reply(w, code, map[string]string{"error": err.Error()})
+}
+
+func checkEnabled(w http.ResponseWriter) bool {
+	if !Enabled() {
+		replyErr(w, http.StatusForbidden, fmt.Errorf("fault injection is not enabled, set %v in the config", ConfigKeyEnable))
+		return false
+	}
+	return true
+}
+
+// ListHandler serves the rules.
+func ListHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkEnabled(w) {
+		return
+	}
+	reply(w, http.StatusOK, List())
+}
+
+// SetHandler sets a rule from the params kind, percent, delayMs, partitionID,
+// disk and ttl in seconds.
+func SetHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkEnabled(w) {
+		return
+	}
+	var (
+		rule = &Rule{Kind: strings.ToLower(r.FormValue("kind")), Disk: r.FormValue("disk")}
+		ttl  int64
+		err  error
+	)
+	parse := func(key string, fn func(string) error) {
+		if v := r.FormValue(key); v != "" && err == nil {
+			if e := fn(v); e != nil {
+				err = fmt.Errorf("invalid %v: %v", key, v)
+			}
+		}
+	}
+	parse("percent", func(v string) (e error) { rule.Percent, e = strconv.ParseFloat(v, 64); return })
+	parse("delayMs", func(v string) (e error) { rule.DelayMs, e = strconv.ParseInt(v, 10, 64); return })
+	parse("partitionID", func(v string) (e error) { rule.PartitionID, e = strconv.ParseUint(v, 10, 64); return })
+	parse("ttl", func(v string) (e error) { ttl, e = strconv.ParseInt(v, 10, 64); return })
+	if err != nil {
+		replyErr(w, http.StatusBadRequest, err)
+		return
+	}
+	if ttl < 0 || ttl > int64(MaxTTL/time.Second) {
+		replyErr(w, http.StatusBadRequest, fmt.Errorf("ttl(%v) should be at most %v seconds", ttl, int64(MaxTTL/time.Second)))
+		return
+	}
+	added, err := Add(rule, time.Duration(ttl)*time.Second)
+	if err != nil {
+		replyErr(w, http.StatusBadRequest, err)
+		return
+	}
+	reply(w, http.StatusOK, added)
+}
+
+// ClearHandler removes the rule of the param id, or all the rules without it.
+func ClearHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkEnabled(w) {
+		return
+	}
+	var id uint64
+	if v := r.FormValue("id"); v != "" {
+		var err error
+		if id, err = strconv.ParseUint(v, 10, 64); err != nil {
+			replyErr(w, http.StatusBadRequest, fmt.Errorf("invalid id: %v", v))
+			return
+		}
+	}
+	reply(w, http.StatusOK, map[string]int{"removed": Clear(id)})
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package faultinject
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInject(t *testing.T) {
+	SetEnabled(false)
+	_, err := Add(&Rule{Kind: KindDrop, Percent: 100}, 0)
+	require.Error(t, err)
+
+	SetEnabled(true)
+	defer SetEnabled(false)
+	require.False(t, Active())
+	require.Equal(t, None, Inject(1, "/data0/datapartition_1"))
+
+	for _, rule := range []*Rule{
+		{Kind: "crash", Percent: 100},
+		{Kind: KindDrop, Percent: 0},
+		{Kind: KindDrop, Percent: 101},
+		{Kind: KindDelay, Percent: 100},
+		{Kind: KindDelay, Percent: 100, DelayMs: MaxDelay.Milliseconds() + 1},
+	} {
+		_, err = Add(rule, 0)
+		require.Error(t, err, "%+v", rule)
+	}
+	_, err = Add(&Rule{Kind: KindDrop, Percent: 100}, MaxTTL+time.Second)
+	require.Error(t, err)
+
+	drop, err := Add(&Rule{Kind: KindDrop, Percent: 100, PartitionID: 1}, 0)
+	require.NoError(t, err)
+	_, err = Add(&Rule{Kind: KindEIO, Percent: 100, Disk: "/data1"}, 0)
+	require.NoError(t, err)
+	_, err = Add(&Rule{Kind: KindDelay, Percent: 100, DelayMs: 20, Disk: "/data1"}, 0)
+	require.NoError(t, err)
+	require.True(t, Active())
+
+	require.Equal(t, Drop, Inject(1, "/data0/datapartition_1"))
+	require.Equal(t, None, Inject(2, "/data0/datapartition_2"))
+	start := time.Now()
+	require.Equal(t, EIO, Inject(3, "/data1/datapartition_3"))
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	// a drop wins over an EIO
+	require.Equal(t, Drop, Inject(1, "/data1/datapartition_1"))
+
+	rules := List()
+	require.Len(t, rules, 3)
+	require.Equal(t, uint64(2), rules[0].Hits)
+	require.Equal(t, uint64(2), rules[1].Hits)
+
+	require.Equal(t, 1, Clear(drop.ID))
+	require.Equal(t, EIO, Inject(1, "/data1/datapartition_1"))
+	require.Equal(t, 2, Clear(0))
+	require.False(t, Active())
+}
+
+func TestExpire(t *testing.T) {
+	SetEnabled(true)
+	defer SetEnabled(false)
+	_, err := Add(&Rule{Kind: KindEIO, Percent: 100}, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, EIO, Inject(1, ""))
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, None, Inject(1, ""))
+	require.False(t, Active())
+	require.Empty(t, List())
+}
+
+func TestHandlers(t *testing.T) {
+	serve := func(handler http.HandlerFunc, url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w
+	}
+	SetEnabled(false)
+	require.Equal(t, http.StatusForbidden, serve(ListHandler, Path).Code)
+	require.Equal(t, http.StatusForbidden, serve(SetHandler, SetPath+"?kind=drop&percent=100").Code)
+
+	SetEnabled(true)
+	defer SetEnabled(false)
+	require.Equal(t, http.StatusBadRequest, serve(SetHandler, SetPath+"?kind=drop&percent=x").Code)
+	require.Equal(t, http.StatusBadRequest, serve(SetHandler, SetPath+"?kind=drop&percent=50&ttl=-1").Code)
+
+	w := serve(SetHandler, SetPath+"?kind=delay&percent=50&delayMs=100&partitionID=7&ttl=60")
+	require.Equal(t, http.StatusOK, w.Code)
+	rule := new(Rule)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), rule))
+	require.Equal(t, KindDelay, rule.Kind)
+	require.Equal(t, uint64(7), rule.PartitionID)
+	require.WithinDuration(t, time.Now().Add(time.Minute), rule.ExpireAt, 5*time.Second)
+
+	w = serve(ListHandler, Path)
+	require.Equal(t, http.StatusOK, w.Code)
+	var rules []Rule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rules))
+	require.Len(t, rules, 1)
+	require.Equal(t, rule.ID, rules[0].ID)
+
+	require.Equal(t, http.StatusBadRequest, serve(ClearHandler, ClearPath+"?id=x").Code)
+	w = serve(ClearHandler, ClearPath)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"removed":1}`, w.Body.String())
+}
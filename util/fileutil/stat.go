@@ -12,6 +12,8 @@
 // implied. See the License for the specific language governing
 // permissions and limitations under the License.
 
+//go:build !windows
+
 package fileutil
 
 import (
@@ -0,0 +1,36 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fileutil
+
+import "golang.org/x/sys/windows"
+
+// FilesystemInfo carries the fields of syscall.Statfs_t in bytes, as windows
+// has no statfs.
+type FilesystemInfo struct {
+	Bsize  int64
+	Blocks uint64
+	Bfree  uint64
+	Bavail uint64
+}
+
+func Statfs(name string) (stat *FilesystemInfo, err error) {
+	path, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return
+	}
+	stat = &FilesystemInfo{Bsize: 1}
+	err = windows.GetDiskFreeSpaceEx(path, &stat.Bavail, &stat.Blocks, &stat.Bfree)
+	return
+}
@@ -33,7 +33,6 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-	"syscall"
 	"time"
 
 	blog "github.com/cubefs/cubefs/blobstore/util/log"
@@ -368,8 +367,8 @@ func InitLog(dir, module string, level Level, rotate *LogRotate, logLeftSpaceLim
 		}
 		_ = os.Chmod(dir, 0o755)
 
-		fs := syscall.Statfs_t{}
-		if err := syscall.Statfs(dir, &fs); err != nil {
+		total, avail, err := diskSpace(dir)
+		if err != nil {
 			return nil, fmt.Errorf("[InitLog] stats disk space: %s", err.Error())
 		}
 
@@ -378,13 +377,13 @@ func InitLog(dir, module string, level Level, rotate *LogRotate, logLeftSpaceLim
 		}
 
 		if rotate.headRoom == 0 {
-			minLogLeftSpaceLimit := float64(fs.Blocks*uint64(fs.Bsize)) * logLeftSpaceLimitRatio / 1024 / 1024
+			minLogLeftSpaceLimit := float64(total) * logLeftSpaceLimitRatio / 1024 / 1024
 
 			rotate.SetHeadRoomMb(int64(math.Min(minLogLeftSpaceLimit, DefaultHeadRoom)))
 		}
 
 		if rotate.rotateSize == 0 {
-			minRotateSize := int64(fs.Bavail * uint64(fs.Bsize) / uint64(len(levelPrefixes)))
+			minRotateSize := int64(avail / uint64(len(levelPrefixes)))
 			if minRotateSize < DefaultMinRotateSize {
 				minRotateSize = DefaultMinRotateSize
 			}
@@ -835,18 +834,18 @@ func (l *Log) checkLogRotation(logDir, module string) {
 	for {
 		needDelFiles = needDelFiles[:0]
 		// check disk space
-		fs := syscall.Statfs_t{}
-		if err := syscall.Statfs(logDir, &fs); err != nil {
+		_, avail, err := diskSpace(logDir)
+		if err != nil {
 			LogErrorf("check disk space: %s", err.Error())
 			time.Sleep(DefaultRotateInterval)
 			continue
 		}
-		diskSpaceLeft := int64(fs.Bavail * uint64(fs.Bsize))
+		diskSpaceLeft := int64(avail)
 		diskSpaceLeft -= l.rotate.headRoom * 1024 * 1024
 		if diskSpaceLeft <= 0 {
 			LogDebugf("logLeftSpaceLimit has been reached, need to clear %v Mb of Space", (-diskSpaceLeft)/1024/1024)
 		}
-		err := l.removeLogFile(logDir, diskSpaceLeft, module)
+		err = l.removeLogFile(logDir, diskSpaceLeft, module)
 		if err != nil {
 			time.Sleep(DefaultRotateInterval)
 			continue
@@ -0,0 +1,330 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package logship uploads the rotated log and audit files of a component to an
+// S3 compatible object storage, a CubeFS bucket or an external S3, so a large
+// cluster keeps its logs without a log agent on every host. The files are put
+// under prefix/module/host/ once they are rotated, and the objects older than
+// the retention are deleted.
+package logship
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cubefs/cubefs/util/config"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const (
+	ConfigKeyEndpoint      = "logShipEndpoint"
+	ConfigKeyRegion        = "logShipRegion"
+	ConfigKeyBucket        = "logShipBucket"
+	ConfigKeyAccessKey     = "logShipAccessKey"
+	ConfigKeySecretKey     = "logShipSecretKey"
+	ConfigKeyPrefix        = "logShipPrefix"
+	ConfigKeyIntervalSec   = "logShipIntervalSec"
+	ConfigKeyRetentionDays = "logShipRetentionDays"
+	ConfigKeyRemoveLocal   = "logShipRemoveLocal"
+
+	DefaultPrefix        = "logs"
+	DefaultIntervalSec   = 300
+	DefaultRetentionDays = 30
+
+	// RotatedExtension is the suffix of the files rotated by the log and the audit log.
+	RotatedExtension = ".old"
+
+	// stateFile keeps the names of the files shipped but not removed, in the log dir.
+	stateFile = ".logship"
+)
+
+// Object is an object of the store.
+type Object struct {
+	Key     string
+	ModTime time.Time
+}
+
+// Store is the object storage the files are shipped to.
+type Store interface {
+	Put(key string, body io.ReadSeeker) error
+	List(prefix string) ([]Object, error)
+	Delete(key string) error
+}
+
+type s3Store struct {
+	client *s3.S3
+	bucket string
+}
+
+func newS3Store(endpoint, region, bucket, accessKey, secretKey string) (store *s3Store, err error) {
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String(region),
+		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return
+	}
+	return &s3Store{client: s3.New(sess), bucket: bucket}, nil
+}
+
+func (s *s3Store) Put(key string, body io.ReadSeeker) (err error) {
+	_, err = s.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	return
+}
+
+func (s *s3Store) List(prefix string) (objects []Object, err error) {
+	err = s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, last bool) bool {
+		for _, obj := range page.Contents {
+			objects = append(objects, Object{Key: aws.StringValue(obj.Key), ModTime: aws.TimeValue(obj.LastModified)})
+		}
+		return true
+	})
+	return
+}
+
+func (s *s3Store) Delete(key string) (err error) {
+	_, err = s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return
+}
+
+// Shipper ships the rotated files of a log dir every interval.
+type Shipper struct {
+	store       Store
+	dir         string
+	keyPrefix   string
+	interval    time.Duration
+	retention   time.Duration
+	removeLocal bool
+
+	// shipped holds the files shipped and kept in dir
+	shipped map[string]struct{}
+	stopC   chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New returns the shipper of the rotated files in dir, written by module, or
+// nil if no endpoint is configured.
+func New(cfg *config.Config, dir, module string) (s *Shipper, err error) {
+	endpoint := cfg.GetString(ConfigKeyEndpoint)
+	if endpoint == "" {
+		return nil, nil
+	}
+	region := cfg.GetString(ConfigKeyRegion)
+	if region == "" {
+		region = "default"
+	}
+	store, err := newS3Store(endpoint, region, cfg.GetString(ConfigKeyBucket),
+		cfg.GetString(ConfigKeyAccessKey), cfg.GetString(ConfigKeySecretKey))
+	if err != nil {
+		return
+	}
+	prefix := cfg.GetString(ConfigKeyPrefix)
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "unknown"
+	}
+	interval := cfg.GetIntWithDefault(ConfigKeyIntervalSec, DefaultIntervalSec)
+	if interval <= 0 {
+		interval = DefaultIntervalSec
+	}
+	retention := cfg.GetIntWithDefault(ConfigKeyRetentionDays, DefaultRetentionDays)
+	return newShipper(store, dir, path.Join(strings.Trim(prefix, "/"), module, host)+"/",
+		time.Duration(interval)*time.Second, time.Duration(retention)*24*time.Hour,
+		cfg.GetBool(ConfigKeyRemoveLocal)), nil
+}
+
+func newShipper(store Store, dir, keyPrefix string, interval, retention time.Duration, removeLocal bool) *Shipper {
+	s := &Shipper{
+		store:       store,
+		dir:         dir,
+		keyPrefix:   keyPrefix,
+		interval:    interval,
+		retention:   retention,
+		removeLocal: removeLocal,
+		shipped:     make(map[string]struct{}),
+		stopC:       make(chan struct{}),
+	}
+	s.loadState()
+	return s
+}
+
+// Start ships the files every interval until Stop.
+func (s *Shipper) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.ship()
+			s.expire(time.Now())
+			select {
+			case <-s.stopC:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.LogInfof("logship: ship the rotated files of %v to %v every %v", s.dir, s.keyPrefix, s.interval)
+}
+
+func (s *Shipper) Stop() {
+	close(s.stopC)
+	s.wg.Wait()
+}
+
+// rotated returns the names of the rotated files in dir, oldest first.
+func (s *Shipper) rotated() (names []string, err error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), RotatedExtension) {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ModTime().Before(infos[j].ModTime()) })
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	return
+}
+
+// ship uploads the rotated files not shipped yet. A file failed to upload is
+// tried again in the next round.
+func (s *Shipper) ship() {
+	names, err := s.rotated()
+	if err != nil {
+		log.LogWarnf("logship: read dir %v err: %v", s.dir, err)
+		return
+	}
+	exist := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		exist[name] = struct{}{}
+		if _, ok := s.shipped[name]; ok {
+			continue
+		}
+		if err = s.put(name); err != nil {
+			log.LogWarnf("logship: ship %v err: %v", name, err)
+			continue
+		}
+		if s.removeLocal {
+			if err = os.Remove(filepath.Join(s.dir, name)); err == nil {
+				continue
+			}
+			log.LogWarnf("logship: remove shipped %v err: %v", name, err)
+		}
+		s.shipped[name] = struct{}{}
+	}
+	// forget the files removed by the log rotation
+	for name := range s.shipped {
+		if _, ok := exist[name]; !ok {
+			delete(s.shipped, name)
+		}
+	}
+	s.saveState()
+}
+
+func (s *Shipper) put(name string) (err error) {
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	return s.store.Put(s.keyPrefix+name, f)
+}
+
+// expire deletes the objects of this host and module older than the retention.
+func (s *Shipper) expire(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+	objects, err := s.store.List(s.keyPrefix)
+	if err != nil {
+		log.LogWarnf("logship: list %v err: %v", s.keyPrefix, err)
+		return
+	}
+	before := now.Add(-s.retention)
+	for _, obj := range objects {
+		if !obj.ModTime.Before(before) {
+			continue
+		}
+		if err = s.store.Delete(obj.Key); err != nil {
+			log.LogWarnf("logship: delete expired %v err: %v", obj.Key, err)
+		}
+	}
+}
+
+func (s *Shipper) loadState() {
+	data, err := os.ReadFile(filepath.Join(s.dir, stateFile))
+	if err != nil {
+		return
+	}
+	var names []string
+	if err = json.Unmarshal(data, &names); err != nil {
+		log.LogWarnf("logship: load state of %v err: %v", s.dir, err)
+		return
+	}
+	for _, name := range names {
+		s.shipped[name] = struct{}{}
+	}
+}
+
+func (s *Shipper) saveState() {
+	names := make([]string, 0, len(s.shipped))
+	for name := range s.shipped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data, _ := json.Marshal(names)
+	tmp := filepath.Join(s.dir, stateFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		log.LogWarnf("logship: save state of %v err: %v", s.dir, err)
+		return
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, stateFile)); err != nil {
+		log.LogWarnf("logship: save state of %v err: %v", s.dir, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package logship
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	sync.Mutex
+	objects map[string]Object
+	data    map[string]string
+}
+
+func newMemStore() *memStore {
+	return &memStore{objects: make(map[string]Object), data: make(map[string]string)}
+}
+
+func (s *memStore) Put(key string, body io.ReadSeeker) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.objects[key] = Object{Key: key, ModTime: time.Now()}
+	s.data[key] = string(data)
+	return nil
+}
+
+func (s *memStore) List(prefix string) (objects []Object, err error) {
+	s.Lock()
+	defer s.Unlock()
+	for key, obj := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, obj)
+		}
+	}
+	return
+}
+
+func (s *memStore) Delete(key string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.objects, key)
+	delete(s.data, key)
+	return nil
+}
+
+func (s *memStore) keys() (keys []string) {
+	s.Lock()
+	defer s.Unlock()
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+func TestShip(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644))
+	}
+	write("master_info.log", "current")
+	write("master_info.log.20250101010101.old", "rotated")
+	write("audit.log.20250101010101.old", "audit")
+
+	store := newMemStore()
+	s := newShipper(store, dir, "logs/master/host/", time.Minute, time.Hour, false)
+	s.ship()
+	require.Equal(t, []string{"logs/master/host/audit.log.20250101010101.old",
+		"logs/master/host/master_info.log.20250101010101.old"}, store.keys())
+	require.Equal(t, "rotated", store.data["logs/master/host/master_info.log.20250101010101.old"])
+
+	// a restarted shipper does not ship the files again
+	store.Delete("logs/master/host/audit.log.20250101010101.old")
+	s = newShipper(store, dir, "logs/master/host/", time.Minute, time.Hour, true)
+	s.ship()
+	require.Len(t, store.keys(), 1)
+
+	write("master_info.log.20250102010101.old", "rotated again")
+	s.ship()
+	require.Len(t, store.keys(), 2)
+	_, err := os.Stat(filepath.Join(dir, "master_info.log.20250102010101.old"))
+	require.True(t, os.IsNotExist(err))
+
+	// the objects older than the retention are deleted
+	s.expire(time.Now().Add(2 * time.Hour))
+	require.Empty(t, store.keys())
+}
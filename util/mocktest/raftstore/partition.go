@@ -163,6 +163,20 @@ func (mr *MockPartitionMockRecorder) LeaderTerm() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaderTerm", reflect.TypeOf((*MockPartition)(nil).LeaderTerm))
 }
 
+// ResetPeers mocks base method.
+func (m *MockPartition) ResetPeers(peers []proto.Peer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPeers", peers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPeers indicates an expected call of ResetPeers.
+func (mr *MockPartitionMockRecorder) ResetPeers(peers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPeers", reflect.TypeOf((*MockPartition)(nil).ResetPeers), peers)
+}
+
 // Status mocks base method.
 func (m *MockPartition) Status() *raftstore.PartitionStatus {
 	m.ctrl.T.Helper()
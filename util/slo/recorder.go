@@ -0,0 +1,125 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package slo collects op latency samples per volume and reports them to the
+// master, which aggregates the attainment of the volume SLOs.
+package slo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/cubefs/cubefs/util/log"
+)
+
+const DefaultReportInterval = time.Minute
+
+type ReportFunc func(reports []*proto.SLOReport) error
+
+type Recorder struct {
+	sync.Mutex
+	source string
+	vols   map[string]map[string]*proto.LatencyHistogram
+	stopC  chan struct{}
+	once   sync.Once
+}
+
+func NewRecorder(source string) *Recorder {
+	return &Recorder{
+		source: source,
+		vols:   make(map[string]map[string]*proto.LatencyHistogram),
+		stopC:  make(chan struct{}),
+	}
+}
+
+func (r *Recorder) Observe(vol, op string, cost time.Duration, failed bool) {
+	if r == nil || vol == "" {
+		return
+	}
+	r.Lock()
+	ops, ok := r.vols[vol]
+	if !ok {
+		ops = make(map[string]*proto.LatencyHistogram)
+		r.vols[vol] = ops
+	}
+	h, ok := ops[op]
+	if !ok {
+		h = proto.NewLatencyHistogram()
+		ops[op] = h
+	}
+	h.Observe(cost, failed)
+	r.Unlock()
+}
+
+// Drain returns the samples collected since the last drain.
+func (r *Recorder) Drain() (reports []*proto.SLOReport) {
+	r.Lock()
+	vols := r.vols
+	r.vols = make(map[string]map[string]*proto.LatencyHistogram)
+	r.Unlock()
+	for vol, ops := range vols {
+		reports = append(reports, &proto.SLOReport{Volume: vol, Source: r.source, Ops: ops})
+	}
+	return
+}
+
+// restore puts back samples which failed to be reported.
+func (r *Recorder) restore(reports []*proto.SLOReport) {
+	r.Lock()
+	defer r.Unlock()
+	for _, report := range reports {
+		ops, ok := r.vols[report.Volume]
+		if !ok {
+			ops = make(map[string]*proto.LatencyHistogram)
+			r.vols[report.Volume] = ops
+		}
+		for op, h := range report.Ops {
+			if cur, ok := ops[op]; ok {
+				cur.Merge(h)
+			} else {
+				ops[op] = h
+			}
+		}
+	}
+}
+
+// Start reports the samples every interval until Stop is called.
+func (r *Recorder) Start(interval time.Duration, report ReportFunc) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stopC:
+				return
+			case <-ticker.C:
+				reports := r.Drain()
+				if len(reports) == 0 {
+					continue
+				}
+				if err := report(reports); err != nil {
+					log.LogWarnf("slo: report %v volumes failed: %v", len(reports), err)
+					r.restore(reports)
+				}
+			}
+		}
+	}()
+}
+
+func (r *Recorder) Stop() {
+	r.once.Do(func() {
+		close(r.stopC)
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package slo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cubefs/cubefs/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	var r *Recorder
+	r.Observe("vol", proto.SLOOpRead, time.Millisecond, false)
+
+	r = NewRecorder("test")
+	r.Observe("", proto.SLOOpRead, time.Millisecond, false)
+	r.Observe("vol", proto.SLOOpRead, time.Millisecond, false)
+	r.Observe("vol", proto.SLOOpRead, time.Millisecond, true)
+	r.Observe("vol", proto.SLOOpMeta, time.Millisecond, false)
+	reports := r.Drain()
+	require.Len(t, reports, 1)
+	require.Equal(t, "test", reports[0].Source)
+	require.EqualValues(t, 2, reports[0].Ops[proto.SLOOpRead].Total())
+	require.Empty(t, r.Drain())
+
+	r.Observe("vol", proto.SLOOpRead, time.Millisecond, false)
+	r.restore(reports)
+	reports = r.Drain()
+	require.EqualValues(t, 3, reports[0].Ops[proto.SLOOpRead].Total())
+	require.EqualValues(t, 1, reports[0].Ops[proto.SLOOpMeta].Total())
+}
+
+func TestRecorderStart(t *testing.T) {
+	r := NewRecorder("test")
+	defer r.Stop()
+	reportC := make(chan []*proto.SLOReport, 1)
+	failed := false
+	r.Observe("vol", proto.SLOOpWrite, time.Millisecond, false)
+	r.Start(10*time.Millisecond, func(reports []*proto.SLOReport) error {
+		if !failed {
+			failed = true
+			return errors.New("master unavailable")
+		}
+		reportC <- reports
+		return nil
+	})
+	select {
+	case reports := <-reportC:
+		require.Len(t, reports, 1)
+		require.EqualValues(t, 1, reports[0].Ops[proto.SLOOpWrite].Total())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no report")
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/cubefs/cubefs/util/fileutil"
 	"github.com/cubefs/cubefs/util/log"
 )
 
@@ -140,8 +141,8 @@ func (st *Statistic) flushScheduler() {
 
 		timer.Reset(DefaultStatInterval)
 
-		fs := syscall.Statfs_t{}
-		if err := syscall.Statfs(st.logDir, &fs); err != nil {
+		fs, err := fileutil.Statfs(st.logDir)
+		if err != nil {
 			log.LogErrorf("Get fs stat failed, err: %v", err)
 			continue
 		}
@@ -385,7 +386,7 @@ func shiftFiles() error {
 		return nil
 	}
 
-	if syscall.Access(logFileName, F_OK) == nil {
+	if _, err = os.Stat(logFileName); err == nil {
 		logNewFileName := logFileName + "." + time.Now().Format(
 			FileNameDateFormat) + ShiftedExtension
 		if syscall.Rename(logFileName, logNewFileName) != nil {
@@ -0,0 +1,122 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// The client starts the TLS handshake right after dialing, and the server tells it
+// apart from a packet or the compress preamble by the first byte, which is the type
+// of a TLS handshake record. The packets are not compressed on the TLS connections.
+const (
+	tlsRecordTypeHandshake = 0x16
+	tlsHandshakeTime       = 5 * time.Second
+)
+
+var ErrTLSNotConfigured = errors.New("tls not configured")
+
+// NewServerTLSConfig loads the certificate the data and meta nodes present to the clients.
+func NewServerTLSConfig(certFile, keyFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// NewClientTLSConfig verifies the servers with the certificates of caFile,
+// or with the system roots if it is empty.
+func NewClientTLSConfig(caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return config, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %v", caFile)
+	}
+	config.RootCAs = roots
+	return config, nil
+}
+
+// tlsConfigFor returns the config to verify the server at target with, whose
+// certificate should name its host, an IP address being the usual one.
+func tlsConfigFor(config *tls.Config, target string) *tls.Config {
+	if config == nil || config.ServerName != "" {
+		return config
+	}
+	host, _, err := net.SplitHostPort(target)
+	if err != nil {
+		host = target
+	}
+	config = config.Clone()
+	config.ServerName = host
+	return config
+}
+
+// NegotiateTLS does the TLS handshake on the new connection c, then the packets
+// on c are read and written on the TLS connection.
+func NegotiateTLS(c *net.TCPConn, config *tls.Config) (err error) {
+	tc := tls.Client(c, config)
+	c.SetDeadline(time.Now().Add(tlsHandshakeTime))
+	defer c.SetDeadline(time.Time{})
+	if err = tc.Handshake(); err != nil {
+		return
+	}
+	registerPacketConn(c, tc)
+	return
+}
+
+// AcceptTLSConn does the TLS handshake if the client started one on the new
+// connection, and returns the connection to serve the packets on and whether
+// it is a TLS one. The handshake is refused if config is nil.
+func AcceptTLSConn(conn net.Conn, config *tls.Config) (net.Conn, bool, error) {
+	first := make([]byte, 1)
+	if _, err := io.ReadFull(conn, first); err != nil {
+		return nil, false, err
+	}
+	pc := &prefixConn{Conn: conn, prefix: first}
+	if first[0] != tlsRecordTypeHandshake {
+		return pc, false, nil
+	}
+	if config == nil {
+		return nil, false, ErrTLSNotConfigured
+	}
+	tc := tls.Server(pc, config)
+	conn.SetDeadline(time.Now().Add(tlsHandshakeTime))
+	defer conn.SetDeadline(time.Time{})
+	if err := tc.Handshake(); err != nil {
+		return nil, false, err
+	}
+	return tc, true, nil
+}
+
+// IsTLSConn reports whether the packets are read and written on conn over TLS.
+func IsTLSConn(conn net.Conn) bool {
+	_, ok := conn.(*tls.Conn)
+	return ok
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The CubeFS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self signed certificate of 127.0.0.1, which is its own CA.
+func writeTestCert(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cubefs test"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return
+}
+
+// startTLSServer echoes on the accepted connections, serving the TLS and the plain
+// clients on the same port, and sends whether each connection is a TLS one.
+func startTLSServer(t *testing.T, config *tls.Config) (string, chan bool) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	secures := make(chan bool, 16)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				sc, secure, err := AcceptTLSConn(conn, config)
+				if err != nil {
+					return
+				}
+				if !secure {
+					if sc, err = AcceptCompressConn(sc); err != nil {
+						return
+					}
+				}
+				secures <- IsTLSConn(sc)
+				io.Copy(sc, sc)
+			}()
+		}
+	}()
+	return ln.Addr().String(), secures
+}
+
+func TestTLSConnNegotiate(t *testing.T) {
+	packet := append([]byte{0xFF}, bytes.Repeat([]byte("data"), 16*KB)...)
+	certFile, keyFile := writeTestCert(t)
+	serverConfig, err := NewServerTLSConfig(certFile, keyFile)
+	require.NoError(t, err)
+	clientConfig, err := NewClientTLSConfig(certFile)
+	require.NoError(t, err)
+	addr, secures := startTLSServer(t, serverConfig)
+
+	pool := NewConnectPoolWithTimeoutAndCap(0, 10, ConnectIdleTime, 1)
+	pool.SetTLSConfig(clientConfig)
+	pool.SetCompressThreshold(4 * KB)
+	defer pool.Close()
+	c, err := pool.GetConnect(addr)
+	require.NoError(t, err)
+	require.IsType(t, &tls.Conn{}, PacketConnOf(c))
+	echo(t, c, packet)
+	require.True(t, <-secures)
+	pool.PutConnect(c, false)
+	c, err = pool.GetConnect(addr)
+	require.NoError(t, err)
+	echo(t, c, packet[:100])
+	pool.PutConnect(c, true)
+	require.Equal(t, net.Conn(c), PacketConnOf(c))
+
+	// plain and compressed clients share the port
+	plain := NewConnectPoolWithTimeoutAndCap(0, 10, ConnectIdleTime, 1)
+	plain.SetCompressThreshold(4 * KB)
+	defer plain.Close()
+	c, err = plain.GetConnect(addr)
+	require.NoError(t, err)
+	require.IsType(t, &CompressConn{}, PacketConnOf(c))
+	echo(t, c, packet)
+	require.False(t, <-secures)
+	plain.PutConnect(c, true)
+
+	// servers are verified, and never talked to in plain after a failed handshake
+	untrusted := NewConnectPoolWithTimeoutAndCap(0, 10, ConnectIdleTime, 1)
+	untrusted.SetTLSConfig(&tls.Config{RootCAs: x509.NewCertPool()})
+	defer untrusted.Close()
+	_, err = untrusted.GetConnect(addr)
+	require.Error(t, err)
+
+	noTLSAddr, _ := startTLSServer(t, nil)
+	_, err = pool.GetConnect(noTLSAddr)
+	require.Error(t, err)
+
+	_, err = NewClientTLSConfig(keyFile)
+	require.Error(t, err)
+}
@@ -51,9 +51,11 @@ const (
 	PacketHeaderSize         = 57 // original header size
 	PacketHeaderVerSize      = 65 // add field VerSeq in Packet struct, for snapshot version
 	PacketHeaderProtoVerSize = 69 // add field ProtoVersion in Packet struct, for protocol version
+	PacketHeaderFenceSize    = 77 // add field FenceToken in Packet struct, for the writes fenced by token
 
-	PacketVerSeqFiledLen   = 8
-	PacketProtoVerFiledLen = 4
+	PacketVerSeqFiledLen     = 8
+	PacketProtoVerFiledLen   = 4
+	PacketFenceTokenFiledLen = 8
 )
 
 const (